@@ -15,7 +15,7 @@ import (
 	"github.com/spf13/viper"
 )
 
-const VERSION = "32.2"
+const VERSION = "33.0"
 
 type Config struct {
 	Server              ServerConfig
@@ -28,6 +28,8 @@ type Config struct {
 	Broadcast           BroadcastConfig
 	TaskScheduler       TaskSchedulerConfig
 	AutomationScheduler AutomationSchedulerConfig
+	EmailQueue          EmailQueueConfig
+	EmailAttachments    EmailAttachmentsConfig
 	Telemetry           bool
 	CheckForUpdates     bool
 	RootEmail           string
@@ -172,9 +174,24 @@ type TaskSchedulerConfig struct {
 }
 
 type AutomationSchedulerConfig struct {
-	Delay     time.Duration // Delay before scheduler starts (default: 30s)
-	Interval  time.Duration // Polling interval (default: 10s)
-	BatchSize int           // Contacts per batch (default: 50)
+	Delay         time.Duration // Delay before scheduler starts (default: 30s)
+	Interval      time.Duration // Polling interval (default: 10s)
+	BatchSize     int           // Contacts per batch (default: 50)
+	RetentionDays int           // Days to keep completed/exited contact automations, 0 keeps them forever (default: 90)
+	NodeTimeout   time.Duration // Max execution time of a single automation node before it is retried, 0 disables (default: 30s)
+	GoalInterval  time.Duration // How often contacts waiting at a node are checked against automation goals, 0 disables (default: 1m)
+}
+
+type EmailQueueConfig struct {
+	DedupWindow    time.Duration // Window in which identical sends to a contact are dropped, 0 disables (default: 10m)
+	SendJitter     time.Duration // Max random delay added before each queued send, 0 disables (default: 0)
+	BatchSize      int           // Max emails claimed per workspace per poll (default: 50)
+	CommitInterval int           // Sent emails removed from the queue together, 1 removes each immediately (default: 1)
+}
+
+type EmailAttachmentsConfig struct {
+	MaxCount       int // Maximum attachments per email, 0 disables (default: 20)
+	MaxTotalSizeMB int // Maximum combined attachment size per email in MB, 0 disables (default: 10)
 }
 
 // LoadOptions contains options for loading configuration
@@ -427,6 +444,19 @@ func LoadWithOptions(opts LoadOptions) (*Config, error) {
 	v.SetDefault("AUTOMATION_SCHEDULER_DELAY", "30s")
 	v.SetDefault("AUTOMATION_SCHEDULER_INTERVAL", "10s")
 	v.SetDefault("AUTOMATION_SCHEDULER_BATCH_SIZE", 50)
+	v.SetDefault("AUTOMATION_SCHEDULER_RETENTION_DAYS", 90)
+	v.SetDefault("AUTOMATION_SCHEDULER_NODE_TIMEOUT", "30s")
+	v.SetDefault("AUTOMATION_SCHEDULER_GOAL_INTERVAL", "1m")
+
+	// Email queue defaults
+	v.SetDefault("EMAIL_QUEUE_DEDUP_WINDOW", "10m")
+	v.SetDefault("EMAIL_QUEUE_SEND_JITTER", "0s")
+	v.SetDefault("EMAIL_QUEUE_BATCH_SIZE", 50)
+	v.SetDefault("EMAIL_QUEUE_COMMIT_INTERVAL", 1)
+
+	// Email attachment limits enforced before sending
+	v.SetDefault("EMAIL_ATTACHMENTS_MAX_COUNT", 20)
+	v.SetDefault("EMAIL_ATTACHMENTS_MAX_TOTAL_SIZE_MB", 10)
 
 	// Load environment file if specified
 	if opts.EnvFile != "" {
@@ -825,9 +855,22 @@ func LoadWithOptions(opts LoadOptions) (*Config, error) {
 			MaxTasks: v.GetInt("TASK_SCHEDULER_MAX_TASKS"),
 		},
 		AutomationScheduler: AutomationSchedulerConfig{
-			Delay:     v.GetDuration("AUTOMATION_SCHEDULER_DELAY"),
-			Interval:  v.GetDuration("AUTOMATION_SCHEDULER_INTERVAL"),
-			BatchSize: v.GetInt("AUTOMATION_SCHEDULER_BATCH_SIZE"),
+			Delay:         v.GetDuration("AUTOMATION_SCHEDULER_DELAY"),
+			Interval:      v.GetDuration("AUTOMATION_SCHEDULER_INTERVAL"),
+			BatchSize:     v.GetInt("AUTOMATION_SCHEDULER_BATCH_SIZE"),
+			RetentionDays: v.GetInt("AUTOMATION_SCHEDULER_RETENTION_DAYS"),
+			NodeTimeout:   v.GetDuration("AUTOMATION_SCHEDULER_NODE_TIMEOUT"),
+			GoalInterval:  v.GetDuration("AUTOMATION_SCHEDULER_GOAL_INTERVAL"),
+		},
+		EmailQueue: EmailQueueConfig{
+			DedupWindow:    v.GetDuration("EMAIL_QUEUE_DEDUP_WINDOW"),
+			SendJitter:     v.GetDuration("EMAIL_QUEUE_SEND_JITTER"),
+			BatchSize:      v.GetInt("EMAIL_QUEUE_BATCH_SIZE"),
+			CommitInterval: v.GetInt("EMAIL_QUEUE_COMMIT_INTERVAL"),
+		},
+		EmailAttachments: EmailAttachmentsConfig{
+			MaxCount:       v.GetInt("EMAIL_ATTACHMENTS_MAX_COUNT"),
+			MaxTotalSizeMB: v.GetInt("EMAIL_ATTACHMENTS_MAX_TOTAL_SIZE_MB"),
 		},
 
 		RootEmail:       rootEmail,
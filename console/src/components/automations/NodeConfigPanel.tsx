@@ -24,7 +24,8 @@ import type {
   RemoveFromListNodeConfig,
   FilterNodeConfig,
   WebhookNodeConfig,
-  ListStatusBranchNodeConfig
+  ListStatusBranchNodeConfig,
+  TriggerPrerequisite
 } from '../../services/api/automation'
 
 const { Title } = Typography
@@ -63,7 +64,7 @@ export const NodeConfigPanel: React.FC<NodeConfigPanelProps> = ({
       case 'trigger':
         return (
           <TriggerConfigForm
-            config={config as { event_kind?: string; list_id?: string; segment_id?: string; custom_event_name?: string; updated_fields?: string[]; frequency?: 'once' | 'every_time' }}
+            config={config as { event_kind?: string; list_id?: string; segment_id?: string; custom_event_name?: string; updated_fields?: string[]; inactivity_days?: number; prerequisite?: TriggerPrerequisite; frequency?: 'once' | 'every_time' }}
             onChange={handleConfigChange}
             workspaceId={workspaceId}
             workspace={workspace}
@@ -1,13 +1,31 @@
 import React from 'react'
-import { Form, Select } from 'antd'
+import { Button, Form, InputNumber, Select, Space } from 'antd'
+import { DeleteOutlined, PlusOutlined } from '@ant-design/icons'
 import { useLingui } from '@lingui/react/macro'
 import TemplateSelectorInput from '../../templates/TemplateSelectorInput'
 import { emailProviders } from '../../integrations/EmailProviders'
-import type { EmailNodeConfig } from '../../../services/api/automation'
+import { TreeNodeInput } from '../../segment/input'
+import { TableSchemas } from '../../segment/table_schemas'
+import { useAutomation } from '../context'
+import type {
+  EmailDelivery,
+  EmailNodeConfig,
+  EmailSenderRule
+} from '../../../services/api/automation'
+import type { TreeNode } from '../../../services/api/segment'
 import type { Workspace } from '../../../services/api/types'
 
 const { Option } = Select
 
+// Empty tree structure required by TreeNodeInput
+const EMPTY_TREE: TreeNode = {
+  kind: 'branch',
+  branch: {
+    operator: 'and',
+    leaves: []
+  }
+}
+
 interface EmailConfigFormProps {
   config: EmailNodeConfig
   onChange: (config: EmailNodeConfig) => void
@@ -22,6 +40,7 @@ export const EmailConfigForm: React.FC<EmailConfigFormProps> = ({
   workspace
 }) => {
   const { t } = useLingui()
+  const { lists } = useAutomation()
 
   const handleTemplateChange = (templateId: string | null) => {
     onChange({ ...config, template_id: templateId || '' })
@@ -38,6 +57,37 @@ export const EmailConfigForm: React.FC<EmailConfigFormProps> = ({
     }
   }
 
+  const handleDeliveryChange = (value: EmailDelivery) => {
+    if (value === 'queued') {
+      // Queued is the default — omit it from the config
+      const { delivery, ...rest } = config
+      void delivery
+      onChange(rest)
+    } else {
+      onChange({ ...config, delivery: value })
+    }
+  }
+
+  const handleSkipWindowChange = (value: number | null) => {
+    if (!value) {
+      // No window — the node always sends
+      const { skip_if_sent_within, skip_if_sent_within_unit, ...rest } = config
+      void skip_if_sent_within
+      void skip_if_sent_within_unit
+      onChange(rest)
+    } else {
+      onChange({
+        ...config,
+        skip_if_sent_within: value,
+        skip_if_sent_within_unit: config.skip_if_sent_within_unit || 'days'
+      })
+    }
+  }
+
+  const handleSkipWindowUnitChange = (value: 'minutes' | 'hours' | 'days') => {
+    onChange({ ...config, skip_if_sent_within_unit: value })
+  }
+
   const emailIntegrations = React.useMemo(
     () =>
       workspace?.integrations?.filter(
@@ -46,6 +96,33 @@ export const EmailConfigForm: React.FC<EmailConfigFormProps> = ({
     [workspace?.integrations]
   )
 
+  const senderRules = config.sender_rules || []
+
+  const updateSenderRules = (rules: EmailSenderRule[]) => {
+    if (rules.length === 0) {
+      const { sender_rules, ...rest } = config
+      void sender_rules
+      onChange(rest)
+    } else {
+      onChange({ ...config, sender_rules: rules })
+    }
+  }
+
+  const handleSenderRuleChange = (index: number, rule: EmailSenderRule) => {
+    updateSenderRules(senderRules.map((r, i) => (i === index ? rule : r)))
+  }
+
+  const handleAddSenderRule = () => {
+    updateSenderRules([
+      ...senderRules,
+      { conditions: EMPTY_TREE, integration_id: emailIntegrations[0]?.id || '' }
+    ])
+  }
+
+  const handleRemoveSenderRule = (index: number) => {
+    updateSenderRules(senderRules.filter((_, i) => i !== index))
+  }
+
   const renderIntegrationOption = (integration: (typeof emailIntegrations)[number]) => {
     const providerKind = integration.email_provider?.kind
     const providerInfo = emailProviders.find((p) => p.kind === providerKind)
@@ -90,6 +167,116 @@ export const EmailConfigForm: React.FC<EmailConfigFormProps> = ({
           </Select>
         </Form.Item>
       )}
+
+      {emailIntegrations.length > 0 && (
+        <Form.Item
+          label={t`Sender Rules`}
+          extra={t`The first rule matching the contact picks the integration, e.g. a local sender for EU contacts. Other contacts use the integration above.`}
+        >
+          <div className="space-y-3">
+            {senderRules.map((rule, index) => {
+              const ruleIntegration = emailIntegrations.find((i) => i.id === rule.integration_id)
+              return (
+                <div key={index} className="border border-gray-200 rounded p-2 space-y-2">
+                  <div className="flex items-center gap-2">
+                    <Select
+                      className="flex-1"
+                      value={rule.integration_id || undefined}
+                      placeholder={t`Integration`}
+                      onChange={(value: string) =>
+                        handleSenderRuleChange(index, {
+                          ...rule,
+                          integration_id: value,
+                          sender_id: undefined
+                        })
+                      }
+                    >
+                      {emailIntegrations.map(renderIntegrationOption)}
+                    </Select>
+                    <Select
+                      className="flex-1"
+                      value={rule.sender_id || ''}
+                      onChange={(value: string) =>
+                        handleSenderRuleChange(index, { ...rule, sender_id: value || undefined })
+                      }
+                    >
+                      <Option value="">{t`Template sender`}</Option>
+                      {ruleIntegration?.email_provider?.senders?.map((sender) => (
+                        <Option key={sender.id} value={sender.id}>
+                          {sender.name} &lt;{sender.email}&gt;
+                        </Option>
+                      ))}
+                    </Select>
+                    <Button
+                      type="text"
+                      icon={<DeleteOutlined />}
+                      onClick={() => handleRemoveSenderRule(index)}
+                      danger
+                    />
+                  </div>
+                  <TreeNodeInput
+                    value={rule.conditions || EMPTY_TREE}
+                    onChange={(conditions: TreeNode) =>
+                      handleSenderRuleChange(index, { ...rule, conditions })
+                    }
+                    schemas={TableSchemas}
+                    lists={lists}
+                    workspaceId={workspaceId}
+                  />
+                </div>
+              )
+            })}
+          </div>
+          <Button
+            type="primary"
+            ghost
+            block
+            size="small"
+            onClick={handleAddSenderRule}
+            icon={<PlusOutlined />}
+            className="!mt-2"
+          >
+            {t`Add Sender Rule`}
+          </Button>
+        </Form.Item>
+      )}
+
+      <Form.Item
+        label={t`Skip If Sent Within`}
+        extra={t`Skip this email when the contact already received the same template within this window`}
+      >
+        <Space.Compact style={{ width: '100%' }}>
+          <InputNumber
+            min={1}
+            max={365}
+            value={config.skip_if_sent_within || undefined}
+            onChange={handleSkipWindowChange}
+            placeholder={t`Always send`}
+            style={{ width: '60%' }}
+          />
+          <Select
+            value={config.skip_if_sent_within_unit || 'days'}
+            onChange={handleSkipWindowUnitChange}
+            disabled={!config.skip_if_sent_within}
+            options={[
+              { label: t`Minutes`, value: 'minutes' },
+              { label: t`Hours`, value: 'hours' },
+              { label: t`Days`, value: 'days' }
+            ]}
+            style={{ width: '40%' }}
+          />
+        </Space.Compact>
+      </Form.Item>
+
+      <Form.Item
+        label={t`Delivery`}
+        extra={t`Send immediately for time-critical emails (e.g. one-time codes) instead of waiting for the email queue`}
+      >
+        <Select className="w-full" value={config.delivery || 'queued'} onChange={handleDeliveryChange}>
+          <Option value="queued">{t`Queue (default)`}</Option>
+          <Option value="immediate">{t`Send immediately`}</Option>
+        </Select>
+      </Form.Item>
     </Form>
   )
 }
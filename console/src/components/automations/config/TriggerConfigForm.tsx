@@ -1,9 +1,10 @@
 import React, { useMemo } from 'react'
-import { Form, Select, Input, Cascader, ConfigProvider } from 'antd'
+import { Form, Select, Input, InputNumber, Space, Cascader, ConfigProvider } from 'antd'
 import { useQuery } from '@tanstack/react-query'
 import { useLingui } from '@lingui/react/macro'
 import { listsApi } from '../../../services/api/list'
 import { listSegments } from '../../../services/api/segment'
+import { automationApi, type TriggerPrerequisite } from '../../../services/api/automation'
 import { OptionSelector } from '../../ui/OptionSelector'
 import type { Workspace } from '../../../services/api/types'
 
@@ -13,6 +14,7 @@ import type { Workspace } from '../../../services/api/types'
 const getCascaderValue = (eventKind?: string): string[] => {
   if (!eventKind) return []
   if (eventKind === 'custom_event') return ['custom_event']
+  if (eventKind === 'inactivity') return ['inactivity']
   const prefix = eventKind.split('.')[0]
   return [prefix, eventKind]
 }
@@ -36,6 +38,8 @@ interface TriggerConfig {
   segment_id?: string
   custom_event_name?: string
   updated_fields?: string[]
+  inactivity_days?: number
+  prerequisite?: TriggerPrerequisite
   frequency?: 'once' | 'every_time'
 }
 
@@ -95,7 +99,8 @@ export const TriggerConfigForm: React.FC<TriggerConfigFormProps> = ({ config, on
         { value: 'email.unsubscribed', label: t`Unsubscribed` }
       ]
     },
-    { value: 'custom_event', label: t`Custom Event` }
+    { value: 'custom_event', label: t`Custom Event` },
+    { value: 'inactivity', label: t`Inactivity` }
   ], [t])
 
   // Build contact field options with custom labels from workspace settings
@@ -180,6 +185,13 @@ export const TriggerConfigForm: React.FC<TriggerConfigFormProps> = ({ config, on
     enabled: !!workspaceId && config.event_kind?.startsWith('segment.')
   })
 
+  // Fetch automations for the prerequisite selector
+  const { data: automationsData } = useQuery({
+    queryKey: ['automations', workspaceId],
+    queryFn: () => automationApi.list({ workspace_id: workspaceId }),
+    enabled: !!workspaceId
+  })
+
   const handleEventKindChange = (value: (string | number)[]) => {
     // Cascader returns array, we want the last value (the actual event kind)
     const eventKind = value.length > 0 ? String(value[value.length - 1]) : undefined
@@ -190,7 +202,8 @@ export const TriggerConfigForm: React.FC<TriggerConfigFormProps> = ({ config, on
       list_id: undefined,
       segment_id: undefined,
       custom_event_name: undefined,
-      updated_fields: undefined
+      updated_fields: undefined,
+      inactivity_days: eventKind === 'inactivity' ? 30 : undefined
     }
     onChange(newConfig)
   }
@@ -207,6 +220,34 @@ export const TriggerConfigForm: React.FC<TriggerConfigFormProps> = ({ config, on
     onChange({ ...config, custom_event_name: e.target.value })
   }
 
+  const handleInactivityDaysChange = (value: number | null) => {
+    onChange({ ...config, inactivity_days: value ?? undefined })
+  }
+
+  const handlePrerequisiteAutomationChange = (value?: string) => {
+    onChange({
+      ...config,
+      prerequisite: value ? { ...config.prerequisite, automation_id: value } : undefined
+    })
+  }
+
+  const handlePrerequisiteWithinChange = (value: number | null) => {
+    if (!config.prerequisite) return
+    onChange({
+      ...config,
+      prerequisite: {
+        ...config.prerequisite,
+        within: value ?? undefined,
+        within_unit: value ? config.prerequisite.within_unit || 'days' : undefined
+      }
+    })
+  }
+
+  const handlePrerequisiteWithinUnitChange = (value: 'minutes' | 'hours' | 'days') => {
+    if (!config.prerequisite) return
+    onChange({ ...config, prerequisite: { ...config.prerequisite, within_unit: value } })
+  }
+
   const handleFrequencyChange = (value: 'once' | 'every_time') => {
     onChange({ ...config, frequency: value })
   }
@@ -219,6 +260,7 @@ export const TriggerConfigForm: React.FC<TriggerConfigFormProps> = ({ config, on
   const isSegmentEvent = config.event_kind?.startsWith('segment.')
   const isCustomEvent = config.event_kind === 'custom_event'
   const isContactUpdated = config.event_kind === 'contact.updated'
+  const isInactivity = config.event_kind === 'inactivity'
 
   // Memoize cascader value to prevent flicker on re-render
   const cascaderValue = useMemo(() => getCascaderValue(config.event_kind), [config.event_kind])
@@ -325,6 +367,66 @@ export const TriggerConfigForm: React.FC<TriggerConfigFormProps> = ({ config, on
         </Form.Item>
       )}
 
+      {/* Inactivity window */}
+      {isInactivity && (
+        <Form.Item
+          label={t`Inactive for (days)`}
+          required
+          extra={t`Contacts without email opens, clicks or custom events during this period are enrolled`}
+        >
+          <InputNumber
+            min={1}
+            value={config.inactivity_days}
+            onChange={handleInactivityDaysChange}
+            style={{ width: '100%' }}
+          />
+        </Form.Item>
+      )}
+
+      {/* Prerequisite automation */}
+      <Form.Item
+        label={t`Only if they completed`}
+        extra={t`Leave empty to enroll contacts regardless of their automation history`}
+      >
+        <Select
+          placeholder={t`Any contact`}
+          value={config.prerequisite?.automation_id}
+          onChange={handlePrerequisiteAutomationChange}
+          allowClear
+          style={{ width: '100%' }}
+          options={automationsData?.automations?.map((automation) => ({
+            label: automation.name,
+            value: automation.id
+          })) || []}
+          loading={!automationsData}
+        />
+      </Form.Item>
+      {config.prerequisite && (
+        <Form.Item
+          label={t`Completed within`}
+          extra={t`Leave empty to accept a completion at any time`}
+        >
+          <Space.Compact style={{ width: '100%' }}>
+            <InputNumber
+              min={1}
+              value={config.prerequisite.within}
+              onChange={handlePrerequisiteWithinChange}
+              style={{ width: '60%' }}
+            />
+            <Select
+              value={config.prerequisite.within_unit || 'days'}
+              onChange={handlePrerequisiteWithinUnitChange}
+              style={{ width: '40%' }}
+              options={[
+                { value: 'minutes', label: t`Minutes` },
+                { value: 'hours', label: t`Hours` },
+                { value: 'days', label: t`Days` }
+              ]}
+            />
+          </Space.Compact>
+        </Form.Item>
+      )}
+
       <Form.Item label={t`Frequency`} required>
         <OptionSelector
           value={config.frequency || 'once'}
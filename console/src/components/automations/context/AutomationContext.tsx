@@ -350,6 +350,10 @@ export function AutomationProvider({
         trigger: triggerConfig,
         root_node_id: rootNodeId,
         nodes: automationNodes,
+        log_webhooks: automation?.log_webhooks,
+        enrollment_ramp: automation?.enrollment_ramp,
+        retry_backoff: automation?.retry_backoff,
+        metadata: automation?.metadata,
         created_at: automation?.created_at || new Date().toISOString(),
         updated_at: new Date().toISOString()
       }
@@ -15,6 +15,7 @@ interface TriggerConfig {
   list_id?: string
   segment_id?: string
   custom_event_name?: string
+  inactivity_days?: number
 }
 
 export const TriggerNode: React.FC<TriggerNodeProps> = ({ data, selected }) => {
@@ -53,6 +54,9 @@ export const TriggerNode: React.FC<TriggerNodeProps> = ({ data, selected }) => {
     if (config.event_kind === 'custom_event' && config.custom_event_name) {
       return t`Custom Event: ${config.custom_event_name}`
     }
+    if (config.event_kind === 'inactivity' && config.inactivity_days) {
+      return t`Inactive for ${config.inactivity_days} days`
+    }
     return formatEventKind(config.event_kind!)
   }
 
@@ -6,6 +6,8 @@ import type {
   NodeType,
   NodePosition,
   TimelineTriggerConfig,
+  TriggerEventSpec,
+  TriggerPrerequisite,
   BranchNodeConfig,
   FilterNodeConfig,
   ABTestNodeConfig,
@@ -258,6 +260,9 @@ export function buildTriggerConfig(
     list_id?: string
     segment_id?: string
     custom_event_name?: string
+    events?: TriggerEventSpec[]
+    inactivity_days?: number
+    prerequisite?: TriggerPrerequisite
     frequency?: 'once' | 'every_time'
   }
 
@@ -266,6 +271,9 @@ export function buildTriggerConfig(
     list_id: config.list_id,
     segment_id: config.segment_id,
     custom_event_name: config.custom_event_name,
+    events: config.events,
+    inactivity_days: config.inactivity_days,
+    prerequisite: config.prerequisite,
     frequency: config.frequency || 'once'
   }
 }
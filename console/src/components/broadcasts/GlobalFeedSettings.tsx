@@ -53,7 +53,8 @@ export function GlobalFeedSettings({
         workspace_id: workspaceId,
         broadcast_id: broadcastId,
         url: settings.url,
-        headers: settings.headers || []
+        headers: settings.headers || [],
+        field_map: settings.field_map
       })
     },
     onSuccess: (response: RefreshGlobalFeedResponse) => {
@@ -10,7 +10,8 @@ import {
   Alert,
   Form,
   Modal,
-  Tooltip
+  Tooltip,
+  InputNumber
 } from 'antd'
 import { useLingui } from '@lingui/react/macro'
 import { CheckCircleOutlined, QuestionCircleOutlined } from '@ant-design/icons'
@@ -60,7 +61,8 @@ export function RecipientFeedSettings({
         broadcast_id: broadcastId,
         contact_email: testEmail || undefined,
         url: settings.url,
-        headers: settings.headers || []
+        headers: settings.headers || [],
+        field_map: settings.field_map
       })
     },
     onSuccess: (response: TestRecipientFeedResponse) => {
@@ -132,6 +134,27 @@ export function RecipientFeedSettings({
             </Space.Compact>
           </Form.Item>
 
+          <Form.Item
+            label={
+              <Space size={4}>
+                <span>{t`Batch size`}</span>
+                <Tooltip title={t`Send up to this many contacts per request as a "contacts" array. The endpoint must answer with an object keyed by contact email. Leave empty for one request per recipient.`}>
+                  <QuestionCircleOutlined className="text-gray-400 cursor-help" />
+                </Tooltip>
+              </Space>
+            }
+          >
+            <InputNumber
+              min={1}
+              max={500}
+              placeholder={t`One request per recipient`}
+              value={settings.batch_size}
+              onChange={(value) => handleChange('batch_size', value ?? undefined)}
+              disabled={disabled}
+              className="w-full"
+            />
+          </Form.Item>
+
           <HeadersEditor
             value={settings.headers}
             onChange={(headers) => handleChange('headers', headers)}
@@ -18,7 +18,7 @@ import {
   Tooltip
 } from 'antd'
 import { useLingui } from '@lingui/react/macro'
-import { useMutation, useQueryClient } from '@tanstack/react-query'
+import { useMutation, useQuery, useQueryClient } from '@tanstack/react-query'
 import {
   broadcastApi,
   Broadcast,
@@ -97,6 +97,17 @@ export function UpsertBroadcastDrawer({
   const { message, modal } = App.useApp()
   const [formTouched, setFormTouched] = useState(false)
   const [tab, setTab] = useState<string>('audience')
+
+  // Sent broadcasts whose openers can be excluded from this one
+  const { data: sentBroadcastsData } = useQuery({
+    queryKey: ['broadcasts', workspace.id, 'processed'],
+    queryFn: () =>
+      broadcastApi.list({ workspace_id: workspace.id, status: 'processed', limit: 100 }),
+    enabled: isOpen
+  })
+  const sentBroadcasts = (sentBroadcastsData?.broadcasts || []).filter(
+    (b) => b.id !== broadcast?.id
+  )
   const [globalFeed, setGlobalFeed] = useState<GlobalFeedSettings>({
     enabled: false,
     url: '',
@@ -111,6 +122,7 @@ export function UpsertBroadcastDrawer({
   // Watch campaign name changes using Form.useWatch
   const campaignName = Form.useWatch('name', form)
   const abTestingEnabled = Form.useWatch(['test_settings', 'enabled'], form)
+  const triggerMode = Form.useWatch(['schedule', 'trigger_mode'], form)
 
   // Enable tracking when A/B testing is enabled
   useEffect(() => {
@@ -139,8 +151,9 @@ export function UpsertBroadcastDrawer({
       // Clone the values to avoid modifying the original
       const payload = { ...values }
 
-      // Make sure schedule is set to not scheduled by default
+      // Make sure schedule is set to not scheduled by default, keeping the trigger mode
       payload.schedule = {
+        ...payload.schedule,
         is_scheduled: false,
         use_recipient_timezone: false
       }
@@ -177,6 +190,11 @@ export function UpsertBroadcastDrawer({
           ...broadcast.audience
         },
         test_settings: broadcast.test_settings,
+        schedule: {
+          trigger_mode: broadcast.schedule.trigger_mode || 'once',
+          join_offset: broadcast.schedule.join_offset,
+          join_offset_unit: broadcast.schedule.join_offset_unit || 'days'
+        },
         utm_parameters: broadcast.utm_parameters || undefined,
         metadata: broadcast.metadata || undefined
       })
@@ -416,10 +434,17 @@ export function UpsertBroadcastDrawer({
               const payload = {
                 ...values,
                 workspace_id: workspace.id,
-                // Set default schedule
+                // Set default schedule, keeping the trigger mode
                 schedule: {
                   is_scheduled: false,
-                  use_recipient_timezone: false
+                  use_recipient_timezone: false,
+                  ...(values.schedule?.trigger_mode === 'list_join'
+                    ? {
+                        trigger_mode: 'list_join' as const,
+                        join_offset: values.schedule.join_offset,
+                        join_offset_unit: values.schedule.join_offset_unit
+                      }
+                    : {})
                 },
                 // Include data feed settings (consolidated)
                 data_feed:
@@ -602,6 +627,71 @@ export function UpsertBroadcastDrawer({
                     >
                       <Switch />
                     </Form.Item>
+
+                    <Form.Item
+                      name={['audience', 'exclude_active_in_automations']}
+                      label={t`Exclude contacts currently in an automation`}
+                      valuePropName="checked"
+                      initialValue={false}
+                    >
+                      <Switch />
+                    </Form.Item>
+
+                    <Form.Item
+                      name={['audience', 'exclude_openers_of']}
+                      label={t`Exclude contacts who opened`}
+                      tooltip={t`Send only to contacts who did not open or click a previous broadcast, e.g. to resend it with a new subject`}
+                    >
+                      <Select
+                        allowClear
+                        showSearch
+                        optionFilterProp="label"
+                        placeholder={t`Select a sent broadcast`}
+                        options={sentBroadcasts.map((b) => ({ value: b.id, label: b.name }))}
+                      />
+                    </Form.Item>
+
+                    <Form.Item
+                      name={['schedule', 'trigger_mode']}
+                      label={t`Send to`}
+                      initialValue="once"
+                    >
+                      <Select
+                        options={[
+                          { value: 'once', label: t`The whole audience at once` },
+                          { value: 'list_join', label: t`Each contact after they join the list` }
+                        ]}
+                      />
+                    </Form.Item>
+
+                    {triggerMode === 'list_join' && (
+                      <Form.Item
+                        label={t`Delay after joining the list`}
+                        extra={t`Contacts already past the delay receive it when the broadcast starts`}
+                        required
+                      >
+                        <Space.Compact>
+                          <Form.Item
+                            name={['schedule', 'join_offset']}
+                            noStyle
+                            initialValue={3}
+                            rules={[{ required: true, message: t`Please enter a delay` }]}
+                          >
+                            <InputNumber min={1} style={{ width: 120 }} />
+                          </Form.Item>
+                          <Form.Item name={['schedule', 'join_offset_unit']} noStyle initialValue="days">
+                            <Select
+                              style={{ width: 120 }}
+                              options={[
+                                { value: 'minutes', label: t`Minutes` },
+                                { value: 'hours', label: t`Hours` },
+                                { value: 'days', label: t`Days` }
+                              ]}
+                            />
+                          </Form.Item>
+                        </Space.Compact>
+                      </Form.Item>
+                    )}
                   </div>
                 </div>
 
@@ -232,6 +232,13 @@ export const ContactsTableSchema: TableSchema = {
       type: 'time',
       shown: false
     },
+    engagement_score: {
+      name: 'engagement_score',
+      title: 'Engagement Score',
+      description: 'Opens, clicks and custom events, decayed with a 30-day half-life',
+      type: 'number',
+      shown: false
+    },
     // Custom JSON fields
     custom_json_1: {
       name: 'custom_json_1',
@@ -7,6 +7,8 @@ import { workspaceService } from '../../services/api/workspace'
 import { TIMEZONE_OPTIONS } from '../../lib/timezones'
 import { LANGUAGE_OPTIONS } from '../../lib/languages'
 import { LogoInput } from './LogoInput'
+import { HeadersEditor } from '../broadcasts/HeadersEditor'
+import type { DataFeedHeader } from '../../services/api/broadcast'
 import { SettingsSectionHeader } from './SettingsSectionHeader'
 
 interface GeneralSettingsProps {
@@ -34,6 +36,10 @@ export function GeneralSettings({ workspace, onWorkspaceUpdate, isOwner }: Gener
       timezone: workspace?.settings.timezone || 'UTC',
       email_tracking_enabled: workspace?.settings.email_tracking_enabled || false,
       custom_endpoint_url: workspace?.settings.custom_endpoint_url || '',
+      list_unsubscribe_mailto: workspace?.settings.list_unsubscribe_mailto || '',
+      bulk_headers_enabled: !workspace?.settings.disable_bulk_headers,
+      broadcast_seed_list: workspace?.settings.broadcast_seed_list || [],
+      default_feed_headers: workspace?.settings.default_feed_headers || [],
       languages: workspace?.settings.languages || ['en'],
       default_language: workspace?.settings.default_language || 'en'
     })
@@ -47,6 +53,10 @@ export function GeneralSettings({ workspace, onWorkspaceUpdate, isOwner }: Gener
     timezone: string
     email_tracking_enabled: boolean
     custom_endpoint_url?: string
+    list_unsubscribe_mailto?: string
+    bulk_headers_enabled: boolean
+    broadcast_seed_list?: string[]
+    default_feed_headers?: DataFeedHeader[]
     languages?: string[]
     default_language?: string
   }) => {
@@ -65,6 +75,14 @@ export function GeneralSettings({ workspace, onWorkspaceUpdate, isOwner }: Gener
           timezone: values.timezone,
           email_tracking_enabled: values.email_tracking_enabled,
           custom_endpoint_url: (values.custom_endpoint_url as string | undefined) || undefined,
+          list_unsubscribe_mailto: values.list_unsubscribe_mailto || undefined,
+          disable_bulk_headers: !values.bulk_headers_enabled || undefined,
+          broadcast_seed_list: values.broadcast_seed_list?.length
+            ? values.broadcast_seed_list
+            : undefined,
+          default_feed_headers: values.default_feed_headers?.length
+            ? values.default_feed_headers
+            : undefined,
           languages: values.languages || ['en'],
           default_language: values.default_language || 'en'
         }
@@ -145,6 +163,26 @@ export function GeneralSettings({ workspace, onWorkspaceUpdate, isOwner }: Gener
             )}
           </Descriptions.Item>
 
+          <Descriptions.Item label={t`Unsubscribe Inbox`}>
+            {workspace?.settings.list_unsubscribe_mailto || t`Not set`}
+          </Descriptions.Item>
+
+          <Descriptions.Item label={t`Bulk Headers`}>
+            {workspace?.settings.disable_bulk_headers ? t`Disabled` : t`Enabled`}
+          </Descriptions.Item>
+
+          <Descriptions.Item label={t`Broadcast Seed List`}>
+            {workspace?.settings.broadcast_seed_list?.length
+              ? workspace.settings.broadcast_seed_list.join(', ')
+              : t`Not set`}
+          </Descriptions.Item>
+
+          <Descriptions.Item label={t`Default Feed Headers`}>
+            {workspace?.settings.default_feed_headers?.length
+              ? workspace.settings.default_feed_headers.map((header) => header.name).join(', ')
+              : t`Not set`}
+          </Descriptions.Item>
+
           <Descriptions.Item label={t`Custom Endpoint URL`}>
             <div>{workspace?.settings.custom_endpoint_url || t`Default (API endpoint)`}</div>
           </Descriptions.Item>
@@ -237,6 +275,40 @@ export function GeneralSettings({ workspace, onWorkspaceUpdate, isOwner }: Gener
           <Switch />
         </Form.Item>
 
+        <Form.Item
+          name="list_unsubscribe_mailto"
+          label={t`Unsubscribe Inbox`}
+          tooltip={t`Email address added as a mailto: entry to the List-Unsubscribe header, next to the one-click URL, for email clients that only support mailto unsubscribes. Leave empty to only send the URL.`}
+          rules={[{ type: 'email' as const, message: t`Please enter a valid email address` }]}
+        >
+          <Input placeholder="unsubscribe@example.com" />
+        </Form.Item>
+
+        <Form.Item
+          name="bulk_headers_enabled"
+          label={t`Bulk Headers`}
+          tooltip={t`Adds the Precedence: bulk and Auto-Submitted headers to broadcast and automation emails, so auto-responders such as out of office replies don't answer them. Transactional emails never carry them.`}
+          valuePropName="checked"
+        >
+          <Switch />
+        </Form.Item>
+
+        <Form.Item
+          name="broadcast_seed_list"
+          label={t`Broadcast Seed List`}
+          tooltip={t`Seed inboxes that receive a copy of every broadcast, to monitor deliverability. Seed copies are not tracked and are not counted in the broadcast recipients or stats.`}
+        >
+          <Select mode="tags" tokenSeparators={[',', ' ']} placeholder="seed@example.com" />
+        </Form.Item>
+
+        <Form.Item
+          name="default_feed_headers"
+          label={t`Default Feed Headers`}
+          tooltip={t`Headers sent with every broadcast data feed request, such as a shared API key. A broadcast header with the same name takes precedence.`}
+        >
+          <HeadersEditor />
+        </Form.Item>
+
         <Form.Item
           name="custom_endpoint_url"
           label={t`Custom Endpoint URL`}
@@ -439,6 +439,7 @@ interface EmailProviderFormValues {
   sendgrid?: EmailProvider['sendgrid']
   senders: Sender[]
   rate_limit_per_minute: number
+  failover_integration_id?: string
   type?: IntegrationType
 }
 
@@ -454,6 +455,9 @@ const constructProviderFromForm = (formValues: EmailProviderFormValues): EmailPr
     provider.ses = formValues.ses
   } else if (formValues.kind === 'smtp' && formValues.smtp) {
     provider.smtp = formValues.smtp
+    if (formValues.failover_integration_id) {
+      provider.failover_integration_id = formValues.failover_integration_id
+    }
   } else if (formValues.kind === 'sparkpost' && formValues.sparkpost) {
     provider.sparkpost = formValues.sparkpost
   } else if (formValues.kind === 'postmark' && formValues.postmark) {
@@ -605,6 +609,7 @@ export function Integrations({ workspace, onSave, loading, isOwner }: Integratio
       kind: integration.email_provider.kind,
       senders: integrationSenders,
       rate_limit_per_minute: integration.email_provider.rate_limit_per_minute || 25,
+      failover_integration_id: integration.email_provider.failover_integration_id,
       ses: integration.email_provider.ses,
       smtp: integration.email_provider.smtp,
       sparkpost: integration.email_provider.sparkpost,
@@ -1731,6 +1736,103 @@ export function Integrations({ workspace, onSave, loading, isOwner }: Integratio
             >
               <Input placeholder={t`Defaults to SMTP host`} disabled={!isOwner} />
             </Form.Item>
+            <Form.Item
+              name={['smtp', 'body_encoding']}
+              label={t`Body Encoding`}
+              tooltip={t`Content-Transfer-Encoding used for the HTML body. Quoted-printable is the default, base64 can help with servers that mangle long lines.`}
+            >
+              <Select
+                placeholder={t`Quoted-printable (default)`}
+                allowClear
+                disabled={!isOwner}
+                options={[
+                  { value: 'quoted-printable', label: 'Quoted-printable' },
+                  { value: 'base64', label: 'Base64' }
+                ]}
+              />
+            </Form.Item>
+            <Form.Item
+              name={['smtp', 'tls_mode']}
+              label={t`TLS Mode`}
+              tooltip={t`STARTTLS upgrades the connection after EHLO (usually port 587) and fails if the server does not offer it. Implicit TLS encrypts the connection from the start (usually port 465). Leave empty to follow the Use TLS switch.`}
+            >
+              <Select
+                placeholder={t`Follow Use TLS`}
+                allowClear
+                disabled={!isOwner}
+                options={[
+                  { value: 'starttls', label: 'STARTTLS' },
+                  { value: 'implicit', label: t`Implicit TLS` },
+                  { value: 'none', label: t`None` }
+                ]}
+              />
+            </Form.Item>
+            <Form.Item
+              name={['smtp', 'min_tls_version']}
+              label={t`Minimum TLS Version`}
+              tooltip={t`Lowest TLS version accepted when negotiating TLS with the server. Servers that cannot negotiate it are rejected.`}
+            >
+              <Select
+                placeholder={t`TLS 1.2 (default)`}
+                allowClear
+                disabled={!isOwner}
+                options={[
+                  { value: '1.2', label: 'TLS 1.2' },
+                  { value: '1.3', label: 'TLS 1.3' }
+                ]}
+              />
+            </Form.Item>
+            <Form.Item
+              name={['smtp', 'max_concurrent_connections']}
+              label={t`Max Concurrent Connections`}
+              tooltip={t`Maximum number of simultaneous connections opened to the SMTP server. Leave empty for no limit.`}
+            >
+              <InputNumber
+                min={1}
+                placeholder={t`Unlimited`}
+                disabled={!isOwner}
+                style={{ width: '100%' }}
+              />
+            </Form.Item>
+            <Form.Item
+              name={['smtp', 'max_idle_connections']}
+              label={t`Max Idle Connections`}
+              tooltip={t`Number of authenticated connections kept open and reused between sends. Leave empty to open a new connection for every email.`}
+            >
+              <InputNumber
+                min={1}
+                placeholder={t`No pooling`}
+                disabled={!isOwner}
+                style={{ width: '100%' }}
+              />
+            </Form.Item>
+            <Form.Item
+              name={['smtp', 'idle_timeout_seconds']}
+              label={t`Idle Connection Timeout (seconds)`}
+              tooltip={t`How long a pooled connection may stay idle before it is closed. Leave empty for the 30 second default.`}
+            >
+              <InputNumber
+                min={1}
+                max={300}
+                placeholder="30"
+                disabled={!isOwner}
+                style={{ width: '100%' }}
+              />
+            </Form.Item>
+            <Form.Item
+              name="failover_integration_id"
+              label={t`Failover Integration`}
+              tooltip={t`When this SMTP server cannot be reached or rejects authentication, the email is sent through this integration instead.`}
+            >
+              <Select
+                allowClear
+                placeholder={t`No failover`}
+                disabled={!isOwner}
+                options={(workspace?.integrations || [])
+                  .filter((i) => i.type === 'email' && i.id !== editingIntegrationId)
+                  .map((i) => ({ value: i.id, label: i.name }))}
+              />
+            </Form.Item>
           </>
         )}
 
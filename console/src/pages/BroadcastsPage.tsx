@@ -956,6 +956,21 @@ const BroadcastCard: React.FC<BroadcastCardProps> = ({
                       />
                     )}
                   </Descriptions.Item>
+
+                  {broadcast.audience.exclude_active_in_automations && (
+                    <Descriptions.Item label={t`Exclude In Automations`}>
+                      <FontAwesomeIcon
+                        icon={faCircleCheck}
+                        className="text-green-500 opacity-70 mt-1"
+                      />
+                    </Descriptions.Item>
+                  )}
+
+                  {broadcast.audience.exclude_openers_of && (
+                    <Descriptions.Item label={t`Exclude Openers Of`}>
+                      {broadcast.audience.exclude_openers_of}
+                    </Descriptions.Item>
+                  )}
                 </Descriptions>
 
                 {/* Schedule Information */}
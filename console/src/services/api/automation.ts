@@ -1,6 +1,7 @@
 import { api } from './client'
 import { analyticsService } from './analytics'
 import { TreeNode } from './segment'
+import type { BulkOperationResult } from './contacts'
 
 // Automation status types
 export type AutomationStatus = 'draft' | 'live' | 'paused'
@@ -20,6 +21,21 @@ export type NodeType =
   | 'ab_test'
   | 'webhook'
   | 'list_status_branch'
+  | 'unsubscribe_all'
+  | 'enroll_in_automation'
+  | 'record_revenue'
+  | 'wait_for_list_status'
+  | 'wait_until_datetime'
+  | 'for_each'
+  | 'suppression_branch'
+  | 'transactional_email'
+  | 'wait_until'
+  | 'expression_branch'
+  | 'update_contact'
+  | 'slack'
+  | 'goal'
+  | 'throttle'
+  | 'reenroll'
 
 // Contact automation status
 export type ContactAutomationStatus = 'active' | 'completed' | 'exited' | 'failed'
@@ -54,11 +70,37 @@ export const VALID_EVENT_KINDS = [
   'email.complained',
   'email.unsubscribed',
   // Custom events (require custom_event_name)
-  'custom_event'
+  'custom_event',
+  // Inactivity (requires inactivity_days, evaluated by the automation scheduler)
+  'inactivity'
 ] as const
 
 export type EventKind = (typeof VALID_EVENT_KINDS)[number]
 
+// A single timeline event that enrolls a contact
+export interface TriggerEventSpec {
+  event_kind: string
+  list_id?: string // Required for list.* events
+  segment_id?: string // Required for segment.* events
+  custom_event_name?: string // Required for custom_event
+  updated_fields?: string[] // For contact.updated: only trigger on these field changes
+  field_change?: TriggerFieldChange // For contact.updated: only trigger when this field changes to a value
+}
+
+// For contact.updated: match the old/new values of one field, unset values match any value
+export interface TriggerFieldChange {
+  field: string
+  from?: string | number | boolean
+  to?: string | number | boolean
+}
+
+// Enroll only contacts who completed another automation
+export interface TriggerPrerequisite {
+  automation_id: string
+  within?: number // How recent the completion must be, 0 or unset accepts any completion
+  within_unit?: 'minutes' | 'hours' | 'days'
+}
+
 // Trigger configuration
 export interface TimelineTriggerConfig {
   event_kind: string
@@ -66,8 +108,15 @@ export interface TimelineTriggerConfig {
   segment_id?: string // Required for segment.* events
   custom_event_name?: string // Required for custom_event
   updated_fields?: string[] // For contact.updated: only trigger on these field changes
+  field_change?: TriggerFieldChange // For contact.updated: only trigger when this field changes to a value
+  events?: TriggerEventSpec[] // Additional events, any of which enrolls the contact
   conditions?: TreeNode
+  enrollment_conditions?: TreeNode // For list.subscribed: contact must also match at enrollment
   frequency: TriggerFrequency
+  min_dwell?: number // For segment.joined: how long the contact must stay in the segment
+  min_dwell_unit?: 'minutes' | 'hours' | 'days'
+  inactivity_days?: number // For inactivity: days without activity before enrollment
+  prerequisite?: TriggerPrerequisite // Contact must have completed this automation
 }
 
 // Automation statistics
@@ -76,6 +125,15 @@ export interface AutomationStats {
   completed: number
   exited: number
   failed: number
+  revenue?: Record<string, number> // Total recorded revenue per currency
+  nodes?: Record<string, AutomationNodeExecutionStats> // Execution counters and latency per node ID
+}
+
+export interface AutomationNodeExecutionStats {
+  executions: number
+  failures: number
+  total_duration_ms: number
+  avg_duration_ms: number
 }
 
 // Node position for visual editor
@@ -88,6 +146,18 @@ export interface NodePosition {
 export interface DelayNodeConfig {
   duration: number
   unit: 'minutes' | 'hours' | 'days'
+  anchor_field?: string
+  offset?: number
+  on_past?: 'skip' | 'exit'
+}
+
+export type EmailDelivery = 'queued' | 'immediate'
+
+// Sends through a specific integration when the contact matches the conditions
+export interface EmailSenderRule {
+  conditions: TreeNode
+  integration_id: string
+  sender_id?: string // Defaults to the template sender
 }
 
 export interface EmailNodeConfig {
@@ -95,6 +165,10 @@ export interface EmailNodeConfig {
   integration_id?: string
   subject_override?: string
   from_override?: string
+  delivery?: EmailDelivery // Defaults to queued
+  sender_rules?: EmailSenderRule[] // First match wins, falls back to integration_id
+  skip_if_sent_within?: number // Skip when the template was sent to the contact within this window
+  skip_if_sent_within_unit?: 'minutes' | 'hours' | 'days'
 }
 
 export interface BranchPath {
@@ -107,6 +181,7 @@ export interface BranchPath {
 export interface BranchNodeConfig {
   paths: BranchPath[]
   default_path_id: string
+  verbose?: boolean
 }
 
 export interface FilterNodeConfig {
@@ -114,6 +189,7 @@ export interface FilterNodeConfig {
   conditions?: TreeNode
   continue_node_id: string
   exit_node_id: string
+  verbose?: boolean
 }
 
 export interface AddToListNodeConfig {
@@ -126,6 +202,20 @@ export interface RemoveFromListNodeConfig {
   list_id: string
 }
 
+export interface UnsubscribeAllNodeConfig {
+  status: 'unsubscribed' | 'removed'
+}
+
+export interface EnrollInAutomationNodeConfig {
+  automation_id: string
+}
+
+export interface RecordRevenueNodeConfig {
+  amount?: number // Literal amount, or
+  amount_field?: string // custom_number_N, or a path in a custom_json_N field
+  currency: string // ISO 4217 code
+}
+
 export interface ListStatusBranchNodeConfig {
   list_id: string
   not_in_list_node_id: string
@@ -133,6 +223,74 @@ export interface ListStatusBranchNodeConfig {
   non_active_node_id: string
 }
 
+export interface SuppressionBranchNodeConfig {
+  suppressed_node_id: string
+  not_suppressed_node_id: string
+}
+
+export interface WaitForListStatusNodeConfig {
+  list_id: string
+  target_status: 'active' | 'pending' | 'unsubscribed' | 'bounced' | 'complained'
+  timeout: number
+  timeout_unit: 'minutes' | 'hours' | 'days'
+  matched_node_id: string // Next node when the target status is reached
+  timeout_node_id: string // Next node when the timeout elapses
+}
+
+export interface WaitUntilDatetimeNodeConfig {
+  datetime: string // e.g. "2026-11-01T09:00" or RFC 3339 with an offset
+  timezone?: string // IANA timezone for a datetime without offset, defaults to UTC
+}
+
+export interface ExpressionBranchNodeConfig {
+  expression: string // Liquid boolean expression, e.g. "{{ event.amount > 100 }}"
+  true_node_id: string
+  false_node_id: string
+}
+
+// Contacts matching a goal exit with exit_reason 'goal_met'. Branch goals are steps of the flow
+// and keep being checked while the contact waits at later nodes; automation goals are not
+// linked to the flow and apply to every contact.
+export type GoalScope = 'branch' | 'automation'
+
+export interface GoalNodeConfig {
+  description?: string
+  conditions?: TreeNode
+  scope?: GoalScope // defaults to 'branch'
+}
+
+// The contact exits with exit_reason 'reenrolled' and starts the automation again at the root node.
+// Contacts not matching the conditions, in a 'once' automation, or over the limit continue to the next node.
+export interface ReenrollNodeConfig {
+  conditions?: TreeNode
+  max_reenrollments: number // Re-enrollments allowed per contact, up to 100
+}
+
+export interface SlackNodeConfig {
+  webhook_url: string // Slack incoming webhook URL
+  text: string // Supports Liquid, e.g. "New signup: {{ contact.email }}"
+}
+
+export interface ThrottleNodeConfig {
+  max_per_minute: number // Contacts over the limit wait for the next minute with capacity
+}
+
+export interface UpdateContactNodeConfig {
+  fields: Record<string, unknown> // Contact fields to set, string values support Liquid, null clears
+}
+
+export interface WaitUntilNodeConfig {
+  days_of_week: string[] // "monday" to "sunday", empty allows every day
+  hour: number // 0-23 in the contact's timezone
+  minute: number // 0-59
+  timezone_field?: string // Contact field holding the timezone, defaults to "timezone"
+}
+
+export interface ForEachNodeConfig {
+  items_path: string // Dot path to an array, e.g. "context.order.items"
+  sub_flow_node_id: string // First node of the sub-flow run for each item (bound to `item`)
+}
+
 export interface ABTestVariant {
   id: string
   name: string
@@ -144,9 +302,52 @@ export interface ABTestNodeConfig {
   variants: ABTestVariant[]
 }
 
+export interface WebhookMTLSConfig {
+  client_cert: string // PEM-encoded client certificate
+  client_key?: string // PEM-encoded private key, omitted in responses (stored encrypted)
+}
+
 export interface WebhookNodeConfig {
   url: string
   secret?: string // Optional Authorization Bearer token
+  mtls?: WebhookMTLSConfig // Optional client certificate for mutual TLS
+  content_type?: 'json' | 'form' // Payload encoding, defaults to json
+  on_response_branches?: WebhookResponseBranch[] // First matching branch wins, otherwise next_node_id
+  headers?: WebhookHeader[] // Custom request headers
+  response_mapping?: WebhookResponseMapping // Stores the response in the automation context
+}
+
+export interface WebhookResponseMapping {
+  save_as: string // Context key, referenced by later nodes as {{ save_as }}
+  json_path?: string // e.g. "$.score", defaults to the whole response
+}
+
+export interface WebhookHeader {
+  name: string
+  value: string // May contain Liquid, e.g. {{ contact.external_id }}
+}
+
+export type WebhookResponseOperator =
+  | 'equals'
+  | 'not_equals'
+  | 'gt'
+  | 'gte'
+  | 'lt'
+  | 'lte'
+  | 'contains'
+  | 'exists'
+
+export interface WebhookResponseCondition {
+  field: string // Dot path in the JSON response, e.g. "result.score"
+  operator: WebhookResponseOperator
+  value?: string | number | boolean
+}
+
+export interface WebhookResponseBranch {
+  id: string
+  name?: string
+  conditions: WebhookResponseCondition[] // All must match
+  next_node_id: string
 }
 
 // Union type for node configs
@@ -157,11 +358,28 @@ export type NodeConfig =
   | FilterNodeConfig
   | AddToListNodeConfig
   | RemoveFromListNodeConfig
+  | UnsubscribeAllNodeConfig
+  | EnrollInAutomationNodeConfig
+  | RecordRevenueNodeConfig
   | ListStatusBranchNodeConfig
+  | WaitForListStatusNodeConfig
+  | WaitUntilDatetimeNodeConfig
+  | WaitUntilNodeConfig
+  | ExpressionBranchNodeConfig
+  | UpdateContactNodeConfig
+  | SlackNodeConfig
+  | ThrottleNodeConfig
+  | ReenrollNodeConfig
+  | GoalNodeConfig
+  | ForEachNodeConfig
+  | SuppressionBranchNodeConfig
   | ABTestNodeConfig
   | WebhookNodeConfig
   | Record<string, unknown> // For trigger nodes with no config
 
+// What happens to a contact when its current node fails
+export type NodeFailurePolicy = 'retry' | 'skip' | 'exit'
+
 // Automation node
 export interface AutomationNode {
   id: string
@@ -169,10 +387,26 @@ export interface AutomationNode {
   type: NodeType
   config: Record<string, unknown>
   next_node_id?: string
+  on_failure?: NodeFailurePolicy // Defaults to retry
+  error_node_id?: string // Action nodes: failures route here instead of on_failure
   position: NodePosition
   created_at: string
 }
 
+// Exponential backoff between retries of a failed node, defaults to 30s doubling up to 1h with 20% jitter
+export interface RetryBackoff {
+  base_seconds: number
+  max_seconds: number
+  jitter: number // Fraction of the delay randomized, 0 to 1
+}
+
+// Limits enrollments per minute during the first minutes after activation
+export interface EnrollmentRamp {
+  per_minute: number
+  duration_minutes: number
+  started_at?: string // Set on activation
+}
+
 // Main automation interface
 export interface Automation {
   id: string
@@ -184,6 +418,12 @@ export interface Automation {
   trigger_sql?: string
   root_node_id: string
   nodes: AutomationNode[]
+  tags?: string[]
+  version?: number
+  log_webhooks?: boolean // Record webhook node request/response exchanges in node executions
+  enrollment_ramp?: EnrollmentRamp
+  retry_backoff?: RetryBackoff
+  metadata?: Record<string, unknown> // Free-form annotations, sent in webhook node payloads
   stats?: AutomationStats
   created_at: string
   updated_at: string
@@ -205,6 +445,7 @@ export interface ContactAutomation {
   last_error?: string
   last_retry_at?: string
   max_retries: number
+  automation_version?: number
 }
 
 // Node execution log
@@ -226,6 +467,7 @@ export interface ListAutomationsRequest {
   workspace_id: string
   status?: AutomationStatus[]
   list_id?: string
+  tag?: string
   limit?: number
   offset?: number
 }
@@ -242,16 +484,30 @@ export interface GetAutomationRequest {
 
 export interface GetAutomationResponse {
   automation: Automation
+  warnings?: string[]
 }
 
 export interface CreateAutomationRequest {
   workspace_id: string
   automation: Automation
+  validate_webhooks?: boolean
 }
 
 export interface UpdateAutomationRequest {
   workspace_id: string
   automation: Automation
+  validate_webhooks?: boolean
+}
+
+// Returned with a 400 by automations.create/update when node configs are invalid
+export interface NodeConfigFieldError {
+  field?: string // JSON path in the node config, e.g. "sender_rules[0].integration_id"
+  message: string
+}
+
+export interface NodeConfigValidationErrorResponse {
+  error: string
+  node_errors: Record<string, NodeConfigFieldError> // Keyed by node ID
 }
 
 export interface DeleteAutomationRequest {
@@ -259,9 +515,26 @@ export interface DeleteAutomationRequest {
   automation_id: string
 }
 
+export interface SetAutomationTagsRequest {
+  workspace_id: string
+  automation_id: string
+  tags: string[]
+}
+
+export interface EnrollContactsRequest {
+  workspace_id: string
+  automation_id: string
+  emails: string[]
+}
+
+export interface ListAutomationTagsResponse {
+  tags: string[]
+}
+
 export interface ActivateAutomationRequest {
   workspace_id: string
   automation_id: string
+  check_integration?: boolean
 }
 
 export interface PauseAutomationRequest {
@@ -280,6 +553,33 @@ export interface GetNodeExecutionsResponse {
   node_executions: NodeExecution[]
 }
 
+export type NextActionType = 'execute_node' | 'complete' | 'exit_node_deleted' | 'wait_automation' | 'none'
+
+// Preview of the scheduler's next tick for a contact
+export interface GetContactNextTickResponse {
+  current_node_id: string | null
+  scheduled_at: string | null
+  next_action: {
+    type: NextActionType
+    node_id?: string
+    node_type?: NodeType
+  }
+}
+
+// Fire a webhook node once against a sample contact
+export interface TestWebhookNodeRequest {
+  workspace_id: string
+  automation_id: string
+  node_id: string
+  email: string
+}
+
+export interface TestWebhookNodeResponse {
+  exchange: Record<string, unknown> | null // Captured request and response
+  output?: Record<string, unknown>
+  error?: string
+}
+
 // Node stats for flow viewer
 export interface AutomationNodeStats {
   node_id: string
@@ -299,6 +599,10 @@ export interface GetNodeStatsResponse {
   node_stats: Record<string, AutomationNodeStats>
 }
 
+export interface GetNodeExecutionStatsResponse {
+  node_stats: Record<string, AutomationNodeExecutionStats>
+}
+
 // API client
 export const automationApi = {
   list: async (params: ListAutomationsRequest): Promise<ListAutomationsResponse> => {
@@ -308,6 +612,7 @@ export const automationApi = {
       params.status.forEach((s) => searchParams.append('status', s))
     }
     if (params.list_id) searchParams.append('list_id', params.list_id)
+    if (params.tag) searchParams.append('tag', params.tag)
     if (params.limit) searchParams.append('limit', params.limit.toString())
     if (params.offset) searchParams.append('offset', params.offset.toString())
 
@@ -334,6 +639,17 @@ export const automationApi = {
     return api.post<{ success: boolean }>('/api/automations.delete', params)
   },
 
+  listTags: async (workspaceId: string): Promise<ListAutomationTagsResponse> => {
+    const searchParams = new URLSearchParams()
+    searchParams.append('workspace_id', workspaceId)
+
+    return api.get<ListAutomationTagsResponse>(`/api/automations.tags?${searchParams.toString()}`)
+  },
+
+  setTags: async (params: SetAutomationTagsRequest): Promise<{ success: boolean }> => {
+    return api.post<{ success: boolean }>('/api/automations.setTags', params)
+  },
+
   activate: async (params: ActivateAutomationRequest): Promise<GetAutomationResponse> => {
     return api.post<GetAutomationResponse>('/api/automations.activate', params)
   },
@@ -342,6 +658,10 @@ export const automationApi = {
     return api.post<GetAutomationResponse>('/api/automations.pause', params)
   },
 
+  enroll: async (params: EnrollContactsRequest): Promise<BulkOperationResult> => {
+    return api.post<BulkOperationResult>('/api/automations.enroll', params)
+  },
+
   getNodeExecutions: async (params: GetNodeExecutionsRequest): Promise<GetNodeExecutionsResponse> => {
     const searchParams = new URLSearchParams()
     searchParams.append('workspace_id', params.workspace_id)
@@ -351,6 +671,27 @@ export const automationApi = {
     return api.get<GetNodeExecutionsResponse>(`/api/automations.nodeExecutions?${searchParams.toString()}`)
   },
 
+  getContactNextTick: async (params: GetNodeExecutionsRequest): Promise<GetContactNextTickResponse> => {
+    const searchParams = new URLSearchParams()
+    searchParams.append('workspace_id', params.workspace_id)
+    searchParams.append('automation_id', params.automation_id)
+    searchParams.append('email', params.email)
+
+    return api.get<GetContactNextTickResponse>(`/api/automations.contacts.next?${searchParams.toString()}`)
+  },
+
+  testWebhookNode: async (params: TestWebhookNodeRequest): Promise<TestWebhookNodeResponse> => {
+    return api.post<TestWebhookNodeResponse>('/api/automations.nodes.testWebhook', params)
+  },
+
+  getNodeExecutionStats: async (params: GetNodeStatsRequest): Promise<GetNodeExecutionStatsResponse> => {
+    const searchParams = new URLSearchParams()
+    searchParams.append('workspace_id', params.workspace_id)
+    searchParams.append('automation_id', params.automation_id)
+
+    return api.get<GetNodeExecutionStatsResponse>(`/api/automations.nodeStats?${searchParams.toString()}`)
+  },
+
   getNodeStats: async (params: GetNodeStatsRequest): Promise<GetNodeStatsResponse> => {
     const response = await analyticsService.query(
       {
@@ -69,8 +69,11 @@ export interface BroadcastTestSettings {
 
 export interface AudienceSettings {
   list?: string
+  lists?: string[] // Additional lists; contacts on several targeted lists receive one email
   segments?: string[]
   exclude_unsubscribed: boolean
+  exclude_active_in_automations?: boolean // Skip contacts currently active in an automation
+  exclude_openers_of?: string // Skip contacts who opened or clicked this broadcast
 }
 
 export interface ScheduleSettings {
@@ -79,6 +82,19 @@ export interface ScheduleSettings {
   scheduled_time?: string // Format: HH:mm
   timezone?: string // IANA timezone format, e.g. "America/New_York"
   use_recipient_timezone: boolean
+  // list_join sends to each list member once they have been on the list for join_offset
+  trigger_mode?: BroadcastTriggerMode
+  join_offset?: number
+  join_offset_unit?: 'minutes' | 'hours' | 'days'
+  ramp_schedule?: BroadcastRampSchedule
+}
+
+export type BroadcastTriggerMode = 'once' | 'list_join'
+
+// Caps the emails sent in each successive interval after the broadcast starts
+export interface BroadcastRampSchedule {
+  interval_minutes: number
+  steps: number[] // e.g. [100, 500, 1000], the rest is sent at full speed
 }
 
 export type BroadcastStatus =
@@ -103,16 +119,32 @@ export interface DataFeedHeader {
   value: string
 }
 
+export interface DataFeedOAuth2Config {
+  token_url: string
+  client_id: string
+  client_secret: string // masked in responses, send the mask back to keep the stored secret
+  scope?: string
+}
+
+export interface DataFeedAuth {
+  oauth2?: DataFeedOAuth2Config
+}
+
 export interface GlobalFeedSettings {
   enabled: boolean
   url?: string
   headers: DataFeedHeader[]
+  auth?: DataFeedAuth
+  field_map?: Record<string, string> // response field -> template variable name
 }
 
 export interface RecipientFeedSettings {
   enabled: boolean
   url?: string
   headers: DataFeedHeader[]
+  auth?: DataFeedAuth
+  field_map?: Record<string, string> // response field -> template variable name
+  batch_size?: number // > 0 sends contacts in arrays and expects a response keyed by email
 }
 
 // DataFeedSettings consolidates all feed configuration and runtime data
@@ -207,6 +239,7 @@ export interface ScheduleBroadcastRequest {
   scheduled_time?: string
   timezone?: string
   use_recipient_timezone?: boolean
+  check_integration?: boolean
 }
 
 export interface PauseBroadcastRequest {
@@ -224,6 +257,11 @@ export interface CancelBroadcastRequest {
   id: string
 }
 
+export interface CancelBroadcastScheduleRequest {
+  workspace_id: string
+  id: string
+}
+
 export interface SendToIndividualRequest {
   workspace_id: string
   broadcast_id: string
@@ -275,6 +313,8 @@ export interface RefreshGlobalFeedRequest {
   broadcast_id: string
   url: string
   headers: DataFeedHeader[]
+  auth?: DataFeedAuth
+  field_map?: Record<string, string> // response field -> template variable name
 }
 
 export interface RefreshGlobalFeedResponse {
@@ -289,6 +329,8 @@ export interface TestRecipientFeedRequest {
   contact_email?: string
   url: string
   headers: DataFeedHeader[]
+  auth?: DataFeedAuth
+  field_map?: Record<string, string> // response field -> template variable name
 }
 
 export interface TestRecipientFeedResponse {
@@ -298,6 +340,26 @@ export interface TestRecipientFeedResponse {
   contact_email?: string
 }
 
+export interface PreviewRenderedBroadcastRequest {
+  workspace_id: string
+  broadcast_id: string
+  template_id?: string // defaults to the first variation
+  limit?: number // defaults to 3, max 10
+}
+
+export interface BroadcastRecipientPreview {
+  email: string
+  list_id?: string
+  subject?: string
+  html?: string
+  error?: string // set instead of subject and html when rendering failed
+}
+
+export interface PreviewRenderedBroadcastResponse {
+  template_id: string
+  previews: BroadcastRecipientPreview[]
+}
+
 export const broadcastApi = {
   list: async (params: ListBroadcastsRequest): Promise<ListBroadcastsResponse> => {
     const searchParams = new URLSearchParams()
@@ -345,6 +407,10 @@ export const broadcastApi = {
     return api.post<{ success: boolean }>('/api/broadcasts.cancel', params)
   },
 
+  cancelSchedule: async (params: CancelBroadcastScheduleRequest): Promise<{ success: boolean }> => {
+    return api.post<{ success: boolean }>('/api/broadcasts.cancelSchedule', params)
+  },
+
   sendToIndividual: async (params: SendToIndividualRequest): Promise<{ success: boolean }> => {
     return api.post<{ success: boolean }>('/api/broadcasts.sendToIndividual', params)
   },
@@ -371,5 +437,11 @@ export const broadcastApi = {
 
   testRecipientFeed: async (params: TestRecipientFeedRequest): Promise<TestRecipientFeedResponse> => {
     return api.post<TestRecipientFeedResponse>('/api/broadcasts.testRecipientFeed', params)
+  },
+
+  previewRendered: async (
+    params: PreviewRenderedBroadcastRequest
+  ): Promise<PreviewRenderedBroadcastResponse> => {
+    return api.post<PreviewRenderedBroadcastResponse>('/api/broadcasts.previewRendered', params)
   }
 }
@@ -60,6 +60,8 @@ export interface Contact {
   custom_json_4?: unknown
   custom_json_5?: unknown
 
+  messaging_hold_until?: string
+
   created_at: string
   updated_at: string
 
@@ -96,7 +98,28 @@ export interface UpsertContactOperation {
   error?: string
 }
 
-export interface BatchImportContactsResponse {
+// Standard response shape of bulk endpoints: each item has its own outcome
+export type BulkItemStatus = 'created' | 'updated' | 'skipped' | 'failed'
+
+export interface BulkItemResult {
+  id: string
+  status: BulkItemStatus
+  error?: string
+}
+
+export interface BulkOperationSummary {
+  created: number
+  updated: number
+  skipped: number
+  failed: number
+}
+
+export interface BulkOperationResult {
+  results: BulkItemResult[]
+  summary: BulkOperationSummary
+}
+
+export interface BatchImportContactsResponse extends BulkOperationResult {
   operations: UpsertContactOperation[]
   error?: string
 }
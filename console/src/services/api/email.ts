@@ -2,6 +2,32 @@ import { api } from './client'
 import type { EmailProvider } from './workspace'
 import type { TestEmailProviderResponse } from './template'
 
+export type DeliverabilityCheckStatus = 'pass' | 'warning' | 'fail'
+
+export interface DeliverabilityCheck {
+  status: DeliverabilityCheckStatus
+  host: string // DNS name that was queried
+  record?: string
+  message: string
+}
+
+export interface DeliverabilityReport {
+  sender: string
+  domain: string
+  spf: DeliverabilityCheck
+  dkim: DeliverabilityCheck
+  dmarc: DeliverabilityCheck
+  passed: boolean // True when no check failed
+}
+
+export interface CheckDeliverabilityRequest {
+  workspace_id: string
+  integration_id: string
+  sender_id?: string // Defaults to the integration's default sender
+  dkim_selector: string
+  spf_include?: string // Defaults to the provider's SPF include
+}
+
 export const emailService = {
   /**
    * Test an email provider configuration by sending a test email
@@ -20,5 +46,27 @@ export const emailService = {
       to,
       workspace_id: workspaceId
     })
+  },
+
+  /**
+   * Check a saved email integration can connect, without sending an email
+   * @param workspaceId The ID of the workspace
+   * @param integrationId The ID of the email integration to check
+   * @returns A response indicating success or failure
+   */
+  testIntegration: (workspaceId: string, integrationId: string): Promise<TestEmailProviderResponse> => {
+    return api.post<TestEmailProviderResponse>('/api/integrations.test', {
+      workspace_id: workspaceId,
+      integration_id: integrationId
+    })
+  },
+
+  /**
+   * Look up the SPF, DKIM and DMARC records of an email integration's sender domain
+   * @param request The integration, sender and DKIM selector to check
+   * @returns A report with one check per record
+   */
+  checkDeliverability: (request: CheckDeliverabilityRequest): Promise<DeliverabilityReport> => {
+    return api.post<DeliverabilityReport>('/api/integrations.checkDeliverability', request)
   }
 }
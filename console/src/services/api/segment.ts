@@ -1,4 +1,5 @@
 import { api } from './client'
+import type { Contact } from './contacts'
 import { FormInstance } from 'antd'
 import type { IconDefinition } from '@fortawesome/fontawesome-svg-core'
 
@@ -275,8 +276,10 @@ export interface RebuildSegmentRequest {
 
 export interface PreviewSegmentRequest {
   workspace_id: string
-  tree: TreeNode
+  tree?: TreeNode // Either tree or segment_id must be provided
+  segment_id?: string
   limit?: number
+  offset?: number
 }
 
 export interface GetSegmentContactsRequest {
@@ -312,9 +315,11 @@ export interface RebuildSegmentResponse {
 }
 
 export interface PreviewSegmentResponse {
-  emails: string[] // Deprecated: Always empty for privacy/performance. Use getSegmentContacts for actual emails.
+  emails: string[]
+  contacts: Contact[]
   total_count: number
   limit: number
+  offset: number
   generated_sql: string
   sql_args: unknown[]
 }
@@ -381,7 +386,7 @@ export async function rebuildSegment(req: RebuildSegmentRequest): Promise<Rebuil
 }
 
 /**
- * Preview contacts that would match a segment tree
+ * Preview contacts that would match a segment tree or an existing segment
  */
 export async function previewSegment(req: PreviewSegmentRequest): Promise<PreviewSegmentResponse> {
   return api.post<PreviewSegmentResponse>('/api/segments.preview', req)
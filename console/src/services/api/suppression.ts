@@ -0,0 +1,31 @@
+import { api } from './client'
+
+export type SuppressionReason = 'bounce' | 'complaint' | 'unsubscribe' | 'manual'
+
+export interface Suppression {
+  email: string
+  reason?: SuppressionReason
+}
+
+// Either suppressions or csv (email column, optional reason column) must be provided
+export interface ImportSuppressionsRequest {
+  workspace_id: string
+  suppressions?: Suppression[]
+  csv?: string
+}
+
+export interface SuppressionImportError {
+  email: string
+  error: string
+}
+
+export interface ImportSuppressionsResponse {
+  imported: number
+  failed: SuppressionImportError[]
+}
+
+export const suppressionApi = {
+  import: async (params: ImportSuppressionsRequest): Promise<ImportSuppressionsResponse> => {
+    return api.post('/api/suppressions.import', params)
+  }
+}
@@ -117,6 +117,15 @@ export interface SendTransactionalNotificationResponse {
   message_id: string
 }
 
+export interface SendTransactionalTemplateRequest {
+  workspace_id: string
+  to: string
+  template_id: string
+  data?: Record<string, unknown>
+  integration_id?: string
+  email_options?: EmailOptions
+}
+
 export const transactionalNotificationsApi = {
   list: async (
     params: ListTransactionalNotificationsRequest
@@ -166,6 +175,12 @@ export const transactionalNotificationsApi = {
     return api.post<SendTransactionalNotificationResponse>('/api/transactional.send', params)
   },
 
+  sendTemplate: async (
+    params: SendTransactionalTemplateRequest
+  ): Promise<SendTransactionalNotificationResponse> => {
+    return api.post<SendTransactionalNotificationResponse>('/api/transactional.sendTemplate', params)
+  },
+
   /**
    * Test a template by sending a test email
    * @param workspaceId The ID of the workspace
@@ -1,5 +1,6 @@
 import { api } from './client'
 import type { EmailBlock } from '../../components/email_builder/types'
+import type { DataFeedHeader } from './broadcast'
 
 // Template Block type
 export interface TemplateBlock {
@@ -51,6 +52,10 @@ export interface WorkspaceSettings {
   blog_settings?: BlogSettings
   default_language: string
   languages: string[]
+  list_unsubscribe_mailto?: string
+  broadcast_seed_list?: string[]
+  default_feed_headers?: DataFeedHeader[]
+  disable_bulk_headers?: boolean
 }
 
 export interface FileManagerSettings {
@@ -85,6 +90,7 @@ export interface EmailProvider {
   sendgrid?: SendGridSettings
   senders: Sender[]
   rate_limit_per_minute: number
+  failover_integration_id?: string // SMTP only: email integration used when the server is unreachable
 }
 
 export interface AmazonSES {
@@ -106,6 +112,12 @@ export interface SMTPSettings {
   encrypted_username?: string
   use_tls: boolean
   ehlo_hostname?: string
+  body_encoding?: 'quoted-printable' | 'base64'
+  max_concurrent_connections?: number
+  max_idle_connections?: number
+  idle_timeout_seconds?: number
+  tls_mode?: 'none' | 'starttls' | 'implicit'
+  min_tls_version?: '1.2' | '1.3'
 
   // Authentication type: 'basic' (default) or 'oauth2'
   auth_type?: SMTPAuthType
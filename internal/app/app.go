@@ -31,6 +31,7 @@ import (
 	"github.com/Notifuse/notifuse/pkg/tracing"
 
 	"contrib.go.opencensus.io/integrations/ocsql"
+	"go.opencensus.io/stats/view"
 )
 
 // AppInterface defines the interface for the App
@@ -117,6 +118,7 @@ type App struct {
 	blogPostRepo                  domain.BlogPostRepository
 	blogThemeRepo                 domain.BlogThemeRepository
 	customEventRepo               domain.CustomEventRepository
+	suppressionRepo               domain.SuppressionRepository
 	webhookSubscriptionRepo       domain.WebhookSubscriptionRepository
 	webhookDeliveryRepo           domain.WebhookDeliveryRepository
 	automationRepo                domain.AutomationRepository
@@ -152,6 +154,7 @@ type App struct {
 	taskScheduler                    *service.TaskScheduler
 	dnsVerificationService           *service.DNSVerificationService
 	customEventService               *service.CustomEventService
+	suppressionService               *service.SuppressionService
 	webhookSubscriptionService       *service.WebhookSubscriptionService
 	webhookDeliveryWorker            *service.WebhookDeliveryWorker
 	automationService                *service.AutomationService
@@ -266,6 +269,11 @@ func (a *App) InitTracing() error {
 			metricsExporter = "prometheus" // Default
 		}
 
+		// Expose data feed health alongside the default metrics
+		if err := view.Register(broadcast.DataFeedViews...); err != nil {
+			return fmt.Errorf("failed to register data feed views: %w", err)
+		}
+
 		a.logger.WithField("trace_exporter", exporter).
 			WithField("metrics_exporter", metricsExporter).
 			WithField("sampling_rate", tracingConfig.SamplingProbability).
@@ -428,6 +436,7 @@ func (a *App) InitRepositories() error {
 	a.blogPostRepo = repository.NewBlogPostRepository(a.workspaceRepo)
 	a.blogThemeRepo = repository.NewBlogThemeRepository(a.workspaceRepo)
 	a.customEventRepo = repository.NewCustomEventRepository(a.workspaceRepo)
+	a.suppressionRepo = repository.NewSuppressionRepository(a.workspaceRepo)
 	a.webhookSubscriptionRepo = repository.NewWebhookSubscriptionRepository(a.workspaceRepo)
 	a.webhookDeliveryRepo = repository.NewWebhookDeliveryRepository(a.workspaceRepo)
 
@@ -437,7 +446,7 @@ func (a *App) InitRepositories() error {
 	a.automationRepo = repository.NewAutomationRepository(a.workspaceRepo, triggerGenerator)
 
 	// Initialize email queue repository
-	a.emailQueueRepo = repository.NewEmailQueueRepository(a.workspaceRepo)
+	a.emailQueueRepo = repository.NewEmailQueueRepository(a.workspaceRepo, a.config.EmailQueue.DedupWindow)
 
 	// Initialize setting service
 	a.settingService = service.NewSettingService(a.settingRepo)
@@ -577,6 +586,13 @@ func (a *App) InitServices() error {
 		a.logger,
 	)
 
+	// Initialize suppression service
+	a.suppressionService = service.NewSuppressionService(
+		a.suppressionRepo,
+		a.authService,
+		a.logger,
+	)
+
 	// Initialize http client
 	httpClient := &http.Client{
 		Timeout: 10 * time.Second,
@@ -610,6 +626,10 @@ func (a *App) InitServices() error {
 		a.config.WebhookEndpoint,
 		a.config.APIEndpoint,
 	)
+	a.emailService.SetAttachmentLimits(domain.AttachmentLimits{
+		MaxCount:     a.config.EmailAttachments.MaxCount,
+		MaxTotalSize: int64(a.config.EmailAttachments.MaxTotalSizeMB) * 1024 * 1024,
+	})
 
 	// Initialize webhook registration service
 	a.webhookRegistrationService = service.NewWebhookRegistrationService(
@@ -659,6 +679,7 @@ func (a *App) InitServices() error {
 		a.templateService,
 		a.contactService,
 		a.emailService,
+		a.emailQueueRepo,
 		a.authService,
 		a.logger,
 		a.workspaceRepo,
@@ -690,7 +711,7 @@ func (a *App) InitServices() error {
 	)
 
 	// Initialize data feed fetcher for external data in broadcasts
-	a.dataFeedFetcher = broadcast.NewDataFeedFetcher(a.logger)
+	a.dataFeedFetcher = broadcast.NewDataFeedFetcher(a.workspaceRepo, a.logger)
 
 	// Initialize broadcast service
 	a.broadcastService = service.NewBroadcastService(
@@ -925,12 +946,20 @@ func (a *App) InitServices() error {
 
 	// Initialize email queue worker for processing marketing emails (broadcasts & automations)
 	// Worker creates message_history entries via UPSERT after each send attempt
+	emailQueueWorkerConfig := queue.DefaultWorkerConfig()
+	emailQueueWorkerConfig.SendJitter = a.config.EmailQueue.SendJitter
+	if a.config.EmailQueue.BatchSize > 0 {
+		emailQueueWorkerConfig.BatchSize = a.config.EmailQueue.BatchSize
+	}
+	if a.config.EmailQueue.CommitInterval > 0 {
+		emailQueueWorkerConfig.CommitInterval = a.config.EmailQueue.CommitInterval
+	}
 	a.emailQueueWorker = queue.NewEmailQueueWorker(
 		a.emailQueueRepo,
 		a.workspaceRepo,
 		a.emailService,
 		a.messageHistoryRepo,
-		queue.DefaultWorkerConfig(),
+		emailQueueWorkerConfig,
 		a.logger,
 	)
 
@@ -940,6 +969,11 @@ func (a *App) InitServices() error {
 		a.authService,
 		a.logger,
 	)
+	a.automationService.SetEmailService(a.emailService)
+	a.automationService.SetContactRepository(a.contactRepo)
+	a.automationService.SetListRepository(a.listRepo)
+	a.automationService.SetSegmentRepository(a.segmentRepo)
+	a.automationService.SetWorkspaceRepository(a.workspaceRepo)
 
 	// Initialize Firecrawl service
 	firecrawlService := service.NewFirecrawlService(a.logger)
@@ -966,15 +1000,23 @@ func (a *App) InitServices() error {
 		a.emailQueueRepo,
 		a.messageHistoryRepo,
 		a.contactTimelineRepo,
+		a.segmentRepo,
+		a.suppressionRepo,
 		a.logger,
 		a.config.APIEndpoint,
 	)
+	// Immediate automation emails share the queue worker's rate limiters and circuit breakers
+	automationExecutor.SetImmediateEmailSender(a.emailQueueWorker)
+	automationExecutor.SetNodeTimeout(a.config.AutomationScheduler.NodeTimeout)
 	a.automationScheduler = service.NewAutomationScheduler(
 		automationExecutor,
 		a.logger,
 		a.config.AutomationScheduler.Interval,
 		a.config.AutomationScheduler.BatchSize,
 	)
+	a.automationScheduler.SetRetentionDays(a.config.AutomationScheduler.RetentionDays)
+	a.automationScheduler.SetInactivityInterval(1 * time.Hour)
+	a.automationScheduler.SetGoalInterval(a.config.AutomationScheduler.GoalInterval)
 
 	// Initialize SMTP bridge handler service
 	a.smtpBridgeHandlerService = service.NewSMTPBridgeHandlerService(
@@ -1150,6 +1192,11 @@ func (a *App) InitHandlers() error {
 		getJWTSecret,
 		a.logger,
 	)
+	suppressionHandler := httpHandler.NewSuppressionHandler(
+		a.suppressionService,
+		getJWTSecret,
+		a.logger,
+	)
 	webhookSubscriptionHandler := httpHandler.NewWebhookSubscriptionHandler(
 		a.webhookSubscriptionService,
 		a.webhookDeliveryWorker,
@@ -1197,6 +1244,7 @@ func (a *App) InitHandlers() error {
 	contactTimelineHandler.RegisterRoutes(a.mux)
 	segmentHandler.RegisterRoutes(a.mux)
 	customEventHandler.RegisterRoutes(a.mux)
+	suppressionHandler.RegisterRoutes(a.mux)
 	webhookSubscriptionHandler.RegisterRoutes(a.mux)
 	automationHandler.RegisterRoutes(a.mux)
 	llmHandler.RegisterRoutes(a.mux)
@@ -107,6 +107,9 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			custom_json_3 JSONB,
 			custom_json_4 JSONB,
 			custom_json_5 JSONB,
+			engagement_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+			engagement_score_at TIMESTAMP WITH TIME ZONE,
+			messaging_hold_until TIMESTAMP WITH TIME ZONE,
 			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			db_created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -396,13 +399,28 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			trigger_sql TEXT,
 			root_node_id VARCHAR(36),
 			nodes JSONB DEFAULT '[]',
+			tags JSONB DEFAULT '[]',
 			stats JSONB DEFAULT '{}',
+			version INTEGER NOT NULL DEFAULT 1,
+			log_webhooks BOOLEAN NOT NULL DEFAULT false,
+			enrollment_ramp JSONB,
+			metadata JSONB,
+			retry_backoff JSONB,
 			created_at TIMESTAMPTZ DEFAULT NOW(),
 			updated_at TIMESTAMPTZ DEFAULT NOW(),
 			deleted_at TIMESTAMPTZ
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_automations_workspace_status ON automations(workspace_id, status) WHERE deleted_at IS NULL`,
 		`CREATE INDEX IF NOT EXISTS idx_automations_list ON automations(list_id, status)`,
+		`CREATE INDEX IF NOT EXISTS idx_automations_tags ON automations USING GIN (tags)`,
+		`CREATE TABLE IF NOT EXISTS automation_versions (
+			automation_id VARCHAR(36) NOT NULL REFERENCES automations(id),
+			version INTEGER NOT NULL,
+			root_node_id VARCHAR(36),
+			nodes JSONB DEFAULT '[]',
+			created_at TIMESTAMPTZ DEFAULT NOW(),
+			PRIMARY KEY (automation_id, version)
+		)`,
 		`CREATE TABLE IF NOT EXISTS contact_automations (
 			id VARCHAR(36) PRIMARY KEY,
 			automation_id VARCHAR(36) NOT NULL REFERENCES automations(id),
@@ -417,10 +435,12 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			last_error TEXT,
 			last_retry_at TIMESTAMPTZ,
 			max_retries INTEGER DEFAULT 3,
+			automation_version INTEGER,
 			UNIQUE(automation_id, contact_email, entered_at)
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_contact_automations_scheduled ON contact_automations(scheduled_at) WHERE status = 'active' AND scheduled_at IS NOT NULL`,
 		`CREATE INDEX IF NOT EXISTS idx_contact_automations_automation ON contact_automations(automation_id, status)`,
+		`CREATE INDEX IF NOT EXISTS idx_contact_automations_automation_scheduled ON contact_automations(automation_id, scheduled_at) WHERE status = 'active'`,
 		`CREATE INDEX IF NOT EXISTS idx_contact_automations_email ON contact_automations(contact_email, status)`,
 		`CREATE TABLE IF NOT EXISTS automation_node_executions (
 			id VARCHAR(36) PRIMARY KEY,
@@ -437,6 +457,24 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_node_executions_contact_automation ON automation_node_executions(contact_automation_id, entered_at DESC)`,
 		`CREATE INDEX IF NOT EXISTS idx_node_executions_automation ON automation_node_executions(automation_id, node_id, action)`,
+		`CREATE TABLE IF NOT EXISTS automation_revenue (
+			id VARCHAR(36) PRIMARY KEY,
+			automation_id VARCHAR(36) NOT NULL REFERENCES automations(id),
+			contact_automation_id VARCHAR(36) NOT NULL,
+			node_id VARCHAR(36) NOT NULL,
+			contact_email VARCHAR(255) NOT NULL,
+			amount NUMERIC(18, 4) NOT NULL,
+			currency VARCHAR(3) NOT NULL,
+			recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_automation_revenue_automation ON automation_revenue(automation_id, recorded_at DESC)`,
+		`CREATE TABLE IF NOT EXISTS automation_throttle_windows (
+			automation_id VARCHAR(36) NOT NULL,
+			node_id VARCHAR(36) NOT NULL,
+			window_start TIMESTAMPTZ NOT NULL,
+			count INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (automation_id, node_id)
+		)`,
 		`CREATE TABLE IF NOT EXISTS automation_trigger_log (
 			id VARCHAR(36) PRIMARY KEY,
 			automation_id VARCHAR(36) NOT NULL REFERENCES automations(id),
@@ -472,6 +510,20 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 		`CREATE INDEX IF NOT EXISTS idx_email_queue_retry ON email_queue(next_retry_at) WHERE status = 'failed' AND attempts < max_attempts`,
 		`CREATE INDEX IF NOT EXISTS idx_email_queue_source ON email_queue(source_type, source_id, status)`,
 		`CREATE INDEX IF NOT EXISTS idx_email_queue_integration ON email_queue(integration_id, status)`,
+		`CREATE TABLE IF NOT EXISTS email_queue_dedup (
+			contact_email VARCHAR(255) NOT NULL,
+			template_id VARCHAR(36) NOT NULL,
+			dedupe_key VARCHAR(255) NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (contact_email, template_id, dedupe_key)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_email_queue_dedup_created_at ON email_queue_dedup(created_at)`,
+		`CREATE TABLE IF NOT EXISTS suppressions (
+			email VARCHAR(255) PRIMARY KEY,
+			reason VARCHAR(20) NOT NULL DEFAULT 'manual',
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
 	}
 
 	// Run all table creation queries
@@ -850,6 +902,55 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 		`CREATE TRIGGER message_history_status_trigger AFTER UPDATE ON message_history FOR EACH ROW EXECUTE FUNCTION update_contact_lists_on_status_change()`,
 		`DROP TRIGGER IF EXISTS custom_event_timeline_trigger ON custom_events`,
 		`CREATE TRIGGER custom_event_timeline_trigger AFTER INSERT OR UPDATE ON custom_events FOR EACH ROW EXECUTE FUNCTION track_custom_event_timeline()`,
+		// Engagement score: opens (+1), clicks (+3) and custom events (+2) add points to
+		// contacts.engagement_score, decayed with a 30-day half-life (domain.EngagementScoreHalfLifeDays).
+		// The stored score is valid as of engagement_score_at; readers decay it to the current time.
+		`CREATE OR REPLACE FUNCTION add_contact_engagement(p_email VARCHAR(255), p_points DOUBLE PRECISION, p_at TIMESTAMPTZ)
+		RETURNS VOID AS $$
+		BEGIN
+			UPDATE contacts SET
+				engagement_score = CASE
+					WHEN engagement_score_at IS NULL THEN p_points
+					-- Decay the stored score up to the new event, then add its points
+					WHEN p_at >= engagement_score_at THEN
+						engagement_score * POWER(0.5::float8, (EXTRACT(EPOCH FROM (p_at - engagement_score_at)) / 86400 / 30)::float8) + p_points
+					-- Late events are decayed to the stored score's timestamp instead
+					ELSE
+						engagement_score + p_points * POWER(0.5::float8, (EXTRACT(EPOCH FROM (engagement_score_at - p_at)) / 86400 / 30)::float8)
+				END,
+				engagement_score_at = GREATEST(COALESCE(engagement_score_at, p_at), p_at)
+			WHERE email = p_email;
+		END;
+		$$ LANGUAGE plpgsql;`,
+		`CREATE OR REPLACE FUNCTION track_contact_engagement()
+		RETURNS TRIGGER AS $$
+		BEGIN
+			IF TG_TABLE_NAME = 'message_history' THEN
+				IF TG_OP = 'INSERT' THEN
+					IF NEW.opened_at IS NOT NULL THEN
+						PERFORM add_contact_engagement(NEW.contact_email, 1, NEW.opened_at);
+					END IF;
+					IF NEW.clicked_at IS NOT NULL THEN
+						PERFORM add_contact_engagement(NEW.contact_email, 3, NEW.clicked_at);
+					END IF;
+				ELSE
+					IF NEW.opened_at IS NOT NULL AND OLD.opened_at IS NULL THEN
+						PERFORM add_contact_engagement(NEW.contact_email, 1, NEW.opened_at);
+					END IF;
+					IF NEW.clicked_at IS NOT NULL AND OLD.clicked_at IS NULL THEN
+						PERFORM add_contact_engagement(NEW.contact_email, 3, NEW.clicked_at);
+					END IF;
+				END IF;
+			ELSIF TG_TABLE_NAME = 'custom_events' AND NEW.deleted_at IS NULL THEN
+				PERFORM add_contact_engagement(NEW.email, 2, NEW.occurred_at);
+			END IF;
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql;`,
+		`DROP TRIGGER IF EXISTS message_history_engagement_trigger ON message_history`,
+		`CREATE TRIGGER message_history_engagement_trigger AFTER INSERT OR UPDATE ON message_history FOR EACH ROW EXECUTE FUNCTION track_contact_engagement()`,
+		`DROP TRIGGER IF EXISTS custom_event_engagement_trigger ON custom_events`,
+		`CREATE TRIGGER custom_event_engagement_trigger AFTER INSERT ON custom_events FOR EACH ROW EXECUTE FUNCTION track_contact_engagement()`,
 		// Webhook trigger functions for outgoing webhooks
 		// Trigger 1: contacts table - contact.created, contact.updated, contact.deleted
 		`CREATE OR REPLACE FUNCTION webhook_contacts_trigger()
@@ -1196,11 +1297,17 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			p_automation_id VARCHAR(36),
 			p_contact_email VARCHAR(255),
 			p_root_node_id VARCHAR(36),
-			p_frequency VARCHAR(20)
+			p_frequency VARCHAR(20),
+			p_message_id VARCHAR(255) DEFAULT NULL
 		) RETURNS VOID AS $$
 		DECLARE
 			v_already_triggered BOOLEAN;
 			v_new_id VARCHAR(36);
+			v_version INTEGER;
+			v_root_node_id VARCHAR(36);
+			v_broadcast_id VARCHAR(255);
+			v_global_feed JSONB;
+			v_context JSONB := '{}'::jsonb;
 		BEGIN
 			-- 1. For "once" frequency, check if already triggered
 			IF p_frequency = 'once' THEN
@@ -1223,18 +1330,43 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			-- 2. Generate new ID for contact_automation
 			v_new_id := gen_random_uuid()::text;
 
-			-- 3. Enroll contact in automation
+			-- Pin the enrollment to the current workflow version and start at its root node
+			-- (the root may have changed since the trigger was created)
+			SELECT version, COALESCE(NULLIF(root_node_id, ''), p_root_node_id)
+			INTO v_version, v_root_node_id
+			FROM automations WHERE id = p_automation_id;
+
+			-- Keep the broadcast context of message events (e.g. a broadcast email being
+			-- opened) so downstream nodes render the same global feed as the broadcast
+			IF p_message_id IS NOT NULL THEN
+				SELECT mh.broadcast_id, b.data_feed->'global_feed_data'
+				INTO v_broadcast_id, v_global_feed
+				FROM message_history mh
+				LEFT JOIN broadcasts b ON b.id = mh.broadcast_id
+				WHERE mh.id = p_message_id;
+
+				IF v_broadcast_id IS NOT NULL THEN
+					v_context := jsonb_build_object('broadcast_id', v_broadcast_id);
+					IF jsonb_typeof(v_global_feed) = 'object' THEN
+						v_context := v_context || jsonb_build_object('global_feed', v_global_feed);
+					END IF;
+				END IF;
+			END IF;
+
+			-- 3. Enroll contact in automation, pinned to the current workflow version
 			INSERT INTO contact_automations (
-				id, automation_id, contact_email, current_node_id,
-				status, entered_at, scheduled_at
+				id, automation_id, automation_version, contact_email, current_node_id,
+				status, entered_at, scheduled_at, context
 			) VALUES (
 				v_new_id,
 				p_automation_id,
+				v_version,
 				p_contact_email,
-				p_root_node_id,
+				v_root_node_id,
 				'active',
 				NOW(),
-				NOW()
+				NOW(),
+				v_context
 			);
 
 			-- 4. Increment enrolled stat
@@ -1254,7 +1386,7 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 				gen_random_uuid()::text,
 				v_new_id,
 				p_automation_id,
-				p_root_node_id,
+				v_root_node_id,
 				'trigger',
 				'entered',
 				NOW(),
@@ -1271,7 +1403,7 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 				p_automation_id,
 				jsonb_build_object(
 					'automation_id', jsonb_build_object('new', p_automation_id),
-					'root_node_id', jsonb_build_object('new', p_root_node_id)
+					'root_node_id', jsonb_build_object('new', v_root_node_id)
 				),
 				NOW()
 			);
@@ -200,6 +200,65 @@ func (a *Attachment) ToMetadata(checksum string) *AttachmentMetadata {
 	}
 }
 
+// Maximum attachments accepted by the API for a single email
+const (
+	MaxAttachmentCount     = 20
+	MaxAttachmentTotalSize = 10 * 1024 * 1024 // bytes
+)
+
+// AttachmentLimits caps the attachments of a single email in the send path, so that
+// messages a provider would reject are failed before they reach it
+type AttachmentLimits struct {
+	MaxCount     int   // Maximum number of attachments, 0 means no limit
+	MaxTotalSize int64 // Maximum combined decoded size in bytes, 0 means no limit
+}
+
+// DefaultAttachmentLimits are the send limits used when none are configured
+var DefaultAttachmentLimits = AttachmentLimits{
+	MaxCount:     MaxAttachmentCount,
+	MaxTotalSize: MaxAttachmentTotalSize,
+}
+
+// ErrAttachmentLimitExceeded is returned when the attachments of an email exceed the send limits
+type ErrAttachmentLimitExceeded struct {
+	Message string
+}
+
+func (e *ErrAttachmentLimitExceeded) Error() string {
+	return e.Message
+}
+
+// Check returns an ErrAttachmentLimitExceeded when the attachments exceed the limits
+func (l AttachmentLimits) Check(attachments []Attachment) error {
+	if l.MaxCount > 0 && len(attachments) > l.MaxCount {
+		return &ErrAttachmentLimitExceeded{
+			Message: fmt.Sprintf("email has %d attachments, exceeding the maximum of %d per email", len(attachments), l.MaxCount),
+		}
+	}
+
+	if l.MaxTotalSize > 0 {
+		totalSize := int64(0)
+		for _, att := range attachments {
+			totalSize += att.DecodedSize()
+		}
+		if totalSize > l.MaxTotalSize {
+			return &ErrAttachmentLimitExceeded{
+				Message: fmt.Sprintf("total attachment size of %d bytes (%d attachments) exceeds the maximum of %d bytes per email",
+					totalSize, len(attachments), l.MaxTotalSize),
+			}
+		}
+	}
+
+	return nil
+}
+
+// DecodedSize returns the size in bytes of the base64 content once decoded, without decoding it
+func (a *Attachment) DecodedSize() int64 {
+	size := base64.StdEncoding.DecodedLen(len(a.Content))
+	size -= strings.Count(a.Content[max(0, len(a.Content)-2):], "=")
+	return int64(size)
+}
+
 // ValidateAttachments validates a slice of attachments
 func ValidateAttachments(attachments []Attachment) error {
 	if len(attachments) == 0 {
@@ -207,8 +266,8 @@ func ValidateAttachments(attachments []Attachment) error {
 	}
 
 	// Check maximum number of attachments
-	if len(attachments) > 20 {
-		return fmt.Errorf("maximum 20 attachments allowed, got %d", len(attachments))
+	if len(attachments) > MaxAttachmentCount {
+		return fmt.Errorf("maximum %d attachments allowed, got %d", MaxAttachmentCount, len(attachments))
 	}
 
 	totalSize := int64(0)
@@ -238,10 +297,9 @@ func ValidateAttachments(attachments []Attachment) error {
 	// AWS SES allows up to 40MB total message size as documented at:
 	// https://docs.aws.amazon.com/ses/latest/dg/attachments.html
 	// We use 10MB as a conservative limit to account for email body and headers
-	const maxTotalSize = 10 * 1024 * 1024
-	if totalSize > maxTotalSize {
+	if totalSize > MaxAttachmentTotalSize {
 		return fmt.Errorf("total attachment size %d bytes exceeds maximum of %d bytes (10MB)",
-			totalSize, maxTotalSize)
+			totalSize, MaxAttachmentTotalSize)
 	}
 
 	return nil
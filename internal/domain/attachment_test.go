@@ -558,3 +558,54 @@ func TestValidateAttachments_SizeLimits(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestAttachment_DecodedSize(t *testing.T) {
+	for _, size := range []int{0, 1, 2, 3, 4, 1000, 1024 * 1024} {
+		att := Attachment{Content: base64.StdEncoding.EncodeToString(make([]byte, size))}
+		assert.Equal(t, int64(size), att.DecodedSize(), "size %d", size)
+	}
+}
+
+func TestAttachmentLimits_Check(t *testing.T) {
+	kb := base64.StdEncoding.EncodeToString(make([]byte, 1024))
+	attachmentsOf := func(n int) []Attachment {
+		attachments := make([]Attachment, n)
+		for i := range attachments {
+			attachments[i] = Attachment{Filename: "file.pdf", Content: kb}
+		}
+		return attachments
+	}
+
+	t.Run("within limits", func(t *testing.T) {
+		limits := AttachmentLimits{MaxCount: 3, MaxTotalSize: 3 * 1024}
+		assert.NoError(t, limits.Check(attachmentsOf(3)))
+		assert.NoError(t, limits.Check(nil))
+	})
+
+	t.Run("too many attachments", func(t *testing.T) {
+		limits := AttachmentLimits{MaxCount: 2}
+		err := limits.Check(attachmentsOf(3))
+		require.Error(t, err)
+		var limitErr *ErrAttachmentLimitExceeded
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, "email has 3 attachments, exceeding the maximum of 2 per email", err.Error())
+	})
+
+	t.Run("combined size too large", func(t *testing.T) {
+		limits := AttachmentLimits{MaxCount: 10, MaxTotalSize: 2 * 1024}
+		err := limits.Check(attachmentsOf(3))
+		require.Error(t, err)
+		var limitErr *ErrAttachmentLimitExceeded
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, "total attachment size of 3072 bytes (3 attachments) exceeds the maximum of 2048 bytes per email", err.Error())
+	})
+
+	t.Run("zero limits disable the checks", func(t *testing.T) {
+		assert.NoError(t, AttachmentLimits{}.Check(attachmentsOf(30)))
+	})
+
+	t.Run("defaults match the API maximums", func(t *testing.T) {
+		assert.Equal(t, MaxAttachmentCount, DefaultAttachmentLimits.MaxCount)
+		assert.Equal(t, int64(MaxAttachmentTotalSize), DefaultAttachmentLimits.MaxTotalSize)
+	})
+}
@@ -2,11 +2,18 @@ package domain
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/Notifuse/notifuse/pkg/crypto"
 )
 
 //go:generate mockgen -destination mocks/mock_automation_repository.go -package mocks github.com/Notifuse/notifuse/internal/domain AutomationRepository
@@ -62,8 +69,14 @@ var ValidEventKinds = []string{
 	"email.bounced", "email.complained", "email.unsubscribed",
 	// Custom events (require custom_event_name)
 	"custom_event",
+	// Inactivity (requires inactivity_days, evaluated by the automation scheduler)
+	"inactivity",
 }
 
+// InactivityActivityKinds lists the contact_timeline kinds that count as activity for
+// inactivity triggers, besides custom events ("custom_event.*")
+var InactivityActivityKinds = []string{"open_email", "click_email"}
+
 // IsValidEventKind checks if the given event kind is valid
 func IsValidEventKind(kind string) bool {
 	for _, k := range ValidEventKinds {
@@ -78,16 +91,31 @@ func IsValidEventKind(kind string) bool {
 type NodeType string
 
 const (
-	NodeTypeTrigger          NodeType = "trigger"
-	NodeTypeDelay            NodeType = "delay"
-	NodeTypeEmail            NodeType = "email"
-	NodeTypeBranch           NodeType = "branch"
-	NodeTypeFilter           NodeType = "filter"
-	NodeTypeAddToList        NodeType = "add_to_list"
-	NodeTypeRemoveFromList   NodeType = "remove_from_list"
-	NodeTypeABTest           NodeType = "ab_test"
-	NodeTypeWebhook          NodeType = "webhook"
-	NodeTypeListStatusBranch NodeType = "list_status_branch"
+	NodeTypeTrigger            NodeType = "trigger"
+	NodeTypeDelay              NodeType = "delay"
+	NodeTypeEmail              NodeType = "email"
+	NodeTypeBranch             NodeType = "branch"
+	NodeTypeFilter             NodeType = "filter"
+	NodeTypeAddToList          NodeType = "add_to_list"
+	NodeTypeRemoveFromList     NodeType = "remove_from_list"
+	NodeTypeABTest             NodeType = "ab_test"
+	NodeTypeWebhook            NodeType = "webhook"
+	NodeTypeListStatusBranch   NodeType = "list_status_branch"
+	NodeTypeUnsubscribeAll     NodeType = "unsubscribe_all"
+	NodeTypeEnrollInAutomation NodeType = "enroll_in_automation"
+	NodeTypeRecordRevenue      NodeType = "record_revenue"
+	NodeTypeWaitForListStatus  NodeType = "wait_for_list_status"
+	NodeTypeWaitUntilDatetime  NodeType = "wait_until_datetime"
+	NodeTypeForEach            NodeType = "for_each"
+	NodeTypeSuppressionBranch  NodeType = "suppression_branch"
+	NodeTypeTransactionalEmail NodeType = "transactional_email"
+	NodeTypeWaitUntil          NodeType = "wait_until"
+	NodeTypeExpressionBranch   NodeType = "expression_branch"
+	NodeTypeUpdateContact      NodeType = "update_contact"
+	NodeTypeSlack              NodeType = "slack"
+	NodeTypeGoal               NodeType = "goal"
+	NodeTypeThrottle           NodeType = "throttle"
+	NodeTypeReenroll           NodeType = "reenroll"
 )
 
 // IsValid checks if the node type is valid
@@ -95,7 +123,23 @@ func (t NodeType) IsValid() bool {
 	switch t {
 	case NodeTypeTrigger, NodeTypeDelay, NodeTypeEmail, NodeTypeBranch,
 		NodeTypeFilter, NodeTypeAddToList, NodeTypeRemoveFromList,
-		NodeTypeABTest, NodeTypeWebhook, NodeTypeListStatusBranch:
+		NodeTypeABTest, NodeTypeWebhook, NodeTypeListStatusBranch, NodeTypeUnsubscribeAll,
+		NodeTypeEnrollInAutomation, NodeTypeRecordRevenue, NodeTypeWaitForListStatus,
+		NodeTypeWaitUntilDatetime, NodeTypeForEach, NodeTypeSuppressionBranch, NodeTypeTransactionalEmail,
+		NodeTypeWaitUntil, NodeTypeExpressionBranch, NodeTypeUpdateContact, NodeTypeSlack, NodeTypeGoal,
+		NodeTypeThrottle, NodeTypeReenroll:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsAction reports whether the node type performs an action on the contact (rather than
+// routing or waiting), so its failures can be routed to an error_node_id
+func (t NodeType) IsAction() bool {
+	switch t {
+	case NodeTypeEmail, NodeTypeTransactionalEmail, NodeTypeWebhook, NodeTypeSlack, NodeTypeAddToList, NodeTypeRemoveFromList,
+		NodeTypeUnsubscribeAll, NodeTypeEnrollInAutomation, NodeTypeRecordRevenue, NodeTypeForEach:
 		return true
 	default:
 		return false
@@ -145,61 +189,315 @@ func (a NodeAction) IsValid() bool {
 	}
 }
 
-// TimelineTriggerConfig defines the trigger configuration for an automation
-type TimelineTriggerConfig struct {
-	EventKind       string           `json:"event_kind"`                  // Timeline event type to listen for
-	ListID          *string          `json:"list_id,omitempty"`           // Required for list.* events
-	SegmentID       *string          `json:"segment_id,omitempty"`        // Required for segment.* events
-	CustomEventName *string          `json:"custom_event_name,omitempty"` // Required for custom_event
-	UpdatedFields   []string         `json:"updated_fields,omitempty"`    // For contact.updated: only trigger on these field changes
-	Conditions      *TreeNode        `json:"conditions"`                  // Reuse segments condition system
-	Frequency       TriggerFrequency `json:"frequency"`
+// TriggerEventSpec describes a single timeline event that enrolls a contact into an automation
+type TriggerEventSpec struct {
+	EventKind       string              `json:"event_kind"`                  // Timeline event type to listen for
+	ListID          *string             `json:"list_id,omitempty"`           // Required for list.* events
+	SegmentID       *string             `json:"segment_id,omitempty"`        // Required for segment.* events
+	CustomEventName *string             `json:"custom_event_name,omitempty"` // Required for custom_event
+	UpdatedFields   []string            `json:"updated_fields,omitempty"`    // For contact.updated: only trigger on these field changes
+	FieldChange     *TriggerFieldChange `json:"field_change,omitempty"`      // For contact.updated: only trigger when this field changes to a value
 }
 
-// Validate validates the trigger configuration
-func (c *TimelineTriggerConfig) Validate() error {
-	if c.EventKind == "" {
+// Validate validates the trigger event spec
+func (s *TriggerEventSpec) Validate() error {
+	if s.EventKind == "" {
 		return fmt.Errorf("event kind is required")
 	}
 
-	if !IsValidEventKind(c.EventKind) {
-		return fmt.Errorf("invalid event kind: %s", c.EventKind)
-	}
-
-	if !c.Frequency.IsValid() {
-		return fmt.Errorf("invalid trigger frequency: %s", c.Frequency)
+	if !IsValidEventKind(s.EventKind) {
+		return fmt.Errorf("invalid event kind: %s", s.EventKind)
 	}
 
 	// list.* events require list_id
-	if strings.HasPrefix(c.EventKind, "list.") {
-		if c.ListID == nil || *c.ListID == "" {
+	if strings.HasPrefix(s.EventKind, "list.") {
+		if s.ListID == nil || *s.ListID == "" {
 			return fmt.Errorf("list_id is required for list events")
 		}
 	}
 
 	// segment.* events require segment_id
-	if strings.HasPrefix(c.EventKind, "segment.") {
-		if c.SegmentID == nil || *c.SegmentID == "" {
+	if strings.HasPrefix(s.EventKind, "segment.") {
+		if s.SegmentID == nil || *s.SegmentID == "" {
 			return fmt.Errorf("segment_id is required for segment events")
 		}
 	}
 
 	// custom_event requires custom_event_name
-	if c.EventKind == "custom_event" {
-		if c.CustomEventName == nil || *c.CustomEventName == "" {
+	if s.EventKind == "custom_event" {
+		if s.CustomEventName == nil || *s.CustomEventName == "" {
 			return fmt.Errorf("custom_event_name is required for custom events")
 		}
 	}
 
+	if s.FieldChange != nil {
+		if s.EventKind != "contact.updated" {
+			return fmt.Errorf("field_change is only supported for contact.updated events")
+		}
+		if err := s.FieldChange.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TriggerFieldChange restricts a contact.updated trigger to updates of one field, matched
+// against the old and new values recorded in the timeline event changes.
+// From and To are optional: when unset, any old or new value matches.
+type TriggerFieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from,omitempty"`
+	To    interface{} `json:"to,omitempty"`
+}
+
+// Validate validates the trigger field change
+func (c *TriggerFieldChange) Validate() error {
+	if c.Field == "" {
+		return fmt.Errorf("field_change field is required")
+	}
+	if !isTriggerFieldValue(c.From) {
+		return fmt.Errorf("field_change from must be a string, number or boolean")
+	}
+	if !isTriggerFieldValue(c.To) {
+		return fmt.Errorf("field_change to must be a string, number or boolean")
+	}
+	return nil
+}
+
+// isTriggerFieldValue reports whether v can be compared with a value recorded in the timeline changes
+func isTriggerFieldValue(v interface{}) bool {
+	switch v.(type) {
+	case nil, string, bool, float64, int:
+		return true
+	default:
+		return false
+	}
+}
+
+// TriggerPrerequisite restricts enrollment to contacts who completed another automation,
+// optionally within a recent window
+type TriggerPrerequisite struct {
+	AutomationID string `json:"automation_id"`
+	Within       int    `json:"within,omitempty"`      // How recent the completion must be, 0 accepts any completion
+	WithinUnit   string `json:"within_unit,omitempty"` // "minutes", "hours", "days"
+}
+
+// Validate validates the trigger prerequisite
+func (p *TriggerPrerequisite) Validate() error {
+	if p.AutomationID == "" {
+		return fmt.Errorf("prerequisite automation_id is required")
+	}
+	if p.Within != 0 {
+		if p.Within < 0 {
+			return fmt.Errorf("prerequisite within must be positive")
+		}
+		switch p.WithinUnit {
+		case "minutes", "hours", "days":
+		default:
+			return fmt.Errorf("invalid prerequisite within_unit: %s (must be minutes, hours, or days)", p.WithinUnit)
+		}
+	}
+	return nil
+}
+
+// WithinDuration returns how recent the prerequisite completion must be, or 0 when any
+// completion qualifies
+func (p *TriggerPrerequisite) WithinDuration() time.Duration {
+	switch p.WithinUnit {
+	case "minutes":
+		return time.Duration(p.Within) * time.Minute
+	case "hours":
+		return time.Duration(p.Within) * time.Hour
+	case "days":
+		return time.Duration(p.Within) * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// TimelineTriggerConfig defines the trigger configuration for an automation.
+// The top-level event fields describe the primary event; Events adds alternative
+// events so that a contact is enrolled when any of them occurs. Conditions and
+// Frequency apply to all events, so frequency dedup is shared across them.
+// EnrollmentConditions is only supported for list.subscribed triggers: it is
+// evaluated by the enrollment function against the contact at subscription time,
+// so a list shared by several audiences only enrolls the contacts that match.
+// Prerequisite is checked the same way for every event: only contacts who completed
+// the referenced automation are enrolled.
+type TimelineTriggerConfig struct {
+	EventKind            string               `json:"event_kind"`                      // Timeline event type to listen for
+	ListID               *string              `json:"list_id,omitempty"`               // Required for list.* events
+	SegmentID            *string              `json:"segment_id,omitempty"`            // Required for segment.* events
+	CustomEventName      *string              `json:"custom_event_name,omitempty"`     // Required for custom_event
+	UpdatedFields        []string             `json:"updated_fields,omitempty"`        // For contact.updated: only trigger on these field changes
+	FieldChange          *TriggerFieldChange  `json:"field_change,omitempty"`          // For contact.updated: only trigger when this field changes to a value
+	Events               []TriggerEventSpec   `json:"events,omitempty"`                // Additional events, any of which enrolls the contact
+	Conditions           *TreeNode            `json:"conditions"`                      // Reuse segments condition system
+	EnrollmentConditions *TreeNode            `json:"enrollment_conditions,omitempty"` // For list.subscribed: contact must also match at enrollment
+	Frequency            TriggerFrequency     `json:"frequency"`
+	MinDwell             int                  `json:"min_dwell,omitempty"`       // For segment.joined: how long the contact must stay in the segment
+	MinDwellUnit         string               `json:"min_dwell_unit,omitempty"`  // "minutes", "hours", "days"
+	InactivityDays       int                  `json:"inactivity_days,omitempty"` // For inactivity: days without activity before enrollment
+	Prerequisite         *TriggerPrerequisite `json:"prerequisite,omitempty"`    // Contact must have completed this automation
+}
+
+// EventSpecs returns every event that enrolls a contact: the primary event (when set)
+// followed by the additional events
+func (c *TimelineTriggerConfig) EventSpecs() []TriggerEventSpec {
+	specs := make([]TriggerEventSpec, 0, len(c.Events)+1)
+	if c.EventKind != "" {
+		specs = append(specs, TriggerEventSpec{
+			EventKind:       c.EventKind,
+			ListID:          c.ListID,
+			SegmentID:       c.SegmentID,
+			CustomEventName: c.CustomEventName,
+			UpdatedFields:   c.UpdatedFields,
+			FieldChange:     c.FieldChange,
+		})
+	}
+	return append(specs, c.Events...)
+}
+
+// Validate validates the trigger configuration
+func (c *TimelineTriggerConfig) Validate() error {
+	specs := c.EventSpecs()
+	if len(specs) == 0 {
+		return fmt.Errorf("event kind is required")
+	}
+
+	if !c.Frequency.IsValid() {
+		return fmt.Errorf("invalid trigger frequency: %s", c.Frequency)
+	}
+
+	if c.EventKind != "" {
+		if err := specs[0].Validate(); err != nil {
+			return err
+		}
+	}
+
+	for i := range c.Events {
+		if err := c.Events[i].Validate(); err != nil {
+			return fmt.Errorf("events[%d]: %w", i, err)
+		}
+	}
+
+	if c.MinDwell != 0 {
+		if c.MinDwell < 0 {
+			return fmt.Errorf("min_dwell must be positive")
+		}
+		switch c.MinDwellUnit {
+		case "minutes", "hours", "days":
+		default:
+			return fmt.Errorf("invalid min_dwell_unit: %s (must be minutes, hours, or days)", c.MinDwellUnit)
+		}
+		for _, spec := range specs {
+			if spec.EventKind != "segment.joined" {
+				return fmt.Errorf("min_dwell is only supported for segment.joined triggers")
+			}
+		}
+	}
+
+	if c.IsInactivity() {
+		if len(specs) > 1 {
+			return fmt.Errorf("inactivity triggers cannot be combined with other events")
+		}
+		if c.InactivityDays <= 0 {
+			return fmt.Errorf("inactivity_days must be positive")
+		}
+		if c.Conditions != nil {
+			return fmt.Errorf("conditions are not supported for inactivity triggers")
+		}
+	} else if c.InactivityDays != 0 {
+		return fmt.Errorf("inactivity_days is only supported for inactivity triggers")
+	}
+
+	if c.EnrollmentConditions != nil {
+		for _, spec := range specs {
+			if spec.EventKind != "list.subscribed" {
+				return fmt.Errorf("enrollment_conditions are only supported for list.subscribed triggers")
+			}
+		}
+		if err := c.EnrollmentConditions.Validate(); err != nil {
+			return fmt.Errorf("invalid enrollment_conditions: %w", err)
+		}
+	}
+
+	if c.Prerequisite != nil {
+		if err := c.Prerequisite.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// MinDwellDuration returns how long a contact must stay in the trigger segment before its
+// journey starts, or 0 when enrollment is immediate
+func (c *TimelineTriggerConfig) MinDwellDuration() time.Duration {
+	switch c.MinDwellUnit {
+	case "minutes":
+		return time.Duration(c.MinDwell) * time.Minute
+	case "hours":
+		return time.Duration(c.MinDwell) * time.Hour
+	case "days":
+		return time.Duration(c.MinDwell) * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// IsInactivity reports whether contacts are enrolled by the inactivity evaluator rather
+// than by a timeline event
+func (c *TimelineTriggerConfig) IsInactivity() bool {
+	for _, spec := range c.EventSpecs() {
+		if spec.EventKind == "inactivity" {
+			return true
+		}
+	}
+	return false
+}
+
+// JoinedSegmentIDs returns the segments of the trigger's segment.joined events
+func (c *TimelineTriggerConfig) JoinedSegmentIDs() []string {
+	var segmentIDs []string
+	for _, spec := range c.EventSpecs() {
+		if spec.EventKind == "segment.joined" && spec.SegmentID != nil && *spec.SegmentID != "" {
+			segmentIDs = append(segmentIDs, *spec.SegmentID)
+		}
+	}
+	return segmentIDs
+}
+
 // AutomationStats holds statistics for an automation
 type AutomationStats struct {
-	Enrolled  int64 `json:"enrolled"`
-	Completed int64 `json:"completed"`
-	Exited    int64 `json:"exited"`
-	Failed    int64 `json:"failed"`
+	Enrolled  int64              `json:"enrolled"`
+	Completed int64              `json:"completed"`
+	Exited    int64              `json:"exited"`
+	Failed    int64              `json:"failed"`
+	Revenue   map[string]float64 `json:"revenue,omitempty"` // Total recorded revenue per currency
+	// Execution counters and latency per node ID
+	Nodes map[string]*AutomationNodeExecutionStats `json:"nodes,omitempty"`
+}
+
+// AutomationNodeExecutionStats holds the execution counters and latency of a single node
+type AutomationNodeExecutionStats struct {
+	Executions      int64   `json:"executions"`
+	Failures        int64   `json:"failures"`
+	TotalDurationMs int64   `json:"total_duration_ms"`
+	AvgDurationMs   float64 `json:"avg_duration_ms"`
+}
+
+// AutomationRevenue is a conversion value recorded for a contact by a record_revenue node
+type AutomationRevenue struct {
+	ID                  string    `json:"id"`
+	AutomationID        string    `json:"automation_id"`
+	ContactAutomationID string    `json:"contact_automation_id"`
+	NodeID              string    `json:"node_id"`
+	ContactEmail        string    `json:"contact_email"`
+	Amount              float64   `json:"amount"`
+	Currency            string    `json:"currency"`
+	RecordedAt          time.Time `json:"recorded_at"`
 }
 
 // AutomationNodeStats holds statistics for a single automation node
@@ -223,10 +521,210 @@ type Automation struct {
 	TriggerSQL  *string                `json:"trigger_sql,omitempty"` // Generated SQL for WHEN clause
 	RootNodeID  string                 `json:"root_node_id"`
 	Nodes       []*AutomationNode      `json:"nodes"` // Embedded workflow nodes
-	Stats       *AutomationStats       `json:"stats,omitempty"`
-	CreatedAt   time.Time              `json:"created_at"`
-	UpdatedAt   time.Time              `json:"updated_at"`
-	DeletedAt   *time.Time             `json:"deleted_at,omitempty"` // Soft-delete timestamp
+	Tags        []string               `json:"tags"`
+	Version     int                    `json:"version"`      // Current workflow version, new enrollments are pinned to it
+	LogWebhooks bool                   `json:"log_webhooks"` // Record webhook node request/response exchanges in node executions
+	// EnrollmentRamp limits how fast contacts enter the workflow right after activation
+	EnrollmentRamp *EnrollmentRamp  `json:"enrollment_ramp,omitempty"`
+	RetryBackoff   *RetryBackoff    `json:"retry_backoff,omitempty"` // Delay between retries of a failed node, defaults to DefaultRetryBackoff
+	Metadata       MapOfAny         `json:"metadata,omitempty"`      // Free-form annotations, sent along in webhook node payloads
+	Stats          *AutomationStats `json:"stats,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	DeletedAt      *time.Time       `json:"deleted_at,omitempty"` // Soft-delete timestamp
+}
+
+// EnrollmentRamp limits enrollments to PerMinute contacts per minute during the first
+// DurationMinutes minutes after activation, so a backlog of trigger events doesn't start
+// thousands of contacts at once. Contacts over the limit wait at their entry node for a
+// later minute.
+type EnrollmentRamp struct {
+	PerMinute       int        `json:"per_minute"`
+	DurationMinutes int        `json:"duration_minutes"`
+	StartedAt       *time.Time `json:"started_at,omitempty"` // Set when the automation is activated
+}
+
+// Validate validates the enrollment ramp
+func (r *EnrollmentRamp) Validate() error {
+	if r.PerMinute <= 0 {
+		return fmt.Errorf("enrollment_ramp.per_minute must be greater than 0")
+	}
+	if r.DurationMinutes <= 0 {
+		return fmt.Errorf("enrollment_ramp.duration_minutes must be greater than 0")
+	}
+	if r.DurationMinutes > 7*24*60 {
+		return fmt.Errorf("enrollment_ramp.duration_minutes cannot exceed 10080 (7 days)")
+	}
+	return nil
+}
+
+// ActiveAt reports whether the ramp still limits enrollments at the given time
+func (r *EnrollmentRamp) ActiveAt(now time.Time) bool {
+	if r == nil || r.StartedAt == nil {
+		return false
+	}
+	return now.Before(r.StartedAt.Add(time.Duration(r.DurationMinutes) * time.Minute))
+}
+
+// Default retry backoff: 30 seconds doubling up to 1 hour, shortened by up to 20% at random
+const (
+	DefaultRetryBackoffBaseSeconds = 30
+	DefaultRetryBackoffMaxSeconds  = 60 * 60
+	DefaultRetryBackoffJitter      = 0.2
+)
+
+// RetryBackoff schedules the retries of a contact whose node failed. The delay starts at
+// BaseSeconds and doubles with each retry up to MaxSeconds. Up to Jitter of it is randomized
+// so contacts failing together don't all hit a downstream endpoint again at the same moment.
+type RetryBackoff struct {
+	BaseSeconds int     `json:"base_seconds"` // Delay before the first retry
+	MaxSeconds  int     `json:"max_seconds"`  // Cap on the delay
+	Jitter      float64 `json:"jitter"`       // Fraction of the delay randomized, 0 to 1
+}
+
+// DefaultRetryBackoff returns the backoff used when an automation doesn't configure one
+func DefaultRetryBackoff() RetryBackoff {
+	return RetryBackoff{
+		BaseSeconds: DefaultRetryBackoffBaseSeconds,
+		MaxSeconds:  DefaultRetryBackoffMaxSeconds,
+		Jitter:      DefaultRetryBackoffJitter,
+	}
+}
+
+// Validate validates the retry backoff
+func (b *RetryBackoff) Validate() error {
+	if b.BaseSeconds <= 0 {
+		return fmt.Errorf("retry_backoff.base_seconds must be greater than 0")
+	}
+	if b.MaxSeconds < b.BaseSeconds {
+		return fmt.Errorf("retry_backoff.max_seconds must be greater than or equal to base_seconds")
+	}
+	if b.MaxSeconds > 24*60*60 {
+		return fmt.Errorf("retry_backoff.max_seconds cannot exceed 86400 (1 day)")
+	}
+	if b.Jitter < 0 || b.Jitter > 1 {
+		return fmt.Errorf("retry_backoff.jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+// Delay returns the delay before the given retry, 1 being the first one. random, in [0, 1),
+// picks the jitter: the delay is shortened by up to Jitter of itself.
+func (b RetryBackoff) Delay(retryCount int, random float64) time.Duration {
+	delay := time.Duration(b.BaseSeconds) * time.Second
+	maxDelay := time.Duration(b.MaxSeconds) * time.Second
+	for i := 1; i < retryCount && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay - time.Duration(float64(delay)*b.Jitter*random)
+}
+
+// GetRetryBackoff returns the automation's retry backoff, or the default one
+func (a *Automation) GetRetryBackoff() RetryBackoff {
+	if a == nil || a.RetryBackoff == nil {
+		return DefaultRetryBackoff()
+	}
+	return *a.RetryBackoff
+}
+
+// Limits for automation tags
+const (
+	MaxAutomationTags      = 20
+	MaxAutomationTagLength = 50
+)
+
+// NormalizeAutomationTags trims whitespace, drops empty values and removes duplicates
+// while preserving the original order
+func NormalizeAutomationTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// ValidateAutomationTags validates a list of automation tags
+func ValidateAutomationTags(tags []string) error {
+	if len(tags) > MaxAutomationTags {
+		return fmt.Errorf("an automation cannot have more than %d tags", MaxAutomationTags)
+	}
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) == "" {
+			return fmt.Errorf("tags cannot be empty")
+		}
+		if len(tag) > MaxAutomationTagLength {
+			return fmt.Errorf("tag %q cannot exceed %d characters", tag, MaxAutomationTagLength)
+		}
+	}
+	return nil
+}
+
+// AutomationVersion is an immutable snapshot of an automation workflow.
+// Contacts are pinned to the version they enrolled on and keep executing it until they
+// leave the automation, so editing a live automation only affects new enrollments.
+type AutomationVersion struct {
+	AutomationID string            `json:"automation_id"`
+	Version      int               `json:"version"`
+	RootNodeID   string            `json:"root_node_id"`
+	Nodes        []*AutomationNode `json:"nodes"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+// NewVersion snapshots the automation's current workflow as the given version
+func (a *Automation) NewVersion(version int) *AutomationVersion {
+	return &AutomationVersion{
+		AutomationID: a.ID,
+		Version:      version,
+		RootNodeID:   a.RootNodeID,
+		Nodes:        a.Nodes,
+		CreatedAt:    time.Now().UTC(),
+	}
+}
+
+// WithVersion returns a copy of the automation running the workflow of the given version
+func (a *Automation) WithVersion(v *AutomationVersion) *Automation {
+	pinned := *a
+	pinned.Version = v.Version
+	pinned.RootNodeID = v.RootNodeID
+	pinned.Nodes = v.Nodes
+	return &pinned
+}
+
+// WorkflowChanged reports whether the executable workflow differs between two automations.
+// Node positions are ignored: moving nodes in the editor does not create a new version.
+func (a *Automation) WorkflowChanged(other *Automation) bool {
+	if a.RootNodeID != other.RootNodeID || len(a.Nodes) != len(other.Nodes) {
+		return true
+	}
+	for i, node := range a.Nodes {
+		if workflowNodeJSON(node) != workflowNodeJSON(other.Nodes[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func workflowNodeJSON(node *AutomationNode) string {
+	if node == nil {
+		return ""
+	}
+	data, _ := json.Marshal(struct {
+		ID          string                 `json:"id"`
+		Type        NodeType               `json:"type"`
+		Config      map[string]interface{} `json:"config"`
+		NextNodeID  *string                `json:"next_node_id"`
+		ErrorNodeID *string                `json:"error_node_id,omitempty"`
+	}{node.ID, node.Type, node.Config, node.NextNodeID, node.ErrorNodeID})
+	return string(data)
 }
 
 // GetNodeByID finds a node in the automation's Nodes array by ID
@@ -271,6 +769,25 @@ func (a *Automation) Validate() error {
 	if err := a.Trigger.Validate(); err != nil {
 		return err
 	}
+	if a.Trigger.Prerequisite != nil && a.Trigger.Prerequisite.AutomationID == a.ID {
+		return fmt.Errorf("prerequisite cannot reference the automation itself")
+	}
+
+	if err := ValidateAutomationTags(a.Tags); err != nil {
+		return err
+	}
+
+	if a.EnrollmentRamp != nil {
+		if err := a.EnrollmentRamp.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if a.RetryBackoff != nil {
+		if err := a.RetryBackoff.Validate(); err != nil {
+			return err
+		}
+	}
 
 	// Validate embedded nodes
 	for i, node := range a.Nodes {
@@ -292,9 +809,140 @@ func (a *Automation) Validate() error {
 		}
 	}
 
+	// Error edges must lead to a node of the automation
+	for _, node := range a.Nodes {
+		if node.ErrorNodeID != nil && a.GetNodeByID(*node.ErrorNodeID) == nil {
+			return fmt.Errorf("invalid node %s: error_node_id %s does not reference a valid node", node.ID, *node.ErrorNodeID)
+		}
+	}
+
+	// An automation-wide goal sits outside the flow, contacts never run it as a step
+	if root := a.GetNodeByID(a.RootNodeID); root != nil && root.Type == NodeTypeGoal {
+		if scope, _ := root.Config["scope"].(string); GoalScope(scope) == GoalScopeAutomation {
+			return fmt.Errorf("invalid node %s: an automation-wide goal cannot be the root node", root.ID)
+		}
+	}
+
+	// A for_each node must point to a sub-flow made of other nodes of the automation
+	for _, node := range a.Nodes {
+		if node.Type != NodeTypeForEach {
+			continue
+		}
+		subFlowNodeID, _ := node.Config["sub_flow_node_id"].(string)
+		if subFlowNodeID == "" {
+			continue // reported by the node config validation
+		}
+		if subFlowNodeID == node.ID {
+			return fmt.Errorf("invalid node %s: sub_flow_node_id cannot reference the for_each node itself", node.ID)
+		}
+		if a.GetNodeByID(subFlowNodeID) == nil {
+			return fmt.Errorf("invalid node %s: sub_flow_node_id %s does not reference a valid node", node.ID, subFlowNodeID)
+		}
+	}
+
+	return nil
+}
+
+// HasGoals reports whether the automation has goal nodes, which the scheduler checks on
+// every processing pass
+func (a *Automation) HasGoals() bool {
+	for _, node := range a.Nodes {
+		if node != nil && node.Type == NodeTypeGoal {
+			return true
+		}
+	}
+	return false
+}
+
+// HasWebhookMTLS returns true if a webhook node of the automation presents a client certificate
+func (a *Automation) HasWebhookMTLS() bool {
+	for _, node := range a.Nodes {
+		if webhookNodeMTLS(node) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// RestoreWebhookClientKeys fills in the mTLS client keys left out of webhook nodes, as API
+// responses redact them, with the key of the same node in the stored automation. Keys are
+// only taken from the stored automation, never from an encrypted_client_key sent by the client.
+func (a *Automation) RestoreWebhookClientKeys(stored *Automation, passphrase string) error {
+	for _, node := range a.Nodes {
+		mtls := webhookNodeMTLS(node)
+		if mtls == nil {
+			continue
+		}
+		delete(mtls, "encrypted_client_key")
+		if key, _ := mtls["client_key"].(string); key != "" {
+			continue
+		}
+
+		var previous map[string]interface{}
+		if stored != nil {
+			previous = webhookNodeMTLS(stored.GetNodeByID(node.ID))
+		}
+		if previous == nil {
+			// Validation reports the missing key
+			continue
+		}
+		if key, _ := previous["client_key"].(string); key != "" {
+			mtls["client_key"] = key
+			continue
+		}
+		if encrypted, _ := previous["encrypted_client_key"].(string); encrypted != "" {
+			key, err := crypto.DecryptFromHexString(encrypted, passphrase)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt client key of webhook node %s: %w", node.ID, err)
+			}
+			mtls["client_key"] = key
+		}
+	}
+	return nil
+}
+
+// EncryptWebhookClientKeys replaces the mTLS client keys of webhook nodes with their
+// encrypted form, so they are not stored in plain text
+func (a *Automation) EncryptWebhookClientKeys(passphrase string) error {
+	for _, node := range a.Nodes {
+		mtls := webhookNodeMTLS(node)
+		if mtls == nil {
+			continue
+		}
+		key, _ := mtls["client_key"].(string)
+		if key == "" {
+			continue
+		}
+		encrypted, err := crypto.EncryptString(key, passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt client key of webhook node %s: %w", node.ID, err)
+		}
+		mtls["encrypted_client_key"] = encrypted
+		delete(mtls, "client_key")
+	}
 	return nil
 }
 
+// RedactWebhookClientKeys removes the mTLS client keys of webhook nodes from an automation
+// returned by the API
+func (a *Automation) RedactWebhookClientKeys() {
+	for _, node := range a.Nodes {
+		if mtls := webhookNodeMTLS(node); mtls != nil {
+			delete(mtls, "client_key")
+			delete(mtls, "encrypted_client_key")
+		}
+	}
+}
+
+// webhookNodeMTLS returns the mtls section of a webhook node config, or nil
+func webhookNodeMTLS(node *AutomationNode) map[string]interface{} {
+	if node == nil || node.Type != NodeTypeWebhook {
+		return nil
+	}
+	mtls, _ := node.Config["mtls"].(map[string]interface{})
+	return mtls
+}
+
 // HasEmailNodeRestriction returns true if email nodes are not allowed for this automation.
 // Email nodes require a list to be configured because emails need contact data from list membership.
 func (a *Automation) HasEmailNodeRestriction() bool {
@@ -307,6 +955,24 @@ type NodePosition struct {
 	Y float64 `json:"y"`
 }
 
+// NodeFailurePolicy controls what happens to a contact when its current node fails
+type NodeFailurePolicy string
+
+const (
+	NodeFailurePolicyRetry NodeFailurePolicy = "retry" // Retry with backoff, then fail the contact (default)
+	NodeFailurePolicySkip  NodeFailurePolicy = "skip"  // Record the failure and continue to next_node_id
+	NodeFailurePolicyExit  NodeFailurePolicy = "exit"  // Record the failure and exit the contact
+)
+
+// IsValid checks if the failure policy is valid, empty meaning retry
+func (p NodeFailurePolicy) IsValid() bool {
+	switch p {
+	case "", NodeFailurePolicyRetry, NodeFailurePolicySkip, NodeFailurePolicyExit:
+		return true
+	}
+	return false
+}
+
 // AutomationNode represents a node in an automation workflow
 type AutomationNode struct {
 	ID           string                 `json:"id"`
@@ -314,6 +980,8 @@ type AutomationNode struct {
 	Type         NodeType               `json:"type"`
 	Config       map[string]interface{} `json:"config"`
 	NextNodeID   *string                `json:"next_node_id,omitempty"`
+	OnFailure    NodeFailurePolicy      `json:"on_failure,omitempty"`    // Defaults to retry
+	ErrorNodeID  *string                `json:"error_node_id,omitempty"` // Action nodes: failures route here instead of on_failure
 	Position     NodePosition           `json:"position"`
 	CreatedAt    time.Time              `json:"created_at"`
 }
@@ -339,34 +1007,257 @@ func (n *AutomationNode) Validate() error {
 		return fmt.Errorf("config is required")
 	}
 
-	return nil
-}
-
-// ValidateForAutomation validates the node in context of its parent automation.
-// This includes additional checks like email node restrictions.
-func (n *AutomationNode) ValidateForAutomation(automation *Automation) error {
-	if err := n.Validate(); err != nil {
-		return err
+	if !n.OnFailure.IsValid() {
+		return fmt.Errorf("invalid on_failure policy: %s (must be retry, skip or exit)", n.OnFailure)
 	}
 
-	// Email nodes require a list to be configured - emails need contact data from list membership
-	if n.Type == NodeTypeEmail && automation.HasEmailNodeRestriction() {
-		return fmt.Errorf("email nodes require a list to be configured - emails need contact data from list membership")
+	if n.ErrorNodeID != nil {
+		if !n.Type.IsAction() {
+			return fmt.Errorf("error_node_id is only supported on action nodes")
+		}
+		if *n.ErrorNodeID == n.ID {
+			return fmt.Errorf("error_node_id cannot reference the node itself")
+		}
 	}
 
-	return nil
-}
+	// Reject unusable client certificates at save time rather than on first delivery
+	if n.Type == NodeTypeWebhook {
+		if err := validateWebhookNodeMTLS(n.Config); err != nil {
+			return err
+		}
+		if err := validateWebhookNodeContentType(n.Config); err != nil {
+			return err
+		}
+	}
 
-// HasEmailNodes checks if any nodes in the provided list are email nodes
-func HasEmailNodes(nodes []*AutomationNode) bool {
-	for _, node := range nodes {
-		if node.Type == NodeTypeEmail {
-			return true
+	// An automation enrolling contacts into itself would loop forever
+	if n.Type == NodeTypeEnrollInAutomation {
+		if target, _ := n.Config["automation_id"].(string); target == n.AutomationID {
+			return fmt.Errorf("automation cannot enroll contacts into itself")
 		}
 	}
-	return false
+
+	return nil
 }
 
+// validateWebhookNodeContentType validates the content_type of a webhook node config, if present
+func validateWebhookNodeContentType(config map[string]interface{}) error {
+	raw, ok := config["content_type"]
+	if !ok || raw == nil {
+		return nil
+	}
+
+	contentType, isString := raw.(string)
+	if !isString {
+		return fmt.Errorf("content_type must be json or form")
+	}
+	switch WebhookContentType(contentType) {
+	case "", WebhookContentTypeJSON, WebhookContentTypeForm:
+		return nil
+	default:
+		return fmt.Errorf("content_type must be json or form")
+	}
+}
+
+// validateWebhookNodeMTLS validates the mtls section of a webhook node config, if present
+func validateWebhookNodeMTLS(config map[string]interface{}) error {
+	if _, ok := config["mtls"]; !ok {
+		return nil
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook config: %w", err)
+	}
+
+	var c WebhookNodeConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return fmt.Errorf("invalid webhook config: %w", err)
+	}
+	if c.MTLS == nil {
+		return nil
+	}
+
+	return c.Validate()
+}
+
+// ValidateForAutomation validates the node in context of its parent automation.
+// This includes additional checks like email node restrictions.
+func (n *AutomationNode) ValidateForAutomation(automation *Automation) error {
+	if err := n.Validate(); err != nil {
+		return err
+	}
+
+	// Email nodes require a list to be configured - emails need contact data from list membership
+	if n.Type == NodeTypeEmail && automation.HasEmailNodeRestriction() {
+		return fmt.Errorf("email nodes require a list to be configured - emails need contact data from list membership")
+	}
+
+	return nil
+}
+
+// HasEmailNodes checks if any nodes in the provided list are email nodes
+func HasEmailNodes(nodes []*AutomationNode) bool {
+	for _, node := range nodes {
+		if node.Type == NodeTypeEmail {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateConfig decodes the node config into its typed form and validates it, so a bad
+// config is rejected when the automation is saved rather than when a contact reaches the
+// node. Node types without a typed config validation (trigger, branch, filter) pass.
+func (n *AutomationNode) ValidateConfig() error {
+	var config interface{ Validate() error }
+	switch n.Type {
+	case NodeTypeDelay:
+		config = &DelayNodeConfig{}
+	case NodeTypeEmail, NodeTypeTransactionalEmail:
+		config = &EmailNodeConfig{}
+	case NodeTypeAddToList:
+		config = &AddToListNodeConfig{}
+	case NodeTypeRemoveFromList:
+		config = &RemoveFromListNodeConfig{}
+	case NodeTypeUnsubscribeAll:
+		config = &UnsubscribeAllNodeConfig{}
+	case NodeTypeEnrollInAutomation:
+		config = &EnrollInAutomationNodeConfig{}
+	case NodeTypeRecordRevenue:
+		config = &RecordRevenueNodeConfig{}
+	case NodeTypeListStatusBranch:
+		config = &ListStatusBranchNodeConfig{}
+	case NodeTypeSuppressionBranch:
+		config = &SuppressionBranchNodeConfig{}
+	case NodeTypeExpressionBranch:
+		config = &ExpressionBranchNodeConfig{}
+	case NodeTypeUpdateContact:
+		config = &UpdateContactNodeConfig{}
+	case NodeTypeWaitForListStatus:
+		config = &WaitForListStatusNodeConfig{}
+	case NodeTypeWaitUntilDatetime:
+		config = &WaitUntilDatetimeNodeConfig{}
+	case NodeTypeWaitUntil:
+		config = &WaitUntilNodeConfig{}
+	case NodeTypeForEach:
+		config = &ForEachNodeConfig{}
+	case NodeTypeABTest:
+		config = &ABTestNodeConfig{}
+	case NodeTypeWebhook:
+		config = &WebhookNodeConfig{}
+	case NodeTypeSlack:
+		config = &SlackNodeConfig{}
+	case NodeTypeGoal:
+		config = &GoalNodeConfig{}
+	case NodeTypeThrottle:
+		config = &ThrottleNodeConfig{}
+	case NodeTypeReenroll:
+		config = &ReenrollNodeConfig{}
+	default:
+		return nil
+	}
+
+	data, err := json.Marshal(n.Config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := json.Unmarshal(data, config); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return newNodeConfigFieldError(typeErr.Field, "%s must be of type %s", typeErr.Field, typeErr.Type)
+		}
+		return newNodeConfigFieldError("", "invalid config: %v", err)
+	}
+
+	return config.Validate()
+}
+
+// ValidateNodeConfigs validates the config of every node and reports all invalid nodes at
+// once, keyed by node ID
+func (a *Automation) ValidateNodeConfigs() error {
+	nodeErrors := make(map[string]NodeConfigFieldError)
+	for _, node := range a.Nodes {
+		if node == nil {
+			continue
+		}
+		if err := node.ValidateConfig(); err != nil {
+			var fieldErr *NodeConfigFieldError
+			if !errors.As(err, &fieldErr) {
+				fieldErr = &NodeConfigFieldError{Message: err.Error()}
+			}
+			nodeErrors[node.ID] = *fieldErr
+		}
+	}
+
+	if len(nodeErrors) == 0 {
+		return nil
+	}
+	return &NodeConfigValidationError{NodeErrors: nodeErrors}
+}
+
+// NodeConfigFieldError is a validation error on a single field of a node config.
+// Field is the JSON path of the field, e.g. "duration" or "sender_rules[0].integration_id",
+// and is empty when the error is not about a specific field.
+type NodeConfigFieldError struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface
+func (e *NodeConfigFieldError) Error() string {
+	return e.Message
+}
+
+// newNodeConfigFieldError creates a field error with a formatted message
+func newNodeConfigFieldError(field, format string, args ...interface{}) error {
+	return &NodeConfigFieldError{Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+// nestNodeConfigFieldError scopes an error from a nested config (a sender rule, a variant...)
+// under its parent field, prefixing the message with label when set
+func nestNodeConfigFieldError(parent, label string, err error) error {
+	nested := &NodeConfigFieldError{Message: err.Error()}
+	var fieldErr *NodeConfigFieldError
+	if errors.As(err, &fieldErr) {
+		nested = fieldErr
+	}
+
+	field := parent
+	if nested.Field != "" {
+		field = parent + "." + nested.Field
+	}
+	message := nested.Message
+	if label != "" {
+		message = label + ": " + message
+	}
+	return &NodeConfigFieldError{Field: field, Message: message}
+}
+
+// NodeConfigValidationError reports the nodes of an automation whose config is invalid,
+// keyed by node ID
+type NodeConfigValidationError struct {
+	NodeErrors map[string]NodeConfigFieldError `json:"node_errors"`
+}
+
+// Error implements the error interface, listing nodes in ID order
+func (e *NodeConfigValidationError) Error() string {
+	nodeIDs := make([]string, 0, len(e.NodeErrors))
+	for nodeID := range e.NodeErrors {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+
+	parts := make([]string, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		parts = append(parts, fmt.Sprintf("node %s: %s", nodeID, e.NodeErrors[nodeID].Message))
+	}
+	return "invalid node config: " + strings.Join(parts, "; ")
+}
+
+// ContactDeletedExitReason is the exit reason of contacts deleted while enrolled
+const ContactDeletedExitReason = "contact_deleted"
+
 // ContactAutomation tracks a contact's journey through an automation
 type ContactAutomation struct {
 	ID            string                  `json:"id"`
@@ -374,7 +1265,7 @@ type ContactAutomation struct {
 	ContactEmail  string                  `json:"contact_email"`
 	CurrentNodeID *string                 `json:"current_node_id,omitempty"`
 	Status        ContactAutomationStatus `json:"status"`
-	ExitReason    *string                 `json:"exit_reason,omitempty"` // Why contact exited: completed, filter_rejected, automation_node_deleted, manual, unsubscribed
+	ExitReason    *string                 `json:"exit_reason,omitempty"` // Why contact exited: completed, filter_rejected, automation_node_deleted, node_failed, segment_dwell_not_met, manual, unsubscribed, goal_met, contact_deleted, reenrolled
 	EnteredAt     time.Time               `json:"entered_at"`
 	ScheduledAt   *time.Time              `json:"scheduled_at,omitempty"`
 	Context       map[string]interface{}  `json:"context,omitempty"`
@@ -382,6 +1273,9 @@ type ContactAutomation struct {
 	LastError     *string                 `json:"last_error,omitempty"`
 	LastRetryAt   *time.Time              `json:"last_retry_at,omitempty"`
 	MaxRetries    int                     `json:"max_retries"`
+	// AutomationVersion is the workflow version the contact enrolled on (nil for enrollments
+	// made before versioning, which follow the latest version)
+	AutomationVersion *int `json:"automation_version,omitempty"`
 }
 
 // simple email regex for validation
@@ -419,6 +1313,107 @@ func (ca *ContactAutomation) Validate() error {
 	return nil
 }
 
+// Enrollment context keys set when a contact is enrolled by a broadcast message event
+const (
+	ContactAutomationContextBroadcastID = "broadcast_id"
+	ContactAutomationContextGlobalFeed  = "global_feed"
+)
+
+// SetContextValues stores values in the contact's automation context, e.g. a webhook
+// response saved for later nodes
+func (ca *ContactAutomation) SetContextValues(values map[string]interface{}) {
+	if len(values) == 0 {
+		return
+	}
+	if ca.Context == nil {
+		ca.Context = make(map[string]interface{}, len(values))
+	}
+	for key, value := range values {
+		ca.Context[key] = value
+	}
+}
+
+// GlobalFeed returns the global feed data of the broadcast that triggered the enrollment, if any
+func (ca *ContactAutomation) GlobalFeed() MapOfAny {
+	if ca == nil || ca.Context == nil {
+		return nil
+	}
+	switch feed := ca.Context[ContactAutomationContextGlobalFeed].(type) {
+	case MapOfAny:
+		return feed
+	case map[string]interface{}:
+		return MapOfAny(feed)
+	default:
+		return nil
+	}
+}
+
+// NextActionType describes what the scheduler will do on a contact's next tick
+type NextActionType string
+
+const (
+	NextActionExecuteNode     NextActionType = "execute_node"      // Run the current node once scheduled_at is reached
+	NextActionComplete        NextActionType = "complete"          // No node left, the contact will be marked completed
+	NextActionExitNodeDeleted NextActionType = "exit_node_deleted" // Current node no longer exists, the contact will exit
+	NextActionWaitAutomation  NextActionType = "wait_automation"   // Automation is not live, the contact stays frozen
+	NextActionNone            NextActionType = "none"              // Contact is no longer active
+)
+
+// ContactNextAction describes the next action, and the node it applies to when relevant
+type ContactNextAction struct {
+	Type     NextActionType `json:"type"`
+	NodeID   *string        `json:"node_id,omitempty"`
+	NodeType *NodeType      `json:"node_type,omitempty"`
+}
+
+// ContactNextTick is a preview of the scheduler's next tick for a contact
+type ContactNextTick struct {
+	CurrentNodeID *string           `json:"current_node_id"`
+	ScheduledAt   *time.Time        `json:"scheduled_at"`
+	NextAction    ContactNextAction `json:"next_action"`
+}
+
+// WebhookNodeTestResult is the outcome of firing a webhook node once against a sample contact
+type WebhookNodeTestResult struct {
+	Exchange map[string]interface{} `json:"exchange"`         // Captured request and response (see log_webhooks)
+	Output   map[string]interface{} `json:"output,omitempty"` // Node output the automation would store, on success
+	Error    string                 `json:"error,omitempty"`  // Why the call failed, the exchange is kept when available
+}
+
+// PreviewNextTick derives what the scheduler will do next for a contact from its state
+// and the automation graph. It mirrors the decisions made by the automation executor
+// without executing anything.
+func PreviewNextTick(automation *Automation, ca *ContactAutomation) *ContactNextTick {
+	tick := &ContactNextTick{
+		CurrentNodeID: ca.CurrentNodeID,
+		ScheduledAt:   ca.ScheduledAt,
+	}
+
+	switch {
+	case ca.Status != ContactAutomationStatusActive:
+		tick.NextAction.Type = NextActionNone
+	case automation.Status != AutomationStatusLive:
+		tick.NextAction.Type = NextActionWaitAutomation
+	case ca.CurrentNodeID == nil:
+		tick.NextAction.Type = NextActionComplete
+	default:
+		node := automation.GetNodeByID(*ca.CurrentNodeID)
+		if node == nil {
+			tick.NextAction.Type = NextActionExitNodeDeleted
+			break
+		}
+		nodeID := node.ID
+		nodeType := node.Type
+		tick.NextAction = ContactNextAction{
+			Type:     NextActionExecuteNode,
+			NodeID:   &nodeID,
+			NodeType: &nodeType,
+		}
+	}
+
+	return tick
+}
+
 // ContactAutomationWithWorkspace includes workspace ID for global processing
 type ContactAutomationWithWorkspace struct {
 	WorkspaceID string
@@ -468,45 +1463,154 @@ func (e *NodeExecution) Validate() error {
 // Node configuration types
 
 // DelayNodeConfig configures a delay node
+// By default the contact waits duration units from the moment it reaches the node. With an
+// anchor_field, it waits until the contact's date in that field plus offset units instead, e.g.
+// offset -3 with unit "days" fires 3 days before a contract end date.
 type DelayNodeConfig struct {
-	Duration int    `json:"duration"`
-	Unit     string `json:"unit"` // "minutes", "hours", "days"
+	Duration    int    `json:"duration"`
+	Unit        string `json:"unit"`                   // "minutes", "hours", "days"
+	AnchorField string `json:"anchor_field,omitempty"` // Contact date field to wait from, e.g. "custom_datetime_1"
+	Offset      int    `json:"offset,omitempty"`       // With anchor_field: units added to the anchor date, negative for before
+	OnPast      string `json:"on_past,omitempty"`      // With anchor_field: DelayOnPastSkip (default) or DelayOnPastExit
+}
+
+// What a delay node does when the anchored moment has already passed
+const (
+	DelayOnPastSkip = "skip" // Continue to the next node right away
+	DelayOnPastExit = "exit" // Exit the automation
+)
+
+// DelayAnchorFields are the contact fields a delay node can be anchored on: datetime fields,
+// and custom strings holding a date (RFC 3339 or YYYY-MM-DD)
+var DelayAnchorFields = map[string]bool{
+	"created_at":        true,
+	"custom_datetime_1": true, "custom_datetime_2": true, "custom_datetime_3": true, "custom_datetime_4": true, "custom_datetime_5": true,
+	"custom_string_1": true, "custom_string_2": true, "custom_string_3": true, "custom_string_4": true, "custom_string_5": true,
 }
 
 // Validate validates the delay node config
 func (c DelayNodeConfig) Validate() error {
-	if c.Duration <= 0 {
-		return fmt.Errorf("duration must be positive")
+	if c.AnchorField == "" && c.Duration <= 0 {
+		return newNodeConfigFieldError("duration", "duration must be positive")
+	}
+
+	if c.AnchorField != "" {
+		if !DelayAnchorFields[c.AnchorField] {
+			return newNodeConfigFieldError("anchor_field", "invalid anchor_field: %s (must be created_at, a custom_datetime or a custom_string field)", c.AnchorField)
+		}
+		switch c.OnPast {
+		case "", DelayOnPastSkip, DelayOnPastExit:
+		default:
+			return newNodeConfigFieldError("on_past", "invalid on_past: %s (must be skip or exit)", c.OnPast)
+		}
 	}
 
 	switch c.Unit {
 	case "minutes", "hours", "days":
 		return nil
 	default:
-		return fmt.Errorf("invalid unit: %s (must be minutes, hours, or days)", c.Unit)
+		return newNodeConfigFieldError("unit", "invalid unit: %s (must be minutes, hours, or days)", c.Unit)
+	}
+}
+
+// EmailDelivery defines how an email node hands its email over to the provider
+type EmailDelivery string
+
+const (
+	EmailDeliveryQueued    EmailDelivery = "queued"    // Enqueued and sent by the email queue worker (default)
+	EmailDeliveryImmediate EmailDelivery = "immediate" // Sent inline while the node executes, e.g. for OTP codes
+)
+
+// EmailSenderRule sends the email through a specific integration (and optionally sender)
+// when the contact matches its conditions, e.g. EU contacts through an EU provider
+type EmailSenderRule struct {
+	Conditions    *TreeNode `json:"conditions"`
+	IntegrationID string    `json:"integration_id"`
+	SenderID      string    `json:"sender_id,omitempty"` // Defaults to the template sender, or the integration default sender
+}
+
+// Validate validates the sender rule
+func (r EmailSenderRule) Validate() error {
+	if r.Conditions == nil {
+		return newNodeConfigFieldError("conditions", "conditions are required")
+	}
+	if err := r.Conditions.Validate(); err != nil {
+		return newNodeConfigFieldError("conditions", "invalid conditions: %v", err)
+	}
+	if r.IntegrationID == "" {
+		return newNodeConfigFieldError("integration_id", "integration_id is required")
 	}
+	return nil
 }
 
 // EmailNodeConfig configures an email node
 type EmailNodeConfig struct {
-	TemplateID      string  `json:"template_id"`
-	IntegrationID   *string `json:"integration_id,omitempty"`
-	SubjectOverride *string `json:"subject_override,omitempty"`
-	FromOverride    *string `json:"from_override,omitempty"`
+	TemplateID      string        `json:"template_id"`
+	IntegrationID   *string       `json:"integration_id,omitempty"`
+	SubjectOverride *string       `json:"subject_override,omitempty"`
+	FromOverride    *string       `json:"from_override,omitempty"`
+	Delivery        EmailDelivery `json:"delivery,omitempty"` // Defaults to queued
+	// SenderRules are evaluated in order against the contact at send time; the first match
+	// picks the integration, otherwise integration_id or the workspace default is used
+	SenderRules []EmailSenderRule `json:"sender_rules,omitempty"`
+	// SkipIfSentWithin skips the send when the contact already received the same template
+	// within this window, e.g. to avoid duplicate reminders across re-entries
+	SkipIfSentWithin     int    `json:"skip_if_sent_within,omitempty"`
+	SkipIfSentWithinUnit string `json:"skip_if_sent_within_unit,omitempty"` // "minutes", "hours", "days"
 }
 
 // Validate validates the email node config
 func (c EmailNodeConfig) Validate() error {
 	if c.TemplateID == "" {
-		return fmt.Errorf("template_id is required")
+		return newNodeConfigFieldError("template_id", "template_id is required")
+	}
+	switch c.Delivery {
+	case "", EmailDeliveryQueued, EmailDeliveryImmediate:
+	default:
+		return newNodeConfigFieldError("delivery", "invalid delivery: %s", c.Delivery)
+	}
+	for i, rule := range c.SenderRules {
+		if err := rule.Validate(); err != nil {
+			return nestNodeConfigFieldError(fmt.Sprintf("sender_rules[%d]", i), fmt.Sprintf("sender rule %d", i), err)
+		}
+	}
+	if c.SkipIfSentWithin < 0 {
+		return newNodeConfigFieldError("skip_if_sent_within", "skip_if_sent_within must be positive")
+	}
+	if c.SkipIfSentWithin > 0 {
+		switch c.SkipIfSentWithinUnit {
+		case "minutes", "hours", "days":
+		default:
+			return newNodeConfigFieldError("skip_if_sent_within_unit", "invalid skip_if_sent_within_unit: %s (must be minutes, hours, or days)", c.SkipIfSentWithinUnit)
+		}
 	}
 	return nil
 }
 
+// IsImmediate returns true when the email must be sent while the node executes
+func (c EmailNodeConfig) IsImmediate() bool {
+	return c.Delivery == EmailDeliveryImmediate
+}
+
+// SkipIfSentWithinDuration returns the window in which a previous send of the template
+// skips the node, or 0 when the node always sends
+func (c EmailNodeConfig) SkipIfSentWithinDuration() time.Duration {
+	switch c.SkipIfSentWithinUnit {
+	case "minutes":
+		return time.Duration(c.SkipIfSentWithin) * time.Minute
+	case "hours":
+		return time.Duration(c.SkipIfSentWithin) * time.Hour
+	case "days":
+		return time.Duration(c.SkipIfSentWithin) * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
 // BranchPath represents a branch path in a branch node
 type BranchPath struct {
 	ID         string    `json:"id"`
-	Name       string    `json:"name"`
+	Name       string    `json:"name"` // May contain Liquid, rendered per contact when recorded
 	Conditions *TreeNode `json:"conditions"`
 	NextNodeID string    `json:"next_node_id"`
 }
@@ -515,6 +1619,7 @@ type BranchPath struct {
 type BranchNodeConfig struct {
 	Paths         []BranchPath `json:"paths"`
 	DefaultPathID string       `json:"default_path_id"`
+	Verbose       bool         `json:"verbose,omitempty"` // Record evaluated field values and path results in the node execution output
 }
 
 // FilterNodeConfig configures a filter node
@@ -523,22 +1628,144 @@ type FilterNodeConfig struct {
 	Conditions     *TreeNode `json:"conditions"`
 	ContinueNodeID string    `json:"continue_node_id"`
 	ExitNodeID     string    `json:"exit_node_id"`
+	Verbose        bool      `json:"verbose,omitempty"` // Record evaluated field values in the node execution output
+}
+
+// GoalExitReason is the exit reason of contacts removed because they met a goal
+const GoalExitReason = "goal_met"
+
+// GoalScope controls where a goal node applies
+type GoalScope string
+
+const (
+	// GoalScopeBranch goals are steps of the flow: checked when the contact reaches them,
+	// then periodically while the contact waits at later nodes (default)
+	GoalScopeBranch GoalScope = "branch"
+	// GoalScopeAutomation goals are not linked to the flow and apply to every contact
+	// from enrollment
+	GoalScopeAutomation GoalScope = "automation"
+)
+
+// GoalNodeConfig configures a goal node. Contacts matching the conditions exit the
+// automation with exit reason goal_met, e.g. subscribers who converted mid-sequence.
+type GoalNodeConfig struct {
+	Description string    `json:"description,omitempty"`
+	Conditions  *TreeNode `json:"conditions"`
+	Scope       GoalScope `json:"scope,omitempty"` // Defaults to branch
+}
+
+// Validate validates the goal node config
+func (c GoalNodeConfig) Validate() error {
+	if c.Conditions == nil {
+		return newNodeConfigFieldError("conditions", "conditions are required")
+	}
+	if err := c.Conditions.Validate(); err != nil {
+		return newNodeConfigFieldError("conditions", "invalid conditions: %v", err)
+	}
+	if c.Scope != "" && c.Scope != GoalScopeBranch && c.Scope != GoalScopeAutomation {
+		return newNodeConfigFieldError("scope", "invalid scope: %s (must be %s or %s)", c.Scope, GoalScopeBranch, GoalScopeAutomation)
+	}
+	return nil
+}
+
+// ReenrolledExitReason is the exit reason of runs ended by a reenroll node that enrolled
+// the contact again
+const ReenrolledExitReason = "reenrolled"
+
+// MaxReenrollments caps the max_reenrollments of reenroll nodes
+const MaxReenrollments = 100
+
+// ReenrollNodeConfig configures a reenroll node, which restarts the automation from the top
+// for contacts matching the conditions (all contacts when unset): the current run exits and
+// a new enrollment starts at the root node, e.g. to loop a drip. A contact is re-enrolled at
+// most MaxReenrollments times, and never in a "once" automation.
+type ReenrollNodeConfig struct {
+	Conditions       *TreeNode `json:"conditions,omitempty"`
+	MaxReenrollments int       `json:"max_reenrollments"`
+}
+
+// Validate validates the reenroll node config
+func (c ReenrollNodeConfig) Validate() error {
+	if c.Conditions != nil {
+		if err := c.Conditions.Validate(); err != nil {
+			return newNodeConfigFieldError("conditions", "invalid conditions: %v", err)
+		}
+	}
+	if c.MaxReenrollments <= 0 {
+		return newNodeConfigFieldError("max_reenrollments", "max_reenrollments must be greater than 0")
+	}
+	if c.MaxReenrollments > MaxReenrollments {
+		return newNodeConfigFieldError("max_reenrollments", "max_reenrollments cannot exceed %d", MaxReenrollments)
+	}
+	return nil
+}
+
+// MaxThrottlePerMinute caps the max_per_minute of throttle nodes
+const MaxThrottlePerMinute = 100000
+
+// ThrottleNodeConfig configures a throttle node, which lets at most MaxPerMinute contacts per
+// minute past it across the whole automation, e.g. ahead of an email node so a large segment
+// enrollment does not flood the SMTP relay. Contacts over the limit wait for a later minute.
+type ThrottleNodeConfig struct {
+	MaxPerMinute int `json:"max_per_minute"`
+}
+
+// Validate validates the throttle node config
+func (c ThrottleNodeConfig) Validate() error {
+	if c.MaxPerMinute <= 0 {
+		return newNodeConfigFieldError("max_per_minute", "max_per_minute must be greater than 0")
+	}
+	if c.MaxPerMinute > MaxThrottlePerMinute {
+		return newNodeConfigFieldError("max_per_minute", "max_per_minute cannot exceed %d", MaxThrottlePerMinute)
+	}
+	return nil
 }
 
 // AddToListNodeConfig configures an add-to-list node
 type AddToListNodeConfig struct {
-	ListID   string                 `json:"list_id"`
-	Status   string                 `json:"status"` // "active", "pending"
+	ListID   string                 `json:"list_id"` // May use Liquid, e.g. "news_{{ global_feed.region }}"
+	Status   string                 `json:"status"`  // "active", "pending"
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // Validate validates the add-to-list node config
 func (c AddToListNodeConfig) Validate() error {
 	if c.ListID == "" {
-		return fmt.Errorf("list_id is required")
+		return newNodeConfigFieldError("list_id", "list_id is required")
 	}
 	if c.Status != string(ContactListStatusActive) && c.Status != string(ContactListStatusPending) {
-		return fmt.Errorf("invalid status: %s (must be %s or %s)", c.Status, ContactListStatusActive, ContactListStatusPending)
+		return newNodeConfigFieldError("status", "invalid status: %s (must be %s or %s)", c.Status, ContactListStatusActive, ContactListStatusPending)
+	}
+	return nil
+}
+
+// UpdateContactNodeFields lists the contact fields an update contact node may set
+var UpdateContactNodeFields = map[string]bool{
+	"external_id": true, "timezone": true, "language": true,
+	"first_name": true, "last_name": true, "full_name": true, "phone": true,
+	"address_line_1": true, "address_line_2": true, "country": true, "postcode": true, "state": true, "job_title": true,
+	"custom_string_1": true, "custom_string_2": true, "custom_string_3": true, "custom_string_4": true, "custom_string_5": true,
+	"custom_number_1": true, "custom_number_2": true, "custom_number_3": true, "custom_number_4": true, "custom_number_5": true,
+	"custom_datetime_1": true, "custom_datetime_2": true, "custom_datetime_3": true, "custom_datetime_4": true, "custom_datetime_5": true,
+	"custom_json_1": true, "custom_json_2": true, "custom_json_3": true, "custom_json_4": true, "custom_json_5": true,
+}
+
+// UpdateContactNodeConfig configures an update contact node
+// String values may use Liquid, e.g. "{{ contact.first_name | capitalize }}", and an explicit
+// null clears the field
+type UpdateContactNodeConfig struct {
+	Fields map[string]interface{} `json:"fields"` // Contact field -> new value
+}
+
+// Validate validates the update contact node config
+func (c UpdateContactNodeConfig) Validate() error {
+	if len(c.Fields) == 0 {
+		return newNodeConfigFieldError("fields", "at least one field is required")
+	}
+	for field := range c.Fields {
+		if !UpdateContactNodeFields[field] {
+			return newNodeConfigFieldError("fields", "field %s cannot be updated", field)
+		}
 	}
 	return nil
 }
@@ -551,11 +1778,85 @@ type RemoveFromListNodeConfig struct {
 // Validate validates the remove-from-list node config
 func (c RemoveFromListNodeConfig) Validate() error {
 	if c.ListID == "" {
-		return fmt.Errorf("list_id is required")
+		return newNodeConfigFieldError("list_id", "list_id is required")
+	}
+	return nil
+}
+
+// UnsubscribeAllNodeConfig configures an unsubscribe-all node.
+// Status "unsubscribed" marks every active membership as unsubscribed,
+// "removed" removes the contact from those lists entirely.
+type UnsubscribeAllNodeConfig struct {
+	Status string `json:"status"` // "unsubscribed", "removed"
+}
+
+// UnsubscribeAllStatusRemoved removes the contact from its lists instead of unsubscribing it
+const UnsubscribeAllStatusRemoved = "removed"
+
+// Validate validates the unsubscribe-all node config
+func (c UnsubscribeAllNodeConfig) Validate() error {
+	if c.Status != string(ContactListStatusUnsubscribed) && c.Status != UnsubscribeAllStatusRemoved {
+		return newNodeConfigFieldError("status", "invalid status: %s (must be %s or %s)", c.Status, ContactListStatusUnsubscribed, UnsubscribeAllStatusRemoved)
+	}
+	return nil
+}
+
+// EnrollInAutomationNodeConfig configures a node that enrolls the contact into another automation.
+// The target automation's trigger frequency applies, as with manual enrollment.
+type EnrollInAutomationNodeConfig struct {
+	AutomationID string `json:"automation_id"`
+}
+
+// Validate validates the enroll-in-automation node config
+func (c EnrollInAutomationNodeConfig) Validate() error {
+	if c.AutomationID == "" {
+		return newNodeConfigFieldError("automation_id", "automation_id is required")
+	}
+	return nil
+}
+
+// RecordRevenueNodeConfig configures a node recording a conversion value for the contact.
+// The amount is either a literal or read from a contact field: custom_number_1..5, or a
+// path inside a custom JSON field such as "custom_json_1.order.total".
+type RecordRevenueNodeConfig struct {
+	Amount      *float64 `json:"amount,omitempty"`
+	AmountField string   `json:"amount_field,omitempty"`
+	Currency    string   `json:"currency"` // ISO 4217 code, e.g. "USD"
+}
+
+// Validate validates the record-revenue node config
+func (c RecordRevenueNodeConfig) Validate() error {
+	if (c.Amount == nil) == (c.AmountField == "") {
+		return newNodeConfigFieldError("amount", "exactly one of amount or amount_field is required")
+	}
+	if c.Amount != nil && *c.Amount < 0 {
+		return newNodeConfigFieldError("amount", "amount must not be negative")
+	}
+	if c.AmountField != "" && !isRevenueAmountField(c.AmountField) {
+		return newNodeConfigFieldError("amount_field", "invalid amount_field: %s (must be a custom_number field or a path in a custom_json field)", c.AmountField)
+	}
+	if !currencyCodeRegex.MatchString(c.Currency) {
+		return newNodeConfigFieldError("currency", "invalid currency: %s (must be a 3-letter ISO 4217 code)", c.Currency)
 	}
 	return nil
 }
 
+var currencyCodeRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// isRevenueAmountField reports whether field names a numeric custom field or a path inside a custom JSON field
+func isRevenueAmountField(field string) bool {
+	root, path, hasPath := strings.Cut(field, ".")
+	for i := 1; i <= 5; i++ {
+		if !hasPath && root == fmt.Sprintf("custom_number_%d", i) {
+			return true
+		}
+		if hasPath && path != "" && root == fmt.Sprintf("custom_json_%d", i) {
+			return true
+		}
+	}
+	return false
+}
+
 // ListStatusBranchNodeConfig configures a list status branch node
 // This node checks a contact's subscription status in a list and branches accordingly
 type ListStatusBranchNodeConfig struct {
@@ -568,10 +1869,233 @@ type ListStatusBranchNodeConfig struct {
 // Validate validates the list status branch node config
 func (c ListStatusBranchNodeConfig) Validate() error {
 	if c.ListID == "" {
-		return fmt.Errorf("list_id is required")
+		return newNodeConfigFieldError("list_id", "list_id is required")
+	}
+	if c.NotInListNodeID == "" && c.ActiveNodeID == "" && c.NonActiveNodeID == "" {
+		return newNodeConfigFieldError("", "at least one branch must have a target node")
+	}
+	return nil
+}
+
+// SuppressionBranchNodeConfig configures a suppression branch node
+// This node checks whether the contact's address is on the workspace suppression list and branches accordingly
+type SuppressionBranchNodeConfig struct {
+	SuppressedNodeID    string `json:"suppressed_node_id"`     // Next node when the address is suppressed
+	NotSuppressedNodeID string `json:"not_suppressed_node_id"` // Next node when the address is not suppressed
+}
+
+// Validate validates the suppression branch node config
+func (c SuppressionBranchNodeConfig) Validate() error {
+	if c.SuppressedNodeID == "" && c.NotSuppressedNodeID == "" {
+		return newNodeConfigFieldError("", "at least one branch must have a target node")
+	}
+	return nil
+}
+
+// ExpressionBranchNodeConfig configures an expression branch node
+// This node renders a Liquid boolean expression, e.g. "{{ event.amount > 100 }}", and branches on the result
+type ExpressionBranchNodeConfig struct {
+	Expression  string `json:"expression"`    // Liquid expression, either "{{ condition }}" or markup rendering "true"
+	TrueNodeID  string `json:"true_node_id"`  // Next node when the expression is true
+	FalseNodeID string `json:"false_node_id"` // Next node when the expression is false
+}
+
+// Validate validates the expression branch node config
+func (c ExpressionBranchNodeConfig) Validate() error {
+	if strings.TrimSpace(c.Expression) == "" {
+		return newNodeConfigFieldError("expression", "expression is required")
+	}
+	if c.TrueNodeID == "" && c.FalseNodeID == "" {
+		return newNodeConfigFieldError("", "at least one branch must have a target node")
+	}
+	return nil
+}
+
+// WaitForListStatusNodeConfig configures a wait for list status node
+// The contact waits until it reaches the target status in a list, re-checked on scheduler ticks,
+// or takes the timeout path when the status is not reached in time
+type WaitForListStatusNodeConfig struct {
+	ListID        string            `json:"list_id"`         // List to watch
+	TargetStatus  ContactListStatus `json:"target_status"`   // Status that ends the wait, e.g. "active"
+	Timeout       int               `json:"timeout"`         // How long to wait for the status
+	TimeoutUnit   string            `json:"timeout_unit"`    // "minutes", "hours", "days"
+	MatchedNodeID string            `json:"matched_node_id"` // Next node when the target status is reached
+	TimeoutNodeID string            `json:"timeout_node_id"` // Next node when the timeout elapses
+}
+
+// Validate validates the wait for list status node config
+func (c WaitForListStatusNodeConfig) Validate() error {
+	if c.ListID == "" {
+		return newNodeConfigFieldError("list_id", "list_id is required")
+	}
+
+	switch c.TargetStatus {
+	case ContactListStatusActive, ContactListStatusPending, ContactListStatusUnsubscribed,
+		ContactListStatusBounced, ContactListStatusComplained:
+	default:
+		return newNodeConfigFieldError("target_status", "invalid target_status: %s", c.TargetStatus)
+	}
+
+	if c.Timeout <= 0 {
+		return newNodeConfigFieldError("timeout", "timeout must be positive")
+	}
+
+	switch c.TimeoutUnit {
+	case "minutes", "hours", "days":
+		return nil
+	default:
+		return newNodeConfigFieldError("timeout_unit", "invalid timeout_unit: %s (must be minutes, hours, or days)", c.TimeoutUnit)
+	}
+}
+
+// waitUntilDatetimeLayouts are the accepted formats for a wait until datetime without a UTC offset
+var waitUntilDatetimeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04"}
+
+// WaitUntilDatetimeNodeConfig configures a wait until datetime node
+// The contact waits until a fixed calendar moment (e.g. a product launch). Contacts reaching the
+// node after that moment pass through immediately
+type WaitUntilDatetimeNodeConfig struct {
+	Datetime string `json:"datetime"`           // e.g. "2026-11-01T09:00" or RFC 3339 with an offset
+	Timezone string `json:"timezone,omitempty"` // IANA timezone for a datetime without offset, defaults to UTC
+}
+
+// Validate validates the wait until datetime node config
+func (c WaitUntilDatetimeNodeConfig) Validate() error {
+	if c.Datetime == "" {
+		return newNodeConfigFieldError("datetime", "datetime is required")
+	}
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			return newNodeConfigFieldError("timezone", "invalid timezone: %s", c.Timezone)
+		}
+	}
+	if _, err := c.ResolveTime(); err != nil {
+		return newNodeConfigFieldError("datetime", "%v", err)
+	}
+	return nil
+}
+
+// ResolveTime returns the moment the wait ends. A datetime with a UTC offset is used as is,
+// otherwise it is read in the configured timezone
+func (c WaitUntilDatetimeNodeConfig) ResolveTime() (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, c.Datetime); err == nil {
+		return t.UTC(), nil
+	}
+
+	location := time.UTC
+	if c.Timezone != "" {
+		loc, err := time.LoadLocation(c.Timezone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid timezone: %s", c.Timezone)
+		}
+		location = loc
+	}
+
+	for _, layout := range waitUntilDatetimeLayouts {
+		if t, err := time.ParseInLocation(layout, c.Datetime, location); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid datetime: %s (must be YYYY-MM-DDTHH:MM[:SS] or RFC 3339)", c.Datetime)
+}
+
+// DefaultWaitUntilTimezoneField is the contact field read for the contact's timezone
+const DefaultWaitUntilTimezoneField = "timezone"
+
+// waitUntilWeekdays maps the days_of_week values to weekdays
+var waitUntilWeekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// WaitUntilNodeConfig configures a wait until node
+// The contact waits until the next matching day and time of day in its own timezone, e.g. the
+// next weekday at 9:00, so emails go out during business hours wherever the contact lives
+type WaitUntilNodeConfig struct {
+	DaysOfWeek    []string `json:"days_of_week"`             // "monday" to "sunday", empty allows every day
+	Hour          int      `json:"hour"`                     // 0-23
+	Minute        int      `json:"minute"`                   // 0-59
+	TimezoneField string   `json:"timezone_field,omitempty"` // Contact field holding the IANA timezone, defaults to "timezone"
+}
+
+// Validate validates the wait until node config
+func (c WaitUntilNodeConfig) Validate() error {
+	for _, day := range c.DaysOfWeek {
+		if _, ok := waitUntilWeekdays[day]; !ok {
+			return newNodeConfigFieldError("days_of_week", "invalid day of week: %s (must be monday to sunday)", day)
+		}
+	}
+	if c.Hour < 0 || c.Hour > 23 {
+		return newNodeConfigFieldError("hour", "hour must be between 0 and 23")
+	}
+	if c.Minute < 0 || c.Minute > 59 {
+		return newNodeConfigFieldError("minute", "minute must be between 0 and 59")
+	}
+	return nil
+}
+
+// GetTimezoneField returns the contact field holding the timezone
+func (c WaitUntilNodeConfig) GetTimezoneField() string {
+	if c.TimezoneField == "" {
+		return DefaultWaitUntilTimezoneField
+	}
+	return c.TimezoneField
+}
+
+// NextTime returns the first moment at or after now that falls on an allowed day at the
+// configured time of day in the given location. A time already past today rolls over to
+// the next allowed day.
+func (c WaitUntilNodeConfig) NextTime(now time.Time, location *time.Location) time.Time {
+	allowed := make(map[time.Weekday]bool, len(c.DaysOfWeek))
+	for _, day := range c.DaysOfWeek {
+		allowed[waitUntilWeekdays[day]] = true
+	}
+
+	local := now.In(location)
+	for offset := 0; offset <= 7; offset++ {
+		candidate := time.Date(local.Year(), local.Month(), local.Day()+offset, c.Hour, c.Minute, 0, 0, location)
+		if candidate.Before(now) {
+			continue
+		}
+		if len(allowed) == 0 || allowed[candidate.Weekday()] {
+			return candidate.UTC()
+		}
+	}
+	// Unreachable: every weekday comes up within a week
+	return now.UTC()
+}
+
+// MaxForEachItems caps how many elements a for_each node iterates over
+const MaxForEachItems = 100
+
+// forEachItemsPathRegex matches a dot path such as "context.order.items" or "nodes.webhook1.response.items"
+var forEachItemsPathRegex = regexp.MustCompile(`^[A-Za-z0-9_\-]+(\.[A-Za-z0-9_\-]+)*$`)
+
+// ForEachNodeConfig configures a for_each node
+// The node runs the sub-flow starting at SubFlowNodeID once per element of the array found at
+// ItemsPath, with the element bound to `item`, then continues to its next node. The path is
+// resolved against `context` (enrollment context), `contact`, `global_feed` and `nodes`
+// (outputs of previous nodes, keyed by node ID)
+type ForEachNodeConfig struct {
+	ItemsPath     string `json:"items_path"`
+	SubFlowNodeID string `json:"sub_flow_node_id"`
+}
+
+// Validate validates the for_each node config
+func (c ForEachNodeConfig) Validate() error {
+	if c.ItemsPath == "" {
+		return newNodeConfigFieldError("items_path", "items_path is required")
 	}
-	if c.NotInListNodeID == "" && c.ActiveNodeID == "" && c.NonActiveNodeID == "" {
-		return fmt.Errorf("at least one branch must have a target node")
+	if !forEachItemsPathRegex.MatchString(c.ItemsPath) {
+		return newNodeConfigFieldError("items_path", "invalid items_path: %s (must be a dot path such as context.items)", c.ItemsPath)
+	}
+	if c.SubFlowNodeID == "" {
+		return newNodeConfigFieldError("sub_flow_node_id", "sub_flow_node_id is required")
 	}
 	return nil
 }
@@ -579,7 +2103,7 @@ func (c ListStatusBranchNodeConfig) Validate() error {
 // ABTestVariant represents a variant in an A/B test node
 type ABTestVariant struct {
 	ID         string `json:"id"`           // "A", "B", etc.
-	Name       string `json:"name"`         // "Control", "Variant B", etc.
+	Name       string `json:"name"`         // "Control", "Variant B", etc. May contain Liquid
 	Weight     int    `json:"weight"`       // 1-100
 	NextNodeID string `json:"next_node_id"` // Node to execute for this variant
 }
@@ -587,16 +2111,16 @@ type ABTestVariant struct {
 // Validate validates the A/B test variant
 func (v ABTestVariant) Validate() error {
 	if v.ID == "" {
-		return fmt.Errorf("variant id is required")
+		return newNodeConfigFieldError("id", "variant id is required")
 	}
 	if v.Name == "" {
-		return fmt.Errorf("variant name is required")
+		return newNodeConfigFieldError("name", "variant name is required")
 	}
 	if v.Weight < 1 || v.Weight > 100 {
-		return fmt.Errorf("variant weight must be between 1 and 100")
+		return newNodeConfigFieldError("weight", "variant weight must be between 1 and 100")
 	}
 	if v.NextNodeID == "" {
-		return fmt.Errorf("variant next_node_id is required")
+		return newNodeConfigFieldError("next_node_id", "variant next_node_id is required")
 	}
 	return nil
 }
@@ -609,7 +2133,7 @@ type ABTestNodeConfig struct {
 // Validate validates the A/B test node config
 func (c ABTestNodeConfig) Validate() error {
 	if len(c.Variants) < 2 {
-		return fmt.Errorf("at least 2 variants are required for A/B test")
+		return newNodeConfigFieldError("variants", "at least 2 variants are required for A/B test")
 	}
 
 	totalWeight := 0
@@ -617,45 +2141,521 @@ func (c ABTestNodeConfig) Validate() error {
 
 	for i, v := range c.Variants {
 		if err := v.Validate(); err != nil {
-			return fmt.Errorf("variant %d: %w", i, err)
+			return nestNodeConfigFieldError(fmt.Sprintf("variants[%d]", i), fmt.Sprintf("variant %d", i), err)
 		}
 		if seenIDs[v.ID] {
-			return fmt.Errorf("duplicate variant id: %s", v.ID)
+			return newNodeConfigFieldError(fmt.Sprintf("variants[%d].id", i), "duplicate variant id: %s", v.ID)
 		}
 		seenIDs[v.ID] = true
 		totalWeight += v.Weight
 	}
 
 	if totalWeight != 100 {
-		return fmt.Errorf("variant weights must sum to 100, got %d", totalWeight)
+		return newNodeConfigFieldError("variants", "variant weights must sum to 100, got %d", totalWeight)
 	}
 
 	return nil
 }
 
+// WebhookContentType selects how a webhook node encodes its payload
+type WebhookContentType string
+
+const (
+	WebhookContentTypeJSON WebhookContentType = "json" // application/json (default)
+	WebhookContentTypeForm WebhookContentType = "form" // application/x-www-form-urlencoded
+)
+
 // WebhookNodeConfig configures a webhook node
 type WebhookNodeConfig struct {
-	URL    string  `json:"url"`
-	Secret *string `json:"secret,omitempty"` // Optional: becomes Authorization: Bearer <secret>
+	URL         string             `json:"url"`
+	Secret      *string            `json:"secret,omitempty"`       // Optional: becomes Authorization: Bearer <secret>
+	MTLS        *WebhookMTLSConfig `json:"mtls,omitempty"`         // Optional: client certificate presented over TLS
+	ContentType WebhookContentType `json:"content_type,omitempty"` // Optional: json (default) or form
+	// OnResponseBranches route the contact on the parsed response, e.g. a fraud score. The
+	// first branch whose conditions all match wins, otherwise next_node_id is used.
+	OnResponseBranches []WebhookResponseBranch `json:"on_response_branches,omitempty"`
+	// Headers are sent with the request, e.g. an API key or a correlation ID
+	Headers []WebhookHeader `json:"headers,omitempty"`
+	// ResponseMapping stores the response in the contact's automation context for later nodes
+	ResponseMapping *WebhookResponseMapping `json:"response_mapping,omitempty"`
+}
+
+// webhookHeaderNameRegex matches an HTTP header field name (RFC 7230 token)
+var webhookHeaderNameRegex = regexp.MustCompile("^[!#$%&'*+\\-.^_`|~0-9A-Za-z]+$")
+
+// webhookReservedHeaders are set by the webhook node itself and cannot be overridden
+var webhookReservedHeaders = map[string]bool{
+	"content-type":   true,
+	"content-length": true,
+	"host":           true,
+}
+
+// WebhookHeader is a custom header sent by a webhook node
+type WebhookHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"` // May contain Liquid, rendered per contact
+}
+
+// Validate validates the webhook header
+func (h WebhookHeader) Validate() error {
+	if h.Name == "" {
+		return newNodeConfigFieldError("name", "name is required")
+	}
+	if !webhookHeaderNameRegex.MatchString(h.Name) {
+		return newNodeConfigFieldError("name", "invalid header name: %q", h.Name)
+	}
+	if webhookReservedHeaders[strings.ToLower(h.Name)] {
+		return newNodeConfigFieldError("name", "header %s is set by the webhook node", h.Name)
+	}
+	if err := ValidateWebhookHeaderValue(h.Value); err != nil {
+		return newNodeConfigFieldError("value", "%s", err.Error())
+	}
+	return nil
+}
+
+// ValidateWebhookHeaderValue rejects header values that could inject extra headers
+func ValidateWebhookHeaderValue(value string) error {
+	if strings.ContainsAny(value, "\r\n\x00") {
+		return fmt.Errorf("header value cannot contain line breaks or null bytes")
+	}
+	return nil
 }
 
 // Validate validates the webhook node config
 func (c WebhookNodeConfig) Validate() error {
 	if c.URL == "" {
-		return fmt.Errorf("url is required")
+		return newNodeConfigFieldError("url", "url is required")
 	}
 	// Basic URL validation - check it's not empty and has valid scheme
 	if !strings.HasPrefix(c.URL, "http://") && !strings.HasPrefix(c.URL, "https://") {
-		return fmt.Errorf("url must start with http:// or https://")
+		return newNodeConfigFieldError("url", "url must start with http:// or https://")
+	}
+	switch c.ContentType {
+	case "", WebhookContentTypeJSON, WebhookContentTypeForm:
+	default:
+		return newNodeConfigFieldError("content_type", "content_type must be json or form")
+	}
+	if c.MTLS != nil {
+		if !strings.HasPrefix(c.URL, "https://") {
+			return newNodeConfigFieldError("url", "url must use https:// when mtls is configured")
+		}
+		if err := c.MTLS.Validate(); err != nil {
+			return nestNodeConfigFieldError("mtls", "", err)
+		}
+	}
+	seenHeaders := make(map[string]bool)
+	for i, header := range c.Headers {
+		if err := header.Validate(); err != nil {
+			return nestNodeConfigFieldError(fmt.Sprintf("headers[%d]", i), fmt.Sprintf("header %d", i), err)
+		}
+		name := strings.ToLower(header.Name)
+		if name == "authorization" && c.Secret != nil && *c.Secret != "" {
+			return newNodeConfigFieldError(fmt.Sprintf("headers[%d].name", i), "authorization header cannot be combined with secret")
+		}
+		if seenHeaders[name] {
+			return newNodeConfigFieldError(fmt.Sprintf("headers[%d].name", i), "duplicate header: %s", header.Name)
+		}
+		seenHeaders[name] = true
+	}
+	if c.ResponseMapping != nil {
+		if err := c.ResponseMapping.Validate(); err != nil {
+			return nestNodeConfigFieldError("response_mapping", "", err)
+		}
+	}
+	seenIDs := make(map[string]bool)
+	for i, branch := range c.OnResponseBranches {
+		if err := branch.Validate(); err != nil {
+			return nestNodeConfigFieldError(fmt.Sprintf("on_response_branches[%d]", i), fmt.Sprintf("response branch %d", i), err)
+		}
+		if seenIDs[branch.ID] {
+			return newNodeConfigFieldError(fmt.Sprintf("on_response_branches[%d].id", i), "duplicate response branch id: %s", branch.ID)
+		}
+		seenIDs[branch.ID] = true
+	}
+	return nil
+}
+
+// SlackNodeConfig configures a slack node, which posts a message to a Slack incoming webhook
+type SlackNodeConfig struct {
+	WebhookURL string `json:"webhook_url"`
+	Text       string `json:"text"` // May contain Liquid, e.g. "New signup: {{ contact.email }}"
+}
+
+// Validate validates the slack node config
+func (c SlackNodeConfig) Validate() error {
+	if c.WebhookURL == "" {
+		return newNodeConfigFieldError("webhook_url", "webhook_url is required")
+	}
+	// Use SSRF-safe URL validation
+	if err := ValidateFeedURL(c.WebhookURL); err != nil {
+		return newNodeConfigFieldError("webhook_url", "webhook_url: %s", err.Error())
+	}
+	if strings.TrimSpace(c.Text) == "" {
+		return newNodeConfigFieldError("text", "text is required")
+	}
+	return nil
+}
+
+// WebhookResponseBranch sends the contact to NextNodeID when all its conditions match the
+// webhook response
+type WebhookResponseBranch struct {
+	ID         string                     `json:"id"`
+	Name       string                     `json:"name,omitempty"` // May contain Liquid, rendered per contact when recorded
+	Conditions []WebhookResponseCondition `json:"conditions"`
+	NextNodeID string                     `json:"next_node_id"`
+}
+
+// Validate validates the response branch
+func (b WebhookResponseBranch) Validate() error {
+	if b.ID == "" {
+		return newNodeConfigFieldError("id", "id is required")
+	}
+	if len(b.Conditions) == 0 {
+		return newNodeConfigFieldError("conditions", "at least one condition is required")
+	}
+	for i, condition := range b.Conditions {
+		if err := condition.Validate(); err != nil {
+			return nestNodeConfigFieldError(fmt.Sprintf("conditions[%d]", i), fmt.Sprintf("condition %d", i), err)
+		}
+	}
+	if b.NextNodeID == "" {
+		return newNodeConfigFieldError("next_node_id", "next_node_id is required")
+	}
+	return nil
+}
+
+// Matches reports whether every condition of the branch holds for the response
+func (b WebhookResponseBranch) Matches(response map[string]interface{}) bool {
+	for _, condition := range b.Conditions {
+		if !condition.Matches(response) {
+			return false
+		}
+	}
+	return true
+}
+
+// WebhookResponseOperator compares a webhook response field with a condition value
+type WebhookResponseOperator string
+
+const (
+	WebhookResponseOperatorEquals    WebhookResponseOperator = "equals"
+	WebhookResponseOperatorNotEquals WebhookResponseOperator = "not_equals"
+	WebhookResponseOperatorGT        WebhookResponseOperator = "gt"
+	WebhookResponseOperatorGTE       WebhookResponseOperator = "gte"
+	WebhookResponseOperatorLT        WebhookResponseOperator = "lt"
+	WebhookResponseOperatorLTE       WebhookResponseOperator = "lte"
+	WebhookResponseOperatorContains  WebhookResponseOperator = "contains" // Substring of a string, or element of an array
+	WebhookResponseOperatorExists    WebhookResponseOperator = "exists"   // Field is present and not null
+)
+
+// WebhookResponseCondition tests one field of the parsed webhook response
+type WebhookResponseCondition struct {
+	Field    string                  `json:"field"` // Dot path in the JSON response, e.g. "risk" or "result.score"
+	Operator WebhookResponseOperator `json:"operator"`
+	Value    interface{}             `json:"value,omitempty"` // Unused by exists
+}
+
+// Validate validates the response condition
+func (c WebhookResponseCondition) Validate() error {
+	if c.Field == "" {
+		return newNodeConfigFieldError("field", "field is required")
+	}
+	switch c.Operator {
+	case WebhookResponseOperatorExists:
+		return nil
+	case WebhookResponseOperatorEquals, WebhookResponseOperatorNotEquals, WebhookResponseOperatorContains:
+		if c.Value == nil {
+			return newNodeConfigFieldError("value", "value is required for operator %s", c.Operator)
+		}
+		return nil
+	case WebhookResponseOperatorGT, WebhookResponseOperatorGTE, WebhookResponseOperatorLT, WebhookResponseOperatorLTE:
+		if _, ok := webhookResponseNumber(c.Value); !ok {
+			return newNodeConfigFieldError("value", "value must be a number for operator %s", c.Operator)
+		}
+		return nil
+	default:
+		return newNodeConfigFieldError("operator", "invalid operator: %s", c.Operator)
+	}
+}
+
+// Matches evaluates the condition against the response. A missing field only matches
+// not_equals.
+func (c WebhookResponseCondition) Matches(response map[string]interface{}) bool {
+	actual, found := webhookResponseField(response, c.Field)
+	if !found || actual == nil {
+		return c.Operator == WebhookResponseOperatorNotEquals
+	}
+
+	switch c.Operator {
+	case WebhookResponseOperatorExists:
+		return true
+	case WebhookResponseOperatorEquals:
+		return webhookResponseValuesEqual(actual, c.Value)
+	case WebhookResponseOperatorNotEquals:
+		return !webhookResponseValuesEqual(actual, c.Value)
+	case WebhookResponseOperatorContains:
+		switch v := actual.(type) {
+		case string:
+			expected, ok := c.Value.(string)
+			return ok && strings.Contains(v, expected)
+		case []interface{}:
+			for _, item := range v {
+				if webhookResponseValuesEqual(item, c.Value) {
+					return true
+				}
+			}
+		}
+		return false
+	case WebhookResponseOperatorGT, WebhookResponseOperatorGTE, WebhookResponseOperatorLT, WebhookResponseOperatorLTE:
+		a, okA := webhookResponseNumber(actual)
+		b, okB := webhookResponseNumber(c.Value)
+		if !okA || !okB {
+			return false
+		}
+		switch c.Operator {
+		case WebhookResponseOperatorGT:
+			return a > b
+		case WebhookResponseOperatorGTE:
+			return a >= b
+		case WebhookResponseOperatorLT:
+			return a < b
+		default:
+			return a <= b
+		}
+	}
+	return false
+}
+
+// webhookResponseField resolves a dot path in a parsed JSON response
+func webhookResponseField(response map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{} = response
+	for _, key := range strings.Split(path, ".") {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = fields[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// webhookResponseValuesEqual compares numbers numerically and other values by their text,
+// so "high" equals "high" and 1 equals 1.0
+func webhookResponseValuesEqual(a, b interface{}) bool {
+	numA, okA := webhookResponseNumber(a)
+	numB, okB := webhookResponseNumber(b)
+	if okA && okB {
+		return numA == numB
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+// webhookResponseNumber converts JSON (float64) and Go numeric values to float64
+func webhookResponseNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// WebhookResponseMaxStoredBytes caps the size of a webhook response stored by a response mapping
+const WebhookResponseMaxStoredBytes = 64 * 1024
+
+// webhookSaveAsRegex matches a context key usable as a Liquid variable
+var webhookSaveAsRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// webhookReservedSaveAs are the context keys and Liquid variables set by Notifuse itself
+var webhookReservedSaveAs = map[string]bool{
+	ContactAutomationContextBroadcastID: true,
+	ContactAutomationContextGlobalFeed:  true,
+	"automation_id":                     true,
+	"automation_name":                   true,
+	"contact":                           true,
+	"context":                           true,
+	"item":                              true,
+	"item_index":                        true,
+	"nodes":                             true,
+}
+
+// WebhookResponseMapping stores the webhook response, or the part of it selected by JSONPath,
+// in the contact's automation context under SaveAs, so later nodes can reference it,
+// e.g. {"save_as": "enrichment", "json_path": "$.score"} makes {{ enrichment }} the score
+type WebhookResponseMapping struct {
+	SaveAs   string `json:"save_as"`
+	JSONPath string `json:"json_path,omitempty"` // e.g. "$.score" or "$.results[0].id", empty or "$" for the whole response
+}
+
+// Validate validates the response mapping
+func (m WebhookResponseMapping) Validate() error {
+	if m.SaveAs == "" {
+		return newNodeConfigFieldError("save_as", "save_as is required")
+	}
+	if !webhookSaveAsRegex.MatchString(m.SaveAs) {
+		return newNodeConfigFieldError("save_as", "save_as must start with a letter or underscore and contain only letters, digits and underscores")
+	}
+	if webhookReservedSaveAs[m.SaveAs] {
+		return newNodeConfigFieldError("save_as", "save_as cannot be %s, it is reserved", m.SaveAs)
+	}
+	if _, err := parseWebhookJSONPath(m.JSONPath); err != nil {
+		return newNodeConfigFieldError("json_path", "invalid json_path: %s", err.Error())
+	}
+	return nil
+}
+
+// Extract returns the value to store for a response body. A body that is not JSON is stored
+// as the raw string and a path that is not found in the response yields nil. Values larger
+// than WebhookResponseMaxStoredBytes are stored as their JSON text truncated to that size.
+func (m WebhookResponseMapping) Extract(body []byte) interface{} {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return truncateWebhookStoredValue(string(body))
+	}
+
+	segments, _ := parseWebhookJSONPath(m.JSONPath)
+	for _, segment := range segments {
+		switch key := segment.(type) {
+		case string:
+			fields, ok := value.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			if value, ok = fields[key]; !ok {
+				return nil
+			}
+		case int:
+			items, ok := value.([]interface{})
+			if !ok || key >= len(items) {
+				return nil
+			}
+			value = items[key]
+		}
+	}
+
+	if text, ok := value.(string); ok {
+		return truncateWebhookStoredValue(text)
+	}
+	encoded, err := json.Marshal(value)
+	if err == nil && len(encoded) > WebhookResponseMaxStoredBytes {
+		return truncateWebhookStoredValue(string(encoded))
+	}
+	return value
+}
+
+// truncateWebhookStoredValue cuts a string to WebhookResponseMaxStoredBytes, dropping a
+// multi-byte character cut in half at the limit
+func truncateWebhookStoredValue(text string) string {
+	if len(text) <= WebhookResponseMaxStoredBytes {
+		return text
+	}
+	return strings.ToValidUTF8(text[:WebhookResponseMaxStoredBytes], "")
+}
+
+// parseWebhookJSONPath splits a JSON path made of "$", ".key" and "[index]" steps into
+// object keys (string) and array indexes (int)
+func parseWebhookJSONPath(path string) ([]interface{}, error) {
+	if path == "" || path == "$" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("must start with $")
+	}
+
+	var segments []interface{}
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			key := rest[1:]
+			if end >= 0 {
+				key = rest[1 : end+1]
+			}
+			if key == "" {
+				return nil, fmt.Errorf("empty key in %s", path)
+			}
+			segments = append(segments, key)
+			rest = rest[1+len(key):]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed [ in %s", path)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid array index %q in %s", rest[1:end], path)
+			}
+			segments = append(segments, index)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q in %s", rest[0], path)
+		}
+	}
+	return segments, nil
+}
+
+// WebhookMTLSConfig holds the PEM-encoded client certificate and key used for mutual TLS
+type WebhookMTLSConfig struct {
+	ClientCert         string `json:"client_cert"`                    // PEM certificate, optionally followed by intermediates
+	ClientKey          string `json:"client_key,omitempty"`           // PEM private key matching the certificate
+	EncryptedClientKey string `json:"encrypted_client_key,omitempty"` // Stored form of the key, encrypted with the workspace secret key
+}
+
+// Validate checks that the certificate and key are present and form a valid pair
+func (c *WebhookMTLSConfig) Validate() error {
+	if c.ClientCert == "" {
+		return newNodeConfigFieldError("client_cert", "mtls client_cert is required")
+	}
+	// A stored key was checked against the certificate when the automation was saved
+	if c.ClientKey == "" && c.EncryptedClientKey != "" {
+		return nil
+	}
+	if c.ClientKey == "" {
+		return newNodeConfigFieldError("client_key", "mtls client_key is required")
+	}
+	if _, err := c.Certificate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecryptClientKey decrypts the stored client key with the workspace secret key
+func (c *WebhookMTLSConfig) DecryptClientKey(passphrase string) error {
+	key, err := crypto.DecryptFromHexString(c.EncryptedClientKey, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt mtls client key: %w", err)
 	}
+	c.ClientKey = key
 	return nil
 }
 
+// Certificate parses the PEM-encoded pair into a tls.Certificate
+func (c *WebhookMTLSConfig) Certificate() (tls.Certificate, error) {
+	cert, err := tls.X509KeyPair([]byte(c.ClientCert), []byte(c.ClientKey))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("invalid mtls client certificate/key pair: %w", err)
+	}
+	return cert, nil
+}
+
 // AutomationFilter defines filtering options for listing automations
 type AutomationFilter struct {
 	Status         []AutomationStatus
 	ListID         string
-	IncludeDeleted bool // When true, includes soft-deleted automations in results
+	Tag            string // When set, only automations carrying this tag are returned
+	IncludeDeleted bool   // When true, includes soft-deleted automations in results
 	Limit          int
 	Offset         int
 }
@@ -670,6 +2670,24 @@ type ContactAutomationFilter struct {
 	Offset       int
 }
 
+// GoalContactFilter selects the contacts waiting at a node (scheduled in the future) of an
+// automation version that meet a goal, paginated by contact automation ID
+type GoalContactFilter struct {
+	AutomationID string
+	// Version of the workflow the contacts run; contacts that are not pinned to a version
+	// run CurrentVersion
+	Version        int
+	CurrentVersion int
+	// PassedNodeID restricts a branch goal to the contacts that passed through it
+	PassedNodeID string
+	// ContactsSQL selects the emails of the contacts meeting the goal conditions
+	// ("SELECT email FROM contacts WHERE ...", numbered placeholders bound to ContactsArgs)
+	ContactsSQL  string
+	ContactsArgs []interface{}
+	AfterID      string
+	Limit        int
+}
+
 // AutomationRepository defines the interface for automation persistence
 type AutomationRepository interface {
 	// Transaction support
@@ -686,6 +2704,13 @@ type AutomationRepository interface {
 	Delete(ctx context.Context, workspaceID, id string) error
 	DeleteTx(ctx context.Context, tx *sql.Tx, workspaceID, id string) error
 
+	// Workflow versions (created by Create/Update whenever the workflow changes)
+	GetVersion(ctx context.Context, workspaceID, automationID string, version int) (*AutomationVersion, error)
+
+	// Tags
+	UpdateTags(ctx context.Context, workspaceID, id string, tags []string) error
+	ListTags(ctx context.Context, workspaceID string) ([]string, error)
+
 	// Trigger management (dynamic SQL execution)
 	CreateAutomationTrigger(ctx context.Context, workspaceID string, automation *Automation) error
 	DropAutomationTrigger(ctx context.Context, workspaceID, automationID string) error
@@ -709,10 +2734,41 @@ type AutomationRepository interface {
 	UpdateNodeExecution(ctx context.Context, workspaceID string, entry *NodeExecution) error
 	UpdateNodeExecutionTx(ctx context.Context, tx *sql.Tx, workspaceID string, entry *NodeExecution) error
 
+	// Manual enrollment (applies the trigger frequency like the automation trigger does)
+	EnrollContact(ctx context.Context, workspaceID string, automation *Automation, email string) (bool, error)
+
+	// Reenroll nodes: exits the contact's active run with ReenrolledExitReason and enrolls the contact
+	// again at the root node, unless it was already re-enrolled maxReenrollments times
+	ReenrollContactAutomation(ctx context.Context, workspaceID string, automation *Automation, contactAutomationID, email string, maxReenrollments int) (bool, error)
+
+	// Segment members for re-enrollment (paginated by email)
+	GetSegmentContactEmails(ctx context.Context, workspaceID, segmentID, afterEmail string, limit int) ([]string, error)
+
+	// Trigger log (lets a contact enroll again in a "once" automation)
+	ClearTriggerLog(ctx context.Context, workspaceID, automationID, email string) error
+
+	// Inactivity triggers (contacts without activity since inactiveSince, paginated by email)
+	GetInactiveContactEmails(ctx context.Context, workspaceID string, automation *Automation, inactiveSince time.Time, afterEmail string, limit int) ([]string, error)
+
+	// Goals (contacts waiting at a node are checked set-based, per automation version and goal)
+	ListWaitingVersions(ctx context.Context, workspaceID, automationID string, currentVersion int) ([]int, error)
+	ListContactAutomationsMeetingGoal(ctx context.Context, workspaceID string, filter GoalContactFilter) ([]*ContactAutomation, error)
+
+	// Retention (deletes completed/exited contact automations finished before the cutoff, up to limit rows)
+	DeleteFinishedContactAutomations(ctx context.Context, workspaceID string, before time.Time, limit int) (int64, error)
+
 	// Stats
 	UpdateAutomationStats(ctx context.Context, workspaceID, automationID string, stats *AutomationStats) error
 	UpdateAutomationStatsTx(ctx context.Context, tx *sql.Tx, workspaceID, automationID string, stats *AutomationStats) error
 	IncrementAutomationStat(ctx context.Context, workspaceID, automationID, statName string) error
+	RecordNodeExecutionStats(ctx context.Context, workspaceID, automationID string, nodes map[string]*AutomationNodeExecutionStats) error
+
+	// Revenue
+	RecordRevenue(ctx context.Context, workspaceID string, revenue *AutomationRevenue) error
+
+	// Throttle nodes: reserves a pass for one contact in the first minute, from now on, that has
+	// fewer than maxPerMinute reservations, and returns the start of that minute
+	ReserveThrottleSlot(ctx context.Context, workspaceID, automationID, nodeID string, maxPerMinute int, now time.Time) (time.Time, error)
 }
 
 //go:generate mockgen -destination mocks/mock_automation_service.go -package mocks github.com/Notifuse/notifuse/internal/domain AutomationService
@@ -726,20 +2782,36 @@ type AutomationService interface {
 	Update(ctx context.Context, workspaceID string, automation *Automation) error
 	Delete(ctx context.Context, workspaceID, automationID string) error
 
+	// Tag management
+	SetTags(ctx context.Context, workspaceID, automationID string, tags []string) error
+	ListTags(ctx context.Context, workspaceID string) ([]string, error)
+
 	// Status management
 	Activate(ctx context.Context, workspaceID, automationID string) error
+	CheckIntegrations(ctx context.Context, workspaceID, automationID string) error
 	Pause(ctx context.Context, workspaceID, automationID string) error
 
+	// Bulk enrollment (per-email outcomes)
+	EnrollContacts(ctx context.Context, workspaceID, automationID string, emails []string) (*BulkOperationResult, error)
+	ReenrollContacts(ctx context.Context, workspaceID, automationID string, emails []string, segmentID string) (*ReenrollContactsResult, error)
+
 	// Node executions/debugging
 	GetContactNodeExecutions(ctx context.Context, workspaceID, automationID, email string) (*ContactAutomation, []*NodeExecution, error)
+	GetContactNextTick(ctx context.Context, workspaceID, automationID, email string) (*ContactNextTick, error)
+	TestWebhookNode(ctx context.Context, workspaceID, automationID, nodeID, email string) (*WebhookNodeTestResult, error)
+	GetNodeStats(ctx context.Context, workspaceID, automationID string) (map[string]*AutomationNodeExecutionStats, error)
+
+	// Save-time checks (warnings only, never block a save)
+	CheckWebhookURLs(ctx context.Context, automation *Automation) []string
 }
 
 // HTTP Request/Response types for automation API
 
 // CreateAutomationRequest represents the request to create an automation
 type CreateAutomationRequest struct {
-	WorkspaceID string      `json:"workspace_id"`
-	Automation  *Automation `json:"automation"`
+	WorkspaceID      string      `json:"workspace_id"`
+	Automation       *Automation `json:"automation"`
+	ValidateWebhooks bool        `json:"validate_webhooks,omitempty"` // Check webhook node URLs and return warnings
 }
 
 // Validate validates the create automation request
@@ -759,8 +2831,9 @@ func (r *CreateAutomationRequest) Validate() error {
 
 // UpdateAutomationRequest represents the request to update an automation
 type UpdateAutomationRequest struct {
-	WorkspaceID string      `json:"workspace_id"`
-	Automation  *Automation `json:"automation"`
+	WorkspaceID      string      `json:"workspace_id"`
+	Automation       *Automation `json:"automation"`
+	ValidateWebhooks bool        `json:"validate_webhooks,omitempty"` // Check webhook node URLs and return warnings
 }
 
 // Validate validates the update automation request
@@ -814,6 +2887,7 @@ type ListAutomationsRequest struct {
 	WorkspaceID string             `json:"workspace_id"`
 	Status      []AutomationStatus `json:"status,omitempty"`
 	ListID      string             `json:"list_id,omitempty"`
+	Tag         string             `json:"tag,omitempty"`
 	Limit       int                `json:"limit,omitempty"`
 	Offset      int                `json:"offset,omitempty"`
 }
@@ -831,6 +2905,9 @@ func (r *ListAutomationsRequest) FromURLParams(params map[string][]string) error
 	if v, ok := params["list_id"]; ok && len(v) > 0 {
 		r.ListID = v[0]
 	}
+	if v, ok := params["tag"]; ok && len(v) > 0 {
+		r.Tag = v[0]
+	}
 	// Parse limit and offset if provided
 	if v, ok := params["limit"]; ok && len(v) > 0 {
 		var limit int
@@ -858,6 +2935,7 @@ func (r *ListAutomationsRequest) ToFilter() AutomationFilter {
 	return AutomationFilter{
 		Status: r.Status,
 		ListID: r.ListID,
+		Tag:    r.Tag,
 		Limit:  r.Limit,
 		Offset: r.Offset,
 	}
@@ -884,6 +2962,9 @@ func (r *DeleteAutomationRequest) Validate() error {
 type ActivateAutomationRequest struct {
 	WorkspaceID  string `json:"workspace_id"`
 	AutomationID string `json:"automation_id"`
+	// CheckIntegration probes the email integrations used by the automation's email
+	// nodes before activating and rejects the request if one cannot connect
+	CheckIntegration bool `json:"check_integration,omitempty"`
 }
 
 // Validate validates the activate automation request
@@ -948,3 +3029,171 @@ func (r *GetContactNodeExecutionsRequest) Validate() error {
 	}
 	return nil
 }
+
+// GetContactNextTickRequest represents the request to preview a contact's next scheduler tick
+type GetContactNextTickRequest struct {
+	WorkspaceID  string `json:"workspace_id"`
+	AutomationID string `json:"automation_id"`
+	Email        string `json:"email"`
+}
+
+// FromURLParams parses the request from URL parameters
+func (r *GetContactNextTickRequest) FromURLParams(params map[string][]string) error {
+	if v, ok := params["workspace_id"]; ok && len(v) > 0 {
+		r.WorkspaceID = v[0]
+	}
+	if v, ok := params["automation_id"]; ok && len(v) > 0 {
+		r.AutomationID = v[0]
+	}
+	if v, ok := params["email"]; ok && len(v) > 0 {
+		r.Email = v[0]
+	}
+	return r.Validate()
+}
+
+// Validate validates the get contact next tick request
+func (r *GetContactNextTickRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.AutomationID == "" {
+		return fmt.Errorf("automation_id is required")
+	}
+	if r.Email == "" {
+		return fmt.Errorf("email is required")
+	}
+	return nil
+}
+
+// TestWebhookNodeRequest represents the request to fire a webhook node once against a contact
+type TestWebhookNodeRequest struct {
+	WorkspaceID  string `json:"workspace_id"`
+	AutomationID string `json:"automation_id"`
+	NodeID       string `json:"node_id"`
+	Email        string `json:"email"`
+}
+
+// Validate validates the test webhook node request
+func (r *TestWebhookNodeRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.AutomationID == "" {
+		return fmt.Errorf("automation_id is required")
+	}
+	if r.NodeID == "" {
+		return fmt.Errorf("node_id is required")
+	}
+	if r.Email == "" {
+		return fmt.Errorf("email is required")
+	}
+	return nil
+}
+
+// SetAutomationTagsRequest represents the request to replace an automation's tags
+type SetAutomationTagsRequest struct {
+	WorkspaceID  string   `json:"workspace_id"`
+	AutomationID string   `json:"automation_id"`
+	Tags         []string `json:"tags"`
+}
+
+// Validate validates the set automation tags request
+func (r *SetAutomationTagsRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.AutomationID == "" {
+		return fmt.Errorf("automation_id is required")
+	}
+	return ValidateAutomationTags(NormalizeAutomationTags(r.Tags))
+}
+
+// MaxEnrollContactsBatch is the maximum number of emails in a single bulk enrollment request
+const MaxEnrollContactsBatch = 1000
+
+// ErrEnrollmentContactNotFound is returned when enrolling an email that matches no contact
+var ErrEnrollmentContactNotFound = errors.New("contact not found")
+
+// EnrollContactsRequest represents the request to enroll contacts in a live automation
+type EnrollContactsRequest struct {
+	WorkspaceID  string   `json:"workspace_id"`
+	AutomationID string   `json:"automation_id"`
+	Emails       []string `json:"emails"`
+}
+
+// Validate validates the enroll contacts request.
+// Individual emails are checked by the service so that invalid ones fail on their own.
+func (r *EnrollContactsRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.AutomationID == "" {
+		return fmt.Errorf("automation_id is required")
+	}
+	if len(r.Emails) == 0 {
+		return fmt.Errorf("emails are required")
+	}
+	if len(r.Emails) > MaxEnrollContactsBatch {
+		return fmt.Errorf("at most %d emails can be enrolled at once", MaxEnrollContactsBatch)
+	}
+	return nil
+}
+
+// ReenrollContactsRequest represents the request to enroll contacts again at the root node of
+// a live automation, e.g. after it was deleted and recreated. Contacts are given either as
+// emails or as the members of a segment.
+type ReenrollContactsRequest struct {
+	WorkspaceID  string   `json:"workspace_id"`
+	AutomationID string   `json:"automation_id"`
+	Emails       []string `json:"emails,omitempty"`
+	SegmentID    string   `json:"segment_id,omitempty"`
+}
+
+// Validate validates the re-enroll contacts request
+func (r *ReenrollContactsRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.AutomationID == "" {
+		return fmt.Errorf("automation_id is required")
+	}
+	if len(r.Emails) == 0 && r.SegmentID == "" {
+		return fmt.Errorf("emails or segment_id is required")
+	}
+	if len(r.Emails) > 0 && r.SegmentID != "" {
+		return fmt.Errorf("emails and segment_id cannot be used together")
+	}
+	if len(r.Emails) > MaxEnrollContactsBatch {
+		return fmt.Errorf("at most %d emails can be enrolled at once", MaxEnrollContactsBatch)
+	}
+	return nil
+}
+
+// ReenrollContactsResult counts the outcome of a re-enrollment. Contacts already active in the
+// automation, or already triggered for a "once" automation, are skipped.
+type ReenrollContactsResult struct {
+	Enrolled int `json:"enrolled"`
+	Skipped  int `json:"skipped"`
+	Failed   int `json:"failed"`
+}
+
+// ListAutomationTagsRequest represents the request to list the tags used in a workspace
+type ListAutomationTagsRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+}
+
+// FromURLParams parses the request from URL parameters
+func (r *ListAutomationTagsRequest) FromURLParams(params map[string][]string) error {
+	if v, ok := params["workspace_id"]; ok && len(v) > 0 {
+		r.WorkspaceID = v[0]
+	}
+	return r.Validate()
+}
+
+// Validate validates the list automation tags request
+func (r *ListAutomationTagsRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	return nil
+}
@@ -1,7 +1,16 @@
 package domain
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 	"time"
 
@@ -62,6 +71,13 @@ func TestNodeType_IsValid(t *testing.T) {
 		{"add_to_list is valid", NodeTypeAddToList, true},
 		{"remove_from_list is valid", NodeTypeRemoveFromList, true},
 		{"ab_test is valid", NodeTypeABTest, true},
+		{"unsubscribe_all is valid", NodeTypeUnsubscribeAll, true},
+		{"enroll_in_automation is valid", NodeTypeEnrollInAutomation, true},
+		{"record_revenue is valid", NodeTypeRecordRevenue, true},
+		{"wait_for_list_status is valid", NodeTypeWaitForListStatus, true},
+		{"wait_until_datetime is valid", NodeTypeWaitUntilDatetime, true},
+		{"suppression_branch is valid", NodeTypeSuppressionBranch, true},
+		{"transactional_email is valid", NodeTypeTransactionalEmail, true},
 		{"empty is invalid", NodeType(""), false},
 		{"unknown is invalid", NodeType("unknown"), false},
 	}
@@ -192,6 +208,182 @@ func TestTimelineTriggerConfig_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid config - list.subscribed with enrollment conditions",
+			config: &TimelineTriggerConfig{
+				EventKind: "list.subscribed",
+				ListID:    &listID,
+				EnrollmentConditions: &TreeNode{
+					Kind: "leaf",
+					Leaf: &TreeNodeLeaf{
+						Source: "contacts",
+						Contact: &ContactCondition{
+							Filters: []*DimensionFilter{
+								{
+									FieldName:    "country",
+									FieldType:    "string",
+									Operator:     "equals",
+									StringValues: []string{"US"},
+								},
+							},
+						},
+					},
+				},
+				Frequency: TriggerFrequencyOnce,
+			},
+			wantErr: false,
+		},
+		{
+			name: "enrollment conditions on non list.subscribed event",
+			config: &TimelineTriggerConfig{
+				EventKind: "contact.created",
+				EnrollmentConditions: &TreeNode{
+					Kind: "leaf",
+					Leaf: &TreeNodeLeaf{
+						Source: "contacts",
+						Contact: &ContactCondition{
+							Filters: []*DimensionFilter{
+								{
+									FieldName:    "country",
+									FieldType:    "string",
+									Operator:     "equals",
+									StringValues: []string{"US"},
+								},
+							},
+						},
+					},
+				},
+				Frequency: TriggerFrequencyOnce,
+			},
+			wantErr: true,
+			errMsg:  "enrollment_conditions are only supported for list.subscribed triggers",
+		},
+		{
+			name: "invalid enrollment conditions tree",
+			config: &TimelineTriggerConfig{
+				EventKind:            "list.subscribed",
+				ListID:               &listID,
+				EnrollmentConditions: &TreeNode{Kind: "unknown"},
+				Frequency:            TriggerFrequencyOnce,
+			},
+			wantErr: true,
+			errMsg:  "invalid enrollment_conditions",
+		},
+		{
+			name: "valid config - segment.joined with min_dwell",
+			config: &TimelineTriggerConfig{
+				EventKind:    "segment.joined",
+				SegmentID:    &segmentID,
+				Frequency:    TriggerFrequencyOnce,
+				MinDwell:     2,
+				MinDwellUnit: "hours",
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative min_dwell",
+			config: &TimelineTriggerConfig{
+				EventKind:    "segment.joined",
+				SegmentID:    &segmentID,
+				Frequency:    TriggerFrequencyOnce,
+				MinDwell:     -1,
+				MinDwellUnit: "hours",
+			},
+			wantErr: true,
+			errMsg:  "min_dwell must be positive",
+		},
+		{
+			name: "invalid min_dwell_unit",
+			config: &TimelineTriggerConfig{
+				EventKind:    "segment.joined",
+				SegmentID:    &segmentID,
+				Frequency:    TriggerFrequencyOnce,
+				MinDwell:     2,
+				MinDwellUnit: "weeks",
+			},
+			wantErr: true,
+			errMsg:  "invalid min_dwell_unit",
+		},
+		{
+			name: "min_dwell on non segment.joined trigger",
+			config: &TimelineTriggerConfig{
+				EventKind:    "list.subscribed",
+				ListID:       &listID,
+				Frequency:    TriggerFrequencyOnce,
+				MinDwell:     2,
+				MinDwellUnit: "hours",
+			},
+			wantErr: true,
+			errMsg:  "min_dwell is only supported for segment.joined triggers",
+		},
+		{
+			name: "valid config - inactivity with inactivity_days",
+			config: &TimelineTriggerConfig{
+				EventKind:      "inactivity",
+				Frequency:      TriggerFrequencyEveryTime,
+				InactivityDays: 30,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config - prerequisite with window",
+			config: &TimelineTriggerConfig{
+				EventKind:    "contact.created",
+				Frequency:    TriggerFrequencyOnce,
+				Prerequisite: &TriggerPrerequisite{AutomationID: "onboarding", Within: 7, WithinUnit: "days"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "prerequisite without automation_id",
+			config: &TimelineTriggerConfig{
+				EventKind:    "contact.created",
+				Frequency:    TriggerFrequencyOnce,
+				Prerequisite: &TriggerPrerequisite{},
+			},
+			wantErr: true,
+			errMsg:  "prerequisite automation_id is required",
+		},
+		{
+			name: "prerequisite with invalid within_unit",
+			config: &TimelineTriggerConfig{
+				EventKind:    "contact.created",
+				Frequency:    TriggerFrequencyOnce,
+				Prerequisite: &TriggerPrerequisite{AutomationID: "onboarding", Within: 7, WithinUnit: "weeks"},
+			},
+			wantErr: true,
+			errMsg:  "invalid prerequisite within_unit",
+		},
+		{
+			name: "inactivity without inactivity_days",
+			config: &TimelineTriggerConfig{
+				EventKind: "inactivity",
+				Frequency: TriggerFrequencyOnce,
+			},
+			wantErr: true,
+			errMsg:  "inactivity_days must be positive",
+		},
+		{
+			name: "inactivity combined with other events",
+			config: &TimelineTriggerConfig{
+				EventKind:      "inactivity",
+				Events:         []TriggerEventSpec{{EventKind: "contact.created"}},
+				Frequency:      TriggerFrequencyOnce,
+				InactivityDays: 30,
+			},
+			wantErr: true,
+			errMsg:  "inactivity triggers cannot be combined with other events",
+		},
+		{
+			name: "inactivity_days on non inactivity trigger",
+			config: &TimelineTriggerConfig{
+				EventKind:      "contact.created",
+				Frequency:      TriggerFrequencyOnce,
+				InactivityDays: 30,
+			},
+			wantErr: true,
+			errMsg:  "inactivity_days is only supported for inactivity triggers",
+		},
 		{
 			name: "empty event kind",
 			config: &TimelineTriggerConfig{
@@ -246,6 +438,102 @@ func TestTimelineTriggerConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "custom_event_name is required for custom events",
 		},
+		{
+			name: "valid config - primary event with additional events",
+			config: &TimelineTriggerConfig{
+				EventKind: "list.subscribed",
+				ListID:    &listID,
+				Events: []TriggerEventSpec{
+					{EventKind: "custom_event", CustomEventName: &customEventName},
+				},
+				Frequency: TriggerFrequencyOnce,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config - events only",
+			config: &TimelineTriggerConfig{
+				Events: []TriggerEventSpec{
+					{EventKind: "segment.joined", SegmentID: &segmentID},
+					{EventKind: "contact.created"},
+				},
+				Frequency: TriggerFrequencyEveryTime,
+			},
+			wantErr: false,
+		},
+		{
+			name: "additional event missing custom_event_name",
+			config: &TimelineTriggerConfig{
+				EventKind: "contact.created",
+				Events: []TriggerEventSpec{
+					{EventKind: "custom_event"},
+				},
+				Frequency: TriggerFrequencyOnce,
+			},
+			wantErr: true,
+			errMsg:  "events[0]: custom_event_name is required for custom events",
+		},
+		{
+			name: "additional event with invalid kind",
+			config: &TimelineTriggerConfig{
+				Events: []TriggerEventSpec{
+					{EventKind: "contact.created"},
+					{EventKind: "invalid.event"},
+				},
+				Frequency: TriggerFrequencyOnce,
+			},
+			wantErr: true,
+			errMsg:  "events[1]: invalid event kind",
+		},
+		{
+			name: "valid config - contact.updated with field_change",
+			config: &TimelineTriggerConfig{
+				EventKind:   "contact.updated",
+				FieldChange: &TriggerFieldChange{Field: "custom_string_1", To: "churned"},
+				Frequency:   TriggerFrequencyOnce,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config - field_change with numeric from and to",
+			config: &TimelineTriggerConfig{
+				Events: []TriggerEventSpec{
+					{EventKind: "contact.updated", FieldChange: &TriggerFieldChange{Field: "custom_number_1", From: float64(1), To: float64(2)}},
+				},
+				Frequency: TriggerFrequencyEveryTime,
+			},
+			wantErr: false,
+		},
+		{
+			name: "field_change on another event kind",
+			config: &TimelineTriggerConfig{
+				EventKind:   "contact.created",
+				FieldChange: &TriggerFieldChange{Field: "custom_string_1", To: "churned"},
+				Frequency:   TriggerFrequencyOnce,
+			},
+			wantErr: true,
+			errMsg:  "field_change is only supported for contact.updated events",
+		},
+		{
+			name: "field_change without field",
+			config: &TimelineTriggerConfig{
+				EventKind:   "contact.updated",
+				FieldChange: &TriggerFieldChange{To: "churned"},
+				Frequency:   TriggerFrequencyOnce,
+			},
+			wantErr: true,
+			errMsg:  "field_change field is required",
+		},
+		{
+			name: "field_change with object value",
+			config: &TimelineTriggerConfig{
+				EventKind:   "contact.updated",
+				FieldChange: &TriggerFieldChange{Field: "custom_string_1", To: map[string]interface{}{"a": 1}},
+				Frequency:   TriggerFrequencyOnce,
+			},
+			wantErr: true,
+			errMsg:  "field_change to must be a string, number or boolean",
+		},
 	}
 
 	for _, tt := range tests {
@@ -261,6 +549,42 @@ func TestTimelineTriggerConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestTimelineTriggerConfig_EventSpecs(t *testing.T) {
+	listID := "list123"
+	customEventName := "purchase"
+
+	t.Run("primary event only", func(t *testing.T) {
+		config := &TimelineTriggerConfig{EventKind: "list.subscribed", ListID: &listID}
+		specs := config.EventSpecs()
+		assert.Len(t, specs, 1)
+		assert.Equal(t, "list.subscribed", specs[0].EventKind)
+		assert.Equal(t, &listID, specs[0].ListID)
+	})
+
+	t.Run("primary event followed by additional events", func(t *testing.T) {
+		config := &TimelineTriggerConfig{
+			EventKind: "list.subscribed",
+			ListID:    &listID,
+			Events: []TriggerEventSpec{
+				{EventKind: "custom_event", CustomEventName: &customEventName},
+			},
+		}
+		specs := config.EventSpecs()
+		assert.Len(t, specs, 2)
+		assert.Equal(t, "list.subscribed", specs[0].EventKind)
+		assert.Equal(t, "custom_event", specs[1].EventKind)
+	})
+
+	t.Run("additional events only", func(t *testing.T) {
+		config := &TimelineTriggerConfig{
+			Events: []TriggerEventSpec{{EventKind: "contact.created"}},
+		}
+		specs := config.EventSpecs()
+		assert.Len(t, specs, 1)
+		assert.Equal(t, "contact.created", specs[0].EventKind)
+	})
+}
+
 func validAutomation() *Automation {
 	return &Automation{
 		ID:          "auto123",
@@ -307,6 +631,16 @@ func TestAutomation_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "id cannot exceed 36 characters",
 		},
+		{
+			name: "prerequisite referencing itself",
+			automation: func() *Automation {
+				a := validAutomation()
+				a.Trigger.Prerequisite = &TriggerPrerequisite{AutomationID: a.ID}
+				return a
+			}(),
+			wantErr: true,
+			errMsg:  "prerequisite cannot reference the automation itself",
+		},
 		{
 			name: "empty workspace ID",
 			automation: func() *Automation {
@@ -528,6 +862,25 @@ func TestAutomationNode_Validate(t *testing.T) {
 			}(),
 			wantErr: false,
 		},
+		{
+			name: "valid node with on_failure skip",
+			node: func() *AutomationNode {
+				n := validAutomationNode()
+				n.OnFailure = NodeFailurePolicySkip
+				return n
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "invalid on_failure policy",
+			node: func() *AutomationNode {
+				n := validAutomationNode()
+				n.OnFailure = "ignore"
+				return n
+			}(),
+			wantErr: true,
+			errMsg:  "invalid on_failure policy: ignore",
+		},
 		{
 			name: "empty ID",
 			node: func() *AutomationNode {
@@ -578,6 +931,27 @@ func TestAutomationNode_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "config is required",
 		},
+		{
+			name: "enroll in another automation",
+			node: func() *AutomationNode {
+				n := validAutomationNode()
+				n.Type = NodeTypeEnrollInAutomation
+				n.Config = map[string]interface{}{"automation_id": "auto456"}
+				return n
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "enroll in own automation",
+			node: func() *AutomationNode {
+				n := validAutomationNode()
+				n.Type = NodeTypeEnrollInAutomation
+				n.Config = map[string]interface{}{"automation_id": n.AutomationID}
+				return n
+			}(),
+			wantErr: true,
+			errMsg:  "automation cannot enroll contacts into itself",
+		},
 	}
 
 	for _, tt := range tests {
@@ -593,58 +967,561 @@ func TestAutomationNode_Validate(t *testing.T) {
 	}
 }
 
-func validContactAutomation() *ContactAutomation {
-	nodeID := "node123"
-	return &ContactAutomation{
-		ID:            "ca123",
-		AutomationID:  "auto123",
-		ContactEmail:  "test@example.com",
-		CurrentNodeID: &nodeID,
-		Status:        ContactAutomationStatusActive,
-		EnteredAt:     time.Now(),
-		Context:       map[string]interface{}{},
-		RetryCount:    0,
-		MaxRetries:    3,
+// generateTestKeyPairPEM returns a PEM-encoded self-signed certificate and its private key
+func generateTestKeyPairPEM(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "webhook-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
 	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM)
 }
 
-func TestContactAutomation_Validate(t *testing.T) {
+func TestAutomationNode_Validate_WebhookMTLS(t *testing.T) {
+	certPEM, keyPEM := generateTestKeyPairPEM(t)
+	_, otherKeyPEM := generateTestKeyPairPEM(t)
+
+	webhookNode := func(config map[string]interface{}) *AutomationNode {
+		n := validAutomationNode()
+		n.Type = NodeTypeWebhook
+		n.Config = config
+		return n
+	}
+
 	tests := []struct {
 		name    string
-		ca      *ContactAutomation
+		config  map[string]interface{}
 		wantErr bool
 		errMsg  string
 	}{
 		{
-			name:    "valid contact automation",
-			ca:      validContactAutomation(),
+			name:    "webhook without mtls",
+			config:  map[string]interface{}{"url": "https://example.com/hook"},
 			wantErr: false,
 		},
 		{
-			name: "empty ID",
-			ca: func() *ContactAutomation {
-				ca := validContactAutomation()
-				ca.ID = ""
-				return ca
-			}(),
-			wantErr: true,
-			errMsg:  "id is required",
+			name: "valid mtls pair",
+			config: map[string]interface{}{
+				"url":  "https://example.com/hook",
+				"mtls": map[string]interface{}{"client_cert": certPEM, "client_key": keyPEM},
+			},
+			wantErr: false,
 		},
 		{
-			name: "empty automation ID",
-			ca: func() *ContactAutomation {
-				ca := validContactAutomation()
-				ca.AutomationID = ""
-				return ca
-			}(),
+			name: "missing client key",
+			config: map[string]interface{}{
+				"url":  "https://example.com/hook",
+				"mtls": map[string]interface{}{"client_cert": certPEM},
+			},
 			wantErr: true,
-			errMsg:  "automation_id is required",
+			errMsg:  "mtls client_key is required",
 		},
 		{
-			name: "empty contact email",
-			ca: func() *ContactAutomation {
-				ca := validContactAutomation()
-				ca.ContactEmail = ""
+			name: "stored encrypted client key",
+			config: map[string]interface{}{
+				"url":  "https://example.com/hook",
+				"mtls": map[string]interface{}{"client_cert": certPEM, "encrypted_client_key": "a1b2c3"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing client cert",
+			config: map[string]interface{}{
+				"url":  "https://example.com/hook",
+				"mtls": map[string]interface{}{"client_key": keyPEM},
+			},
+			wantErr: true,
+			errMsg:  "mtls client_cert is required",
+		},
+		{
+			name: "key does not match certificate",
+			config: map[string]interface{}{
+				"url":  "https://example.com/hook",
+				"mtls": map[string]interface{}{"client_cert": certPEM, "client_key": otherKeyPEM},
+			},
+			wantErr: true,
+			errMsg:  "invalid mtls client certificate/key pair",
+		},
+		{
+			name: "garbage PEM",
+			config: map[string]interface{}{
+				"url":  "https://example.com/hook",
+				"mtls": map[string]interface{}{"client_cert": "not a cert", "client_key": "not a key"},
+			},
+			wantErr: true,
+			errMsg:  "invalid mtls client certificate/key pair",
+		},
+		{
+			name: "mtls over plain http",
+			config: map[string]interface{}{
+				"url":  "http://example.com/hook",
+				"mtls": map[string]interface{}{"client_cert": certPEM, "client_key": keyPEM},
+			},
+			wantErr: true,
+			errMsg:  "url must use https:// when mtls is configured",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webhookNode(tt.config).Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAutomation_WebhookClientKeys(t *testing.T) {
+	certPEM, keyPEM := generateTestKeyPairPEM(t)
+	passphrase := "workspace-secret-key"
+
+	newAutomation := func(mtls map[string]interface{}) *Automation {
+		return &Automation{
+			ID: "auto1",
+			Nodes: []*AutomationNode{
+				{ID: "delay", Type: NodeTypeDelay, Config: map[string]interface{}{"duration": 1, "unit": "days"}},
+				{ID: "hook", Type: NodeTypeWebhook, Config: map[string]interface{}{"url": "https://example.com/hook", "mtls": mtls}},
+			},
+		}
+	}
+	mtlsOf := func(a *Automation) map[string]interface{} {
+		return a.GetNodeByID("hook").Config["mtls"].(map[string]interface{})
+	}
+
+	// Saved automations keep the key encrypted
+	stored := newAutomation(map[string]interface{}{"client_cert": certPEM, "client_key": keyPEM})
+	assert.True(t, stored.HasWebhookMTLS())
+	require.NoError(t, stored.EncryptWebhookClientKeys(passphrase))
+	assert.NotContains(t, mtlsOf(stored), "client_key")
+	encrypted, _ := mtlsOf(stored)["encrypted_client_key"].(string)
+	require.NotEmpty(t, encrypted)
+
+	var config WebhookMTLSConfig
+	config.EncryptedClientKey = encrypted
+	require.NoError(t, config.DecryptClientKey(passphrase))
+	assert.Equal(t, keyPEM, config.ClientKey)
+
+	t.Run("redacts the key", func(t *testing.T) {
+		response := newAutomation(map[string]interface{}{"client_cert": certPEM, "encrypted_client_key": encrypted})
+		response.RedactWebhookClientKeys()
+		assert.Equal(t, map[string]interface{}{"client_cert": certPEM}, mtlsOf(response))
+	})
+
+	t.Run("restores the stored key of a node sent without one", func(t *testing.T) {
+		update := newAutomation(map[string]interface{}{"client_cert": certPEM})
+		require.NoError(t, update.RestoreWebhookClientKeys(stored, passphrase))
+		assert.Equal(t, keyPEM, mtlsOf(update)["client_key"])
+	})
+
+	t.Run("keeps a new key", func(t *testing.T) {
+		update := newAutomation(map[string]interface{}{"client_cert": certPEM, "client_key": "new key"})
+		require.NoError(t, update.RestoreWebhookClientKeys(stored, passphrase))
+		assert.Equal(t, "new key", mtlsOf(update)["client_key"])
+	})
+
+	t.Run("ignores an encrypted key sent by the client", func(t *testing.T) {
+		create := newAutomation(map[string]interface{}{"client_cert": certPEM, "encrypted_client_key": encrypted})
+		require.NoError(t, create.RestoreWebhookClientKeys(nil, passphrase))
+		assert.Equal(t, map[string]interface{}{"client_cert": certPEM}, mtlsOf(create))
+	})
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		update := newAutomation(map[string]interface{}{"client_cert": certPEM})
+		err := update.RestoreWebhookClientKeys(stored, "other-secret-key")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to decrypt client key of webhook node hook")
+	})
+}
+
+func TestAutomationNode_Validate_WebhookContentType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType interface{}
+		wantErr     bool
+	}{
+		{name: "default", contentType: nil, wantErr: false},
+		{name: "json", contentType: "json", wantErr: false},
+		{name: "form", contentType: "form", wantErr: false},
+		{name: "unsupported", contentType: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := validAutomationNode()
+			n.Type = NodeTypeWebhook
+			n.Config = map[string]interface{}{"url": "https://example.com/hook"}
+			if tt.contentType != nil {
+				n.Config["content_type"] = tt.contentType
+			}
+
+			err := n.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "content_type must be json or form")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWebhookNodeConfig_Validate_ResponseBranches(t *testing.T) {
+	branch := func(conditions ...interface{}) map[string]interface{} {
+		return map[string]interface{}{"id": "high_risk", "conditions": conditions, "next_node_id": "high_risk_node"}
+	}
+
+	tests := []struct {
+		name      string
+		branches  []interface{}
+		wantField string
+		wantMsg   string
+	}{
+		{
+			name:     "valid branches",
+			branches: []interface{}{branch(map[string]interface{}{"field": "risk", "operator": "equals", "value": "high"})},
+		},
+		{
+			name:      "missing conditions",
+			branches:  []interface{}{branch()},
+			wantField: "on_response_branches[0].conditions",
+			wantMsg:   "response branch 0: at least one condition is required",
+		},
+		{
+			name:      "unknown operator",
+			branches:  []interface{}{branch(map[string]interface{}{"field": "risk", "operator": "matches", "value": "high"})},
+			wantField: "on_response_branches[0].conditions[0].operator",
+			wantMsg:   "response branch 0: condition 0: invalid operator: matches",
+		},
+		{
+			name:      "numeric operator with a string value",
+			branches:  []interface{}{branch(map[string]interface{}{"field": "score", "operator": "gt", "value": "high"})},
+			wantField: "on_response_branches[0].conditions[0].value",
+			wantMsg:   "response branch 0: condition 0: value must be a number for operator gt",
+		},
+		{
+			name: "duplicate branch id",
+			branches: []interface{}{
+				branch(map[string]interface{}{"field": "risk", "operator": "exists"}),
+				branch(map[string]interface{}{"field": "risk", "operator": "exists"}),
+			},
+			wantField: "on_response_branches[1].id",
+			wantMsg:   "duplicate response branch id: high_risk",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &AutomationNode{ID: "node-1", Type: NodeTypeWebhook, Config: map[string]interface{}{
+				"url":                  "https://example.com/hook",
+				"on_response_branches": tt.branches,
+			}}
+			err := node.ValidateConfig()
+			if tt.wantMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var fieldErr *NodeConfigFieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, tt.wantField, fieldErr.Field)
+			assert.Equal(t, tt.wantMsg, fieldErr.Message)
+		})
+	}
+}
+
+func TestWebhookNodeConfig_Validate_Headers(t *testing.T) {
+	header := func(name, value string) map[string]interface{} {
+		return map[string]interface{}{"name": name, "value": value}
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		headers   []interface{}
+		wantField string
+		wantMsg   string
+	}{
+		{
+			name:    "valid static and templated headers",
+			headers: []interface{}{header("X-Api-Key", "abc123"), header("X-Correlation-ID", "{{ contact.email }}")},
+		},
+		{
+			name:      "missing name",
+			headers:   []interface{}{header("", "abc123")},
+			wantField: "headers[0].name",
+			wantMsg:   "header 0: name is required",
+		},
+		{
+			name:      "invalid name",
+			headers:   []interface{}{header("X Api Key", "abc123")},
+			wantField: "headers[0].name",
+			wantMsg:   `header 0: invalid header name: "X Api Key"`,
+		},
+		{
+			name:      "name with line break",
+			headers:   []interface{}{header("X-Api-Key\r\nX-Injected", "abc123")},
+			wantField: "headers[0].name",
+			wantMsg:   `header 0: invalid header name: "X-Api-Key\r\nX-Injected"`,
+		},
+		{
+			name:      "value with line break",
+			headers:   []interface{}{header("X-Api-Key", "abc123\r\nX-Injected: yes")},
+			wantField: "headers[0].value",
+			wantMsg:   "header 0: header value cannot contain line breaks or null bytes",
+		},
+		{
+			name:      "reserved header",
+			headers:   []interface{}{header("content-type", "text/plain")},
+			wantField: "headers[0].name",
+			wantMsg:   "header 0: header content-type is set by the webhook node",
+		},
+		{
+			name:      "authorization header with secret",
+			secret:    "s3cret",
+			headers:   []interface{}{header("Authorization", "Basic abc")},
+			wantField: "headers[0].name",
+			wantMsg:   "authorization header cannot be combined with secret",
+		},
+		{
+			name:      "duplicate header",
+			headers:   []interface{}{header("X-Api-Key", "a"), header("x-api-key", "b")},
+			wantField: "headers[1].name",
+			wantMsg:   "duplicate header: x-api-key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"url":     "https://example.com/hook",
+				"headers": tt.headers,
+			}
+			if tt.secret != "" {
+				config["secret"] = tt.secret
+			}
+			node := &AutomationNode{ID: "node-1", Type: NodeTypeWebhook, Config: config}
+			err := node.ValidateConfig()
+			if tt.wantMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var fieldErr *NodeConfigFieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, tt.wantField, fieldErr.Field)
+			assert.Equal(t, tt.wantMsg, fieldErr.Message)
+		})
+	}
+}
+
+func TestWebhookNodeConfig_Validate_ResponseMapping(t *testing.T) {
+	tests := []struct {
+		name      string
+		mapping   map[string]interface{}
+		wantField string
+		wantMsg   string
+	}{
+		{name: "whole response", mapping: map[string]interface{}{"save_as": "enrichment"}},
+		{name: "json path", mapping: map[string]interface{}{"save_as": "enrichment", "json_path": "$.results[0].score"}},
+		{
+			name:      "missing save_as",
+			mapping:   map[string]interface{}{"json_path": "$.score"},
+			wantField: "response_mapping.save_as",
+			wantMsg:   "save_as is required",
+		},
+		{
+			name:      "save_as not a variable name",
+			mapping:   map[string]interface{}{"save_as": "lead score"},
+			wantField: "response_mapping.save_as",
+			wantMsg:   "save_as must start with a letter or underscore and contain only letters, digits and underscores",
+		},
+		{
+			name:      "reserved save_as",
+			mapping:   map[string]interface{}{"save_as": "contact"},
+			wantField: "response_mapping.save_as",
+			wantMsg:   "save_as cannot be contact, it is reserved",
+		},
+		{
+			name:      "path without root",
+			mapping:   map[string]interface{}{"save_as": "enrichment", "json_path": "score"},
+			wantField: "response_mapping.json_path",
+			wantMsg:   "invalid json_path: must start with $",
+		},
+		{
+			name:      "invalid array index",
+			mapping:   map[string]interface{}{"save_as": "enrichment", "json_path": "$.results[first]"},
+			wantField: "response_mapping.json_path",
+			wantMsg:   `invalid json_path: invalid array index "first" in $.results[first]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &AutomationNode{ID: "node-1", Type: NodeTypeWebhook, Config: map[string]interface{}{
+				"url":              "https://example.com/hook",
+				"response_mapping": tt.mapping,
+			}}
+			err := node.ValidateConfig()
+			if tt.wantMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var fieldErr *NodeConfigFieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, tt.wantField, fieldErr.Field)
+			assert.Equal(t, tt.wantMsg, fieldErr.Message)
+		})
+	}
+}
+
+func TestWebhookResponseMapping_Extract(t *testing.T) {
+	body := []byte(`{"score": 87, "tier": "gold", "results": [{"id": "a"}, {"id": "b"}]}`)
+
+	tests := []struct {
+		name     string
+		jsonPath string
+		body     []byte
+		want     interface{}
+	}{
+		{name: "number", jsonPath: "$.score", body: body, want: float64(87)},
+		{name: "array element", jsonPath: "$.results[1].id", body: body, want: "b"},
+		{name: "whole response", jsonPath: "", body: []byte(`{"score": 87}`), want: map[string]interface{}{"score": float64(87)}},
+		{name: "missing key", jsonPath: "$.missing", body: body, want: nil},
+		{name: "index out of range", jsonPath: "$.results[5]", body: body, want: nil},
+		{name: "non-JSON response", jsonPath: "$.score", body: []byte("accepted"), want: "accepted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping := WebhookResponseMapping{SaveAs: "enrichment", JSONPath: tt.jsonPath}
+			assert.Equal(t, tt.want, mapping.Extract(tt.body))
+		})
+	}
+
+	t.Run("oversized values are truncated", func(t *testing.T) {
+		mapping := WebhookResponseMapping{SaveAs: "enrichment"}
+
+		raw := mapping.Extract([]byte(strings.Repeat("x", WebhookResponseMaxStoredBytes+10)))
+		assert.Len(t, raw, WebhookResponseMaxStoredBytes)
+
+		large := []byte(`{"text": "` + strings.Repeat("y", WebhookResponseMaxStoredBytes) + `"}`)
+		stored, ok := mapping.Extract(large).(string)
+		require.True(t, ok, "an oversized JSON value is stored as its truncated JSON text")
+		assert.Len(t, stored, WebhookResponseMaxStoredBytes)
+	})
+}
+
+func TestWebhookResponseCondition_Matches(t *testing.T) {
+	response := map[string]interface{}{
+		"risk":  "high",
+		"score": float64(87),
+		"tags":  []interface{}{"vpn", "new_device"},
+		"result": map[string]interface{}{
+			"approved": false,
+		},
+		"reason": nil,
+	}
+
+	tests := []struct {
+		name      string
+		condition WebhookResponseCondition
+		want      bool
+	}{
+		{"equals string", WebhookResponseCondition{Field: "risk", Operator: WebhookResponseOperatorEquals, Value: "high"}, true},
+		{"equals other string", WebhookResponseCondition{Field: "risk", Operator: WebhookResponseOperatorEquals, Value: "low"}, false},
+		{"equals number across types", WebhookResponseCondition{Field: "score", Operator: WebhookResponseOperatorEquals, Value: 87}, true},
+		{"equals nested bool", WebhookResponseCondition{Field: "result.approved", Operator: WebhookResponseOperatorEquals, Value: false}, true},
+		{"not equals", WebhookResponseCondition{Field: "risk", Operator: WebhookResponseOperatorNotEquals, Value: "low"}, true},
+		{"not equals on missing field", WebhookResponseCondition{Field: "missing", Operator: WebhookResponseOperatorNotEquals, Value: "low"}, true},
+		{"gt", WebhookResponseCondition{Field: "score", Operator: WebhookResponseOperatorGT, Value: 80}, true},
+		{"lte", WebhookResponseCondition{Field: "score", Operator: WebhookResponseOperatorLTE, Value: 80.5}, false},
+		{"gt on a string field", WebhookResponseCondition{Field: "risk", Operator: WebhookResponseOperatorGT, Value: 1}, false},
+		{"contains in array", WebhookResponseCondition{Field: "tags", Operator: WebhookResponseOperatorContains, Value: "vpn"}, true},
+		{"contains in string", WebhookResponseCondition{Field: "risk", Operator: WebhookResponseOperatorContains, Value: "ig"}, true},
+		{"exists", WebhookResponseCondition{Field: "result.approved", Operator: WebhookResponseOperatorExists}, true},
+		{"exists on null", WebhookResponseCondition{Field: "reason", Operator: WebhookResponseOperatorExists}, false},
+		{"path through a scalar", WebhookResponseCondition{Field: "risk.level", Operator: WebhookResponseOperatorExists}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.condition.Matches(response))
+		})
+	}
+}
+
+func validContactAutomation() *ContactAutomation {
+	nodeID := "node123"
+	return &ContactAutomation{
+		ID:            "ca123",
+		AutomationID:  "auto123",
+		ContactEmail:  "test@example.com",
+		CurrentNodeID: &nodeID,
+		Status:        ContactAutomationStatusActive,
+		EnteredAt:     time.Now(),
+		Context:       map[string]interface{}{},
+		RetryCount:    0,
+		MaxRetries:    3,
+	}
+}
+
+func TestContactAutomation_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ca      *ContactAutomation
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "valid contact automation",
+			ca:      validContactAutomation(),
+			wantErr: false,
+		},
+		{
+			name: "empty ID",
+			ca: func() *ContactAutomation {
+				ca := validContactAutomation()
+				ca.ID = ""
+				return ca
+			}(),
+			wantErr: true,
+			errMsg:  "id is required",
+		},
+		{
+			name: "empty automation ID",
+			ca: func() *ContactAutomation {
+				ca := validContactAutomation()
+				ca.AutomationID = ""
+				return ca
+			}(),
+			wantErr: true,
+			errMsg:  "automation_id is required",
+		},
+		{
+			name: "empty contact email",
+			ca: func() *ContactAutomation {
+				ca := validContactAutomation()
+				ca.ContactEmail = ""
 				return ca
 			}(),
 			wantErr: true,
@@ -705,6 +1582,24 @@ func TestContactAutomation_Validate(t *testing.T) {
 	}
 }
 
+func TestContactAutomation_GlobalFeed(t *testing.T) {
+	t.Run("returns the feed stored by a broadcast enrollment", func(t *testing.T) {
+		ca := &ContactAutomation{Context: map[string]interface{}{
+			ContactAutomationContextBroadcastID: "bc_1",
+			ContactAutomationContextGlobalFeed:  map[string]interface{}{"headline": "Spring Sale"},
+		}}
+		assert.Equal(t, MapOfAny{"headline": "Spring Sale"}, ca.GlobalFeed())
+	})
+
+	t.Run("returns nil without broadcast context", func(t *testing.T) {
+		assert.Nil(t, (&ContactAutomation{}).GlobalFeed())
+		assert.Nil(t, (&ContactAutomation{Context: map[string]interface{}{"global_feed": "invalid"}}).GlobalFeed())
+
+		var ca *ContactAutomation
+		assert.Nil(t, ca.GlobalFeed())
+	})
+}
+
 func validNodeExecution() *NodeExecution {
 	return &NodeExecution{
 		ID:                  "entry123",
@@ -915,6 +1810,23 @@ func TestDelayNodeConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid unit",
 		},
+		{
+			name:    "valid anchored config without duration",
+			config:  DelayNodeConfig{Unit: "days", AnchorField: "custom_datetime_1", Offset: -3, OnPast: DelayOnPastExit},
+			wantErr: false,
+		},
+		{
+			name:    "invalid anchor field",
+			config:  DelayNodeConfig{Unit: "days", AnchorField: "first_name"},
+			wantErr: true,
+			errMsg:  "invalid anchor_field",
+		},
+		{
+			name:    "invalid on_past",
+			config:  DelayNodeConfig{Unit: "days", AnchorField: "custom_datetime_1", OnPast: "wait"},
+			wantErr: true,
+			errMsg:  "invalid on_past",
+		},
 	}
 
 	for _, tt := range tests {
@@ -968,16 +1880,560 @@ func TestEmailNodeConfig_Validate(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:    "empty template ID",
-			config:  EmailNodeConfig{TemplateID: ""},
-			wantErr: true,
-			errMsg:  "template_id is required",
+			name:    "valid config with immediate delivery",
+			config:  EmailNodeConfig{TemplateID: "tmpl123", Delivery: EmailDeliveryImmediate},
+			wantErr: false,
 		},
+		{
+			name: "valid config with sender rules",
+			config: EmailNodeConfig{
+				TemplateID: "tmpl123",
+				SenderRules: []EmailSenderRule{{
+					Conditions: &TreeNode{
+						Kind: "leaf",
+						Leaf: &TreeNodeLeaf{
+							Source: "contacts",
+							Contact: &ContactCondition{Filters: []*DimensionFilter{{
+								FieldName:    "country",
+								FieldType:    "string",
+								Operator:     "equals",
+								StringValues: []string{"FR"},
+							}}},
+						},
+					},
+					IntegrationID: "eu-integration",
+				}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sender rule without integration",
+			config: EmailNodeConfig{
+				TemplateID: "tmpl123",
+				SenderRules: []EmailSenderRule{{
+					Conditions: &TreeNode{Kind: "leaf", Leaf: &TreeNodeLeaf{Source: "contacts", Contact: &ContactCondition{Filters: []*DimensionFilter{{
+						FieldName: "country", FieldType: "string", Operator: "equals", StringValues: []string{"FR"},
+					}}}}},
+				}},
+			},
+			wantErr: true,
+			errMsg:  "sender rule 0: integration_id is required",
+		},
+		{
+			name: "sender rule without conditions",
+			config: EmailNodeConfig{
+				TemplateID:  "tmpl123",
+				SenderRules: []EmailSenderRule{{IntegrationID: "eu-integration"}},
+			},
+			wantErr: true,
+			errMsg:  "sender rule 0: conditions are required",
+		},
+		{
+			name:    "invalid delivery",
+			config:  EmailNodeConfig{TemplateID: "tmpl123", Delivery: "later"},
+			wantErr: true,
+			errMsg:  "invalid delivery: later",
+		},
+		{
+			name:    "valid config with skip window",
+			config:  EmailNodeConfig{TemplateID: "tmpl123", SkipIfSentWithin: 7, SkipIfSentWithinUnit: "days"},
+			wantErr: false,
+		},
+		{
+			name:    "negative skip window",
+			config:  EmailNodeConfig{TemplateID: "tmpl123", SkipIfSentWithin: -1, SkipIfSentWithinUnit: "days"},
+			wantErr: true,
+			errMsg:  "skip_if_sent_within must be positive",
+		},
+		{
+			name:    "skip window without unit",
+			config:  EmailNodeConfig{TemplateID: "tmpl123", SkipIfSentWithin: 7},
+			wantErr: true,
+			errMsg:  "invalid skip_if_sent_within_unit",
+		},
+		{
+			name:    "empty template ID",
+			config:  EmailNodeConfig{TemplateID: ""},
+			wantErr: true,
+			errMsg:  "template_id is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEmailNodeConfig_SkipIfSentWithinDuration(t *testing.T) {
+	assert.Equal(t, time.Duration(0), EmailNodeConfig{TemplateID: "tmpl123"}.SkipIfSentWithinDuration())
+	assert.Equal(t, 30*time.Minute, EmailNodeConfig{SkipIfSentWithin: 30, SkipIfSentWithinUnit: "minutes"}.SkipIfSentWithinDuration())
+	assert.Equal(t, 12*time.Hour, EmailNodeConfig{SkipIfSentWithin: 12, SkipIfSentWithinUnit: "hours"}.SkipIfSentWithinDuration())
+	assert.Equal(t, 7*24*time.Hour, EmailNodeConfig{SkipIfSentWithin: 7, SkipIfSentWithinUnit: "days"}.SkipIfSentWithinDuration())
+}
+
+func TestAddToListNodeConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  AddToListNodeConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "valid config - active status",
+			config:  AddToListNodeConfig{ListID: "list123", Status: "active"},
+			wantErr: false,
+		},
+		{
+			name:    "valid config - pending status",
+			config:  AddToListNodeConfig{ListID: "list123", Status: "pending"},
+			wantErr: false,
+		},
+		{
+			name:    "empty list ID",
+			config:  AddToListNodeConfig{ListID: "", Status: "active"},
+			wantErr: true,
+			errMsg:  "list_id is required",
+		},
+		{
+			name:    "invalid status - subscribed is not valid",
+			config:  AddToListNodeConfig{ListID: "list123", Status: "subscribed"},
+			wantErr: true,
+			errMsg:  "invalid status: subscribed",
+		},
+		{
+			name:    "invalid status - unknown",
+			config:  AddToListNodeConfig{ListID: "list123", Status: "invalid"},
+			wantErr: true,
+			errMsg:  "invalid status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRemoveFromListNodeConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  RemoveFromListNodeConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "valid config",
+			config:  RemoveFromListNodeConfig{ListID: "list123"},
+			wantErr: false,
+		},
+		{
+			name:    "empty list ID",
+			config:  RemoveFromListNodeConfig{ListID: ""},
+			wantErr: true,
+			errMsg:  "list_id is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUnsubscribeAllNodeConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  UnsubscribeAllNodeConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "unsubscribed status",
+			config:  UnsubscribeAllNodeConfig{Status: "unsubscribed"},
+			wantErr: false,
+		},
+		{
+			name:    "removed status",
+			config:  UnsubscribeAllNodeConfig{Status: "removed"},
+			wantErr: false,
+		},
+		{
+			name:    "empty status",
+			config:  UnsubscribeAllNodeConfig{},
+			wantErr: true,
+			errMsg:  "invalid status",
+		},
+		{
+			name:    "active status",
+			config:  UnsubscribeAllNodeConfig{Status: "active"},
+			wantErr: true,
+			errMsg:  "invalid status: active",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEnrollInAutomationNodeConfig_Validate(t *testing.T) {
+	assert.NoError(t, EnrollInAutomationNodeConfig{AutomationID: "auto456"}.Validate())
+
+	err := EnrollInAutomationNodeConfig{}.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "automation_id is required")
+}
+
+func TestRecordRevenueNodeConfig_Validate(t *testing.T) {
+	amount := 19.99
+	negative := -1.0
+
+	tests := []struct {
+		name    string
+		config  RecordRevenueNodeConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{name: "literal amount", config: RecordRevenueNodeConfig{Amount: &amount, Currency: "USD"}},
+		{name: "custom number field", config: RecordRevenueNodeConfig{AmountField: "custom_number_3", Currency: "EUR"}},
+		{name: "custom json path", config: RecordRevenueNodeConfig{AmountField: "custom_json_2.order.total", Currency: "EUR"}},
+		{name: "no amount", config: RecordRevenueNodeConfig{Currency: "USD"}, wantErr: true, errMsg: "exactly one of amount or amount_field"},
+		{name: "both amounts", config: RecordRevenueNodeConfig{Amount: &amount, AmountField: "custom_number_1", Currency: "USD"}, wantErr: true, errMsg: "exactly one of amount or amount_field"},
+		{name: "negative amount", config: RecordRevenueNodeConfig{Amount: &negative, Currency: "USD"}, wantErr: true, errMsg: "amount must not be negative"},
+		{name: "non numeric field", config: RecordRevenueNodeConfig{AmountField: "first_name", Currency: "USD"}, wantErr: true, errMsg: "invalid amount_field"},
+		{name: "custom json without path", config: RecordRevenueNodeConfig{AmountField: "custom_json_1", Currency: "USD"}, wantErr: true, errMsg: "invalid amount_field"},
+		{name: "path in custom number", config: RecordRevenueNodeConfig{AmountField: "custom_number_1.value", Currency: "USD"}, wantErr: true, errMsg: "invalid amount_field"},
+		{name: "missing currency", config: RecordRevenueNodeConfig{Amount: &amount}, wantErr: true, errMsg: "invalid currency"},
+		{name: "lowercase currency", config: RecordRevenueNodeConfig{Amount: &amount, Currency: "usd"}, wantErr: true, errMsg: "invalid currency"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestListStatusBranchNodeConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ListStatusBranchNodeConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "valid config with all branches",
+			config: ListStatusBranchNodeConfig{
+				ListID:          "list123",
+				NotInListNodeID: "node1",
+				ActiveNodeID:    "node2",
+				NonActiveNodeID: "node3",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config with only not_in_list branch",
+			config: ListStatusBranchNodeConfig{
+				ListID:          "list123",
+				NotInListNodeID: "node1",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config with only active branch",
+			config: ListStatusBranchNodeConfig{
+				ListID:       "list123",
+				ActiveNodeID: "node1",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config with only non_active branch",
+			config: ListStatusBranchNodeConfig{
+				ListID:          "list123",
+				NonActiveNodeID: "node1",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "empty list ID",
+			config:  ListStatusBranchNodeConfig{ListID: "", NotInListNodeID: "node1"},
+			wantErr: true,
+			errMsg:  "list_id is required",
+		},
+		{
+			name:    "no branch targets",
+			config:  ListStatusBranchNodeConfig{ListID: "list123"},
+			wantErr: true,
+			errMsg:  "at least one branch must have a target node",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNodeType_IsValid_ListStatusBranch(t *testing.T) {
+	assert.True(t, NodeTypeListStatusBranch.IsValid())
+}
+
+func TestSuppressionBranchNodeConfig_Validate(t *testing.T) {
+	assert.NoError(t, SuppressionBranchNodeConfig{SuppressedNodeID: "node1", NotSuppressedNodeID: "node2"}.Validate())
+	assert.NoError(t, SuppressionBranchNodeConfig{SuppressedNodeID: "node1"}.Validate())
+	assert.NoError(t, SuppressionBranchNodeConfig{NotSuppressedNodeID: "node2"}.Validate())
+
+	err := SuppressionBranchNodeConfig{}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one branch must have a target node")
+
+	node := AutomationNode{Type: NodeTypeSuppressionBranch, Config: map[string]interface{}{}}
+	assert.Error(t, node.ValidateConfig())
+}
+
+func TestRetryBackoff_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff RetryBackoff
+		errMsg  string
+	}{
+		{name: "default", backoff: DefaultRetryBackoff()},
+		{name: "no jitter", backoff: RetryBackoff{BaseSeconds: 10, MaxSeconds: 10}},
+		{name: "zero base", backoff: RetryBackoff{MaxSeconds: 60}, errMsg: "base_seconds must be greater than 0"},
+		{name: "max below base", backoff: RetryBackoff{BaseSeconds: 60, MaxSeconds: 30}, errMsg: "max_seconds must be greater than or equal to base_seconds"},
+		{name: "max above one day", backoff: RetryBackoff{BaseSeconds: 60, MaxSeconds: 86401}, errMsg: "cannot exceed 86400"},
+		{name: "jitter above one", backoff: RetryBackoff{BaseSeconds: 30, MaxSeconds: 60, Jitter: 1.5}, errMsg: "jitter must be between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.backoff.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestRetryBackoff_Delay(t *testing.T) {
+	backoff := RetryBackoff{BaseSeconds: 30, MaxSeconds: 3600}
+
+	// Doubles from the base delay for each retry
+	assert.Equal(t, 30*time.Second, backoff.Delay(1, 0))
+	assert.Equal(t, 60*time.Second, backoff.Delay(2, 0))
+	assert.Equal(t, 2*time.Minute, backoff.Delay(3, 0))
+	assert.Equal(t, 32*time.Minute, backoff.Delay(7, 0))
+
+	// Capped at the max delay, also for very high retry counts
+	assert.Equal(t, time.Hour, backoff.Delay(8, 0))
+	assert.Equal(t, time.Hour, backoff.Delay(1000, 0))
+
+	// Jitter shortens the delay by up to its fraction
+	backoff.Jitter = 0.2
+	assert.Equal(t, 30*time.Second, backoff.Delay(1, 0))
+	assert.Equal(t, 27*time.Second, backoff.Delay(1, 0.5))
+	assert.Equal(t, 48*time.Minute, backoff.Delay(1000, 1))
+}
+
+func TestAutomation_GetRetryBackoff(t *testing.T) {
+	var nilAutomation *Automation
+	assert.Equal(t, DefaultRetryBackoff(), nilAutomation.GetRetryBackoff())
+	assert.Equal(t, DefaultRetryBackoff(), (&Automation{}).GetRetryBackoff())
+
+	custom := RetryBackoff{BaseSeconds: 5, MaxSeconds: 60, Jitter: 0.1}
+	assert.Equal(t, custom, (&Automation{RetryBackoff: &custom}).GetRetryBackoff())
+}
+
+func TestExpressionBranchNodeConfig_Validate(t *testing.T) {
+	assert.NoError(t, ExpressionBranchNodeConfig{Expression: "{{ event.amount > 100 }}", TrueNodeID: "node1", FalseNodeID: "node2"}.Validate())
+	assert.NoError(t, ExpressionBranchNodeConfig{Expression: "{{ event.amount > 100 }}", FalseNodeID: "node2"}.Validate())
+
+	err := ExpressionBranchNodeConfig{Expression: " ", TrueNodeID: "node1"}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expression is required")
+
+	err = ExpressionBranchNodeConfig{Expression: "{{ event.amount > 100 }}"}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one branch must have a target node")
+
+	node := AutomationNode{Type: NodeTypeExpressionBranch, Config: map[string]interface{}{}}
+	assert.Error(t, node.ValidateConfig())
+}
+
+func TestUpdateContactNodeConfig_Validate(t *testing.T) {
+	assert.NoError(t, UpdateContactNodeConfig{Fields: map[string]interface{}{"first_name": "{{ event.first_name }}", "custom_number_1": 5}}.Validate())
+	assert.NoError(t, UpdateContactNodeConfig{Fields: map[string]interface{}{"job_title": nil}}.Validate())
+
+	err := UpdateContactNodeConfig{}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one field is required")
+
+	err = UpdateContactNodeConfig{Fields: map[string]interface{}{"email": "other@example.com"}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "field email cannot be updated")
+
+	node := AutomationNode{Type: NodeTypeUpdateContact, Config: map[string]interface{}{"fields": map[string]interface{}{"lifecycle_stage": "customer"}}}
+	assert.Error(t, node.ValidateConfig())
+}
+
+func TestSlackNodeConfig_Validate(t *testing.T) {
+	assert.NoError(t, SlackNodeConfig{WebhookURL: "https://hooks.slack.com/services/T000/B000/XXXX", Text: "New signup: {{ contact.email }}"}.Validate())
+
+	err := SlackNodeConfig{Text: "hello"}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "webhook_url is required")
+
+	err = SlackNodeConfig{WebhookURL: "http://localhost:8080/hook", Text: "hello"}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "localhost")
+
+	err = SlackNodeConfig{WebhookURL: "https://10.0.0.5/hook", Text: "hello"}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "private or restricted")
+
+	err = SlackNodeConfig{WebhookURL: "https://hooks.slack.com/services/T000/B000/XXXX", Text: " "}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "text is required")
+
+	assert.True(t, NodeTypeSlack.IsValid())
+	assert.True(t, NodeTypeSlack.IsAction())
+}
+
+func TestGoalNodeConfig_Validate(t *testing.T) {
+	conditions := &TreeNode{
+		Kind: "leaf",
+		Leaf: &TreeNodeLeaf{
+			Source: "contacts",
+			Contact: &ContactCondition{
+				Filters: []*DimensionFilter{
+					{FieldName: "email", FieldType: "string", Operator: "equals", StringValues: []string{"test@example.com"}},
+				},
+			},
+		},
+	}
+
+	assert.NoError(t, GoalNodeConfig{Conditions: conditions}.Validate())
+	assert.NoError(t, GoalNodeConfig{Conditions: conditions, Scope: GoalScopeBranch}.Validate())
+	assert.NoError(t, GoalNodeConfig{Conditions: conditions, Scope: GoalScopeAutomation}.Validate())
+
+	err := GoalNodeConfig{}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conditions are required")
+
+	err = GoalNodeConfig{Conditions: &TreeNode{Kind: "unknown"}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid conditions")
+
+	err = GoalNodeConfig{Conditions: conditions, Scope: "global"}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid scope: global")
+
+	assert.True(t, NodeTypeGoal.IsValid())
+	assert.False(t, NodeTypeGoal.IsAction())
+}
+
+func TestAutomation_Validate_AutomationGoal(t *testing.T) {
+	withGoal := func(rootNodeID, scope string) *Automation {
+		a := validAutomation()
+		a.RootNodeID = rootNodeID
+		a.Nodes = []*AutomationNode{
+			{ID: "goal", AutomationID: a.ID, Type: NodeTypeGoal, Config: map[string]interface{}{"scope": scope}},
+			{ID: "notify", AutomationID: a.ID, Type: NodeTypeWebhook, Config: map[string]interface{}{"url": "https://example.com"}},
+		}
+		return a
+	}
+
+	assert.True(t, withGoal("notify", "automation").HasGoals())
+	assert.False(t, validAutomation().HasGoals())
+
+	assert.NoError(t, withGoal("notify", "automation").Validate())
+	assert.NoError(t, withGoal("goal", "branch").Validate())
+
+	err := withGoal("goal", "automation").Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "an automation-wide goal cannot be the root node")
+}
+
+func TestWaitForListStatusNodeConfig_Validate(t *testing.T) {
+	valid := WaitForListStatusNodeConfig{
+		ListID:        "list123",
+		TargetStatus:  ContactListStatusActive,
+		Timeout:       3,
+		TimeoutUnit:   "days",
+		MatchedNodeID: "node1",
+		TimeoutNodeID: "node2",
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *WaitForListStatusNodeConfig)
+		wantErr bool
+		errMsg  string
+	}{
+		{name: "valid config", modify: func(c *WaitForListStatusNodeConfig) {}},
+		{name: "valid config without targets", modify: func(c *WaitForListStatusNodeConfig) {
+			c.MatchedNodeID = ""
+			c.TimeoutNodeID = ""
+		}},
+		{name: "empty list ID", modify: func(c *WaitForListStatusNodeConfig) { c.ListID = "" }, wantErr: true, errMsg: "list_id is required"},
+		{name: "missing target status", modify: func(c *WaitForListStatusNodeConfig) { c.TargetStatus = "" }, wantErr: true, errMsg: "invalid target_status"},
+		{name: "unknown target status", modify: func(c *WaitForListStatusNodeConfig) { c.TargetStatus = "subscribed" }, wantErr: true, errMsg: "invalid target_status"},
+		{name: "zero timeout", modify: func(c *WaitForListStatusNodeConfig) { c.Timeout = 0 }, wantErr: true, errMsg: "timeout must be positive"},
+		{name: "invalid timeout unit", modify: func(c *WaitForListStatusNodeConfig) { c.TimeoutUnit = "weeks" }, wantErr: true, errMsg: "invalid timeout_unit"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.config.Validate()
+			config := valid
+			tt.modify(&config)
+			err := config.Validate()
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errMsg)
@@ -988,41 +2444,19 @@ func TestEmailNodeConfig_Validate(t *testing.T) {
 	}
 }
 
-func TestAddToListNodeConfig_Validate(t *testing.T) {
+func TestWaitUntilDatetimeNodeConfig_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
-		config  AddToListNodeConfig
+		config  WaitUntilDatetimeNodeConfig
 		wantErr bool
 		errMsg  string
 	}{
-		{
-			name:    "valid config - active status",
-			config:  AddToListNodeConfig{ListID: "list123", Status: "active"},
-			wantErr: false,
-		},
-		{
-			name:    "valid config - pending status",
-			config:  AddToListNodeConfig{ListID: "list123", Status: "pending"},
-			wantErr: false,
-		},
-		{
-			name:    "empty list ID",
-			config:  AddToListNodeConfig{ListID: "", Status: "active"},
-			wantErr: true,
-			errMsg:  "list_id is required",
-		},
-		{
-			name:    "invalid status - subscribed is not valid",
-			config:  AddToListNodeConfig{ListID: "list123", Status: "subscribed"},
-			wantErr: true,
-			errMsg:  "invalid status: subscribed",
-		},
-		{
-			name:    "invalid status - unknown",
-			config:  AddToListNodeConfig{ListID: "list123", Status: "invalid"},
-			wantErr: true,
-			errMsg:  "invalid status",
-		},
+		{name: "RFC 3339 datetime", config: WaitUntilDatetimeNodeConfig{Datetime: "2026-11-01T09:00:00+01:00"}},
+		{name: "local datetime in timezone", config: WaitUntilDatetimeNodeConfig{Datetime: "2026-11-01T09:00", Timezone: "America/New_York"}},
+		{name: "local datetime with seconds defaults to UTC", config: WaitUntilDatetimeNodeConfig{Datetime: "2026-11-01T09:00:30"}},
+		{name: "missing datetime", config: WaitUntilDatetimeNodeConfig{}, wantErr: true, errMsg: "datetime is required"},
+		{name: "unparseable datetime", config: WaitUntilDatetimeNodeConfig{Datetime: "01/11/2026"}, wantErr: true, errMsg: "invalid datetime"},
+		{name: "unknown timezone", config: WaitUntilDatetimeNodeConfig{Datetime: "2026-11-01T09:00", Timezone: "Mars/Olympus"}, wantErr: true, errMsg: "invalid timezone"},
 	}
 
 	for _, tt := range tests {
@@ -1038,24 +2472,29 @@ func TestAddToListNodeConfig_Validate(t *testing.T) {
 	}
 }
 
-func TestRemoveFromListNodeConfig_Validate(t *testing.T) {
+func TestWaitUntilDatetimeNodeConfig_ResolveTime(t *testing.T) {
+	resolved, err := WaitUntilDatetimeNodeConfig{Datetime: "2026-11-01T09:00", Timezone: "America/New_York"}.ResolveTime()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 11, 1, 14, 0, 0, 0, time.UTC), resolved)
+
+	// An explicit offset wins over the timezone
+	resolved, err = WaitUntilDatetimeNodeConfig{Datetime: "2026-11-01T09:00:00+01:00", Timezone: "America/New_York"}.ResolveTime()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 11, 1, 8, 0, 0, 0, time.UTC), resolved)
+}
+
+func TestWaitUntilNodeConfig_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
-		config  RemoveFromListNodeConfig
+		config  WaitUntilNodeConfig
 		wantErr bool
 		errMsg  string
 	}{
-		{
-			name:    "valid config",
-			config:  RemoveFromListNodeConfig{ListID: "list123"},
-			wantErr: false,
-		},
-		{
-			name:    "empty list ID",
-			config:  RemoveFromListNodeConfig{ListID: ""},
-			wantErr: true,
-			errMsg:  "list_id is required",
-		},
+		{name: "weekdays at 9:00", config: WaitUntilNodeConfig{DaysOfWeek: []string{"monday", "friday"}, Hour: 9}},
+		{name: "every day", config: WaitUntilNodeConfig{Hour: 23, Minute: 59}},
+		{name: "unknown day", config: WaitUntilNodeConfig{DaysOfWeek: []string{"Monday"}, Hour: 9}, wantErr: true, errMsg: "invalid day of week"},
+		{name: "hour out of range", config: WaitUntilNodeConfig{Hour: 24}, wantErr: true, errMsg: "hour must be between 0 and 23"},
+		{name: "minute out of range", config: WaitUntilNodeConfig{Hour: 9, Minute: -1}, wantErr: true, errMsg: "minute must be between 0 and 59"},
 	}
 
 	for _, tt := range tests {
@@ -1071,59 +2510,39 @@ func TestRemoveFromListNodeConfig_Validate(t *testing.T) {
 	}
 }
 
-func TestListStatusBranchNodeConfig_Validate(t *testing.T) {
+func TestWaitUntilNodeConfig_NextTime(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	config := WaitUntilNodeConfig{DaysOfWeek: []string{"monday", "wednesday"}, Hour: 9}
+
+	// Monday 2026-06-01 07:00 in Paris, before the window
+	now := time.Date(2026, 6, 1, 5, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 6, 1, 7, 0, 0, 0, time.UTC), config.NextTime(now, paris))
+
+	// Monday 10:00 in Paris, past the window rolls to Wednesday
+	now = time.Date(2026, 6, 1, 8, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 6, 3, 7, 0, 0, 0, time.UTC), config.NextTime(now, paris))
+
+	// Wednesday past the window rolls over the weekend to next Monday
+	now = time.Date(2026, 6, 3, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 6, 8, 7, 0, 0, 0, time.UTC), config.NextTime(now, paris))
+
+	// Without days every day matches
+	assert.Equal(t, time.Date(2026, 6, 4, 7, 0, 0, 0, time.UTC), WaitUntilNodeConfig{Hour: 9}.NextTime(now, paris))
+}
+
+func TestForEachNodeConfig_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
-		config  ListStatusBranchNodeConfig
+		config  ForEachNodeConfig
 		wantErr bool
 		errMsg  string
 	}{
-		{
-			name: "valid config with all branches",
-			config: ListStatusBranchNodeConfig{
-				ListID:          "list123",
-				NotInListNodeID: "node1",
-				ActiveNodeID:    "node2",
-				NonActiveNodeID: "node3",
-			},
-			wantErr: false,
-		},
-		{
-			name: "valid config with only not_in_list branch",
-			config: ListStatusBranchNodeConfig{
-				ListID:          "list123",
-				NotInListNodeID: "node1",
-			},
-			wantErr: false,
-		},
-		{
-			name: "valid config with only active branch",
-			config: ListStatusBranchNodeConfig{
-				ListID:       "list123",
-				ActiveNodeID: "node1",
-			},
-			wantErr: false,
-		},
-		{
-			name: "valid config with only non_active branch",
-			config: ListStatusBranchNodeConfig{
-				ListID:          "list123",
-				NonActiveNodeID: "node1",
-			},
-			wantErr: false,
-		},
-		{
-			name:    "empty list ID",
-			config:  ListStatusBranchNodeConfig{ListID: "", NotInListNodeID: "node1"},
-			wantErr: true,
-			errMsg:  "list_id is required",
-		},
-		{
-			name:    "no branch targets",
-			config:  ListStatusBranchNodeConfig{ListID: "list123"},
-			wantErr: true,
-			errMsg:  "at least one branch must have a target node",
-		},
+		{name: "valid config", config: ForEachNodeConfig{ItemsPath: "context.order.items", SubFlowNodeID: "sub1"}},
+		{name: "node output path", config: ForEachNodeConfig{ItemsPath: "nodes.webhook-1.items", SubFlowNodeID: "sub1"}},
+		{name: "missing items_path", config: ForEachNodeConfig{SubFlowNodeID: "sub1"}, wantErr: true, errMsg: "items_path is required"},
+		{name: "invalid items_path", config: ForEachNodeConfig{ItemsPath: "context..items", SubFlowNodeID: "sub1"}, wantErr: true, errMsg: "invalid items_path"},
+		{name: "missing sub_flow_node_id", config: ForEachNodeConfig{ItemsPath: "context.items"}, wantErr: true, errMsg: "sub_flow_node_id is required"},
 	}
 
 	for _, tt := range tests {
@@ -1139,8 +2558,71 @@ func TestListStatusBranchNodeConfig_Validate(t *testing.T) {
 	}
 }
 
-func TestNodeType_IsValid_ListStatusBranch(t *testing.T) {
-	assert.True(t, NodeTypeListStatusBranch.IsValid())
+func TestAutomation_Validate_ForEachSubFlow(t *testing.T) {
+	forEachAutomation := func(subFlowNodeID string) *Automation {
+		a := validAutomation()
+		a.RootNodeID = "loop"
+		a.Nodes = []*AutomationNode{
+			{ID: "loop", AutomationID: a.ID, Type: NodeTypeForEach, Config: map[string]interface{}{
+				"items_path":       "context.items",
+				"sub_flow_node_id": subFlowNodeID,
+			}},
+			{ID: "per_item", AutomationID: a.ID, Type: NodeTypeWebhook, Config: map[string]interface{}{"url": "https://example.com"}},
+		}
+		return a
+	}
+
+	assert.NoError(t, forEachAutomation("per_item").Validate())
+
+	err := forEachAutomation("missing").Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sub_flow_node_id missing does not reference a valid node")
+
+	err = forEachAutomation("loop").Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot reference the for_each node itself")
+}
+
+func TestAutomation_Validate_ErrorNodeID(t *testing.T) {
+	errorRouted := func(errorNodeID string) *Automation {
+		a := validAutomation()
+		a.RootNodeID = "add"
+		a.Nodes = []*AutomationNode{
+			{ID: "add", AutomationID: a.ID, Type: NodeTypeAddToList, ErrorNodeID: automationStringPtr(errorNodeID), Config: map[string]interface{}{
+				"list_id": "newsletter",
+				"status":  "active",
+			}},
+			{ID: "log_error", AutomationID: a.ID, Type: NodeTypeWebhook, Config: map[string]interface{}{"url": "https://example.com"}},
+		}
+		return a
+	}
+
+	assert.NoError(t, errorRouted("log_error").Validate())
+
+	err := errorRouted("missing").Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error_node_id missing does not reference a valid node")
+
+	err = errorRouted("add").Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error_node_id cannot reference the node itself")
+
+	// Only action nodes can route failures
+	delay := validAutomationNode()
+	delay.ErrorNodeID = automationStringPtr("log_error")
+	err = delay.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error_node_id is only supported on action nodes")
+}
+
+func TestNodeType_IsAction(t *testing.T) {
+	assert.True(t, NodeTypeEmail.IsAction())
+	assert.True(t, NodeTypeTransactionalEmail.IsAction())
+	assert.True(t, NodeTypeWebhook.IsAction())
+	assert.True(t, NodeTypeAddToList.IsAction())
+	assert.False(t, NodeTypeDelay.IsAction())
+	assert.False(t, NodeTypeTrigger.IsAction())
+	assert.False(t, NodeTypeFilter.IsAction())
 }
 
 // Helper function - using automationStringPtr to avoid conflict with other test files
@@ -1175,6 +2657,104 @@ func TestAutomation_HasEmailNodeRestriction(t *testing.T) {
 	}
 }
 
+func TestAutomationNode_ValidateConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		nodeType  NodeType
+		config    map[string]interface{}
+		wantField string
+		wantMsg   string
+	}{
+		{
+			name:     "valid delay",
+			nodeType: NodeTypeDelay,
+			config:   map[string]interface{}{"duration": 5, "unit": "minutes"},
+		},
+		{
+			name:      "negative delay duration",
+			nodeType:  NodeTypeDelay,
+			config:    map[string]interface{}{"duration": -5, "unit": "minutes"},
+			wantField: "duration",
+			wantMsg:   "duration must be positive",
+		},
+		{
+			name:      "delay duration of the wrong type",
+			nodeType:  NodeTypeDelay,
+			config:    map[string]interface{}{"duration": "five", "unit": "minutes"},
+			wantField: "duration",
+			wantMsg:   "duration must be of type int",
+		},
+		{
+			name:      "email without template",
+			nodeType:  NodeTypeEmail,
+			config:    map[string]interface{}{},
+			wantField: "template_id",
+			wantMsg:   "template_id is required",
+		},
+		{
+			name:     "email sender rule without conditions",
+			nodeType: NodeTypeEmail,
+			config: map[string]interface{}{
+				"template_id":  "tpl-1",
+				"sender_rules": []interface{}{map[string]interface{}{"integration_id": "eu-integration"}},
+			},
+			wantField: "sender_rules[0].conditions",
+			wantMsg:   "sender rule 0: conditions are required",
+		},
+		{
+			name:      "A/B test variant weight",
+			nodeType:  NodeTypeABTest,
+			config:    map[string]interface{}{"variants": []interface{}{map[string]interface{}{"id": "A", "name": "A", "weight": 0, "next_node_id": "n1"}, map[string]interface{}{"id": "B", "name": "B", "weight": 100, "next_node_id": "n2"}}},
+			wantField: "variants[0].weight",
+			wantMsg:   "variant 0: variant weight must be between 1 and 100",
+		},
+		{
+			name:     "node type without typed config",
+			nodeType: NodeTypeTrigger,
+			config:   map[string]interface{}{"anything": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &AutomationNode{ID: "node-1", Type: tt.nodeType, Config: tt.config}
+			err := node.ValidateConfig()
+			if tt.wantMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var fieldErr *NodeConfigFieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, tt.wantField, fieldErr.Field)
+			assert.Equal(t, tt.wantMsg, fieldErr.Message)
+		})
+	}
+}
+
+func TestAutomation_ValidateNodeConfigs(t *testing.T) {
+	automation := &Automation{
+		Nodes: []*AutomationNode{
+			{ID: "trigger", Type: NodeTypeTrigger, Config: map[string]interface{}{}},
+			{ID: "delay-1", Type: NodeTypeDelay, Config: map[string]interface{}{"duration": -5, "unit": "minutes"}},
+			{ID: "email-1", Type: NodeTypeEmail, Config: map[string]interface{}{}},
+			{ID: "delay-2", Type: NodeTypeDelay, Config: map[string]interface{}{"duration": 1, "unit": "days"}},
+		},
+	}
+
+	err := automation.ValidateNodeConfigs()
+	var nodeErr *NodeConfigValidationError
+	require.ErrorAs(t, err, &nodeErr)
+	assert.Equal(t, map[string]NodeConfigFieldError{
+		"delay-1": {Field: "duration", Message: "duration must be positive"},
+		"email-1": {Field: "template_id", Message: "template_id is required"},
+	}, nodeErr.NodeErrors)
+	assert.EqualError(t, err, "invalid node config: node delay-1: duration must be positive; node email-1: template_id is required")
+
+	automation.Nodes = automation.Nodes[3:]
+	assert.NoError(t, automation.ValidateNodeConfigs())
+}
+
 func TestAutomationNode_ValidateForAutomation(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -1561,6 +3141,191 @@ func TestAutomationFilter_IncludeDeleted(t *testing.T) {
 	})
 }
 
+func TestNormalizeAutomationTags(t *testing.T) {
+	assert.Equal(t, []string{"onboarding", "vip"}, NormalizeAutomationTags([]string{" onboarding", "vip ", "", "  ", "onboarding"}))
+	assert.Equal(t, []string{}, NormalizeAutomationTags(nil))
+}
+
+func TestValidateAutomationTags(t *testing.T) {
+	t.Run("valid tags", func(t *testing.T) {
+		assert.NoError(t, ValidateAutomationTags([]string{"onboarding", "vip"}))
+		assert.NoError(t, ValidateAutomationTags(nil))
+	})
+
+	t.Run("too many tags", func(t *testing.T) {
+		tags := make([]string, MaxAutomationTags+1)
+		for i := range tags {
+			tags[i] = fmt.Sprintf("tag-%d", i)
+		}
+		err := ValidateAutomationTags(tags)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot have more than")
+	})
+
+	t.Run("tag too long", func(t *testing.T) {
+		err := ValidateAutomationTags([]string{strings.Repeat("a", MaxAutomationTagLength+1)})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot exceed")
+	})
+
+	t.Run("empty tag", func(t *testing.T) {
+		err := ValidateAutomationTags([]string{" "})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "tags cannot be empty")
+	})
+}
+
+func TestSetAutomationTagsRequest_Validate(t *testing.T) {
+	t.Run("valid request", func(t *testing.T) {
+		req := SetAutomationTagsRequest{WorkspaceID: "ws1", AutomationID: "auto1", Tags: []string{"vip", " vip "}}
+		assert.NoError(t, req.Validate())
+	})
+
+	t.Run("clearing tags is allowed", func(t *testing.T) {
+		req := SetAutomationTagsRequest{WorkspaceID: "ws1", AutomationID: "auto1"}
+		assert.NoError(t, req.Validate())
+	})
+
+	t.Run("missing automation_id", func(t *testing.T) {
+		req := SetAutomationTagsRequest{WorkspaceID: "ws1", Tags: []string{"vip"}}
+		err := req.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "automation_id is required")
+	})
+}
+
+func TestEnrollContactsRequest_Validate(t *testing.T) {
+	t.Run("valid request", func(t *testing.T) {
+		req := EnrollContactsRequest{WorkspaceID: "ws1", AutomationID: "auto1", Emails: []string{"a@example.com", "not-an-email"}}
+		assert.NoError(t, req.Validate())
+	})
+
+	t.Run("missing emails", func(t *testing.T) {
+		req := EnrollContactsRequest{WorkspaceID: "ws1", AutomationID: "auto1"}
+		err := req.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "emails are required")
+	})
+
+	t.Run("too many emails", func(t *testing.T) {
+		req := EnrollContactsRequest{WorkspaceID: "ws1", AutomationID: "auto1", Emails: make([]string, MaxEnrollContactsBatch+1)}
+		err := req.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "at most 1000 emails")
+	})
+
+	t.Run("missing automation_id", func(t *testing.T) {
+		req := EnrollContactsRequest{WorkspaceID: "ws1", Emails: []string{"a@example.com"}}
+		err := req.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "automation_id is required")
+	})
+}
+
+func TestReenrollContactsRequest_Validate(t *testing.T) {
+	t.Run("valid with emails", func(t *testing.T) {
+		req := ReenrollContactsRequest{WorkspaceID: "ws1", AutomationID: "auto1", Emails: []string{"a@example.com"}}
+		assert.NoError(t, req.Validate())
+	})
+
+	t.Run("valid with segment", func(t *testing.T) {
+		req := ReenrollContactsRequest{WorkspaceID: "ws1", AutomationID: "auto1", SegmentID: "seg1"}
+		assert.NoError(t, req.Validate())
+	})
+
+	t.Run("missing emails and segment", func(t *testing.T) {
+		req := ReenrollContactsRequest{WorkspaceID: "ws1", AutomationID: "auto1"}
+		err := req.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "emails or segment_id is required")
+	})
+
+	t.Run("emails and segment together", func(t *testing.T) {
+		req := ReenrollContactsRequest{WorkspaceID: "ws1", AutomationID: "auto1", Emails: []string{"a@example.com"}, SegmentID: "seg1"}
+		err := req.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be used together")
+	})
+
+	t.Run("too many emails", func(t *testing.T) {
+		req := ReenrollContactsRequest{WorkspaceID: "ws1", AutomationID: "auto1", Emails: make([]string, MaxEnrollContactsBatch+1)}
+		err := req.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "at most 1000 emails")
+	})
+
+	t.Run("missing workspace_id", func(t *testing.T) {
+		req := ReenrollContactsRequest{AutomationID: "auto1", SegmentID: "seg1"}
+		err := req.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "workspace_id is required")
+	})
+}
+
+func TestListAutomationsRequest_FromURLParams_Tag(t *testing.T) {
+	req := ListAutomationsRequest{}
+	err := req.FromURLParams(map[string][]string{
+		"workspace_id": {"ws1"},
+		"tag":          {"onboarding"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "onboarding", req.Tag)
+	assert.Equal(t, "onboarding", req.ToFilter().Tag)
+}
+
+func TestPreviewNextTick(t *testing.T) {
+	emailNodeID := "email-1"
+	automation := &Automation{
+		ID:     "auto-1",
+		Status: AutomationStatusLive,
+		Nodes: []*AutomationNode{
+			{ID: "delay-1", Type: NodeTypeDelay, NextNodeID: &emailNodeID},
+			{ID: emailNodeID, Type: NodeTypeEmail},
+		},
+	}
+	scheduledAt := time.Now().UTC().Add(24 * time.Hour)
+
+	t.Run("delayed contact runs the node after the delay", func(t *testing.T) {
+		ca := &ContactAutomation{
+			Status:        ContactAutomationStatusActive,
+			CurrentNodeID: &emailNodeID,
+			ScheduledAt:   &scheduledAt,
+		}
+		tick := PreviewNextTick(automation, ca)
+		assert.Equal(t, &emailNodeID, tick.CurrentNodeID)
+		assert.Equal(t, &scheduledAt, tick.ScheduledAt)
+		assert.Equal(t, NextActionExecuteNode, tick.NextAction.Type)
+		require.NotNil(t, tick.NextAction.NodeID)
+		assert.Equal(t, emailNodeID, *tick.NextAction.NodeID)
+		require.NotNil(t, tick.NextAction.NodeType)
+		assert.Equal(t, NodeTypeEmail, *tick.NextAction.NodeType)
+	})
+
+	t.Run("no current node completes", func(t *testing.T) {
+		tick := PreviewNextTick(automation, &ContactAutomation{Status: ContactAutomationStatusActive})
+		assert.Equal(t, NextActionComplete, tick.NextAction.Type)
+		assert.Nil(t, tick.NextAction.NodeID)
+	})
+
+	t.Run("deleted node exits", func(t *testing.T) {
+		missing := "missing"
+		tick := PreviewNextTick(automation, &ContactAutomation{Status: ContactAutomationStatusActive, CurrentNodeID: &missing})
+		assert.Equal(t, NextActionExitNodeDeleted, tick.NextAction.Type)
+	})
+
+	t.Run("paused automation freezes the contact", func(t *testing.T) {
+		paused := *automation
+		paused.Status = AutomationStatusPaused
+		tick := PreviewNextTick(&paused, &ContactAutomation{Status: ContactAutomationStatusActive, CurrentNodeID: &emailNodeID})
+		assert.Equal(t, NextActionWaitAutomation, tick.NextAction.Type)
+	})
+
+	t.Run("finished contact has no next action", func(t *testing.T) {
+		tick := PreviewNextTick(automation, &ContactAutomation{Status: ContactAutomationStatusCompleted})
+		assert.Equal(t, NextActionNone, tick.NextAction.Type)
+	})
+}
+
 func TestABTestNodeConfig_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1681,3 +3446,134 @@ func TestABTestNodeConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestAutomation_WorkflowChanged(t *testing.T) {
+	next := "node_email"
+	base := func() *Automation {
+		return &Automation{
+			ID:         "auto1",
+			RootNodeID: "node_trigger",
+			Nodes: []*AutomationNode{
+				{ID: "node_trigger", Type: NodeTypeTrigger, NextNodeID: &next, Position: NodePosition{X: 0, Y: 0}},
+				{ID: "node_email", Type: NodeTypeEmail, Config: map[string]interface{}{"template_id": "tpl1"}},
+			},
+		}
+	}
+
+	t.Run("identical workflow", func(t *testing.T) {
+		assert.False(t, base().WorkflowChanged(base()))
+	})
+
+	t.Run("moved node is not a change", func(t *testing.T) {
+		moved := base()
+		moved.Nodes[0].Position = NodePosition{X: 300, Y: 120}
+		assert.False(t, base().WorkflowChanged(moved))
+	})
+
+	t.Run("config change", func(t *testing.T) {
+		edited := base()
+		edited.Nodes[1].Config = map[string]interface{}{"template_id": "tpl2"}
+		assert.True(t, base().WorkflowChanged(edited))
+	})
+
+	t.Run("root change", func(t *testing.T) {
+		edited := base()
+		edited.RootNodeID = "node_email"
+		assert.True(t, base().WorkflowChanged(edited))
+	})
+
+	t.Run("added node", func(t *testing.T) {
+		edited := base()
+		edited.Nodes = append(edited.Nodes, &AutomationNode{ID: "node_delay", Type: NodeTypeDelay})
+		assert.True(t, base().WorkflowChanged(edited))
+	})
+}
+
+func TestAutomation_WithVersion(t *testing.T) {
+	automation := &Automation{
+		ID:         "auto1",
+		Name:       "Welcome",
+		Version:    3,
+		RootNodeID: "node_new",
+		Nodes:      []*AutomationNode{{ID: "node_new", Type: NodeTypeTrigger}},
+	}
+
+	snapshot := (&Automation{
+		ID:         "auto1",
+		RootNodeID: "node_old",
+		Nodes:      []*AutomationNode{{ID: "node_old", Type: NodeTypeTrigger}},
+	}).NewVersion(1)
+	assert.Equal(t, "auto1", snapshot.AutomationID)
+	assert.Equal(t, 1, snapshot.Version)
+
+	pinned := automation.WithVersion(snapshot)
+	assert.Equal(t, 1, pinned.Version)
+	assert.Equal(t, "node_old", pinned.RootNodeID)
+	assert.Equal(t, "Welcome", pinned.Name)
+	assert.NotNil(t, pinned.GetNodeByID("node_old"))
+
+	// The live automation is left untouched
+	assert.Equal(t, 3, automation.Version)
+	assert.Equal(t, "node_new", automation.RootNodeID)
+}
+
+func TestEnrollmentRamp_Validate(t *testing.T) {
+	assert.NoError(t, (&EnrollmentRamp{PerMinute: 100, DurationMinutes: 30}).Validate())
+	assert.Error(t, (&EnrollmentRamp{PerMinute: 0, DurationMinutes: 30}).Validate())
+	assert.Error(t, (&EnrollmentRamp{PerMinute: 100, DurationMinutes: 0}).Validate())
+	assert.Error(t, (&EnrollmentRamp{PerMinute: 100, DurationMinutes: 7*24*60 + 1}).Validate())
+}
+
+func TestEnrollmentRamp_ActiveAt(t *testing.T) {
+	startedAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	ramp := &EnrollmentRamp{PerMinute: 10, DurationMinutes: 30, StartedAt: &startedAt}
+
+	assert.True(t, ramp.ActiveAt(startedAt.Add(29*time.Minute)))
+	assert.False(t, ramp.ActiveAt(startedAt.Add(30*time.Minute)))
+
+	// Not activated yet
+	assert.False(t, (&EnrollmentRamp{PerMinute: 10, DurationMinutes: 30}).ActiveAt(startedAt))
+	assert.False(t, (*EnrollmentRamp)(nil).ActiveAt(startedAt))
+}
+
+func TestThrottleNodeConfig_Validate(t *testing.T) {
+	assert.NoError(t, ThrottleNodeConfig{MaxPerMinute: 500}.Validate())
+	assert.NoError(t, ThrottleNodeConfig{MaxPerMinute: MaxThrottlePerMinute}.Validate())
+
+	err := ThrottleNodeConfig{}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max_per_minute must be greater than 0")
+
+	err = ThrottleNodeConfig{MaxPerMinute: MaxThrottlePerMinute + 1}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max_per_minute cannot exceed")
+
+	node := AutomationNode{Type: NodeTypeThrottle, Config: map[string]interface{}{"max_per_minute": 0}}
+	assert.Error(t, node.ValidateConfig())
+	node.Config["max_per_minute"] = 500
+	assert.NoError(t, node.ValidateConfig())
+	assert.True(t, NodeTypeThrottle.IsValid())
+}
+
+func TestReenrollNodeConfig_Validate(t *testing.T) {
+	assert.NoError(t, ReenrollNodeConfig{MaxReenrollments: 3}.Validate())
+	assert.NoError(t, ReenrollNodeConfig{MaxReenrollments: MaxReenrollments}.Validate())
+
+	err := ReenrollNodeConfig{}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max_reenrollments must be greater than 0")
+
+	err = ReenrollNodeConfig{MaxReenrollments: MaxReenrollments + 1}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max_reenrollments cannot exceed")
+
+	err = ReenrollNodeConfig{MaxReenrollments: 3, Conditions: &TreeNode{Kind: "leaf"}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conditions")
+
+	node := AutomationNode{Type: NodeTypeReenroll, Config: map[string]interface{}{"max_reenrollments": 0}}
+	assert.Error(t, node.ValidateConfig())
+	node.Config["max_reenrollments"] = 3
+	assert.NoError(t, node.ValidateConfig())
+	assert.True(t, NodeTypeReenroll.IsValid())
+}
@@ -148,11 +148,38 @@ func (m *VariationMetrics) Scan(value interface{}) error {
 	return json.Unmarshal(cloned, m)
 }
 
-// AudienceSettings defines how recipients are determined for a broadcast
+// AudienceSettings defines how recipients are determined for a broadcast.
+// List and Lists are combined into a single audience (union): a contact subscribed
+// to several targeted lists receives the broadcast only once.
 type AudienceSettings struct {
-	List                string   `json:"list,omitempty"`
-	Segments            []string `json:"segments,omitempty"`
-	ExcludeUnsubscribed bool     `json:"exclude_unsubscribed"`
+	List                       string   `json:"list,omitempty"`
+	Lists                      []string `json:"lists,omitempty"` // Additional lists targeted alongside List
+	Segments                   []string `json:"segments,omitempty"`
+	ExcludeUnsubscribed        bool     `json:"exclude_unsubscribed"`
+	ExcludeActiveInAutomations bool     `json:"exclude_active_in_automations,omitempty"` // Skip contacts currently active in an automation
+	ExcludeOpenersOf           string   `json:"exclude_openers_of,omitempty"`            // Skip contacts who opened or clicked this broadcast, e.g. to resend to non-openers
+}
+
+// ListIDs returns every targeted list without duplicates, List first
+func (a AudienceSettings) ListIDs() []string {
+	ids := make([]string, 0, len(a.Lists)+1)
+	seen := make(map[string]bool, len(a.Lists)+1)
+	for _, id := range append([]string{a.List}, a.Lists...) {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// PrimaryList returns the list used when a recipient cannot be attributed to a specific list
+func (a AudienceSettings) PrimaryList() string {
+	if ids := a.ListIDs(); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
 }
 
 // Value implements the driver.Valuer interface for database serialization
@@ -182,6 +209,87 @@ type ScheduleSettings struct {
 	ScheduledTime        string `json:"scheduled_time,omitempty"` // Format: HH:mm
 	Timezone             string `json:"timezone,omitempty"`       // IANA timezone format, e.g. "America/New_York"
 	UseRecipientTimezone bool   `json:"use_recipient_timezone"`
+	// TriggerMode "list_join" keeps the broadcast running and sends it to each list member
+	// once they have been on the list for JoinOffset
+	TriggerMode    BroadcastTriggerMode `json:"trigger_mode,omitempty"`
+	JoinOffset     int                  `json:"join_offset,omitempty"`
+	JoinOffsetUnit string               `json:"join_offset_unit,omitempty"` // "minutes", "hours", "days"
+	// RampSchedule ramps send volume up within the broadcast, e.g. to warm up an IP
+	RampSchedule *BroadcastRampSchedule `json:"ramp_schedule,omitempty"`
+}
+
+// BroadcastRampSchedule caps how many emails a broadcast sends in each successive interval
+// after it starts: Steps[0] in the first interval, Steps[1] in the second, and so on. Once
+// the steps are used up, the remaining recipients are sent at full speed.
+type BroadcastRampSchedule struct {
+	IntervalMinutes int   `json:"interval_minutes"`
+	Steps           []int `json:"steps"`
+}
+
+// Validate validates the ramp schedule
+func (r *BroadcastRampSchedule) Validate() error {
+	if r.IntervalMinutes <= 0 {
+		return fmt.Errorf("ramp_schedule.interval_minutes must be greater than 0")
+	}
+	if r.IntervalMinutes > 10080 {
+		return fmt.Errorf("ramp_schedule.interval_minutes cannot exceed 10080 (7 days)")
+	}
+	if len(r.Steps) == 0 {
+		return fmt.Errorf("ramp_schedule.steps must contain at least one step")
+	}
+	if len(r.Steps) > 100 {
+		return fmt.Errorf("ramp_schedule.steps cannot contain more than 100 steps")
+	}
+	for i, step := range r.Steps {
+		if step <= 0 {
+			return fmt.Errorf("ramp_schedule.steps[%d] must be greater than 0", i)
+		}
+	}
+	return nil
+}
+
+// ReleaseAt returns when the recipient at position (0-based, in send order) may be sent,
+// for a broadcast that started sending at startedAt
+func (r *BroadcastRampSchedule) ReleaseAt(startedAt time.Time, position int) time.Time {
+	interval := time.Duration(r.IntervalMinutes) * time.Minute
+	cumulative := 0
+	for i, step := range r.Steps {
+		cumulative += step
+		if position < cumulative {
+			return startedAt.Add(time.Duration(i) * interval)
+		}
+	}
+	return startedAt.Add(time.Duration(len(r.Steps)) * interval)
+}
+
+// BroadcastTriggerMode defines how a broadcast selects when each recipient receives it
+type BroadcastTriggerMode string
+
+const (
+	// BroadcastTriggerModeOnce sends the broadcast to the whole audience in a single run (default)
+	BroadcastTriggerModeOnce BroadcastTriggerMode = "once"
+	// BroadcastTriggerModeListJoin sends the broadcast to each contact an offset after they joined the list
+	BroadcastTriggerModeListJoin BroadcastTriggerMode = "list_join"
+)
+
+// IsListJoin reports whether the broadcast is sent relative to each contact's list join time
+func (s ScheduleSettings) IsListJoin() bool {
+	return s.TriggerMode == BroadcastTriggerModeListJoin
+}
+
+// JoinOffsetDuration returns how long a contact must have been on the list before receiving
+// a list-join broadcast
+func (s ScheduleSettings) JoinOffsetDuration() time.Duration {
+	switch s.JoinOffsetUnit {
+	case "minutes":
+		return time.Duration(s.JoinOffset) * time.Minute
+	case "hours":
+		return time.Duration(s.JoinOffset) * time.Hour
+	case "days":
+		return time.Duration(s.JoinOffset) * 24 * time.Hour
+	default:
+		return 0
+	}
 }
 
 // Value implements the driver.Valuer interface for database serialization
@@ -388,9 +496,12 @@ func (b *Broadcast) Validate() error {
 
 	// Validate audience settings
 	// CHANGED: List is required (for all broadcasts, not just web)
-	if b.Audience.List == "" {
+	if len(b.Audience.ListIDs()) == 0 {
 		return fmt.Errorf("list is required")
 	}
+	if b.Audience.ExcludeOpenersOf != "" && b.Audience.ExcludeOpenersOf == b.ID {
+		return fmt.Errorf("exclude_openers_of cannot reference the broadcast itself")
+	}
 
 	// Validate schedule settings
 	if b.Schedule.IsScheduled && (b.Schedule.ScheduledDate == "" || b.Schedule.ScheduledTime == "") {
@@ -417,6 +528,36 @@ func (b *Broadcast) Validate() error {
 		}
 	}
 
+	switch b.Schedule.TriggerMode {
+	case "", BroadcastTriggerModeOnce:
+	case BroadcastTriggerModeListJoin:
+		if b.Schedule.JoinOffset <= 0 {
+			return fmt.Errorf("join_offset must be greater than 0 for list_join broadcasts")
+		}
+		switch b.Schedule.JoinOffsetUnit {
+		case "minutes", "hours", "days":
+		default:
+			return fmt.Errorf("invalid join_offset_unit: %s (must be minutes, hours, or days)", b.Schedule.JoinOffsetUnit)
+		}
+		if len(b.Audience.ListIDs()) != 1 || len(b.Audience.Segments) > 0 {
+			return fmt.Errorf("list_join broadcasts must target exactly one list and no segments")
+		}
+		if b.TestSettings.Enabled {
+			return fmt.Errorf("A/B testing is not supported for list_join broadcasts")
+		}
+	default:
+		return fmt.Errorf("invalid trigger_mode: %s", b.Schedule.TriggerMode)
+	}
+
+	if b.Schedule.RampSchedule != nil {
+		if err := b.Schedule.RampSchedule.Validate(); err != nil {
+			return err
+		}
+		if b.Schedule.IsListJoin() {
+			return fmt.Errorf("ramp_schedule is not supported for list_join broadcasts")
+		}
+	}
+
 	// Validate data feed settings if present
 	if b.DataFeed != nil {
 		if err := b.DataFeed.Validate(); err != nil {
@@ -543,6 +684,9 @@ type ScheduleBroadcastRequest struct {
 	ScheduledTime        string `json:"scheduled_time,omitempty"`
 	Timezone             string `json:"timezone,omitempty"`
 	UseRecipientTimezone bool   `json:"use_recipient_timezone"`
+	// CheckIntegration probes the marketing email integration before scheduling
+	// and rejects the request if it cannot connect or authenticate
+	CheckIntegration bool `json:"check_integration,omitempty"`
 }
 
 // Validate validates the schedule broadcast request
@@ -640,6 +784,25 @@ func (r *CancelBroadcastRequest) Validate() error {
 	return nil
 }
 
+// CancelBroadcastScheduleRequest defines the request to revert a scheduled broadcast to draft
+type CancelBroadcastScheduleRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	ID          string `json:"id"`
+}
+
+// Validate validates the cancel broadcast schedule request
+func (r *CancelBroadcastScheduleRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+
+	if r.ID == "" {
+		return fmt.Errorf("broadcast id is required")
+	}
+
+	return nil
+}
+
 // DeleteBroadcastRequest defines the request to delete a broadcast
 type DeleteBroadcastRequest struct {
 	WorkspaceID string `json:"workspace_id"`
@@ -865,10 +1028,12 @@ type TestResultsResponse struct {
 
 // RefreshGlobalFeedRequest defines the request to refresh global feed data
 type RefreshGlobalFeedRequest struct {
-	WorkspaceID string           `json:"workspace_id"`
-	BroadcastID string           `json:"broadcast_id"`
-	URL         string           `json:"url"`
-	Headers     []DataFeedHeader `json:"headers"`
+	WorkspaceID string            `json:"workspace_id"`
+	BroadcastID string            `json:"broadcast_id"`
+	URL         string            `json:"url"`
+	Headers     []DataFeedHeader  `json:"headers"`
+	Auth        *DataFeedAuth     `json:"auth,omitempty"`
+	FieldMap    map[string]string `json:"field_map,omitempty"`
 }
 
 // Validate validates the refresh global feed request
@@ -903,6 +1068,10 @@ func (r *RefreshGlobalFeedRequest) Validate() error {
 		}
 	}
 
+	if err := ValidateFeedFieldMap(r.FieldMap); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -925,11 +1094,13 @@ func (e *ErrContactNotFoundForFeed) Error() string {
 
 // TestRecipientFeedRequest defines the request to test recipient feed
 type TestRecipientFeedRequest struct {
-	WorkspaceID  string           `json:"workspace_id"`
-	BroadcastID  string           `json:"broadcast_id"`
-	ContactEmail string           `json:"contact_email,omitempty"`
-	URL          string           `json:"url"`
-	Headers      []DataFeedHeader `json:"headers"`
+	WorkspaceID  string            `json:"workspace_id"`
+	BroadcastID  string            `json:"broadcast_id"`
+	ContactEmail string            `json:"contact_email,omitempty"`
+	URL          string            `json:"url"`
+	Headers      []DataFeedHeader  `json:"headers"`
+	Auth         *DataFeedAuth     `json:"auth,omitempty"`
+	FieldMap     map[string]string `json:"field_map,omitempty"`
 }
 
 // Validate validates the test recipient feed request
@@ -964,6 +1135,10 @@ func (r *TestRecipientFeedRequest) Validate() error {
 		}
 	}
 
+	if err := ValidateFeedFieldMap(r.FieldMap); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -977,6 +1152,54 @@ type TestRecipientFeedResponse struct {
 	ContactEmail string `json:"contact_email,omitempty"`
 }
 
+// Sample recipients rendered by a broadcast preview
+const (
+	DefaultBroadcastPreviewRecipients = 3
+	MaxBroadcastPreviewRecipients     = 10
+)
+
+// PreviewRenderedBroadcastRequest defines the request to render a broadcast for sample recipients
+type PreviewRenderedBroadcastRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	BroadcastID string `json:"broadcast_id"`
+	TemplateID  string `json:"template_id,omitempty"` // Variation to render, defaults to the first one
+	Limit       int    `json:"limit,omitempty"`       // Sample recipients, DefaultBroadcastPreviewRecipients when 0
+}
+
+// Validate validates the preview rendered broadcast request
+func (r *PreviewRenderedBroadcastRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+
+	if r.BroadcastID == "" {
+		return fmt.Errorf("broadcast_id is required")
+	}
+
+	if r.Limit < 0 || r.Limit > MaxBroadcastPreviewRecipients {
+		return fmt.Errorf("limit must be between 1 and %d", MaxBroadcastPreviewRecipients)
+	}
+
+	return nil
+}
+
+// BroadcastRecipientPreview is the email a sample recipient would receive
+type BroadcastRecipientPreview struct {
+	Email   string `json:"email"`
+	ListID  string `json:"list_id,omitempty"`
+	Subject string `json:"subject,omitempty"`
+	HTML    string `json:"html,omitempty"`
+	// Error is set instead of Subject and HTML when the email could not be rendered
+	// for this recipient, e.g. a failing recipient feed
+	Error string `json:"error,omitempty"`
+}
+
+// PreviewRenderedBroadcastResponse defines the response for preview rendered broadcast
+type PreviewRenderedBroadcastResponse struct {
+	TemplateID string                      `json:"template_id"`
+	Previews   []BroadcastRecipientPreview `json:"previews"`
+}
+
 // BroadcastService defines the interface for broadcast operations
 type BroadcastService interface {
 	// CreateBroadcast creates a new broadcast
@@ -1003,6 +1226,9 @@ type BroadcastService interface {
 	// CancelBroadcast cancels a scheduled broadcast
 	CancelBroadcast(ctx context.Context, request *CancelBroadcastRequest) error
 
+	// CancelBroadcastSchedule reverts a scheduled broadcast to draft without sending it
+	CancelBroadcastSchedule(ctx context.Context, request *CancelBroadcastScheduleRequest) error
+
 	// DeleteBroadcast deletes a broadcast
 	DeleteBroadcast(ctx context.Context, request *DeleteBroadcastRequest) error
 
@@ -1020,6 +1246,9 @@ type BroadcastService interface {
 
 	// TestRecipientFeed tests the recipient feed configuration with a sample or specified contact
 	TestRecipientFeed(ctx context.Context, request *TestRecipientFeedRequest) (*TestRecipientFeedResponse, error)
+
+	// PreviewRendered renders the subject and body a few sample recipients of the audience would receive
+	PreviewRendered(ctx context.Context, request *PreviewRenderedBroadcastRequest) (*PreviewRenderedBroadcastResponse, error)
 }
 
 // BroadcastSender is a minimal interface needed for sending broadcasts,
@@ -166,6 +166,35 @@ func TestBroadcast_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "list is required",
 		},
+		{
+			name: "exclude openers of another broadcast (valid)",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcast()
+				b.Audience.ExcludeOpenersOf = "other-broadcast"
+				return b
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "exclude openers of itself",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcast()
+				b.Audience.ExcludeOpenersOf = b.ID
+				return b
+			}(),
+			wantErr: true,
+			errMsg:  "exclude_openers_of cannot reference the broadcast itself",
+		},
+		{
+			name: "multiple lists without primary list (valid)",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcast()
+				b.Audience.List = ""
+				b.Audience.Lists = []string{"list1", "list2"}
+				return b
+			}(),
+			wantErr: false,
+		},
 		{
 			name: "list and segments specified (valid - segments filter list)",
 			broadcast: func() domain.Broadcast {
@@ -313,6 +342,75 @@ func TestBroadcast_Validate(t *testing.T) {
 			}(),
 			wantErr: false,
 		},
+		{
+			name: "valid list_join broadcast",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcast()
+				b.Schedule.TriggerMode = domain.BroadcastTriggerModeListJoin
+				b.Schedule.JoinOffset = 3
+				b.Schedule.JoinOffsetUnit = "days"
+				return b
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "list_join without offset",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcast()
+				b.Schedule.TriggerMode = domain.BroadcastTriggerModeListJoin
+				b.Schedule.JoinOffsetUnit = "days"
+				return b
+			}(),
+			wantErr: true,
+			errMsg:  "join_offset must be greater than 0",
+		},
+		{
+			name: "list_join with invalid unit",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcast()
+				b.Schedule.TriggerMode = domain.BroadcastTriggerModeListJoin
+				b.Schedule.JoinOffset = 3
+				b.Schedule.JoinOffsetUnit = "weeks"
+				return b
+			}(),
+			wantErr: true,
+			errMsg:  "invalid join_offset_unit",
+		},
+		{
+			name: "list_join with several lists",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcast()
+				b.Audience.Lists = []string{"list456"}
+				b.Schedule.TriggerMode = domain.BroadcastTriggerModeListJoin
+				b.Schedule.JoinOffset = 3
+				b.Schedule.JoinOffsetUnit = "days"
+				return b
+			}(),
+			wantErr: true,
+			errMsg:  "must target exactly one list",
+		},
+		{
+			name: "list_join with A/B test",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcastWithTest()
+				b.Schedule.TriggerMode = domain.BroadcastTriggerModeListJoin
+				b.Schedule.JoinOffset = 3
+				b.Schedule.JoinOffsetUnit = "days"
+				return b
+			}(),
+			wantErr: true,
+			errMsg:  "A/B testing is not supported",
+		},
+		{
+			name: "invalid trigger mode",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcast()
+				b.Schedule.TriggerMode = "weekly"
+				return b
+			}(),
+			wantErr: true,
+			errMsg:  "invalid trigger_mode",
+		},
 	}
 
 	for _, tt := range tests {
@@ -723,6 +821,83 @@ func TestPauseBroadcastRequest_Validate(t *testing.T) {
 	}
 }
 
+func TestPreviewRenderedBroadcastRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domain.PreviewRenderedBroadcastRequest
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "valid request with default limit",
+			request: domain.PreviewRenderedBroadcastRequest{
+				WorkspaceID: "workspace123",
+				BroadcastID: "broadcast123",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid request with max limit",
+			request: domain.PreviewRenderedBroadcastRequest{
+				WorkspaceID: "workspace123",
+				BroadcastID: "broadcast123",
+				Limit:       domain.MaxBroadcastPreviewRecipients,
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing workspace ID",
+			request: domain.PreviewRenderedBroadcastRequest{
+				BroadcastID: "broadcast123",
+			},
+			wantErr: true,
+			errMsg:  "workspace_id is required",
+		},
+		{
+			name: "missing broadcast ID",
+			request: domain.PreviewRenderedBroadcastRequest{
+				WorkspaceID: "workspace123",
+			},
+			wantErr: true,
+			errMsg:  "broadcast_id is required",
+		},
+		{
+			name: "limit too large",
+			request: domain.PreviewRenderedBroadcastRequest{
+				WorkspaceID: "workspace123",
+				BroadcastID: "broadcast123",
+				Limit:       domain.MaxBroadcastPreviewRecipients + 1,
+			},
+			wantErr: true,
+			errMsg:  "limit must be between 1 and 10",
+		},
+		{
+			name: "negative limit",
+			request: domain.PreviewRenderedBroadcastRequest{
+				WorkspaceID: "workspace123",
+				BroadcastID: "broadcast123",
+				Limit:       -1,
+			},
+			wantErr: true,
+			errMsg:  "limit must be between 1 and 10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errMsg != "" {
+					assert.Contains(t, err.Error(), tt.errMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestResumeBroadcastRequest_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1256,7 +1431,111 @@ func TestAudienceSettings_ValueScan(t *testing.T) {
 	assert.Contains(t, err.Error(), "type assertion to []byte failed")
 }
 
+func TestAudienceSettings_ListIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		audience domain.AudienceSettings
+		expected []string
+		primary  string
+	}{
+		{"single list", domain.AudienceSettings{List: "list1"}, []string{"list1"}, "list1"},
+		{"list and additional lists", domain.AudienceSettings{List: "list1", Lists: []string{"list2", "list3"}}, []string{"list1", "list2", "list3"}, "list1"},
+		{"duplicates and empty ids removed", domain.AudienceSettings{List: "list1", Lists: []string{"list2", "list1", "", "list2"}}, []string{"list1", "list2"}, "list1"},
+		{"additional lists only", domain.AudienceSettings{Lists: []string{"list2"}}, []string{"list2"}, "list2"},
+		{"no lists", domain.AudienceSettings{}, []string{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.audience.ListIDs())
+			assert.Equal(t, tt.primary, tt.audience.PrimaryList())
+		})
+	}
+}
+
 // TestScheduleSettings_SetScheduledDateTime tests the SetScheduledDateTime method
+func TestScheduleSettings_JoinOffsetDuration(t *testing.T) {
+	tests := []struct {
+		offset int
+		unit   string
+		want   time.Duration
+	}{
+		{30, "minutes", 30 * time.Minute},
+		{2, "hours", 2 * time.Hour},
+		{3, "days", 72 * time.Hour},
+		{3, "", 0},
+	}
+
+	for _, tt := range tests {
+		s := domain.ScheduleSettings{JoinOffset: tt.offset, JoinOffsetUnit: tt.unit}
+		assert.Equal(t, tt.want, s.JoinOffsetDuration(), "%d %s", tt.offset, tt.unit)
+	}
+	assert.True(t, domain.ScheduleSettings{TriggerMode: domain.BroadcastTriggerModeListJoin}.IsListJoin())
+	assert.False(t, domain.ScheduleSettings{}.IsListJoin())
+}
+
+func TestBroadcastRampSchedule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ramp    domain.BroadcastRampSchedule
+		wantErr string
+	}{
+		{"valid", domain.BroadcastRampSchedule{IntervalMinutes: 60, Steps: []int{100, 500, 1000}}, ""},
+		{"missing interval", domain.BroadcastRampSchedule{Steps: []int{100}}, "ramp_schedule.interval_minutes must be greater than 0"},
+		{"interval too long", domain.BroadcastRampSchedule{IntervalMinutes: 10081, Steps: []int{100}}, "ramp_schedule.interval_minutes cannot exceed 10080 (7 days)"},
+		{"no steps", domain.BroadcastRampSchedule{IntervalMinutes: 60}, "ramp_schedule.steps must contain at least one step"},
+		{"too many steps", domain.BroadcastRampSchedule{IntervalMinutes: 60, Steps: make([]int, 101)}, "ramp_schedule.steps cannot contain more than 100 steps"},
+		{"empty step", domain.BroadcastRampSchedule{IntervalMinutes: 60, Steps: []int{100, 0}}, "ramp_schedule.steps[1] must be greater than 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ramp.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("broadcast rejects an invalid ramp", func(t *testing.T) {
+		b := createValidBroadcast()
+		b.Schedule.RampSchedule = &domain.BroadcastRampSchedule{IntervalMinutes: 60}
+		assert.EqualError(t, b.Validate(), "ramp_schedule.steps must contain at least one step")
+	})
+
+	t.Run("broadcast rejects a ramp on list_join broadcasts", func(t *testing.T) {
+		b := createValidBroadcast()
+		b.Schedule.TriggerMode = domain.BroadcastTriggerModeListJoin
+		b.Schedule.JoinOffset = 1
+		b.Schedule.JoinOffsetUnit = "days"
+		b.Schedule.RampSchedule = &domain.BroadcastRampSchedule{IntervalMinutes: 60, Steps: []int{100}}
+		assert.EqualError(t, b.Validate(), "ramp_schedule is not supported for list_join broadcasts")
+	})
+}
+
+func TestBroadcastRampSchedule_ReleaseAt(t *testing.T) {
+	startedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	ramp := domain.BroadcastRampSchedule{IntervalMinutes: 30, Steps: []int{2, 3}}
+
+	tests := []struct {
+		position int
+		want     time.Duration
+	}{
+		{0, 0},
+		{1, 0},
+		{2, 30 * time.Minute},
+		{4, 30 * time.Minute},
+		{5, 60 * time.Minute},
+		{500, 60 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, startedAt.Add(tt.want), ramp.ReleaseAt(startedAt, tt.position), "position %d", tt.position)
+	}
+}
+
 func TestScheduleSettings_SetScheduledDateTime(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,55 @@
+package domain
+
+// BulkItemStatus is the outcome of a single item of a bulk operation
+type BulkItemStatus string
+
+const (
+	BulkItemStatusCreated BulkItemStatus = "created"
+	BulkItemStatusUpdated BulkItemStatus = "updated"
+	BulkItemStatusSkipped BulkItemStatus = "skipped"
+	BulkItemStatusFailed  BulkItemStatus = "failed"
+)
+
+// BulkItemResult reports the outcome of one item, identified by its ID (or email for contacts)
+type BulkItemResult struct {
+	ID     string         `json:"id"`
+	Status BulkItemStatus `json:"status"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// BulkOperationSummary counts the items of a bulk operation by outcome
+type BulkOperationSummary struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
+
+// BulkOperationResult is the standard response shape of bulk endpoints: items are
+// processed independently, so some can fail while the others succeed
+type BulkOperationResult struct {
+	Results []BulkItemResult     `json:"results"`
+	Summary BulkOperationSummary `json:"summary"`
+}
+
+// NewBulkOperationResult creates an empty result with room for size items
+func NewBulkOperationResult(size int) *BulkOperationResult {
+	return &BulkOperationResult{Results: make([]BulkItemResult, 0, size)}
+}
+
+// Add records the outcome of an item and updates the summary.
+// The reason is reported as the item error for skipped and failed items.
+func (r *BulkOperationResult) Add(id string, status BulkItemStatus, reason string) {
+	r.Results = append(r.Results, BulkItemResult{ID: id, Status: status, Error: reason})
+
+	switch status {
+	case BulkItemStatusCreated:
+		r.Summary.Created++
+	case BulkItemStatusUpdated:
+		r.Summary.Updated++
+	case BulkItemStatusSkipped:
+		r.Summary.Skipped++
+	case BulkItemStatusFailed:
+		r.Summary.Failed++
+	}
+}
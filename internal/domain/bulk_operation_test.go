@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkOperationResult_Add(t *testing.T) {
+	result := NewBulkOperationResult(4)
+	result.Add("a@example.com", BulkItemStatusCreated, "")
+	result.Add("b@example.com", BulkItemStatusUpdated, "")
+	result.Add("c@example.com", BulkItemStatusSkipped, "already enrolled")
+	result.Add("d", BulkItemStatusFailed, "invalid email")
+
+	assert.Len(t, result.Results, 4)
+	assert.Equal(t, BulkOperationSummary{Created: 1, Updated: 1, Skipped: 1, Failed: 1}, result.Summary)
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"results": [
+			{"id": "a@example.com", "status": "created"},
+			{"id": "b@example.com", "status": "updated"},
+			{"id": "c@example.com", "status": "skipped", "error": "already enrolled"},
+			{"id": "d", "status": "failed", "error": "invalid email"}
+		],
+		"summary": {"created": 1, "updated": 1, "skipped": 1, "failed": 1}
+	}`, string(data))
+}
+
+func TestNewBulkOperationResult_EmptyResultsEncodeAsArray(t *testing.T) {
+	data, err := json.Marshal(NewBulkOperationResult(0))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"results": [], "summary": {"created": 0, "updated": 0, "skipped": 0, "failed": 0}}`, string(data))
+}
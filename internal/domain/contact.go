@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"strconv"
 	"strings"
@@ -69,6 +70,10 @@ type Contact struct {
 	CustomJSON4 *NullableJSON `json:"custom_json_4,omitempty" valid:"optional"`
 	CustomJSON5 *NullableJSON `json:"custom_json_5,omitempty" valid:"optional"`
 
+	// MessagingHoldUntil defers broadcast and automation emails to the contact until it
+	// passes, e.g. while a support agent is handling a ticket. Transactional emails are sent.
+	MessagingHoldUntil *NullableTime `json:"messaging_hold_until,omitempty" valid:"optional"`
+
 	// Timestamps
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -159,6 +164,8 @@ type dbContact struct {
 	UpdatedAt   time.Time
 	DBCreatedAt time.Time
 	DBUpdatedAt time.Time
+
+	MessagingHoldUntil sql.NullTime
 }
 
 // ScanContact scans a contact from the database
@@ -207,6 +214,7 @@ func ScanContact(scanner interface {
 		&dbc.UpdatedAt,
 		&dbc.DBCreatedAt,
 		&dbc.DBUpdatedAt,
+		&dbc.MessagingHoldUntil,
 	)
 
 	if err != nil {
@@ -332,6 +340,10 @@ func ScanContact(scanner interface {
 		c.CustomDatetime5 = &NullableTime{Time: dbc.CustomDatetime5.Time, IsNull: false}
 	}
 
+	if dbc.MessagingHoldUntil.Valid {
+		c.MessagingHoldUntil = &NullableTime{Time: dbc.MessagingHoldUntil.Time, IsNull: false}
+	}
+
 	// Handle JSON fields
 	if len(dbc.CustomJSON1) > 0 && string(dbc.CustomJSON1) != "null" {
 		var data interface{}
@@ -557,6 +569,23 @@ func (r *UpsertContactRequest) Validate() (contact *Contact, workspaceID string,
 type BatchImportContactsResponse struct {
 	Operations []*UpsertContactOperation `json:"operations"`
 	Error      string                    `json:"error,omitempty"`
+	// Results and Summary report the operations in the standard bulk operation shape
+	BulkOperationResult
+}
+
+// Summarize fills the bulk operation results and summary from the operations
+func (r *BatchImportContactsResponse) Summarize() {
+	r.BulkOperationResult = *NewBulkOperationResult(len(r.Operations))
+	for _, op := range r.Operations {
+		switch op.Action {
+		case UpsertContactOperationCreate:
+			r.Add(op.Email, BulkItemStatusCreated, "")
+		case UpsertContactOperationUpdate:
+			r.Add(op.Email, BulkItemStatusUpdated, "")
+		default:
+			r.Add(op.Email, BulkItemStatusFailed, op.Error)
+		}
+	}
 }
 
 const (
@@ -601,6 +630,9 @@ type ContactService interface {
 
 	// CountContacts returns the total number of contacts in a workspace
 	CountContacts(ctx context.Context, workspaceID string) (int, error)
+
+	// ExportContacts streams the contacts matching the request filters to w as CSV
+	ExportContacts(ctx context.Context, req *ExportContactsRequest, w io.Writer) error
 }
 
 // ContactRepository is the interface for contact operations
@@ -633,6 +665,10 @@ type ContactRepository interface {
 	// Uses cursor-based pagination: afterEmail is the last email from the previous batch (empty for first batch)
 	GetContactsForBroadcast(ctx context.Context, workspaceID string, audience AudienceSettings, limit int, afterEmail string) ([]*ContactWithList, error)
 
+	// GetContactsJoinedListBefore retrieves active members of a list who joined at or before joinedBefore,
+	// ordered by join time then email. The (afterJoinedAt, afterEmail) cursor is the last member of the previous batch
+	GetContactsJoinedListBefore(ctx context.Context, workspaceID string, listID string, joinedBefore time.Time, afterJoinedAt time.Time, afterEmail string, limit int) ([]*ContactWithList, error)
+
 	// CountContactsForBroadcast counts contacts based on broadcast audience settings
 	CountContactsForBroadcast(ctx context.Context, workspaceID string, audience AudienceSettings) (int, error)
 
@@ -773,6 +809,10 @@ func FromJSON(data interface{}) (*Contact, error) {
 		return nil, err
 	}
 
+	if err := parseNullableTime(jsonResult, "messaging_hold_until", &contact.MessagingHoldUntil); err != nil {
+		return nil, err
+	}
+
 	// Parse custom JSON fields if they exist
 	for i := 1; i <= 5; i++ {
 		field := fmt.Sprintf("custom_json_%d", i)
@@ -999,6 +1039,10 @@ func (c *Contact) Merge(other *Contact) {
 		c.CustomJSON5 = other.CustomJSON5
 	}
 
+	if other.MessagingHoldUntil != nil {
+		c.MessagingHoldUntil = other.MessagingHoldUntil
+	}
+
 	// Update timestamps
 	if !other.CreatedAt.IsZero() {
 		c.CreatedAt = other.CreatedAt
@@ -1057,7 +1101,11 @@ func (c *Contact) ToMapOfAny() (MapOfAny, error) {
 
 // ContactWithList represents a contact with information about which list it belongs to
 type ContactWithList struct {
-	Contact  *Contact `json:"contact"`   // The contact
-	ListID   string   `json:"list_id"`   // ID of the list that the contact belongs to
-	ListName string   `json:"list_name"` // Name of the list that the contact belongs to
+	Contact  *Contact  `json:"contact"`   // The contact
+	ListID   string    `json:"list_id"`   // ID of the list that the contact belongs to
+	ListName string    `json:"list_name"` // Name of the list that the contact belongs to
+	IsSeed   bool      `json:"-"`         // Seed inbox copy, sent without tracking and kept out of broadcast stats
+	JoinedAt time.Time `json:"-"`         // When the contact joined the list, set by list-join queries
+	// SendAfter holds back the recipient's email until the given time, set by broadcast ramp schedules
+	SendAfter *time.Time `json:"-"`
 }
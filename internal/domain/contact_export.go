@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContactExportBatchSize is the number of contacts fetched per cursor page while streaming an export
+const ContactExportBatchSize = 500
+
+// DefaultContactExportColumns are exported when the request does not select columns
+var DefaultContactExportColumns = []string{
+	"email",
+	"external_id",
+	"first_name",
+	"last_name",
+	"country",
+	"language",
+	"timezone",
+	"created_at",
+}
+
+// contactExportColumns maps each exportable column to its CSV cell value
+var contactExportColumns = map[string]func(c *Contact) string{
+	"email":             func(c *Contact) string { return c.Email },
+	"external_id":       func(c *Contact) string { return exportString(c.ExternalID) },
+	"timezone":          func(c *Contact) string { return exportString(c.Timezone) },
+	"language":          func(c *Contact) string { return exportString(c.Language) },
+	"first_name":        func(c *Contact) string { return exportString(c.FirstName) },
+	"last_name":         func(c *Contact) string { return exportString(c.LastName) },
+	"full_name":         func(c *Contact) string { return exportString(c.FullName) },
+	"phone":             func(c *Contact) string { return exportString(c.Phone) },
+	"address_line_1":    func(c *Contact) string { return exportString(c.AddressLine1) },
+	"address_line_2":    func(c *Contact) string { return exportString(c.AddressLine2) },
+	"country":           func(c *Contact) string { return exportString(c.Country) },
+	"postcode":          func(c *Contact) string { return exportString(c.Postcode) },
+	"state":             func(c *Contact) string { return exportString(c.State) },
+	"job_title":         func(c *Contact) string { return exportString(c.JobTitle) },
+	"custom_string_1":   func(c *Contact) string { return exportString(c.CustomString1) },
+	"custom_string_2":   func(c *Contact) string { return exportString(c.CustomString2) },
+	"custom_string_3":   func(c *Contact) string { return exportString(c.CustomString3) },
+	"custom_string_4":   func(c *Contact) string { return exportString(c.CustomString4) },
+	"custom_string_5":   func(c *Contact) string { return exportString(c.CustomString5) },
+	"custom_number_1":   func(c *Contact) string { return exportNumber(c.CustomNumber1) },
+	"custom_number_2":   func(c *Contact) string { return exportNumber(c.CustomNumber2) },
+	"custom_number_3":   func(c *Contact) string { return exportNumber(c.CustomNumber3) },
+	"custom_number_4":   func(c *Contact) string { return exportNumber(c.CustomNumber4) },
+	"custom_number_5":   func(c *Contact) string { return exportNumber(c.CustomNumber5) },
+	"custom_datetime_1": func(c *Contact) string { return exportTime(c.CustomDatetime1) },
+	"custom_datetime_2": func(c *Contact) string { return exportTime(c.CustomDatetime2) },
+	"custom_datetime_3": func(c *Contact) string { return exportTime(c.CustomDatetime3) },
+	"custom_datetime_4": func(c *Contact) string { return exportTime(c.CustomDatetime4) },
+	"custom_datetime_5": func(c *Contact) string { return exportTime(c.CustomDatetime5) },
+	"custom_json_1":     func(c *Contact) string { return exportJSON(c.CustomJSON1) },
+	"custom_json_2":     func(c *Contact) string { return exportJSON(c.CustomJSON2) },
+	"custom_json_3":     func(c *Contact) string { return exportJSON(c.CustomJSON3) },
+	"custom_json_4":     func(c *Contact) string { return exportJSON(c.CustomJSON4) },
+	"custom_json_5":     func(c *Contact) string { return exportJSON(c.CustomJSON5) },
+	"created_at":        func(c *Contact) string { return c.CreatedAt.UTC().Format(time.RFC3339) },
+	"updated_at":        func(c *Contact) string { return c.UpdatedAt.UTC().Format(time.RFC3339) },
+}
+
+// IsValidContactExportColumn checks if the column can be exported
+func IsValidContactExportColumn(column string) bool {
+	_, ok := contactExportColumns[column]
+	return ok
+}
+
+// ContactExportRow returns the CSV cells of a contact for the given columns
+func ContactExportRow(contact *Contact, columns []string) []string {
+	row := make([]string, len(columns))
+	for i, column := range columns {
+		if value, ok := contactExportColumns[column]; ok {
+			row[i] = value(contact)
+		}
+	}
+	return row
+}
+
+func exportString(v *NullableString) string {
+	if v == nil || v.IsNull {
+		return ""
+	}
+	return v.String
+}
+
+func exportNumber(v *NullableFloat64) string {
+	if v == nil || v.IsNull {
+		return ""
+	}
+	return strconv.FormatFloat(v.Float64, 'f', -1, 64)
+}
+
+func exportTime(v *NullableTime) string {
+	if v == nil || v.IsNull {
+		return ""
+	}
+	return v.Time.UTC().Format(time.RFC3339)
+}
+
+func exportJSON(v *NullableJSON) string {
+	if v == nil || v.IsNull || v.Data == nil {
+		return ""
+	}
+	data, err := json.Marshal(v.Data)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// ExportContactsRequest defines the request to export contacts as CSV.
+// It accepts the same filters as contacts.list (including segments); limit and cursor are ignored
+// because the export walks every matching contact with the cursor.
+type ExportContactsRequest struct {
+	GetContactsRequest
+	Columns []string `json:"columns,omitempty"`
+}
+
+// FromQueryParams populates the request from URL query parameters
+func (r *ExportContactsRequest) FromQueryParams(params url.Values) error {
+	filters := url.Values{}
+	for key, values := range params {
+		if key == "limit" || key == "cursor" || key == "columns" {
+			continue
+		}
+		filters[key] = values
+	}
+	if err := r.GetContactsRequest.FromQueryParams(filters); err != nil {
+		return err
+	}
+
+	if columns := params.Get("columns"); columns != "" {
+		for _, column := range strings.Split(columns, ",") {
+			if column = strings.TrimSpace(column); column != "" {
+				r.Columns = append(r.Columns, column)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the request and applies the default columns
+func (r *ExportContactsRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+
+	if len(r.Columns) == 0 {
+		r.Columns = DefaultContactExportColumns
+	}
+
+	seen := make(map[string]bool, len(r.Columns))
+	for _, column := range r.Columns {
+		if !IsValidContactExportColumn(column) {
+			return fmt.Errorf("invalid column: %s", column)
+		}
+		if seen[column] {
+			return fmt.Errorf("duplicate column: %s", column)
+		}
+		seen[column] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactExportRow(t *testing.T) {
+	createdAt := time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)
+	contact := &Contact{
+		Email:           "ann@example.com",
+		FirstName:       &NullableString{String: "Ann"},
+		LastName:        &NullableString{IsNull: true},
+		CustomNumber1:   &NullableFloat64{Float64: 42.5},
+		CustomDatetime1: &NullableTime{Time: createdAt},
+		CustomJSON1:     &NullableJSON{Data: map[string]interface{}{"plan": "pro"}},
+		CreatedAt:       createdAt,
+	}
+
+	row := ContactExportRow(contact, []string{
+		"email", "first_name", "last_name", "phone", "custom_number_1", "custom_datetime_1", "custom_json_1", "created_at",
+	})
+
+	assert.Equal(t, []string{
+		"ann@example.com",
+		"Ann",
+		"",
+		"",
+		"42.5",
+		"2026-03-01T10:30:00Z",
+		`{"plan":"pro"}`,
+		"2026-03-01T10:30:00Z",
+	}, row)
+}
+
+func TestExportContactsRequest_FromQueryParams(t *testing.T) {
+	params := url.Values{
+		"workspace_id": {"ws1"},
+		"country":      {"FR"},
+		"segments[]":   {"seg1", "seg2"},
+		"columns":      {"email, first_name,,country"},
+		"limit":        {"1000"}, // ignored, contacts.list would reject it
+		"cursor":       {"abc"},
+	}
+
+	var req ExportContactsRequest
+	require.NoError(t, req.FromQueryParams(params))
+
+	assert.Equal(t, "ws1", req.WorkspaceID)
+	assert.Equal(t, "FR", req.Country)
+	assert.Equal(t, []string{"seg1", "seg2"}, req.Segments)
+	assert.Equal(t, []string{"email", "first_name", "country"}, req.Columns)
+	assert.Equal(t, 0, req.Limit)
+	assert.Equal(t, "", req.Cursor)
+}
+
+func TestExportContactsRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     ExportContactsRequest
+		wantErr string
+	}{
+		{
+			name: "default columns",
+			req:  ExportContactsRequest{GetContactsRequest: GetContactsRequest{WorkspaceID: "ws1"}},
+		},
+		{
+			name: "selected columns",
+			req: ExportContactsRequest{
+				GetContactsRequest: GetContactsRequest{WorkspaceID: "ws1"},
+				Columns:            []string{"email", "custom_json_5"},
+			},
+		},
+		{
+			name:    "missing workspace",
+			req:     ExportContactsRequest{},
+			wantErr: "workspace_id is required",
+		},
+		{
+			name: "unknown column",
+			req: ExportContactsRequest{
+				GetContactsRequest: GetContactsRequest{WorkspaceID: "ws1"},
+				Columns:            []string{"email", "password"},
+			},
+			wantErr: "invalid column: password",
+		},
+		{
+			name: "duplicate column",
+			req: ExportContactsRequest{
+				GetContactsRequest: GetContactsRequest{WorkspaceID: "ws1"},
+				Columns:            []string{"email", "email"},
+			},
+			wantErr: "duplicate column: email",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, tt.req.Columns)
+		})
+	}
+}
@@ -2883,3 +2883,47 @@ func TestTrimUnicodeSpace(t *testing.T) {
 		})
 	}
 }
+
+func TestBatchImportContactsResponse_Summarize(t *testing.T) {
+	response := &BatchImportContactsResponse{
+		Operations: []*UpsertContactOperation{
+			{Email: "new@example.com", Action: UpsertContactOperationCreate},
+			{Email: "known@example.com", Action: UpsertContactOperationUpdate},
+			{Email: "bad", Action: UpsertContactOperationError, Error: "invalid email"},
+		},
+	}
+
+	response.Summarize()
+
+	assert.Equal(t, []BulkItemResult{
+		{ID: "new@example.com", Status: BulkItemStatusCreated},
+		{ID: "known@example.com", Status: BulkItemStatusUpdated},
+		{ID: "bad", Status: BulkItemStatusFailed, Error: "invalid email"},
+	}, response.Results)
+	assert.Equal(t, BulkOperationSummary{Created: 1, Updated: 1, Failed: 1}, response.Summary)
+}
+
+func TestFromJSON_MessagingHoldUntil(t *testing.T) {
+	t.Run("parses hold timestamp", func(t *testing.T) {
+		contact, err := FromJSON([]byte(`{"email": "test@example.com", "messaging_hold_until": "2026-06-01T12:00:00Z"}`))
+		require.NoError(t, err)
+		require.NotNil(t, contact.MessagingHoldUntil)
+		assert.False(t, contact.MessagingHoldUntil.IsNull)
+		assert.Equal(t, time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC), contact.MessagingHoldUntil.Time)
+	})
+
+	t.Run("null clears the hold", func(t *testing.T) {
+		contact, err := FromJSON([]byte(`{"email": "test@example.com", "messaging_hold_until": null}`))
+		require.NoError(t, err)
+		require.NotNil(t, contact.MessagingHoldUntil)
+		assert.True(t, contact.MessagingHoldUntil.IsNull)
+	})
+
+	t.Run("merge keeps existing hold when not provided", func(t *testing.T) {
+		holdUntil := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+		existing := &Contact{Email: "test@example.com", MessagingHoldUntil: &NullableTime{Time: holdUntil}}
+		existing.Merge(&Contact{Email: "test@example.com", FirstName: &NullableString{String: "John"}})
+		require.NotNil(t, existing.MessagingHoldUntil)
+		assert.Equal(t, holdUntil, existing.MessagingHoldUntil.Time)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -79,12 +80,77 @@ func (r *TimelineListRequest) FromQuery(query url.Values) error {
 	return r.Validate()
 }
 
+// Automation node executions are not stored in contact_timeline: contacts.timeline reads them
+// from automation_node_executions and returns them with this entity type and kind
+const (
+	TimelineEntityTypeAutomationNode = "automation_node"
+	TimelineKindAutomationNode       = "automation.node"
+)
+
+// ContactTimelineRequest represents the request parameters for fetching the full timeline of a contact
+type ContactTimelineRequest struct {
+	WorkspaceID string
+	Email       string
+	Kinds       []string // Only return entries of these kinds (every kind when empty)
+	Limit       int
+	Cursor      *string
+}
+
+// Validate validates the contact timeline request
+func (r *ContactTimelineRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.Email == "" {
+		return fmt.Errorf("email is required")
+	}
+	if r.Limit < 0 {
+		return fmt.Errorf("limit must be non-negative")
+	}
+	if r.Limit > 100 {
+		return fmt.Errorf("limit cannot exceed 100")
+	}
+	return nil
+}
+
+// FromQuery parses query parameters into a ContactTimelineRequest.
+// kinds is a comma-separated list of timeline kinds (e.g. "automation.start,automation.node,insert_message_history").
+func (r *ContactTimelineRequest) FromQuery(query url.Values) error {
+	r.WorkspaceID = query.Get("workspace_id")
+	r.Email = query.Get("email")
+
+	r.Limit = 50 // Default
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return fmt.Errorf("invalid limit parameter: must be an integer")
+		}
+		r.Limit = parsedLimit
+	}
+
+	if cursorStr := query.Get("cursor"); cursorStr != "" {
+		r.Cursor = &cursorStr
+	}
+
+	r.Kinds = nil
+	for _, kind := range strings.Split(query.Get("kinds"), ",") {
+		if kind = strings.TrimSpace(kind); kind != "" {
+			r.Kinds = append(r.Kinds, kind)
+		}
+	}
+
+	return r.Validate()
+}
+
 // ContactTimelineRepository defines methods for contact timeline persistence
 type ContactTimelineRepository interface {
 	// Create inserts a new timeline entry
 	Create(ctx context.Context, workspaceID string, entry *ContactTimelineEntry) error
 	// List retrieves timeline entries for a contact
 	List(ctx context.Context, workspaceID string, email string, limit int, cursor *string) ([]*ContactTimelineEntry, *string, error)
+	// ListAll retrieves every timeline entry for a contact, automation node executions included,
+	// optionally restricted to some kinds
+	ListAll(ctx context.Context, workspaceID string, email string, kinds []string, limit int, cursor *string) ([]*ContactTimelineEntry, *string, error)
 	// DeleteForEmail deletes all timeline entries for a contact
 	DeleteForEmail(ctx context.Context, workspaceID string, email string) error
 }
@@ -93,4 +159,7 @@ type ContactTimelineRepository interface {
 type ContactTimelineService interface {
 	// List retrieves timeline entries for a contact with pagination
 	List(ctx context.Context, workspaceID string, email string, limit int, cursor *string) ([]*ContactTimelineEntry, *string, error)
+	// ListAll retrieves the full timeline of a contact (automation, message, list, custom events...)
+	// with pagination and kind filtering
+	ListAll(ctx context.Context, workspaceID string, email string, kinds []string, limit int, cursor *string) ([]*ContactTimelineEntry, *string, error)
 }
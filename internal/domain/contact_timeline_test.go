@@ -178,3 +178,63 @@ func TestTimelineListRequest_FromQuery(t *testing.T) {
 		assert.Contains(t, err.Error(), "email is required")
 	})
 }
+
+func TestContactTimelineRequest_FromQuery(t *testing.T) {
+	t.Run("valid query with kinds", func(t *testing.T) {
+		query := url.Values{
+			"workspace_id": {"workspace123"},
+			"email":        {"test@example.com"},
+			"limit":        {"25"},
+			"cursor":       {"cursor123"},
+			"kinds":        {"automation.start, automation.node,,insert_message_history"},
+		}
+
+		req := &ContactTimelineRequest{}
+		err := req.FromQuery(query)
+
+		require.NoError(t, err)
+		assert.Equal(t, "workspace123", req.WorkspaceID)
+		assert.Equal(t, "test@example.com", req.Email)
+		assert.Equal(t, 25, req.Limit)
+		require.NotNil(t, req.Cursor)
+		assert.Equal(t, "cursor123", *req.Cursor)
+		assert.Equal(t, []string{"automation.start", "automation.node", "insert_message_history"}, req.Kinds)
+	})
+
+	t.Run("valid query with defaults", func(t *testing.T) {
+		query := url.Values{
+			"workspace_id": {"workspace123"},
+			"email":        {"test@example.com"},
+		}
+
+		req := &ContactTimelineRequest{}
+		err := req.FromQuery(query)
+
+		require.NoError(t, err)
+		assert.Equal(t, 50, req.Limit)
+		assert.Nil(t, req.Cursor)
+		assert.Nil(t, req.Kinds)
+	})
+
+	t.Run("missing email", func(t *testing.T) {
+		req := &ContactTimelineRequest{}
+		err := req.FromQuery(url.Values{"workspace_id": {"workspace123"}})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "email is required")
+	})
+
+	t.Run("limit exceeds maximum", func(t *testing.T) {
+		query := url.Values{
+			"workspace_id": {"workspace123"},
+			"email":        {"test@example.com"},
+			"limit":        {"150"},
+		}
+
+		req := &ContactTimelineRequest{}
+		err := req.FromQuery(query)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "limit cannot exceed 100")
+	})
+}
@@ -6,7 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
+
+	"github.com/Notifuse/notifuse/pkg/crypto"
 )
 
 // DataFeedHeader represents a custom HTTP header for data feed requests
@@ -26,12 +29,177 @@ func (h *DataFeedHeader) Validate() error {
 	return nil
 }
 
+// MergeFeedHeaders combines workspace default headers with a feed's own headers.
+// Header names are compared case-insensitively and the feed's header wins on conflict.
+func MergeFeedHeaders(defaults, headers []DataFeedHeader) []DataFeedHeader {
+	if len(defaults) == 0 {
+		return headers
+	}
+
+	overridden := make(map[string]bool, len(headers))
+	for _, header := range headers {
+		overridden[strings.ToLower(header.Name)] = true
+	}
+
+	merged := make([]DataFeedHeader, 0, len(defaults)+len(headers))
+	for _, header := range defaults {
+		if !overridden[strings.ToLower(header.Name)] {
+			merged = append(merged, header)
+		}
+	}
+	return append(merged, headers...)
+}
+
+// DataFeedOAuth2Config configures the OAuth2 client-credentials flow used
+// to obtain a bearer token before calling a data feed endpoint
+type DataFeedOAuth2Config struct {
+	TokenURL              string `json:"token_url"`
+	ClientID              string `json:"client_id"`
+	ClientSecret          string `json:"client_secret,omitempty"`
+	EncryptedClientSecret string `json:"encrypted_client_secret,omitempty"` // Stored form of the secret, encrypted with the workspace secret key
+	Scope                 string `json:"scope,omitempty"`
+}
+
+// Validate validates the OAuth2 client-credentials configuration
+func (o *DataFeedOAuth2Config) Validate() error {
+	if o.TokenURL == "" {
+		return fmt.Errorf("oauth2 token_url is required")
+	}
+	if err := ValidateFeedURL(o.TokenURL); err != nil {
+		return fmt.Errorf("oauth2 token_url: %w", err)
+	}
+	if o.ClientID == "" {
+		return fmt.Errorf("oauth2 client_id is required")
+	}
+	if o.ClientSecret == "" && o.EncryptedClientSecret == "" {
+		return fmt.Errorf("oauth2 client_secret is required")
+	}
+	return nil
+}
+
+// EncryptClientSecret replaces the client secret with its encrypted form
+func (o *DataFeedOAuth2Config) EncryptClientSecret(passphrase string) error {
+	if o.ClientSecret == "" {
+		return nil
+	}
+	encrypted, err := crypto.EncryptString(o.ClientSecret, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt oauth2 client secret: %w", err)
+	}
+	o.EncryptedClientSecret = encrypted
+	o.ClientSecret = ""
+	return nil
+}
+
+// DecryptClientSecret decrypts the stored client secret with the workspace secret key
+func (o *DataFeedOAuth2Config) DecryptClientSecret(passphrase string) error {
+	secret, err := crypto.DecryptFromHexString(o.EncryptedClientSecret, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt oauth2 client secret: %w", err)
+	}
+	o.ClientSecret = secret
+	return nil
+}
+
+// DataFeedAuth defines how a data feed request is authenticated
+// beyond the static custom headers
+type DataFeedAuth struct {
+	OAuth2 *DataFeedOAuth2Config `json:"oauth2,omitempty"`
+}
+
+// Validate validates the data feed auth configuration
+func (a *DataFeedAuth) Validate() error {
+	if a.OAuth2 != nil {
+		if err := a.OAuth2.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DataFeedClientSecretMask replaces the OAuth2 client secret in API responses.
+// Sending it back keeps the stored secret.
+const DataFeedClientSecretMask = "\u2022\u2022\u2022\u2022\u2022\u2022\u2022\u2022" // ••••••••
+
+// RestoreClientSecret replaces a masked OAuth2 client secret with the secret of the stored
+// auth. The secret is only restored while the token URL and client ID are unchanged, and never
+// taken from an encrypted_client_secret sent by the client.
+func (a *DataFeedAuth) RestoreClientSecret(stored *DataFeedAuth, passphrase string) error {
+	if a == nil || a.OAuth2 == nil {
+		return nil
+	}
+	a.OAuth2.EncryptedClientSecret = ""
+	if a.OAuth2.ClientSecret != DataFeedClientSecretMask {
+		return nil
+	}
+
+	if stored == nil || stored.OAuth2 == nil ||
+		stored.OAuth2.TokenURL != a.OAuth2.TokenURL || stored.OAuth2.ClientID != a.OAuth2.ClientID {
+		return NewValidationError("oauth2 client_secret is required when the token_url or client_id changes")
+	}
+
+	previous := *stored.OAuth2
+	if previous.ClientSecret == "" {
+		if err := previous.DecryptClientSecret(passphrase); err != nil {
+			return err
+		}
+	}
+	a.OAuth2.ClientSecret = previous.ClientSecret
+	return nil
+}
+
+// ValidateFeedFieldMap validates a feed field mapping: source and target names
+// are required, targets must be unique and must not use the reserved "_" prefix
+func ValidateFeedFieldMap(fieldMap map[string]string) error {
+	targets := make(map[string]string, len(fieldMap))
+	for source, target := range fieldMap {
+		if source == "" {
+			return fmt.Errorf("field_map: source field is required")
+		}
+		if target == "" {
+			return fmt.Errorf("field_map: target name is required for field %s", source)
+		}
+		if strings.HasPrefix(target, "_") {
+			return fmt.Errorf("field_map: target name %s is reserved", target)
+		}
+		if other, exists := targets[target]; exists {
+			return fmt.Errorf("field_map: fields %s and %s both map to %s", other, source, target)
+		}
+		targets[target] = source
+	}
+	return nil
+}
+
+// ApplyFeedFieldMap renames top-level feed response fields according to the
+// field map so templates can use friendlier variable names. Fields not present
+// in the map are kept as-is; mapped fields missing from the response are ignored
+func ApplyFeedFieldMap(data map[string]interface{}, fieldMap map[string]string) map[string]interface{} {
+	if len(fieldMap) == 0 || data == nil {
+		return data
+	}
+
+	result := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		if _, mapped := fieldMap[key]; !mapped {
+			result[key] = value
+		}
+	}
+	for source, target := range fieldMap {
+		if value, ok := data[source]; ok {
+			result[target] = value
+		}
+	}
+	return result
+}
+
 // GlobalFeedSettings defines the configuration for fetching global data
 // that will be available to all recipients in a broadcast
 type GlobalFeedSettings struct {
-	Enabled bool             `json:"enabled"`
-	URL     string           `json:"url,omitempty"`
-	Headers []DataFeedHeader `json:"headers"` // Always include headers (empty array, not null)
+	Enabled  bool              `json:"enabled"`
+	URL      string            `json:"url,omitempty"`
+	Headers  []DataFeedHeader  `json:"headers"` // Always include headers (empty array, not null)
+	Auth     *DataFeedAuth     `json:"auth,omitempty"`
+	FieldMap map[string]string `json:"field_map,omitempty"` // Response field -> template variable name
 }
 
 // Validate validates the global feed settings
@@ -58,6 +226,16 @@ func (g *GlobalFeedSettings) Validate() error {
 		}
 	}
 
+	if g.Auth != nil {
+		if err := g.Auth.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := ValidateFeedFieldMap(g.FieldMap); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -107,8 +285,9 @@ func (g *GlobalFeedSettings) Scan(value interface{}) error {
 
 // GlobalFeedBroadcast represents broadcast information sent in the feed request
 type GlobalFeedBroadcast struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Metadata MapOfAny `json:"metadata,omitempty"`
 }
 
 // GlobalFeedList represents list information sent in the feed request
@@ -134,11 +313,19 @@ type GlobalFeedRequestPayload struct {
 // On failure: retries up to 2 times (3 total attempts) with 5 second delay
 // If all retries fail: broadcast pauses immediately
 type RecipientFeedSettings struct {
-	Enabled bool             `json:"enabled"`
-	URL     string           `json:"url,omitempty"`
-	Headers []DataFeedHeader `json:"headers"` // Always include headers (empty array, not null)
+	Enabled  bool              `json:"enabled"`
+	URL      string            `json:"url,omitempty"`
+	Headers  []DataFeedHeader  `json:"headers"` // Always include headers (empty array, not null)
+	Auth     *DataFeedAuth     `json:"auth,omitempty"`
+	FieldMap map[string]string `json:"field_map,omitempty"` // Response field -> template variable name
+	// BatchSize > 0 sends up to BatchSize contacts per request and expects a
+	// response object keyed by contact email; 0 keeps one request per recipient
+	BatchSize int `json:"batch_size,omitempty"`
 }
 
+// MaxRecipientFeedBatchSize caps how many contacts a single batched recipient feed request may carry
+const MaxRecipientFeedBatchSize = 500
+
 // Validate validates the recipient feed settings
 func (r *RecipientFeedSettings) Validate() error {
 	// If not enabled, skip validation
@@ -169,6 +356,20 @@ func (r *RecipientFeedSettings) Validate() error {
 		}
 	}
 
+	if r.Auth != nil {
+		if err := r.Auth.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := ValidateFeedFieldMap(r.FieldMap); err != nil {
+		return err
+	}
+
+	if r.BatchSize < 0 || r.BatchSize > MaxRecipientFeedBatchSize {
+		return fmt.Errorf("batch_size must be between 0 and %d", MaxRecipientFeedBatchSize)
+	}
+
 	return nil
 }
 
@@ -284,8 +485,9 @@ type RecipientFeedList struct {
 
 // RecipientFeedBroadcast represents broadcast information sent in the recipient feed request
 type RecipientFeedBroadcast struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Metadata MapOfAny `json:"metadata,omitempty"`
 }
 
 // RecipientFeedWorkspace represents workspace information sent in the recipient feed request
@@ -302,6 +504,15 @@ type RecipientFeedRequestPayload struct {
 	Workspace RecipientFeedWorkspace `json:"workspace"`
 }
 
+// RecipientFeedBatchRequestPayload is the JSON body sent to the recipient feed endpoint
+// when batching is enabled. The endpoint must answer with an object keyed by contact email.
+type RecipientFeedBatchRequestPayload struct {
+	Contacts  []RecipientFeedContact `json:"contacts"`
+	Broadcast RecipientFeedBroadcast `json:"broadcast"`
+	List      RecipientFeedList      `json:"list"`
+	Workspace RecipientFeedWorkspace `json:"workspace"`
+}
+
 // DataFeedSettings consolidates all feed configuration and runtime data
 // into a single structure for database storage
 type DataFeedSettings struct {
@@ -350,6 +561,76 @@ func (d *DataFeedSettings) Scan(value interface{}) error {
 	return nil
 }
 
+// HasOAuth2 returns true when the global or recipient feed authenticates with OAuth2
+func (d *DataFeedSettings) HasOAuth2() bool {
+	for _, auth := range d.auths() {
+		if auth != nil && auth.OAuth2 != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// RestoreClientSecrets replaces masked OAuth2 client secrets of the feeds with the
+// secrets of the same feeds in the stored settings, see DataFeedAuth.RestoreClientSecret
+func (d *DataFeedSettings) RestoreClientSecrets(stored *DataFeedSettings, passphrase string) error {
+	var storedGlobal, storedRecipient *DataFeedAuth
+	if stored != nil && stored.GlobalFeed != nil {
+		storedGlobal = stored.GlobalFeed.Auth
+	}
+	if stored != nil && stored.RecipientFeed != nil {
+		storedRecipient = stored.RecipientFeed.Auth
+	}
+
+	if d.GlobalFeed != nil {
+		if err := d.GlobalFeed.Auth.RestoreClientSecret(storedGlobal, passphrase); err != nil {
+			return err
+		}
+	}
+	if d.RecipientFeed != nil {
+		if err := d.RecipientFeed.Auth.RestoreClientSecret(storedRecipient, passphrase); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EncryptClientSecrets replaces the OAuth2 client secrets of the feeds with their
+// encrypted form, so they are not stored in plain text
+func (d *DataFeedSettings) EncryptClientSecrets(passphrase string) error {
+	for _, auth := range d.auths() {
+		if auth == nil || auth.OAuth2 == nil {
+			continue
+		}
+		if err := auth.OAuth2.EncryptClientSecret(passphrase); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MaskClientSecrets masks the OAuth2 client secrets of the feeds in settings returned by the API
+func (d *DataFeedSettings) MaskClientSecrets() {
+	for _, auth := range d.auths() {
+		if auth != nil && auth.OAuth2 != nil {
+			auth.OAuth2.ClientSecret = DataFeedClientSecretMask
+			auth.OAuth2.EncryptedClientSecret = ""
+		}
+	}
+}
+
+// auths returns the auth configuration of the global and recipient feeds
+func (d *DataFeedSettings) auths() []*DataFeedAuth {
+	var auths []*DataFeedAuth
+	if d.GlobalFeed != nil {
+		auths = append(auths, d.GlobalFeed.Auth)
+	}
+	if d.RecipientFeed != nil {
+		auths = append(auths, d.RecipientFeed.Auth)
+	}
+	return auths
+}
+
 // Validate validates the data feed settings
 func (d *DataFeedSettings) Validate() error {
 	if d.GlobalFeed != nil {
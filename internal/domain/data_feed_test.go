@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Notifuse/notifuse/pkg/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -74,6 +75,20 @@ func TestDataFeedHeader_Validate(t *testing.T) {
 	}
 }
 
+func TestMergeFeedHeaders(t *testing.T) {
+	feed := []DataFeedHeader{{Name: "X-Feed", Value: "feed"}, {Name: "authorization", Value: "Bearer feed"}}
+
+	assert.Equal(t, feed, MergeFeedHeaders(nil, feed))
+	assert.Equal(t, []DataFeedHeader{
+		{Name: "X-Api-Key", Value: "workspace"},
+		{Name: "X-Feed", Value: "feed"},
+		{Name: "authorization", Value: "Bearer feed"},
+	}, MergeFeedHeaders([]DataFeedHeader{
+		{Name: "X-Api-Key", Value: "workspace"},
+		{Name: "Authorization", Value: "Bearer workspace"},
+	}, feed))
+}
+
 func TestGlobalFeedSettings_Validate(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -216,6 +231,53 @@ func TestGlobalFeedSettings_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "URL must have a host",
 		},
+		{
+			name: "valid oauth2 auth",
+			settings: GlobalFeedSettings{
+				Enabled: true,
+				URL:     "https://api.example.com/data",
+				Auth: &DataFeedAuth{
+					OAuth2: &DataFeedOAuth2Config{
+						TokenURL:     "https://auth.example.com/oauth/token",
+						ClientID:     "client",
+						ClientSecret: "secret",
+						Scope:        "feeds.read",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "oauth2 auth missing client secret",
+			settings: GlobalFeedSettings{
+				Enabled: true,
+				URL:     "https://api.example.com/data",
+				Auth: &DataFeedAuth{
+					OAuth2: &DataFeedOAuth2Config{
+						TokenURL: "https://auth.example.com/oauth/token",
+						ClientID: "client",
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "oauth2 client_secret is required",
+		},
+		{
+			name: "oauth2 auth with private token URL",
+			settings: GlobalFeedSettings{
+				Enabled: true,
+				URL:     "https://api.example.com/data",
+				Auth: &DataFeedAuth{
+					OAuth2: &DataFeedOAuth2Config{
+						TokenURL:     "http://127.0.0.1/oauth/token",
+						ClientID:     "client",
+						ClientSecret: "secret",
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "oauth2 token_url",
+		},
 	}
 
 	for _, tt := range tests {
@@ -456,6 +518,35 @@ func TestRecipientFeedSettings_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "URL is required when recipient feed is enabled",
 		},
+		{
+			name: "valid batch size",
+			settings: RecipientFeedSettings{
+				Enabled:   true,
+				URL:       "https://api.example.com/recipient",
+				BatchSize: 50,
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative batch size",
+			settings: RecipientFeedSettings{
+				Enabled:   true,
+				URL:       "https://api.example.com/recipient",
+				BatchSize: -1,
+			},
+			wantErr: true,
+			errMsg:  "batch_size must be between 0 and 500",
+		},
+		{
+			name: "batch size above maximum",
+			settings: RecipientFeedSettings{
+				Enabled:   true,
+				URL:       "https://api.example.com/recipient",
+				BatchSize: MaxRecipientFeedBatchSize + 1,
+			},
+			wantErr: true,
+			errMsg:  "batch_size must be between 0 and 500",
+		},
 		{
 			name: "invalid URL scheme - ftp",
 			settings: RecipientFeedSettings{
@@ -1055,3 +1146,171 @@ func TestDataFeedSettings_JSONSerialization(t *testing.T) {
 	assert.True(t, unmarshaled.GlobalFeed.Enabled)
 	assert.Equal(t, "value", unmarshaled.GlobalFeedData["key"])
 }
+
+func TestDataFeedSettings_ClientSecrets(t *testing.T) {
+	const passphrase = "workspace-secret-key"
+
+	newSettings := func(clientID, clientSecret string) *DataFeedSettings {
+		return &DataFeedSettings{
+			GlobalFeed: &GlobalFeedSettings{
+				Enabled: true,
+				URL:     "https://api.example.com/data",
+				Auth: &DataFeedAuth{OAuth2: &DataFeedOAuth2Config{
+					TokenURL:     "https://auth.example.com/token",
+					ClientID:     clientID,
+					ClientSecret: clientSecret,
+				}},
+			},
+			RecipientFeed: &RecipientFeedSettings{
+				Enabled: true,
+				URL:     "https://api.example.com/recipient",
+			},
+		}
+	}
+
+	t.Run("secret is stored encrypted and masked in responses", func(t *testing.T) {
+		settings := newSettings("client", "s3cret")
+		require.True(t, settings.HasOAuth2())
+		require.NoError(t, settings.EncryptClientSecrets(passphrase))
+
+		oauth2 := settings.GlobalFeed.Auth.OAuth2
+		assert.Empty(t, oauth2.ClientSecret)
+		require.NotEmpty(t, oauth2.EncryptedClientSecret)
+		secret, err := crypto.DecryptFromHexString(oauth2.EncryptedClientSecret, passphrase)
+		require.NoError(t, err)
+		assert.Equal(t, "s3cret", secret)
+		assert.NoError(t, settings.Validate(), "a stored encrypted secret is valid")
+
+		data, err := json.Marshal(settings)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "s3cret")
+
+		settings.MaskClientSecrets()
+		assert.Equal(t, DataFeedClientSecretMask, oauth2.ClientSecret)
+		assert.Empty(t, oauth2.EncryptedClientSecret)
+	})
+
+	t.Run("masked secret is restored from the stored settings", func(t *testing.T) {
+		stored := newSettings("client", "s3cret")
+		require.NoError(t, stored.EncryptClientSecrets(passphrase))
+
+		settings := newSettings("client", DataFeedClientSecretMask)
+		require.NoError(t, settings.RestoreClientSecrets(stored, passphrase))
+		assert.Equal(t, "s3cret", settings.GlobalFeed.Auth.OAuth2.ClientSecret)
+	})
+
+	t.Run("new secret replaces the stored one", func(t *testing.T) {
+		stored := newSettings("client", "s3cret")
+		require.NoError(t, stored.EncryptClientSecrets(passphrase))
+
+		settings := newSettings("client", "rotated")
+		require.NoError(t, settings.RestoreClientSecrets(stored, passphrase))
+		assert.Equal(t, "rotated", settings.GlobalFeed.Auth.OAuth2.ClientSecret)
+	})
+
+	t.Run("masked secret is not restored for another client", func(t *testing.T) {
+		stored := newSettings("client", "s3cret")
+		require.NoError(t, stored.EncryptClientSecrets(passphrase))
+
+		settings := newSettings("other-client", DataFeedClientSecretMask)
+		err := settings.RestoreClientSecrets(stored, passphrase)
+		require.Error(t, err)
+		assert.IsType(t, ValidationError{}, err)
+	})
+
+	t.Run("masked secret without stored settings is rejected", func(t *testing.T) {
+		settings := newSettings("client", DataFeedClientSecretMask)
+		err := settings.RestoreClientSecrets(nil, passphrase)
+		require.Error(t, err)
+		assert.IsType(t, ValidationError{}, err)
+	})
+
+	t.Run("encrypted secret sent by the client is ignored", func(t *testing.T) {
+		settings := newSettings("client", "")
+		settings.GlobalFeed.Auth.OAuth2.EncryptedClientSecret = "deadbeef"
+		require.NoError(t, settings.RestoreClientSecrets(nil, passphrase))
+		assert.Empty(t, settings.GlobalFeed.Auth.OAuth2.EncryptedClientSecret)
+		assert.Error(t, settings.Validate())
+	})
+}
+
+func TestValidateFeedFieldMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		fieldMap map[string]string
+		wantErr  bool
+		errMsg   string
+	}{
+		{name: "nil map", fieldMap: nil},
+		{name: "valid mapping", fieldMap: map[string]string{"promoCode": "promo_code", "productName": "product_name"}},
+		{name: "empty source", fieldMap: map[string]string{"": "promo_code"}, wantErr: true, errMsg: "source field is required"},
+		{name: "empty target", fieldMap: map[string]string{"promoCode": ""}, wantErr: true, errMsg: "target name is required"},
+		{name: "reserved target", fieldMap: map[string]string{"ok": "_success"}, wantErr: true, errMsg: "is reserved"},
+		{name: "duplicate target", fieldMap: map[string]string{"a": "code", "b": "code"}, wantErr: true, errMsg: "both map to code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFeedFieldMap(tt.fieldMap)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("feed settings validate the field map", func(t *testing.T) {
+		global := GlobalFeedSettings{
+			Enabled:  true,
+			URL:      "https://api.example.com/data",
+			FieldMap: map[string]string{"promoCode": ""},
+		}
+		assert.Error(t, global.Validate())
+
+		recipient := RecipientFeedSettings{
+			Enabled:  true,
+			URL:      "https://api.example.com/data",
+			FieldMap: map[string]string{"promoCode": "_promo"},
+		}
+		assert.Error(t, recipient.Validate())
+	})
+}
+
+func TestApplyFeedFieldMap(t *testing.T) {
+	t.Run("renames mapped fields and keeps others", func(t *testing.T) {
+		data := map[string]interface{}{
+			"promoCode":   "WINTER2026",
+			"productName": "Premium Plan",
+			"discount":    30,
+		}
+
+		result := ApplyFeedFieldMap(data, map[string]string{
+			"promoCode":   "promo_code",
+			"productName": "product_name",
+			"missing":     "not_there",
+		})
+
+		assert.Equal(t, map[string]interface{}{
+			"promo_code":   "WINTER2026",
+			"product_name": "Premium Plan",
+			"discount":     30,
+		}, result)
+		assert.Contains(t, data, "promoCode", "input map should not be modified")
+	})
+
+	t.Run("mapped field wins over an existing field with the target name", func(t *testing.T) {
+		result := ApplyFeedFieldMap(map[string]interface{}{
+			"promoCode":  "NEW",
+			"promo_code": "OLD",
+		}, map[string]string{"promoCode": "promo_code"})
+
+		assert.Equal(t, map[string]interface{}{"promo_code": "NEW"}, result)
+	})
+
+	t.Run("empty map returns data unchanged", func(t *testing.T) {
+		data := map[string]interface{}{"promoCode": "X"}
+		assert.Equal(t, data, ApplyFeedFieldMap(data, nil))
+	})
+}
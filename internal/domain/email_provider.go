@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
 	"github.com/asaskevich/govalidator"
@@ -63,6 +65,9 @@ type EmailProvider struct {
 	SendGrid           *SendGridSettings  `json:"sendgrid,omitempty"`
 	Senders            []EmailSender      `json:"senders"`
 	RateLimitPerMinute int                `json:"rate_limit_per_minute"`
+	// FailoverIntegrationID is the email integration a send is retried through when
+	// the SMTP server cannot be reached or rejects authentication
+	FailoverIntegrationID string `json:"failover_integration_id,omitempty"`
 }
 
 // Validate validates the email provider settings
@@ -77,6 +82,11 @@ func (e *EmailProvider) Validate(passphrase string) error {
 		return fmt.Errorf("rate limit per minute is required and must be greater than 0")
 	}
 
+	// Only SMTP session failures are detected, so failover is SMTP-only
+	if e.FailoverIntegrationID != "" && e.Kind != EmailProviderKindSMTP {
+		return fmt.Errorf("failover integration is only supported for SMTP providers")
+	}
+
 	// Validate senders
 	if len(e.Senders) == 0 {
 		return fmt.Errorf("at least one sender is required")
@@ -310,14 +320,58 @@ func (e *EmailProvider) DecryptSecretKeys(passphrase string) error {
 }
 
 type EmailOptions struct {
-	FromName           *string      `json:"from_name,omitempty"`        // Override default sender from name
-	Subject            *string      `json:"subject,omitempty"`          // Override template subject
-	SubjectPreview     *string      `json:"subject_preview,omitempty"`  // Override template preheader
-	CC                 []string     `json:"cc,omitempty"`
-	BCC                []string     `json:"bcc,omitempty"`
-	ReplyTo            string       `json:"reply_to,omitempty"`
-	Attachments        []Attachment `json:"attachments,omitempty"`
-	ListUnsubscribeURL string       `json:"list_unsubscribe_url,omitempty"` // RFC-8058 one-click unsubscribe URL
+	FromName              *string      `json:"from_name,omitempty"`       // Override default sender from name
+	Subject               *string      `json:"subject,omitempty"`         // Override template subject
+	SubjectPreview        *string      `json:"subject_preview,omitempty"` // Override template preheader
+	CC                    []string     `json:"cc,omitempty"`
+	BCC                   []string     `json:"bcc,omitempty"`
+	ReplyTo               string       `json:"reply_to,omitempty"`
+	Attachments           []Attachment `json:"attachments,omitempty"`
+	ListUnsubscribeURL    string       `json:"list_unsubscribe_url,omitempty"`    // RFC-8058 one-click unsubscribe URL
+	ListUnsubscribeMailto string       `json:"list_unsubscribe_mailto,omitempty"` // RFC-2369 mailto unsubscribe URI, sent alongside the URL
+
+	// Adds the Precedence and Auto-Submitted headers of bulk emails, set at send time for marketing emails
+	BulkHeaders bool `json:"-"`
+}
+
+// EmailHeader is a header added to an outgoing email
+type EmailHeader struct {
+	Name  string
+	Value string
+}
+
+// BulkHeaderFields returns the headers marking a marketing email as bulk, so auto-responders
+// (vacation replies, out of office) don't answer it, or nil when BulkHeaders is not set.
+// See RFC 2076 (Precedence) and RFC 3834 (Auto-Submitted).
+func (eo EmailOptions) BulkHeaderFields() []EmailHeader {
+	if !eo.BulkHeaders {
+		return nil
+	}
+	return []EmailHeader{
+		{Name: "Precedence", Value: "bulk"},
+		{Name: "Auto-Submitted", Value: "auto-generated"},
+	}
+}
+
+// ListUnsubscribeHeader returns the List-Unsubscribe header value: the one-click URL,
+// followed by the mailto URI when set, for clients that only honor mailto unsubscribes
+func (eo EmailOptions) ListUnsubscribeHeader() string {
+	if eo.ListUnsubscribeMailto == "" {
+		return fmt.Sprintf("<%s>", eo.ListUnsubscribeURL)
+	}
+	return fmt.Sprintf("<%s>, <%s>", eo.ListUnsubscribeURL, eo.ListUnsubscribeMailto)
+}
+
+// BuildListUnsubscribeMailto builds the mailto unsubscribe URI pointing at the given inbox.
+// The one-click URL is carried in the body so an incoming unsubscribe mail identifies the
+// contact, list and message. Returns an empty string when no inbox is configured.
+func BuildListUnsubscribeMailto(address, oneclickURL string) string {
+	if address == "" {
+		return ""
+	}
+	// mailto URIs (RFC 6068) encode spaces as %20, not +
+	body := strings.ReplaceAll(url.QueryEscape(oneclickURL), "+", "%20")
+	return fmt.Sprintf("mailto:%s?subject=unsubscribe&body=%s", address, body)
 }
 
 // IsEmpty returns true if no email options are set
@@ -357,6 +411,7 @@ type SendEmailProviderRequest struct {
 	To            string         `validate:"required"`
 	Subject       string         `validate:"required"`
 	Content       string         `validate:"required"`
+	TextContent   string         // Optional plain-text version of Content, generated from it when empty (SMTP only)
 	Provider      *EmailProvider `validate:"required"`
 	EmailOptions  EmailOptions
 }
@@ -440,6 +495,14 @@ func (r *SendEmailRequest) Validate() error {
 // EmailServiceInterface defines the interface for the email service
 type EmailServiceInterface interface {
 	TestEmailProvider(ctx context.Context, workspaceID string, provider EmailProvider, to string) error
+	// TestIntegration authenticates the user and checks a saved email integration can connect
+	TestIntegration(ctx context.Context, workspaceID, integrationID string) error
+	// CheckIntegration checks an email integration can connect without sending a message.
+	// An empty integrationID checks the workspace marketing provider.
+	CheckIntegration(ctx context.Context, workspaceID, integrationID string) error
+	// CheckDeliverability authenticates the user and looks up the SPF, DKIM and DMARC
+	// records of an email integration's sender domain
+	CheckDeliverability(ctx context.Context, req CheckDeliverabilityRequest) (*DeliverabilityReport, error)
 	SendEmail(ctx context.Context, request SendEmailProviderRequest, isMarketing bool) error
 	SendEmailForTemplate(ctx context.Context, request SendEmailRequest) error
 	VisitLink(ctx context.Context, messageID string, workspaceID string) error
@@ -449,3 +512,9 @@ type EmailServiceInterface interface {
 type EmailProviderService interface {
 	SendEmail(ctx context.Context, request SendEmailProviderRequest) error
 }
+
+// EmailProviderConnectionChecker is implemented by provider services able to verify
+// connectivity and credentials (e.g. an SMTP EHLO/AUTH probe) without sending a message
+type EmailProviderConnectionChecker interface {
+	CheckConnection(ctx context.Context, provider *EmailProvider) error
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/Notifuse/notifuse/pkg/crypto"
@@ -23,6 +24,53 @@ type SMTPWebhookPayload struct {
 	ComplaintType  string            `json:"complaint_type,omitempty"`
 }
 
+// Content-Transfer-Encoding values supported for the HTML body of SMTP emails
+const (
+	SMTPBodyEncodingQuotedPrintable = "quoted-printable"
+	SMTPBodyEncodingBase64          = "base64"
+)
+
+// TLS modes of SMTP connections
+const (
+	// SMTPTLSModeNone sends everything in cleartext
+	SMTPTLSModeNone = "none"
+	// SMTPTLSModeStartTLS upgrades the connection with STARTTLS after EHLO (port 587 style)
+	SMTPTLSModeStartTLS = "starttls"
+	// SMTPTLSModeImplicit negotiates TLS as soon as the connection is open (port 465 style)
+	SMTPTLSModeImplicit = "implicit"
+)
+
+// Minimum TLS versions accepted when negotiating TLS on SMTP connections
+const (
+	SMTPTLSVersion12 = "1.2"
+	SMTPTLSVersion13 = "1.3"
+)
+
+// MaxSMTPIdleTimeoutSeconds caps how long pooled SMTP connections may stay idle
+const MaxSMTPIdleTimeoutSeconds = 300
+
+// SMTPSessionError marks a failure to open an SMTP session (connection, TLS or
+// authentication), as opposed to the server rejecting a specific message
+type SMTPSessionError struct {
+	Err error
+}
+
+// Error implements the error interface
+func (e *SMTPSessionError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *SMTPSessionError) Unwrap() error {
+	return e.Err
+}
+
+// IsSMTPSessionError reports whether err comes from an SMTP session that could not be opened
+func IsSMTPSessionError(err error) bool {
+	var sessionErr *SMTPSessionError
+	return errors.As(err, &sessionErr)
+}
+
 // SMTPSettings contains configuration for SMTP email server
 type SMTPSettings struct {
 	Host              string `json:"host"`
@@ -32,6 +80,25 @@ type SMTPSettings struct {
 	UseTLS            bool   `json:"use_tls"`
 	EHLOHostname      string `json:"ehlo_hostname,omitempty"`
 
+	// Content-Transfer-Encoding of the HTML body: "quoted-printable" (default) or "base64"
+	BodyEncoding string `json:"body_encoding,omitempty"`
+
+	// Maximum number of simultaneous connections opened to the relay, 0 means unlimited
+	MaxConcurrentConnections int `json:"max_concurrent_connections,omitempty"`
+
+	// Number of authenticated connections kept open for reuse between sends, 0 disables pooling
+	MaxIdleConnections int `json:"max_idle_connections,omitempty"`
+
+	// Seconds a pooled connection may stay idle before it is closed, 0 uses the 30 second default
+	IdleTimeoutSeconds int `json:"idle_timeout_seconds,omitempty"`
+
+	// How the connection is secured: "none", "starttls" or "implicit".
+	// Empty follows UseTLS, which enables STARTTLS.
+	TLSMode string `json:"tls_mode,omitempty"`
+
+	// Minimum TLS version negotiated with the server: "1.2" (default) or "1.3"
+	MinTLSVersion string `json:"min_tls_version,omitempty"`
+
 	// decoded username, not stored in the database
 	// decoded password , not stored in the database
 	Username string `json:"username"`
@@ -52,6 +119,18 @@ type SMTPSettings struct {
 	OAuth2RefreshToken string `json:"oauth2_refresh_token,omitempty"` // Decrypted refresh token (Google)
 }
 
+// EffectiveTLSMode returns the configured TLS mode, falling back to UseTLS for
+// integrations saved before TLSMode existed
+func (s *SMTPSettings) EffectiveTLSMode() string {
+	if s.TLSMode != "" {
+		return s.TLSMode
+	}
+	if s.UseTLS {
+		return SMTPTLSModeStartTLS
+	}
+	return SMTPTLSModeNone
+}
+
 func (s *SMTPSettings) DecryptUsername(passphrase string) error {
 	username, err := crypto.DecryptFromHexString(s.EncryptedUsername, passphrase)
 	if err != nil {
@@ -135,6 +214,33 @@ func (s *SMTPSettings) Validate(passphrase string) error {
 		return fmt.Errorf("invalid port number for SMTP configuration: %d", s.Port)
 	}
 
+	if s.BodyEncoding != "" && s.BodyEncoding != SMTPBodyEncodingQuotedPrintable && s.BodyEncoding != SMTPBodyEncodingBase64 {
+		return fmt.Errorf("body_encoding must be '%s' or '%s'", SMTPBodyEncodingQuotedPrintable, SMTPBodyEncodingBase64)
+	}
+
+	if s.MaxConcurrentConnections < 0 {
+		return fmt.Errorf("max_concurrent_connections must be 0 (unlimited) or greater")
+	}
+
+	if s.MaxIdleConnections < 0 {
+		return fmt.Errorf("max_idle_connections must be 0 (no pooling) or greater")
+	}
+
+	// Servers may close connections idle for 5 minutes (RFC 5321 section 4.5.3.2.7)
+	if s.IdleTimeoutSeconds < 0 || s.IdleTimeoutSeconds > MaxSMTPIdleTimeoutSeconds {
+		return fmt.Errorf("idle_timeout_seconds must be between 0 (default) and %d", MaxSMTPIdleTimeoutSeconds)
+	}
+
+	switch s.TLSMode {
+	case "", SMTPTLSModeNone, SMTPTLSModeStartTLS, SMTPTLSModeImplicit:
+	default:
+		return fmt.Errorf("tls_mode must be '%s', '%s' or '%s'", SMTPTLSModeNone, SMTPTLSModeStartTLS, SMTPTLSModeImplicit)
+	}
+
+	if s.MinTLSVersion != "" && s.MinTLSVersion != SMTPTLSVersion12 && s.MinTLSVersion != SMTPTLSVersion13 {
+		return fmt.Errorf("min_tls_version must be '%s' or '%s'", SMTPTLSVersion12, SMTPTLSVersion13)
+	}
+
 	// Handle OAuth2 authentication
 	if s.AuthType == "oauth2" {
 		return s.validateOAuth2(passphrase)
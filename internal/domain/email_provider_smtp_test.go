@@ -235,6 +235,130 @@ func TestSMTPSettings_Validate(t *testing.T) {
 			},
 			wantErr: false, // Empty password is allowed
 		},
+		{
+			name: "base64 body encoding",
+			settings: domain.SMTPSettings{
+				Host:         "smtp.example.com",
+				Port:         587,
+				BodyEncoding: domain.SMTPBodyEncodingBase64,
+			},
+			wantErr: false,
+		},
+		{
+			name: "quoted-printable body encoding",
+			settings: domain.SMTPSettings{
+				Host:         "smtp.example.com",
+				Port:         587,
+				BodyEncoding: domain.SMTPBodyEncodingQuotedPrintable,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid body encoding",
+			settings: domain.SMTPSettings{
+				Host:         "smtp.example.com",
+				Port:         587,
+				BodyEncoding: "7bit",
+			},
+			wantErr: true,
+			errMsg:  "body_encoding must be",
+		},
+		{
+			name: "max concurrent connections",
+			settings: domain.SMTPSettings{
+				Host:                     "smtp.example.com",
+				Port:                     587,
+				MaxConcurrentConnections: 2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative max concurrent connections",
+			settings: domain.SMTPSettings{
+				Host:                     "smtp.example.com",
+				Port:                     587,
+				MaxConcurrentConnections: -1,
+			},
+			wantErr: true,
+			errMsg:  "max_concurrent_connections must be",
+		},
+		{
+			name: "max idle connections",
+			settings: domain.SMTPSettings{
+				Host:               "smtp.example.com",
+				Port:               587,
+				MaxIdleConnections: 4,
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative max idle connections",
+			settings: domain.SMTPSettings{
+				Host:               "smtp.example.com",
+				Port:               587,
+				MaxIdleConnections: -1,
+			},
+			wantErr: true,
+			errMsg:  "max_idle_connections must be",
+		},
+		{
+			name: "idle timeout",
+			settings: domain.SMTPSettings{
+				Host:               "smtp.example.com",
+				Port:               587,
+				MaxIdleConnections: 4,
+				IdleTimeoutSeconds: 120,
+			},
+			wantErr: false,
+		},
+		{
+			name: "idle timeout above the server timeout",
+			settings: domain.SMTPSettings{
+				Host:               "smtp.example.com",
+				Port:               587,
+				IdleTimeoutSeconds: domain.MaxSMTPIdleTimeoutSeconds + 1,
+			},
+			wantErr: true,
+			errMsg:  "idle_timeout_seconds must be between",
+		},
+		{
+			name: "TLS 1.3 minimum version",
+			settings: domain.SMTPSettings{
+				Host:          "smtp.example.com",
+				Port:          587,
+				MinTLSVersion: domain.SMTPTLSVersion13,
+			},
+			wantErr: false,
+		},
+		{
+			name: "TLS minimum version below 1.2",
+			settings: domain.SMTPSettings{
+				Host:          "smtp.example.com",
+				Port:          587,
+				MinTLSVersion: "1.1",
+			},
+			wantErr: true,
+			errMsg:  "min_tls_version must be",
+		},
+		{
+			name: "implicit TLS mode",
+			settings: domain.SMTPSettings{
+				Host:    "smtp.example.com",
+				Port:    465,
+				TLSMode: domain.SMTPTLSModeImplicit,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown TLS mode",
+			settings: domain.SMTPSettings{
+				Host:    "smtp.example.com",
+				Port:    587,
+				TLSMode: "ssl",
+			},
+			wantErr: true,
+			errMsg:  "tls_mode must be",
+		},
 	}
 
 	for _, tt := range tests {
@@ -256,6 +380,13 @@ func TestSMTPSettings_Validate(t *testing.T) {
 // OAuth2 Authentication Tests
 // ============================================================================
 
+func TestSMTPSettings_EffectiveTLSMode(t *testing.T) {
+	assert.Equal(t, domain.SMTPTLSModeNone, (&domain.SMTPSettings{}).EffectiveTLSMode())
+	assert.Equal(t, domain.SMTPTLSModeStartTLS, (&domain.SMTPSettings{UseTLS: true}).EffectiveTLSMode())
+	assert.Equal(t, domain.SMTPTLSModeImplicit, (&domain.SMTPSettings{UseTLS: true, TLSMode: domain.SMTPTLSModeImplicit}).EffectiveTLSMode())
+	assert.Equal(t, domain.SMTPTLSModeNone, (&domain.SMTPSettings{UseTLS: true, TLSMode: domain.SMTPTLSModeNone}).EffectiveTLSMode())
+}
+
 func TestSMTPSettings_OAuth2_Validation(t *testing.T) {
 	passphrase := "test-passphrase"
 
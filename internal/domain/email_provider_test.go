@@ -2,6 +2,8 @@ package domain
 
 import (
 	"encoding/json"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -1640,6 +1642,60 @@ func TestEmailOptions_JSONMarshaling(t *testing.T) {
 	})
 }
 
+func TestEmailOptions_ListUnsubscribeHeader(t *testing.T) {
+	oneclickURL := "https://api.example.com/unsubscribe-oneclick?email=john%40example.com&lids=newsletter&mid=msg-1&wid=ws-1"
+
+	t.Run("URL only", func(t *testing.T) {
+		options := EmailOptions{ListUnsubscribeURL: oneclickURL}
+		assert.Equal(t, "<"+oneclickURL+">", options.ListUnsubscribeHeader())
+	})
+
+	t.Run("URL and mailto", func(t *testing.T) {
+		options := EmailOptions{
+			ListUnsubscribeURL:    oneclickURL,
+			ListUnsubscribeMailto: BuildListUnsubscribeMailto("unsubscribe@example.com", oneclickURL),
+		}
+		header := options.ListUnsubscribeHeader()
+		assert.True(t, strings.HasPrefix(header, "<https://api.example.com/unsubscribe-oneclick?"))
+		assert.Contains(t, header, ">, <mailto:unsubscribe@example.com?subject=unsubscribe&body=")
+		assert.True(t, strings.HasSuffix(header, ">"))
+	})
+}
+
+func TestEmailOptions_BulkHeaderFields(t *testing.T) {
+	assert.Nil(t, EmailOptions{}.BulkHeaderFields())
+	assert.Equal(t, []EmailHeader{
+		{Name: "Precedence", Value: "bulk"},
+		{Name: "Auto-Submitted", Value: "auto-generated"},
+	}, EmailOptions{BulkHeaders: true}.BulkHeaderFields())
+
+	// Decided at send time, never stored with queued payloads
+	data, err := json.Marshal(EmailOptions{BulkHeaders: true})
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "bulk")
+}
+
+func TestBuildListUnsubscribeMailto(t *testing.T) {
+	t.Run("no inbox configured", func(t *testing.T) {
+		assert.Equal(t, "", BuildListUnsubscribeMailto("", "https://example.com/unsubscribe"))
+	})
+
+	t.Run("carries the one-click URL in the body", func(t *testing.T) {
+		oneclickURL := "https://api.example.com/unsubscribe-oneclick?lids=newsletter&wid=ws-1"
+		mailto := BuildListUnsubscribeMailto("unsubscribe@example.com", oneclickURL)
+
+		parsed, err := url.Parse(mailto)
+		require.NoError(t, err)
+		assert.Equal(t, "mailto", parsed.Scheme)
+		assert.Equal(t, "unsubscribe@example.com", parsed.Opaque)
+		assert.Equal(t, "unsubscribe", parsed.Query().Get("subject"))
+		assert.Equal(t, oneclickURL, parsed.Query().Get("body"))
+		// Header separators must not appear unescaped inside the URI
+		assert.NotContains(t, mailto, ",")
+		assert.NotContains(t, mailto, ">")
+	})
+}
+
 func TestEmailOptions_IsEmpty(t *testing.T) {
 	t.Run("Empty EmailOptions", func(t *testing.T) {
 		options := EmailOptions{}
@@ -24,13 +24,22 @@ const (
 type EmailQueueSourceType string
 
 const (
-	EmailQueueSourceBroadcast  EmailQueueSourceType = "broadcast"
-	EmailQueueSourceAutomation EmailQueueSourceType = "automation"
+	EmailQueueSourceBroadcast     EmailQueueSourceType = "broadcast"
+	EmailQueueSourceAutomation    EmailQueueSourceType = "automation"
+	EmailQueueSourceTransactional EmailQueueSourceType = "transactional"
 )
 
+// IsMarketing returns true for sources that are subject to marketing opt-outs
+func (t EmailQueueSourceType) IsMarketing() bool {
+	return t != EmailQueueSourceTransactional
+}
+
 // Default priority for marketing emails (broadcasts and automations)
 const EmailQueuePriorityMarketing = 5
 
+// Priority for transactional emails, processed ahead of marketing emails
+const EmailQueuePriorityTransactional = 1
+
 // EmailQueueEntry represents a single email in the queue
 type EmailQueueEntry struct {
 	ID            string               `json:"id"`
@@ -46,6 +55,11 @@ type EmailQueueEntry struct {
 	MessageID    string `json:"message_id"`
 	TemplateID   string `json:"template_id"`
 
+	// DedupeKey identifies the logical message for short-window deduplication at enqueue
+	// time: a second entry with the same contact, template and key within the window is
+	// dropped. Empty disables deduplication for the entry. Not persisted on the queue row.
+	DedupeKey string `json:"dedupe_key,omitempty"`
+
 	// Serialized payload for sending (contains all data needed to send)
 	Payload EmailQueuePayload `json:"payload"`
 
@@ -61,6 +75,12 @@ type EmailQueueEntry struct {
 	ProcessedAt *time.Time `json:"processed_at,omitempty"`
 }
 
+// IsMarketing returns true when the entry is sent as a marketing email: its source is a
+// marketing source and the payload is not marked transactional
+func (e *EmailQueueEntry) IsMarketing() bool {
+	return e.SourceType.IsMarketing() && !e.Payload.Transactional
+}
+
 // EmailQueuePayload contains all data needed to send the email
 // This is stored as JSONB in the database
 type EmailQueuePayload struct {
@@ -83,6 +103,14 @@ type EmailQueuePayload struct {
 	TemplateVersion int                    `json:"template_version"`        // Needed for message_history
 	ListID          string                 `json:"list_id,omitempty"`       // For broadcasts
 	TemplateData    map[string]interface{} `json:"template_data,omitempty"` // For message history logging
+
+	// Seed marks a broadcast copy sent to a workspace seed inbox: it is delivered without
+	// a message history record so it stays out of the broadcast stats
+	Seed bool `json:"seed,omitempty"`
+
+	// Transactional marks an email of a marketing source that is sent as a transactional
+	// message (transactional_email automation nodes), so it goes out without bulk headers
+	Transactional bool `json:"transactional,omitempty"`
 }
 
 // ToSendEmailProviderRequest converts the payload to a SendEmailProviderRequest
@@ -118,6 +146,13 @@ type EmailQueueRepository interface {
 	// EnqueueTx adds emails to the queue within an existing transaction
 	EnqueueTx(ctx context.Context, tx *sql.Tx, entries []*EmailQueueEntry) error
 
+	// ClaimDedupeKey records the dedupe key of an entry sent without being enqueued and
+	// reports false when the key was already claimed within the dedup window
+	ClaimDedupeKey(ctx context.Context, workspaceID string, entry *EmailQueueEntry) (bool, error)
+
+	// ReleaseDedupeKey removes the dedupe key claimed for an entry whose send failed
+	ReleaseDedupeKey(ctx context.Context, workspaceID string, entry *EmailQueueEntry) error
+
 	// FetchPending retrieves pending emails for processing
 	// Uses FOR UPDATE SKIP LOCKED to allow concurrent workers
 	// Orders by priority ASC (lower = higher priority), then created_at ASC
@@ -130,6 +165,9 @@ type EmailQueueRepository interface {
 	// (entries are removed immediately rather than marked with a "sent" status)
 	MarkAsSent(ctx context.Context, workspaceID string, id string) error
 
+	// MarkAsSentBatch deletes several entries after successful sends in a single statement
+	MarkAsSentBatch(ctx context.Context, workspaceID string, ids []string) error
+
 	// MarkAsFailed marks an entry as failed and schedules retry
 	MarkAsFailed(ctx context.Context, workspaceID string, id string, errorMsg string, nextRetryAt *time.Time) error
 
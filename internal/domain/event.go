@@ -20,6 +20,7 @@ const (
 	EventBroadcastSent           EventType = "broadcast.sent"
 	EventBroadcastFailed         EventType = "broadcast.failed"
 	EventBroadcastCancelled      EventType = "broadcast.cancelled"
+	EventBroadcastUnscheduled    EventType = "broadcast.unscheduled"
 	EventBroadcastCircuitBreaker EventType = "broadcast.circuit_breaker"
 )
 
@@ -1,5 +1,7 @@
 package domain
 
+import "strings"
+
 // DefaultLanguageCode is the fallback language used when none is specified.
 const DefaultLanguageCode = "en"
 
@@ -45,6 +47,35 @@ func IsValidLanguage(code string) bool {
 	return ok
 }
 
+// LocaleCandidates returns the language codes to look up for a contact locale, most specific
+// first. The locale is normalized ("fr_ca" becomes "fr-CA") and each trailing subtag is then
+// dropped, so a "fr-CA" contact falls back to "fr" content.
+func LocaleCandidates(locale string) []string {
+	parts := strings.FieldsFunc(strings.TrimSpace(locale), func(r rune) bool {
+		return r == '-' || r == '_'
+	})
+	if len(parts) == 0 {
+		return nil
+	}
+
+	for i, part := range parts {
+		switch {
+		case i == 0:
+			parts[i] = strings.ToLower(part)
+		case len(part) == 2:
+			parts[i] = strings.ToUpper(part) // Region, e.g. "BR"
+		case len(part) == 4:
+			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:]) // Script, e.g. "Hant"
+		}
+	}
+
+	candidates := make([]string, 0, len(parts))
+	for i := len(parts); i > 0; i-- {
+		candidates = append(candidates, strings.Join(parts[:i], "-"))
+	}
+	return candidates
+}
+
 // SupportedUILanguages are the locales the console UI and the system emails
 // (magic code, workspace invitation, circuit-breaker alert) are translated into.
 // A user's language preference must be one of these. It is deliberately narrower
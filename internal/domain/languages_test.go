@@ -66,3 +66,21 @@ func TestIsSupportedUILanguage(t *testing.T) {
 		assert.True(t, IsValidLanguage(code), "UI language %s must be in SupportedLanguages", code)
 	}
 }
+
+func TestLocaleCandidates(t *testing.T) {
+	tests := []struct {
+		locale   string
+		expected []string
+	}{
+		{"", nil},
+		{"fr", []string{"fr"}},
+		{"FR", []string{"fr"}},
+		{"fr-CA", []string{"fr-CA", "fr"}},
+		{"pt_br", []string{"pt-BR", "pt"}},
+		{" zh-hant-tw ", []string{"zh-Hant-TW", "zh-Hant", "zh"}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, LocaleCandidates(tt.locale), "locale %q", tt.locale)
+	}
+}
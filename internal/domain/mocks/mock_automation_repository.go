@@ -37,6 +37,20 @@ func (m *MockAutomationRepository) EXPECT() *MockAutomationRepositoryMockRecorde
 	return m.recorder
 }
 
+// ClearTriggerLog mocks base method.
+func (m *MockAutomationRepository) ClearTriggerLog(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearTriggerLog", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearTriggerLog indicates an expected call of ClearTriggerLog.
+func (mr *MockAutomationRepositoryMockRecorder) ClearTriggerLog(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearTriggerLog", reflect.TypeOf((*MockAutomationRepository)(nil).ClearTriggerLog), arg0, arg1, arg2, arg3)
+}
+
 // Create mocks base method.
 func (m *MockAutomationRepository) Create(arg0 context.Context, arg1 string, arg2 *domain.Automation) error {
 	m.ctrl.T.Helper()
@@ -121,6 +135,21 @@ func (mr *MockAutomationRepositoryMockRecorder) Delete(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAutomationRepository)(nil).Delete), arg0, arg1, arg2)
 }
 
+// DeleteFinishedContactAutomations mocks base method.
+func (m *MockAutomationRepository) DeleteFinishedContactAutomations(arg0 context.Context, arg1 string, arg2 time.Time, arg3 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFinishedContactAutomations", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFinishedContactAutomations indicates an expected call of DeleteFinishedContactAutomations.
+func (mr *MockAutomationRepositoryMockRecorder) DeleteFinishedContactAutomations(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFinishedContactAutomations", reflect.TypeOf((*MockAutomationRepository)(nil).DeleteFinishedContactAutomations), arg0, arg1, arg2, arg3)
+}
+
 // DeleteTx mocks base method.
 func (m *MockAutomationRepository) DeleteTx(arg0 context.Context, arg1 *sql.Tx, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -149,6 +178,21 @@ func (mr *MockAutomationRepositoryMockRecorder) DropAutomationTrigger(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropAutomationTrigger", reflect.TypeOf((*MockAutomationRepository)(nil).DropAutomationTrigger), arg0, arg1, arg2)
 }
 
+// EnrollContact mocks base method.
+func (m *MockAutomationRepository) EnrollContact(arg0 context.Context, arg1 string, arg2 *domain.Automation, arg3 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollContact", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollContact indicates an expected call of EnrollContact.
+func (mr *MockAutomationRepositoryMockRecorder) EnrollContact(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollContact", reflect.TypeOf((*MockAutomationRepository)(nil).EnrollContact), arg0, arg1, arg2, arg3)
+}
+
 // GetByID mocks base method.
 func (m *MockAutomationRepository) GetByID(arg0 context.Context, arg1, arg2 string) (*domain.Automation, error) {
 	m.ctrl.T.Helper()
@@ -224,6 +268,21 @@ func (mr *MockAutomationRepositoryMockRecorder) GetContactAutomationTx(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactAutomationTx", reflect.TypeOf((*MockAutomationRepository)(nil).GetContactAutomationTx), arg0, arg1, arg2, arg3)
 }
 
+// GetInactiveContactEmails mocks base method.
+func (m *MockAutomationRepository) GetInactiveContactEmails(arg0 context.Context, arg1 string, arg2 *domain.Automation, arg3 time.Time, arg4 string, arg5 int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInactiveContactEmails", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInactiveContactEmails indicates an expected call of GetInactiveContactEmails.
+func (mr *MockAutomationRepositoryMockRecorder) GetInactiveContactEmails(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInactiveContactEmails", reflect.TypeOf((*MockAutomationRepository)(nil).GetInactiveContactEmails), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // GetNodeExecutions mocks base method.
 func (m *MockAutomationRepository) GetNodeExecutions(arg0 context.Context, arg1, arg2 string) ([]*domain.NodeExecution, error) {
 	m.ctrl.T.Helper()
@@ -269,6 +328,36 @@ func (mr *MockAutomationRepositoryMockRecorder) GetScheduledContactAutomationsGl
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledContactAutomationsGlobal", reflect.TypeOf((*MockAutomationRepository)(nil).GetScheduledContactAutomationsGlobal), arg0, arg1, arg2)
 }
 
+// GetSegmentContactEmails mocks base method.
+func (m *MockAutomationRepository) GetSegmentContactEmails(arg0 context.Context, arg1, arg2, arg3 string, arg4 int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSegmentContactEmails", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSegmentContactEmails indicates an expected call of GetSegmentContactEmails.
+func (mr *MockAutomationRepositoryMockRecorder) GetSegmentContactEmails(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSegmentContactEmails", reflect.TypeOf((*MockAutomationRepository)(nil).GetSegmentContactEmails), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetVersion mocks base method.
+func (m *MockAutomationRepository) GetVersion(arg0 context.Context, arg1, arg2 string, arg3 int) (*domain.AutomationVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersion", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.AutomationVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersion indicates an expected call of GetVersion.
+func (mr *MockAutomationRepositoryMockRecorder) GetVersion(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockAutomationRepository)(nil).GetVersion), arg0, arg1, arg2, arg3)
+}
+
 // IncrementAutomationStat mocks base method.
 func (m *MockAutomationRepository) IncrementAutomationStat(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -315,6 +404,109 @@ func (mr *MockAutomationRepositoryMockRecorder) ListContactAutomations(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContactAutomations", reflect.TypeOf((*MockAutomationRepository)(nil).ListContactAutomations), arg0, arg1, arg2)
 }
 
+// ListContactAutomationsMeetingGoal mocks base method.
+func (m *MockAutomationRepository) ListContactAutomationsMeetingGoal(arg0 context.Context, arg1 string, arg2 domain.GoalContactFilter) ([]*domain.ContactAutomation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListContactAutomationsMeetingGoal", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.ContactAutomation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListContactAutomationsMeetingGoal indicates an expected call of ListContactAutomationsMeetingGoal.
+func (mr *MockAutomationRepositoryMockRecorder) ListContactAutomationsMeetingGoal(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContactAutomationsMeetingGoal", reflect.TypeOf((*MockAutomationRepository)(nil).ListContactAutomationsMeetingGoal), arg0, arg1, arg2)
+}
+
+// ListTags mocks base method.
+func (m *MockAutomationRepository) ListTags(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockAutomationRepositoryMockRecorder) ListTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockAutomationRepository)(nil).ListTags), arg0, arg1)
+}
+
+// ListWaitingVersions mocks base method.
+func (m *MockAutomationRepository) ListWaitingVersions(arg0 context.Context, arg1, arg2 string, arg3 int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWaitingVersions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWaitingVersions indicates an expected call of ListWaitingVersions.
+func (mr *MockAutomationRepositoryMockRecorder) ListWaitingVersions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWaitingVersions", reflect.TypeOf((*MockAutomationRepository)(nil).ListWaitingVersions), arg0, arg1, arg2, arg3)
+}
+
+// RecordNodeExecutionStats mocks base method.
+func (m *MockAutomationRepository) RecordNodeExecutionStats(arg0 context.Context, arg1, arg2 string, arg3 map[string]*domain.AutomationNodeExecutionStats) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordNodeExecutionStats", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordNodeExecutionStats indicates an expected call of RecordNodeExecutionStats.
+func (mr *MockAutomationRepositoryMockRecorder) RecordNodeExecutionStats(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordNodeExecutionStats", reflect.TypeOf((*MockAutomationRepository)(nil).RecordNodeExecutionStats), arg0, arg1, arg2, arg3)
+}
+
+// RecordRevenue mocks base method.
+func (m *MockAutomationRepository) RecordRevenue(arg0 context.Context, arg1 string, arg2 *domain.AutomationRevenue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordRevenue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordRevenue indicates an expected call of RecordRevenue.
+func (mr *MockAutomationRepositoryMockRecorder) RecordRevenue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRevenue", reflect.TypeOf((*MockAutomationRepository)(nil).RecordRevenue), arg0, arg1, arg2)
+}
+
+// ReenrollContactAutomation mocks base method.
+func (m *MockAutomationRepository) ReenrollContactAutomation(arg0 context.Context, arg1 string, arg2 *domain.Automation, arg3, arg4 string, arg5 int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReenrollContactAutomation", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReenrollContactAutomation indicates an expected call of ReenrollContactAutomation.
+func (mr *MockAutomationRepositoryMockRecorder) ReenrollContactAutomation(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReenrollContactAutomation", reflect.TypeOf((*MockAutomationRepository)(nil).ReenrollContactAutomation), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// ReserveThrottleSlot mocks base method.
+func (m *MockAutomationRepository) ReserveThrottleSlot(arg0 context.Context, arg1, arg2, arg3 string, arg4 int, arg5 time.Time) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveThrottleSlot", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveThrottleSlot indicates an expected call of ReserveThrottleSlot.
+func (mr *MockAutomationRepositoryMockRecorder) ReserveThrottleSlot(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveThrottleSlot", reflect.TypeOf((*MockAutomationRepository)(nil).ReserveThrottleSlot), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // Update mocks base method.
 func (m *MockAutomationRepository) Update(arg0 context.Context, arg1 string, arg2 *domain.Automation) error {
 	m.ctrl.T.Helper()
@@ -413,6 +605,20 @@ func (mr *MockAutomationRepositoryMockRecorder) UpdateNodeExecutionTx(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodeExecutionTx", reflect.TypeOf((*MockAutomationRepository)(nil).UpdateNodeExecutionTx), arg0, arg1, arg2, arg3)
 }
 
+// UpdateTags mocks base method.
+func (m *MockAutomationRepository) UpdateTags(arg0 context.Context, arg1, arg2 string, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTags", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTags indicates an expected call of UpdateTags.
+func (mr *MockAutomationRepositoryMockRecorder) UpdateTags(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTags", reflect.TypeOf((*MockAutomationRepository)(nil).UpdateTags), arg0, arg1, arg2, arg3)
+}
+
 // UpdateTx mocks base method.
 func (m *MockAutomationRepository) UpdateTx(arg0 context.Context, arg1 *sql.Tx, arg2 string, arg3 *domain.Automation) error {
 	m.ctrl.T.Helper()
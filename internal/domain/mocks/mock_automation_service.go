@@ -49,6 +49,34 @@ func (mr *MockAutomationServiceMockRecorder) Activate(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activate", reflect.TypeOf((*MockAutomationService)(nil).Activate), arg0, arg1, arg2)
 }
 
+// CheckIntegrations mocks base method.
+func (m *MockAutomationService) CheckIntegrations(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIntegrations", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckIntegrations indicates an expected call of CheckIntegrations.
+func (mr *MockAutomationServiceMockRecorder) CheckIntegrations(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIntegrations", reflect.TypeOf((*MockAutomationService)(nil).CheckIntegrations), arg0, arg1, arg2)
+}
+
+// CheckWebhookURLs mocks base method.
+func (m *MockAutomationService) CheckWebhookURLs(arg0 context.Context, arg1 *domain.Automation) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckWebhookURLs", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// CheckWebhookURLs indicates an expected call of CheckWebhookURLs.
+func (mr *MockAutomationServiceMockRecorder) CheckWebhookURLs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckWebhookURLs", reflect.TypeOf((*MockAutomationService)(nil).CheckWebhookURLs), arg0, arg1)
+}
+
 // Create mocks base method.
 func (m *MockAutomationService) Create(arg0 context.Context, arg1 string, arg2 *domain.Automation) error {
 	m.ctrl.T.Helper()
@@ -77,6 +105,21 @@ func (mr *MockAutomationServiceMockRecorder) Delete(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAutomationService)(nil).Delete), arg0, arg1, arg2)
 }
 
+// EnrollContacts mocks base method.
+func (m *MockAutomationService) EnrollContacts(arg0 context.Context, arg1, arg2 string, arg3 []string) (*domain.BulkOperationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollContacts", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.BulkOperationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollContacts indicates an expected call of EnrollContacts.
+func (mr *MockAutomationServiceMockRecorder) EnrollContacts(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollContacts", reflect.TypeOf((*MockAutomationService)(nil).EnrollContacts), arg0, arg1, arg2, arg3)
+}
+
 // Get mocks base method.
 func (m *MockAutomationService) Get(arg0 context.Context, arg1, arg2 string) (*domain.Automation, error) {
 	m.ctrl.T.Helper()
@@ -92,6 +135,21 @@ func (mr *MockAutomationServiceMockRecorder) Get(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAutomationService)(nil).Get), arg0, arg1, arg2)
 }
 
+// GetContactNextTick mocks base method.
+func (m *MockAutomationService) GetContactNextTick(arg0 context.Context, arg1, arg2, arg3 string) (*domain.ContactNextTick, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactNextTick", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.ContactNextTick)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactNextTick indicates an expected call of GetContactNextTick.
+func (mr *MockAutomationServiceMockRecorder) GetContactNextTick(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactNextTick", reflect.TypeOf((*MockAutomationService)(nil).GetContactNextTick), arg0, arg1, arg2, arg3)
+}
+
 // GetContactNodeExecutions mocks base method.
 func (m *MockAutomationService) GetContactNodeExecutions(arg0 context.Context, arg1, arg2, arg3 string) (*domain.ContactAutomation, []*domain.NodeExecution, error) {
 	m.ctrl.T.Helper()
@@ -108,6 +166,21 @@ func (mr *MockAutomationServiceMockRecorder) GetContactNodeExecutions(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactNodeExecutions", reflect.TypeOf((*MockAutomationService)(nil).GetContactNodeExecutions), arg0, arg1, arg2, arg3)
 }
 
+// GetNodeStats mocks base method.
+func (m *MockAutomationService) GetNodeStats(arg0 context.Context, arg1, arg2 string) (map[string]*domain.AutomationNodeExecutionStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeStats", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]*domain.AutomationNodeExecutionStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodeStats indicates an expected call of GetNodeStats.
+func (mr *MockAutomationServiceMockRecorder) GetNodeStats(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeStats", reflect.TypeOf((*MockAutomationService)(nil).GetNodeStats), arg0, arg1, arg2)
+}
+
 // List mocks base method.
 func (m *MockAutomationService) List(arg0 context.Context, arg1 string, arg2 domain.AutomationFilter) ([]*domain.Automation, int, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +197,21 @@ func (mr *MockAutomationServiceMockRecorder) List(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAutomationService)(nil).List), arg0, arg1, arg2)
 }
 
+// ListTags mocks base method.
+func (m *MockAutomationService) ListTags(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockAutomationServiceMockRecorder) ListTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockAutomationService)(nil).ListTags), arg0, arg1)
+}
+
 // Pause mocks base method.
 func (m *MockAutomationService) Pause(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -138,6 +226,35 @@ func (mr *MockAutomationServiceMockRecorder) Pause(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockAutomationService)(nil).Pause), arg0, arg1, arg2)
 }
 
+// ReenrollContacts mocks base method.
+func (m *MockAutomationService) ReenrollContacts(arg0 context.Context, arg1, arg2 string, arg3 []string, arg4 string) (*domain.ReenrollContactsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReenrollContacts", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*domain.ReenrollContactsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReenrollContacts indicates an expected call of ReenrollContacts.
+func (mr *MockAutomationServiceMockRecorder) ReenrollContacts(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReenrollContacts", reflect.TypeOf((*MockAutomationService)(nil).ReenrollContacts), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SetTags mocks base method.
+func (m *MockAutomationService) SetTags(arg0 context.Context, arg1, arg2 string, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTags", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTags indicates an expected call of SetTags.
+func (mr *MockAutomationServiceMockRecorder) SetTags(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTags", reflect.TypeOf((*MockAutomationService)(nil).SetTags), arg0, arg1, arg2, arg3)
+}
+
 // Update mocks base method.
 func (m *MockAutomationService) Update(arg0 context.Context, arg1 string, arg2 *domain.Automation) error {
 	m.ctrl.T.Helper()
@@ -151,3 +268,18 @@ func (mr *MockAutomationServiceMockRecorder) Update(arg0, arg1, arg2 interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAutomationService)(nil).Update), arg0, arg1, arg2)
 }
+
+// TestWebhookNode mocks base method.
+func (m *MockAutomationService) TestWebhookNode(arg0 context.Context, arg1, arg2, arg3, arg4 string) (*domain.WebhookNodeTestResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestWebhookNode", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*domain.WebhookNodeTestResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestWebhookNode indicates an expected call of TestWebhookNode.
+func (mr *MockAutomationServiceMockRecorder) TestWebhookNode(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestWebhookNode", reflect.TypeOf((*MockAutomationService)(nil).TestWebhookNode), arg0, arg1, arg2, arg3, arg4)
+}
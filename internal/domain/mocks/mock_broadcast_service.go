@@ -49,6 +49,20 @@ func (mr *MockBroadcastServiceMockRecorder) CancelBroadcast(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBroadcast", reflect.TypeOf((*MockBroadcastService)(nil).CancelBroadcast), arg0, arg1)
 }
 
+// CancelBroadcastSchedule mocks base method.
+func (m *MockBroadcastService) CancelBroadcastSchedule(arg0 context.Context, arg1 *domain.CancelBroadcastScheduleRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelBroadcastSchedule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelBroadcastSchedule indicates an expected call of CancelBroadcastSchedule.
+func (mr *MockBroadcastServiceMockRecorder) CancelBroadcastSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBroadcastSchedule", reflect.TypeOf((*MockBroadcastService)(nil).CancelBroadcastSchedule), arg0, arg1)
+}
+
 // CreateBroadcast mocks base method.
 func (m *MockBroadcastService) CreateBroadcast(arg0 context.Context, arg1 *domain.CreateBroadcastRequest) (*domain.Broadcast, error) {
 	m.ctrl.T.Helper()
@@ -137,6 +151,21 @@ func (mr *MockBroadcastServiceMockRecorder) PauseBroadcast(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseBroadcast", reflect.TypeOf((*MockBroadcastService)(nil).PauseBroadcast), arg0, arg1)
 }
 
+// PreviewRendered mocks base method.
+func (m *MockBroadcastService) PreviewRendered(arg0 context.Context, arg1 *domain.PreviewRenderedBroadcastRequest) (*domain.PreviewRenderedBroadcastResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewRendered", arg0, arg1)
+	ret0, _ := ret[0].(*domain.PreviewRenderedBroadcastResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewRendered indicates an expected call of PreviewRendered.
+func (mr *MockBroadcastServiceMockRecorder) PreviewRendered(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewRendered", reflect.TypeOf((*MockBroadcastService)(nil).PreviewRendered), arg0, arg1)
+}
+
 // RefreshGlobalFeed mocks base method.
 func (m *MockBroadcastService) RefreshGlobalFeed(arg0 context.Context, arg1 *domain.RefreshGlobalFeedRequest) (*domain.RefreshGlobalFeedResponse, error) {
 	m.ctrl.T.Helper()
@@ -170,6 +170,21 @@ func (mr *MockContactRepositoryMockRecorder) GetContactsForBroadcast(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactsForBroadcast", reflect.TypeOf((*MockContactRepository)(nil).GetContactsForBroadcast), arg0, arg1, arg2, arg3, arg4)
 }
 
+// GetContactsJoinedListBefore mocks base method.
+func (m *MockContactRepository) GetContactsJoinedListBefore(arg0 context.Context, arg1, arg2 string, arg3, arg4 time.Time, arg5 string, arg6 int) ([]*domain.ContactWithList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactsJoinedListBefore", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].([]*domain.ContactWithList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactsJoinedListBefore indicates an expected call of GetContactsJoinedListBefore.
+func (mr *MockContactRepositoryMockRecorder) GetContactsJoinedListBefore(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactsJoinedListBefore", reflect.TypeOf((*MockContactRepository)(nil).GetContactsJoinedListBefore), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
 // MarkEmailsAsBounced mocks base method.
 func (m *MockContactRepository) MarkEmailsAsBounced(arg0 context.Context, arg1 string, arg2 []string, arg3 time.Time) error {
 	m.ctrl.T.Helper()
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	domain "github.com/Notifuse/notifuse/internal/domain"
@@ -78,6 +79,20 @@ func (mr *MockContactServiceMockRecorder) DeleteContact(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContact", reflect.TypeOf((*MockContactService)(nil).DeleteContact), arg0, arg1, arg2)
 }
 
+// ExportContacts mocks base method.
+func (m *MockContactService) ExportContacts(arg0 context.Context, arg1 *domain.ExportContactsRequest, arg2 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportContacts", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportContacts indicates an expected call of ExportContacts.
+func (mr *MockContactServiceMockRecorder) ExportContacts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportContacts", reflect.TypeOf((*MockContactService)(nil).ExportContacts), arg0, arg1, arg2)
+}
+
 // GetContactByEmail mocks base method.
 func (m *MockContactService) GetContactByEmail(arg0 context.Context, arg1, arg2 string) (*domain.Contact, error) {
 	m.ctrl.T.Helper()
@@ -78,3 +78,19 @@ func (mr *MockContactTimelineRepositoryMockRecorder) List(arg0, arg1, arg2, arg3
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockContactTimelineRepository)(nil).List), arg0, arg1, arg2, arg3, arg4)
 }
+
+// ListAll mocks base method.
+func (m *MockContactTimelineRepository) ListAll(arg0 context.Context, arg1, arg2 string, arg3 []string, arg4 int, arg5 *string) ([]*domain.ContactTimelineEntry, *string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAll", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]*domain.ContactTimelineEntry)
+	ret1, _ := ret[1].(*string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAll indicates an expected call of ListAll.
+func (mr *MockContactTimelineRepositoryMockRecorder) ListAll(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockContactTimelineRepository)(nil).ListAll), arg0, arg1, arg2, arg3, arg4, arg5)
+}
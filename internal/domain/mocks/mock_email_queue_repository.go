@@ -37,6 +37,21 @@ func (m *MockEmailQueueRepository) EXPECT() *MockEmailQueueRepositoryMockRecorde
 	return m.recorder
 }
 
+// ClaimDedupeKey mocks base method.
+func (m *MockEmailQueueRepository) ClaimDedupeKey(arg0 context.Context, arg1 string, arg2 *domain.EmailQueueEntry) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDedupeKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDedupeKey indicates an expected call of ClaimDedupeKey.
+func (mr *MockEmailQueueRepositoryMockRecorder) ClaimDedupeKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDedupeKey", reflect.TypeOf((*MockEmailQueueRepository)(nil).ClaimDedupeKey), arg0, arg1, arg2)
+}
+
 // CountBySourceAndStatus mocks base method.
 func (m *MockEmailQueueRepository) CountBySourceAndStatus(arg0 context.Context, arg1 string, arg2 domain.EmailQueueSourceType, arg3 string, arg4 domain.EmailQueueStatus) (int64, error) {
 	m.ctrl.T.Helper()
@@ -211,6 +226,20 @@ func (mr *MockEmailQueueRepositoryMockRecorder) MarkAsSent(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsSent", reflect.TypeOf((*MockEmailQueueRepository)(nil).MarkAsSent), arg0, arg1, arg2)
 }
 
+// MarkAsSentBatch mocks base method.
+func (m *MockEmailQueueRepository) MarkAsSentBatch(arg0 context.Context, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAsSentBatch", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAsSentBatch indicates an expected call of MarkAsSentBatch.
+func (mr *MockEmailQueueRepositoryMockRecorder) MarkAsSentBatch(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsSentBatch", reflect.TypeOf((*MockEmailQueueRepository)(nil).MarkAsSentBatch), arg0, arg1, arg2)
+}
+
 // PauseBySource mocks base method.
 func (m *MockEmailQueueRepository) PauseBySource(arg0 context.Context, arg1 string, arg2 domain.EmailQueueSourceType, arg3 string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -241,6 +270,20 @@ func (mr *MockEmailQueueRepositoryMockRecorder) PauseBySourceTx(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseBySourceTx", reflect.TypeOf((*MockEmailQueueRepository)(nil).PauseBySourceTx), arg0, arg1, arg2, arg3)
 }
 
+// ReleaseDedupeKey mocks base method.
+func (m *MockEmailQueueRepository) ReleaseDedupeKey(arg0 context.Context, arg1 string, arg2 *domain.EmailQueueEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDedupeKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseDedupeKey indicates an expected call of ReleaseDedupeKey.
+func (mr *MockEmailQueueRepositoryMockRecorder) ReleaseDedupeKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDedupeKey", reflect.TypeOf((*MockEmailQueueRepository)(nil).ReleaseDedupeKey), arg0, arg1, arg2)
+}
+
 // ResumeBySource mocks base method.
 func (m *MockEmailQueueRepository) ResumeBySource(arg0 context.Context, arg1 string, arg2 domain.EmailQueueSourceType, arg3 string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -35,6 +35,35 @@ func (m *MockEmailServiceInterface) EXPECT() *MockEmailServiceInterfaceMockRecor
 	return m.recorder
 }
 
+// CheckDeliverability mocks base method.
+func (m *MockEmailServiceInterface) CheckDeliverability(arg0 context.Context, arg1 domain.CheckDeliverabilityRequest) (*domain.DeliverabilityReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckDeliverability", arg0, arg1)
+	ret0, _ := ret[0].(*domain.DeliverabilityReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckDeliverability indicates an expected call of CheckDeliverability.
+func (mr *MockEmailServiceInterfaceMockRecorder) CheckDeliverability(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDeliverability", reflect.TypeOf((*MockEmailServiceInterface)(nil).CheckDeliverability), arg0, arg1)
+}
+
+// CheckIntegration mocks base method.
+func (m *MockEmailServiceInterface) CheckIntegration(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckIntegration", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckIntegration indicates an expected call of CheckIntegration.
+func (mr *MockEmailServiceInterfaceMockRecorder) CheckIntegration(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIntegration", reflect.TypeOf((*MockEmailServiceInterface)(nil).CheckIntegration), arg0, arg1, arg2)
+}
+
 // OpenEmail mocks base method.
 func (m *MockEmailServiceInterface) OpenEmail(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -91,6 +120,20 @@ func (mr *MockEmailServiceInterfaceMockRecorder) TestEmailProvider(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestEmailProvider", reflect.TypeOf((*MockEmailServiceInterface)(nil).TestEmailProvider), arg0, arg1, arg2, arg3)
 }
 
+// TestIntegration mocks base method.
+func (m *MockEmailServiceInterface) TestIntegration(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestIntegration", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TestIntegration indicates an expected call of TestIntegration.
+func (mr *MockEmailServiceInterfaceMockRecorder) TestIntegration(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestIntegration", reflect.TypeOf((*MockEmailServiceInterface)(nil).TestIntegration), arg0, arg1, arg2)
+}
+
 // VisitLink mocks base method.
 func (m *MockEmailServiceInterface) VisitLink(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -78,6 +78,21 @@ func (mr *MockSegmentRepositoryMockRecorder) DeleteSegment(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSegment", reflect.TypeOf((*MockSegmentRepository)(nil).DeleteSegment), arg0, arg1, arg2)
 }
 
+// GetContactSegmentMembership mocks base method.
+func (m *MockSegmentRepository) GetContactSegmentMembership(arg0 context.Context, arg1, arg2, arg3 string) (*domain.ContactSegment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactSegmentMembership", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.ContactSegment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactSegmentMembership indicates an expected call of GetContactSegmentMembership.
+func (mr *MockSegmentRepositoryMockRecorder) GetContactSegmentMembership(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactSegmentMembership", reflect.TypeOf((*MockSegmentRepository)(nil).GetContactSegmentMembership), arg0, arg1, arg2, arg3)
+}
+
 // GetContactSegments mocks base method.
 func (m *MockSegmentRepository) GetContactSegments(arg0 context.Context, arg1, arg2 string) ([]*domain.Segment, error) {
 	m.ctrl.T.Helper()
@@ -168,6 +183,21 @@ func (mr *MockSegmentRepositoryMockRecorder) PreviewSegment(arg0, arg1, arg2, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewSegment", reflect.TypeOf((*MockSegmentRepository)(nil).PreviewSegment), arg0, arg1, arg2, arg3, arg4)
 }
 
+// PreviewSegmentContacts mocks base method.
+func (m *MockSegmentRepository) PreviewSegmentContacts(arg0 context.Context, arg1, arg2 string, arg3 []interface{}, arg4, arg5 int) ([]*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewSegmentContacts", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewSegmentContacts indicates an expected call of PreviewSegmentContacts.
+func (mr *MockSegmentRepositoryMockRecorder) PreviewSegmentContacts(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewSegmentContacts", reflect.TypeOf((*MockSegmentRepository)(nil).PreviewSegmentContacts), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // RemoveContactFromSegment mocks base method.
 func (m *MockSegmentRepository) RemoveContactFromSegment(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -110,18 +110,18 @@ func (mr *MockSegmentServiceMockRecorder) ListSegments(arg0, arg1 interface{}) *
 }
 
 // PreviewSegment mocks base method.
-func (m *MockSegmentService) PreviewSegment(arg0 context.Context, arg1 string, arg2 *domain.TreeNode, arg3 int) (*domain.PreviewSegmentResponse, error) {
+func (m *MockSegmentService) PreviewSegment(arg0 context.Context, arg1 *domain.PreviewSegmentRequest) (*domain.PreviewSegmentResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PreviewSegment", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "PreviewSegment", arg0, arg1)
 	ret0, _ := ret[0].(*domain.PreviewSegmentResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // PreviewSegment indicates an expected call of PreviewSegment.
-func (mr *MockSegmentServiceMockRecorder) PreviewSegment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockSegmentServiceMockRecorder) PreviewSegment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewSegment", reflect.TypeOf((*MockSegmentService)(nil).PreviewSegment), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewSegment", reflect.TypeOf((*MockSegmentService)(nil).PreviewSegment), arg0, arg1)
 }
 
 // RebuildSegment mocks base method.
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Notifuse/notifuse/internal/domain (interfaces: SuppressionRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/Notifuse/notifuse/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSuppressionRepository is a mock of SuppressionRepository interface.
+type MockSuppressionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSuppressionRepositoryMockRecorder
+}
+
+// MockSuppressionRepositoryMockRecorder is the mock recorder for MockSuppressionRepository.
+type MockSuppressionRepositoryMockRecorder struct {
+	mock *MockSuppressionRepository
+}
+
+// NewMockSuppressionRepository creates a new mock instance.
+func NewMockSuppressionRepository(ctrl *gomock.Controller) *MockSuppressionRepository {
+	mock := &MockSuppressionRepository{ctrl: ctrl}
+	mock.recorder = &MockSuppressionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSuppressionRepository) EXPECT() *MockSuppressionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BulkUpsert mocks base method.
+func (m *MockSuppressionRepository) BulkUpsert(arg0 context.Context, arg1 string, arg2 []*domain.Suppression) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpsert", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkUpsert indicates an expected call of BulkUpsert.
+func (mr *MockSuppressionRepositoryMockRecorder) BulkUpsert(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpsert", reflect.TypeOf((*MockSuppressionRepository)(nil).BulkUpsert), arg0, arg1, arg2)
+}
+
+// IsSuppressed mocks base method.
+func (m *MockSuppressionRepository) IsSuppressed(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSuppressed", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSuppressed indicates an expected call of IsSuppressed.
+func (mr *MockSuppressionRepositoryMockRecorder) IsSuppressed(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSuppressed", reflect.TypeOf((*MockSuppressionRepository)(nil).IsSuppressed), arg0, arg1, arg2)
+}
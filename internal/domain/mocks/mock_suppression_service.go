@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Notifuse/notifuse/internal/domain (interfaces: SuppressionService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/Notifuse/notifuse/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSuppressionService is a mock of SuppressionService interface.
+type MockSuppressionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSuppressionServiceMockRecorder
+}
+
+// MockSuppressionServiceMockRecorder is the mock recorder for MockSuppressionService.
+type MockSuppressionServiceMockRecorder struct {
+	mock *MockSuppressionService
+}
+
+// NewMockSuppressionService creates a new mock instance.
+func NewMockSuppressionService(ctrl *gomock.Controller) *MockSuppressionService {
+	mock := &MockSuppressionService{ctrl: ctrl}
+	mock.recorder = &MockSuppressionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSuppressionService) EXPECT() *MockSuppressionServiceMockRecorder {
+	return m.recorder
+}
+
+// ImportSuppressions mocks base method.
+func (m *MockSuppressionService) ImportSuppressions(arg0 context.Context, arg1 *domain.ImportSuppressionsRequest) (*domain.ImportSuppressionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportSuppressions", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ImportSuppressionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportSuppressions indicates an expected call of ImportSuppressions.
+func (mr *MockSuppressionServiceMockRecorder) ImportSuppressions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSuppressions", reflect.TypeOf((*MockSuppressionService)(nil).ImportSuppressions), arg0, arg1)
+}
@@ -110,6 +110,21 @@ func (mr *MockTransactionalNotificationServiceMockRecorder) SendNotification(arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendNotification", reflect.TypeOf((*MockTransactionalNotificationService)(nil).SendNotification), arg0, arg1, arg2)
 }
 
+// SendTemplate mocks base method.
+func (m *MockTransactionalNotificationService) SendTemplate(arg0 context.Context, arg1 string, arg2 domain.TransactionalTemplateSendParams) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendTemplate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendTemplate indicates an expected call of SendTemplate.
+func (mr *MockTransactionalNotificationServiceMockRecorder) SendTemplate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTemplate", reflect.TypeOf((*MockTransactionalNotificationService)(nil).SendTemplate), arg0, arg1, arg2)
+}
+
 // TestTemplate mocks base method.
 func (m *MockTransactionalNotificationService) TestTemplate(arg0 context.Context, arg1, arg2, arg3, arg4, arg5, arg6 string, arg7 domain.EmailOptions) error {
 	m.ctrl.T.Helper()
@@ -2,9 +2,11 @@ package domain
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"net/url"
 	"regexp"
+	"strings"
 )
 
 //go:generate mockgen -destination mocks/mock_notification_center_service.go -package mocks github.com/Notifuse/notifuse/internal/domain NotificationCenterService
@@ -12,7 +14,7 @@ import (
 type NotificationCenterService interface {
 	// GetContactPreferences returns public lists and notifications for a contact
 	GetContactPreferences(ctx context.Context, workspaceID string, email string, emailHMAC string) (*ContactPreferencesResponse, error)
-	// UpdateContactPreferences updates a contact's language and/or timezone
+	// UpdateContactPreferences updates a contact's language, timezone and/or list subscriptions
 	UpdateContactPreferences(ctx context.Context, req *UpdateContactPreferencesRequest) error
 }
 
@@ -42,6 +44,13 @@ func (r *NotificationCenterRequest) FromURLValues(values url.Values) error {
 	r.Email = values.Get("email")
 	r.EmailHMAC = values.Get("email_hmac")
 	r.WorkspaceID = values.Get("workspace_id")
+	if token := values.Get("token"); token != "" {
+		workspaceID, email, emailHMAC, err := ParsePreferencesToken(token)
+		if err != nil {
+			return err
+		}
+		r.WorkspaceID, r.Email, r.EmailHMAC = workspaceID, email, emailHMAC
+	}
 	r.Action = values.Get("action")
 	r.ListID = values.Get("lid")
 	r.MessageID = values.Get("mid")
@@ -57,18 +66,64 @@ type ContactPreferencesResponse struct {
 	WebsiteURL   string         `json:"website_url"`
 }
 
+// GeneratePreferencesToken returns a signed token granting access to a contact's preference center.
+// The token carries the workspace and email, and is signed with the workspace secret key
+// through the email HMAC, so it stays valid as long as the secret key is not rotated.
+func GeneratePreferencesToken(workspaceID string, email string, secretKey string) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(workspaceID + "\n" + email))
+	return payload + "." + ComputeEmailHMAC(email, secretKey)
+}
+
+// ParsePreferencesToken decodes a preference center token. The returned email HMAC
+// still has to be verified against the workspace secret key.
+func ParsePreferencesToken(token string) (workspaceID string, email string, emailHMAC string, err error) {
+	payload, signature, found := strings.Cut(token, ".")
+	if !found || payload == "" || signature == "" {
+		return "", "", "", errors.New("invalid token")
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", "", "", errors.New("invalid token")
+	}
+
+	workspaceID, email, found = strings.Cut(string(decoded), "\n")
+	if !found || workspaceID == "" || email == "" {
+		return "", "", "", errors.New("invalid token")
+	}
+
+	return workspaceID, email, signature, nil
+}
+
+// ListPreference is the desired subscription state of a contact for a list
+type ListPreference struct {
+	ListID     string `json:"list_id"`
+	Subscribed bool   `json:"subscribed"`
+}
+
 // UpdateContactPreferencesRequest represents a request to update a contact's language/timezone
+// and list subscriptions. The contact is identified either by email/email_hmac or by a preference center token.
 type UpdateContactPreferencesRequest struct {
-	WorkspaceID string `json:"workspace_id"`
-	Email       string `json:"email"`
-	EmailHMAC   string `json:"email_hmac"`
-	Language    string `json:"language,omitempty"`
-	Timezone    string `json:"timezone,omitempty"`
+	WorkspaceID string           `json:"workspace_id"`
+	Email       string           `json:"email"`
+	EmailHMAC   string           `json:"email_hmac"`
+	Token       string           `json:"token,omitempty"`
+	Language    string           `json:"language,omitempty"`
+	Timezone    string           `json:"timezone,omitempty"`
+	Lists       []ListPreference `json:"lists,omitempty"`
 }
 
 var languageCodeRegex = regexp.MustCompile(`^[a-z]{2}$`)
 
+// Validate validates the request, resolving the token into workspace_id, email and email_hmac
 func (r *UpdateContactPreferencesRequest) Validate() error {
+	if r.Token != "" {
+		workspaceID, email, emailHMAC, err := ParsePreferencesToken(r.Token)
+		if err != nil {
+			return err
+		}
+		r.WorkspaceID, r.Email, r.EmailHMAC = workspaceID, email, emailHMAC
+	}
 	if r.WorkspaceID == "" {
 		return errors.New("workspace_id is required")
 	}
@@ -78,8 +133,8 @@ func (r *UpdateContactPreferencesRequest) Validate() error {
 	if r.EmailHMAC == "" {
 		return errors.New("email_hmac is required")
 	}
-	if r.Language == "" && r.Timezone == "" {
-		return errors.New("at least one of language or timezone must be provided")
+	if r.Language == "" && r.Timezone == "" && len(r.Lists) == 0 {
+		return errors.New("at least one of language, timezone or lists must be provided")
 	}
 	if r.Language != "" && !languageCodeRegex.MatchString(r.Language) {
 		return errors.New("language must be a 2-letter lowercase code")
@@ -87,5 +142,32 @@ func (r *UpdateContactPreferencesRequest) Validate() error {
 	if r.Timezone != "" && (len(r.Timezone) > 50 || len(r.Timezone) < 2) {
 		return errors.New("timezone must be between 2 and 50 characters")
 	}
+	seen := make(map[string]bool, len(r.Lists))
+	for _, list := range r.Lists {
+		if list.ListID == "" {
+			return errors.New("list_id is required for each list")
+		}
+		if seen[list.ListID] {
+			return errors.New("duplicate list_id: " + list.ListID)
+		}
+		seen[list.ListID] = true
+	}
 	return nil
 }
+
+// HasProfileChanges returns true when the request updates the contact's language or timezone
+func (r *UpdateContactPreferencesRequest) HasProfileChanges() bool {
+	return r.Language != "" || r.Timezone != ""
+}
+
+// ListIDsBySubscription splits the requested lists into the ones to subscribe to and to unsubscribe from
+func (r *UpdateContactPreferencesRequest) ListIDsBySubscription() (subscribe []string, unsubscribe []string) {
+	for _, list := range r.Lists {
+		if list.Subscribed {
+			subscribe = append(subscribe, list.ListID)
+		} else {
+			unsubscribe = append(unsubscribe, list.ListID)
+		}
+	}
+	return subscribe, unsubscribe
+}
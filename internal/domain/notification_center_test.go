@@ -8,6 +8,7 @@ import (
 
 	"github.com/Notifuse/notifuse/pkg/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNotificationCenterRequest_Validate(t *testing.T) {
@@ -417,7 +418,54 @@ func TestUpdateContactPreferencesRequest_Validate(t *testing.T) {
 				Email:       "test@example.com",
 				EmailHMAC:   "hmac",
 			},
-			wantErr: "at least one of language or timezone must be provided",
+			wantErr: "at least one of language, timezone or lists must be provided",
+		},
+		{
+			name: "lists only",
+			request: UpdateContactPreferencesRequest{
+				WorkspaceID: "ws1",
+				Email:       "test@example.com",
+				EmailHMAC:   "hmac",
+				Lists:       []ListPreference{{ListID: "newsletter", Subscribed: false}},
+			},
+		},
+		{
+			name: "list without id",
+			request: UpdateContactPreferencesRequest{
+				WorkspaceID: "ws1",
+				Email:       "test@example.com",
+				EmailHMAC:   "hmac",
+				Lists:       []ListPreference{{Subscribed: true}},
+			},
+			wantErr: "list_id is required for each list",
+		},
+		{
+			name: "duplicate list",
+			request: UpdateContactPreferencesRequest{
+				WorkspaceID: "ws1",
+				Email:       "test@example.com",
+				EmailHMAC:   "hmac",
+				Lists: []ListPreference{
+					{ListID: "newsletter", Subscribed: true},
+					{ListID: "newsletter", Subscribed: false},
+				},
+			},
+			wantErr: "duplicate list_id: newsletter",
+		},
+		{
+			name: "token instead of email and hmac",
+			request: UpdateContactPreferencesRequest{
+				Token:    GeneratePreferencesToken("ws1", "test@example.com", "secret"),
+				Language: "fr",
+			},
+		},
+		{
+			name: "invalid token",
+			request: UpdateContactPreferencesRequest{
+				Token:    "not-a-token",
+				Language: "fr",
+			},
+			wantErr: "invalid token",
 		},
 		{
 			name: "invalid language - too long",
@@ -483,6 +531,53 @@ func TestUpdateContactPreferencesRequest_Validate(t *testing.T) {
 	}
 }
 
+func TestPreferencesToken(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		token := GeneratePreferencesToken("ws1", "john+news@example.com", "secret")
+
+		workspaceID, email, emailHMAC, err := ParsePreferencesToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, "ws1", workspaceID)
+		assert.Equal(t, "john+news@example.com", email)
+		assert.True(t, VerifyEmailHMAC(email, emailHMAC, "secret"))
+		assert.False(t, VerifyEmailHMAC(email, emailHMAC, "other-secret"))
+	})
+
+	t.Run("token is URL safe", func(t *testing.T) {
+		token := GeneratePreferencesToken("ws1", "john+news@example.com", "secret")
+		assert.Equal(t, token, url.QueryEscape(token))
+	})
+
+	t.Run("invalid tokens", func(t *testing.T) {
+		for _, token := range []string{"", "abc", "abc.", ".hmac", "!!!.hmac", "d3Mx.hmac"} {
+			_, _, _, err := ParsePreferencesToken(token)
+			assert.Error(t, err, token)
+		}
+	})
+
+	t.Run("notification center request accepts a token", func(t *testing.T) {
+		var req NotificationCenterRequest
+		err := req.FromURLValues(url.Values{"token": {GeneratePreferencesToken("ws1", "test@example.com", "secret")}})
+		require.NoError(t, err)
+		assert.Equal(t, "ws1", req.WorkspaceID)
+		assert.Equal(t, "test@example.com", req.Email)
+		assert.Equal(t, ComputeEmailHMAC("test@example.com", "secret"), req.EmailHMAC)
+	})
+}
+
+func TestUpdateContactPreferencesRequest_ListIDsBySubscription(t *testing.T) {
+	req := UpdateContactPreferencesRequest{Lists: []ListPreference{
+		{ListID: "news", Subscribed: true},
+		{ListID: "promo", Subscribed: false},
+		{ListID: "product", Subscribed: true},
+	}}
+
+	subscribe, unsubscribe := req.ListIDsBySubscription()
+	assert.Equal(t, []string{"news", "product"}, subscribe)
+	assert.Equal(t, []string{"promo"}, unsubscribe)
+	assert.False(t, req.HasProfileChanges())
+}
+
 func TestNotificationCenterServiceInterface(t *testing.T) {
 	// This test is simply a placeholder to ensure our interface definition is correct
 	// We're not testing actual implementation here
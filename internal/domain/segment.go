@@ -377,10 +377,38 @@ func (r *DeleteSegmentRequest) Validate() (workspaceID string, id string, err er
 	return r.WorkspaceID, r.ID, nil
 }
 
+// PreviewSegmentRequest previews the contacts matching either an inline segment tree
+// or the definition of an existing segment, without persisting any membership
+type PreviewSegmentRequest struct {
+	WorkspaceID string    `json:"workspace_id"`
+	SegmentID   string    `json:"segment_id,omitempty"`
+	Tree        *TreeNode `json:"tree,omitempty"`
+	Limit       int       `json:"limit,omitempty"`
+	Offset      int       `json:"offset,omitempty"`
+}
+
+func (r *PreviewSegmentRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("invalid preview segment request: workspace_id is required")
+	}
+	if r.Tree == nil && r.SegmentID == "" {
+		return fmt.Errorf("invalid preview segment request: tree or segment_id is required")
+	}
+	if r.Tree != nil && r.SegmentID != "" {
+		return fmt.Errorf("invalid preview segment request: tree and segment_id cannot be used together")
+	}
+	if r.Offset < 0 {
+		return fmt.Errorf("invalid preview segment request: offset cannot be negative")
+	}
+	return nil
+}
+
 type PreviewSegmentResponse struct {
 	Emails       []string      `json:"emails"`
+	Contacts     []*Contact    `json:"contacts"`
 	TotalCount   int           `json:"total_count"`
 	Limit        int           `json:"limit"`
+	Offset       int           `json:"offset"`
 	GeneratedSQL string        `json:"generated_sql"`
 	SQLArgs      []interface{} `json:"sql_args"`
 }
@@ -405,8 +433,8 @@ type SegmentService interface {
 	// RebuildSegment triggers a rebuild of a segment
 	RebuildSegment(ctx context.Context, workspaceID, segmentID string) error
 
-	// PreviewSegment previews the contacts that would match a segment tree
-	PreviewSegment(ctx context.Context, workspaceID string, tree *TreeNode, limit int) (*PreviewSegmentResponse, error)
+	// PreviewSegment returns the total count and a page of the contacts that would match a segment
+	PreviewSegment(ctx context.Context, req *PreviewSegmentRequest) (*PreviewSegmentResponse, error)
 
 	// GetSegmentContacts retrieves the contacts belonging to a segment
 	GetSegmentContacts(ctx context.Context, workspaceID, segmentID string, limit, offset int) ([]string, error)
@@ -440,12 +468,18 @@ type SegmentRepository interface {
 	// GetContactSegments retrieves all segments a contact belongs to
 	GetContactSegments(ctx context.Context, workspaceID string, email string) ([]*Segment, error)
 
+	// GetContactSegmentMembership retrieves a contact's membership of a segment, or nil when the contact is not a member
+	GetContactSegmentMembership(ctx context.Context, workspaceID string, email string, segmentID string) (*ContactSegment, error)
+
 	// GetSegmentContactCount gets the count of contacts in a segment
 	GetSegmentContactCount(ctx context.Context, workspaceID string, segmentID string) (int, error)
 
 	// PreviewSegment executes a segment query and returns the count of matching contacts
 	PreviewSegment(ctx context.Context, workspaceID string, sqlQuery string, args []interface{}, limit int) (int, error)
 
+	// PreviewSegmentContacts executes a segment query and returns a page of matching contacts
+	PreviewSegmentContacts(ctx context.Context, workspaceID string, sqlQuery string, args []interface{}, limit, offset int) ([]*Contact, error)
+
 	// GetSegmentsDueForRecompute retrieves segments that need recomputation (recompute_after <= now)
 	GetSegmentsDueForRecompute(ctx context.Context, workspaceID string, limit int) ([]*Segment, error)
 
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+)
+
+//go:generate mockgen -destination mocks/mock_suppression_service.go -package mocks github.com/Notifuse/notifuse/internal/domain SuppressionService
+//go:generate mockgen -destination mocks/mock_suppression_repository.go -package mocks github.com/Notifuse/notifuse/internal/domain SuppressionRepository
+
+// MaxSuppressionImportSize is the maximum number of addresses accepted by a single import
+const MaxSuppressionImportSize = 50000
+
+// SuppressionReason explains why an address is suppressed
+type SuppressionReason string
+
+const (
+	// SuppressionReasonBounce indicates the address hard bounced
+	SuppressionReasonBounce SuppressionReason = "bounce"
+	// SuppressionReasonComplaint indicates the recipient marked a message as spam
+	SuppressionReasonComplaint SuppressionReason = "complaint"
+	// SuppressionReasonUnsubscribe indicates the recipient unsubscribed
+	SuppressionReasonUnsubscribe SuppressionReason = "unsubscribe"
+	// SuppressionReasonManual indicates the address was suppressed by hand (default)
+	SuppressionReasonManual SuppressionReason = "manual"
+)
+
+// ValidSuppressionReasons is the list of all valid suppression reasons
+var ValidSuppressionReasons = []SuppressionReason{
+	SuppressionReasonBounce,
+	SuppressionReasonComplaint,
+	SuppressionReasonUnsubscribe,
+	SuppressionReasonManual,
+}
+
+// Suppression is a workspace-wide address that broadcasts must never be sent to,
+// whether or not the address is a contact or subscribed to a list
+type Suppression struct {
+	Email     string            `json:"email"`
+	Reason    SuppressionReason `json:"reason"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// Normalize normalizes the email and defaults the reason to manual
+func (s *Suppression) Normalize() {
+	s.Email = NormalizeEmail(s.Email)
+	s.Reason = SuppressionReason(strings.ToLower(strings.TrimSpace(string(s.Reason))))
+	if s.Reason == "" {
+		s.Reason = SuppressionReasonManual
+	}
+}
+
+// Validate performs validation on the suppression fields
+func (s *Suppression) Validate() error {
+	if s.Email == "" {
+		return fmt.Errorf("email is required")
+	}
+	if !govalidator.IsEmail(s.Email) {
+		return fmt.Errorf("invalid email format: %s", s.Email)
+	}
+
+	for _, reason := range ValidSuppressionReasons {
+		if s.Reason == reason {
+			return nil
+		}
+	}
+	return fmt.Errorf("reason must be one of: %v", ValidSuppressionReasons)
+}
+
+// ImportSuppressionsRequest for bulk import of suppressed addresses.
+// Addresses are given either as an array or as CSV content with an email column
+// and an optional reason column (a header row naming the columns is optional).
+type ImportSuppressionsRequest struct {
+	WorkspaceID  string         `json:"workspace_id"`
+	Suppressions []*Suppression `json:"suppressions,omitempty"`
+	CSV          string         `json:"csv,omitempty"`
+}
+
+func (r *ImportSuppressionsRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+
+	if r.CSV != "" {
+		if len(r.Suppressions) > 0 {
+			return fmt.Errorf("suppressions and csv cannot both be provided")
+		}
+		suppressions, err := ParseSuppressionsCSV(r.CSV)
+		if err != nil {
+			return err
+		}
+		r.Suppressions = suppressions
+		r.CSV = ""
+	}
+
+	if len(r.Suppressions) == 0 {
+		return fmt.Errorf("suppressions array cannot be empty")
+	}
+	if len(r.Suppressions) > MaxSuppressionImportSize {
+		return fmt.Errorf("cannot import more than %d suppressions at once", MaxSuppressionImportSize)
+	}
+	for i, s := range r.Suppressions {
+		if s == nil {
+			return fmt.Errorf("suppression at index %d is null", i)
+		}
+	}
+	return nil
+}
+
+// ParseSuppressionsCSV parses CSV content into suppressions. Without a header row
+// the first column is the email and the second (optional) column the reason.
+func ParseSuppressionsCSV(content string) ([]*Suppression, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	emailCol, reasonCol := 0, 1
+	var suppressions []*Suppression
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv: %w", err)
+		}
+
+		if line == 1 && isSuppressionCSVHeader(record) {
+			emailCol, reasonCol = -1, -1
+			for i, column := range record {
+				switch strings.ToLower(strings.TrimSpace(column)) {
+				case "email":
+					emailCol = i
+				case "reason":
+					reasonCol = i
+				}
+			}
+			continue
+		}
+
+		// Skip blank lines
+		if len(record) == 0 || (len(record) == 1 && strings.TrimSpace(record[0]) == "") {
+			continue
+		}
+
+		suppression := &Suppression{}
+		if emailCol < len(record) {
+			suppression.Email = record[emailCol]
+		}
+		if reasonCol >= 0 && reasonCol < len(record) {
+			suppression.Reason = SuppressionReason(record[reasonCol])
+		}
+		suppressions = append(suppressions, suppression)
+	}
+
+	return suppressions, nil
+}
+
+// isSuppressionCSVHeader reports whether a CSV row names an email column
+func isSuppressionCSVHeader(record []string) bool {
+	for _, column := range record {
+		if strings.ToLower(strings.TrimSpace(column)) == "email" {
+			return true
+		}
+	}
+	return false
+}
+
+// SuppressionImportError describes an address rejected during an import
+type SuppressionImportError struct {
+	Email string `json:"email"`
+	Error string `json:"error"`
+}
+
+// ImportSuppressionsResponse reports the outcome of a suppression import
+type ImportSuppressionsResponse struct {
+	Imported int                      `json:"imported"`
+	Failed   []SuppressionImportError `json:"failed"`
+}
+
+// SuppressionRepository defines persistence methods
+type SuppressionRepository interface {
+	// BulkUpsert inserts the suppressions, updating the reason of addresses already suppressed
+	BulkUpsert(ctx context.Context, workspaceID string, suppressions []*Suppression) error
+
+	// IsSuppressed reports whether the address is suppressed in the workspace
+	IsSuppressed(ctx context.Context, workspaceID string, email string) (bool, error)
+}
+
+// SuppressionService defines business logic
+type SuppressionService interface {
+	ImportSuppressions(ctx context.Context, req *ImportSuppressionsRequest) (*ImportSuppressionsResponse, error)
+}
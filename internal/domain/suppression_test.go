@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuppression_NormalizeAndValidate(t *testing.T) {
+	t.Run("normalizes email and defaults reason", func(t *testing.T) {
+		s := &Suppression{Email: "  User@Example.COM "}
+		s.Normalize()
+		assert.Equal(t, "user@example.com", s.Email)
+		assert.Equal(t, SuppressionReasonManual, s.Reason)
+		assert.NoError(t, s.Validate())
+	})
+
+	t.Run("normalizes reason case", func(t *testing.T) {
+		s := &Suppression{Email: "user@example.com", Reason: " Bounce "}
+		s.Normalize()
+		assert.Equal(t, SuppressionReasonBounce, s.Reason)
+		assert.NoError(t, s.Validate())
+	})
+
+	t.Run("rejects invalid email", func(t *testing.T) {
+		s := &Suppression{Email: "not-an-email", Reason: SuppressionReasonBounce}
+		err := s.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid email format")
+	})
+
+	t.Run("rejects missing email", func(t *testing.T) {
+		s := &Suppression{Reason: SuppressionReasonBounce}
+		assert.EqualError(t, s.Validate(), "email is required")
+	})
+
+	t.Run("rejects unknown reason", func(t *testing.T) {
+		s := &Suppression{Email: "user@example.com", Reason: "spamtrap"}
+		err := s.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reason must be one of")
+	})
+}
+
+func TestParseSuppressionsCSV(t *testing.T) {
+	t.Run("without header", func(t *testing.T) {
+		suppressions, err := ParseSuppressionsCSV("a@example.com,bounce\nb@example.com\n\nc@example.com, complaint\n")
+		require.NoError(t, err)
+		require.Len(t, suppressions, 3)
+		assert.Equal(t, "a@example.com", suppressions[0].Email)
+		assert.Equal(t, SuppressionReasonBounce, suppressions[0].Reason)
+		assert.Equal(t, "b@example.com", suppressions[1].Email)
+		assert.Equal(t, SuppressionReason(""), suppressions[1].Reason)
+		assert.Equal(t, SuppressionReason("complaint"), suppressions[2].Reason)
+	})
+
+	t.Run("with header in any column order", func(t *testing.T) {
+		suppressions, err := ParseSuppressionsCSV("reason,created,Email\nunsubscribe,2024-01-01,a@example.com\n")
+		require.NoError(t, err)
+		require.Len(t, suppressions, 1)
+		assert.Equal(t, "a@example.com", suppressions[0].Email)
+		assert.Equal(t, SuppressionReasonUnsubscribe, suppressions[0].Reason)
+	})
+
+	t.Run("header without reason column", func(t *testing.T) {
+		suppressions, err := ParseSuppressionsCSV("email\na@example.com\nb@example.com")
+		require.NoError(t, err)
+		require.Len(t, suppressions, 2)
+		assert.Equal(t, SuppressionReason(""), suppressions[0].Reason)
+	})
+
+	t.Run("invalid csv", func(t *testing.T) {
+		_, err := ParseSuppressionsCSV("\"a@example.com,bounce\n")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid csv")
+	})
+}
+
+func TestImportSuppressionsRequest_Validate(t *testing.T) {
+	t.Run("valid array", func(t *testing.T) {
+		req := &ImportSuppressionsRequest{
+			WorkspaceID:  "ws1",
+			Suppressions: []*Suppression{{Email: "a@example.com"}},
+		}
+		assert.NoError(t, req.Validate())
+	})
+
+	t.Run("csv is parsed into suppressions", func(t *testing.T) {
+		req := &ImportSuppressionsRequest{
+			WorkspaceID: "ws1",
+			CSV:         "email,reason\na@example.com,bounce\nb@example.com,complaint",
+		}
+		require.NoError(t, req.Validate())
+		require.Len(t, req.Suppressions, 2)
+		assert.Equal(t, "b@example.com", req.Suppressions[1].Email)
+		assert.Empty(t, req.CSV)
+	})
+
+	t.Run("missing workspace_id", func(t *testing.T) {
+		req := &ImportSuppressionsRequest{Suppressions: []*Suppression{{Email: "a@example.com"}}}
+		assert.EqualError(t, req.Validate(), "workspace_id is required")
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		req := &ImportSuppressionsRequest{WorkspaceID: "ws1"}
+		assert.EqualError(t, req.Validate(), "suppressions array cannot be empty")
+	})
+
+	t.Run("both array and csv", func(t *testing.T) {
+		req := &ImportSuppressionsRequest{
+			WorkspaceID:  "ws1",
+			Suppressions: []*Suppression{{Email: "a@example.com"}},
+			CSV:          "b@example.com",
+		}
+		assert.EqualError(t, req.Validate(), "suppressions and csv cannot both be provided")
+	})
+
+	t.Run("null entry", func(t *testing.T) {
+		req := &ImportSuppressionsRequest{WorkspaceID: "ws1", Suppressions: []*Suppression{nil}}
+		assert.EqualError(t, req.Validate(), "suppression at index 0 is null")
+	})
+
+	t.Run("too many", func(t *testing.T) {
+		csv := strings.Repeat("a@example.com\n", MaxSuppressionImportSize+1)
+		req := &ImportSuppressionsRequest{WorkspaceID: "ws1", CSV: csv}
+		err := req.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot import more than")
+	})
+}
@@ -101,6 +101,12 @@ type SendBroadcastState struct {
 	TestPhaseCompleted        bool   `json:"test_phase_completed"`
 	TestPhaseRecipientCount   int    `json:"test_phase_recipient_count"`
 	WinnerPhaseRecipientCount int    `json:"winner_phase_recipient_count"`
+	// SeedsEnqueued is set once the workspace seed inboxes have been sent their copy
+	SeedsEnqueued bool `json:"seeds_enqueued,omitempty"`
+	// ListJoin marks a recurring list-join broadcast; ListJoinCursorAt and LastProcessedEmail
+	// track the last member sent to, ordered by join time
+	ListJoin         bool       `json:"list_join,omitempty"`
+	ListJoinCursorAt *time.Time `json:"list_join_cursor_at,omitempty"`
 }
 
 // BuildSegmentState contains state specific to segment building tasks
@@ -130,15 +130,15 @@ type Template struct {
 }
 
 // ResolveEmailContent returns the EmailTemplate for the given contact language.
-// Falls back to the default template content if no translation exists.
+// A regional locale falls back to its base language ("fr-CA" uses the "fr" translation),
+// then to the default template content if no translation exists.
 func (t *Template) ResolveEmailContent(contactLanguage string, workspaceDefaultLanguage string) *EmailTemplate {
-	if t.Email == nil || t.Translations == nil || contactLanguage == "" {
-		return t.Email
-	}
-	if contactLanguage == workspaceDefaultLanguage {
+	if t.Email == nil || t.Translations == nil {
 		return t.Email
 	}
-	if translation, ok := t.Translations[contactLanguage]; ok && translation.Email != nil {
+	if translation := t.resolveTranslation(contactLanguage, workspaceDefaultLanguage, func(tr TemplateTranslation) bool {
+		return tr.Email != nil
+	}); translation != nil {
 		return translation.Email
 	}
 	return t.Email
@@ -147,18 +147,32 @@ func (t *Template) ResolveEmailContent(contactLanguage string, workspaceDefaultL
 // ResolveWebContent returns the WebTemplate for the given contact language.
 // Falls back to the default template content if no translation exists.
 func (t *Template) ResolveWebContent(contactLanguage string, workspaceDefaultLanguage string) *WebTemplate {
-	if t.Web == nil || t.Translations == nil || contactLanguage == "" {
+	if t.Web == nil || t.Translations == nil {
 		return t.Web
 	}
-	if contactLanguage == workspaceDefaultLanguage {
-		return t.Web
-	}
-	if translation, ok := t.Translations[contactLanguage]; ok && translation.Web != nil {
+	if translation := t.resolveTranslation(contactLanguage, workspaceDefaultLanguage, func(tr TemplateTranslation) bool {
+		return tr.Web != nil
+	}); translation != nil {
 		return translation.Web
 	}
 	return t.Web
 }
 
+// resolveTranslation returns the most specific translation for the contact locale, or nil
+// when the default content applies: no usable translation, or the locale resolves to the
+// workspace default language first
+func (t *Template) resolveTranslation(contactLanguage string, workspaceDefaultLanguage string, usable func(TemplateTranslation) bool) *TemplateTranslation {
+	for _, lang := range LocaleCandidates(contactLanguage) {
+		if lang == workspaceDefaultLanguage {
+			return nil
+		}
+		if translation, ok := t.Translations[lang]; ok && usable(translation) {
+			return &translation
+		}
+	}
+	return nil
+}
+
 func (t *Template) Validate() error {
 	// First validate the template itself
 	if err := validateTemplateID(t.ID); err != nil {
@@ -838,6 +852,21 @@ func (r *TemplateDataRequest) Validate() error {
 	return nil
 }
 
+// RecipientTemplateVariables lists the template variables and link tags whose value
+// differs per recipient. A template referencing none of them renders the same body for
+// every recipient of a broadcast. Keep in sync with BuildTemplateData.
+var RecipientTemplateVariables = []string{
+	"contact",
+	"list",
+	"message_id",
+	"unsubscribe_url",
+	"oneclick_unsubscribe_url",
+	"notification_center_url",
+	"confirm_subscription_url",
+	"preferences_url",
+	"recipient_feed",
+}
+
 // BuildTemplateData creates a template data map with flexible options
 func BuildTemplateData(req TemplateDataRequest) (MapOfAny, error) {
 	if err := req.Validate(); err != nil {
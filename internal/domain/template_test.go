@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/url"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -1933,6 +1934,44 @@ func TestBuildTemplateData(t *testing.T) {
 		assert.Equal(t, "https://my-app.example.com", workspaceData["website_url"])
 	})
 
+	t.Run("unsubscribe_url and preferences_url tags render signed links", func(t *testing.T) {
+		secretKey := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+		data, err := BuildTemplateData(TemplateDataRequest{
+			WorkspaceID:        "ws-123",
+			WorkspaceSecretKey: secretKey,
+			ContactWithList: ContactWithList{
+				Contact:  &Contact{Email: "test@example.com"},
+				ListID:   "list-789",
+				ListName: "Newsletter",
+			},
+			MessageID:        "msg-456",
+			TrackingSettings: notifuse_mjml.TrackingSettings{Endpoint: "https://api.example.com"},
+		})
+		assert.NoError(t, err)
+
+		rendered, err := notifuse_mjml.ProcessLiquidTemplate("{% unsubscribe_url %}|{% preferences_url %}", data, "test")
+		assert.NoError(t, err)
+
+		parts := strings.Split(rendered, "|")
+		assert.Len(t, parts, 2)
+
+		unsubscribeURL, err := url.Parse(parts[0])
+		assert.NoError(t, err)
+		assert.Equal(t, "/notification-center", unsubscribeURL.Path)
+		query := unsubscribeURL.Query()
+		assert.Equal(t, "unsubscribe", query.Get("action"))
+		assert.Equal(t, "list-789", query.Get("lid"))
+		assert.Equal(t, "ws-123", query.Get("wid"))
+		assert.Equal(t, "msg-456", query.Get("mid"))
+		assert.True(t, VerifyEmailHMAC(query.Get("email"), query.Get("email_hmac"), secretKey))
+
+		preferencesURL, err := url.Parse(parts[1])
+		assert.NoError(t, err)
+		assert.Equal(t, "/notification-center", preferencesURL.Path)
+		assert.Empty(t, preferencesURL.Query().Get("lid"), "preference center link is not list-scoped")
+		assert.True(t, VerifyEmailHMAC(preferencesURL.Query().Get("email"), preferencesURL.Query().Get("email_hmac"), secretKey))
+	})
+
 	// We'll skip other test cases since they would require mocking
 }
 
@@ -2397,6 +2436,9 @@ func TestTemplate_ResolveEmailContent(t *testing.T) {
 		{"contact language has translation", "fr", "en", "Sujet Français"},
 		{"contact language has translation (es)", "es", "en", "Asunto Español"},
 		{"contact language has no translation falls back", "de", "en", "Default Subject"},
+		{"regional locale falls back to base language", "fr-CA", "en", "Sujet Français"},
+		{"locale is normalized", "ES_mx", "en", "Asunto Español"},
+		{"regional locale of the default language returns default", "en-GB", "en", "Default Subject"},
 	}
 
 	for _, tc := range tests {
@@ -2412,6 +2454,18 @@ func TestTemplate_ResolveEmailContent(t *testing.T) {
 		assert.Nil(t, result)
 	})
 
+	t.Run("exact regional translation wins over base language", func(t *testing.T) {
+		tmpl := &Template{
+			Email: defaultEmail,
+			Translations: map[string]TemplateTranslation{
+				"pt":    {Email: &EmailTemplate{Subject: "Assunto"}},
+				"pt-BR": {Email: &EmailTemplate{Subject: "Assunto (Brasil)"}},
+			},
+		}
+		assert.Equal(t, "Assunto (Brasil)", tmpl.ResolveEmailContent("pt-BR", "en").Subject)
+		assert.Equal(t, "Assunto", tmpl.ResolveEmailContent("pt-PT", "en").Subject)
+	})
+
 	t.Run("nil translations returns default", func(t *testing.T) {
 		tmpl := &Template{Email: defaultEmail, Translations: nil}
 		result := tmpl.ResolveEmailContent("fr", "en")
@@ -118,6 +118,16 @@ type TransactionalNotificationSendParams struct {
 	EmailOptions EmailOptions           `json:"email_options,omitempty"`     // Email options for the notification
 }
 
+// TransactionalTemplateSendParams contains the parameters for sending a one-off transactional
+// email straight from a template, without a configured transactional notification
+type TransactionalTemplateSendParams struct {
+	To            string       `json:"to"`
+	TemplateID    string       `json:"template_id"`
+	Data          MapOfAny     `json:"data,omitempty"`
+	IntegrationID string       `json:"integration_id,omitempty"` // Defaults to the workspace transactional provider
+	EmailOptions  EmailOptions `json:"email_options,omitempty"`
+}
+
 // TestTemplateRequest represents a request to test a template
 type TestTemplateRequest struct {
 	WorkspaceID    string       `json:"workspace_id"`
@@ -200,6 +210,9 @@ type TransactionalNotificationService interface {
 	// SendNotification sends a transactional notification to a contact
 	SendNotification(ctx context.Context, workspaceID string, params TransactionalNotificationSendParams) (string, error)
 
+	// SendTemplate renders a template for a single recipient and enqueues it as a transactional email
+	SendTemplate(ctx context.Context, workspaceID string, params TransactionalTemplateSendParams) (string, error)
+
 	TestTemplate(ctx context.Context, workspaceID string, templateID string, integrationID string, senderID string, recipientEmail string, language string, options EmailOptions) error
 }
 
@@ -407,6 +420,57 @@ func (req *SendTransactionalRequest) Validate() error {
 	return nil
 }
 
+// SendTransactionalTemplateRequest represents a request to send a transactional email from a template
+type SendTransactionalTemplateRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	TransactionalTemplateSendParams
+}
+
+// Validate validates the send template request
+func (req *SendTransactionalTemplateRequest) Validate() error {
+	if req.WorkspaceID == "" {
+		return NewValidationError("workspace_id is required")
+	}
+
+	if req.To == "" {
+		return NewValidationError("to is required")
+	}
+
+	if !govalidator.IsEmail(req.To) {
+		return NewValidationError(fmt.Sprintf("to '%s' must be a valid email address", req.To))
+	}
+
+	if req.TemplateID == "" {
+		return NewValidationError("template_id is required")
+	}
+
+	for _, cc := range req.EmailOptions.CC {
+		if !govalidator.IsEmail(cc) {
+			return NewValidationError(fmt.Sprintf("cc '%s' must be a valid email address", cc))
+		}
+	}
+
+	for _, bcc := range req.EmailOptions.BCC {
+		if !govalidator.IsEmail(bcc) {
+			return NewValidationError(fmt.Sprintf("bcc '%s' must be a valid email address", bcc))
+		}
+	}
+
+	if req.EmailOptions.ReplyTo != "" && !govalidator.IsEmail(req.EmailOptions.ReplyTo) {
+		return NewValidationError(fmt.Sprintf("replyTo '%s' must be a valid email address", req.EmailOptions.ReplyTo))
+	}
+
+	if req.EmailOptions.Subject != nil && len(*req.EmailOptions.Subject) > 255 {
+		return NewValidationError("subject length must not exceed 255 characters")
+	}
+
+	if len(req.EmailOptions.Attachments) > 0 {
+		return NewValidationError("attachments are not supported when sending from a template")
+	}
+
+	return nil
+}
+
 // Helper function to get the first value from a map of string slices
 func getFirstValue(values map[string][]string, key string) string {
 	if vals, ok := values[key]; ok && len(vals) > 0 {
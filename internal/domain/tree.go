@@ -3,6 +3,7 @@ package domain
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // TreeNode represents a node in the segment tree structure
@@ -78,6 +79,36 @@ type DimensionFilter struct {
 	JSONPath []string `json:"json_path,omitempty"`
 }
 
+// ComputedFieldDaysSince is the computed field function returning the number of
+// whole days elapsed since a datetime contact field, e.g. "days_since(created_at)"
+const ComputedFieldDaysSince = "days_since"
+
+// ContactFieldEngagementScore is the contact engagement score maintained by the database
+// from opens (+1), clicks (+3) and custom events (+2). Points decay with a half-life of
+// EngagementScoreHalfLifeDays, so the score is usable as a number field in conditions.
+const ContactFieldEngagementScore = "engagement_score"
+
+// EngagementScoreHalfLifeDays is the number of days after which engagement points count for half
+const EngagementScoreHalfLifeDays = 30
+
+// ParseComputedField splits a computed field name of the form "fn(field)" into
+// its function and argument. ok is false for plain field names.
+func ParseComputedField(fieldName string) (fn string, arg string, ok bool) {
+	open := strings.Index(fieldName, "(")
+	if open <= 0 || !strings.HasSuffix(fieldName, ")") {
+		return "", "", false
+	}
+	fn = strings.TrimSpace(fieldName[:open])
+	arg = strings.TrimSpace(fieldName[open+1 : len(fieldName)-1])
+	return fn, arg, true
+}
+
+// IsComputed returns true if the filter targets a computed field like "days_since(created_at)"
+func (f *DimensionFilter) IsComputed() bool {
+	_, _, ok := ParseComputedField(f.FieldName)
+	return ok
+}
+
 // Validate validates the tree structure
 func (t *TreeNode) Validate() error {
 	if t.Kind == "" {
@@ -321,6 +352,19 @@ func (f *DimensionFilter) Validate() error {
 		return fmt.Errorf("filter must have 'operator'")
 	}
 
+	// Computed fields evaluate to a number derived from another contact field
+	if fn, arg, ok := ParseComputedField(f.FieldName); ok {
+		if fn != ComputedFieldDaysSince {
+			return fmt.Errorf("unsupported computed field function: %s", fn)
+		}
+		if arg == "" {
+			return fmt.Errorf("computed field %s must reference a field", f.FieldName)
+		}
+		if f.FieldType != "number" {
+			return fmt.Errorf("computed field %s must have field_type 'number'", f.FieldName)
+		}
+	}
+
 	// Validate JSONPath usage
 	if len(f.JSONPath) > 0 {
 		// JSONPath can only be used with custom_json fields
@@ -441,6 +485,50 @@ func TreeNodeFromJSON(jsonStr string) (*TreeNode, error) {
 	return &node, nil
 }
 
+// ContactFieldNames returns the distinct contact fields referenced by the tree's
+// contact filters, in order of appearance. Computed fields report their source field.
+func (t *TreeNode) ContactFieldNames() []string {
+	var names []string
+	seen := map[string]bool{}
+	t.collectContactFieldNames(&names, seen)
+	return names
+}
+
+func (t *TreeNode) collectContactFieldNames(names *[]string, seen map[string]bool) {
+	if t == nil {
+		return
+	}
+
+	switch t.Kind {
+	case "branch":
+		if t.Branch == nil {
+			return
+		}
+		for _, leaf := range t.Branch.Leaves {
+			leaf.collectContactFieldNames(names, seen)
+		}
+
+	case "leaf":
+		if t.Leaf == nil || t.Leaf.Contact == nil {
+			return
+		}
+		for _, filter := range t.Leaf.Contact.Filters {
+			if filter == nil {
+				continue
+			}
+			name := filter.FieldName
+			if _, arg, ok := ParseComputedField(name); ok {
+				name = arg
+			}
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			*names = append(*names, name)
+		}
+	}
+}
+
 // HasRelativeDates checks if the tree contains any relative date filters
 // that require daily recomputation (e.g., "in_the_last_days")
 func (t *TreeNode) HasRelativeDates() bool {
@@ -475,7 +563,10 @@ func (t *TreeNode) HasRelativeDates() bool {
 		// Check contact property filters for relative date operators
 		if t.Leaf.Contact != nil && t.Leaf.Contact.Filters != nil {
 			for _, filter := range t.Leaf.Contact.Filters {
-				if filter.Operator == "in_the_last_days" {
+				// Computed day counts and the decaying engagement score change every day,
+				// just like relative date operators
+				if filter.Operator == "in_the_last_days" || filter.IsComputed() ||
+					filter.FieldName == ContactFieldEngagementScore {
 					return true
 				}
 			}
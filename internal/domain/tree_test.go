@@ -217,6 +217,57 @@ func TestTreeNodeLeaf_Validate(t *testing.T) {
 }
 
 func TestDimensionFilter_Validate(t *testing.T) {
+	t.Run("valid computed days_since filter", func(t *testing.T) {
+		filter := &DimensionFilter{
+			FieldName:    "days_since(created_at)",
+			FieldType:    "number",
+			Operator:     "gt",
+			NumberValues: []float64{30},
+		}
+
+		err := filter.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("computed filter with unknown function", func(t *testing.T) {
+		filter := &DimensionFilter{
+			FieldName:    "age_years(created_at)",
+			FieldType:    "number",
+			Operator:     "gt",
+			NumberValues: []float64{1},
+		}
+
+		err := filter.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported computed field function")
+	})
+
+	t.Run("computed filter without referenced field", func(t *testing.T) {
+		filter := &DimensionFilter{
+			FieldName:    "days_since()",
+			FieldType:    "number",
+			Operator:     "gt",
+			NumberValues: []float64{1},
+		}
+
+		err := filter.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "must reference a field")
+	})
+
+	t.Run("computed filter with non-number field_type", func(t *testing.T) {
+		filter := &DimensionFilter{
+			FieldName:    "days_since(created_at)",
+			FieldType:    "time",
+			Operator:     "gt",
+			StringValues: []string{"30"},
+		}
+
+		err := filter.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "must have field_type 'number'")
+	})
+
 	t.Run("valid string filter", func(t *testing.T) {
 		filter := &DimensionFilter{
 			FieldName:    "country",
@@ -643,6 +694,48 @@ func TestTreeNodeFromJSON(t *testing.T) {
 }
 
 func TestTreeNode_HasRelativeDates(t *testing.T) {
+	t.Run("returns true for computed days_since filter", func(t *testing.T) {
+		node := &TreeNode{
+			Kind: "leaf",
+			Leaf: &TreeNodeLeaf{
+				Source: "contacts",
+				Contact: &ContactCondition{
+					Filters: []*DimensionFilter{
+						{
+							FieldName:    "days_since(created_at)",
+							FieldType:    "number",
+							Operator:     "gt",
+							NumberValues: []float64{30},
+						},
+					},
+				},
+			},
+		}
+
+		assert.True(t, node.HasRelativeDates())
+	})
+
+	t.Run("returns true for engagement_score filter", func(t *testing.T) {
+		node := &TreeNode{
+			Kind: "leaf",
+			Leaf: &TreeNodeLeaf{
+				Source: "contacts",
+				Contact: &ContactCondition{
+					Filters: []*DimensionFilter{
+						{
+							FieldName:    ContactFieldEngagementScore,
+							FieldType:    "number",
+							Operator:     "gte",
+							NumberValues: []float64{5},
+						},
+					},
+				},
+			},
+		}
+
+		assert.True(t, node.HasRelativeDates())
+	})
+
 	t.Run("returns true for in_the_last_days operator", func(t *testing.T) {
 		inTheLastDays := "in_the_last_days"
 		node := &TreeNode{
@@ -1081,3 +1174,67 @@ func TestContactTimelineCondition_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestParseComputedField(t *testing.T) {
+	tests := []struct {
+		input      string
+		expectedFn string
+		expectedAr string
+		expectedOk bool
+	}{
+		{"days_since(created_at)", "days_since", "created_at", true},
+		{"days_since( custom_datetime_1 )", "days_since", "custom_datetime_1", true},
+		{"created_at", "", "", false},
+		{"(created_at)", "", "", false},
+		{"days_since(created_at", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			fn, arg, ok := ParseComputedField(tt.input)
+			assert.Equal(t, tt.expectedFn, fn)
+			assert.Equal(t, tt.expectedAr, arg)
+			assert.Equal(t, tt.expectedOk, ok)
+		})
+	}
+}
+
+func TestTreeNode_ContactFieldNames(t *testing.T) {
+	contactLeaf := func(fields ...string) *TreeNode {
+		filters := make([]*DimensionFilter, 0, len(fields))
+		for _, field := range fields {
+			filters = append(filters, &DimensionFilter{FieldName: field, FieldType: "string", Operator: "equals"})
+		}
+		return &TreeNode{
+			Kind: "leaf",
+			Leaf: &TreeNodeLeaf{Source: "contacts", Contact: &ContactCondition{Filters: filters}},
+		}
+	}
+
+	t.Run("nil tree", func(t *testing.T) {
+		var node *TreeNode
+		assert.Empty(t, node.ContactFieldNames())
+	})
+
+	t.Run("collects distinct fields across branches", func(t *testing.T) {
+		node := &TreeNode{
+			Kind: "branch",
+			Branch: &TreeNodeBranch{
+				Operator: "or",
+				Leaves: []*TreeNode{
+					contactLeaf("country", "language"),
+					contactLeaf("country", "days_since(created_at)"),
+					{
+						Kind: "leaf",
+						Leaf: &TreeNodeLeaf{
+							Source:      "contact_lists",
+							ContactList: &ContactListCondition{Operator: "in", ListID: "list1"},
+						},
+					},
+				},
+			},
+		}
+
+		assert.Equal(t, []string{"country", "language", "created_at"}, node.ContactFieldNames())
+	})
+}
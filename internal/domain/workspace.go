@@ -346,11 +346,36 @@ type WorkspaceSettings struct {
 	BlogSettings                 *BlogSettings       `json:"blog_settings,omitempty"` // Blog styling and SEO settings
 	DefaultLanguage              string              `json:"default_language"`
 	Languages                    []string            `json:"languages"`
+	ListUnsubscribeMailto        string              `json:"list_unsubscribe_mailto,omitempty"` // Inbox advertised as mailto in List-Unsubscribe headers
+	BroadcastSeedList            []string            `json:"broadcast_seed_list,omitempty"`     // Seed inboxes receiving a copy of every broadcast, excluded from its stats
+	DefaultFeedHeaders           []DataFeedHeader    `json:"default_feed_headers,omitempty"`    // Headers sent with every data feed request, per-feed headers win on conflict
+	DisableBulkHeaders           bool                `json:"disable_bulk_headers,omitempty"`    // Don't add Precedence: bulk and Auto-Submitted headers to marketing emails
 
 	// decoded secret key, not stored in the database
 	SecretKey string `json:"-"`
 }
 
+// BulkHeadersEnabled returns true if marketing emails carry the Precedence: bulk and Auto-Submitted headers
+func (ws *WorkspaceSettings) BulkHeadersEnabled() bool {
+	return !ws.DisableBulkHeaders
+}
+
+// MaxBroadcastSeedAddresses is the maximum number of seed inboxes a workspace can configure
+const MaxBroadcastSeedAddresses = 50
+
+// BroadcastSeedRecipients returns the seed list as broadcast recipients flagged as seeds.
+// Seeds have no list, so their copies carry no unsubscribe links.
+func (ws *WorkspaceSettings) BroadcastSeedRecipients() []*ContactWithList {
+	recipients := make([]*ContactWithList, 0, len(ws.BroadcastSeedList))
+	for _, seed := range ws.BroadcastSeedList {
+		recipients = append(recipients, &ContactWithList{
+			Contact: &Contact{Email: seed},
+			IsSeed:  true,
+		})
+	}
+	return recipients
+}
+
 // Validate validates workspace settings
 func (ws *WorkspaceSettings) Validate(passphrase string) error {
 	if ws.Timezone == "" {
@@ -385,6 +410,25 @@ func (ws *WorkspaceSettings) Validate(passphrase string) error {
 		}
 	}
 
+	if ws.ListUnsubscribeMailto != "" && !govalidator.IsEmail(ws.ListUnsubscribeMailto) {
+		return fmt.Errorf("invalid list unsubscribe mailto address: %s", ws.ListUnsubscribeMailto)
+	}
+
+	if len(ws.BroadcastSeedList) > MaxBroadcastSeedAddresses {
+		return fmt.Errorf("broadcast seed list cannot have more than %d addresses", MaxBroadcastSeedAddresses)
+	}
+	for _, seed := range ws.BroadcastSeedList {
+		if !govalidator.IsEmail(seed) {
+			return fmt.Errorf("invalid broadcast seed address: %s", seed)
+		}
+	}
+
+	for i := range ws.DefaultFeedHeaders {
+		if err := ws.DefaultFeedHeaders[i].Validate(); err != nil {
+			return fmt.Errorf("invalid default feed header at index %d: %w", i, err)
+		}
+	}
+
 	// FileManager is completely optional, but if any fields are set, validate them
 	if err := ws.FileManager.Validate(passphrase); err != nil {
 		return fmt.Errorf("invalid file manager settings: %w", err)
@@ -548,6 +592,11 @@ func (w *Workspace) Validate(passphrase string) error {
 		if err := integration.Validate(passphrase); err != nil {
 			return fmt.Errorf("invalid integration (%s): %w", integration.ID, err)
 		}
+		if integration.Type == IntegrationTypeEmail {
+			if err := w.ValidateFailoverIntegration(integration.ID, &integration.EmailProvider); err != nil {
+				return fmt.Errorf("invalid integration (%s): %w", integration.ID, err)
+			}
+		}
 	}
 
 	return nil
@@ -619,6 +668,36 @@ func (w *Workspace) GetIntegrationByID(id string) *Integration {
 	return nil
 }
 
+// ValidateFailoverIntegration checks that the provider's failover references another
+// email integration of the workspace
+func (w *Workspace) ValidateFailoverIntegration(integrationID string, provider *EmailProvider) error {
+	if provider == nil || provider.FailoverIntegrationID == "" {
+		return nil
+	}
+	if provider.FailoverIntegrationID == integrationID {
+		return fmt.Errorf("failover integration cannot be the integration itself")
+	}
+	failover := w.GetIntegrationByID(provider.FailoverIntegrationID)
+	if failover == nil || failover.Type != IntegrationTypeEmail {
+		return fmt.Errorf("failover integration %s is not an email integration of this workspace", provider.FailoverIntegrationID)
+	}
+	return nil
+}
+
+// GetFailoverIntegration returns the email integration configured as the given integration's
+// failover, or nil when none is set or it no longer exists
+func (w *Workspace) GetFailoverIntegration(integration *Integration) *Integration {
+	if integration == nil || integration.EmailProvider.FailoverIntegrationID == "" ||
+		integration.EmailProvider.FailoverIntegrationID == integration.ID {
+		return nil
+	}
+	failover := w.GetIntegrationByID(integration.EmailProvider.FailoverIntegrationID)
+	if failover == nil || failover.Type != IntegrationTypeEmail {
+		return nil
+	}
+	return failover
+}
+
 // GetIntegrationsByType returns all integrations of a specific type
 func (w *Workspace) GetIntegrationsByType(integrationType IntegrationType) []*Integration {
 	var results []*Integration
@@ -1315,6 +1394,77 @@ type TestEmailProviderRequest struct {
 	WorkspaceID string        `json:"workspace_id"`
 }
 
+// TestIntegrationRequest is the request for checking a saved email integration can connect
+type TestIntegrationRequest struct {
+	WorkspaceID   string `json:"workspace_id"`
+	IntegrationID string `json:"integration_id"`
+}
+
+// Validate validates the test integration request
+func (r *TestIntegrationRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.IntegrationID == "" {
+		return fmt.Errorf("integration_id is required")
+	}
+	return nil
+}
+
+// CheckDeliverabilityRequest is the request for checking the DNS authentication of an
+// email integration's sender domain
+type CheckDeliverabilityRequest struct {
+	WorkspaceID   string `json:"workspace_id"`
+	IntegrationID string `json:"integration_id"`
+	SenderID      string `json:"sender_id,omitempty"`   // Defaults to the integration's default sender
+	DKIMSelector  string `json:"dkim_selector"`         // e.g. "s1" for s1._domainkey.example.com
+	SPFInclude    string `json:"spf_include,omitempty"` // Expected SPF include, defaults to the provider's
+}
+
+// Validate validates the check deliverability request
+func (r *CheckDeliverabilityRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.IntegrationID == "" {
+		return fmt.Errorf("integration_id is required")
+	}
+	if r.DKIMSelector == "" {
+		return fmt.Errorf("dkim_selector is required")
+	}
+	if strings.ContainsAny(r.DKIMSelector, " /@") {
+		return fmt.Errorf("invalid dkim_selector: %s", r.DKIMSelector)
+	}
+	return nil
+}
+
+// DeliverabilityCheckStatus is the outcome of one DNS authentication check
+type DeliverabilityCheckStatus string
+
+const (
+	DeliverabilityCheckPass    DeliverabilityCheckStatus = "pass"
+	DeliverabilityCheckWarning DeliverabilityCheckStatus = "warning" // Valid but weak, e.g. DMARC p=none
+	DeliverabilityCheckFail    DeliverabilityCheckStatus = "fail"
+)
+
+// DeliverabilityCheck is the result of one DNS authentication check
+type DeliverabilityCheck struct {
+	Status  DeliverabilityCheckStatus `json:"status"`
+	Host    string                    `json:"host"`             // DNS name that was queried
+	Record  string                    `json:"record,omitempty"` // Matching TXT record, if any
+	Message string                    `json:"message"`
+}
+
+// DeliverabilityReport lists the SPF, DKIM and DMARC checks for a sender domain
+type DeliverabilityReport struct {
+	Sender string              `json:"sender"`
+	Domain string              `json:"domain"`
+	SPF    DeliverabilityCheck `json:"spf"`
+	DKIM   DeliverabilityCheck `json:"dkim"`
+	DMARC  DeliverabilityCheck `json:"dmarc"`
+	Passed bool                `json:"passed"` // True when no check failed
+}
+
 // TestEmailProviderResponse is the response for testing an email provider
 // It can be extended to include more details if needed
 type TestEmailProviderResponse struct {
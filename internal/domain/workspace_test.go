@@ -3,6 +3,7 @@ package domain
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -1918,6 +1919,66 @@ func TestWorkspace_GetIntegrationsByType(t *testing.T) {
 	}
 }
 
+func TestWorkspace_FailoverIntegration(t *testing.T) {
+	smtpIntegration := func(id, failoverID string) Integration {
+		return Integration{
+			ID:   id,
+			Type: IntegrationTypeEmail,
+			EmailProvider: EmailProvider{
+				Kind:                  EmailProviderKindSMTP,
+				FailoverIntegrationID: failoverID,
+			},
+		}
+	}
+	workspace := &Workspace{
+		Integrations: []Integration{
+			smtpIntegration("primary", "backup"),
+			smtpIntegration("backup", ""),
+			smtpIntegration("orphan", "deleted"),
+			{ID: "supabase", Type: IntegrationTypeSupabase},
+		},
+	}
+
+	t.Run("GetFailoverIntegration", func(t *testing.T) {
+		failover := workspace.GetFailoverIntegration(workspace.GetIntegrationByID("primary"))
+		require.NotNil(t, failover)
+		assert.Equal(t, "backup", failover.ID)
+
+		assert.Nil(t, workspace.GetFailoverIntegration(workspace.GetIntegrationByID("backup")))
+		assert.Nil(t, workspace.GetFailoverIntegration(workspace.GetIntegrationByID("orphan")))
+		assert.Nil(t, workspace.GetFailoverIntegration(nil))
+	})
+
+	t.Run("ValidateFailoverIntegration", func(t *testing.T) {
+		assert.NoError(t, workspace.ValidateFailoverIntegration("primary", &EmailProvider{FailoverIntegrationID: "backup"}))
+		assert.NoError(t, workspace.ValidateFailoverIntegration("primary", &EmailProvider{}))
+
+		err := workspace.ValidateFailoverIntegration("primary", &EmailProvider{FailoverIntegrationID: "primary"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be the integration itself")
+
+		err = workspace.ValidateFailoverIntegration("primary", &EmailProvider{FailoverIntegrationID: "deleted"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not an email integration of this workspace")
+
+		err = workspace.ValidateFailoverIntegration("primary", &EmailProvider{FailoverIntegrationID: "supabase"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not an email integration of this workspace")
+	})
+
+	t.Run("only SMTP providers can fail over", func(t *testing.T) {
+		provider := EmailProvider{
+			Kind:                  EmailProviderKindSES,
+			Senders:               []EmailSender{{Email: "sender@example.com", Name: "Sender"}},
+			RateLimitPerMinute:    60,
+			FailoverIntegrationID: "backup",
+		}
+		err := provider.Validate("passphrase")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failover integration is only supported for SMTP providers")
+	})
+}
+
 func TestWorkspace_AddIntegration(t *testing.T) {
 	now := time.Now()
 
@@ -5036,3 +5097,97 @@ func TestErrWorkspaceLimitReached_Error(t *testing.T) {
 	}
 	assert.Equal(t, "workspace limit reached: 3 workspaces exist (limit: 3)", err.Error())
 }
+
+func TestWorkspaceSettings_ValidateListUnsubscribeMailto(t *testing.T) {
+	newSettings := func(mailto string) *WorkspaceSettings {
+		return &WorkspaceSettings{
+			Timezone:              "UTC",
+			DefaultLanguage:       "en",
+			Languages:             []string{"en"},
+			ListUnsubscribeMailto: mailto,
+		}
+	}
+
+	assert.NoError(t, newSettings("").Validate(""))
+	assert.NoError(t, newSettings("unsubscribe@example.com").Validate(""))
+
+	err := newSettings("not-an-email").Validate("")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid list unsubscribe mailto address: not-an-email")
+}
+
+func TestWorkspaceSettings_ValidateBroadcastSeedList(t *testing.T) {
+	newSettings := func(seeds []string) *WorkspaceSettings {
+		return &WorkspaceSettings{
+			Timezone:          "UTC",
+			DefaultLanguage:   "en",
+			Languages:         []string{"en"},
+			BroadcastSeedList: seeds,
+		}
+	}
+
+	assert.NoError(t, newSettings(nil).Validate(""))
+	assert.NoError(t, newSettings([]string{"seed1@example.com", "seed2@example.com"}).Validate(""))
+
+	err := newSettings([]string{"seed1@example.com", "not-an-email"}).Validate("")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid broadcast seed address: not-an-email")
+
+	tooMany := make([]string, MaxBroadcastSeedAddresses+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("seed%d@example.com", i)
+	}
+	err = newSettings(tooMany).Validate("")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "broadcast seed list cannot have more than")
+}
+
+func TestWorkspaceSettings_ValidateDefaultFeedHeaders(t *testing.T) {
+	settings := &WorkspaceSettings{
+		Timezone:           "UTC",
+		DefaultLanguage:    "en",
+		Languages:          []string{"en"},
+		DefaultFeedHeaders: []DataFeedHeader{{Name: "X-Api-Key", Value: "secret"}},
+	}
+	assert.NoError(t, settings.Validate(""))
+
+	settings.DefaultFeedHeaders = append(settings.DefaultFeedHeaders, DataFeedHeader{Name: "X-Empty"})
+	err := settings.Validate("")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid default feed header at index 1: header value is required")
+}
+
+func TestWorkspaceSettings_BroadcastSeedRecipients(t *testing.T) {
+	settings := &WorkspaceSettings{BroadcastSeedList: []string{"seed1@example.com", "seed2@example.com"}}
+
+	recipients := settings.BroadcastSeedRecipients()
+	require.Len(t, recipients, 2)
+	for i, recipient := range recipients {
+		assert.True(t, recipient.IsSeed)
+		assert.Equal(t, settings.BroadcastSeedList[i], recipient.Contact.Email)
+		assert.Empty(t, recipient.ListID, "Seeds have no list, so no unsubscribe links")
+	}
+
+	assert.Empty(t, (&WorkspaceSettings{}).BroadcastSeedRecipients())
+}
+
+func TestCheckDeliverabilityRequest_Validate(t *testing.T) {
+	valid := CheckDeliverabilityRequest{WorkspaceID: "ws1", IntegrationID: "int1", DKIMSelector: "s1"}
+	assert.NoError(t, valid.Validate())
+
+	missingWorkspace := valid
+	missingWorkspace.WorkspaceID = ""
+	assert.EqualError(t, missingWorkspace.Validate(), "workspace_id is required")
+
+	missingIntegration := valid
+	missingIntegration.IntegrationID = ""
+	assert.EqualError(t, missingIntegration.Validate(), "integration_id is required")
+
+	missingSelector := valid
+	missingSelector.DKIMSelector = ""
+	assert.EqualError(t, missingSelector.Validate(), "dkim_selector is required")
+
+	invalidSelector := valid
+	invalidSelector.DKIMSelector = "s1/evil"
+	assert.EqualError(t, invalidSelector.Validate(), "invalid dkim_selector: s1/evil")
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/Notifuse/notifuse/internal/domain"
@@ -37,12 +38,25 @@ func (h *AutomationHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/api/automations.update", requireAuth(http.HandlerFunc(h.handleUpdate)))
 	mux.Handle("/api/automations.delete", requireAuth(http.HandlerFunc(h.handleDelete)))
 
+	// Tag management
+	mux.Handle("/api/automations.tags", requireAuth(http.HandlerFunc(h.handleListTags)))
+	mux.Handle("/api/automations.setTags", requireAuth(http.HandlerFunc(h.handleSetTags)))
+
 	// Automation status management
 	mux.Handle("/api/automations.activate", requireAuth(http.HandlerFunc(h.handleActivate)))
 	mux.Handle("/api/automations.pause", requireAuth(http.HandlerFunc(h.handlePause)))
 
+	// Bulk enrollment
+	mux.Handle("/api/automations.enroll", requireAuth(http.HandlerFunc(h.handleEnroll)))
+	mux.Handle("/api/automations.reenroll", requireAuth(http.HandlerFunc(h.handleReenroll)))
+
 	// Node executions/debugging
 	mux.Handle("/api/automations.nodeExecutions", requireAuth(http.HandlerFunc(h.handleGetContactNodeExecutions)))
+	mux.Handle("/api/automations.nodeStats", requireAuth(http.HandlerFunc(h.handleGetNodeStats)))
+	mux.Handle("/api/automations.contacts.next", requireAuth(http.HandlerFunc(h.handleGetContactNextTick)))
+
+	// Authoring tests
+	mux.Handle("/api/automations.nodes.testWebhook", requireAuth(http.HandlerFunc(h.handleTestWebhookNode)))
 }
 
 func (h *AutomationHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
@@ -69,13 +83,23 @@ func (h *AutomationHandler) handleCreate(w http.ResponseWriter, r *http.Request)
 			WriteJSONError(w, err.Error(), http.StatusForbidden)
 			return
 		}
+		if writeNodeConfigError(w, err) {
+			return
+		}
 		WriteJSONError(w, "Failed to create automation", http.StatusInternalServerError)
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, map[string]interface{}{
+	response := map[string]interface{}{
 		"automation": req.Automation,
-	})
+	}
+	if req.ValidateWebhooks {
+		if warnings := h.service.CheckWebhookURLs(r.Context(), req.Automation); len(warnings) > 0 {
+			response["warnings"] = warnings
+		}
+	}
+
+	writeJSON(w, http.StatusCreated, response)
 }
 
 func (h *AutomationHandler) handleGet(w http.ResponseWriter, r *http.Request) {
@@ -159,13 +183,23 @@ func (h *AutomationHandler) handleUpdate(w http.ResponseWriter, r *http.Request)
 			WriteJSONError(w, err.Error(), http.StatusForbidden)
 			return
 		}
+		if writeNodeConfigError(w, err) {
+			return
+		}
 		WriteJSONError(w, "Failed to update automation", http.StatusInternalServerError)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]interface{}{
+	response := map[string]interface{}{
 		"automation": req.Automation,
-	})
+	}
+	if req.ValidateWebhooks {
+		if warnings := h.service.CheckWebhookURLs(r.Context(), req.Automation); len(warnings) > 0 {
+			response["warnings"] = warnings
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
 }
 
 func (h *AutomationHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
@@ -201,6 +235,67 @@ func (h *AutomationHandler) handleDelete(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+func (h *AutomationHandler) handleListTags(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.ListAutomationTagsRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tags, err := h.service.ListTags(r.Context(), req.WorkspaceID)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to list automation tags")
+		if _, ok := err.(*domain.PermissionError); ok {
+			WriteJSONError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		WriteJSONError(w, "Failed to list automation tags", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"tags": tags,
+	})
+}
+
+func (h *AutomationHandler) handleSetTags(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.SetAutomationTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to decode request body")
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SetTags(r.Context(), req.WorkspaceID, req.AutomationID, req.Tags); err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to set automation tags")
+		if _, ok := err.(*domain.PermissionError); ok {
+			WriteJSONError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		WriteJSONError(w, "Failed to set automation tags", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+	})
+}
+
 func (h *AutomationHandler) handleActivate(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -219,12 +314,33 @@ func (h *AutomationHandler) handleActivate(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// Optionally probe the email integrations so a misconfigured provider fails before going live
+	if req.CheckIntegration {
+		if err := h.service.CheckIntegrations(r.Context(), req.WorkspaceID, req.AutomationID); err != nil {
+			h.logger.WithField("error", err.Error()).Error("Automation integration check failed")
+			if _, ok := err.(*domain.PermissionError); ok {
+				WriteJSONError(w, err.Error(), http.StatusForbidden)
+				return
+			}
+			if _, ok := err.(domain.ValidationError); ok {
+				WriteJSONError(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			WriteJSONError(w, "Failed to check automation integrations", http.StatusInternalServerError)
+			return
+		}
+	}
+
 	if err := h.service.Activate(r.Context(), req.WorkspaceID, req.AutomationID); err != nil {
 		h.logger.WithField("error", err.Error()).Error("Failed to activate automation")
 		if _, ok := err.(*domain.PermissionError); ok {
 			WriteJSONError(w, err.Error(), http.StatusForbidden)
 			return
 		}
+		if _, ok := err.(domain.ValidationError); ok {
+			WriteJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		WriteJSONError(w, "Failed to activate automation", http.StatusInternalServerError)
 		return
 	}
@@ -267,6 +383,70 @@ func (h *AutomationHandler) handlePause(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+func (h *AutomationHandler) handleEnroll(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.EnrollContactsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to decode request body")
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.EnrollContacts(r.Context(), req.WorkspaceID, req.AutomationID, req.Emails)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to enroll contacts")
+		if _, ok := err.(*domain.PermissionError); ok {
+			WriteJSONError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		WriteJSONError(w, "Failed to enroll contacts", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *AutomationHandler) handleReenroll(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.ReenrollContactsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to decode request body")
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.ReenrollContacts(r.Context(), req.WorkspaceID, req.AutomationID, req.Emails, req.SegmentID)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to re-enroll contacts")
+		if _, ok := err.(*domain.PermissionError); ok {
+			WriteJSONError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		WriteJSONError(w, "Failed to re-enroll contacts", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
 func (h *AutomationHandler) handleGetContactNodeExecutions(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -295,3 +475,106 @@ func (h *AutomationHandler) handleGetContactNodeExecutions(w http.ResponseWriter
 		"node_executions":    nodeExecutions,
 	})
 }
+
+func (h *AutomationHandler) handleGetNodeStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.GetAutomationRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	nodeStats, err := h.service.GetNodeStats(r.Context(), req.WorkspaceID, req.AutomationID)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to get automation node stats")
+		if _, ok := err.(*domain.PermissionError); ok {
+			WriteJSONError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		WriteJSONError(w, "Failed to get automation node stats", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"node_stats": nodeStats,
+	})
+}
+
+func (h *AutomationHandler) handleGetContactNextTick(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.GetContactNextTickRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tick, err := h.service.GetContactNextTick(r.Context(), req.WorkspaceID, req.AutomationID, req.Email)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to get contact next tick")
+		if _, ok := err.(*domain.PermissionError); ok {
+			WriteJSONError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		WriteJSONError(w, "Failed to get contact next tick", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tick)
+}
+
+func (h *AutomationHandler) handleTestWebhookNode(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.TestWebhookNodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to decode request body")
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.TestWebhookNode(r.Context(), req.WorkspaceID, req.AutomationID, req.NodeID, req.Email)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to test webhook node")
+		if _, ok := err.(*domain.PermissionError); ok {
+			WriteJSONError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if _, ok := err.(domain.ValidationError); ok {
+			WriteJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		WriteJSONError(w, "Failed to test webhook node", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// writeNodeConfigError writes a 400 with the per-node config errors when err carries them
+func writeNodeConfigError(w http.ResponseWriter, err error) bool {
+	var nodeErr *domain.NodeConfigValidationError
+	if !errors.As(err, &nodeErr) {
+		return false
+	}
+	writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+		"error":       nodeErr.Error(),
+		"node_errors": nodeErr.NodeErrors,
+	})
+	return true
+}
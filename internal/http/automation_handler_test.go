@@ -83,6 +83,37 @@ func TestAutomationHandler_Create(t *testing.T) {
 		assert.Equal(t, http.StatusCreated, w.Code)
 	})
 
+	t.Run("webhook warnings do not block the save", func(t *testing.T) {
+		automation := createTestAutomation("auto-123", "workspace-123")
+
+		automationSvc.EXPECT().Create(gomock.Any(), "workspace-123", gomock.Any()).Return(nil)
+		automationSvc.EXPECT().CheckWebhookURLs(gomock.Any(), gomock.Any()).
+			Return([]string{"webhook node hook: URL https://example.invalid is not reachable"})
+
+		reqBody := domain.CreateAutomationRequest{
+			WorkspaceID:      "workspace-123",
+			Automation:       automation,
+			ValidateWebhooks: true,
+		}
+		body, err := json.Marshal(reqBody)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/automations.create", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		var response struct {
+			Automation *domain.Automation `json:"automation"`
+			Warnings   []string           `json:"warnings"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "auto-123", response.Automation.ID)
+		assert.Equal(t, []string{"webhook node hook: URL https://example.invalid is not reachable"}, response.Warnings)
+	})
+
 	t.Run("validation error", func(t *testing.T) {
 		reqBody := domain.CreateAutomationRequest{
 			WorkspaceID: "",
@@ -121,6 +152,38 @@ func TestAutomationHandler_Create(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
+	t.Run("invalid node configs", func(t *testing.T) {
+		automation := createTestAutomation("auto-123", "workspace-123")
+
+		automationSvc.EXPECT().Create(gomock.Any(), "workspace-123", gomock.Any()).Return(&domain.NodeConfigValidationError{
+			NodeErrors: map[string]domain.NodeConfigFieldError{
+				"delay-1": {Field: "duration", Message: "duration must be positive"},
+			},
+		})
+
+		reqBody := domain.CreateAutomationRequest{
+			WorkspaceID: "workspace-123",
+			Automation:  automation,
+		}
+		body, err := json.Marshal(reqBody)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/automations.create", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response struct {
+			Error      string                                 `json:"error"`
+			NodeErrors map[string]domain.NodeConfigFieldError `json:"node_errors"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "invalid node config: node delay-1: duration must be positive", response.Error)
+		assert.Equal(t, domain.NodeConfigFieldError{Field: "duration", Message: "duration must be positive"}, response.NodeErrors["delay-1"])
+	})
+
 	t.Run("method not allowed", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/api/automations.create", nil)
 		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
@@ -208,6 +271,20 @@ func TestAutomationHandler_List(t *testing.T) {
 		assert.Equal(t, 2, response.Total)
 	})
 
+	t.Run("filters by tag", func(t *testing.T) {
+		automationSvc.EXPECT().
+			List(gomock.Any(), "workspace-123", domain.AutomationFilter{Tag: "onboarding"}).
+			Return([]*domain.Automation{createTestAutomation("auto-1", "workspace-123")}, 1, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/automations.list?workspace_id=workspace-123&tag=onboarding", nil)
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
 	t.Run("validation error - missing workspace_id", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/api/automations.list", nil)
 		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
@@ -244,6 +321,32 @@ func TestAutomationHandler_Update(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
+	t.Run("webhook check without warnings", func(t *testing.T) {
+		automation := createTestAutomation("auto-123", "workspace-123")
+
+		automationSvc.EXPECT().Update(gomock.Any(), "workspace-123", gomock.Any()).Return(nil)
+		automationSvc.EXPECT().CheckWebhookURLs(gomock.Any(), gomock.Any()).Return(nil)
+
+		reqBody := domain.UpdateAutomationRequest{
+			WorkspaceID:      "workspace-123",
+			Automation:       automation,
+			ValidateWebhooks: true,
+		}
+		body, err := json.Marshal(reqBody)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/automations.update", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response map[string]interface{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.NotContains(t, response, "warnings")
+	})
+
 	t.Run("validation error", func(t *testing.T) {
 		reqBody := domain.UpdateAutomationRequest{
 			WorkspaceID: "",
@@ -304,6 +407,102 @@ func TestAutomationHandler_Delete(t *testing.T) {
 	})
 }
 
+func TestAutomationHandler_ListTags(t *testing.T) {
+	_, automationSvc, mux, secretKey := setupAutomationTest(t)
+
+	t.Run("successful list", func(t *testing.T) {
+		automationSvc.EXPECT().ListTags(gomock.Any(), "workspace-123").Return([]string{"onboarding", "vip"}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/automations.tags?workspace_id=workspace-123", nil)
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Tags []string `json:"tags"`
+		}
+		err := json.NewDecoder(w.Body).Decode(&response)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"onboarding", "vip"}, response.Tags)
+	})
+
+	t.Run("validation error - missing workspace_id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/automations.tags", nil)
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/automations.tags?workspace_id=workspace-123", nil)
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestAutomationHandler_SetTags(t *testing.T) {
+	_, automationSvc, mux, secretKey := setupAutomationTest(t)
+
+	t.Run("successful set", func(t *testing.T) {
+		automationSvc.EXPECT().SetTags(gomock.Any(), "workspace-123", "auto-123", []string{"onboarding", "vip"}).Return(nil)
+
+		reqBody := domain.SetAutomationTagsRequest{
+			WorkspaceID:  "workspace-123",
+			AutomationID: "auto-123",
+			Tags:         []string{"onboarding", "vip"},
+		}
+		body, err := json.Marshal(reqBody)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/automations.setTags", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("validation error - missing automation_id", func(t *testing.T) {
+		body, err := json.Marshal(domain.SetAutomationTagsRequest{WorkspaceID: "workspace-123", Tags: []string{"vip"}})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/automations.setTags", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		automationSvc.EXPECT().SetTags(gomock.Any(), "workspace-123", "auto-123", []string{"vip"}).
+			Return(domain.NewPermissionError(domain.PermissionResourceAutomations, domain.PermissionTypeWrite, "Insufficient permissions: write access to automations required"))
+
+		body, err := json.Marshal(domain.SetAutomationTagsRequest{WorkspaceID: "workspace-123", AutomationID: "auto-123", Tags: []string{"vip"}})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/automations.setTags", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
 func TestAutomationHandler_Activate(t *testing.T) {
 	_, automationSvc, mux, secretKey := setupAutomationTest(t)
 
@@ -344,6 +543,49 @@ func TestAutomationHandler_Activate(t *testing.T) {
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
+
+	t.Run("missing trigger segment is a bad request", func(t *testing.T) {
+		automationSvc.EXPECT().Activate(gomock.Any(), "workspace-123", "auto-123").
+			Return(domain.NewValidationError("trigger event segment.joined references segment seg-missing, which does not exist"))
+
+		reqBody := domain.ActivateAutomationRequest{
+			WorkspaceID:  "workspace-123",
+			AutomationID: "auto-123",
+		}
+		body, err := json.Marshal(reqBody)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/automations.activate", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "references segment seg-missing, which does not exist")
+	})
+
+	t.Run("failed integration check does not activate", func(t *testing.T) {
+		automationSvc.EXPECT().CheckIntegrations(gomock.Any(), "workspace-123", "auto-123").
+			Return(domain.NewValidationError("email integration check failed: no marketing email provider configured for this workspace"))
+
+		reqBody := domain.ActivateAutomationRequest{
+			WorkspaceID:      "workspace-123",
+			AutomationID:     "auto-123",
+			CheckIntegration: true,
+		}
+		body, err := json.Marshal(reqBody)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/automations.activate", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "email integration check failed")
+	})
 }
 
 func TestAutomationHandler_Pause(t *testing.T) {
@@ -388,6 +630,117 @@ func TestAutomationHandler_Pause(t *testing.T) {
 	})
 }
 
+func TestAutomationHandler_Enroll(t *testing.T) {
+	_, automationSvc, mux, secretKey := setupAutomationTest(t)
+
+	postEnroll := func(t *testing.T, reqBody domain.EnrollContactsRequest) *httptest.ResponseRecorder {
+		body, err := json.Marshal(reqBody)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/automations.enroll", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("returns per-item outcomes", func(t *testing.T) {
+		emails := []string{"new@example.com", "not-an-email"}
+		result := domain.NewBulkOperationResult(len(emails))
+		result.Add("new@example.com", domain.BulkItemStatusCreated, "")
+		result.Add("not-an-email", domain.BulkItemStatusFailed, "invalid email")
+		automationSvc.EXPECT().EnrollContacts(gomock.Any(), "workspace-123", "auto-123", emails).Return(result, nil)
+
+		w := postEnroll(t, domain.EnrollContactsRequest{
+			WorkspaceID:  "workspace-123",
+			AutomationID: "auto-123",
+			Emails:       emails,
+		})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response domain.BulkOperationResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, *result, response)
+	})
+
+	t.Run("missing emails", func(t *testing.T) {
+		w := postEnroll(t, domain.EnrollContactsRequest{
+			WorkspaceID:  "workspace-123",
+			AutomationID: "auto-123",
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("permission error", func(t *testing.T) {
+		automationSvc.EXPECT().EnrollContacts(gomock.Any(), "workspace-123", "auto-123", []string{"new@example.com"}).
+			Return(nil, domain.NewPermissionError(domain.PermissionResourceAutomations, domain.PermissionTypeWrite, "Insufficient permissions"))
+
+		w := postEnroll(t, domain.EnrollContactsRequest{
+			WorkspaceID:  "workspace-123",
+			AutomationID: "auto-123",
+			Emails:       []string{"new@example.com"},
+		})
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestAutomationHandler_Reenroll(t *testing.T) {
+	_, automationSvc, mux, secretKey := setupAutomationTest(t)
+
+	postReenroll := func(t *testing.T, reqBody domain.ReenrollContactsRequest) *httptest.ResponseRecorder {
+		body, err := json.Marshal(reqBody)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/automations.reenroll", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("returns counts for a segment", func(t *testing.T) {
+		result := &domain.ReenrollContactsResult{Enrolled: 3, Skipped: 1}
+		automationSvc.EXPECT().ReenrollContacts(gomock.Any(), "workspace-123", "auto-123", []string(nil), "seg-1").Return(result, nil)
+
+		w := postReenroll(t, domain.ReenrollContactsRequest{
+			WorkspaceID:  "workspace-123",
+			AutomationID: "auto-123",
+			SegmentID:    "seg-1",
+		})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response domain.ReenrollContactsResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, *result, response)
+	})
+
+	t.Run("missing emails and segment", func(t *testing.T) {
+		w := postReenroll(t, domain.ReenrollContactsRequest{
+			WorkspaceID:  "workspace-123",
+			AutomationID: "auto-123",
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("permission error", func(t *testing.T) {
+		automationSvc.EXPECT().ReenrollContacts(gomock.Any(), "workspace-123", "auto-123", []string{"new@example.com"}, "").
+			Return(nil, domain.NewPermissionError(domain.PermissionResourceAutomations, domain.PermissionTypeWrite, "Insufficient permissions"))
+
+		w := postReenroll(t, domain.ReenrollContactsRequest{
+			WorkspaceID:  "workspace-123",
+			AutomationID: "auto-123",
+			Emails:       []string{"new@example.com"},
+		})
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
 func TestAutomationHandler_GetContactNodeExecutions(t *testing.T) {
 	_, automationSvc, mux, secretKey := setupAutomationTest(t)
 
@@ -450,3 +803,189 @@ func TestAutomationHandler_GetContactNodeExecutions(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 }
+
+func TestAutomationHandler_GetNodeStats(t *testing.T) {
+	_, automationSvc, mux, secretKey := setupAutomationTest(t)
+
+	t.Run("successful get node stats", func(t *testing.T) {
+		nodeStats := map[string]*domain.AutomationNodeExecutionStats{
+			"email-1": {Executions: 4, Failures: 1, TotalDurationMs: 200, AvgDurationMs: 50},
+		}
+		automationSvc.EXPECT().GetNodeStats(gomock.Any(), "workspace-123", "auto-123").Return(nodeStats, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/automations.nodeStats?workspace_id=workspace-123&automation_id=auto-123", nil)
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			NodeStats map[string]*domain.AutomationNodeExecutionStats `json:"node_stats"`
+		}
+		err := json.NewDecoder(w.Body).Decode(&response)
+		require.NoError(t, err)
+		require.Contains(t, response.NodeStats, "email-1")
+		assert.Equal(t, int64(1), response.NodeStats["email-1"].Failures)
+		assert.Equal(t, float64(50), response.NodeStats["email-1"].AvgDurationMs)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/automations.nodeStats?workspace_id=workspace-123", nil)
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/automations.nodeStats?workspace_id=workspace-123&automation_id=auto-123", nil)
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		automationSvc.EXPECT().GetNodeStats(gomock.Any(), "workspace-123", "auto-123").
+			Return(nil, domain.NewPermissionError(domain.PermissionResourceAutomations, domain.PermissionTypeRead, "Insufficient permissions: read access to automations required"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/automations.nodeStats?workspace_id=workspace-123&automation_id=auto-123", nil)
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestAutomationHandler_GetContactNextTick(t *testing.T) {
+	_, automationSvc, mux, secretKey := setupAutomationTest(t)
+
+	t.Run("successful get contact next tick", func(t *testing.T) {
+		nodeID := "email-1"
+		nodeType := domain.NodeTypeEmail
+		scheduledAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+		tick := &domain.ContactNextTick{
+			CurrentNodeID: &nodeID,
+			ScheduledAt:   &scheduledAt,
+			NextAction: domain.ContactNextAction{
+				Type:     domain.NextActionExecuteNode,
+				NodeID:   &nodeID,
+				NodeType: &nodeType,
+			},
+		}
+
+		automationSvc.EXPECT().GetContactNextTick(gomock.Any(), "workspace-123", "auto-123", "test@example.com").Return(tick, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/automations.contacts.next?workspace_id=workspace-123&automation_id=auto-123&email=test@example.com", nil)
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response domain.ContactNextTick
+		err := json.NewDecoder(w.Body).Decode(&response)
+		require.NoError(t, err)
+		require.NotNil(t, response.CurrentNodeID)
+		assert.Equal(t, "email-1", *response.CurrentNodeID)
+		require.NotNil(t, response.ScheduledAt)
+		assert.True(t, scheduledAt.Equal(*response.ScheduledAt))
+		assert.Equal(t, domain.NextActionExecuteNode, response.NextAction.Type)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/automations.contacts.next?workspace_id=workspace-123&automation_id=auto-123", nil)
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		automationSvc.EXPECT().GetContactNextTick(gomock.Any(), "workspace-123", "auto-123", "test@example.com").
+			Return(nil, domain.NewPermissionError(domain.PermissionResourceAutomations, domain.PermissionTypeRead, "Insufficient permissions: read access to automations required"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/automations.contacts.next?workspace_id=workspace-123&automation_id=auto-123&email=test@example.com", nil)
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestAutomationHandler_TestWebhookNode(t *testing.T) {
+	_, automationSvc, mux, secretKey := setupAutomationTest(t)
+
+	t.Run("returns the captured exchange", func(t *testing.T) {
+		result := &domain.WebhookNodeTestResult{
+			Exchange: map[string]interface{}{
+				"request":  map[string]interface{}{"method": "POST", "url": "https://example.com/hook", "body": `{"email":"test@example.com"}`},
+				"response": map[string]interface{}{"status_code": 200, "body": `{"ok":true}`},
+			},
+			Output: map[string]interface{}{"status_code": 200},
+		}
+		automationSvc.EXPECT().TestWebhookNode(gomock.Any(), "workspace-123", "auto-123", "webhook-1", "test@example.com").Return(result, nil)
+
+		body, _ := json.Marshal(domain.TestWebhookNodeRequest{
+			WorkspaceID:  "workspace-123",
+			AutomationID: "auto-123",
+			NodeID:       "webhook-1",
+			Email:        "test@example.com",
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/automations.nodes.testWebhook", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response domain.WebhookNodeTestResult
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, `{"email":"test@example.com"}`, response.Exchange["request"].(map[string]interface{})["body"])
+		assert.Equal(t, float64(200), response.Exchange["response"].(map[string]interface{})["status_code"])
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		body, _ := json.Marshal(domain.TestWebhookNodeRequest{WorkspaceID: "workspace-123", AutomationID: "auto-123", Email: "test@example.com"})
+		req := httptest.NewRequest(http.MethodPost, "/api/automations.nodes.testWebhook", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("node is not a webhook", func(t *testing.T) {
+		automationSvc.EXPECT().TestWebhookNode(gomock.Any(), "workspace-123", "auto-123", "email-1", "test@example.com").
+			Return(nil, domain.NewValidationError("node email-1 has type email, not webhook"))
+
+		body, _ := json.Marshal(domain.TestWebhookNodeRequest{
+			WorkspaceID:  "workspace-123",
+			AutomationID: "auto-123",
+			NodeID:       "email-1",
+			Email:        "test@example.com",
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/automations.nodes.testWebhook", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -54,6 +54,7 @@ func (h *BroadcastHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/api/broadcasts.pause", requireAuth(http.HandlerFunc(h.HandlePause)))
 	mux.Handle("/api/broadcasts.resume", requireAuth(http.HandlerFunc(h.HandleResume)))
 	mux.Handle("/api/broadcasts.cancel", requireAuth(http.HandlerFunc(h.HandleCancel)))
+	mux.Handle("/api/broadcasts.cancelSchedule", requireAuth(http.HandlerFunc(h.HandleCancelSchedule)))
 	mux.Handle("/api/broadcasts.sendToIndividual", requireAuth(http.HandlerFunc(h.HandleSendToIndividual)))
 	mux.Handle("/api/broadcasts.delete", requireAuth(http.HandlerFunc(h.HandleDelete)))
 	// A/B Testing endpoints
@@ -63,6 +64,7 @@ func (h *BroadcastHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/api/broadcasts.refreshGlobalFeed", requireAuth(http.HandlerFunc(h.HandleRefreshGlobalFeed)))
 	// Recipient feed endpoints
 	mux.Handle("/api/broadcasts.testRecipientFeed", requireAuth(http.HandlerFunc(h.HandleTestRecipientFeed)))
+	mux.Handle("/api/broadcasts.previewRendered", requireAuth(http.HandlerFunc(h.HandlePreviewRendered)))
 }
 
 // HandleList handles the broadcast list request
@@ -174,6 +176,10 @@ func (h *BroadcastHandler) HandleCreate(w http.ResponseWriter, r *http.Request)
 
 	broadcast, err := h.service.CreateBroadcast(r.Context(), &req)
 	if err != nil {
+		if _, ok := err.(domain.ValidationError); ok {
+			WriteJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		h.logger.WithField("error", err.Error()).Error("Failed to create broadcast")
 		WriteJSONError(w, "Failed to create broadcast", http.StatusInternalServerError)
 		return
@@ -218,6 +224,10 @@ func (h *BroadcastHandler) HandleUpdate(w http.ResponseWriter, r *http.Request)
 
 	updatedBroadcast, err := h.service.UpdateBroadcast(r.Context(), &req)
 	if err != nil {
+		if _, ok := err.(domain.ValidationError); ok {
+			WriteJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		h.logger.WithField("error", err.Error()).Error("Failed to update broadcast")
 		WriteJSONError(w, "Failed to update broadcast", http.StatusInternalServerError)
 		return
@@ -253,6 +263,10 @@ func (h *BroadcastHandler) HandleSchedule(w http.ResponseWriter, r *http.Request
 			WriteJSONError(w, "Broadcast not found", http.StatusNotFound)
 			return
 		}
+		if _, ok := err.(domain.ValidationError); ok {
+			WriteJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		h.logger.WithField("error", err.Error()).Error("Failed to schedule broadcast")
 		WriteJSONError(w, "Failed to schedule broadcast", http.StatusInternalServerError)
 		return
@@ -368,6 +382,41 @@ func (h *BroadcastHandler) HandleCancel(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// HandleCancelSchedule handles the broadcast cancel schedule request
+func (h *BroadcastHandler) HandleCancelSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.CancelBroadcastScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to decode request body")
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err := h.service.CancelBroadcastSchedule(r.Context(), &req)
+	if err != nil {
+		if _, ok := err.(*domain.ErrBroadcastNotFound); ok {
+			WriteJSONError(w, "Broadcast not found", http.StatusNotFound)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to cancel broadcast schedule")
+		WriteJSONError(w, "Failed to cancel broadcast schedule", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+	})
+}
+
 // HandleSendToIndividual handles the broadcast send to individual request
 func (h *BroadcastHandler) HandleSendToIndividual(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -528,6 +577,10 @@ func (h *BroadcastHandler) HandleRefreshGlobalFeed(w http.ResponseWriter, r *htt
 	response, err := h.service.RefreshGlobalFeed(r.Context(), &req)
 	if err != nil {
 		// Check for specific error types
+		if _, ok := err.(domain.ValidationError); ok {
+			WriteJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		if _, ok := err.(*domain.ErrBroadcastNotFound); ok {
 			WriteJSONError(w, "Broadcast not found", http.StatusNotFound)
 			return
@@ -562,6 +615,10 @@ func (h *BroadcastHandler) HandleTestRecipientFeed(w http.ResponseWriter, r *htt
 	response, err := h.service.TestRecipientFeed(r.Context(), &req)
 	if err != nil {
 		// Check for specific error types
+		if _, ok := err.(domain.ValidationError); ok {
+			WriteJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		if _, ok := err.(*domain.ErrBroadcastNotFound); ok {
 			WriteJSONError(w, "Broadcast not found", http.StatusNotFound)
 			return
@@ -577,3 +634,36 @@ func (h *BroadcastHandler) HandleTestRecipientFeed(w http.ResponseWriter, r *htt
 
 	writeJSON(w, http.StatusOK, response)
 }
+
+// HandlePreviewRendered handles the request to render a broadcast for sample recipients
+func (h *BroadcastHandler) HandlePreviewRendered(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.PreviewRenderedBroadcastRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to decode request body")
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.PreviewRendered(r.Context(), &req)
+	if err != nil {
+		if _, ok := err.(*domain.ErrBroadcastNotFound); ok {
+			WriteJSONError(w, "Broadcast not found", http.StatusNotFound)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to preview broadcast")
+		WriteJSONError(w, "Failed to preview broadcast", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
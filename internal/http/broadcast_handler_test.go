@@ -21,6 +21,7 @@ import (
 	"github.com/golang/mock/gomock"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Helper function to create a test broadcast
@@ -237,6 +238,27 @@ func TestHandleGet(t *testing.T) {
 		assert.Contains(t, response, "broadcast")
 	})
 
+	t.Run("ReturnsMetadata", func(t *testing.T) {
+		withMetadata := *broadcast
+		withMetadata.Metadata = domain.MapOfAny{"campaign_id": "crm-42"}
+		mockService.EXPECT().
+			GetBroadcast(gomock.Any(), "workspace123", "broadcast123").
+			Return(&withMetadata, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.get?workspace_id=workspace123&id=broadcast123", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleGet(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Broadcast domain.Broadcast `json:"broadcast"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, domain.MapOfAny{"campaign_id": "crm-42"}, response.Broadcast.Metadata)
+	})
+
 	// Test successful get with template fetching
 	t.Run("SuccessWithTemplates", func(t *testing.T) {
 		mockService.EXPECT().
@@ -445,6 +467,32 @@ func TestHandleCreate(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 
+	// Test validation error from the service
+	t.Run("ServiceValidationError", func(t *testing.T) {
+		createRequest := &domain.CreateBroadcastRequest{
+			WorkspaceID: "workspace123",
+			Name:        "Test Broadcast",
+			Audience: domain.AudienceSettings{
+				List:             "list123",
+				ExcludeOpenersOf: "missing",
+			},
+		}
+
+		mockService.EXPECT().
+			CreateBroadcast(gomock.Any(), gomock.Any()).
+			Return(nil, domain.NewValidationError("exclude_openers_of references an unknown broadcast: missing"))
+
+		requestBody, _ := json.Marshal(createRequest)
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.create", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.HandleCreate(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "exclude_openers_of")
+	})
+
 	// Test invalid JSON
 	t.Run("InvalidJSON", func(t *testing.T) {
 		// Set up logger expectations using gomock
@@ -724,6 +772,87 @@ func TestHandleSchedule(t *testing.T) {
 	})
 }
 
+func TestHandleCancelSchedule(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		handler, mockService, _, _, ctrl := setupBroadcastHandler(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().
+			CancelBroadcastSchedule(gomock.Any(), &domain.CancelBroadcastScheduleRequest{WorkspaceID: "workspace123", ID: "broadcast123"}).
+			Return(nil)
+
+		requestBody, _ := json.Marshal(map[string]string{"workspace_id": "workspace123", "id": "broadcast123"})
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.cancelSchedule", bytes.NewBuffer(requestBody))
+		w := httptest.NewRecorder()
+
+		handler.HandleCancelSchedule(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.True(t, response["success"].(bool))
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		handler, _, _, _, ctrl := setupBroadcastHandler(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.cancelSchedule", bytes.NewBufferString(`{"workspace_id":"workspace123"}`))
+		w := httptest.NewRecorder()
+
+		handler.HandleCancelSchedule(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("BroadcastNotFound", func(t *testing.T) {
+		handler, mockService, _, _, ctrl := setupBroadcastHandler(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().
+			CancelBroadcastSchedule(gomock.Any(), gomock.Any()).
+			Return(&domain.ErrBroadcastNotFound{ID: "nonexistent"})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.cancelSchedule", bytes.NewBufferString(`{"workspace_id":"workspace123","id":"nonexistent"}`))
+		w := httptest.NewRecorder()
+
+		handler.HandleCancelSchedule(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("ServiceError", func(t *testing.T) {
+		handler, mockService, _, mockLogger, ctrl := setupBroadcastHandler(t)
+		defer ctrl.Finish()
+
+		mockLogger.EXPECT().WithField("error", gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+		mockService.EXPECT().
+			CancelBroadcastSchedule(gomock.Any(), gomock.Any()).
+			Return(fmt.Errorf("only broadcasts with scheduled status can be unscheduled, current status: processing"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.cancelSchedule", bytes.NewBufferString(`{"workspace_id":"workspace123","id":"broadcast123"}`))
+		w := httptest.NewRecorder()
+
+		handler.HandleCancelSchedule(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("MethodNotAllowed", func(t *testing.T) {
+		handler, _, _, _, ctrl := setupBroadcastHandler(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.cancelSchedule", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleCancelSchedule(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
 // TestHandleCancel tests the handleCancel function
 func TestHandleCancel(t *testing.T) {
 	handler, mockService, _, mockLogger, ctrl := setupBroadcastHandler(t)
@@ -2244,3 +2373,72 @@ func TestMissingParameterError_Error(t *testing.T) {
 		assert.Equal(t, expected, err.Error())
 	})
 }
+
+func TestHandlePreviewRendered(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		handler, mockService, _, _, ctrl := setupBroadcastHandler(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().
+			PreviewRendered(gomock.Any(), &domain.PreviewRenderedBroadcastRequest{WorkspaceID: "workspace123", BroadcastID: "broadcast123", Limit: 2}).
+			Return(&domain.PreviewRenderedBroadcastResponse{
+				TemplateID: "template123",
+				Previews: []domain.BroadcastRecipientPreview{
+					{Email: "john@example.com", Subject: "Hello John", HTML: "<p>Hi</p>"},
+					{Email: "jane@example.com", Error: "failed to fetch recipient feed: timeout"},
+				},
+			}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.previewRendered", bytes.NewBufferString(`{"workspace_id":"workspace123","broadcast_id":"broadcast123","limit":2}`))
+		w := httptest.NewRecorder()
+
+		handler.HandlePreviewRendered(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response domain.PreviewRenderedBroadcastResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Previews, 2)
+		assert.Equal(t, "Hello John", response.Previews[0].Subject)
+		assert.Equal(t, "failed to fetch recipient feed: timeout", response.Previews[1].Error)
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		handler, _, _, _, ctrl := setupBroadcastHandler(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.previewRendered", bytes.NewBufferString(`{"workspace_id":"workspace123","broadcast_id":"broadcast123","limit":50}`))
+		w := httptest.NewRecorder()
+
+		handler.HandlePreviewRendered(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("BroadcastNotFound", func(t *testing.T) {
+		handler, mockService, _, _, ctrl := setupBroadcastHandler(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().
+			PreviewRendered(gomock.Any(), gomock.Any()).
+			Return(nil, &domain.ErrBroadcastNotFound{ID: "nonexistent"})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.previewRendered", bytes.NewBufferString(`{"workspace_id":"workspace123","broadcast_id":"nonexistent"}`))
+		w := httptest.NewRecorder()
+
+		handler.HandlePreviewRendered(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("MethodNotAllowed", func(t *testing.T) {
+		handler, _, _, _, ctrl := setupBroadcastHandler(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.previewRendered", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandlePreviewRendered(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
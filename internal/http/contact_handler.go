@@ -34,6 +34,7 @@ func (h *ContactHandler) RegisterRoutes(mux *http.ServeMux) {
 	// Register RPC-style endpoints with dot notation
 	mux.Handle("/api/contacts.list", requireAuth(http.HandlerFunc(h.handleList)))
 	mux.Handle("/api/contacts.count", requireAuth(http.HandlerFunc(h.handleCount)))
+	mux.Handle("/api/contacts.export", requireAuth(http.HandlerFunc(h.handleExport)))
 	mux.Handle("/api/contacts.getByEmail", requireAuth(http.HandlerFunc(h.handleGetByEmail)))
 	mux.Handle("/api/contacts.getByExternalID", requireAuth(http.HandlerFunc(h.handleGetByExternalID)))
 	mux.Handle("/api/contacts.delete", requireAuth(http.HandlerFunc(h.handleDelete)))
@@ -102,6 +103,69 @@ func (h *ContactHandler) handleCount(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// csvExportWriter sends the CSV response headers on the first write and flushes
+// every write so large exports are streamed to the client as they are produced.
+// Until something is written, the handler can still reply with a JSON error.
+type csvExportWriter struct {
+	w        http.ResponseWriter
+	filename string
+	started  bool
+}
+
+func (c *csvExportWriter) Write(p []byte) (int, error) {
+	if !c.started {
+		c.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		c.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", c.filename))
+		c.w.WriteHeader(http.StatusOK)
+		c.started = true
+	}
+	n, err := c.w.Write(p)
+	if flusher, ok := c.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return n, err
+}
+
+func (h *ContactHandler) handleExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req := &domain.ExportContactsRequest{}
+	if err := req.FromQueryParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	out := &csvExportWriter{
+		w:        w,
+		filename: fmt.Sprintf("contacts-%s.csv", req.WorkspaceID),
+	}
+
+	if err := h.service.ExportContacts(r.Context(), req, out); err != nil {
+		h.logger.WithFields(map[string]interface{}{
+			"workspace_id": req.WorkspaceID,
+			"error":        err.Error(),
+		}).Error("Failed to export contacts")
+
+		// Once rows have been streamed the status can no longer change
+		if out.started {
+			return
+		}
+		if _, ok := err.(*domain.PermissionError); ok {
+			WriteJSONError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		WriteJSONError(w, "Failed to export contacts", http.StatusInternalServerError)
+	}
+}
+
 func (h *ContactHandler) handleGetByEmail(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
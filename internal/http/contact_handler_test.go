@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -52,6 +53,7 @@ func TestContactHandler_RegisterRoutes(t *testing.T) {
 	endpoints := []string{
 		"/api/contacts.list",
 		"/api/contacts.count",
+		"/api/contacts.export",
 		"/api/contacts.get",
 		"/api/contacts.getByEmail",
 		"/api/contacts.getByExternalID",
@@ -260,6 +262,113 @@ func TestContactHandler_HandleCount(t *testing.T) {
 	}
 }
 
+func TestContactHandler_HandleExport(t *testing.T) {
+	testCases := []struct {
+		name            string
+		method          string
+		queryParams     string
+		setupMock       func(*mocks.MockContactService)
+		expectedStatus  int
+		expectedBody    string
+		expectedCSVType bool
+	}{
+		{
+			name:        "Export Contacts Success",
+			method:      http.MethodGet,
+			queryParams: "workspace_id=workspace123&segments[]=seg1&columns=email,first_name",
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().ExportContacts(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, req *domain.ExportContactsRequest, w io.Writer) error {
+						assert.Equal(t, "workspace123", req.WorkspaceID)
+						assert.Equal(t, []string{"seg1"}, req.Segments)
+						assert.Equal(t, []string{"email", "first_name"}, req.Columns)
+						_, err := w.Write([]byte("email,first_name\na@example.com,Ann\n"))
+						return err
+					})
+			},
+			expectedStatus:  http.StatusOK,
+			expectedBody:    "email,first_name\na@example.com,Ann\n",
+			expectedCSVType: true,
+		},
+		{
+			name:        "Default Columns",
+			method:      http.MethodGet,
+			queryParams: "workspace_id=workspace123",
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().ExportContacts(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, req *domain.ExportContactsRequest, w io.Writer) error {
+						assert.Equal(t, domain.DefaultContactExportColumns, req.Columns)
+						_, err := w.Write([]byte("email\n"))
+						return err
+					})
+			},
+			expectedStatus:  http.StatusOK,
+			expectedCSVType: true,
+		},
+		{
+			name:           "Invalid Column",
+			method:         http.MethodGet,
+			queryParams:    "workspace_id=workspace123&columns=email,password",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing Workspace ID",
+			method:         http.MethodGet,
+			queryParams:    "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Permission Denied",
+			method:      http.MethodGet,
+			queryParams: "workspace_id=workspace123",
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().ExportContacts(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					domain.NewPermissionError(domain.PermissionResourceContacts, domain.PermissionTypeRead, "Insufficient permissions: read access to contacts required"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:        "Service Error Before Streaming",
+			method:      http.MethodGet,
+			queryParams: "workspace_id=workspace123",
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().ExportContacts(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("service error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "Method Not Allowed",
+			method:         http.MethodPost,
+			queryParams:    "workspace_id=workspace123",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService, _, handler := setupContactHandlerTest(t)
+
+			if tc.setupMock != nil {
+				tc.setupMock(mockService)
+			}
+
+			req := httptest.NewRequest(tc.method, "/api/contacts.export?"+tc.queryParams, nil)
+			rr := httptest.NewRecorder()
+
+			handler.handleExport(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedCSVType {
+				assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+				assert.Contains(t, rr.Header().Get("Content-Disposition"), "contacts-workspace123.csv")
+			}
+			if tc.expectedBody != "" {
+				assert.Equal(t, tc.expectedBody, rr.Body.String())
+			}
+		})
+	}
+}
+
 func TestContactHandler_HandleGet(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -59,6 +59,7 @@ func (h *ContactTimelineHandler) RegisterRoutes(mux *http.ServeMux) {
 
 	// Register RPC-style endpoints with dot notation
 	mux.Handle("/api/timeline.list", requireAuth(http.HandlerFunc(h.handleList)))
+	mux.Handle("/api/contacts.timeline", requireAuth(http.HandlerFunc(h.handleContactTimeline)))
 }
 
 // handleList handles requests to list contact timeline with pagination
@@ -119,3 +120,57 @@ func (h *ContactTimelineHandler) handleList(w http.ResponseWriter, r *http.Reque
 	}
 	writeJSON(w, http.StatusOK, response)
 }
+
+// handleContactTimeline handles requests for the full timeline of a contact (automation, message,
+// list, custom events...) with pagination and kind filtering
+func (h *ContactTimelineHandler) handleContactTimeline(w http.ResponseWriter, r *http.Request) {
+	// codecov:ignore:start
+	ctx, span := h.tracer.StartSpan(r.Context(), "ContactTimelineHandler.handleContactTimeline")
+	defer func() {
+		if span != nil {
+			h.tracer.EndSpan(span, nil)
+		}
+	}()
+	// codecov:ignore:end
+
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.ContactTimelineRequest
+	if err := req.FromQuery(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	ctx, _, _, err = h.authService.AuthenticateUserForWorkspace(ctx, req.WorkspaceID)
+	if err != nil {
+		// codecov:ignore:start
+		h.logger.Error(err.Error())
+		if span != nil {
+			h.tracer.MarkSpanError(ctx, err)
+		}
+		// codecov:ignore:end
+		WriteJSONError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	entries, nextCursor, err := h.service.ListAll(ctx, req.WorkspaceID, req.Email, req.Kinds, req.Limit, req.Cursor)
+	if err != nil {
+		// codecov:ignore:start
+		h.logger.Error(err.Error())
+		if span != nil {
+			h.tracer.MarkSpanError(ctx, err)
+		}
+		// codecov:ignore:end
+		WriteJSONError(w, "Failed to get contact timeline", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, domain.TimelineListResponse{
+		Timeline:   entries,
+		NextCursor: nextCursor,
+	})
+}
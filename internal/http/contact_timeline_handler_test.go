@@ -218,6 +218,136 @@ func TestContactTimelineHandler_handleList(t *testing.T) {
 	})
 }
 
+func TestContactTimelineHandler_handleContactTimeline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockContactTimelineService(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockTracer := pkgmocks.NewMockTracer(ctrl)
+
+	mockSpan := &trace.Span{}
+	mockTracer.EXPECT().
+		StartSpan(gomock.Any(), "ContactTimelineHandler.handleContactTimeline").
+		Return(context.Background(), mockSpan).
+		AnyTimes()
+	mockTracer.EXPECT().
+		EndSpan(mockSpan, nil).
+		AnyTimes()
+	mockTracer.EXPECT().
+		MarkSpanError(gomock.Any(), gomock.Any()).
+		AnyTimes()
+
+	jwtSecret := []byte("test-jwt-secret-key-for-testing-32bytes")
+	handler := NewContactTimelineHandlerWithTracer(
+		mockService,
+		mockAuthService,
+		func() ([]byte, error) { return jwtSecret, nil },
+		mockLogger,
+		mockTracer,
+	)
+
+	t.Run("Success - Full timeline filtered by kind", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/contacts.timeline?workspace_id=ws1&email=user@example.com&limit=20&kinds=automation.start,automation.node", nil)
+		w := httptest.NewRecorder()
+
+		mockAuthService.EXPECT().
+			AuthenticateUserForWorkspace(gomock.Any(), "ws1").
+			Return(context.Background(), &domain.User{ID: "user1"}, &domain.UserWorkspace{WorkspaceID: "ws1"}, nil)
+
+		cursor := "cursor123"
+		now := time.Now()
+		entries := []*domain.ContactTimelineEntry{
+			{
+				ID:         "exec1",
+				Email:      "user@example.com",
+				Operation:  "insert",
+				EntityType: domain.TimelineEntityTypeAutomationNode,
+				Kind:       domain.TimelineKindAutomationNode,
+				CreatedAt:  now,
+			},
+			{
+				ID:         "entry1",
+				Email:      "user@example.com",
+				Operation:  "insert",
+				EntityType: "automation",
+				Kind:       "automation.start",
+				CreatedAt:  now.Add(-time.Minute),
+			},
+		}
+		mockService.EXPECT().
+			ListAll(gomock.Any(), "ws1", "user@example.com", []string{"automation.start", "automation.node"}, 20, (*string)(nil)).
+			Return(entries, &cursor, nil)
+
+		handler.handleContactTimeline(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response domain.TimelineListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		require.Len(t, response.Timeline, 2)
+		assert.Equal(t, domain.TimelineKindAutomationNode, response.Timeline[0].Kind)
+		assert.Equal(t, "automation.start", response.Timeline[1].Kind)
+		require.NotNil(t, response.NextCursor)
+		assert.Equal(t, "cursor123", *response.NextCursor)
+	})
+
+	t.Run("Error - Method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/contacts.timeline?workspace_id=ws1&email=user@example.com", nil)
+		w := httptest.NewRecorder()
+
+		handler.handleContactTimeline(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("Error - Missing email", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/contacts.timeline?workspace_id=ws1", nil)
+		w := httptest.NewRecorder()
+
+		handler.handleContactTimeline(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "email is required")
+	})
+
+	t.Run("Error - Authentication failed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/contacts.timeline?workspace_id=ws1&email=user@example.com", nil)
+		w := httptest.NewRecorder()
+
+		mockAuthService.EXPECT().
+			AuthenticateUserForWorkspace(gomock.Any(), "ws1").
+			Return(context.Background(), nil, nil, assert.AnError)
+
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		handler.handleContactTimeline(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Error - Service error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/contacts.timeline?workspace_id=ws1&email=user@example.com", nil)
+		w := httptest.NewRecorder()
+
+		mockAuthService.EXPECT().
+			AuthenticateUserForWorkspace(gomock.Any(), "ws1").
+			Return(context.Background(), &domain.User{ID: "user1"}, &domain.UserWorkspace{WorkspaceID: "ws1"}, nil)
+
+		mockService.EXPECT().
+			ListAll(gomock.Any(), "ws1", "user@example.com", []string(nil), 50, (*string)(nil)).
+			Return(nil, nil, assert.AnError)
+
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		handler.handleContactTimeline(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "Failed to get contact timeline")
+	})
+}
+
 func TestContactTimelineHandler_RegisterRoutes(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
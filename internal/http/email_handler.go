@@ -109,6 +109,8 @@ func (h *EmailHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/opens", http.HandlerFunc(h.handleOpens))
 
 	mux.Handle("/api/email.testProvider", requireAuth(http.HandlerFunc(h.handleTestEmailProvider)))
+	mux.Handle("/api/integrations.test", requireAuth(http.HandlerFunc(h.handleTestIntegration)))
+	mux.Handle("/api/integrations.checkDeliverability", requireAuth(http.HandlerFunc(h.handleCheckDeliverability)))
 }
 
 // Add the handler for testEmailProvider
@@ -142,6 +144,65 @@ func (h *EmailHandler) handleTestEmailProvider(w http.ResponseWriter, r *http.Re
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// handleTestIntegration checks a saved email integration can connect, without sending an email
+func (h *EmailHandler) handleTestIntegration(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.TestIntegrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err := h.emailService.TestIntegration(r.Context(), req.WorkspaceID, req.IntegrationID)
+	resp := domain.TestEmailProviderResponse{Success: err == nil}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleCheckDeliverability looks up the SPF, DKIM and DMARC records of an email
+// integration's sender domain and returns the report
+func (h *EmailHandler) handleCheckDeliverability(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.CheckDeliverabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.emailService.CheckDeliverability(r.Context(), req)
+	if err != nil {
+		if _, ok := err.(domain.ValidationError); ok {
+			WriteJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to check deliverability")
+		WriteJSONError(w, "Failed to check deliverability", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
 func (h *EmailHandler) handleClickRedirection(w http.ResponseWriter, r *http.Request) {
 	// Get the message id (mid) and workspace id (wid) from the query parameters
 	messageID := r.URL.Query().Get("mid")
@@ -266,6 +266,195 @@ func TestEmailHandler_HandleTestEmailProvider(t *testing.T) {
 	}
 }
 
+func TestEmailHandler_HandleTestIntegration(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		reqBody        interface{}
+		setupMock      func(*mocks.MockEmailServiceInterface)
+		expectedStatus int
+		expectedResp   *domain.TestEmailProviderResponse
+	}{
+		{
+			name:           "Method not allowed",
+			method:         http.MethodGet,
+			setupMock:      func(m *mocks.MockEmailServiceInterface) {},
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "Invalid request body",
+			method:         http.MethodPost,
+			reqBody:        "invalid json",
+			setupMock:      func(m *mocks.MockEmailServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing integration ID",
+			method:         http.MethodPost,
+			reqBody:        domain.TestIntegrationRequest{WorkspaceID: "workspace123"},
+			setupMock:      func(m *mocks.MockEmailServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "Connection check failure",
+			method:  http.MethodPost,
+			reqBody: domain.TestIntegrationRequest{WorkspaceID: "workspace123", IntegrationID: "integration123"},
+			setupMock: func(m *mocks.MockEmailServiceInterface) {
+				m.EXPECT().TestIntegration(gomock.Any(), "workspace123", "integration123").
+					Return(errors.New("authentication failed with code: 535"))
+			},
+			expectedStatus: http.StatusOK,
+			expectedResp: &domain.TestEmailProviderResponse{
+				Success: false,
+				Error:   "authentication failed with code: 535",
+			},
+		},
+		{
+			name:    "Success",
+			method:  http.MethodPost,
+			reqBody: domain.TestIntegrationRequest{WorkspaceID: "workspace123", IntegrationID: "integration123"},
+			setupMock: func(m *mocks.MockEmailServiceInterface) {
+				m.EXPECT().TestIntegration(gomock.Any(), "workspace123", "integration123").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResp:   &domain.TestEmailProviderResponse{Success: true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService, _, handler, _ := setupEmailHandlerTest(t)
+			tc.setupMock(mockService)
+
+			var reqBody []byte
+			if strBody, ok := tc.reqBody.(string); ok {
+				reqBody = []byte(strBody)
+			} else if tc.reqBody != nil {
+				var err error
+				reqBody, err = json.Marshal(tc.reqBody)
+				require.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(tc.method, "/api/integrations.test", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.handleTestIntegration(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedResp != nil {
+				var response domain.TestEmailProviderResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+				assert.Equal(t, *tc.expectedResp, response)
+			}
+		})
+	}
+}
+
+func TestEmailHandler_HandleCheckDeliverability(t *testing.T) {
+	validReq := domain.CheckDeliverabilityRequest{WorkspaceID: "workspace123", IntegrationID: "integration123", DKIMSelector: "s1"}
+
+	tests := []struct {
+		name           string
+		method         string
+		reqBody        interface{}
+		setupMock      func(*mocks.MockEmailServiceInterface)
+		expectedStatus int
+		expectedResp   *domain.DeliverabilityReport
+	}{
+		{
+			name:           "Method not allowed",
+			method:         http.MethodGet,
+			setupMock:      func(m *mocks.MockEmailServiceInterface) {},
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "Invalid request body",
+			method:         http.MethodPost,
+			reqBody:        "invalid json",
+			setupMock:      func(m *mocks.MockEmailServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing DKIM selector",
+			method:         http.MethodPost,
+			reqBody:        domain.CheckDeliverabilityRequest{WorkspaceID: "workspace123", IntegrationID: "integration123"},
+			setupMock:      func(m *mocks.MockEmailServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "Unknown integration",
+			method:  http.MethodPost,
+			reqBody: validReq,
+			setupMock: func(m *mocks.MockEmailServiceInterface) {
+				m.EXPECT().CheckDeliverability(gomock.Any(), validReq).
+					Return(nil, domain.ValidationError{Message: "integration integration123 not found in workspace"})
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "Service error",
+			method:  http.MethodPost,
+			reqBody: validReq,
+			setupMock: func(m *mocks.MockEmailServiceInterface) {
+				m.EXPECT().CheckDeliverability(gomock.Any(), validReq).Return(nil, errors.New("database down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:    "Success",
+			method:  http.MethodPost,
+			reqBody: validReq,
+			setupMock: func(m *mocks.MockEmailServiceInterface) {
+				m.EXPECT().CheckDeliverability(gomock.Any(), validReq).Return(&domain.DeliverabilityReport{
+					Sender: "news@example.com",
+					Domain: "example.com",
+					SPF:    domain.DeliverabilityCheck{Status: domain.DeliverabilityCheckPass, Host: "example.com"},
+					DKIM:   domain.DeliverabilityCheck{Status: domain.DeliverabilityCheckFail, Host: "s1._domainkey.example.com"},
+					DMARC:  domain.DeliverabilityCheck{Status: domain.DeliverabilityCheckPass, Host: "_dmarc.example.com"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResp: &domain.DeliverabilityReport{
+				Sender: "news@example.com",
+				Domain: "example.com",
+				SPF:    domain.DeliverabilityCheck{Status: domain.DeliverabilityCheckPass, Host: "example.com"},
+				DKIM:   domain.DeliverabilityCheck{Status: domain.DeliverabilityCheckFail, Host: "s1._domainkey.example.com"},
+				DMARC:  domain.DeliverabilityCheck{Status: domain.DeliverabilityCheckPass, Host: "_dmarc.example.com"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService, _, handler, _ := setupEmailHandlerTest(t)
+			tc.setupMock(mockService)
+
+			var reqBody []byte
+			if strBody, ok := tc.reqBody.(string); ok {
+				reqBody = []byte(strBody)
+			} else if tc.reqBody != nil {
+				var err error
+				reqBody, err = json.Marshal(tc.reqBody)
+				require.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(tc.method, "/api/integrations.checkDeliverability", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.handleCheckDeliverability(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedResp != nil {
+				var response domain.DeliverabilityReport
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+				assert.Equal(t, *tc.expectedResp, response)
+			}
+		})
+	}
+}
+
 func TestEmailHandler_HandleClickRedirection(t *testing.T) {
 	tests := []struct {
 		name               string
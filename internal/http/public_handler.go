@@ -133,14 +133,38 @@ func (h *NotificationCenterHandler) handleUpdatePreferences(w http.ResponseWrite
 		return
 	}
 
-	if err := h.service.UpdateContactPreferences(r.Context(), &req); err != nil {
-		if strings.Contains(err.Error(), "invalid email verification") {
-			WriteJSONError(w, "Unauthorized: invalid verification", http.StatusUnauthorized)
+	if req.HasProfileChanges() {
+		if err := h.service.UpdateContactPreferences(r.Context(), &req); err != nil {
+			h.writePreferencesError(w, err)
+			return
+		}
+	}
+
+	// List toggles go through the list service so statuses, double opt-in and
+	// timeline events behave exactly like the subscribe/unsubscribe endpoints
+	subscribeIDs, unsubscribeIDs := req.ListIDsBySubscription()
+	if len(subscribeIDs) > 0 {
+		subscribeReq := &domain.SubscribeToListsRequest{
+			WorkspaceID: req.WorkspaceID,
+			Contact:     domain.Contact{Email: req.Email, EmailHMAC: req.EmailHMAC},
+			ListIDs:     subscribeIDs,
+		}
+		if err := h.listService.SubscribeToLists(r.Context(), subscribeReq, false); err != nil {
+			h.writePreferencesError(w, err)
+			return
+		}
+	}
+	if len(unsubscribeIDs) > 0 {
+		unsubscribeReq := &domain.UnsubscribeFromListsRequest{
+			WorkspaceID: req.WorkspaceID,
+			Email:       req.Email,
+			EmailHMAC:   req.EmailHMAC,
+			ListIDs:     unsubscribeIDs,
+		}
+		if err := h.listService.UnsubscribeFromLists(r.Context(), unsubscribeReq, false); err != nil {
+			h.writePreferencesError(w, err)
 			return
 		}
-		h.logger.WithField("error", err.Error()).Error("Failed to update contact preferences")
-		WriteJSONError(w, "Failed to update contact preferences", http.StatusInternalServerError)
-		return
 	}
 
 	writeJSON(w, http.StatusOK, map[string]interface{}{
@@ -148,6 +172,19 @@ func (h *NotificationCenterHandler) handleUpdatePreferences(w http.ResponseWrite
 	})
 }
 
+func (h *NotificationCenterHandler) writePreferencesError(w http.ResponseWriter, err error) {
+	if strings.Contains(err.Error(), "invalid email verification") {
+		WriteJSONError(w, "Unauthorized: invalid verification", http.StatusUnauthorized)
+		return
+	}
+	if strings.Contains(err.Error(), "list not found") {
+		WriteJSONError(w, "List not found", http.StatusBadRequest)
+		return
+	}
+	h.logger.WithField("error", err.Error()).Error("Failed to update contact preferences")
+	WriteJSONError(w, "Failed to update contact preferences", http.StatusInternalServerError)
+}
+
 func (h *NotificationCenterHandler) handleSubscribe(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -143,6 +143,31 @@ func TestNotificationCenterHandler_handleNotificationCenter(t *testing.T) {
 			expectedStatusCode: http.StatusOK,
 			// We'll do a partial match for the response
 		},
+		{
+			name:        "signed token",
+			method:      http.MethodGet,
+			queryParams: "?token=" + domain.GeneratePreferencesToken("ws123", "test@example.com", "secret"),
+			setupMock: func() {
+				mockService.EXPECT().
+					GetContactPreferences(gomock.Any(), "ws123", "test@example.com", domain.ComputeEmailHMAC("test@example.com", "secret")).
+					Return(&domain.ContactPreferencesResponse{
+						Contact:      &domain.Contact{Email: "test@example.com"},
+						PublicLists:  []*domain.List{{ID: "list1", Name: "Public List"}},
+						ContactLists: []*domain.ContactList{{Email: "test@example.com", ListID: "list1"}},
+						LogoURL:      "https://example.com/logo.png",
+						WebsiteURL:   "https://example.com",
+					}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "malformed token",
+			method:             http.MethodGet,
+			queryParams:        "?token=malformed",
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"invalid token"}`,
+		},
 	}
 
 	for _, tc := range tests {
@@ -496,7 +521,17 @@ func TestNotificationCenterHandler_handleUpdatePreferences(t *testing.T) {
 			},
 			setupMock:          func() {},
 			expectedStatusCode: http.StatusBadRequest,
-			expectedResponse:   `{"error":"at least one of language or timezone must be provided"}`,
+			expectedResponse:   `{"error":"at least one of language, timezone or lists must be provided"}`,
+		},
+		{
+			name: "validation failure - invalid token",
+			requestBody: map[string]interface{}{
+				"token":    "invalid",
+				"language": "fr",
+			},
+			setupMock:          func() {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"invalid token"}`,
 		},
 		{
 			name: "service returns HMAC error",
@@ -547,6 +582,68 @@ func TestNotificationCenterHandler_handleUpdatePreferences(t *testing.T) {
 			expectedStatusCode: http.StatusOK,
 			expectedResponse:   `{"success":true}`,
 		},
+		{
+			name: "toggles lists with a token",
+			requestBody: domain.UpdateContactPreferencesRequest{
+				Token: domain.GeneratePreferencesToken("ws123", "test@example.com", "secret"),
+				Lists: []domain.ListPreference{
+					{ListID: "news", Subscribed: true},
+					{ListID: "promo", Subscribed: false},
+				},
+			},
+			setupMock: func() {
+				emailHMAC := domain.ComputeEmailHMAC("test@example.com", "secret")
+				mockListService.EXPECT().
+					SubscribeToLists(gomock.Any(), &domain.SubscribeToListsRequest{
+						WorkspaceID: "ws123",
+						Contact:     domain.Contact{Email: "test@example.com", EmailHMAC: emailHMAC},
+						ListIDs:     []string{"news"},
+					}, false).
+					Return(nil)
+				mockListService.EXPECT().
+					UnsubscribeFromLists(gomock.Any(), &domain.UnsubscribeFromListsRequest{
+						WorkspaceID: "ws123",
+						Email:       "test@example.com",
+						EmailHMAC:   emailHMAC,
+						ListIDs:     []string{"promo"},
+					}, false).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"success":true}`,
+		},
+		{
+			name: "list toggle with invalid HMAC",
+			requestBody: domain.UpdateContactPreferencesRequest{
+				WorkspaceID: "ws123",
+				Email:       "test@example.com",
+				EmailHMAC:   "invalid",
+				Lists:       []domain.ListPreference{{ListID: "promo", Subscribed: false}},
+			},
+			setupMock: func() {
+				mockListService.EXPECT().
+					UnsubscribeFromLists(gomock.Any(), gomock.Any(), false).
+					Return(errors.New("invalid email verification"))
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"error":"Unauthorized: invalid verification"}`,
+		},
+		{
+			name: "list toggle with unknown list",
+			requestBody: domain.UpdateContactPreferencesRequest{
+				WorkspaceID: "ws123",
+				Email:       "test@example.com",
+				EmailHMAC:   "valid",
+				Lists:       []domain.ListPreference{{ListID: "unknown", Subscribed: true}},
+			},
+			setupMock: func() {
+				mockListService.EXPECT().
+					SubscribeToLists(gomock.Any(), gomock.Any(), false).
+					Return(errors.New("list not found"))
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"List not found"}`,
+		},
 	}
 
 	for _, tc := range tests {
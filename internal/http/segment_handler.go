@@ -223,25 +223,15 @@ func (h *SegmentHandler) handlePreview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var req struct {
-		WorkspaceID string           `json:"workspace_id"`
-		Tree        *domain.TreeNode `json:"tree"`
-		Limit       int              `json:"limit,omitempty"`
-	}
-
+	var req domain.PreviewSegmentRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.logger.WithField("error", err.Error()).Error("Failed to decode request body")
 		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
 
-	if req.WorkspaceID == "" {
-		WriteJSONError(w, "workspace_id is required", http.StatusBadRequest)
-		return
-	}
-
-	if req.Tree == nil {
-		WriteJSONError(w, "tree is required", http.StatusBadRequest)
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -250,7 +240,7 @@ func (h *SegmentHandler) handlePreview(w http.ResponseWriter, r *http.Request) {
 		req.Limit = 10
 	}
 
-	response, err := h.service.PreviewSegment(r.Context(), req.WorkspaceID, req.Tree, req.Limit)
+	response, err := h.service.PreviewSegment(r.Context(), &req)
 	if err != nil {
 		h.logger.WithField("error", err.Error()).Error("Failed to preview segment")
 		WriteJSONError(w, "Failed to preview segment", http.StatusInternalServerError)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -828,6 +829,26 @@ func TestSegmentHandler_HandleRebuild(t *testing.T) {
 	}
 }
 
+// previewRequestMatcher matches a *domain.PreviewSegmentRequest on its scalar fields
+type previewRequestMatcher struct {
+	workspaceID string
+	segmentID   string
+	limit       int
+	offset      int
+}
+
+func (m previewRequestMatcher) Matches(x interface{}) bool {
+	req, ok := x.(*domain.PreviewSegmentRequest)
+	if !ok {
+		return false
+	}
+	return req.WorkspaceID == m.workspaceID && req.SegmentID == m.segmentID && req.Limit == m.limit && req.Offset == m.offset
+}
+
+func (m previewRequestMatcher) String() string {
+	return fmt.Sprintf("preview request for workspace %s (segment %q, limit %d, offset %d)", m.workspaceID, m.segmentID, m.limit, m.offset)
+}
+
 func TestSegmentHandler_HandlePreview(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -854,7 +875,7 @@ func TestSegmentHandler_HandlePreview(t *testing.T) {
 				"limit": 5,
 			},
 			setupMock: func(m *mocks.MockSegmentService) {
-				m.EXPECT().PreviewSegment(gomock.Any(), "workspace123", gomock.Any(), 5).Return(
+				m.EXPECT().PreviewSegment(gomock.Any(), previewRequestMatcher{workspaceID: "workspace123", limit: 5}).Return(
 					&domain.PreviewSegmentResponse{
 						Emails:       []string{"user1@example.com", "user2@example.com", "user3@example.com"},
 						TotalCount:   100,
@@ -890,7 +911,7 @@ func TestSegmentHandler_HandlePreview(t *testing.T) {
 				},
 			},
 			setupMock: func(m *mocks.MockSegmentService) {
-				m.EXPECT().PreviewSegment(gomock.Any(), "workspace123", gomock.Any(), 10).Return(
+				m.EXPECT().PreviewSegment(gomock.Any(), previewRequestMatcher{workspaceID: "workspace123", limit: 10}).Return(
 					&domain.PreviewSegmentResponse{
 						Emails:       []string{"user1@example.com"},
 						TotalCount:   1,
@@ -922,7 +943,7 @@ func TestSegmentHandler_HandlePreview(t *testing.T) {
 				},
 			},
 			setupMock: func(m *mocks.MockSegmentService) {
-				m.EXPECT().PreviewSegment(gomock.Any(), "workspace123", gomock.Any(), 10).Return(
+				m.EXPECT().PreviewSegment(gomock.Any(), previewRequestMatcher{workspaceID: "workspace123", limit: 10}).Return(
 					nil, errors.New("service error"),
 				)
 			},
@@ -948,6 +969,47 @@ func TestSegmentHandler_HandlePreview(t *testing.T) {
 			setupMock:      func(m *mocks.MockSegmentService) {},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name:   "Preview Existing Segment With Offset",
+			method: http.MethodPost,
+			requestBody: map[string]interface{}{
+				"workspace_id": "workspace123",
+				"segment_id":   "seg123",
+				"limit":        2,
+				"offset":       4,
+			},
+			setupMock: func(m *mocks.MockSegmentService) {
+				m.EXPECT().PreviewSegment(gomock.Any(), previewRequestMatcher{workspaceID: "workspace123", segmentID: "seg123", limit: 2, offset: 4}).Return(
+					&domain.PreviewSegmentResponse{
+						Emails:     []string{"user5@example.com", "user6@example.com"},
+						Contacts:   []*domain.Contact{{Email: "user5@example.com"}, {Email: "user6@example.com"}},
+						TotalCount: 6,
+						Limit:      2,
+						Offset:     4,
+					},
+					nil,
+				)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, response map[string]interface{}) {
+				contacts, ok := response["contacts"].([]interface{})
+				assert.True(t, ok)
+				assert.Len(t, contacts, 2)
+				assert.Equal(t, float64(6), response["total_count"])
+				assert.Equal(t, float64(4), response["offset"])
+			},
+		},
+		{
+			name:   "Tree And Segment ID Together",
+			method: http.MethodPost,
+			requestBody: map[string]interface{}{
+				"workspace_id": "workspace123",
+				"segment_id":   "seg123",
+				"tree":         map[string]interface{}{"kind": "leaf"},
+			},
+			setupMock:      func(m *mocks.MockSegmentService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:           "Method Not Allowed",
 			method:         http.MethodGet,
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/http/middleware"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+type SuppressionHandler struct {
+	service      domain.SuppressionService
+	logger       logger.Logger
+	getJWTSecret func() ([]byte, error)
+}
+
+func NewSuppressionHandler(service domain.SuppressionService, getJWTSecret func() ([]byte, error), logger logger.Logger) *SuppressionHandler {
+	return &SuppressionHandler{
+		service:      service,
+		getJWTSecret: getJWTSecret,
+		logger:       logger,
+	}
+}
+
+// RegisterRoutes registers the suppression HTTP endpoints
+func (h *SuppressionHandler) RegisterRoutes(mux *http.ServeMux) {
+	// Create auth middleware
+	authMiddleware := middleware.NewAuthMiddleware(h.getJWTSecret)
+	requireAuth := authMiddleware.RequireAuth()
+
+	// Register RPC-style endpoints with dot notation
+	mux.Handle("/api/suppressions.import", requireAuth(http.HandlerFunc(h.ImportSuppressions)))
+}
+
+// POST /api/suppressions.import - upserts addresses (array or CSV) into the suppression list
+func (h *SuppressionHandler) ImportSuppressions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.ImportSuppressionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to decode request")
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.ImportSuppressions(r.Context(), &req)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to import suppressions")
+		if _, ok := err.(*domain.PermissionError); ok {
+			WriteJSONError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if _, ok := err.(domain.ValidationError); ok {
+			WriteJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		WriteJSONError(w, "Failed to import suppressions", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
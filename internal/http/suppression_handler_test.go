@@ -0,0 +1,140 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupSuppressionHandlerTest(t *testing.T) (*mocks.MockSuppressionService, *SuppressionHandler) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(func() { ctrl.Finish() })
+
+	mockService := mocks.NewMockSuppressionService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	jwtSecret := []byte("test-jwt-secret-key-for-testing-32bytes")
+	handler := NewSuppressionHandler(mockService, func() ([]byte, error) { return jwtSecret, nil }, mockLogger)
+	return mockService, handler
+}
+
+func TestSuppressionHandler_RegisterRoutes(t *testing.T) {
+	_, handler := setupSuppressionHandlerTest(t)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	h, pattern := mux.Handler(&http.Request{URL: &url.URL{Path: "/api/suppressions.import"}})
+	assert.NotNil(t, h)
+	assert.Equal(t, "/api/suppressions.import", pattern)
+}
+
+func TestSuppressionHandler_ImportSuppressions(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		requestBody    interface{}
+		setupMock      func(*mocks.MockSuppressionService)
+		expectedStatus int
+		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Success",
+			method: http.MethodPost,
+			requestBody: domain.ImportSuppressionsRequest{
+				WorkspaceID: "workspace123",
+				CSV:         "a@example.com,bounce\nbad",
+			},
+			setupMock: func(m *mocks.MockSuppressionService) {
+				m.EXPECT().ImportSuppressions(gomock.Any(), gomock.Any()).Return(&domain.ImportSuppressionsResponse{
+					Imported: 1,
+					Failed:   []domain.SuppressionImportError{{Email: "bad", Error: "invalid email format: bad"}},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var response domain.ImportSuppressionsResponse
+				assert.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+				assert.Equal(t, 1, response.Imported)
+				assert.Len(t, response.Failed, 1)
+			},
+		},
+		{
+			name:           "Method not allowed",
+			method:         http.MethodGet,
+			setupMock:      func(m *mocks.MockSuppressionService) {},
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "Invalid JSON",
+			method:         http.MethodPost,
+			requestBody:    "invalid json",
+			setupMock:      func(m *mocks.MockSuppressionService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Validation error",
+			method:      http.MethodPost,
+			requestBody: domain.ImportSuppressionsRequest{WorkspaceID: "workspace123"},
+			setupMock: func(m *mocks.MockSuppressionService) {
+				m.EXPECT().ImportSuppressions(gomock.Any(), gomock.Any()).
+					Return(nil, domain.NewValidationError("invalid request: suppressions array cannot be empty"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Permission error",
+			method:      http.MethodPost,
+			requestBody: domain.ImportSuppressionsRequest{WorkspaceID: "workspace123"},
+			setupMock: func(m *mocks.MockSuppressionService) {
+				m.EXPECT().ImportSuppressions(gomock.Any(), gomock.Any()).
+					Return(nil, domain.NewPermissionError(domain.PermissionResourceContacts, domain.PermissionTypeWrite, "denied"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:        "Service error",
+			method:      http.MethodPost,
+			requestBody: domain.ImportSuppressionsRequest{WorkspaceID: "workspace123"},
+			setupMock: func(m *mocks.MockSuppressionService) {
+				m.EXPECT().ImportSuppressions(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService, handler := setupSuppressionHandlerTest(t)
+			tc.setupMock(mockService)
+
+			var body []byte
+			if s, ok := tc.requestBody.(string); ok {
+				body = []byte(s)
+			} else if tc.requestBody != nil {
+				body, _ = json.Marshal(tc.requestBody)
+			}
+
+			req := httptest.NewRequest(tc.method, "/api/suppressions.import", bytes.NewBuffer(body))
+			rr := httptest.NewRecorder()
+			handler.ImportSuppressions(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.checkResponse != nil {
+				tc.checkResponse(t, rr)
+			}
+		})
+	}
+}
@@ -48,6 +48,7 @@ func (h *TransactionalNotificationHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/api/transactional.update", requireAuth(http.HandlerFunc(h.handleUpdate)))
 	mux.Handle("/api/transactional.delete", requireAuth(http.HandlerFunc(h.handleDelete)))
 	mux.Handle("/api/transactional.send", restrictedInDemo(requireAuth(http.HandlerFunc(h.handleSend))))
+	mux.Handle("/api/transactional.sendTemplate", restrictedInDemo(requireAuth(http.HandlerFunc(h.handleSendTemplate))))
 	mux.Handle("/api/transactional.testTemplate", restrictedInDemo(requireAuth(http.HandlerFunc(h.handleTestTemplate))))
 }
 
@@ -257,6 +258,46 @@ func (h *TransactionalNotificationHandler) handleSend(w http.ResponseWriter, r *
 	})
 }
 
+// handleSendTemplate handles requests to send a transactional email directly from a template
+func (h *TransactionalNotificationHandler) handleSendTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.SendTransactionalTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to decode request body")
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	messageID, err := h.service.SendTemplate(r.Context(), req.WorkspaceID, req.TransactionalTemplateSendParams)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to send transactional template")
+
+		if strings.Contains(err.Error(), "not found") ||
+			strings.Contains(err.Error(), "not an email provider") ||
+			strings.Contains(err.Error(), "no email provider configured") {
+			WriteJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		WriteJSONError(w, "Failed to send email", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message_id": messageID,
+		"success":    true,
+	})
+}
+
 // handleTestTemplate handles requests to test a template
 func (h *TransactionalNotificationHandler) handleTestTemplate(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -98,6 +98,7 @@ func TestTransactionalNotificationHandler_RegisterRoutes(t *testing.T) {
 		"/api/transactional.update",
 		"/api/transactional.delete",
 		"/api/transactional.send",
+		"/api/transactional.sendTemplate",
 	}
 
 	// Make requests to verify routes are registered
@@ -1153,6 +1154,166 @@ func TestTransactionalNotificationHandler_HandleSend(t *testing.T) {
 	}
 }
 
+func TestTransactionalNotificationHandler_HandleSendTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTransactionalNotificationService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	jwtSecret := []byte("test-jwt-secret-key-for-testing-32bytes")
+	handler := NewTransactionalNotificationHandler(mockService, func() ([]byte, error) { return jwtSecret, nil }, mockLogger, false)
+
+	workspaceID := "workspace1"
+
+	validReqBody := domain.SendTransactionalTemplateRequest{
+		WorkspaceID: workspaceID,
+		TransactionalTemplateSendParams: domain.TransactionalTemplateSendParams{
+			To:         "test@example.com",
+			TemplateID: "password-reset",
+			Data: domain.MapOfAny{
+				"reset_url": "https://example.com/reset",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		method         string
+		requestBody    interface{}
+		setupMock      func()
+		expectedStatus int
+		checkResponse  func(t *testing.T, response map[string]interface{})
+	}{
+		{
+			name:           "method not allowed",
+			method:         http.MethodGet,
+			requestBody:    nil,
+			setupMock:      func() {},
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:        "invalid request body",
+			method:      http.MethodPost,
+			requestBody: "invalid json",
+			setupMock: func() {
+				mockLogger.EXPECT().
+					WithField(gomock.Eq("error"), gomock.Any()).
+					Return(mockLogger)
+				mockLogger.EXPECT().
+					Error(gomock.Eq("Failed to decode request body"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "missing template_id",
+			method: http.MethodPost,
+			requestBody: domain.SendTransactionalTemplateRequest{
+				WorkspaceID: workspaceID,
+				TransactionalTemplateSendParams: domain.TransactionalTemplateSendParams{
+					To: "test@example.com",
+				},
+			},
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid recipient",
+			method: http.MethodPost,
+			requestBody: domain.SendTransactionalTemplateRequest{
+				WorkspaceID: workspaceID,
+				TransactionalTemplateSendParams: domain.TransactionalTemplateSendParams{
+					To:         "not-an-email",
+					TemplateID: "password-reset",
+				},
+			},
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "successful send",
+			method:      http.MethodPost,
+			requestBody: validReqBody,
+			setupMock: func() {
+				mockService.EXPECT().
+					SendTemplate(gomock.Any(), gomock.Eq(workspaceID), gomock.Eq(validReqBody.TransactionalTemplateSendParams)).
+					Return("message-123", nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, response map[string]interface{}) {
+				assert.Equal(t, "message-123", response["message_id"])
+				assert.Equal(t, true, response["success"])
+			},
+		},
+		{
+			name:        "template not found",
+			method:      http.MethodPost,
+			requestBody: validReqBody,
+			setupMock: func() {
+				mockService.EXPECT().
+					SendTemplate(gomock.Any(), gomock.Eq(workspaceID), gomock.Any()).
+					Return("", errors.New("template not found: no rows"))
+
+				mockLogger.EXPECT().
+					WithField(gomock.Eq("error"), gomock.Any()).
+					Return(mockLogger)
+				mockLogger.EXPECT().
+					Error(gomock.Eq("Failed to send transactional template"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "service error",
+			method:      http.MethodPost,
+			requestBody: validReqBody,
+			setupMock: func() {
+				mockService.EXPECT().
+					SendTemplate(gomock.Any(), gomock.Eq(workspaceID), gomock.Any()).
+					Return("", errors.New("failed to enqueue email"))
+
+				mockLogger.EXPECT().
+					WithField(gomock.Eq("error"), gomock.Any()).
+					Return(mockLogger)
+				mockLogger.EXPECT().
+					Error(gomock.Eq("Failed to send transactional template"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setupMock()
+
+			var reqBody []byte
+			var err error
+
+			switch body := tc.requestBody.(type) {
+			case string:
+				reqBody = []byte(body)
+			default:
+				reqBody, err = json.Marshal(body)
+				require.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(tc.method, "/api/transactional.sendTemplate", bytes.NewBuffer(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.handleSendTemplate(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+
+			if tc.expectedStatus == http.StatusOK && tc.checkResponse != nil {
+				var response map[string]interface{}
+				err := json.NewDecoder(w.Body).Decode(&response)
+				require.NoError(t, err)
+				tc.checkResponse(t, response)
+			}
+		})
+	}
+}
+
 func TestTransactionalNotificationHandler_HandleTestTemplate(t *testing.T) {
 	// Create a mock controller for the entire test function
 	ctrl := gomock.NewController(t)
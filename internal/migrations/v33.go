@@ -0,0 +1,516 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// V33Migration adds tags and workflow versioning to automations.
+//
+// A new `tags` JSONB column on the workspace `automations` table stores a list
+// of free-form labels used to organize and filter automations. A GIN index
+// backs the `tags @> '["tag"]'` containment filter used by automations.list.
+//
+// Automations get a `version` column and an `automation_versions` table holding a
+// snapshot of each workflow version. Enrollments are pinned to the version current
+// when they enter (`contact_automations.automation_version`) so editing a live
+// automation does not change the path of contacts already in flight. Existing
+// automations are backfilled as version 1 and active contacts pinned to it.
+//
+// Enrollments triggered by a message event keep the broadcast context: the enroll
+// function takes the message ID and stores the broadcast ID and its global feed data in
+// `contact_automations.context`. Existing trigger functions keep calling it with 4
+// arguments and pick up the message ID once the automation is saved again.
+//
+// Contacts get an `engagement_score` maintained by triggers on message_history
+// (opens, clicks) and custom_events, decayed with a 30-day half-life. The score is
+// backfilled from the last 180 days of activity, older events having decayed below 2%.
+//
+// An `email_queue_dedup` table records the (contact, template, dedupe key) of each
+// queued send so the same logical message enqueued twice within the configured
+// window is dropped instead of being delivered twice.
+//
+// An `automation_revenue` table stores the conversion values recorded by
+// record_revenue nodes; their per-currency totals are kept in `automations.stats`.
+//
+// A `suppressions` table holds workspace-wide suppressed addresses (e.g. imported
+// from a previous ESP). Broadcasts skip these addresses regardless of list status.
+//
+// A `log_webhooks` flag on automations records the request/response exchange of
+// their webhook nodes in the node execution output, for auditing.
+//
+// An `enrollment_ramp` setting on automations limits how many contacts enter the
+// workflow per minute during the first minutes after activation.
+//
+// A free-form `metadata` map on automations stores annotations for external
+// systems and is sent along in webhook node payloads.
+//
+// A `retry_backoff` setting on automations configures the exponential backoff
+// between retries of a failed node.
+//
+// A `messaging_hold_until` column on contacts lets integrations pause broadcast and
+// automation emails to a contact; the email queue worker skips them until it passes.
+//
+// An `automation_throttle_windows` table holds the per-minute counter of each throttle
+// node, shared by all scheduler instances through row locking.
+//
+// A partial index on `contact_automations(automation_id, scheduled_at)` lets the
+// scheduler read the next due contacts of each automation without sorting its backlog.
+type V33Migration struct{}
+
+func (m *V33Migration) GetMajorVersion() float64 {
+	return 33.0
+}
+
+func (m *V33Migration) HasSystemUpdate() bool {
+	return false
+}
+
+func (m *V33Migration) HasWorkspaceUpdate() bool {
+	return true
+}
+
+func (m *V33Migration) ShouldRestartServer() bool {
+	return false
+}
+
+func (m *V33Migration) UpdateSystem(ctx context.Context, cfg *config.Config, db DBExecutor) error {
+	return nil
+}
+
+func (m *V33Migration) UpdateWorkspace(ctx context.Context, cfg *config.Config, workspace *domain.Workspace, db DBExecutor) error {
+	_, err := db.ExecContext(ctx, `
+		ALTER TABLE automations
+		ADD COLUMN IF NOT EXISTS tags JSONB DEFAULT '[]'
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add tags column to automations table for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_automations_tags ON automations USING GIN (tags)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create automations tags index for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE automations
+		ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add version column to automations table for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS automation_versions (
+			automation_id VARCHAR(36) NOT NULL REFERENCES automations(id),
+			version INTEGER NOT NULL,
+			root_node_id VARCHAR(36),
+			nodes JSONB DEFAULT '[]',
+			created_at TIMESTAMPTZ DEFAULT NOW(),
+			PRIMARY KEY (automation_id, version)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create automation_versions table for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO automation_versions (automation_id, version, root_node_id, nodes, created_at)
+		SELECT id, version, root_node_id, COALESCE(nodes, '[]'::jsonb), NOW()
+		FROM automations
+		ON CONFLICT (automation_id, version) DO NOTHING
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to backfill automation versions for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE contact_automations
+		ADD COLUMN IF NOT EXISTS automation_version INTEGER
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add automation_version column to contact_automations table for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		UPDATE contact_automations ca
+		SET automation_version = a.version
+		FROM automations a
+		WHERE ca.automation_id = a.id
+		AND ca.automation_version IS NULL
+		AND ca.status = 'active'
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to pin active contact automations for workspace %s: %w", workspace.ID, err)
+	}
+
+	// The enroll function gains an optional message ID parameter. The 4-parameter version is
+	// dropped first so existing trigger functions resolve to the new one instead of an overload.
+	_, err = db.ExecContext(ctx, `
+		DROP FUNCTION IF EXISTS automation_enroll_contact(VARCHAR, VARCHAR, VARCHAR, VARCHAR)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to drop previous automation_enroll_contact function for workspace %s: %w", workspace.ID, err)
+	}
+
+	// Enrollments record the workflow version they start on, and the broadcast context
+	// (broadcast_id, global_feed) when triggered by a broadcast message event
+	_, err = db.ExecContext(ctx, `
+		CREATE OR REPLACE FUNCTION automation_enroll_contact(
+			p_automation_id VARCHAR(36),
+			p_contact_email VARCHAR(255),
+			p_root_node_id VARCHAR(36),
+			p_frequency VARCHAR(20),
+			p_message_id VARCHAR(255) DEFAULT NULL
+		) RETURNS VOID AS $$
+		DECLARE
+			v_already_triggered BOOLEAN;
+			v_new_id VARCHAR(36);
+			v_version INTEGER;
+			v_root_node_id VARCHAR(36);
+			v_broadcast_id VARCHAR(255);
+			v_global_feed JSONB;
+			v_context JSONB := '{}'::jsonb;
+		BEGIN
+			-- 1. For "once" frequency, check if already triggered
+			IF p_frequency = 'once' THEN
+				SELECT EXISTS(
+					SELECT 1 FROM automation_trigger_log
+					WHERE automation_id = p_automation_id
+					AND contact_email = p_contact_email
+				) INTO v_already_triggered;
+
+				IF v_already_triggered THEN
+					RETURN;  -- Already triggered for this contact, skip
+				END IF;
+
+				-- Record trigger for deduplication
+				INSERT INTO automation_trigger_log (id, automation_id, contact_email, triggered_at)
+				VALUES (gen_random_uuid()::text, p_automation_id, p_contact_email, NOW())
+				ON CONFLICT (automation_id, contact_email) DO NOTHING;
+			END IF;
+
+			-- 2. Generate new ID for contact_automation
+			v_new_id := gen_random_uuid()::text;
+
+			-- Pin the enrollment to the current workflow version and start at its root node
+			-- (the root may have changed since the trigger was created)
+			SELECT version, COALESCE(NULLIF(root_node_id, ''), p_root_node_id)
+			INTO v_version, v_root_node_id
+			FROM automations WHERE id = p_automation_id;
+
+			-- Keep the broadcast context of message events (e.g. a broadcast email being
+			-- opened) so downstream nodes render the same global feed as the broadcast
+			IF p_message_id IS NOT NULL THEN
+				SELECT mh.broadcast_id, b.data_feed->'global_feed_data'
+				INTO v_broadcast_id, v_global_feed
+				FROM message_history mh
+				LEFT JOIN broadcasts b ON b.id = mh.broadcast_id
+				WHERE mh.id = p_message_id;
+
+				IF v_broadcast_id IS NOT NULL THEN
+					v_context := jsonb_build_object('broadcast_id', v_broadcast_id);
+					IF jsonb_typeof(v_global_feed) = 'object' THEN
+						v_context := v_context || jsonb_build_object('global_feed', v_global_feed);
+					END IF;
+				END IF;
+			END IF;
+
+			-- 3. Enroll contact in automation, pinned to the current workflow version
+			INSERT INTO contact_automations (
+				id, automation_id, automation_version, contact_email, current_node_id,
+				status, entered_at, scheduled_at, context
+			) VALUES (
+				v_new_id,
+				p_automation_id,
+				v_version,
+				p_contact_email,
+				v_root_node_id,
+				'active',
+				NOW(),
+				NOW(),
+				v_context
+			);
+
+			-- 4. Increment enrolled stat
+			UPDATE automations
+			SET stats = jsonb_set(
+				COALESCE(stats, '{}'::jsonb),
+				'{enrolled}',
+				to_jsonb(COALESCE((stats->>'enrolled')::int, 0) + 1)
+			),
+			updated_at = NOW()
+			WHERE id = p_automation_id;
+
+			-- 5. Log node execution entry
+			INSERT INTO automation_node_executions (
+				id, contact_automation_id, automation_id, node_id, node_type, action, entered_at, output
+			) VALUES (
+				gen_random_uuid()::text,
+				v_new_id,
+				p_automation_id,
+				v_root_node_id,
+				'trigger',
+				'entered',
+				NOW(),
+				'{}'::jsonb
+			);
+
+			-- 6. Create automation.start timeline event
+			INSERT INTO contact_timeline (email, operation, entity_type, kind, entity_id, changes, created_at)
+			VALUES (
+				p_contact_email,
+				'insert',
+				'automation',
+				'automation.start',
+				p_automation_id,
+				jsonb_build_object(
+					'automation_id', jsonb_build_object('new', p_automation_id),
+					'root_node_id', jsonb_build_object('new', v_root_node_id)
+				),
+				NOW()
+			);
+
+		END;
+		$$ LANGUAGE plpgsql
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to update automation_enroll_contact function for workspace %s: %w", workspace.ID, err)
+	}
+
+	if err := m.addEngagementScore(ctx, workspace, db); err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS email_queue_dedup (
+			contact_email VARCHAR(255) NOT NULL,
+			template_id VARCHAR(36) NOT NULL,
+			dedupe_key VARCHAR(255) NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (contact_email, template_id, dedupe_key)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create email_queue_dedup table for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_email_queue_dedup_created_at ON email_queue_dedup(created_at)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create email_queue_dedup index for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS automation_revenue (
+			id VARCHAR(36) PRIMARY KEY,
+			automation_id VARCHAR(36) NOT NULL REFERENCES automations(id),
+			contact_automation_id VARCHAR(36) NOT NULL,
+			node_id VARCHAR(36) NOT NULL,
+			contact_email VARCHAR(255) NOT NULL,
+			amount NUMERIC(18, 4) NOT NULL,
+			currency VARCHAR(3) NOT NULL,
+			recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create automation_revenue table for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_automation_revenue_automation ON automation_revenue(automation_id, recorded_at DESC)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create automation_revenue index for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS suppressions (
+			email VARCHAR(255) PRIMARY KEY,
+			reason VARCHAR(20) NOT NULL DEFAULT 'manual',
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create suppressions table for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE automations
+		ADD COLUMN IF NOT EXISTS log_webhooks BOOLEAN NOT NULL DEFAULT false
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add log_webhooks column to automations table for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE automations
+		ADD COLUMN IF NOT EXISTS enrollment_ramp JSONB
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add enrollment_ramp column to automations table for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE automations
+		ADD COLUMN IF NOT EXISTS metadata JSONB
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add metadata column to automations table for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE automations
+		ADD COLUMN IF NOT EXISTS retry_backoff JSONB
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add retry_backoff column to automations table for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE contacts
+		ADD COLUMN IF NOT EXISTS messaging_hold_until TIMESTAMPTZ
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add messaging_hold_until column to contacts table for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS automation_throttle_windows (
+			automation_id VARCHAR(36) NOT NULL,
+			node_id VARCHAR(36) NOT NULL,
+			window_start TIMESTAMPTZ NOT NULL,
+			count INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (automation_id, node_id)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create automation_throttle_windows table for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_contact_automations_automation_scheduled
+		ON contact_automations(automation_id, scheduled_at) WHERE status = 'active'
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create contact_automations scheduled index for workspace %s: %w", workspace.ID, err)
+	}
+
+	return nil
+}
+
+// addEngagementScore adds the contact engagement score columns, the triggers
+// maintaining them and backfills scores from recent activity
+func (m *V33Migration) addEngagementScore(ctx context.Context, workspace *domain.Workspace, db DBExecutor) error {
+	_, err := db.ExecContext(ctx, `
+		ALTER TABLE contacts
+		ADD COLUMN IF NOT EXISTS engagement_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+		ADD COLUMN IF NOT EXISTS engagement_score_at TIMESTAMPTZ
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add engagement score columns to contacts table for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE OR REPLACE FUNCTION add_contact_engagement(p_email VARCHAR(255), p_points DOUBLE PRECISION, p_at TIMESTAMPTZ)
+		RETURNS VOID AS $$
+		BEGIN
+			UPDATE contacts SET
+				engagement_score = CASE
+					WHEN engagement_score_at IS NULL THEN p_points
+					-- Decay the stored score up to the new event, then add its points
+					WHEN p_at >= engagement_score_at THEN
+						engagement_score * POWER(0.5::float8, (EXTRACT(EPOCH FROM (p_at - engagement_score_at)) / 86400 / 30)::float8) + p_points
+					-- Late events are decayed to the stored score's timestamp instead
+					ELSE
+						engagement_score + p_points * POWER(0.5::float8, (EXTRACT(EPOCH FROM (engagement_score_at - p_at)) / 86400 / 30)::float8)
+				END,
+				engagement_score_at = GREATEST(COALESCE(engagement_score_at, p_at), p_at)
+			WHERE email = p_email;
+		END;
+		$$ LANGUAGE plpgsql
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create add_contact_engagement function for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE OR REPLACE FUNCTION track_contact_engagement()
+		RETURNS TRIGGER AS $$
+		BEGIN
+			IF TG_TABLE_NAME = 'message_history' THEN
+				IF TG_OP = 'INSERT' THEN
+					IF NEW.opened_at IS NOT NULL THEN
+						PERFORM add_contact_engagement(NEW.contact_email, 1, NEW.opened_at);
+					END IF;
+					IF NEW.clicked_at IS NOT NULL THEN
+						PERFORM add_contact_engagement(NEW.contact_email, 3, NEW.clicked_at);
+					END IF;
+				ELSE
+					IF NEW.opened_at IS NOT NULL AND OLD.opened_at IS NULL THEN
+						PERFORM add_contact_engagement(NEW.contact_email, 1, NEW.opened_at);
+					END IF;
+					IF NEW.clicked_at IS NOT NULL AND OLD.clicked_at IS NULL THEN
+						PERFORM add_contact_engagement(NEW.contact_email, 3, NEW.clicked_at);
+					END IF;
+				END IF;
+			ELSIF TG_TABLE_NAME = 'custom_events' AND NEW.deleted_at IS NULL THEN
+				PERFORM add_contact_engagement(NEW.email, 2, NEW.occurred_at);
+			END IF;
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create track_contact_engagement function for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		DROP TRIGGER IF EXISTS message_history_engagement_trigger ON message_history;
+		CREATE TRIGGER message_history_engagement_trigger AFTER INSERT OR UPDATE ON message_history
+		FOR EACH ROW EXECUTE FUNCTION track_contact_engagement();
+
+		DROP TRIGGER IF EXISTS custom_event_engagement_trigger ON custom_events;
+		CREATE TRIGGER custom_event_engagement_trigger AFTER INSERT ON custom_events
+		FOR EACH ROW EXECUTE FUNCTION track_contact_engagement();
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create engagement score triggers for workspace %s: %w", workspace.ID, err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		UPDATE contacts c
+		SET engagement_score = s.score, engagement_score_at = NOW()
+		FROM (
+			SELECT email, SUM(points * POWER(0.5::float8, (EXTRACT(EPOCH FROM (NOW() - at)) / 86400 / 30)::float8)) AS score
+			FROM (
+				SELECT contact_email AS email, 1 AS points, opened_at AS at FROM message_history
+				WHERE opened_at > NOW() - INTERVAL '180 days'
+				UNION ALL
+				SELECT contact_email, 3, clicked_at FROM message_history
+				WHERE clicked_at > NOW() - INTERVAL '180 days'
+				UNION ALL
+				SELECT email, 2, occurred_at FROM custom_events
+				WHERE deleted_at IS NULL AND occurred_at > NOW() - INTERVAL '180 days'
+			) events
+			GROUP BY email
+		) s
+		WHERE c.email = s.email AND c.engagement_score_at IS NULL
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to backfill contact engagement scores for workspace %s: %w", workspace.ID, err)
+	}
+
+	return nil
+}
+
+func init() {
+	Register(&V33Migration{})
+}
@@ -0,0 +1,197 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+func TestV33Migration_GetMajorVersion(t *testing.T) {
+	m := &V33Migration{}
+	assert.Equal(t, 33.0, m.GetMajorVersion())
+}
+
+func TestV33Migration_HasSystemUpdate(t *testing.T) {
+	m := &V33Migration{}
+	assert.False(t, m.HasSystemUpdate())
+}
+
+func TestV33Migration_HasWorkspaceUpdate(t *testing.T) {
+	m := &V33Migration{}
+	assert.True(t, m.HasWorkspaceUpdate())
+}
+
+func TestV33Migration_ShouldRestartServer(t *testing.T) {
+	m := &V33Migration{}
+	assert.False(t, m.ShouldRestartServer())
+}
+
+func TestV33Migration_UpdateSystem_NoOp(t *testing.T) {
+	m := &V33Migration{}
+	assert.NoError(t, m.UpdateSystem(context.Background(), &config.Config{}, nil))
+}
+
+func TestV33Migration_UpdateWorkspace_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(`ALTER TABLE automations\s+ADD COLUMN IF NOT EXISTS tags JSONB`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE INDEX IF NOT EXISTS idx_automations_tags`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`ALTER TABLE automations\s+ADD COLUMN IF NOT EXISTS version INTEGER`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS automation_versions`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO automation_versions`).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`ALTER TABLE contact_automations\s+ADD COLUMN IF NOT EXISTS automation_version INTEGER`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE contact_automations ca\s+SET automation_version = a.version`).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectExec(`DROP FUNCTION IF EXISTS automation_enroll_contact\(VARCHAR, VARCHAR, VARCHAR, VARCHAR\)`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE OR REPLACE FUNCTION automation_enroll_contact`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`ALTER TABLE contacts\s+ADD COLUMN IF NOT EXISTS engagement_score`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE OR REPLACE FUNCTION add_contact_engagement`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE OR REPLACE FUNCTION track_contact_engagement`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DROP TRIGGER IF EXISTS message_history_engagement_trigger`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE contacts c\s+SET engagement_score`).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS email_queue_dedup`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE INDEX IF NOT EXISTS idx_email_queue_dedup_created_at`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS automation_revenue`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE INDEX IF NOT EXISTS idx_automation_revenue_automation`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS suppressions`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`ALTER TABLE automations\s+ADD COLUMN IF NOT EXISTS log_webhooks BOOLEAN`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`ALTER TABLE automations\s+ADD COLUMN IF NOT EXISTS enrollment_ramp JSONB`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`ALTER TABLE automations\s+ADD COLUMN IF NOT EXISTS metadata JSONB`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`ALTER TABLE automations\s+ADD COLUMN IF NOT EXISTS retry_backoff JSONB`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`ALTER TABLE contacts\s+ADD COLUMN IF NOT EXISTS messaging_hold_until TIMESTAMPTZ`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS automation_throttle_windows`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE INDEX IF NOT EXISTS idx_contact_automations_automation_scheduled`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	m := &V33Migration{}
+	err = m.UpdateWorkspace(context.Background(), &config.Config{},
+		&domain.Workspace{ID: "ws_test"}, db)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestV33Migration_UpdateWorkspace_ColumnError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(`ALTER TABLE automations`).WillReturnError(assert.AnError)
+
+	m := &V33Migration{}
+	err = m.UpdateWorkspace(context.Background(), &config.Config{},
+		&domain.Workspace{ID: "ws_test"}, db)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to add tags column to automations table")
+	assert.Contains(t, err.Error(), "ws_test")
+}
+
+func TestV33Migration_UpdateWorkspace_IndexError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(`ALTER TABLE automations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE INDEX IF NOT EXISTS idx_automations_tags`).WillReturnError(assert.AnError)
+
+	m := &V33Migration{}
+	err = m.UpdateWorkspace(context.Background(), &config.Config{},
+		&domain.Workspace{ID: "ws_test"}, db)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create automations tags index")
+}
+
+func TestV33Migration_UpdateWorkspace_StepErrors(t *testing.T) {
+	steps := []struct {
+		pattern string
+		message string
+	}{
+		{`ALTER TABLE automations\s+ADD COLUMN IF NOT EXISTS version`, "failed to add version column to automations table"},
+		{`CREATE TABLE IF NOT EXISTS automation_versions`, "failed to create automation_versions table"},
+		{`INSERT INTO automation_versions`, "failed to backfill automation versions"},
+		{`ALTER TABLE contact_automations`, "failed to add automation_version column to contact_automations table"},
+		{`UPDATE contact_automations`, "failed to pin active contact automations"},
+		{`DROP FUNCTION IF EXISTS automation_enroll_contact`, "failed to drop previous automation_enroll_contact function"},
+		{`CREATE OR REPLACE FUNCTION automation_enroll_contact`, "failed to update automation_enroll_contact function"},
+		{`ALTER TABLE contacts`, "failed to add engagement score columns to contacts table"},
+		{`CREATE OR REPLACE FUNCTION add_contact_engagement`, "failed to create add_contact_engagement function"},
+		{`CREATE OR REPLACE FUNCTION track_contact_engagement`, "failed to create track_contact_engagement function"},
+		{`DROP TRIGGER IF EXISTS message_history_engagement_trigger`, "failed to create engagement score triggers"},
+		{`UPDATE contacts c`, "failed to backfill contact engagement scores"},
+		{`CREATE TABLE IF NOT EXISTS email_queue_dedup`, "failed to create email_queue_dedup table"},
+		{`CREATE INDEX IF NOT EXISTS idx_email_queue_dedup_created_at`, "failed to create email_queue_dedup index"},
+		{`CREATE TABLE IF NOT EXISTS automation_revenue`, "failed to create automation_revenue table"},
+		{`CREATE INDEX IF NOT EXISTS idx_automation_revenue_automation`, "failed to create automation_revenue index"},
+		{`CREATE TABLE IF NOT EXISTS suppressions`, "failed to create suppressions table"},
+		{`ALTER TABLE automations\s+ADD COLUMN IF NOT EXISTS log_webhooks`, "failed to add log_webhooks column to automations table"},
+		{`ALTER TABLE automations\s+ADD COLUMN IF NOT EXISTS enrollment_ramp`, "failed to add enrollment_ramp column to automations table"},
+		{`ALTER TABLE automations\s+ADD COLUMN IF NOT EXISTS metadata`, "failed to add metadata column to automations table"},
+		{`ALTER TABLE automations\s+ADD COLUMN IF NOT EXISTS retry_backoff`, "failed to add retry_backoff column to automations table"},
+		{`ALTER TABLE contacts\s+ADD COLUMN IF NOT EXISTS messaging_hold_until`, "failed to add messaging_hold_until column to contacts table"},
+		{`CREATE TABLE IF NOT EXISTS automation_throttle_windows`, "failed to create automation_throttle_windows table"},
+		{`CREATE INDEX IF NOT EXISTS idx_contact_automations_automation_scheduled`, "failed to create contact_automations scheduled index"},
+	}
+
+	for failing, step := range steps {
+		t.Run(step.message, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec(`ALTER TABLE automations\s+ADD COLUMN IF NOT EXISTS tags`).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(`CREATE INDEX IF NOT EXISTS idx_automations_tags`).WillReturnResult(sqlmock.NewResult(0, 0))
+			for i := 0; i < failing; i++ {
+				mock.ExpectExec(steps[i].pattern).WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+			mock.ExpectExec(step.pattern).WillReturnError(assert.AnError)
+
+			m := &V33Migration{}
+			err = m.UpdateWorkspace(context.Background(), &config.Config{},
+				&domain.Workspace{ID: "ws_test"}, db)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), step.message)
+			assert.Contains(t, err.Error(), "ws_test")
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestV33Migration_Registered(t *testing.T) {
+	for _, m := range GetRegisteredMigrations() {
+		if m.GetMajorVersion() == 33.0 {
+			return
+		}
+	}
+	t.Fatal("V33Migration not registered")
+}
@@ -11,6 +11,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/service"
+	"github.com/lib/pq"
 )
 
 // AutomationRepository implements domain.AutomationRepository
@@ -85,47 +86,144 @@ func (r *AutomationRepository) CreateTx(ctx context.Context, tx *sql.Tx, workspa
 		return fmt.Errorf("failed to marshal stats: %w", err)
 	}
 
+	tagsJSON, err := marshalAutomationTags(automation.Tags)
+	if err != nil {
+		return err
+	}
+
+	rampJSON, err := marshalEnrollmentRamp(automation.EnrollmentRamp)
+	if err != nil {
+		return err
+	}
+
+	backoffJSON, err := marshalRetryBackoff(automation.RetryBackoff)
+	if err != nil {
+		return err
+	}
+
 	now := time.Now().UTC()
 	automation.CreatedAt = now
 	automation.UpdatedAt = now
+	if automation.Version == 0 {
+		automation.Version = 1
+	}
 
 	query, args, err := automationPsql.
 		Insert("automations").
 		Columns(
 			"id", "workspace_id", "name", "status", "list_id", "trigger_config",
-			"trigger_sql", "root_node_id", "nodes", "stats", "created_at", "updated_at",
+			"trigger_sql", "root_node_id", "nodes", "tags", "stats", "created_at", "updated_at", "version",
+			"log_webhooks", "enrollment_ramp", "metadata", "retry_backoff",
 		).
 		Values(
 			automation.ID, workspaceID, automation.Name, automation.Status,
 			automation.ListID, triggerJSON, automation.TriggerSQL,
-			automation.RootNodeID, nodesJSON, statsJSON, automation.CreatedAt, automation.UpdatedAt,
+			automation.RootNodeID, nodesJSON, tagsJSON, statsJSON, automation.CreatedAt, automation.UpdatedAt,
+			automation.Version, automation.LogWebhooks, rampJSON, automation.Metadata, backoffJSON,
 		).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("failed to build query: %w", err)
 	}
 
-	var execer interface {
-		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
-	}
-	if tx != nil {
-		execer = tx
-	} else {
-		db, err := r.getDB(ctx, workspaceID)
-		if err != nil {
-			return fmt.Errorf("failed to get database connection: %w", err)
+	// The automation and the snapshot of its first version are written together
+	return r.withTx(ctx, tx, workspaceID, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to create automation: %w", err)
 		}
-		execer = db
+		return r.createVersionTx(ctx, tx, automation.NewVersion(automation.Version))
+	})
+}
+
+// withTx runs fn in the given transaction, or in a new transaction on the workspace database when tx is nil
+func (r *AutomationRepository) withTx(ctx context.Context, tx *sql.Tx, workspaceID string, fn func(*sql.Tx) error) error {
+	if tx != nil {
+		return fn(tx)
 	}
 
-	_, err = execer.ExecContext(ctx, query, args...)
+	db, err := r.getDB(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	tx, err = db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// createVersionTx stores the workflow snapshot of an automation version
+func (r *AutomationRepository) createVersionTx(ctx context.Context, tx *sql.Tx, version *domain.AutomationVersion) error {
+	nodesJSON, err := json.Marshal(version.Nodes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal nodes: %w", err)
+	}
+
+	query, args, err := automationPsql.
+		Insert("automation_versions").
+		Columns("automation_id", "version", "root_node_id", "nodes", "created_at").
+		Values(version.AutomationID, version.Version, version.RootNodeID, nodesJSON, version.CreatedAt).
+		ToSql()
 	if err != nil {
-		return fmt.Errorf("failed to create automation: %w", err)
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create automation version: %w", err)
 	}
 
 	return nil
 }
 
+// GetVersion retrieves the workflow snapshot of an automation version
+func (r *AutomationRepository) GetVersion(ctx context.Context, workspaceID, automationID string, version int) (*domain.AutomationVersion, error) {
+	db, err := r.getDB(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query, args, err := automationPsql.
+		Select("automation_id", "version", "root_node_id", "nodes", "created_at").
+		From("automation_versions").
+		Where(sq.Eq{"automation_id": automationID, "version": version}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var v domain.AutomationVersion
+	var rootNodeID sql.NullString
+	var nodesJSON []byte
+
+	err = db.QueryRowContext(ctx, query, args...).Scan(&v.AutomationID, &v.Version, &rootNodeID, &nodesJSON, &v.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("automation version not found: %s v%d", automationID, version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get automation version: %w", err)
+	}
+
+	v.RootNodeID = rootNodeID.String
+	if len(nodesJSON) > 0 {
+		if err := json.Unmarshal(nodesJSON, &v.Nodes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal nodes: %w", err)
+		}
+	}
+
+	return &v, nil
+}
+
 // GetByID retrieves an automation by ID
 func (r *AutomationRepository) GetByID(ctx context.Context, workspaceID, id string) (*domain.Automation, error) {
 	return r.GetByIDTx(ctx, nil, workspaceID, id)
@@ -137,7 +235,8 @@ func (r *AutomationRepository) GetByIDTx(ctx context.Context, tx *sql.Tx, worksp
 	query, args, err := automationPsql.
 		Select(
 			"id", "workspace_id", "name", "status", "list_id", "trigger_config",
-			"trigger_sql", "root_node_id", "nodes", "stats", "created_at", "updated_at", "deleted_at",
+			"trigger_sql", "root_node_id", "nodes", "tags", "stats", "created_at", "updated_at", "deleted_at",
+			"version", "log_webhooks", "enrollment_ramp", "metadata", "retry_backoff",
 		).
 		From("automations").
 		Where(sq.Eq{"id": id, "workspace_id": workspaceID, "deleted_at": nil}).
@@ -160,13 +259,14 @@ func (r *AutomationRepository) GetByIDTx(ctx context.Context, tx *sql.Tx, worksp
 	}
 
 	var automation domain.Automation
-	var triggerJSON, nodesJSON, statsJSON []byte
+	var triggerJSON, nodesJSON, tagsJSON, statsJSON, rampJSON, backoffJSON []byte
 	var deletedAt sql.NullTime
 
 	err = queryer.QueryRowContext(ctx, query, args...).Scan(
 		&automation.ID, &automation.WorkspaceID, &automation.Name, &automation.Status,
 		&automation.ListID, &triggerJSON, &automation.TriggerSQL, &automation.RootNodeID,
-		&nodesJSON, &statsJSON, &automation.CreatedAt, &automation.UpdatedAt, &deletedAt,
+		&nodesJSON, &tagsJSON, &statsJSON, &automation.CreatedAt, &automation.UpdatedAt, &deletedAt,
+		&automation.Version, &automation.LogWebhooks, &rampJSON, &automation.Metadata, &backoffJSON,
 	)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("automation not found: %s", id)
@@ -183,6 +283,15 @@ func (r *AutomationRepository) GetByIDTx(ctx context.Context, tx *sql.Tx, worksp
 			return nil, fmt.Errorf("failed to unmarshal nodes: %w", err)
 		}
 	}
+	if automation.Tags, err = unmarshalAutomationTags(tagsJSON); err != nil {
+		return nil, err
+	}
+	if automation.EnrollmentRamp, err = unmarshalEnrollmentRamp(rampJSON); err != nil {
+		return nil, err
+	}
+	if automation.RetryBackoff, err = unmarshalRetryBackoff(backoffJSON); err != nil {
+		return nil, err
+	}
 	if len(statsJSON) > 0 {
 		if err := json.Unmarshal(statsJSON, &automation.Stats); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal stats: %w", err)
@@ -223,11 +332,20 @@ func (r *AutomationRepository) List(ctx context.Context, workspaceID string, fil
 		whereClause["deleted_at"] = nil
 	}
 
+	conditions := sq.And{whereClause}
+	if filter.Tag != "" {
+		tagJSON, err := json.Marshal([]string{filter.Tag})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to marshal tag filter: %w", err)
+		}
+		conditions = append(conditions, sq.Expr("tags @> ?::jsonb", string(tagJSON)))
+	}
+
 	// Count query
 	countQuery, countArgs, err := automationPsql.
 		Select("COUNT(*)").
 		From("automations").
-		Where(whereClause).
+		Where(conditions).
 		ToSql()
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to build count query: %w", err)
@@ -242,10 +360,11 @@ func (r *AutomationRepository) List(ctx context.Context, workspaceID string, fil
 	dataQuery := automationPsql.
 		Select(
 			"id", "workspace_id", "name", "status", "list_id", "trigger_config",
-			"trigger_sql", "root_node_id", "nodes", "stats", "created_at", "updated_at", "deleted_at",
+			"trigger_sql", "root_node_id", "nodes", "tags", "stats", "created_at", "updated_at", "deleted_at",
+			"version", "log_webhooks", "enrollment_ramp", "metadata", "retry_backoff",
 		).
 		From("automations").
-		Where(whereClause).
+		Where(conditions).
 		OrderBy("created_at DESC")
 
 	if filter.Limit > 0 {
@@ -269,13 +388,14 @@ func (r *AutomationRepository) List(ctx context.Context, workspaceID string, fil
 	var automations []*domain.Automation
 	for rows.Next() {
 		var automation domain.Automation
-		var triggerJSON, nodesJSON, statsJSON []byte
+		var triggerJSON, nodesJSON, tagsJSON, statsJSON, rampJSON, backoffJSON []byte
 		var deletedAt sql.NullTime
 
 		err := rows.Scan(
 			&automation.ID, &automation.WorkspaceID, &automation.Name, &automation.Status,
 			&automation.ListID, &triggerJSON, &automation.TriggerSQL, &automation.RootNodeID,
-			&nodesJSON, &statsJSON, &automation.CreatedAt, &automation.UpdatedAt, &deletedAt,
+			&nodesJSON, &tagsJSON, &statsJSON, &automation.CreatedAt, &automation.UpdatedAt, &deletedAt,
+			&automation.Version, &automation.LogWebhooks, &rampJSON, &automation.Metadata, &backoffJSON,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan automation row: %w", err)
@@ -289,6 +409,15 @@ func (r *AutomationRepository) List(ctx context.Context, workspaceID string, fil
 				return nil, 0, fmt.Errorf("failed to unmarshal nodes: %w", err)
 			}
 		}
+		if automation.Tags, err = unmarshalAutomationTags(tagsJSON); err != nil {
+			return nil, 0, err
+		}
+		if automation.EnrollmentRamp, err = unmarshalEnrollmentRamp(rampJSON); err != nil {
+			return nil, 0, err
+		}
+		if automation.RetryBackoff, err = unmarshalRetryBackoff(backoffJSON); err != nil {
+			return nil, 0, err
+		}
 		if len(statsJSON) > 0 {
 			if err := json.Unmarshal(statsJSON, &automation.Stats); err != nil {
 				return nil, 0, fmt.Errorf("failed to unmarshal stats: %w", err)
@@ -326,43 +455,144 @@ func (r *AutomationRepository) UpdateTx(ctx context.Context, tx *sql.Tx, workspa
 		return fmt.Errorf("failed to marshal nodes: %w", err)
 	}
 
+	tagsJSON, err := marshalAutomationTags(automation.Tags)
+	if err != nil {
+		return err
+	}
+
+	rampJSON, err := marshalEnrollmentRamp(automation.EnrollmentRamp)
+	if err != nil {
+		return err
+	}
+
+	backoffJSON, err := marshalRetryBackoff(automation.RetryBackoff)
+	if err != nil {
+		return err
+	}
+
 	// NOTE: Stats are NOT updated here - they should only be modified via atomic methods
 	// like IncrementAutomationStat or UpdateAutomationStats to prevent accidental resets
 
-	automation.UpdatedAt = time.Now().UTC()
+	return r.withTx(ctx, tx, workspaceID, func(tx *sql.Tx) error {
+		// Lock the row and compare with the stored workflow: a changed workflow becomes a new
+		// version, while contacts already enrolled keep running the version they are pinned to
+		current, err := r.getWorkflowForUpdateTx(ctx, tx, workspaceID, automation.ID)
+		if err != nil {
+			return err
+		}
 
+		newVersion := automation.WorkflowChanged(current)
+		automation.Version = current.Version
+		if newVersion {
+			automation.Version++
+		}
+		automation.UpdatedAt = time.Now().UTC()
+
+		query, args, err := automationPsql.
+			Update("automations").
+			Set("name", automation.Name).
+			Set("status", automation.Status).
+			Set("list_id", automation.ListID).
+			Set("trigger_config", triggerJSON).
+			Set("trigger_sql", automation.TriggerSQL).
+			Set("root_node_id", automation.RootNodeID).
+			Set("nodes", nodesJSON).
+			Set("tags", tagsJSON).
+			Set("version", automation.Version).
+			Set("log_webhooks", automation.LogWebhooks).
+			Set("enrollment_ramp", rampJSON).
+			Set("metadata", automation.Metadata).
+			Set("retry_backoff", backoffJSON).
+			Set("updated_at", automation.UpdatedAt).
+			Where(sq.Eq{"id": automation.ID, "workspace_id": workspaceID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build query: %w", err)
+		}
+
+		result, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to update automation: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("automation not found: %s", automation.ID)
+		}
+
+		if newVersion {
+			// Deleted nodes drop out of the per-node stats
+			if err := r.pruneNodeStatsTx(ctx, tx, workspaceID, automation); err != nil {
+				return err
+			}
+			return r.createVersionTx(ctx, tx, automation.NewVersion(automation.Version))
+		}
+		return nil
+	})
+}
+
+// getWorkflowForUpdateTx locks an automation row and returns its current workflow and version
+func (r *AutomationRepository) getWorkflowForUpdateTx(ctx context.Context, tx *sql.Tx, workspaceID, id string) (*domain.Automation, error) {
 	query, args, err := automationPsql.
-		Update("automations").
-		Set("name", automation.Name).
-		Set("status", automation.Status).
-		Set("list_id", automation.ListID).
-		Set("trigger_config", triggerJSON).
-		Set("trigger_sql", automation.TriggerSQL).
-		Set("root_node_id", automation.RootNodeID).
-		Set("nodes", nodesJSON).
-		Set("updated_at", automation.UpdatedAt).
-		Where(sq.Eq{"id": automation.ID, "workspace_id": workspaceID}).
+		Select("version", "root_node_id", "nodes").
+		From("automations").
+		Where(sq.Eq{"id": id, "workspace_id": workspaceID}).
+		Suffix("FOR UPDATE").
 		ToSql()
 	if err != nil {
-		return fmt.Errorf("failed to build query: %w", err)
+		return nil, fmt.Errorf("failed to build query: %w", err)
 	}
 
-	var execer interface {
-		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	current := domain.Automation{ID: id}
+	var rootNodeID sql.NullString
+	var nodesJSON []byte
+
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&current.Version, &rootNodeID, &nodesJSON)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("automation not found: %s", id)
 	}
-	if tx != nil {
-		execer = tx
-	} else {
-		db, err := r.getDB(ctx, workspaceID)
-		if err != nil {
-			return fmt.Errorf("failed to get database connection: %w", err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get automation: %w", err)
+	}
+
+	current.RootNodeID = rootNodeID.String
+	if len(nodesJSON) > 0 {
+		if err := json.Unmarshal(nodesJSON, &current.Nodes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal nodes: %w", err)
 		}
-		execer = db
 	}
 
-	result, err := execer.ExecContext(ctx, query, args...)
+	return &current, nil
+}
+
+// UpdateTags replaces the tags of an automation
+func (r *AutomationRepository) UpdateTags(ctx context.Context, workspaceID, id string, tags []string) error {
+	db, err := r.getDB(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	tagsJSON, err := marshalAutomationTags(tags)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := automationPsql.
+		Update("automations").
+		Set("tags", tagsJSON).
+		Set("updated_at", time.Now().UTC()).
+		Where(sq.Eq{"id": id, "workspace_id": workspaceID, "deleted_at": nil}).
+		ToSql()
 	if err != nil {
-		return fmt.Errorf("failed to update automation: %w", err)
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update automation tags: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -370,12 +600,125 @@ func (r *AutomationRepository) UpdateTx(ctx context.Context, tx *sql.Tx, workspa
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("automation not found: %s", automation.ID)
+		return fmt.Errorf("automation not found: %s", id)
 	}
 
 	return nil
 }
 
+// ListTags returns the distinct tags used by non-deleted automations, sorted alphabetically
+func (r *AutomationRepository) ListTags(ctx context.Context, workspaceID string) ([]string, error) {
+	db, err := r.getDB(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query, args, err := automationPsql.
+		Select("DISTINCT jsonb_array_elements_text(tags) AS tag").
+		From("automations").
+		Where(sq.Eq{"workspace_id": workspaceID, "deleted_at": nil}).
+		OrderBy("tag").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list automation tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []string{}
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("failed to scan automation tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating automation tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// marshalAutomationTags encodes tags as a JSON array, never as JSON null
+func marshalAutomationTags(tags []string) ([]byte, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+	tagsJSON, err := json.Marshal(tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tags: %w", err)
+	}
+	return tagsJSON, nil
+}
+
+// unmarshalAutomationTags decodes the tags column, defaulting to an empty list
+func unmarshalAutomationTags(tagsJSON []byte) ([]string, error) {
+	tags := []string{}
+	if len(tagsJSON) > 0 {
+		if err := json.Unmarshal(tagsJSON, &tags); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
+		}
+	}
+	if tags == nil {
+		tags = []string{}
+	}
+	return tags, nil
+}
+
+// marshalEnrollmentRamp encodes the enrollment_ramp column, NULL when the automation has no ramp
+func marshalEnrollmentRamp(ramp *domain.EnrollmentRamp) (interface{}, error) {
+	if ramp == nil {
+		return nil, nil
+	}
+	rampJSON, err := json.Marshal(ramp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal enrollment ramp: %w", err)
+	}
+	return rampJSON, nil
+}
+
+// unmarshalEnrollmentRamp decodes the enrollment_ramp column, nil when it is NULL
+func unmarshalEnrollmentRamp(rampJSON []byte) (*domain.EnrollmentRamp, error) {
+	if len(rampJSON) == 0 || string(rampJSON) == "null" {
+		return nil, nil
+	}
+	var ramp domain.EnrollmentRamp
+	if err := json.Unmarshal(rampJSON, &ramp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal enrollment ramp: %w", err)
+	}
+	return &ramp, nil
+}
+
+// marshalRetryBackoff encodes the retry_backoff column, NULL when the automation uses the default backoff
+func marshalRetryBackoff(backoff *domain.RetryBackoff) (interface{}, error) {
+	if backoff == nil {
+		return nil, nil
+	}
+	backoffJSON, err := json.Marshal(backoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal retry backoff: %w", err)
+	}
+	return backoffJSON, nil
+}
+
+// unmarshalRetryBackoff decodes the retry_backoff column, nil when it is NULL
+func unmarshalRetryBackoff(backoffJSON []byte) (*domain.RetryBackoff, error) {
+	if len(backoffJSON) == 0 || string(backoffJSON) == "null" {
+		return nil, nil
+	}
+	var backoff domain.RetryBackoff
+	if err := json.Unmarshal(backoffJSON, &backoff); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal retry backoff: %w", err)
+	}
+	return &backoff, nil
+}
+
 // Delete soft-deletes an automation by setting deleted_at timestamp
 // It also drops the trigger if automation is live and exits all active contacts
 func (r *AutomationRepository) Delete(ctx context.Context, workspaceID, id string) error {
@@ -467,6 +810,10 @@ func (r *AutomationRepository) CreateAutomationTrigger(ctx context.Context, work
 		{triggerSQL.FunctionBody, "failed to create trigger function"},
 		{triggerSQL.TriggerDDL, "failed to create trigger"},
 	}
+	// Inactivity automations are enrolled by the scheduler, so only stale triggers are dropped
+	if automation.Trigger != nil && automation.Trigger.IsInactivity() {
+		statements = statements[:2]
+	}
 
 	for _, stmt := range statements {
 		if _, err := db.ExecContext(ctx, stmt.sql); err != nil {
@@ -519,7 +866,7 @@ func (r *AutomationRepository) GetContactAutomationTx(ctx context.Context, tx *s
 		Select(
 			"id", "automation_id", "contact_email", "current_node_id", "status",
 			"exit_reason", "entered_at", "scheduled_at", "context", "retry_count", "last_error",
-			"last_retry_at", "max_retries",
+			"last_retry_at", "max_retries", "automation_version",
 		).
 		From("contact_automations").
 		Where(sq.Eq{"id": id}).
@@ -547,7 +894,7 @@ func (r *AutomationRepository) GetContactAutomationTx(ctx context.Context, tx *s
 	err = queryer.QueryRowContext(ctx, query, args...).Scan(
 		&ca.ID, &ca.AutomationID, &ca.ContactEmail, &ca.CurrentNodeID, &ca.Status,
 		&ca.ExitReason, &ca.EnteredAt, &ca.ScheduledAt, &contextJSON, &ca.RetryCount, &ca.LastError,
-		&ca.LastRetryAt, &ca.MaxRetries,
+		&ca.LastRetryAt, &ca.MaxRetries, &ca.AutomationVersion,
 	)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("contact automation not found: %s", id)
@@ -576,7 +923,7 @@ func (r *AutomationRepository) GetContactAutomationByEmail(ctx context.Context,
 		Select(
 			"id", "automation_id", "contact_email", "current_node_id", "status",
 			"exit_reason", "entered_at", "scheduled_at", "context", "retry_count", "last_error",
-			"last_retry_at", "max_retries",
+			"last_retry_at", "max_retries", "automation_version",
 		).
 		From("contact_automations").
 		Where(sq.Eq{"automation_id": automationID, "contact_email": email}).
@@ -593,7 +940,7 @@ func (r *AutomationRepository) GetContactAutomationByEmail(ctx context.Context,
 	err = db.QueryRowContext(ctx, query, args...).Scan(
 		&ca.ID, &ca.AutomationID, &ca.ContactEmail, &ca.CurrentNodeID, &ca.Status,
 		&ca.ExitReason, &ca.EnteredAt, &ca.ScheduledAt, &contextJSON, &ca.RetryCount, &ca.LastError,
-		&ca.LastRetryAt, &ca.MaxRetries,
+		&ca.LastRetryAt, &ca.MaxRetries, &ca.AutomationVersion,
 	)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("contact automation not found for email: %s", email)
@@ -658,7 +1005,7 @@ func (r *AutomationRepository) ListContactAutomations(ctx context.Context, works
 		Select(
 			"id", "automation_id", "contact_email", "current_node_id", "status",
 			"exit_reason", "entered_at", "scheduled_at", "context", "retry_count", "last_error",
-			"last_retry_at", "max_retries",
+			"last_retry_at", "max_retries", "automation_version",
 		).
 		From("contact_automations").
 		Where(whereClause).
@@ -690,7 +1037,7 @@ func (r *AutomationRepository) ListContactAutomations(ctx context.Context, works
 		err := rows.Scan(
 			&ca.ID, &ca.AutomationID, &ca.ContactEmail, &ca.CurrentNodeID, &ca.Status,
 			&ca.ExitReason, &ca.EnteredAt, &ca.ScheduledAt, &contextJSON, &ca.RetryCount, &ca.LastError,
-			&ca.LastRetryAt, &ca.MaxRetries,
+			&ca.LastRetryAt, &ca.MaxRetries, &ca.AutomationVersion,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan contact automation row: %w", err)
@@ -773,6 +1120,7 @@ func (r *AutomationRepository) UpdateContactAutomationTx(ctx context.Context, tx
 // GetScheduledContactAutomations retrieves contact automations scheduled for processing
 // Uses FOR UPDATE SKIP LOCKED to prevent concurrent processing of the same records
 // Only returns contacts from LIVE automations (paused automations' contacts stay frozen)
+// Contacts are interleaved across automations (round-robin) so one automation can't fill the batch
 func (r *AutomationRepository) GetScheduledContactAutomations(ctx context.Context, workspaceID string, beforeTime time.Time, limit int) ([]*domain.ContactAutomation, error) {
 	db, err := r.getDB(ctx, workspaceID)
 	if err != nil {
@@ -782,17 +1130,36 @@ func (r *AutomationRepository) GetScheduledContactAutomations(ctx context.Contex
 	// Build query with FOR UPDATE SKIP LOCKED to prevent concurrent processing
 	// Join with automations to filter by automation status (only process contacts from live automations)
 	// This implements the "pause" behavior: paused automations' contacts stay frozen at their current node
+	//
+	// Round-robin across automations: each due contact gets a turn number within its automation
+	// (1 for the earliest scheduled, 2 for the next...), and contacts are picked turn by turn, so a
+	// large automation can't starve smaller ones. No automation can contribute more than the batch
+	// size, so only its first $2 due contacts are numbered (idx_contact_automations_automation_scheduled),
+	// keeping the query bounded however large the backlog. The status and schedule are checked again
+	// on the locked rows in case another scheduler processed them in the meantime.
 	query := `
 		SELECT ca.id, ca.automation_id, ca.contact_email, ca.current_node_id, ca.status,
 		       ca.exit_reason, ca.entered_at, ca.scheduled_at, ca.context, ca.retry_count, ca.last_error,
-		       ca.last_retry_at, ca.max_retries
+		       ca.last_retry_at, ca.max_retries, ca.automation_version
 		FROM contact_automations ca
-		JOIN automations a ON ca.automation_id = a.id
+		JOIN (
+			SELECT next.id, ROW_NUMBER() OVER (PARTITION BY a.id ORDER BY next.scheduled_at ASC) AS turn
+			FROM automations a
+			CROSS JOIN LATERAL (
+				SELECT c.id, c.scheduled_at
+				FROM contact_automations c
+				WHERE c.automation_id = a.id
+				  AND c.status = 'active'
+				  AND c.scheduled_at <= $1
+				ORDER BY c.scheduled_at ASC
+				LIMIT $2
+			) next
+			WHERE a.status = 'live'
+			  AND a.deleted_at IS NULL
+		) due ON due.id = ca.id
 		WHERE ca.status = 'active'
 		  AND ca.scheduled_at <= $1
-		  AND a.status = 'live'
-		  AND a.deleted_at IS NULL
-		ORDER BY ca.scheduled_at ASC
+		ORDER BY due.turn ASC, ca.scheduled_at ASC
 		LIMIT $2
 		FOR UPDATE OF ca SKIP LOCKED
 	`
@@ -812,7 +1179,7 @@ func (r *AutomationRepository) GetScheduledContactAutomations(ctx context.Contex
 		err := rows.Scan(
 			&ca.ID, &ca.AutomationID, &ca.ContactEmail, &ca.CurrentNodeID, &ca.Status,
 			&ca.ExitReason, &ca.EnteredAt, &ca.ScheduledAt, &contextJSON, &ca.RetryCount, &ca.LastError,
-			&ca.LastRetryAt, &ca.MaxRetries,
+			&ca.LastRetryAt, &ca.MaxRetries, &ca.AutomationVersion,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan contact automation row: %w", err)
@@ -1048,6 +1415,414 @@ func (r *AutomationRepository) UpdateNodeExecutionTx(ctx context.Context, tx *sq
 	return nil
 }
 
+// Manual enrollment
+
+// EnrollContact enrolls a contact in an automation through automation_enroll_contact, the
+// function called by automation triggers, so "once" frequency dedup and stats apply the same way.
+// Returns false when the contact is already active in the automation or was already
+// triggered for a "once" automation, and ErrEnrollmentContactNotFound when no contact matches.
+func (r *AutomationRepository) EnrollContact(ctx context.Context, workspaceID string, automation *domain.Automation, email string) (bool, error) {
+	frequency := domain.TriggerFrequencyEveryTime
+	if automation.Trigger != nil && automation.Trigger.Frequency != "" {
+		frequency = automation.Trigger.Frequency
+	}
+
+	enrolled := false
+	err := r.withTx(ctx, nil, workspaceID, func(tx *sql.Tx) error {
+		var contactExists, alreadyActive bool
+		err := tx.QueryRowContext(ctx,
+			`SELECT EXISTS(SELECT 1 FROM contacts WHERE email = $1),
+				EXISTS(SELECT 1 FROM contact_automations WHERE automation_id = $2 AND contact_email = $1 AND status = 'active')`,
+			email, automation.ID,
+		).Scan(&contactExists, &alreadyActive)
+		if err != nil {
+			return fmt.Errorf("failed to check contact: %w", err)
+		}
+		if !contactExists {
+			return domain.ErrEnrollmentContactNotFound
+		}
+		if alreadyActive {
+			return nil
+		}
+
+		countQuery := `SELECT COUNT(*) FROM contact_automations WHERE automation_id = $1 AND contact_email = $2`
+		var before, after int
+		if err := tx.QueryRowContext(ctx, countQuery, automation.ID, email).Scan(&before); err != nil {
+			return fmt.Errorf("failed to count enrollments: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `SELECT automation_enroll_contact($1, $2, $3, $4)`,
+			automation.ID, email, automation.RootNodeID, string(frequency))
+		if err != nil {
+			return fmt.Errorf("failed to enroll contact: %w", err)
+		}
+
+		// automation_enroll_contact returns nothing, so compare counts to detect a "once" skip
+		if err := tx.QueryRowContext(ctx, countQuery, automation.ID, email).Scan(&after); err != nil {
+			return fmt.Errorf("failed to count enrollments: %w", err)
+		}
+		enrolled = after > before
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return enrolled, nil
+}
+
+// ReenrollContactAutomation exits an active contact automation with the "reenrolled" reason and
+// enrolls the contact again at the root node, in one transaction. It returns false without changes
+// when the run is no longer active, when the contact was already re-enrolled maxReenrollments times,
+// or when the trigger frequency is "once".
+func (r *AutomationRepository) ReenrollContactAutomation(ctx context.Context, workspaceID string, automation *domain.Automation, contactAutomationID, email string, maxReenrollments int) (bool, error) {
+	frequency := domain.TriggerFrequencyEveryTime
+	if automation.Trigger != nil && automation.Trigger.Frequency != "" {
+		frequency = automation.Trigger.Frequency
+	}
+	// automation_trigger_log already holds the contact's first enrollment, the new one would be skipped
+	if frequency == domain.TriggerFrequencyOnce {
+		return false, nil
+	}
+
+	reenrolled := false
+	err := r.withTx(ctx, nil, workspaceID, func(tx *sql.Tx) error {
+		var id string
+		err := tx.QueryRowContext(ctx,
+			`SELECT id FROM contact_automations WHERE id = $1 AND status = 'active' FOR UPDATE`,
+			contactAutomationID,
+		).Scan(&id)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to lock contact automation: %w", err)
+		}
+
+		var count int
+		if err := tx.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM contact_automations WHERE automation_id = $1 AND contact_email = $2 AND exit_reason = $3`,
+			automation.ID, email, domain.ReenrolledExitReason,
+		).Scan(&count); err != nil {
+			return fmt.Errorf("failed to count re-enrollments: %w", err)
+		}
+		if count >= maxReenrollments {
+			return nil
+		}
+
+		// Exit the current run first, so the contact never has two active runs
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE contact_automations SET status = 'exited', exit_reason = $1, current_node_id = NULL, scheduled_at = NULL WHERE id = $2`,
+			domain.ReenrolledExitReason, contactAutomationID,
+		); err != nil {
+			return fmt.Errorf("failed to exit contact automation: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `SELECT automation_enroll_contact($1, $2, $3, $4)`,
+			automation.ID, email, automation.RootNodeID, string(frequency)); err != nil {
+			return fmt.Errorf("failed to enroll contact: %w", err)
+		}
+
+		reenrolled = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return reenrolled, nil
+}
+
+// GetSegmentContactEmails returns up to limit members of a segment, ordered by email after afterEmail
+func (r *AutomationRepository) GetSegmentContactEmails(ctx context.Context, workspaceID, segmentID, afterEmail string, limit int) ([]string, error) {
+	db, err := r.getDB(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query, args, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select("email").
+		From("contact_segments").
+		Where(sq.Eq{"segment_id": segmentID}).
+		Where(sq.Gt{"email": afterEmail}).
+		OrderBy("email").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get segment contacts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var emails []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, fmt.Errorf("failed to scan segment contact: %w", err)
+		}
+		emails = append(emails, email)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate segment contacts: %w", err)
+	}
+
+	return emails, nil
+}
+
+// ClearTriggerLog removes the "once" frequency trigger log entry of a contact, so the
+// automation trigger can enroll the contact again
+func (r *AutomationRepository) ClearTriggerLog(ctx context.Context, workspaceID, automationID, email string) error {
+	db, err := r.getDB(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx,
+		`DELETE FROM automation_trigger_log WHERE automation_id = $1 AND contact_email = $2`,
+		automationID, email)
+	if err != nil {
+		return fmt.Errorf("failed to clear trigger log: %w", err)
+	}
+
+	return nil
+}
+
+// Inactivity
+
+// GetInactiveContactEmails returns up to limit contacts, ordered by email after afterEmail, that
+// existed before inactiveSince and have no activity (email opens or clicks, custom events) since
+// then. Contacts active in the automation or enrolled in it since inactiveSince are skipped, when
+// the automation has a list only its active subscribers are considered, and a trigger prerequisite
+// is applied like the automation trigger does.
+func (r *AutomationRepository) GetInactiveContactEmails(ctx context.Context, workspaceID string, automation *domain.Automation, inactiveSince time.Time, afterEmail string, limit int) ([]string, error) {
+	db, err := r.getDB(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	builder := psql.Select("c.email").
+		From("contacts c").
+		Where(sq.Lt{"c.created_at": inactiveSince}).
+		Where(sq.Gt{"c.email": afterEmail}).
+		Where(sq.Expr(`NOT EXISTS (
+			SELECT 1 FROM contact_timeline ct
+			WHERE ct.email = c.email AND ct.created_at >= ?
+			AND (ct.kind IN (`+sq.Placeholders(len(domain.InactivityActivityKinds))+`) OR ct.kind LIKE 'custom_event.%')
+		)`, append([]interface{}{inactiveSince}, stringsToArgs(domain.InactivityActivityKinds)...)...)).
+		Where(sq.Expr(`NOT EXISTS (
+			SELECT 1 FROM contact_automations ca
+			WHERE ca.automation_id = ? AND ca.contact_email = c.email
+			AND (ca.status = 'active' OR ca.entered_at >= ?)
+		)`, automation.ID, inactiveSince)).
+		OrderBy("c.email").
+		Limit(uint64(limit))
+
+	if automation.ListID != "" {
+		builder = builder.Where(sq.Expr(`EXISTS (
+			SELECT 1 FROM contact_lists cl
+			WHERE cl.email = c.email AND cl.list_id = ? AND cl.status = 'active' AND cl.deleted_at IS NULL
+		)`, automation.ListID))
+	}
+	if automation.Trigger != nil && automation.Trigger.Prerequisite != nil {
+		builder = builder.Where(service.BuildPrerequisiteCondition(automation.Trigger.Prerequisite, "c.email"))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inactive contacts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var emails []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, fmt.Errorf("failed to scan inactive contact: %w", err)
+		}
+		emails = append(emails, email)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate inactive contacts: %w", err)
+	}
+
+	return emails, nil
+}
+
+// stringsToArgs converts strings into query arguments
+func stringsToArgs(values []string) []interface{} {
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
+
+// Goals
+
+// ListWaitingVersions returns the workflow versions run by the contacts waiting at a node of the
+// automation. Contacts that are not pinned to a version run currentVersion.
+func (r *AutomationRepository) ListWaitingVersions(ctx context.Context, workspaceID, automationID string, currentVersion int) ([]int, error) {
+	db, err := r.getDB(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `
+		SELECT DISTINCT COALESCE(automation_version, $2)
+		FROM contact_automations
+		WHERE automation_id = $1
+		AND status = 'active'
+		AND scheduled_at > NOW()
+	`
+
+	rows, err := db.QueryContext(ctx, query, automationID, currentVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waiting versions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var versions []int
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("failed to scan waiting version: %w", err)
+		}
+		versions = append(versions, version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate waiting versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// ListContactAutomationsMeetingGoal returns up to filter.Limit contacts waiting at a node of the
+// automation version, ordered by ID after filter.AfterID, whose contact matches the goal
+// conditions. Branch goals only match the contacts that passed through the goal node.
+func (r *AutomationRepository) ListContactAutomationsMeetingGoal(ctx context.Context, workspaceID string, filter domain.GoalContactFilter) ([]*domain.ContactAutomation, error) {
+	db, err := r.getDB(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	// The goal conditions are numbered from $1, the filter placeholders follow them
+	args := append([]interface{}{}, filter.ContactsArgs...)
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	automationID := placeholder(filter.AutomationID)
+	currentVersion := placeholder(filter.CurrentVersion)
+	version := placeholder(filter.Version)
+	afterID := placeholder(filter.AfterID)
+
+	query := `
+		SELECT ca.id, ca.automation_id, ca.contact_email, ca.current_node_id, ca.status,
+		       ca.exit_reason, ca.entered_at, ca.scheduled_at, ca.context, ca.retry_count, ca.last_error,
+		       ca.last_retry_at, ca.max_retries, ca.automation_version
+		FROM contact_automations ca
+		WHERE ca.automation_id = ` + automationID + `
+		AND ca.status = 'active'
+		AND ca.scheduled_at > NOW()
+		AND COALESCE(ca.automation_version, ` + currentVersion + `) = ` + version + `
+		AND ca.id > ` + afterID + `
+		AND ca.contact_email IN (` + filter.ContactsSQL + `)`
+	if filter.PassedNodeID != "" {
+		query += `
+		AND EXISTS (
+			SELECT 1 FROM automation_node_executions ne
+			WHERE ne.contact_automation_id = ca.id AND ne.node_id = ` + placeholder(filter.PassedNodeID) + `
+		)`
+	}
+	query += `
+		ORDER BY ca.id
+		LIMIT ` + placeholder(filter.Limit)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contacts meeting goal: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var cas []*domain.ContactAutomation
+	for rows.Next() {
+		var ca domain.ContactAutomation
+		var contextJSON []byte
+
+		err := rows.Scan(
+			&ca.ID, &ca.AutomationID, &ca.ContactEmail, &ca.CurrentNodeID, &ca.Status,
+			&ca.ExitReason, &ca.EnteredAt, &ca.ScheduledAt, &contextJSON, &ca.RetryCount, &ca.LastError,
+			&ca.LastRetryAt, &ca.MaxRetries, &ca.AutomationVersion,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan contact automation row: %w", err)
+		}
+
+		if len(contextJSON) > 0 {
+			if err := json.Unmarshal(contextJSON, &ca.Context); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal context: %w", err)
+			}
+		}
+
+		cas = append(cas, &ca)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating contact automation rows: %w", err)
+	}
+
+	return cas, nil
+}
+
+// Retention
+
+// DeleteFinishedContactAutomations deletes up to limit completed or exited contact automations
+// whose last activity is before the cutoff. Node executions are removed by ON DELETE CASCADE;
+// automation stats and the trigger log (used for "once" deduplication) are left untouched.
+func (r *AutomationRepository) DeleteFinishedContactAutomations(ctx context.Context, workspaceID string, before time.Time, limit int) (int64, error) {
+	db, err := r.getDB(ctx, workspaceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `
+		DELETE FROM contact_automations
+		WHERE id IN (
+			SELECT ca.id FROM contact_automations ca
+			WHERE ca.status IN ('completed', 'exited')
+			AND ca.entered_at < $1
+			AND NOT EXISTS (
+				SELECT 1 FROM automation_node_executions ne
+				WHERE ne.contact_automation_id = ca.id
+				AND COALESCE(ne.completed_at, ne.entered_at) >= $1
+			)
+			LIMIT $2
+		)
+	`
+
+	result, err := db.ExecContext(ctx, query, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished contact automations: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return deleted, nil
+}
+
 // Stats
 
 // UpdateAutomationStats updates the stats for an automation
@@ -1144,3 +1919,179 @@ func (r *AutomationRepository) IncrementAutomationStat(ctx context.Context, work
 
 	return nil
 }
+
+// RecordNodeExecutionStats adds executions, failures and durations to the automation's per-node
+// stats in a single statement, recomputing each node's average duration
+func (r *AutomationRepository) RecordNodeExecutionStats(ctx context.Context, workspaceID, automationID string, nodes map[string]*domain.AutomationNodeExecutionStats) error {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	db, err := r.getDB(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	deltasJSON, err := json.Marshal(nodes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal node stats: %w", err)
+	}
+
+	result, err := db.ExecContext(ctx, `
+		UPDATE automations
+		SET stats = COALESCE(stats, '{}'::jsonb) || jsonb_build_object('nodes',
+			COALESCE(stats->'nodes', '{}'::jsonb) || (
+				SELECT COALESCE(jsonb_object_agg(n.node_id, jsonb_build_object(
+					'executions', n.executions,
+					'failures', n.failures,
+					'total_duration_ms', n.total_duration_ms,
+					'avg_duration_ms', CASE WHEN n.executions > 0
+						THEN round(n.total_duration_ms::numeric / n.executions, 2) ELSE 0 END
+				)), '{}'::jsonb)
+				FROM (
+					SELECT d.key AS node_id,
+						COALESCE((automations.stats->'nodes'->d.key->>'executions')::bigint, 0) + (d.value->>'executions')::bigint AS executions,
+						COALESCE((automations.stats->'nodes'->d.key->>'failures')::bigint, 0) + (d.value->>'failures')::bigint AS failures,
+						COALESCE((automations.stats->'nodes'->d.key->>'total_duration_ms')::bigint, 0) + (d.value->>'total_duration_ms')::bigint AS total_duration_ms
+					FROM jsonb_each($1::jsonb) d
+				) n
+			)),
+			updated_at = $2
+		WHERE id = $3 AND workspace_id = $4
+	`, deltasJSON, time.Now().UTC(), automationID, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to record node execution stats: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("automation not found: %s", automationID)
+	}
+
+	return nil
+}
+
+// pruneNodeStatsTx removes the per-node stats of nodes that are no longer in the workflow
+func (r *AutomationRepository) pruneNodeStatsTx(ctx context.Context, tx *sql.Tx, workspaceID string, automation *domain.Automation) error {
+	nodeIDs := make([]string, 0, len(automation.Nodes))
+	for _, node := range automation.Nodes {
+		if node != nil {
+			nodeIDs = append(nodeIDs, node.ID)
+		}
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		UPDATE automations
+		SET stats = jsonb_set(stats, '{nodes}', (
+			SELECT COALESCE(jsonb_object_agg(key, value), '{}'::jsonb)
+			FROM jsonb_each(stats->'nodes')
+			WHERE key = ANY($1)
+		))
+		WHERE id = $2 AND workspace_id = $3 AND jsonb_typeof(stats->'nodes') = 'object'
+	`, pq.Array(nodeIDs), automation.ID, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to prune node stats: %w", err)
+	}
+	return nil
+}
+
+// Revenue
+
+// RecordRevenue stores a revenue record and adds its amount to the automation's
+// per-currency revenue total in stats, in a single transaction
+func (r *AutomationRepository) RecordRevenue(ctx context.Context, workspaceID string, revenue *domain.AutomationRevenue) error {
+	return r.withTx(ctx, nil, workspaceID, func(tx *sql.Tx) error {
+		query, args, err := automationPsql.
+			Insert("automation_revenue").
+			Columns("id", "automation_id", "contact_automation_id", "node_id", "contact_email", "amount", "currency", "recorded_at").
+			Values(revenue.ID, revenue.AutomationID, revenue.ContactAutomationID, revenue.NodeID,
+				revenue.ContactEmail, revenue.Amount, revenue.Currency, revenue.RecordedAt).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build query: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert revenue record: %w", err)
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			UPDATE automations
+			SET stats = COALESCE(stats, '{}'::jsonb) || jsonb_build_object('revenue',
+				COALESCE(stats->'revenue', '{}'::jsonb) ||
+				jsonb_build_object($1::text, COALESCE((stats->'revenue'->>$1)::numeric, 0) + $2::numeric)),
+				updated_at = $3
+			WHERE id = $4 AND workspace_id = $5
+		`, revenue.Currency, revenue.Amount, time.Now().UTC(), revenue.AutomationID, workspaceID)
+		if err != nil {
+			return fmt.Errorf("failed to update automation revenue stats: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("automation not found: %s", revenue.AutomationID)
+		}
+
+		return nil
+	})
+}
+
+// Throttle
+
+// ReserveThrottleSlot reserves a pass through a throttle node for one contact. The node's counter
+// row is locked while reserving, so scheduler instances running concurrently share the limit.
+// The current minute is filled first, then the following minutes in order.
+func (r *AutomationRepository) ReserveThrottleSlot(ctx context.Context, workspaceID, automationID, nodeID string, maxPerMinute int, now time.Time) (time.Time, error) {
+	minute := now.UTC().Truncate(time.Minute)
+
+	var slot time.Time
+	err := r.withTx(ctx, nil, workspaceID, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO automation_throttle_windows (automation_id, node_id, window_start, count)
+			VALUES ($1, $2, $3, 0)
+			ON CONFLICT (automation_id, node_id) DO NOTHING
+		`, automationID, nodeID, minute); err != nil {
+			return fmt.Errorf("failed to create throttle counter: %w", err)
+		}
+
+		var windowStart time.Time
+		var count int
+		if err := tx.QueryRowContext(ctx, `
+			SELECT window_start, count FROM automation_throttle_windows
+			WHERE automation_id = $1 AND node_id = $2
+			FOR UPDATE
+		`, automationID, nodeID).Scan(&windowStart, &count); err != nil {
+			return fmt.Errorf("failed to lock throttle counter: %w", err)
+		}
+
+		switch {
+		case windowStart.Before(minute):
+			// The counter resets once its minute has passed
+			windowStart, count = minute, 0
+		case count >= maxPerMinute:
+			// The last reserved minute is full, reserve in the next one
+			windowStart, count = windowStart.Add(time.Minute), 0
+		}
+		count++
+
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE automation_throttle_windows SET window_start = $1, count = $2
+			WHERE automation_id = $3 AND node_id = $4
+		`, windowStart, count, automationID, nodeID); err != nil {
+			return fmt.Errorf("failed to update throttle counter: %w", err)
+		}
+
+		slot = windowStart.UTC()
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return slot, nil
+}
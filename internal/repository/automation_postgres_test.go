@@ -11,6 +11,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/service"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -116,7 +117,8 @@ func TestAutomationRepository_Create(t *testing.T) {
 	workspaceID := "workspace-123"
 	automation := createTestAutomation("auto-123", workspaceID)
 
-	// Test successful create
+	// Test successful create: the automation and its first version snapshot are inserted together
+	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO automations").
 		WithArgs(
 			automation.ID,
@@ -128,17 +130,29 @@ func TestAutomationRepository_Create(t *testing.T) {
 			automation.TriggerSQL,
 			automation.RootNodeID,
 			sqlmock.AnyArg(), // nodes JSON
+			sqlmock.AnyArg(), // tags JSON
 			sqlmock.AnyArg(), // stats JSON
 			sqlmock.AnyArg(), // created_at
 			sqlmock.AnyArg(), // updated_at
+			1,                // version
+			false,            // log_webhooks
+			nil,              // enrollment_ramp
+			sqlmock.AnyArg(), // metadata
+			nil,              // retry_backoff
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO automation_versions").
+		WithArgs(automation.ID, 1, automation.RootNodeID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
 	err := repo.Create(ctx, workspaceID, automation)
 	assert.NoError(t, err)
+	assert.Equal(t, 1, automation.Version)
 	assert.NoError(t, mock.ExpectationsWereMet())
 
 	// Test database error
+	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO automations").
 		WithArgs(
 			automation.ID,
@@ -150,16 +164,34 @@ func TestAutomationRepository_Create(t *testing.T) {
 			automation.TriggerSQL,
 			automation.RootNodeID,
 			sqlmock.AnyArg(), // nodes JSON
+			sqlmock.AnyArg(), // tags JSON
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 		).
 		WillReturnError(fmt.Errorf("database error"))
+	mock.ExpectRollback()
 
 	err = repo.Create(ctx, workspaceID, automation)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to create automation")
 	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Test version snapshot error rolls back the automation
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO automations").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO automation_versions").WillReturnError(fmt.Errorf("database error"))
+	mock.ExpectRollback()
+
+	err = repo.Create(ctx, workspaceID, automation)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create automation version")
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestAutomationRepository_GetByID(t *testing.T) {
@@ -181,10 +213,11 @@ func TestAutomationRepository_GetByID(t *testing.T) {
 	// Test successful retrieval (includes deleted_at IS NULL filter)
 	rows := sqlmock.NewRows([]string{
 		"id", "workspace_id", "name", "status", "list_id", "trigger_config",
-		"trigger_sql", "root_node_id", "nodes", "stats", "created_at", "updated_at", "deleted_at",
+		"trigger_sql", "root_node_id", "nodes", "tags", "stats", "created_at", "updated_at", "deleted_at", "version", "log_webhooks", "enrollment_ramp", "metadata", "retry_backoff",
 	}).AddRow(
 		automationID, workspaceID, "Test Automation", "draft", "list-123",
-		triggerJSON, nil, "node-root", nodesJSON, statsJSON, now, now, nil,
+		triggerJSON, nil, "node-root", nodesJSON, []byte(`["onboarding"]`), statsJSON, now, now, nil, 1, false, nil,
+		[]byte(`{"team":"growth"}`), nil,
 	)
 
 	mock.ExpectQuery("SELECT .* FROM automations WHERE.*deleted_at IS NULL").
@@ -195,6 +228,8 @@ func TestAutomationRepository_GetByID(t *testing.T) {
 	assert.NotNil(t, automation)
 	assert.Equal(t, automationID, automation.ID)
 	assert.Equal(t, workspaceID, automation.WorkspaceID)
+	assert.Equal(t, []string{"onboarding"}, automation.Tags)
+	assert.Equal(t, domain.MapOfAny{"team": "growth"}, automation.Metadata)
 	assert.Nil(t, automation.DeletedAt)
 	assert.NoError(t, mock.ExpectationsWereMet())
 
@@ -219,6 +254,72 @@ func TestAutomationRepository_GetByID(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestAutomationRepository_GetByID_EnrollmentRamp(t *testing.T) {
+	db, mock, repo := setupAutomationMock(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	now := time.Now().UTC()
+
+	triggerJSON, _ := json.Marshal(&domain.TimelineTriggerConfig{
+		EventKind: "email.opened",
+		Frequency: domain.TriggerFrequencyOnce,
+	})
+	rampJSON := []byte(`{"per_minute":100,"duration_minutes":30,"started_at":"2026-10-16T09:00:00Z"}`)
+
+	rows := sqlmock.NewRows([]string{
+		"id", "workspace_id", "name", "status", "list_id", "trigger_config",
+		"trigger_sql", "root_node_id", "nodes", "tags", "stats", "created_at", "updated_at", "deleted_at", "version", "log_webhooks", "enrollment_ramp", "metadata", "retry_backoff",
+	}).AddRow(
+		"auto-123", workspaceID, "Test Automation", "live", "list-123",
+		triggerJSON, nil, "node-root", []byte(`[]`), []byte(`[]`), []byte(`{}`), now, now, nil, 1, false, rampJSON, nil, nil,
+	)
+	mock.ExpectQuery("SELECT .* FROM automations WHERE.*deleted_at IS NULL").
+		WillReturnRows(rows)
+
+	automation, err := repo.GetByID(ctx, workspaceID, "auto-123")
+	require.NoError(t, err)
+	require.NotNil(t, automation.EnrollmentRamp)
+	assert.Equal(t, 100, automation.EnrollmentRamp.PerMinute)
+	assert.Equal(t, 30, automation.EnrollmentRamp.DurationMinutes)
+	require.NotNil(t, automation.EnrollmentRamp.StartedAt)
+	assert.True(t, automation.EnrollmentRamp.StartedAt.Equal(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAutomationRepository_GetByID_RetryBackoff(t *testing.T) {
+	db, mock, repo := setupAutomationMock(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	now := time.Now().UTC()
+
+	triggerJSON, _ := json.Marshal(&domain.TimelineTriggerConfig{
+		EventKind: "email.opened",
+		Frequency: domain.TriggerFrequencyOnce,
+	})
+	backoffJSON := []byte(`{"base_seconds":10,"max_seconds":600,"jitter":0.5}`)
+
+	rows := sqlmock.NewRows([]string{
+		"id", "workspace_id", "name", "status", "list_id", "trigger_config",
+		"trigger_sql", "root_node_id", "nodes", "tags", "stats", "created_at", "updated_at", "deleted_at", "version", "log_webhooks", "enrollment_ramp", "metadata", "retry_backoff",
+	}).AddRow(
+		"auto-123", workspaceID, "Test Automation", "live", "list-123",
+		triggerJSON, nil, "node-root", []byte(`[]`), []byte(`[]`), []byte(`{}`), now, now, nil, 1, false, nil, nil, backoffJSON,
+	)
+	mock.ExpectQuery("SELECT .* FROM automations WHERE.*deleted_at IS NULL").
+		WillReturnRows(rows)
+
+	automation, err := repo.GetByID(ctx, workspaceID, "auto-123")
+	require.NoError(t, err)
+	assert.Nil(t, automation.EnrollmentRamp)
+	require.NotNil(t, automation.RetryBackoff)
+	assert.Equal(t, domain.RetryBackoff{BaseSeconds: 10, MaxSeconds: 600, Jitter: 0.5}, *automation.RetryBackoff)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestAutomationRepository_List(t *testing.T) {
 	db, mock, repo := setupAutomationMock(t)
 	defer func() { _ = db.Close() }()
@@ -248,13 +349,13 @@ func TestAutomationRepository_List(t *testing.T) {
 	// Test data query (includes deleted_at IS NULL)
 	rows := sqlmock.NewRows([]string{
 		"id", "workspace_id", "name", "status", "list_id", "trigger_config",
-		"trigger_sql", "root_node_id", "nodes", "stats", "created_at", "updated_at", "deleted_at",
+		"trigger_sql", "root_node_id", "nodes", "tags", "stats", "created_at", "updated_at", "deleted_at", "version", "log_webhooks", "enrollment_ramp", "metadata", "retry_backoff",
 	}).AddRow(
 		"auto-1", workspaceID, "Auto 1", "draft", "list-123",
-		triggerJSON, nil, "node-1", nodesJSON, statsJSON, now, now, nil,
+		triggerJSON, nil, "node-1", nodesJSON, []byte(`["onboarding"]`), statsJSON, now, now, nil, 1, false, nil, nil, nil,
 	).AddRow(
 		"auto-2", workspaceID, "Auto 2", "live", "list-123",
-		triggerJSON, nil, "node-2", nodesJSON, statsJSON, now, now, nil,
+		triggerJSON, nil, "node-2", nodesJSON, []byte(`["onboarding"]`), statsJSON, now, now, nil, 1, false, nil, nil, nil,
 	)
 
 	mock.ExpectQuery("SELECT .* FROM automations WHERE.*deleted_at IS NULL").
@@ -275,7 +376,7 @@ func TestAutomationRepository_List(t *testing.T) {
 	mock.ExpectQuery("SELECT .* FROM automations WHERE.*deleted_at IS NULL").
 		WillReturnRows(sqlmock.NewRows([]string{
 			"id", "workspace_id", "name", "status", "list_id", "trigger_config",
-			"trigger_sql", "root_node_id", "nodes", "stats", "created_at", "updated_at", "deleted_at",
+			"trigger_sql", "root_node_id", "nodes", "tags", "stats", "created_at", "updated_at", "deleted_at", "version", "log_webhooks", "enrollment_ramp", "metadata", "retry_backoff",
 		}))
 
 	automations, count, err = repo.List(ctx, workspaceID, filter)
@@ -296,6 +397,14 @@ func TestAutomationRepository_List(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+// expectWorkflowLock expects the row lock taken by UpdateTx to read the stored workflow
+func expectWorkflowLock(mock sqlmock.Sqlmock, version int, rootNodeID string, nodes []*domain.AutomationNode) {
+	nodesJSON, _ := json.Marshal(nodes)
+	mock.ExpectQuery("SELECT version, root_node_id, nodes FROM automations WHERE .* FOR UPDATE").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "root_node_id", "nodes"}).
+			AddRow(version, rootNodeID, nodesJSON))
+}
+
 func TestAutomationRepository_Update(t *testing.T) {
 	db, mock, repo := setupAutomationMock(t)
 	defer func() { _ = db.Close() }()
@@ -308,6 +417,8 @@ func TestAutomationRepository_Update(t *testing.T) {
 
 	// Test successful update
 	// NOTE: stats is NOT included in the update query - it's only modified via atomic methods
+	mock.ExpectBegin()
+	expectWorkflowLock(mock, 1, automation.RootNodeID, automation.Nodes)
 	mock.ExpectExec("UPDATE automations SET").
 		WithArgs(
 			automation.Name,
@@ -317,31 +428,41 @@ func TestAutomationRepository_Update(t *testing.T) {
 			automation.TriggerSQL,
 			automation.RootNodeID,
 			sqlmock.AnyArg(), // nodes JSON
+			sqlmock.AnyArg(), // tags JSON
+			1,                // version (workflow unchanged)
+			false,            // log_webhooks
+			nil,              // enrollment_ramp
+			sqlmock.AnyArg(), // metadata
+			nil,              // retry_backoff
 			sqlmock.AnyArg(), // updated_at
 			automation.ID,
 			workspaceID,
 		).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	err := repo.Update(ctx, workspaceID, automation)
 	assert.NoError(t, err)
+	assert.Equal(t, 1, automation.Version)
 	assert.NoError(t, mock.ExpectationsWereMet())
 
 	// Test not found
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT version, root_node_id, nodes FROM automations").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	err = repo.Update(ctx, workspaceID, automation)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "automation not found")
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Test no row updated
+	mock.ExpectBegin()
+	expectWorkflowLock(mock, 1, automation.RootNodeID, automation.Nodes)
 	mock.ExpectExec("UPDATE automations SET").
-		WithArgs(
-			automation.Name,
-			automation.Status,
-			automation.ListID,
-			sqlmock.AnyArg(), // trigger_config JSON
-			automation.TriggerSQL,
-			automation.RootNodeID,
-			sqlmock.AnyArg(), // nodes JSON
-			sqlmock.AnyArg(), // updated_at
-			automation.ID,
-			workspaceID,
-		).
 		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
 
 	err = repo.Update(ctx, workspaceID, automation)
 	assert.Error(t, err)
@@ -349,20 +470,11 @@ func TestAutomationRepository_Update(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 
 	// Test database error
+	mock.ExpectBegin()
+	expectWorkflowLock(mock, 1, automation.RootNodeID, automation.Nodes)
 	mock.ExpectExec("UPDATE automations SET").
-		WithArgs(
-			automation.Name,
-			automation.Status,
-			automation.ListID,
-			sqlmock.AnyArg(), // trigger_config JSON
-			automation.TriggerSQL,
-			automation.RootNodeID,
-			sqlmock.AnyArg(), // nodes JSON
-			sqlmock.AnyArg(), // updated_at
-			automation.ID,
-			workspaceID,
-		).
 		WillReturnError(fmt.Errorf("database error"))
+	mock.ExpectRollback()
 
 	err = repo.Update(ctx, workspaceID, automation)
 	assert.Error(t, err)
@@ -370,6 +482,102 @@ func TestAutomationRepository_Update(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestAutomationRepository_Update_NewVersion(t *testing.T) {
+	db, mock, repo := setupAutomationMock(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	stored := []*domain.AutomationNode{createTestAutomationNode("node-root", "auto-123", domain.NodeTypeTrigger)}
+
+	t.Run("changed workflow creates a version", func(t *testing.T) {
+		automation := createTestAutomation("auto-123", workspaceID)
+		automation.Nodes = []*domain.AutomationNode{
+			createTestAutomationNode("node-root", "auto-123", domain.NodeTypeTrigger),
+			createTestAutomationNode("node-delay", "auto-123", domain.NodeTypeDelay),
+		}
+
+		mock.ExpectBegin()
+		expectWorkflowLock(mock, 3, "node-root", stored)
+		mock.ExpectExec("UPDATE automations SET").
+			WithArgs(
+				automation.Name, automation.Status, automation.ListID, sqlmock.AnyArg(),
+				automation.TriggerSQL, automation.RootNodeID, sqlmock.AnyArg(), sqlmock.AnyArg(),
+				4, false, nil, sqlmock.AnyArg(), nil, sqlmock.AnyArg(), automation.ID, workspaceID,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		// Stats of nodes no longer in the workflow are pruned
+		mock.ExpectExec("UPDATE automations SET stats = jsonb_set").
+			WithArgs(pq.Array([]string{"node-root", "node-delay"}), automation.ID, workspaceID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO automation_versions").
+			WithArgs(automation.ID, 4, "node-root", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Update(ctx, workspaceID, automation)
+		require.NoError(t, err)
+		assert.Equal(t, 4, automation.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("moving nodes keeps the version", func(t *testing.T) {
+		automation := createTestAutomation("auto-123", workspaceID)
+		moved := createTestAutomationNode("node-root", "auto-123", domain.NodeTypeTrigger)
+		moved.Position = domain.NodePosition{X: 500, Y: 40}
+		automation.Nodes = []*domain.AutomationNode{moved}
+
+		mock.ExpectBegin()
+		expectWorkflowLock(mock, 3, "node-root", stored)
+		mock.ExpectExec("UPDATE automations SET").
+			WithArgs(
+				automation.Name, automation.Status, automation.ListID, sqlmock.AnyArg(),
+				automation.TriggerSQL, automation.RootNodeID, sqlmock.AnyArg(), sqlmock.AnyArg(),
+				3, false, nil, sqlmock.AnyArg(), nil, sqlmock.AnyArg(), automation.ID, workspaceID,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.Update(ctx, workspaceID, automation)
+		require.NoError(t, err)
+		assert.Equal(t, 3, automation.Version)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAutomationRepository_GetVersion(t *testing.T) {
+	db, mock, repo := setupAutomationMock(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	nodesJSON, _ := json.Marshal([]*domain.AutomationNode{
+		createTestAutomationNode("node-root", "auto-123", domain.NodeTypeTrigger),
+	})
+
+	mock.ExpectQuery("SELECT automation_id, version, root_node_id, nodes, created_at FROM automation_versions").
+		WithArgs("auto-123", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"automation_id", "version", "root_node_id", "nodes", "created_at"}).
+			AddRow("auto-123", 2, "node-root", nodesJSON, now))
+
+	version, err := repo.GetVersion(ctx, "workspace-123", "auto-123", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version.Version)
+	assert.Equal(t, "node-root", version.RootNodeID)
+	require.Len(t, version.Nodes, 1)
+	assert.Equal(t, "node-root", version.Nodes[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectQuery("SELECT .* FROM automation_versions").
+		WithArgs("auto-123", 9).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.GetVersion(ctx, "workspace-123", "auto-123", 9)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "automation version not found")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestAutomationRepository_Delete(t *testing.T) {
 	db, mock, repo := setupAutomationMock(t)
 	defer func() { _ = db.Close() }()
@@ -458,6 +666,28 @@ func TestAutomationRepository_CreateAutomationTrigger(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestAutomationRepository_CreateAutomationTrigger_Inactivity(t *testing.T) {
+	db, mock, repo := setupAutomationMock(t)
+	defer func() { _ = db.Close() }()
+
+	automation := createTestAutomation("auto-123", "workspace-123")
+	automation.Trigger = &domain.TimelineTriggerConfig{
+		EventKind:      "inactivity",
+		Frequency:      domain.TriggerFrequencyEveryTime,
+		InactivityDays: 30,
+	}
+
+	// Inactivity automations are enrolled by the scheduler: stale triggers are dropped, none is created
+	mock.ExpectExec("DROP TRIGGER IF EXISTS").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DROP FUNCTION IF EXISTS").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.CreateAutomationTrigger(context.Background(), "workspace-123", automation)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestAutomationRepository_DropAutomationTrigger(t *testing.T) {
 	db, mock, repo := setupAutomationMock(t)
 	defer func() { _ = db.Close() }()
@@ -501,10 +731,10 @@ func TestAutomationRepository_GetContactAutomation(t *testing.T) {
 	rows := sqlmock.NewRows([]string{
 		"id", "automation_id", "contact_email", "current_node_id", "status",
 		"exit_reason", "entered_at", "scheduled_at", "context", "retry_count", "last_error",
-		"last_retry_at", "max_retries",
+		"last_retry_at", "max_retries", "automation_version",
 	}).AddRow(
 		id, "auto-123", "test@example.com", "node-1", "active",
-		nil, now, now, contextJSON, 0, nil, nil, 3,
+		nil, now, now, contextJSON, 0, nil, nil, 3, 1,
 	)
 
 	mock.ExpectQuery("SELECT .* FROM contact_automations WHERE id = .*").
@@ -546,10 +776,10 @@ func TestAutomationRepository_GetContactAutomationByEmail(t *testing.T) {
 	rows := sqlmock.NewRows([]string{
 		"id", "automation_id", "contact_email", "current_node_id", "status",
 		"exit_reason", "entered_at", "scheduled_at", "context", "retry_count", "last_error",
-		"last_retry_at", "max_retries",
+		"last_retry_at", "max_retries", "automation_version",
 	}).AddRow(
 		"ca-123", automationID, email, nil, "active",
-		nil, now, nil, contextJSON, 0, nil, nil, 3,
+		nil, now, nil, contextJSON, 0, nil, nil, 3, 1,
 	)
 
 	mock.ExpectQuery("SELECT .* FROM contact_automations WHERE automation_id = .* AND contact_email = .*").
@@ -590,13 +820,13 @@ func TestAutomationRepository_ListContactAutomations(t *testing.T) {
 	rows := sqlmock.NewRows([]string{
 		"id", "automation_id", "contact_email", "current_node_id", "status",
 		"exit_reason", "entered_at", "scheduled_at", "context", "retry_count", "last_error",
-		"last_retry_at", "max_retries",
+		"last_retry_at", "max_retries", "automation_version",
 	}).AddRow(
 		"ca-1", "auto-123", "user1@example.com", nil, "active",
-		nil, now, nil, contextJSON, 0, nil, nil, 3,
+		nil, now, nil, contextJSON, 0, nil, nil, 3, 1,
 	).AddRow(
 		"ca-2", "auto-123", "user2@example.com", nil, "active",
-		nil, now, nil, contextJSON, 0, nil, nil, 3,
+		nil, now, nil, contextJSON, 0, nil, nil, 3, 1,
 	)
 
 	mock.ExpectQuery("SELECT .* FROM contact_automations WHERE").
@@ -676,13 +906,13 @@ func TestAutomationRepository_GetScheduledContactAutomations(t *testing.T) {
 	rows := sqlmock.NewRows([]string{
 		"id", "automation_id", "contact_email", "current_node_id", "status",
 		"exit_reason", "entered_at", "scheduled_at", "context", "retry_count", "last_error",
-		"last_retry_at", "max_retries",
+		"last_retry_at", "max_retries", "automation_version",
 	}).AddRow(
 		"ca-1", "auto-123", "user1@example.com", "node-1", "active",
-		nil, now, now, contextJSON, 0, nil, nil, 3,
+		nil, now, now, contextJSON, 0, nil, nil, 3, 1,
 	)
 
-	mock.ExpectQuery("SELECT ca.* FROM contact_automations ca JOIN automations a").
+	mock.ExpectQuery("SELECT ca.* FROM contact_automations ca JOIN .* FROM automations a CROSS JOIN LATERAL").
 		WillReturnRows(rows)
 
 	cas, err := repo.GetScheduledContactAutomations(ctx, workspaceID, now, limit)
@@ -692,11 +922,11 @@ func TestAutomationRepository_GetScheduledContactAutomations(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 
 	// Test empty result
-	mock.ExpectQuery("SELECT ca.* FROM contact_automations ca JOIN automations a").
+	mock.ExpectQuery("SELECT ca.* FROM contact_automations ca JOIN .* FROM automations a CROSS JOIN LATERAL").
 		WillReturnRows(sqlmock.NewRows([]string{
 			"id", "automation_id", "contact_email", "current_node_id", "status",
 			"exit_reason", "entered_at", "scheduled_at", "context", "retry_count", "last_error",
-			"last_retry_at", "max_retries",
+			"last_retry_at", "max_retries", "automation_version",
 		}))
 
 	cas, err = repo.GetScheduledContactAutomations(ctx, workspaceID, now, limit)
@@ -705,7 +935,7 @@ func TestAutomationRepository_GetScheduledContactAutomations(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 
 	// Test database error
-	mock.ExpectQuery("SELECT ca.* FROM contact_automations ca JOIN automations a").
+	mock.ExpectQuery("SELECT ca.* FROM contact_automations ca JOIN .* FROM automations a CROSS JOIN LATERAL").
 		WillReturnError(fmt.Errorf("database error"))
 
 	cas, err = repo.GetScheduledContactAutomations(ctx, workspaceID, now, limit)
@@ -715,6 +945,36 @@ func TestAutomationRepository_GetScheduledContactAutomations(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestAutomationRepository_GetScheduledContactAutomations_RoundRobin(t *testing.T) {
+	db, mock, repo := setupAutomationMock(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+	contextJSON, _ := json.Marshal(map[string]interface{}{})
+
+	// Contacts are numbered within their automation and picked turn by turn, each
+	// automation contributing at most a batch worth of due contacts
+	rows := sqlmock.NewRows([]string{
+		"id", "automation_id", "contact_email", "current_node_id", "status",
+		"exit_reason", "entered_at", "scheduled_at", "context", "retry_count", "last_error",
+		"last_retry_at", "max_retries", "automation_version",
+	}).
+		AddRow("ca-1", "auto-a", "a1@example.com", "node-1", "active", nil, now, now, contextJSON, 0, nil, nil, 3, 1).
+		AddRow("ca-2", "auto-b", "b1@example.com", "node-1", "active", nil, now, now, contextJSON, 0, nil, nil, 3, 1)
+
+	mock.ExpectQuery(`ROW_NUMBER\(\) OVER \(PARTITION BY a.id ORDER BY next.scheduled_at ASC\) AS turn.*CROSS JOIN LATERAL \(.*ORDER BY c.scheduled_at ASC\s+LIMIT \$2\s+\) next.*ORDER BY due.turn ASC, ca.scheduled_at ASC LIMIT \$2 FOR UPDATE OF ca SKIP LOCKED`).
+		WithArgs(now, 2).
+		WillReturnRows(rows)
+
+	cas, err := repo.GetScheduledContactAutomations(ctx, "workspace-123", now, 2)
+	require.NoError(t, err)
+	require.Len(t, cas, 2)
+	assert.Equal(t, "auto-a", cas[0].AutomationID)
+	assert.Equal(t, "auto-b", cas[1].AutomationID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestAutomationRepository_CreateNodeExecution(t *testing.T) {
 	db, mock, repo := setupAutomationMock(t)
 	defer func() { _ = db.Close() }()
@@ -932,6 +1192,601 @@ func TestAutomationRepository_UpdateAutomationStats(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestAutomationRepository_EnrollContact(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	email := "test@example.com"
+	automation := createTestAutomation("auto-123", workspaceID)
+
+	expectCheck := func(mock sqlmock.Sqlmock, contactExists, alreadyActive bool) {
+		mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM contacts WHERE email = \\$1\\)").
+			WithArgs(email, automation.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"contact_exists", "already_active"}).AddRow(contactExists, alreadyActive))
+	}
+	expectCount := func(mock sqlmock.Sqlmock, count int) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM contact_automations").
+			WithArgs(automation.ID, email).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+	}
+	expectEnroll := func(mock sqlmock.Sqlmock) {
+		mock.ExpectExec("SELECT automation_enroll_contact").
+			WithArgs(automation.ID, email, automation.RootNodeID, "once").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	t.Run("enrolls the contact", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectBegin()
+		expectCheck(mock, true, false)
+		expectCount(mock, 0)
+		expectEnroll(mock)
+		expectCount(mock, 1)
+		mock.ExpectCommit()
+
+		enrolled, err := repo.EnrollContact(ctx, workspaceID, automation, email)
+		require.NoError(t, err)
+		assert.True(t, enrolled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("skips a contact already triggered for a once automation", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectBegin()
+		expectCheck(mock, true, false)
+		expectCount(mock, 1)
+		expectEnroll(mock)
+		expectCount(mock, 1)
+		mock.ExpectCommit()
+
+		enrolled, err := repo.EnrollContact(ctx, workspaceID, automation, email)
+		require.NoError(t, err)
+		assert.False(t, enrolled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("skips a contact already active", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectBegin()
+		expectCheck(mock, true, true)
+		mock.ExpectCommit()
+
+		enrolled, err := repo.EnrollContact(ctx, workspaceID, automation, email)
+		require.NoError(t, err)
+		assert.False(t, enrolled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("contact not found", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectBegin()
+		expectCheck(mock, false, false)
+		mock.ExpectRollback()
+
+		enrolled, err := repo.EnrollContact(ctx, workspaceID, automation, email)
+		assert.ErrorIs(t, err, domain.ErrEnrollmentContactNotFound)
+		assert.False(t, enrolled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAutomationRepository_ReenrollContactAutomation(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	email := "test@example.com"
+	automation := createTestAutomation("auto-123", workspaceID)
+	automation.Trigger.Frequency = domain.TriggerFrequencyEveryTime
+
+	expectLock := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery("SELECT id FROM contact_automations WHERE id = \\$1 AND status = 'active' FOR UPDATE").
+			WithArgs("ca-1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("ca-1"))
+	}
+	expectReenrollCount := func(mock sqlmock.Sqlmock, count int) {
+		mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM contact_automations WHERE automation_id = \\$1 AND contact_email = \\$2 AND exit_reason = \\$3").
+			WithArgs(automation.ID, email, domain.ReenrolledExitReason).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+	}
+
+	t.Run("exits the run and enrolls the contact again", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectBegin()
+		expectLock(mock)
+		expectReenrollCount(mock, 0)
+		mock.ExpectExec("UPDATE contact_automations SET status = 'exited'").
+			WithArgs(domain.ReenrolledExitReason, "ca-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("SELECT automation_enroll_contact").
+			WithArgs(automation.ID, email, automation.RootNodeID, "every_time").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		reenrolled, err := repo.ReenrollContactAutomation(ctx, workspaceID, automation, "ca-1", email, 2)
+		require.NoError(t, err)
+		assert.True(t, reenrolled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("stops at the re-enrollment limit", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectBegin()
+		expectLock(mock)
+		expectReenrollCount(mock, 2)
+		mock.ExpectCommit()
+
+		reenrolled, err := repo.ReenrollContactAutomation(ctx, workspaceID, automation, "ca-1", email, 2)
+		require.NoError(t, err)
+		assert.False(t, reenrolled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("skips a run that is no longer active", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM contact_automations").
+			WithArgs("ca-1").
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectCommit()
+
+		reenrolled, err := repo.ReenrollContactAutomation(ctx, workspaceID, automation, "ca-1", email, 2)
+		require.NoError(t, err)
+		assert.False(t, reenrolled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("skips a once automation", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		reenrolled, err := repo.ReenrollContactAutomation(ctx, workspaceID, createTestAutomation("auto-123", workspaceID), "ca-1", email, 2)
+		require.NoError(t, err)
+		assert.False(t, reenrolled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("enroll error rolls back", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectBegin()
+		expectLock(mock)
+		expectReenrollCount(mock, 0)
+		mock.ExpectExec("UPDATE contact_automations SET status = 'exited'").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("SELECT automation_enroll_contact").
+			WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		_, err := repo.ReenrollContactAutomation(ctx, workspaceID, automation, "ca-1", email, 2)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to enroll contact")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAutomationRepository_DeleteFinishedContactAutomations(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	before := time.Now().Add(-90 * 24 * time.Hour)
+
+	t.Run("deletes finished contact automations", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("DELETE FROM contact_automations").
+			WithArgs(before, 500).
+			WillReturnResult(sqlmock.NewResult(0, 42))
+
+		deleted, err := repo.DeleteFinishedContactAutomations(ctx, workspaceID, before, 500)
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("DELETE FROM contact_automations").
+			WithArgs(before, 500).
+			WillReturnError(fmt.Errorf("connection lost"))
+
+		deleted, err := repo.DeleteFinishedContactAutomations(ctx, workspaceID, before, 500)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete finished contact automations")
+		assert.Equal(t, int64(0), deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAutomationRepository_GetInactiveContactEmails(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	inactiveSince := time.Now().Add(-30 * 24 * time.Hour)
+
+	t.Run("returns inactive contacts", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		automation := createTestAutomation("auto-1", workspaceID)
+		automation.ListID = ""
+
+		mock.ExpectQuery("SELECT c.email FROM contacts c").
+			WithArgs(inactiveSince, "", inactiveSince, "open_email", "click_email", "auto-1", inactiveSince).
+			WillReturnRows(sqlmock.NewRows([]string{"email"}).
+				AddRow("a@example.com").
+				AddRow("b@example.com"))
+
+		emails, err := repo.GetInactiveContactEmails(ctx, workspaceID, automation, inactiveSince, "", 500)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a@example.com", "b@example.com"}, emails)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("restricts to list subscribers", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		automation := createTestAutomation("auto-1", workspaceID)
+		automation.ListID = "list-1"
+
+		mock.ExpectQuery("FROM contact_lists cl").
+			WithArgs(inactiveSince, "a@example.com", inactiveSince, "open_email", "click_email", "auto-1", inactiveSince, "list-1").
+			WillReturnRows(sqlmock.NewRows([]string{"email"}))
+
+		emails, err := repo.GetInactiveContactEmails(ctx, workspaceID, automation, inactiveSince, "a@example.com", 500)
+		require.NoError(t, err)
+		assert.Empty(t, emails)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("applies the trigger prerequisite", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		automation := createTestAutomation("auto-1", workspaceID)
+		automation.ListID = ""
+		automation.Trigger = &domain.TimelineTriggerConfig{
+			EventKind:      "inactivity",
+			Frequency:      domain.TriggerFrequencyEveryTime,
+			InactivityDays: 30,
+			Prerequisite:   &domain.TriggerPrerequisite{AutomationID: "onboarding"},
+		}
+
+		mock.ExpectQuery("prereq.automation_id = 'onboarding'").
+			WithArgs(inactiveSince, "", inactiveSince, "open_email", "click_email", "auto-1", inactiveSince).
+			WillReturnRows(sqlmock.NewRows([]string{"email"}).AddRow("a@example.com"))
+
+		emails, err := repo.GetInactiveContactEmails(ctx, workspaceID, automation, inactiveSince, "", 500)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a@example.com"}, emails)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		automation := createTestAutomation("auto-1", workspaceID)
+		automation.ListID = ""
+
+		mock.ExpectQuery("SELECT c.email FROM contacts c").
+			WillReturnError(fmt.Errorf("connection lost"))
+
+		_, err := repo.GetInactiveContactEmails(ctx, workspaceID, automation, inactiveSince, "", 500)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get inactive contacts")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAutomationRepository_ListWaitingVersions(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+
+	t.Run("returns the versions of the waiting contacts", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(`SELECT DISTINCT COALESCE\(automation_version, \$2\)\s+FROM contact_automations`).
+			WithArgs("auto-123", 3).
+			WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(3).AddRow(2))
+
+		versions, err := repo.ListWaitingVersions(ctx, workspaceID, "auto-123", 3)
+		require.NoError(t, err)
+		assert.Equal(t, []int{3, 2}, versions)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery("SELECT DISTINCT COALESCE").
+			WillReturnError(fmt.Errorf("connection lost"))
+
+		versions, err := repo.ListWaitingVersions(ctx, workspaceID, "auto-123", 3)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get waiting versions")
+		assert.Nil(t, versions)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAutomationRepository_ListContactAutomationsMeetingGoal(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	columns := []string{
+		"id", "automation_id", "contact_email", "current_node_id", "status",
+		"exit_reason", "entered_at", "scheduled_at", "context", "retry_count", "last_error",
+		"last_retry_at", "max_retries", "automation_version",
+	}
+	filter := domain.GoalContactFilter{
+		AutomationID:   "auto-123",
+		Version:        2,
+		CurrentVersion: 3,
+		ContactsSQL:    "SELECT email FROM contacts WHERE country = $1",
+		ContactsArgs:   []interface{}{"FR"},
+		AfterID:        "ca-100",
+		Limit:          500,
+	}
+
+	t.Run("automation-wide goal", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		later := time.Now().Add(time.Hour)
+		// The goal conditions keep $1, the filter follows them
+		mock.ExpectQuery(`FROM contact_automations ca\s+WHERE ca.automation_id = \$2.*`+
+			`COALESCE\(ca.automation_version, \$3\) = \$4\s+AND ca.id > \$5\s+`+
+			`AND ca.contact_email IN \(SELECT email FROM contacts WHERE country = \$1\)\s+ORDER BY ca.id\s+LIMIT \$6`).
+			WithArgs("FR", "auto-123", 3, 2, "ca-100", 500).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(
+				"ca-101", "auto-123", "test@example.com", "delay-1", "active",
+				nil, time.Now(), later, nil, 0, nil, nil, 3, 2,
+			))
+
+		cas, err := repo.ListContactAutomationsMeetingGoal(ctx, workspaceID, filter)
+		require.NoError(t, err)
+		require.Len(t, cas, 1)
+		assert.Equal(t, "ca-101", cas[0].ID)
+		assert.Equal(t, "test@example.com", cas[0].ContactEmail)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("branch goal only matches contacts that passed through it", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		branch := filter
+		branch.PassedNodeID = "goal-1"
+		mock.ExpectQuery(`AND EXISTS \(\s+SELECT 1 FROM automation_node_executions ne\s+`+
+			`WHERE ne.contact_automation_id = ca.id AND ne.node_id = \$6\s+\)\s+ORDER BY ca.id\s+LIMIT \$7`).
+			WithArgs("FR", "auto-123", 3, 2, "ca-100", "goal-1", 500).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		cas, err := repo.ListContactAutomationsMeetingGoal(ctx, workspaceID, branch)
+		require.NoError(t, err)
+		assert.Empty(t, cas)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery("FROM contact_automations ca").
+			WillReturnError(fmt.Errorf("connection lost"))
+
+		cas, err := repo.ListContactAutomationsMeetingGoal(ctx, workspaceID, filter)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get contacts meeting goal")
+		assert.Nil(t, cas)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAutomationRepository_GetSegmentContactEmails(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+
+	t.Run("returns a page of segment members after the cursor", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery("SELECT email FROM contact_segments WHERE segment_id = \\$1 AND email > \\$2 ORDER BY email LIMIT 500").
+			WithArgs("seg-1", "a@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"email"}).
+				AddRow("b@example.com").
+				AddRow("c@example.com"))
+
+		emails, err := repo.GetSegmentContactEmails(ctx, workspaceID, "seg-1", "a@example.com", 500)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b@example.com", "c@example.com"}, emails)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery("SELECT email FROM contact_segments").
+			WillReturnError(fmt.Errorf("connection reset"))
+
+		emails, err := repo.GetSegmentContactEmails(ctx, workspaceID, "seg-1", "", 500)
+		assert.Error(t, err)
+		assert.Nil(t, emails)
+		assert.Contains(t, err.Error(), "failed to get segment contacts")
+	})
+}
+
+func TestAutomationRepository_ClearTriggerLog(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+
+	t.Run("deletes the trigger log entry", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("DELETE FROM automation_trigger_log").
+			WithArgs("auto-1", "test@example.com").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.ClearTriggerLog(ctx, workspaceID, "auto-1", "test@example.com")
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("DELETE FROM automation_trigger_log").
+			WithArgs("auto-1", "test@example.com").
+			WillReturnError(fmt.Errorf("connection lost"))
+
+		err := repo.ClearTriggerLog(ctx, workspaceID, "auto-1", "test@example.com")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to clear trigger log")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAutomationRepository_RecordNodeExecutionStats(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	nodes := map[string]*domain.AutomationNodeExecutionStats{
+		"node-1": {Executions: 3, Failures: 1, TotalDurationMs: 120},
+	}
+
+	t.Run("adds node stats in a single update", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("UPDATE automations SET stats = .*jsonb_build_object\\('nodes'").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "auto-1", workspaceID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.RecordNodeExecutionStats(ctx, workspaceID, "auto-1", nodes)
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing to record", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		err := repo.RecordNodeExecutionStats(ctx, workspaceID, "auto-1", nil)
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("automation not found", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("UPDATE automations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.RecordNodeExecutionStats(ctx, workspaceID, "auto-1", nodes)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "automation not found")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("UPDATE automations").
+			WillReturnError(fmt.Errorf("database error"))
+
+		err := repo.RecordNodeExecutionStats(ctx, workspaceID, "auto-1", nodes)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to record node execution stats")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAutomationRepository_RecordRevenue(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	recordedAt := time.Now().UTC()
+	revenue := &domain.AutomationRevenue{
+		ID:                  "rev-1",
+		AutomationID:        "auto-1",
+		ContactAutomationID: "ca-1",
+		NodeID:              "node-1",
+		ContactEmail:        "test@example.com",
+		Amount:              49.9,
+		Currency:            "USD",
+		RecordedAt:          recordedAt,
+	}
+
+	t.Run("inserts record and updates stats", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO automation_revenue").
+			WithArgs("rev-1", "auto-1", "ca-1", "node-1", "test@example.com", 49.9, "USD", recordedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE automations").
+			WithArgs("USD", 49.9, sqlmock.AnyArg(), "auto-1", workspaceID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.RecordRevenue(ctx, workspaceID, revenue)
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("automation not found", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO automation_revenue").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE automations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.RecordRevenue(ctx, workspaceID, revenue)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "automation not found")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("insert error", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO automation_revenue").
+			WillReturnError(fmt.Errorf("connection lost"))
+		mock.ExpectRollback()
+
+		err := repo.RecordRevenue(ctx, workspaceID, revenue)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to insert revenue record")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 // Helper function for int64 pointer
 func int64Ptr(i int64) *int64 {
 	return &i
@@ -958,11 +1813,20 @@ func TestAutomationRepository_CreateTx(t *testing.T) {
 			automation.TriggerSQL,
 			automation.RootNodeID,
 			sqlmock.AnyArg(), // nodes
+			sqlmock.AnyArg(), // tags JSON
 			sqlmock.AnyArg(), // stats
 			sqlmock.AnyArg(), // created_at
 			sqlmock.AnyArg(), // updated_at
+			1,                // version
+			false,            // log_webhooks
+			nil,              // enrollment_ramp
+			sqlmock.AnyArg(), // metadata
+			nil,              // retry_backoff
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO automation_versions").
+		WithArgs(automation.ID, 1, automation.RootNodeID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
 	tx, err := db.Begin()
@@ -995,10 +1859,10 @@ func TestAutomationRepository_GetByIDTx(t *testing.T) {
 	mock.ExpectBegin()
 	rows := sqlmock.NewRows([]string{
 		"id", "workspace_id", "name", "status", "list_id", "trigger_config",
-		"trigger_sql", "root_node_id", "nodes", "stats", "created_at", "updated_at", "deleted_at",
+		"trigger_sql", "root_node_id", "nodes", "tags", "stats", "created_at", "updated_at", "deleted_at", "version", "log_webhooks", "enrollment_ramp", "metadata", "retry_backoff",
 	}).AddRow(
 		automationID, workspaceID, "Test", "draft", "list-123",
-		triggerJSON, nil, "node-root", nodesJSON, statsJSON, now, now, nil,
+		triggerJSON, nil, "node-root", nodesJSON, []byte(`["onboarding"]`), statsJSON, now, now, nil, 1, false, nil, nil, nil,
 	)
 	mock.ExpectQuery("SELECT .* FROM automations WHERE.*deleted_at IS NULL").
 		WillReturnRows(rows)
@@ -1040,10 +1904,10 @@ func TestAutomationRepository_GetByID_JSONUnmarshalError(t *testing.T) {
 	// Invalid JSON for trigger_config
 	rows := sqlmock.NewRows([]string{
 		"id", "workspace_id", "name", "status", "list_id", "trigger_config",
-		"trigger_sql", "root_node_id", "nodes", "stats", "created_at", "updated_at", "deleted_at",
+		"trigger_sql", "root_node_id", "nodes", "tags", "stats", "created_at", "updated_at", "deleted_at", "version", "log_webhooks", "enrollment_ramp", "metadata", "retry_backoff",
 	}).AddRow(
 		automationID, workspaceID, "Test", "draft", "list-123",
-		"invalid json", nil, "node-root", "[]", "{}", now, now, nil,
+		"invalid json", nil, "node-root", "[]", "[]", "{}", now, now, nil, 1, false, nil, nil, nil,
 	)
 
 	mock.ExpectQuery("SELECT .* FROM automations WHERE.*deleted_at IS NULL").
@@ -1077,10 +1941,10 @@ func TestAutomationRepository_List_JSONUnmarshalError(t *testing.T) {
 	// Invalid JSON for trigger_config
 	rows := sqlmock.NewRows([]string{
 		"id", "workspace_id", "name", "status", "list_id", "trigger_config",
-		"trigger_sql", "root_node_id", "nodes", "stats", "created_at", "updated_at", "deleted_at",
+		"trigger_sql", "root_node_id", "nodes", "tags", "stats", "created_at", "updated_at", "deleted_at", "version", "log_webhooks", "enrollment_ramp", "metadata", "retry_backoff",
 	}).AddRow(
 		"auto-1", workspaceID, "Auto 1", "draft", "list-123",
-		"invalid json", nil, "node-1", "[]", "{}", now, now, nil,
+		"invalid json", nil, "node-1", "[]", "[]", "{}", now, now, nil, 1, false, nil, nil, nil,
 	)
 
 	mock.ExpectQuery("SELECT .* FROM automations.*deleted_at IS NULL").
@@ -1168,7 +2032,7 @@ func TestAutomationRepository_GetScheduledContactAutomations_ScanError(t *testin
 	rows := sqlmock.NewRows([]string{"id", "automation_id"}).
 		AddRow("ca-1", "auto-123")
 
-	mock.ExpectQuery("SELECT ca.* FROM contact_automations ca JOIN automations a").
+	mock.ExpectQuery("SELECT ca.* FROM contact_automations ca JOIN .* FROM automations a CROSS JOIN LATERAL").
 		WillReturnRows(rows)
 
 	cas, err := repo.GetScheduledContactAutomations(ctx, workspaceID, now, limit)
@@ -1209,6 +2073,8 @@ func TestAutomationRepository_Update_RowsAffectedError(t *testing.T) {
 	workspaceID := "workspace-123"
 	automation := createTestAutomation("auto-123", workspaceID)
 
+	mock.ExpectBegin()
+	expectWorkflowLock(mock, 1, automation.RootNodeID, automation.Nodes)
 	mock.ExpectExec("UPDATE automations SET").
 		WithArgs(
 			automation.Name,
@@ -1218,11 +2084,18 @@ func TestAutomationRepository_Update_RowsAffectedError(t *testing.T) {
 			automation.TriggerSQL,
 			automation.RootNodeID,
 			sqlmock.AnyArg(), // nodes
+			sqlmock.AnyArg(), // tags JSON
+			sqlmock.AnyArg(), // version
+			sqlmock.AnyArg(), // log_webhooks
+			sqlmock.AnyArg(), // enrollment_ramp
+			sqlmock.AnyArg(), // metadata
+			nil,              // retry_backoff
 			sqlmock.AnyArg(), // updated_at
 			automation.ID,
 			workspaceID,
 		).
 		WillReturnResult(sqlmock.NewErrorResult(fmt.Errorf("rows affected error")))
+	mock.ExpectRollback()
 
 	err := repo.Update(ctx, workspaceID, automation)
 	assert.Error(t, err)
@@ -1405,10 +2278,10 @@ func TestAutomationRepository_List_ExcludesDeleted(t *testing.T) {
 	// Data query should include deleted_at IS NULL
 	rows := sqlmock.NewRows([]string{
 		"id", "workspace_id", "name", "status", "list_id", "trigger_config",
-		"trigger_sql", "root_node_id", "nodes", "stats", "created_at", "updated_at", "deleted_at",
+		"trigger_sql", "root_node_id", "nodes", "tags", "stats", "created_at", "updated_at", "deleted_at", "version", "log_webhooks", "enrollment_ramp", "metadata", "retry_backoff",
 	}).AddRow(
 		"auto-1", workspaceID, "Auto 1", "draft", "list-123",
-		triggerJSON, nil, "node-1", nodesJSON, statsJSON, now, now, nil,
+		triggerJSON, nil, "node-1", nodesJSON, []byte(`["onboarding"]`), statsJSON, now, now, nil, 1, false, nil, nil, nil,
 	)
 
 	mock.ExpectQuery("SELECT .* FROM automations WHERE.*deleted_at IS NULL").
@@ -1453,13 +2326,13 @@ func TestAutomationRepository_List_IncludeDeleted(t *testing.T) {
 	// Data query should NOT filter by deleted_at
 	rows := sqlmock.NewRows([]string{
 		"id", "workspace_id", "name", "status", "list_id", "trigger_config",
-		"trigger_sql", "root_node_id", "nodes", "stats", "created_at", "updated_at", "deleted_at",
+		"trigger_sql", "root_node_id", "nodes", "tags", "stats", "created_at", "updated_at", "deleted_at", "version", "log_webhooks", "enrollment_ramp", "metadata", "retry_backoff",
 	}).AddRow(
 		"auto-1", workspaceID, "Auto 1", "draft", "list-123",
-		triggerJSON, nil, "node-1", nodesJSON, statsJSON, now, now, nil,
+		triggerJSON, nil, "node-1", nodesJSON, []byte(`["onboarding"]`), statsJSON, now, now, nil, 1, false, nil, nil, nil,
 	).AddRow(
 		"auto-2", workspaceID, "Auto 2 (Deleted)", "draft", "list-123",
-		triggerJSON, nil, "node-2", nodesJSON, statsJSON, now, now, deletedAt,
+		triggerJSON, nil, "node-2", nodesJSON, []byte(`["onboarding"]`), statsJSON, now, now, deletedAt, 1, false, nil, nil, nil,
 	)
 
 	mock.ExpectQuery("SELECT .* FROM automations WHERE").
@@ -1525,3 +2398,190 @@ func TestAutomationRepository_ListContactAutomations_DataQueryError(t *testing.T
 	assert.Nil(t, cas)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestAutomationRepository_List_TagFilter(t *testing.T) {
+	db, mock, repo := setupAutomationMock(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	now := time.Now().UTC()
+
+	triggerJSON, _ := json.Marshal(&domain.TimelineTriggerConfig{
+		EventKind: "email.opened",
+		Frequency: domain.TriggerFrequencyOnce,
+	})
+	nodesJSON, _ := json.Marshal([]*domain.AutomationNode{})
+	statsJSON, _ := json.Marshal(&domain.AutomationStats{})
+
+	filter := domain.AutomationFilter{
+		Tag:   "onboarding",
+		Limit: 10,
+	}
+
+	mock.ExpectQuery(`SELECT COUNT.*FROM automations.*tags @> \$\d+::jsonb`).
+		WithArgs(workspaceID, `["onboarding"]`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	mock.ExpectQuery(`SELECT .* FROM automations WHERE.*tags @> \$\d+::jsonb`).
+		WithArgs(workspaceID, `["onboarding"]`).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "workspace_id", "name", "status", "list_id", "trigger_config",
+			"trigger_sql", "root_node_id", "nodes", "tags", "stats", "created_at", "updated_at", "deleted_at", "version", "log_webhooks", "enrollment_ramp", "metadata", "retry_backoff",
+		}).AddRow(
+			"auto-1", workspaceID, "Auto 1", "draft", "list-123",
+			triggerJSON, nil, "node-1", nodesJSON, []byte(`["onboarding","welcome"]`), statsJSON, now, now, nil, 1, false, nil, nil, nil,
+		))
+
+	automations, count, err := repo.List(ctx, workspaceID, filter)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, automations, 1)
+	assert.Equal(t, []string{"onboarding", "welcome"}, automations[0].Tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAutomationRepository_UpdateTags(t *testing.T) {
+	db, mock, repo := setupAutomationMock(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	automationID := "auto-123"
+
+	// Test successful update
+	mock.ExpectExec("UPDATE automations SET tags").
+		WithArgs([]byte(`["onboarding","vip"]`), sqlmock.AnyArg(), automationID, workspaceID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.UpdateTags(ctx, workspaceID, automationID, []string{"onboarding", "vip"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Test nil tags are stored as an empty array
+	mock.ExpectExec("UPDATE automations SET tags").
+		WithArgs([]byte(`[]`), sqlmock.AnyArg(), automationID, workspaceID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.UpdateTags(ctx, workspaceID, automationID, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Test not found
+	mock.ExpectExec("UPDATE automations SET tags").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.UpdateTags(ctx, workspaceID, automationID, []string{"vip"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "automation not found")
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Test database error
+	mock.ExpectExec("UPDATE automations SET tags").
+		WillReturnError(fmt.Errorf("database error"))
+
+	err = repo.UpdateTags(ctx, workspaceID, automationID, []string{"vip"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to update automation tags")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAutomationRepository_ListTags(t *testing.T) {
+	db, mock, repo := setupAutomationMock(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+
+	// Test successful list
+	mock.ExpectQuery("SELECT DISTINCT jsonb_array_elements_text\\(tags\\) AS tag FROM automations").
+		WithArgs(workspaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"tag"}).AddRow("onboarding").AddRow("vip"))
+
+	tags, err := repo.ListTags(ctx, workspaceID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"onboarding", "vip"}, tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Test empty result
+	mock.ExpectQuery("SELECT DISTINCT jsonb_array_elements_text").
+		WillReturnRows(sqlmock.NewRows([]string{"tag"}))
+
+	tags, err = repo.ListTags(ctx, workspaceID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Test database error
+	mock.ExpectQuery("SELECT DISTINCT jsonb_array_elements_text").
+		WillReturnError(fmt.Errorf("database error"))
+
+	tags, err = repo.ListTags(ctx, workspaceID)
+	assert.Error(t, err)
+	assert.Nil(t, tags)
+	assert.Contains(t, err.Error(), "failed to list automation tags")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAutomationRepository_ReserveThrottleSlot(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	now := time.Date(2026, 1, 1, 12, 30, 45, 0, time.UTC)
+	minute := time.Date(2026, 1, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		windowStart time.Time
+		count       int
+		wantSlot    time.Time
+		wantCount   int
+	}{
+		{"new counter", minute, 0, minute, 1},
+		{"current minute has room", minute, 4, minute, 5},
+		{"current minute is full", minute, 5, minute.Add(time.Minute), 1},
+		{"later minute has room", minute.Add(2 * time.Minute), 3, minute.Add(2 * time.Minute), 4},
+		{"later minute is full", minute.Add(2 * time.Minute), 5, minute.Add(3 * time.Minute), 1},
+		{"counter from a past minute resets", minute.Add(-10 * time.Minute), 5, minute, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, repo := setupAutomationMock(t)
+			defer func() { _ = db.Close() }()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("INSERT INTO automation_throttle_windows").
+				WithArgs("auto-1", "throttle-1", minute).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery(`SELECT window_start, count FROM automation_throttle_windows.*FOR UPDATE`).
+				WithArgs("auto-1", "throttle-1").
+				WillReturnRows(sqlmock.NewRows([]string{"window_start", "count"}).AddRow(tt.windowStart, tt.count))
+			mock.ExpectExec("UPDATE automation_throttle_windows SET window_start").
+				WithArgs(tt.wantSlot, tt.wantCount, "auto-1", "throttle-1").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			slot, err := repo.ReserveThrottleSlot(ctx, workspaceID, "auto-1", "throttle-1", 5, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSlot, slot)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("lock error", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO automation_throttle_windows").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT window_start, count FROM automation_throttle_windows").
+			WillReturnError(fmt.Errorf("lock timeout"))
+		mock.ExpectRollback()
+
+		_, err := repo.ReserveThrottleSlot(ctx, workspaceID, "auto-1", "throttle-1", 5, now)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to lock throttle counter")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -227,6 +227,11 @@ func (r *broadcastRepository) UpdateBroadcastTx(ctx context.Context, tx *sql.Tx,
 	// Update the timestamp
 	broadcast.UpdatedAt = time.Now().UTC()
 
+	// data_feed is pinned once the broadcast has completed, so the global feed data
+	// it was sent with stays available for audit. The completing update itself still
+	// writes it (completed_at is NULL until then), and processed broadcasts are excluded
+	// below, but a processed broadcast paused through UpdateBroadcastStatusTx keeps its
+	// completed_at with a non-terminal status, which the CASE guards.
 	query := `
 		UPDATE broadcasts SET
 			name = $3,
@@ -246,7 +251,7 @@ func (r *broadcastRepository) UpdateBroadcastTx(ctx context.Context, tx *sql.Tx,
 			paused_at = $17,
 			pause_reason = $18,
 			enqueued_count = $19,
-			data_feed = $20
+			data_feed = CASE WHEN completed_at IS NULL THEN $20 ELSE data_feed END
 		WHERE id = $1 AND workspace_id = $2
 			AND status != 'cancelled'
 			AND status != 'processed'
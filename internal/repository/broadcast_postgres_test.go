@@ -1478,8 +1478,8 @@ func TestBroadcastRepository_UpdateBroadcast_WithDataFeed(t *testing.T) {
 	// Expect transaction begin
 	mock.ExpectBegin()
 
-	// Expect UPDATE query with the correct parameters
-	mock.ExpectExec("UPDATE broadcasts SET").
+	// Expect UPDATE query with the correct parameters, keeping data_feed once the broadcast has completed
+	mock.ExpectExec(`UPDATE broadcasts SET .* data_feed = CASE WHEN completed_at IS NULL THEN \$20 ELSE data_feed END`).
 		WithArgs(
 			broadcastID,
 			workspaceID,
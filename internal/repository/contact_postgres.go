@@ -34,6 +34,7 @@ var contactColumns = []string{
 	"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 	"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
 	"created_at", "updated_at", "db_created_at", "db_updated_at",
+	"messaging_hold_until",
 }
 
 // contactColumnsWithPrefix returns contact columns prefixed with a table alias
@@ -825,6 +826,7 @@ func (r *contactRepository) UpsertContact(ctx context.Context, workspaceID strin
 				"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 				"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
 				"created_at", "updated_at", "db_created_at", "db_updated_at",
+				"messaging_hold_until",
 			).
 			Values(
 				contact.Email, externalIDSQL, timezoneSQL, languageSQL,
@@ -835,6 +837,7 @@ func (r *contactRepository) UpsertContact(ctx context.Context, workspaceID strin
 				customDatetime1SQL, customDatetime2SQL, customDatetime3SQL, customDatetime4SQL, customDatetime5SQL,
 				customJSON1SQL, customJSON2SQL, customJSON3SQL, customJSON4SQL, customJSON5SQL,
 				createdAtValue.UTC(), updatedAtValue.UTC(), contact.DBCreatedAt, contact.DBUpdatedAt,
+				contactToNullTime(contact.MessagingHoldUntil),
 			)
 
 		insertQuery, insertArgs, err := insertBuilder.ToSql()
@@ -1067,40 +1070,41 @@ func (r *contactRepository) UpsertContact(ctx context.Context, workspaceID strin
 
 		// Build update query using squirrel
 		updateMap := sq.Eq{
-			"external_id":       externalIDSQL,
-			"timezone":          timezoneSQL,
-			"language":          languageSQL,
-			"first_name":        firstNameSQL,
-			"last_name":         lastNameSQL,
-			"full_name":         fullNameSQL,
-			"phone":             phoneSQL,
-			"address_line_1":    addressLine1SQL,
-			"address_line_2":    addressLine2SQL,
-			"country":           countrySQL,
-			"postcode":          postcodeSQL,
-			"state":             stateSQL,
-			"job_title":         jobTitleSQL,
-			"custom_string_1":   customString1SQL,
-			"custom_string_2":   customString2SQL,
-			"custom_string_3":   customString3SQL,
-			"custom_string_4":   customString4SQL,
-			"custom_string_5":   customString5SQL,
-			"custom_number_1":   customNumber1SQL,
-			"custom_number_2":   customNumber2SQL,
-			"custom_number_3":   customNumber3SQL,
-			"custom_number_4":   customNumber4SQL,
-			"custom_number_5":   customNumber5SQL,
-			"custom_datetime_1": customDatetime1SQL,
-			"custom_datetime_2": customDatetime2SQL,
-			"custom_datetime_3": customDatetime3SQL,
-			"custom_datetime_4": customDatetime4SQL,
-			"custom_datetime_5": customDatetime5SQL,
-			"custom_json_1":     customJSON1SQL,
-			"custom_json_2":     customJSON2SQL,
-			"custom_json_3":     customJSON3SQL,
-			"custom_json_4":     customJSON4SQL,
-			"custom_json_5":     customJSON5SQL,
-			"db_updated_at":     existingContact.DBUpdatedAt,
+			"external_id":          externalIDSQL,
+			"timezone":             timezoneSQL,
+			"language":             languageSQL,
+			"first_name":           firstNameSQL,
+			"last_name":            lastNameSQL,
+			"full_name":            fullNameSQL,
+			"phone":                phoneSQL,
+			"address_line_1":       addressLine1SQL,
+			"address_line_2":       addressLine2SQL,
+			"country":              countrySQL,
+			"postcode":             postcodeSQL,
+			"state":                stateSQL,
+			"job_title":            jobTitleSQL,
+			"custom_string_1":      customString1SQL,
+			"custom_string_2":      customString2SQL,
+			"custom_string_3":      customString3SQL,
+			"custom_string_4":      customString4SQL,
+			"custom_string_5":      customString5SQL,
+			"custom_number_1":      customNumber1SQL,
+			"custom_number_2":      customNumber2SQL,
+			"custom_number_3":      customNumber3SQL,
+			"custom_number_4":      customNumber4SQL,
+			"custom_number_5":      customNumber5SQL,
+			"custom_datetime_1":    customDatetime1SQL,
+			"custom_datetime_2":    customDatetime2SQL,
+			"custom_datetime_3":    customDatetime3SQL,
+			"custom_datetime_4":    customDatetime4SQL,
+			"custom_datetime_5":    customDatetime5SQL,
+			"custom_json_1":        customJSON1SQL,
+			"custom_json_2":        customJSON2SQL,
+			"custom_json_3":        customJSON3SQL,
+			"custom_json_4":        customJSON4SQL,
+			"custom_json_5":        customJSON5SQL,
+			"messaging_hold_until": contactToNullTime(existingContact.MessagingHoldUntil),
+			"db_updated_at":        existingContact.DBUpdatedAt,
 		}
 
 		// Always update updated_at to current time for updates
@@ -1193,7 +1197,7 @@ func (r *contactRepository) BulkUpsertContacts(ctx context.Context, workspaceID
 	// Build the multi-row INSERT statement
 	// We'll use a raw SQL query because squirrel doesn't handle complex ON CONFLICT well
 	var queryBuilder strings.Builder
-	args := make([]interface{}, 0, len(contacts)*36) // 36 fields per contact (db_created_at and db_updated_at are managed by DB)
+	args := make([]interface{}, 0, len(contacts)*37) // 37 fields per contact (db_created_at and db_updated_at are managed by DB)
 	argIndex := 1
 
 	queryBuilder.WriteString(`INSERT INTO contacts (
@@ -1204,7 +1208,7 @@ func (r *contactRepository) BulkUpsertContacts(ctx context.Context, workspaceID
 		custom_number_1, custom_number_2, custom_number_3, custom_number_4, custom_number_5,
 		custom_datetime_1, custom_datetime_2, custom_datetime_3, custom_datetime_4, custom_datetime_5,
 		custom_json_1, custom_json_2, custom_json_3, custom_json_4, custom_json_5,
-		created_at, updated_at
+		created_at, updated_at, messaging_hold_until
 	) VALUES `)
 
 	// Add value placeholders for each contact
@@ -1214,8 +1218,8 @@ func (r *contactRepository) BulkUpsertContacts(ctx context.Context, workspaceID
 		}
 		queryBuilder.WriteString("(")
 
-		// Add 36 placeholders for contact fields (excluding db_created_at and db_updated_at)
-		for j := 0; j < 36; j++ {
+		// Add 37 placeholders for contact fields (excluding db_created_at and db_updated_at)
+		for j := 0; j < 37; j++ {
 			if j > 0 {
 				queryBuilder.WriteString(", ")
 			}
@@ -1274,6 +1278,7 @@ func (r *contactRepository) BulkUpsertContacts(ctx context.Context, workspaceID
 			contactToNullJSON(contact.CustomJSON5),      // 34
 			createdAt,                            // 35 - application-level timestamp
 			updatedAt,                            // 36 - application-level timestamp
+			contactToNullTime(contact.MessagingHoldUntil), // 37
 		)
 	}
 
@@ -1315,6 +1320,7 @@ func (r *contactRepository) BulkUpsertContacts(ctx context.Context, workspaceID
 		custom_json_3 = CASE WHEN EXCLUDED.custom_json_3 IS NOT NULL THEN EXCLUDED.custom_json_3 ELSE contacts.custom_json_3 END,
 		custom_json_4 = CASE WHEN EXCLUDED.custom_json_4 IS NOT NULL THEN EXCLUDED.custom_json_4 ELSE contacts.custom_json_4 END,
 		custom_json_5 = CASE WHEN EXCLUDED.custom_json_5 IS NOT NULL THEN EXCLUDED.custom_json_5 ELSE contacts.custom_json_5 END,
+		messaging_hold_until = CASE WHEN EXCLUDED.messaging_hold_until IS NOT NULL THEN EXCLUDED.messaging_hold_until ELSE contacts.messaging_hold_until END,
 		created_at = EXCLUDED.created_at,
 		updated_at = EXCLUDED.updated_at,
 		db_updated_at = NOW()
@@ -1373,7 +1379,8 @@ func (r *contactRepository) GetContactsForBroadcast(
 	var includeListID bool
 
 	// If we're filtering by list, include list_id in the result
-	if audience.List != "" {
+	listIDs := audience.ListIDs()
+	if len(listIDs) > 0 {
 		includeListID = true
 		// Build column list: all contact columns plus list_id and list_name
 		selectCols := append(contactColumnsWithPrefix("c"), "cl.list_id", "l.name as list_name")
@@ -1381,11 +1388,18 @@ func (r *contactRepository) GetContactsForBroadcast(
 			From("contacts c").
 			Join("contact_lists cl ON c.email = cl.email").
 			Join("lists l ON cl.list_id = l.id"). // Join with lists table to get the name
-			Where(sq.Eq{"cl.list_id": audience.List}).
+			Where(sq.Eq{"cl.list_id": broadcastListFilter(listIDs)}).
 			Where(sq.Eq{"l.deleted_at": nil}). // Filter out deleted lists
 			Limit(uint64(limit)).
 			OrderBy("c.email ASC") // Sort by email only (unique, deterministic)
 
+		// A contact on several targeted lists must only be returned once.
+		// Status filters below apply per membership, so the contact is kept
+		// as long as one of its targeted memberships qualifies.
+		if len(listIDs) > 1 {
+			query = query.Options("DISTINCT ON (c.email)").OrderBy("cl.list_id ASC")
+		}
+
 		// Cursor-based pagination: fetch contacts with email > afterEmail
 		if afterEmail != "" {
 			query = query.Where(sq.Gt{"c.email": afterEmail})
@@ -1415,7 +1429,7 @@ func (r *contactRepository) GetContactsForBroadcast(
 	if len(audience.Segments) > 0 {
 		// If we already have list filtering, we need to add segments as an additional filter
 		// This means contacts must be in BOTH the specified list AND segments
-		if audience.List != "" {
+		if len(listIDs) > 0 {
 			// Join with contact_segments table in addition to the existing list joins
 			query = query.Join("contact_segments cs ON c.email = cs.email")
 			query = query.Where(sq.Eq{"cs.segment_id": audience.Segments})
@@ -1437,6 +1451,19 @@ func (r *contactRepository) GetContactsForBroadcast(
 		}
 	}
 
+	// Suppressed addresses never receive broadcasts, whatever their list status
+	query = query.Where(notSuppressedClause)
+
+	// Contacts going through an automation are left to it when required
+	if audience.ExcludeActiveInAutomations {
+		query = query.Where(notInActiveAutomationClause, domain.ContactAutomationStatusActive)
+	}
+
+	// Contacts who opened an earlier broadcast are left out of its follow-up
+	if audience.ExcludeOpenersOf != "" {
+		query = query.Where(notOpenedBroadcastClause, audience.ExcludeOpenersOf)
+	}
+
 	// Build the final query
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
@@ -1470,6 +1497,7 @@ func (r *contactRepository) GetContactsForBroadcast(
 			var customDatetime1, customDatetime2, customDatetime3, customDatetime4, customDatetime5 sql.NullTime
 			var customJSON1, customJSON2, customJSON3, customJSON4, customJSON5 sql.NullString
 			var createdAt, updatedAt, dbCreatedAt, dbUpdatedAt time.Time
+			var messagingHoldUntil sql.NullTime
 
 			// Scan all columns including contact fields + list_id + list_name
 			scanErr = rows.Scan(
@@ -1481,6 +1509,7 @@ func (r *contactRepository) GetContactsForBroadcast(
 				&customDatetime1, &customDatetime2, &customDatetime3, &customDatetime4, &customDatetime5,
 				&customJSON1, &customJSON2, &customJSON3, &customJSON4, &customJSON5,
 				&createdAt, &updatedAt, &dbCreatedAt, &dbUpdatedAt,
+				&messagingHoldUntil,
 				&listID, &listName, // Additional columns
 			)
 			if scanErr != nil {
@@ -1582,6 +1611,9 @@ func (r *contactRepository) GetContactsForBroadcast(
 			if customDatetime5.Valid {
 				contact.CustomDatetime5 = &domain.NullableTime{Time: customDatetime5.Time, IsNull: false}
 			}
+			if messagingHoldUntil.Valid {
+				contact.MessagingHoldUntil = &domain.NullableTime{Time: messagingHoldUntil.Time, IsNull: false}
+			}
 			if customJSON1.Valid {
 				var jsonData interface{}
 				if err := json.Unmarshal([]byte(customJSON1.String), &jsonData); err == nil {
@@ -1636,6 +1668,102 @@ func (r *contactRepository) GetContactsForBroadcast(
 	return contactsWithList, nil
 }
 
+// GetContactsJoinedListBefore retrieves active, non-suppressed members of a list who joined
+// at or before joinedBefore. Members are ordered by join time then email so list-join
+// broadcasts can resume from the last member they sent to
+func (r *contactRepository) GetContactsJoinedListBefore(
+	ctx context.Context,
+	workspaceID string,
+	listID string,
+	joinedBefore time.Time,
+	afterJoinedAt time.Time,
+	afterEmail string,
+	limit int,
+) ([]*domain.ContactWithList, error) {
+	db, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	selectCols := append(contactColumnsWithPrefix("c"), "cl.list_id", "l.name as list_name", "cl.created_at")
+	query := psql.Select(selectCols...).
+		From("contacts c").
+		Join("contact_lists cl ON c.email = cl.email").
+		Join("lists l ON cl.list_id = l.id").
+		Where(sq.Eq{"cl.list_id": listID}).
+		Where(sq.Eq{"cl.status": domain.ContactListStatusActive}).
+		Where(sq.Eq{"cl.deleted_at": nil}).
+		Where(sq.Eq{"l.deleted_at": nil}).
+		Where(sq.LtOrEq{"cl.created_at": joinedBefore}).
+		Where("(cl.created_at, c.email) > (?, ?)", afterJoinedAt, afterEmail).
+		Where(notSuppressedClause).
+		OrderBy("cl.created_at ASC", "c.email ASC").
+		Limit(uint64(limit))
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var contactsWithList []*domain.ContactWithList
+	for rows.Next() {
+		member := &domain.ContactWithList{}
+		contact, scanErr := domain.ScanContact(trailingColumnsScanner{
+			rows:  rows,
+			extra: []interface{}{&member.ListID, &member.ListName, &member.JoinedAt},
+		})
+		if scanErr != nil {
+			return nil, fmt.Errorf("failed to scan list member: %w", scanErr)
+		}
+		member.Contact = contact
+		contactsWithList = append(contactsWithList, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over list member rows: %w", err)
+	}
+
+	return contactsWithList, nil
+}
+
+// trailingColumnsScanner lets domain.ScanContact read a row that selects extra columns
+// after the contact columns
+type trailingColumnsScanner struct {
+	rows  *sql.Rows
+	extra []interface{}
+}
+
+func (s trailingColumnsScanner) Scan(dest ...interface{}) error {
+	return s.rows.Scan(append(dest, s.extra...)...)
+}
+
+// notSuppressedClause excludes contacts whose address is in the workspace suppression list
+const notSuppressedClause = "NOT EXISTS (SELECT 1 FROM suppressions s WHERE s.email = c.email)"
+
+// notInActiveAutomationClause excludes contacts with a contact_automations row in the given status
+const notInActiveAutomationClause = "NOT EXISTS (SELECT 1 FROM contact_automations ca WHERE ca.contact_email = c.email AND ca.status = ?)"
+
+// notOpenedBroadcastClause excludes contacts who opened the given broadcast. A click counts as
+// an open, since opens go unrecorded when the client blocks the tracking pixel.
+const notOpenedBroadcastClause = "NOT EXISTS (SELECT 1 FROM message_history mh WHERE mh.contact_email = c.email AND mh.broadcast_id = ? AND (mh.opened_at IS NOT NULL OR mh.clicked_at IS NOT NULL))"
+
+// broadcastListFilter returns the cl.list_id filter value for the targeted lists.
+// A single list is matched with = so the query is unchanged for single-list audiences.
+func broadcastListFilter(listIDs []string) interface{} {
+	if len(listIDs) == 1 {
+		return listIDs[0]
+	}
+	return listIDs
+}
+
 // CountContactsForBroadcast counts how many contacts match broadcast audience settings
 // without retrieving all contact records
 func (r *contactRepository) CountContactsForBroadcast(
@@ -1651,18 +1779,24 @@ func (r *contactRepository) CountContactsForBroadcast(
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
 	// Start building the count query
-	query := psql.Select("COUNT(*)").
+	// A contact on several targeted lists is only counted once
+	listIDs := audience.ListIDs()
+	countExpr := "COUNT(*)"
+	if len(listIDs) > 1 {
+		countExpr = "COUNT(DISTINCT c.email)"
+	}
+	query := psql.Select(countExpr).
 		From("contacts c")
 
 	// Handle list filtering
-	if audience.List != "" {
+	if len(listIDs) > 0 {
 		// Join with contact_lists table to filter by list membership and status
 		query = query.Join("contact_lists cl ON c.email = cl.email")
 		// Join with lists table to filter by list deletion status (matches GetContactsForBroadcast)
 		query = query.Join("lists l ON cl.list_id = l.id")
 
-		// Filter by the specified list
-		query = query.Where(sq.Eq{"cl.list_id": audience.List})
+		// Filter by the specified lists
+		query = query.Where(sq.Eq{"cl.list_id": broadcastListFilter(listIDs)})
 		// Filter out soft-deleted lists (matches GetContactsForBroadcast)
 		query = query.Where(sq.Eq{"l.deleted_at": nil})
 
@@ -1678,7 +1812,7 @@ func (r *contactRepository) CountContactsForBroadcast(
 	if len(audience.Segments) > 0 {
 		// If we already have list filtering, we need to add segments as an additional filter
 		// This means contacts must be in BOTH the specified list AND segments
-		if audience.List != "" {
+		if len(listIDs) > 0 {
 			// Join with contact_segments table in addition to the existing list joins
 			query = query.Join("contact_segments cs ON c.email = cs.email")
 			query = query.Where(sq.Eq{"cs.segment_id": audience.Segments})
@@ -1691,6 +1825,19 @@ func (r *contactRepository) CountContactsForBroadcast(
 		}
 	}
 
+	// Suppressed addresses are not counted (matches GetContactsForBroadcast)
+	query = query.Where(notSuppressedClause)
+
+	// Contacts active in an automation are not counted when excluded (matches GetContactsForBroadcast)
+	if audience.ExcludeActiveInAutomations {
+		query = query.Where(notInActiveAutomationClause, domain.ContactAutomationStatusActive)
+	}
+
+	// Openers of the referenced broadcast are not counted (matches GetContactsForBroadcast)
+	if audience.ExcludeOpenersOf != "" {
+		query = query.Where(notOpenedBroadcastClause, audience.ExcludeOpenersOf)
+	}
+
 	// Build and execute the query
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
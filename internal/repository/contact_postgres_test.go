@@ -23,7 +23,7 @@ import (
 
 // contactColumnsPattern is the regex pattern for matching explicit contact columns in queries.
 // This matches the contactColumnsWithPrefix("c") output in contact_postgres.go.
-const contactColumnsPattern = `c\.email, c\.external_id, c\.timezone, c\.language, c\.first_name, c\.last_name, c\.full_name, c\.phone, c\.address_line_1, c\.address_line_2, c\.country, c\.postcode, c\.state, c\.job_title, c\.custom_string_1, c\.custom_string_2, c\.custom_string_3, c\.custom_string_4, c\.custom_string_5, c\.custom_number_1, c\.custom_number_2, c\.custom_number_3, c\.custom_number_4, c\.custom_number_5, c\.custom_datetime_1, c\.custom_datetime_2, c\.custom_datetime_3, c\.custom_datetime_4, c\.custom_datetime_5, c\.custom_json_1, c\.custom_json_2, c\.custom_json_3, c\.custom_json_4, c\.custom_json_5, c\.created_at, c\.updated_at, c\.db_created_at, c\.db_updated_at`
+const contactColumnsPattern = `c\.email, c\.external_id, c\.timezone, c\.language, c\.first_name, c\.last_name, c\.full_name, c\.phone, c\.address_line_1, c\.address_line_2, c\.country, c\.postcode, c\.state, c\.job_title, c\.custom_string_1, c\.custom_string_2, c\.custom_string_3, c\.custom_string_4, c\.custom_string_5, c\.custom_number_1, c\.custom_number_2, c\.custom_number_3, c\.custom_number_4, c\.custom_number_5, c\.custom_datetime_1, c\.custom_datetime_2, c\.custom_datetime_3, c\.custom_datetime_4, c\.custom_datetime_5, c\.custom_json_1, c\.custom_json_2, c\.custom_json_3, c\.custom_json_4, c\.custom_json_5, c\.created_at, c\.updated_at, c\.db_created_at, c\.db_updated_at, c\.messaging_hold_until`
 
 // setupMockDB creates a mock database and sqlmock for testing
 func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, func()) {
@@ -60,7 +60,7 @@ func TestGetContactByEmail(t *testing.T) {
 		"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 		"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 		"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-		"created_at", "updated_at", "db_created_at", "db_updated_at",
+		"created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 	}).
 		AddRow(
 			email, "ext123", "Europe/Paris", "en-US",
@@ -70,7 +70,7 @@ func TestGetContactByEmail(t *testing.T) {
 			42.0, 43.0, 44.0, 45.0, 46.0,
 			now, now, now, now, now,
 			[]byte(`{"key": "value1"}`), []byte(`{"key": "value2"}`), []byte(`{"key": "value3"}`), []byte(`{"key": "value4"}`), []byte(`{"key": "value5"}`),
-			now, now, now, now,
+			now, now, now, now, nil,
 		)
 
 	mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE c.email = \$1`).
@@ -143,7 +143,7 @@ func TestGetContactByExternalID(t *testing.T) {
 		"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 		"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 		"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-		"created_at", "updated_at", "db_created_at", "db_updated_at",
+		"created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 	}).
 		AddRow(
 			email, externalID, "Europe/Paris", "en-US",
@@ -153,7 +153,7 @@ func TestGetContactByExternalID(t *testing.T) {
 			42.0, 43.0, 44.0, 45.0, 46.0,
 			now, now, now, now, now,
 			[]byte(`{"key": "value1"}`), []byte(`{"key": "value2"}`), []byte(`{"key": "value3"}`), []byte(`{"key": "value4"}`), []byte(`{"key": "value5"}`),
-			now, now, now, now,
+			now, now, now, now, nil,
 		)
 
 	mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE c.external_id = \$1`).
@@ -215,12 +215,12 @@ func TestGetContactByExternalID(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).AddRow(
 			email, "e-123", "Europe/Paris", "en-US", "John", "Doe", "John Doe", "", "", "", "", "", "", "",
 			"", "", "", "", "", 0, 0, 0, 0, 0, time.Time{}, time.Time{}, time.Time{}, time.Time{}, time.Time{},
 			[]byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil,
 		)
 
 		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE c.external_id = \$1`).
@@ -284,7 +284,7 @@ func TestFetchContact(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).
 			AddRow(
 				email, "ext123", "Europe/Paris", "en-US",
@@ -294,7 +294,7 @@ func TestFetchContact(t *testing.T) {
 				42.0, 43.0, 44.0, 45.0, 46.0,
 				now, now, now, now, now,
 				[]byte(`{"key": "value1"}`), []byte(`{"key": "value2"}`), []byte(`{"key": "value3"}`), []byte(`{"key": "value4"}`), []byte(`{"key": "value5"}`),
-				now, now, now, now,
+				now, now, now, now, nil,
 			)
 
 		phone := "+1234567890"
@@ -352,7 +352,7 @@ func TestFetchContact(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).
 			AddRow(
 				email, "ext123", "Europe/Paris", "en-US",
@@ -362,7 +362,7 @@ func TestFetchContact(t *testing.T) {
 				42.0, 43.0, 44.0, 45.0, 46.0,
 				now, now, now, now, now,
 				[]byte(`{"key": "value1"}`), []byte(`{"key": "value2"}`), []byte(`{"key": "value3"}`), []byte(`{"key": "value4"}`), []byte(`{"key": "value5"}`),
-				now, now, now, now,
+				now, now, now, now, nil,
 			)
 
 		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE c.email = \$1`).
@@ -405,7 +405,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).AddRow(
 			"test@example.com", "ext123", "UTC", "en", "John", "Doe", "John Doe",
 			"+1234567890", "123 Main St", "Apt 4B", "US", "12345", "CA",
@@ -415,7 +415,7 @@ func TestGetContacts(t *testing.T) {
 			time.Now(), time.Now(), time.Now(), time.Now(), time.Now(),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil,
 		)
 
 		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c ORDER BY c\.created_at DESC, c\.email ASC LIMIT 11`).
@@ -486,7 +486,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).AddRow(
 			"test@example.com", "ext123", "UTC", "en", "John", "Doe", "John Doe",
 			"+1234567890", "123 Main St", "Apt 4B", "US", "12345", "CA",
@@ -496,7 +496,7 @@ func TestGetContacts(t *testing.T) {
 			time.Now(), time.Now(), time.Now(), time.Now(), time.Now(),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil,
 		)
 
 		mock.ExpectQuery(`SELECT `+contactColumnsPattern+` FROM contacts c WHERE c\.email ILIKE \$1 AND c\.first_name ILIKE \$2 AND c\.country ILIKE \$3 ORDER BY c\.created_at DESC, c\.email ASC LIMIT 11`).
@@ -568,7 +568,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		})
 
 		// Add multiple contacts to ensure pagination works
@@ -583,7 +583,7 @@ func TestGetContacts(t *testing.T) {
 				now, now, now, now, now,
 				[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 				[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-				now.Add(time.Duration(-i)*time.Hour), now, now.Add(time.Duration(-i)*time.Hour), now, // Use decreasing created_at times
+				now.Add(time.Duration(-i)*time.Hour), now, now.Add(time.Duration(-i)*time.Hour), now, nil, // Use decreasing created_at times
 			)
 		}
 
@@ -814,7 +814,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).AddRow(
 			"test@example.com", "ext123", "UTC", "en", "John", "Doe", "John Doe",
 			"+1234567890", "123 Main St", "Apt 4B", "US", "12345", "CA",
@@ -824,7 +824,7 @@ func TestGetContacts(t *testing.T) {
 			time.Now(), time.Now(), time.Now(), time.Now(), time.Now(),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil,
 		)
 
 		mock.ExpectQuery(`SELECT `+contactColumnsPattern+` FROM contacts c WHERE c\.email ILIKE \$1 AND c\.external_id ILIKE \$2 AND c\.first_name ILIKE \$3 AND c\.last_name ILIKE \$4 AND c\.phone ILIKE \$5 AND c\.country ILIKE \$6 ORDER BY c\.created_at DESC, c\.email ASC LIMIT 11`).
@@ -928,7 +928,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).AddRow(
 			"test@example.com", "ext123", "UTC", "en", "John", "Doe", "John Doe",
 			"+1234567890", "123 Main St", "Apt 4B", "US", "12345", "CA",
@@ -938,7 +938,7 @@ func TestGetContacts(t *testing.T) {
 			time.Now(), time.Now(), time.Now(), time.Now(), time.Now(),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil,
 		)
 
 		// Match the query using a regex pattern that includes the EXISTS subquery
@@ -1008,7 +1008,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).AddRow(
 			"test@example.com", "ext123", "UTC", "en", "John", "Doe", "John Doe",
 			"+1234567890", "123 Main St", "Apt 4B", "US", "12345", "CA",
@@ -1018,7 +1018,7 @@ func TestGetContacts(t *testing.T) {
 			time.Now(), time.Now(), time.Now(), time.Now(), time.Now(),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil,
 		)
 
 		// Match the query using a regex pattern that includes the EXISTS subquery
@@ -1088,7 +1088,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).AddRow(
 			"test@example.com", "ext123", "UTC", "en", "John", "Doe", "John Doe",
 			"+1234567890", "123 Main St", "Apt 4B", "US", "12345", "CA",
@@ -1098,7 +1098,7 @@ func TestGetContacts(t *testing.T) {
 			time.Now(), time.Now(), time.Now(), time.Now(), time.Now(),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil,
 		)
 
 		// Match the query using a regex pattern that includes the EXISTS subquery with both list_id and status filters
@@ -1167,7 +1167,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).AddRow(
 			"test@example.com", "ext123", "UTC", "en", "John", "Doe", "John Doe",
 			"+1234567890", "123 Main St", "Apt 4B", "US", "12345", "CA",
@@ -1177,7 +1177,7 @@ func TestGetContacts(t *testing.T) {
 			time.Now(), time.Now(), time.Now(), time.Now(), time.Now(),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil,
 		)
 
 		// Match the query using a regex pattern that includes the EXISTS subquery for segments
@@ -1257,7 +1257,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).AddRow(
 			"test@example.com", "ext123", "UTC", "en", "John", "Doe", "John Doe",
 			"+1234567890", "123 Main St", "Apt 4B", "US", "12345", "CA",
@@ -1267,7 +1267,7 @@ func TestGetContacts(t *testing.T) {
 			time.Now(), time.Now(), time.Now(), time.Now(), time.Now(),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil,
 		)
 
 		// Match the query using a regex pattern that includes the EXISTS subquery for a single segment
@@ -1343,7 +1343,7 @@ func TestGetContactsForBroadcast(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 			"list_id", "list_name", // Additional columns for list filtering (makes it 42 total)
 		}).
 			AddRow(
@@ -1354,7 +1354,7 @@ func TestGetContactsForBroadcast(t *testing.T) {
 				42.0, 43.0, 44.0, 45.0, 46.0,
 				now, now, now, now, now,
 				[]byte(`{"key": "value1"}`), []byte(`{"key": "value2"}`), []byte(`{"key": "value3"}`), []byte(`{"key": "value4"}`), []byte(`{"key": "value5"}`),
-				now, now, now, now,
+				now, now, now, now, nil,
 				"list1", "Marketing List", // Additional values for list filtering
 			).
 			AddRow(
@@ -1365,12 +1365,12 @@ func TestGetContactsForBroadcast(t *testing.T) {
 				52.0, 53.0, 54.0, 55.0, 56.0,
 				now, now, now, now, now,
 				[]byte(`{"key": "value1-2"}`), []byte(`{"key": "value2-2"}`), []byte(`{"key": "value3-2"}`), []byte(`{"key": "value4-2"}`), []byte(`{"key": "value5-2"}`),
-				now, now, now, now,
+				now, now, now, now, nil,
 				"list1", "Marketing List", // Additional values for list filtering - same list
 			)
 
 			// Expect query with JOINS for list filtering and excludeUnsubscribed (cursor-based pagination)
-		mock.ExpectQuery(`SELECT `+contactColumnsPattern+`, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 ORDER BY c\.email ASC LIMIT 10`).
+		mock.ExpectQuery(`SELECT `+contactColumnsPattern+`, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND NOT EXISTS \(SELECT 1 FROM suppressions s WHERE s\.email = c\.email\) ORDER BY c\.email ASC LIMIT 10`).
 			WithArgs("list1",
 				domain.ContactListStatusUnsubscribed,
 				domain.ContactListStatusBounced,
@@ -1394,6 +1394,66 @@ func TestGetContactsForBroadcast(t *testing.T) {
 		assert.Equal(t, "Marketing List", contacts[1].ListName)
 	})
 
+	t.Run("should dedup contacts across multiple targeted lists", func(t *testing.T) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		// list2 is listed twice and must only be matched once
+		audience := domain.AudienceSettings{
+			List:                "list1",
+			Lists:               []string{"list2", "list2"},
+			ExcludeUnsubscribed: true,
+		}
+
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		rows := sqlmock.NewRows([]string{
+			"email", "external_id", "timezone", "language",
+			"first_name", "last_name", "full_name", "phone", "address_line_1", "address_line_2",
+			"country", "postcode", "state", "job_title",
+			"custom_string_1", "custom_string_2", "custom_string_3", "custom_string_4", "custom_string_5",
+			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
+			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
+			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
+			"list_id", "list_name",
+		}).
+			AddRow(
+				"both@example.com", nil, nil, nil,
+				nil, nil, nil, nil, nil, nil,
+				nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				now, now, now, now, nil,
+				"list1", "Newsletter",
+			)
+
+		// DISTINCT ON (c.email) keeps one row per contact, preferring the first list id
+		mock.ExpectQuery(`SELECT DISTINCT ON \(c\.email\) `+contactColumnsPattern+`, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id IN \(\$1,\$2\) AND l\.deleted_at IS NULL AND cl\.status <> \$3 AND cl\.status <> \$4 AND cl\.status <> \$5 AND NOT EXISTS \(SELECT 1 FROM suppressions s WHERE s\.email = c\.email\) ORDER BY c\.email ASC, cl\.list_id ASC LIMIT 10`).
+			WithArgs("list1", "list2",
+				domain.ContactListStatusUnsubscribed,
+				domain.ContactListStatusBounced,
+				domain.ContactListStatusComplained).
+			WillReturnRows(rows)
+
+		contacts, err := repo.GetContactsForBroadcast(context.Background(), "workspace123", audience, 10, "")
+
+		require.NoError(t, err)
+		require.Len(t, contacts, 1)
+		assert.Equal(t, "both@example.com", contacts[0].Contact.Email)
+		assert.Equal(t, "list1", contacts[0].ListID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("should get contacts without list filtering", func(t *testing.T) {
 		// Create a mock workspace database
 		mockDB, mock, cleanup := setupMockDB(t)
@@ -1425,7 +1485,7 @@ func TestGetContactsForBroadcast(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).
 			AddRow(
 				"test1@example.com", "ext123", "Europe/Paris", "en-US",
@@ -1435,7 +1495,7 @@ func TestGetContactsForBroadcast(t *testing.T) {
 				42.0, 43.0, 44.0, 45.0, 46.0,
 				now, now, now, now, now,
 				[]byte(`{"key": "value1"}`), []byte(`{"key": "value2"}`), []byte(`{"key": "value3"}`), []byte(`{"key": "value4"}`), []byte(`{"key": "value5"}`),
-				now, now, now, now,
+				now, now, now, now, nil,
 			).
 			AddRow(
 				"test2@example.com", "ext456", "America/New_York", "en-US",
@@ -1445,11 +1505,11 @@ func TestGetContactsForBroadcast(t *testing.T) {
 				52.0, 53.0, 54.0, 55.0, 56.0,
 				now, now, now, now, now,
 				[]byte(`{"key": "value1-2"}`), []byte(`{"key": "value2-2"}`), []byte(`{"key": "value3-2"}`), []byte(`{"key": "value4-2"}`), []byte(`{"key": "value5-2"}`),
-				now, now, now, now,
+				now, now, now, now, nil,
 			)
 
 		// Expect query without JOINS for all contacts (cursor-based pagination)
-		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c ORDER BY c\.email ASC LIMIT 10`).
+		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE NOT EXISTS \(SELECT 1 FROM suppressions s WHERE s\.email = c\.email\) ORDER BY c\.email ASC LIMIT 10`).
 			WillReturnRows(rows)
 
 		// Call the method being tested (empty string for first batch cursor)
@@ -1470,6 +1530,114 @@ func TestGetContactsForBroadcast(t *testing.T) {
 		assert.Empty(t, contacts[1].ListName)
 	})
 
+	t.Run("should exclude contacts active in an automation", func(t *testing.T) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		audience := domain.AudienceSettings{
+			List:                       "list1",
+			ExcludeActiveInAutomations: true,
+		}
+
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		rows := sqlmock.NewRows([]string{
+			"email", "external_id", "timezone", "language",
+			"first_name", "last_name", "full_name", "phone", "address_line_1", "address_line_2",
+			"country", "postcode", "state", "job_title",
+			"custom_string_1", "custom_string_2", "custom_string_3", "custom_string_4", "custom_string_5",
+			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
+			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
+			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
+			"list_id", "list_name",
+		}).
+			AddRow(
+				"idle@example.com", nil, nil, nil,
+				nil, nil, nil, nil, nil, nil,
+				nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				now, now, now, now, nil,
+				"list1", "Newsletter",
+			)
+
+		// busy@example.com is active in an automation, so the anti-join leaves only idle@example.com
+		mock.ExpectQuery(`SELECT `+contactColumnsPattern+`, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND NOT EXISTS \(SELECT 1 FROM suppressions s WHERE s\.email = c\.email\) AND NOT EXISTS \(SELECT 1 FROM contact_automations ca WHERE ca\.contact_email = c\.email AND ca\.status = \$2\) ORDER BY c\.email ASC LIMIT 10`).
+			WithArgs("list1", domain.ContactAutomationStatusActive).
+			WillReturnRows(rows)
+
+		contacts, err := repo.GetContactsForBroadcast(context.Background(), "workspace123", audience, 10, "")
+
+		require.NoError(t, err)
+		require.Len(t, contacts, 1)
+		assert.Equal(t, "idle@example.com", contacts[0].Contact.Email)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should exclude openers of the referenced broadcast", func(t *testing.T) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		audience := domain.AudienceSettings{
+			List:             "list1",
+			ExcludeOpenersOf: "broadcastA",
+		}
+
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		rows := sqlmock.NewRows([]string{
+			"email", "external_id", "timezone", "language",
+			"first_name", "last_name", "full_name", "phone", "address_line_1", "address_line_2",
+			"country", "postcode", "state", "job_title",
+			"custom_string_1", "custom_string_2", "custom_string_3", "custom_string_4", "custom_string_5",
+			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
+			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
+			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
+			"list_id", "list_name",
+		}).
+			AddRow(
+				"non-opener@example.com", nil, nil, nil,
+				nil, nil, nil, nil, nil, nil,
+				nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				now, now, now, now, nil,
+				"list1", "Newsletter",
+			)
+
+		// opener@example.com opened broadcastA, so the anti-join leaves only non-opener@example.com
+		mock.ExpectQuery(`SELECT `+contactColumnsPattern+`, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND NOT EXISTS \(SELECT 1 FROM suppressions s WHERE s\.email = c\.email\) AND NOT EXISTS \(SELECT 1 FROM message_history mh WHERE mh\.contact_email = c\.email AND mh\.broadcast_id = \$2 AND \(mh\.opened_at IS NOT NULL OR mh\.clicked_at IS NOT NULL\)\) ORDER BY c\.email ASC LIMIT 10`).
+			WithArgs("list1", "broadcastA").
+			WillReturnRows(rows)
+
+		contacts, err := repo.GetContactsForBroadcast(context.Background(), "workspace123", audience, 10, "")
+
+		require.NoError(t, err)
+		require.Len(t, contacts, 1)
+		assert.Equal(t, "non-opener@example.com", contacts[0].Contact.Email)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("should handle database connection error", func(t *testing.T) {
 		// Create a mock workspace database
 		ctrl := gomock.NewController(t)
@@ -1517,7 +1685,7 @@ func TestGetContactsForBroadcast(t *testing.T) {
 		}
 
 		// Expect query with error (cursor-based pagination)
-		mock.ExpectQuery(`SELECT `+contactColumnsPattern+`, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 ORDER BY c\.email ASC LIMIT 10`).
+		mock.ExpectQuery(`SELECT `+contactColumnsPattern+`, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND NOT EXISTS \(SELECT 1 FROM suppressions s WHERE s\.email = c\.email\) ORDER BY c\.email ASC LIMIT 10`).
 			WithArgs("list1",
 				domain.ContactListStatusUnsubscribed,
 				domain.ContactListStatusBounced,
@@ -1564,17 +1732,17 @@ func TestGetContactsForBroadcast(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).
 			AddRow("test1@example.com", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
-				nil, nil, nil, nil, nil, createdAt1, createdAt1, createdAt1, createdAt1).
+				nil, nil, nil, nil, nil, createdAt1, createdAt1, createdAt1, createdAt1, nil).
 			AddRow("test2@example.com", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
-				nil, nil, nil, nil, nil, createdAt2, createdAt2, createdAt2, createdAt2)
+				nil, nil, nil, nil, nil, createdAt2, createdAt2, createdAt2, createdAt2, nil)
 
 		// Expect the query to join contacts with contact_segments (cursor-based pagination)
-		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c JOIN contact_segments cs ON c\.email = cs\.email WHERE cs\.segment_id IN \(\$1\) ORDER BY c\.email ASC LIMIT 10`).
+		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c JOIN contact_segments cs ON c\.email = cs\.email WHERE cs\.segment_id IN \(\$1\) AND NOT EXISTS \(SELECT 1 FROM suppressions s WHERE s\.email = c\.email\) ORDER BY c\.email ASC LIMIT 10`).
 			WithArgs("segment1").
 			WillReturnRows(rows)
 
@@ -1592,6 +1760,88 @@ func TestGetContactsForBroadcast(t *testing.T) {
 	})
 }
 
+func TestGetContactsJoinedListBefore(t *testing.T) {
+	columns := []string{
+		"email", "external_id", "timezone", "language",
+		"first_name", "last_name", "full_name", "phone", "address_line_1", "address_line_2",
+		"country", "postcode", "state", "job_title",
+		"custom_string_1", "custom_string_2", "custom_string_3", "custom_string_4", "custom_string_5",
+		"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
+		"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
+		"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
+		"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
+		"list_id", "list_name", "created_at",
+	}
+
+	t.Run("should get list members who joined before the cutoff after the cursor", func(t *testing.T) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		joinedBefore := now.Add(-72 * time.Hour)
+		joinedAt := now.Add(-96 * time.Hour)
+		afterJoinedAt := now.Add(-120 * time.Hour)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(
+				"old@example.com", nil, nil, nil,
+				"Old", nil, nil, nil, nil, nil,
+				nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				now, now, now, now, nil,
+				"list1", "Onboarding", joinedAt,
+			)
+
+		mock.ExpectQuery(`SELECT `+contactColumnsPattern+`, cl\.list_id, l\.name as list_name, cl\.created_at FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND cl\.status = \$2 AND cl\.deleted_at IS NULL AND l\.deleted_at IS NULL AND cl\.created_at <= \$3 AND \(cl\.created_at, c\.email\) > \(\$4, \$5\) AND NOT EXISTS \(SELECT 1 FROM suppressions s WHERE s\.email = c\.email\) ORDER BY cl\.created_at ASC, c\.email ASC LIMIT 50`).
+			WithArgs("list1", domain.ContactListStatusActive, joinedBefore, afterJoinedAt, "earlier@example.com").
+			WillReturnRows(rows)
+
+		members, err := repo.GetContactsJoinedListBefore(context.Background(), "workspace123", "list1", joinedBefore, afterJoinedAt, "earlier@example.com", 50)
+
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+		assert.Equal(t, "old@example.com", members[0].Contact.Email)
+		assert.Equal(t, "Old", members[0].Contact.FirstName.String)
+		assert.Equal(t, "list1", members[0].ListID)
+		assert.Equal(t, "Onboarding", members[0].ListName)
+		assert.True(t, joinedAt.Equal(members[0].JoinedAt))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should return error when query fails", func(t *testing.T) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		mock.ExpectQuery(`SELECT .* FROM contacts c JOIN contact_lists cl`).
+			WillReturnError(errors.New("db down"))
+
+		members, err := repo.GetContactsJoinedListBefore(context.Background(), "workspace123", "list1", time.Now(), time.Time{}, "", 50)
+
+		require.Error(t, err)
+		assert.Nil(t, members)
+		assert.Contains(t, err.Error(), "failed to execute query")
+	})
+}
+
 func TestCountContactsForBroadcast(t *testing.T) {
 	t.Run("should count contacts for broadcast with list filtering", func(t *testing.T) {
 		// Create a mock workspace database
@@ -1618,7 +1868,7 @@ func TestCountContactsForBroadcast(t *testing.T) {
 
 		// Expect query with JOINS for list filtering, soft-deleted lists filtering, and excludeUnsubscribed
 		// Note: SkipDuplicateEmails is false, so we expect COUNT(*) not COUNT(DISTINCT)
-		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4`).
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND NOT EXISTS \(SELECT 1 FROM suppressions s WHERE s\.email = c\.email\)`).
 			WithArgs("list1",
 				domain.ContactListStatusUnsubscribed,
 				domain.ContactListStatusBounced,
@@ -1633,6 +1883,94 @@ func TestCountContactsForBroadcast(t *testing.T) {
 		assert.Equal(t, 25, count)
 	})
 
+	t.Run("should not count contacts active in an automation", func(t *testing.T) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		audience := domain.AudienceSettings{
+			Segments:                   []string{"segment1"},
+			ExcludeActiveInAutomations: true,
+		}
+
+		rows := sqlmock.NewRows([]string{"count"}).AddRow(7)
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c JOIN contact_segments cs ON c\.email = cs\.email WHERE cs\.segment_id IN \(\$1\) AND NOT EXISTS \(SELECT 1 FROM suppressions s WHERE s\.email = c\.email\) AND NOT EXISTS \(SELECT 1 FROM contact_automations ca WHERE ca\.contact_email = c\.email AND ca\.status = \$2\)`).
+			WithArgs("segment1", domain.ContactAutomationStatusActive).
+			WillReturnRows(rows)
+
+		count, err := repo.CountContactsForBroadcast(context.Background(), "workspace123", audience)
+
+		require.NoError(t, err)
+		assert.Equal(t, 7, count)
+	})
+
+	t.Run("should not count openers of the referenced broadcast", func(t *testing.T) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		audience := domain.AudienceSettings{
+			Segments:         []string{"segment1"},
+			ExcludeOpenersOf: "broadcastA",
+		}
+
+		rows := sqlmock.NewRows([]string{"count"}).AddRow(4)
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c JOIN contact_segments cs ON c\.email = cs\.email WHERE cs\.segment_id IN \(\$1\) AND NOT EXISTS \(SELECT 1 FROM suppressions s WHERE s\.email = c\.email\) AND NOT EXISTS \(SELECT 1 FROM message_history mh WHERE mh\.contact_email = c\.email AND mh\.broadcast_id = \$2 AND \(mh\.opened_at IS NOT NULL OR mh\.clicked_at IS NOT NULL\)\)`).
+			WithArgs("segment1", "broadcastA").
+			WillReturnRows(rows)
+
+		count, err := repo.CountContactsForBroadcast(context.Background(), "workspace123", audience)
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, count)
+	})
+
+	t.Run("should count distinct contacts across multiple targeted lists", func(t *testing.T) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		audience := domain.AudienceSettings{
+			List:  "list1",
+			Lists: []string{"list2"},
+		}
+
+		rows := sqlmock.NewRows([]string{"count"}).AddRow(3)
+
+		mock.ExpectQuery(`SELECT COUNT\(DISTINCT c\.email\) FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id IN \(\$1,\$2\) AND l\.deleted_at IS NULL`).
+			WithArgs("list1", "list2").
+			WillReturnRows(rows)
+
+		count, err := repo.CountContactsForBroadcast(context.Background(), "workspace123", audience)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("should count all contacts without filtering", func(t *testing.T) {
 		// Create a mock workspace database
 		mockDB, mock, cleanup := setupMockDB(t)
@@ -131,7 +131,7 @@ func TestUpsertContact(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).
 			AddRow(
 				existingContact.Email, "old-ext", nil, nil, "Old", "Name", nil, nil,
@@ -140,7 +140,7 @@ func TestUpsertContact(t *testing.T) {
 				nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil,
-				existingContact.CreatedAt, existingContact.UpdatedAt, existingContact.CreatedAt, existingContact.UpdatedAt,
+				existingContact.CreatedAt, existingContact.UpdatedAt, existingContact.CreatedAt, existingContact.UpdatedAt, nil,
 			)
 
 		// New contact data with updates
@@ -364,7 +364,7 @@ func TestUpsertContact(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).
 			AddRow(
 				email, "old-ext", nil, nil, "Old", "Name", nil, nil,
@@ -373,7 +373,7 @@ func TestUpsertContact(t *testing.T) {
 				nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil,
-				now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour),
+				now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), nil,
 			)
 
 		// Expect transaction begin
@@ -537,7 +537,7 @@ func TestUpsertContact(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).
 			AddRow(
 				email, "ext123", nil, nil, "John", "Doe", nil, nil,
@@ -546,7 +546,7 @@ func TestUpsertContact(t *testing.T) {
 				nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil,
-				time.Now(), time.Now(), time.Now(), time.Now(),
+				time.Now(), time.Now(), time.Now(), time.Now(), nil,
 			)
 
 		// Create an update with unmarshalable JSON
@@ -768,7 +768,7 @@ func TestUpsertContact(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).
 			AddRow(
 				email, "old-ext", "UTC", "en-US", "Old", "Name", nil, nil,
@@ -777,7 +777,7 @@ func TestUpsertContact(t *testing.T) {
 				nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil,
-				now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour),
+				now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), nil,
 			)
 
 		// Update with mixed null and non-null fields
@@ -849,7 +849,7 @@ func TestUpsertContact(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).
 			AddRow(
 				email, "old-ext", "UTC", "en-US", "Old", "Name", "Old Name", "+1234567000",
@@ -858,7 +858,7 @@ func TestUpsertContact(t *testing.T) {
 				1.1, 2.2, 3.3, 4.4, 5.5,
 				now.Add(-10*time.Hour), now.Add(-20*time.Hour), now.Add(-30*time.Hour), now.Add(-40*time.Hour), now.Add(-50*time.Hour),
 				[]byte(`{"old":"json1"}`), []byte(`{"old":"json2"}`), []byte(`{"old":"json3"}`), []byte(`{"old":"json4"}`), []byte(`{"old":"json5"}`),
-				now.Add(-24*time.Hour), now.Add(-12*time.Hour), now.Add(-24*time.Hour), now.Add(-12*time.Hour),
+				now.Add(-24*time.Hour), now.Add(-12*time.Hour), now.Add(-24*time.Hour), now.Add(-12*time.Hour), nil,
 			)
 
 		// Create update contact with ALL fields populated with new values
@@ -963,7 +963,7 @@ func TestUpsertContact(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).
 			AddRow(
 				email, "ext123", "UTC", "en-US", "John", "Doe", "John Doe", "+1234567890",
@@ -972,7 +972,7 @@ func TestUpsertContact(t *testing.T) {
 				1.1, 2.2, 3.3, 4.4, 5.5,
 				now.Add(-1*time.Hour), now.Add(-2*time.Hour), now.Add(-3*time.Hour), now.Add(-4*time.Hour), now.Add(-5*time.Hour),
 				[]byte(`{"key1":"value1"}`), []byte(`{"key2":"value2"}`), []byte(`{"key3":"value3"}`), []byte(`{"key4":"value4"}`), []byte(`{"key5":"value5"}`),
-				now.Add(-24*time.Hour), now.Add(-12*time.Hour), now.Add(-24*time.Hour), now.Add(-12*time.Hour),
+				now.Add(-24*time.Hour), now.Add(-12*time.Hour), now.Add(-24*time.Hour), now.Add(-12*time.Hour), nil,
 			)
 
 		// Create update with explicit NULL values for fields
@@ -1064,7 +1064,7 @@ func TestUpsertContact(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).
 			AddRow(
 				email, "old-ext", "UTC", "en-US", "Old", "Name", nil, nil,
@@ -1073,7 +1073,7 @@ func TestUpsertContact(t *testing.T) {
 				nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil,
-				now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour),
+				now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), nil,
 			)
 
 		// Create update with unmarshalable JSON
@@ -1139,7 +1139,7 @@ func TestUpsertContact(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).
 			AddRow(
 				email, "old-ext", "UTC", "en-US", "Old", "Name", nil, nil,
@@ -1148,7 +1148,7 @@ func TestUpsertContact(t *testing.T) {
 				nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil,
-				now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour),
+				now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), nil,
 			)
 
 		// Update with unmarshalable JSON for CustomJSON3
@@ -1213,7 +1213,7 @@ func TestUpsertContact(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).
 			AddRow(
 				email, "old-ext", "UTC", "en-US", "Old", "Name", nil, nil,
@@ -1222,7 +1222,7 @@ func TestUpsertContact(t *testing.T) {
 				nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil,
-				now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour),
+				now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), nil,
 			)
 
 		// Update with unmarshalable JSON for CustomJSON4
@@ -1287,7 +1287,7 @@ func TestUpsertContact(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "messaging_hold_until",
 		}).
 			AddRow(
 				email, "old-ext", "UTC", "en-US", "Old", "Name", nil, nil,
@@ -1296,7 +1296,7 @@ func TestUpsertContact(t *testing.T) {
 				nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil,
-				now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour),
+				now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), now.Add(-24*time.Hour), nil,
 			)
 
 		// Update with unmarshalable JSON for CustomJSON5
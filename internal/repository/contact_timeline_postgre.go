@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/lib/pq"
 )
 
 // ContactTimelineRepository implements domain.ContactTimelineRepository
@@ -50,26 +52,10 @@ func (r *ContactTimelineRepository) Create(ctx context.Context, workspaceID stri
 	return nil
 }
 
-// List retrieves timeline entries for a contact with cursor-based pagination
-func (r *ContactTimelineRepository) List(ctx context.Context, workspaceID string, email string, limit int, cursor *string) ([]*domain.ContactTimelineEntry, *string, error) {
-	// Get the workspace database connection
-	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get workspace connection: %w", err)
-	}
-
-	// Default limit
-	if limit <= 0 {
-		limit = 50
-	}
-	if limit > 100 {
-		limit = 100
-	}
-
-	// Build query with JOINs to fetch entity data
-	query := `
+// contactTimelineSelect selects timeline entries with the data of the entity they refer to
+const contactTimelineSelect = `
 		SELECT 
-			ct.id,
+			ct.id::text AS id,
 			ct.email,
 			ct.operation,
 			ct.entity_type,
@@ -133,7 +119,52 @@ func (r *ContactTimelineRepository) List(ctx context.Context, workspaceID string
 		LEFT JOIN inbound_webhook_events we ON ct.entity_type = 'inbound_webhook_event' AND (ct.entity_id = we.message_id OR ct.entity_id = we.id::text)
 		LEFT JOIN message_history mh_we ON ct.entity_type = 'inbound_webhook_event' AND we.message_id = mh_we.id
 		LEFT JOIN templates t_we ON ct.entity_type = 'inbound_webhook_event' AND mh_we.template_id = t_we.id AND mh_we.template_version = t_we.version
-		WHERE ct.email = $1
+`
+
+// automationNodeTimelineSelect selects a contact's automation node executions shaped as timeline entries
+var automationNodeTimelineSelect = fmt.Sprintf(`
+		SELECT
+			ne.id,
+			ca.contact_email AS email,
+			'insert' AS operation,
+			'%s' AS entity_type,
+			'%s' AS kind,
+			jsonb_build_object(
+				'node_id', jsonb_build_object('new', ne.node_id),
+				'node_type', jsonb_build_object('new', ne.node_type),
+				'action', jsonb_build_object('new', ne.action)
+			) AS changes,
+			ne.automation_id AS entity_id,
+			COALESCE(ne.entered_at, ca.entered_at) AS created_at,
+			COALESCE(ne.entered_at, ca.entered_at) AS db_created_at,
+			json_build_object(
+				'automation_id', ne.automation_id,
+				'automation_name', a.name,
+				'contact_automation_id', ne.contact_automation_id,
+				'node_id', ne.node_id,
+				'node_type', ne.node_type,
+				'action', ne.action,
+				'completed_at', ne.completed_at,
+				'duration_ms', ne.duration_ms,
+				'output', ne.output,
+				'error', ne.error
+			) AS entity_data
+		FROM automation_node_executions ne
+		INNER JOIN contact_automations ca ON ca.id = ne.contact_automation_id
+		LEFT JOIN automations a ON a.id = ne.automation_id
+	`, domain.TimelineEntityTypeAutomationNode, domain.TimelineKindAutomationNode)
+
+// List retrieves timeline entries for a contact with cursor-based pagination
+func (r *ContactTimelineRepository) List(ctx context.Context, workspaceID string, email string, limit int, cursor *string) ([]*domain.ContactTimelineEntry, *string, error) {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	limit = normalizeTimelineLimit(limit)
+
+	query := contactTimelineSelect + `		WHERE ct.email = $1
 	`
 
 	args := []interface{}{email}
@@ -141,31 +172,100 @@ func (r *ContactTimelineRepository) List(ctx context.Context, workspaceID string
 
 	// Handle cursor-based pagination
 	if cursor != nil && *cursor != "" {
-		decodedCursor, err := decodeCursor(*cursor)
+		cursorTime, cursorID, err := parseTimelineCursor(*cursor)
 		if err != nil {
-			return nil, nil, fmt.Errorf("invalid cursor: %w", err)
+			return nil, nil, err
 		}
 
-		// Cursor format: "timestamp|id"
-		parts := strings.Split(decodedCursor, "|")
-		if len(parts) != 2 {
-			return nil, nil, fmt.Errorf("invalid cursor format")
-		}
+		query += fmt.Sprintf(" AND (ct.created_at < $%d OR (ct.created_at = $%d AND ct.id < $%d))", argIndex, argIndex+1, argIndex+2)
+		args = append(args, cursorTime, cursorTime, cursorID)
+		argIndex += 3
+	}
+
+	query += fmt.Sprintf(" ORDER BY ct.created_at DESC, ct.id DESC LIMIT $%d", argIndex)
+	args = append(args, limit+1) // Fetch one extra to determine if there's a next page
+
+	return queryTimelinePage(ctx, workspaceDB, query, args, limit)
+}
 
-		cursorTime, err := time.Parse(time.RFC3339Nano, parts[0])
+// ListAll retrieves every timeline entry for a contact, merging the contact_timeline entries with
+// the contact's automation node executions, newest first with cursor-based pagination.
+// When kinds is not empty, only entries of those kinds are returned.
+func (r *ContactTimelineRepository) ListAll(ctx context.Context, workspaceID string, email string, kinds []string, limit int, cursor *string) ([]*domain.ContactTimelineEntry, *string, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	limit = normalizeTimelineLimit(limit)
+
+	query := `
+		SELECT id, email, operation, entity_type, kind, changes, entity_id, created_at, db_created_at, entity_data
+		FROM (` + contactTimelineSelect + `		WHERE ct.email = $1
+		UNION ALL` + automationNodeTimelineSelect + `		WHERE ca.contact_email = $1
+		) timeline
+		WHERE TRUE`
+
+	args := []interface{}{email}
+	argIndex := 2
+
+	if len(kinds) > 0 {
+		query += fmt.Sprintf(" AND kind = ANY($%d)", argIndex)
+		args = append(args, pq.Array(kinds))
+		argIndex++
+	}
+
+	if cursor != nil && *cursor != "" {
+		cursorTime, cursorID, err := parseTimelineCursor(*cursor)
 		if err != nil {
-			return nil, nil, fmt.Errorf("invalid cursor timestamp: %w", err)
+			return nil, nil, err
 		}
-		cursorID := parts[1]
 
-		query += fmt.Sprintf(" AND (ct.created_at < $%d OR (ct.created_at = $%d AND ct.id < $%d))", argIndex, argIndex+1, argIndex+2)
+		query += fmt.Sprintf(" AND (created_at < $%d OR (created_at = $%d AND id < $%d))", argIndex, argIndex+1, argIndex+2)
 		args = append(args, cursorTime, cursorTime, cursorID)
 		argIndex += 3
 	}
 
-	query += fmt.Sprintf(" ORDER BY ct.created_at DESC, ct.id DESC LIMIT $%d", argIndex)
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", argIndex)
 	args = append(args, limit+1) // Fetch one extra to determine if there's a next page
 
+	return queryTimelinePage(ctx, workspaceDB, query, args, limit)
+}
+
+// normalizeTimelineLimit applies the default and maximum page size
+func normalizeTimelineLimit(limit int) int {
+	if limit <= 0 {
+		return 50
+	}
+	if limit > 100 {
+		return 100
+	}
+	return limit
+}
+
+// parseTimelineCursor decodes a "timestamp|id" pagination cursor
+func parseTimelineCursor(cursor string) (time.Time, string, error) {
+	decodedCursor, err := decodeCursor(cursor)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	parts := strings.Split(decodedCursor, "|")
+	if len(parts) != 2 {
+		return time.Time{}, "", fmt.Errorf("invalid cursor format")
+	}
+
+	cursorTime, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("invalid cursor timestamp: %w", err)
+	}
+
+	return cursorTime, parts[1], nil
+}
+
+// queryTimelinePage runs a timeline query fetching limit+1 rows and returns the page with the
+// cursor of the next one
+func queryTimelinePage(ctx context.Context, workspaceDB *sql.DB, query string, args []interface{}, limit int) ([]*domain.ContactTimelineEntry, *string, error) {
 	// Execute query
 	rows, err := workspaceDB.QueryContext(ctx, query, args...)
 	if err != nil {
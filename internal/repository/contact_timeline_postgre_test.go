@@ -389,6 +389,119 @@ func TestContactTimelineRepository_List(t *testing.T) {
 	})
 }
 
+func TestContactTimelineRepository_ListAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewContactTimelineRepository(mockWorkspaceRepo)
+
+	ctx := context.Background()
+	workspaceID := "ws123"
+	email := "user@example.com"
+	columns := []string{"id", "email", "operation", "entity_type", "kind", "changes", "entity_id", "created_at", "db_created_at", "entity_data"}
+
+	t.Run("Success - Merges automation node executions into the timeline", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(db, nil)
+
+		now := time.Now()
+		rows := sqlmock.NewRows(columns).
+			AddRow("exec1", email, "insert", "automation_node", "automation.node",
+				[]byte(`{"node_type":{"new":"email"},"action":{"new":"completed"}}`), "auto1", now, now,
+				[]byte(`{"automation_name":"Welcome","node_type":"email"}`)).
+			AddRow("entry1", email, "insert", "automation", "automation.start", nil, "auto1", now.Add(-time.Minute), now.Add(-time.Minute),
+				[]byte(`{"id":"auto1","name":"Welcome"}`))
+
+		mock.ExpectQuery("SELECT(.+)FROM contact_timeline ct(.+)UNION ALL(.+)FROM automation_node_executions ne(.+)ORDER BY created_at DESC, id DESC").
+			WithArgs(email, sqlmock.AnyArg()).
+			WillReturnRows(rows)
+
+		entries, nextCursor, err := repo.ListAll(ctx, workspaceID, email, nil, 50, nil)
+
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Nil(t, nextCursor)
+		assert.Equal(t, "automation.node", entries[0].Kind)
+		assert.Equal(t, "Welcome", entries[0].EntityData["automation_name"])
+		assert.Equal(t, "automation.start", entries[1].Kind)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success - Filters by kind and paginates", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(db, nil)
+
+		cursorTime := time.Now().Add(-time.Hour)
+		cursor := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s|%s", cursorTime.Format(time.RFC3339Nano), "entry0")))
+
+		now := time.Now().Add(-2 * time.Hour)
+		rows := sqlmock.NewRows(columns).
+			AddRow("entry1", email, "insert", "automation", "automation.start", nil, "auto1", now, now, nil).
+			AddRow("entry2", email, "insert", "automation", "automation.start", nil, "auto2", now.Add(-time.Minute), now, nil)
+
+		mock.ExpectQuery(`(.+)\) timeline WHERE TRUE AND kind = ANY\(\$2\) AND \(created_at < \$3 OR \(created_at = \$4 AND id < \$5\)\) ORDER BY created_at DESC, id DESC LIMIT \$6`).
+			WithArgs(email, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "entry0", 2).
+			WillReturnRows(rows)
+
+		entries, nextCursor, err := repo.ListAll(ctx, workspaceID, email, []string{"automation.start"}, 1, &cursor)
+
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "entry1", entries[0].ID)
+		require.NotNil(t, nextCursor)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Invalid cursor", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(db, nil)
+
+		cursor := "not-base64!"
+		entries, nextCursor, err := repo.ListAll(ctx, workspaceID, email, nil, 50, &cursor)
+
+		assert.Error(t, err)
+		assert.Nil(t, entries)
+		assert.Nil(t, nextCursor)
+		assert.Contains(t, err.Error(), "invalid cursor")
+	})
+
+	t.Run("Error - Query fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(db, nil)
+
+		mock.ExpectQuery("SELECT(.+)").
+			WillReturnError(sql.ErrConnDone)
+
+		entries, nextCursor, err := repo.ListAll(ctx, workspaceID, email, nil, 50, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, entries)
+		assert.Nil(t, nextCursor)
+		assert.Contains(t, err.Error(), "failed to query timeline")
+	})
+}
+
 func TestNewContactTimelineRepository(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
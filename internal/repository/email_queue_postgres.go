@@ -15,13 +15,15 @@ import (
 // EmailQueueRepository implements domain.EmailQueueRepository
 type EmailQueueRepository struct {
 	workspaceRepo domain.WorkspaceRepository
-	db            *sql.DB // Used for testing with sqlmock
+	db            *sql.DB       // Used for testing with sqlmock
+	dedupWindow   time.Duration // Entries with a dedupe key already enqueued within this window are dropped, 0 disables
 }
 
 // NewEmailQueueRepository creates a new EmailQueueRepository using workspace repository
-func NewEmailQueueRepository(workspaceRepo domain.WorkspaceRepository) domain.EmailQueueRepository {
+func NewEmailQueueRepository(workspaceRepo domain.WorkspaceRepository, dedupWindow time.Duration) domain.EmailQueueRepository {
 	return &EmailQueueRepository{
 		workspaceRepo: workspaceRepo,
+		dedupWindow:   dedupWindow,
 	}
 }
 
@@ -80,13 +82,21 @@ func (r *EmailQueueRepository) EnqueueTx(ctx context.Context, tx *sql.Tx, entrie
 
 	now := time.Now().UTC()
 
+	entries, err := r.claimDedupeKeys(ctx, tx, entries, now)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
 	insertBuilder := emailQueuePsql.
 		Insert("email_queue").
 		Columns(
 			"id", "status", "priority", "source_type", "source_id",
 			"integration_id", "provider_kind", "contact_email", "message_id",
 			"template_id", "payload", "attempts", "max_attempts",
-			"created_at", "updated_at",
+			"next_retry_at", "created_at", "updated_at",
 		)
 
 	for _, entry := range entries {
@@ -118,7 +128,7 @@ func (r *EmailQueueRepository) EnqueueTx(ctx context.Context, tx *sql.Tx, entrie
 			entry.ID, entry.Status, entry.Priority, entry.SourceType, entry.SourceID,
 			entry.IntegrationID, entry.ProviderKind, entry.ContactEmail, entry.MessageID,
 			entry.TemplateID, payloadJSON, entry.Attempts, entry.MaxAttempts,
-			entry.CreatedAt, entry.UpdatedAt,
+			entry.NextRetryAt, entry.CreatedAt, entry.UpdatedAt,
 		)
 	}
 
@@ -135,6 +145,141 @@ func (r *EmailQueueRepository) EnqueueTx(ctx context.Context, tx *sql.Tx, entrie
 	return nil
 }
 
+// emailQueueDedupeKey identifies a logical message in the email_queue_dedup table
+type emailQueueDedupeKey struct {
+	contactEmail string
+	templateID   string
+	dedupeKey    string
+}
+
+// claimDedupeKeys records the dedupe key of each entry and returns the entries left to enqueue.
+// Entries whose (contact, template, dedupe key) was already claimed within the dedup window are
+// dropped, as are repeats within the batch. Entries without a dedupe key are always kept.
+func (r *EmailQueueRepository) claimDedupeKeys(ctx context.Context, tx *sql.Tx, entries []*domain.EmailQueueEntry, now time.Time) ([]*domain.EmailQueueEntry, error) {
+	if r.dedupWindow <= 0 {
+		return entries, nil
+	}
+
+	firstByKey := make(map[emailQueueDedupeKey]*domain.EmailQueueEntry)
+	insertBuilder := emailQueuePsql.
+		Insert("email_queue_dedup").
+		Columns("contact_email", "template_id", "dedupe_key", "created_at")
+
+	for _, entry := range entries {
+		if entry.DedupeKey == "" {
+			continue
+		}
+		key := emailQueueDedupeKey{entry.ContactEmail, entry.TemplateID, entry.DedupeKey}
+		if _, exists := firstByKey[key]; exists {
+			continue
+		}
+		firstByKey[key] = entry
+		insertBuilder = insertBuilder.Values(key.contactEmail, key.templateID, key.dedupeKey, now)
+	}
+
+	if len(firstByKey) == 0 {
+		return entries, nil
+	}
+
+	windowStart := now.Add(-r.dedupWindow)
+
+	// Expired keys are reclaimed by the upsert below, this only keeps the table small
+	_, err := tx.ExecContext(ctx, `DELETE FROM email_queue_dedup WHERE created_at < $1`, windowStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete expired dedupe keys: %w", err)
+	}
+
+	query, args, err := insertBuilder.
+		Suffix(`ON CONFLICT (contact_email, template_id, dedupe_key) DO UPDATE SET created_at = EXCLUDED.created_at
+			WHERE email_queue_dedup.created_at < ?
+			RETURNING contact_email, template_id, dedupe_key`, windowStart).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim dedupe keys: %w", err)
+	}
+	defer rows.Close()
+
+	claimed := make(map[emailQueueDedupeKey]bool)
+	for rows.Next() {
+		var key emailQueueDedupeKey
+		if err := rows.Scan(&key.contactEmail, &key.templateID, &key.dedupeKey); err != nil {
+			return nil, fmt.Errorf("failed to scan dedupe key: %w", err)
+		}
+		claimed[key] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating dedupe keys: %w", err)
+	}
+
+	kept := make([]*domain.EmailQueueEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.DedupeKey == "" {
+			kept = append(kept, entry)
+			continue
+		}
+		key := emailQueueDedupeKey{entry.ContactEmail, entry.TemplateID, entry.DedupeKey}
+		if claimed[key] && firstByKey[key] == entry {
+			kept = append(kept, entry)
+		}
+	}
+
+	return kept, nil
+}
+
+// ClaimDedupeKey records the dedupe key of an entry sent without being enqueued, and reports
+// whether the send should go ahead: false when the key was already claimed within the window
+func (r *EmailQueueRepository) ClaimDedupeKey(ctx context.Context, workspaceID string, entry *domain.EmailQueueEntry) (bool, error) {
+	if entry.DedupeKey == "" || r.dedupWindow <= 0 {
+		return true, nil
+	}
+
+	db, err := r.getDB(ctx, workspaceID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	kept, err := r.claimDedupeKeys(ctx, tx, []*domain.EmailQueueEntry{entry}, time.Now().UTC())
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return len(kept) == 1, nil
+}
+
+// ReleaseDedupeKey removes the dedupe key claimed for an entry whose send failed, so it can be retried
+func (r *EmailQueueRepository) ReleaseDedupeKey(ctx context.Context, workspaceID string, entry *domain.EmailQueueEntry) error {
+	if entry.DedupeKey == "" || r.dedupWindow <= 0 {
+		return nil
+	}
+
+	db, err := r.getDB(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `DELETE FROM email_queue_dedup WHERE contact_email = $1 AND template_id = $2 AND dedupe_key = $3`
+	if _, err := db.ExecContext(ctx, query, entry.ContactEmail, entry.TemplateID, entry.DedupeKey); err != nil {
+		return fmt.Errorf("failed to release dedupe key: %w", err)
+	}
+
+	return nil
+}
+
 // FetchPending retrieves pending emails for processing
 // Uses FOR UPDATE SKIP LOCKED for safe concurrent worker access
 func (r *EmailQueueRepository) FetchPending(ctx context.Context, workspaceID string, limit int) ([]*domain.EmailQueueEntry, error) {
@@ -146,14 +291,20 @@ func (r *EmailQueueRepository) FetchPending(ctx context.Context, workspaceID str
 	// Fetch pending emails ordered by priority (lower = higher priority), then by creation time
 	// Include failed emails that are ready for retry
 	// Include stuck processing entries (>2 minutes old) for recovery after worker crash
+	// Skip broadcast and automation emails to contacts with an active messaging hold;
+	// they stay queued and are picked up once the hold passes
 	query := `
 		SELECT id, status, priority, source_type, source_id, integration_id, provider_kind,
 		       contact_email, message_id, template_id, payload, attempts, max_attempts,
 		       last_error, next_retry_at, created_at, updated_at, processed_at
 		FROM email_queue
-		WHERE (status = 'pending' AND (next_retry_at IS NULL OR next_retry_at <= NOW()))
+		WHERE ((status = 'pending' AND (next_retry_at IS NULL OR next_retry_at <= NOW()))
 		   OR (status = 'failed' AND attempts < max_attempts AND next_retry_at <= NOW())
-		   OR (status = 'processing' AND updated_at < NOW() - INTERVAL '2 minutes')
+		   OR (status = 'processing' AND updated_at < NOW() - INTERVAL '2 minutes'))
+		  AND (source_type = 'transactional' OR NOT EXISTS (
+		       SELECT 1 FROM contacts c
+		       WHERE c.email = email_queue.contact_email AND c.messaging_hold_until > NOW()
+		  ))
 		ORDER BY priority ASC, created_at ASC
 		LIMIT $1
 		FOR UPDATE SKIP LOCKED
@@ -232,6 +383,33 @@ func (r *EmailQueueRepository) MarkAsSent(ctx context.Context, workspaceID strin
 	return nil
 }
 
+// MarkAsSentBatch deletes several entries after successful sends in a single statement
+func (r *EmailQueueRepository) MarkAsSentBatch(ctx context.Context, workspaceID string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	db, err := r.getDB(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query, args, err := emailQueuePsql.
+		Delete("email_queue").
+		Where(sq.Eq{"id": ids}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete sent emails: %w", err)
+	}
+
+	return nil
+}
+
 // MarkAsFailed marks an entry as failed and schedules retry
 func (r *EmailQueueRepository) MarkAsFailed(ctx context.Context, workspaceID string, id string, errorMsg string, nextRetryAt *time.Time) error {
 	db, err := r.getDB(ctx, workspaceID)
@@ -388,7 +566,7 @@ const pauseBySourceSQL = `
 
 const resumeBySourceSQL = `
 	UPDATE email_queue
-	SET status = 'pending', next_retry_at = NULL, updated_at = NOW()
+	SET status = 'pending', next_retry_at = CASE WHEN attempts = 0 THEN next_retry_at END, updated_at = NOW()
 	WHERE source_type = $1 AND source_id = $2
 	  AND status = 'paused'
 `
@@ -429,7 +607,8 @@ func (r *EmailQueueRepository) PauseBySourceTx(ctx context.Context, tx *sql.Tx,
 	return n, nil
 }
 
-// ResumeBySource flips paused entries back to pending and clears next_retry_at.
+// ResumeBySource flips paused entries back to pending and clears the retry backoff of
+// attempted entries. Entries never attempted keep next_retry_at (broadcast ramp schedules).
 func (r *EmailQueueRepository) ResumeBySource(ctx context.Context, workspaceID string, sourceType domain.EmailQueueSourceType, sourceID string) (int64, error) {
 	db, err := r.getDB(ctx, workspaceID)
 	if err != nil {
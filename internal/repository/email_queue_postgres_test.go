@@ -15,7 +15,7 @@ import (
 )
 
 func TestNewEmailQueueRepository(t *testing.T) {
-	repo := NewEmailQueueRepository(nil)
+	repo := NewEmailQueueRepository(nil, 10*time.Minute)
 	require.NotNil(t, repo)
 }
 
@@ -57,6 +57,7 @@ func TestEmailQueueRepository_Enqueue(t *testing.T) {
 				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
 				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
 				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				nil, // next_retry_at: sent as soon as possible
 				sqlmock.AnyArg(), sqlmock.AnyArg(),
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1))
@@ -139,7 +140,7 @@ func TestEmailQueueRepository_Enqueue(t *testing.T) {
 				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
 				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
 				3, // max_attempts default
-				sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
@@ -176,6 +177,198 @@ func TestEmailQueueRepository_Enqueue(t *testing.T) {
 	})
 }
 
+func TestEmailQueueRepository_Enqueue_Dedup(t *testing.T) {
+	ctx := context.Background()
+
+	newEntry := func(id, dedupeKey string) *domain.EmailQueueEntry {
+		return &domain.EmailQueueEntry{
+			ID:           id,
+			SourceType:   domain.EmailQueueSourceBroadcast,
+			ContactEmail: "test@example.com",
+			TemplateID:   "tpl-001",
+			DedupeKey:    dedupeKey,
+		}
+	}
+
+	t.Run("drops entries already enqueued within the window", func(t *testing.T) {
+		db, mock, cleanup := testutil.SetupMockDB(t)
+		defer cleanup()
+
+		repo := &EmailQueueRepository{db: db, dedupWindow: 10 * time.Minute}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM email_queue_dedup WHERE created_at < \$1`).
+			WithArgs(sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`INSERT INTO email_queue_dedup .* ON CONFLICT \(contact_email, template_id, dedupe_key\) DO UPDATE`).
+			WithArgs(
+				"test@example.com", "tpl-001", "broadcast", sqlmock.AnyArg(),
+				sqlmock.AnyArg(),
+			).
+			WillReturnRows(sqlmock.NewRows([]string{"contact_email", "template_id", "dedupe_key"}))
+		mock.ExpectCommit()
+
+		err := repo.Enqueue(ctx, "workspace-123", []*domain.EmailQueueEntry{newEntry("entry-1", "broadcast")})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("keeps the first of repeated entries and entries without key", func(t *testing.T) {
+		db, mock, cleanup := testutil.SetupMockDB(t)
+		defer cleanup()
+
+		repo := &EmailQueueRepository{db: db, dedupWindow: 10 * time.Minute}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM email_queue_dedup`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`INSERT INTO email_queue_dedup`).
+			WithArgs(
+				"test@example.com", "tpl-001", "broadcast", sqlmock.AnyArg(),
+				sqlmock.AnyArg(),
+			).
+			WillReturnRows(sqlmock.NewRows([]string{"contact_email", "template_id", "dedupe_key"}).
+				AddRow("test@example.com", "tpl-001", "broadcast"))
+		// Only entry-1 and entry-3 reach the queue
+		mock.ExpectExec(`INSERT INTO email_queue \(`).
+			WithArgs(
+				"entry-1", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				"entry-3", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			).
+			WillReturnResult(sqlmock.NewResult(2, 2))
+		mock.ExpectCommit()
+
+		err := repo.Enqueue(ctx, "workspace-123", []*domain.EmailQueueEntry{
+			newEntry("entry-1", "broadcast"),
+			newEntry("entry-2", "broadcast"),
+			newEntry("entry-3", ""),
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("skips deduplication when the window is disabled", func(t *testing.T) {
+		db, mock, cleanup := testutil.SetupMockDB(t)
+		defer cleanup()
+
+		repo := NewEmailQueueRepositoryWithDB(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO email_queue \(`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Enqueue(ctx, "workspace-123", []*domain.EmailQueueEntry{newEntry("entry-1", "broadcast")})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("returns error when claiming keys fails", func(t *testing.T) {
+		db, mock, cleanup := testutil.SetupMockDB(t)
+		defer cleanup()
+
+		repo := &EmailQueueRepository{db: db, dedupWindow: 10 * time.Minute}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM email_queue_dedup`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`INSERT INTO email_queue_dedup`).
+			WillReturnError(errors.New("claim error"))
+		mock.ExpectRollback()
+
+		err := repo.Enqueue(ctx, "workspace-123", []*domain.EmailQueueEntry{newEntry("entry-1", "broadcast")})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to claim dedupe keys")
+	})
+}
+
+func TestEmailQueueRepository_ClaimDedupeKey(t *testing.T) {
+	ctx := context.Background()
+	entry := &domain.EmailQueueEntry{
+		SourceType:   domain.EmailQueueSourceAutomation,
+		ContactEmail: "test@example.com",
+		TemplateID:   "tpl-001",
+		DedupeKey:    "automation:ca-1:node-1",
+	}
+
+	t.Run("claims a key not seen within the window", func(t *testing.T) {
+		db, mock, cleanup := testutil.SetupMockDB(t)
+		defer cleanup()
+
+		repo := &EmailQueueRepository{db: db, dedupWindow: 10 * time.Minute}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM email_queue_dedup WHERE created_at < \$1`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`INSERT INTO email_queue_dedup`).
+			WithArgs("test@example.com", "tpl-001", "automation:ca-1:node-1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"contact_email", "template_id", "dedupe_key"}).
+				AddRow("test@example.com", "tpl-001", "automation:ca-1:node-1"))
+		mock.ExpectCommit()
+
+		claimed, err := repo.ClaimDedupeKey(ctx, "workspace-123", entry)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("reports a key already claimed", func(t *testing.T) {
+		db, mock, cleanup := testutil.SetupMockDB(t)
+		defer cleanup()
+
+		repo := &EmailQueueRepository{db: db, dedupWindow: 10 * time.Minute}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM email_queue_dedup`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`INSERT INTO email_queue_dedup`).
+			WillReturnRows(sqlmock.NewRows([]string{"contact_email", "template_id", "dedupe_key"}))
+		mock.ExpectCommit()
+
+		claimed, err := repo.ClaimDedupeKey(ctx, "workspace-123", entry)
+		require.NoError(t, err)
+		assert.False(t, claimed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("always claims when the window is disabled", func(t *testing.T) {
+		db, mock, cleanup := testutil.SetupMockDB(t)
+		defer cleanup()
+
+		repo := NewEmailQueueRepositoryWithDB(db)
+
+		claimed, err := repo.ClaimDedupeKey(ctx, "workspace-123", entry)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestEmailQueueRepository_ReleaseDedupeKey(t *testing.T) {
+	db, mock, cleanup := testutil.SetupMockDB(t)
+	defer cleanup()
+
+	repo := &EmailQueueRepository{db: db, dedupWindow: 10 * time.Minute}
+
+	mock.ExpectExec(`DELETE FROM email_queue_dedup WHERE contact_email = \$1 AND template_id = \$2 AND dedupe_key = \$3`).
+		WithArgs("test@example.com", "tpl-001", "automation:ca-1:node-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.ReleaseDedupeKey(context.Background(), "workspace-123", &domain.EmailQueueEntry{
+		ContactEmail: "test@example.com",
+		TemplateID:   "tpl-001",
+		DedupeKey:    "automation:ca-1:node-1",
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestEmailQueueRepository_FetchPending(t *testing.T) {
 	ctx := context.Background()
 
@@ -336,6 +529,49 @@ func TestEmailQueueRepository_MarkAsSent(t *testing.T) {
 	})
 }
 
+func TestEmailQueueRepository_MarkAsSentBatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deletes all entries in one statement", func(t *testing.T) {
+		db, mock, cleanup := testutil.SetupMockDB(t)
+		defer cleanup()
+
+		repo := NewEmailQueueRepositoryWithDB(db)
+
+		mock.ExpectExec(`DELETE FROM email_queue WHERE id IN \(\$1,\$2,\$3\)`).
+			WithArgs("entry-1", "entry-2", "entry-3").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		err := repo.MarkAsSentBatch(ctx, "workspace-123", []string{"entry-1", "entry-2", "entry-3"})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("does nothing without entries", func(t *testing.T) {
+		db, mock, cleanup := testutil.SetupMockDB(t)
+		defer cleanup()
+
+		repo := NewEmailQueueRepositoryWithDB(db)
+
+		err := repo.MarkAsSentBatch(ctx, "workspace-123", nil)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("handles database error", func(t *testing.T) {
+		db, mock, cleanup := testutil.SetupMockDB(t)
+		defer cleanup()
+
+		repo := NewEmailQueueRepositoryWithDB(db)
+
+		mock.ExpectExec(`DELETE FROM email_queue WHERE id IN`).
+			WillReturnError(errors.New("database error"))
+
+		err := repo.MarkAsSentBatch(ctx, "workspace-123", []string{"entry-1", "entry-2"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete sent emails")
+	})
+}
 func TestEmailQueueRepository_MarkAsFailed(t *testing.T) {
 	ctx := context.Background()
 
@@ -588,6 +824,32 @@ func TestEmailQueueRepository_EnqueueTx(t *testing.T) {
 	})
 }
 
+func TestEmailQueueRepository_FetchPending_MessagingHold(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("skips marketing emails to contacts with an active messaging hold", func(t *testing.T) {
+		db, mock, cleanup := testutil.SetupMockDB(t)
+		defer cleanup()
+
+		repo := NewEmailQueueRepositoryWithDB(db)
+
+		rows := sqlmock.NewRows([]string{
+			"id", "status", "priority", "source_type", "source_id", "integration_id", "provider_kind",
+			"contact_email", "message_id", "template_id", "payload", "attempts", "max_attempts",
+			"last_error", "next_retry_at", "created_at", "updated_at", "processed_at",
+		})
+
+		mock.ExpectQuery(`SELECT .+ FROM email_queue WHERE .+ AND \(source_type = 'transactional' OR NOT EXISTS \(\s*SELECT 1 FROM contacts c\s+WHERE c\.email = email_queue\.contact_email AND c\.messaging_hold_until > NOW\(\)`).
+			WithArgs(10).
+			WillReturnRows(rows)
+
+		entries, err := repo.FetchPending(ctx, "workspace-123", 10)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestEmailQueueRepository_FetchPending_StuckProcessing(t *testing.T) {
 	ctx := context.Background()
 
@@ -738,13 +1000,13 @@ func TestEmailQueueRepository_PauseBySourceTx(t *testing.T) {
 func TestEmailQueueRepository_ResumeBySource(t *testing.T) {
 	ctx := context.Background()
 
-	t.Run("flips paused to pending and clears next_retry_at", func(t *testing.T) {
+	t.Run("flips paused to pending and clears the retry backoff", func(t *testing.T) {
 		db, mock, cleanup := testutil.SetupMockDB(t)
 		defer cleanup()
 
 		repo := NewEmailQueueRepositoryWithDB(db)
 
-		mock.ExpectExec(`UPDATE email_queue\s+SET status = 'pending', next_retry_at = NULL, updated_at = NOW\(\)\s+WHERE source_type = \$1 AND source_id = \$2\s+AND status = 'paused'`).
+		mock.ExpectExec(`UPDATE email_queue\s+SET status = 'pending', next_retry_at = CASE WHEN attempts = 0 THEN next_retry_at END, updated_at = NOW\(\)\s+WHERE source_type = \$1 AND source_id = \$2\s+AND status = 'paused'`).
 			WithArgs(domain.EmailQueueSourceBroadcast, "broadcast-1").
 			WillReturnResult(sqlmock.NewResult(0, 4))
 
@@ -782,7 +1044,7 @@ func TestEmailQueueRepository_ResumeBySourceTx(t *testing.T) {
 		tx, err := db.BeginTx(ctx, nil)
 		require.NoError(t, err)
 
-		mock.ExpectExec(`UPDATE email_queue\s+SET status = 'pending', next_retry_at = NULL`).
+		mock.ExpectExec(`UPDATE email_queue\s+SET status = 'pending', next_retry_at = CASE WHEN attempts = 0`).
 			WithArgs(domain.EmailQueueSourceBroadcast, "broadcast-1").
 			WillReturnResult(sqlmock.NewResult(0, 2))
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
@@ -394,6 +395,34 @@ func (r *segmentRepository) GetContactSegments(ctx context.Context, workspaceID
 	return segments, nil
 }
 
+// GetContactSegmentMembership retrieves a contact's membership of a segment, or nil when the contact is not a member
+func (r *segmentRepository) GetContactSegmentMembership(ctx context.Context, workspaceID string, email string, segmentID string) (*domain.ContactSegment, error) {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		SELECT email, segment_id, version, matched_at, computed_at
+		FROM contact_segments
+		WHERE email = $1 AND segment_id = $2
+	`
+
+	var membership domain.ContactSegment
+	err = workspaceDB.QueryRowContext(ctx, query, email, segmentID).Scan(
+		&membership.Email, &membership.SegmentID, &membership.Version, &membership.MatchedAt, &membership.ComputedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact segment membership: %w", err)
+	}
+
+	return &membership, nil
+}
+
 // GetSegmentContactCount gets the count of contacts in a segment
 func (r *segmentRepository) GetSegmentContactCount(ctx context.Context, workspaceID string, segmentID string) (int, error) {
 	// Get the workspace database connection
@@ -432,6 +461,43 @@ func (r *segmentRepository) PreviewSegment(ctx context.Context, workspaceID stri
 	return totalCount, nil
 }
 
+// PreviewSegmentContacts executes a segment query and returns a page of matching contacts,
+// most recently created first
+func (r *segmentRepository) PreviewSegmentContacts(ctx context.Context, workspaceID string, sqlQuery string, args []interface{}, limit, offset int) ([]*domain.Contact, error) {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM contacts c WHERE c.email IN (%s) ORDER BY c.created_at DESC, c.email ASC LIMIT $%d OFFSET $%d",
+		strings.Join(contactColumnsWithPrefix("c"), ", "), sqlQuery, len(args)+1, len(args)+2,
+	)
+	queryArgs := append(append([]interface{}{}, args...), limit, offset)
+
+	rows, err := workspaceDB.QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute preview contacts query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	contacts := make([]*domain.Contact, 0)
+	for rows.Next() {
+		contact, err := domain.ScanContact(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan contact: %w", err)
+		}
+		contacts = append(contacts, contact)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating preview contacts: %w", err)
+	}
+
+	return contacts, nil
+}
+
 // GetSegmentsDueForRecompute retrieves segments that need recomputation (recompute_after <= now)
 func (r *segmentRepository) GetSegmentsDueForRecompute(ctx context.Context, workspaceID string, limit int) ([]*domain.Segment, error) {
 	// Get the workspace database connection
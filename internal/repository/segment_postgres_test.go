@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"regexp"
 	"testing"
@@ -707,6 +708,57 @@ func TestSegmentRepository_GetContactSegments(t *testing.T) {
 	})
 }
 
+func TestSegmentRepository_GetContactSegmentMembership(t *testing.T) {
+	repo, _, mockWorkspaceRepo := setupSegmentRepositoryTest(t)
+
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), "workspace123").
+		Return(db, nil).
+		AnyTimes()
+
+	query := regexp.QuoteMeta(`SELECT email, segment_id, version, matched_at, computed_at
+		FROM contact_segments
+		WHERE email = $1 AND segment_id = $2`)
+
+	t.Run("contact is a member", func(t *testing.T) {
+		matchedAt := time.Now().UTC().Add(-time.Hour)
+		rows := sqlmock.NewRows([]string{"email", "segment_id", "version", "matched_at", "computed_at"}).
+			AddRow("test@example.com", "segment123", int64(2), matchedAt, time.Now().UTC())
+
+		sqlMock.ExpectQuery(query).WithArgs("test@example.com", "segment123").WillReturnRows(rows)
+
+		membership, err := repo.GetContactSegmentMembership(context.Background(), "workspace123", "test@example.com", "segment123")
+		require.NoError(t, err)
+		require.NotNil(t, membership)
+		assert.Equal(t, "segment123", membership.SegmentID)
+		assert.Equal(t, int64(2), membership.Version)
+		assert.True(t, membership.MatchedAt.Equal(matchedAt))
+	})
+
+	t.Run("contact is not a member", func(t *testing.T) {
+		sqlMock.ExpectQuery(query).WithArgs("test@example.com", "segment123").WillReturnError(sql.ErrNoRows)
+
+		membership, err := repo.GetContactSegmentMembership(context.Background(), "workspace123", "test@example.com", "segment123")
+		require.NoError(t, err)
+		assert.Nil(t, membership)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		sqlMock.ExpectQuery(query).WithArgs("test@example.com", "segment123").WillReturnError(errors.New("database error"))
+
+		membership, err := repo.GetContactSegmentMembership(context.Background(), "workspace123", "test@example.com", "segment123")
+		require.Error(t, err)
+		assert.Nil(t, membership)
+		assert.Contains(t, err.Error(), "failed to get contact segment membership")
+	})
+
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
 func TestSegmentRepository_GetSegmentContactCount(t *testing.T) {
 	repo, _, mockWorkspaceRepo := setupSegmentRepositoryTest(t)
 
@@ -831,6 +883,61 @@ func TestSegmentRepository_PreviewSegment(t *testing.T) {
 	})
 }
 
+func TestSegmentRepository_PreviewSegmentContacts(t *testing.T) {
+	repo, _, mockWorkspaceRepo := setupSegmentRepositoryTest(t)
+
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), "workspace123").
+		Return(db, nil).
+		AnyTimes()
+
+	testQuery := "SELECT email FROM contacts WHERE country = $1"
+	testArgs := []interface{}{"FR"}
+
+	contactRow := func(email string) []driver.Value {
+		now := time.Now().UTC()
+		values := make([]driver.Value, len(contactColumns))
+		values[0] = email
+		values[10] = "FR"
+		// created_at, updated_at, db_created_at, db_updated_at precede messaging_hold_until
+		for i := len(contactColumns) - 5; i < len(contactColumns)-1; i++ {
+			values[i] = now
+		}
+		return values
+	}
+
+	t.Run("returns a page of matching contacts", func(t *testing.T) {
+		rows := sqlmock.NewRows(contactColumns).
+			AddRow(contactRow("anne@example.com")...).
+			AddRow(contactRow("bruno@example.com")...)
+
+		sqlMock.ExpectQuery(`SELECT c\.email, .* FROM contacts c WHERE c\.email IN \(SELECT email FROM contacts WHERE country = \$1\) ORDER BY c\.created_at DESC, c\.email ASC LIMIT \$2 OFFSET \$3`).
+			WithArgs("FR", 2, 4).
+			WillReturnRows(rows)
+
+		contacts, err := repo.PreviewSegmentContacts(context.Background(), "workspace123", testQuery, testArgs, 2, 4)
+		require.NoError(t, err)
+		require.Len(t, contacts, 2)
+		assert.Equal(t, "anne@example.com", contacts[0].Email)
+		assert.Equal(t, "bruno@example.com", contacts[1].Email)
+		assert.Equal(t, []interface{}{"FR"}, testArgs, "segment args must not be modified")
+	})
+
+	t.Run("database query error", func(t *testing.T) {
+		sqlMock.ExpectQuery(`SELECT c\.email, .* FROM contacts c WHERE c\.email IN`).
+			WillReturnError(errors.New("database error"))
+
+		contacts, err := repo.PreviewSegmentContacts(context.Background(), "workspace123", testQuery, testArgs, 10, 0)
+		require.Error(t, err)
+		assert.Nil(t, contacts)
+		assert.Contains(t, err.Error(), "failed to execute preview contacts query")
+	})
+}
+
 func TestSegmentRepository_WithTransaction(t *testing.T) {
 	// Test segmentRepository.WithTransaction - this was at 0% coverage
 	repoInterface, _, mockWorkspaceRepo := setupSegmentRepositoryTest(t)
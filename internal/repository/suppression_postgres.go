@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// suppressionUpsertBatchSize caps the rows per INSERT statement, keeping the
+// number of bind parameters well below the PostgreSQL limit of 65535
+const suppressionUpsertBatchSize = 1000
+
+type suppressionRepository struct {
+	workspaceRepo domain.WorkspaceRepository
+}
+
+func NewSuppressionRepository(workspaceRepo domain.WorkspaceRepository) domain.SuppressionRepository {
+	return &suppressionRepository{
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// BulkUpsert inserts the suppressions in batches within a single transaction.
+// Addresses already suppressed keep their created_at and take the new reason.
+// Emails must be unique within the slice.
+func (r *suppressionRepository) BulkUpsert(ctx context.Context, workspaceID string, suppressions []*domain.Suppression) error {
+	if len(suppressions) == 0 {
+		return nil
+	}
+
+	db, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	for start := 0; start < len(suppressions); start += suppressionUpsertBatchSize {
+		end := min(start+suppressionUpsertBatchSize, len(suppressions))
+
+		query := psql.Insert("suppressions").
+			Columns("email", "reason", "created_at", "updated_at").
+			Suffix("ON CONFLICT (email) DO UPDATE SET reason = EXCLUDED.reason, updated_at = EXCLUDED.updated_at")
+		for _, s := range suppressions[start:end] {
+			query = query.Values(s.Email, s.Reason, s.CreatedAt, s.UpdatedAt)
+		}
+
+		sqlQuery, args, err := query.ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build query: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, sqlQuery, args...); err != nil {
+			return fmt.Errorf("failed to upsert suppressions: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// IsSuppressed reports whether the address is suppressed in the workspace
+func (r *suppressionRepository) IsSuppressed(ctx context.Context, workspaceID string, email string) (bool, error) {
+	db, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	var suppressed bool
+	err = db.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM suppressions WHERE email = $1)",
+		domain.NormalizeEmail(email),
+	).Scan(&suppressed)
+	if err != nil {
+		return false, fmt.Errorf("failed to check suppression: %w", err)
+	}
+
+	return suppressed, nil
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuppressionRepository_BulkUpsert(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace123"
+	now := time.Now().UTC()
+
+	setup := func(t *testing.T) (*mocks.MockWorkspaceRepository, domain.SuppressionRepository, sqlmock.Sqlmock) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil).AnyTimes()
+		return workspaceRepo, NewSuppressionRepository(workspaceRepo), mock
+	}
+
+	t.Run("empty input is a no-op", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		repo := NewSuppressionRepository(mocks.NewMockWorkspaceRepository(ctrl))
+
+		assert.NoError(t, repo.BulkUpsert(ctx, workspaceID, nil))
+	})
+
+	t.Run("upserts in a single statement", func(t *testing.T) {
+		_, repo, mock := setup(t)
+
+		suppressions := []*domain.Suppression{
+			{Email: "a@example.com", Reason: domain.SuppressionReasonBounce, CreatedAt: now, UpdatedAt: now},
+			{Email: "b@example.com", Reason: domain.SuppressionReasonComplaint, CreatedAt: now, UpdatedAt: now},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO suppressions \(email,reason,created_at,updated_at\) VALUES \(\$1,\$2,\$3,\$4\),\(\$5,\$6,\$7,\$8\) ON CONFLICT \(email\) DO UPDATE SET reason = EXCLUDED\.reason, updated_at = EXCLUDED\.updated_at`).
+			WithArgs("a@example.com", domain.SuppressionReasonBounce, now, now,
+				"b@example.com", domain.SuppressionReasonComplaint, now, now).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.BulkUpsert(ctx, workspaceID, suppressions))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("splits large imports into batches", func(t *testing.T) {
+		_, repo, mock := setup(t)
+
+		suppressions := make([]*domain.Suppression, suppressionUpsertBatchSize+1)
+		for i := range suppressions {
+			suppressions[i] = &domain.Suppression{
+				Email:     fmt.Sprintf("user%d@example.com", i),
+				Reason:    domain.SuppressionReasonManual,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO suppressions`).WillReturnResult(sqlmock.NewResult(0, suppressionUpsertBatchSize))
+		mock.ExpectExec(`INSERT INTO suppressions`).
+			WithArgs(suppressions[suppressionUpsertBatchSize].Email, domain.SuppressionReasonManual, now, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.BulkUpsert(ctx, workspaceID, suppressions))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back on error", func(t *testing.T) {
+		_, repo, mock := setup(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO suppressions`).WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err := repo.BulkUpsert(ctx, workspaceID, []*domain.Suppression{
+			{Email: "a@example.com", Reason: domain.SuppressionReasonBounce, CreatedAt: now, UpdatedAt: now},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to upsert suppressions")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(nil, errors.New("connection error"))
+		repo := NewSuppressionRepository(workspaceRepo)
+
+		err := repo.BulkUpsert(ctx, workspaceID, []*domain.Suppression{{Email: "a@example.com"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+}
+
+func TestSuppressionRepository_IsSuppressed(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace123"
+
+	setup := func(t *testing.T) (domain.SuppressionRepository, sqlmock.Sqlmock) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil).AnyTimes()
+		return NewSuppressionRepository(workspaceRepo), mock
+	}
+
+	t.Run("matches the normalized address", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM suppressions WHERE email = \$1\)`).
+			WithArgs("bounced@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		suppressed, err := repo.IsSuppressed(ctx, workspaceID, " Bounced@Example.com ")
+		require.NoError(t, err)
+		assert.True(t, suppressed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not suppressed", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectQuery(`SELECT EXISTS`).
+			WithArgs("clean@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		suppressed, err := repo.IsSuppressed(ctx, workspaceID, "clean@example.com")
+		require.NoError(t, err)
+		assert.False(t, suppressed)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectQuery(`SELECT EXISTS`).WillReturnError(errors.New("db error"))
+
+		_, err := repo.IsSuppressed(ctx, workspaceID, "a@example.com")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to check suppression")
+	})
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// enrollmentRampWindow counts the contacts that entered an automation during one minute
+type enrollmentRampWindow struct {
+	minute time.Time
+	count  int
+}
+
+// enrollmentRampLimiter enforces automations' enrollment ramps. Counts are kept in
+// memory, so the per-minute limit applies to each scheduler instance.
+type enrollmentRampLimiter struct {
+	mu      sync.Mutex
+	windows map[string]*enrollmentRampWindow
+	now     func() time.Time
+}
+
+// newEnrollmentRampLimiter creates a new enrollmentRampLimiter
+func newEnrollmentRampLimiter() *enrollmentRampLimiter {
+	return &enrollmentRampLimiter{
+		windows: make(map[string]*enrollmentRampWindow),
+		now:     time.Now,
+	}
+}
+
+// allow reports whether a contact may enter the automation now. When the current minute's
+// quota is used up, it returns the start of the next minute.
+func (l *enrollmentRampLimiter) allow(workspaceID string, automation *domain.Automation) (bool, time.Time) {
+	now := l.now().UTC()
+	key := workspaceID + ":" + automation.ID
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !automation.EnrollmentRamp.ActiveAt(now) {
+		delete(l.windows, key)
+		return true, time.Time{}
+	}
+
+	minute := now.Truncate(time.Minute)
+	window, ok := l.windows[key]
+	if !ok || !window.minute.Equal(minute) {
+		window = &enrollmentRampWindow{minute: minute}
+		l.windows[key] = window
+	}
+
+	if window.count < automation.EnrollmentRamp.PerMinute {
+		window.count++
+		return true, time.Time{}
+	}
+	return false, minute.Add(time.Minute)
+}
+
+// holdForEnrollmentRamp reschedules a contact that has not entered the workflow yet when
+// the automation's enrollment ramp is full for the current minute. It reports whether the
+// contact was held.
+func (e *AutomationExecutor) holdForEnrollmentRamp(ctx context.Context, workspaceID string, automation *domain.Automation, ca *domain.ContactAutomation) (bool, error) {
+	if automation.EnrollmentRamp == nil || ca.CurrentNodeID == nil || *ca.CurrentNodeID != automation.RootNodeID || ca.RetryCount > 0 {
+		return false, nil
+	}
+
+	allowed, retryAt := e.enrollmentRamp.allow(workspaceID, automation)
+	if allowed {
+		return false, nil
+	}
+
+	ca.ScheduledAt = &retryAt
+	if err := e.automationRepo.UpdateContactAutomation(ctx, workspaceID, ca); err != nil {
+		return true, fmt.Errorf("failed to reschedule contact for enrollment ramp: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomationExecutor_EnrollmentRamp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	workspaceID := "ws1"
+	rootNodeID := "trigger-1"
+	activatedAt := time.Date(2026, 10, 16, 9, 0, 30, 0, time.UTC)
+	clock := activatedAt
+
+	// Contacts entering the workflow run the trigger node, then wait on the next node
+	var entered []string
+	triggerExecutor := &testNodeExecutor{
+		nodeType: domain.NodeTypeTrigger,
+		execute: func(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+			entered = append(entered, params.Contact.ContactEmail)
+			scheduledAt := time.Now().Add(time.Hour)
+			return &NodeExecutionResult{
+				NextNodeID:  strPtr("delay-1"),
+				ScheduledAt: &scheduledAt,
+				Status:      domain.ContactAutomationStatusActive,
+			}, nil
+		},
+	}
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		contactRepo:    mockContactRepo,
+		nodeExecutors: map[domain.NodeType]NodeExecutor{
+			domain.NodeTypeTrigger: triggerExecutor,
+		},
+		enrollmentRamp: &enrollmentRampLimiter{
+			windows: make(map[string]*enrollmentRampWindow),
+			now:     func() time.Time { return clock },
+		},
+		logger: mockLogger,
+	}
+
+	// Activated with a ramp of 3 enrollments per minute for 2 minutes
+	automation := &domain.Automation{
+		ID:         "auto1",
+		Status:     domain.AutomationStatusLive,
+		RootNodeID: rootNodeID,
+		Nodes: []*domain.AutomationNode{
+			{ID: rootNodeID, Type: domain.NodeTypeTrigger, NextNodeID: strPtr("delay-1")},
+			{ID: "delay-1", Type: domain.NodeTypeDelay},
+		},
+		EnrollmentRamp: &domain.EnrollmentRamp{PerMinute: 3, DurationMinutes: 2, StartedAt: &activatedAt},
+	}
+
+	// 10 trigger events were pending when the automation went live
+	var pending []*domain.ContactAutomationWithWorkspace
+	for i := 1; i <= 10; i++ {
+		pending = append(pending, &domain.ContactAutomationWithWorkspace{
+			WorkspaceID: workspaceID,
+			ContactAutomation: domain.ContactAutomation{
+				ID:            fmt.Sprintf("ca%d", i),
+				AutomationID:  "auto1",
+				ContactEmail:  fmt.Sprintf("contact%d@example.com", i),
+				CurrentNodeID: strPtr(rootNodeID),
+				Status:        domain.ContactAutomationStatusActive,
+				MaxRetries:    3,
+			},
+		})
+	}
+
+	mockAutomationRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto1").Return(automation, nil).AnyTimes()
+	mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, email string) (*domain.Contact, error) {
+			return &domain.Contact{Email: email}, nil
+		}).AnyTimes()
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil).AnyTimes()
+	mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil).AnyTimes()
+	mockAutomationRepo.EXPECT().GetNodeExecutions(gomock.Any(), workspaceID, gomock.Any()).Return([]*domain.NodeExecution{}, nil).AnyTimes()
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil).AnyTimes()
+	mockAutomationRepo.EXPECT().RecordNodeExecutionStats(gomock.Any(), workspaceID, "auto1", gomock.Any()).Return(nil).AnyTimes()
+
+	// runTick processes the due contacts and returns the ones held at the entry node
+	runTick := func(due []*domain.ContactAutomationWithWorkspace) []*domain.ContactAutomationWithWorkspace {
+		mockAutomationRepo.EXPECT().GetScheduledContactAutomationsGlobal(gomock.Any(), gomock.Any(), 50).Return(due, nil)
+
+		processed, err := executor.ProcessBatch(context.Background(), 50)
+		require.NoError(t, err)
+		assert.Equal(t, len(due), processed)
+
+		var held []*domain.ContactAutomationWithWorkspace
+		for _, ca := range due {
+			if *ca.CurrentNodeID == rootNodeID {
+				held = append(held, ca)
+			}
+		}
+		return held
+	}
+
+	// First minute: 3 contacts enter, the others wait for the next minute
+	held := runTick(pending)
+	assert.Equal(t, []string{"contact1@example.com", "contact2@example.com", "contact3@example.com"}, entered)
+	require.Len(t, held, 7)
+	for _, ca := range held {
+		require.NotNil(t, ca.ScheduledAt)
+		assert.Equal(t, time.Date(2026, 10, 16, 9, 1, 0, 0, time.UTC), *ca.ScheduledAt)
+	}
+
+	// Second minute: 3 more enter
+	clock = activatedAt.Add(time.Minute)
+	held = runTick(held)
+	assert.Len(t, entered, 6)
+	require.Len(t, held, 4)
+	assert.Equal(t, time.Date(2026, 10, 16, 9, 2, 0, 0, time.UTC), *held[0].ScheduledAt)
+
+	// Once the ramp is over, the remaining contacts enter at once
+	clock = activatedAt.Add(2 * time.Minute)
+	held = runTick(held)
+	assert.Len(t, entered, 10)
+	assert.Empty(t, held)
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
@@ -10,6 +12,15 @@ import (
 	"github.com/google/uuid"
 )
 
+// purgeBatchSize caps how many finished contact automations are deleted per statement
+const purgeBatchSize = 1000
+
+// inactivityBatchSize caps how many inactive contacts are fetched per query
+const inactivityBatchSize = 500
+
+// goalBatchSize caps how many contacts are fetched per query when checking goals
+const goalBatchSize = 500
+
 // AutomationExecutor processes contacts through automation workflows
 type AutomationExecutor struct {
 	automationRepo  domain.AutomationRepository
@@ -21,6 +32,9 @@ type AutomationExecutor struct {
 	messageRepo     domain.MessageHistoryRepository
 	timelineRepo    domain.ContactTimelineRepository
 	nodeExecutors   map[domain.NodeType]NodeExecutor
+	nodeTimeout     time.Duration
+	enrollmentRamp  *enrollmentRampLimiter
+	nodeStats       nodeStatsAggregator
 	logger          logger.Logger
 	apiEndpoint     string
 }
@@ -36,23 +50,51 @@ func NewAutomationExecutor(
 	emailQueueRepo domain.EmailQueueRepository,
 	messageRepo domain.MessageHistoryRepository,
 	timelineRepo domain.ContactTimelineRepository,
+	segmentRepo domain.SegmentRepository,
+	suppressionRepo domain.SuppressionRepository,
 	log logger.Logger,
 	apiEndpoint string,
 ) *AutomationExecutor {
 	qb := NewQueryBuilder()
 
+	emailExecutor := NewEmailNodeExecutor(emailQueueRepo, templateRepo, workspaceRepo, listRepo, contactListRepo, apiEndpoint, log)
+	emailExecutor.SetMessageHistoryRepository(messageRepo)
+	transactionalEmailExecutor := NewTransactionalEmailNodeExecutor(emailQueueRepo, templateRepo, workspaceRepo, listRepo, contactListRepo, apiEndpoint, log)
+	transactionalEmailExecutor.SetMessageHistoryRepository(messageRepo)
+	forEachExecutor := NewForEachNodeExecutor()
+	addToListExecutor := NewAddToListNodeExecutor(contactListRepo)
+	addToListExecutor.SetListRepository(listRepo)
+	webhookExecutor := NewWebhookNodeExecutor(log)
+	webhookExecutor.SetWorkspaceRepository(workspaceRepo)
+
 	executors := map[domain.NodeType]NodeExecutor{
-		domain.NodeTypeTrigger:          NewTriggerNodeExecutor(),
-		domain.NodeTypeDelay:            NewDelayNodeExecutor(),
-		domain.NodeTypeEmail:            NewEmailNodeExecutor(emailQueueRepo, templateRepo, workspaceRepo, listRepo, contactListRepo, apiEndpoint, log),
-		domain.NodeTypeBranch:           NewBranchNodeExecutor(qb, workspaceRepo),
-		domain.NodeTypeFilter:           NewFilterNodeExecutor(qb, workspaceRepo),
-		domain.NodeTypeAddToList:        NewAddToListNodeExecutor(contactListRepo),
-		domain.NodeTypeRemoveFromList:   NewRemoveFromListNodeExecutor(contactListRepo),
-		domain.NodeTypeABTest:           NewABTestNodeExecutor(),
-		domain.NodeTypeWebhook:          NewWebhookNodeExecutor(log),
-		domain.NodeTypeListStatusBranch: NewListStatusBranchNodeExecutor(contactListRepo),
+		domain.NodeTypeTrigger:            NewTriggerNodeExecutor(segmentRepo, automationRepo),
+		domain.NodeTypeDelay:              NewDelayNodeExecutor(),
+		domain.NodeTypeEmail:              emailExecutor,
+		domain.NodeTypeBranch:             NewBranchNodeExecutor(qb, workspaceRepo),
+		domain.NodeTypeFilter:             NewFilterNodeExecutor(qb, workspaceRepo),
+		domain.NodeTypeAddToList:          addToListExecutor,
+		domain.NodeTypeRemoveFromList:     NewRemoveFromListNodeExecutor(contactListRepo),
+		domain.NodeTypeABTest:             NewABTestNodeExecutor(),
+		domain.NodeTypeWebhook:            webhookExecutor,
+		domain.NodeTypeSlack:              NewSlackNodeExecutor(log),
+		domain.NodeTypeListStatusBranch:   NewListStatusBranchNodeExecutor(contactListRepo),
+		domain.NodeTypeUnsubscribeAll:     NewUnsubscribeAllNodeExecutor(contactListRepo),
+		domain.NodeTypeEnrollInAutomation: NewEnrollInAutomationNodeExecutor(automationRepo),
+		domain.NodeTypeRecordRevenue:      NewRecordRevenueNodeExecutor(automationRepo),
+		domain.NodeTypeWaitForListStatus:  NewWaitForListStatusNodeExecutor(contactListRepo),
+		domain.NodeTypeWaitUntilDatetime:  NewWaitUntilDatetimeNodeExecutor(),
+		domain.NodeTypeForEach:            forEachExecutor,
+		domain.NodeTypeSuppressionBranch:  NewSuppressionBranchNodeExecutor(suppressionRepo),
+		domain.NodeTypeTransactionalEmail: transactionalEmailExecutor,
+		domain.NodeTypeWaitUntil:          NewWaitUntilNodeExecutor(workspaceRepo),
+		domain.NodeTypeExpressionBranch:   NewExpressionBranchNodeExecutor(),
+		domain.NodeTypeUpdateContact:      NewUpdateContactNodeExecutor(contactRepo),
+		domain.NodeTypeGoal:               NewGoalNodeExecutor(qb, workspaceRepo),
+		domain.NodeTypeThrottle:           NewThrottleNodeExecutor(automationRepo),
+		domain.NodeTypeReenroll:           NewReenrollNodeExecutor(qb, workspaceRepo, automationRepo),
 	}
+	forEachExecutor.SetNodeExecutors(executors)
 
 	return &AutomationExecutor{
 		automationRepo:  automationRepo,
@@ -64,18 +106,35 @@ func NewAutomationExecutor(
 		messageRepo:     messageRepo,
 		timelineRepo:    timelineRepo,
 		nodeExecutors:   executors,
+		enrollmentRamp:  newEnrollmentRampLimiter(),
 		logger:          log,
 		apiEndpoint:     apiEndpoint,
 	}
 }
 
+// SetNodeTimeout bounds how long a single node may run before it is treated as a
+// transient failure and retried on a later tick. Zero disables the timeout.
+func (e *AutomationExecutor) SetNodeTimeout(timeout time.Duration) {
+	e.nodeTimeout = timeout
+}
+
+// SetImmediateEmailSender enables immediate delivery on email and transactional_email nodes
+// (set after construction to avoid circular dependencies)
+func (e *AutomationExecutor) SetImmediateEmailSender(sender ImmediateEmailSender) {
+	for _, nodeType := range []domain.NodeType{domain.NodeTypeEmail, domain.NodeTypeTransactionalEmail} {
+		if emailExecutor, ok := e.nodeExecutors[nodeType].(*EmailNodeExecutor); ok {
+			emailExecutor.SetImmediateDelivery(sender, e.messageRepo)
+		}
+	}
+}
+
 // Execute processes a contact through their automation nodes until a delay or completion.
 // It loops through multiple nodes in a single tick for efficiency, persisting state after each node.
 func (e *AutomationExecutor) Execute(ctx context.Context, workspaceID string, contactAutomation *domain.ContactAutomation) error {
 	// Get automation once (outside loop)
 	automation, err := e.automationRepo.GetByID(ctx, workspaceID, contactAutomation.AutomationID)
 	if err != nil {
-		return e.handleError(ctx, workspaceID, contactAutomation, err, "failed to get automation")
+		return e.handleError(ctx, workspaceID, contactAutomation, domain.DefaultRetryBackoff(), err, "failed to get automation")
 	}
 
 	// Check if automation is paused/not live
@@ -84,6 +143,18 @@ func (e *AutomationExecutor) Execute(ctx context.Context, workspaceID string, co
 		return nil
 	}
 
+	// Contacts that have not entered the workflow yet wait while the enrollment ramp is full
+	if held, err := e.holdForEnrollmentRamp(ctx, workspaceID, automation, contactAutomation); held || err != nil {
+		return err
+	}
+
+	// Run the workflow version the contact enrolled on, so live edits don't affect contacts in flight
+	liveAutomation := automation
+	automation, err = pinnedAutomationVersion(ctx, e.automationRepo, workspaceID, automation, contactAutomation)
+	if err != nil {
+		return e.handleError(ctx, workspaceID, contactAutomation, automation.GetRetryBackoff(), err, "failed to get automation version")
+	}
+
 	// Early exit if already completed (no current node) - avoid fetching contact unnecessarily
 	if contactAutomation.CurrentNodeID == nil {
 		return e.markAsCompleted(ctx, workspaceID, contactAutomation, "completed")
@@ -92,7 +163,20 @@ func (e *AutomationExecutor) Execute(ctx context.Context, workspaceID string, co
 	// Get contact data once (outside loop) - only if we have nodes to process
 	contactData, err := e.contactRepo.GetContactByEmail(ctx, workspaceID, contactAutomation.ContactEmail)
 	if err != nil {
-		return e.handleError(ctx, workspaceID, contactAutomation, err, "failed to get contact")
+		// A contact deleted while enrolled will never be found again, exit instead of retrying
+		if errors.Is(err, domain.ErrContactNotFound) {
+			return e.markAsContactDeleted(ctx, workspaceID, contactAutomation)
+		}
+		return e.handleError(ctx, workspaceID, contactAutomation, automation.GetRetryBackoff(), err, "failed to get contact")
+	}
+
+	// Contacts that met a goal leave before running their next node
+	goal, err := e.metGoal(ctx, workspaceID, automation, contactAutomation, contactData)
+	if err != nil {
+		return e.handleError(ctx, workspaceID, contactAutomation, automation.GetRetryBackoff(), err, "failed to check goals")
+	}
+	if goal != nil {
+		return e.exitOnGoal(ctx, workspaceID, contactAutomation, goal)
 	}
 
 	// LOOP: Process nodes until delay, completion, or max iterations
@@ -108,7 +192,7 @@ func (e *AutomationExecutor) Execute(ctx context.Context, workspaceID string, co
 		// Get executor for node type
 		executor, ok := e.nodeExecutors[node.Type]
 		if !ok {
-			return e.handleError(ctx, workspaceID, contactAutomation,
+			return e.handleError(ctx, workspaceID, contactAutomation, automation.GetRetryBackoff(),
 				fmt.Errorf("unsupported node type: %s", node.Type), "unsupported node type")
 		}
 
@@ -133,16 +217,80 @@ func (e *AutomationExecutor) Execute(ctx context.Context, workspaceID string, co
 			ContactData:      contactData,
 			ExecutionContext: executionContext,
 		}
-		result, execErr := executor.Execute(ctx, params)
+		executionStart := time.Now()
+		result, execErr := e.executeNode(ctx, executor, params)
+		// Node stats describe the live graph: nodes a pinned version still runs after they were
+		// deleted from the current version are not counted
+		if liveAutomation.GetNodeByID(node.ID) != nil {
+			e.nodeStats.record(workspaceID, automation.ID, node.ID, time.Since(executionStart), execErr != nil)
+		}
+		completedAction := domain.NodeActionCompleted
 
-		// Handle execution error
-		if execErr != nil {
-			nodeExecution.Action = domain.NodeActionFailed
+		// An action node with an error edge routes its failures there instead of applying on_failure
+		if execErr != nil && node.ErrorNodeID != nil && ctx.Err() == nil {
+			e.logger.WithFields(map[string]interface{}{
+				"contact_email": contactAutomation.ContactEmail,
+				"automation_id": automation.ID,
+				"node_id":       node.ID,
+				"error_node_id": *node.ErrorNodeID,
+				"error":         execErr.Error(),
+			}).Warn("Node execution failed, routing to error node")
 			nodeExecution.Error = strPtr(execErr.Error())
-			completedAt := time.Now().UTC()
-			nodeExecution.CompletedAt = &completedAt
-			_ = e.automationRepo.UpdateNodeExecution(ctx, workspaceID, nodeExecution)
-			return e.handleError(ctx, workspaceID, contactAutomation, execErr, "node execution failed")
+			completedAction = domain.NodeActionFailed
+			result = &NodeExecutionResult{
+				NextNodeID: node.ErrorNodeID,
+				Status:     domain.ContactAutomationStatusActive,
+				Output:     failedNodeOutput(node.Type, result, map[string]interface{}{"error": execErr.Error()}),
+			}
+			execErr = nil
+		}
+
+		// Handle execution error according to the node's failure policy
+		if execErr != nil {
+			policy := node.OnFailure
+			if ctx.Err() != nil {
+				// Cancelled (e.g. shutdown) rather than failed: retry on a later tick
+				policy = domain.NodeFailurePolicyRetry
+			}
+			switch policy {
+			case domain.NodeFailurePolicySkip:
+				// Carry on to the next node, keeping the error on the skipped execution entry
+				e.logger.WithFields(map[string]interface{}{
+					"contact_email": contactAutomation.ContactEmail,
+					"automation_id": automation.ID,
+					"node_id":       node.ID,
+					"error":         execErr.Error(),
+				}).Warn("Node execution failed, skipping node per on_failure policy")
+				nodeExecution.Error = strPtr(execErr.Error())
+				completedAction = domain.NodeActionSkipped
+				result = &NodeExecutionResult{
+					NextNodeID: node.NextNodeID,
+					Status:     domain.ContactAutomationStatusActive,
+					Output:     failedNodeOutput(node.Type, result, map[string]interface{}{"skipped": true}),
+				}
+			case domain.NodeFailurePolicyExit:
+				nodeExecution.Action = domain.NodeActionFailed
+				nodeExecution.Error = strPtr(execErr.Error())
+				if result != nil {
+					nodeExecution.Output = result.Output
+				}
+				completedAt := time.Now().UTC()
+				nodeExecution.CompletedAt = &completedAt
+				_ = e.automationRepo.UpdateNodeExecution(ctx, workspaceID, nodeExecution)
+				errStr := fmt.Sprintf("node execution failed: %s", execErr.Error())
+				contactAutomation.LastError = &errStr
+				return e.markAsExited(ctx, workspaceID, contactAutomation, "node_failed")
+			default:
+				nodeExecution.Action = domain.NodeActionFailed
+				nodeExecution.Error = strPtr(execErr.Error())
+				if result != nil {
+					nodeExecution.Output = result.Output
+				}
+				completedAt := time.Now().UTC()
+				nodeExecution.CompletedAt = &completedAt
+				_ = e.automationRepo.UpdateNodeExecution(ctx, workspaceID, nodeExecution)
+				return e.handleError(ctx, workspaceID, contactAutomation, automation.GetRetryBackoff(), execErr, "node execution failed")
+			}
 		}
 
 		// Update contact automation state
@@ -151,6 +299,7 @@ func (e *AutomationExecutor) Execute(ctx context.Context, workspaceID string, co
 		if result.ExitReason != nil {
 			contactAutomation.ExitReason = result.ExitReason
 		}
+		contactAutomation.SetContextValues(result.Context)
 
 		// Determine status (terminal node = completed, unless waiting for a delay)
 		isTerminalNode := result.NextNodeID == nil && result.Status == domain.ContactAutomationStatusActive
@@ -163,12 +312,12 @@ func (e *AutomationExecutor) Execute(ctx context.Context, workspaceID string, co
 
 		// PERSIST STATE (critical for crash recovery)
 		if err := e.automationRepo.UpdateContactAutomation(ctx, workspaceID, contactAutomation); err != nil {
-			return e.handleError(ctx, workspaceID, contactAutomation, err, "failed to update contact automation")
+			return e.handleError(ctx, workspaceID, contactAutomation, automation.GetRetryBackoff(), err, "failed to update contact automation")
 		}
 
 		// Update node execution to completed
 		duration := time.Since(nodeStartTime).Milliseconds()
-		nodeExecution.Action = domain.NodeActionCompleted
+		nodeExecution.Action = completedAction
 		completedAt := time.Now().UTC()
 		nodeExecution.CompletedAt = &completedAt
 		nodeExecution.DurationMs = &duration
@@ -206,6 +355,40 @@ func (e *AutomationExecutor) Execute(ctx context.Context, workspaceID string, co
 	return nil
 }
 
+// executeNode runs a node executor bounded by the per-node timeout.
+// Executors honor ctx, so the node stops where it is when the timeout fires. It runs in
+// the caller's goroutine: its side effects (queued email, webhook call) are either
+// recorded as done or aborted before the contact is retried, never both.
+func (e *AutomationExecutor) executeNode(ctx context.Context, executor NodeExecutor, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	if e.nodeTimeout <= 0 {
+		return executor.Execute(ctx, params)
+	}
+
+	nodeCtx, cancel := context.WithTimeout(ctx, e.nodeTimeout)
+	defer cancel()
+
+	result, err := executor.Execute(nodeCtx, params)
+	// A node that completed is kept even if it finished past the deadline
+	if err != nil && ctx.Err() == nil && errors.Is(nodeCtx.Err(), context.DeadlineExceeded) {
+		return result, fmt.Errorf("node %s (%s) exceeded execution timeout of %s: %w", params.Node.ID, params.Node.Type, e.nodeTimeout, err)
+	}
+	return result, err
+}
+
+// failedNodeOutput builds the output of a node execution that failed, keeping the output
+// the executor returned alongside its error (e.g. a logged webhook exchange)
+func failedNodeOutput(nodeType domain.NodeType, partial *NodeExecutionResult, data map[string]interface{}) map[string]interface{} {
+	output := buildNodeOutput(nodeType, data)
+	if partial != nil {
+		for key, value := range partial.Output {
+			if _, exists := output[key]; !exists {
+				output[key] = value
+			}
+		}
+	}
+	return output
+}
+
 // ProcessBatch processes a batch of scheduled contacts
 func (e *AutomationExecutor) ProcessBatch(ctx context.Context, limit int) (int, error) {
 	// Get scheduled contacts globally
@@ -218,6 +401,9 @@ func (e *AutomationExecutor) ProcessBatch(ctx context.Context, limit int) (int,
 		return 0, nil
 	}
 
+	// Per-node stats are written once per automation for the whole batch
+	defer e.flushNodeStats(ctx)
+
 	processed := 0
 	for _, ca := range contacts {
 		if err := e.Execute(ctx, ca.WorkspaceID, &ca.ContactAutomation); err != nil {
@@ -236,15 +422,316 @@ func (e *AutomationExecutor) ProcessBatch(ctx context.Context, limit int) (int,
 	return processed, nil
 }
 
-// handleError handles an error during execution by updating retry count and status
-func (e *AutomationExecutor) handleError(ctx context.Context, workspaceID string, ca *domain.ContactAutomation, err error, context string) error {
+// PurgeFinishedContactAutomations deletes completed and exited contact automations older than
+// retentionDays in every workspace, keeping the scheduler query fast. Automation stats are counters
+// stored on the automation itself, so they are unaffected.
+func (e *AutomationExecutor) PurgeFinishedContactAutomations(ctx context.Context, retentionDays int) (int64, error) {
+	workspaces, err := e.workspaceRepo.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	cutoff := time.Now().UTC().AddDate(0, 0, -retentionDays)
+
+	var total int64
+	for _, workspace := range workspaces {
+		for {
+			deleted, err := e.automationRepo.DeleteFinishedContactAutomations(ctx, workspace.ID, cutoff, purgeBatchSize)
+			if err != nil {
+				e.logger.WithFields(map[string]interface{}{
+					"workspace_id": workspace.ID,
+					"error":        err.Error(),
+				}).Error("Failed to purge finished contact automations")
+				break
+			}
+			total += deleted
+			if deleted < purgeBatchSize {
+				break
+			}
+		}
+	}
+
+	return total, nil
+}
+
+// EnrollInactiveContacts enrolls, in every live automation with an inactivity trigger, the
+// contacts that had no activity during the trigger's inactivity_days. Returns the number of
+// contacts enrolled.
+func (e *AutomationExecutor) EnrollInactiveContacts(ctx context.Context) (int, error) {
+	workspaces, err := e.workspaceRepo.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	now := time.Now().UTC()
+	total := 0
+	for _, workspace := range workspaces {
+		automations, _, err := e.automationRepo.List(ctx, workspace.ID, domain.AutomationFilter{
+			Status: []domain.AutomationStatus{domain.AutomationStatusLive},
+		})
+		if err != nil {
+			e.logger.WithFields(map[string]interface{}{
+				"workspace_id": workspace.ID,
+				"error":        err.Error(),
+			}).Error("Failed to list automations for inactivity triggers")
+			continue
+		}
+
+		for _, automation := range automations {
+			if automation.Trigger == nil || !automation.Trigger.IsInactivity() {
+				continue
+			}
+			inactiveSince := now.AddDate(0, 0, -automation.Trigger.InactivityDays)
+			total += e.enrollInactiveContacts(ctx, workspace.ID, automation, inactiveSince)
+		}
+	}
+
+	return total, nil
+}
+
+// enrollInactiveContacts enrolls the inactive contacts of a single automation, page by page
+func (e *AutomationExecutor) enrollInactiveContacts(ctx context.Context, workspaceID string, automation *domain.Automation, inactiveSince time.Time) int {
+	enrolled := 0
+	afterEmail := ""
+	for {
+		emails, err := e.automationRepo.GetInactiveContactEmails(ctx, workspaceID, automation, inactiveSince, afterEmail, inactivityBatchSize)
+		if err != nil {
+			e.logger.WithFields(map[string]interface{}{
+				"workspace_id":  workspaceID,
+				"automation_id": automation.ID,
+				"error":         err.Error(),
+			}).Error("Failed to get inactive contacts")
+			return enrolled
+		}
+
+		for _, email := range emails {
+			ok, err := e.automationRepo.EnrollContact(ctx, workspaceID, automation, email)
+			if err != nil {
+				e.logger.WithFields(map[string]interface{}{
+					"workspace_id":  workspaceID,
+					"automation_id": automation.ID,
+					"contact_email": email,
+					"error":         err.Error(),
+				}).Error("Failed to enroll inactive contact")
+				continue
+			}
+			if ok {
+				enrolled++
+			}
+		}
+
+		if len(emails) < inactivityBatchSize {
+			return enrolled
+		}
+		afterEmail = emails[len(emails)-1]
+	}
+}
+
+// ProcessGoals exits the contacts waiting at a node (e.g. a delay) of a live automation that
+// met one of its goals, instead of letting them wait for their next scheduled pass. Goals are
+// evaluated in SQL for all the waiting contacts at once; contacts due now are checked by
+// Execute. Returns the number of contacts that exited.
+func (e *AutomationExecutor) ProcessGoals(ctx context.Context) (int, error) {
+	workspaces, err := e.workspaceRepo.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	total := 0
+	for _, workspace := range workspaces {
+		automations, _, err := e.automationRepo.List(ctx, workspace.ID, domain.AutomationFilter{
+			Status: []domain.AutomationStatus{domain.AutomationStatusLive},
+		})
+		if err != nil {
+			e.logger.WithFields(map[string]interface{}{
+				"workspace_id": workspace.ID,
+				"error":        err.Error(),
+			}).Error("Failed to list automations for goals")
+			continue
+		}
+
+		for _, automation := range automations {
+			if !automation.HasGoals() {
+				continue
+			}
+			total += e.processAutomationGoals(ctx, workspace.ID, automation)
+		}
+	}
+
+	return total, nil
+}
+
+// processAutomationGoals checks the goals of a single automation, for each workflow version
+// its waiting contacts run
+func (e *AutomationExecutor) processAutomationGoals(ctx context.Context, workspaceID string, automation *domain.Automation) int {
+	logFields := map[string]interface{}{
+		"workspace_id":  workspaceID,
+		"automation_id": automation.ID,
+	}
+
+	versions, err := e.automationRepo.ListWaitingVersions(ctx, workspaceID, automation.ID, automation.Version)
+	if err != nil {
+		logFields["error"] = err.Error()
+		e.logger.WithFields(logFields).Error("Failed to list waiting contacts for goals")
+		return 0
+	}
+
+	exited := 0
+	for _, version := range versions {
+		workflow := automation
+		if version != automation.Version {
+			pinned, err := e.automationRepo.GetVersion(ctx, workspaceID, automation.ID, version)
+			if err != nil {
+				logFields["version"] = version
+				logFields["error"] = err.Error()
+				e.logger.WithFields(logFields).Warn("Failed to get automation version for goals")
+				continue
+			}
+			workflow = automation.WithVersion(pinned)
+		}
+
+		for _, node := range workflow.Nodes {
+			if node == nil || node.Type != domain.NodeTypeGoal {
+				continue
+			}
+			exited += e.exitContactsMeetingGoal(ctx, workspaceID, automation, version, node)
+		}
+	}
+	return exited
+}
+
+// exitContactsMeetingGoal exits the waiting contacts of an automation version that meet a goal, page by page
+func (e *AutomationExecutor) exitContactsMeetingGoal(ctx context.Context, workspaceID string, automation *domain.Automation, version int, goal *domain.AutomationNode) int {
+	logFields := map[string]interface{}{
+		"workspace_id":  workspaceID,
+		"automation_id": automation.ID,
+		"node_id":       goal.ID,
+	}
+
+	goalExecutor, ok := e.nodeExecutors[domain.NodeTypeGoal].(*GoalNodeExecutor)
+	if !ok {
+		return 0
+	}
+	config, err := parseGoalNodeConfig(goal.Config)
+	if err != nil {
+		logFields["error"] = err.Error()
+		e.logger.WithFields(logFields).Warn("Invalid goal node config")
+		return 0
+	}
+	contactsSQL, contactsArgs, err := goalExecutor.ContactsSQL(config)
+	if err != nil {
+		logFields["error"] = err.Error()
+		e.logger.WithFields(logFields).Warn("Failed to build goal conditions")
+		return 0
+	}
+
+	filter := domain.GoalContactFilter{
+		AutomationID:   automation.ID,
+		Version:        version,
+		CurrentVersion: automation.Version,
+		ContactsSQL:    contactsSQL,
+		ContactsArgs:   contactsArgs,
+		Limit:          goalBatchSize,
+	}
+	// Automation-wide goals apply from enrollment, branch goals once the contact has passed through them
+	if config.Scope != domain.GoalScopeAutomation {
+		filter.PassedNodeID = goal.ID
+	}
+
+	exited := 0
+	for {
+		cas, err := e.automationRepo.ListContactAutomationsMeetingGoal(ctx, workspaceID, filter)
+		if err != nil {
+			logFields["error"] = err.Error()
+			e.logger.WithFields(logFields).Error("Failed to list contacts meeting goal")
+			return exited
+		}
+
+		for _, ca := range cas {
+			if err := e.exitOnGoal(ctx, workspaceID, ca, goal); err != nil {
+				e.logger.WithFields(map[string]interface{}{
+					"workspace_id":  workspaceID,
+					"automation_id": automation.ID,
+					"contact_email": ca.ContactEmail,
+					"error":         err.Error(),
+				}).Error("Failed to exit contact on goal")
+				continue
+			}
+			exited++
+		}
+
+		if len(cas) < goalBatchSize {
+			return exited
+		}
+		filter.AfterID = cas[len(cas)-1].ID
+	}
+}
+
+// metGoal returns the first goal node the contact meets, or nil. Automation-wide goals
+// apply from enrollment, branch goals once the contact has passed through them.
+func (e *AutomationExecutor) metGoal(ctx context.Context, workspaceID string, automation *domain.Automation, ca *domain.ContactAutomation, contact *domain.Contact) (*domain.AutomationNode, error) {
+	if !automation.HasGoals() {
+		return nil, nil
+	}
+	goalExecutor, ok := e.nodeExecutors[domain.NodeTypeGoal].(*GoalNodeExecutor)
+	if !ok {
+		return nil, nil
+	}
+
+	var passed map[string]interface{}
+	for _, node := range automation.Nodes {
+		if node == nil || node.Type != domain.NodeTypeGoal {
+			continue
+		}
+		config, err := parseGoalNodeConfig(node.Config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid goal node %s config: %w", node.ID, err)
+		}
+
+		if config.Scope != domain.GoalScopeAutomation {
+			if passed == nil {
+				if passed, err = e.buildContextFromNodeExecutions(ctx, workspaceID, ca.ID); err != nil {
+					return nil, fmt.Errorf("failed to get node executions: %w", err)
+				}
+			}
+			if _, ok := passed[node.ID]; !ok {
+				continue
+			}
+		}
+
+		met, err := goalExecutor.GoalMet(ctx, workspaceID, config, contact)
+		if err != nil {
+			return nil, err
+		}
+		if met {
+			return node, nil
+		}
+	}
+	return nil, nil
+}
+
+// exitOnGoal records the goal that was met and exits the contact with reason goal_met
+func (e *AutomationExecutor) exitOnGoal(ctx context.Context, workspaceID string, ca *domain.ContactAutomation, goal *domain.AutomationNode) error {
+	entry := e.createNodeExecution(ca, goal, domain.NodeActionCompleted)
+	completedAt := time.Now().UTC()
+	entry.CompletedAt = &completedAt
+	entry.Output = buildNodeOutput(domain.NodeTypeGoal, map[string]interface{}{"goal_met": true})
+	_ = e.automationRepo.CreateNodeExecution(ctx, workspaceID, entry)
+
+	return e.markAsExited(ctx, workspaceID, ca, domain.GoalExitReason)
+}
+
+// handleError handles an error during execution by updating retry count and status.
+// The contact is retried after the backoff delay until it reaches its max retries, and
+// fails right away on a permanent node error.
+func (e *AutomationExecutor) handleError(ctx context.Context, workspaceID string, ca *domain.ContactAutomation, backoff domain.RetryBackoff, err error, context string) error {
 	ca.RetryCount++
 	errStr := fmt.Sprintf("%s: %s", context, err.Error())
 	ca.LastError = &errStr
 	now := time.Now().UTC()
 	ca.LastRetryAt = &now
 
-	if ca.RetryCount >= ca.MaxRetries {
+	if ca.RetryCount >= ca.MaxRetries || isPermanentNodeError(err) {
 		ca.Status = domain.ContactAutomationStatusFailed
 		_ = e.automationRepo.IncrementAutomationStat(ctx, workspaceID, ca.AutomationID, "failed")
 
@@ -256,11 +743,10 @@ func (e *AutomationExecutor) handleError(ctx context.Context, workspaceID string
 			"workspace_id":  workspaceID,
 			"retry_count":   ca.RetryCount,
 			"error":         errStr,
-		}).Error("Automation execution failed after max retries")
+		}).Error("Automation execution failed")
 	} else {
-		// Exponential backoff: 1min, 2min, 4min, etc.
-		backoff := time.Duration(1<<uint(ca.RetryCount)) * time.Minute
-		nextRetry := time.Now().UTC().Add(backoff)
+		// Exponential backoff with jitter, e.g. ~30s, ~1min, ~2min, ... up to the cap
+		nextRetry := now.Add(backoff.Delay(ca.RetryCount, rand.Float64()))
 		ca.ScheduledAt = &nextRetry
 
 		e.logger.WithFields(map[string]interface{}{
@@ -331,6 +817,25 @@ func (e *AutomationExecutor) markAsExited(ctx context.Context, workspaceID strin
 	return e.automationRepo.UpdateContactAutomation(ctx, workspaceID, ca)
 }
 
+// markAsContactDeleted exits a contact automation whose contact was deleted. Unlike markAsExited
+// it records no automation.end timeline event, as there is no contact left to attach it to.
+func (e *AutomationExecutor) markAsContactDeleted(ctx context.Context, workspaceID string, ca *domain.ContactAutomation) error {
+	reason := domain.ContactDeletedExitReason
+	ca.Status = domain.ContactAutomationStatusExited
+	ca.ScheduledAt = nil
+	ca.ExitReason = &reason
+
+	e.logger.WithFields(map[string]interface{}{
+		"contact_email": ca.ContactEmail,
+		"automation_id": ca.AutomationID,
+		"workspace_id":  workspaceID,
+	}).Info("Contact deleted, exiting automation")
+
+	_ = e.automationRepo.IncrementAutomationStat(ctx, workspaceID, ca.AutomationID, "exited")
+
+	return e.automationRepo.UpdateContactAutomation(ctx, workspaceID, ca)
+}
+
 // createNodeExecution creates a new node execution entry for logging
 func (e *AutomationExecutor) createNodeExecution(ca *domain.ContactAutomation, node *domain.AutomationNode, action domain.NodeAction) *domain.NodeExecution {
 	return &domain.NodeExecution{
@@ -385,3 +890,18 @@ func (e *AutomationExecutor) createAutomationEndEvent(ctx context.Context, works
 		}).Warn("Failed to create automation.end timeline event")
 	}
 }
+
+// pinnedAutomationVersion returns the automation running the workflow version the contact is pinned to.
+// Contacts enrolled before versioning, or on the current version, use the automation as is.
+func pinnedAutomationVersion(ctx context.Context, repo domain.AutomationRepository, workspaceID string, automation *domain.Automation, ca *domain.ContactAutomation) (*domain.Automation, error) {
+	if ca.AutomationVersion == nil || *ca.AutomationVersion == automation.Version {
+		return automation, nil
+	}
+
+	version, err := repo.GetVersion(ctx, workspaceID, automation.ID, *ca.AutomationVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return automation.WithVersion(version), nil
+}
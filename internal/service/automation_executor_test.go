@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/domain/mocks"
 	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
@@ -144,6 +146,141 @@ func TestAutomationExecutor_Execute_AutomationPaused(t *testing.T) {
 	assert.Equal(t, &nodeID, contactAutomation.CurrentNodeID)
 }
 
+func TestAutomationExecutor_Execute_ResumeAfterPause(t *testing.T) {
+	// A contact parked after a delay must pick up at its current node with its context intact
+	// once the automation is resumed, without re-running the nodes it already went through
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	var executedNodes []string
+	var capturedContext map[string]interface{}
+	recordingExecutor := func(nodeType domain.NodeType) *testNodeExecutor {
+		return &testNodeExecutor{
+			nodeType: nodeType,
+			execute: func(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+				executedNodes = append(executedNodes, params.Node.ID)
+				capturedContext = params.ExecutionContext
+				return &NodeExecutionResult{
+					NextNodeID: params.Node.NextNodeID,
+					Status:     domain.ContactAutomationStatusActive,
+					Output:     map[string]interface{}{"node_type": string(nodeType)},
+				}, nil
+			},
+		}
+	}
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		contactRepo:    mockContactRepo,
+		timelineRepo:   mockTimelineRepo,
+		nodeExecutors: map[domain.NodeType]NodeExecutor{
+			domain.NodeTypeTrigger:   recordingExecutor(domain.NodeTypeTrigger),
+			domain.NodeTypeDelay:     recordingExecutor(domain.NodeTypeDelay),
+			domain.NodeTypeAddToList: recordingExecutor(domain.NodeTypeAddToList),
+		},
+		logger: mockLogger,
+	}
+
+	workspaceID := "ws1"
+	triggerNodeID := "trigger_node"
+	delayNodeID := "delay_node"
+	addNodeID := "add_node"
+
+	// trigger -> delay -> add_to_list, with the contact parked on add_to_list until the delay ends
+	automation := &domain.Automation{
+		ID:         "auto1",
+		Name:       "Test Automation",
+		Status:     domain.AutomationStatusLive,
+		Version:    2,
+		RootNodeID: triggerNodeID,
+		Nodes: []*domain.AutomationNode{
+			{ID: triggerNodeID, Type: domain.NodeTypeTrigger, NextNodeID: &delayNodeID},
+			{ID: delayNodeID, Type: domain.NodeTypeDelay, NextNodeID: &addNodeID},
+			{ID: addNodeID, Type: domain.NodeTypeAddToList},
+		},
+	}
+
+	version := 2
+	scheduledAt := time.Now().UTC().Add(-10 * time.Minute).Truncate(time.Second)
+	contactAutomation := &domain.ContactAutomation{
+		ID:                "ca1",
+		AutomationID:      "auto1",
+		AutomationVersion: &version,
+		ContactEmail:      "test@example.com",
+		CurrentNodeID:     &addNodeID,
+		Status:            domain.ContactAutomationStatusActive,
+		ScheduledAt:       &scheduledAt,
+		Context:           map[string]interface{}{"order_id": "order_42"},
+	}
+
+	previousExecutions := []*domain.NodeExecution{
+		{
+			ID:                  "exec1",
+			ContactAutomationID: "ca1",
+			NodeID:              triggerNodeID,
+			NodeType:            domain.NodeTypeTrigger,
+			Action:              domain.NodeActionCompleted,
+			Output:              map[string]interface{}{"node_type": "trigger", "event_name": "order_placed"},
+		},
+		{
+			ID:                  "exec2",
+			ContactAutomationID: "ca1",
+			NodeID:              delayNodeID,
+			NodeType:            domain.NodeTypeDelay,
+			Action:              domain.NodeActionCompleted,
+			Output:              map[string]interface{}{"node_type": "delay", "delay_until": scheduledAt.Format(time.RFC3339)},
+		},
+	}
+
+	// 1. While paused, a tick leaves the contact untouched (no writes expected)
+	paused := *automation
+	paused.Status = domain.AutomationStatusPaused
+	mockAutomationRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto1").Return(&paused, nil)
+
+	err := executor.Execute(context.Background(), workspaceID, contactAutomation)
+	require.NoError(t, err)
+
+	assert.Empty(t, executedNodes, "No node should run while the automation is paused")
+	assert.Equal(t, addNodeID, *contactAutomation.CurrentNodeID)
+	require.NotNil(t, contactAutomation.ScheduledAt)
+	assert.True(t, scheduledAt.Equal(*contactAutomation.ScheduledAt), "scheduled_at must be preserved while paused")
+	assert.Equal(t, "order_42", contactAutomation.Context["order_id"])
+
+	// 2. Once resumed, the contact continues from the node it was parked on
+	mockAutomationRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto1").Return(automation, nil)
+	mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, "test@example.com").Return(&domain.Contact{Email: "test@example.com"}, nil)
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, entry *domain.NodeExecution) error {
+			assert.Equal(t, addNodeID, entry.NodeID)
+			return nil
+		})
+	mockAutomationRepo.EXPECT().GetNodeExecutions(gomock.Any(), workspaceID, "ca1").Return(previousExecutions, nil)
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "auto1", "completed").Return(nil)
+	mockTimelineRepo.EXPECT().Create(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+
+	err = executor.Execute(context.Background(), workspaceID, contactAutomation)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{addNodeID}, executedNodes, "Only the parked node should run, not the trigger or the delay again")
+	require.NotNil(t, capturedContext)
+	assert.Len(t, capturedContext, 2)
+	triggerOutput, ok := capturedContext[triggerNodeID].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "order_placed", triggerOutput["event_name"])
+	delayOutput, ok := capturedContext[delayNodeID].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, scheduledAt.Format(time.RFC3339), delayOutput["delay_until"])
+	assert.Equal(t, "order_42", contactAutomation.Context["order_id"], "Contact context must survive pause and resume")
+	assert.Equal(t, domain.ContactAutomationStatusCompleted, contactAutomation.Status)
+}
+
 func TestAutomationExecutor_Execute_NoCurrentNode(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -402,6 +539,117 @@ func TestAutomationExecutor_Execute_TerminalNode(t *testing.T) {
 	assert.Equal(t, domain.ContactAutomationStatusCompleted, contactAutomation.Status)
 }
 
+func TestAutomationExecutor_Execute_PinnedVersion(t *testing.T) {
+	// Tests that a contact enrolled on an older version runs that version's workflow
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	executor := &AutomationExecutor{
+		automationRepo:  mockAutomationRepo,
+		contactRepo:     mockContactRepo,
+		contactListRepo: mockContactListRepo,
+		timelineRepo:    mockTimelineRepo,
+		nodeExecutors: map[domain.NodeType]NodeExecutor{
+			domain.NodeTypeAddToList: NewAddToListNodeExecutor(mockContactListRepo),
+		},
+		logger: mockLogger,
+	}
+
+	workspaceID := "ws1"
+	nodeID := "v1_node"
+	pinned := 1
+
+	contactAutomation := &domain.ContactAutomation{
+		ID:                "ca1",
+		AutomationID:      "auto1",
+		AutomationVersion: &pinned,
+		ContactEmail:      "test@example.com",
+		CurrentNodeID:     &nodeID,
+		Status:            domain.ContactAutomationStatusActive,
+	}
+
+	// The live automation no longer contains the node the contact is parked on
+	automation := &domain.Automation{
+		ID:         "auto1",
+		Name:       "Test Automation",
+		Status:     domain.AutomationStatusLive,
+		Version:    2,
+		RootNodeID: "v2_node",
+		Nodes:      []*domain.AutomationNode{{ID: "v2_node", Type: domain.NodeTypeAddToList}},
+	}
+
+	v1 := &domain.AutomationVersion{
+		AutomationID: "auto1",
+		Version:      1,
+		RootNodeID:   nodeID,
+		Nodes: []*domain.AutomationNode{{
+			ID:   nodeID,
+			Type: domain.NodeTypeAddToList,
+			Config: map[string]interface{}{
+				"list_id": "list1",
+				"status":  "active",
+			},
+		}},
+	}
+
+	mockAutomationRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto1").Return(automation, nil)
+	mockAutomationRepo.EXPECT().GetVersion(gomock.Any(), workspaceID, "auto1", 1).Return(v1, nil)
+	mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, "test@example.com").Return(&domain.Contact{Email: "test@example.com"}, nil)
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, execution *domain.NodeExecution) error {
+			assert.Equal(t, nodeID, execution.NodeID)
+			return nil
+		})
+	mockAutomationRepo.EXPECT().GetNodeExecutions(gomock.Any(), workspaceID, "ca1").Return([]*domain.NodeExecution{}, nil)
+	mockContactListRepo.EXPECT().AddContactToList(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "auto1", "completed").Return(nil)
+	mockTimelineRepo.EXPECT().Create(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+
+	err := executor.Execute(context.Background(), workspaceID, contactAutomation)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.ContactAutomationStatusCompleted, contactAutomation.Status)
+	assert.Equal(t, 2, automation.Version, "live automation should not be modified")
+	assert.Empty(t, executor.nodeStats.drain(), "nodes deleted from the live automation get no stats")
+}
+
+func TestPinnedAutomationVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	automation := &domain.Automation{ID: "auto1", Version: 2}
+
+	t.Run("unpinned contact uses the current version", func(t *testing.T) {
+		result, err := pinnedAutomationVersion(context.Background(), mockAutomationRepo, "ws1", automation, &domain.ContactAutomation{})
+		require.NoError(t, err)
+		assert.Same(t, automation, result)
+	})
+
+	t.Run("contact pinned to the current version skips the lookup", func(t *testing.T) {
+		current := 2
+		result, err := pinnedAutomationVersion(context.Background(), mockAutomationRepo, "ws1", automation, &domain.ContactAutomation{AutomationVersion: &current})
+		require.NoError(t, err)
+		assert.Same(t, automation, result)
+	})
+
+	t.Run("missing version returns an error", func(t *testing.T) {
+		old := 1
+		mockAutomationRepo.EXPECT().GetVersion(gomock.Any(), "ws1", "auto1", 1).Return(nil, errors.New("automation version not found: auto1 v1"))
+
+		_, err := pinnedAutomationVersion(context.Background(), mockAutomationRepo, "ws1", automation, &domain.ContactAutomation{AutomationVersion: &old})
+		require.Error(t, err)
+	})
+}
+
 func TestAutomationExecutor_Execute_MaxRetriesExceeded(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -443,6 +691,77 @@ func TestAutomationExecutor_Execute_MaxRetriesExceeded(t *testing.T) {
 	assert.Equal(t, 3, contactAutomation.RetryCount)
 }
 
+func TestAutomationExecutor_Execute_PermanentNodeError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		contactRepo:    mockContactRepo,
+		timelineRepo:   mockTimelineRepo,
+		nodeExecutors: map[domain.NodeType]NodeExecutor{
+			domain.NodeTypeDelay: NewDelayNodeExecutor(),
+		},
+		logger: mockLogger,
+	}
+
+	workspaceID := "ws1"
+	nodeID := "delay_node"
+
+	contactAutomation := &domain.ContactAutomation{
+		ID:            "ca1",
+		AutomationID:  "auto1",
+		ContactEmail:  "test@example.com",
+		CurrentNodeID: &nodeID,
+		Status:        domain.ContactAutomationStatusActive,
+		MaxRetries:    3,
+	}
+
+	automation := &domain.Automation{
+		ID:     "auto1",
+		Status: domain.AutomationStatusLive,
+		Nodes: []*domain.AutomationNode{
+			{
+				ID:   nodeID,
+				Type: domain.NodeTypeDelay,
+				Config: map[string]interface{}{
+					"duration":     1,
+					"unit":         "days",
+					"anchor_field": "custom_string_1",
+				},
+			},
+		},
+	}
+
+	contact := &domain.Contact{
+		Email:         "test@example.com",
+		CustomString1: &domain.NullableString{String: "next tuesday"},
+	}
+
+	mockAutomationRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto1").Return(automation, nil)
+	mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, "test@example.com").Return(contact, nil)
+	mockAutomationRepo.EXPECT().GetNodeExecutions(gomock.Any(), workspaceID, "ca1").Return([]*domain.NodeExecution{}, nil)
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(2)
+	mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "auto1", "failed").Return(nil)
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockTimelineRepo.EXPECT().Create(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+
+	err := executor.Execute(context.Background(), workspaceID, contactAutomation)
+	require.NoError(t, err)
+
+	// A malformed anchor date fails the contact on the first attempt instead of retrying
+	assert.Equal(t, domain.ContactAutomationStatusFailed, contactAutomation.Status)
+	assert.Equal(t, 1, contactAutomation.RetryCount)
+	require.NotNil(t, contactAutomation.LastError)
+	assert.Contains(t, *contactAutomation.LastError, "invalid date")
+}
+
 func TestAutomationExecutor_ProcessBatch(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -535,6 +854,15 @@ func TestAutomationExecutor_ProcessBatch(t *testing.T) {
 	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "auto1", "completed").Return(nil)
 	mockTimelineRepo.EXPECT().Create(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
 
+	// Both executions of the node are written in a single stats update
+	mockAutomationRepo.EXPECT().RecordNodeExecutionStats(gomock.Any(), workspaceID, "auto1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, nodes map[string]*domain.AutomationNodeExecutionStats) error {
+			require.Contains(t, nodes, nodeID)
+			assert.Equal(t, int64(2), nodes[nodeID].Executions)
+			assert.Equal(t, int64(0), nodes[nodeID].Failures)
+			return nil
+		})
+
 	processed, err := executor.ProcessBatch(context.Background(), 50)
 	require.NoError(t, err)
 	assert.Equal(t, 2, processed)
@@ -560,6 +888,113 @@ func TestAutomationExecutor_ProcessBatch_Empty(t *testing.T) {
 	assert.Equal(t, 0, processed)
 }
 
+func TestAutomationExecutor_PurgeFinishedContactAutomations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		workspaceRepo:  mockWorkspaceRepo,
+		nodeExecutors:  map[domain.NodeType]NodeExecutor{},
+		logger:         mockLogger,
+	}
+
+	mockWorkspaceRepo.EXPECT().List(gomock.Any()).Return([]*domain.Workspace{
+		{ID: "ws1"}, {ID: "ws2"}, {ID: "ws3"},
+	}, nil)
+
+	cutoffMatcher := gomock.AssignableToTypeOf(time.Time{})
+
+	// ws1 needs a second batch, ws2 fails and is skipped, ws3 has nothing to purge
+	gomock.InOrder(
+		mockAutomationRepo.EXPECT().DeleteFinishedContactAutomations(gomock.Any(), "ws1", cutoffMatcher, purgeBatchSize).Return(int64(purgeBatchSize), nil),
+		mockAutomationRepo.EXPECT().DeleteFinishedContactAutomations(gomock.Any(), "ws1", cutoffMatcher, purgeBatchSize).Return(int64(5), nil),
+	)
+	mockAutomationRepo.EXPECT().DeleteFinishedContactAutomations(gomock.Any(), "ws2", cutoffMatcher, purgeBatchSize).Return(int64(0), errors.New("db error"))
+	mockAutomationRepo.EXPECT().DeleteFinishedContactAutomations(gomock.Any(), "ws3", cutoffMatcher, purgeBatchSize).
+		DoAndReturn(func(ctx context.Context, workspaceID string, before time.Time, limit int) (int64, error) {
+			expected := time.Now().UTC().AddDate(0, 0, -90)
+			assert.WithinDuration(t, expected, before, time.Minute)
+			return 0, nil
+		})
+
+	deleted, err := executor.PurgeFinishedContactAutomations(context.Background(), 90)
+	require.NoError(t, err)
+	assert.Equal(t, int64(purgeBatchSize+5), deleted)
+}
+
+func TestAutomationExecutor_PurgeFinishedContactAutomations_ListError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+
+	executor := &AutomationExecutor{
+		workspaceRepo: mockWorkspaceRepo,
+		nodeExecutors: map[domain.NodeType]NodeExecutor{},
+		logger:        setupMockLogger(ctrl),
+	}
+
+	mockWorkspaceRepo.EXPECT().List(gomock.Any()).Return(nil, errors.New("db error"))
+
+	deleted, err := executor.PurgeFinishedContactAutomations(context.Background(), 90)
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), deleted)
+}
+
+func TestAutomationExecutor_EnrollInactiveContacts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		workspaceRepo:  mockWorkspaceRepo,
+		nodeExecutors:  map[domain.NodeType]NodeExecutor{},
+		logger:         setupMockLogger(ctrl),
+	}
+
+	inactivity := &domain.Automation{
+		ID:     "winback",
+		Status: domain.AutomationStatusLive,
+		Trigger: &domain.TimelineTriggerConfig{
+			EventKind:      "inactivity",
+			Frequency:      domain.TriggerFrequencyEveryTime,
+			InactivityDays: 30,
+		},
+	}
+	eventTriggered := &domain.Automation{
+		ID:      "welcome",
+		Status:  domain.AutomationStatusLive,
+		Trigger: &domain.TimelineTriggerConfig{EventKind: "contact.created", Frequency: domain.TriggerFrequencyOnce},
+	}
+
+	mockWorkspaceRepo.EXPECT().List(gomock.Any()).Return([]*domain.Workspace{{ID: "ws1"}}, nil)
+	mockAutomationRepo.EXPECT().List(gomock.Any(), "ws1", domain.AutomationFilter{
+		Status: []domain.AutomationStatus{domain.AutomationStatusLive},
+	}).Return([]*domain.Automation{eventTriggered, inactivity}, 2, nil)
+
+	// Only the inactivity automation is evaluated, over the last 30 days
+	mockAutomationRepo.EXPECT().GetInactiveContactEmails(gomock.Any(), "ws1", inactivity, gomock.Any(), "", inactivityBatchSize).
+		DoAndReturn(func(ctx context.Context, workspaceID string, automation *domain.Automation, inactiveSince time.Time, afterEmail string, limit int) ([]string, error) {
+			assert.WithinDuration(t, time.Now().UTC().AddDate(0, 0, -30), inactiveSince, time.Minute)
+			return []string{"dormant@example.com", "once@example.com", "gone@example.com"}, nil
+		})
+	mockAutomationRepo.EXPECT().EnrollContact(gomock.Any(), "ws1", inactivity, "dormant@example.com").Return(true, nil)
+	mockAutomationRepo.EXPECT().EnrollContact(gomock.Any(), "ws1", inactivity, "once@example.com").Return(false, nil)
+	mockAutomationRepo.EXPECT().EnrollContact(gomock.Any(), "ws1", inactivity, "gone@example.com").Return(false, domain.ErrEnrollmentContactNotFound)
+
+	enrolled, err := executor.EnrollInactiveContacts(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, enrolled)
+}
+
 func TestAutomationExecutor_ProcessBatch_PartialFailure(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -646,6 +1081,7 @@ func TestAutomationExecutor_ProcessBatch_PartialFailure(t *testing.T) {
 	mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
 	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "auto1", "completed").Return(nil)
 	mockTimelineRepo.EXPECT().Create(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().RecordNodeExecutionStats(gomock.Any(), workspaceID, "auto1", gomock.Any()).Return(nil)
 
 	processed, err := executor.ProcessBatch(context.Background(), 50)
 	require.NoError(t, err)
@@ -682,34 +1118,33 @@ func TestAutomationExecutor_handleError_Retry(t *testing.T) {
 	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
 	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
 
-	err := executor.handleError(context.Background(), workspaceID, ca, errors.New("test error"), "test context")
+	before := time.Now().UTC()
+	err := executor.handleError(context.Background(), workspaceID, ca, domain.DefaultRetryBackoff(), errors.New("test error"), "test context")
 	require.NoError(t, err)
 
 	assert.Equal(t, 1, ca.RetryCount)
-	assert.NotNil(t, ca.ScheduledAt)
+	assert.Equal(t, domain.ContactAutomationStatusActive, ca.Status)
+	require.NotNil(t, ca.ScheduledAt)
 	assert.Contains(t, *ca.LastError, "test error")
-	// Should have exponential backoff - 2 minutes for first retry (1<<1 = 2)
-	expectedTime := time.Now().UTC().Add(2 * time.Minute)
-	assert.WithinDuration(t, expectedTime, *ca.ScheduledAt, 10*time.Second)
+	// First retry after the 30s base delay, shortened by up to 20% jitter
+	delay := ca.ScheduledAt.Sub(before)
+	assert.GreaterOrEqual(t, delay, 24*time.Second)
+	assert.LessOrEqual(t, delay, 31*time.Second)
 }
 
-func TestAutomationExecutor_handleError_MaxRetriesExceeded(t *testing.T) {
+func TestAutomationExecutor_handleError_ConfiguredBackoff(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
-	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
 	mockLogger := setupMockLogger(ctrl)
 
 	executor := &AutomationExecutor{
 		automationRepo: mockAutomationRepo,
-		timelineRepo:   mockTimelineRepo,
 		logger:         mockLogger,
 	}
 
-	workspaceID := "ws1"
 	nodeID := "node1"
-
 	ca := &domain.ContactAutomation{
 		ID:            "ca1",
 		AutomationID:  "auto1",
@@ -717,7 +1152,49 @@ func TestAutomationExecutor_handleError_MaxRetriesExceeded(t *testing.T) {
 		CurrentNodeID: &nodeID,
 		Status:        domain.ContactAutomationStatusActive,
 		RetryCount:    2,
-		MaxRetries:    3,
+		MaxRetries:    5,
+	}
+
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), "ws1", gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), "ws1", gomock.Any()).Return(nil)
+
+	// Third retry: 10s doubled twice, capped at 25s, no jitter
+	backoff := domain.RetryBackoff{BaseSeconds: 10, MaxSeconds: 25}
+	err := executor.handleError(context.Background(), "ws1", ca, backoff, errors.New("503 Service Unavailable"), "node execution failed")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, ca.RetryCount)
+	assert.Equal(t, domain.ContactAutomationStatusActive, ca.Status)
+	require.NotNil(t, ca.ScheduledAt)
+	require.NotNil(t, ca.LastRetryAt)
+	assert.Equal(t, 25*time.Second, ca.ScheduledAt.Sub(*ca.LastRetryAt))
+}
+
+func TestAutomationExecutor_handleError_MaxRetriesExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		timelineRepo:   mockTimelineRepo,
+		logger:         mockLogger,
+	}
+
+	workspaceID := "ws1"
+	nodeID := "node1"
+
+	ca := &domain.ContactAutomation{
+		ID:            "ca1",
+		AutomationID:  "auto1",
+		ContactEmail:  "test@example.com",
+		CurrentNodeID: &nodeID,
+		Status:        domain.ContactAutomationStatusActive,
+		RetryCount:    2,
+		MaxRetries:    3,
 	}
 
 	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "auto1", "failed").Return(nil)
@@ -725,7 +1202,7 @@ func TestAutomationExecutor_handleError_MaxRetriesExceeded(t *testing.T) {
 	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
 	mockTimelineRepo.EXPECT().Create(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
 
-	err := executor.handleError(context.Background(), workspaceID, ca, errors.New("test error"), "test context")
+	err := executor.handleError(context.Background(), workspaceID, ca, domain.DefaultRetryBackoff(), errors.New("test error"), "test context")
 	require.NoError(t, err)
 
 	assert.Equal(t, 3, ca.RetryCount)
@@ -1106,6 +1583,126 @@ func (e *testNodeExecutor) Execute(ctx context.Context, params NodeExecutionPara
 	return e.execute(ctx, params)
 }
 
+func TestAutomationExecutor_Execute_NodeTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	// Slow executor giving up when its context ends, like a webhook call or a query
+	slowExecutor := &testNodeExecutor{
+		nodeType: domain.NodeTypeFilter,
+		execute: func(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+			select {
+			case <-time.After(10 * time.Second):
+				return &NodeExecutionResult{Status: domain.ContactAutomationStatusActive}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		},
+	}
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		contactRepo:    mockContactRepo,
+		nodeExecutors: map[domain.NodeType]NodeExecutor{
+			domain.NodeTypeFilter: slowExecutor,
+		},
+		nodeTimeout: 50 * time.Millisecond,
+		logger:      mockLogger,
+	}
+
+	workspaceID := "ws1"
+	automationID := "auto1"
+	nodeID := "slow_node"
+
+	contactAutomation := &domain.ContactAutomation{
+		ID:            "ca1",
+		AutomationID:  automationID,
+		ContactEmail:  "test@example.com",
+		CurrentNodeID: &nodeID,
+		Status:        domain.ContactAutomationStatusActive,
+		MaxRetries:    3,
+	}
+
+	automation := &domain.Automation{
+		ID:     automationID,
+		Status: domain.AutomationStatusLive,
+		Nodes: []*domain.AutomationNode{
+			{ID: nodeID, Type: domain.NodeTypeFilter},
+		},
+	}
+
+	mockAutomationRepo.EXPECT().GetByID(gomock.Any(), workspaceID, automationID).Return(automation, nil)
+	mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, "test@example.com").Return(&domain.Contact{Email: "test@example.com"}, nil)
+	mockAutomationRepo.EXPECT().GetNodeExecutions(gomock.Any(), workspaceID, "ca1").Return([]*domain.NodeExecution{}, nil)
+	// Processing entry + failure entry logged by handleError
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(2)
+	mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, ne *domain.NodeExecution) error {
+			assert.Equal(t, domain.NodeActionFailed, ne.Action)
+			return nil
+		})
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+
+	start := time.Now()
+	err := executor.Execute(context.Background(), workspaceID, contactAutomation)
+	elapsed := time.Since(start)
+
+	require.NoError(t, err)
+	assert.Less(t, elapsed, 2*time.Second, "slow node must not block the scheduler slot")
+
+	// Contact is rescheduled for retry rather than failed or advanced
+	assert.Equal(t, domain.ContactAutomationStatusActive, contactAutomation.Status)
+	assert.Equal(t, &nodeID, contactAutomation.CurrentNodeID)
+	assert.Equal(t, 1, contactAutomation.RetryCount)
+	require.NotNil(t, contactAutomation.ScheduledAt)
+	assert.True(t, contactAutomation.ScheduledAt.After(time.Now()))
+	require.NotNil(t, contactAutomation.LastError)
+	assert.Contains(t, *contactAutomation.LastError, "exceeded execution timeout")
+}
+
+func TestAutomationExecutor_ExecuteNode_CompletedPastTimeout(t *testing.T) {
+	// A node that finished its work past the deadline is kept, retrying it would repeat its side effect
+	lateExecutor := &testNodeExecutor{
+		nodeType: domain.NodeTypeWebhook,
+		execute: func(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+			<-ctx.Done()
+			return &NodeExecutionResult{Status: domain.ContactAutomationStatusActive}, nil
+		},
+	}
+
+	executor := &AutomationExecutor{nodeTimeout: 20 * time.Millisecond}
+	result, err := executor.executeNode(context.Background(), lateExecutor, NodeExecutionParams{
+		Node: &domain.AutomationNode{ID: "webhook_1", Type: domain.NodeTypeWebhook},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+}
+
+func TestAutomationExecutor_ExecuteNode_TimeoutDisabled(t *testing.T) {
+	var hasDeadline bool
+	nodeExecutor := &testNodeExecutor{
+		nodeType: domain.NodeTypeWebhook,
+		execute: func(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+			_, hasDeadline = ctx.Deadline()
+			return &NodeExecutionResult{Status: domain.ContactAutomationStatusActive}, nil
+		},
+	}
+
+	executor := &AutomationExecutor{}
+	_, err := executor.executeNode(context.Background(), nodeExecutor, NodeExecutionParams{
+		Node: &domain.AutomationNode{ID: "webhook_1", Type: domain.NodeTypeWebhook},
+	})
+
+	require.NoError(t, err)
+	assert.False(t, hasDeadline)
+}
+
 // Webhook Node Integration Tests
 
 func TestAutomationExecutor_Execute_WebhookNode_Success(t *testing.T) {
@@ -1364,7 +1961,7 @@ func TestAutomationExecutor_Execute_WebhookNode_ServerError_TriggersRetry(t *tes
 	assert.NotNil(t, contactAutomation.ScheduledAt)
 }
 
-func TestAutomationExecutor_Execute_WebhookNode_ClientError_TriggersRetry(t *testing.T) {
+func TestAutomationExecutor_Execute_WebhookNode_LogWebhooks(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -1372,10 +1969,11 @@ func TestAutomationExecutor_Execute_WebhookNode_ClientError_TriggersRetry(t *tes
 	mockContactRepo := mocks.NewMockContactRepository(ctrl)
 	mockLogger := setupMockLogger(ctrl)
 
-	// Create a test HTTP server that returns 400 error
+	// The response body is longer than what an exchange records
+	responseBody := `{"error": "` + strings.Repeat("x", webhookLogBodyLimit) + `"}`
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte(`{"error": "bad request"}`))
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(responseBody))
 	}))
 	defer server.Close()
 
@@ -1397,67 +1995,75 @@ func TestAutomationExecutor_Execute_WebhookNode_ClientError_TriggersRetry(t *tes
 		ContactEmail:  "test@example.com",
 		CurrentNodeID: &nodeID,
 		Status:        domain.ContactAutomationStatusActive,
-		RetryCount:    0,
 		MaxRetries:    3,
 	}
 
-	webhookNode := &domain.AutomationNode{
-		ID:   nodeID,
-		Type: domain.NodeTypeWebhook,
-		Config: map[string]interface{}{
-			"url": server.URL,
-		},
-	}
-
 	automation := &domain.Automation{
-		ID:     "auto1",
-		Name:   "Test Automation",
-		Status: domain.AutomationStatusLive,
-		Nodes:  []*domain.AutomationNode{webhookNode},
-	}
-
-	contact := &domain.Contact{Email: "test@example.com"}
-
+		ID:          "auto1",
+		Name:        "Test Automation",
+		Status:      domain.AutomationStatusLive,
+		LogWebhooks: true,
+		Nodes: []*domain.AutomationNode{{
+			ID:     nodeID,
+			Type:   domain.NodeTypeWebhook,
+			Config: map[string]interface{}{"url": server.URL},
+		}},
+	}
+
+	var recorded *domain.NodeExecution
 	mockAutomationRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto1").Return(automation, nil)
-	mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, "test@example.com").Return(contact, nil)
+	mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, "test@example.com").Return(&domain.Contact{Email: "test@example.com"}, nil)
 	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
 	mockAutomationRepo.EXPECT().GetNodeExecutions(gomock.Any(), workspaceID, "ca1").Return([]*domain.NodeExecution{}, nil)
-	// Node execution is updated with failure before error handling
-	mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
-	// Error handling expects these calls
+	mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, execution *domain.NodeExecution) error {
+			recorded = execution
+			return nil
+		})
 	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
 	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
 
 	err := executor.Execute(context.Background(), workspaceID, contactAutomation)
 	require.NoError(t, err)
 
-	// Verify error was recorded (4xx errors also trigger retry in the executor)
-	assert.Equal(t, 1, contactAutomation.RetryCount)
-	assert.NotNil(t, contactAutomation.LastError)
-	assert.Contains(t, *contactAutomation.LastError, "webhook returned client error")
-	assert.Contains(t, *contactAutomation.LastError, "400")
+	// The failed execution record keeps the exchange with the server's status and a truncated body
+	require.NotNil(t, recorded)
+	assert.Equal(t, domain.NodeActionFailed, recorded.Action)
+	require.NotNil(t, recorded.Error)
+	assert.Contains(t, *recorded.Error, "503")
+
+	exchange, ok := recorded.Output["exchange"].(map[string]interface{})
+	require.True(t, ok, "exchange should be recorded in the node execution output")
+	assert.Contains(t, exchange, "latency_ms")
+
+	request := exchange["request"].(map[string]interface{})
+	assert.Equal(t, server.URL, request["url"])
+	assert.Contains(t, request["body"], "test@example.com")
+
+	response := exchange["response"].(map[string]interface{})
+	assert.Equal(t, http.StatusServiceUnavailable, response["status_code"])
+	assert.Equal(t, responseBody[:webhookLogBodyLimit], response["body"])
+	assert.Equal(t, true, response["body_truncated"])
 }
 
-func TestAutomationExecutor_Execute_WebhookNode_TerminalNode(t *testing.T) {
+func TestAutomationExecutor_Execute_WebhookNode_ClientError_TriggersRetry(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
 	mockContactRepo := mocks.NewMockContactRepository(ctrl)
-	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
 	mockLogger := setupMockLogger(ctrl)
 
-	// Create a test HTTP server
+	// Create a test HTTP server that returns 400 error
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"success": true}`))
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "bad request"}`))
 	}))
 	defer server.Close()
 
 	executor := &AutomationExecutor{
 		automationRepo: mockAutomationRepo,
 		contactRepo:    mockContactRepo,
-		timelineRepo:   mockTimelineRepo,
 		nodeExecutors: map[domain.NodeType]NodeExecutor{
 			domain.NodeTypeWebhook: NewWebhookNodeExecutor(mockLogger),
 		},
@@ -1465,7 +2071,7 @@ func TestAutomationExecutor_Execute_WebhookNode_TerminalNode(t *testing.T) {
 	}
 
 	workspaceID := "ws1"
-	nodeID := "webhook_terminal"
+	nodeID := "webhook_node1"
 
 	contactAutomation := &domain.ContactAutomation{
 		ID:            "ca1",
@@ -1473,13 +2079,13 @@ func TestAutomationExecutor_Execute_WebhookNode_TerminalNode(t *testing.T) {
 		ContactEmail:  "test@example.com",
 		CurrentNodeID: &nodeID,
 		Status:        domain.ContactAutomationStatusActive,
+		RetryCount:    0,
+		MaxRetries:    3,
 	}
 
-	// Webhook node with no NextNodeID - terminal node
 	webhookNode := &domain.AutomationNode{
-		ID:         nodeID,
-		Type:       domain.NodeTypeWebhook,
-		NextNodeID: nil, // Terminal node
+		ID:   nodeID,
+		Type: domain.NodeTypeWebhook,
 		Config: map[string]interface{}{
 			"url": server.URL,
 		},
@@ -1498,65 +2104,464 @@ func TestAutomationExecutor_Execute_WebhookNode_TerminalNode(t *testing.T) {
 	mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, "test@example.com").Return(contact, nil)
 	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
 	mockAutomationRepo.EXPECT().GetNodeExecutions(gomock.Any(), workspaceID, "ca1").Return([]*domain.NodeExecution{}, nil)
-	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	// Node execution is updated with failure before error handling
 	mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
-	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "auto1", "completed").Return(nil)
-	mockTimelineRepo.EXPECT().Create(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	// Error handling expects these calls
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
 
 	err := executor.Execute(context.Background(), workspaceID, contactAutomation)
 	require.NoError(t, err)
 
-	// Verify automation completed
-	assert.Nil(t, contactAutomation.CurrentNodeID)
-	assert.Equal(t, domain.ContactAutomationStatusCompleted, contactAutomation.Status)
+	// Verify error was recorded (4xx errors also trigger retry in the executor)
+	assert.Equal(t, 1, contactAutomation.RetryCount)
+	assert.NotNil(t, contactAutomation.LastError)
+	assert.Contains(t, *contactAutomation.LastError, "webhook returned client error")
+	assert.Contains(t, *contactAutomation.LastError, "400")
 }
 
-func TestAutomationExecutor_Execute_WebhookNode_ResponseStoredInContext(t *testing.T) {
+func TestAutomationExecutor_Execute_OnFailurePolicy(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	var terminalCalled bool
+	terminal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		terminalCalled = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer terminal.Close()
+
+	workspaceID := "ws1"
+	nodeID := "webhook_node1"
+	terminalNodeID := "terminal_node"
+
+	setup := func(t *testing.T, policy domain.NodeFailurePolicy) (*mocks.MockAutomationRepository, *mocks.MockContactTimelineRepository, *AutomationExecutor, *domain.ContactAutomation) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		mockContactRepo := mocks.NewMockContactRepository(ctrl)
+		mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+		mockLogger := setupMockLogger(ctrl)
+
+		executor := &AutomationExecutor{
+			automationRepo: mockAutomationRepo,
+			contactRepo:    mockContactRepo,
+			timelineRepo:   mockTimelineRepo,
+			nodeExecutors: map[domain.NodeType]NodeExecutor{
+				domain.NodeTypeWebhook: NewWebhookNodeExecutor(mockLogger),
+			},
+			logger: mockLogger,
+		}
+
+		automation := &domain.Automation{
+			ID:     "auto1",
+			Name:   "Test Automation",
+			Status: domain.AutomationStatusLive,
+			Nodes: []*domain.AutomationNode{
+				{
+					ID:         nodeID,
+					Type:       domain.NodeTypeWebhook,
+					NextNodeID: &terminalNodeID,
+					OnFailure:  policy,
+					Config:     map[string]interface{}{"url": failing.URL},
+				},
+				{
+					ID:     terminalNodeID,
+					Type:   domain.NodeTypeWebhook,
+					Config: map[string]interface{}{"url": terminal.URL},
+				},
+			},
+		}
+
+		mockAutomationRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto1").Return(automation, nil)
+		mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, "test@example.com").
+			Return(&domain.Contact{Email: "test@example.com"}, nil)
+
+		currentNodeID := nodeID
+		contactAutomation := &domain.ContactAutomation{
+			ID:            "ca1",
+			AutomationID:  "auto1",
+			ContactEmail:  "test@example.com",
+			CurrentNodeID: &currentNodeID,
+			Status:        domain.ContactAutomationStatusActive,
+			MaxRetries:    3,
+		}
+		return mockAutomationRepo, mockTimelineRepo, executor, contactAutomation
+	}
+
+	t.Run("skip continues to the terminal node", func(t *testing.T) {
+		terminalCalled = false
+		mockAutomationRepo, mockTimelineRepo, executor, contactAutomation := setup(t, domain.NodeFailurePolicySkip)
+
+		var executions []*domain.NodeExecution
+		mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(2)
+		mockAutomationRepo.EXPECT().GetNodeExecutions(gomock.Any(), workspaceID, "ca1").Return([]*domain.NodeExecution{}, nil).Times(2)
+		mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(2)
+		mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, execution *domain.NodeExecution) error {
+				copied := *execution
+				executions = append(executions, &copied)
+				return nil
+			}).Times(2)
+		mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "auto1", "completed").Return(nil)
+		mockTimelineRepo.EXPECT().Create(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+
+		err := executor.Execute(context.Background(), workspaceID, contactAutomation)
+		require.NoError(t, err)
+
+		assert.True(t, terminalCalled, "the terminal node should run after the skipped node")
+		assert.Equal(t, domain.ContactAutomationStatusCompleted, contactAutomation.Status)
+		assert.Nil(t, contactAutomation.CurrentNodeID)
+		assert.Equal(t, 0, contactAutomation.RetryCount)
+
+		require.Len(t, executions, 2)
+		assert.Equal(t, domain.NodeActionSkipped, executions[0].Action)
+		require.NotNil(t, executions[0].Error)
+		assert.Contains(t, *executions[0].Error, "webhook returned server error")
+		assert.Equal(t, domain.NodeActionCompleted, executions[1].Action)
+	})
+
+	t.Run("exit ends the contact", func(t *testing.T) {
+		terminalCalled = false
+		mockAutomationRepo, mockTimelineRepo, executor, contactAutomation := setup(t, domain.NodeFailurePolicyExit)
+
+		mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+		mockAutomationRepo.EXPECT().GetNodeExecutions(gomock.Any(), workspaceID, "ca1").Return([]*domain.NodeExecution{}, nil)
+		mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+		mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "auto1", "exited").Return(nil)
+		mockTimelineRepo.EXPECT().Create(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+		mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+
+		err := executor.Execute(context.Background(), workspaceID, contactAutomation)
+		require.NoError(t, err)
+
+		assert.False(t, terminalCalled)
+		assert.Equal(t, domain.ContactAutomationStatusExited, contactAutomation.Status)
+		require.NotNil(t, contactAutomation.ExitReason)
+		assert.Equal(t, "node_failed", *contactAutomation.ExitReason)
+		require.NotNil(t, contactAutomation.LastError)
+		assert.Contains(t, *contactAutomation.LastError, "webhook returned server error")
+		assert.Equal(t, 0, contactAutomation.RetryCount)
+	})
+}
+
+func TestAutomationExecutor_Execute_ErrorNodeRouting(t *testing.T) {
+	var welcomeCalled bool
+	welcome := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		welcomeCalled = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer welcome.Close()
+
+	var errorLogged bool
+	errorLog := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorLogged = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer errorLog.Close()
+
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
 	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
 	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
 	mockLogger := setupMockLogger(ctrl)
 
-	// Create a test HTTP server that returns data
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"user_id":    "usr_123",
-			"created":    true,
-			"attributes": map[string]string{"tier": "premium"},
-		})
-	}))
-	defer server.Close()
-
 	executor := &AutomationExecutor{
-		automationRepo: mockAutomationRepo,
-		contactRepo:    mockContactRepo,
-		timelineRepo:   mockTimelineRepo,
+		automationRepo:  mockAutomationRepo,
+		contactRepo:     mockContactRepo,
+		contactListRepo: mockContactListRepo,
+		timelineRepo:    mockTimelineRepo,
 		nodeExecutors: map[domain.NodeType]NodeExecutor{
-			domain.NodeTypeWebhook: NewWebhookNodeExecutor(mockLogger),
+			domain.NodeTypeAddToList: NewAddToListNodeExecutor(mockContactListRepo),
+			domain.NodeTypeWebhook:   NewWebhookNodeExecutor(mockLogger),
 		},
 		logger: mockLogger,
 	}
 
 	workspaceID := "ws1"
-	nodeID := "webhook_node1"
-
-	contactAutomation := &domain.ContactAutomation{
-		ID:            "ca1",
-		AutomationID:  "auto1",
-		ContactEmail:  "test@example.com",
-		CurrentNodeID: &nodeID,
-		Status:        domain.ContactAutomationStatusActive,
-	}
+	addNodeID := "add_node"
+	welcomeNodeID := "welcome_node"
+	errorNodeID := "log_error"
 
-	// Terminal webhook node (no NextNodeID)
-	webhookNode := &domain.AutomationNode{
-		ID:         nodeID,
-		Type:       domain.NodeTypeWebhook,
+	automation := &domain.Automation{
+		ID:     "auto1",
+		Name:   "Test Automation",
+		Status: domain.AutomationStatusLive,
+		Nodes: []*domain.AutomationNode{
+			{
+				ID:          addNodeID,
+				Type:        domain.NodeTypeAddToList,
+				NextNodeID:  &welcomeNodeID,
+				ErrorNodeID: &errorNodeID,
+				Config:      map[string]interface{}{"list_id": "missing_list", "status": "active"},
+			},
+			{
+				ID:     welcomeNodeID,
+				Type:   domain.NodeTypeWebhook,
+				Config: map[string]interface{}{"url": welcome.URL},
+			},
+			{
+				ID:     errorNodeID,
+				Type:   domain.NodeTypeWebhook,
+				Config: map[string]interface{}{"url": errorLog.URL},
+			},
+		},
+	}
+
+	mockAutomationRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto1").Return(automation, nil)
+	mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, "test@example.com").
+		Return(&domain.Contact{Email: "test@example.com"}, nil)
+	mockContactListRepo.EXPECT().AddContactToList(gomock.Any(), workspaceID, gomock.Any()).
+		Return(errors.New("list not found"))
+
+	var executions []*domain.NodeExecution
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(2)
+	mockAutomationRepo.EXPECT().GetNodeExecutions(gomock.Any(), workspaceID, "ca1").Return([]*domain.NodeExecution{}, nil).Times(2)
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(2)
+	mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, execution *domain.NodeExecution) error {
+			copied := *execution
+			executions = append(executions, &copied)
+			return nil
+		}).Times(2)
+	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "auto1", "completed").Return(nil)
+	mockTimelineRepo.EXPECT().Create(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+
+	currentNodeID := addNodeID
+	contactAutomation := &domain.ContactAutomation{
+		ID:            "ca1",
+		AutomationID:  "auto1",
+		ContactEmail:  "test@example.com",
+		CurrentNodeID: &currentNodeID,
+		Status:        domain.ContactAutomationStatusActive,
+		MaxRetries:    3,
+	}
+
+	err := executor.Execute(context.Background(), workspaceID, contactAutomation)
+	require.NoError(t, err)
+
+	// The failure took the error path, which ended the journey without retries
+	assert.True(t, errorLogged, "the error node should run after the failed node")
+	assert.False(t, welcomeCalled, "the next node should not run after a routed failure")
+	assert.Equal(t, domain.ContactAutomationStatusCompleted, contactAutomation.Status)
+	assert.Nil(t, contactAutomation.CurrentNodeID)
+	assert.Equal(t, 0, contactAutomation.RetryCount)
+	assert.Nil(t, contactAutomation.LastError)
+
+	require.Len(t, executions, 2)
+	assert.Equal(t, addNodeID, executions[0].NodeID)
+	assert.Equal(t, domain.NodeActionFailed, executions[0].Action)
+	require.NotNil(t, executions[0].Error)
+	assert.Contains(t, *executions[0].Error, "list not found")
+	assert.Equal(t, errorNodeID, executions[1].NodeID)
+	assert.Equal(t, domain.NodeActionCompleted, executions[1].Action)
+}
+
+func TestAutomationExecutor_Execute_WaitUntilDatetime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		contactRepo:    mockContactRepo,
+		timelineRepo:   mockTimelineRepo,
+		nodeExecutors: map[domain.NodeType]NodeExecutor{
+			domain.NodeTypeWaitUntilDatetime: NewWaitUntilDatetimeNodeExecutor(),
+		},
+		logger: mockLogger,
+	}
+
+	workspaceID := "ws1"
+	waitNodeID := "launch_wait"
+	afterNodeID := "after_launch"
+	launch := time.Now().UTC().Add(time.Second)
+
+	automation := &domain.Automation{
+		ID:     "auto1",
+		Name:   "Product Launch",
+		Status: domain.AutomationStatusLive,
+		Nodes: []*domain.AutomationNode{
+			{
+				ID:         waitNodeID,
+				Type:       domain.NodeTypeWaitUntilDatetime,
+				NextNodeID: &afterNodeID,
+				Config:     map[string]interface{}{"datetime": launch.Format(time.RFC3339Nano)},
+			},
+			{
+				// Already past, so it passes through and ends the journey
+				ID:     afterNodeID,
+				Type:   domain.NodeTypeWaitUntilDatetime,
+				Config: map[string]interface{}{"datetime": "2020-01-01T00:00:00Z"},
+			},
+		},
+	}
+
+	mockAutomationRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto1").Return(automation, nil).Times(2)
+	mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, "test@example.com").
+		Return(&domain.Contact{Email: "test@example.com"}, nil).Times(2)
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(3)
+	mockAutomationRepo.EXPECT().GetNodeExecutions(gomock.Any(), workspaceID, "ca1").Return([]*domain.NodeExecution{}, nil).Times(3)
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(3)
+	mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(3)
+	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "auto1", "completed").Return(nil)
+	mockTimelineRepo.EXPECT().Create(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+
+	currentNodeID := waitNodeID
+	contactAutomation := &domain.ContactAutomation{
+		ID:            "ca1",
+		AutomationID:  "auto1",
+		ContactEmail:  "test@example.com",
+		CurrentNodeID: &currentNodeID,
+		Status:        domain.ContactAutomationStatusActive,
+	}
+
+	// Before the datetime the contact stays on the wait node, scheduled for the datetime
+	err := executor.Execute(context.Background(), workspaceID, contactAutomation)
+	require.NoError(t, err)
+	require.NotNil(t, contactAutomation.CurrentNodeID)
+	assert.Equal(t, waitNodeID, *contactAutomation.CurrentNodeID)
+	require.NotNil(t, contactAutomation.ScheduledAt)
+	assert.True(t, launch.Equal(*contactAutomation.ScheduledAt))
+	assert.Equal(t, domain.ContactAutomationStatusActive, contactAutomation.Status)
+
+	// Once the datetime has passed the contact advances
+	time.Sleep(time.Until(launch) + 10*time.Millisecond)
+	err = executor.Execute(context.Background(), workspaceID, contactAutomation)
+	require.NoError(t, err)
+	assert.Nil(t, contactAutomation.CurrentNodeID)
+	assert.Equal(t, domain.ContactAutomationStatusCompleted, contactAutomation.Status)
+}
+
+func TestAutomationExecutor_Execute_WebhookNode_TerminalNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	// Create a test HTTP server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success": true}`))
+	}))
+	defer server.Close()
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		contactRepo:    mockContactRepo,
+		timelineRepo:   mockTimelineRepo,
+		nodeExecutors: map[domain.NodeType]NodeExecutor{
+			domain.NodeTypeWebhook: NewWebhookNodeExecutor(mockLogger),
+		},
+		logger: mockLogger,
+	}
+
+	workspaceID := "ws1"
+	nodeID := "webhook_terminal"
+
+	contactAutomation := &domain.ContactAutomation{
+		ID:            "ca1",
+		AutomationID:  "auto1",
+		ContactEmail:  "test@example.com",
+		CurrentNodeID: &nodeID,
+		Status:        domain.ContactAutomationStatusActive,
+	}
+
+	// Webhook node with no NextNodeID - terminal node
+	webhookNode := &domain.AutomationNode{
+		ID:         nodeID,
+		Type:       domain.NodeTypeWebhook,
+		NextNodeID: nil, // Terminal node
+		Config: map[string]interface{}{
+			"url": server.URL,
+		},
+	}
+
+	automation := &domain.Automation{
+		ID:     "auto1",
+		Name:   "Test Automation",
+		Status: domain.AutomationStatusLive,
+		Nodes:  []*domain.AutomationNode{webhookNode},
+	}
+
+	contact := &domain.Contact{Email: "test@example.com"}
+
+	mockAutomationRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto1").Return(automation, nil)
+	mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, "test@example.com").Return(contact, nil)
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().GetNodeExecutions(gomock.Any(), workspaceID, "ca1").Return([]*domain.NodeExecution{}, nil)
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "auto1", "completed").Return(nil)
+	mockTimelineRepo.EXPECT().Create(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+
+	err := executor.Execute(context.Background(), workspaceID, contactAutomation)
+	require.NoError(t, err)
+
+	// Verify automation completed
+	assert.Nil(t, contactAutomation.CurrentNodeID)
+	assert.Equal(t, domain.ContactAutomationStatusCompleted, contactAutomation.Status)
+}
+
+func TestAutomationExecutor_Execute_WebhookNode_ResponseStoredInContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	// Create a test HTTP server that returns data
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"user_id":    "usr_123",
+			"created":    true,
+			"attributes": map[string]string{"tier": "premium"},
+		})
+	}))
+	defer server.Close()
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		contactRepo:    mockContactRepo,
+		timelineRepo:   mockTimelineRepo,
+		nodeExecutors: map[domain.NodeType]NodeExecutor{
+			domain.NodeTypeWebhook: NewWebhookNodeExecutor(mockLogger),
+		},
+		logger: mockLogger,
+	}
+
+	workspaceID := "ws1"
+	nodeID := "webhook_node1"
+
+	contactAutomation := &domain.ContactAutomation{
+		ID:            "ca1",
+		AutomationID:  "auto1",
+		ContactEmail:  "test@example.com",
+		CurrentNodeID: &nodeID,
+		Status:        domain.ContactAutomationStatusActive,
+	}
+
+	// Terminal webhook node (no NextNodeID)
+	webhookNode := &domain.AutomationNode{
+		ID:         nodeID,
+		Type:       domain.NodeTypeWebhook,
 		NextNodeID: nil,
 		Config: map[string]interface{}{
 			"url": server.URL,
@@ -1605,6 +2610,93 @@ func TestAutomationExecutor_Execute_WebhookNode_ResponseStoredInContext(t *testi
 	assert.Equal(t, true, response["created"])
 }
 
+func TestAutomationExecutor_Execute_WebhookNode_ResponseMappingUsedDownstream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"score": 87, "segment": "enterprise"}`))
+	}))
+	defer server.Close()
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		contactRepo:    mockContactRepo,
+		timelineRepo:   mockTimelineRepo,
+		nodeExecutors: map[domain.NodeType]NodeExecutor{
+			domain.NodeTypeWebhook:          NewWebhookNodeExecutor(mockLogger),
+			domain.NodeTypeExpressionBranch: NewExpressionBranchNodeExecutor(),
+		},
+		logger: mockLogger,
+	}
+
+	workspaceID := "ws1"
+	webhookNode := &domain.AutomationNode{
+		ID:         "enrich",
+		Type:       domain.NodeTypeWebhook,
+		NextNodeID: strPtr("score_branch"),
+		Config: map[string]interface{}{
+			"url":              server.URL,
+			"response_mapping": map[string]interface{}{"save_as": "enrichment", "json_path": "$.score"},
+		},
+	}
+	// A low score would route to a node that does not exist and exit the contact
+	branchNode := &domain.AutomationNode{
+		ID:   "score_branch",
+		Type: domain.NodeTypeExpressionBranch,
+		Config: map[string]interface{}{
+			"expression":    "{{ enrichment >= 50 }}",
+			"false_node_id": "cold_lead",
+		},
+	}
+	automation := &domain.Automation{
+		ID:     "auto1",
+		Name:   "Lead scoring",
+		Status: domain.AutomationStatusLive,
+		Nodes:  []*domain.AutomationNode{webhookNode, branchNode},
+	}
+
+	contactAutomation := &domain.ContactAutomation{
+		ID:            "ca1",
+		AutomationID:  "auto1",
+		ContactEmail:  "test@example.com",
+		CurrentNodeID: strPtr("enrich"),
+		Status:        domain.ContactAutomationStatusActive,
+		Context:       map[string]interface{}{"event": "signup"},
+	}
+
+	var branchOutput map[string]interface{}
+	mockAutomationRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto1").Return(automation, nil)
+	mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, "test@example.com").Return(&domain.Contact{Email: "test@example.com"}, nil)
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(2)
+	mockAutomationRepo.EXPECT().GetNodeExecutions(gomock.Any(), workspaceID, "ca1").Return([]*domain.NodeExecution{}, nil).Times(2)
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(2)
+	mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, ne *domain.NodeExecution) error {
+			if ne.NodeID == "score_branch" {
+				branchOutput = ne.Output
+			}
+			return nil
+		}).Times(2)
+	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "auto1", "completed").Return(nil)
+	mockTimelineRepo.EXPECT().Create(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+
+	err := executor.Execute(context.Background(), workspaceID, contactAutomation)
+	require.NoError(t, err)
+
+	// The mapped value is persisted with the contact, next to the enrollment context
+	assert.Equal(t, map[string]interface{}{"event": "signup", "enrichment": float64(87)}, contactAutomation.Context)
+	assert.Equal(t, domain.ContactAutomationStatusCompleted, contactAutomation.Status)
+	require.NotNil(t, branchOutput)
+	assert.Equal(t, "true", branchOutput["branch_taken"])
+}
+
 // Loop Behavior Tests
 
 func TestAutomationExecutor_Execute_LoopMultipleNonDelayNodes(t *testing.T) {
@@ -2042,3 +3134,412 @@ func TestAutomationExecutor_Execute_ExitReasonPropagation(t *testing.T) {
 	require.NotNil(t, contactAutomation.ExitReason)
 	assert.Equal(t, "unsubscribed", *contactAutomation.ExitReason)
 }
+
+func TestAutomationExecutor_Execute_StoresRenderedPathLabel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		contactRepo:    mockContactRepo,
+		nodeExecutors: map[domain.NodeType]NodeExecutor{
+			domain.NodeTypeABTest: NewABTestNodeExecutor(),
+			domain.NodeTypeDelay:  NewDelayNodeExecutor(),
+		},
+		logger: mockLogger,
+	}
+
+	workspaceID := "ws1"
+	abNodeID := "ab1"
+	delayNodeID := "delay1"
+
+	contactAutomation := &domain.ContactAutomation{
+		ID:            "ca1",
+		AutomationID:  "auto1",
+		ContactEmail:  "test@example.com",
+		CurrentNodeID: &abNodeID,
+		Status:        domain.ContactAutomationStatusActive,
+	}
+
+	automation := &domain.Automation{
+		ID:     "auto1",
+		Name:   "Onboarding",
+		Status: domain.AutomationStatusLive,
+		Nodes: []*domain.AutomationNode{
+			{
+				ID:   abNodeID,
+				Type: domain.NodeTypeABTest,
+				Config: map[string]interface{}{
+					"variants": []interface{}{
+						map[string]interface{}{"id": "A", "name": "Offer for {{ contact.first_name }}", "weight": 50, "next_node_id": delayNodeID},
+						map[string]interface{}{"id": "B", "name": "Offer for {{ contact.first_name }}", "weight": 50, "next_node_id": delayNodeID},
+					},
+				},
+			},
+			{
+				ID:   delayNodeID,
+				Type: domain.NodeTypeDelay,
+				Config: map[string]interface{}{
+					"duration": 1,
+					"unit":     "days",
+				},
+			},
+		},
+	}
+
+	contact := &domain.Contact{
+		Email:     "test@example.com",
+		FirstName: &domain.NullableString{String: "Jane", IsNull: false},
+	}
+
+	var stored []*domain.NodeExecution
+	mockAutomationRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto1").Return(automation, nil)
+	mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, "test@example.com").Return(contact, nil)
+	mockAutomationRepo.EXPECT().GetNodeExecutions(gomock.Any(), workspaceID, "ca1").Return([]*domain.NodeExecution{}, nil).Times(2)
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(2)
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(2)
+	mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, ne *domain.NodeExecution) error {
+			stored = append(stored, ne)
+			return nil
+		}).Times(2)
+
+	err := executor.Execute(context.Background(), workspaceID, contactAutomation)
+	require.NoError(t, err)
+
+	require.Len(t, stored, 2)
+	assert.Equal(t, abNodeID, stored[0].NodeID)
+	assert.Equal(t, "Offer for Jane", stored[0].Output["variant_name"])
+}
+
+func TestAutomationExecutor_Execute_AutomationGoalMet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+
+	// The email node must not run once the goal is met
+	emailExecutor := &testNodeExecutor{
+		nodeType: domain.NodeTypeEmail,
+		execute: func(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+			t.Fatal("email node executed for a contact that met the goal")
+			return nil, nil
+		},
+	}
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		contactRepo:    mockContactRepo,
+		workspaceRepo:  mockWorkspaceRepo,
+		timelineRepo:   mockTimelineRepo,
+		nodeExecutors: map[domain.NodeType]NodeExecutor{
+			domain.NodeTypeEmail: emailExecutor,
+			domain.NodeTypeGoal:  NewGoalNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo),
+		},
+		logger: setupMockLogger(ctrl),
+	}
+
+	workspaceID := "ws1"
+	nodeID := "email2"
+	contactAutomation := &domain.ContactAutomation{
+		ID:            "ca1",
+		AutomationID:  "welcome",
+		ContactEmail:  "test@example.com",
+		CurrentNodeID: &nodeID,
+		Status:        domain.ContactAutomationStatusActive,
+		MaxRetries:    3,
+	}
+
+	automation := &domain.Automation{
+		ID:     "welcome",
+		Status: domain.AutomationStatusLive,
+		Nodes: []*domain.AutomationNode{
+			{ID: nodeID, Type: domain.NodeTypeEmail, Config: map[string]interface{}{}},
+			{
+				ID:   "converted",
+				Type: domain.NodeTypeGoal,
+				Config: map[string]interface{}{
+					"scope":      "automation",
+					"conditions": buildSimpleConditionMap(),
+				},
+			},
+		},
+	}
+
+	mockAutomationRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "welcome").Return(automation, nil)
+	mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, "test@example.com").
+		Return(&domain.Contact{Email: "test@example.com"}, nil)
+	mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+	sqlMock.ExpectQuery("SELECT EXISTS").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, wsID string, entry *domain.NodeExecution) error {
+			assert.Equal(t, "converted", entry.NodeID)
+			assert.Equal(t, domain.NodeTypeGoal, entry.NodeType)
+			assert.Equal(t, true, entry.Output["goal_met"])
+			return nil
+		})
+	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "welcome", "exited").Return(nil)
+	mockTimelineRepo.EXPECT().Create(gomock.Any(), workspaceID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, wsID string, entry *domain.ContactTimelineEntry) error {
+			assert.Equal(t, "automation.end", entry.Kind)
+			assert.Equal(t, map[string]interface{}{"new": "goal_met"}, entry.Changes["exit_reason"])
+			return nil
+		})
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, contactAutomation).Return(nil)
+
+	err = executor.Execute(context.Background(), workspaceID, contactAutomation)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.ContactAutomationStatusExited, contactAutomation.Status)
+	require.NotNil(t, contactAutomation.ExitReason)
+	assert.Equal(t, domain.GoalExitReason, *contactAutomation.ExitReason)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestAutomationExecutor_ProcessGoals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+
+	// No contact is loaded: goals are evaluated in SQL for all the waiting contacts
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		contactRepo:    mocks.NewMockContactRepository(ctrl),
+		workspaceRepo:  mockWorkspaceRepo,
+		timelineRepo:   mockTimelineRepo,
+		nodeExecutors: map[domain.NodeType]NodeExecutor{
+			domain.NodeTypeGoal: NewGoalNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo),
+		},
+		logger: setupMockLogger(ctrl),
+	}
+
+	delayNodeID := "wait_3_days"
+	withoutGoals := &domain.Automation{ID: "newsletter", Status: domain.AutomationStatusLive, Version: 1}
+	withGoal := &domain.Automation{
+		ID:      "welcome",
+		Status:  domain.AutomationStatusLive,
+		Version: 3,
+		Nodes: []*domain.AutomationNode{
+			{
+				ID:         "purchased",
+				Type:       domain.NodeTypeGoal,
+				NextNodeID: &delayNodeID,
+				Config:     map[string]interface{}{"conditions": buildSimpleConditionMap()},
+			},
+			{ID: delayNodeID, Type: domain.NodeTypeDelay, Config: map[string]interface{}{"duration": 3, "unit": "days"}},
+		},
+	}
+	// Contacts pinned to version 2 run its automation-wide goal instead
+	previous := &domain.AutomationVersion{
+		AutomationID: "welcome",
+		Version:      2,
+		RootNodeID:   delayNodeID,
+		Nodes: []*domain.AutomationNode{
+			{ID: delayNodeID, Type: domain.NodeTypeDelay, Config: map[string]interface{}{"duration": 3, "unit": "days"}},
+			{
+				ID:     "converted",
+				Type:   domain.NodeTypeGoal,
+				Config: map[string]interface{}{"conditions": buildSimpleConditionMap(), "scope": "automation"},
+			},
+		},
+	}
+
+	later := time.Now().Add(48 * time.Hour)
+	metBranchGoal := &domain.ContactAutomation{ID: "ca1", AutomationID: "welcome", ContactEmail: "test@example.com",
+		CurrentNodeID: &delayNodeID, Status: domain.ContactAutomationStatusActive, ScheduledAt: &later}
+	metAutomationGoal := &domain.ContactAutomation{ID: "ca2", AutomationID: "welcome", ContactEmail: "other@example.com",
+		CurrentNodeID: &delayNodeID, Status: domain.ContactAutomationStatusActive, ScheduledAt: &later}
+
+	mockWorkspaceRepo.EXPECT().List(gomock.Any()).Return([]*domain.Workspace{{ID: "ws1"}}, nil)
+	mockAutomationRepo.EXPECT().List(gomock.Any(), "ws1", domain.AutomationFilter{
+		Status: []domain.AutomationStatus{domain.AutomationStatusLive},
+	}).Return([]*domain.Automation{withoutGoals, withGoal}, 2, nil)
+
+	// Only the automation with a goal is checked, once per version its waiting contacts run
+	mockAutomationRepo.EXPECT().ListWaitingVersions(gomock.Any(), "ws1", "welcome", 3).Return([]int{3, 2}, nil)
+	mockAutomationRepo.EXPECT().GetVersion(gomock.Any(), "ws1", "welcome", 2).Return(previous, nil)
+
+	mockAutomationRepo.EXPECT().
+		ListContactAutomationsMeetingGoal(gomock.Any(), "ws1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, filter domain.GoalContactFilter) ([]*domain.ContactAutomation, error) {
+			assert.Equal(t, "welcome", filter.AutomationID)
+			assert.Equal(t, 3, filter.CurrentVersion)
+			assert.Equal(t, goalBatchSize, filter.Limit)
+			assert.Contains(t, filter.ContactsSQL, "SELECT email FROM contacts WHERE")
+			assert.NotEmpty(t, filter.ContactsArgs)
+
+			switch filter.Version {
+			case 3:
+				// The branch goal only applies to the contacts that passed through it
+				assert.Equal(t, "purchased", filter.PassedNodeID)
+				return []*domain.ContactAutomation{metBranchGoal}, nil
+			case 2:
+				assert.Empty(t, filter.PassedNodeID)
+				return []*domain.ContactAutomation{metAutomationGoal}, nil
+			}
+			t.Fatalf("unexpected version %d", filter.Version)
+			return nil, nil
+		}).Times(2)
+
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), "ws1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, entry *domain.NodeExecution) error {
+			assert.Equal(t, domain.NodeTypeGoal, entry.NodeType)
+			assert.Equal(t, true, entry.Output["goal_met"])
+			return nil
+		}).Times(2)
+	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), "ws1", "welcome", "exited").Return(nil).Times(2)
+	mockTimelineRepo.EXPECT().Create(gomock.Any(), "ws1", gomock.Any()).Return(nil).Times(2)
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), "ws1", metBranchGoal).Return(nil)
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), "ws1", metAutomationGoal).Return(nil)
+
+	exited, err := executor.ProcessGoals(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, exited)
+
+	for _, ca := range []*domain.ContactAutomation{metBranchGoal, metAutomationGoal} {
+		assert.Equal(t, domain.ContactAutomationStatusExited, ca.Status)
+		require.NotNil(t, ca.ExitReason)
+		assert.Equal(t, domain.GoalExitReason, *ca.ExitReason)
+		assert.Nil(t, ca.ScheduledAt)
+	}
+}
+
+func TestAutomationExecutor_ProcessGoals_Pagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		workspaceRepo:  mockWorkspaceRepo,
+		timelineRepo:   mockTimelineRepo,
+		nodeExecutors: map[domain.NodeType]NodeExecutor{
+			domain.NodeTypeGoal: NewGoalNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo),
+		},
+		logger: setupMockLogger(ctrl),
+	}
+
+	automation := &domain.Automation{
+		ID:      "welcome",
+		Status:  domain.AutomationStatusLive,
+		Version: 1,
+		Nodes: []*domain.AutomationNode{
+			{
+				ID:     "converted",
+				Type:   domain.NodeTypeGoal,
+				Config: map[string]interface{}{"conditions": buildSimpleConditionMap(), "scope": "automation"},
+			},
+		},
+	}
+
+	later := time.Now().Add(time.Hour)
+	page := make([]*domain.ContactAutomation, goalBatchSize)
+	for i := range page {
+		page[i] = &domain.ContactAutomation{ID: fmt.Sprintf("ca%04d", i), AutomationID: "welcome",
+			ContactEmail: fmt.Sprintf("contact%d@example.com", i), Status: domain.ContactAutomationStatusActive, ScheduledAt: &later}
+	}
+	failed := page[0]
+
+	mockWorkspaceRepo.EXPECT().List(gomock.Any()).Return([]*domain.Workspace{{ID: "ws1"}}, nil)
+	mockAutomationRepo.EXPECT().List(gomock.Any(), "ws1", gomock.Any()).Return([]*domain.Automation{automation}, 1, nil)
+	mockAutomationRepo.EXPECT().ListWaitingVersions(gomock.Any(), "ws1", "welcome", 1).Return([]int{1}, nil)
+
+	// A contact that failed to exit does not stop the pages: the next one starts after the last ID
+	gomock.InOrder(
+		mockAutomationRepo.EXPECT().
+			ListContactAutomationsMeetingGoal(gomock.Any(), "ws1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, filter domain.GoalContactFilter) ([]*domain.ContactAutomation, error) {
+				assert.Empty(t, filter.AfterID)
+				return page, nil
+			}),
+		mockAutomationRepo.EXPECT().
+			ListContactAutomationsMeetingGoal(gomock.Any(), "ws1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, filter domain.GoalContactFilter) ([]*domain.ContactAutomation, error) {
+				assert.Equal(t, page[len(page)-1].ID, filter.AfterID)
+				return nil, nil
+			}),
+	)
+
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), "ws1", gomock.Any()).Return(nil).Times(goalBatchSize)
+	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), "ws1", "welcome", "exited").Return(nil).Times(goalBatchSize)
+	mockTimelineRepo.EXPECT().Create(gomock.Any(), "ws1", gomock.Any()).Return(nil).Times(goalBatchSize)
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), "ws1", failed).Return(errors.New("connection lost"))
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), "ws1", gomock.Any()).Return(nil).Times(goalBatchSize - 1)
+
+	exited, err := executor.ProcessGoals(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, goalBatchSize-1, exited)
+}
+
+func TestAutomationExecutor_Execute_ContactDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	// No timeline event is expected for a deleted contact
+	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		contactRepo:    mockContactRepo,
+		timelineRepo:   mockTimelineRepo,
+		nodeExecutors: map[domain.NodeType]NodeExecutor{
+			domain.NodeTypeDelay: NewDelayNodeExecutor(),
+		},
+		logger: setupMockLogger(ctrl),
+	}
+
+	workspaceID := "ws1"
+	nodeID := "delay1"
+	contactAutomation := &domain.ContactAutomation{
+		ID:            "ca1",
+		AutomationID:  "auto1",
+		ContactEmail:  "deleted@example.com",
+		CurrentNodeID: &nodeID,
+		Status:        domain.ContactAutomationStatusActive,
+		MaxRetries:    3,
+	}
+	automation := &domain.Automation{
+		ID:     "auto1",
+		Status: domain.AutomationStatusLive,
+		Nodes: []*domain.AutomationNode{
+			{ID: nodeID, Type: domain.NodeTypeDelay, Config: map[string]interface{}{"duration": 1, "unit": "days"}},
+		},
+	}
+
+	mockAutomationRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto1").Return(automation, nil)
+	mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, "deleted@example.com").
+		Return(nil, domain.ErrContactNotFound)
+	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "auto1", "exited").Return(nil)
+	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), workspaceID, contactAutomation).Return(nil)
+
+	err := executor.Execute(context.Background(), workspaceID, contactAutomation)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.ContactAutomationStatusExited, contactAutomation.Status)
+	require.NotNil(t, contactAutomation.ExitReason)
+	assert.Equal(t, domain.ContactDeletedExitReason, *contactAutomation.ExitReason)
+	assert.Nil(t, contactAutomation.ScheduledAt)
+	assert.Equal(t, 0, contactAutomation.RetryCount, "a deleted contact is not retried")
+	assert.Nil(t, contactAutomation.LastError)
+}
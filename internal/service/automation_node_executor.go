@@ -3,16 +3,25 @@ package service
 import (
 	"bytes"
 	"context"
-	"database/sql"
+	"crypto/sha256"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/service/queue"
 	"github.com/Notifuse/notifuse/pkg/logger"
 	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+	"github.com/Notifuse/notifuse/pkg/safehttpclient"
 	"github.com/google/uuid"
 )
 
@@ -27,6 +36,32 @@ type NodeExecutionResult struct {
 	Error       error                          // Error if failed
 }
 
+// permanentNodeError is a node failure that retrying can't fix, such as an invalid node
+// config or contact data the node can't use. The contact fails (or takes the node's error
+// path) right away instead of being retried with backoff.
+type permanentNodeError struct {
+	err error
+}
+
+func (e *permanentNodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentNodeError) Unwrap() error {
+	return e.err
+}
+
+// permanentNodeErrorf formats a permanent node error
+func permanentNodeErrorf(format string, args ...interface{}) error {
+	return &permanentNodeError{err: fmt.Errorf(format, args...)}
+}
+
+// isPermanentNodeError reports whether err can't be fixed by retrying the node
+func isPermanentNodeError(err error) bool {
+	var permanent *permanentNodeError
+	return errors.As(err, &permanent)
+}
+
 // NodeExecutionParams contains all data needed to execute a node
 type NodeExecutionParams struct {
 	WorkspaceID      string
@@ -39,6 +74,8 @@ type NodeExecutionParams struct {
 
 // NodeExecutor executes a specific node type
 type NodeExecutor interface {
+	// Execute runs the node. On failure it may return a result alongside the error,
+	// whose Output is kept on the failed node execution.
 	Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error)
 	NodeType() domain.NodeType
 }
@@ -52,14 +89,25 @@ func buildNodeOutput(nodeType domain.NodeType, data map[string]interface{}) map[
 	return data
 }
 
+// segmentDwellClockSkew tolerates the delay between a contact joining the segment and
+// the enrollment trigger firing
+const segmentDwellClockSkew = 5 * time.Second
+
 // TriggerNodeExecutor handles trigger nodes (pass-through to next node)
 // Trigger nodes are entry points - the actual trigger logic is handled by the
-// database trigger during enrollment. This executor just advances to the next node.
-type TriggerNodeExecutor struct{}
+// database trigger during enrollment. This executor just advances to the next node,
+// unless the trigger requires a minimum dwell time in the segment before the journey starts.
+type TriggerNodeExecutor struct {
+	segmentRepo    domain.SegmentRepository
+	automationRepo domain.AutomationRepository
+}
 
 // NewTriggerNodeExecutor creates a new trigger node executor
-func NewTriggerNodeExecutor() *TriggerNodeExecutor {
-	return &TriggerNodeExecutor{}
+func NewTriggerNodeExecutor(segmentRepo domain.SegmentRepository, automationRepo domain.AutomationRepository) *TriggerNodeExecutor {
+	return &TriggerNodeExecutor{
+		segmentRepo:    segmentRepo,
+		automationRepo: automationRepo,
+	}
 }
 
 // NodeType returns the node type this executor handles
@@ -69,6 +117,12 @@ func (e *TriggerNodeExecutor) NodeType() domain.NodeType {
 
 // Execute passes through to the next node
 func (e *TriggerNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	if params.Automation != nil && params.Automation.Trigger != nil {
+		if minDwell := params.Automation.Trigger.MinDwellDuration(); minDwell > 0 {
+			return e.checkSegmentDwell(ctx, params, minDwell)
+		}
+	}
+
 	return &NodeExecutionResult{
 		NextNodeID: params.Node.NextNodeID,
 		Status:     domain.ContactAutomationStatusActive,
@@ -76,6 +130,69 @@ func (e *TriggerNodeExecutor) Execute(ctx context.Context, params NodeExecutionP
 	}, nil
 }
 
+// checkSegmentDwell holds the contact on the trigger node until it has stayed in the
+// segment for minDwell. A contact that left the segment in the meantime (or left and
+// joined again, which restarts the clock) exits without running the journey.
+func (e *TriggerNodeExecutor) checkSegmentDwell(ctx context.Context, params NodeExecutionParams, minDwell time.Duration) (*NodeExecutionResult, error) {
+	var dwellUntil *time.Time
+	for _, segmentID := range params.Automation.Trigger.JoinedSegmentIDs() {
+		membership, err := e.segmentRepo.GetContactSegmentMembership(ctx, params.WorkspaceID, params.Contact.ContactEmail, segmentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check segment membership: %w", err)
+		}
+		if membership == nil || membership.MatchedAt.After(params.Contact.EnteredAt.Add(segmentDwellClockSkew)) {
+			continue
+		}
+		until := membership.MatchedAt.Add(minDwell)
+		if dwellUntil == nil || until.Before(*dwellUntil) {
+			dwellUntil = &until
+		}
+	}
+
+	output := map[string]interface{}{
+		"trigger_type":   "timeline",
+		"min_dwell":      params.Automation.Trigger.MinDwell,
+		"min_dwell_unit": params.Automation.Trigger.MinDwellUnit,
+	}
+
+	if dwellUntil == nil {
+		// Let the contact enroll again when it next joins the segment
+		if params.Automation.Trigger.Frequency == domain.TriggerFrequencyOnce {
+			if err := e.automationRepo.ClearTriggerLog(ctx, params.WorkspaceID, params.Automation.ID, params.Contact.ContactEmail); err != nil {
+				return nil, err
+			}
+		}
+		exitReason := "segment_dwell_not_met"
+		output["outcome"] = "left_segment"
+		return &NodeExecutionResult{
+			NextNodeID: nil,
+			Status:     domain.ContactAutomationStatusExited,
+			ExitReason: &exitReason,
+			Output:     buildNodeOutput(domain.NodeTypeTrigger, output),
+		}, nil
+	}
+
+	output["dwell_until"] = dwellUntil.UTC().Format(time.RFC3339Nano)
+
+	if time.Now().UTC().Before(*dwellUntil) {
+		scheduledAt := dwellUntil.UTC()
+		output["outcome"] = "waiting"
+		return &NodeExecutionResult{
+			NextNodeID:  &params.Node.ID,
+			ScheduledAt: &scheduledAt,
+			Status:      domain.ContactAutomationStatusActive,
+			Output:      buildNodeOutput(domain.NodeTypeTrigger, output),
+		}, nil
+	}
+
+	output["outcome"] = "dwell_met"
+	return &NodeExecutionResult{
+		NextNodeID: params.Node.NextNodeID,
+		Status:     domain.ContactAutomationStatusActive,
+		Output:     buildNodeOutput(domain.NodeTypeTrigger, output),
+	}, nil
+}
+
 // DelayNodeExecutor executes delay nodes
 type DelayNodeExecutor struct{}
 
@@ -94,23 +211,27 @@ func (e *DelayNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 	// Parse config
 	config, err := parseDelayNodeConfig(params.Node.Config)
 	if err != nil {
-		return nil, fmt.Errorf("invalid delay node config: %w", err)
+		return nil, permanentNodeErrorf("invalid delay node config: %w", err)
 	}
 
 	// Calculate scheduled time
-	var duration time.Duration
+	var unit time.Duration
 	switch config.Unit {
 	case "minutes":
-		duration = time.Duration(config.Duration) * time.Minute
+		unit = time.Minute
 	case "hours":
-		duration = time.Duration(config.Duration) * time.Hour
+		unit = time.Hour
 	case "days":
-		duration = time.Duration(config.Duration) * 24 * time.Hour
+		unit = 24 * time.Hour
 	default:
-		return nil, fmt.Errorf("invalid delay unit: %s", config.Unit)
+		return nil, permanentNodeErrorf("invalid delay unit: %s", config.Unit)
+	}
+
+	if config.AnchorField != "" {
+		return e.executeAnchored(params, config, unit)
 	}
 
-	scheduledAt := time.Now().UTC().Add(duration)
+	scheduledAt := time.Now().UTC().Add(time.Duration(config.Duration) * unit)
 
 	return &NodeExecutionResult{
 		NextNodeID:  params.Node.NextNodeID,
@@ -124,6 +245,88 @@ func (e *DelayNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 	}, nil
 }
 
+// executeAnchored waits until the contact's anchor date plus the offset. When that moment
+// has already passed, the contact continues right away or exits depending on on_past.
+func (e *DelayNodeExecutor) executeAnchored(params NodeExecutionParams, config *domain.DelayNodeConfig, unit time.Duration) (*NodeExecutionResult, error) {
+	anchor, err := delayAnchorTime(params.ContactData, config.AnchorField)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledAt := anchor.Add(time.Duration(config.Offset) * unit)
+	output := map[string]interface{}{
+		"anchor_field": config.AnchorField,
+		"anchor":       anchor.Format(time.RFC3339Nano),
+		"offset":       config.Offset,
+		"delay_unit":   config.Unit,
+		"delay_until":  scheduledAt,
+	}
+
+	if time.Now().UTC().Before(scheduledAt) {
+		output["outcome"] = "waiting"
+		return &NodeExecutionResult{
+			NextNodeID:  params.Node.NextNodeID,
+			ScheduledAt: &scheduledAt,
+			Status:      domain.ContactAutomationStatusActive,
+			Output:      buildNodeOutput(domain.NodeTypeDelay, output),
+		}, nil
+	}
+
+	if config.OnPast == domain.DelayOnPastExit {
+		exitReason := "delay_anchor_passed"
+		output["outcome"] = "exited"
+		return &NodeExecutionResult{
+			NextNodeID: nil,
+			Status:     domain.ContactAutomationStatusExited,
+			ExitReason: &exitReason,
+			Output:     buildNodeOutput(domain.NodeTypeDelay, output),
+		}, nil
+	}
+
+	output["outcome"] = "skipped"
+	return &NodeExecutionResult{
+		NextNodeID: params.Node.NextNodeID,
+		Status:     domain.ContactAutomationStatusActive,
+		Output:     buildNodeOutput(domain.NodeTypeDelay, output),
+	}, nil
+}
+
+// delayAnchorDateLayouts are the layouts accepted for anchor dates stored in custom string fields
+var delayAnchorDateLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"}
+
+// delayAnchorTime reads the anchor date of a delay node from the contact. Dates without an
+// offset are read as UTC. A missing or malformed date is a permanent error, the contact's
+// data won't change by retrying.
+func delayAnchorTime(contact *domain.Contact, field string) (time.Time, error) {
+	if contact == nil {
+		return time.Time{}, fmt.Errorf("contact data is required for anchor_field %s", field)
+	}
+
+	contactMap, err := contact.ToMapOfAny()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var value string
+	switch v := contactMap[field].(type) {
+	case nil:
+	case string:
+		value = strings.TrimSpace(v)
+	default:
+		return time.Time{}, permanentNodeErrorf("anchor_field %s is not a date: %v", field, v)
+	}
+	if value == "" {
+		return time.Time{}, permanentNodeErrorf("contact has no value for anchor_field %s", field)
+	}
+
+	for _, layout := range delayAnchorDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, permanentNodeErrorf("anchor_field %s has an invalid date: %s (must be RFC 3339 or YYYY-MM-DD)", field, value)
+}
+
 // parseDelayNodeConfig parses delay node configuration from map
 func parseDelayNodeConfig(config map[string]interface{}) (*domain.DelayNodeConfig, error) {
 	data, err := json.Marshal(config)
@@ -152,6 +355,15 @@ type EmailNodeExecutor struct {
 	contactListRepo domain.ContactListRepository
 	apiEndpoint     string
 	logger          logger.Logger
+
+	// Used by nodes with immediate delivery, which bypass the email queue, and by
+	// skip_if_sent_within lookups
+	immediateSender    ImmediateEmailSender
+	messageHistoryRepo domain.MessageHistoryRepository
+
+	// transactional executors send through the workspace transactional provider with
+	// transactional priority, ignoring list opt-outs (transactional_email nodes)
+	transactional bool
 }
 
 // NewEmailNodeExecutor creates a new email node executor
@@ -175,11 +387,50 @@ func NewEmailNodeExecutor(
 	}
 }
 
+// NewTransactionalEmailNodeExecutor creates an executor for transactional_email nodes, which
+// send like the transactional API: through the transactional provider, ahead of marketing
+// emails in the queue, and regardless of the contact's marketing opt-out
+func NewTransactionalEmailNodeExecutor(
+	emailQueueRepo domain.EmailQueueRepository,
+	templateRepo domain.TemplateRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	listRepo domain.ListRepository,
+	contactListRepo domain.ContactListRepository,
+	apiEndpoint string,
+	log logger.Logger,
+) *EmailNodeExecutor {
+	executor := NewEmailNodeExecutor(emailQueueRepo, templateRepo, workspaceRepo, listRepo, contactListRepo, apiEndpoint, log)
+	executor.transactional = true
+	return executor
+}
+
 // NodeType returns the node type this executor handles
 func (e *EmailNodeExecutor) NodeType() domain.NodeType {
+	if e.transactional {
+		return domain.NodeTypeTransactionalEmail
+	}
 	return domain.NodeTypeEmail
 }
 
+// SetMessageHistoryRepository sets the repository used to look up recent sends
+// for nodes with a skip_if_sent_within window
+func (e *EmailNodeExecutor) SetMessageHistoryRepository(messageHistoryRepo domain.MessageHistoryRepository) {
+	e.messageHistoryRepo = messageHistoryRepo
+}
+
+// ImmediateEmailSender sends an email queue entry right away, with the rate limiting and
+// circuit breaking of queued sends (implemented by the email queue worker)
+type ImmediateEmailSender interface {
+	SendNow(ctx context.Context, workspace *domain.Workspace, entry *domain.EmailQueueEntry) error
+}
+
+// SetImmediateDelivery sets the sender and message history repository used by nodes
+// with immediate delivery (set after construction to avoid circular dependencies)
+func (e *EmailNodeExecutor) SetImmediateDelivery(sender ImmediateEmailSender, messageHistoryRepo domain.MessageHistoryRepository) {
+	e.immediateSender = sender
+	e.messageHistoryRepo = messageHistoryRepo
+}
+
 // isSubscriptionSensitiveCategory returns true for template categories
 // that should respect contact unsubscribe status (marketing content).
 func isSubscriptionSensitiveCategory(category string) bool {
@@ -191,7 +442,8 @@ func isSubscriptionSensitiveCategory(category string) bool {
 	}
 }
 
-// Execute processes an email node by enqueuing to the email queue
+// Execute processes an email node by enqueuing to the email queue,
+// or by sending it right away when the node uses immediate delivery
 func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
 	// 0. Validate required parameters
 	if params.ContactData == nil {
@@ -207,29 +459,59 @@ func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 		return nil, fmt.Errorf("invalid email node config: %w", err)
 	}
 
+	// Immediate emails bypass the queue, which holds emails to contacts with an active
+	// messaging hold: keep the contact on this node until the hold passes instead
+	if hold := params.ContactData.MessagingHoldUntil; config.IsImmediate() && hold != nil && !hold.IsNull && time.Now().Before(hold.Time) {
+		scheduledAt := hold.Time.UTC()
+		return &NodeExecutionResult{
+			NextNodeID:  &params.Node.ID,
+			ScheduledAt: &scheduledAt,
+			Status:      domain.ContactAutomationStatusActive,
+			Output: buildNodeOutput(e.NodeType(), map[string]interface{}{
+				"template_id": config.TemplateID,
+				"to":          params.ContactData.Email,
+				"delivery":    string(domain.EmailDeliveryImmediate),
+				"held_until":  scheduledAt.Format(time.RFC3339),
+			}),
+		}, nil
+	}
+
 	// 2. Get workspace for email provider
 	workspace, err := e.workspaceRepo.GetByID(ctx, params.WorkspaceID)
 	if err != nil {
 		return nil, fmt.Errorf("workspace not found: %w", err)
 	}
 
-	// 3. Get email provider - use node-level override if set, else workspace default
+	// 3. Get email provider - use the first matching sender rule, else the node-level
+	// override if set, else workspace default
+	senderRule, err := e.matchSenderRule(ctx, params, config.SenderRules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate sender rules: %w", err)
+	}
+
+	overrideIntegrationID := ""
+	if senderRule != nil {
+		overrideIntegrationID = senderRule.IntegrationID
+	} else if config.IntegrationID != nil {
+		overrideIntegrationID = *config.IntegrationID
+	}
+
 	var emailProvider *domain.EmailProvider
 	var integrationID string
 
-	if config.IntegrationID != nil && *config.IntegrationID != "" {
-		integration := workspace.GetIntegrationByID(*config.IntegrationID)
+	if overrideIntegrationID != "" {
+		integration := workspace.GetIntegrationByID(overrideIntegrationID)
 		if integration == nil {
-			return nil, fmt.Errorf("integration %s not found in workspace", *config.IntegrationID)
+			return nil, fmt.Errorf("integration %s not found in workspace", overrideIntegrationID)
 		}
 		if integration.Type != domain.IntegrationTypeEmail {
-			return nil, fmt.Errorf("integration %s is not an email provider", *config.IntegrationID)
+			return nil, fmt.Errorf("integration %s is not an email provider", overrideIntegrationID)
 		}
 		emailProvider = &integration.EmailProvider
 		integrationID = integration.ID
 	} else {
 		var err error
-		emailProvider, integrationID, err = workspace.GetEmailProviderWithIntegrationID(true)
+		emailProvider, integrationID, err = workspace.GetEmailProviderWithIntegrationID(!e.transactional)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get email provider: %w", err)
 		}
@@ -244,8 +526,8 @@ func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 		return nil, fmt.Errorf("failed to get template: %w", err)
 	}
 
-	// 4b. Check subscription status for marketing/blog emails
-	if isSubscriptionSensitiveCategory(template.Category) && params.Automation.ListID != "" {
+	// 4b. Check subscription status for marketing/blog emails (transactional sends are exempt)
+	if !e.transactional && isSubscriptionSensitiveCategory(template.Category) && params.Automation.ListID != "" {
 		contactList, clErr := e.contactListRepo.GetContactListByIDs(
 			ctx,
 			params.WorkspaceID,
@@ -278,7 +560,7 @@ func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 					NextNodeID: nil,
 					Status:     domain.ContactAutomationStatusExited,
 					ExitReason: &exitReason,
-					Output: buildNodeOutput(domain.NodeTypeEmail, map[string]interface{}{
+					Output: buildNodeOutput(e.NodeType(), map[string]interface{}{
 						"template_id":    config.TemplateID,
 						"skipped":        true,
 						"skip_reason":    exitReason,
@@ -290,6 +572,42 @@ func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 		}
 	}
 
+	// 4c. Skip when the contact already received this template within the configured window
+	if window := config.SkipIfSentWithinDuration(); window > 0 && e.messageHistoryRepo != nil {
+		sentAfter := time.Now().UTC().Add(-window)
+		recent, _, histErr := e.messageHistoryRepo.ListMessages(ctx, params.WorkspaceID, workspace.Settings.SecretKey, domain.MessageListParams{
+			Limit:        1,
+			Channel:      "email",
+			ContactEmail: params.ContactData.Email,
+			TemplateID:   config.TemplateID,
+			SentAfter:    &sentAfter,
+		})
+		if histErr != nil {
+			return nil, fmt.Errorf("failed to check recent sends: %w", histErr)
+		}
+		if len(recent) > 0 {
+			e.logger.WithFields(map[string]interface{}{
+				"workspace_id":  params.WorkspaceID,
+				"automation_id": params.Automation.ID,
+				"contact_email": params.ContactData.Email,
+				"template_id":   config.TemplateID,
+				"last_sent_at":  recent[0].SentAt,
+			}).Info("Email node skipped - template already sent within window")
+
+			return &NodeExecutionResult{
+				NextNodeID: params.Node.NextNodeID,
+				Status:     domain.ContactAutomationStatusActive,
+				Output: buildNodeOutput(e.NodeType(), map[string]interface{}{
+					"template_id":  config.TemplateID,
+					"skipped":      true,
+					"skip_reason":  "sent_within_window",
+					"last_sent_at": recent[0].SentAt.Format(time.RFC3339),
+					"to":           params.ContactData.Email,
+				}),
+			}, nil
+		}
+	}
+
 	// 5. Generate message ID
 	messageID := fmt.Sprintf("%s_%s", params.WorkspaceID, uuid.New().String())
 
@@ -321,6 +639,28 @@ func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 		listName = list.Name
 	}
 
+	providedData := domain.MapOfAny{
+		"automation_id":   params.Automation.ID,
+		"automation_name": params.Automation.Name,
+	}
+	// Enrollments triggered by a broadcast message render the broadcast's global feed
+	if globalFeed := params.Contact.GlobalFeed(); globalFeed != nil {
+		providedData["global_feed"] = globalFeed
+	}
+	// Inside a for_each sub-flow the template renders the current element as `item`
+	if item, ok := forEachItem(params); ok {
+		providedData["item"] = item
+	}
+	// Values stored in the automation context (e.g. a webhook response mapping) render at
+	// the top level, e.g. {{ enrichment }}
+	if params.Contact != nil {
+		for key, value := range params.Contact.Context {
+			if _, exists := providedData[key]; !exists {
+				providedData[key] = value
+			}
+		}
+	}
+
 	templateData, err := domain.BuildTemplateData(domain.TemplateDataRequest{
 		WorkspaceID:         params.WorkspaceID,
 		WorkspaceSecretKey:  workspace.Settings.SecretKey,
@@ -328,10 +668,7 @@ func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 		ContactWithList:     domain.ContactWithList{Contact: params.ContactData, ListID: listID, ListName: listName},
 		MessageID:           messageID,
 		TrackingSettings:    trackingSettings,
-		ProvidedData: domain.MapOfAny{
-			"automation_id":   params.Automation.ID,
-			"automation_name": params.Automation.Name,
-		},
+		ProvidedData:        providedData,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to build template data: %w", err)
@@ -376,17 +713,25 @@ func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 		return nil, fmt.Errorf("failed to process subject: %w", err)
 	}
 
-	// 11. Get sender
-	sender := emailProvider.GetSender(emailContent.SenderID)
+	// 11. Get sender (a sender rule can pick a sender of its integration)
+	senderID := emailContent.SenderID
+	if senderRule != nil && senderRule.SenderID != "" {
+		senderID = senderRule.SenderID
+	}
+	sender := emailProvider.GetSender(senderID)
 	if sender == nil {
 		return nil, fmt.Errorf("no sender configured for email provider")
 	}
 
 	// 12. Create queue entry
+	priority := domain.EmailQueuePriorityMarketing
+	if e.transactional {
+		priority = domain.EmailQueuePriorityTransactional
+	}
 	entry := &domain.EmailQueueEntry{
 		ID:            uuid.New().String(),
 		Status:        domain.EmailQueueStatusPending,
-		Priority:      domain.EmailQueuePriorityMarketing,
+		Priority:      priority,
 		SourceType:    domain.EmailQueueSourceAutomation,
 		SourceID:      params.Automation.ID,
 		IntegrationID: integrationID,
@@ -403,18 +748,73 @@ func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 			EmailOptions: domain.EmailOptions{
 				ReplyTo: emailContent.ReplyTo,
 			},
+			Transactional: e.transactional,
 		},
 		MaxAttempts: 3,
 		CreatedAt:   time.Now().UTC(),
 		UpdatedAt:   time.Now().UTC(),
 	}
 
-	// 13. Add List-Unsubscribe header for RFC-8058 compliance
-	if url, ok := templateData["oneclick_unsubscribe_url"].(string); ok && url != "" {
+	// 13. Add List-Unsubscribe header for RFC-8058 compliance (marketing sends only)
+	if url, ok := templateData["oneclick_unsubscribe_url"].(string); ok && url != "" && !e.transactional {
 		entry.Payload.EmailOptions.ListUnsubscribeURL = url
+		entry.Payload.EmailOptions.ListUnsubscribeMailto = domain.BuildListUnsubscribeMailto(workspace.Settings.ListUnsubscribeMailto, url)
+	}
+
+	// 14. Deduplicate per enrollment and node so a retried execution does not send the same email twice
+	if params.Contact != nil {
+		entry.DedupeKey = "automation:" + params.Contact.ID + ":" + params.Node.ID
 	}
 
-	// 14. Enqueue the email
+	// 15. Send immediate emails inline, without waiting for the next queue poll
+	if config.IsImmediate() {
+		sent, err := e.sendImmediately(ctx, workspace, entry)
+		if err != nil {
+			return nil, err
+		}
+		if !sent {
+			e.logger.WithFields(map[string]interface{}{
+				"workspace_id":  params.WorkspaceID,
+				"automation_id": params.Automation.ID,
+				"template_id":   config.TemplateID,
+				"contact_email": params.ContactData.Email,
+			}).Info("Email node skipped - email already sent by a previous execution")
+
+			return &NodeExecutionResult{
+				NextNodeID: params.Node.NextNodeID,
+				Status:     domain.ContactAutomationStatusActive,
+				Output: buildNodeOutput(e.NodeType(), map[string]interface{}{
+					"template_id": config.TemplateID,
+					"to":          params.ContactData.Email,
+					"delivery":    string(domain.EmailDeliveryImmediate),
+					"skipped":     true,
+					"skip_reason": "already_sent",
+				}),
+			}, nil
+		}
+
+		e.logger.WithFields(map[string]interface{}{
+			"workspace_id":  params.WorkspaceID,
+			"automation_id": params.Automation.ID,
+			"template_id":   config.TemplateID,
+			"contact_email": params.ContactData.Email,
+			"message_id":    messageID,
+		}).Info("Email node executed - email sent immediately")
+
+		return &NodeExecutionResult{
+			NextNodeID: params.Node.NextNodeID,
+			Status:     domain.ContactAutomationStatusActive,
+			Output: buildNodeOutput(e.NodeType(), map[string]interface{}{
+				"template_id": config.TemplateID,
+				"message_id":  messageID,
+				"to":          params.ContactData.Email,
+				"delivery":    string(domain.EmailDeliveryImmediate),
+				"sent":        true,
+			}),
+		}, nil
+	}
+
+	// 16. Enqueue the email
 	if err := e.emailQueueRepo.Enqueue(ctx, params.WorkspaceID, []*domain.EmailQueueEntry{entry}); err != nil {
 		return nil, fmt.Errorf("failed to enqueue email: %w", err)
 	}
@@ -430,7 +830,7 @@ func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 	return &NodeExecutionResult{
 		NextNodeID: params.Node.NextNodeID,
 		Status:     domain.ContactAutomationStatusActive,
-		Output: buildNodeOutput(domain.NodeTypeEmail, map[string]interface{}{
+		Output: buildNodeOutput(e.NodeType(), map[string]interface{}{
 			"template_id": config.TemplateID,
 			"message_id":  messageID,
 			"to":          params.ContactData.Email,
@@ -439,6 +839,92 @@ func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 	}, nil
 }
 
+// matchSenderRule returns the first sender rule matching the contact, or nil when none match
+func (e *EmailNodeExecutor) matchSenderRule(ctx context.Context, params NodeExecutionParams, rules []domain.EmailSenderRule) (*domain.EmailSenderRule, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	db, err := e.workspaceRepo.GetConnection(ctx, params.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get db connection: %w", err)
+	}
+
+	for i := range rules {
+		matches, err := EvaluateConditions(ctx, rules[i].Conditions, params.ContactData, ConditionContext{DB: db})
+		if err != nil {
+			return nil, fmt.Errorf("sender rule %d: %w", i, err)
+		}
+		if matches {
+			return &rules[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// sendImmediately sends the email and records it in the message history, like the email queue
+// worker does for queued entries, and reports false when a previous execution already sent it.
+// A failed send is recorded too and returned so the node is retried.
+func (e *EmailNodeExecutor) sendImmediately(ctx context.Context, workspace *domain.Workspace, entry *domain.EmailQueueEntry) (bool, error) {
+	if e.immediateSender == nil || e.messageHistoryRepo == nil {
+		return false, fmt.Errorf("immediate delivery is not available")
+	}
+
+	claimed, err := e.emailQueueRepo.ClaimDedupeKey(ctx, workspace.ID, entry)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim dedupe key: %w", err)
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	sendErr := e.immediateSender.SendNow(ctx, workspace, entry)
+
+	// Let the retry send again, unless the node timed out while the provider had the
+	// email: it may have been delivered, so the retry skips it rather than risk a duplicate
+	if sendErr != nil && (errors.Is(sendErr, queue.ErrNotSent) || ctx.Err() == nil) {
+		if err := e.emailQueueRepo.ReleaseDedupeKey(context.WithoutCancel(ctx), workspace.ID, entry); err != nil {
+			e.logger.WithFields(map[string]interface{}{
+				"message_id": entry.MessageID,
+				"error":      err.Error(),
+			}).Warn("Failed to release dedupe key of failed immediate email")
+		}
+	}
+
+	now := time.Now().UTC()
+	message := &domain.MessageHistory{
+		ID:           entry.MessageID,
+		ContactEmail: entry.ContactEmail,
+		TemplateID:   entry.TemplateID,
+		AutomationID: &entry.SourceID,
+		Channel:      "email",
+		SentAt:       entry.CreatedAt,
+		CreatedAt:    entry.CreatedAt,
+		UpdatedAt:    now,
+	}
+	if sendErr != nil {
+		message.FailedAt = &now
+		errStr := sendErr.Error()
+		if len(errStr) > 255 {
+			errStr = errStr[:255]
+		}
+		message.StatusInfo = &errStr
+	}
+
+	if err := e.messageHistoryRepo.Upsert(ctx, workspace.ID, workspace.Settings.SecretKey, message); err != nil {
+		e.logger.WithFields(map[string]interface{}{
+			"message_id": entry.MessageID,
+			"error":      err.Error(),
+		}).Warn("Failed to record immediate email in message history")
+	}
+
+	if sendErr != nil {
+		return false, fmt.Errorf("failed to send email: %w", sendErr)
+	}
+	return true, nil
+}
+
 // parseEmailNodeConfig parses email node configuration from map
 func parseEmailNodeConfig(config map[string]interface{}) (*domain.EmailNodeConfig, error) {
 	data, err := json.Marshal(config)
@@ -489,6 +975,21 @@ func (e *BranchNodeExecutor) Execute(ctx context.Context, params NodeExecutionPa
 	if err != nil {
 		return nil, fmt.Errorf("failed to get db connection: %w", err)
 	}
+	evalCtx := ConditionContext{QueryBuilder: e.queryBuilder, DB: db}
+
+	// In verbose mode, record what was evaluated so unexpected routing can be debugged
+	var pathResults []map[string]interface{}
+	output := func(data map[string]interface{}) map[string]interface{} {
+		if config.Verbose {
+			conditions := make([]*domain.TreeNode, 0, len(config.Paths))
+			for _, path := range config.Paths {
+				conditions = append(conditions, path.Conditions)
+			}
+			data["evaluated_fields"] = evaluatedContactFields(params.ContactData, conditions...)
+			data["path_results"] = pathResults
+		}
+		return buildNodeOutput(domain.NodeTypeBranch, data)
+	}
 
 	// Evaluate each path's conditions against contact using database query
 	for _, path := range config.Paths {
@@ -496,19 +997,23 @@ func (e *BranchNodeExecutor) Execute(ctx context.Context, params NodeExecutionPa
 			continue
 		}
 
-		matches, err := e.evaluateConditionsWithDB(ctx, db, params.ContactData.Email, path.Conditions)
+		matches, err := EvaluateConditions(ctx, path.Conditions, params.ContactData, evalCtx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to evaluate path %s: %w", path.ID, err)
 		}
+		pathResults = append(pathResults, map[string]interface{}{
+			"path_id": path.ID,
+			"matched": matches,
+		})
 
 		if matches {
 			nextNodeID := path.NextNodeID
 			return &NodeExecutionResult{
 				NextNodeID: &nextNodeID,
 				Status:     domain.ContactAutomationStatusActive,
-				Output: buildNodeOutput(domain.NodeTypeBranch, map[string]interface{}{
+				Output: output(map[string]interface{}{
 					"path_taken": path.ID,
-					"path_name":  path.Name,
+					"path_name":  renderPathLabel(path.Name, params),
 				}),
 			}, nil
 		}
@@ -521,7 +1026,7 @@ func (e *BranchNodeExecutor) Execute(ctx context.Context, params NodeExecutionPa
 		return &NodeExecutionResult{
 			NextNodeID: &nextNodeID,
 			Status:     domain.ContactAutomationStatusActive,
-			Output: buildNodeOutput(domain.NodeTypeBranch, map[string]interface{}{
+			Output: output(map[string]interface{}{
 				"path_taken": "default",
 			}),
 		}, nil
@@ -531,33 +1036,66 @@ func (e *BranchNodeExecutor) Execute(ctx context.Context, params NodeExecutionPa
 	return &NodeExecutionResult{
 		NextNodeID: nil,
 		Status:     domain.ContactAutomationStatusCompleted,
-		Output: buildNodeOutput(domain.NodeTypeBranch, map[string]interface{}{
+		Output: output(map[string]interface{}{
 			"path_taken": "none",
 		}),
 	}, nil
 }
 
-// evaluateConditionsWithDB uses QueryBuilder to check if contact matches conditions
-func (e *BranchNodeExecutor) evaluateConditionsWithDB(ctx context.Context, db *sql.DB, email string, conditions *domain.TreeNode) (bool, error) {
-	// Build SQL using QueryBuilder (same as segments/triggers)
-	sqlStr, args, err := e.queryBuilder.BuildSQL(conditions)
+// renderPathLabel renders Liquid in a branch path or A/B variant name so execution
+// records carry a meaningful label. The raw name is kept if rendering fails.
+func renderPathLabel(name string, params NodeExecutionParams) string {
+	if !strings.Contains(name, "{{") && !strings.Contains(name, "{%") {
+		return name
+	}
+
+	rendered, err := notifuse_mjml.ProcessLiquidTemplate(name, nodeLiquidData(params), "path_label")
 	if err != nil {
-		return false, err
+		return name
+	}
+	return rendered
+}
+
+// nodeLiquidData returns the Liquid variables available to per-contact node settings
+func nodeLiquidData(params NodeExecutionParams) map[string]interface{} {
+	data := map[string]interface{}{}
+	if params.Automation != nil {
+		data["automation_id"] = params.Automation.ID
+		data["automation_name"] = params.Automation.Name
+	}
+	if params.ContactData != nil {
+		if contact, err := params.ContactData.ToMapOfAny(); err == nil {
+			data["contact"] = map[string]interface{}(contact)
+		}
+	}
+	if globalFeed := params.Contact.GlobalFeed(); globalFeed != nil {
+		data["global_feed"] = map[string]interface{}(globalFeed)
+	}
+	if item, ok := forEachItem(params); ok {
+		data["item"] = item
 	}
+	return data
+}
 
-	// Wrap in EXISTS with email filter
-	// The QueryBuilder returns a SELECT ... FROM contacts ... WHERE ... query
-	// We need to add the email filter
-	checkSQL := fmt.Sprintf("SELECT EXISTS (%s AND email = $%d)", sqlStr, len(args)+1)
-	args = append(args, email)
+// evaluatedContactFields returns the contact's values for the fields referenced by the conditions.
+// Fields the contact has no value for are reported as nil.
+func evaluatedContactFields(contact *domain.Contact, conditions ...*domain.TreeNode) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if contact == nil {
+		return fields
+	}
 
-	var exists bool
-	err = db.QueryRowContext(ctx, checkSQL, args...).Scan(&exists)
+	contactMap, err := contact.ToMapOfAny()
 	if err != nil {
-		return false, fmt.Errorf("condition query failed: %w", err)
+		return fields
 	}
 
-	return exists, nil
+	for _, tree := range conditions {
+		for _, name := range tree.ContactFieldNames() {
+			fields[name] = contactMap[name]
+		}
+	}
+	return fields
 }
 
 // parseBranchNodeConfig parses branch node configuration from map
@@ -622,11 +1160,16 @@ func (e *FilterNodeExecutor) Execute(ctx context.Context, params NodeExecutionPa
 	}
 
 	// Evaluate conditions using database query
-	matches, err := e.evaluateConditionsWithDB(ctx, db, params.ContactData.Email, config.Conditions)
+	matches, err := EvaluateConditions(ctx, config.Conditions, params.ContactData, ConditionContext{QueryBuilder: e.queryBuilder, DB: db})
 	if err != nil {
 		return nil, fmt.Errorf("failed to evaluate filter: %w", err)
 	}
 
+	output := map[string]interface{}{"filter_passed": matches}
+	if config.Verbose {
+		output["evaluated_fields"] = evaluatedContactFields(params.ContactData, config.Conditions)
+	}
+
 	if matches {
 		// Filter passed - continue to next node (or complete if empty)
 		var nextNodeID *string
@@ -640,7 +1183,7 @@ func (e *FilterNodeExecutor) Execute(ctx context.Context, params NodeExecutionPa
 		return &NodeExecutionResult{
 			NextNodeID: nextNodeID,
 			Status:     status,
-			Output:     buildNodeOutput(domain.NodeTypeFilter, map[string]interface{}{"filter_passed": true}),
+			Output:     buildNodeOutput(domain.NodeTypeFilter, output),
 		}, nil
 	}
 
@@ -656,29 +1199,10 @@ func (e *FilterNodeExecutor) Execute(ctx context.Context, params NodeExecutionPa
 	return &NodeExecutionResult{
 		NextNodeID: nextNodeID,
 		Status:     status,
-		Output:     buildNodeOutput(domain.NodeTypeFilter, map[string]interface{}{"filter_passed": false}),
+		Output:     buildNodeOutput(domain.NodeTypeFilter, output),
 	}, nil
 }
 
-// evaluateConditionsWithDB uses QueryBuilder to check if contact matches conditions
-func (e *FilterNodeExecutor) evaluateConditionsWithDB(ctx context.Context, db *sql.DB, email string, conditions *domain.TreeNode) (bool, error) {
-	sqlStr, args, err := e.queryBuilder.BuildSQL(conditions)
-	if err != nil {
-		return false, err
-	}
-
-	checkSQL := fmt.Sprintf("SELECT EXISTS (%s AND email = $%d)", sqlStr, len(args)+1)
-	args = append(args, email)
-
-	var exists bool
-	err = db.QueryRowContext(ctx, checkSQL, args...).Scan(&exists)
-	if err != nil {
-		return false, fmt.Errorf("condition query failed: %w", err)
-	}
-
-	return exists, nil
-}
-
 // parseFilterNodeConfig parses filter node configuration from map
 func parseFilterNodeConfig(config map[string]interface{}) (*domain.FilterNodeConfig, error) {
 	data, err := json.Marshal(config)
@@ -694,74 +1218,186 @@ func parseFilterNodeConfig(config map[string]interface{}) (*domain.FilterNodeCon
 	return &c, nil
 }
 
-// AddToListNodeExecutor executes add-to-list nodes
-type AddToListNodeExecutor struct {
-	contactListRepo domain.ContactListRepository
+// GoalNodeExecutor executes goal nodes, exiting contacts that meet the goal conditions
+type GoalNodeExecutor struct {
+	queryBuilder  *QueryBuilder
+	workspaceRepo domain.WorkspaceRepository
 }
 
-// NewAddToListNodeExecutor creates a new add-to-list node executor
-func NewAddToListNodeExecutor(contactListRepo domain.ContactListRepository) *AddToListNodeExecutor {
-	return &AddToListNodeExecutor{
-		contactListRepo: contactListRepo,
+// NewGoalNodeExecutor creates a new goal node executor
+func NewGoalNodeExecutor(queryBuilder *QueryBuilder, workspaceRepo domain.WorkspaceRepository) *GoalNodeExecutor {
+	return &GoalNodeExecutor{
+		queryBuilder:  queryBuilder,
+		workspaceRepo: workspaceRepo,
 	}
 }
 
 // NodeType returns the node type this executor handles
-func (e *AddToListNodeExecutor) NodeType() domain.NodeType {
-	return domain.NodeTypeAddToList
+func (e *GoalNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeGoal
 }
 
-// Execute processes an add-to-list node
-func (e *AddToListNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
-	config, err := parseAddToListNodeConfig(params.Node.Config)
+// Execute checks the goal when the contact reaches the node: a contact meeting it exits
+// the automation, the others continue to the next node. The scheduler keeps checking the
+// goal afterwards while the contact waits at later nodes.
+func (e *GoalNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseGoalNodeConfig(params.Node.Config)
 	if err != nil {
-		return nil, fmt.Errorf("invalid add-to-list node config: %w", err)
+		return nil, fmt.Errorf("invalid goal node config: %w", err)
 	}
 
-	// Add contact to list
-	now := time.Now().UTC()
-	contactList := &domain.ContactList{
-		Email:     params.Contact.ContactEmail,
-		ListID:    config.ListID,
-		Status:    domain.ContactListStatus(config.Status),
-		CreatedAt: now,
-		UpdatedAt: now,
+	met, err := e.GoalMet(ctx, params.WorkspaceID, config, params.ContactData)
+	if err != nil {
+		return nil, err
 	}
 
-	err = e.contactListRepo.AddContactToList(ctx, params.WorkspaceID, contactList)
-	if err != nil {
-		// Log but don't fail - contact might already be in list
+	output := buildNodeOutput(domain.NodeTypeGoal, map[string]interface{}{"goal_met": met})
+	if met {
+		exitReason := domain.GoalExitReason
 		return &NodeExecutionResult{
-			NextNodeID: params.Node.NextNodeID,
-			Status:     domain.ContactAutomationStatusActive,
-			Output: buildNodeOutput(domain.NodeTypeAddToList, map[string]interface{}{
-				"list_id": config.ListID,
-				"status":  config.Status,
-				"error":   err.Error(),
-			}),
+			NextNodeID: nil,
+			Status:     domain.ContactAutomationStatusExited,
+			ExitReason: &exitReason,
+			Output:     output,
 		}, nil
 	}
 
 	return &NodeExecutionResult{
 		NextNodeID: params.Node.NextNodeID,
 		Status:     domain.ContactAutomationStatusActive,
-		Output: buildNodeOutput(domain.NodeTypeAddToList, map[string]interface{}{
-			"list_id": config.ListID,
-			"status":  config.Status,
-		}),
+		Output:     output,
 	}, nil
 }
 
-// parseAddToListNodeConfig parses add-to-list node configuration from map
-func parseAddToListNodeConfig(config map[string]interface{}) (*domain.AddToListNodeConfig, error) {
-	data, err := json.Marshal(config)
+// GoalMet reports whether the contact matches the goal conditions
+func (e *GoalNodeExecutor) GoalMet(ctx context.Context, workspaceID string, config *domain.GoalNodeConfig, contact *domain.Contact) (bool, error) {
+	db, err := e.workspaceRepo.GetConnection(ctx, workspaceID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal config: %w", err)
+		return false, fmt.Errorf("failed to get db connection: %w", err)
 	}
 
-	var c domain.AddToListNodeConfig
-	if err := json.Unmarshal(data, &c); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	met, err := EvaluateConditions(ctx, config.Conditions, contact, ConditionContext{QueryBuilder: e.queryBuilder, DB: db})
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate goal: %w", err)
+	}
+	return met, nil
+}
+
+// ContactsSQL returns the query selecting the emails of all the contacts that meet the goal
+// conditions, to check a goal for many contacts at once
+func (e *GoalNodeExecutor) ContactsSQL(config *domain.GoalNodeConfig) (string, []interface{}, error) {
+	queryBuilder := e.queryBuilder
+	if queryBuilder == nil {
+		queryBuilder = NewQueryBuilder()
+	}
+
+	return queryBuilder.BuildSQL(config.Conditions)
+}
+
+// parseGoalNodeConfig parses goal node configuration from map
+func parseGoalNodeConfig(config map[string]interface{}) (*domain.GoalNodeConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var c domain.GoalNodeConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	return &c, nil
+}
+
+// ThrottleNodeExecutor executes throttle nodes
+type ThrottleNodeExecutor struct {
+	automationRepo domain.AutomationRepository
+}
+
+// NewThrottleNodeExecutor creates a new throttle node executor
+func NewThrottleNodeExecutor(automationRepo domain.AutomationRepository) *ThrottleNodeExecutor {
+	return &ThrottleNodeExecutor{
+		automationRepo: automationRepo,
+	}
+}
+
+// NodeType returns the node type this executor handles
+func (e *ThrottleNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeThrottle
+}
+
+// Execute reserves a pass through the node for the contact. A pass in the current minute lets
+// the contact continue right away; otherwise the pass is reserved in a later minute and the
+// contact waits on the node until then, so contacts over the limit are spread over the
+// following minutes instead of failing.
+func (e *ThrottleNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseThrottleNodeConfig(params.Node.Config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid throttle node config: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	// A contact coming back for the minute it reserved passes without reserving again
+	reservedAt, ok := throttleReservation(params.ExecutionContext, params.Node.ID)
+	if !ok {
+		reservedAt, err = e.automationRepo.ReserveThrottleSlot(ctx, params.WorkspaceID, params.Automation.ID, params.Node.ID, config.MaxPerMinute, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve throttle slot: %w", err)
+		}
+	}
+
+	output := map[string]interface{}{
+		"max_per_minute": config.MaxPerMinute,
+		"reserved_at":    reservedAt.Format(time.RFC3339Nano),
+	}
+
+	if reservedAt.After(now) {
+		output["outcome"] = "throttled"
+		return &NodeExecutionResult{
+			NextNodeID:  &params.Node.ID,
+			ScheduledAt: &reservedAt,
+			Status:      domain.ContactAutomationStatusActive,
+			Output:      buildNodeOutput(domain.NodeTypeThrottle, output),
+		}, nil
+	}
+
+	output["outcome"] = "passed"
+	return &NodeExecutionResult{
+		NextNodeID: params.Node.NextNodeID,
+		Status:     domain.ContactAutomationStatusActive,
+		Output:     buildNodeOutput(domain.NodeTypeThrottle, output),
+	}, nil
+}
+
+// throttleReservation returns the minute reserved by the contact on a previous tick, when it
+// was throttled on the node
+func throttleReservation(executionContext map[string]interface{}, nodeID string) (time.Time, bool) {
+	previous, ok := executionContext[nodeID].(map[string]interface{})
+	if !ok || previous["outcome"] != "throttled" {
+		return time.Time{}, false
+	}
+	raw, ok := previous["reserved_at"].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	reservedAt, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return reservedAt, true
+}
+
+// parseThrottleNodeConfig parses throttle node configuration from map
+func parseThrottleNodeConfig(config map[string]interface{}) (*domain.ThrottleNodeConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var c domain.ThrottleNodeConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
 	if err := c.Validate(); err != nil {
@@ -771,39 +1407,164 @@ func parseAddToListNodeConfig(config map[string]interface{}) (*domain.AddToListN
 	return &c, nil
 }
 
-// RemoveFromListNodeExecutor executes remove-from-list nodes
-type RemoveFromListNodeExecutor struct {
+// ReenrollNodeExecutor executes reenroll nodes
+type ReenrollNodeExecutor struct {
+	queryBuilder   *QueryBuilder
+	workspaceRepo  domain.WorkspaceRepository
+	automationRepo domain.AutomationRepository
+}
+
+// NewReenrollNodeExecutor creates a new reenroll node executor
+func NewReenrollNodeExecutor(queryBuilder *QueryBuilder, workspaceRepo domain.WorkspaceRepository, automationRepo domain.AutomationRepository) *ReenrollNodeExecutor {
+	return &ReenrollNodeExecutor{
+		queryBuilder:   queryBuilder,
+		workspaceRepo:  workspaceRepo,
+		automationRepo: automationRepo,
+	}
+}
+
+// NodeType returns the node type this executor handles
+func (e *ReenrollNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeReenroll
+}
+
+// Execute restarts the automation for the contact: the current run exits with the "reenrolled"
+// reason and a new run starts at the root node. Contacts not matching the conditions, in a
+// "once" automation, or already re-enrolled max_reenrollments times continue to the next node.
+func (e *ReenrollNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseReenrollNodeConfig(params.Node.Config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reenroll node config: %w", err)
+	}
+
+	skip := func(reason string) *NodeExecutionResult {
+		return &NodeExecutionResult{
+			NextNodeID: params.Node.NextNodeID,
+			Status:     domain.ContactAutomationStatusActive,
+			Output: buildNodeOutput(domain.NodeTypeReenroll, map[string]interface{}{
+				"reenrolled": false,
+				"reason":     reason,
+			}),
+		}
+	}
+
+	if config.Conditions != nil {
+		db, err := e.workspaceRepo.GetConnection(ctx, params.WorkspaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get db connection: %w", err)
+		}
+		met, err := EvaluateConditions(ctx, config.Conditions, params.ContactData, ConditionContext{QueryBuilder: e.queryBuilder, DB: db})
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate reenroll conditions: %w", err)
+		}
+		if !met {
+			return skip("conditions_not_met"), nil
+		}
+	}
+
+	if params.Automation.Trigger != nil && params.Automation.Trigger.Frequency == domain.TriggerFrequencyOnce {
+		return skip("frequency_once"), nil
+	}
+
+	reenrolled, err := e.automationRepo.ReenrollContactAutomation(ctx, params.WorkspaceID, params.Automation,
+		params.Contact.ID, params.Contact.ContactEmail, config.MaxReenrollments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reenroll contact: %w", err)
+	}
+	if !reenrolled {
+		return skip("max_reenrollments_reached"), nil
+	}
+
+	exitReason := domain.ReenrolledExitReason
+	return &NodeExecutionResult{
+		NextNodeID: nil,
+		Status:     domain.ContactAutomationStatusExited,
+		ExitReason: &exitReason,
+		Output: buildNodeOutput(domain.NodeTypeReenroll, map[string]interface{}{
+			"reenrolled": true,
+		}),
+	}, nil
+}
+
+// parseReenrollNodeConfig parses reenroll node configuration from map
+func parseReenrollNodeConfig(config map[string]interface{}) (*domain.ReenrollNodeConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var c domain.ReenrollNodeConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// AddToListNodeExecutor executes add-to-list nodes
+type AddToListNodeExecutor struct {
 	contactListRepo domain.ContactListRepository
+	listRepo        domain.ListRepository
 }
 
-// NewRemoveFromListNodeExecutor creates a new remove-from-list node executor
-func NewRemoveFromListNodeExecutor(contactListRepo domain.ContactListRepository) *RemoveFromListNodeExecutor {
-	return &RemoveFromListNodeExecutor{
+// NewAddToListNodeExecutor creates a new add-to-list node executor
+func NewAddToListNodeExecutor(contactListRepo domain.ContactListRepository) *AddToListNodeExecutor {
+	return &AddToListNodeExecutor{
 		contactListRepo: contactListRepo,
 	}
 }
 
+// SetListRepository sets the repository used to check that a Liquid-templated
+// list_id resolves to an existing list
+func (e *AddToListNodeExecutor) SetListRepository(listRepo domain.ListRepository) {
+	e.listRepo = listRepo
+}
+
 // NodeType returns the node type this executor handles
-func (e *RemoveFromListNodeExecutor) NodeType() domain.NodeType {
-	return domain.NodeTypeRemoveFromList
+func (e *AddToListNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeAddToList
 }
 
-// Execute processes a remove-from-list node
-func (e *RemoveFromListNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
-	config, err := parseRemoveFromListNodeConfig(params.Node.Config)
+// Execute processes an add-to-list node
+func (e *AddToListNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseAddToListNodeConfig(params.Node.Config)
 	if err != nil {
-		return nil, fmt.Errorf("invalid remove-from-list node config: %w", err)
+		return nil, fmt.Errorf("invalid add-to-list node config: %w", err)
 	}
 
-	// Remove contact from list
-	err = e.contactListRepo.RemoveContactFromList(ctx, params.WorkspaceID, params.Contact.ContactEmail, config.ListID)
+	listID, err := e.resolveListID(ctx, config.ListID, params)
 	if err != nil {
-		// Log but don't fail - contact might not be in list
+		return nil, err
+	}
+	config.ListID = listID
+
+	// Add contact to list
+	now := time.Now().UTC()
+	contactList := &domain.ContactList{
+		Email:     params.Contact.ContactEmail,
+		ListID:    config.ListID,
+		Status:    domain.ContactListStatus(config.Status),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err = e.contactListRepo.AddContactToList(ctx, params.WorkspaceID, contactList)
+	if err != nil {
+		// With an error edge the failure is routed there instead
+		if params.Node.ErrorNodeID != nil {
+			return nil, fmt.Errorf("failed to add contact to list %s: %w", config.ListID, err)
+		}
+		// Log but don't fail - contact might already be in list
 		return &NodeExecutionResult{
 			NextNodeID: params.Node.NextNodeID,
 			Status:     domain.ContactAutomationStatusActive,
-			Output: buildNodeOutput(domain.NodeTypeRemoveFromList, map[string]interface{}{
+			Output: buildNodeOutput(domain.NodeTypeAddToList, map[string]interface{}{
 				"list_id": config.ListID,
+				"status":  config.Status,
 				"error":   err.Error(),
 			}),
 		}, nil
@@ -812,20 +1573,166 @@ func (e *RemoveFromListNodeExecutor) Execute(ctx context.Context, params NodeExe
 	return &NodeExecutionResult{
 		NextNodeID: params.Node.NextNodeID,
 		Status:     domain.ContactAutomationStatusActive,
-		Output: buildNodeOutput(domain.NodeTypeRemoveFromList, map[string]interface{}{
+		Output: buildNodeOutput(domain.NodeTypeAddToList, map[string]interface{}{
 			"list_id": config.ListID,
+			"status":  config.Status,
 		}),
 	}, nil
 }
 
-// parseRemoveFromListNodeConfig parses remove-from-list node configuration from map
-func parseRemoveFromListNodeConfig(config map[string]interface{}) (*domain.RemoveFromListNodeConfig, error) {
+// resolveListID renders a Liquid-templated list_id for the contact (e.g. a region-specific
+// list picked from feed data) and checks that it names an existing list.
+// Static list ids are returned unchanged.
+func (e *AddToListNodeExecutor) resolveListID(ctx context.Context, listID string, params NodeExecutionParams) (string, error) {
+	if !strings.Contains(listID, "{{") && !strings.Contains(listID, "{%") {
+		return listID, nil
+	}
+
+	rendered, err := notifuse_mjml.ProcessLiquidTemplate(listID, nodeLiquidData(params), "list_id")
+	if err != nil {
+		return "", fmt.Errorf("failed to render list_id: %w", err)
+	}
+	rendered = strings.TrimSpace(rendered)
+	if rendered == "" {
+		return "", fmt.Errorf("list_id %q resolved to an empty list id", listID)
+	}
+
+	if e.listRepo != nil {
+		if _, err := e.listRepo.GetListByID(ctx, params.WorkspaceID, rendered); err != nil {
+			return "", fmt.Errorf("list_id %q resolved to unknown list %s: %w", listID, rendered, err)
+		}
+	}
+
+	return rendered, nil
+}
+
+// parseAddToListNodeConfig parses add-to-list node configuration from map
+func parseAddToListNodeConfig(config map[string]interface{}) (*domain.AddToListNodeConfig, error) {
 	data, err := json.Marshal(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	var c domain.RemoveFromListNodeConfig
+	var c domain.AddToListNodeConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// UpdateContactNodeExecutor executes update contact nodes
+type UpdateContactNodeExecutor struct {
+	contactRepo domain.ContactRepository
+}
+
+// NewUpdateContactNodeExecutor creates a new update contact node executor
+func NewUpdateContactNodeExecutor(contactRepo domain.ContactRepository) *UpdateContactNodeExecutor {
+	return &UpdateContactNodeExecutor{
+		contactRepo: contactRepo,
+	}
+}
+
+// NodeType returns the node type this executor handles
+func (e *UpdateContactNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeUpdateContact
+}
+
+// Execute sets the configured fields on the contact, rendering Liquid in string values. The
+// update goes through the contact repository like an API update, so it records a
+// contact.updated timeline event.
+func (e *UpdateContactNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseUpdateContactNodeConfig(params.Node.Config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid update_contact node config: %w", err)
+	}
+
+	data := contextLiquidData(params)
+	fields := make(map[string]interface{}, len(config.Fields))
+	for field, value := range config.Fields {
+		rendered, err := renderUpdateContactValue(field, value, data)
+		if err != nil {
+			return nil, err
+		}
+		fields[field] = rendered
+	}
+
+	update := map[string]interface{}{"email": params.Contact.ContactEmail}
+	for field, value := range fields {
+		update[field] = value
+	}
+	updateJSON, err := json.Marshal(update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal contact update: %w", err)
+	}
+	contact, err := domain.FromJSON(updateJSON)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contact update: %w", err)
+	}
+	if err := contact.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid contact update: %w", err)
+	}
+
+	if _, err := e.contactRepo.UpsertContact(ctx, params.WorkspaceID, contact); err != nil {
+		return nil, fmt.Errorf("failed to update contact: %w", err)
+	}
+
+	// Later nodes of this tick see the updated values
+	if params.ContactData != nil {
+		params.ContactData.Merge(contact)
+	}
+
+	return &NodeExecutionResult{
+		NextNodeID: params.Node.NextNodeID,
+		Status:     domain.ContactAutomationStatusActive,
+		Output: buildNodeOutput(domain.NodeTypeUpdateContact, map[string]interface{}{
+			"fields": fields,
+		}),
+	}, nil
+}
+
+// renderUpdateContactValue renders Liquid in a string value. Number fields also accept a
+// numeric string, so they can be set from Liquid, and an empty one clears them.
+func renderUpdateContactValue(field string, value interface{}, data map[string]interface{}) (interface{}, error) {
+	str, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+
+	if strings.Contains(str, "{{") || strings.Contains(str, "{%") {
+		rendered, err := notifuse_mjml.ProcessLiquidTemplate(str, data, field)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", field, err)
+		}
+		str = rendered
+	}
+
+	if strings.HasPrefix(field, "custom_number_") {
+		trimmed := strings.TrimSpace(str)
+		if trimmed == "" {
+			return nil, nil
+		}
+		number, err := strconv.ParseFloat(trimmed, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number, got %q", field, trimmed)
+		}
+		return number, nil
+	}
+	return str, nil
+}
+
+// parseUpdateContactNodeConfig parses update contact node configuration from map
+func parseUpdateContactNodeConfig(config map[string]interface{}) (*domain.UpdateContactNodeConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var c domain.UpdateContactNodeConfig
 	if err := json.Unmarshal(data, &c); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
@@ -834,98 +1741,1189 @@ func parseRemoveFromListNodeConfig(config map[string]interface{}) (*domain.Remov
 		return nil, err
 	}
 
-	return &c, nil
+	return &c, nil
+}
+
+// RemoveFromListNodeExecutor executes remove-from-list nodes
+type RemoveFromListNodeExecutor struct {
+	contactListRepo domain.ContactListRepository
+}
+
+// NewRemoveFromListNodeExecutor creates a new remove-from-list node executor
+func NewRemoveFromListNodeExecutor(contactListRepo domain.ContactListRepository) *RemoveFromListNodeExecutor {
+	return &RemoveFromListNodeExecutor{
+		contactListRepo: contactListRepo,
+	}
+}
+
+// NodeType returns the node type this executor handles
+func (e *RemoveFromListNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeRemoveFromList
+}
+
+// Execute processes a remove-from-list node
+func (e *RemoveFromListNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseRemoveFromListNodeConfig(params.Node.Config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remove-from-list node config: %w", err)
+	}
+
+	// Remove contact from list
+	err = e.contactListRepo.RemoveContactFromList(ctx, params.WorkspaceID, params.Contact.ContactEmail, config.ListID)
+	if err != nil {
+		// With an error edge the failure is routed there instead
+		if params.Node.ErrorNodeID != nil {
+			return nil, fmt.Errorf("failed to remove contact from list %s: %w", config.ListID, err)
+		}
+		// Log but don't fail - contact might not be in list
+		return &NodeExecutionResult{
+			NextNodeID: params.Node.NextNodeID,
+			Status:     domain.ContactAutomationStatusActive,
+			Output: buildNodeOutput(domain.NodeTypeRemoveFromList, map[string]interface{}{
+				"list_id": config.ListID,
+				"error":   err.Error(),
+			}),
+		}, nil
+	}
+
+	return &NodeExecutionResult{
+		NextNodeID: params.Node.NextNodeID,
+		Status:     domain.ContactAutomationStatusActive,
+		Output: buildNodeOutput(domain.NodeTypeRemoveFromList, map[string]interface{}{
+			"list_id": config.ListID,
+		}),
+	}, nil
+}
+
+// parseRemoveFromListNodeConfig parses remove-from-list node configuration from map
+func parseRemoveFromListNodeConfig(config map[string]interface{}) (*domain.RemoveFromListNodeConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var c domain.RemoveFromListNodeConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// UnsubscribeAllNodeExecutor executes unsubscribe-all nodes
+type UnsubscribeAllNodeExecutor struct {
+	contactListRepo domain.ContactListRepository
+}
+
+// NewUnsubscribeAllNodeExecutor creates a new unsubscribe-all node executor
+func NewUnsubscribeAllNodeExecutor(contactListRepo domain.ContactListRepository) *UnsubscribeAllNodeExecutor {
+	return &UnsubscribeAllNodeExecutor{
+		contactListRepo: contactListRepo,
+	}
+}
+
+// NodeType returns the node type this executor handles
+func (e *UnsubscribeAllNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeUnsubscribeAll
+}
+
+// Execute processes an unsubscribe-all node.
+// Every active or pending membership of the contact is unsubscribed or removed;
+// the contact_lists timeline trigger emits the matching list.* events.
+func (e *UnsubscribeAllNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseUnsubscribeAllNodeConfig(params.Node.Config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid unsubscribe-all node config: %w", err)
+	}
+
+	memberships, err := e.contactListRepo.GetListsByEmail(ctx, params.WorkspaceID, params.Contact.ContactEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact lists: %w", err)
+	}
+
+	updated := []string{}
+	failed := map[string]interface{}{}
+	for _, membership := range memberships {
+		if membership.Status != domain.ContactListStatusActive && membership.Status != domain.ContactListStatusPending {
+			continue
+		}
+
+		if config.Status == domain.UnsubscribeAllStatusRemoved {
+			err = e.contactListRepo.RemoveContactFromList(ctx, params.WorkspaceID, params.Contact.ContactEmail, membership.ListID)
+		} else {
+			err = e.contactListRepo.UpdateContactListStatus(ctx, params.WorkspaceID, params.Contact.ContactEmail, membership.ListID, domain.ContactListStatusUnsubscribed)
+		}
+		if err != nil {
+			// Don't fail the node - keep going so the contact leaves as many lists as possible
+			failed[membership.ListID] = err.Error()
+			continue
+		}
+		updated = append(updated, membership.ListID)
+	}
+
+	output := map[string]interface{}{
+		"status":   config.Status,
+		"list_ids": updated,
+	}
+	if len(failed) > 0 {
+		output["errors"] = failed
+	}
+
+	return &NodeExecutionResult{
+		NextNodeID: params.Node.NextNodeID,
+		Status:     domain.ContactAutomationStatusActive,
+		Output:     buildNodeOutput(domain.NodeTypeUnsubscribeAll, output),
+	}, nil
+}
+
+// parseUnsubscribeAllNodeConfig parses unsubscribe-all node configuration from map
+func parseUnsubscribeAllNodeConfig(config map[string]interface{}) (*domain.UnsubscribeAllNodeConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var c domain.UnsubscribeAllNodeConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// maxEnrollmentChainDepth bounds how many automations are followed when checking
+// enroll-in-automation chains for loops
+const maxEnrollmentChainDepth = 10
+
+// EnrollInAutomationNodeExecutor executes enroll-in-automation nodes
+type EnrollInAutomationNodeExecutor struct {
+	automationRepo domain.AutomationRepository
+}
+
+// NewEnrollInAutomationNodeExecutor creates a new enroll-in-automation node executor
+func NewEnrollInAutomationNodeExecutor(automationRepo domain.AutomationRepository) *EnrollInAutomationNodeExecutor {
+	return &EnrollInAutomationNodeExecutor{
+		automationRepo: automationRepo,
+	}
+}
+
+// NodeType returns the node type this executor handles
+func (e *EnrollInAutomationNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeEnrollInAutomation
+}
+
+// Execute processes an enroll-in-automation node.
+// The contact is enrolled into the target automation (applying its trigger frequency)
+// and continues in the current one. Enrollment is skipped, not failed, when the target
+// is not live or when it would lead back into the current automation.
+func (e *EnrollInAutomationNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseEnrollInAutomationNodeConfig(params.Node.Config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid enroll-in-automation node config: %w", err)
+	}
+
+	skip := func(reason string) *NodeExecutionResult {
+		return &NodeExecutionResult{
+			NextNodeID: params.Node.NextNodeID,
+			Status:     domain.ContactAutomationStatusActive,
+			Output: buildNodeOutput(domain.NodeTypeEnrollInAutomation, map[string]interface{}{
+				"automation_id": config.AutomationID,
+				"enrolled":      false,
+				"reason":        reason,
+			}),
+		}
+	}
+
+	if config.AutomationID == params.Automation.ID {
+		return skip("self_enrollment"), nil
+	}
+
+	target, err := e.automationRepo.GetByID(ctx, params.WorkspaceID, config.AutomationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target automation: %w", err)
+	}
+
+	if target.Status != domain.AutomationStatusLive {
+		return skip("automation_not_live"), nil
+	}
+
+	if e.leadsBackTo(ctx, params.WorkspaceID, target, params.Automation.ID) {
+		return skip("enrollment_loop"), nil
+	}
+
+	enrolled, err := e.automationRepo.EnrollContact(ctx, params.WorkspaceID, target, params.Contact.ContactEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enroll contact: %w", err)
+	}
+	if !enrolled {
+		// Already active in the target, or already triggered for a "once" automation
+		return skip("already_enrolled"), nil
+	}
+
+	return &NodeExecutionResult{
+		NextNodeID: params.Node.NextNodeID,
+		Status:     domain.ContactAutomationStatusActive,
+		Output: buildNodeOutput(domain.NodeTypeEnrollInAutomation, map[string]interface{}{
+			"automation_id": config.AutomationID,
+			"enrolled":      true,
+		}),
+	}, nil
+}
+
+// leadsBackTo reports whether following the enroll-in-automation nodes of the given
+// automation (transitively) reaches the automation with ID originID
+func (e *EnrollInAutomationNodeExecutor) leadsBackTo(ctx context.Context, workspaceID string, automation *domain.Automation, originID string) bool {
+	visited := map[string]bool{automation.ID: true}
+	queue := []*domain.Automation{automation}
+
+	for depth := 0; len(queue) > 0 && depth < maxEnrollmentChainDepth; depth++ {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, node := range current.Nodes {
+			if node == nil || node.Type != domain.NodeTypeEnrollInAutomation {
+				continue
+			}
+			nextID, _ := node.Config["automation_id"].(string)
+			if nextID == originID {
+				return true
+			}
+			if nextID == "" || visited[nextID] {
+				continue
+			}
+			visited[nextID] = true
+
+			next, err := e.automationRepo.GetByID(ctx, workspaceID, nextID)
+			if err != nil {
+				// Missing automations can't enroll anyone, so they can't close a loop
+				continue
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	return false
+}
+
+// parseEnrollInAutomationNodeConfig parses enroll-in-automation node configuration from map
+func parseEnrollInAutomationNodeConfig(config map[string]interface{}) (*domain.EnrollInAutomationNodeConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var c domain.EnrollInAutomationNodeConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// RecordRevenueNodeExecutor executes record-revenue nodes
+type RecordRevenueNodeExecutor struct {
+	automationRepo domain.AutomationRepository
+}
+
+// NewRecordRevenueNodeExecutor creates a new record-revenue node executor
+func NewRecordRevenueNodeExecutor(automationRepo domain.AutomationRepository) *RecordRevenueNodeExecutor {
+	return &RecordRevenueNodeExecutor{
+		automationRepo: automationRepo,
+	}
+}
+
+// NodeType returns the node type this executor handles
+func (e *RecordRevenueNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeRecordRevenue
+}
+
+// Execute processes a record-revenue node.
+// The amount is recorded for the contact and added to the automation's revenue stats.
+// Recording is skipped, not failed, when the contact field holds no usable amount.
+func (e *RecordRevenueNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseRecordRevenueNodeConfig(params.Node.Config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid record-revenue node config: %w", err)
+	}
+
+	amount, found := resolveRevenueAmount(config, params.ContactData)
+	if !found || amount < 0 {
+		reason := "amount_not_found"
+		if found {
+			reason = "negative_amount"
+		}
+		return &NodeExecutionResult{
+			NextNodeID: params.Node.NextNodeID,
+			Status:     domain.ContactAutomationStatusActive,
+			Output: buildNodeOutput(domain.NodeTypeRecordRevenue, map[string]interface{}{
+				"currency": config.Currency,
+				"recorded": false,
+				"reason":   reason,
+			}),
+		}, nil
+	}
+
+	revenue := &domain.AutomationRevenue{
+		ID:                  uuid.New().String(),
+		AutomationID:        params.Automation.ID,
+		ContactAutomationID: params.Contact.ID,
+		NodeID:              params.Node.ID,
+		ContactEmail:        params.Contact.ContactEmail,
+		Amount:              amount,
+		Currency:            config.Currency,
+		RecordedAt:          time.Now().UTC(),
+	}
+	if err := e.automationRepo.RecordRevenue(ctx, params.WorkspaceID, revenue); err != nil {
+		return nil, fmt.Errorf("failed to record revenue: %w", err)
+	}
+
+	return &NodeExecutionResult{
+		NextNodeID: params.Node.NextNodeID,
+		Status:     domain.ContactAutomationStatusActive,
+		Output: buildNodeOutput(domain.NodeTypeRecordRevenue, map[string]interface{}{
+			"amount":   amount,
+			"currency": config.Currency,
+			"recorded": true,
+		}),
+	}, nil
+}
+
+// resolveRevenueAmount returns the literal amount of the config, or the number found at
+// its amount_field path in the contact data
+func resolveRevenueAmount(config *domain.RecordRevenueNodeConfig, contact *domain.Contact) (float64, bool) {
+	if config.Amount != nil {
+		return *config.Amount, true
+	}
+	if contact == nil {
+		return 0, false
+	}
+
+	data, err := contact.ToMapOfAny()
+	if err != nil {
+		return 0, false
+	}
+
+	var value interface{} = map[string]interface{}(data)
+	for _, key := range strings.Split(config.AmountField, ".") {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return 0, false
+		}
+		value = fields[key]
+	}
+
+	amount, ok := value.(float64)
+	return amount, ok
+}
+
+// parseRecordRevenueNodeConfig parses record-revenue node configuration from map
+func parseRecordRevenueNodeConfig(config map[string]interface{}) (*domain.RecordRevenueNodeConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var c domain.RecordRevenueNodeConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// ListStatusBranchNodeExecutor executes list status branch nodes
+type ListStatusBranchNodeExecutor struct {
+	contactListRepo domain.ContactListRepository
+}
+
+// NewListStatusBranchNodeExecutor creates a new list status branch node executor
+func NewListStatusBranchNodeExecutor(contactListRepo domain.ContactListRepository) *ListStatusBranchNodeExecutor {
+	return &ListStatusBranchNodeExecutor{
+		contactListRepo: contactListRepo,
+	}
+}
+
+// NodeType returns the node type this executor handles
+func (e *ListStatusBranchNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeListStatusBranch
+}
+
+// Execute processes a list status branch node
+func (e *ListStatusBranchNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseListStatusBranchNodeConfig(params.Node.Config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid list_status_branch node config: %w", err)
+	}
+
+	// Query contact's status in the specified list
+	contactList, err := e.contactListRepo.GetContactListByIDs(
+		ctx,
+		params.WorkspaceID,
+		params.Contact.ContactEmail,
+		config.ListID,
+	)
+
+	var nextNodeID string
+	var branchTaken string
+	var contactStatus string
+
+	if err != nil {
+		// Check if error is "not found" - means contact is not in list
+		if _, ok := err.(*domain.ErrContactListNotFound); ok {
+			nextNodeID = config.NotInListNodeID
+			branchTaken = "not_in_list"
+			contactStatus = "not_found"
+		} else {
+			// Actual error - propagate it
+			return nil, fmt.Errorf("failed to check contact list status: %w", err)
+		}
+	} else {
+		// Contact found in list - check status
+		contactStatus = string(contactList.Status)
+		if contactList.Status == domain.ContactListStatusActive {
+			nextNodeID = config.ActiveNodeID
+			branchTaken = "active"
+		} else {
+			// Any non-active status: pending, unsubscribed, bounced, complained
+			nextNodeID = config.NonActiveNodeID
+			branchTaken = "non_active"
+		}
+	}
+
+	// Handle case where branch has no target (terminal)
+	var nextNodePtr *string
+	if nextNodeID != "" {
+		nextNodePtr = &nextNodeID
+	}
+
+	status := domain.ContactAutomationStatusActive
+	if nextNodePtr == nil {
+		status = domain.ContactAutomationStatusCompleted
+	}
+
+	return &NodeExecutionResult{
+		NextNodeID: nextNodePtr,
+		Status:     status,
+		Output: buildNodeOutput(domain.NodeTypeListStatusBranch, map[string]interface{}{
+			"list_id":        config.ListID,
+			"branch_taken":   branchTaken,
+			"contact_status": contactStatus,
+		}),
+	}, nil
+}
+
+// parseListStatusBranchNodeConfig parses list status branch node configuration from map
+func parseListStatusBranchNodeConfig(config map[string]interface{}) (*domain.ListStatusBranchNodeConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var c domain.ListStatusBranchNodeConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// SuppressionBranchNodeExecutor executes suppression branch nodes
+type SuppressionBranchNodeExecutor struct {
+	suppressionRepo domain.SuppressionRepository
+}
+
+// NewSuppressionBranchNodeExecutor creates a new suppression branch node executor
+func NewSuppressionBranchNodeExecutor(suppressionRepo domain.SuppressionRepository) *SuppressionBranchNodeExecutor {
+	return &SuppressionBranchNodeExecutor{
+		suppressionRepo: suppressionRepo,
+	}
+}
+
+// NodeType returns the node type this executor handles
+func (e *SuppressionBranchNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeSuppressionBranch
+}
+
+// Execute processes a suppression branch node
+func (e *SuppressionBranchNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseSuppressionBranchNodeConfig(params.Node.Config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid suppression_branch node config: %w", err)
+	}
+
+	suppressed, err := e.suppressionRepo.IsSuppressed(ctx, params.WorkspaceID, params.Contact.ContactEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check suppression status: %w", err)
+	}
+
+	nextNodeID := config.NotSuppressedNodeID
+	branchTaken := "not_suppressed"
+	if suppressed {
+		nextNodeID = config.SuppressedNodeID
+		branchTaken = "suppressed"
+	}
+
+	// Handle case where branch has no target (terminal)
+	var nextNodePtr *string
+	if nextNodeID != "" {
+		nextNodePtr = &nextNodeID
+	}
+
+	status := domain.ContactAutomationStatusActive
+	if nextNodePtr == nil {
+		status = domain.ContactAutomationStatusCompleted
+	}
+
+	return &NodeExecutionResult{
+		NextNodeID: nextNodePtr,
+		Status:     status,
+		Output: buildNodeOutput(domain.NodeTypeSuppressionBranch, map[string]interface{}{
+			"branch_taken": branchTaken,
+			"suppressed":   suppressed,
+		}),
+	}, nil
+}
+
+// parseSuppressionBranchNodeConfig parses suppression branch node configuration from map
+func parseSuppressionBranchNodeConfig(config map[string]interface{}) (*domain.SuppressionBranchNodeConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var c domain.SuppressionBranchNodeConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// ExpressionBranchNodeExecutor executes expression branch nodes
+type ExpressionBranchNodeExecutor struct{}
+
+// NewExpressionBranchNodeExecutor creates a new expression branch node executor
+func NewExpressionBranchNodeExecutor() *ExpressionBranchNodeExecutor {
+	return &ExpressionBranchNodeExecutor{}
+}
+
+// NodeType returns the node type this executor handles
+func (e *ExpressionBranchNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeExpressionBranch
+}
+
+// Execute renders the Liquid expression and takes the true path when it evaluates to true
+func (e *ExpressionBranchNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseExpressionBranchNodeConfig(params.Node.Config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression_branch node config: %w", err)
+	}
+
+	rendered, err := notifuse_mjml.ProcessLiquidTemplate(expressionBranchTemplate(config.Expression), contextLiquidData(params), "expression")
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate expression: %w", err)
+	}
+	result := strings.TrimSpace(rendered) == "true"
+
+	nextNodeID := config.FalseNodeID
+	branchTaken := "false"
+	if result {
+		nextNodeID = config.TrueNodeID
+		branchTaken = "true"
+	}
+
+	// Handle case where branch has no target (terminal)
+	var nextNodePtr *string
+	if nextNodeID != "" {
+		nextNodePtr = &nextNodeID
+	}
+
+	status := domain.ContactAutomationStatusActive
+	if nextNodePtr == nil {
+		status = domain.ContactAutomationStatusCompleted
+	}
+
+	return &NodeExecutionResult{
+		NextNodeID: nextNodePtr,
+		Status:     status,
+		Output: buildNodeOutput(domain.NodeTypeExpressionBranch, map[string]interface{}{
+			"branch_taken": branchTaken,
+			"expression":   config.Expression,
+			"result":       result,
+		}),
+	}, nil
+}
+
+// expressionBranchOutputRegexp matches an expression made of a single {{ ... }} output
+var expressionBranchOutputRegexp = regexp.MustCompile(`^\{\{-?(.*?)-?\}\}$`)
+
+// expressionBranchTemplate turns a "{{ condition }}" expression into an if tag, since Liquid
+// only evaluates comparisons and boolean operators inside tags. Other markup is rendered as is.
+func expressionBranchTemplate(expression string) string {
+	expression = strings.TrimSpace(expression)
+	match := expressionBranchOutputRegexp.FindStringSubmatch(expression)
+	if match == nil || strings.Contains(match[1], "{{") || strings.Contains(match[1], "}}") {
+		return expression
+	}
+	return "{% if " + strings.TrimSpace(match[1]) + " %}true{% else %}false{% endif %}"
+}
+
+// contextLiquidData returns the Liquid variables available to expressions and contact updates:
+// the per-contact node variables, the enrollment context and previous node outputs. Enrollment
+// context keys are also available at the top level, e.g. "event" for context.event, when no
+// node variable has that name.
+func contextLiquidData(params NodeExecutionParams) map[string]interface{} {
+	data := nodeLiquidData(params)
+	data["context"] = params.Contact.Context
+	data["nodes"] = params.ExecutionContext
+	for key, value := range params.Contact.Context {
+		if _, exists := data[key]; !exists {
+			data[key] = value
+		}
+	}
+	return data
+}
+
+// parseExpressionBranchNodeConfig parses expression branch node configuration from map
+func parseExpressionBranchNodeConfig(config map[string]interface{}) (*domain.ExpressionBranchNodeConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var c domain.ExpressionBranchNodeConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// waitForListStatusRecheckInterval is how often a waiting contact's list status is re-checked
+const waitForListStatusRecheckInterval = time.Minute
+
+// WaitForListStatusNodeExecutor executes wait for list status nodes
+type WaitForListStatusNodeExecutor struct {
+	contactListRepo domain.ContactListRepository
+}
+
+// NewWaitForListStatusNodeExecutor creates a new wait for list status node executor
+func NewWaitForListStatusNodeExecutor(contactListRepo domain.ContactListRepository) *WaitForListStatusNodeExecutor {
+	return &WaitForListStatusNodeExecutor{
+		contactListRepo: contactListRepo,
+	}
+}
+
+// NodeType returns the node type this executor handles
+func (e *WaitForListStatusNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeWaitForListStatus
+}
+
+// Execute checks the contact's status in the list. While the target status is not reached and
+// the timeout has not elapsed, the contact stays on this node and is re-checked on a later tick.
+func (e *WaitForListStatusNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseWaitForListStatusNodeConfig(params.Node.Config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wait_for_list_status node config: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	// The deadline is set on the first check and carried over in this node's output on later ticks
+	waitUntil, ok := waitForListStatusDeadline(params.ExecutionContext, params.Node.ID)
+	if !ok {
+		var timeout time.Duration
+		switch config.TimeoutUnit {
+		case "minutes":
+			timeout = time.Duration(config.Timeout) * time.Minute
+		case "hours":
+			timeout = time.Duration(config.Timeout) * time.Hour
+		case "days":
+			timeout = time.Duration(config.Timeout) * 24 * time.Hour
+		}
+		waitUntil = now.Add(timeout)
+	}
+
+	contactStatus := "not_found"
+	contactList, err := e.contactListRepo.GetContactListByIDs(ctx, params.WorkspaceID, params.Contact.ContactEmail, config.ListID)
+	if err != nil {
+		if _, ok := err.(*domain.ErrContactListNotFound); !ok {
+			return nil, fmt.Errorf("failed to check contact list status: %w", err)
+		}
+	} else {
+		contactStatus = string(contactList.Status)
+	}
+
+	output := map[string]interface{}{
+		"list_id":        config.ListID,
+		"target_status":  string(config.TargetStatus),
+		"contact_status": contactStatus,
+		"wait_until":     waitUntil.Format(time.RFC3339Nano),
+	}
+
+	var nextNodeID string
+	switch {
+	case contactStatus == string(config.TargetStatus):
+		nextNodeID = config.MatchedNodeID
+		output["outcome"] = "matched"
+	case !now.Before(waitUntil):
+		nextNodeID = config.TimeoutNodeID
+		output["outcome"] = "timed_out"
+	default:
+		// Keep waiting on this node, never past the deadline
+		scheduledAt := now.Add(waitForListStatusRecheckInterval)
+		if scheduledAt.After(waitUntil) {
+			scheduledAt = waitUntil
+		}
+		output["outcome"] = "waiting"
+		return &NodeExecutionResult{
+			NextNodeID:  &params.Node.ID,
+			ScheduledAt: &scheduledAt,
+			Status:      domain.ContactAutomationStatusActive,
+			Output:      buildNodeOutput(domain.NodeTypeWaitForListStatus, output),
+		}, nil
+	}
+
+	// Handle case where the path has no target (terminal)
+	var nextNodePtr *string
+	if nextNodeID != "" {
+		nextNodePtr = &nextNodeID
+	}
+
+	status := domain.ContactAutomationStatusActive
+	if nextNodePtr == nil {
+		status = domain.ContactAutomationStatusCompleted
+	}
+
+	return &NodeExecutionResult{
+		NextNodeID: nextNodePtr,
+		Status:     status,
+		Output:     buildNodeOutput(domain.NodeTypeWaitForListStatus, output),
+	}, nil
+}
+
+// waitForListStatusDeadline returns the deadline recorded by a previous check of a node the contact is still waiting on
+func waitForListStatusDeadline(executionContext map[string]interface{}, nodeID string) (time.Time, bool) {
+	previous, ok := executionContext[nodeID].(map[string]interface{})
+	if !ok || previous["outcome"] != "waiting" {
+		return time.Time{}, false
+	}
+	raw, ok := previous["wait_until"].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	waitUntil, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return waitUntil, true
+}
+
+// parseWaitForListStatusNodeConfig parses wait for list status node configuration from map
+func parseWaitForListStatusNodeConfig(config map[string]interface{}) (*domain.WaitForListStatusNodeConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var c domain.WaitForListStatusNodeConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// WaitUntilDatetimeNodeExecutor executes wait until datetime nodes
+type WaitUntilDatetimeNodeExecutor struct{}
+
+// NewWaitUntilDatetimeNodeExecutor creates a new wait until datetime node executor
+func NewWaitUntilDatetimeNodeExecutor() *WaitUntilDatetimeNodeExecutor {
+	return &WaitUntilDatetimeNodeExecutor{}
+}
+
+// NodeType returns the node type this executor handles
+func (e *WaitUntilDatetimeNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeWaitUntilDatetime
+}
+
+// Execute holds the contact on this node until the configured datetime, then continues to the
+// next node. The datetime is checked again when the contact is picked up, so a contact only
+// advances once it has passed; contacts arriving after it pass through immediately.
+func (e *WaitUntilDatetimeNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseWaitUntilDatetimeNodeConfig(params.Node.Config)
+	if err != nil {
+		return nil, permanentNodeErrorf("invalid wait_until_datetime node config: %w", err)
+	}
+
+	waitUntil, err := config.ResolveTime()
+	if err != nil {
+		return nil, permanentNodeErrorf("invalid wait_until_datetime node config: %w", err)
+	}
+
+	output := map[string]interface{}{
+		"datetime":   config.Datetime,
+		"timezone":   config.Timezone,
+		"wait_until": waitUntil.Format(time.RFC3339Nano),
+	}
+
+	if time.Now().UTC().Before(waitUntil) {
+		output["outcome"] = "waiting"
+		return &NodeExecutionResult{
+			NextNodeID:  &params.Node.ID,
+			ScheduledAt: &waitUntil,
+			Status:      domain.ContactAutomationStatusActive,
+			Output:      buildNodeOutput(domain.NodeTypeWaitUntilDatetime, output),
+		}, nil
+	}
+
+	output["outcome"] = "passed"
+	return &NodeExecutionResult{
+		NextNodeID: params.Node.NextNodeID,
+		Status:     domain.ContactAutomationStatusActive,
+		Output:     buildNodeOutput(domain.NodeTypeWaitUntilDatetime, output),
+	}, nil
+}
+
+// parseWaitUntilDatetimeNodeConfig parses wait until datetime node configuration from map
+func parseWaitUntilDatetimeNodeConfig(config map[string]interface{}) (*domain.WaitUntilDatetimeNodeConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var c domain.WaitUntilDatetimeNodeConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// WaitUntilNodeExecutor executes wait until nodes
+type WaitUntilNodeExecutor struct {
+	workspaceRepo domain.WorkspaceRepository
+}
+
+// NewWaitUntilNodeExecutor creates a new wait until node executor
+func NewWaitUntilNodeExecutor(workspaceRepo domain.WorkspaceRepository) *WaitUntilNodeExecutor {
+	return &WaitUntilNodeExecutor{workspaceRepo: workspaceRepo}
+}
+
+// NodeType returns the node type this executor handles
+func (e *WaitUntilNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeWaitUntil
+}
+
+// Execute schedules the next node at the next allowed day and time of day in the contact's
+// timezone. Contacts without a timezone use the workspace timezone, and contacts with an
+// unknown timezone fall back to UTC rather than failing.
+func (e *WaitUntilNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseWaitUntilNodeConfig(params.Node.Config)
+	if err != nil {
+		return nil, permanentNodeErrorf("invalid wait_until node config: %w", err)
+	}
+
+	timezone, source, err := e.resolveTimezone(ctx, params, config.GetTimezoneField())
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		timezone, source, location = "UTC", "fallback", time.UTC
+	}
+
+	scheduledAt := config.NextTime(time.Now(), location)
+
+	return &NodeExecutionResult{
+		NextNodeID:  params.Node.NextNodeID,
+		ScheduledAt: &scheduledAt,
+		Status:      domain.ContactAutomationStatusActive,
+		Output: buildNodeOutput(domain.NodeTypeWaitUntil, map[string]interface{}{
+			"days_of_week":    config.DaysOfWeek,
+			"hour":            config.Hour,
+			"minute":          config.Minute,
+			"timezone":        timezone,
+			"timezone_source": source,
+			"wait_until":      scheduledAt,
+		}),
+	}, nil
+}
+
+// resolveTimezone returns the contact's timezone, or the workspace timezone when the contact
+// has none, along with where it came from
+func (e *WaitUntilNodeExecutor) resolveTimezone(ctx context.Context, params NodeExecutionParams, field string) (string, string, error) {
+	if params.ContactData != nil {
+		if contact, err := params.ContactData.ToMapOfAny(); err == nil {
+			if timezone, ok := contact[field].(string); ok && timezone != "" {
+				return timezone, "contact", nil
+			}
+		}
+	}
+
+	workspace, err := e.workspaceRepo.GetByID(ctx, params.WorkspaceID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get workspace: %w", err)
+	}
+	if workspace.Settings.Timezone == "" {
+		return "UTC", "fallback", nil
+	}
+	return workspace.Settings.Timezone, "workspace", nil
+}
+
+// parseWaitUntilNodeConfig parses wait until node configuration from map
+func parseWaitUntilNodeConfig(config map[string]interface{}) (*domain.WaitUntilNodeConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var c domain.WaitUntilNodeConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// forEachItemKey and forEachIndexKey bind the current element of a for_each node in the
+// execution context of its sub-flow nodes
+const (
+	forEachItemKey  = "item"
+	forEachIndexKey = "item_index"
+)
+
+// maxForEachSubFlowNodes bounds how many nodes a single for_each iteration may run, so a
+// sub-flow looping back on itself cannot run forever
+const maxForEachSubFlowNodes = 50
+
+// forEachItem returns the element bound by an enclosing for_each node, if any
+func forEachItem(params NodeExecutionParams) (interface{}, bool) {
+	item, ok := params.ExecutionContext[forEachItemKey]
+	return item, ok
 }
 
-// ListStatusBranchNodeExecutor executes list status branch nodes
-type ListStatusBranchNodeExecutor struct {
-	contactListRepo domain.ContactListRepository
+// ForEachNodeExecutor executes for_each nodes
+type ForEachNodeExecutor struct {
+	nodeExecutors map[domain.NodeType]NodeExecutor
 }
 
-// NewListStatusBranchNodeExecutor creates a new list status branch node executor
-func NewListStatusBranchNodeExecutor(contactListRepo domain.ContactListRepository) *ListStatusBranchNodeExecutor {
-	return &ListStatusBranchNodeExecutor{
-		contactListRepo: contactListRepo,
-	}
+// NewForEachNodeExecutor creates a new for_each node executor
+func NewForEachNodeExecutor() *ForEachNodeExecutor {
+	return &ForEachNodeExecutor{}
+}
+
+// SetNodeExecutors sets the executors used to run the sub-flow nodes
+// (set after construction since the for_each executor is one of them)
+func (e *ForEachNodeExecutor) SetNodeExecutors(executors map[domain.NodeType]NodeExecutor) {
+	e.nodeExecutors = executors
 }
 
 // NodeType returns the node type this executor handles
-func (e *ListStatusBranchNodeExecutor) NodeType() domain.NodeType {
-	return domain.NodeTypeListStatusBranch
+func (e *ForEachNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeForEach
 }
 
-// Execute processes a list status branch node
-func (e *ListStatusBranchNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
-	config, err := parseListStatusBranchNodeConfig(params.Node.Config)
+// Execute runs the sub-flow once per element of the configured array, with the element bound
+// to `item`, then continues to the next node. Sub-flows run to their end within this node, so
+// nodes that hold the contact (delays, waits) are not allowed in them. A sub-flow node that
+// exits the contact (e.g. a filter) only ends the iteration for that element.
+func (e *ForEachNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseForEachNodeConfig(params.Node.Config)
 	if err != nil {
-		return nil, fmt.Errorf("invalid list_status_branch node config: %w", err)
+		return nil, fmt.Errorf("invalid for_each node config: %w", err)
 	}
 
-	// Query contact's status in the specified list
-	contactList, err := e.contactListRepo.GetContactListByIDs(
-		ctx,
-		params.WorkspaceID,
-		params.Contact.ContactEmail,
-		config.ListID,
-	)
-
-	var nextNodeID string
-	var branchTaken string
-	var contactStatus string
-
+	items, err := resolveForEachItems(config.ItemsPath, params)
 	if err != nil {
-		// Check if error is "not found" - means contact is not in list
-		if _, ok := err.(*domain.ErrContactListNotFound); ok {
-			nextNodeID = config.NotInListNodeID
-			branchTaken = "not_in_list"
-			contactStatus = "not_found"
-		} else {
-			// Actual error - propagate it
-			return nil, fmt.Errorf("failed to check contact list status: %w", err)
-		}
-	} else {
-		// Contact found in list - check status
-		contactStatus = string(contactList.Status)
-		if contactList.Status == domain.ContactListStatusActive {
-			nextNodeID = config.ActiveNodeID
-			branchTaken = "active"
-		} else {
-			// Any non-active status: pending, unsubscribed, bounced, complained
-			nextNodeID = config.NonActiveNodeID
-			branchTaken = "non_active"
-		}
+		return nil, err
 	}
 
-	// Handle case where branch has no target (terminal)
-	var nextNodePtr *string
-	if nextNodeID != "" {
-		nextNodePtr = &nextNodeID
+	truncated := len(items) > domain.MaxForEachItems
+	if truncated {
+		items = items[:domain.MaxForEachItems]
 	}
 
-	status := domain.ContactAutomationStatusActive
-	if nextNodePtr == nil {
-		status = domain.ContactAutomationStatusCompleted
+	// Context values saved by sub-flow nodes are visible to the nodes after them and kept on the contact
+	contextValues := make(map[string]interface{})
+	iterations := make([]map[string]interface{}, 0, len(items))
+	for i, item := range items {
+		nodeIDs, err := e.runSubFlow(ctx, params, config.SubFlowNodeID, i, item, contextValues)
+		if err != nil {
+			return nil, fmt.Errorf("for_each item %d: %w", i, err)
+		}
+		iterations = append(iterations, map[string]interface{}{
+			"index": i,
+			"nodes": nodeIDs,
+		})
 	}
 
 	return &NodeExecutionResult{
-		NextNodeID: nextNodePtr,
-		Status:     status,
-		Output: buildNodeOutput(domain.NodeTypeListStatusBranch, map[string]interface{}{
-			"list_id":        config.ListID,
-			"branch_taken":   branchTaken,
-			"contact_status": contactStatus,
+		NextNodeID: params.Node.NextNodeID,
+		Status:     domain.ContactAutomationStatusActive,
+		Context:    contextValues,
+		Output: buildNodeOutput(domain.NodeTypeForEach, map[string]interface{}{
+			"items_path": config.ItemsPath,
+			"item_count": len(items),
+			"truncated":  truncated,
+			"iterations": iterations,
 		}),
 	}, nil
 }
 
-// parseListStatusBranchNodeConfig parses list status branch node configuration from map
-func parseListStatusBranchNodeConfig(config map[string]interface{}) (*domain.ListStatusBranchNodeConfig, error) {
+// runSubFlow runs the sub-flow for one element and returns the IDs of the nodes it ran.
+// Context values saved by its nodes are added to contextValues.
+func (e *ForEachNodeExecutor) runSubFlow(ctx context.Context, params NodeExecutionParams, startNodeID string, index int, item interface{}, contextValues map[string]interface{}) ([]string, error) {
+	executionContext := make(map[string]interface{}, len(params.ExecutionContext)+2)
+	for k, v := range params.ExecutionContext {
+		executionContext[k] = v
+	}
+	executionContext[forEachItemKey] = item
+	executionContext[forEachIndexKey] = index
+
+	var nodeIDs []string
+	nextNodeID := &startNodeID
+	for nextNodeID != nil {
+		if len(nodeIDs) >= maxForEachSubFlowNodes {
+			return nodeIDs, fmt.Errorf("sub-flow exceeded %d nodes", maxForEachSubFlowNodes)
+		}
+
+		node := params.Automation.GetNodeByID(*nextNodeID)
+		if node == nil {
+			return nodeIDs, fmt.Errorf("sub-flow node %s not found", *nextNodeID)
+		}
+		switch node.Type {
+		case domain.NodeTypeTrigger, domain.NodeTypeDelay, domain.NodeTypeWaitForListStatus,
+			domain.NodeTypeWaitUntilDatetime, domain.NodeTypeWaitUntil, domain.NodeTypeForEach, domain.NodeTypeThrottle,
+			domain.NodeTypeReenroll:
+			return nodeIDs, fmt.Errorf("%s node %s cannot run in a for_each sub-flow", node.Type, node.ID)
+		}
+		executor, ok := e.nodeExecutors[node.Type]
+		if !ok {
+			return nodeIDs, fmt.Errorf("unsupported node type: %s", node.Type)
+		}
+
+		nodeParams := params
+		nodeParams.Node = node
+		nodeParams.ExecutionContext = executionContext
+		if len(contextValues) > 0 {
+			nodeParams.Contact = contactWithContextValues(params.Contact, contextValues)
+		}
+		result, err := executor.Execute(ctx, nodeParams)
+		if err != nil {
+			return nodeIDs, fmt.Errorf("sub-flow node %s failed: %w", node.ID, err)
+		}
+		nodeIDs = append(nodeIDs, node.ID)
+
+		for key, value := range result.Context {
+			contextValues[key] = value
+		}
+
+		if result.Status != domain.ContactAutomationStatusActive {
+			break
+		}
+		if result.Output != nil {
+			executionContext[node.ID] = result.Output
+		}
+		nextNodeID = result.NextNodeID
+	}
+
+	return nodeIDs, nil
+}
+
+// contactWithContextValues returns a copy of the contact automation with the values added
+// to its context, leaving the original untouched
+func contactWithContextValues(ca *domain.ContactAutomation, values map[string]interface{}) *domain.ContactAutomation {
+	contact := *ca
+	contact.Context = make(map[string]interface{}, len(ca.Context)+len(values))
+	for key, value := range ca.Context {
+		contact.Context[key] = value
+	}
+	contact.SetContextValues(values)
+	return &contact
+}
+
+// resolveForEachItems returns the array found at the dot path, an absent or null value
+// being an empty array
+func resolveForEachItems(path string, params NodeExecutionParams) ([]interface{}, error) {
+	liquidData := nodeLiquidData(params)
+	var value interface{} = map[string]interface{}{
+		"context":     params.Contact.Context,
+		"contact":     liquidData["contact"],
+		"global_feed": liquidData["global_feed"],
+		"nodes":       params.ExecutionContext,
+	}
+
+	for _, segment := range strings.Split(path, ".") {
+		switch current := value.(type) {
+		case map[string]interface{}:
+			value = current[segment]
+		case domain.MapOfAny:
+			value = current[segment]
+		default:
+			value = nil
+		}
+		if value == nil {
+			return nil, nil
+		}
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("items_path %s is not an array", path)
+	}
+	return items, nil
+}
+
+// parseForEachNodeConfig parses for_each node configuration from map
+func parseForEachNodeConfig(config map[string]interface{}) (*domain.ForEachNodeConfig, error) {
 	data, err := json.Marshal(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	var c domain.ListStatusBranchNodeConfig
+	var c domain.ForEachNodeConfig
 	if err := json.Unmarshal(data, &c); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
@@ -969,7 +2967,7 @@ func (e *ABTestNodeExecutor) Execute(ctx context.Context, params NodeExecutionPa
 		Status:     domain.ContactAutomationStatusActive,
 		Output: buildNodeOutput(domain.NodeTypeABTest, map[string]interface{}{
 			"variant_id":   variant.ID,
-			"variant_name": variant.Name,
+			"variant_name": renderPathLabel(variant.Name, params),
 		}),
 	}, nil
 }
@@ -1026,8 +3024,14 @@ func parseABTestNodeConfig(config map[string]interface{}) (*domain.ABTestNodeCon
 
 // WebhookNodeExecutor executes webhook nodes
 type WebhookNodeExecutor struct {
-	httpClient *http.Client
-	logger     logger.Logger
+	httpClient    *http.Client
+	workspaceRepo domain.WorkspaceRepository // Decrypts mTLS client keys with the workspace secret key
+	logger        logger.Logger
+
+	// Clients presenting a client certificate, by certificate fingerprint, so that
+	// executions share their transport and its idle connections
+	mtlsClients   map[[sha256.Size]byte]*http.Client
+	mtlsClientsMu sync.Mutex
 }
 
 // NewWebhookNodeExecutor creates a new webhook node executor
@@ -1038,6 +3042,12 @@ func NewWebhookNodeExecutor(log logger.Logger) *WebhookNodeExecutor {
 	}
 }
 
+// SetWorkspaceRepository sets the repository used to get the workspace secret key that
+// decrypts mTLS client keys
+func (e *WebhookNodeExecutor) SetWorkspaceRepository(workspaceRepo domain.WorkspaceRepository) {
+	e.workspaceRepo = workspaceRepo
+}
+
 // NodeType returns the node type this executor handles
 func (e *WebhookNodeExecutor) NodeType() domain.NodeType {
 	return domain.NodeTypeWebhook
@@ -1053,10 +3063,16 @@ func (e *WebhookNodeExecutor) Execute(ctx context.Context, params NodeExecutionP
 
 	// 2. Build payload with contact data
 	payload := buildWebhookPayload(params.ContactData, params.Automation, params.Node.ID)
+	if globalFeed := params.Contact.GlobalFeed(); globalFeed != nil {
+		payload["global_feed"] = globalFeed
+	}
+	if item, ok := forEachItem(params); ok {
+		payload["item"] = item
+	}
 
-	payloadBytes, err := json.Marshal(payload)
+	payloadBytes, contentType, err := encodeWebhookPayload(payload, config.ContentType)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal webhook payload: %w", err)
+		return nil, err
 	}
 
 	// 3. Create HTTP request
@@ -1066,32 +3082,68 @@ func (e *WebhookNodeExecutor) Execute(ctx context.Context, params NodeExecutionP
 	}
 
 	// Set headers
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 	if config.Secret != nil && *config.Secret != "" {
 		req.Header.Set("Authorization", "Bearer "+*config.Secret)
 	}
+	for _, header := range config.Headers {
+		value, err := renderWebhookHeaderValue(header.Value, params)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook header %s: %w", header.Name, err)
+		}
+		req.Header.Set(header.Name, value)
+	}
 
 	// 4. Make HTTP POST request
-	resp, err := e.httpClient.Do(req)
+	client, err := e.clientFor(ctx, params.WorkspaceID, config)
+	if err != nil {
+		return nil, err
+	}
+	logExchange := params.Automation != nil && params.Automation.LogWebhooks
+	startedAt := time.Now()
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("webhook request failed: %w", err)
+		err = fmt.Errorf("webhook request failed: %w", err)
+		if logExchange {
+			exchange := buildWebhookExchange(config.URL, payloadBytes, 0, nil, time.Since(startedAt))
+			exchange["error"] = err.Error()
+			return webhookExchangeResult(exchange), err
+		}
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	// Read response body (limit to 10KB)
-	bodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024))
+	// Read response body (limit to 10KB, or up to the stored size cap when the response is mapped)
+	readLimit := int64(10 * 1024)
+	if config.ResponseMapping != nil {
+		readLimit = domain.WebhookResponseMaxStoredBytes + 1
+	}
+	bodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, readLimit))
 	if err != nil {
 		return nil, fmt.Errorf("failed to read webhook response: %w", err)
 	}
 
+	var exchange map[string]interface{}
+	if logExchange {
+		exchange = buildWebhookExchange(config.URL, payloadBytes, resp.StatusCode, bodyBytes, time.Since(startedAt))
+	}
+
 	// 5. Handle response status
 	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
 		// 4xx - client error, fail immediately (won't be fixed by retry)
-		return nil, fmt.Errorf("webhook returned client error: %d %s", resp.StatusCode, string(bodyBytes))
+		err := fmt.Errorf("webhook returned client error: %d %s", resp.StatusCode, string(bodyBytes))
+		if exchange != nil {
+			return webhookExchangeResult(exchange), err
+		}
+		return nil, err
 	}
 	if resp.StatusCode >= 500 {
 		// 5xx - server error, return error to trigger retry via existing backoff
-		return nil, fmt.Errorf("webhook returned server error: %d %s", resp.StatusCode, string(bodyBytes))
+		err := fmt.Errorf("webhook returned server error: %d %s", resp.StatusCode, string(bodyBytes))
+		if exchange != nil {
+			return webhookExchangeResult(exchange), err
+		}
+		return nil, err
 	}
 
 	// 6. Parse JSON response for context storage
@@ -1112,15 +3164,161 @@ func (e *WebhookNodeExecutor) Execute(ctx context.Context, params NodeExecutionP
 		"status_code":   resp.StatusCode,
 	}).Info("Webhook node executed successfully")
 
+	output := map[string]interface{}{
+		"url":         config.URL,
+		"status_code": resp.StatusCode,
+		"response":    responseData,
+	}
+	if exchange != nil {
+		output["exchange"] = exchange
+	}
+	nextNodeID := params.Node.NextNodeID
+
+	// Store the mapped response in the automation context for later nodes
+	var contextValues map[string]interface{}
+	if config.ResponseMapping != nil {
+		contextValues = map[string]interface{}{
+			config.ResponseMapping.SaveAs: config.ResponseMapping.Extract(bodyBytes),
+		}
+		output["saved_as"] = config.ResponseMapping.SaveAs
+	}
+
+	// 7. Route on the response when branches are configured, falling back to next_node_id
+	if len(config.OnResponseBranches) > 0 {
+		output["branch_taken"] = "default"
+		for _, branch := range config.OnResponseBranches {
+			if branch.Matches(responseData) {
+				branchNodeID := branch.NextNodeID
+				nextNodeID = &branchNodeID
+				output["branch_taken"] = branch.ID
+				if branch.Name != "" {
+					output["branch_name"] = renderPathLabel(branch.Name, params)
+				}
+				break
+			}
+		}
+	}
+
 	return &NodeExecutionResult{
-		NextNodeID: params.Node.NextNodeID,
+		NextNodeID: nextNodeID,
 		Status:     domain.ContactAutomationStatusActive,
+		Context:    contextValues,
+		Output:     buildNodeOutput(domain.NodeTypeWebhook, output),
+	}, nil
+}
+
+// webhookLogBodyLimit caps the request and response bodies recorded in a webhook exchange
+const webhookLogBodyLimit = 2048
+
+// buildWebhookExchange records a webhook call for automations with log_webhooks enabled.
+// Bodies are truncated to webhookLogBodyLimit bytes and headers are left out since they
+// may carry secrets. statusCode is 0 when no response was received.
+func buildWebhookExchange(url string, requestBody []byte, statusCode int, responseBody []byte, latency time.Duration) map[string]interface{} {
+	request := map[string]interface{}{
+		"method": http.MethodPost,
+		"url":    url,
+	}
+	request["body"], request["body_truncated"] = truncateWebhookLogBody(requestBody)
+
+	exchange := map[string]interface{}{
+		"request":    request,
+		"latency_ms": latency.Milliseconds(),
+	}
+	if statusCode > 0 {
+		response := map[string]interface{}{
+			"status_code": statusCode,
+		}
+		response["body"], response["body_truncated"] = truncateWebhookLogBody(responseBody)
+		exchange["response"] = response
+	}
+	return exchange
+}
+
+// truncateWebhookLogBody returns the body as a string of at most webhookLogBodyLimit bytes
+// and whether it was truncated
+func truncateWebhookLogBody(body []byte) (string, bool) {
+	if len(body) <= webhookLogBodyLimit {
+		return string(body), false
+	}
+	// Drop a multi-byte character cut in half at the limit
+	return strings.ToValidUTF8(string(body[:webhookLogBodyLimit]), ""), true
+}
+
+// webhookExchangeResult returns the partial result of a failed webhook call, so the
+// exchange is still recorded on the failed node execution
+func webhookExchangeResult(exchange map[string]interface{}) *NodeExecutionResult {
+	return &NodeExecutionResult{
 		Output: buildNodeOutput(domain.NodeTypeWebhook, map[string]interface{}{
-			"url":         config.URL,
-			"status_code": resp.StatusCode,
-			"response":    responseData,
+			"exchange": exchange,
 		}),
-	}, nil
+	}
+}
+
+// renderWebhookHeaderValue renders the Liquid in a header value for the contact and
+// rejects results that would inject extra headers
+func renderWebhookHeaderValue(value string, params NodeExecutionParams) (string, error) {
+	if !strings.Contains(value, "{{") && !strings.Contains(value, "{%") {
+		return value, nil
+	}
+	rendered, err := notifuse_mjml.ProcessLiquidTemplate(value, nodeLiquidData(params), "webhook_header")
+	if err != nil {
+		return "", err
+	}
+	if err := domain.ValidateWebhookHeaderValue(rendered); err != nil {
+		return "", err
+	}
+	return rendered, nil
+}
+
+// clientFor returns the HTTP client for a webhook, presenting the configured
+// client certificate when mutual TLS is enabled
+func (e *WebhookNodeExecutor) clientFor(ctx context.Context, workspaceID string, config *domain.WebhookNodeConfig) (*http.Client, error) {
+	if config.MTLS == nil {
+		return e.httpClient, nil
+	}
+
+	mtls := *config.MTLS
+	if mtls.ClientKey == "" {
+		if e.workspaceRepo == nil {
+			return nil, fmt.Errorf("workspace repository not configured")
+		}
+		workspace, err := e.workspaceRepo.GetByID(ctx, workspaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workspace: %w", err)
+		}
+		if err := mtls.DecryptClientKey(workspace.Settings.SecretKey); err != nil {
+			return nil, err
+		}
+	}
+
+	cert, err := mtls.Certificate()
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := sha256.Sum256(cert.Certificate[0])
+	e.mtlsClientsMu.Lock()
+	defer e.mtlsClientsMu.Unlock()
+	if client, ok := e.mtlsClients[fingerprint]; ok {
+		return client, nil
+	}
+
+	base, ok := e.httpClient.Transport.(*http.Transport)
+	if !ok {
+		base = http.DefaultTransport.(*http.Transport)
+	}
+	transport := base.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+
+	client := &http.Client{Timeout: e.httpClient.Timeout, Transport: transport}
+	if e.mtlsClients == nil {
+		e.mtlsClients = make(map[[sha256.Size]byte]*http.Client)
+	}
+	e.mtlsClients[fingerprint] = client
+	return client, nil
 }
 
 // buildWebhookPayload creates the payload for webhook requests
@@ -1138,11 +3336,66 @@ func buildWebhookPayload(contact *domain.Contact, automation *domain.Automation,
 	if automation != nil {
 		payload["automation_id"] = automation.ID
 		payload["automation_name"] = automation.Name
+		if len(automation.Metadata) > 0 {
+			payload["automation_metadata"] = automation.Metadata
+		}
 	}
 
 	return payload
 }
 
+// encodeWebhookPayload serializes the payload for the node's content type and
+// returns the body along with the matching Content-Type header
+func encodeWebhookPayload(payload map[string]interface{}, contentType domain.WebhookContentType) ([]byte, string, error) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+	if contentType != domain.WebhookContentTypeForm {
+		return payloadBytes, "application/json", nil
+	}
+
+	// Round-trip through JSON so nested values (e.g. the contact) use their JSON field names
+	var generic map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payloadBytes))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, "", fmt.Errorf("failed to encode webhook form payload: %w", err)
+	}
+
+	values := url.Values{}
+	flattenFormValues(values, "", generic)
+	return []byte(values.Encode()), "application/x-www-form-urlencoded", nil
+}
+
+// flattenFormValues writes nested values using bracket notation
+// (contact[first_name]=John, tags[0]=vip); null values are omitted
+func flattenFormValues(values url.Values, key string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			childKey := k
+			if key != "" {
+				childKey = key + "[" + k + "]"
+			}
+			flattenFormValues(values, childKey, child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			flattenFormValues(values, fmt.Sprintf("%s[%d]", key, i), child)
+		}
+	case nil:
+	case string:
+		values.Add(key, v)
+	case json.Number:
+		values.Add(key, v.String())
+	case bool:
+		values.Add(key, strconv.FormatBool(v))
+	default:
+		values.Add(key, fmt.Sprint(v))
+	}
+}
+
 // parseWebhookNodeConfig parses webhook node configuration from map
 func parseWebhookNodeConfig(config map[string]interface{}) (*domain.WebhookNodeConfig, error) {
 	data, err := json.Marshal(config)
@@ -1161,3 +3414,100 @@ func parseWebhookNodeConfig(config map[string]interface{}) (*domain.WebhookNodeC
 
 	return &c, nil
 }
+
+// SlackNodeExecutor executes slack nodes
+type SlackNodeExecutor struct {
+	httpClient *http.Client // SSRF-safe client, the URL is also validated at save time
+	logger     logger.Logger
+}
+
+// NewSlackNodeExecutor creates a new slack node executor
+func NewSlackNodeExecutor(log logger.Logger) *SlackNodeExecutor {
+	return &SlackNodeExecutor{
+		httpClient: safehttpclient.New(),
+		logger:     log,
+	}
+}
+
+// NodeType returns the node type this executor handles
+func (e *SlackNodeExecutor) NodeType() domain.NodeType {
+	return domain.NodeTypeSlack
+}
+
+// Execute posts the node's message to the Slack incoming webhook. Any non-2xx response is
+// returned as an error, so the message is retried with the usual backoff.
+func (e *SlackNodeExecutor) Execute(ctx context.Context, params NodeExecutionParams) (*NodeExecutionResult, error) {
+	config, err := parseSlackNodeConfig(params.Node.Config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid slack node config: %w", err)
+	}
+
+	text, err := notifuse_mjml.ProcessLiquidTemplate(config.Text, contextLiquidData(params), "slack_text")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render slack text: %w", err)
+	}
+
+	payloadBytes, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal slack payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.WebhookURL, bytes.NewReader(payloadBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("slack request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Slack answers "ok" on success and a short error code otherwise, e.g. "invalid_payload"
+	bodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read slack response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("slack returned status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	automationID := ""
+	if params.Automation != nil {
+		automationID = params.Automation.ID
+	}
+	e.logger.WithFields(map[string]interface{}{
+		"workspace_id":  params.WorkspaceID,
+		"automation_id": automationID,
+		"status_code":   resp.StatusCode,
+	}).Info("Slack node executed successfully")
+
+	return &NodeExecutionResult{
+		NextNodeID: params.Node.NextNodeID,
+		Status:     domain.ContactAutomationStatusActive,
+		Output: buildNodeOutput(domain.NodeTypeSlack, map[string]interface{}{
+			"text":        text,
+			"status_code": resp.StatusCode,
+		}),
+	}, nil
+}
+
+// parseSlackNodeConfig parses slack node configuration from map
+func parseSlackNodeConfig(config map[string]interface{}) (*domain.SlackNodeConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var c domain.SlackNodeConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
@@ -2,18 +2,33 @@ package service
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"database/sql"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/internal/service/queue"
+	"github.com/Notifuse/notifuse/pkg/crypto"
+	"github.com/Notifuse/notifuse/pkg/logger"
 	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
 	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
 	"github.com/golang/mock/gomock"
@@ -235,13 +250,149 @@ func TestDelayNodeExecutor_Execute(t *testing.T) {
 	})
 }
 
+func TestDelayNodeExecutor_Execute_Anchored(t *testing.T) {
+	executor := NewDelayNodeExecutor()
+
+	anchoredParams := func(config map[string]interface{}, contact *domain.Contact) NodeExecutionParams {
+		return NodeExecutionParams{
+			WorkspaceID: "ws1",
+			Node: &domain.AutomationNode{
+				ID:         "node1",
+				Type:       domain.NodeTypeDelay,
+				NextNodeID: strPtr("node2"),
+				Config:     config,
+			},
+			Contact:     &domain.ContactAutomation{ID: "ca1", ContactEmail: "test@example.com"},
+			ContactData: contact,
+		}
+	}
+
+	t.Run("future anchor waits until anchor plus offset", func(t *testing.T) {
+		contractEnd := time.Now().UTC().Add(10 * 24 * time.Hour).Truncate(time.Second)
+		contact := &domain.Contact{
+			Email:           "test@example.com",
+			CustomDatetime1: &domain.NullableTime{Time: contractEnd},
+		}
+
+		result, err := executor.Execute(context.Background(), anchoredParams(map[string]interface{}{
+			"unit":         "days",
+			"anchor_field": "custom_datetime_1",
+			"offset":       -3,
+		}, contact))
+		require.NoError(t, err)
+		require.NotNil(t, result.ScheduledAt)
+
+		assert.True(t, contractEnd.Add(-3*24*time.Hour).Equal(*result.ScheduledAt))
+		assert.Equal(t, "node2", *result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "waiting", result.Output["outcome"])
+		assert.Equal(t, "custom_datetime_1", result.Output["anchor_field"])
+	})
+
+	t.Run("date-only string anchor", func(t *testing.T) {
+		day := time.Now().UTC().AddDate(0, 0, 5).Format("2006-01-02")
+		contact := &domain.Contact{
+			Email:         "test@example.com",
+			CustomString1: &domain.NullableString{String: day},
+		}
+
+		result, err := executor.Execute(context.Background(), anchoredParams(map[string]interface{}{
+			"unit":         "hours",
+			"anchor_field": "custom_string_1",
+			"offset":       9,
+		}, contact))
+		require.NoError(t, err)
+		require.NotNil(t, result.ScheduledAt)
+
+		expected, _ := time.Parse("2006-01-02", day)
+		assert.True(t, expected.Add(9*time.Hour).Equal(*result.ScheduledAt))
+	})
+
+	t.Run("past anchor skips by default", func(t *testing.T) {
+		contact := &domain.Contact{
+			Email:           "test@example.com",
+			CustomDatetime1: &domain.NullableTime{Time: time.Now().UTC().Add(-48 * time.Hour)},
+		}
+
+		result, err := executor.Execute(context.Background(), anchoredParams(map[string]interface{}{
+			"unit":         "days",
+			"anchor_field": "custom_datetime_1",
+			"offset":       1,
+		}, contact))
+		require.NoError(t, err)
+
+		assert.Nil(t, result.ScheduledAt)
+		assert.Equal(t, "node2", *result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "skipped", result.Output["outcome"])
+	})
+
+	t.Run("past anchor exits with on_past exit", func(t *testing.T) {
+		contact := &domain.Contact{
+			Email:           "test@example.com",
+			CustomDatetime1: &domain.NullableTime{Time: time.Now().UTC().Add(-48 * time.Hour)},
+		}
+
+		result, err := executor.Execute(context.Background(), anchoredParams(map[string]interface{}{
+			"unit":         "days",
+			"anchor_field": "custom_datetime_1",
+			"on_past":      "exit",
+		}, contact))
+		require.NoError(t, err)
+
+		assert.Nil(t, result.NextNodeID)
+		assert.Nil(t, result.ScheduledAt)
+		assert.Equal(t, domain.ContactAutomationStatusExited, result.Status)
+		require.NotNil(t, result.ExitReason)
+		assert.Equal(t, "delay_anchor_passed", *result.ExitReason)
+	})
+
+	t.Run("malformed date returns error", func(t *testing.T) {
+		contact := &domain.Contact{
+			Email:         "test@example.com",
+			CustomString1: &domain.NullableString{String: "next tuesday"},
+		}
+
+		result, err := executor.Execute(context.Background(), anchoredParams(map[string]interface{}{
+			"unit":         "days",
+			"anchor_field": "custom_string_1",
+		}, contact))
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid date")
+		assert.True(t, isPermanentNodeError(err), "retrying can't fix the contact's date")
+	})
+
+	t.Run("missing anchor value returns error", func(t *testing.T) {
+		contact := &domain.Contact{Email: "test@example.com"}
+
+		result, err := executor.Execute(context.Background(), anchoredParams(map[string]interface{}{
+			"unit":         "days",
+			"anchor_field": "custom_datetime_2",
+		}, contact))
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "no value")
+		assert.True(t, isPermanentNodeError(err), "retrying can't fix the contact's date")
+	})
+
+	t.Run("missing contact data returns error", func(t *testing.T) {
+		result, err := executor.Execute(context.Background(), anchoredParams(map[string]interface{}{
+			"unit":         "days",
+			"anchor_field": "created_at",
+		}, nil))
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
 func TestDelayNodeExecutor_NodeType(t *testing.T) {
 	executor := NewDelayNodeExecutor()
 	assert.Equal(t, domain.NodeTypeDelay, executor.NodeType())
 }
 
 func TestTriggerNodeExecutor_Execute(t *testing.T) {
-	executor := NewTriggerNodeExecutor()
+	executor := NewTriggerNodeExecutor(nil, nil)
 
 	t.Run("passes through to next node", func(t *testing.T) {
 		params := NodeExecutionParams{
@@ -295,10 +446,146 @@ func TestTriggerNodeExecutor_Execute(t *testing.T) {
 }
 
 func TestTriggerNodeExecutor_NodeType(t *testing.T) {
-	executor := NewTriggerNodeExecutor()
+	executor := NewTriggerNodeExecutor(nil, nil)
 	assert.Equal(t, domain.NodeTypeTrigger, executor.NodeType())
 }
 
+func TestTriggerNodeExecutor_Execute_MinDwell(t *testing.T) {
+	enteredAt := time.Now().UTC().Add(-30 * time.Minute)
+	dwellAutomation := func(frequency domain.TriggerFrequency) *domain.Automation {
+		return &domain.Automation{
+			ID:          "auto1",
+			WorkspaceID: "ws1",
+			Trigger: &domain.TimelineTriggerConfig{
+				EventKind:    "segment.joined",
+				SegmentID:    strPtr("seg1"),
+				Frequency:    frequency,
+				MinDwell:     1,
+				MinDwellUnit: "hours",
+			},
+		}
+	}
+	dwellParams := func(automation *domain.Automation) NodeExecutionParams {
+		return NodeExecutionParams{
+			WorkspaceID: "ws1",
+			Automation:  automation,
+			Node: &domain.AutomationNode{
+				ID:         "trigger_node",
+				Type:       domain.NodeTypeTrigger,
+				NextNodeID: strPtr("node2"),
+				Config:     map[string]interface{}{},
+			},
+			Contact: &domain.ContactAutomation{
+				ID:           "ca1",
+				ContactEmail: "test@example.com",
+				EnteredAt:    enteredAt,
+			},
+		}
+	}
+
+	t.Run("contact that left the segment quickly is not enrolled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSegmentRepo := mocks.NewMockSegmentRepository(ctrl)
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		mockSegmentRepo.EXPECT().
+			GetContactSegmentMembership(gomock.Any(), "ws1", "test@example.com", "seg1").
+			Return(nil, nil)
+		mockAutomationRepo.EXPECT().
+			ClearTriggerLog(gomock.Any(), "ws1", "auto1", "test@example.com").
+			Return(nil)
+
+		executor := NewTriggerNodeExecutor(mockSegmentRepo, mockAutomationRepo)
+		result, err := executor.Execute(context.Background(), dwellParams(dwellAutomation(domain.TriggerFrequencyOnce)))
+		require.NoError(t, err)
+
+		assert.Nil(t, result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusExited, result.Status)
+		require.NotNil(t, result.ExitReason)
+		assert.Equal(t, "segment_dwell_not_met", *result.ExitReason)
+		assert.Equal(t, "left_segment", result.Output["outcome"])
+	})
+
+	t.Run("contact that left and joined again restarts the clock", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSegmentRepo := mocks.NewMockSegmentRepository(ctrl)
+		mockSegmentRepo.EXPECT().
+			GetContactSegmentMembership(gomock.Any(), "ws1", "test@example.com", "seg1").
+			Return(&domain.ContactSegment{Email: "test@example.com", SegmentID: "seg1", MatchedAt: enteredAt.Add(10 * time.Minute)}, nil)
+
+		// every_time automations keep their trigger log untouched
+		executor := NewTriggerNodeExecutor(mockSegmentRepo, mocks.NewMockAutomationRepository(ctrl))
+		result, err := executor.Execute(context.Background(), dwellParams(dwellAutomation(domain.TriggerFrequencyEveryTime)))
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.ContactAutomationStatusExited, result.Status)
+		require.NotNil(t, result.ExitReason)
+		assert.Equal(t, "segment_dwell_not_met", *result.ExitReason)
+	})
+
+	t.Run("contact still within the dwell time waits on the trigger", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSegmentRepo := mocks.NewMockSegmentRepository(ctrl)
+		mockSegmentRepo.EXPECT().
+			GetContactSegmentMembership(gomock.Any(), "ws1", "test@example.com", "seg1").
+			Return(&domain.ContactSegment{Email: "test@example.com", SegmentID: "seg1", MatchedAt: enteredAt}, nil)
+
+		executor := NewTriggerNodeExecutor(mockSegmentRepo, mocks.NewMockAutomationRepository(ctrl))
+		result, err := executor.Execute(context.Background(), dwellParams(dwellAutomation(domain.TriggerFrequencyOnce)))
+		require.NoError(t, err)
+
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "trigger_node", *result.NextNodeID)
+		require.NotNil(t, result.ScheduledAt)
+		assert.True(t, result.ScheduledAt.Equal(enteredAt.Add(time.Hour)))
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "waiting", result.Output["outcome"])
+	})
+
+	t.Run("contact that stayed in the segment is enrolled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		params := dwellParams(dwellAutomation(domain.TriggerFrequencyOnce))
+		params.Contact.EnteredAt = time.Now().UTC().Add(-2 * time.Hour)
+
+		mockSegmentRepo := mocks.NewMockSegmentRepository(ctrl)
+		mockSegmentRepo.EXPECT().
+			GetContactSegmentMembership(gomock.Any(), "ws1", "test@example.com", "seg1").
+			Return(&domain.ContactSegment{Email: "test@example.com", SegmentID: "seg1", MatchedAt: params.Contact.EnteredAt}, nil)
+
+		executor := NewTriggerNodeExecutor(mockSegmentRepo, mocks.NewMockAutomationRepository(ctrl))
+		result, err := executor.Execute(context.Background(), params)
+		require.NoError(t, err)
+
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "node2", *result.NextNodeID)
+		assert.Nil(t, result.ScheduledAt)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "dwell_met", result.Output["outcome"])
+	})
+
+	t.Run("returns error when the membership lookup fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockSegmentRepo := mocks.NewMockSegmentRepository(ctrl)
+		mockSegmentRepo.EXPECT().
+			GetContactSegmentMembership(gomock.Any(), "ws1", "test@example.com", "seg1").
+			Return(nil, errors.New("db down"))
+
+		executor := NewTriggerNodeExecutor(mockSegmentRepo, mocks.NewMockAutomationRepository(ctrl))
+		result, err := executor.Execute(context.Background(), dwellParams(dwellAutomation(domain.TriggerFrequencyOnce)))
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
 func TestParseDelayNodeConfig(t *testing.T) {
 	t.Run("valid config", func(t *testing.T) {
 		config := map[string]interface{}{
@@ -537,7 +824,7 @@ func TestEmailNodeExecutor_Execute_Success(t *testing.T) {
 	assert.Equal(t, true, result.Output["queued"])
 }
 
-func TestEmailNodeExecutor_Execute_NilContactData(t *testing.T) {
+func TestEmailNodeExecutor_Execute_SkipIfSentWithin(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -546,37 +833,71 @@ func TestEmailNodeExecutor_Execute_NilContactData(t *testing.T) {
 	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
 	mockListRepo := mocks.NewMockListRepository(ctrl)
 	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
 	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
 
 	executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
+	executor.SetMessageHistoryRepository(mockMessageHistoryRepo)
+
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(createTestWorkspaceWithEmailProvider(), nil).Times(2)
+	mockTemplateRepo.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "tpl123", int64(0)).Return(createTestTemplate(), nil).Times(2)
+
+	// Message history records every enqueued email as sent
+	var history []*domain.MessageHistory
+	mockMessageHistoryRepo.EXPECT().
+		ListMessages(gomock.Any(), "ws1", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, listParams domain.MessageListParams) ([]*domain.MessageHistory, string, error) {
+			assert.Equal(t, "recipient@example.com", listParams.ContactEmail)
+			assert.Equal(t, "tpl123", listParams.TemplateID)
+			require.NotNil(t, listParams.SentAfter)
+			assert.WithinDuration(t, time.Now().Add(-24*time.Hour), *listParams.SentAfter, time.Minute)
+			return history, "", nil
+		}).Times(2)
+	mockEmailQueueRepo.EXPECT().
+		Enqueue(gomock.Any(), "ws1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, entries []*domain.EmailQueueEntry) error {
+			history = append(history, &domain.MessageHistory{
+				ID:           entries[0].ID,
+				ContactEmail: "recipient@example.com",
+				TemplateID:   "tpl123",
+				SentAt:       time.Now().UTC(),
+			})
+			return nil
+		}).Times(1)
 
 	params := NodeExecutionParams{
 		WorkspaceID: "ws1",
 		Node: &domain.AutomationNode{
-			ID:   "email_node1",
-			Type: domain.NodeTypeEmail,
+			ID:         "email_node1",
+			Type:       domain.NodeTypeEmail,
+			NextNodeID: strPtr("next_node"),
 			Config: map[string]interface{}{
-				"template_id": "tpl123",
+				"template_id":              "tpl123",
+				"skip_if_sent_within":      24,
+				"skip_if_sent_within_unit": "hours",
 			},
 		},
-		Contact: &domain.ContactAutomation{
-			ID:           "ca1",
-			ContactEmail: "recipient@example.com",
-		},
-		ContactData: nil, // Missing contact data
-		Automation: &domain.Automation{
-			ID:   "auto1",
-			Name: "Test Automation",
-		},
+		Contact:     &domain.ContactAutomation{ID: "ca1", ContactEmail: "recipient@example.com"},
+		ContactData: &domain.Contact{Email: "recipient@example.com"},
+		Automation:  &domain.Automation{ID: "auto1", Name: "Test Automation"},
 	}
 
-	result, err := executor.Execute(context.Background(), params)
-	assert.Error(t, err)
-	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "contact data is required for email node")
+	first, err := executor.Execute(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, true, first.Output["queued"])
+	assert.Nil(t, first.Output["skipped"])
+
+	second, err := executor.Execute(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, "next_node", *second.NextNodeID)
+	assert.Equal(t, domain.ContactAutomationStatusActive, second.Status)
+	assert.Equal(t, true, second.Output["skipped"])
+	assert.Equal(t, "sent_within_window", second.Output["skip_reason"])
+	assert.NotEmpty(t, second.Output["last_sent_at"])
+	assert.Nil(t, second.Output["queued"])
 }
 
-func TestEmailNodeExecutor_Execute_NilAutomation(t *testing.T) {
+func TestEmailNodeExecutor_Execute_BroadcastGlobalFeed(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -589,32 +910,46 @@ func TestEmailNodeExecutor_Execute_NilAutomation(t *testing.T) {
 
 	executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
 
+	template := createTestTemplate()
+	template.Email.Subject = "Featured: {{ global_feed.headline }}"
+
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(createTestWorkspaceWithEmailProvider(), nil)
+	mockTemplateRepo.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "tpl123", int64(0)).Return(template, nil)
+
+	var queued *domain.EmailQueueEntry
+	mockEmailQueueRepo.EXPECT().
+		Enqueue(gomock.Any(), "ws1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, entries []*domain.EmailQueueEntry) error {
+			queued = entries[0]
+			return nil
+		})
+
 	params := NodeExecutionParams{
 		WorkspaceID: "ws1",
 		Node: &domain.AutomationNode{
-			ID:   "email_node1",
-			Type: domain.NodeTypeEmail,
-			Config: map[string]interface{}{
-				"template_id": "tpl123",
-			},
+			ID:     "email_node1",
+			Type:   domain.NodeTypeEmail,
+			Config: map[string]interface{}{"template_id": "tpl123"},
 		},
 		Contact: &domain.ContactAutomation{
 			ID:           "ca1",
 			ContactEmail: "recipient@example.com",
+			Context: map[string]interface{}{
+				domain.ContactAutomationContextBroadcastID: "bc1",
+				domain.ContactAutomationContextGlobalFeed:  map[string]interface{}{"headline": "Spring Sale"},
+			},
 		},
-		ContactData: &domain.Contact{
-			Email: "recipient@example.com",
-		},
-		Automation: nil, // Missing automation
+		ContactData: &domain.Contact{Email: "recipient@example.com"},
+		Automation:  &domain.Automation{ID: "auto1", Name: "Broadcast Follow-up"},
 	}
 
-	result, err := executor.Execute(context.Background(), params)
-	assert.Error(t, err)
-	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "automation is required for email node")
+	_, err := executor.Execute(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, queued)
+	assert.Equal(t, "Featured: Spring Sale", queued.Payload.Subject)
 }
 
-func TestEmailNodeExecutor_Execute_InvalidConfig(t *testing.T) {
+func TestEmailNodeExecutor_Execute_AutomationContextValues(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -627,35 +962,47 @@ func TestEmailNodeExecutor_Execute_InvalidConfig(t *testing.T) {
 
 	executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
 
+	template := createTestTemplate()
+	template.Email.Subject = "Your score: {{ enrichment }} ({{ automation_name }})"
+
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(createTestWorkspaceWithEmailProvider(), nil)
+	mockTemplateRepo.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "tpl123", int64(0)).Return(template, nil)
+
+	var queued *domain.EmailQueueEntry
+	mockEmailQueueRepo.EXPECT().
+		Enqueue(gomock.Any(), "ws1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, entries []*domain.EmailQueueEntry) error {
+			queued = entries[0]
+			return nil
+		})
+
 	params := NodeExecutionParams{
 		WorkspaceID: "ws1",
 		Node: &domain.AutomationNode{
 			ID:     "email_node1",
 			Type:   domain.NodeTypeEmail,
-			Config: map[string]interface{}{
-				// Missing template_id
-			},
+			Config: map[string]interface{}{"template_id": "tpl123"},
 		},
 		Contact: &domain.ContactAutomation{
 			ID:           "ca1",
 			ContactEmail: "recipient@example.com",
+			Context: map[string]interface{}{
+				"enrichment": float64(87),
+				// Context keys cannot shadow the variables set by the email node
+				"automation_name": "Overridden",
+			},
 		},
-		ContactData: &domain.Contact{
-			Email: "recipient@example.com",
-		},
-		Automation: &domain.Automation{
-			ID:   "auto1",
-			Name: "Test Automation",
-		},
+		ContactData: &domain.Contact{Email: "recipient@example.com"},
+		Automation:  &domain.Automation{ID: "auto1", Name: "Lead scoring"},
 	}
 
-	result, err := executor.Execute(context.Background(), params)
-	assert.Error(t, err)
-	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "invalid email node config")
+	_, err := executor.Execute(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, queued)
+	assert.Equal(t, "Your score: 87 (Lead scoring)", queued.Payload.Subject)
 }
 
-func TestEmailNodeExecutor_Execute_WorkspaceNotFound(t *testing.T) {
+func TestEmailNodeExecutor_Execute_LocalizedTemplate(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -668,61 +1015,472 @@ func TestEmailNodeExecutor_Execute_WorkspaceNotFound(t *testing.T) {
 
 	executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
 
-	mockWorkspaceRepo.EXPECT().
-		GetByID(gomock.Any(), "ws1").
-		Return(nil, errors.New("workspace not found"))
+	workspace := createTestWorkspaceWithEmailProvider()
+	workspace.Settings.DefaultLanguage = "en"
 
-	params := NodeExecutionParams{
-		WorkspaceID: "ws1",
-		Node: &domain.AutomationNode{
-			ID:   "email_node1",
-			Type: domain.NodeTypeEmail,
-			Config: map[string]interface{}{
-				"template_id": "tpl123",
-			},
-		},
-		Contact: &domain.ContactAutomation{
-			ID:           "ca1",
-			ContactEmail: "recipient@example.com",
-		},
-		ContactData: &domain.Contact{
-			Email: "recipient@example.com",
-		},
-		Automation: &domain.Automation{
-			ID:   "auto1",
-			Name: "Test Automation",
-		},
+	template := createTestTemplate()
+	template.Email.Subject = "Welcome"
+	template.Email.VisualEditorTree = createValidMJMLTree(createTestTextBlock("txt1", "Thanks for joining"))
+	template.Translations = map[string]domain.TemplateTranslation{
+		"fr": {Email: &domain.EmailTemplate{
+			Subject:          "Bienvenue",
+			SenderID:         "sender1",
+			VisualEditorTree: createValidMJMLTree(createTestTextBlock("txt1", "Merci de votre inscription")),
+		}},
 	}
 
-	result, err := executor.Execute(context.Background(), params)
-	assert.Error(t, err)
-	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "workspace not found")
-}
-
-func TestEmailNodeExecutor_Execute_NoEmailProvider(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(workspace, nil).Times(2)
+	mockTemplateRepo.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "tpl123", int64(0)).Return(template, nil).Times(2)
 
-	mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
-	mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
-	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
-	mockListRepo := mocks.NewMockListRepository(ctrl)
-	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
-	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+	queued := map[string]*domain.EmailQueueEntry{}
+	mockEmailQueueRepo.EXPECT().
+		Enqueue(gomock.Any(), "ws1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, entries []*domain.EmailQueueEntry) error {
+			queued[entries[0].ContactEmail] = entries[0]
+			return nil
+		}).Times(2)
 
-	executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
+	automation := &domain.Automation{ID: "auto1", Name: "Onboarding"}
+	node := &domain.AutomationNode{
+		ID:     "email_node1",
+		Type:   domain.NodeTypeEmail,
+		Config: map[string]interface{}{"template_id": "tpl123"},
+	}
 
-	// Workspace without email provider
-	workspace := &domain.Workspace{
-		ID:           "ws1",
-		Name:         "Test Workspace",
-		Integrations: []domain.Integration{},
+	// A regional French locale uses the "fr" translation, English gets the default content
+	for _, contact := range []*domain.Contact{
+		{Email: "marie@example.com", Language: &domain.NullableString{String: "fr-CA"}},
+		{Email: "john@example.com", Language: &domain.NullableString{String: "en"}},
+	} {
+		_, err := executor.Execute(context.Background(), NodeExecutionParams{
+			WorkspaceID: "ws1",
+			Node:        node,
+			Contact:     &domain.ContactAutomation{ID: "ca_" + contact.Email, ContactEmail: contact.Email},
+			ContactData: contact,
+			Automation:  automation,
+		})
+		require.NoError(t, err)
 	}
 
-	mockWorkspaceRepo.EXPECT().
-		GetByID(gomock.Any(), "ws1").
-		Return(workspace, nil)
+	require.Len(t, queued, 2)
+	assert.Equal(t, "Bienvenue", queued["marie@example.com"].Payload.Subject)
+	assert.Contains(t, queued["marie@example.com"].Payload.HTMLContent, "Merci de votre inscription")
+	assert.Equal(t, "Welcome", queued["john@example.com"].Payload.Subject)
+	assert.Contains(t, queued["john@example.com"].Payload.HTMLContent, "Thanks for joining")
+}
+
+func TestEmailNodeExecutor_Execute_ImmediateDelivery(t *testing.T) {
+	newParams := func() NodeExecutionParams {
+		return NodeExecutionParams{
+			WorkspaceID: "ws1",
+			Node: &domain.AutomationNode{
+				ID:   "email_node1",
+				Type: domain.NodeTypeEmail,
+				Config: map[string]interface{}{
+					"template_id": "tpl123",
+					"delivery":    "immediate",
+				},
+			},
+			Contact:     &domain.ContactAutomation{ID: "ca1", ContactEmail: "recipient@example.com"},
+			ContactData: &domain.Contact{Email: "recipient@example.com"},
+			Automation:  &domain.Automation{ID: "auto1", Name: "OTP"},
+		}
+	}
+	// Immediate emails go through the queue worker's rate limiter and circuit breaker
+	newSender := func(emailService domain.EmailServiceInterface, log logger.Logger) ImmediateEmailSender {
+		return queue.NewEmailQueueWorker(nil, nil, emailService, nil, nil, log)
+	}
+
+	t.Run("sends before the node execution returns", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// Only the dedupe key is claimed: enqueueing fails the test
+		mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockListRepo := mocks.NewMockListRepository(ctrl)
+		mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+		executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
+		executor.SetImmediateDelivery(newSender(mockEmailService, mockLogger), mockMessageHistoryRepo)
+
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(createTestWorkspaceWithEmailProvider(), nil)
+		mockTemplateRepo.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "tpl123", int64(0)).Return(createTestTemplate(), nil)
+
+		var sent *domain.SendEmailProviderRequest
+		var recorded *domain.MessageHistory
+		gomock.InOrder(
+			mockEmailQueueRepo.EXPECT().
+				ClaimDedupeKey(gomock.Any(), "ws1", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, entry *domain.EmailQueueEntry) (bool, error) {
+					assert.Equal(t, "automation:ca1:email_node1", entry.DedupeKey)
+					return true, nil
+				}),
+			mockEmailService.EXPECT().
+				SendEmail(gomock.Any(), gomock.Any(), true).
+				DoAndReturn(func(_ context.Context, request domain.SendEmailProviderRequest, _ bool) error {
+					sent = &request
+					return nil
+				}),
+			mockMessageHistoryRepo.EXPECT().
+				Upsert(gomock.Any(), "ws1", gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _ string, message *domain.MessageHistory) error {
+					recorded = message
+					return nil
+				}),
+		)
+
+		result, err := executor.Execute(context.Background(), newParams())
+		require.NoError(t, err)
+
+		require.NotNil(t, sent)
+		assert.Equal(t, "recipient@example.com", sent.To)
+		assert.True(t, sent.EmailOptions.BulkHeaders)
+		require.NotNil(t, recorded)
+		assert.Equal(t, sent.MessageID, recorded.ID)
+		assert.Equal(t, "auto1", *recorded.AutomationID)
+		assert.Nil(t, recorded.FailedAt)
+
+		assert.Equal(t, true, result.Output["sent"])
+		assert.Equal(t, "immediate", result.Output["delivery"])
+		assert.Nil(t, result.Output["queued"])
+	})
+
+	t.Run("transactional node sends without bulk headers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+		executor := NewTransactionalEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo,
+			mocks.NewMockListRepository(ctrl), mocks.NewMockContactListRepository(ctrl), "https://api.example.com", mockLogger)
+		executor.SetImmediateDelivery(newSender(mockEmailService, mockLogger), mockMessageHistoryRepo)
+
+		workspace := createTestWorkspaceWithEmailProvider()
+		workspace.Settings.TransactionalEmailProviderID = workspace.Integrations[0].ID
+
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(workspace, nil)
+		mockTemplateRepo.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "tpl123", int64(0)).Return(createTestTemplate(), nil)
+		mockEmailQueueRepo.EXPECT().ClaimDedupeKey(gomock.Any(), "ws1", gomock.Any()).Return(true, nil)
+		mockEmailService.EXPECT().
+			SendEmail(gomock.Any(), gomock.Any(), false).
+			DoAndReturn(func(_ context.Context, request domain.SendEmailProviderRequest, _ bool) error {
+				assert.False(t, request.EmailOptions.BulkHeaders, "transactional emails must not carry Precedence: bulk")
+				return nil
+			})
+		mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), "ws1", gomock.Any(), gomock.Any()).Return(nil)
+
+		params := newParams()
+		params.Node.Type = domain.NodeTypeTransactionalEmail
+		result, err := executor.Execute(context.Background(), params)
+		require.NoError(t, err)
+		assert.Equal(t, true, result.Output["sent"])
+	})
+
+	t.Run("contact on messaging hold waits on the node until the hold passes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// No workspace, template or send expectations: nothing is rendered or sent during the hold
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+		executor := NewEmailNodeExecutor(mocks.NewMockEmailQueueRepository(ctrl), mocks.NewMockTemplateRepository(ctrl), mocks.NewMockWorkspaceRepository(ctrl),
+			mocks.NewMockListRepository(ctrl), mocks.NewMockContactListRepository(ctrl), "https://api.example.com", mockLogger)
+		executor.SetImmediateDelivery(newSender(mockEmailService, mockLogger), mocks.NewMockMessageHistoryRepository(ctrl))
+
+		holdUntil := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second)
+		params := newParams()
+		params.ContactData.MessagingHoldUntil = &domain.NullableTime{Time: holdUntil}
+
+		result, err := executor.Execute(context.Background(), params)
+		require.NoError(t, err)
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "email_node1", *result.NextNodeID)
+		require.NotNil(t, result.ScheduledAt)
+		assert.True(t, holdUntil.Equal(*result.ScheduledAt))
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, holdUntil.Format(time.RFC3339), result.Output["held_until"])
+		assert.Nil(t, result.Output["sent"])
+	})
+
+	t.Run("email already sent by a previous execution is skipped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		// No send or message history expectations: the email is not sent again
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+		executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo,
+			mocks.NewMockListRepository(ctrl), mocks.NewMockContactListRepository(ctrl), "https://api.example.com", mockLogger)
+		executor.SetImmediateDelivery(newSender(mockEmailService, mockLogger), mocks.NewMockMessageHistoryRepository(ctrl))
+
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(createTestWorkspaceWithEmailProvider(), nil)
+		mockTemplateRepo.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "tpl123", int64(0)).Return(createTestTemplate(), nil)
+		mockEmailQueueRepo.EXPECT().ClaimDedupeKey(gomock.Any(), "ws1", gomock.Any()).Return(false, nil)
+
+		result, err := executor.Execute(context.Background(), newParams())
+		require.NoError(t, err)
+		assert.Equal(t, true, result.Output["skipped"])
+		assert.Equal(t, "already_sent", result.Output["skip_reason"])
+		assert.Nil(t, result.Output["sent"])
+	})
+
+	t.Run("send failure is recorded and fails the node", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockListRepo := mocks.NewMockListRepository(ctrl)
+		mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+		executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
+		executor.SetImmediateDelivery(newSender(mockEmailService, mockLogger), mockMessageHistoryRepo)
+
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(createTestWorkspaceWithEmailProvider(), nil)
+		mockTemplateRepo.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "tpl123", int64(0)).Return(createTestTemplate(), nil)
+		mockEmailQueueRepo.EXPECT().ClaimDedupeKey(gomock.Any(), "ws1", gomock.Any()).Return(true, nil)
+		mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).Return(errors.New("provider unavailable"))
+		// The provider rejected the email, the retry may send it again
+		mockEmailQueueRepo.EXPECT().ReleaseDedupeKey(gomock.Any(), "ws1", gomock.Any()).Return(nil)
+		mockMessageHistoryRepo.EXPECT().
+			Upsert(gomock.Any(), "ws1", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, message *domain.MessageHistory) error {
+				assert.NotNil(t, message.FailedAt)
+				assert.Equal(t, "provider unavailable", *message.StatusInfo)
+				return nil
+			})
+
+		result, err := executor.Execute(context.Background(), newParams())
+		assert.Nil(t, result)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to send email")
+	})
+
+	t.Run("not available without an email service", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+		executor := NewEmailNodeExecutor(mocks.NewMockEmailQueueRepository(ctrl), mockTemplateRepo, mockWorkspaceRepo,
+			mocks.NewMockListRepository(ctrl), mocks.NewMockContactListRepository(ctrl), "https://api.example.com", mockLogger)
+
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(createTestWorkspaceWithEmailProvider(), nil)
+		mockTemplateRepo.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "tpl123", int64(0)).Return(createTestTemplate(), nil)
+
+		_, err := executor.Execute(context.Background(), newParams())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "immediate delivery is not available")
+	})
+}
+
+func TestEmailNodeExecutor_Execute_NilContactData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+	mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockListRepo := mocks.NewMockListRepository(ctrl)
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+	executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
+
+	params := NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:   "email_node1",
+			Type: domain.NodeTypeEmail,
+			Config: map[string]interface{}{
+				"template_id": "tpl123",
+			},
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "recipient@example.com",
+		},
+		ContactData: nil, // Missing contact data
+		Automation: &domain.Automation{
+			ID:   "auto1",
+			Name: "Test Automation",
+		},
+	}
+
+	result, err := executor.Execute(context.Background(), params)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "contact data is required for email node")
+}
+
+func TestEmailNodeExecutor_Execute_NilAutomation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+	mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockListRepo := mocks.NewMockListRepository(ctrl)
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+	executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
+
+	params := NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:   "email_node1",
+			Type: domain.NodeTypeEmail,
+			Config: map[string]interface{}{
+				"template_id": "tpl123",
+			},
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "recipient@example.com",
+		},
+		ContactData: &domain.Contact{
+			Email: "recipient@example.com",
+		},
+		Automation: nil, // Missing automation
+	}
+
+	result, err := executor.Execute(context.Background(), params)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "automation is required for email node")
+}
+
+func TestEmailNodeExecutor_Execute_InvalidConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+	mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockListRepo := mocks.NewMockListRepository(ctrl)
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+	executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
+
+	params := NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:     "email_node1",
+			Type:   domain.NodeTypeEmail,
+			Config: map[string]interface{}{
+				// Missing template_id
+			},
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "recipient@example.com",
+		},
+		ContactData: &domain.Contact{
+			Email: "recipient@example.com",
+		},
+		Automation: &domain.Automation{
+			ID:   "auto1",
+			Name: "Test Automation",
+		},
+	}
+
+	result, err := executor.Execute(context.Background(), params)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "invalid email node config")
+}
+
+func TestEmailNodeExecutor_Execute_WorkspaceNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+	mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockListRepo := mocks.NewMockListRepository(ctrl)
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+	executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
+
+	mockWorkspaceRepo.EXPECT().
+		GetByID(gomock.Any(), "ws1").
+		Return(nil, errors.New("workspace not found"))
+
+	params := NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:   "email_node1",
+			Type: domain.NodeTypeEmail,
+			Config: map[string]interface{}{
+				"template_id": "tpl123",
+			},
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "recipient@example.com",
+		},
+		ContactData: &domain.Contact{
+			Email: "recipient@example.com",
+		},
+		Automation: &domain.Automation{
+			ID:   "auto1",
+			Name: "Test Automation",
+		},
+	}
+
+	result, err := executor.Execute(context.Background(), params)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "workspace not found")
+}
+
+func TestEmailNodeExecutor_Execute_NoEmailProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+	mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockListRepo := mocks.NewMockListRepository(ctrl)
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+	executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
+
+	// Workspace without email provider
+	workspace := &domain.Workspace{
+		ID:           "ws1",
+		Name:         "Test Workspace",
+		Integrations: []domain.Integration{},
+	}
+
+	mockWorkspaceRepo.EXPECT().
+		GetByID(gomock.Any(), "ws1").
+		Return(workspace, nil)
 
 	params := NodeExecutionParams{
 		WorkspaceID: "ws1",
@@ -971,25 +1729,156 @@ func TestEmailNodeExecutor_Execute_WithIntegrationOverride(t *testing.T) {
 	assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
 }
 
-func TestEmailNodeExecutor_Execute_IntegrationOverrideNotFound(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
-	mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
-	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
-	mockListRepo := mocks.NewMockListRepository(ctrl)
-	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
-	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
-
-	executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
-
+func TestEmailNodeExecutor_Execute_SenderRules(t *testing.T) {
+	euIntegrationID := "eu_integration"
 	workspace := createTestWorkspaceWithEmailProvider()
+	workspace.Integrations = append(workspace.Integrations, domain.Integration{
+		ID:   euIntegrationID,
+		Name: "EU Email Provider",
+		Type: domain.IntegrationTypeEmail,
+		EmailProvider: domain.EmailProvider{
+			Kind:               domain.EmailProviderKindSMTP,
+			RateLimitPerMinute: 30,
+			Senders: []domain.EmailSender{
+				{ID: "eu_default", Email: "hello@example.eu", Name: "EU Sender", IsDefault: true},
+				{ID: "eu_support", Email: "support@example.eu", Name: "EU Support"},
+			},
+			SMTP: &domain.SMTPSettings{Host: "smtp.example.eu", Port: 587, Username: "user", Password: "pass"},
+		},
+	})
+
+	countryLeaf := func(country string) map[string]interface{} {
+		return map[string]interface{}{
+			"kind": "leaf",
+			"leaf": map[string]interface{}{
+				"source": "contacts",
+				"contact": map[string]interface{}{
+					"filters": []interface{}{
+						map[string]interface{}{
+							"field_name":    "country",
+							"field_type":    "string",
+							"operator":      "equals",
+							"string_values": []interface{}{country},
+						},
+					},
+				},
+			},
+		}
+	}
+	nodeConfig := map[string]interface{}{
+		"template_id": "tpl123",
+		"sender_rules": []interface{}{
+			map[string]interface{}{
+				"integration_id": euIntegrationID,
+				"sender_id":      "eu_support",
+				"conditions": map[string]interface{}{
+					"kind": "branch",
+					"branch": map[string]interface{}{
+						"operator": "or",
+						"leaves":   []interface{}{countryLeaf("FR"), countryLeaf("DE")},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		contact       *domain.Contact
+		isEU          bool
+		integrationID string
+		fromAddress   string
+	}{
+		{
+			name:          "EU contact queues with the EU integration",
+			contact:       &domain.Contact{Email: "eu@example.com", Country: &domain.NullableString{String: "FR"}},
+			isEU:          true,
+			integrationID: euIntegrationID,
+			fromAddress:   "support@example.eu",
+		},
+		{
+			name:          "other contacts queue with the default integration",
+			contact:       &domain.Contact{Email: "us@example.com", Country: &domain.NullableString{String: "US"}},
+			isEU:          false,
+			integrationID: "integration123",
+			fromAddress:   "sender@example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+			mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+			mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+			mockListRepo := mocks.NewMockListRepository(ctrl)
+			mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+			mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+			executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
+
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(workspace, nil)
+			mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws1").Return(db, nil)
+			sqlMock.ExpectQuery(`SELECT EXISTS \(.*\(country = \$1\) OR \(country = \$2\).* AND email = \$3\)`).
+				WithArgs("FR", "DE", tt.contact.Email).
+				WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(tt.isEU))
+			mockTemplateRepo.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "tpl123", int64(0)).Return(createTestTemplate(), nil)
+
+			var queued *domain.EmailQueueEntry
+			mockEmailQueueRepo.EXPECT().
+				Enqueue(gomock.Any(), "ws1", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, entries []*domain.EmailQueueEntry) error {
+					queued = entries[0]
+					return nil
+				})
+
+			params := NodeExecutionParams{
+				WorkspaceID: "ws1",
+				Node: &domain.AutomationNode{
+					ID:     "email_node1",
+					Type:   domain.NodeTypeEmail,
+					Config: nodeConfig,
+				},
+				Contact:     &domain.ContactAutomation{ID: "ca1", ContactEmail: tt.contact.Email},
+				ContactData: tt.contact,
+				Automation:  &domain.Automation{ID: "auto1", Name: "Regional Welcome"},
+			}
+
+			_, err = executor.Execute(context.Background(), params)
+			require.NoError(t, err)
+			require.NotNil(t, queued)
+			assert.Equal(t, tt.integrationID, queued.IntegrationID)
+			assert.Equal(t, tt.fromAddress, queued.Payload.FromAddress)
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestEmailNodeExecutor_Execute_IntegrationOverrideNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+	mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockListRepo := mocks.NewMockListRepository(ctrl)
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+	executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
+
+	workspace := createTestWorkspaceWithEmailProvider()
+
+	mockWorkspaceRepo.EXPECT().
+		GetByID(gomock.Any(), "ws1").
+		Return(workspace, nil)
 
-	mockWorkspaceRepo.EXPECT().
-		GetByID(gomock.Any(), "ws1").
-		Return(workspace, nil)
-
 	params := NodeExecutionParams{
 		WorkspaceID: "ws1",
 		Node: &domain.AutomationNode{
@@ -1445,6 +2334,81 @@ func TestEmailNodeExecutor_Execute_MarketingEmail_UnsubscribedContact(t *testing
 	assert.Equal(t, "unsubscribed", result.Output["skip_reason"])
 }
 
+func TestTransactionalEmailNodeExecutor_NodeType(t *testing.T) {
+	executor := NewTransactionalEmailNodeExecutor(nil, nil, nil, nil, nil, "https://api.example.com", nil)
+	assert.Equal(t, domain.NodeTypeTransactionalEmail, executor.NodeType())
+}
+
+func TestTransactionalEmailNodeExecutor_Execute_MarketingOptedOutContact(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+	mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockListRepo := mocks.NewMockListRepository(ctrl)
+	// No subscription lookup: the contact's marketing opt-out does not apply
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+	executor := NewTransactionalEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
+
+	// Marketing and transactional sends go through separate integrations, each with its own rate limit
+	workspace := createTestWorkspaceWithEmailProvider()
+	transactionalIntegration := workspace.Integrations[0]
+	transactionalIntegration.ID = "transactional123"
+	transactionalIntegration.EmailProvider.RateLimitPerMinute = 600
+	workspace.Integrations = append(workspace.Integrations, transactionalIntegration)
+	workspace.Settings.TransactionalEmailProviderID = "transactional123"
+
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(workspace, nil)
+	mockTemplateRepo.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "tpl123", int64(0)).
+		Return(createTestTemplateWithCategory("marketing"), nil)
+	mockListRepo.EXPECT().GetListByID(gomock.Any(), "ws1", "list1").
+		Return(&domain.List{ID: "list1", Name: "Test List"}, nil)
+
+	var enqueued []*domain.EmailQueueEntry
+	mockEmailQueueRepo.EXPECT().Enqueue(gomock.Any(), "ws1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, entries []*domain.EmailQueueEntry) error {
+			enqueued = entries
+			return nil
+		})
+
+	params := NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:         "order_confirmation",
+			Type:       domain.NodeTypeTransactionalEmail,
+			NextNodeID: strPtr("next_node"),
+			Config:     map[string]interface{}{"template_id": "tpl123"},
+		},
+		Contact:     &domain.ContactAutomation{ID: "ca1", ContactEmail: "recipient@example.com"},
+		ContactData: &domain.Contact{Email: "recipient@example.com"},
+		Automation:  &domain.Automation{ID: "auto1", Name: "Test Automation", ListID: "list1"},
+	}
+
+	result, err := executor.Execute(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, "next_node", *result.NextNodeID)
+	assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+	assert.Equal(t, "transactional_email", result.Output["node_type"])
+	assert.Equal(t, true, result.Output["queued"])
+
+	require.Len(t, enqueued, 1)
+	entry := enqueued[0]
+	assert.Equal(t, "recipient@example.com", entry.ContactEmail)
+	assert.Equal(t, "transactional123", entry.IntegrationID)
+	assert.Equal(t, domain.EmailQueuePriorityTransactional, entry.Priority)
+	assert.Equal(t, 600, entry.Payload.RateLimitPerMinute)
+	assert.Empty(t, entry.Payload.EmailOptions.ListUnsubscribeURL)
+	// Still attributed to the automation in the queue and message history
+	assert.Equal(t, domain.EmailQueueSourceAutomation, entry.SourceType)
+	assert.Equal(t, "auto1", entry.SourceID)
+	// but sent as a transactional message, without bulk headers
+	assert.True(t, entry.Payload.Transactional)
+	assert.False(t, entry.IsMarketing())
+}
+
 func TestEmailNodeExecutor_Execute_MarketingEmail_BouncedContact(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1928,6 +2892,73 @@ func TestBranchNodeExecutor_Execute_FirstPathMatches(t *testing.T) {
 	assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
 }
 
+func TestBranchNodeExecutor_Execute_RendersPathName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), "ws1").
+		Return(db, nil)
+
+	mock.ExpectQuery("SELECT EXISTS").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	executor := NewBranchNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo)
+
+	params := NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:   "branch1",
+			Type: domain.NodeTypeBranch,
+			Config: map[string]interface{}{
+				"paths": []interface{}{
+					map[string]interface{}{
+						"id":           "p1",
+						"name":         "VIP {{ contact.first_name }}",
+						"next_node_id": "vip_node",
+						"conditions":   buildSimpleConditionMap(),
+					},
+				},
+			},
+		},
+		ContactData: &domain.Contact{
+			Email:     "test@example.com",
+			FirstName: &domain.NullableString{String: "John", IsNull: false},
+		},
+	}
+
+	result, err := executor.Execute(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	assert.Equal(t, "p1", result.Output["path_taken"])
+	assert.Equal(t, "VIP John", result.Output["path_name"])
+}
+
+func TestRenderPathLabel(t *testing.T) {
+	params := NodeExecutionParams{
+		Automation:  &domain.Automation{ID: "auto1", Name: "Welcome"},
+		ContactData: &domain.Contact{Email: "test@example.com"},
+	}
+
+	t.Run("plain name is returned unchanged", func(t *testing.T) {
+		assert.Equal(t, "Control", renderPathLabel("Control", params))
+	})
+
+	t.Run("renders automation and contact data", func(t *testing.T) {
+		assert.Equal(t, "Welcome - test@example.com", renderPathLabel("{{ automation_name }} - {{ contact.email }}", params))
+	})
+
+	t.Run("invalid liquid keeps the raw name", func(t *testing.T) {
+		assert.Equal(t, "Broken {{ contact.email", renderPathLabel("Broken {{ contact.email", params))
+	})
+}
+
 // buildSimpleConditionMap creates a simple condition map for testing branch/filter nodes
 // This matches the actual TreeNode schema used by the codebase
 func buildSimpleConditionMap() map[string]interface{} {
@@ -2281,7 +3312,7 @@ func TestFilterNodeExecutor_Execute_PassesFilter(t *testing.T) {
 	assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
 }
 
-func TestFilterNodeExecutor_Execute_FailsFilter(t *testing.T) {
+func TestBranchNodeExecutor_Execute_Verbose(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -2294,67 +3325,108 @@ func TestFilterNodeExecutor_Execute_FailsFilter(t *testing.T) {
 		GetConnection(gomock.Any(), "ws1").
 		Return(db, nil)
 
-	// Contact doesn't match filter
+	// US path doesn't match, FR path matches
 	mock.ExpectQuery("SELECT EXISTS").
 		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery("SELECT EXISTS").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
 
-	executor := NewFilterNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo)
+	countryCondition := func(country string) map[string]interface{} {
+		return map[string]interface{}{
+			"kind": "leaf",
+			"leaf": map[string]interface{}{
+				"source": "contacts",
+				"contact": map[string]interface{}{
+					"filters": []interface{}{
+						map[string]interface{}{
+							"field_name":    "country",
+							"field_type":    "string",
+							"operator":      "equals",
+							"string_values": []interface{}{country},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	executor := NewBranchNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo)
 
 	params := NodeExecutionParams{
 		WorkspaceID: "ws1",
 		Node: &domain.AutomationNode{
-			ID:   "filter1",
-			Type: domain.NodeTypeFilter,
+			ID:   "branch1",
+			Type: domain.NodeTypeBranch,
 			Config: map[string]interface{}{
-				"continue_node_id": "continue_node",
-				"exit_node_id":     "exit_node",
-				"conditions":       buildSimpleConditionMap(),
+				"paths": []interface{}{
+					map[string]interface{}{"id": "us", "name": "US", "next_node_id": "us_node", "conditions": countryCondition("US")},
+					map[string]interface{}{"id": "fr", "name": "France", "next_node_id": "fr_node", "conditions": countryCondition("FR")},
+					map[string]interface{}{"id": "other", "name": "Other", "next_node_id": "other_node"},
+				},
+				"default_path_id": "other",
+				"verbose":         true,
 			},
 		},
-		ContactData: &domain.Contact{Email: "other@example.com"},
+		ContactData: &domain.Contact{
+			Email:   "test@example.com",
+			Country: &domain.NullableString{String: "FR"},
+		},
 	}
 
 	result, err := executor.Execute(context.Background(), params)
 	require.NoError(t, err)
 	require.NotNil(t, result)
 
-	assert.Equal(t, "exit_node", *result.NextNodeID)
-	assert.Equal(t, false, result.Output["filter_passed"])
-	assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+	assert.Equal(t, "fr_node", *result.NextNodeID)
+	assert.Equal(t, "fr", result.Output["path_taken"])
+	assert.Equal(t, map[string]interface{}{"country": "FR"}, result.Output["evaluated_fields"])
+	assert.Equal(t, []map[string]interface{}{
+		{"path_id": "us", "matched": false},
+		{"path_id": "fr", "matched": true},
+	}, result.Output["path_results"])
 }
 
-func TestFilterNodeExecutor_Execute_DBConnectionError(t *testing.T) {
+func TestBranchNodeExecutor_Execute_NotVerbose(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
 	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
 	mockWorkspaceRepo.EXPECT().
 		GetConnection(gomock.Any(), "ws1").
-		Return(nil, errors.New("database connection error"))
+		Return(db, nil)
 
-	executor := NewFilterNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo)
+	mock.ExpectQuery("SELECT EXISTS").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	executor := NewBranchNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo)
 
 	params := NodeExecutionParams{
 		WorkspaceID: "ws1",
 		Node: &domain.AutomationNode{
-			ID:   "filter1",
-			Type: domain.NodeTypeFilter,
+			ID:   "branch1",
+			Type: domain.NodeTypeBranch,
 			Config: map[string]interface{}{
-				"continue_node_id": "continue_node",
-				"exit_node_id":     "exit_node",
-				"conditions":       buildSimpleConditionMap(),
+				"paths": []interface{}{
+					map[string]interface{}{"id": "p1", "name": "VIP Path", "next_node_id": "vip_node", "conditions": buildSimpleConditionMap()},
+				},
+				"default_path_id": "p1",
 			},
 		},
 		ContactData: &domain.Contact{Email: "test@example.com"},
 	}
 
 	result, err := executor.Execute(context.Background(), params)
-	assert.Error(t, err)
-	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "failed to get db connection")
+	require.NoError(t, err)
+
+	assert.NotContains(t, result.Output, "evaluated_fields")
+	assert.NotContains(t, result.Output, "path_results")
 }
 
-func TestFilterNodeExecutor_Execute_QueryError(t *testing.T) {
+func TestFilterNodeExecutor_Execute_Verbose(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -2367,9 +3439,8 @@ func TestFilterNodeExecutor_Execute_QueryError(t *testing.T) {
 		GetConnection(gomock.Any(), "ws1").
 		Return(db, nil)
 
-	// Query fails
 	mock.ExpectQuery("SELECT EXISTS").
-		WillReturnError(sql.ErrConnDone)
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
 
 	executor := NewFilterNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo)
 
@@ -2382,51 +3453,260 @@ func TestFilterNodeExecutor_Execute_QueryError(t *testing.T) {
 				"continue_node_id": "continue_node",
 				"exit_node_id":     "exit_node",
 				"conditions":       buildSimpleConditionMap(),
+				"verbose":          true,
 			},
 		},
-		ContactData: &domain.Contact{Email: "test@example.com"},
+		ContactData: &domain.Contact{Email: "other@example.com"},
 	}
 
 	result, err := executor.Execute(context.Background(), params)
-	assert.Error(t, err)
-	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "failed to evaluate filter")
+	require.NoError(t, err)
+
+	assert.Equal(t, "exit_node", *result.NextNodeID)
+	assert.Equal(t, false, result.Output["filter_passed"])
+	assert.Equal(t, map[string]interface{}{"email": "other@example.com"}, result.Output["evaluated_fields"])
 }
 
-func TestFilterNodeExecutor_NodeType(t *testing.T) {
+func TestFilterNodeExecutor_Execute_FailsFilter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
-	executor := NewFilterNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo)
-	assert.Equal(t, domain.NodeTypeFilter, executor.NodeType())
-}
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
 
-func TestAddToListNodeExecutor_Execute_Success(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), "ws1").
+		Return(db, nil)
 
-	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
-	mockContactListRepo.EXPECT().
-		AddContactToList(gomock.Any(), "ws1", gomock.Any()).
-		Return(nil)
+	// Contact doesn't match filter
+	mock.ExpectQuery("SELECT EXISTS").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
 
-	executor := NewAddToListNodeExecutor(mockContactListRepo)
+	executor := NewFilterNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo)
 
 	params := NodeExecutionParams{
 		WorkspaceID: "ws1",
 		Node: &domain.AutomationNode{
-			ID:         "add_to_list1",
-			Type:       domain.NodeTypeAddToList,
-			NextNodeID: strPtr("next_node"),
+			ID:   "filter1",
+			Type: domain.NodeTypeFilter,
 			Config: map[string]interface{}{
-				"list_id": "list123",
-				"status":  "active",
+				"continue_node_id": "continue_node",
+				"exit_node_id":     "exit_node",
+				"conditions":       buildSimpleConditionMap(),
 			},
 		},
-		Contact: &domain.ContactAutomation{
-			ID:           "ca1",
-			ContactEmail: "test@example.com",
+		ContactData: &domain.Contact{Email: "other@example.com"},
+	}
+
+	result, err := executor.Execute(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	assert.Equal(t, "exit_node", *result.NextNodeID)
+	assert.Equal(t, false, result.Output["filter_passed"])
+	assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+}
+
+func TestFilterNodeExecutor_Execute_DBConnectionError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), "ws1").
+		Return(nil, errors.New("database connection error"))
+
+	executor := NewFilterNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo)
+
+	params := NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:   "filter1",
+			Type: domain.NodeTypeFilter,
+			Config: map[string]interface{}{
+				"continue_node_id": "continue_node",
+				"exit_node_id":     "exit_node",
+				"conditions":       buildSimpleConditionMap(),
+			},
+		},
+		ContactData: &domain.Contact{Email: "test@example.com"},
+	}
+
+	result, err := executor.Execute(context.Background(), params)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to get db connection")
+}
+
+func TestFilterNodeExecutor_Execute_QueryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), "ws1").
+		Return(db, nil)
+
+	// Query fails
+	mock.ExpectQuery("SELECT EXISTS").
+		WillReturnError(sql.ErrConnDone)
+
+	executor := NewFilterNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo)
+
+	params := NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:   "filter1",
+			Type: domain.NodeTypeFilter,
+			Config: map[string]interface{}{
+				"continue_node_id": "continue_node",
+				"exit_node_id":     "exit_node",
+				"conditions":       buildSimpleConditionMap(),
+			},
+		},
+		ContactData: &domain.Contact{Email: "test@example.com"},
+	}
+
+	result, err := executor.Execute(context.Background(), params)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to evaluate filter")
+}
+
+func TestFilterNodeExecutor_NodeType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	executor := NewFilterNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo)
+	assert.Equal(t, domain.NodeTypeFilter, executor.NodeType())
+}
+
+func TestGoalNodeExecutor_Execute(t *testing.T) {
+	goalNode := func() *domain.AutomationNode {
+		next := "delay1"
+		return &domain.AutomationNode{
+			ID:         "goal1",
+			Type:       domain.NodeTypeGoal,
+			NextNodeID: &next,
+			Config: map[string]interface{}{
+				"conditions": buildSimpleConditionMap(),
+			},
+		}
+	}
+
+	t.Run("goal met exits the contact", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws1").Return(db, nil)
+		mock.ExpectQuery("SELECT EXISTS").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		executor := NewGoalNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo)
+		result, err := executor.Execute(context.Background(), NodeExecutionParams{
+			WorkspaceID: "ws1",
+			Node:        goalNode(),
+			ContactData: &domain.Contact{Email: "test@example.com"},
+		})
+		require.NoError(t, err)
+
+		assert.Nil(t, result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusExited, result.Status)
+		require.NotNil(t, result.ExitReason)
+		assert.Equal(t, domain.GoalExitReason, *result.ExitReason)
+		assert.Equal(t, "goal", result.Output["node_type"])
+		assert.Equal(t, true, result.Output["goal_met"])
+	})
+
+	t.Run("goal not met continues", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws1").Return(db, nil)
+		mock.ExpectQuery("SELECT EXISTS").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		executor := NewGoalNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo)
+		result, err := executor.Execute(context.Background(), NodeExecutionParams{
+			WorkspaceID: "ws1",
+			Node:        goalNode(),
+			ContactData: &domain.Contact{Email: "other@example.com"},
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "delay1", *result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Nil(t, result.ExitReason)
+		assert.Equal(t, false, result.Output["goal_met"])
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws1").Return(db, nil)
+		mock.ExpectQuery("SELECT EXISTS").WillReturnError(sql.ErrConnDone)
+
+		executor := NewGoalNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo)
+		result, err := executor.Execute(context.Background(), NodeExecutionParams{
+			WorkspaceID: "ws1",
+			Node:        goalNode(),
+			ContactData: &domain.Contact{Email: "test@example.com"},
+		})
+		assert.Nil(t, result)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to evaluate goal")
+	})
+}
+
+func TestAddToListNodeExecutor_Execute_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	mockContactListRepo.EXPECT().
+		AddContactToList(gomock.Any(), "ws1", gomock.Any()).
+		Return(nil)
+
+	executor := NewAddToListNodeExecutor(mockContactListRepo)
+
+	params := NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:         "add_to_list1",
+			Type:       domain.NodeTypeAddToList,
+			NextNodeID: strPtr("next_node"),
+			Config: map[string]interface{}{
+				"list_id": "list123",
+				"status":  "active",
+			},
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "test@example.com",
 		},
 	}
 
@@ -2511,6 +3791,96 @@ func TestAddToListNodeExecutor_Execute_InvalidConfig(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid add-to-list node config")
 }
 
+func TestAddToListNodeExecutor_Execute_TemplatedListID(t *testing.T) {
+	newParams := func(region string) NodeExecutionParams {
+		return NodeExecutionParams{
+			WorkspaceID: "ws1",
+			Node: &domain.AutomationNode{
+				ID:         "add_to_list1",
+				Type:       domain.NodeTypeAddToList,
+				NextNodeID: strPtr("next_node"),
+				Config: map[string]interface{}{
+					"list_id": "news_{{ global_feed.region }}",
+					"status":  "active",
+				},
+			},
+			Contact: &domain.ContactAutomation{
+				ID:           "ca1",
+				ContactEmail: "test@example.com",
+				Context: map[string]interface{}{
+					domain.ContactAutomationContextGlobalFeed: map[string]interface{}{"region": region},
+				},
+			},
+		}
+	}
+
+	t.Run("adds the contact to the list resolved from context", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockListRepo := mocks.NewMockListRepository(ctrl)
+		mockListRepo.EXPECT().
+			GetListByID(gomock.Any(), "ws1", "news_eu").
+			Return(&domain.List{ID: "news_eu"}, nil)
+
+		mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+		mockContactListRepo.EXPECT().
+			AddContactToList(gomock.Any(), "ws1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, contactList *domain.ContactList) error {
+				assert.Equal(t, "news_eu", contactList.ListID)
+				assert.Equal(t, "test@example.com", contactList.Email)
+				return nil
+			})
+
+		executor := NewAddToListNodeExecutor(mockContactListRepo)
+		executor.SetListRepository(mockListRepo)
+
+		result, err := executor.Execute(context.Background(), newParams("eu"))
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		assert.Equal(t, "next_node", *result.NextNodeID)
+		assert.Equal(t, "news_eu", result.Output["list_id"])
+	})
+
+	t.Run("fails when the resolved list does not exist", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockListRepo := mocks.NewMockListRepository(ctrl)
+		mockListRepo.EXPECT().
+			GetListByID(gomock.Any(), "ws1", "news_mars").
+			Return(nil, &domain.ErrListNotFound{Message: "list not found"})
+
+		// The contact must not be added to an unknown list
+		mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+
+		executor := NewAddToListNodeExecutor(mockContactListRepo)
+		executor.SetListRepository(mockListRepo)
+
+		result, err := executor.Execute(context.Background(), newParams("mars"))
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "unknown list news_mars")
+	})
+
+	t.Run("fails when the template resolves to nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+		executor := NewAddToListNodeExecutor(mockContactListRepo)
+
+		params := newParams("")
+		params.Node.Config["list_id"] = "{{ global_feed.region }}"
+
+		result, err := executor.Execute(context.Background(), params)
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "empty list id")
+	})
+}
+
 func TestAddToListNodeExecutor_NodeType(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -2634,66 +4004,480 @@ func TestRemoveFromListNodeExecutor_NodeType(t *testing.T) {
 	assert.Equal(t, domain.NodeTypeRemoveFromList, executor.NodeType())
 }
 
-// ListStatusBranchNodeExecutor tests
+// UnsubscribeAllNodeExecutor tests
 
-func TestListStatusBranchNodeExecutor_NodeType(t *testing.T) {
+func TestUnsubscribeAllNodeExecutor_NodeType(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
-	executor := NewListStatusBranchNodeExecutor(mockContactListRepo)
-	assert.Equal(t, domain.NodeTypeListStatusBranch, executor.NodeType())
+	executor := NewUnsubscribeAllNodeExecutor(mockContactListRepo)
+	assert.Equal(t, domain.NodeTypeUnsubscribeAll, executor.NodeType())
 }
 
-func TestParseListStatusBranchNodeConfig(t *testing.T) {
-	t.Run("valid config", func(t *testing.T) {
-		config := map[string]interface{}{
-			"list_id":             "list123",
-			"not_in_list_node_id": "node1",
-			"active_node_id":      "node2",
-			"non_active_node_id":  "node3",
-		}
+func unsubscribeAllParams(status string) NodeExecutionParams {
+	return NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:         "unsubscribe_all1",
+			Type:       domain.NodeTypeUnsubscribeAll,
+			NextNodeID: strPtr("next_node"),
+			Config: map[string]interface{}{
+				"status": status,
+			},
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "test@example.com",
+		},
+	}
+}
 
-		c, err := parseListStatusBranchNodeConfig(config)
-		require.NoError(t, err)
-		assert.Equal(t, "list123", c.ListID)
-		assert.Equal(t, "node1", c.NotInListNodeID)
-		assert.Equal(t, "node2", c.ActiveNodeID)
-		assert.Equal(t, "node3", c.NonActiveNodeID)
-	})
+func TestUnsubscribeAllNodeExecutor_Execute_Unsubscribed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-	t.Run("missing list_id", func(t *testing.T) {
-		config := map[string]interface{}{
-			"not_in_list_node_id": "node1",
-		}
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	mockContactListRepo.EXPECT().
+		GetListsByEmail(gomock.Any(), "ws1", "test@example.com").
+		Return([]*domain.ContactList{
+			{Email: "test@example.com", ListID: "newsletter", Status: domain.ContactListStatusActive},
+			{Email: "test@example.com", ListID: "beta", Status: domain.ContactListStatusPending},
+			{Email: "test@example.com", ListID: "old", Status: domain.ContactListStatusUnsubscribed},
+			{Email: "test@example.com", ListID: "bounced", Status: domain.ContactListStatusBounced},
+		}, nil)
+	mockContactListRepo.EXPECT().
+		UpdateContactListStatus(gomock.Any(), "ws1", "test@example.com", "newsletter", domain.ContactListStatusUnsubscribed).
+		Return(nil)
+	mockContactListRepo.EXPECT().
+		UpdateContactListStatus(gomock.Any(), "ws1", "test@example.com", "beta", domain.ContactListStatusUnsubscribed).
+		Return(nil)
 
-		_, err := parseListStatusBranchNodeConfig(config)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "list_id is required")
-	})
+	executor := NewUnsubscribeAllNodeExecutor(mockContactListRepo)
 
-	t.Run("no branch targets", func(t *testing.T) {
-		config := map[string]interface{}{
-			"list_id": "list123",
-		}
+	result, err := executor.Execute(context.Background(), unsubscribeAllParams("unsubscribed"))
+	require.NoError(t, err)
+	require.NotNil(t, result)
 
-		_, err := parseListStatusBranchNodeConfig(config)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "at least one branch must have a target node")
-	})
+	assert.Equal(t, "next_node", *result.NextNodeID)
+	assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+	assert.Equal(t, "unsubscribe_all", result.Output["node_type"])
+	assert.Equal(t, "unsubscribed", result.Output["status"])
+	assert.Equal(t, []string{"newsletter", "beta"}, result.Output["list_ids"])
+	assert.NotContains(t, result.Output, "errors")
 }
 
-func TestListStatusBranchNodeExecutor_Execute_NotInList(t *testing.T) {
+func TestUnsubscribeAllNodeExecutor_Execute_Removed(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
 	mockContactListRepo.EXPECT().
-		GetContactListByIDs(gomock.Any(), "ws1", "test@example.com", "list123").
-		Return(nil, &domain.ErrContactListNotFound{Message: "not found"})
-
-	executor := NewListStatusBranchNodeExecutor(mockContactListRepo)
-
+		GetListsByEmail(gomock.Any(), "ws1", "test@example.com").
+		Return([]*domain.ContactList{
+			{Email: "test@example.com", ListID: "newsletter", Status: domain.ContactListStatusActive},
+			{Email: "test@example.com", ListID: "product", Status: domain.ContactListStatusActive},
+		}, nil)
+	mockContactListRepo.EXPECT().
+		RemoveContactFromList(gomock.Any(), "ws1", "test@example.com", "newsletter").
+		Return(nil)
+	mockContactListRepo.EXPECT().
+		RemoveContactFromList(gomock.Any(), "ws1", "test@example.com", "product").
+		Return(errors.New("db error"))
+
+	executor := NewUnsubscribeAllNodeExecutor(mockContactListRepo)
+
+	result, err := executor.Execute(context.Background(), unsubscribeAllParams("removed"))
+	require.NoError(t, err) // Per-list errors don't fail the node
+	require.NotNil(t, result)
+
+	assert.Equal(t, "next_node", *result.NextNodeID)
+	assert.Equal(t, []string{"newsletter"}, result.Output["list_ids"])
+	assert.Equal(t, map[string]interface{}{"product": "db error"}, result.Output["errors"])
+}
+
+func TestUnsubscribeAllNodeExecutor_Execute_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	mockContactListRepo.EXPECT().
+		GetListsByEmail(gomock.Any(), "ws1", "test@example.com").
+		Return(nil, errors.New("connection refused"))
+
+	executor := NewUnsubscribeAllNodeExecutor(mockContactListRepo)
+
+	result, err := executor.Execute(context.Background(), unsubscribeAllParams("unsubscribed"))
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to get contact lists")
+}
+
+func TestUnsubscribeAllNodeExecutor_Execute_InvalidConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	executor := NewUnsubscribeAllNodeExecutor(mockContactListRepo)
+
+	result, err := executor.Execute(context.Background(), unsubscribeAllParams("active"))
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "invalid unsubscribe-all node config")
+}
+
+// EnrollInAutomationNodeExecutor tests
+
+func TestEnrollInAutomationNodeExecutor_NodeType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	executor := NewEnrollInAutomationNodeExecutor(mockAutomationRepo)
+	assert.Equal(t, domain.NodeTypeEnrollInAutomation, executor.NodeType())
+}
+
+func enrollInAutomationParams(targetID string) NodeExecutionParams {
+	return NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Automation:  &domain.Automation{ID: "auto_a"},
+		Node: &domain.AutomationNode{
+			ID:         "enroll1",
+			Type:       domain.NodeTypeEnrollInAutomation,
+			NextNodeID: strPtr("next_node"),
+			Config: map[string]interface{}{
+				"automation_id": targetID,
+			},
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "test@example.com",
+		},
+	}
+}
+
+func TestEnrollInAutomationNodeExecutor_Execute(t *testing.T) {
+	enrollNode := func(targetID string) *domain.AutomationNode {
+		return &domain.AutomationNode{
+			ID:     "enroll_" + targetID,
+			Type:   domain.NodeTypeEnrollInAutomation,
+			Config: map[string]interface{}{"automation_id": targetID},
+		}
+	}
+
+	t.Run("enrolls the contact and continues", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		target := &domain.Automation{ID: "auto_b", Status: domain.AutomationStatusLive}
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		mockAutomationRepo.EXPECT().GetByID(gomock.Any(), "ws1", "auto_b").Return(target, nil)
+		mockAutomationRepo.EXPECT().EnrollContact(gomock.Any(), "ws1", target, "test@example.com").Return(true, nil)
+
+		result, err := NewEnrollInAutomationNodeExecutor(mockAutomationRepo).Execute(context.Background(), enrollInAutomationParams("auto_b"))
+		require.NoError(t, err)
+		assert.Equal(t, "next_node", *result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "enroll_in_automation", result.Output["node_type"])
+		assert.Equal(t, "auto_b", result.Output["automation_id"])
+		assert.Equal(t, true, result.Output["enrolled"])
+	})
+
+	t.Run("skips when already enrolled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		target := &domain.Automation{ID: "auto_b", Status: domain.AutomationStatusLive}
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		mockAutomationRepo.EXPECT().GetByID(gomock.Any(), "ws1", "auto_b").Return(target, nil)
+		mockAutomationRepo.EXPECT().EnrollContact(gomock.Any(), "ws1", target, "test@example.com").Return(false, nil)
+
+		result, err := NewEnrollInAutomationNodeExecutor(mockAutomationRepo).Execute(context.Background(), enrollInAutomationParams("auto_b"))
+		require.NoError(t, err)
+		assert.Equal(t, "next_node", *result.NextNodeID)
+		assert.Equal(t, false, result.Output["enrolled"])
+		assert.Equal(t, "already_enrolled", result.Output["reason"])
+	})
+
+	t.Run("skips self-enrollment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+
+		result, err := NewEnrollInAutomationNodeExecutor(mockAutomationRepo).Execute(context.Background(), enrollInAutomationParams("auto_a"))
+		require.NoError(t, err)
+		assert.Equal(t, "next_node", *result.NextNodeID)
+		assert.Equal(t, "self_enrollment", result.Output["reason"])
+	})
+
+	t.Run("skips a target that is not live", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		mockAutomationRepo.EXPECT().GetByID(gomock.Any(), "ws1", "auto_b").
+			Return(&domain.Automation{ID: "auto_b", Status: domain.AutomationStatusPaused}, nil)
+
+		result, err := NewEnrollInAutomationNodeExecutor(mockAutomationRepo).Execute(context.Background(), enrollInAutomationParams("auto_b"))
+		require.NoError(t, err)
+		assert.Equal(t, "automation_not_live", result.Output["reason"])
+	})
+
+	t.Run("skips a chain leading back to the current automation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// auto_b enrolls into auto_c, which enrolls back into auto_a
+		autoB := &domain.Automation{ID: "auto_b", Status: domain.AutomationStatusLive, Nodes: []*domain.AutomationNode{enrollNode("auto_c")}}
+		autoC := &domain.Automation{ID: "auto_c", Status: domain.AutomationStatusLive, Nodes: []*domain.AutomationNode{enrollNode("auto_a")}}
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		mockAutomationRepo.EXPECT().GetByID(gomock.Any(), "ws1", "auto_b").Return(autoB, nil)
+		mockAutomationRepo.EXPECT().GetByID(gomock.Any(), "ws1", "auto_c").Return(autoC, nil)
+
+		result, err := NewEnrollInAutomationNodeExecutor(mockAutomationRepo).Execute(context.Background(), enrollInAutomationParams("auto_b"))
+		require.NoError(t, err)
+		assert.Equal(t, "next_node", *result.NextNodeID)
+		assert.Equal(t, "enrollment_loop", result.Output["reason"])
+	})
+
+	t.Run("target not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		mockAutomationRepo.EXPECT().GetByID(gomock.Any(), "ws1", "auto_b").Return(nil, errors.New("automation not found"))
+
+		result, err := NewEnrollInAutomationNodeExecutor(mockAutomationRepo).Execute(context.Background(), enrollInAutomationParams("auto_b"))
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to get target automation")
+	})
+
+	t.Run("missing automation_id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+
+		_, err := NewEnrollInAutomationNodeExecutor(mockAutomationRepo).Execute(context.Background(), enrollInAutomationParams(""))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "automation_id is required")
+	})
+}
+
+// ListStatusBranchNodeExecutor tests
+
+// RecordRevenueNodeExecutor tests
+
+func TestRecordRevenueNodeExecutor_NodeType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	executor := NewRecordRevenueNodeExecutor(mockAutomationRepo)
+	assert.Equal(t, domain.NodeTypeRecordRevenue, executor.NodeType())
+}
+
+func recordRevenueParams(config map[string]interface{}, contact *domain.Contact) NodeExecutionParams {
+	return NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Automation:  &domain.Automation{ID: "auto1"},
+		Node: &domain.AutomationNode{
+			ID:         "revenue1",
+			Type:       domain.NodeTypeRecordRevenue,
+			NextNodeID: strPtr("next_node"),
+			Config:     config,
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "test@example.com",
+		},
+		ContactData: contact,
+	}
+}
+
+func TestRecordRevenueNodeExecutor_Execute(t *testing.T) {
+	contact := &domain.Contact{
+		Email:         "test@example.com",
+		CustomNumber1: &domain.NullableFloat64{Float64: 120.5},
+		CustomJSON1: &domain.NullableJSON{Data: map[string]interface{}{
+			"order": map[string]interface{}{"total": 42.0, "id": "order_1"},
+		}},
+	}
+
+	expectRecord := func(mockAutomationRepo *mocks.MockAutomationRepository, amount float64) {
+		mockAutomationRepo.EXPECT().RecordRevenue(gomock.Any(), "ws1", gomock.AssignableToTypeOf(&domain.AutomationRevenue{})).
+			DoAndReturn(func(_ context.Context, _ string, revenue *domain.AutomationRevenue) error {
+				assert.NotEmpty(t, revenue.ID)
+				assert.Equal(t, "auto1", revenue.AutomationID)
+				assert.Equal(t, "ca1", revenue.ContactAutomationID)
+				assert.Equal(t, "revenue1", revenue.NodeID)
+				assert.Equal(t, "test@example.com", revenue.ContactEmail)
+				assert.Equal(t, amount, revenue.Amount)
+				assert.Equal(t, "EUR", revenue.Currency)
+				return nil
+			})
+	}
+
+	t.Run("records a literal amount", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		expectRecord(mockAutomationRepo, 19.99)
+
+		params := recordRevenueParams(map[string]interface{}{"amount": 19.99, "currency": "EUR"}, contact)
+		result, err := NewRecordRevenueNodeExecutor(mockAutomationRepo).Execute(context.Background(), params)
+		require.NoError(t, err)
+		assert.Equal(t, "next_node", *result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "record_revenue", result.Output["node_type"])
+		assert.Equal(t, 19.99, result.Output["amount"])
+		assert.Equal(t, true, result.Output["recorded"])
+	})
+
+	t.Run("records the amount of a custom number field", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		expectRecord(mockAutomationRepo, 120.5)
+
+		params := recordRevenueParams(map[string]interface{}{"amount_field": "custom_number_1", "currency": "EUR"}, contact)
+		result, err := NewRecordRevenueNodeExecutor(mockAutomationRepo).Execute(context.Background(), params)
+		require.NoError(t, err)
+		assert.Equal(t, true, result.Output["recorded"])
+	})
+
+	t.Run("records the amount at a custom JSON path", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		expectRecord(mockAutomationRepo, 42.0)
+
+		params := recordRevenueParams(map[string]interface{}{"amount_field": "custom_json_1.order.total", "currency": "EUR"}, contact)
+		result, err := NewRecordRevenueNodeExecutor(mockAutomationRepo).Execute(context.Background(), params)
+		require.NoError(t, err)
+		assert.Equal(t, true, result.Output["recorded"])
+	})
+
+	t.Run("skips when the field holds no number", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+
+		for _, field := range []string{"custom_number_2", "custom_json_1.order.id", "custom_json_1.order.total.value"} {
+			params := recordRevenueParams(map[string]interface{}{"amount_field": field, "currency": "EUR"}, contact)
+			result, err := NewRecordRevenueNodeExecutor(mockAutomationRepo).Execute(context.Background(), params)
+			require.NoError(t, err, field)
+			assert.Equal(t, "next_node", *result.NextNodeID)
+			assert.Equal(t, false, result.Output["recorded"], field)
+			assert.Equal(t, "amount_not_found", result.Output["reason"], field)
+		}
+	})
+
+	t.Run("skips a negative field amount", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		refund := &domain.Contact{Email: "test@example.com", CustomNumber1: &domain.NullableFloat64{Float64: -10}}
+
+		params := recordRevenueParams(map[string]interface{}{"amount_field": "custom_number_1", "currency": "EUR"}, refund)
+		result, err := NewRecordRevenueNodeExecutor(mockAutomationRepo).Execute(context.Background(), params)
+		require.NoError(t, err)
+		assert.Equal(t, "negative_amount", result.Output["reason"])
+	})
+
+	t.Run("returns repository errors", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		mockAutomationRepo.EXPECT().RecordRevenue(gomock.Any(), "ws1", gomock.Any()).Return(errors.New("db error"))
+
+		params := recordRevenueParams(map[string]interface{}{"amount": 10.0, "currency": "EUR"}, contact)
+		result, err := NewRecordRevenueNodeExecutor(mockAutomationRepo).Execute(context.Background(), params)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to record revenue")
+	})
+
+	t.Run("rejects invalid config", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+
+		params := recordRevenueParams(map[string]interface{}{"amount": 10.0}, contact)
+		result, err := NewRecordRevenueNodeExecutor(mockAutomationRepo).Execute(context.Background(), params)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid record-revenue node config")
+	})
+}
+
+func TestListStatusBranchNodeExecutor_NodeType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	executor := NewListStatusBranchNodeExecutor(mockContactListRepo)
+	assert.Equal(t, domain.NodeTypeListStatusBranch, executor.NodeType())
+}
+
+func TestParseListStatusBranchNodeConfig(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		config := map[string]interface{}{
+			"list_id":             "list123",
+			"not_in_list_node_id": "node1",
+			"active_node_id":      "node2",
+			"non_active_node_id":  "node3",
+		}
+
+		c, err := parseListStatusBranchNodeConfig(config)
+		require.NoError(t, err)
+		assert.Equal(t, "list123", c.ListID)
+		assert.Equal(t, "node1", c.NotInListNodeID)
+		assert.Equal(t, "node2", c.ActiveNodeID)
+		assert.Equal(t, "node3", c.NonActiveNodeID)
+	})
+
+	t.Run("missing list_id", func(t *testing.T) {
+		config := map[string]interface{}{
+			"not_in_list_node_id": "node1",
+		}
+
+		_, err := parseListStatusBranchNodeConfig(config)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "list_id is required")
+	})
+
+	t.Run("no branch targets", func(t *testing.T) {
+		config := map[string]interface{}{
+			"list_id": "list123",
+		}
+
+		_, err := parseListStatusBranchNodeConfig(config)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "at least one branch must have a target node")
+	})
+}
+
+func TestListStatusBranchNodeExecutor_Execute_NotInList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	mockContactListRepo.EXPECT().
+		GetContactListByIDs(gomock.Any(), "ws1", "test@example.com", "list123").
+		Return(nil, &domain.ErrContactListNotFound{Message: "not found"})
+
+	executor := NewListStatusBranchNodeExecutor(mockContactListRepo)
+
 	params := NodeExecutionParams{
 		WorkspaceID: "ws1",
 		Node: &domain.AutomationNode{
@@ -2923,47 +4707,738 @@ func TestListStatusBranchNodeExecutor_Execute_EmptyBranchCompletes(t *testing.T)
 	assert.Equal(t, "not_in_list", result.Output["branch_taken"])
 }
 
-// ABTestNodeExecutor tests
+// SuppressionBranchNodeExecutor tests
 
-func TestABTestNodeExecutor_NodeType(t *testing.T) {
-	executor := NewABTestNodeExecutor()
-	assert.Equal(t, domain.NodeTypeABTest, executor.NodeType())
+func suppressionBranchParams(email string, config map[string]interface{}) NodeExecutionParams {
+	return NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:     "suppression_branch1",
+			Type:   domain.NodeTypeSuppressionBranch,
+			Config: config,
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca_" + email,
+			ContactEmail: email,
+		},
+	}
 }
 
-func TestParseABTestNodeConfig(t *testing.T) {
-	t.Run("valid config", func(t *testing.T) {
-		config := map[string]interface{}{
-			"variants": []map[string]interface{}{
-				{
-					"id":           "A",
-					"name":         "Control",
-					"weight":       50,
-					"next_node_id": "node_a",
-				},
-				{
-					"id":           "B",
-					"name":         "Variant B",
-					"weight":       50,
-					"next_node_id": "node_b",
-				},
-			},
-		}
+func TestSuppressionBranchNodeExecutor_NodeType(t *testing.T) {
+	executor := NewSuppressionBranchNodeExecutor(nil)
+	assert.Equal(t, domain.NodeTypeSuppressionBranch, executor.NodeType())
+}
 
-		c, err := parseABTestNodeConfig(config)
-		require.NoError(t, err)
-		assert.Len(t, c.Variants, 2)
-		assert.Equal(t, "A", c.Variants[0].ID)
-		assert.Equal(t, "Control", c.Variants[0].Name)
-		assert.Equal(t, 50, c.Variants[0].Weight)
-		assert.Equal(t, "node_a", c.Variants[0].NextNodeID)
-	})
+func TestSuppressionBranchNodeExecutor_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-	t.Run("invalid config - less than 2 variants", func(t *testing.T) {
-		config := map[string]interface{}{
-			"variants": []map[string]interface{}{
-				{
-					"id":           "A",
-					"name":         "Control",
+	mockSuppressionRepo := mocks.NewMockSuppressionRepository(ctrl)
+	mockSuppressionRepo.EXPECT().IsSuppressed(gomock.Any(), "ws1", "bounced@example.com").Return(true, nil)
+	mockSuppressionRepo.EXPECT().IsSuppressed(gomock.Any(), "ws1", "clean@example.com").Return(false, nil)
+
+	executor := NewSuppressionBranchNodeExecutor(mockSuppressionRepo)
+	config := map[string]interface{}{
+		"suppressed_node_id":     "node_suppressed",
+		"not_suppressed_node_id": "node_not_suppressed",
+	}
+
+	result, err := executor.Execute(context.Background(), suppressionBranchParams("bounced@example.com", config))
+	require.NoError(t, err)
+	require.NotNil(t, result.NextNodeID)
+	assert.Equal(t, "node_suppressed", *result.NextNodeID)
+	assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+	assert.Equal(t, "suppression_branch", result.Output["node_type"])
+	assert.Equal(t, "suppressed", result.Output["branch_taken"])
+	assert.Equal(t, true, result.Output["suppressed"])
+
+	result, err = executor.Execute(context.Background(), suppressionBranchParams("clean@example.com", config))
+	require.NoError(t, err)
+	require.NotNil(t, result.NextNodeID)
+	assert.Equal(t, "node_not_suppressed", *result.NextNodeID)
+	assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+	assert.Equal(t, "not_suppressed", result.Output["branch_taken"])
+	assert.Equal(t, false, result.Output["suppressed"])
+}
+
+func TestSuppressionBranchNodeExecutor_Execute_EmptyBranchCompletes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSuppressionRepo := mocks.NewMockSuppressionRepository(ctrl)
+	mockSuppressionRepo.EXPECT().IsSuppressed(gomock.Any(), "ws1", "bounced@example.com").Return(true, nil)
+
+	executor := NewSuppressionBranchNodeExecutor(mockSuppressionRepo)
+
+	result, err := executor.Execute(context.Background(), suppressionBranchParams("bounced@example.com", map[string]interface{}{
+		"not_suppressed_node_id": "node_not_suppressed",
+	}))
+	require.NoError(t, err)
+	assert.Nil(t, result.NextNodeID)
+	assert.Equal(t, domain.ContactAutomationStatusCompleted, result.Status)
+	assert.Equal(t, "suppressed", result.Output["branch_taken"])
+}
+
+func TestSuppressionBranchNodeExecutor_Execute_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSuppressionRepo := mocks.NewMockSuppressionRepository(ctrl)
+	executor := NewSuppressionBranchNodeExecutor(mockSuppressionRepo)
+
+	t.Run("invalid config", func(t *testing.T) {
+		result, err := executor.Execute(context.Background(), suppressionBranchParams("a@example.com", map[string]interface{}{}))
+		assert.Nil(t, result)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid suppression_branch node config")
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockSuppressionRepo.EXPECT().IsSuppressed(gomock.Any(), "ws1", "a@example.com").Return(false, errors.New("db error"))
+
+		result, err := executor.Execute(context.Background(), suppressionBranchParams("a@example.com", map[string]interface{}{
+			"suppressed_node_id": "node_suppressed",
+		}))
+		assert.Nil(t, result)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to check suppression status")
+	})
+}
+
+func expressionBranchParams(contactContext map[string]interface{}, config map[string]interface{}) NodeExecutionParams {
+	return NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:     "expr1",
+			Type:   domain.NodeTypeExpressionBranch,
+			Config: config,
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "test@example.com",
+			Context:      contactContext,
+		},
+		ContactData: &domain.Contact{Email: "test@example.com"},
+	}
+}
+
+func TestExpressionBranchNodeExecutor_NodeType(t *testing.T) {
+	executor := NewExpressionBranchNodeExecutor()
+	assert.Equal(t, domain.NodeTypeExpressionBranch, executor.NodeType())
+}
+
+func TestExpressionBranchNodeExecutor_Execute(t *testing.T) {
+	executor := NewExpressionBranchNodeExecutor()
+	config := map[string]interface{}{
+		"expression":    "{{ event.amount > 100 }}",
+		"true_node_id":  "node_high_value",
+		"false_node_id": "node_regular",
+	}
+
+	t.Run("high value event takes the true path", func(t *testing.T) {
+		params := expressionBranchParams(map[string]interface{}{
+			"event": map[string]interface{}{"amount": 250.0},
+		}, config)
+
+		result, err := executor.Execute(context.Background(), params)
+		require.NoError(t, err)
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "node_high_value", *result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "expression_branch", result.Output["node_type"])
+		assert.Equal(t, "true", result.Output["branch_taken"])
+		assert.Equal(t, true, result.Output["result"])
+	})
+
+	t.Run("low value event takes the false path", func(t *testing.T) {
+		params := expressionBranchParams(map[string]interface{}{
+			"event": map[string]interface{}{"amount": 40.0},
+		}, config)
+
+		result, err := executor.Execute(context.Background(), params)
+		require.NoError(t, err)
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "node_regular", *result.NextNodeID)
+		assert.Equal(t, "false", result.Output["branch_taken"])
+	})
+
+	t.Run("missing variable is false", func(t *testing.T) {
+		result, err := executor.Execute(context.Background(), expressionBranchParams(nil, config))
+		require.NoError(t, err)
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "node_regular", *result.NextNodeID)
+	})
+
+	t.Run("tag markup rendering true", func(t *testing.T) {
+		params := expressionBranchParams(nil, map[string]interface{}{
+			"expression":   `{% if contact.email contains "@example.com" %}true{% endif %}`,
+			"true_node_id": "node_internal",
+		})
+
+		result, err := executor.Execute(context.Background(), params)
+		require.NoError(t, err)
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "node_internal", *result.NextNodeID)
+	})
+
+	t.Run("empty branch completes", func(t *testing.T) {
+		params := expressionBranchParams(map[string]interface{}{
+			"event": map[string]interface{}{"amount": 40.0},
+		}, map[string]interface{}{
+			"expression":   "{{ event.amount > 100 }}",
+			"true_node_id": "node_high_value",
+		})
+
+		result, err := executor.Execute(context.Background(), params)
+		require.NoError(t, err)
+		assert.Nil(t, result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusCompleted, result.Status)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		result, err := executor.Execute(context.Background(), expressionBranchParams(nil, map[string]interface{}{
+			"true_node_id": "node_high_value",
+		}))
+		assert.Nil(t, result)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid expression_branch node config")
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		result, err := executor.Execute(context.Background(), expressionBranchParams(nil, map[string]interface{}{
+			"expression":   "{% if event.amount > %}true",
+			"true_node_id": "node_high_value",
+		}))
+		assert.Nil(t, result)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to evaluate expression")
+	})
+}
+
+func updateContactParams(fields map[string]interface{}) NodeExecutionParams {
+	return NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:         "update1",
+			Type:       domain.NodeTypeUpdateContact,
+			Config:     map[string]interface{}{"fields": fields},
+			NextNodeID: strPtr("node_next"),
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "test@example.com",
+			Context: map[string]interface{}{
+				"event": map[string]interface{}{"plan": "pro", "seats": 12},
+			},
+		},
+		ContactData: &domain.Contact{
+			Email:    "test@example.com",
+			JobTitle: &domain.NullableString{String: "Engineer"},
+		},
+	}
+}
+
+func TestUpdateContactNodeExecutor_NodeType(t *testing.T) {
+	executor := NewUpdateContactNodeExecutor(nil)
+	assert.Equal(t, domain.NodeTypeUpdateContact, executor.NodeType())
+}
+
+func TestUpdateContactNodeExecutor_Execute(t *testing.T) {
+	t.Run("renders liquid and advances", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockContactRepo := mocks.NewMockContactRepository(ctrl)
+		mockContactRepo.EXPECT().
+			UpsertContact(gomock.Any(), "ws1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, contact *domain.Contact) (bool, error) {
+				assert.Equal(t, "test@example.com", contact.Email)
+				require.NotNil(t, contact.CustomString1)
+				assert.Equal(t, "pro", contact.CustomString1.String)
+				require.NotNil(t, contact.CustomNumber1)
+				assert.Equal(t, 12.0, contact.CustomNumber1.Float64)
+				assert.Nil(t, contact.FirstName)
+				return false, nil
+			})
+
+		executor := NewUpdateContactNodeExecutor(mockContactRepo)
+		params := updateContactParams(map[string]interface{}{
+			"custom_string_1": "{{ event.plan }}",
+			"custom_number_1": "{{ event.seats }}",
+		})
+
+		result, err := executor.Execute(context.Background(), params)
+		require.NoError(t, err)
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "node_next", *result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "update_contact", result.Output["node_type"])
+		assert.Equal(t, map[string]interface{}{"custom_string_1": "pro", "custom_number_1": 12.0}, result.Output["fields"])
+		require.NotNil(t, params.ContactData.CustomString1)
+		assert.Equal(t, "pro", params.ContactData.CustomString1.String)
+	})
+
+	t.Run("explicit null clears the field", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockContactRepo := mocks.NewMockContactRepository(ctrl)
+		mockContactRepo.EXPECT().
+			UpsertContact(gomock.Any(), "ws1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, contact *domain.Contact) (bool, error) {
+				require.NotNil(t, contact.JobTitle)
+				assert.True(t, contact.JobTitle.IsNull)
+				return false, nil
+			})
+
+		executor := NewUpdateContactNodeExecutor(mockContactRepo)
+		params := updateContactParams(map[string]interface{}{"job_title": nil})
+
+		result, err := executor.Execute(context.Background(), params)
+		require.NoError(t, err)
+		require.NotNil(t, result.NextNodeID)
+		assert.True(t, params.ContactData.JobTitle.IsNull)
+	})
+
+	t.Run("non numeric value for number field", func(t *testing.T) {
+		executor := NewUpdateContactNodeExecutor(nil)
+
+		result, err := executor.Execute(context.Background(), updateContactParams(map[string]interface{}{
+			"custom_number_1": "{{ event.plan }}",
+		}))
+		assert.Nil(t, result)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "custom_number_1 must be a number")
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockContactRepo := mocks.NewMockContactRepository(ctrl)
+		mockContactRepo.EXPECT().
+			UpsertContact(gomock.Any(), "ws1", gomock.Any()).
+			Return(false, errors.New("db error"))
+
+		executor := NewUpdateContactNodeExecutor(mockContactRepo)
+
+		result, err := executor.Execute(context.Background(), updateContactParams(map[string]interface{}{
+			"first_name": "Jane",
+		}))
+		assert.Nil(t, result)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to update contact")
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		executor := NewUpdateContactNodeExecutor(nil)
+
+		result, err := executor.Execute(context.Background(), updateContactParams(map[string]interface{}{}))
+		assert.Nil(t, result)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid update_contact node config")
+	})
+}
+
+// ABTestNodeExecutor tests
+
+func TestWaitForListStatusNodeExecutor_NodeType(t *testing.T) {
+	executor := NewWaitForListStatusNodeExecutor(nil)
+	assert.Equal(t, domain.NodeTypeWaitForListStatus, executor.NodeType())
+}
+
+func waitForListStatusParams(executionContext map[string]interface{}) NodeExecutionParams {
+	return NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:   "wait1",
+			Type: domain.NodeTypeWaitForListStatus,
+			Config: map[string]interface{}{
+				"list_id":         "list123",
+				"target_status":   "active",
+				"timeout":         2,
+				"timeout_unit":    "hours",
+				"matched_node_id": "node_matched",
+				"timeout_node_id": "node_timeout",
+			},
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "test@example.com",
+		},
+		ExecutionContext: executionContext,
+	}
+}
+
+func TestWaitForListStatusNodeExecutor_Execute(t *testing.T) {
+	t.Run("target status reached takes the matched path", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+		mockContactListRepo.EXPECT().
+			GetContactListByIDs(gomock.Any(), "ws1", "test@example.com", "list123").
+			Return(&domain.ContactList{Email: "test@example.com", ListID: "list123", Status: domain.ContactListStatusActive}, nil)
+
+		result, err := NewWaitForListStatusNodeExecutor(mockContactListRepo).Execute(context.Background(), waitForListStatusParams(nil))
+		require.NoError(t, err)
+
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "node_matched", *result.NextNodeID)
+		assert.Nil(t, result.ScheduledAt)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "wait_for_list_status", result.Output["node_type"])
+		assert.Equal(t, "matched", result.Output["outcome"])
+		assert.Equal(t, "active", result.Output["contact_status"])
+	})
+
+	t.Run("not in list keeps waiting on the node", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+		mockContactListRepo.EXPECT().
+			GetContactListByIDs(gomock.Any(), "ws1", "test@example.com", "list123").
+			Return(nil, &domain.ErrContactListNotFound{Message: "not found"})
+
+		before := time.Now()
+		result, err := NewWaitForListStatusNodeExecutor(mockContactListRepo).Execute(context.Background(), waitForListStatusParams(nil))
+		require.NoError(t, err)
+
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "wait1", *result.NextNodeID)
+		require.NotNil(t, result.ScheduledAt)
+		assert.WithinDuration(t, before.Add(waitForListStatusRecheckInterval), *result.ScheduledAt, 5*time.Second)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "waiting", result.Output["outcome"])
+		assert.Equal(t, "not_found", result.Output["contact_status"])
+
+		waitUntil, err := time.Parse(time.RFC3339Nano, result.Output["wait_until"].(string))
+		require.NoError(t, err)
+		assert.WithinDuration(t, before.Add(2*time.Hour), waitUntil, 5*time.Second)
+	})
+
+	t.Run("keeps the deadline of the first check", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+		mockContactListRepo.EXPECT().
+			GetContactListByIDs(gomock.Any(), "ws1", "test@example.com", "list123").
+			Return(&domain.ContactList{Email: "test@example.com", ListID: "list123", Status: domain.ContactListStatusPending}, nil)
+
+		deadline := time.Now().UTC().Add(30 * time.Second)
+		previous := map[string]interface{}{
+			"wait1": map[string]interface{}{"outcome": "waiting", "wait_until": deadline.Format(time.RFC3339Nano)},
+		}
+		result, err := NewWaitForListStatusNodeExecutor(mockContactListRepo).Execute(context.Background(), waitForListStatusParams(previous))
+		require.NoError(t, err)
+
+		assert.Equal(t, "wait1", *result.NextNodeID)
+		assert.Equal(t, deadline.Format(time.RFC3339Nano), result.Output["wait_until"])
+		require.NotNil(t, result.ScheduledAt)
+		assert.True(t, result.ScheduledAt.Equal(deadline), "re-check should not be scheduled past the deadline")
+	})
+
+	t.Run("deadline elapsed takes the timeout path", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+		mockContactListRepo.EXPECT().
+			GetContactListByIDs(gomock.Any(), "ws1", "test@example.com", "list123").
+			Return(nil, &domain.ErrContactListNotFound{Message: "not found"})
+
+		previous := map[string]interface{}{
+			"wait1": map[string]interface{}{"outcome": "waiting", "wait_until": time.Now().Add(-time.Minute).Format(time.RFC3339Nano)},
+		}
+		result, err := NewWaitForListStatusNodeExecutor(mockContactListRepo).Execute(context.Background(), waitForListStatusParams(previous))
+		require.NoError(t, err)
+
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "node_timeout", *result.NextNodeID)
+		assert.Nil(t, result.ScheduledAt)
+		assert.Equal(t, "timed_out", result.Output["outcome"])
+	})
+
+	t.Run("timeout without target completes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+		mockContactListRepo.EXPECT().
+			GetContactListByIDs(gomock.Any(), "ws1", "test@example.com", "list123").
+			Return(nil, &domain.ErrContactListNotFound{Message: "not found"})
+
+		params := waitForListStatusParams(map[string]interface{}{
+			"wait1": map[string]interface{}{"outcome": "waiting", "wait_until": time.Now().Add(-time.Minute).Format(time.RFC3339Nano)},
+		})
+		delete(params.Node.Config, "timeout_node_id")
+		result, err := NewWaitForListStatusNodeExecutor(mockContactListRepo).Execute(context.Background(), params)
+		require.NoError(t, err)
+
+		assert.Nil(t, result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusCompleted, result.Status)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+		mockContactListRepo.EXPECT().
+			GetContactListByIDs(gomock.Any(), "ws1", "test@example.com", "list123").
+			Return(nil, errors.New("db down"))
+
+		result, err := NewWaitForListStatusNodeExecutor(mockContactListRepo).Execute(context.Background(), waitForListStatusParams(nil))
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to check contact list status")
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		params := waitForListStatusParams(nil)
+		params.Node.Config["target_status"] = "joined"
+		result, err := NewWaitForListStatusNodeExecutor(nil).Execute(context.Background(), params)
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid wait_for_list_status node config")
+	})
+}
+
+func TestWaitUntilDatetimeNodeExecutor_NodeType(t *testing.T) {
+	executor := NewWaitUntilDatetimeNodeExecutor()
+	assert.Equal(t, domain.NodeTypeWaitUntilDatetime, executor.NodeType())
+}
+
+func waitUntilDatetimeParams(config map[string]interface{}) NodeExecutionParams {
+	nextNodeID := "next_node"
+	return NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:         "wait1",
+			Type:       domain.NodeTypeWaitUntilDatetime,
+			NextNodeID: &nextNodeID,
+			Config:     config,
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "test@example.com",
+		},
+	}
+}
+
+func TestWaitUntilDatetimeNodeExecutor_Execute(t *testing.T) {
+	t.Run("future datetime keeps waiting on the node", func(t *testing.T) {
+		launch := time.Now().UTC().Add(48 * time.Hour).Truncate(time.Second)
+		params := waitUntilDatetimeParams(map[string]interface{}{
+			"datetime": launch.Format(time.RFC3339),
+		})
+
+		result, err := NewWaitUntilDatetimeNodeExecutor().Execute(context.Background(), params)
+		require.NoError(t, err)
+
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "wait1", *result.NextNodeID)
+		require.NotNil(t, result.ScheduledAt)
+		assert.True(t, launch.Equal(*result.ScheduledAt))
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "wait_until_datetime", result.Output["node_type"])
+		assert.Equal(t, "waiting", result.Output["outcome"])
+	})
+
+	t.Run("datetime is read in the configured timezone", func(t *testing.T) {
+		params := waitUntilDatetimeParams(map[string]interface{}{
+			"datetime": "2999-06-01T09:00",
+			"timezone": "Europe/Paris",
+		})
+
+		result, err := NewWaitUntilDatetimeNodeExecutor().Execute(context.Background(), params)
+		require.NoError(t, err)
+
+		require.NotNil(t, result.ScheduledAt)
+		assert.Equal(t, time.Date(2999, 6, 1, 7, 0, 0, 0, time.UTC), *result.ScheduledAt)
+	})
+
+	t.Run("past datetime passes through immediately", func(t *testing.T) {
+		params := waitUntilDatetimeParams(map[string]interface{}{
+			"datetime": "2020-01-01T00:00:00Z",
+		})
+
+		result, err := NewWaitUntilDatetimeNodeExecutor().Execute(context.Background(), params)
+		require.NoError(t, err)
+
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "next_node", *result.NextNodeID)
+		assert.Nil(t, result.ScheduledAt)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "passed", result.Output["outcome"])
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		params := waitUntilDatetimeParams(map[string]interface{}{
+			"datetime": "next tuesday",
+		})
+
+		result, err := NewWaitUntilDatetimeNodeExecutor().Execute(context.Background(), params)
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid wait_until_datetime node config")
+	})
+}
+
+func waitUntilParams(timezone *domain.NullableString) NodeExecutionParams {
+	nextNodeID := "next_node"
+	return NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:         "wait1",
+			Type:       domain.NodeTypeWaitUntil,
+			NextNodeID: &nextNodeID,
+			Config: map[string]interface{}{
+				"days_of_week": []interface{}{"tuesday", "thursday"},
+				"hour":         9,
+				"minute":       30,
+			},
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "test@example.com",
+		},
+		ContactData: &domain.Contact{Email: "test@example.com", Timezone: timezone},
+	}
+}
+
+func TestWaitUntilNodeExecutor_Execute(t *testing.T) {
+	// assertScheduled checks the contact moves on to the next node at 9:30 on a Tuesday or
+	// Thursday in the given timezone, within the coming week
+	assertScheduled := func(t *testing.T, result *NodeExecutionResult, timezone string) {
+		location, err := time.LoadLocation(timezone)
+		require.NoError(t, err)
+
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "next_node", *result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		require.NotNil(t, result.ScheduledAt)
+		assert.True(t, result.ScheduledAt.After(time.Now()))
+		assert.True(t, result.ScheduledAt.Before(time.Now().Add(8*24*time.Hour)))
+
+		local := result.ScheduledAt.In(location)
+		assert.Equal(t, 9, local.Hour())
+		assert.Equal(t, 30, local.Minute())
+		assert.Contains(t, []time.Weekday{time.Tuesday, time.Thursday}, local.Weekday())
+		assert.Equal(t, "wait_until", result.Output["node_type"])
+		assert.Equal(t, timezone, result.Output["timezone"])
+	}
+
+	t.Run("uses the contact timezone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+
+		params := waitUntilParams(&domain.NullableString{String: "Asia/Tokyo"})
+		result, err := NewWaitUntilNodeExecutor(mockWorkspaceRepo).Execute(context.Background(), params)
+		require.NoError(t, err)
+
+		assertScheduled(t, result, "Asia/Tokyo")
+		assert.Equal(t, "contact", result.Output["timezone_source"])
+	})
+
+	t.Run("falls back to the workspace timezone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(&domain.Workspace{
+			ID:       "ws1",
+			Settings: domain.WorkspaceSettings{Timezone: "America/New_York"},
+		}, nil)
+
+		params := waitUntilParams(nil)
+		result, err := NewWaitUntilNodeExecutor(mockWorkspaceRepo).Execute(context.Background(), params)
+		require.NoError(t, err)
+
+		assertScheduled(t, result, "America/New_York")
+		assert.Equal(t, "workspace", result.Output["timezone_source"])
+	})
+
+	t.Run("invalid contact timezone falls back to UTC", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+
+		params := waitUntilParams(&domain.NullableString{String: "Mars/Olympus"})
+		result, err := NewWaitUntilNodeExecutor(mockWorkspaceRepo).Execute(context.Background(), params)
+		require.NoError(t, err)
+
+		assertScheduled(t, result, "UTC")
+		assert.Equal(t, "fallback", result.Output["timezone_source"])
+	})
+
+	t.Run("workspace lookup error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(nil, errors.New("db down"))
+
+		result, err := NewWaitUntilNodeExecutor(mockWorkspaceRepo).Execute(context.Background(), waitUntilParams(nil))
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to get workspace")
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+
+		params := waitUntilParams(nil)
+		params.Node.Config["hour"] = 25
+		result, err := NewWaitUntilNodeExecutor(mockWorkspaceRepo).Execute(context.Background(), params)
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid wait_until node config")
+	})
+}
+
+func TestABTestNodeExecutor_NodeType(t *testing.T) {
+	executor := NewABTestNodeExecutor()
+	assert.Equal(t, domain.NodeTypeABTest, executor.NodeType())
+}
+
+func TestParseABTestNodeConfig(t *testing.T) {
+	t.Run("valid config", func(t *testing.T) {
+		config := map[string]interface{}{
+			"variants": []map[string]interface{}{
+				{
+					"id":           "A",
+					"name":         "Control",
+					"weight":       50,
+					"next_node_id": "node_a",
+				},
+				{
+					"id":           "B",
+					"name":         "Variant B",
+					"weight":       50,
+					"next_node_id": "node_b",
+				},
+			},
+		}
+
+		c, err := parseABTestNodeConfig(config)
+		require.NoError(t, err)
+		assert.Len(t, c.Variants, 2)
+		assert.Equal(t, "A", c.Variants[0].ID)
+		assert.Equal(t, "Control", c.Variants[0].Name)
+		assert.Equal(t, 50, c.Variants[0].Weight)
+		assert.Equal(t, "node_a", c.Variants[0].NextNodeID)
+	})
+
+	t.Run("invalid config - less than 2 variants", func(t *testing.T) {
+		config := map[string]interface{}{
+			"variants": []map[string]interface{}{
+				{
+					"id":           "A",
+					"name":         "Control",
 					"weight":       100,
 					"next_node_id": "node_a",
 				},
@@ -3135,6 +5610,36 @@ func TestABTestNodeExecutor_Execute_OutputContainsVariantInfo(t *testing.T) {
 	assert.NotNil(t, result.NextNodeID)
 }
 
+func TestABTestNodeExecutor_Execute_RendersVariantName(t *testing.T) {
+	executor := NewABTestNodeExecutor()
+
+	params := NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "test@example.com",
+		},
+		Node: &domain.AutomationNode{
+			ID:   "ab1",
+			Type: domain.NodeTypeABTest,
+			Config: map[string]interface{}{
+				"variants": []interface{}{
+					map[string]interface{}{"id": "A", "name": "{{ automation_name }} A", "weight": 50, "next_node_id": "node_a"},
+					map[string]interface{}{"id": "B", "name": "{{ automation_name }} B", "weight": 50, "next_node_id": "node_b"},
+				},
+			},
+		},
+		Automation:  &domain.Automation{ID: "auto1", Name: "Onboarding"},
+		ContactData: &domain.Contact{Email: "test@example.com"},
+	}
+
+	result, err := executor.Execute(context.Background(), params)
+	require.NoError(t, err)
+
+	variantID := result.Output["variant_id"].(string)
+	assert.Equal(t, "Onboarding "+variantID, result.Output["variant_name"])
+}
+
 func TestABTestNodeExecutor_Execute_InvalidConfig(t *testing.T) {
 	executor := NewABTestNodeExecutor()
 
@@ -3310,6 +5815,19 @@ func TestBuildWebhookPayload(t *testing.T) {
 		assert.Equal(t, "node123", payload["node_id"])
 		assert.NotEmpty(t, payload["timestamp"])
 		assert.NotNil(t, payload["contact"])
+		assert.NotContains(t, payload, "automation_metadata")
+	})
+
+	t.Run("includes automation metadata", func(t *testing.T) {
+		automation := &domain.Automation{
+			ID:       "auto123",
+			Name:     "Test Automation",
+			Metadata: domain.MapOfAny{"crm_campaign": "spring"},
+		}
+
+		payload := buildWebhookPayload(nil, automation, "node123")
+
+		assert.Equal(t, domain.MapOfAny{"crm_campaign": "spring"}, payload["automation_metadata"])
 	})
 
 	t.Run("handles nil contact", func(t *testing.T) {
@@ -3349,12 +5867,139 @@ func TestWebhookNodeExecutor_Execute_Success(t *testing.T) {
 		"id":      "webhook_123",
 	}
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		assert.Equal(t, "POST", r.Method)
-		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(responseData)
+	}))
+	defer server.Close()
+
+	executor := NewWebhookNodeExecutor(mockLogger)
+
+	params := NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:         "webhook_node1",
+			Type:       domain.NodeTypeWebhook,
+			NextNodeID: strPtr("next_node"),
+			Config: map[string]interface{}{
+				"url": server.URL,
+			},
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "test@example.com",
+		},
+		ContactData: &domain.Contact{
+			Email: "test@example.com",
+		},
+		Automation: &domain.Automation{
+			ID:   "auto1",
+			Name: "Test Automation",
+		},
+	}
+
+	result, err := executor.Execute(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	assert.Equal(t, "next_node", *result.NextNodeID)
+	assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+	assert.Equal(t, "webhook", result.Output["node_type"])
+	assert.Equal(t, server.URL, result.Output["url"])
+	assert.Equal(t, 200, result.Output["status_code"])
+	assert.NotNil(t, result.Output["response"])
+}
+
+func TestWebhookNodeExecutor_Execute_LogWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"webhook_123"}`))
+	}))
+	defer server.Close()
+
+	executor := NewWebhookNodeExecutor(mockLogger)
+
+	newParams := func(logWebhooks bool) NodeExecutionParams {
+		return NodeExecutionParams{
+			WorkspaceID: "ws1",
+			Node: &domain.AutomationNode{
+				ID:         "webhook_node1",
+				Type:       domain.NodeTypeWebhook,
+				NextNodeID: strPtr("next_node"),
+				Config:     map[string]interface{}{"url": server.URL},
+			},
+			Contact:     &domain.ContactAutomation{ID: "ca1", ContactEmail: "test@example.com"},
+			ContactData: &domain.Contact{Email: "test@example.com"},
+			Automation:  &domain.Automation{ID: "auto1", Name: "Test Automation", LogWebhooks: logWebhooks},
+		}
+	}
+
+	t.Run("records the exchange when enabled", func(t *testing.T) {
+		result, err := executor.Execute(context.Background(), newParams(true))
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		exchange, ok := result.Output["exchange"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Contains(t, exchange, "latency_ms")
+
+		request := exchange["request"].(map[string]interface{})
+		assert.Equal(t, http.MethodPost, request["method"])
+		assert.Equal(t, false, request["body_truncated"])
+
+		response := exchange["response"].(map[string]interface{})
+		assert.Equal(t, http.StatusCreated, response["status_code"])
+		assert.Equal(t, `{"id":"webhook_123"}`, response["body"])
+		assert.Equal(t, false, response["body_truncated"])
+	})
+
+	t.Run("records nothing when disabled", func(t *testing.T) {
+		result, err := executor.Execute(context.Background(), newParams(false))
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.NotContains(t, result.Output, "exchange")
+	})
+}
+
+func TestTruncateWebhookLogBody(t *testing.T) {
+	body, truncated := truncateWebhookLogBody([]byte("short"))
+	assert.Equal(t, "short", body)
+	assert.False(t, truncated)
+
+	// A multi-byte character cut at the limit is dropped
+	long := strings.Repeat("a", webhookLogBodyLimit-1) + "é"
+	body, truncated = truncateWebhookLogBody([]byte(long))
+	assert.True(t, truncated)
+	assert.Equal(t, strings.Repeat("a", webhookLogBodyLimit-1), body)
+}
+
+func TestWebhookNodeExecutor_Execute_FormEncoded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+	// Create test server that parses the body as a form
+	var received url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = r.PostForm
 
-		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(responseData)
+		w.Write([]byte(`{"ok": true}`))
 	}))
 	defer server.Close()
 
@@ -3367,7 +6012,8 @@ func TestWebhookNodeExecutor_Execute_Success(t *testing.T) {
 			Type:       domain.NodeTypeWebhook,
 			NextNodeID: strPtr("next_node"),
 			Config: map[string]interface{}{
-				"url": server.URL,
+				"url":          server.URL,
+				"content_type": "form",
 			},
 		},
 		Contact: &domain.ContactAutomation{
@@ -3375,7 +6021,8 @@ func TestWebhookNodeExecutor_Execute_Success(t *testing.T) {
 			ContactEmail: "test@example.com",
 		},
 		ContactData: &domain.Contact{
-			Email: "test@example.com",
+			Email:     "test@example.com",
+			FirstName: &domain.NullableString{String: "John", IsNull: false},
 		},
 		Automation: &domain.Automation{
 			ID:   "auto1",
@@ -3386,13 +6033,17 @@ func TestWebhookNodeExecutor_Execute_Success(t *testing.T) {
 	result, err := executor.Execute(context.Background(), params)
 	require.NoError(t, err)
 	require.NotNil(t, result)
-
-	assert.Equal(t, "next_node", *result.NextNodeID)
-	assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
-	assert.Equal(t, "webhook", result.Output["node_type"])
-	assert.Equal(t, server.URL, result.Output["url"])
 	assert.Equal(t, 200, result.Output["status_code"])
-	assert.NotNil(t, result.Output["response"])
+
+	// Top-level fields are plain keys, nested contact fields use bracket notation
+	require.NotNil(t, received)
+	assert.Equal(t, "test@example.com", received.Get("email"))
+	assert.Equal(t, "auto1", received.Get("automation_id"))
+	assert.Equal(t, "Test Automation", received.Get("automation_name"))
+	assert.Equal(t, "webhook_node1", received.Get("node_id"))
+	assert.NotEmpty(t, received.Get("timestamp"))
+	assert.Equal(t, "test@example.com", received.Get("contact[email]"))
+	assert.Equal(t, "John", received.Get("contact[first_name]"))
 }
 
 func TestWebhookNodeExecutor_Execute_WithSecret(t *testing.T) {
@@ -3443,6 +6094,75 @@ func TestWebhookNodeExecutor_Execute_WithSecret(t *testing.T) {
 	assert.Equal(t, 200, result.Output["status_code"])
 }
 
+func TestWebhookNodeExecutor_Execute_CustomHeaders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	executor := NewWebhookNodeExecutor(mockLogger)
+
+	newParams := func(firstName string) NodeExecutionParams {
+		return NodeExecutionParams{
+			WorkspaceID: "ws1",
+			Node: &domain.AutomationNode{
+				ID:         "webhook_node1",
+				Type:       domain.NodeTypeWebhook,
+				NextNodeID: strPtr("next_node"),
+				Config: map[string]interface{}{
+					"url": server.URL,
+					"headers": []interface{}{
+						map[string]interface{}{"name": "X-Api-Key", "value": "static-key"},
+						map[string]interface{}{"name": "X-Correlation-ID", "value": "{{ automation_id }}-{{ contact.email }}"},
+						map[string]interface{}{"name": "X-Contact-Name", "value": "{{ contact.first_name }}"},
+					},
+				},
+			},
+			Contact: &domain.ContactAutomation{
+				ID:           "ca1",
+				ContactEmail: "test@example.com",
+			},
+			ContactData: &domain.Contact{
+				Email:     "test@example.com",
+				FirstName: &domain.NullableString{String: firstName, IsNull: false},
+			},
+			Automation: &domain.Automation{
+				ID:   "auto1",
+				Name: "Test Automation",
+			},
+		}
+	}
+
+	t.Run("sends static and templated headers", func(t *testing.T) {
+		result, err := executor.Execute(context.Background(), newParams("Jane"))
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		assert.Equal(t, "static-key", received.Get("X-Api-Key"))
+		assert.Equal(t, "auto1-test@example.com", received.Get("X-Correlation-ID"))
+		assert.Equal(t, "Jane", received.Get("X-Contact-Name"))
+		assert.Equal(t, "application/json", received.Get("Content-Type"))
+	})
+
+	t.Run("rejects templated values that inject headers", func(t *testing.T) {
+		received = nil
+
+		result, err := executor.Execute(context.Background(), newParams("Jane\r\nX-Injected: yes"))
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid webhook header X-Contact-Name")
+		assert.Nil(t, received, "request must not be sent")
+	})
+}
+
 func TestWebhookNodeExecutor_Execute_4xxError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -3524,23 +6244,357 @@ func TestWebhookNodeExecutor_Execute_5xxError(t *testing.T) {
 		},
 	}
 
-	result, err := executor.Execute(context.Background(), params)
-	assert.Error(t, err)
-	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "webhook returned server error")
-	assert.Contains(t, err.Error(), "500")
+	result, err := executor.Execute(context.Background(), params)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "webhook returned server error")
+	assert.Contains(t, err.Error(), "500")
+}
+
+func TestWebhookNodeExecutor_Execute_NonJSONResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+	// Create test server that returns plain text
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK - webhook received"))
+	}))
+	defer server.Close()
+
+	executor := NewWebhookNodeExecutor(mockLogger)
+
+	params := NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:         "webhook_node1",
+			Type:       domain.NodeTypeWebhook,
+			NextNodeID: strPtr("next_node"),
+			Config: map[string]interface{}{
+				"url": server.URL,
+			},
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "test@example.com",
+		},
+		ContactData: &domain.Contact{
+			Email: "test@example.com",
+		},
+		Automation: &domain.Automation{
+			ID:   "auto1",
+			Name: "Test Automation",
+		},
+	}
+
+	result, err := executor.Execute(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	// Response should be stored as raw string
+	response := result.Output["response"].(map[string]interface{})
+	assert.Equal(t, "OK - webhook received", response["raw"])
+}
+
+func TestWebhookNodeExecutor_Execute_ResponseMapping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+	executor := NewWebhookNodeExecutor(mockLogger)
+
+	execute := func(t *testing.T, body string, mapping map[string]interface{}) *NodeExecutionResult {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body))
+		}))
+		defer server.Close()
+
+		result, err := executor.Execute(context.Background(), NodeExecutionParams{
+			WorkspaceID: "ws1",
+			Node: &domain.AutomationNode{
+				ID:         "webhook_node1",
+				Type:       domain.NodeTypeWebhook,
+				NextNodeID: strPtr("next_node"),
+				Config: map[string]interface{}{
+					"url":              server.URL,
+					"response_mapping": mapping,
+				},
+			},
+			Contact:     &domain.ContactAutomation{ID: "ca1", ContactEmail: "test@example.com"},
+			ContactData: &domain.Contact{Email: "test@example.com"},
+			Automation:  &domain.Automation{ID: "auto1", Name: "Test Automation"},
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("stores the value at the json path", func(t *testing.T) {
+		result := execute(t, `{"score": 87, "tier": "gold"}`, map[string]interface{}{"save_as": "enrichment", "json_path": "$.score"})
+
+		assert.Equal(t, map[string]interface{}{"enrichment": float64(87)}, result.Context)
+		assert.Equal(t, "enrichment", result.Output["saved_as"])
+		assert.Equal(t, "next_node", *result.NextNodeID)
+	})
+
+	t.Run("stores a non-JSON response as the raw string", func(t *testing.T) {
+		result := execute(t, "score=87", map[string]interface{}{"save_as": "enrichment", "json_path": "$.score"})
+
+		assert.Equal(t, map[string]interface{}{"enrichment": "score=87"}, result.Context)
+	})
+
+	t.Run("caps the stored response size", func(t *testing.T) {
+		result := execute(t, strings.Repeat("x", domain.WebhookResponseMaxStoredBytes*2), map[string]interface{}{"save_as": "enrichment"})
+
+		assert.Len(t, result.Context["enrichment"], domain.WebhookResponseMaxStoredBytes)
+	})
+
+	t.Run("nothing is stored without a mapping", func(t *testing.T) {
+		result := execute(t, `{"score": 87}`, nil)
+
+		assert.Nil(t, result.Context)
+		assert.NotContains(t, result.Output, "saved_as")
+	})
+}
+
+func TestWebhookNodeExecutor_Execute_InvalidConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+	executor := NewWebhookNodeExecutor(mockLogger)
+
+	params := NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:     "webhook_node1",
+			Type:   domain.NodeTypeWebhook,
+			Config: map[string]interface{}{
+				// Missing URL
+			},
+		},
+		Contact: &domain.ContactAutomation{
+			ID:           "ca1",
+			ContactEmail: "test@example.com",
+		},
+		ContactData: &domain.Contact{
+			Email: "test@example.com",
+		},
+		Automation: &domain.Automation{
+			ID:   "auto1",
+			Name: "Test Automation",
+		},
+	}
+
+	result, err := executor.Execute(context.Background(), params)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "invalid webhook node config")
+}
+
+// generateClientCertPEM creates a self-signed client certificate and returns it PEM-encoded
+// along with a pool that trusts it, for use as a server's ClientCAs
+func generateClientCertPEM(t *testing.T) (string, string, *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "notifuse-webhook-client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM), pool
+}
+
+func TestWebhookNodeExecutor_Execute_MTLS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+	certPEM, keyPEM, clientCAs := generateClientCertPEM(t)
+
+	// Server requires and verifies a client certificate signed by our test CA
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if assert.Len(t, r.TLS.PeerCertificates, 1) {
+			assert.Equal(t, "notifuse-webhook-client", r.TLS.PeerCertificates[0].Subject.CommonName)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	newParams := func(config map[string]interface{}) NodeExecutionParams {
+		return NodeExecutionParams{
+			WorkspaceID: "ws1",
+			Node: &domain.AutomationNode{
+				ID:         "webhook_node1",
+				Type:       domain.NodeTypeWebhook,
+				NextNodeID: strPtr("next_node"),
+				Config:     config,
+			},
+			Contact: &domain.ContactAutomation{
+				ID:           "ca1",
+				ContactEmail: "test@example.com",
+			},
+			ContactData: &domain.Contact{
+				Email: "test@example.com",
+			},
+			Automation: &domain.Automation{
+				ID:   "auto1",
+				Name: "Test Automation",
+			},
+		}
+	}
+
+	t.Run("succeeds with client certificate", func(t *testing.T) {
+		executor := NewWebhookNodeExecutor(mockLogger)
+		// Trust the test server's certificate
+		executor.httpClient = server.Client()
+
+		result, err := executor.Execute(context.Background(), newParams(map[string]interface{}{
+			"url": server.URL,
+			"mtls": map[string]interface{}{
+				"client_cert": certPEM,
+				"client_key":  keyPEM,
+			},
+		}))
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, "next_node", *result.NextNodeID)
+		assert.Equal(t, 200, result.Output["status_code"])
+	})
+
+	t.Run("fails without client certificate", func(t *testing.T) {
+		executor := NewWebhookNodeExecutor(mockLogger)
+		executor.httpClient = server.Client()
+
+		result, err := executor.Execute(context.Background(), newParams(map[string]interface{}{
+			"url": server.URL,
+		}))
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "webhook request failed")
+	})
+
+	t.Run("rejects mismatched certificate and key", func(t *testing.T) {
+		_, otherKeyPEM, _ := generateClientCertPEM(t)
+		executor := NewWebhookNodeExecutor(mockLogger)
+		executor.httpClient = server.Client()
+
+		result, err := executor.Execute(context.Background(), newParams(map[string]interface{}{
+			"url": server.URL,
+			"mtls": map[string]interface{}{
+				"client_cert": certPEM,
+				"client_key":  otherKeyPEM,
+			},
+		}))
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid mtls client certificate/key pair")
+	})
+}
+
+func TestWebhookNodeExecutor_Execute_MTLSEncryptedKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+
+	certPEM, keyPEM, clientCAs := generateClientCertPEM(t)
+	encryptedKey, err := crypto.EncryptString(keyPEM, "workspace-secret-key")
+	require.NoError(t, err)
+
+	var connections int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	executor := NewWebhookNodeExecutor(mockLogger)
+	executor.SetWorkspaceRepository(mockWorkspaceRepo)
+	executor.httpClient = server.Client()
+
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").
+		Return(&domain.Workspace{ID: "ws1", Settings: domain.WorkspaceSettings{SecretKey: "workspace-secret-key"}}, nil).
+		Times(2)
+
+	params := NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:         "webhook_node1",
+			Type:       domain.NodeTypeWebhook,
+			NextNodeID: strPtr("next_node"),
+			Config: map[string]interface{}{
+				"url": server.URL,
+				"mtls": map[string]interface{}{
+					"client_cert":          certPEM,
+					"encrypted_client_key": encryptedKey,
+				},
+			},
+		},
+		Contact:     &domain.ContactAutomation{ID: "ca1", ContactEmail: "test@example.com"},
+		ContactData: &domain.Contact{Email: "test@example.com"},
+		Automation:  &domain.Automation{ID: "auto1", Name: "Test Automation"},
+	}
+
+	// Executions with the same certificate share a client and its idle connections
+	for i := 0; i < 2; i++ {
+		result, err := executor.Execute(context.Background(), params)
+		require.NoError(t, err)
+		assert.Equal(t, 200, result.Output["status_code"])
+	}
+	assert.Len(t, executor.mtlsClients, 1)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&connections))
 }
 
-func TestWebhookNodeExecutor_Execute_NonJSONResponse(t *testing.T) {
+func TestWebhookNodeExecutor_Execute_EmptyResponse(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
 
-	// Create test server that returns plain text
+	// Create test server that returns 204 No Content
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("OK - webhook received"))
+		w.WriteHeader(http.StatusNoContent)
 	}))
 	defer server.Close()
 
@@ -3573,88 +6627,597 @@ func TestWebhookNodeExecutor_Execute_NonJSONResponse(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, result)
 
-	// Response should be stored as raw string
-	response := result.Output["response"].(map[string]interface{})
-	assert.Equal(t, "OK - webhook received", response["raw"])
+	assert.Equal(t, 204, result.Output["status_code"])
+	// Empty response should result in nil map
+	assert.Nil(t, result.Output["response"])
 }
 
-func TestWebhookNodeExecutor_Execute_InvalidConfig(t *testing.T) {
+func TestWebhookNodeExecutor_Execute_ResponseBranches(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+	// The mock fraud service answers with the risk level named by the request path
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scores := map[string]int{"high": 92, "medium": 60, "low": 10}
+		risk := strings.TrimPrefix(r.URL.Path, "/")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"risk": risk, "score": scores[risk]})
+	}))
+	defer server.Close()
+
 	executor := NewWebhookNodeExecutor(mockLogger)
 
-	params := NodeExecutionParams{
-		WorkspaceID: "ws1",
-		Node: &domain.AutomationNode{
-			ID:     "webhook_node1",
-			Type:   domain.NodeTypeWebhook,
-			Config: map[string]interface{}{
-				// Missing URL
+	branches := []interface{}{
+		map[string]interface{}{
+			"id":   "high_risk",
+			"name": "High risk",
+			"conditions": []interface{}{
+				map[string]interface{}{"field": "risk", "operator": "equals", "value": "high"},
 			},
+			"next_node_id": "high_risk_node",
 		},
-		Contact: &domain.ContactAutomation{
-			ID:           "ca1",
-			ContactEmail: "test@example.com",
-		},
-		ContactData: &domain.Contact{
-			Email: "test@example.com",
-		},
-		Automation: &domain.Automation{
-			ID:   "auto1",
-			Name: "Test Automation",
+		map[string]interface{}{
+			"id": "review",
+			"conditions": []interface{}{
+				map[string]interface{}{"field": "score", "operator": "gte", "value": 50},
+			},
+			"next_node_id": "review_node",
 		},
 	}
 
-	result, err := executor.Execute(context.Background(), params)
-	assert.Error(t, err)
-	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "invalid webhook node config")
+	execute := func(t *testing.T, risk string) *NodeExecutionResult {
+		result, err := executor.Execute(context.Background(), NodeExecutionParams{
+			WorkspaceID: "ws1",
+			Node: &domain.AutomationNode{
+				ID:         "fraud_check",
+				Type:       domain.NodeTypeWebhook,
+				NextNodeID: strPtr("low_risk_node"),
+				Config: map[string]interface{}{
+					"url":                  server.URL + "/" + risk,
+					"on_response_branches": branches,
+				},
+			},
+			Contact: &domain.ContactAutomation{
+				ID:           "ca1",
+				ContactEmail: "test@example.com",
+			},
+			ContactData: &domain.Contact{
+				Email: "test@example.com",
+			},
+			Automation: &domain.Automation{
+				ID:   "auto1",
+				Name: "Test Automation",
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		return result
+	}
+
+	t.Run("high risk response routes to the high-risk path", func(t *testing.T) {
+		result := execute(t, "high")
+		assert.Equal(t, "high_risk_node", *result.NextNodeID)
+		assert.Equal(t, "high_risk", result.Output["branch_taken"])
+		assert.Equal(t, "High risk", result.Output["branch_name"])
+	})
+
+	t.Run("later branches are evaluated when earlier ones do not match", func(t *testing.T) {
+		result := execute(t, "medium")
+		assert.Equal(t, "review_node", *result.NextNodeID)
+		assert.Equal(t, "review", result.Output["branch_taken"])
+	})
+
+	t.Run("falls back to next_node_id when no branch matches", func(t *testing.T) {
+		result := execute(t, "low")
+		assert.Equal(t, "low_risk_node", *result.NextNodeID)
+		assert.Equal(t, "default", result.Output["branch_taken"])
+	})
 }
 
-func TestWebhookNodeExecutor_Execute_EmptyResponse(t *testing.T) {
+func TestForEachNodeExecutor_NodeType(t *testing.T) {
+	executor := NewForEachNodeExecutor()
+	assert.Equal(t, domain.NodeTypeForEach, executor.NodeType())
+}
+
+func TestForEachNodeExecutor_Execute(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockLogger := setupMockLoggerForNodeExecutor(ctrl)
 
-	// Create test server that returns 204 No Content
+	// The sub-flow is a single webhook node, which posts the current item
+	var receivedItems []map[string]interface{}
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusNoContent)
+		var payload map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		item, _ := payload["item"].(map[string]interface{})
+		receivedItems = append(receivedItems, item)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok": true}`))
 	}))
 	defer server.Close()
 
-	executor := NewWebhookNodeExecutor(mockLogger)
+	executor := NewForEachNodeExecutor()
+	executor.SetNodeExecutors(map[domain.NodeType]NodeExecutor{
+		domain.NodeTypeWebhook: NewWebhookNodeExecutor(mockLogger),
+		domain.NodeTypeDelay:   NewDelayNodeExecutor(),
+	})
 
-	params := NodeExecutionParams{
+	newParams := func(subFlowNode *domain.AutomationNode, enrollmentContext map[string]interface{}) NodeExecutionParams {
+		forEachNode := &domain.AutomationNode{
+			ID:         "for_each1",
+			Type:       domain.NodeTypeForEach,
+			NextNodeID: strPtr("after_loop"),
+			Config: map[string]interface{}{
+				"items_path":       "context.event.items",
+				"sub_flow_node_id": subFlowNode.ID,
+			},
+		}
+		return NodeExecutionParams{
+			WorkspaceID: "ws1",
+			Node:        forEachNode,
+			Contact: &domain.ContactAutomation{
+				ID:           "ca1",
+				ContactEmail: "test@example.com",
+				Context:      enrollmentContext,
+			},
+			ContactData: &domain.Contact{Email: "test@example.com"},
+			Automation: &domain.Automation{
+				ID:    "auto1",
+				Name:  "Purchase follow-up",
+				Nodes: []*domain.AutomationNode{forEachNode, subFlowNode},
+			},
+			ExecutionContext: map[string]interface{}{},
+		}
+	}
+
+	webhookNode := &domain.AutomationNode{
+		ID:   "per_item",
+		Type: domain.NodeTypeWebhook,
+		Config: map[string]interface{}{
+			"url": server.URL,
+		},
+	}
+
+	t.Run("runs the sub-flow once per item with the item bound", func(t *testing.T) {
+		receivedItems = nil
+		params := newParams(webhookNode, map[string]interface{}{
+			"event": map[string]interface{}{
+				"items": []interface{}{
+					map[string]interface{}{"sku": "SKU-1", "quantity": float64(1)},
+					map[string]interface{}{"sku": "SKU-2", "quantity": float64(3)},
+				},
+			},
+		})
+
+		result, err := executor.Execute(context.Background(), params)
+		require.NoError(t, err)
+
+		require.Len(t, receivedItems, 2)
+		assert.Equal(t, "SKU-1", receivedItems[0]["sku"])
+		assert.Equal(t, float64(1), receivedItems[0]["quantity"])
+		assert.Equal(t, "SKU-2", receivedItems[1]["sku"])
+		assert.Equal(t, float64(3), receivedItems[1]["quantity"])
+
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "after_loop", *result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "for_each", result.Output["node_type"])
+		assert.Equal(t, 2, result.Output["item_count"])
+		iterations := result.Output["iterations"].([]map[string]interface{})
+		require.Len(t, iterations, 2)
+		assert.Equal(t, []string{"per_item"}, iterations[1]["nodes"])
+		assert.NotContains(t, params.ExecutionContext, "item", "The item is only bound inside the sub-flow")
+	})
+
+	t.Run("values saved by the sub-flow are kept on the contact", func(t *testing.T) {
+		mappedNode := &domain.AutomationNode{
+			ID:   "per_item_mapped",
+			Type: domain.NodeTypeWebhook,
+			Config: map[string]interface{}{
+				"url":              server.URL,
+				"response_mapping": map[string]interface{}{"save_as": "last_ack", "json_path": "$.ok"},
+			},
+		}
+		params := newParams(mappedNode, map[string]interface{}{
+			"event": map[string]interface{}{"items": []interface{}{"SKU-1"}},
+		})
+
+		result, err := executor.Execute(context.Background(), params)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]interface{}{"last_ack": true}, result.Context)
+		assert.NotContains(t, params.Contact.Context, "last_ack", "The contact is updated by the executor from the result")
+	})
+
+	t.Run("missing array runs no iteration", func(t *testing.T) {
+		receivedItems = nil
+		result, err := executor.Execute(context.Background(), newParams(webhookNode, nil))
+		require.NoError(t, err)
+
+		assert.Empty(t, receivedItems)
+		assert.Equal(t, 0, result.Output["item_count"])
+		assert.Equal(t, "after_loop", *result.NextNodeID)
+	})
+
+	t.Run("value that is not an array fails", func(t *testing.T) {
+		params := newParams(webhookNode, map[string]interface{}{
+			"event": map[string]interface{}{"items": "SKU-1"},
+		})
+
+		result, err := executor.Execute(context.Background(), params)
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "is not an array")
+	})
+
+	t.Run("waiting nodes are rejected in the sub-flow", func(t *testing.T) {
+		delayNode := &domain.AutomationNode{
+			ID:     "per_item_delay",
+			Type:   domain.NodeTypeDelay,
+			Config: map[string]interface{}{"duration": float64(1), "unit": "days"},
+		}
+		params := newParams(delayNode, map[string]interface{}{
+			"event": map[string]interface{}{"items": []interface{}{"SKU-1"}},
+		})
+
+		result, err := executor.Execute(context.Background(), params)
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "cannot run in a for_each sub-flow")
+	})
+}
+
+// slackTestExecutor returns a slack executor whose requests to slackTestWebhookURL reach the
+// test server, since the SSRF-safe client and URL check reject the loopback address
+func slackTestExecutor(t *testing.T, ctrl *gomock.Controller, server *httptest.Server) *SlackNodeExecutor {
+	t.Helper()
+	executor := NewSlackNodeExecutor(setupMockLoggerForNodeExecutor(ctrl))
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	executor.httpClient = &http.Client{Transport: transport, Timeout: 5 * time.Second}
+	return executor
+}
+
+const slackTestWebhookURL = "http://hooks.slack.com/services/T000/B000/XXXX"
+
+func slackTestParams() NodeExecutionParams {
+	return NodeExecutionParams{
 		WorkspaceID: "ws1",
 		Node: &domain.AutomationNode{
-			ID:         "webhook_node1",
-			Type:       domain.NodeTypeWebhook,
+			ID:         "slack_node1",
+			Type:       domain.NodeTypeSlack,
 			NextNodeID: strPtr("next_node"),
 			Config: map[string]interface{}{
-				"url": server.URL,
+				"webhook_url": slackTestWebhookURL,
+				"text":        "New signup: {{ contact.email }} via {{ event.source }}",
 			},
 		},
 		Contact: &domain.ContactAutomation{
 			ID:           "ca1",
 			ContactEmail: "test@example.com",
+			Context:      map[string]interface{}{"event": map[string]interface{}{"source": "pricing_page"}},
 		},
-		ContactData: &domain.Contact{
-			Email: "test@example.com",
-		},
-		Automation: &domain.Automation{
-			ID:   "auto1",
-			Name: "Test Automation",
-		},
+		ContactData: &domain.Contact{Email: "test@example.com"},
+		Automation:  &domain.Automation{ID: "auto1", Name: "Ops Alerts"},
 	}
+}
 
-	result, err := executor.Execute(context.Background(), params)
-	require.NoError(t, err)
-	require.NotNil(t, result)
+func TestSlackNodeExecutor_Execute(t *testing.T) {
+	t.Run("posts rendered text", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var received map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/services/T000/B000/XXXX", r.URL.Path)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			_, _ = w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+
+		result, err := slackTestExecutor(t, ctrl, server).Execute(context.Background(), slackTestParams())
+		require.NoError(t, err)
 
-	assert.Equal(t, 204, result.Output["status_code"])
-	// Empty response should result in nil map
-	assert.Nil(t, result.Output["response"])
+		assert.Equal(t, map[string]interface{}{"text": "New signup: test@example.com via pricing_page"}, received)
+		assert.Equal(t, "next_node", *result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "slack", result.Output["node_type"])
+		assert.Equal(t, 200, result.Output["status_code"])
+	})
+
+	t.Run("server error is returned for retry", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte("internal_error"))
+				return
+			}
+			_, _ = w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+
+		executor := slackTestExecutor(t, ctrl, server)
+
+		result, err := executor.Execute(context.Background(), slackTestParams())
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "slack returned status 500: internal_error")
+
+		// The retry delivers the message
+		result, err = executor.Execute(context.Background(), slackTestParams())
+		require.NoError(t, err)
+		assert.Equal(t, "next_node", *result.NextNodeID)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("private webhook url is rejected", func(t *testing.T) {
+		params := slackTestParams()
+		params.Node.Config["webhook_url"] = "http://169.254.169.254/latest/meta-data"
+
+		result, err := NewSlackNodeExecutor(nil).Execute(context.Background(), params)
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "private or restricted")
+	})
+}
+
+func throttleTestParams(executionContext map[string]interface{}) NodeExecutionParams {
+	nextNodeID := "next_node"
+	return NodeExecutionParams{
+		WorkspaceID: "ws1",
+		Node: &domain.AutomationNode{
+			ID:         "throttle1",
+			Type:       domain.NodeTypeThrottle,
+			NextNodeID: &nextNodeID,
+			Config:     map[string]interface{}{"max_per_minute": 500},
+		},
+		Automation:       &domain.Automation{ID: "auto1"},
+		Contact:          &domain.ContactAutomation{ID: "ca1", ContactEmail: "test@example.com"},
+		ExecutionContext: executionContext,
+	}
+}
+
+func TestThrottleNodeExecutor_Execute(t *testing.T) {
+	assert.Equal(t, domain.NodeTypeThrottle, NewThrottleNodeExecutor(nil).NodeType())
+
+	t.Run("slot in the current minute continues", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		mockAutomationRepo.EXPECT().
+			ReserveThrottleSlot(gomock.Any(), "ws1", "auto1", "throttle1", 500, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _, _ string, _ int, now time.Time) (time.Time, error) {
+				return now.Truncate(time.Minute), nil
+			})
+
+		result, err := NewThrottleNodeExecutor(mockAutomationRepo).Execute(context.Background(), throttleTestParams(nil))
+		require.NoError(t, err)
+
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "next_node", *result.NextNodeID)
+		assert.Nil(t, result.ScheduledAt)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "throttle", result.Output["node_type"])
+		assert.Equal(t, "passed", result.Output["outcome"])
+		assert.Equal(t, 500, result.Output["max_per_minute"])
+	})
+
+	t.Run("full minute waits on the node for the reserved slot", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var reservedAt time.Time
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		mockAutomationRepo.EXPECT().
+			ReserveThrottleSlot(gomock.Any(), "ws1", "auto1", "throttle1", 500, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _, _ string, _ int, now time.Time) (time.Time, error) {
+				reservedAt = now.Truncate(time.Minute).Add(2 * time.Minute)
+				return reservedAt, nil
+			})
+
+		result, err := NewThrottleNodeExecutor(mockAutomationRepo).Execute(context.Background(), throttleTestParams(nil))
+		require.NoError(t, err)
+
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "throttle1", *result.NextNodeID)
+		require.NotNil(t, result.ScheduledAt)
+		assert.True(t, reservedAt.Equal(*result.ScheduledAt))
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "throttled", result.Output["outcome"])
+		assert.Equal(t, reservedAt.Format(time.RFC3339Nano), result.Output["reserved_at"])
+	})
+
+	t.Run("throttled contact passes at its reserved slot without reserving again", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		// No repository call is expected
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+
+		executionContext := map[string]interface{}{
+			"throttle1": map[string]interface{}{
+				"node_type":   "throttle",
+				"outcome":     "throttled",
+				"reserved_at": time.Now().UTC().Add(-time.Second).Format(time.RFC3339Nano),
+			},
+		}
+
+		result, err := NewThrottleNodeExecutor(mockAutomationRepo).Execute(context.Background(), throttleTestParams(executionContext))
+		require.NoError(t, err)
+
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "next_node", *result.NextNodeID)
+		assert.Nil(t, result.ScheduledAt)
+		assert.Equal(t, "passed", result.Output["outcome"])
+	})
+
+	t.Run("reservation error is returned for retry", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		mockAutomationRepo.EXPECT().
+			ReserveThrottleSlot(gomock.Any(), "ws1", "auto1", "throttle1", 500, gomock.Any()).
+			Return(time.Time{}, errors.New("database error"))
+
+		result, err := NewThrottleNodeExecutor(mockAutomationRepo).Execute(context.Background(), throttleTestParams(nil))
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to reserve throttle slot")
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		params := throttleTestParams(nil)
+		params.Node.Config["max_per_minute"] = 0
+
+		result, err := NewThrottleNodeExecutor(nil).Execute(context.Background(), params)
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid throttle node config")
+	})
+}
+
+func TestReenrollNodeExecutor_Execute(t *testing.T) {
+	reenrollParams := func(config map[string]interface{}, frequency domain.TriggerFrequency) NodeExecutionParams {
+		next := "email1"
+		return NodeExecutionParams{
+			WorkspaceID: "ws1",
+			Contact:     &domain.ContactAutomation{ID: "ca1", ContactEmail: "test@example.com"},
+			Node: &domain.AutomationNode{
+				ID:         "reenroll1",
+				Type:       domain.NodeTypeReenroll,
+				NextNodeID: &next,
+				Config:     config,
+			},
+			Automation: &domain.Automation{
+				ID:         "auto1",
+				RootNodeID: "trigger1",
+				Trigger:    &domain.TimelineTriggerConfig{EventKind: "contact.updated", Frequency: frequency},
+			},
+			ContactData: &domain.Contact{Email: "test@example.com"},
+		}
+	}
+
+	t.Run("re-enrolls the contact and exits the current run", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		params := reenrollParams(map[string]interface{}{"max_reenrollments": 1}, domain.TriggerFrequencyEveryTime)
+		mockAutomationRepo.EXPECT().
+			ReenrollContactAutomation(gomock.Any(), "ws1", params.Automation, "ca1", "test@example.com", 1).
+			Return(true, nil)
+
+		result, err := NewReenrollNodeExecutor(NewQueryBuilder(), nil, mockAutomationRepo).Execute(context.Background(), params)
+		require.NoError(t, err)
+
+		assert.Nil(t, result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusExited, result.Status)
+		require.NotNil(t, result.ExitReason)
+		assert.Equal(t, domain.ReenrolledExitReason, *result.ExitReason)
+		assert.Equal(t, "reenroll", result.Output["node_type"])
+		assert.Equal(t, true, result.Output["reenrolled"])
+	})
+
+	t.Run("continues once the limit is reached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		mockAutomationRepo.EXPECT().
+			ReenrollContactAutomation(gomock.Any(), "ws1", gomock.Any(), "ca1", "test@example.com", 1).
+			Return(false, nil)
+
+		params := reenrollParams(map[string]interface{}{"max_reenrollments": 1}, domain.TriggerFrequencyEveryTime)
+		result, err := NewReenrollNodeExecutor(NewQueryBuilder(), nil, mockAutomationRepo).Execute(context.Background(), params)
+		require.NoError(t, err)
+
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "email1", *result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Nil(t, result.ExitReason)
+		assert.Equal(t, false, result.Output["reenrolled"])
+		assert.Equal(t, "max_reenrollments_reached", result.Output["reason"])
+	})
+
+	t.Run("continues when the conditions are not met", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws1").Return(db, nil)
+		mock.ExpectQuery("SELECT EXISTS").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+
+		params := reenrollParams(map[string]interface{}{
+			"conditions":        buildSimpleConditionMap(),
+			"max_reenrollments": 1,
+		}, domain.TriggerFrequencyEveryTime)
+		result, err := NewReenrollNodeExecutor(NewQueryBuilder(), mockWorkspaceRepo, mockAutomationRepo).Execute(context.Background(), params)
+		require.NoError(t, err)
+
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "email1", *result.NextNodeID)
+		assert.Equal(t, "conditions_not_met", result.Output["reason"])
+	})
+
+	t.Run("continues in a once automation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+
+		params := reenrollParams(map[string]interface{}{"max_reenrollments": 1}, domain.TriggerFrequencyOnce)
+		result, err := NewReenrollNodeExecutor(NewQueryBuilder(), nil, mockAutomationRepo).Execute(context.Background(), params)
+		require.NoError(t, err)
+
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+		assert.Equal(t, "frequency_once", result.Output["reason"])
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+		mockAutomationRepo.EXPECT().
+			ReenrollContactAutomation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(false, errors.New("database error"))
+
+		params := reenrollParams(map[string]interface{}{"max_reenrollments": 1}, domain.TriggerFrequencyEveryTime)
+		result, err := NewReenrollNodeExecutor(NewQueryBuilder(), nil, mockAutomationRepo).Execute(context.Background(), params)
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to reenroll contact")
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		params := reenrollParams(map[string]interface{}{}, domain.TriggerFrequencyEveryTime)
+		result, err := NewReenrollNodeExecutor(NewQueryBuilder(), nil, nil).Execute(context.Background(), params)
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid reenroll node config")
+	})
+
+	assert.Equal(t, domain.NodeTypeReenroll, NewReenrollNodeExecutor(nil, nil, nil).NodeType())
 }
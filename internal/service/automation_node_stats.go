@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// nodeStatsKey identifies an automation across workspaces
+type nodeStatsKey struct {
+	workspaceID  string
+	automationID string
+}
+
+// nodeStatsAggregator accumulates node executions in memory so that per-node stats are
+// written once per automation and batch, rather than once per execution. The zero value is ready to use.
+type nodeStatsAggregator struct {
+	mu      sync.Mutex
+	pending map[nodeStatsKey]map[string]*domain.AutomationNodeExecutionStats
+}
+
+// record adds one execution of a node
+func (a *nodeStatsAggregator) record(workspaceID, automationID, nodeID string, duration time.Duration, failed bool) {
+	key := nodeStatsKey{workspaceID: workspaceID, automationID: automationID}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.pending == nil {
+		a.pending = make(map[nodeStatsKey]map[string]*domain.AutomationNodeExecutionStats)
+	}
+	nodes, ok := a.pending[key]
+	if !ok {
+		nodes = make(map[string]*domain.AutomationNodeExecutionStats)
+		a.pending[key] = nodes
+	}
+	stats, ok := nodes[nodeID]
+	if !ok {
+		stats = &domain.AutomationNodeExecutionStats{}
+		nodes[nodeID] = stats
+	}
+	stats.Executions++
+	stats.TotalDurationMs += duration.Milliseconds()
+	if failed {
+		stats.Failures++
+	}
+}
+
+// drain returns the accumulated executions and resets the aggregator
+func (a *nodeStatsAggregator) drain() map[nodeStatsKey]map[string]*domain.AutomationNodeExecutionStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	pending := a.pending
+	a.pending = nil
+	return pending
+}
+
+// flushNodeStats writes the node executions accumulated since the last flush, one update per automation.
+// Stats are best effort: a failed write is logged and its executions are dropped.
+func (e *AutomationExecutor) flushNodeStats(ctx context.Context) {
+	for key, nodes := range e.nodeStats.drain() {
+		if err := e.automationRepo.RecordNodeExecutionStats(ctx, key.workspaceID, key.automationID, nodes); err != nil {
+			e.logger.WithFields(map[string]interface{}{
+				"workspace_id":  key.workspaceID,
+				"automation_id": key.automationID,
+				"error":         err.Error(),
+			}).Warn("Failed to record node execution stats")
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeStatsAggregator(t *testing.T) {
+	var aggregator nodeStatsAggregator
+
+	aggregator.record("ws1", "auto1", "email-1", 40*time.Millisecond, false)
+	aggregator.record("ws1", "auto1", "email-1", 60*time.Millisecond, true)
+	aggregator.record("ws1", "auto1", "delay-1", 5*time.Millisecond, false)
+	aggregator.record("ws2", "auto1", "email-1", 10*time.Millisecond, false)
+
+	pending := aggregator.drain()
+	require.Len(t, pending, 2, "executions are grouped per workspace and automation")
+
+	nodes := pending[nodeStatsKey{workspaceID: "ws1", automationID: "auto1"}]
+	require.Len(t, nodes, 2)
+	assert.Equal(t, &domain.AutomationNodeExecutionStats{Executions: 2, Failures: 1, TotalDurationMs: 100}, nodes["email-1"])
+	assert.Equal(t, &domain.AutomationNodeExecutionStats{Executions: 1, TotalDurationMs: 5}, nodes["delay-1"])
+
+	assert.Empty(t, aggregator.drain(), "draining resets the aggregator")
+}
+
+func TestAutomationExecutor_FlushNodeStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		logger:         setupMockLogger(ctrl),
+	}
+
+	executor.nodeStats.record("ws1", "auto1", "email-1", 40*time.Millisecond, false)
+	executor.nodeStats.record("ws1", "auto2", "webhook-1", 900*time.Millisecond, true)
+
+	// One write per automation; a failed write does not stop the others
+	mockAutomationRepo.EXPECT().RecordNodeExecutionStats(gomock.Any(), "ws1", "auto1", gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().RecordNodeExecutionStats(gomock.Any(), "ws1", "auto2", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, nodes map[string]*domain.AutomationNodeExecutionStats) error {
+			assert.Equal(t, int64(1), nodes["webhook-1"].Failures)
+			return errors.New("database error")
+		})
+
+	executor.flushNodeStats(context.Background())
+
+	// Nothing is left to write
+	executor.flushNodeStats(context.Background())
+}
@@ -18,6 +18,19 @@ type AutomationScheduler struct {
 	stoppedChan chan struct{}
 	mu          sync.Mutex
 	running     bool
+
+	// Retention of finished contact automations (0 disables the purge)
+	retentionDays   int
+	cleanupInterval time.Duration
+	lastCleanupTime time.Time
+
+	// Enrollment of contacts into automations with an inactivity trigger (0 disables it)
+	inactivityInterval time.Duration
+	lastInactivityTime time.Time
+
+	// Goal checks for contacts waiting at a node (0 disables them)
+	goalInterval time.Duration
+	lastGoalTime time.Time
 }
 
 // NewAutomationScheduler creates a new automation scheduler
@@ -28,15 +41,35 @@ func NewAutomationScheduler(
 	batchSize int,
 ) *AutomationScheduler {
 	return &AutomationScheduler{
-		executor:    executor,
-		logger:      log,
-		interval:    interval,
-		batchSize:   batchSize,
-		stopChan:    make(chan struct{}),
-		stoppedChan: make(chan struct{}),
+		executor:        executor,
+		logger:          log,
+		interval:        interval,
+		batchSize:       batchSize,
+		stopChan:        make(chan struct{}),
+		stoppedChan:     make(chan struct{}),
+		cleanupInterval: 1 * time.Hour,
 	}
 }
 
+// SetRetentionDays sets how long completed and exited contact automations are kept.
+// A value of 0 keeps them forever.
+func (s *AutomationScheduler) SetRetentionDays(days int) {
+	s.retentionDays = days
+}
+
+// SetInactivityInterval sets how often contacts are enrolled into automations with an
+// inactivity trigger. A value of 0 disables the evaluation.
+func (s *AutomationScheduler) SetInactivityInterval(interval time.Duration) {
+	s.inactivityInterval = interval
+}
+
+// SetGoalInterval sets how often goals are checked for the contacts waiting at a node, e.g. a
+// delay. A value of 0 disables the checks; contacts due for processing are always checked when
+// they run.
+func (s *AutomationScheduler) SetGoalInterval(interval time.Duration) {
+	s.goalInterval = interval
+}
+
 // Start begins the automation execution scheduler
 func (s *AutomationScheduler) Start(ctx context.Context) {
 	s.mu.Lock()
@@ -119,6 +152,80 @@ func (s *AutomationScheduler) processBatch(ctx context.Context) {
 			WithField("elapsed", elapsed).
 			Info("Processed automation batch")
 	}
+
+	s.checkGoals(ctx)
+	s.purgeFinished(ctx)
+	s.enrollInactive(ctx)
+}
+
+// checkGoals exits the waiting contacts that met a goal of their automation
+func (s *AutomationScheduler) checkGoals(ctx context.Context) {
+	if s.goalInterval <= 0 {
+		return
+	}
+	// Skip if not enough time has passed since last check
+	if time.Since(s.lastGoalTime) < s.goalInterval {
+		return
+	}
+	s.lastGoalTime = time.Now()
+
+	exited, err := s.executor.ProcessGoals(ctx)
+	if err != nil {
+		s.logger.WithField("error", err.Error()).
+			Error("Failed to check automation goals")
+		return
+	}
+	if exited > 0 {
+		s.logger.WithField("exited", exited).
+			Info("Exited contacts that met an automation goal")
+	}
+}
+
+// purgeFinished removes finished contact automations older than the retention period
+func (s *AutomationScheduler) purgeFinished(ctx context.Context) {
+	if s.retentionDays <= 0 {
+		return
+	}
+	// Skip if not enough time has passed since last cleanup
+	if time.Since(s.lastCleanupTime) < s.cleanupInterval {
+		return
+	}
+	s.lastCleanupTime = time.Now()
+
+	deleted, err := s.executor.PurgeFinishedContactAutomations(ctx, s.retentionDays)
+	if err != nil {
+		s.logger.WithField("error", err.Error()).
+			Error("Failed to purge finished contact automations")
+		return
+	}
+	if deleted > 0 {
+		s.logger.WithField("deleted", deleted).
+			WithField("retention_days", s.retentionDays).
+			Info("Purged finished contact automations")
+	}
+}
+
+// enrollInactive enrolls inactive contacts into automations with an inactivity trigger
+func (s *AutomationScheduler) enrollInactive(ctx context.Context) {
+	if s.inactivityInterval <= 0 {
+		return
+	}
+	// Skip if not enough time has passed since last evaluation
+	if time.Since(s.lastInactivityTime) < s.inactivityInterval {
+		return
+	}
+	s.lastInactivityTime = time.Now()
+
+	enrolled, err := s.executor.EnrollInactiveContacts(ctx)
+	if err != nil {
+		s.logger.WithField("error", err.Error()).
+			Error("Failed to enroll inactive contacts")
+		return
+	}
+	if enrolled > 0 {
+		s.logger.WithField("enrolled", enrolled).
+			Info("Enrolled inactive contacts")
+	}
 }
 
 // IsRunning returns whether the scheduler is currently running
@@ -275,6 +275,7 @@ func TestAutomationScheduler_ProcessBatchOnInterval(t *testing.T) {
 	mockAutomationRepo.EXPECT().UpdateContactAutomation(gomock.Any(), "ws1", gomock.Any()).Return(nil).AnyTimes()
 	mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), "ws1", gomock.Any()).Return(nil).AnyTimes()
 	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), "ws1", "auto1", "completed").Return(nil).AnyTimes()
+	mockAutomationRepo.EXPECT().RecordNodeExecutionStats(gomock.Any(), "ws1", "auto1", gomock.Any()).Return(nil).AnyTimes()
 	mockTimelineRepo.EXPECT().Create(gomock.Any(), "ws1", gomock.Any()).Return(nil).AnyTimes()
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -420,3 +421,127 @@ func TestAutomationScheduler_PausedAutomationsNotProcessed(t *testing.T) {
 	// Test passes if no contacts are processed (empty result from scheduler query)
 	// The key behavior is that the SQL query itself filters out paused automations
 }
+
+func TestAutomationScheduler_PurgeFinished(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		workspaceRepo:  mockWorkspaceRepo,
+		nodeExecutors:  map[domain.NodeType]NodeExecutor{},
+		logger:         mockLogger,
+	}
+
+	t.Run("disabled when retention is zero", func(t *testing.T) {
+		scheduler := NewAutomationScheduler(executor, mockLogger, time.Second, 50)
+
+		mockAutomationRepo.EXPECT().GetScheduledContactAutomationsGlobal(gomock.Any(), gomock.Any(), 50).
+			Return([]*domain.ContactAutomationWithWorkspace{}, nil)
+
+		// No workspace listing expected
+		scheduler.processBatch(context.Background())
+	})
+
+	t.Run("purges at most once per cleanup interval", func(t *testing.T) {
+		scheduler := NewAutomationScheduler(executor, mockLogger, time.Second, 50)
+		scheduler.SetRetentionDays(30)
+
+		mockAutomationRepo.EXPECT().GetScheduledContactAutomationsGlobal(gomock.Any(), gomock.Any(), 50).
+			Return([]*domain.ContactAutomationWithWorkspace{}, nil).Times(2)
+		mockWorkspaceRepo.EXPECT().List(gomock.Any()).Return([]*domain.Workspace{{ID: "ws1"}}, nil).Times(1)
+		mockAutomationRepo.EXPECT().DeleteFinishedContactAutomations(gomock.Any(), "ws1", gomock.Any(), purgeBatchSize).
+			Return(int64(3), nil).Times(1)
+
+		scheduler.processBatch(context.Background())
+		scheduler.processBatch(context.Background())
+
+		require.False(t, scheduler.lastCleanupTime.IsZero())
+	})
+}
+
+func TestAutomationScheduler_EnrollInactive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		workspaceRepo:  mockWorkspaceRepo,
+		nodeExecutors:  map[domain.NodeType]NodeExecutor{},
+		logger:         mockLogger,
+	}
+
+	t.Run("disabled when interval is zero", func(t *testing.T) {
+		scheduler := NewAutomationScheduler(executor, mockLogger, time.Second, 50)
+
+		mockAutomationRepo.EXPECT().GetScheduledContactAutomationsGlobal(gomock.Any(), gomock.Any(), 50).
+			Return([]*domain.ContactAutomationWithWorkspace{}, nil)
+
+		// No workspace listing expected
+		scheduler.processBatch(context.Background())
+	})
+
+	t.Run("evaluates at most once per interval", func(t *testing.T) {
+		scheduler := NewAutomationScheduler(executor, mockLogger, time.Second, 50)
+		scheduler.SetInactivityInterval(time.Hour)
+
+		mockAutomationRepo.EXPECT().GetScheduledContactAutomationsGlobal(gomock.Any(), gomock.Any(), 50).
+			Return([]*domain.ContactAutomationWithWorkspace{}, nil).Times(2)
+		mockWorkspaceRepo.EXPECT().List(gomock.Any()).Return([]*domain.Workspace{{ID: "ws1"}}, nil).Times(1)
+		mockAutomationRepo.EXPECT().List(gomock.Any(), "ws1", gomock.Any()).Return([]*domain.Automation{}, 0, nil).Times(1)
+
+		scheduler.processBatch(context.Background())
+		scheduler.processBatch(context.Background())
+
+		require.False(t, scheduler.lastInactivityTime.IsZero())
+	})
+}
+
+func TestAutomationScheduler_CheckGoals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		workspaceRepo:  mockWorkspaceRepo,
+		nodeExecutors:  map[domain.NodeType]NodeExecutor{},
+		logger:         mockLogger,
+	}
+
+	t.Run("disabled when interval is zero", func(t *testing.T) {
+		scheduler := NewAutomationScheduler(executor, mockLogger, time.Second, 50)
+
+		mockAutomationRepo.EXPECT().GetScheduledContactAutomationsGlobal(gomock.Any(), gomock.Any(), 50).
+			Return([]*domain.ContactAutomationWithWorkspace{}, nil)
+
+		// No workspace listing expected
+		scheduler.processBatch(context.Background())
+	})
+
+	t.Run("checks goals at most once per interval", func(t *testing.T) {
+		scheduler := NewAutomationScheduler(executor, mockLogger, time.Second, 50)
+		scheduler.SetGoalInterval(time.Minute)
+
+		mockAutomationRepo.EXPECT().GetScheduledContactAutomationsGlobal(gomock.Any(), gomock.Any(), 50).
+			Return([]*domain.ContactAutomationWithWorkspace{}, nil).Times(2)
+		mockWorkspaceRepo.EXPECT().List(gomock.Any()).Return([]*domain.Workspace{{ID: "ws1"}}, nil).Times(1)
+		mockAutomationRepo.EXPECT().List(gomock.Any(), "ws1", gomock.Any()).Return([]*domain.Automation{}, 0, nil).Times(1)
+
+		scheduler.processBatch(context.Background())
+		scheduler.processBatch(context.Background())
+
+		require.False(t, scheduler.lastGoalTime.IsZero())
+	})
+}
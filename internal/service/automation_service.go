@@ -2,17 +2,34 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"sync"
+	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/Notifuse/notifuse/pkg/safehttpclient"
+	"github.com/asaskevich/govalidator"
 )
 
+// webhookCheckTimeout bounds the save-time reachability check of a webhook URL
+const webhookCheckTimeout = 5 * time.Second
+
 // AutomationService handles automation business logic
 type AutomationService struct {
 	repo        domain.AutomationRepository
 	authService domain.AuthService
 	logger      logger.Logger
+	httpClient  *http.Client // SSRF-safe client for save-time webhook checks
+	emailSvc    domain.EmailServiceInterface
+	contactRepo domain.ContactRepository
+	listRepo    domain.ListRepository
+	segmentRepo domain.SegmentRepository
+
+	workspaceRepo   domain.WorkspaceRepository // Workspace secret key encrypting mTLS client keys
+	webhookExecutor *WebhookNodeExecutor       // Fires webhook nodes for authoring tests
 }
 
 // NewAutomationService creates a new AutomationService
@@ -25,6 +42,9 @@ func NewAutomationService(
 		repo:        repo,
 		authService: authService,
 		logger:      logger,
+		httpClient:  safehttpclient.New(),
+
+		webhookExecutor: NewWebhookNodeExecutor(logger),
 	}
 }
 
@@ -43,15 +63,28 @@ func (s *AutomationService) Create(ctx context.Context, workspaceID string, auto
 		)
 	}
 
+	secretKey, err := s.restoreWebhookClientKeys(ctx, workspaceID, automation, false)
+	if err != nil {
+		return err
+	}
+
+	automation.Tags = domain.NormalizeAutomationTags(automation.Tags)
 	if err := automation.Validate(); err != nil {
 		return fmt.Errorf("invalid automation: %w", err)
 	}
+	if err := automation.ValidateNodeConfigs(); err != nil {
+		return err
+	}
 
+	if err := automation.EncryptWebhookClientKeys(secretKey); err != nil {
+		return err
+	}
 	if err := s.repo.Create(ctx, workspaceID, automation); err != nil {
 		s.logger.WithField("automation_id", automation.ID).Error(fmt.Sprintf("failed to create automation: %v", err))
 		return fmt.Errorf("failed to create automation: %w", err)
 	}
 
+	automation.RedactWebhookClientKeys()
 	return nil
 }
 
@@ -75,6 +108,7 @@ func (s *AutomationService) Get(ctx context.Context, workspaceID, automationID s
 		return nil, fmt.Errorf("failed to get automation: %w", err)
 	}
 
+	automation.RedactWebhookClientKeys()
 	return automation, nil
 }
 
@@ -98,6 +132,9 @@ func (s *AutomationService) List(ctx context.Context, workspaceID string, filter
 		return nil, 0, fmt.Errorf("failed to list automations: %w", err)
 	}
 
+	for _, automation := range automations {
+		automation.RedactWebhookClientKeys()
+	}
 	return automations, count, nil
 }
 
@@ -116,6 +153,13 @@ func (s *AutomationService) Update(ctx context.Context, workspaceID string, auto
 		)
 	}
 
+	// The stored keys are kept for the nodes the request sends without one
+	secretKey, err := s.restoreWebhookClientKeys(ctx, workspaceID, automation, true)
+	if err != nil {
+		return err
+	}
+
+	automation.Tags = domain.NormalizeAutomationTags(automation.Tags)
 	if err := automation.Validate(); err != nil {
 		return fmt.Errorf("invalid automation: %w", err)
 	}
@@ -127,14 +171,73 @@ func (s *AutomationService) Update(ctx context.Context, workspaceID string, auto
 		}
 	}
 
+	if err := automation.ValidateNodeConfigs(); err != nil {
+		return err
+	}
+
+	if err := automation.EncryptWebhookClientKeys(secretKey); err != nil {
+		return err
+	}
 	if err := s.repo.Update(ctx, workspaceID, automation); err != nil {
 		s.logger.WithField("automation_id", automation.ID).Error(fmt.Sprintf("failed to update automation: %v", err))
 		return fmt.Errorf("failed to update automation: %w", err)
 	}
 
+	automation.RedactWebhookClientKeys()
 	return nil
 }
 
+// SetTags replaces the tags of an automation
+func (s *AutomationService) SetTags(ctx context.Context, workspaceID, automationID string, tags []string) error {
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceAutomations, domain.PermissionTypeWrite) {
+		return domain.NewPermissionError(
+			domain.PermissionResourceAutomations,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to automations required",
+		)
+	}
+
+	tags = domain.NormalizeAutomationTags(tags)
+	if err := domain.ValidateAutomationTags(tags); err != nil {
+		return fmt.Errorf("invalid tags: %w", err)
+	}
+
+	if err := s.repo.UpdateTags(ctx, workspaceID, automationID, tags); err != nil {
+		s.logger.WithField("automation_id", automationID).Error(fmt.Sprintf("failed to update automation tags: %v", err))
+		return fmt.Errorf("failed to update automation tags: %w", err)
+	}
+
+	return nil
+}
+
+// ListTags returns the distinct tags used across the workspace's automations
+func (s *AutomationService) ListTags(ctx context.Context, workspaceID string) ([]string, error) {
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceAutomations, domain.PermissionTypeRead) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceAutomations,
+			domain.PermissionTypeRead,
+			"Insufficient permissions: read access to automations required",
+		)
+	}
+
+	tags, err := s.repo.ListTags(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list automation tags: %w", err)
+	}
+
+	return tags, nil
+}
+
 // Delete soft-deletes an automation (can delete live automations)
 // The repository handles dropping triggers and exiting active contacts
 func (s *AutomationService) Delete(ctx context.Context, workspaceID, automationID string) error {
@@ -163,6 +266,164 @@ func (s *AutomationService) Delete(ctx context.Context, workspaceID, automationI
 	return nil
 }
 
+// SetEmailService sets the email service used by integration checks (used to avoid circular dependencies)
+func (s *AutomationService) SetEmailService(emailSvc domain.EmailServiceInterface) {
+	s.emailSvc = emailSvc
+}
+
+// SetContactRepository sets the contact repository used to load sample contacts for node tests
+func (s *AutomationService) SetContactRepository(contactRepo domain.ContactRepository) {
+	s.contactRepo = contactRepo
+}
+
+// SetListRepository sets the list repository used to check trigger lists at activation
+func (s *AutomationService) SetListRepository(listRepo domain.ListRepository) {
+	s.listRepo = listRepo
+}
+
+// SetSegmentRepository sets the segment repository used to check trigger segments at activation
+func (s *AutomationService) SetSegmentRepository(segmentRepo domain.SegmentRepository) {
+	s.segmentRepo = segmentRepo
+}
+
+// SetWorkspaceRepository sets the workspace repository whose secret key encrypts the mTLS
+// client keys of webhook nodes
+func (s *AutomationService) SetWorkspaceRepository(workspaceRepo domain.WorkspaceRepository) {
+	s.workspaceRepo = workspaceRepo
+	s.webhookExecutor.SetWorkspaceRepository(workspaceRepo)
+}
+
+// restoreWebhookClientKeys fills in the mTLS client keys that webhook nodes leave out, as API
+// responses redact them, with the keys of the stored automation when it is updated. It returns
+// the workspace secret key encrypting them, and does nothing when no webhook node uses mTLS.
+func (s *AutomationService) restoreWebhookClientKeys(ctx context.Context, workspaceID string, automation *domain.Automation, update bool) (string, error) {
+	if !automation.HasWebhookMTLS() {
+		return "", nil
+	}
+	if s.workspaceRepo == nil {
+		return "", fmt.Errorf("workspace repository not configured")
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	var stored *domain.Automation
+	if update {
+		if stored, err = s.repo.GetByID(ctx, workspaceID, automation.ID); err != nil {
+			return "", fmt.Errorf("failed to get automation: %w", err)
+		}
+	}
+	if err := automation.RestoreWebhookClientKeys(stored, workspace.Settings.SecretKey); err != nil {
+		return "", err
+	}
+	return workspace.Settings.SecretKey, nil
+}
+
+// checkTriggerReferences returns a validation error when a trigger event references a list or
+// segment that does not exist (or was deleted), as the trigger would then never enroll anyone
+func (s *AutomationService) checkTriggerReferences(ctx context.Context, workspaceID string, trigger *domain.TimelineTriggerConfig) error {
+	if trigger == nil {
+		return nil
+	}
+
+	for _, spec := range trigger.EventSpecs() {
+		if spec.ListID != nil && *spec.ListID != "" && s.listRepo != nil {
+			_, err := s.listRepo.GetListByID(ctx, workspaceID, *spec.ListID)
+			var listNotFound *domain.ErrListNotFound
+			if errors.As(err, &listNotFound) {
+				return domain.NewValidationError(fmt.Sprintf("trigger event %s references list %s, which does not exist", spec.EventKind, *spec.ListID))
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get trigger list: %w", err)
+			}
+		}
+
+		if spec.SegmentID != nil && *spec.SegmentID != "" && s.segmentRepo != nil {
+			segment, err := s.segmentRepo.GetSegmentByID(ctx, workspaceID, *spec.SegmentID)
+			var segmentNotFound *domain.ErrSegmentNotFound
+			if errors.As(err, &segmentNotFound) || (err == nil && segment.Status == string(domain.SegmentStatusDeleted)) {
+				return domain.NewValidationError(fmt.Sprintf("trigger event %s references segment %s, which does not exist", spec.EventKind, *spec.SegmentID))
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get trigger segment: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// CheckIntegrations probes every email integration the automation's email nodes can send
+// through (sender rules, node override and workspace marketing provider), returning a
+// validation error naming the first one that cannot connect
+func (s *AutomationService) CheckIntegrations(ctx context.Context, workspaceID, automationID string) error {
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceAutomations, domain.PermissionTypeRead) {
+		return domain.NewPermissionError(
+			domain.PermissionResourceAutomations,
+			domain.PermissionTypeRead,
+			"Insufficient permissions: read access to automations required",
+		)
+	}
+
+	if s.emailSvc == nil {
+		return fmt.Errorf("email service not configured")
+	}
+
+	automation, err := s.repo.GetByID(ctx, workspaceID, automationID)
+	if err != nil {
+		return fmt.Errorf("failed to get automation: %w", err)
+	}
+
+	for _, integrationID := range emailNodeIntegrationIDs(automation.Nodes) {
+		if err := s.emailSvc.CheckIntegration(ctx, workspaceID, integrationID); err != nil {
+			return domain.NewValidationError(fmt.Sprintf("email integration check failed: %s", err.Error()))
+		}
+	}
+
+	return nil
+}
+
+// emailNodeIntegrationIDs lists the integrations email nodes can send through, in node
+// order and without duplicates. An empty ID stands for the workspace marketing provider.
+func emailNodeIntegrationIDs(nodes []*domain.AutomationNode) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, node := range nodes {
+		if node == nil || node.Type != domain.NodeTypeEmail {
+			continue
+		}
+		config, err := parseEmailNodeConfig(node.Config)
+		if err != nil {
+			continue
+		}
+		for _, rule := range config.SenderRules {
+			add(rule.IntegrationID)
+		}
+		// Contacts matching no sender rule use the node override or the workspace default
+		if config.IntegrationID != nil && *config.IntegrationID != "" {
+			add(*config.IntegrationID)
+		} else {
+			add("")
+		}
+	}
+
+	return ids
+}
+
 // Activate activates an automation (changes status to live and creates trigger)
 func (s *AutomationService) Activate(ctx context.Context, workspaceID, automationID string) error {
 	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
@@ -196,8 +457,16 @@ func (s *AutomationService) Activate(ctx context.Context, workspaceID, automatio
 		}
 	}
 
-	// Update status to live
+	if err := s.checkTriggerReferences(ctx, workspaceID, automation.Trigger); err != nil {
+		return err
+	}
+
+	// Update status to live, starting the enrollment ramp from now
 	automation.Status = domain.AutomationStatusLive
+	if automation.EnrollmentRamp != nil {
+		startedAt := time.Now().UTC()
+		automation.EnrollmentRamp.StartedAt = &startedAt
+	}
 	if err := s.repo.Update(ctx, workspaceID, automation); err != nil {
 		return fmt.Errorf("failed to update automation status: %w", err)
 	}
@@ -282,3 +551,326 @@ func (s *AutomationService) GetContactNodeExecutions(ctx context.Context, worksp
 
 	return contactAutomation, entries, nil
 }
+
+// GetNodeStats returns the execution counters and average duration of each node of an automation
+func (s *AutomationService) GetNodeStats(ctx context.Context, workspaceID, automationID string) (map[string]*domain.AutomationNodeExecutionStats, error) {
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceAutomations, domain.PermissionTypeRead) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceAutomations,
+			domain.PermissionTypeRead,
+			"Insufficient permissions: read access to automations required",
+		)
+	}
+
+	automation, err := s.repo.GetByID(ctx, workspaceID, automationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get automation: %w", err)
+	}
+
+	if automation.Stats == nil || automation.Stats.Nodes == nil {
+		return map[string]*domain.AutomationNodeExecutionStats{}, nil
+	}
+	return automation.Stats.Nodes, nil
+}
+
+// GetContactNextTick previews what the scheduler will do next for a contact in an automation
+func (s *AutomationService) GetContactNextTick(ctx context.Context, workspaceID, automationID, email string) (*domain.ContactNextTick, error) {
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceAutomations, domain.PermissionTypeRead) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceAutomations,
+			domain.PermissionTypeRead,
+			"Insufficient permissions: read access to automations required",
+		)
+	}
+
+	automation, err := s.repo.GetByID(ctx, workspaceID, automationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get automation: %w", err)
+	}
+
+	contactAutomation, err := s.repo.GetContactAutomationByEmail(ctx, workspaceID, automationID, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact automation: %w", err)
+	}
+
+	automation, err = pinnedAutomationVersion(ctx, s.repo, workspaceID, automation, contactAutomation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get automation version: %w", err)
+	}
+
+	return domain.PreviewNextTick(automation, contactAutomation), nil
+}
+
+// TestWebhookNode fires a webhook node once against a contact and returns the captured
+// request and response. The contact is not enrolled and nothing is recorded: the HTTP call
+// is the only side effect. A failed call is reported in the result, not as an error.
+func (s *AutomationService) TestWebhookNode(ctx context.Context, workspaceID, automationID, nodeID, email string) (*domain.WebhookNodeTestResult, error) {
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceAutomations, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceAutomations,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to automations required",
+		)
+	}
+
+	if s.contactRepo == nil {
+		return nil, fmt.Errorf("contact repository not configured")
+	}
+
+	automation, err := s.repo.GetByID(ctx, workspaceID, automationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get automation: %w", err)
+	}
+
+	node := automation.GetNodeByID(nodeID)
+	if node == nil {
+		return nil, domain.NewValidationError(fmt.Sprintf("node %s not found in automation", nodeID))
+	}
+	if node.Type != domain.NodeTypeWebhook {
+		return nil, domain.NewValidationError(fmt.Sprintf("node %s has type %s, not webhook", nodeID, node.Type))
+	}
+
+	contact, err := s.contactRepo.GetContactByEmail(ctx, workspaceID, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact: %w", err)
+	}
+
+	// Capture the exchange whatever the automation's log_webhooks setting
+	sandbox := *automation
+	sandbox.LogWebhooks = true
+
+	params := NodeExecutionParams{
+		WorkspaceID: workspaceID,
+		Contact: &domain.ContactAutomation{
+			AutomationID:  automation.ID,
+			ContactEmail:  contact.Email,
+			CurrentNodeID: &node.ID,
+			Status:        domain.ContactAutomationStatusActive,
+		},
+		Node:             node,
+		Automation:       &sandbox,
+		ContactData:      contact,
+		ExecutionContext: make(map[string]interface{}),
+	}
+
+	nodeResult, execErr := s.webhookExecutor.Execute(ctx, params)
+
+	result := &domain.WebhookNodeTestResult{}
+	if nodeResult != nil {
+		if exchange, ok := nodeResult.Output["exchange"].(map[string]interface{}); ok {
+			result.Exchange = exchange
+		}
+	}
+	if execErr != nil {
+		result.Error = execErr.Error()
+		return result, nil
+	}
+
+	result.Output = nodeResult.Output
+	delete(result.Output, "exchange")
+	return result, nil
+}
+
+// EnrollContacts enrolls a batch of contacts in a live automation. Each email is processed
+// on its own and reported in the result, so invalid or unknown emails don't block the others.
+func (s *AutomationService) EnrollContacts(ctx context.Context, workspaceID, automationID string, emails []string) (*domain.BulkOperationResult, error) {
+	ctx, automation, err := s.getLiveAutomationForEnrollment(ctx, workspaceID, automationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := domain.NewBulkOperationResult(len(emails))
+	seen := make(map[string]bool, len(emails))
+
+	for _, email := range emails {
+		if !govalidator.IsEmail(email) {
+			result.Add(email, domain.BulkItemStatusFailed, "invalid email")
+			continue
+		}
+		if seen[email] {
+			result.Add(email, domain.BulkItemStatusSkipped, "duplicate email")
+			continue
+		}
+		seen[email] = true
+
+		status, reason := s.enrollContact(ctx, workspaceID, automation, email)
+		result.Add(email, status, reason)
+	}
+
+	return result, nil
+}
+
+// reenrollSegmentBatchSize is the number of segment members loaded per page when re-enrolling a segment
+const reenrollSegmentBatchSize = 500
+
+// ReenrollContacts enrolls contacts again at the root node of a live automation, e.g. after it
+// was deleted and recreated. Contacts are given as emails or as the members of a segment.
+// The trigger frequency applies like for any enrollment, and contacts already active in the
+// automation are skipped.
+func (s *AutomationService) ReenrollContacts(ctx context.Context, workspaceID, automationID string, emails []string, segmentID string) (*domain.ReenrollContactsResult, error) {
+	ctx, automation, err := s.getLiveAutomationForEnrollment(ctx, workspaceID, automationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ReenrollContactsResult{}
+	enroll := func(email string) {
+		switch status, _ := s.enrollContact(ctx, workspaceID, automation, email); status {
+		case domain.BulkItemStatusCreated:
+			result.Enrolled++
+		case domain.BulkItemStatusSkipped:
+			result.Skipped++
+		default:
+			result.Failed++
+		}
+	}
+
+	if segmentID == "" {
+		seen := make(map[string]bool, len(emails))
+		for _, email := range emails {
+			if !govalidator.IsEmail(email) {
+				result.Failed++
+				continue
+			}
+			if seen[email] {
+				result.Skipped++
+				continue
+			}
+			seen[email] = true
+			enroll(email)
+		}
+		return result, nil
+	}
+
+	afterEmail := ""
+	for {
+		batch, err := s.repo.GetSegmentContactEmails(ctx, workspaceID, segmentID, afterEmail, reenrollSegmentBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get segment contacts: %w", err)
+		}
+		for _, email := range batch {
+			enroll(email)
+		}
+		if len(batch) < reenrollSegmentBatchSize {
+			return result, nil
+		}
+		afterEmail = batch[len(batch)-1]
+	}
+}
+
+// getLiveAutomationForEnrollment checks that the user can write automations and returns the
+// automation to enroll contacts in, which must be live
+func (s *AutomationService) getLiveAutomationForEnrollment(ctx context.Context, workspaceID, automationID string) (context.Context, *domain.Automation, error) {
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceAutomations, domain.PermissionTypeWrite) {
+		return nil, nil, domain.NewPermissionError(
+			domain.PermissionResourceAutomations,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to automations required",
+		)
+	}
+
+	automation, err := s.repo.GetByID(ctx, workspaceID, automationID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get automation: %w", err)
+	}
+
+	if automation.Status != domain.AutomationStatusLive {
+		return nil, nil, fmt.Errorf("automation must be live to enroll contacts")
+	}
+
+	return ctx, automation, nil
+}
+
+// enrollContact enrolls a single contact and maps the outcome to a bulk item status and reason
+func (s *AutomationService) enrollContact(ctx context.Context, workspaceID string, automation *domain.Automation, email string) (domain.BulkItemStatus, string) {
+	enrolled, err := s.repo.EnrollContact(ctx, workspaceID, automation, email)
+	switch {
+	case errors.Is(err, domain.ErrEnrollmentContactNotFound):
+		return domain.BulkItemStatusFailed, err.Error()
+	case err != nil:
+		s.logger.WithField("automation_id", automation.ID).Error(fmt.Sprintf("failed to enroll contact %s: %v", email, err))
+		return domain.BulkItemStatusFailed, "failed to enroll contact"
+	case !enrolled:
+		return domain.BulkItemStatusSkipped, "already enrolled"
+	default:
+		return domain.BulkItemStatusCreated, ""
+	}
+}
+
+// CheckWebhookURLs sends a HEAD request to the URL of each webhook node and returns a warning
+// for every URL that is unreachable or resolves to a private address. Any HTTP response,
+// whatever its status, counts as reachable. The checks never block a save.
+func (s *AutomationService) CheckWebhookURLs(ctx context.Context, automation *domain.Automation) []string {
+	var nodes []*domain.AutomationNode
+	for _, node := range automation.Nodes {
+		if node != nil && node.Type == domain.NodeTypeWebhook {
+			nodes = append(nodes, node)
+		}
+	}
+
+	results := make([]string, len(nodes))
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node *domain.AutomationNode) {
+			defer wg.Done()
+			results[i] = s.checkWebhookURL(ctx, node)
+		}(i, node)
+	}
+	wg.Wait()
+
+	var warnings []string
+	for _, warning := range results {
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	return warnings
+}
+
+// checkWebhookURL returns a warning for a webhook node whose URL can't be reached, or "" when it can
+func (s *AutomationService) checkWebhookURL(ctx context.Context, node *domain.AutomationNode) string {
+	config, err := parseWebhookNodeConfig(node.Config)
+	if err != nil {
+		return fmt.Sprintf("webhook node %s: %v", node.ID, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, config.URL, nil)
+	if err != nil {
+		return fmt.Sprintf("webhook node %s: invalid URL %s: %v", node.ID, config.URL, err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, safehttpclient.ErrPrivateIP) {
+			return fmt.Sprintf("webhook node %s: URL %s resolves to a private or reserved address", node.ID, config.URL)
+		}
+		return fmt.Sprintf("webhook node %s: URL %s is not reachable: %v", node.ID, config.URL, err)
+	}
+	_ = resp.Body.Close()
+
+	return ""
+}
@@ -3,6 +3,11 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,6 +16,7 @@ import (
 	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Helper function to create test automation
@@ -106,6 +112,33 @@ func TestAutomationService_Create(t *testing.T) {
 		assert.Contains(t, err.Error(), "invalid automation")
 	})
 
+	t.Run("invalid node configs are reported per node", func(t *testing.T) {
+		automation := createTestAutomationService("auto-123", workspaceID)
+		delayNode := createTestAutomationNodeService("delay-1", "auto-123", domain.NodeTypeDelay)
+		delayNode.Config = map[string]interface{}{"duration": -5, "unit": "minutes"}
+		emailNode := createTestAutomationNodeService("email-1", "auto-123", domain.NodeTypeEmail)
+		emailNode.Config = map[string]interface{}{"subject_override": "Hello"}
+		automation.Nodes = []*domain.AutomationNode{delayNode, emailNode}
+		automation.RootNodeID = "delay-1"
+
+		userWorkspace := &domain.UserWorkspace{
+			UserID:      "user-123",
+			WorkspaceID: workspaceID,
+			Role:        "admin",
+			Permissions: domain.FullPermissions,
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+
+		err := service.Create(ctx, workspaceID, automation)
+		var nodeErr *domain.NodeConfigValidationError
+		require.ErrorAs(t, err, &nodeErr)
+		assert.Equal(t, map[string]domain.NodeConfigFieldError{
+			"delay-1": {Field: "duration", Message: "duration must be positive"},
+			"email-1": {Field: "template_id", Message: "template_id is required"},
+		}, nodeErr.NodeErrors)
+		assert.EqualError(t, err, "invalid node config: node delay-1: duration must be positive; node email-1: template_id is required")
+	})
+
 	t.Run("repository failure", func(t *testing.T) {
 		automation := createTestAutomationService("auto-123", workspaceID)
 
@@ -309,9 +342,9 @@ func TestAutomationService_Update(t *testing.T) {
 	t.Run("remove list_id without email nodes - allowed", func(t *testing.T) {
 		automation := createTestAutomationService("auto-123", workspaceID)
 		automation.ListID = "" // Removing list_id
-		automation.Nodes = []*domain.AutomationNode{
-			createTestAutomationNodeService("node-1", "auto-123", domain.NodeTypeDelay),
-		}
+		delayNode := createTestAutomationNodeService("node-1", "auto-123", domain.NodeTypeDelay)
+		delayNode.Config = map[string]interface{}{"duration": 1, "unit": "days"}
+		automation.Nodes = []*domain.AutomationNode{delayNode}
 		automation.RootNodeID = "node-1" // Must reference a valid node
 
 		userWorkspace := &domain.UserWorkspace{
@@ -328,6 +361,210 @@ func TestAutomationService_Update(t *testing.T) {
 	})
 }
 
+func TestAutomationService_WebhookClientKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	service := NewAutomationService(mockRepo, mockAuthService, mockLogger)
+	service.SetWorkspaceRepository(mockWorkspaceRepo)
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	secretKey := "workspace-secret-key"
+	certPEM, keyPEM, _ := generateClientCertPEM(t)
+
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user-123",
+		WorkspaceID: workspaceID,
+		Role:        "admin",
+		Permissions: domain.FullPermissions,
+	}
+	workspace := &domain.Workspace{ID: workspaceID, Settings: domain.WorkspaceSettings{SecretKey: secretKey}}
+
+	newAutomation := func(mtls map[string]interface{}) *domain.Automation {
+		automation := createTestAutomationService("auto-123", workspaceID)
+		node := createTestAutomationNodeService("node-root", "auto-123", domain.NodeTypeWebhook)
+		node.Config = map[string]interface{}{"url": "https://example.com/hook", "mtls": mtls}
+		automation.Nodes = []*domain.AutomationNode{node}
+		return automation
+	}
+	mtlsOf := func(automation *domain.Automation) map[string]interface{} {
+		return automation.Nodes[0].Config["mtls"].(map[string]interface{})
+	}
+
+	var stored *domain.Automation
+
+	t.Run("create stores the key encrypted and redacts it", func(t *testing.T) {
+		automation := newAutomation(map[string]interface{}{"client_cert": certPEM, "client_key": keyPEM})
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+		mockRepo.EXPECT().Create(gomock.Any(), workspaceID, automation).
+			DoAndReturn(func(_ context.Context, _ string, automation *domain.Automation) error {
+				mtls := mtlsOf(automation)
+				assert.NotContains(t, mtls, "client_key")
+				require.NotEmpty(t, mtls["encrypted_client_key"])
+
+				stored = newAutomation(map[string]interface{}{"client_cert": certPEM, "encrypted_client_key": mtls["encrypted_client_key"]})
+				return nil
+			})
+
+		err := service.Create(ctx, workspaceID, automation)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"client_cert": certPEM}, mtlsOf(automation))
+	})
+
+	t.Run("update keeps the stored key when none is sent", func(t *testing.T) {
+		require.NotNil(t, stored)
+		automation := newAutomation(map[string]interface{}{"client_cert": certPEM})
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+		mockRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto-123").Return(stored, nil)
+		mockRepo.EXPECT().Update(gomock.Any(), workspaceID, automation).
+			DoAndReturn(func(_ context.Context, _ string, automation *domain.Automation) error {
+				config := domain.WebhookMTLSConfig{EncryptedClientKey: mtlsOf(automation)["encrypted_client_key"].(string)}
+				require.NoError(t, config.DecryptClientKey(secretKey))
+				assert.Equal(t, keyPEM, config.ClientKey)
+				return nil
+			})
+
+		err := service.Update(ctx, workspaceID, automation)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"client_cert": certPEM}, mtlsOf(automation))
+	})
+
+	t.Run("update rejects a new certificate without its key", func(t *testing.T) {
+		otherCertPEM, _, _ := generateClientCertPEM(t)
+		automation := newAutomation(map[string]interface{}{"client_cert": otherCertPEM})
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+		mockRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto-123").Return(stored, nil)
+
+		err := service.Update(ctx, workspaceID, automation)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid mtls client certificate/key pair")
+	})
+
+	t.Run("get redacts the key", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto-123").
+			Return(newAutomation(map[string]interface{}{"client_cert": certPEM, "encrypted_client_key": "a1b2c3"}), nil)
+
+		automation, err := service.Get(ctx, workspaceID, "auto-123")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"client_cert": certPEM}, mtlsOf(automation))
+	})
+}
+
+func TestAutomationService_SetTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	service := NewAutomationService(mockRepo, mockAuthService, mockLogger)
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	automationID := "auto-123"
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user-123",
+		WorkspaceID: workspaceID,
+		Role:        "admin",
+		Permissions: domain.FullPermissions,
+	}
+
+	t.Run("normalizes tags before saving", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().UpdateTags(ctx, workspaceID, automationID, []string{"onboarding", "vip"}).Return(nil)
+
+		err := service.SetTags(ctx, workspaceID, automationID, []string{" onboarding ", "vip", "", "onboarding"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects tags that are too long", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+
+		err := service.SetTags(ctx, workspaceID, automationID, []string{strings.Repeat("a", domain.MaxAutomationTagLength+1)})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid tags")
+	})
+
+	t.Run("insufficient permissions", func(t *testing.T) {
+		readOnly := &domain.UserWorkspace{
+			UserID:      "user-123",
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceAutomations: {Read: true, Write: false},
+			},
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, readOnly, nil)
+
+		err := service.SetTags(ctx, workspaceID, automationID, []string{"vip"})
+		assert.Error(t, err)
+		var permErr *domain.PermissionError
+		assert.ErrorAs(t, err, &permErr)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().UpdateTags(ctx, workspaceID, automationID, []string{"vip"}).Return(errors.New("automation not found"))
+		mockLogger.EXPECT().WithField("automation_id", automationID).Return(mockLogger)
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		err := service.SetTags(ctx, workspaceID, automationID, []string{"vip"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to update automation tags")
+	})
+}
+
+func TestAutomationService_ListTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	service := NewAutomationService(mockRepo, mockAuthService, mockLogger)
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+
+	t.Run("successful list", func(t *testing.T) {
+		userWorkspace := &domain.UserWorkspace{
+			UserID:      "user-123",
+			WorkspaceID: workspaceID,
+			Role:        "admin",
+			Permissions: domain.FullPermissions,
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().ListTags(ctx, workspaceID).Return([]string{"onboarding", "vip"}, nil)
+
+		tags, err := service.ListTags(ctx, workspaceID)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"onboarding", "vip"}, tags)
+	})
+
+	t.Run("authentication failure", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, nil, nil, errors.New("auth error"))
+
+		tags, err := service.ListTags(ctx, workspaceID)
+		assert.Error(t, err)
+		assert.Nil(t, tags)
+	})
+}
+
 func TestAutomationService_Delete(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -434,6 +671,30 @@ func TestAutomationService_Activate(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("starts the enrollment ramp", func(t *testing.T) {
+		userWorkspace := &domain.UserWorkspace{
+			UserID:      "user-123",
+			WorkspaceID: workspaceID,
+			Role:        "admin",
+			Permissions: domain.FullPermissions,
+		}
+		existingAutomation := createTestAutomationService(automationID, workspaceID)
+		existingAutomation.EnrollmentRamp = &domain.EnrollmentRamp{PerMinute: 50, DurationMinutes: 10}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(existingAutomation, nil)
+		mockRepo.EXPECT().Update(ctx, workspaceID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, automation *domain.Automation) error {
+				require.NotNil(t, automation.EnrollmentRamp.StartedAt)
+				assert.WithinDuration(t, time.Now(), *automation.EnrollmentRamp.StartedAt, time.Minute)
+				return nil
+			})
+		mockRepo.EXPECT().CreateAutomationTrigger(ctx, workspaceID, gomock.Any()).Return(nil)
+
+		err := service.Activate(ctx, workspaceID, automationID)
+		assert.NoError(t, err)
+	})
+
 	t.Run("already live", func(t *testing.T) {
 		userWorkspace := &domain.UserWorkspace{
 			UserID:      "user-123",
@@ -520,6 +781,111 @@ func TestAutomationService_Activate(t *testing.T) {
 	})
 }
 
+func TestAutomationService_Activate_TriggerReferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockListRepo := mocks.NewMockListRepository(ctrl)
+	mockSegmentRepo := mocks.NewMockSegmentRepository(ctrl)
+
+	service := NewAutomationService(mockRepo, mockAuthService, pkgmocks.NewMockLogger(ctrl))
+	service.SetListRepository(mockListRepo)
+	service.SetSegmentRepository(mockSegmentRepo)
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	automationID := "auto-123"
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user-123",
+		WorkspaceID: workspaceID,
+		Role:        "admin",
+		Permissions: domain.FullPermissions,
+	}
+	strPtr := func(s string) *string { return &s }
+
+	t.Run("nonexistent segment is rejected", func(t *testing.T) {
+		automation := createTestAutomationService(automationID, workspaceID)
+		automation.Trigger = &domain.TimelineTriggerConfig{
+			EventKind: "segment.joined",
+			SegmentID: strPtr("seg-missing"),
+			Frequency: domain.TriggerFrequencyOnce,
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(automation, nil)
+		mockSegmentRepo.EXPECT().GetSegmentByID(ctx, workspaceID, "seg-missing").
+			Return(nil, &domain.ErrSegmentNotFound{Message: "segment not found: seg-missing"})
+
+		err := service.Activate(ctx, workspaceID, automationID)
+		require.Error(t, err)
+		assert.IsType(t, domain.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "trigger event segment.joined references segment seg-missing, which does not exist")
+		assert.Equal(t, domain.AutomationStatusDraft, automation.Status)
+	})
+
+	t.Run("deleted segment is rejected", func(t *testing.T) {
+		automation := createTestAutomationService(automationID, workspaceID)
+		automation.Trigger = &domain.TimelineTriggerConfig{
+			EventKind: "segment.left",
+			SegmentID: strPtr("seg-deleted"),
+			Frequency: domain.TriggerFrequencyOnce,
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(automation, nil)
+		mockSegmentRepo.EXPECT().GetSegmentByID(ctx, workspaceID, "seg-deleted").
+			Return(&domain.Segment{ID: "seg-deleted", Status: string(domain.SegmentStatusDeleted)}, nil)
+
+		err := service.Activate(ctx, workspaceID, automationID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "references segment seg-deleted, which does not exist")
+	})
+
+	t.Run("nonexistent list in an additional event is rejected", func(t *testing.T) {
+		automation := createTestAutomationService(automationID, workspaceID)
+		automation.Trigger = &domain.TimelineTriggerConfig{
+			EventKind: "email.opened",
+			Events: []domain.TriggerEventSpec{
+				{EventKind: "list.subscribed", ListID: strPtr("list-missing")},
+			},
+			Frequency: domain.TriggerFrequencyOnce,
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(automation, nil)
+		mockListRepo.EXPECT().GetListByID(ctx, workspaceID, "list-missing").
+			Return(nil, &domain.ErrListNotFound{Message: "list not found"})
+
+		err := service.Activate(ctx, workspaceID, automationID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "trigger event list.subscribed references list list-missing, which does not exist")
+	})
+
+	t.Run("existing list and segment are allowed", func(t *testing.T) {
+		automation := createTestAutomationService(automationID, workspaceID)
+		automation.Trigger = &domain.TimelineTriggerConfig{
+			EventKind: "list.subscribed",
+			ListID:    strPtr("list-123"),
+			Events: []domain.TriggerEventSpec{
+				{EventKind: "segment.joined", SegmentID: strPtr("seg-123")},
+			},
+			Frequency: domain.TriggerFrequencyOnce,
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(automation, nil)
+		mockListRepo.EXPECT().GetListByID(ctx, workspaceID, "list-123").Return(&domain.List{ID: "list-123"}, nil)
+		mockSegmentRepo.EXPECT().GetSegmentByID(ctx, workspaceID, "seg-123").
+			Return(&domain.Segment{ID: "seg-123", Status: string(domain.SegmentStatusActive)}, nil)
+		mockRepo.EXPECT().Update(ctx, workspaceID, gomock.Any()).Return(nil)
+		mockRepo.EXPECT().CreateAutomationTrigger(ctx, workspaceID, gomock.Any()).Return(nil)
+
+		assert.NoError(t, service.Activate(ctx, workspaceID, automationID))
+	})
+}
+
 func TestAutomationService_Pause(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -647,3 +1013,615 @@ func TestAutomationService_GetContactNodeExecutions(t *testing.T) {
 		assert.Nil(t, entries)
 	})
 }
+
+func TestAutomationService_GetContactNextTick(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	service := NewAutomationService(mockRepo, mockAuthService, mockLogger)
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	automationID := "auto-123"
+	email := "test@example.com"
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user-123",
+		WorkspaceID: workspaceID,
+		Role:        "admin",
+		Permissions: domain.FullPermissions,
+	}
+
+	t.Run("delayed contact reports the node after the delay", func(t *testing.T) {
+		// Graph: delay-1 -> email-1. Once the delay node has run, the executor moves
+		// current_node_id to email-1 and sets scheduled_at to the end of the delay.
+		automation := createTestAutomationService(automationID, workspaceID)
+		automation.Status = domain.AutomationStatusLive
+		delayNode := createTestAutomationNodeService("delay-1", automationID, domain.NodeTypeDelay)
+		delayNode.NextNodeID = strPtr("email-1")
+		automation.Nodes = []*domain.AutomationNode{
+			delayNode,
+			createTestAutomationNodeService("email-1", automationID, domain.NodeTypeEmail),
+		}
+		automation.RootNodeID = "delay-1"
+
+		scheduledAt := time.Now().UTC().Add(2 * time.Hour)
+		contactAutomation := &domain.ContactAutomation{
+			ID:            "ca-123",
+			AutomationID:  automationID,
+			ContactEmail:  email,
+			CurrentNodeID: strPtr("email-1"),
+			Status:        domain.ContactAutomationStatusActive,
+			ScheduledAt:   &scheduledAt,
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(automation, nil)
+		mockRepo.EXPECT().GetContactAutomationByEmail(ctx, workspaceID, automationID, email).Return(contactAutomation, nil)
+
+		tick, err := service.GetContactNextTick(ctx, workspaceID, automationID, email)
+		assert.NoError(t, err)
+		if assert.NotNil(t, tick) {
+			assert.Equal(t, "email-1", *tick.CurrentNodeID)
+			assert.True(t, tick.ScheduledAt.After(time.Now()))
+			assert.Equal(t, scheduledAt, *tick.ScheduledAt)
+			assert.Equal(t, domain.NextActionExecuteNode, tick.NextAction.Type)
+			assert.Equal(t, "email-1", *tick.NextAction.NodeID)
+			assert.Equal(t, domain.NodeTypeEmail, *tick.NextAction.NodeType)
+		}
+	})
+
+	t.Run("contact not found", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(createTestAutomationService(automationID, workspaceID), nil)
+		mockRepo.EXPECT().GetContactAutomationByEmail(ctx, workspaceID, automationID, email).Return(nil, errors.New("not found"))
+
+		tick, err := service.GetContactNextTick(ctx, workspaceID, automationID, email)
+		assert.Error(t, err)
+		assert.Nil(t, tick)
+		assert.Contains(t, err.Error(), "failed to get contact automation")
+	})
+
+	t.Run("authentication failure", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, nil, nil, errors.New("auth error"))
+
+		tick, err := service.GetContactNextTick(ctx, workspaceID, automationID, email)
+		assert.Error(t, err)
+		assert.Nil(t, tick)
+	})
+}
+
+func TestAutomationService_GetNodeStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	service := NewAutomationService(mockRepo, mockAuthService, mockLogger)
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	automationID := "auto-123"
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user-123",
+		WorkspaceID: workspaceID,
+		Role:        "admin",
+		Permissions: domain.FullPermissions,
+	}
+
+	t.Run("returns per-node stats", func(t *testing.T) {
+		automation := createTestAutomationService(automationID, workspaceID)
+		automation.Stats = &domain.AutomationStats{
+			Enrolled: 10,
+			Nodes: map[string]*domain.AutomationNodeExecutionStats{
+				"email-1": {Executions: 4, Failures: 1, TotalDurationMs: 200, AvgDurationMs: 50},
+			},
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(automation, nil)
+
+		nodeStats, err := service.GetNodeStats(ctx, workspaceID, automationID)
+		require.NoError(t, err)
+		require.Contains(t, nodeStats, "email-1")
+		assert.Equal(t, int64(4), nodeStats["email-1"].Executions)
+		assert.Equal(t, float64(50), nodeStats["email-1"].AvgDurationMs)
+	})
+
+	t.Run("automation without node stats", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(createTestAutomationService(automationID, workspaceID), nil)
+
+		nodeStats, err := service.GetNodeStats(ctx, workspaceID, automationID)
+		require.NoError(t, err)
+		assert.NotNil(t, nodeStats)
+		assert.Empty(t, nodeStats)
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		noReadWorkspace := &domain.UserWorkspace{
+			UserID:      "user-123",
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{},
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, noReadWorkspace, nil)
+
+		nodeStats, err := service.GetNodeStats(ctx, workspaceID, automationID)
+		assert.Nil(t, nodeStats)
+		var permErr *domain.PermissionError
+		assert.ErrorAs(t, err, &permErr)
+	})
+
+	t.Run("automation not found", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(nil, errors.New("not found"))
+
+		nodeStats, err := service.GetNodeStats(ctx, workspaceID, automationID)
+		assert.Error(t, err)
+		assert.Nil(t, nodeStats)
+		assert.Contains(t, err.Error(), "failed to get automation")
+	})
+}
+
+func TestAutomationService_TestWebhookNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// No enrollment or node execution calls are expected on the repositories
+	mockRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	service := NewAutomationService(mockRepo, mockAuthService, mockLogger)
+	service.SetContactRepository(mockContactRepo)
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	automationID := "auto-123"
+	email := "test@example.com"
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user-123",
+		WorkspaceID: workspaceID,
+		Role:        "admin",
+		Permissions: domain.FullPermissions,
+	}
+
+	var receivedBody []byte
+	statusCode := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(`{"score":42}`))
+	}))
+	defer server.Close()
+
+	newAutomation := func() *domain.Automation {
+		automation := createTestAutomationService(automationID, workspaceID)
+		webhookNode := createTestAutomationNodeService("webhook-1", automationID, domain.NodeTypeWebhook)
+		webhookNode.Config = map[string]interface{}{"url": server.URL}
+		automation.Nodes = []*domain.AutomationNode{
+			webhookNode,
+			createTestAutomationNodeService("email-1", automationID, domain.NodeTypeEmail),
+		}
+		automation.RootNodeID = "webhook-1"
+		return automation
+	}
+	contact := &domain.Contact{Email: email, FirstName: &domain.NullableString{String: "John", IsNull: false}}
+
+	t.Run("returns the captured request and response", func(t *testing.T) {
+		statusCode = http.StatusOK
+		automation := newAutomation()
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(automation, nil)
+		mockContactRepo.EXPECT().GetContactByEmail(ctx, workspaceID, email).Return(contact, nil)
+
+		result, err := service.TestWebhookNode(ctx, workspaceID, automationID, "webhook-1", email)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Empty(t, result.Error)
+
+		// The exchange is captured although the automation doesn't log webhooks
+		assert.False(t, automation.LogWebhooks)
+		request := result.Exchange["request"].(map[string]interface{})
+		assert.Equal(t, server.URL, request["url"])
+		assert.Equal(t, string(receivedBody), request["body"])
+		assert.Contains(t, request["body"], `"email":"test@example.com"`)
+		assert.Contains(t, request["body"], `"first_name":"John"`)
+
+		response := result.Exchange["response"].(map[string]interface{})
+		assert.Equal(t, http.StatusOK, response["status_code"])
+		assert.Equal(t, `{"score":42}`, response["body"])
+
+		assert.Equal(t, map[string]interface{}{"score": float64(42)}, result.Output["response"])
+		assert.NotContains(t, result.Output, "exchange")
+	})
+
+	t.Run("reports a failed call in the result", func(t *testing.T) {
+		statusCode = http.StatusBadRequest
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(newAutomation(), nil)
+		mockContactRepo.EXPECT().GetContactByEmail(ctx, workspaceID, email).Return(contact, nil)
+
+		result, err := service.TestWebhookNode(ctx, workspaceID, automationID, "webhook-1", email)
+		require.NoError(t, err)
+		assert.Contains(t, result.Error, "webhook returned client error: 400")
+		assert.Nil(t, result.Output)
+		response := result.Exchange["response"].(map[string]interface{})
+		assert.Equal(t, http.StatusBadRequest, response["status_code"])
+	})
+
+	t.Run("rejects a node that is not a webhook", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(newAutomation(), nil)
+
+		result, err := service.TestWebhookNode(ctx, workspaceID, automationID, "email-1", email)
+		assert.Nil(t, result)
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+
+	t.Run("requires write permission", func(t *testing.T) {
+		readOnly := &domain.UserWorkspace{
+			UserID:      "user-123",
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceAutomations: {Read: true, Write: false},
+			},
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, readOnly, nil)
+
+		result, err := service.TestWebhookNode(ctx, workspaceID, automationID, "webhook-1", email)
+		assert.Nil(t, result)
+		assert.IsType(t, &domain.PermissionError{}, err)
+	})
+}
+
+func TestAutomationService_EnrollContacts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	service := NewAutomationService(mockRepo, mockAuthService, mockLogger)
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	automationID := "auto-123"
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user-123",
+		WorkspaceID: workspaceID,
+		Role:        "admin",
+		Permissions: domain.FullPermissions,
+	}
+
+	t.Run("mix of valid and invalid emails reports per-item outcomes", func(t *testing.T) {
+		automation := createTestAutomationService(automationID, workspaceID)
+		automation.Status = domain.AutomationStatusLive
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(automation, nil)
+		mockRepo.EXPECT().EnrollContact(ctx, workspaceID, automation, "new@example.com").Return(true, nil)
+		mockRepo.EXPECT().EnrollContact(ctx, workspaceID, automation, "active@example.com").Return(false, nil)
+		mockRepo.EXPECT().EnrollContact(ctx, workspaceID, automation, "unknown@example.com").Return(false, domain.ErrEnrollmentContactNotFound)
+		mockRepo.EXPECT().EnrollContact(ctx, workspaceID, automation, "broken@example.com").Return(false, errors.New("connection reset"))
+		mockLogger.EXPECT().WithField("automation_id", automationID).Return(mockLogger)
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		result, err := service.EnrollContacts(ctx, workspaceID, automationID, []string{
+			"new@example.com",
+			"not-an-email",
+			"active@example.com",
+			"unknown@example.com",
+			"new@example.com",
+			"broken@example.com",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []domain.BulkItemResult{
+			{ID: "new@example.com", Status: domain.BulkItemStatusCreated},
+			{ID: "not-an-email", Status: domain.BulkItemStatusFailed, Error: "invalid email"},
+			{ID: "active@example.com", Status: domain.BulkItemStatusSkipped, Error: "already enrolled"},
+			{ID: "unknown@example.com", Status: domain.BulkItemStatusFailed, Error: "contact not found"},
+			{ID: "new@example.com", Status: domain.BulkItemStatusSkipped, Error: "duplicate email"},
+			{ID: "broken@example.com", Status: domain.BulkItemStatusFailed, Error: "failed to enroll contact"},
+		}, result.Results)
+		assert.Equal(t, domain.BulkOperationSummary{Created: 1, Skipped: 2, Failed: 3}, result.Summary)
+	})
+
+	t.Run("automation not live", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(createTestAutomationService(automationID, workspaceID), nil)
+
+		result, err := service.EnrollContacts(ctx, workspaceID, automationID, []string{"new@example.com"})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "automation must be live")
+	})
+
+	t.Run("insufficient permissions", func(t *testing.T) {
+		readOnly := &domain.UserWorkspace{
+			UserID:      "user-123",
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceAutomations: {Read: true, Write: false},
+			},
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, readOnly, nil)
+
+		result, err := service.EnrollContacts(ctx, workspaceID, automationID, []string{"new@example.com"})
+		assert.Nil(t, result)
+		var permErr *domain.PermissionError
+		assert.ErrorAs(t, err, &permErr)
+	})
+}
+
+func TestAutomationService_ReenrollContacts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	service := NewAutomationService(mockRepo, mockAuthService, mockLogger)
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	automationID := "auto-123"
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user-123",
+		WorkspaceID: workspaceID,
+		Role:        "admin",
+		Permissions: domain.FullPermissions,
+	}
+	liveAutomation := func() *domain.Automation {
+		automation := createTestAutomationService(automationID, workspaceID)
+		automation.Status = domain.AutomationStatusLive
+		return automation
+	}
+
+	t.Run("counts enrolled and skipped emails", func(t *testing.T) {
+		automation := liveAutomation()
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(automation, nil)
+		mockRepo.EXPECT().EnrollContact(ctx, workspaceID, automation, "new@example.com").Return(true, nil)
+		mockRepo.EXPECT().EnrollContact(ctx, workspaceID, automation, "active@example.com").Return(false, nil)
+		mockRepo.EXPECT().EnrollContact(ctx, workspaceID, automation, "unknown@example.com").Return(false, domain.ErrEnrollmentContactNotFound)
+
+		result, err := service.ReenrollContacts(ctx, workspaceID, automationID, []string{
+			"new@example.com",
+			"active@example.com",
+			"new@example.com",
+			"unknown@example.com",
+			"not-an-email",
+		}, "")
+		require.NoError(t, err)
+		assert.Equal(t, &domain.ReenrollContactsResult{Enrolled: 1, Skipped: 2, Failed: 2}, result)
+	})
+
+	t.Run("pages through segment members", func(t *testing.T) {
+		automation := liveAutomation()
+
+		firstPage := make([]string, reenrollSegmentBatchSize)
+		for i := range firstPage {
+			firstPage[i] = fmt.Sprintf("member-%04d@example.com", i)
+		}
+		lastEmail := firstPage[len(firstPage)-1]
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(automation, nil)
+		mockRepo.EXPECT().GetSegmentContactEmails(ctx, workspaceID, "seg-1", "", reenrollSegmentBatchSize).Return(firstPage, nil)
+		mockRepo.EXPECT().GetSegmentContactEmails(ctx, workspaceID, "seg-1", lastEmail, reenrollSegmentBatchSize).Return([]string{"zed@example.com"}, nil)
+		mockRepo.EXPECT().EnrollContact(ctx, workspaceID, automation, gomock.Any()).Return(true, nil).Times(reenrollSegmentBatchSize)
+		mockRepo.EXPECT().EnrollContact(ctx, workspaceID, automation, "zed@example.com").Return(false, nil)
+
+		result, err := service.ReenrollContacts(ctx, workspaceID, automationID, nil, "seg-1")
+		require.NoError(t, err)
+		assert.Equal(t, &domain.ReenrollContactsResult{Enrolled: reenrollSegmentBatchSize, Skipped: 1}, result)
+	})
+
+	t.Run("segment lookup error", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(liveAutomation(), nil)
+		mockRepo.EXPECT().GetSegmentContactEmails(ctx, workspaceID, "seg-1", "", reenrollSegmentBatchSize).Return(nil, errors.New("connection reset"))
+
+		result, err := service.ReenrollContacts(ctx, workspaceID, automationID, nil, "seg-1")
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to get segment contacts")
+	})
+
+	t.Run("insufficient permissions", func(t *testing.T) {
+		readOnly := &domain.UserWorkspace{
+			UserID:      "user-123",
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceAutomations: {Read: true, Write: false},
+			},
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, readOnly, nil)
+
+		result, err := service.ReenrollContacts(ctx, workspaceID, automationID, nil, "seg-1")
+		assert.Nil(t, result)
+		var permErr *domain.PermissionError
+		assert.ErrorAs(t, err, &permErr)
+	})
+}
+
+func TestAutomationService_CheckWebhookURLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewAutomationService(mocks.NewMockAutomationRepository(ctrl), mocks.NewMockAuthService(ctrl), pkgmocks.NewMockLogger(ctrl))
+	ctx := context.Background()
+
+	reachable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		// Any response counts as reachable, even for POST-only endpoints
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	defer reachable.Close()
+
+	closed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	unreachableURL := closed.URL + "/hook"
+	closed.Close()
+
+	webhookNode := func(id, url string) *domain.AutomationNode {
+		return &domain.AutomationNode{
+			ID:     id,
+			Type:   domain.NodeTypeWebhook,
+			Config: map[string]interface{}{"url": url},
+		}
+	}
+
+	automation := &domain.Automation{
+		ID: "auto-123",
+		Nodes: []*domain.AutomationNode{
+			{ID: "trigger", Type: domain.NodeTypeTrigger},
+			webhookNode("ok", reachable.URL+"/hook"),
+			webhookNode("down", unreachableURL),
+		},
+	}
+
+	t.Run("private addresses are reported by the SSRF check", func(t *testing.T) {
+		warnings := service.CheckWebhookURLs(ctx, automation)
+		require.Len(t, warnings, 2)
+		assert.Contains(t, warnings[0], "webhook node ok")
+		assert.Contains(t, warnings[0], "private or reserved address")
+	})
+
+	t.Run("unreachable URL", func(t *testing.T) {
+		// Allow loopback so the reachability check itself is exercised
+		service.httpClient = &http.Client{Timeout: time.Second}
+
+		warnings := service.CheckWebhookURLs(ctx, automation)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "webhook node down")
+		assert.Contains(t, warnings[0], "is not reachable")
+	})
+
+	t.Run("no webhook nodes", func(t *testing.T) {
+		warnings := service.CheckWebhookURLs(ctx, &domain.Automation{
+			Nodes: []*domain.AutomationNode{{ID: "trigger", Type: domain.NodeTypeTrigger}},
+		})
+		assert.Empty(t, warnings)
+	})
+}
+
+func TestAutomationService_CheckIntegrations(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	automationID := "auto-123"
+
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user-123",
+		WorkspaceID: workspaceID,
+		Role:        "admin",
+		Permissions: domain.FullPermissions,
+	}
+
+	emailNode := func(id string, config map[string]interface{}) *domain.AutomationNode {
+		return &domain.AutomationNode{ID: id, Type: domain.NodeTypeEmail, Config: config}
+	}
+
+	automation := createTestAutomationService(automationID, workspaceID)
+	automation.Nodes = []*domain.AutomationNode{
+		{ID: "trigger", Type: domain.NodeTypeTrigger},
+		emailNode("welcome", map[string]interface{}{"template_id": "tpl-1"}),
+		emailNode("eu", map[string]interface{}{
+			"template_id":    "tpl-2",
+			"integration_id": "transactional",
+			"sender_rules": []interface{}{
+				map[string]interface{}{"integration_id": "eu-provider", "conditions": map[string]interface{}{
+					"kind": "leaf",
+					"leaf": map[string]interface{}{
+						"source": "contacts",
+						"contact": map[string]interface{}{
+							"filters": []interface{}{
+								map[string]interface{}{
+									"field_name":    "country",
+									"field_type":    "string",
+									"operator":      "equals",
+									"string_values": []interface{}{"FR"},
+								},
+							},
+						},
+					},
+				}},
+			},
+		}),
+		emailNode("follow-up", map[string]interface{}{"template_id": "tpl-3"}),
+	}
+
+	setup := func(t *testing.T) (*mocks.MockAutomationRepository, *mocks.MockAuthService, *mocks.MockEmailServiceInterface, *AutomationService) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockRepo := mocks.NewMockAutomationRepository(ctrl)
+		mockAuthService := mocks.NewMockAuthService(ctrl)
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		service := NewAutomationService(mockRepo, mockAuthService, pkgmocks.NewMockLogger(ctrl))
+		service.SetEmailService(mockEmailService)
+		return mockRepo, mockAuthService, mockEmailService, service
+	}
+
+	t.Run("checks each integration once", func(t *testing.T) {
+		mockRepo, mockAuthService, mockEmailService, service := setup(t)
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(automation, nil)
+		gomock.InOrder(
+			mockEmailService.EXPECT().CheckIntegration(ctx, workspaceID, "").Return(nil),
+			mockEmailService.EXPECT().CheckIntegration(ctx, workspaceID, "eu-provider").Return(nil),
+			mockEmailService.EXPECT().CheckIntegration(ctx, workspaceID, "transactional").Return(nil),
+		)
+
+		assert.NoError(t, service.CheckIntegrations(ctx, workspaceID, automationID))
+	})
+
+	t.Run("failing integration", func(t *testing.T) {
+		mockRepo, mockAuthService, mockEmailService, service := setup(t)
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID, automationID).Return(automation, nil)
+		mockEmailService.EXPECT().CheckIntegration(ctx, workspaceID, "").Return(errors.New("no marketing email provider configured for this workspace"))
+
+		err := service.CheckIntegrations(ctx, workspaceID, automationID)
+		require.Error(t, err)
+		_, ok := err.(domain.ValidationError)
+		assert.True(t, ok)
+		assert.Contains(t, err.Error(), "no marketing email provider configured")
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		_, mockAuthService, _, service := setup(t)
+
+		noPermissions := &domain.UserWorkspace{
+			UserID:      "user-123",
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{},
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, noPermissions, nil)
+
+		err := service.CheckIntegrations(ctx, workspaceID, automationID)
+		var permErr *domain.PermissionError
+		assert.ErrorAs(t, err, &permErr)
+	})
+}
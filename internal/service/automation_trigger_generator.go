@@ -1,6 +1,7 @@
 package service
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"sort"
@@ -32,6 +33,18 @@ var AllowedContactFields = map[string]bool{
 	"custom_json_4": true, "custom_json_5": true,
 }
 
+// emailEventTimelineKinds maps email trigger events to the contact_timeline kinds
+// recorded by the message_history trigger
+var emailEventTimelineKinds = map[string]string{
+	"email.sent":         "insert_message_history",
+	"email.delivered":    "update_message_history",
+	"email.opened":       "open_email",
+	"email.clicked":      "click_email",
+	"email.bounced":      "bounce_email",
+	"email.complained":   "complain_email",
+	"email.unsubscribed": "unsubscribe_email",
+}
+
 // TriggerSQL contains the generated SQL statements for an automation trigger
 type TriggerSQL struct {
 	FunctionName string // automation_trigger_{id}
@@ -63,7 +76,7 @@ func (g *AutomationTriggerGenerator) Generate(automation *domain.Automation) (*T
 	if automation.Trigger == nil {
 		return nil, fmt.Errorf("automation trigger config is nil")
 	}
-	if automation.Trigger.EventKind == "" {
+	if len(automation.Trigger.EventSpecs()) == 0 {
 		return nil, fmt.Errorf("automation must have an event kind")
 	}
 	if automation.RootNodeID == "" {
@@ -82,7 +95,10 @@ func (g *AutomationTriggerGenerator) Generate(automation *domain.Automation) (*T
 	functionName := triggerName
 
 	// Build function body
-	functionBody := g.buildFunctionBody(functionName, automation)
+	functionBody, err := g.buildFunctionBody(functionName, automation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build function body: %w", err)
+	}
 
 	// Build trigger DDL
 	triggerDDL := g.buildTriggerDDL(triggerName, functionName, whenClause)
@@ -103,29 +119,77 @@ func (g *AutomationTriggerGenerator) buildWHENClause(automation *domain.Automati
 	var conditions []string
 	trigger := automation.Trigger
 
+	// 1-5. Event filters - a contact is enrolled when any of the trigger events matches
+	specs := trigger.EventSpecs()
+	eventClauses := make([]string, 0, len(specs))
+	for i := range specs {
+		eventClause, err := g.buildEventClause(&specs[i])
+		if err != nil {
+			return "", err
+		}
+		eventClauses = append(eventClauses, eventClause)
+	}
+	if len(eventClauses) == 1 {
+		conditions = append(conditions, eventClauses[0])
+	} else {
+		conditions = append(conditions, "(("+strings.Join(eventClauses, ") OR (")+"))")
+	}
+
+	// 6. TreeNode conditions (optional)
+	if trigger.Conditions != nil {
+		// Get SQL with placeholders and args
+		conditionSQL, args, err := g.queryBuilder.BuildTriggerCondition(trigger.Conditions, "NEW.email")
+		if err != nil {
+			return "", fmt.Errorf("failed to build TreeNode conditions: %w", err)
+		}
+		if conditionSQL != "" {
+			// Embed args into SQL (trigger WHEN clauses can't use parameters)
+			embeddedSQL, err := embedArgs(conditionSQL, args)
+			if err != nil {
+				return "", fmt.Errorf("failed to embed args: %w", err)
+			}
+			conditions = append(conditions, embeddedSQL)
+		}
+	}
+
+	// Combine with AND
+	return strings.Join(conditions, " AND "), nil
+}
+
+// buildEventClause builds the conditions matching a single trigger event
+func (g *AutomationTriggerGenerator) buildEventClause(spec *domain.TriggerEventSpec) (string, error) {
+	var conditions []string
+
 	// 1. Event kind filter (required)
 	// For custom_event, the kind is "custom_event.{name}" in the timeline
-	if trigger.EventKind == "custom_event" && trigger.CustomEventName != nil && *trigger.CustomEventName != "" {
+	// For email.* events, the kind is the message_history timeline kind (e.g. "open_email")
+	if spec.EventKind == "custom_event" && spec.CustomEventName != nil && *spec.CustomEventName != "" {
 		// Custom event with specific name filter
-		conditions = append(conditions, fmt.Sprintf("NEW.kind = 'custom_event.%s'", escapeString(*trigger.CustomEventName)))
+		conditions = append(conditions, fmt.Sprintf("NEW.kind = 'custom_event.%s'", escapeString(*spec.CustomEventName)))
+	} else if timelineKind, ok := emailEventTimelineKinds[spec.EventKind]; ok {
+		conditions = append(conditions, fmt.Sprintf("NEW.kind = '%s'", timelineKind))
+		// Delivery is recorded as a generic message_history update
+		if spec.EventKind == "email.delivered" {
+			conditions = append(conditions, "NEW.changes ? 'delivered_at'")
+		}
 	} else {
-		conditions = append(conditions, fmt.Sprintf("NEW.kind = '%s'", escapeString(trigger.EventKind)))
+		conditions = append(conditions, fmt.Sprintf("NEW.kind = '%s'", escapeString(spec.EventKind)))
 	}
 
 	// 2. List ID filter (for list.* events) - entity_id stores list_id
-	if trigger.ListID != nil && *trigger.ListID != "" && strings.HasPrefix(trigger.EventKind, "list.") {
-		conditions = append(conditions, fmt.Sprintf("NEW.entity_id = '%s'", escapeString(*trigger.ListID)))
+	if spec.ListID != nil && *spec.ListID != "" && strings.HasPrefix(spec.EventKind, "list.") {
+		conditions = append(conditions, fmt.Sprintf("NEW.entity_id = '%s'", escapeString(*spec.ListID)))
 	}
 
 	// 3. Segment ID filter (for segment.* events) - entity_id stores segment_id
-	if trigger.SegmentID != nil && *trigger.SegmentID != "" && strings.HasPrefix(trigger.EventKind, "segment.") {
-		conditions = append(conditions, fmt.Sprintf("NEW.entity_id = '%s'", escapeString(*trigger.SegmentID)))
+	if spec.SegmentID != nil && *spec.SegmentID != "" && strings.HasPrefix(spec.EventKind, "segment.") {
+		conditions = append(conditions, fmt.Sprintf("NEW.entity_id = '%s'", escapeString(*spec.SegmentID)))
 	}
 
 	// 4. Updated fields filter (for contact.updated events) - checks if specific fields were changed
-	if trigger.EventKind == "contact.updated" && len(trigger.UpdatedFields) > 0 {
-		fieldChecks := make([]string, 0, len(trigger.UpdatedFields))
-		for _, field := range trigger.UpdatedFields {
+	if spec.EventKind == "contact.updated" && len(spec.UpdatedFields) > 0 {
+		fieldChecks := make([]string, 0, len(spec.UpdatedFields))
+		for _, field := range spec.UpdatedFields {
 			if !AllowedContactFields[field] {
 				return "", fmt.Errorf("invalid updated_field: %s", field)
 			}
@@ -137,24 +201,34 @@ func (g *AutomationTriggerGenerator) buildWHENClause(automation *domain.Automati
 		}
 	}
 
-	// 5. TreeNode conditions (optional)
-	if trigger.Conditions != nil {
-		// Get SQL with placeholders and args
-		conditionSQL, args, err := g.queryBuilder.BuildTriggerCondition(trigger.Conditions, "NEW.email")
-		if err != nil {
-			return "", fmt.Errorf("failed to build TreeNode conditions: %w", err)
+	// 5. Field change filter (for contact.updated events) - checks the field changed, and its old/new values when set
+	if spec.EventKind == "contact.updated" && spec.FieldChange != nil {
+		change := spec.FieldChange
+		if !AllowedContactFields[change.Field] {
+			return "", fmt.Errorf("invalid field_change field: %s", change.Field)
 		}
-		if conditionSQL != "" {
-			// Embed args into SQL (trigger WHEN clauses can't use parameters)
-			embeddedSQL, err := embedArgs(conditionSQL, args)
+		field := escapeString(change.Field)
+		conditions = append(conditions, fmt.Sprintf("NEW.changes ? '%s'", field))
+
+		values := []struct {
+			key   string
+			value interface{}
+		}{
+			{"old", change.From},
+			{"new", change.To},
+		}
+		for _, v := range values {
+			if v.value == nil {
+				continue
+			}
+			valueJSON, err := json.Marshal(v.value)
 			if err != nil {
-				return "", fmt.Errorf("failed to embed args: %w", err)
+				return "", fmt.Errorf("invalid field_change value: %w", err)
 			}
-			conditions = append(conditions, embeddedSQL)
+			conditions = append(conditions, fmt.Sprintf("NEW.changes->'%s'->'%s' = '%s'::jsonb", field, v.key, escapeString(string(valueJSON))))
 		}
 	}
 
-	// Combine with AND
 	return strings.Join(conditions, " AND "), nil
 }
 
@@ -163,30 +237,84 @@ func (g *AutomationTriggerGenerator) buildEventKindFilter(eventKind string) stri
 	return fmt.Sprintf("NEW.kind = '%s'", escapeString(eventKind))
 }
 
-// buildFunctionBody generates the function body SQL
-func (g *AutomationTriggerGenerator) buildFunctionBody(functionName string, automation *domain.Automation) string {
+// buildFunctionBody generates the function body SQL.
+// When the trigger has enrollment conditions or a prerequisite, the enrollment is guarded
+// by an IF so the contact is only enrolled when it matches them at enrollment time.
+func (g *AutomationTriggerGenerator) buildFunctionBody(functionName string, automation *domain.Automation) (string, error) {
 	frequency := string(automation.Trigger.Frequency)
 	if frequency == "" {
 		frequency = "every_time"
 	}
 
-	return fmt.Sprintf(`CREATE OR REPLACE FUNCTION %s()
-RETURNS TRIGGER AS $$
-BEGIN
-    PERFORM automation_enroll_contact(
+	// Message events pass their message ID so the enrollment keeps the broadcast context
+	enroll := fmt.Sprintf(`PERFORM automation_enroll_contact(
         '%s',
         NEW.email,
         '%s',
-        '%s'
-    );
+        '%s',
+        CASE WHEN NEW.entity_type = 'message_history' THEN NEW.entity_id END
+    );`,
+		escapeString(automation.ID),
+		escapeString(automation.RootNodeID),
+		escapeString(frequency),
+	)
+
+	var guards []string
+	if automation.Trigger.EnrollmentConditions != nil {
+		conditionSQL, args, err := g.queryBuilder.BuildTriggerCondition(automation.Trigger.EnrollmentConditions, "NEW.email")
+		if err != nil {
+			return "", fmt.Errorf("failed to build enrollment conditions: %w", err)
+		}
+		if conditionSQL != "" {
+			embeddedSQL, err := embedArgs(conditionSQL, args)
+			if err != nil {
+				return "", fmt.Errorf("failed to embed args: %w", err)
+			}
+			guards = append(guards, embeddedSQL)
+		}
+	}
+	if automation.Trigger.Prerequisite != nil {
+		guards = append(guards, BuildPrerequisiteCondition(automation.Trigger.Prerequisite, "NEW.email"))
+	}
+	if len(guards) > 0 {
+		enroll = fmt.Sprintf(`IF %s THEN
+        %s
+    END IF;`, strings.Join(guards, " AND "), strings.ReplaceAll(enroll, "\n", "\n    "))
+	}
+
+	return fmt.Sprintf(`CREATE OR REPLACE FUNCTION %s()
+RETURNS TRIGGER AS $$
+BEGIN
+    %s
     RETURN NEW;
 END;
 $$ LANGUAGE plpgsql`,
 		functionName,
-		escapeString(automation.ID),
-		escapeString(automation.RootNodeID),
-		escapeString(frequency),
+		enroll,
+	), nil
+}
+
+// BuildPrerequisiteCondition returns a SQL condition, with values embedded, that is true when the
+// contact identified by emailExpr completed the prerequisite automation (within its window when set).
+// The completion time is the contact automation's last node execution.
+func BuildPrerequisiteCondition(prerequisite *domain.TriggerPrerequisite, emailExpr string) string {
+	condition := fmt.Sprintf(`EXISTS (
+        SELECT 1 FROM contact_automations prereq
+        WHERE prereq.automation_id = '%s'
+        AND prereq.contact_email = %s
+        AND prereq.status = 'completed'`,
+		escapeString(prerequisite.AutomationID),
+		emailExpr,
 	)
+	if within := prerequisite.WithinDuration(); within > 0 {
+		condition += fmt.Sprintf(`
+        AND COALESCE((
+            SELECT MAX(COALESCE(ne.completed_at, ne.entered_at)) FROM automation_node_executions ne
+            WHERE ne.contact_automation_id = prereq.id
+        ), prereq.entered_at) >= NOW() - INTERVAL '%d seconds'`, int64(within.Seconds()))
+	}
+	return condition + `
+    )`
 }
 
 // buildTriggerDDL generates the trigger DDL SQL
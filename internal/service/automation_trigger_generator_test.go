@@ -1,6 +1,7 @@
 package service
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Notifuse/notifuse/internal/domain"
@@ -296,12 +297,193 @@ func TestAutomationTriggerGenerator_Generate(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, result)
 
-		assert.Contains(t, result.WHENClause, "NEW.kind = 'email.delivered'")
+		assert.Contains(t, result.WHENClause, "NEW.kind = 'update_message_history'")
 		assert.Contains(t, result.WHENClause, "EXISTS (SELECT 1 FROM contact_lists cl")
 		assert.Contains(t, result.WHENClause, "cl.email = NEW.email")
 		assert.Contains(t, result.WHENClause, "'premium_members'") // Embedded value
 	})
 
+	t.Run("enrollment conditions guard the enrollment in the function body", func(t *testing.T) {
+		listID := "newsletter"
+		automation := &domain.Automation{
+			ID:         "testenrollcond",
+			ListID:     "newsletter",
+			RootNodeID: "node1",
+			Trigger: &domain.TimelineTriggerConfig{
+				EventKind: "list.subscribed",
+				ListID:    &listID,
+				Frequency: domain.TriggerFrequencyOnce,
+				EnrollmentConditions: &domain.TreeNode{
+					Kind: "leaf",
+					Leaf: &domain.TreeNodeLeaf{
+						Source: "contacts",
+						Contact: &domain.ContactCondition{
+							Filters: []*domain.DimensionFilter{
+								{
+									FieldName:    "country",
+									FieldType:    "string",
+									Operator:     "equals",
+									StringValues: []string{"US"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		result, err := gen.Generate(automation)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		// Conditions are evaluated by the function, not the WHEN clause
+		assert.NotContains(t, result.WHENClause, "EXISTS")
+		assert.Contains(t, result.FunctionBody, "IF EXISTS (SELECT 1 FROM contacts WHERE email = NEW.email")
+		assert.Contains(t, result.FunctionBody, "country = 'US'")
+		assert.NotContains(t, result.FunctionBody, "$1")
+		assert.Less(t, strings.Index(result.FunctionBody, "IF EXISTS"), strings.Index(result.FunctionBody, "automation_enroll_contact"))
+		assert.Contains(t, result.FunctionBody, "END IF;")
+	})
+
+	t.Run("prerequisite guards the enrollment in the function body", func(t *testing.T) {
+		eventName := "purchase"
+		automation := &domain.Automation{
+			ID:         "testprereq",
+			RootNodeID: "node1",
+			Trigger: &domain.TimelineTriggerConfig{
+				EventKind:       "custom_event",
+				CustomEventName: &eventName,
+				Frequency:       domain.TriggerFrequencyOnce,
+				Prerequisite: &domain.TriggerPrerequisite{
+					AutomationID: "onboarding",
+					Within:       7,
+					WithinUnit:   "days",
+				},
+			},
+		}
+
+		result, err := gen.Generate(automation)
+		require.NoError(t, err)
+
+		assert.NotContains(t, result.WHENClause, "contact_automations")
+		assert.Contains(t, result.FunctionBody, "IF EXISTS (")
+		assert.Contains(t, result.FunctionBody, "prereq.automation_id = 'onboarding'")
+		assert.Contains(t, result.FunctionBody, "prereq.contact_email = NEW.email")
+		assert.Contains(t, result.FunctionBody, "prereq.status = 'completed'")
+		assert.Contains(t, result.FunctionBody, "NOW() - INTERVAL '604800 seconds'")
+		assert.Less(t, strings.Index(result.FunctionBody, "IF EXISTS"), strings.Index(result.FunctionBody, "automation_enroll_contact"))
+	})
+
+	t.Run("prerequisite without window accepts any completion", func(t *testing.T) {
+		condition := BuildPrerequisiteCondition(&domain.TriggerPrerequisite{AutomationID: "o'nboarding"}, "c.email")
+
+		assert.Contains(t, condition, "prereq.automation_id = 'o''nboarding'")
+		assert.Contains(t, condition, "prereq.contact_email = c.email")
+		assert.NotContains(t, condition, "INTERVAL")
+	})
+
+	t.Run("function body without enrollment conditions has no guard", func(t *testing.T) {
+		automation := &domain.Automation{
+			ID:         "testnoguard",
+			ListID:     "list1",
+			RootNodeID: "node1",
+			Trigger: &domain.TimelineTriggerConfig{
+				EventKind: "contact.created",
+				Frequency: domain.TriggerFrequencyOnce,
+			},
+		}
+
+		result, err := gen.Generate(automation)
+		require.NoError(t, err)
+		assert.NotContains(t, result.FunctionBody, "IF ")
+	})
+
+	t.Run("multiple trigger events are combined with OR", func(t *testing.T) {
+		listID := "newsletter"
+		eventName := "signup_completed"
+		automation := &domain.Automation{
+			ID:         "testmulti",
+			ListID:     listID,
+			RootNodeID: "node1",
+			Trigger: &domain.TimelineTriggerConfig{
+				EventKind: "list.subscribed",
+				ListID:    &listID,
+				Events: []domain.TriggerEventSpec{
+					{EventKind: "custom_event", CustomEventName: &eventName},
+				},
+				Frequency: domain.TriggerFrequencyOnce,
+			},
+		}
+
+		result, err := gen.Generate(automation)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		assert.Equal(t,
+			"((NEW.kind = 'list.subscribed' AND NEW.entity_id = 'newsletter') OR (NEW.kind = 'custom_event.signup_completed'))",
+			result.WHENClause)
+		// A single enrollment call shares frequency dedup across all events
+		assert.Equal(t, 1, strings.Count(result.FunctionBody, "automation_enroll_contact"))
+		assert.Contains(t, result.FunctionBody, "'once'")
+	})
+
+	t.Run("multiple trigger events with conditions", func(t *testing.T) {
+		automation := &domain.Automation{
+			ID:         "testmulticond",
+			ListID:     "list1",
+			RootNodeID: "node1",
+			Trigger: &domain.TimelineTriggerConfig{
+				Events: []domain.TriggerEventSpec{
+					{EventKind: "contact.created"},
+					{EventKind: "contact.updated", UpdatedFields: []string{"country"}},
+				},
+				Frequency: domain.TriggerFrequencyEveryTime,
+				Conditions: &domain.TreeNode{
+					Kind: "leaf",
+					Leaf: &domain.TreeNodeLeaf{
+						Source: "contacts",
+						Contact: &domain.ContactCondition{
+							Filters: []*domain.DimensionFilter{
+								{
+									FieldName:    "country",
+									FieldType:    "string",
+									Operator:     "equals",
+									StringValues: []string{"US"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		result, err := gen.Generate(automation)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		assert.True(t, strings.HasPrefix(result.WHENClause,
+			"((NEW.kind = 'contact.created') OR (NEW.kind = 'contact.updated' AND (NEW.changes ? 'country'))) AND "))
+		assert.Contains(t, result.WHENClause, "country = 'US'")
+	})
+
+	t.Run("invalid updated field in additional event returns error", func(t *testing.T) {
+		automation := &domain.Automation{
+			ID:         "testmultibad",
+			RootNodeID: "node1",
+			Trigger: &domain.TimelineTriggerConfig{
+				EventKind: "contact.created",
+				Events: []domain.TriggerEventSpec{
+					{EventKind: "contact.updated", UpdatedFields: []string{"email; DROP TABLE"}},
+				},
+				Frequency: domain.TriggerFrequencyOnce,
+			},
+		}
+
+		_, err := gen.Generate(automation)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid updated_field")
+	})
+
 	t.Run("escapes SQL injection in automation ID", func(t *testing.T) {
 		automation := &domain.Automation{
 			ID:         "test'; DROP TABLE--",
@@ -517,9 +699,30 @@ func TestAutomationTriggerGenerator_Generate(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, result)
 
-		assert.Contains(t, result.WHENClause, "NEW.kind = 'email.opened'")
+		// email.opened matches the open_email kind recorded by the message_history trigger
+		assert.Contains(t, result.WHENClause, "NEW.kind = 'open_email'")
 		// Should NOT have entity_id filter for email events
 		assert.NotContains(t, result.WHENClause, "NEW.entity_id")
+		// The message ID is passed along so the enrollment keeps the broadcast context
+		assert.Contains(t, result.FunctionBody, "CASE WHEN NEW.entity_type = 'message_history' THEN NEW.entity_id END")
+	})
+
+	t.Run("email.delivered matches message history delivery updates", func(t *testing.T) {
+		automation := &domain.Automation{
+			ID:         "testdelivered",
+			ListID:     "list1",
+			RootNodeID: "node1",
+			Trigger: &domain.TimelineTriggerConfig{
+				EventKind: "email.delivered",
+				Frequency: domain.TriggerFrequencyEveryTime,
+			},
+		}
+
+		result, err := gen.Generate(automation)
+		require.NoError(t, err)
+
+		assert.Contains(t, result.WHENClause, "NEW.kind = 'update_message_history'")
+		assert.Contains(t, result.WHENClause, "NEW.changes ? 'delivered_at'")
 	})
 
 	t.Run("contact.updated with updated_fields filter", func(t *testing.T) {
@@ -641,6 +844,91 @@ func TestAutomationTriggerGenerator_Generate(t *testing.T) {
 		assert.Contains(t, result.WHENClause, "NEW.changes ? 'custom_datetime_5'")
 	})
 
+	t.Run("contact.updated with field_change to a value", func(t *testing.T) {
+		automation := &domain.Automation{
+			ID:         "testfieldchange",
+			RootNodeID: "node1",
+			Trigger: &domain.TimelineTriggerConfig{
+				EventKind:   "contact.updated",
+				FieldChange: &domain.TriggerFieldChange{Field: "custom_string_1", To: "churned"},
+				Frequency:   domain.TriggerFrequencyOnce,
+			},
+		}
+
+		result, err := gen.Generate(automation)
+		require.NoError(t, err)
+
+		assert.Equal(t, "NEW.kind = 'contact.updated' AND NEW.changes ? 'custom_string_1' AND NEW.changes->'custom_string_1'->'new' = '\"churned\"'::jsonb", result.WHENClause)
+	})
+
+	t.Run("contact.updated with field_change from and to values", func(t *testing.T) {
+		automation := &domain.Automation{
+			ID:         "testfieldchangefrom",
+			RootNodeID: "node1",
+			Trigger: &domain.TimelineTriggerConfig{
+				EventKind:   "contact.updated",
+				FieldChange: &domain.TriggerFieldChange{Field: "custom_number_2", From: float64(1), To: float64(2.5)},
+				Frequency:   domain.TriggerFrequencyEveryTime,
+			},
+		}
+
+		result, err := gen.Generate(automation)
+		require.NoError(t, err)
+
+		assert.Contains(t, result.WHENClause, "NEW.changes->'custom_number_2'->'old' = '1'::jsonb")
+		assert.Contains(t, result.WHENClause, "NEW.changes->'custom_number_2'->'new' = '2.5'::jsonb")
+	})
+
+	t.Run("contact.updated with field_change on any value", func(t *testing.T) {
+		automation := &domain.Automation{
+			ID:         "testfieldchangeany",
+			RootNodeID: "node1",
+			Trigger: &domain.TimelineTriggerConfig{
+				EventKind:   "contact.updated",
+				FieldChange: &domain.TriggerFieldChange{Field: "country"},
+				Frequency:   domain.TriggerFrequencyEveryTime,
+			},
+		}
+
+		result, err := gen.Generate(automation)
+		require.NoError(t, err)
+
+		assert.Equal(t, "NEW.kind = 'contact.updated' AND NEW.changes ? 'country'", result.WHENClause)
+	})
+
+	t.Run("field_change values are escaped", func(t *testing.T) {
+		automation := &domain.Automation{
+			ID:         "testfieldchangeescape",
+			RootNodeID: "node1",
+			Trigger: &domain.TimelineTriggerConfig{
+				EventKind:   "contact.updated",
+				FieldChange: &domain.TriggerFieldChange{Field: "custom_string_1", To: "it's'; DROP TABLE--"},
+				Frequency:   domain.TriggerFrequencyEveryTime,
+			},
+		}
+
+		result, err := gen.Generate(automation)
+		require.NoError(t, err)
+
+		assert.Contains(t, result.WHENClause, "'\"it''s''; DROP TABLE--\"'::jsonb")
+	})
+
+	t.Run("contact.updated with invalid field_change field returns error", func(t *testing.T) {
+		automation := &domain.Automation{
+			ID:         "testfieldchangeinvalid",
+			RootNodeID: "node1",
+			Trigger: &domain.TimelineTriggerConfig{
+				EventKind:   "contact.updated",
+				FieldChange: &domain.TriggerFieldChange{Field: "lifecycle_stage'; DROP TABLE--", To: "churned"},
+				Frequency:   domain.TriggerFrequencyEveryTime,
+			},
+		}
+
+		_, err := gen.Generate(automation)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid field_change field")
+	})
+
 	t.Run("updated_fields ignored for non-contact.updated events", func(t *testing.T) {
 		automation := &domain.Automation{
 			ID:         "testignored",
@@ -39,33 +39,103 @@ type DataFeedFetcher interface {
 	// Supports retry logic for 5xx errors and 408/429 status codes
 	FetchRecipient(ctx context.Context, settings *domain.RecipientFeedSettings,
 		payload *domain.RecipientFeedRequestPayload) (map[string]interface{}, error)
+
+	// FetchRecipientBatch fetches per-recipient data for several contacts in one request
+	// The endpoint must answer with an object keyed by contact email; every contact in the
+	// payload gets an entry in the result, empty when the endpoint returned nothing for it
+	// Returns nil, nil if settings are nil or disabled
+	FetchRecipientBatch(ctx context.Context, settings *domain.RecipientFeedSettings,
+		payload *domain.RecipientFeedBatchRequestPayload) (map[string]map[string]interface{}, error)
 }
 
 // dataFeedFetcher implements the DataFeedFetcher interface
 type dataFeedFetcher struct {
-	httpClient *http.Client
-	logger     logger.Logger
+	httpClient        *http.Client
+	tokenProvider     *feedTokenProvider
+	workspaceProvider *feedWorkspaceProvider
+	logger            logger.Logger
 }
 
 // NewDataFeedFetcher creates a new DataFeedFetcher instance
-func NewDataFeedFetcher(log logger.Logger) DataFeedFetcher {
-	return &dataFeedFetcher{
-		httpClient: &http.Client{
-			// Base timeout; will be overridden per-request
-			Timeout: 30 * time.Second,
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 10,
-				IdleConnTimeout:     90 * time.Second,
-			},
+// workspaceRepo is used to load the workspace default feed headers and the secret key stored OAuth2
+// client secrets are decrypted with; when nil only per-feed headers are sent
+func NewDataFeedFetcher(workspaceRepo domain.WorkspaceRepository, log logger.Logger) DataFeedFetcher {
+	httpClient := &http.Client{
+		// Base timeout; will be overridden per-request
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 10,
+			IdleConnTimeout:     90 * time.Second,
 		},
-		logger: log,
 	}
+	return &dataFeedFetcher{
+		httpClient:        httpClient,
+		tokenProvider:     newFeedTokenProvider(httpClient),
+		workspaceProvider: newFeedWorkspaceProvider(workspaceRepo),
+		logger:            log,
+	}
+}
+
+// withWorkspaceHeaders returns the feed headers merged with the workspace default feed headers
+func (f *dataFeedFetcher) withWorkspaceHeaders(ctx context.Context, workspaceID string, headers []domain.DataFeedHeader) ([]domain.DataFeedHeader, error) {
+	merged, err := f.workspaceProvider.MergeHeaders(ctx, workspaceID, headers)
+	if err != nil {
+		f.logger.WithFields(map[string]interface{}{
+			"workspace_id": workspaceID,
+			"error":        err.Error(),
+		}).Error("Failed to apply workspace default feed headers")
+		return nil, err
+	}
+	return merged, nil
+}
+
+// applyAuth sets the Authorization header when the feed is configured with OAuth2
+func (f *dataFeedFetcher) applyAuth(ctx context.Context, req *http.Request, auth *domain.DataFeedAuth) error {
+	if auth == nil || auth.OAuth2 == nil {
+		return nil
+	}
+
+	token, err := f.tokenProvider.GetAccessToken(ctx, auth.OAuth2)
+	if err != nil {
+		f.logger.WithFields(map[string]interface{}{
+			"token_url": auth.OAuth2.TokenURL,
+			"error":     err.Error(),
+		}).Error("Failed to obtain OAuth2 token for data feed")
+		return fmt.Errorf("failed to obtain OAuth2 token: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// withDecryptedAuth returns the feed auth with a stored OAuth2 client secret decrypted with the
+// workspace secret key. The feed settings are shared between concurrent fetches, so the secret
+// is decrypted into a copy.
+func (f *dataFeedFetcher) withDecryptedAuth(ctx context.Context, workspaceID string, auth *domain.DataFeedAuth) (*domain.DataFeedAuth, error) {
+	if auth == nil || auth.OAuth2 == nil || auth.OAuth2.ClientSecret != "" || auth.OAuth2.EncryptedClientSecret == "" {
+		return auth, nil
+	}
+
+	secretKey, err := f.workspaceProvider.SecretKey(ctx, workspaceID)
+	if err == nil {
+		config := *auth.OAuth2
+		if err = config.DecryptClientSecret(secretKey); err == nil {
+			return &domain.DataFeedAuth{OAuth2: &config}, nil
+		}
+	}
+
+	f.logger.WithFields(map[string]interface{}{
+		"workspace_id": workspaceID,
+		"token_url":    auth.OAuth2.TokenURL,
+		"error":        err.Error(),
+	}).Error("Failed to decrypt OAuth2 client secret for data feed")
+	return nil, err
 }
 
 // FetchGlobal fetches global data from a configured endpoint
 func (f *dataFeedFetcher) FetchGlobal(ctx context.Context, settings *domain.GlobalFeedSettings,
-	payload *domain.GlobalFeedRequestPayload) (map[string]interface{}, error) {
+	payload *domain.GlobalFeedRequestPayload) (result map[string]interface{}, err error) {
 
 	// Return early if settings are nil or disabled
 	if settings == nil || !settings.Enabled {
@@ -76,6 +146,33 @@ func (f *dataFeedFetcher) FetchGlobal(ctx context.Context, settings *domain.Glob
 		return nil, nil
 	}
 
+	// Record the fetch outcome once it is known
+	broadcastID := ""
+	workspaceID := ""
+	if payload != nil {
+		broadcastID = payload.Broadcast.ID
+		workspaceID = payload.Workspace.ID
+	}
+	start := time.Now()
+	statusCode := 0
+	defer func() {
+		recordFeedFetch(ctx, broadcastID, feedTypeGlobal, statusCode, time.Since(start), err)
+	}()
+
+	// Send the workspace default headers alongside the feed's own headers
+	headers, err := f.withWorkspaceHeaders(ctx, workspaceID, settings.Headers)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := f.withDecryptedAuth(ctx, workspaceID, settings.Auth)
+	if err != nil {
+		return nil, err
+	}
+	feedSettings := *settings
+	feedSettings.Headers = headers
+	feedSettings.Auth = auth
+	settings = &feedSettings
+
 	// Determine timeout
 	timeout := time.Duration(settings.GetTimeout()) * time.Second
 
@@ -85,7 +182,6 @@ func (f *dataFeedFetcher) FetchGlobal(ctx context.Context, settings *domain.Glob
 
 	// Prepare payload (use empty struct if nil)
 	var payloadBytes []byte
-	var err error
 	if payload != nil {
 		payloadBytes, err = json.Marshal(payload)
 	} else {
@@ -124,6 +220,11 @@ func (f *dataFeedFetcher) FetchGlobal(ctx context.Context, settings *domain.Glob
 		req.Header.Set(header.Name, header.Value)
 	}
 
+	// Add OAuth2 bearer token (overrides any static Authorization header)
+	if err := f.applyAuth(fetchCtx, req, settings.Auth); err != nil {
+		return nil, err
+	}
+
 	// Execute request
 	resp, err := f.httpClient.Do(req)
 	if err != nil {
@@ -149,6 +250,7 @@ func (f *dataFeedFetcher) FetchGlobal(ctx context.Context, settings *domain.Glob
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	statusCode = resp.StatusCode
 
 	// Check HTTP status code
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
@@ -179,7 +281,6 @@ func (f *dataFeedFetcher) FetchGlobal(ctx context.Context, settings *domain.Glob
 	}
 
 	// Parse JSON response directly into map (accept any valid JSON object)
-	var result map[string]interface{}
 	if err := json.Unmarshal(responseBody, &result); err != nil {
 		f.logger.WithFields(map[string]interface{}{
 			"url":           settings.URL,
@@ -193,6 +294,9 @@ func (f *dataFeedFetcher) FetchGlobal(ctx context.Context, settings *domain.Glob
 		result = make(map[string]interface{})
 	}
 
+	// Rename fields for templates before adding metadata
+	result = domain.ApplyFeedFieldMap(result, settings.FieldMap)
+
 	// Add metadata
 	result["_success"] = true
 	result["_fetched_at"] = time.Now().UTC().Format(time.RFC3339)
@@ -208,7 +312,7 @@ func (f *dataFeedFetcher) FetchGlobal(ctx context.Context, settings *domain.Glob
 
 // FetchRecipient fetches per-recipient data from a configured endpoint
 func (f *dataFeedFetcher) FetchRecipient(ctx context.Context, settings *domain.RecipientFeedSettings,
-	payload *domain.RecipientFeedRequestPayload) (map[string]interface{}, error) {
+	payload *domain.RecipientFeedRequestPayload) (result map[string]interface{}, err error) {
 
 	// Return early if settings are nil or disabled
 	if settings == nil || !settings.Enabled {
@@ -219,14 +323,35 @@ func (f *dataFeedFetcher) FetchRecipient(ctx context.Context, settings *domain.R
 		return nil, nil
 	}
 
-	// Determine timeout and retry settings
-	timeout := time.Duration(settings.GetTimeout()) * time.Second
-	maxRetries := settings.GetMaxRetries()
-	retryDelay := time.Duration(settings.GetRetryDelay()) * time.Millisecond
+	// Record the fetch outcome once it is known
+	broadcastID := ""
+	workspaceID := ""
+	if payload != nil {
+		broadcastID = payload.Broadcast.ID
+		workspaceID = payload.Workspace.ID
+	}
+	start := time.Now()
+	statusCode := 0
+	defer func() {
+		recordFeedFetch(ctx, broadcastID, feedTypeRecipient, statusCode, time.Since(start), err)
+	}()
+
+	// Send the workspace default headers alongside the feed's own headers
+	headers, err := f.withWorkspaceHeaders(ctx, workspaceID, settings.Headers)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := f.withDecryptedAuth(ctx, workspaceID, settings.Auth)
+	if err != nil {
+		return nil, err
+	}
+	feedSettings := *settings
+	feedSettings.Headers = headers
+	feedSettings.Auth = auth
+	settings = &feedSettings
 
 	// Prepare payload (use empty struct if nil)
 	var payloadBytes []byte
-	var err error
 	if payload != nil {
 		payloadBytes, err = json.Marshal(payload)
 	} else {
@@ -240,6 +365,192 @@ func (f *dataFeedFetcher) FetchRecipient(ctx context.Context, settings *domain.R
 		return nil, fmt.Errorf("failed to marshal request payload: %w", err)
 	}
 
+	responseBody, statusCode, err := f.postRecipientWithRetry(ctx, settings, payloadBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse JSON response directly into map (accept any valid JSON object)
+	if err := json.Unmarshal(responseBody, &result); err != nil {
+		f.logger.WithFields(map[string]interface{}{
+			"url":           settings.URL,
+			"error":         err.Error(),
+			"response_size": len(responseBody),
+		}).Error("Failed to parse JSON response")
+		return nil, fmt.Errorf("invalid JSON response: %w", err)
+	}
+
+	result = finalizeRecipientFeedData(result, settings.FieldMap)
+
+	f.logger.WithFields(map[string]interface{}{
+		"url":        settings.URL,
+		"data_keys":  len(result) - 2, // Exclude _success and _fetched_at
+		"fetched_at": result["_fetched_at"],
+	}).Info("Recipient feed data fetched successfully")
+
+	return result, nil
+}
+
+// FetchRecipientBatch fetches data for several recipients in one request
+func (f *dataFeedFetcher) FetchRecipientBatch(ctx context.Context, settings *domain.RecipientFeedSettings,
+	payload *domain.RecipientFeedBatchRequestPayload) (results map[string]map[string]interface{}, err error) {
+
+	// Return early if settings are nil or disabled
+	if settings == nil || !settings.Enabled {
+		f.logger.WithFields(map[string]interface{}{
+			"settings_nil": settings == nil,
+			"enabled":      settings != nil && settings.Enabled,
+		}).Debug("Recipient feed batch fetch skipped: not enabled")
+		return nil, nil
+	}
+	if payload == nil || len(payload.Contacts) == 0 {
+		return map[string]map[string]interface{}{}, nil
+	}
+
+	// Record the fetch outcome once it is known
+	start := time.Now()
+	statusCode := 0
+	defer func() {
+		recordFeedFetch(ctx, payload.Broadcast.ID, feedTypeRecipientBatch, statusCode, time.Since(start), err)
+	}()
+
+	// Send the workspace default headers alongside the feed's own headers
+	headers, err := f.withWorkspaceHeaders(ctx, payload.Workspace.ID, settings.Headers)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := f.withDecryptedAuth(ctx, payload.Workspace.ID, settings.Auth)
+	if err != nil {
+		return nil, err
+	}
+	feedSettings := *settings
+	feedSettings.Headers = headers
+	feedSettings.Auth = auth
+	settings = &feedSettings
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		f.logger.WithFields(map[string]interface{}{
+			"url":   settings.URL,
+			"error": err.Error(),
+		}).Error("Failed to marshal request payload")
+		return nil, fmt.Errorf("failed to marshal request payload: %w", err)
+	}
+
+	responseBody, statusCode, err := f.postRecipientWithRetry(ctx, settings, payloadBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// The endpoint answers with one object per contact, keyed by email
+	var keyed map[string]map[string]interface{}
+	if err := json.Unmarshal(responseBody, &keyed); err != nil {
+		f.logger.WithFields(map[string]interface{}{
+			"url":           settings.URL,
+			"error":         err.Error(),
+			"response_size": len(responseBody),
+		}).Error("Failed to parse batched JSON response")
+		return nil, fmt.Errorf("invalid JSON response: expected an object keyed by contact email: %w", err)
+	}
+
+	// Contacts missing from the response get an empty data set, like an empty single response
+	results = make(map[string]map[string]interface{}, len(payload.Contacts))
+	for _, contact := range payload.Contacts {
+		results[contact.Email] = finalizeRecipientFeedData(keyed[contact.Email], settings.FieldMap)
+	}
+
+	f.logger.WithFields(map[string]interface{}{
+		"url":        settings.URL,
+		"contacts":   len(payload.Contacts),
+		"with_data":  len(keyed),
+		"batch_size": settings.BatchSize,
+	}).Info("Recipient feed batch fetched successfully")
+
+	return results, nil
+}
+
+// prefetchRecipientFeeds fetches recipient feed data for a whole send batch when the broadcast's
+// recipient feed has a batch size. Recipients are grouped by list, since each request carries a
+// single list, and split into chunks of BatchSize. Returns nil, nil when batching does not apply.
+func prefetchRecipientFeeds(ctx context.Context, fetcher DataFeedFetcher, broadcast *domain.Broadcast,
+	workspaceID string, recipients []*domain.ContactWithList) (map[string]map[string]interface{}, error) {
+
+	if fetcher == nil || broadcast.DataFeed == nil || broadcast.DataFeed.RecipientFeed == nil {
+		return nil, nil
+	}
+	settings := broadcast.DataFeed.RecipientFeed
+	if !settings.Enabled || settings.BatchSize <= 0 {
+		return nil, nil
+	}
+
+	// Group contacts per list, keeping the recipients' order
+	var listOrder []domain.RecipientFeedList
+	contactsByList := make(map[string][]domain.RecipientFeedContact)
+	for _, recipient := range recipients {
+		if recipient == nil || recipient.Contact == nil || recipient.Contact.Email == "" {
+			continue
+		}
+		if _, ok := contactsByList[recipient.ListID]; !ok {
+			listOrder = append(listOrder, domain.RecipientFeedList{ID: recipient.ListID, Name: recipient.ListName})
+		}
+		contactsByList[recipient.ListID] = append(contactsByList[recipient.ListID], domain.BuildRecipientFeedContact(recipient.Contact))
+	}
+
+	results := make(map[string]map[string]interface{})
+	for _, list := range listOrder {
+		contacts := contactsByList[list.ID]
+		for start := 0; start < len(contacts); start += settings.BatchSize {
+			end := start + settings.BatchSize
+			if end > len(contacts) {
+				end = len(contacts)
+			}
+
+			payload := &domain.RecipientFeedBatchRequestPayload{
+				Contacts:  contacts[start:end],
+				Broadcast: domain.RecipientFeedBroadcast{ID: broadcast.ID, Name: broadcast.Name, Metadata: broadcast.Metadata},
+				List:      list,
+				Workspace: domain.RecipientFeedWorkspace{ID: workspaceID},
+			}
+
+			batch, err := fetcher.FetchRecipientBatch(ctx, settings, payload)
+			if err != nil {
+				return nil, err
+			}
+			for email, data := range batch {
+				results[email] = data
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// finalizeRecipientFeedData applies the field map and adds the fetch metadata
+func finalizeRecipientFeedData(result map[string]interface{}, fieldMap map[string]string) map[string]interface{} {
+	if result == nil {
+		result = make(map[string]interface{})
+	}
+
+	// Rename fields for templates before adding metadata
+	result = domain.ApplyFeedFieldMap(result, fieldMap)
+
+	// Add metadata
+	result["_success"] = true
+	result["_fetched_at"] = time.Now().UTC().Format(time.RFC3339)
+
+	return result
+}
+
+// postRecipientWithRetry posts a recipient feed payload, retrying on 5xx, 408/429 and
+// network errors, and returns the raw response body with the last HTTP status code
+func (f *dataFeedFetcher) postRecipientWithRetry(ctx context.Context, settings *domain.RecipientFeedSettings,
+	payloadBytes []byte) ([]byte, int, error) {
+
+	// Determine timeout and retry settings
+	timeout := time.Duration(settings.GetTimeout()) * time.Second
+	maxRetries := settings.GetMaxRetries()
+	retryDelay := time.Duration(settings.GetRetryDelay()) * time.Millisecond
+
 	f.logger.WithFields(map[string]interface{}{
 		"url":         settings.URL,
 		"timeout":     timeout.String(),
@@ -249,6 +560,7 @@ func (f *dataFeedFetcher) FetchRecipient(ctx context.Context, settings *domain.R
 
 	// Execute request with retry logic
 	var lastErr error
+	lastStatusCode := 0
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
 			f.logger.WithFields(map[string]interface{}{
@@ -258,25 +570,26 @@ func (f *dataFeedFetcher) FetchRecipient(ctx context.Context, settings *domain.R
 			time.Sleep(retryDelay)
 		}
 
-		result, statusCode, err := f.doRecipientRequest(ctx, settings, payloadBytes, timeout)
+		body, statusCode, err := f.doRecipientRequest(ctx, settings, payloadBytes, timeout)
+		lastStatusCode = statusCode
 		if err != nil {
 			lastErr = err
 			// Check if error is retryable
 			if !f.shouldRetry(statusCode, err, attempt, maxRetries) {
-				return nil, err
+				return nil, statusCode, err
 			}
 			continue
 		}
 
-		return result, nil
+		return body, statusCode, nil
 	}
 
-	return nil, lastErr
+	return nil, lastStatusCode, lastErr
 }
 
-// doRecipientRequest performs a single recipient feed request
+// doRecipientRequest performs a single recipient feed request and returns the response body
 func (f *dataFeedFetcher) doRecipientRequest(ctx context.Context, settings *domain.RecipientFeedSettings,
-	payloadBytes []byte, timeout time.Duration) (map[string]interface{}, int, error) {
+	payloadBytes []byte, timeout time.Duration) ([]byte, int, error) {
 
 	// Create a context with timeout
 	fetchCtx, cancel := context.WithTimeout(ctx, timeout)
@@ -302,6 +615,11 @@ func (f *dataFeedFetcher) doRecipientRequest(ctx context.Context, settings *doma
 		req.Header.Set(header.Name, header.Value)
 	}
 
+	// Add OAuth2 bearer token (overrides any static Authorization header)
+	if err := f.applyAuth(fetchCtx, req, settings.Auth); err != nil {
+		return nil, 0, err
+	}
+
 	// Execute request
 	resp, err := f.httpClient.Do(req)
 	if err != nil {
@@ -356,32 +674,7 @@ func (f *dataFeedFetcher) doRecipientRequest(ctx context.Context, settings *doma
 		return nil, resp.StatusCode, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// Parse JSON response directly into map (accept any valid JSON object)
-	var result map[string]interface{}
-	if err := json.Unmarshal(responseBody, &result); err != nil {
-		f.logger.WithFields(map[string]interface{}{
-			"url":           settings.URL,
-			"error":         err.Error(),
-			"response_size": len(responseBody),
-		}).Error("Failed to parse JSON response")
-		return nil, resp.StatusCode, fmt.Errorf("invalid JSON response: %w", err)
-	}
-
-	if result == nil {
-		result = make(map[string]interface{})
-	}
-
-	// Add metadata
-	result["_success"] = true
-	result["_fetched_at"] = time.Now().UTC().Format(time.RFC3339)
-
-	f.logger.WithFields(map[string]interface{}{
-		"url":        settings.URL,
-		"data_keys":  len(result) - 2, // Exclude _success and _fetched_at
-		"fetched_at": result["_fetched_at"],
-	}).Info("Recipient feed data fetched successfully")
-
-	return result, resp.StatusCode, nil
+	return responseBody, resp.StatusCode, nil
 }
 
 // shouldRetry determines if a request should be retried based on status code and error
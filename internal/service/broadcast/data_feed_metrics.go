@@ -0,0 +1,109 @@
+package broadcast
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// Feed types used to label data feed metrics
+const (
+	feedTypeGlobal         = "global"
+	feedTypeRecipient      = "recipient"
+	feedTypeRecipientBatch = "recipient_batch"
+)
+
+var (
+	// Measures recorded by the data feed fetcher
+	feedFetchLatencyMs = stats.Float64("notifuse/data_feed/fetch_latency", "Latency of data feed fetches", stats.UnitMilliseconds)
+	feedFetches        = stats.Int64("notifuse/data_feed/fetches", "Number of data feed fetches", stats.UnitDimensionless)
+	feedFetchFailures  = stats.Int64("notifuse/data_feed/fetch_failures", "Number of failed data feed fetches", stats.UnitDimensionless)
+	feedCacheHits      = stats.Int64("notifuse/data_feed/cache_hits", "Number of recipient feed lookups served from prefetched data", stats.UnitDimensionless)
+
+	// Tag keys used to label data feed metrics
+	feedBroadcastKey  = tag.MustNewKey("broadcast_id")
+	feedTypeKey       = tag.MustNewKey("feed_type")
+	feedStatusCodeKey = tag.MustNewKey("status_code")
+)
+
+var (
+	// DataFeedFetchLatencyView is the latency distribution of data feed fetches
+	DataFeedFetchLatencyView = &view.View{
+		Name:        "notifuse/data_feed/fetch_latency",
+		Description: "Latency distribution of data feed fetches",
+		Measure:     feedFetchLatencyMs,
+		TagKeys:     []tag.Key{feedBroadcastKey, feedTypeKey},
+		Aggregation: view.Distribution(10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000),
+	}
+
+	// DataFeedFetchCountView counts data feed fetches by HTTP status code
+	DataFeedFetchCountView = &view.View{
+		Name:        "notifuse/data_feed/fetch_count",
+		Description: "Number of data feed fetches by HTTP status code",
+		Measure:     feedFetches,
+		TagKeys:     []tag.Key{feedBroadcastKey, feedTypeKey, feedStatusCodeKey},
+		Aggregation: view.Count(),
+	}
+
+	// DataFeedFetchFailureView counts failed data feed fetches
+	DataFeedFetchFailureView = &view.View{
+		Name:        "notifuse/data_feed/fetch_failures",
+		Description: "Number of failed data feed fetches",
+		Measure:     feedFetchFailures,
+		TagKeys:     []tag.Key{feedBroadcastKey, feedTypeKey},
+		Aggregation: view.Count(),
+	}
+
+	// DataFeedCacheHitView counts recipient feed lookups served from a batch prefetch
+	DataFeedCacheHitView = &view.View{
+		Name:        "notifuse/data_feed/cache_hits",
+		Description: "Number of recipient feed lookups served from prefetched data",
+		Measure:     feedCacheHits,
+		TagKeys:     []tag.Key{feedBroadcastKey, feedTypeKey},
+		Aggregation: view.Count(),
+	}
+
+	// DataFeedViews lists every data feed view, for registration with the metrics exporter
+	DataFeedViews = []*view.View{
+		DataFeedFetchLatencyView,
+		DataFeedFetchCountView,
+		DataFeedFetchFailureView,
+		DataFeedCacheHitView,
+	}
+)
+
+// recordFeedFetch records the latency, status code and outcome of a data feed fetch.
+// statusCode is 0 when no HTTP response was received.
+func recordFeedFetch(ctx context.Context, broadcastID, feedType string, statusCode int, latency time.Duration, err error) {
+	statusLabel := "none"
+	if statusCode > 0 {
+		statusLabel = strconv.Itoa(statusCode)
+	}
+
+	measurements := []stats.Measurement{
+		feedFetchLatencyMs.M(float64(latency) / float64(time.Millisecond)),
+		feedFetches.M(1),
+	}
+	if err != nil {
+		measurements = append(measurements, feedFetchFailures.M(1))
+	}
+
+	// Recording only fails on invalid tags, which never blocks a fetch
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{
+		tag.Upsert(feedBroadcastKey, broadcastID),
+		tag.Upsert(feedTypeKey, feedType),
+		tag.Upsert(feedStatusCodeKey, statusLabel),
+	}, measurements...)
+}
+
+// recordFeedCacheHit records a recipient feed lookup served from prefetched data
+func recordFeedCacheHit(ctx context.Context, broadcastID string) {
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{
+		tag.Upsert(feedBroadcastKey, broadcastID),
+		tag.Upsert(feedTypeKey, feedTypeRecipient),
+	}, feedCacheHits.M(1))
+}
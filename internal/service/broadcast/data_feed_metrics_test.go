@@ -0,0 +1,131 @@
+package broadcast
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// feedMetricValue sums a data feed view's rows matching the given tag values
+func feedMetricValue(t *testing.T, v *view.View, labels map[tag.Key]string) int64 {
+	t.Helper()
+
+	rows, err := view.RetrieveData(v.Name)
+	require.NoError(t, err)
+
+	var total int64
+	for _, row := range rows {
+		matched := 0
+		for _, rowTag := range row.Tags {
+			if value, ok := labels[rowTag.Key]; ok && value == rowTag.Value {
+				matched++
+			}
+		}
+		if matched != len(labels) {
+			continue
+		}
+		switch data := row.Data.(type) {
+		case *view.CountData:
+			total += data.Value
+		case *view.DistributionData:
+			total += data.Count
+		}
+	}
+	return total
+}
+
+func TestDataFeedFetcher_Metrics(t *testing.T) {
+	require.NoError(t, view.Register(DataFeedViews...))
+	defer view.Unregister(DataFeedViews...)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	failing := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"headline":"Hello"}`))
+	}))
+	defer server.Close()
+
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
+	ctx := context.Background()
+
+	t.Run("global feed", func(t *testing.T) {
+		settings := &domain.GlobalFeedSettings{Enabled: true, URL: server.URL, Headers: []domain.DataFeedHeader{}}
+		payload := &domain.GlobalFeedRequestPayload{Broadcast: domain.GlobalFeedBroadcast{ID: "bc_metrics_global"}}
+		labels := map[tag.Key]string{feedBroadcastKey: "bc_metrics_global", feedTypeKey: feedTypeGlobal}
+
+		failing = false
+		_, err := fetcher.FetchGlobal(ctx, settings, payload)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1), feedMetricValue(t, DataFeedFetchLatencyView, labels))
+		assert.Equal(t, int64(1), feedMetricValue(t, DataFeedFetchCountView, map[tag.Key]string{
+			feedBroadcastKey: "bc_metrics_global", feedStatusCodeKey: "200",
+		}))
+		assert.Equal(t, int64(0), feedMetricValue(t, DataFeedFetchFailureView, labels))
+
+		failing = true
+		_, err = fetcher.FetchGlobal(ctx, settings, payload)
+		require.Error(t, err)
+
+		assert.Equal(t, int64(2), feedMetricValue(t, DataFeedFetchLatencyView, labels))
+		assert.Equal(t, int64(1), feedMetricValue(t, DataFeedFetchCountView, map[tag.Key]string{
+			feedBroadcastKey: "bc_metrics_global", feedStatusCodeKey: "400",
+		}))
+		assert.Equal(t, int64(1), feedMetricValue(t, DataFeedFetchFailureView, labels))
+	})
+
+	t.Run("recipient feed", func(t *testing.T) {
+		settings := &domain.RecipientFeedSettings{Enabled: true, URL: server.URL, Headers: []domain.DataFeedHeader{}}
+		payload := &domain.RecipientFeedRequestPayload{
+			Contact:   domain.RecipientFeedContact{Email: "john@example.com"},
+			Broadcast: domain.RecipientFeedBroadcast{ID: "bc_metrics_recipient"},
+		}
+		labels := map[tag.Key]string{feedBroadcastKey: "bc_metrics_recipient", feedTypeKey: feedTypeRecipient}
+
+		failing = false
+		_, err := fetcher.FetchRecipient(ctx, settings, payload)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1), feedMetricValue(t, DataFeedFetchCountView, labels))
+		assert.Equal(t, int64(0), feedMetricValue(t, DataFeedFetchFailureView, labels))
+
+		// 400 is not retried, so the failure is recorded after a single request
+		failing = true
+		_, err = fetcher.FetchRecipient(ctx, settings, payload)
+		require.Error(t, err)
+
+		assert.Equal(t, int64(2), feedMetricValue(t, DataFeedFetchCountView, labels))
+		assert.Equal(t, int64(1), feedMetricValue(t, DataFeedFetchFailureView, labels))
+	})
+
+	t.Run("cache hits", func(t *testing.T) {
+		labels := map[tag.Key]string{feedBroadcastKey: "bc_metrics_cache", feedTypeKey: feedTypeRecipient}
+
+		recordFeedCacheHit(ctx, "bc_metrics_cache")
+		recordFeedCacheHit(ctx, "bc_metrics_cache")
+
+		assert.Equal(t, int64(2), feedMetricValue(t, DataFeedCacheHitView, labels))
+	})
+}
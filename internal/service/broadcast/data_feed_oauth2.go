@@ -0,0 +1,159 @@
+package broadcast
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"golang.org/x/sync/singleflight"
+)
+
+// feedTokenRefreshBuffer is the time before token expiry when we should refresh
+const feedTokenRefreshBuffer = 1 * time.Minute
+
+// feedCachedToken holds a cached OAuth2 access token with its expiration time
+type feedCachedToken struct {
+	accessToken string
+	expiresAt   time.Time
+}
+
+// feedTokenResponse represents the OAuth2 token response from the token endpoint
+type feedTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// feedTokenProvider fetches and caches OAuth2 client-credentials tokens for data feeds.
+// Concurrent requests for the same credentials share one token fetch, made outside the
+// cache lock so feeds using other credentials are not held up by a slow token endpoint.
+type feedTokenProvider struct {
+	httpClient *http.Client
+	mu         sync.Mutex
+	tokenCache map[string]*feedCachedToken
+	fetches    singleflight.Group
+}
+
+// newFeedTokenProvider creates a new feedTokenProvider
+func newFeedTokenProvider(httpClient *http.Client) *feedTokenProvider {
+	return &feedTokenProvider{
+		httpClient: httpClient,
+		tokenCache: make(map[string]*feedCachedToken),
+	}
+}
+
+// GetAccessToken returns a valid access token for the given OAuth2 config,
+// using the cached token when it has not expired yet
+func (p *feedTokenProvider) GetAccessToken(ctx context.Context, config *domain.DataFeedOAuth2Config) (string, error) {
+	cacheKey := p.getCacheKey(config)
+	if accessToken, ok := p.cachedToken(cacheKey); ok {
+		return accessToken, nil
+	}
+
+	// The shared fetch is not cancelled when one of its callers gives up, it is bounded by
+	// the HTTP client timeout instead
+	fetchCtx := context.WithoutCancel(ctx)
+	results := p.fetches.DoChan(cacheKey, func() (interface{}, error) {
+		if accessToken, ok := p.cachedToken(cacheKey); ok {
+			return accessToken, nil
+		}
+
+		accessToken, expiresAt, err := p.fetchToken(fetchCtx, config)
+		if err != nil {
+			return "", err
+		}
+
+		p.mu.Lock()
+		p.tokenCache[cacheKey] = &feedCachedToken{
+			accessToken: accessToken,
+			expiresAt:   expiresAt,
+		}
+		p.mu.Unlock()
+
+		return accessToken, nil
+	})
+
+	select {
+	case result := <-results:
+		if result.Err != nil {
+			return "", result.Err
+		}
+		return result.Val.(string), nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// cachedToken returns the cached token for the key when it is not about to expire
+func (p *feedTokenProvider) cachedToken(cacheKey string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cached, exists := p.tokenCache[cacheKey]
+	if !exists || !time.Now().Add(feedTokenRefreshBuffer).Before(cached.expiresAt) {
+		return "", false
+	}
+	return cached.accessToken, true
+}
+
+// getCacheKey generates a unique cache key for the given OAuth2 config
+// Format: tokenURL:clientID:scope:secretHash, so a rotated client secret gets a new token
+func (p *feedTokenProvider) getCacheKey(config *domain.DataFeedOAuth2Config) string {
+	secretHash := sha256.Sum256([]byte(config.ClientSecret))
+	return fmt.Sprintf("%s:%s:%s:%s", config.TokenURL, config.ClientID, config.Scope, hex.EncodeToString(secretHash[:8]))
+}
+
+// fetchToken requests a new access token using the client-credentials grant
+func (p *feedTokenProvider) fetchToken(ctx context.Context, config *domain.DataFeedOAuth2Config) (string, time.Time, error) {
+	data := url.Values{}
+	data.Set("grant_type", "client_credentials")
+	data.Set("client_id", config.ClientID)
+	data.Set("client_secret", config.ClientSecret)
+	if config.Scope != "" {
+		data.Set("scope", config.Scope)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.TokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to fetch OAuth2 token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, fmt.Errorf("OAuth2 token request failed with status %d", resp.StatusCode)
+	}
+
+	var tokenResp feedTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return "", time.Time{}, fmt.Errorf("OAuth2 token response has no access_token")
+	}
+
+	expiresAt := time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+
+	return tokenResp.AccessToken, expiresAt, nil
+}
@@ -0,0 +1,338 @@
+package broadcast
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	domainmocks "github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/pkg/crypto"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOAuth2TokenServer(t *testing.T, tokenCalls *int32, expiresIn int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(tokenCalls, 1)
+
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+		assert.Equal(t, "feed-client", r.Form.Get("client_id"))
+		assert.Equal(t, "feed-secret", r.Form.Get("client_secret"))
+		assert.Equal(t, "feeds.read", r.Form.Get("scope"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "feed-token-123",
+			"token_type":   "Bearer",
+			"expires_in":   expiresIn,
+		})
+	}))
+}
+
+func TestDataFeedFetcher_OAuth2_TokenFetchedOnceAndReused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	var tokenCalls int32
+	tokenServer := newOAuth2TokenServer(t, &tokenCalls, 3600)
+	defer tokenServer.Close()
+
+	var feedCalls int32
+	feedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&feedCalls, 1)
+		if r.Header.Get("Authorization") != "Bearer feed-token-123" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"product":"Widget"}`))
+	}))
+	defer feedServer.Close()
+
+	auth := &domain.DataFeedAuth{
+		OAuth2: &domain.DataFeedOAuth2Config{
+			TokenURL:     tokenServer.URL,
+			ClientID:     "feed-client",
+			ClientSecret: "feed-secret",
+			Scope:        "feeds.read",
+		},
+	}
+
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
+
+	globalSettings := &domain.GlobalFeedSettings{Enabled: true, URL: feedServer.URL, Auth: auth}
+	recipientSettings := &domain.RecipientFeedSettings{Enabled: true, URL: feedServer.URL, Auth: auth}
+
+	for i := 0; i < 3; i++ {
+		result, err := fetcher.FetchGlobal(context.Background(), globalSettings, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Widget", result["product"])
+	}
+
+	result, err := fetcher.FetchRecipient(context.Background(), recipientSettings, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Widget", result["product"])
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tokenCalls), "token should be fetched once and reused")
+	assert.Equal(t, int32(4), atomic.LoadInt32(&feedCalls))
+}
+
+func TestDataFeedFetcher_OAuth2_EncryptedClientSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	// Loaded once, then served from the cache on the second fetch
+	mockWorkspaceRepo := domainmocks.NewMockWorkspaceRepository(ctrl)
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "w-1").Return(&domain.Workspace{
+		ID:       "w-1",
+		Settings: domain.WorkspaceSettings{SecretKey: "workspace-secret-key"},
+	}, nil).Times(1)
+
+	var tokenCalls int32
+	tokenServer := newOAuth2TokenServer(t, &tokenCalls, 3600)
+	defer tokenServer.Close()
+
+	feedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer feed-token-123" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"product":"Widget"}`))
+	}))
+	defer feedServer.Close()
+
+	encryptedSecret, err := crypto.EncryptString("feed-secret", "workspace-secret-key")
+	require.NoError(t, err)
+
+	settings := &domain.GlobalFeedSettings{
+		Enabled: true,
+		URL:     feedServer.URL,
+		Auth: &domain.DataFeedAuth{
+			OAuth2: &domain.DataFeedOAuth2Config{
+				TokenURL:              tokenServer.URL,
+				ClientID:              "feed-client",
+				EncryptedClientSecret: encryptedSecret,
+				Scope:                 "feeds.read",
+			},
+		},
+	}
+	payload := &domain.GlobalFeedRequestPayload{
+		Broadcast: domain.GlobalFeedBroadcast{ID: "b-1"},
+		Workspace: domain.GlobalFeedWorkspace{ID: "w-1"},
+	}
+
+	fetcher := NewDataFeedFetcher(mockWorkspaceRepo, mockLogger)
+
+	for i := 0; i < 2; i++ {
+		result, err := fetcher.FetchGlobal(context.Background(), settings, payload)
+		require.NoError(t, err)
+		assert.Equal(t, "Widget", result["product"])
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tokenCalls))
+	// The decrypted secret is not written back to the shared broadcast settings
+	assert.Empty(t, settings.Auth.OAuth2.ClientSecret)
+}
+
+func TestDataFeedFetcher_OAuth2_ExpiredTokenRefetched(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	// Token expires within the refresh buffer, so it is never reused
+	var tokenCalls int32
+	tokenServer := newOAuth2TokenServer(t, &tokenCalls, 30)
+	defer tokenServer.Close()
+
+	feedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer feedServer.Close()
+
+	settings := &domain.GlobalFeedSettings{
+		Enabled: true,
+		URL:     feedServer.URL,
+		Auth: &domain.DataFeedAuth{
+			OAuth2: &domain.DataFeedOAuth2Config{
+				TokenURL:     tokenServer.URL,
+				ClientID:     "feed-client",
+				ClientSecret: "feed-secret",
+				Scope:        "feeds.read",
+			},
+		},
+	}
+
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
+	for i := 0; i < 2; i++ {
+		_, err := fetcher.FetchGlobal(context.Background(), settings, nil)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&tokenCalls))
+}
+
+func TestDataFeedFetcher_OAuth2_TokenEndpointError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer tokenServer.Close()
+
+	var feedCalls int32
+	feedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&feedCalls, 1)
+	}))
+	defer feedServer.Close()
+
+	settings := &domain.GlobalFeedSettings{
+		Enabled: true,
+		URL:     feedServer.URL,
+		Auth: &domain.DataFeedAuth{
+			OAuth2: &domain.DataFeedOAuth2Config{
+				TokenURL:     tokenServer.URL,
+				ClientID:     "feed-client",
+				ClientSecret: "wrong-secret",
+			},
+		},
+	}
+
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
+	result, err := fetcher.FetchGlobal(context.Background(), settings, nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to obtain OAuth2 token")
+	assert.Nil(t, result)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&feedCalls), "feed must not be called without a token")
+}
+
+func TestFeedTokenProvider_ConcurrentRequestsShareOneFetch(t *testing.T) {
+	var tokenCalls int32
+	release := make(chan struct{})
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenCalls, 1)
+		<-release
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "shared-token", "expires_in": 3600})
+	}))
+	defer tokenServer.Close()
+
+	provider := newFeedTokenProvider(tokenServer.Client())
+	config := &domain.DataFeedOAuth2Config{TokenURL: tokenServer.URL, ClientID: "feed-client", ClientSecret: "feed-secret"}
+
+	var wg sync.WaitGroup
+	tokens := make([]string, 5)
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			token, err := provider.GetAccessToken(context.Background(), config)
+			assert.NoError(t, err)
+			tokens[i] = token
+		}(i)
+	}
+
+	// Wait for the first fetch to reach the endpoint before letting it answer
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&tokenCalls) == 1 }, 2*time.Second, 5*time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tokenCalls))
+	for _, token := range tokens {
+		assert.Equal(t, "shared-token", token)
+	}
+}
+
+func TestFeedTokenProvider_SlowFetchDoesNotBlockOtherCredentials(t *testing.T) {
+	release := make(chan struct{})
+	slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slowServer.Close()
+	defer close(release)
+
+	fastServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "fast-token", "expires_in": 3600})
+	}))
+	defer fastServer.Close()
+
+	provider := newFeedTokenProvider(&http.Client{})
+
+	slowCtx, cancel := context.WithCancel(context.Background())
+	slowDone := make(chan error, 1)
+	go func() {
+		_, err := provider.GetAccessToken(slowCtx, &domain.DataFeedOAuth2Config{TokenURL: slowServer.URL, ClientID: "slow"})
+		slowDone <- err
+	}()
+
+	token, err := provider.GetAccessToken(context.Background(), &domain.DataFeedOAuth2Config{TokenURL: fastServer.URL, ClientID: "fast"})
+	require.NoError(t, err)
+	assert.Equal(t, "fast-token", token)
+
+	// A caller giving up returns right away even though the shared fetch is still running
+	cancel()
+	select {
+	case err := <-slowDone:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(2 * time.Second):
+		t.Fatal("cancelled caller kept waiting for the token endpoint")
+	}
+}
+
+func TestFeedTokenProvider_RotatedSecretFetchesNewToken(t *testing.T) {
+	var tokenCalls int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenCalls, 1)
+		require.NoError(t, r.ParseForm())
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "token-for-" + r.Form.Get("client_secret"),
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	provider := newFeedTokenProvider(tokenServer.Client())
+	config := &domain.DataFeedOAuth2Config{TokenURL: tokenServer.URL, ClientID: "feed-client", ClientSecret: "old-secret"}
+
+	token, err := provider.GetAccessToken(context.Background(), config)
+	require.NoError(t, err)
+	assert.Equal(t, "token-for-old-secret", token)
+
+	rotated := *config
+	rotated.ClientSecret = "new-secret"
+	token, err = provider.GetAccessToken(context.Background(), &rotated)
+	require.NoError(t, err)
+	assert.Equal(t, "token-for-new-secret", token)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&tokenCalls))
+	assert.NotContains(t, provider.getCacheKey(config), "old-secret", "cache key must not hold the secret itself")
+}
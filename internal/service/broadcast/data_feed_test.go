@@ -3,12 +3,16 @@ package broadcast
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
+	domainmocks "github.com/Notifuse/notifuse/internal/domain/mocks"
+	bmocks "github.com/Notifuse/notifuse/internal/service/broadcast/mocks"
 	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -23,7 +27,7 @@ func TestDataFeedFetcher_FetchGlobal_NilSettings(t *testing.T) {
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	// Test: nil settings returns nil, nil
 	result, err := fetcher.FetchGlobal(context.Background(), nil, nil)
@@ -40,7 +44,7 @@ func TestDataFeedFetcher_FetchGlobal_Disabled(t *testing.T) {
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	// Test: disabled settings returns nil, nil
 	settings := &domain.GlobalFeedSettings{
@@ -93,7 +97,7 @@ func TestDataFeedFetcher_FetchGlobal_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	settings := &domain.GlobalFeedSettings{
 		Enabled: true,
@@ -145,6 +149,43 @@ func TestDataFeedFetcher_FetchGlobal_Success(t *testing.T) {
 	assert.Equal(t, "Featured Product", result["featured_item"])
 }
 
+func TestDataFeedFetcher_FetchGlobal_FieldMap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"promoCode":    "WINTER2026",
+			"discountText": "30% off",
+		})
+	}))
+	defer server.Close()
+
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
+
+	settings := &domain.GlobalFeedSettings{
+		Enabled:  true,
+		URL:      server.URL,
+		Headers:  []domain.DataFeedHeader{},
+		FieldMap: map[string]string{"promoCode": "promo_code"},
+	}
+
+	result, err := fetcher.FetchGlobal(context.Background(), settings, &domain.GlobalFeedRequestPayload{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "WINTER2026", result["promo_code"])
+	assert.NotContains(t, result, "promoCode")
+	assert.Equal(t, "30% off", result["discountText"], "unmapped fields are kept")
+	assert.Equal(t, true, result["_success"])
+}
+
 func TestDataFeedFetcher_FetchGlobal_CustomHeaders(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -166,7 +207,7 @@ func TestDataFeedFetcher_FetchGlobal_CustomHeaders(t *testing.T) {
 	}))
 	defer server.Close()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	settings := &domain.GlobalFeedSettings{
 		Enabled: true,
@@ -211,7 +252,7 @@ func TestDataFeedFetcher_FetchGlobal_Timeout(t *testing.T) {
 	}))
 	defer server.Close()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	settings := &domain.GlobalFeedSettings{
 		Enabled: true,
@@ -263,7 +304,7 @@ func TestDataFeedFetcher_FetchGlobal_HTTPErrors(t *testing.T) {
 			}))
 			defer server.Close()
 
-			fetcher := NewDataFeedFetcher(mockLogger)
+			fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 			settings := &domain.GlobalFeedSettings{
 				Enabled: true,
@@ -303,7 +344,7 @@ func TestDataFeedFetcher_FetchGlobal_InvalidJSON(t *testing.T) {
 	}))
 	defer server.Close()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	settings := &domain.GlobalFeedSettings{
 		Enabled: true,
@@ -341,7 +382,7 @@ func TestDataFeedFetcher_FetchGlobal_EmptyDataResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	settings := &domain.GlobalFeedSettings{
 		Enabled: true,
@@ -382,7 +423,7 @@ func TestDataFeedFetcher_FetchGlobal_ContextCancelled(t *testing.T) {
 	}))
 	defer server.Close()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	settings := &domain.GlobalFeedSettings{
 		Enabled: true,
@@ -426,7 +467,7 @@ func TestDataFeedFetcher_FetchGlobal_NilPayload(t *testing.T) {
 	}))
 	defer server.Close()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	settings := &domain.GlobalFeedSettings{
 		Enabled: true,
@@ -448,7 +489,7 @@ func TestNewDataFeedFetcher(t *testing.T) {
 
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	assert.NotNil(t, fetcher)
 	assert.Implements(t, (*DataFeedFetcher)(nil), fetcher)
@@ -470,7 +511,7 @@ func TestDataFeedFetcher_FetchGlobal_HardcodedTimeout(t *testing.T) {
 	}))
 	defer server.Close()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	// Timeout is now hardcoded to 5 seconds
 	settings := &domain.GlobalFeedSettings{
@@ -525,7 +566,7 @@ func TestDataFeedFetcher_FetchGlobal_LargeResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	settings := &domain.GlobalFeedSettings{
 		Enabled: true,
@@ -560,7 +601,7 @@ func TestDataFeedFetcher_FetchRecipient_Disabled(t *testing.T) {
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	// Test: disabled settings returns nil, nil
 	settings := &domain.RecipientFeedSettings{
@@ -582,7 +623,7 @@ func TestDataFeedFetcher_FetchRecipient_NilSettings(t *testing.T) {
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	// Test: nil settings returns nil, nil
 	result, err := fetcher.FetchRecipient(context.Background(), nil, nil)
@@ -630,7 +671,7 @@ func TestDataFeedFetcher_FetchRecipient_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	settings := &domain.RecipientFeedSettings{
 		Enabled: true,
@@ -721,7 +762,7 @@ func TestDataFeedFetcher_FetchRecipient_Retry(t *testing.T) {
 	}))
 	defer server.Close()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	settings := &domain.RecipientFeedSettings{
 		Enabled: true,
@@ -769,7 +810,7 @@ func TestDataFeedFetcher_FetchRecipient_RetryExhausted(t *testing.T) {
 	}))
 	defer server.Close()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	settings := &domain.RecipientFeedSettings{
 		Enabled: true,
@@ -817,7 +858,7 @@ func TestDataFeedFetcher_FetchRecipient_NoRetryOn4xx(t *testing.T) {
 	}))
 	defer server.Close()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	settings := &domain.RecipientFeedSettings{
 		Enabled: true,
@@ -864,7 +905,7 @@ func TestDataFeedFetcher_FetchRecipient_CustomHeaders(t *testing.T) {
 	}))
 	defer server.Close()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	settings := &domain.RecipientFeedSettings{
 		Enabled: true,
@@ -894,6 +935,65 @@ func TestDataFeedFetcher_FetchRecipient_CustomHeaders(t *testing.T) {
 	assert.Equal(t, "recipient-value", receivedHeaders.Get("X-Recipient-Header"))
 }
 
+func TestDataFeedFetcher_FetchRecipient_WorkspaceDefaultHeaders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	// Loaded once, then served from the cache on the second fetch
+	mockWorkspaceRepo := domainmocks.NewMockWorkspaceRepository(ctrl)
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "w-1").Return(&domain.Workspace{
+		ID: "w-1",
+		Settings: domain.WorkspaceSettings{
+			DefaultFeedHeaders: []domain.DataFeedHeader{
+				{Name: "X-Api-Key", Value: "workspace-key"},
+				{Name: "x-tenant", Value: "workspace-tenant"},
+			},
+		},
+	}, nil).Times(1)
+
+	var receivedHeaders http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedHeaders = r.Header
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	fetcher := NewDataFeedFetcher(mockWorkspaceRepo, mockLogger)
+
+	settings := &domain.RecipientFeedSettings{
+		Enabled: true,
+		URL:     server.URL,
+		Headers: []domain.DataFeedHeader{
+			{Name: "X-Broadcast-Header", Value: "broadcast-value"},
+			{Name: "X-Tenant", Value: "broadcast-tenant"},
+		},
+	}
+	payload := &domain.RecipientFeedRequestPayload{
+		Contact:   domain.RecipientFeedContact{Email: "test@example.com"},
+		Broadcast: domain.RecipientFeedBroadcast{ID: "b-1", Name: "B"},
+		Workspace: domain.RecipientFeedWorkspace{ID: "w-1", Name: "W"},
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := fetcher.FetchRecipient(context.Background(), settings, payload)
+		require.NoError(t, err)
+
+		assert.Equal(t, "workspace-key", receivedHeaders.Get("X-Api-Key"))
+		assert.Equal(t, "broadcast-value", receivedHeaders.Get("X-Broadcast-Header"))
+		// The broadcast's header wins over the workspace default
+		assert.Equal(t, []string{"broadcast-tenant"}, receivedHeaders.Values("X-Tenant"))
+	}
+
+	// The broadcast settings are left untouched
+	assert.Len(t, settings.Headers, 2)
+}
+
 func TestDataFeedFetcher_FetchRecipient_RetryOn408(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -923,7 +1023,7 @@ func TestDataFeedFetcher_FetchRecipient_RetryOn408(t *testing.T) {
 	}))
 	defer server.Close()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	settings := &domain.RecipientFeedSettings{
 		Enabled: true,
@@ -978,7 +1078,7 @@ func TestDataFeedFetcher_FetchRecipient_RetryOn429(t *testing.T) {
 	}))
 	defer server.Close()
 
-	fetcher := NewDataFeedFetcher(mockLogger)
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
 
 	settings := &domain.RecipientFeedSettings{
 		Enabled: true,
@@ -1003,3 +1103,199 @@ func TestDataFeedFetcher_FetchRecipient_RetryOn429(t *testing.T) {
 	require.NotNil(t, result)
 	assert.Equal(t, true, result["_success"])
 }
+
+func TestDataFeedFetcher_FetchRecipientBatch_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&receivedBody); err != nil {
+			t.Fatalf("Failed to parse request body: %v", err)
+		}
+		// bob@example.com is deliberately missing from the response
+		response := map[string]interface{}{
+			"alice@example.com": map[string]interface{}{"discount_code": "ALICE10"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
+
+	settings := &domain.RecipientFeedSettings{
+		Enabled:   true,
+		URL:       server.URL,
+		Headers:   []domain.DataFeedHeader{},
+		FieldMap:  map[string]string{"discount_code": "code"},
+		BatchSize: 50,
+	}
+
+	payload := &domain.RecipientFeedBatchRequestPayload{
+		Contacts: []domain.RecipientFeedContact{
+			{Email: "alice@example.com"},
+			{Email: "bob@example.com"},
+		},
+		Broadcast: domain.RecipientFeedBroadcast{ID: "b-1", Name: "B"},
+		List:      domain.RecipientFeedList{ID: "l-1", Name: "L"},
+		Workspace: domain.RecipientFeedWorkspace{ID: "w-1"},
+	}
+
+	result, err := fetcher.FetchRecipientBatch(context.Background(), settings, payload)
+	require.NoError(t, err)
+
+	// Verify the contacts were sent as an array
+	contacts, ok := receivedBody["contacts"].([]interface{})
+	require.True(t, ok)
+	assert.Len(t, contacts, 2)
+	assert.Equal(t, "l-1", receivedBody["list"].(map[string]interface{})["id"])
+
+	// Every contact gets an entry, with the field map applied
+	require.Len(t, result, 2)
+	assert.Equal(t, "ALICE10", result["alice@example.com"]["code"])
+	assert.Equal(t, true, result["alice@example.com"]["_success"])
+	assert.Equal(t, true, result["bob@example.com"]["_success"])
+	assert.NotContains(t, result["bob@example.com"], "code")
+}
+
+func TestDataFeedFetcher_FetchRecipientBatch_InvalidResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	// A single-recipient style response is not keyed by email
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"discount_code": "SAVE10"}`))
+	}))
+	defer server.Close()
+
+	fetcher := NewDataFeedFetcher(nil, mockLogger)
+
+	settings := &domain.RecipientFeedSettings{
+		Enabled:   true,
+		URL:       server.URL,
+		BatchSize: 10,
+	}
+
+	payload := &domain.RecipientFeedBatchRequestPayload{
+		Contacts: []domain.RecipientFeedContact{{Email: "alice@example.com"}},
+	}
+
+	result, err := fetcher.FetchRecipientBatch(context.Background(), settings, payload)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "keyed by contact email")
+	assert.Nil(t, result)
+}
+
+func TestPrefetchRecipientFeeds_BatchSize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	// The server answers each contact with data derived from its email
+	var mu sync.Mutex
+	var batchSizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body domain.RecipientFeedBatchRequestPayload
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to parse request body: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		batchSizes = append(batchSizes, len(body.Contacts))
+		mu.Unlock()
+
+		response := make(map[string]interface{}, len(body.Contacts))
+		for _, contact := range body.Contacts {
+			response[contact.Email] = map[string]interface{}{
+				"greeting": "Hello " + contact.Email,
+				"list":     body.List.ID,
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	broadcast := &domain.Broadcast{
+		ID:   "broadcast-1",
+		Name: "Batched",
+		DataFeed: &domain.DataFeedSettings{
+			RecipientFeed: &domain.RecipientFeedSettings{
+				Enabled:   true,
+				URL:       server.URL,
+				BatchSize: 50,
+			},
+		},
+	}
+
+	// 120 recipients on one list and 5 on another
+	var recipients []*domain.ContactWithList
+	for i := 0; i < 120; i++ {
+		recipients = append(recipients, &domain.ContactWithList{
+			Contact: &domain.Contact{Email: fmt.Sprintf("user%d@example.com", i)},
+			ListID:  "list-a",
+		})
+	}
+	for i := 0; i < 5; i++ {
+		recipients = append(recipients, &domain.ContactWithList{
+			Contact: &domain.Contact{Email: fmt.Sprintf("other%d@example.com", i)},
+			ListID:  "list-b",
+		})
+	}
+
+	results, err := prefetchRecipientFeeds(context.Background(), NewDataFeedFetcher(nil, mockLogger), broadcast, "workspace-1", recipients)
+	require.NoError(t, err)
+
+	// 125 recipients were fetched in 4 requests instead of 125
+	assert.Equal(t, []int{50, 50, 20, 5}, batchSizes)
+
+	require.Len(t, results, 125)
+	for _, recipient := range recipients {
+		data := results[recipient.Contact.Email]
+		require.NotNil(t, data, recipient.Contact.Email)
+		assert.Equal(t, "Hello "+recipient.Contact.Email, data["greeting"])
+		assert.Equal(t, recipient.ListID, data["list"])
+		assert.Equal(t, true, data["_success"])
+	}
+}
+
+func TestPrefetchRecipientFeeds_NotBatched(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFetcher := bmocks.NewMockDataFeedFetcher(ctrl)
+
+	broadcast := &domain.Broadcast{
+		ID: "broadcast-1",
+		DataFeed: &domain.DataFeedSettings{
+			RecipientFeed: &domain.RecipientFeedSettings{
+				Enabled: true,
+				URL:     "https://feed.example.com/recipient",
+			},
+		},
+	}
+	recipients := []*domain.ContactWithList{{Contact: &domain.Contact{Email: "user@example.com"}}}
+
+	// No batch size: nothing is prefetched, the sender falls back to per-recipient requests
+	results, err := prefetchRecipientFeeds(context.Background(), mockFetcher, broadcast, "workspace-1", recipients)
+	require.NoError(t, err)
+	assert.Nil(t, results)
+}
@@ -0,0 +1,90 @@
+package broadcast
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// feedWorkspaceCacheTTL is how long a workspace's feed settings are reused
+// before being reloaded, so per-recipient fetches don't hit the database each time
+const feedWorkspaceCacheTTL = 1 * time.Minute
+
+// feedCachedWorkspace holds the workspace settings used by data feeds with their expiration time
+type feedCachedWorkspace struct {
+	headers   []domain.DataFeedHeader
+	secretKey string
+	expiresAt time.Time
+}
+
+// feedWorkspaceProvider loads and caches the workspace settings used by data feeds:
+// the default feed headers and the secret key the OAuth2 client secrets are encrypted with
+type feedWorkspaceProvider struct {
+	workspaceRepo  domain.WorkspaceRepository
+	mu             sync.Mutex
+	workspaceCache map[string]*feedCachedWorkspace
+}
+
+// newFeedWorkspaceProvider creates a new feedWorkspaceProvider
+func newFeedWorkspaceProvider(workspaceRepo domain.WorkspaceRepository) *feedWorkspaceProvider {
+	return &feedWorkspaceProvider{
+		workspaceRepo:  workspaceRepo,
+		workspaceCache: make(map[string]*feedCachedWorkspace),
+	}
+}
+
+// MergeHeaders returns the feed's headers merged with the workspace's default feed headers
+func (p *feedWorkspaceProvider) MergeHeaders(ctx context.Context, workspaceID string, headers []domain.DataFeedHeader) ([]domain.DataFeedHeader, error) {
+	if p == nil || p.workspaceRepo == nil || workspaceID == "" {
+		return headers, nil
+	}
+
+	cached, err := p.getWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workspace default feed headers: %w", err)
+	}
+
+	return domain.MergeFeedHeaders(cached.headers, headers), nil
+}
+
+// SecretKey returns the workspace secret key stored OAuth2 client secrets are encrypted with
+func (p *feedWorkspaceProvider) SecretKey(ctx context.Context, workspaceID string) (string, error) {
+	if p == nil || p.workspaceRepo == nil || workspaceID == "" {
+		return "", fmt.Errorf("workspace secret key is not available")
+	}
+
+	cached, err := p.getWorkspace(ctx, workspaceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load workspace secret key: %w", err)
+	}
+
+	return cached.secretKey, nil
+}
+
+// getWorkspace returns the workspace's feed settings,
+// using the cached value when it has not expired yet
+func (p *feedWorkspaceProvider) getWorkspace(ctx context.Context, workspaceID string) (*feedCachedWorkspace, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if cached, exists := p.workspaceCache[workspaceID]; exists && time.Now().Before(cached.expiresAt) {
+		return cached, nil
+	}
+
+	workspace, err := p.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	cached := &feedCachedWorkspace{
+		headers:   workspace.Settings.DefaultFeedHeaders,
+		secretKey: workspace.Settings.SecretKey,
+		expiresAt: time.Now().Add(feedWorkspaceCacheTTL),
+	}
+	p.workspaceCache[workspaceID] = cached
+
+	return cached, nil
+}
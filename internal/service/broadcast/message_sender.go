@@ -21,11 +21,11 @@ import (
 // MessageSender is the interface for sending messages to recipients
 type MessageSender interface {
 	// SendToRecipient sends a message to a single recipient
-	SendToRecipient(ctx context.Context, workspaceID string, integrationID string, endpoint string, trackingEnabled bool, broadcast *domain.Broadcast, messageID string, email string,
+	SendToRecipient(ctx context.Context, workspaceID string, integrationID string, endpoint string, trackingEnabled bool, bulkHeaders bool, broadcast *domain.Broadcast, messageID string, email string,
 		template *domain.Template, data map[string]interface{}, emailProvider *domain.EmailProvider, timeoutAt time.Time, contactLanguage string, workspaceDefaultLanguage string) error
 
 	// SendBatch sends messages to a batch of recipients
-	SendBatch(ctx context.Context, workspaceID string, integrationID string, workspaceSecretKey string, endpoint string, websiteURL string, trackingEnabled bool, broadcastID string, recipients []*domain.ContactWithList,
+	SendBatch(ctx context.Context, workspaceID string, integrationID string, workspaceSecretKey string, endpoint string, websiteURL string, trackingEnabled bool, bulkHeaders bool, broadcastID string, recipients []*domain.ContactWithList,
 		templates map[string]*domain.Template, emailProvider *domain.EmailProvider, timeoutAt time.Time, workspaceDefaultLanguage string) (sent int, failed int, err error)
 }
 
@@ -199,7 +199,7 @@ func (s *messageSender) enforceRateLimit(ctx context.Context, integrationRateLim
 }
 
 // SendToRecipient sends a message to a single recipient
-func (s *messageSender) SendToRecipient(ctx context.Context, workspaceID string, integrationID string, endpoint string, trackingEnabled bool, broadcast *domain.Broadcast, messageID string, email string,
+func (s *messageSender) SendToRecipient(ctx context.Context, workspaceID string, integrationID string, endpoint string, trackingEnabled bool, bulkHeaders bool, broadcast *domain.Broadcast, messageID string, email string,
 	template *domain.Template, data map[string]interface{}, emailProvider *domain.EmailProvider, timeoutAt time.Time, contactLanguage string, workspaceDefaultLanguage string) error {
 
 	// Ensure UTM parameters object is present to avoid nil dereference
@@ -335,7 +335,8 @@ func (s *messageSender) SendToRecipient(ctx context.Context, workspaceID string,
 		Content:       *compiledTemplate.HTML,
 		Provider:      emailProvider,
 		EmailOptions: domain.EmailOptions{
-			ReplyTo: emailContent.ReplyTo,
+			ReplyTo:     emailContent.ReplyTo,
+			BulkHeaders: bulkHeaders,
 		},
 	}
 
@@ -380,7 +381,7 @@ func (s *messageSender) SendToRecipient(ctx context.Context, workspaceID string,
 }
 
 // SendBatch sends messages to a batch of recipients
-func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integrationID string, workspaceSecretKey string, endpoint string, websiteURL string, trackingEnabled bool, broadcastID string, recipients []*domain.ContactWithList,
+func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integrationID string, workspaceSecretKey string, endpoint string, websiteURL string, trackingEnabled bool, bulkHeaders bool, broadcastID string, recipients []*domain.ContactWithList,
 	templates map[string]*domain.Template, emailProvider *domain.EmailProvider, timeoutAt time.Time, workspaceDefaultLanguage string) (sent int, failed int, err error) {
 
 	// Track specific error types for better reporting
@@ -462,6 +463,17 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 		"recipients":             len(recipients),
 	}).Info("Starting batch send with rate limiting")
 
+	// Fetch recipient feed data up front when the feed is batched
+	batchedFeedData, prefetchErr := prefetchRecipientFeeds(ctx, s.dataFeedFetcher, broadcast, workspaceID, recipients)
+	if prefetchErr != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"broadcast_id": broadcastID,
+			"workspace_id": workspaceID,
+			"error":        prefetchErr.Error(),
+		}).Error("Recipient feed batch fetch failed, pausing broadcast")
+		return sent, failed, fmt.Errorf("%w: recipient feed batch failed: %v", ErrBroadcastShouldPause, prefetchErr)
+	}
+
 	// Send to each recipient
 	for _, contactWithList := range recipients {
 		// Extract the contact from the ContactWithList
@@ -524,9 +536,12 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 		// Generate a unique message ID for tracking
 		messageID := generateMessageID(workspaceID)
 
+		// Seed copies are not tracked so opens and clicks don't count towards the stats
+		recipientTracking := trackingEnabled && !contactWithList.IsSeed
+
 		trackingSettings := notifuse_mjml.TrackingSettings{
 			Endpoint:       endpoint,
-			EnableTracking: trackingEnabled,
+			EnableTracking: recipientTracking,
 			UTMSource:      broadcast.UTMParameters.Source,
 			UTMMedium:      broadcast.UTMParameters.Medium,
 			UTMCampaign:    broadcast.UTMParameters.Campaign,
@@ -569,23 +584,32 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 
 		// Fetch recipient feed if configured and enabled
 		if broadcast.DataFeed != nil && broadcast.DataFeed.RecipientFeed != nil && broadcast.DataFeed.RecipientFeed.Enabled && s.dataFeedFetcher != nil {
-			// Build the recipient feed payload
-			payload := &domain.RecipientFeedRequestPayload{
-				Contact: domain.BuildRecipientFeedContact(contact),
-				List: domain.RecipientFeedList{
-					ID:   contactWithList.ListID,
-					Name: contactWithList.ListName,
-				},
-				Broadcast: domain.RecipientFeedBroadcast{
-					ID:   broadcast.ID,
-					Name: broadcast.Name,
-				},
-				Workspace: domain.RecipientFeedWorkspace{
-					ID: workspaceID,
-				},
-			}
+			var feedData map[string]interface{}
+			var feedErr error
+			if batchedFeedData != nil {
+				// Already fetched in a batched request
+				feedData = batchedFeedData[contact.Email]
+				recordFeedCacheHit(ctx, broadcast.ID)
+			} else {
+				// Build the recipient feed payload
+				payload := &domain.RecipientFeedRequestPayload{
+					Contact: domain.BuildRecipientFeedContact(contact),
+					List: domain.RecipientFeedList{
+						ID:   contactWithList.ListID,
+						Name: contactWithList.ListName,
+					},
+					Broadcast: domain.RecipientFeedBroadcast{
+						ID:       broadcast.ID,
+						Name:     broadcast.Name,
+						Metadata: broadcast.Metadata,
+					},
+					Workspace: domain.RecipientFeedWorkspace{
+						ID: workspaceID,
+					},
+				}
 
-			feedData, feedErr := s.dataFeedFetcher.FetchRecipient(ctx, broadcast.DataFeed.RecipientFeed, payload)
+				feedData, feedErr = s.dataFeedFetcher.FetchRecipient(ctx, broadcast.DataFeed.RecipientFeed, payload)
+			}
 			if feedErr != nil {
 				// Feed failed after retries - pause broadcast immediately
 				// If feed data is configured, it's mandatory - no skipping, no sending without data
@@ -611,7 +635,7 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 		}
 
 		// Send to the recipient
-		err = s.SendToRecipient(ctx, workspaceID, integrationID, endpoint, trackingEnabled, broadcast, messageID, contact.Email, templates[templateID], recipientData, emailProvider, timeoutAt, contactLanguage, workspaceDefaultLanguage)
+		err = s.SendToRecipient(ctx, workspaceID, integrationID, endpoint, recipientTracking, bulkHeaders, broadcast, messageID, contact.Email, templates[templateID], recipientData, emailProvider, timeoutAt, contactLanguage, workspaceDefaultLanguage)
 		if err != nil {
 			// SendToRecipient already logs errors
 			failed++
@@ -623,8 +647,17 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 			sent++
 		}
 
+		// Seed copies are kept out of message history, and so out of the broadcast stats
+		if contactWithList.IsSeed {
+			continue
+		}
+
 		now := time.Now().UTC()
-		listID := broadcast.Audience.List
+		// Attribute the message to the list the recipient was selected from (multi-list audiences)
+		listID := contactWithList.ListID
+		if listID == "" {
+			listID = broadcast.Audience.PrimaryList()
+		}
 		message := &domain.MessageHistory{
 			ID:              messageID,
 			ContactEmail:    contact.Email,
@@ -175,10 +175,64 @@ func TestSendToRecipientSuccess(t *testing.T) {
 
 	// Test
 	timeoutAt := time.Now().Add(30 * time.Second)
-	err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, broadcast, "message-123", "test@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
+	err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, true, broadcast, "message-123", "test@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
 	assert.NoError(t, err)
 }
 
+// TestSendToRecipientBulkHeaders tests that the workspace bulk headers setting reaches the provider request
+func TestSendToRecipientBulkHeaders(t *testing.T) {
+	for _, bulkHeaders := range []bool{true, false} {
+		t.Run(fmt.Sprintf("bulk headers %v", bulkHeaders), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+			mockLogger := pkgmocks.NewMockLogger(ctrl)
+			mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+			mockLogger.EXPECT().Debug(gomock.Any()).Return().AnyTimes()
+			mockLogger.EXPECT().Info(gomock.Any()).Return().AnyTimes()
+
+			broadcast := &domain.Broadcast{ID: "broadcast-123", WorkspaceID: "workspace-123", UTMParameters: &domain.UTMParameters{}}
+			emailSender := domain.NewEmailSender("sender@example.com", "Sender")
+			emailProvider := &domain.EmailProvider{
+				Kind:    domain.EmailProviderKindSMTP,
+				Senders: []domain.EmailSender{emailSender},
+				SMTP:    &domain.SMTPSettings{Host: "smtp.example.com", Port: 587, Username: "user", Password: "pass", UseTLS: true},
+			}
+			template := &domain.Template{
+				ID: "template-123",
+				Email: &domain.EmailTemplate{
+					SenderID:         emailSender.ID,
+					Subject:          "Test Subject",
+					VisualEditorTree: createValidTestTree(createTestTextBlock("txt1", "Test content")),
+				},
+			}
+
+			mockEmailService.EXPECT().
+				SendEmail(gomock.Any(), gomock.Any(), true).
+				DoAndReturn(func(_ context.Context, request domain.SendEmailProviderRequest, _ bool) error {
+					assert.Equal(t, bulkHeaders, request.EmailOptions.BulkHeaders)
+					return nil
+				})
+
+			sender := NewMessageSender(
+				mocks.NewMockBroadcastRepository(ctrl),
+				mocks.NewMockMessageHistoryRepository(ctrl),
+				mocks.NewMockTemplateRepository(ctrl),
+				mockEmailService,
+				nil, // dataFeedFetcher
+				mockLogger,
+				TestConfig(),
+				"",
+			)
+
+			timeoutAt := time.Now().Add(30 * time.Second)
+			err := sender.SendToRecipient(context.Background(), "workspace-123", "test-integration-id", "https://api.test.com", false, bulkHeaders, broadcast, "message-123", "test@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
+			assert.NoError(t, err)
+		})
+	}
+}
+
 // TestSendToRecipientCompileFailure tests failure in template compilation
 func TestSendToRecipientCompileFailure(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -249,7 +303,7 @@ func TestSendToRecipientCompileFailure(t *testing.T) {
 
 	// Test - this should fail due to template compilation issues
 	timeoutAt := time.Now().Add(30 * time.Second)
-	err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, broadcast, "message-123", "test@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
+	err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, true, broadcast, "message-123", "test@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
 	assert.Error(t, err)
 	broadcastErr, ok := err.(*BroadcastError)
 	assert.True(t, ok)
@@ -297,11 +351,11 @@ func TestWithMockMessageSender(t *testing.T) {
 	messageID := "test-message-id"
 	timeoutAt := time.Now().Add(30 * time.Second)
 	mockSender.EXPECT().
-		SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", trackingEnabled, broadcast, messageID, recipientEmail, template, templateData, nil, timeoutAt, "", "").
+		SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", trackingEnabled, true, broadcast, messageID, recipientEmail, template, templateData, nil, timeoutAt, "", "").
 		Return(nil)
 
 	// Use the mock (normally this would be in the system under test)
-	err := mockSender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", trackingEnabled, broadcast, messageID, recipientEmail, template, templateData, nil, timeoutAt, "", "")
+	err := mockSender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", trackingEnabled, true, broadcast, messageID, recipientEmail, template, templateData, nil, timeoutAt, "", "")
 
 	// Verify the result
 	assert.NoError(t, err)
@@ -317,11 +371,11 @@ func TestWithMockMessageSender(t *testing.T) {
 	// Set up expectations with specific return values
 	timeoutAt = time.Now().Add(30 * time.Second)
 	mockSender.EXPECT().
-		SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", "", trackingEnabled, broadcast.ID, mockContacts, mockTemplates, nil, timeoutAt, "").
+		SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", "", trackingEnabled, true, broadcast.ID, mockContacts, mockTemplates, nil, timeoutAt, "").
 		Return(1, 0, nil)
 
 	// Use the mock
-	sent, failed, err := mockSender.SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", "", trackingEnabled, broadcast.ID, mockContacts, mockTemplates, nil, timeoutAt, "")
+	sent, failed, err := mockSender.SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", "", trackingEnabled, true, broadcast.ID, mockContacts, mockTemplates, nil, timeoutAt, "")
 
 	// Verify results
 	assert.NoError(t, err)
@@ -371,11 +425,11 @@ func TestErrorHandlingWithMock(t *testing.T) {
 	mockError := errors.New("send failed: service unavailable")
 	messageID := "test-message-id"
 	mockSender.EXPECT().
-		SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", trackingEnabled, broadcast, messageID, recipientEmail, template, templateData, nil, timeoutAt, "", "").
+		SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", trackingEnabled, true, broadcast, messageID, recipientEmail, template, templateData, nil, timeoutAt, "", "").
 		Return(mockError)
 
 	// Call the method
-	err := mockSender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", trackingEnabled, broadcast, messageID, recipientEmail, template, templateData, nil, timeoutAt, "", "")
+	err := mockSender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", trackingEnabled, true, broadcast, messageID, recipientEmail, template, templateData, nil, timeoutAt, "", "")
 
 	// Verify error handling
 	assert.Error(t, err)
@@ -392,10 +446,10 @@ func TestErrorHandlingWithMock(t *testing.T) {
 	batchError := errors.New("batch processing failed")
 
 	mockSender.EXPECT().
-		SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", "", trackingEnabled, broadcast.ID, mockContacts, mockTemplates, nil, timeoutAt, "").
+		SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", "", trackingEnabled, true, broadcast.ID, mockContacts, mockTemplates, nil, timeoutAt, "").
 		Return(0, 0, batchError)
 
-	sent, failed, err := mockSender.SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", "", trackingEnabled, broadcast.ID, mockContacts, mockTemplates, nil, timeoutAt, "")
+	sent, failed, err := mockSender.SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", "", trackingEnabled, true, broadcast.ID, mockContacts, mockTemplates, nil, timeoutAt, "")
 	assert.Error(t, err)
 	assert.Equal(t, batchError, err)
 	assert.Equal(t, 0, sent)
@@ -522,7 +576,7 @@ func TestSendBatch(t *testing.T) {
 		},
 	}
 	templates := map[string]*domain.Template{"template-123": template}
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", "", tracking, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
+	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", "", tracking, true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
 	assert.NoError(t, err)
 	assert.Equal(t, 2, sent)
 	assert.Equal(t, 0, failed)
@@ -572,7 +626,7 @@ func TestSendBatch_EmptyRecipients(t *testing.T) {
 	)
 
 	// Call the method being tested with empty recipients
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", "", trackingEnabled, broadcastID, []*domain.ContactWithList{},
+	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", "", trackingEnabled, true, broadcastID, []*domain.ContactWithList{},
 		map[string]*domain.Template{}, emailProvider, timeoutAt, "")
 
 	// Verify results
@@ -640,7 +694,7 @@ func TestSendBatch_CircuitBreakerOpen(t *testing.T) {
 	messageSenderImpl.circuitBreaker.RecordFailure(fmt.Errorf("test error"))
 
 	// Call the method being tested
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", "", trackingEnabled, broadcastID, recipients,
+	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", "", trackingEnabled, true, broadcastID, recipients,
 		map[string]*domain.Template{}, emailProvider, timeoutAt, "")
 
 	// Verify results
@@ -787,7 +841,7 @@ func TestSendBatch_WithFailure(t *testing.T) {
 		},
 	}
 	templates := map[string]*domain.Template{"template-123": template}
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", "", tracking, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
+	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", "", tracking, true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
 	assert.NoError(t, err)
 	assert.Equal(t, 0, sent)
 	assert.Equal(t, 1, failed)
@@ -906,7 +960,7 @@ func TestSendBatch_RecordMessageFails(t *testing.T) {
 		},
 	}
 	templates := map[string]*domain.Template{"template-123": template}
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", "", tracking, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
+	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", "", tracking, true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
 	assert.NoError(t, err)
 	assert.Equal(t, 1, sent)
 	assert.Equal(t, 0, failed)
@@ -1003,7 +1057,7 @@ func TestSendToRecipientWithLiquidSubject(t *testing.T) {
 		Return(nil)
 
 	// Call the method
-	err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, broadcast, messageID, email, template, templateData, emailProvider, timeoutAt, "", "")
+	err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, true, broadcast, messageID, email, template, templateData, emailProvider, timeoutAt, "", "")
 
 	// Verify
 	assert.NoError(t, err)
@@ -1188,7 +1242,7 @@ func TestSendToRecipient_ErrorCases(t *testing.T) {
 			SendEmail(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(nil)
 
-		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, broadcast, "message-123", "test@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
+		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, true, broadcast, "message-123", "test@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
 		assert.NoError(t, err)
 		// UTM parameters should be initialized to non-nil
 		assert.NotNil(t, broadcast.UTMParameters)
@@ -1247,7 +1301,7 @@ func TestSendToRecipient_ErrorCases(t *testing.T) {
 			},
 		}
 
-		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, broadcast, "message-123", "test@example.com", template, map[string]interface{}{}, emptyEmailProvider, timeoutAt, "", "")
+		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, true, broadcast, "message-123", "test@example.com", template, map[string]interface{}{}, emptyEmailProvider, timeoutAt, "", "")
 		assert.Error(t, err)
 		broadcastErr, ok := err.(*BroadcastError)
 		assert.True(t, ok)
@@ -1320,7 +1374,7 @@ func TestSendToRecipient_ErrorCases(t *testing.T) {
 			Return(nil).
 			MaxTimes(1) // Allow 0 or 1 calls
 
-		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, broadcast, "message-123", "test@example.com", template, templateData, emailProvider, timeoutAt, "", "")
+		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, true, broadcast, "message-123", "test@example.com", template, templateData, emailProvider, timeoutAt, "", "")
 		// This might succeed or fail depending on the Liquid processor implementation
 		// If it fails, it should be a template compile error
 		if err != nil {
@@ -1399,7 +1453,7 @@ func TestSendToRecipient_ErrorCases(t *testing.T) {
 			Return(nil).
 			Times(1)
 
-		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, broadcast, "message-1", "test@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
+		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, true, broadcast, "message-1", "test@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
 		assert.NoError(t, err)
 
 		// Create a context that will be cancelled quickly
@@ -1418,7 +1472,7 @@ func TestSendToRecipient_ErrorCases(t *testing.T) {
 		// Second message should fail due to context cancellation
 		// Note: Error could be ErrCodeRateLimitExceeded (cancelled during rate limiting)
 		// or ErrCodeSendFailed (cancelled during email send)
-		err = sender.SendToRecipient(cancelCtx, workspaceID, "test-integration-id", "https://api.test.com", tracking, broadcast, "message-2", "test@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
+		err = sender.SendToRecipient(cancelCtx, workspaceID, "test-integration-id", "https://api.test.com", tracking, true, broadcast, "message-2", "test@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
 		assert.Error(t, err)
 		broadcastErr, ok := err.(*BroadcastError)
 		if assert.True(t, ok, "Expected error to be a BroadcastError but got: %T", err) {
@@ -1514,7 +1568,7 @@ func TestSendToRecipient_LanguageResolution(t *testing.T) {
 				return nil
 			})
 
-		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, broadcast, "message-fr", "fr-user@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "fr", "en")
+		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, true, broadcast, "message-fr", "fr-user@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "fr", "en")
 		assert.NoError(t, err)
 	})
 
@@ -1527,7 +1581,7 @@ func TestSendToRecipient_LanguageResolution(t *testing.T) {
 				return nil
 			})
 
-		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, broadcast, "message-default", "default-user@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "en")
+		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, true, broadcast, "message-default", "default-user@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "en")
 		assert.NoError(t, err)
 	})
 
@@ -1540,7 +1594,7 @@ func TestSendToRecipient_LanguageResolution(t *testing.T) {
 				return nil
 			})
 
-		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, broadcast, "message-unknown", "de-user@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "de", "en")
+		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, true, broadcast, "message-unknown", "de-user@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "de", "en")
 		assert.NoError(t, err)
 	})
 
@@ -1550,7 +1604,7 @@ func TestSendToRecipient_LanguageResolution(t *testing.T) {
 			Email: nil,
 		}
 
-		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, broadcast, "message-nil", "user@example.com", nilEmailTemplate, map[string]interface{}{}, emailProvider, timeoutAt, "", "en")
+		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, true, broadcast, "message-nil", "user@example.com", nilEmailTemplate, map[string]interface{}{}, emailProvider, timeoutAt, "", "en")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "email content not available")
 	})
@@ -1601,7 +1655,7 @@ func TestSendBatch_AdvancedScenarios(t *testing.T) {
 			GetBroadcast(ctx, workspaceID, broadcastID).
 			Return(nil, nil)
 
-		sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", "", true, broadcastID, recipients, map[string]*domain.Template{}, nil, timeoutAt, "")
+		sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", "", true, true, broadcastID, recipients, map[string]*domain.Template{}, nil, timeoutAt, "")
 
 		assert.Error(t, err)
 		assert.Equal(t, 0, sent)
@@ -1668,7 +1722,7 @@ func TestSendBatch_AdvancedScenarios(t *testing.T) {
 		// Use a timeout that's already passed
 		pastTimeout := time.Now().Add(-1 * time.Second)
 
-		sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", "", true, broadcastID, recipients, templates, emailProvider, pastTimeout, "")
+		sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", "", true, true, broadcastID, recipients, templates, emailProvider, pastTimeout, "")
 
 		// Should return immediately without processing any recipients
 		assert.NoError(t, err)
@@ -1759,7 +1813,7 @@ func TestSendBatch_AdvancedScenarios(t *testing.T) {
 			}).
 			Return(nil).Times(2)
 
-		sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", "", true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
+		sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", "", true, true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
 
 		assert.NoError(t, err)
 		assert.Equal(t, 2, sent)
@@ -1840,7 +1894,7 @@ func TestSendBatch_AdvancedScenarios(t *testing.T) {
 			}).
 			Return(nil)
 
-		sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", "", true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
+		sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", "", true, true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
 
 		assert.NoError(t, err)
 		assert.Equal(t, 1, sent)
@@ -1924,7 +1978,7 @@ func TestSendBatch_AdvancedScenarios(t *testing.T) {
 			}).
 			Return(nil).Times(3)
 
-		sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", "", true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
+		sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", "", true, true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
 
 		assert.NoError(t, err) // SendBatch itself doesn't return error, just counts
 		assert.Equal(t, 0, sent)
@@ -2154,7 +2208,7 @@ func TestSendBatch_TemplateDataBuildFailure(t *testing.T) {
 		}).
 		Return(nil).AnyTimes()
 
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", "", true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
+	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", "", true, true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
 
 	// Should handle the case gracefully
 	assert.NoError(t, err)
@@ -2258,7 +2312,7 @@ func TestSendBatch_EmptyEmailContact(t *testing.T) {
 		}).
 		Return(nil).Times(1)
 
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", "", true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
+	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", "", true, true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
 
 	assert.NoError(t, err)
 	assert.Equal(t, 1, sent)
@@ -2332,7 +2386,7 @@ func TestSendBatch_NoVariations(t *testing.T) {
 		GetBroadcast(ctx, workspaceID, broadcastID).
 		Return(broadcast, nil)
 
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", "", true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
+	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", "", true, true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
 
 	assert.NoError(t, err)
 	assert.Equal(t, 0, sent)
@@ -2401,7 +2455,7 @@ func TestSendToRecipient_CompilationFailsWithNilHTML(t *testing.T) {
 		},
 	}
 
-	err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, broadcast, "message-123", "test@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
+	err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, true, broadcast, "message-123", "test@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
 
 	// Should fail with template compilation error
 	assert.Error(t, err)
@@ -2484,7 +2538,7 @@ func TestSendToRecipient_CircuitBreakerSuccessRecording(t *testing.T) {
 		SendEmail(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil)
 
-	err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, broadcast, "message-123", "test@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
+	err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, true, broadcast, "message-123", "test@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
 
 	assert.NoError(t, err)
 	// Circuit breaker should have recorded success and reset failures
@@ -2668,12 +2722,12 @@ func TestPerBroadcastRateLimit(t *testing.T) {
 			Return(nil).Times(2)
 
 		// First send should be fast
-		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, broadcast, "message-1", "test1@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
+		err := sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, true, broadcast, "message-1", "test1@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
 		assert.NoError(t, err)
 
 		// Second send should be delayed due to broadcast rate limit
 		start := time.Now()
-		err = sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, broadcast, "message-2", "test2@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
+		err = sender.SendToRecipient(ctx, workspaceID, "test-integration-id", "https://api.test.com", tracking, true, broadcast, "message-2", "test2@example.com", template, map[string]interface{}{}, emailProvider, timeoutAt, "", "")
 		elapsed := time.Since(start)
 		assert.NoError(t, err)
 
@@ -2801,7 +2855,7 @@ func TestSendBatch_WithRecipientFeed_Success(t *testing.T) {
 	}
 	templates := map[string]*domain.Template{"template-123": template}
 
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", "", tracking, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
+	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", "", tracking, true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
 	assert.NoError(t, err)
 	assert.Equal(t, 1, sent)
 	assert.Equal(t, 0, failed)
@@ -2915,7 +2969,7 @@ func TestSendBatch_WithRecipientFeed_PauseOnFailure(t *testing.T) {
 	}
 	templates := map[string]*domain.Template{"template-123": template}
 
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", "", tracking, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
+	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", "", tracking, true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
 	// Broadcast should pause on first feed failure
 	assert.Error(t, err)
 	assert.True(t, errors.Is(err, ErrBroadcastShouldPause), "Expected ErrBroadcastShouldPause")
@@ -3063,7 +3117,7 @@ func TestSendBatch_WithBothFeeds(t *testing.T) {
 	}
 	templates := map[string]*domain.Template{"template-123": template}
 
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", "", tracking, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
+	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", "", tracking, true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
 	assert.NoError(t, err)
 	assert.Equal(t, 1, sent)
 	assert.Equal(t, 0, failed)
@@ -3179,7 +3233,7 @@ func TestSendBatch_WithRecipientFeed_Disabled(t *testing.T) {
 	}
 	templates := map[string]*domain.Template{"template-123": template}
 
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", "", tracking, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
+	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", "", tracking, true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
 	assert.NoError(t, err)
 	assert.Equal(t, 1, sent)
 	assert.Equal(t, 0, failed)
@@ -3290,7 +3344,7 @@ func TestSendBatch_WithRecipientFeed_NilSettings(t *testing.T) {
 	}
 	templates := map[string]*domain.Template{"template-123": template}
 
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", "", tracking, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
+	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", "", tracking, true, broadcastID, recipients, templates, emailProvider, timeoutAt, "")
 	assert.NoError(t, err)
 	assert.Equal(t, 1, sent)
 	assert.Equal(t, 0, failed)
@@ -64,3 +64,18 @@ func (mr *MockDataFeedFetcherMockRecorder) FetchRecipient(arg0, arg1, arg2 inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRecipient", reflect.TypeOf((*MockDataFeedFetcher)(nil).FetchRecipient), arg0, arg1, arg2)
 }
+
+// FetchRecipientBatch mocks base method.
+func (m *MockDataFeedFetcher) FetchRecipientBatch(arg0 context.Context, arg1 *domain.RecipientFeedSettings, arg2 *domain.RecipientFeedBatchRequestPayload) (map[string]map[string]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRecipientBatch", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]map[string]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRecipientBatch indicates an expected call of FetchRecipientBatch.
+func (mr *MockDataFeedFetcherMockRecorder) FetchRecipientBatch(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRecipientBatch", reflect.TypeOf((*MockDataFeedFetcher)(nil).FetchRecipientBatch), arg0, arg1, arg2)
+}
@@ -267,7 +267,7 @@ func (o *BroadcastOrchestrator) GetTotalRecipientCount(ctx context.Context, work
 		"broadcast_id":      broadcastID,
 		"workspace_id":      workspaceID,
 		"recipient_count":   count,
-		"audience_list":     broadcast.Audience.List,
+		"audience_list":     broadcast.Audience.ListIDs(),
 		"audience_segments": len(broadcast.Audience.Segments),
 	}).Info("Got recipient count for broadcast")
 	// codecov:ignore:end
@@ -443,6 +443,213 @@ func (o *BroadcastOrchestrator) SaveProgressState(
 	return currentTime, nil
 }
 
+// sendSeedCopies sends the broadcast to the workspace seed inboxes. Their sends are not added
+// to the enqueued or failed counts. It returns false when the seeds should be retried on the next run.
+func (o *BroadcastOrchestrator) sendSeedCopies(
+	ctx context.Context,
+	workspaceID string,
+	workspace *domain.Workspace,
+	integrationID string,
+	broadcastID string,
+	templates map[string]*domain.Template,
+	emailProvider *domain.EmailProvider,
+	timeoutAt time.Time,
+) bool {
+	endpoint := o.apiEndpoint
+	if workspace.Settings.CustomEndpointURL != nil && *workspace.Settings.CustomEndpointURL != "" {
+		endpoint = *workspace.Settings.CustomEndpointURL
+	}
+
+	seeds := workspace.Settings.BroadcastSeedRecipients()
+	sent, failed, err := o.messageSender.SendBatch(
+		ctx,
+		workspaceID,
+		integrationID,
+		workspace.Settings.SecretKey,
+		endpoint,
+		workspace.Settings.WebsiteURL,
+		workspace.Settings.EmailTrackingEnabled,
+		workspace.Settings.BulkHeadersEnabled(),
+		broadcastID,
+		seeds,
+		templates,
+		emailProvider,
+		timeoutAt,
+		workspace.Settings.DefaultLanguage,
+	)
+	if err != nil {
+		o.logger.WithFields(map[string]interface{}{
+			"broadcast_id": broadcastID,
+			"workspace_id": workspaceID,
+			"seeds":        len(seeds),
+			"error":        err.Error(),
+		}).Warn("Failed to send broadcast to seed list, will retry on next run")
+		return false
+	}
+
+	o.logger.WithFields(map[string]interface{}{
+		"broadcast_id": broadcastID,
+		"workspace_id": workspaceID,
+		"sent":         sent,
+		"failed":       failed,
+	}).Info("Broadcast sent to seed list")
+	return true
+}
+
+// processListJoin runs one cycle of a list-join broadcast: every active member of the list
+// who has been on it for the join offset receives the broadcast once, in join order.
+// It returns true when the cycle is over so the recurring task is rescheduled.
+func (o *BroadcastOrchestrator) processListJoin(ctx context.Context, task *domain.Task, broadcastState *domain.SendBroadcastState, timeoutAt time.Time) (bool, error) {
+	broadcast, err := o.broadcastRepo.GetBroadcast(ctx, task.WorkspaceID, broadcastState.BroadcastID)
+	if err != nil {
+		return false, err
+	}
+
+	switch broadcast.Status {
+	case domain.BroadcastStatusCancelled, domain.BroadcastStatusPaused:
+		task.State.Message = fmt.Sprintf("List-join broadcast %s", broadcast.Status)
+		return true, nil
+	case domain.BroadcastStatusScheduled:
+		now := time.Now().UTC()
+		broadcast.Status = domain.BroadcastStatusProcessing
+		broadcast.StartedAt = &now
+		broadcast.UpdatedAt = now
+		if updateErr := o.broadcastRepo.UpdateBroadcast(ctx, broadcast); updateErr != nil {
+			return false, fmt.Errorf("failed to update broadcast status to processing: %w", updateErr)
+		}
+	}
+
+	workspace, err := o.workspaceRepo.GetByID(ctx, task.WorkspaceID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	emailProvider, integrationID, err := workspace.GetEmailProviderWithIntegrationID(true)
+	if err != nil {
+		return false, err
+	}
+	if emailProvider == nil || emailProvider.Kind == "" {
+		return false, fmt.Errorf("no email provider configured for marketing emails")
+	}
+
+	templateIDs := make([]string, len(broadcast.TestSettings.Variations))
+	for i, variation := range broadcast.TestSettings.Variations {
+		templateIDs[i] = variation.TemplateID
+	}
+	templates, err := o.LoadTemplates(ctx, task.WorkspaceID, templateIDs)
+	if err != nil {
+		return false, err
+	}
+	if err := o.ValidateTemplates(templates); err != nil {
+		return false, err
+	}
+
+	endpoint := o.apiEndpoint
+	if workspace.Settings.CustomEndpointURL != nil && *workspace.Settings.CustomEndpointURL != "" {
+		endpoint = *workspace.Settings.CustomEndpointURL
+	}
+
+	listID := broadcast.Audience.PrimaryList()
+	joinedBefore := o.timeProvider.Now().UTC().Add(-broadcast.Schedule.JoinOffsetDuration())
+	var afterJoinedAt time.Time
+	if broadcastState.ListJoinCursorAt != nil {
+		afterJoinedAt = *broadcastState.ListJoinCursorAt
+	}
+
+	sentThisCycle := 0
+	for time.Now().Before(timeoutAt) {
+		members, fetchErr := o.contactRepo.GetContactsJoinedListBefore(
+			ctx,
+			task.WorkspaceID,
+			listID,
+			joinedBefore,
+			afterJoinedAt,
+			broadcastState.LastProcessedEmail,
+			o.config.FetchBatchSize,
+		)
+		if fetchErr != nil {
+			return false, fmt.Errorf("failed to fetch list members: %w", fetchErr)
+		}
+		if len(members) == 0 {
+			break
+		}
+
+		sent, failed, sendErr := o.messageSender.SendBatch(
+			ctx,
+			task.WorkspaceID,
+			integrationID,
+			workspace.Settings.SecretKey,
+			endpoint,
+			workspace.Settings.WebsiteURL,
+			workspace.Settings.EmailTrackingEnabled,
+			workspace.Settings.BulkHeadersEnabled(),
+			broadcastState.BroadcastID,
+			members,
+			templates,
+			emailProvider,
+			timeoutAt,
+			workspace.Settings.DefaultLanguage,
+		)
+		if sendErr != nil {
+			return false, sendErr
+		}
+
+		last := members[len(members)-1]
+		afterJoinedAt = last.JoinedAt
+		broadcastState.ListJoinCursorAt = &afterJoinedAt
+		broadcastState.LastProcessedEmail = last.Contact.Email
+		broadcastState.EnqueuedCount += sent
+		broadcastState.FailedCount += failed
+		sentThisCycle += sent
+
+		if len(members) < o.config.FetchBatchSize {
+			break
+		}
+	}
+
+	if sentThisCycle > 0 {
+		broadcast.EnqueuedCount = broadcastState.EnqueuedCount
+		broadcast.UpdatedAt = time.Now().UTC()
+		if updateErr := o.broadcastRepo.UpdateBroadcast(ctx, broadcast); updateErr != nil {
+			return false, fmt.Errorf("failed to update broadcast enqueued count: %w", updateErr)
+		}
+	}
+
+	o.logger.WithFields(map[string]interface{}{
+		"task_id":        task.ID,
+		"broadcast_id":   broadcastState.BroadcastID,
+		"list_id":        listID,
+		"joined_before":  joinedBefore.Format(time.RFC3339),
+		"sent":           sentThisCycle,
+		"enqueued_total": broadcastState.EnqueuedCount,
+	}).Info("List-join broadcast cycle completed")
+
+	task.State.Message = fmt.Sprintf("Sent to %d list members so far", broadcastState.EnqueuedCount)
+	return true, nil
+}
+
+// applyRampSchedule sets when each recipient of a batch may be sent according to the broadcast's
+// ramp schedule. offset is the position of the first recipient in send order; recipients already
+// due are left to be sent right away.
+func applyRampSchedule(broadcast *domain.Broadcast, recipients []*domain.ContactWithList, offset int, now time.Time) {
+	ramp := broadcast.Schedule.RampSchedule
+	if ramp == nil {
+		return
+	}
+
+	startedAt := now
+	if broadcast.StartedAt != nil {
+		startedAt = *broadcast.StartedAt
+	}
+
+	for i, recipient := range recipients {
+		releaseAt := ramp.ReleaseAt(startedAt, offset+i).UTC()
+		if releaseAt.After(now) {
+			recipient.SendAfter = &releaseAt
+		}
+	}
+}
+
 // Process executes or continues a broadcast sending task
 func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task, timeoutAt time.Time) (bool, error) {
 	o.logger.WithField("task_id", task.ID).Info("Processing send_broadcast task")
@@ -572,6 +779,12 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 	// Store the broadcast ID for the defer function
 	broadcastID = broadcastState.BroadcastID
 
+	// List-join broadcasts run as a recurring task and send to members as they reach the offset
+	if broadcastState.ListJoin {
+		allDone, err = o.processListJoin(ctx, task, broadcastState, timeoutAt)
+		return allDone, err
+	}
+
 	// Track progress
 	sentCount := broadcastState.EnqueuedCount
 	failedCount := broadcastState.FailedCount
@@ -831,6 +1044,11 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 		return false, err
 	}
 
+	// Send the workspace seed inboxes their copy once, outside the recipient counts
+	if !broadcastState.SeedsEnqueued && len(workspace.Settings.BroadcastSeedList) > 0 {
+		broadcastState.SeedsEnqueued = o.sendSeedCopies(ctx, task.WorkspaceID, workspace, integrationID, broadcastState.BroadcastID, templates, emailProvider, timeoutAt)
+	}
+
 	// Phase 3: Process recipients in batches with a timeout
 	// Use the timeoutAt parameter passed from task service
 	processTimeoutAt := timeoutAt
@@ -1058,6 +1276,9 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 			break
 		}
 
+		// Hold back the recipients beyond the ramp schedule's allowance for the current interval
+		applyRampSchedule(broadcast, recipients, currentOffset, o.timeProvider.Now())
+
 		// Use workspace CustomEndpointURL if provided, otherwise use default API endpoint
 		endpoint := o.apiEndpoint
 		if workspace.Settings.CustomEndpointURL != nil && *workspace.Settings.CustomEndpointURL != "" {
@@ -1073,6 +1294,7 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 			endpoint,
 			workspace.Settings.WebsiteURL,
 			workspace.Settings.EmailTrackingEnabled,
+			workspace.Settings.BulkHeadersEnabled(),
 			broadcastState.BroadcastID,
 			recipients,
 			templates,
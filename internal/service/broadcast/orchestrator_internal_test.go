@@ -290,3 +290,74 @@ func TestHandleTestPhaseCompletion_AutoWinnerLogsEvaluationTime(t *testing.T) {
 	// The key assertion is that the test passed without errors, meaning the auto-winner
 	// evaluation time logging path (lines 1069-1075) was executed successfully
 }
+
+func TestApplyRampSchedule(t *testing.T) {
+	startedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	newRecipients := func(n int) []*domain.ContactWithList {
+		recipients := make([]*domain.ContactWithList, n)
+		for i := range recipients {
+			recipients[i] = &domain.ContactWithList{Contact: &domain.Contact{Email: "user@example.com"}}
+		}
+		return recipients
+	}
+
+	t.Run("send counts per interval follow the ramp", func(t *testing.T) {
+		broadcast := &domain.Broadcast{
+			StartedAt: &startedAt,
+			Schedule: domain.ScheduleSettings{
+				RampSchedule: &domain.BroadcastRampSchedule{IntervalMinutes: 60, Steps: []int{2, 3}},
+			},
+		}
+
+		// 8 recipients fetched in batches of 3, as the orchestrator does
+		var all []*domain.ContactWithList
+		for offset := 0; offset < 8; offset += 3 {
+			batch := newRecipients(min(3, 8-offset))
+			applyRampSchedule(broadcast, batch, offset, startedAt)
+			all = append(all, batch...)
+		}
+
+		perInterval := map[time.Duration]int{}
+		for _, recipient := range all {
+			var delay time.Duration
+			if recipient.SendAfter != nil {
+				delay = recipient.SendAfter.Sub(startedAt)
+			}
+			perInterval[delay]++
+		}
+		assert.Equal(t, map[time.Duration]int{
+			0:                2,
+			60 * time.Minute: 3,
+			// Once the steps are used up the rest is sent at full speed
+			120 * time.Minute: 3,
+		}, perInterval)
+	})
+
+	t.Run("recipients whose interval has passed are not delayed", func(t *testing.T) {
+		broadcast := &domain.Broadcast{
+			StartedAt: &startedAt,
+			Schedule: domain.ScheduleSettings{
+				RampSchedule: &domain.BroadcastRampSchedule{IntervalMinutes: 60, Steps: []int{2, 3}},
+			},
+		}
+
+		// Resumed 90 minutes in: the second interval has opened, the third has not
+		recipients := newRecipients(4)
+		applyRampSchedule(broadcast, recipients, 2, startedAt.Add(90*time.Minute))
+
+		for _, recipient := range recipients[:3] {
+			assert.Nil(t, recipient.SendAfter)
+		}
+		require.NotNil(t, recipients[3].SendAfter)
+		assert.Equal(t, startedAt.Add(120*time.Minute), *recipients[3].SendAfter)
+	})
+
+	t.Run("no ramp schedule", func(t *testing.T) {
+		recipients := newRecipients(3)
+		applyRampSchedule(&domain.Broadcast{StartedAt: &startedAt}, recipients, 0, startedAt)
+
+		for _, recipient := range recipients {
+			assert.Nil(t, recipient.SendAfter)
+		}
+	})
+}
@@ -754,6 +754,7 @@ func TestBroadcastOrchestrator_Process(t *testing.T) {
 					gomock.Any(),
 					gomock.Any(),
 					true,
+					true,
 					"broadcast-123",
 					recipients,
 					gomock.Any(),
@@ -886,6 +887,7 @@ func TestBroadcastOrchestrator_Process(t *testing.T) {
 					gomock.Any(),
 					gomock.Any(),
 					true,
+					true,
 					"broadcast-123",
 					recipients,
 					gomock.Any(),
@@ -1329,6 +1331,7 @@ func TestBroadcastOrchestrator_Process(t *testing.T) {
 					gomock.Any(),
 					gomock.Any(),
 					true,
+					true,
 					"broadcast-456",
 					recipients,
 					gomock.Any(),
@@ -1490,7 +1493,7 @@ func TestBroadcastOrchestrator_Process_ABTestStartSetsTestingAndCompletesTestPha
 	mockContactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", bcast.Audience, 1, "").Return(recipients, nil)
 
 	// Send batch
-	mockMessageSender.EXPECT().SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), gomock.Any(), true, "broadcast-123", recipients, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(1, 0, nil)
+	mockMessageSender.EXPECT().SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), gomock.Any(), true, true, "broadcast-123", recipients, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(1, 0, nil)
 
 	// Save state
 	mockTaskRepo.EXPECT().SaveState(gomock.Any(), "workspace-123", "task-123", gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
@@ -1803,7 +1806,7 @@ func TestBroadcastOrchestrator_Process_AutoWinnerEvaluationPath(t *testing.T) {
 	mockContactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "w", bcast.Audience, 1, "").Return([]*domain.ContactWithList{{Contact: &domain.Contact{Email: "w@x.com"}}}, nil)
 
 	// Send
-	mockMessageSender.EXPECT().SendBatch(gomock.Any(), "w", "pid", "k", gomock.Any(), gomock.Any(), true, "b", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(1, 0, nil)
+	mockMessageSender.EXPECT().SendBatch(gomock.Any(), "w", "pid", "k", gomock.Any(), gomock.Any(), true, true, "b", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(1, 0, nil)
 
 	// Save state
 	mockTaskRepo.EXPECT().SaveState(gomock.Any(), "w", "t", gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
@@ -1941,6 +1944,7 @@ func TestBroadcastOrchestrator_Process_ABTestWinnerPhaseProcessesRemainingRecipi
 		gomock.Any(), // custom endpoint
 		gomock.Any(),
 		true,
+		true,
 		"broadcast-123",
 		[]*domain.ContactWithList{recipient},
 		gomock.Any(), // templates
@@ -2026,6 +2030,300 @@ func TestBroadcastOrchestrator_Process_ABTestWinnerPhaseProcessesRemainingRecipi
 	assert.Equal(t, 100.0, task.Progress, "Task progress should be 100%")
 }
 
+// TestBroadcastOrchestrator_Process_SendsSeedListOutsideRecipientCounts tests that the workspace
+// seed inboxes receive the broadcast once while the recipient counts only cover the audience
+func TestBroadcastOrchestrator_Process_SendsSeedListOutsideRecipientCounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMessageSender := mocks.NewMockMessageSender(ctrl)
+	mockBroadcastRepo := domainmocks.NewMockBroadcastRepository(ctrl)
+	mockTemplateRepo := domainmocks.NewMockTemplateRepository(ctrl)
+	mockContactRepo := domainmocks.NewMockContactRepository(ctrl)
+	mockTaskRepo := domainmocks.NewMockTaskRepository(ctrl)
+	mockWorkspaceRepo := domainmocks.NewMockWorkspaceRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockTimeProvider := mocks.NewMockTimeProvider(ctrl)
+	mockEventBus := domainmocks.NewMockEventBus(ctrl)
+
+	base := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockTimeProvider.EXPECT().Now().Return(base).AnyTimes()
+	mockTimeProvider.EXPECT().Since(gomock.Any()).Return(time.Minute).AnyTimes()
+
+	workspace := &domain.Workspace{
+		ID: "workspace-123",
+		Settings: domain.WorkspaceSettings{
+			SecretKey:                "secret-key",
+			EmailTrackingEnabled:     true,
+			MarketingEmailProviderID: "marketing-provider-id",
+			BroadcastSeedList:        []string{"seed1@inbox.test", "seed2@inbox.test"},
+		},
+		Integrations: []domain.Integration{
+			{
+				ID:   "marketing-provider-id",
+				Type: domain.IntegrationTypeEmail,
+				EmailProvider: domain.EmailProvider{
+					Kind: domain.EmailProviderKindSES,
+					SES: &domain.AmazonSESSettings{
+						AccessKey: "access-key",
+						SecretKey: "secret-key",
+						Region:    "us-east-1",
+					},
+				},
+			},
+		},
+	}
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "workspace-123").Return(workspace, nil).AnyTimes()
+
+	bcast := &domain.Broadcast{
+		ID:          "broadcast-123",
+		WorkspaceID: "workspace-123",
+		Audience:    domain.AudienceSettings{List: "list-1"},
+		Status:      domain.BroadcastStatusProcessing,
+		TestSettings: domain.BroadcastTestSettings{
+			Variations: []domain.BroadcastVariation{{TemplateID: "template-1"}},
+		},
+	}
+	mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-123", "broadcast-123").Return(bcast, nil).AnyTimes()
+
+	template := &domain.Template{
+		ID: "template-1",
+		Email: &domain.EmailTemplate{
+			Subject:  "Test Subject",
+			SenderID: "sender-1",
+			VisualEditorTree: &notifuse_mjml.MJMLBlock{
+				BaseBlock: notifuse_mjml.NewBaseBlock("root", notifuse_mjml.MJMLComponentMjml),
+			},
+		},
+	}
+	mockTemplateRepo.EXPECT().GetTemplateByID(gomock.Any(), "workspace-123", "template-1", int64(0)).Return(template, nil).AnyTimes()
+
+	recipients := []*domain.ContactWithList{
+		{Contact: &domain.Contact{Email: "recipient1@example.com"}, ListID: "list-1"},
+		{Contact: &domain.Contact{Email: "recipient2@example.com"}, ListID: "list-1"},
+	}
+	mockContactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", bcast.Audience, 2, "").
+		Return(recipients, nil)
+
+	// Seeds are sent first, in their own batch, flagged as seeds
+	var seedsReceived []string
+	seedBatch := mockMessageSender.EXPECT().SendBatch(
+		gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key",
+		gomock.Any(), gomock.Any(), true, true, "broadcast-123",
+		workspace.Settings.BroadcastSeedRecipients(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+	).DoAndReturn(func(_ context.Context, _, _, _, _, _ string, _, _ bool, _ string, seeds []*domain.ContactWithList,
+		_ map[string]*domain.Template, _ *domain.EmailProvider, _ time.Time, _ string) (int, int, error) {
+		for _, seed := range seeds {
+			assert.True(t, seed.IsSeed)
+			seedsReceived = append(seedsReceived, seed.Contact.Email)
+		}
+		return len(seeds), 0, nil
+	})
+	mockMessageSender.EXPECT().SendBatch(
+		gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key",
+		gomock.Any(), gomock.Any(), true, true, "broadcast-123",
+		recipients,
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+	).Return(2, 0, nil).After(seedBatch)
+
+	mockTaskRepo.EXPECT().SaveState(gomock.Any(), "workspace-123", "task-123", gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	mockBroadcastRepo.EXPECT().UpdateBroadcast(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, b *domain.Broadcast) error {
+		assert.Equal(t, domain.BroadcastStatusProcessed, b.Status)
+		assert.Equal(t, 2, b.EnqueuedCount, "Broadcast enqueued count should exclude the seeds")
+		return nil
+	})
+
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	orchestrator := broadcast.NewBroadcastOrchestrator(
+		mockMessageSender,
+		mockBroadcastRepo,
+		mockTemplateRepo,
+		mockContactRepo,
+		mockTaskRepo,
+		mockWorkspaceRepo,
+		nil, // emailQueueRepo not needed for this test
+		nil, // abTestEvaluator not needed for this test
+		mockLogger,
+		&broadcast.Config{
+			FetchBatchSize:      100,
+			MaxProcessTime:      30 * time.Second,
+			ProgressLogInterval: 5 * time.Second,
+		},
+		mockTimeProvider,
+		"https://api.example.com",
+		mockEventBus,
+	)
+
+	task := &domain.Task{
+		ID:          "task-123",
+		WorkspaceID: "workspace-123",
+		Type:        "send_broadcast",
+		BroadcastID: stringPtr("broadcast-123"),
+		State: &domain.TaskState{
+			SendBroadcast: &domain.SendBroadcastState{
+				BroadcastID:     "broadcast-123",
+				TotalRecipients: 2,
+				Phase:           "single",
+			},
+		},
+	}
+
+	done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.ElementsMatch(t, []string{"seed1@inbox.test", "seed2@inbox.test"}, seedsReceived, "Every seed inbox should receive the broadcast")
+	assert.True(t, task.State.SendBroadcast.SeedsEnqueued)
+	assert.Equal(t, 2, task.State.SendBroadcast.TotalRecipients, "Total recipients should exclude the seeds")
+	assert.Equal(t, 2, task.State.SendBroadcast.EnqueuedCount, "Enqueued count should exclude the seeds")
+	assert.Equal(t, 100.0, task.Progress)
+}
+
+func TestBroadcastOrchestrator_Process_ListJoinSendsMembersPastOffset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMessageSender := mocks.NewMockMessageSender(ctrl)
+	mockBroadcastRepo := domainmocks.NewMockBroadcastRepository(ctrl)
+	mockTemplateRepo := domainmocks.NewMockTemplateRepository(ctrl)
+	mockContactRepo := domainmocks.NewMockContactRepository(ctrl)
+	mockTaskRepo := domainmocks.NewMockTaskRepository(ctrl)
+	mockWorkspaceRepo := domainmocks.NewMockWorkspaceRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockTimeProvider := mocks.NewMockTimeProvider(ctrl)
+	mockEventBus := domainmocks.NewMockEventBus(ctrl)
+
+	base := time.Date(2023, 1, 10, 12, 0, 0, 0, time.UTC)
+	mockTimeProvider.EXPECT().Now().Return(base).AnyTimes()
+
+	workspace := &domain.Workspace{
+		ID: "workspace-123",
+		Settings: domain.WorkspaceSettings{
+			SecretKey:                "secret-key",
+			MarketingEmailProviderID: "marketing-provider-id",
+		},
+		Integrations: []domain.Integration{
+			{
+				ID:   "marketing-provider-id",
+				Type: domain.IntegrationTypeEmail,
+				EmailProvider: domain.EmailProvider{
+					Kind: domain.EmailProviderKindSES,
+					SES: &domain.AmazonSESSettings{
+						AccessKey: "access-key",
+						SecretKey: "secret-key",
+						Region:    "us-east-1",
+					},
+				},
+			},
+		},
+	}
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "workspace-123").Return(workspace, nil)
+
+	bcast := &domain.Broadcast{
+		ID:          "broadcast-123",
+		WorkspaceID: "workspace-123",
+		Audience:    domain.AudienceSettings{List: "list-1"},
+		Status:      domain.BroadcastStatusProcessing,
+		Schedule: domain.ScheduleSettings{
+			TriggerMode:    domain.BroadcastTriggerModeListJoin,
+			JoinOffset:     3,
+			JoinOffsetUnit: "days",
+		},
+		TestSettings: domain.BroadcastTestSettings{
+			Variations: []domain.BroadcastVariation{{TemplateID: "template-1"}},
+		},
+	}
+	mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-123", "broadcast-123").Return(bcast, nil)
+
+	template := &domain.Template{
+		ID: "template-1",
+		Email: &domain.EmailTemplate{
+			Subject:  "Welcome",
+			SenderID: "sender-1",
+			VisualEditorTree: &notifuse_mjml.MJMLBlock{
+				BaseBlock: notifuse_mjml.NewBaseBlock("root", notifuse_mjml.MJMLComponentMjml),
+			},
+		},
+	}
+	mockTemplateRepo.EXPECT().GetTemplateByID(gomock.Any(), "workspace-123", "template-1", int64(0)).Return(template, nil)
+
+	// Only members who joined at least 3 days before now are due
+	joinedAt := base.Add(-80 * time.Hour)
+	members := []*domain.ContactWithList{
+		{Contact: &domain.Contact{Email: "old@example.com"}, ListID: "list-1", JoinedAt: joinedAt},
+	}
+	mockContactRepo.EXPECT().
+		GetContactsJoinedListBefore(gomock.Any(), "workspace-123", "list-1", base.Add(-72*time.Hour), time.Time{}, "", 100).
+		Return(members, nil)
+
+	mockMessageSender.EXPECT().SendBatch(
+		gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key",
+		"https://api.example.com", gomock.Any(), false, true, "broadcast-123",
+		members,
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+	).Return(1, 0, nil)
+
+	mockBroadcastRepo.EXPECT().UpdateBroadcast(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, b *domain.Broadcast) error {
+		assert.Equal(t, domain.BroadcastStatusProcessing, b.Status, "List-join broadcasts keep running")
+		assert.Equal(t, 1, b.EnqueuedCount)
+		return nil
+	})
+
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	orchestrator := broadcast.NewBroadcastOrchestrator(
+		mockMessageSender,
+		mockBroadcastRepo,
+		mockTemplateRepo,
+		mockContactRepo,
+		mockTaskRepo,
+		mockWorkspaceRepo,
+		nil, // emailQueueRepo not needed for this test
+		nil, // abTestEvaluator not needed for this test
+		mockLogger,
+		&broadcast.Config{
+			FetchBatchSize:      100,
+			MaxProcessTime:      30 * time.Second,
+			ProgressLogInterval: 5 * time.Second,
+		},
+		mockTimeProvider,
+		"https://api.example.com",
+		mockEventBus,
+	)
+
+	task := &domain.Task{
+		ID:          "task-123",
+		WorkspaceID: "workspace-123",
+		Type:        "send_broadcast",
+		BroadcastID: stringPtr("broadcast-123"),
+		State: &domain.TaskState{
+			SendBroadcast: &domain.SendBroadcastState{
+				BroadcastID: "broadcast-123",
+				ListJoin:    true,
+			},
+		},
+	}
+
+	done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+
+	require.NoError(t, err)
+	assert.True(t, done, "Each cycle ends so the recurring task is rescheduled")
+	state := task.State.SendBroadcast
+	assert.Equal(t, 1, state.EnqueuedCount)
+	assert.Equal(t, "old@example.com", state.LastProcessedEmail)
+	require.NotNil(t, state.ListJoinCursorAt)
+	assert.True(t, joinedAt.Equal(*state.ListJoinCursorAt))
+}
+
 // TestBroadcastOrchestrator_Process_NoRecipientsUpdatesBroadcastStatus tests that
 // when a broadcast has no recipients, both the task and broadcast are marked as completed
 func TestBroadcastOrchestrator_Process_NoRecipientsUpdatesBroadcastStatus(t *testing.T) {
@@ -2897,13 +3195,14 @@ func TestBroadcastOrchestrator_Process_PartialBatchCursorUpdate(t *testing.T) {
 		gomock.Any(),
 		gomock.Any(),
 		true,
+		true,
 		"broadcast-123",
 		recipients1,
 		gomock.Any(),
 		gomock.Any(),
 		gomock.Any(),
 		gomock.Any(),
-	).DoAndReturn(func(_ context.Context, _, _, _, _, _ interface{}, _ bool, _ string, _ []*domain.ContactWithList, _, _, _, _ interface{}) (int, int, error) {
+	).DoAndReturn(func(_ context.Context, _, _, _, _, _ interface{}, _, _ bool, _ string, _ []*domain.ContactWithList, _, _, _, _ interface{}) (int, int, error) {
 		sendBatchCalled = true
 		return 3, 0, nil // Only 3 sent due to internal timeout
 	})
@@ -2916,6 +3215,7 @@ func TestBroadcastOrchestrator_Process_PartialBatchCursorUpdate(t *testing.T) {
 		gomock.Any(),
 		gomock.Any(),
 		true,
+		true,
 		"broadcast-123",
 		recipients2,
 		gomock.Any(),
@@ -3067,7 +3367,7 @@ func TestProcessBroadcastTask_RecipientFeedFailure_PausesBroadcast(t *testing.T)
 
 	// SendBatch returns ErrBroadcastShouldPause
 	mockMessageSender.EXPECT().SendBatch(
-		gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), gomock.Any(), true, "broadcast-123",
+		gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), gomock.Any(), true, true, "broadcast-123",
 		recipients, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
 	).Return(0, 0, fmt.Errorf("%w: recipient feed failed for user1@test.com: server error", broadcast.ErrBroadcastShouldPause))
 
@@ -3177,7 +3477,7 @@ func TestProcessBroadcastTask_RecipientFeedFailure_NotMarkedAsFailed(t *testing.
 
 	// SendBatch returns ErrBroadcastShouldPause
 	mockMessageSender.EXPECT().SendBatch(
-		gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), gomock.Any(), true, "broadcast-123",
+		gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), gomock.Any(), true, true, "broadcast-123",
 		recipients, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
 	).Return(0, 0, fmt.Errorf("%w: recipient feed failed for user1@test.com: server error", broadcast.ErrBroadcastShouldPause))
 
@@ -25,6 +25,9 @@ type queueMessageSender struct {
 	logger             logger.Logger
 	config             *Config
 	apiEndpoint        string
+
+	// compileTemplate renders email bodies; replaceable in tests to count renders
+	compileTemplate func(notifuse_mjml.CompileTemplateRequest) (*notifuse_mjml.CompileTemplateResponse, error)
 }
 
 // NewQueueMessageSender creates a new message sender that enqueues to the email queue
@@ -51,6 +54,7 @@ func NewQueueMessageSender(
 		logger:             logger,
 		config:             config,
 		apiEndpoint:        apiEndpoint,
+		compileTemplate:    notifuse_mjml.CompileTemplate,
 	}
 }
 
@@ -61,6 +65,7 @@ func (s *queueMessageSender) SendToRecipient(
 	integrationID string,
 	endpoint string,
 	trackingEnabled bool,
+	_ bool, // bulk headers are added by the queue worker from the workspace settings
 	broadcast *domain.Broadcast,
 	messageID string,
 	email string,
@@ -72,7 +77,7 @@ func (s *queueMessageSender) SendToRecipient(
 	workspaceDefaultLanguage string,
 ) error {
 	// Build the email payload
-	entry, err := s.buildQueueEntry(ctx, workspaceID, integrationID, endpoint, trackingEnabled, broadcast, messageID, email, template, data, emailProvider, contactLanguage, workspaceDefaultLanguage)
+	entry, err := s.buildQueueEntry(ctx, workspaceID, integrationID, endpoint, trackingEnabled, broadcast, messageID, email, template, data, emailProvider, contactLanguage, workspaceDefaultLanguage, nil)
 	if err != nil {
 		return err
 	}
@@ -100,6 +105,7 @@ func (s *queueMessageSender) SendBatch(
 	endpoint string,
 	websiteURL string,
 	trackingEnabled bool,
+	_ bool, // bulk headers are added by the queue worker from the workspace settings
 	broadcastID string,
 	recipients []*domain.ContactWithList,
 	templates map[string]*domain.Template,
@@ -117,9 +123,21 @@ func (s *queueMessageSender) SendBatch(
 		return 0, len(recipients), fmt.Errorf("failed to get broadcast: %w", err)
 	}
 
+	// Fetch recipient feed data up front when the feed is batched
+	batchedFeedData, err := prefetchRecipientFeeds(ctx, s.dataFeedFetcher, broadcast, workspaceID, recipients)
+	if err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"broadcast_id": broadcastID,
+			"workspace_id": workspaceID,
+			"error":        err.Error(),
+		}).Error("Recipient feed batch fetch failed, pausing broadcast")
+		return 0, 0, fmt.Errorf("%w: recipient feed batch failed: %v", ErrBroadcastShouldPause, err)
+	}
+
 	// Build queue entries
 	var entries []*domain.EmailQueueEntry
 	var buildErrors int
+	bodies := newStaticBodyCache()
 
 	for _, recipient := range recipients {
 		// Check timeout
@@ -141,6 +159,9 @@ func (s *queueMessageSender) SendBatch(
 		// Generate message ID
 		messageID := fmt.Sprintf("%s_%s", workspaceID, uuid.New().String())
 
+		// Seed copies are not tracked so opens and clicks don't count towards the stats
+		recipientTracking := trackingEnabled && !recipient.IsSeed
+
 		// Ensure UTM parameters object is present
 		if broadcast.UTMParameters == nil {
 			broadcast.UTMParameters = &domain.UTMParameters{}
@@ -153,7 +174,7 @@ func (s *queueMessageSender) SendBatch(
 		// Build tracking settings for BuildTemplateData
 		trackingSettings := notifuse_mjml.TrackingSettings{
 			Endpoint:       endpoint,
-			EnableTracking: trackingEnabled,
+			EnableTracking: recipientTracking,
 			UTMSource:      broadcast.UTMParameters.Source,
 			UTMMedium:      broadcast.UTMParameters.Medium,
 			UTMCampaign:    broadcast.UTMParameters.Campaign,
@@ -189,14 +210,20 @@ func (s *queueMessageSender) SendBatch(
 		if broadcast.DataFeed != nil && broadcast.DataFeed.RecipientFeed != nil &&
 			broadcast.DataFeed.RecipientFeed.Enabled && s.dataFeedFetcher != nil {
 
-			payload := &domain.RecipientFeedRequestPayload{
-				Contact:   domain.BuildRecipientFeedContact(recipient.Contact),
-				List:      domain.RecipientFeedList{ID: recipient.ListID, Name: recipient.ListName},
-				Broadcast: domain.RecipientFeedBroadcast{ID: broadcast.ID, Name: broadcast.Name},
-				Workspace: domain.RecipientFeedWorkspace{ID: workspaceID},
+			var feedData map[string]interface{}
+			var feedErr error
+			if batchedFeedData != nil {
+				feedData = batchedFeedData[recipient.Contact.Email]
+				recordFeedCacheHit(ctx, broadcast.ID)
+			} else {
+				payload := &domain.RecipientFeedRequestPayload{
+					Contact:   domain.BuildRecipientFeedContact(recipient.Contact),
+					List:      domain.RecipientFeedList{ID: recipient.ListID, Name: recipient.ListName},
+					Broadcast: domain.RecipientFeedBroadcast{ID: broadcast.ID, Name: broadcast.Name, Metadata: broadcast.Metadata},
+					Workspace: domain.RecipientFeedWorkspace{ID: workspaceID},
+				}
+				feedData, feedErr = s.dataFeedFetcher.FetchRecipient(ctx, broadcast.DataFeed.RecipientFeed, payload)
 			}
-
-			feedData, feedErr := s.dataFeedFetcher.FetchRecipient(ctx, broadcast.DataFeed.RecipientFeed, payload)
 			if feedErr != nil {
 				s.logger.WithFields(map[string]interface{}{
 					"broadcast_id": broadcastID,
@@ -220,7 +247,7 @@ func (s *queueMessageSender) SendBatch(
 		}
 
 		// Build queue entry
-		entry, err := s.buildQueueEntry(ctx, workspaceID, integrationID, endpoint, trackingEnabled, broadcast, messageID, recipient.Contact.Email, template, data, emailProvider, contactLanguage, workspaceDefaultLanguage)
+		entry, err := s.buildQueueEntry(ctx, workspaceID, integrationID, endpoint, recipientTracking, broadcast, messageID, recipient.Contact.Email, template, data, emailProvider, contactLanguage, workspaceDefaultLanguage, bodies)
 		if err != nil {
 			s.logger.WithFields(map[string]interface{}{
 				"broadcast_id": broadcastID,
@@ -232,6 +259,22 @@ func (s *queueMessageSender) SendBatch(
 			continue
 		}
 
+		// Attribute the message to the list the recipient was selected from (multi-list audiences)
+		if recipient.ListID != "" {
+			entry.Payload.ListID = recipient.ListID
+		}
+
+		// Held back by the broadcast ramp schedule: the queue worker sends it once due
+		if recipient.SendAfter != nil {
+			entry.NextRetryAt = recipient.SendAfter
+		}
+
+		// Seed copies skip message history and must reach the seed inbox on every broadcast
+		if recipient.IsSeed {
+			entry.Payload.Seed = true
+			entry.DedupeKey = ""
+		}
+
 		entries = append(entries, entry)
 	}
 
@@ -276,6 +319,7 @@ func (s *queueMessageSender) buildQueueEntry(
 	emailProvider *domain.EmailProvider,
 	contactLanguage string,
 	workspaceDefaultLanguage string,
+	bodies *staticBodyCache,
 ) (*domain.EmailQueueEntry, error) {
 	// Ensure UTM parameters object is present
 	if broadcast.UTMParameters == nil {
@@ -311,27 +355,27 @@ func (s *queueMessageSender) buildQueueEntry(
 		return nil, fmt.Errorf("no sender configured for email provider")
 	}
 
-	// Compile template with the provided data
-	compileReq := notifuse_mjml.CompileTemplateRequest{
-		WorkspaceID:      workspaceID,
-		MessageID:        messageID,
-		VisualEditorTree: emailContent.VisualEditorTree,
-		TemplateData:     data,
-		TrackingSettings: trackingSettings,
-	}
-	compileReq.MjmlSource = emailContent.GetCodeModeMjmlSource()
-	compiledTemplate, err := notifuse_mjml.CompileTemplate(compileReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to compile template: %w", err)
-	}
-	if !compiledTemplate.Success || compiledTemplate.HTML == nil {
-		errMsg := "template compilation failed"
-		if compiledTemplate.Error != nil {
-			errMsg = compiledTemplate.Error.Message
+	// Render the body once per batch when it does not depend on the recipient,
+	// then only apply the recipient's link tracking to the shared body
+	var htmlContent string
+	if bodies != nil && bodies.isStatic(emailContent) {
+		body, err := bodies.get(emailContent, func() (string, error) {
+			return s.compileBody(workspaceID, messageID, emailContent, data, notifuse_mjml.TrackingSettings{})
+		})
+		if err != nil {
+			return nil, err
+		}
+		htmlContent, err = notifuse_mjml.TrackLinks(body, trackingSettings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to track links: %w", err)
+		}
+	} else {
+		var err error
+		htmlContent, err = s.compileBody(workspaceID, messageID, emailContent, data, trackingSettings)
+		if err != nil {
+			return nil, err
 		}
-		return nil, fmt.Errorf("%s", errMsg)
 	}
-	htmlContent := *compiledTemplate.HTML
 
 	// Process subject line through Liquid templating
 	subject, err := notifuse_mjml.ProcessLiquidTemplate(
@@ -355,6 +399,9 @@ func (s *queueMessageSender) buildQueueEntry(
 		ContactEmail:  email,
 		MessageID:     messageID,
 		TemplateID:    template.ID,
+		// Keyed on the source rather than the broadcast ID so a double-scheduled
+		// broadcast of the same template is caught as well
+		DedupeKey: string(domain.EmailQueueSourceBroadcast),
 		Payload: domain.EmailQueuePayload{
 			FromAddress:        sender.Email,
 			FromName:           sender.Name,
@@ -365,7 +412,7 @@ func (s *queueMessageSender) buildQueueEntry(
 				ReplyTo: emailContent.ReplyTo,
 			},
 			TemplateVersion: int(template.Version),
-			ListID:          broadcast.Audience.List,
+			ListID:          broadcast.Audience.PrimaryList(),
 			TemplateData:    data, // Store template data for message history
 		},
 		MaxAttempts: 3,
@@ -381,6 +428,82 @@ func (s *queueMessageSender) buildQueueEntry(
 	return entry, nil
 }
 
+// compileBody compiles the email content to HTML with the given data and tracking settings
+func (s *queueMessageSender) compileBody(
+	workspaceID string,
+	messageID string,
+	emailContent *domain.EmailTemplate,
+	data map[string]interface{},
+	trackingSettings notifuse_mjml.TrackingSettings,
+) (string, error) {
+	compileReq := notifuse_mjml.CompileTemplateRequest{
+		WorkspaceID:      workspaceID,
+		MessageID:        messageID,
+		VisualEditorTree: emailContent.VisualEditorTree,
+		TemplateData:     data,
+		TrackingSettings: trackingSettings,
+	}
+	compileReq.MjmlSource = emailContent.GetCodeModeMjmlSource()
+	compiledTemplate, err := s.compileTemplate(compileReq)
+	if err != nil {
+		return "", fmt.Errorf("failed to compile template: %w", err)
+	}
+	if !compiledTemplate.Success || compiledTemplate.HTML == nil {
+		errMsg := "template compilation failed"
+		if compiledTemplate.Error != nil {
+			errMsg = compiledTemplate.Error.Message
+		}
+		return "", fmt.Errorf("%s", errMsg)
+	}
+	return *compiledTemplate.HTML, nil
+}
+
+// staticBodyCache keeps the rendered bodies of a batch for email contents whose
+// Liquid does not reference any recipient-dependent variable. Bodies are stored
+// without link tracking, which is applied per recipient.
+type staticBodyCache struct {
+	static map[*domain.EmailTemplate]bool
+	bodies map[*domain.EmailTemplate]string
+}
+
+func newStaticBodyCache() *staticBodyCache {
+	return &staticBodyCache{
+		static: make(map[*domain.EmailTemplate]bool),
+		bodies: make(map[*domain.EmailTemplate]string),
+	}
+}
+
+// isStatic reports whether the email content renders the same body for every recipient
+func (c *staticBodyCache) isStatic(emailContent *domain.EmailTemplate) bool {
+	if static, ok := c.static[emailContent]; ok {
+		return static
+	}
+
+	var source string
+	if mjmlSource := emailContent.GetCodeModeMjmlSource(); mjmlSource != nil && *mjmlSource != "" {
+		source = *mjmlSource
+	} else if emailContent.VisualEditorTree != nil {
+		source = notifuse_mjml.ConvertJSONToMJMLRaw(emailContent.VisualEditorTree)
+	}
+
+	static := source != "" && !notifuse_mjml.LiquidReferencesAny(source, domain.RecipientTemplateVariables)
+	c.static[emailContent] = static
+	return static
+}
+
+// get returns the cached body for the email content, rendering it on first use
+func (c *staticBodyCache) get(emailContent *domain.EmailTemplate, render func() (string, error)) (string, error) {
+	if body, ok := c.bodies[emailContent]; ok {
+		return body, nil
+	}
+	body, err := render()
+	if err != nil {
+		return "", err
+	}
+	c.bodies[emailContent] = body
+	return body, nil
+}
+
 // selectTemplate selects a template for sending
 // For A/B testing, this uses random selection; for normal sends, uses the first template
 func (s *queueMessageSender) selectTemplate(templates map[string]*domain.Template, broadcast *domain.Broadcast) *domain.Template {
@@ -218,6 +218,7 @@ func TestQueueMessageSender_SendToRecipient(t *testing.T) {
 			"integration-1",
 			"https://api.test.com",
 			true,
+			true,
 			broadcast,
 			"msg-1",
 			"recipient@example.com",
@@ -285,6 +286,7 @@ func TestQueueMessageSender_SendToRecipient(t *testing.T) {
 			"integration-1",
 			"https://api.test.com",
 			true,
+			true,
 			broadcast,
 			"msg-1",
 			"recipient@example.com",
@@ -345,6 +347,7 @@ func TestQueueMessageSender_SendToRecipient(t *testing.T) {
 			"integration-1",
 			"https://api.test.com",
 			true,
+			true,
 			broadcast,
 			"msg-1",
 			"recipient@example.com",
@@ -435,6 +438,183 @@ func TestQueueMessageSender_SendBatch(t *testing.T) {
 			"https://api.example.com",
 			"",
 			true,
+			true,
+			"broadcast-1",
+			recipients,
+			map[string]*domain.Template{"template-1": template},
+			emailProvider,
+			time.Now().Add(5*time.Minute),
+			"",
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, sent)
+		assert.Equal(t, 0, failed)
+	})
+
+	t.Run("defers recipients held back by the ramp schedule", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockBroadcastRepo := mocks.NewMockBroadcastRepository(ctrl)
+		mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+		emailSender := domain.NewEmailSender("sender@example.com", "Test Sender")
+		emailProvider := &domain.EmailProvider{
+			Kind:    domain.EmailProviderKindSMTP,
+			Senders: []domain.EmailSender{emailSender},
+		}
+
+		template := &domain.Template{
+			ID: "template-1",
+			Email: &domain.EmailTemplate{
+				SenderID:         emailSender.ID,
+				Subject:          "Test Subject",
+				VisualEditorTree: createQueueValidTestTree(createQueueTestTextBlock("txt1", "Hello")),
+			},
+		}
+
+		sendAfter := time.Date(2026, 1, 1, 13, 0, 0, 0, time.UTC)
+		recipients := []*domain.ContactWithList{
+			{Contact: &domain.Contact{Email: "user1@example.com"}, ListID: "list-1"},
+			{Contact: &domain.Contact{Email: "user2@example.com"}, ListID: "list-1", SendAfter: &sendAfter},
+		}
+
+		mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-1", "broadcast-1").
+			Return(&domain.Broadcast{ID: "broadcast-1", WorkspaceID: "workspace-1"}, nil)
+
+		mockQueueRepo.EXPECT().Enqueue(gomock.Any(), "workspace-1", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID string, entries []*domain.EmailQueueEntry) error {
+				require.Len(t, entries, 2)
+				assert.Nil(t, entries[0].NextRetryAt, "recipients within the ramp are sent right away")
+				require.NotNil(t, entries[1].NextRetryAt)
+				assert.Equal(t, sendAfter, *entries[1].NextRetryAt)
+				return nil
+			})
+
+		sender := NewQueueMessageSender(
+			mockQueueRepo,
+			mockBroadcastRepo,
+			mockMessageHistoryRepo,
+			mockTemplateRepo,
+			nil,
+			mockLogger,
+			nil,
+			"https://api.example.com",
+		)
+
+		sent, failed, err := sender.SendBatch(
+			context.Background(),
+			"workspace-1",
+			"integration-1",
+			"secret-key",
+			"https://api.example.com",
+			"",
+			true,
+			true,
+			"broadcast-1",
+			recipients,
+			map[string]*domain.Template{"template-1": template},
+			emailProvider,
+			time.Now().Add(5*time.Minute),
+			"",
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, sent)
+		assert.Equal(t, 0, failed)
+	})
+
+	t.Run("flags seed copies and leaves them untracked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockBroadcastRepo := mocks.NewMockBroadcastRepository(ctrl)
+		mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+		emailSender := domain.NewEmailSender("sender@example.com", "Test Sender")
+		emailProvider := &domain.EmailProvider{
+			Kind:    domain.EmailProviderKindSMTP,
+			Senders: []domain.EmailSender{emailSender},
+		}
+
+		broadcast := &domain.Broadcast{
+			ID:            "broadcast-1",
+			WorkspaceID:   "workspace-1",
+			Name:          "Test Broadcast",
+			UTMParameters: &domain.UTMParameters{Source: "test", Medium: "email"},
+		}
+
+		template := &domain.Template{
+			ID: "template-1",
+			Email: &domain.EmailTemplate{
+				SenderID:         emailSender.ID,
+				Subject:          "Test Subject",
+				VisualEditorTree: createQueueValidTestTree(createQueueTestTextBlock("txt1", "Hello")),
+			},
+		}
+
+		settings := domain.WorkspaceSettings{BroadcastSeedList: []string{"seed@inbox.test"}}
+		recipients := append([]*domain.ContactWithList{
+			{
+				Contact: &domain.Contact{Email: "user1@example.com"},
+				ListID:  "list-1",
+			},
+		}, settings.BroadcastSeedRecipients()...)
+
+		mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-1", "broadcast-1").
+			Return(broadcast, nil)
+
+		mockQueueRepo.EXPECT().Enqueue(gomock.Any(), "workspace-1", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID string, entries []*domain.EmailQueueEntry) error {
+				require.Len(t, entries, 2)
+
+				contactEntry, seedEntry := entries[0], entries[1]
+				assert.Equal(t, "user1@example.com", contactEntry.ContactEmail)
+				assert.False(t, contactEntry.Payload.Seed)
+				assert.NotEmpty(t, contactEntry.DedupeKey)
+				assert.Contains(t, contactEntry.Payload.HTMLContent, `<img src="https://api.example.com/`, "Contact copy should carry the open pixel")
+
+				assert.Equal(t, "seed@inbox.test", seedEntry.ContactEmail)
+				assert.True(t, seedEntry.Payload.Seed)
+				assert.Empty(t, seedEntry.DedupeKey, "Seed copies must not be deduplicated across broadcasts")
+				assert.NotContains(t, seedEntry.Payload.HTMLContent, `<img src="https://api.example.com/`, "Seed copy should not be tracked")
+				assert.Empty(t, seedEntry.Payload.EmailOptions.ListUnsubscribeURL, "Seeds have no list to unsubscribe from")
+				return nil
+			})
+
+		sender := NewQueueMessageSender(
+			mockQueueRepo,
+			mockBroadcastRepo,
+			mockMessageHistoryRepo,
+			mockTemplateRepo,
+			nil,
+			mockLogger,
+			nil,
+			"https://api.example.com",
+		)
+
+		sent, failed, err := sender.SendBatch(
+			context.Background(),
+			"workspace-1",
+			"integration-1",
+			"secret-key",
+			"https://api.example.com",
+			"",
+			true,
+			true,
 			"broadcast-1",
 			recipients,
 			map[string]*domain.Template{"template-1": template},
@@ -477,6 +657,7 @@ func TestQueueMessageSender_SendBatch(t *testing.T) {
 			"https://api.example.com",
 			"",
 			true,
+			true,
 			"broadcast-1",
 			[]*domain.ContactWithList{}, // Empty
 			nil,
@@ -554,6 +735,7 @@ func TestQueueMessageSender_SendBatch(t *testing.T) {
 			"https://api.example.com",
 			"",
 			true,
+			true,
 			"broadcast-1",
 			recipients,
 			map[string]*domain.Template{"template-1": template},
@@ -666,6 +848,7 @@ func TestQueueMessageSender_SendBatch(t *testing.T) {
 			"https://api.example.com",
 			"",
 			true,
+			true,
 			"broadcast-1",
 			recipients,
 			map[string]*domain.Template{"template-1": template},
@@ -761,6 +944,7 @@ func TestQueueMessageSender_SendBatch(t *testing.T) {
 			"https://api.example.com",
 			"",
 			true,
+			true,
 			"broadcast-1",
 			recipients,
 			map[string]*domain.Template{"template-1": template},
@@ -775,6 +959,133 @@ func TestQueueMessageSender_SendBatch(t *testing.T) {
 	})
 }
 
+func TestQueueSendBatch_StaticBodyRenderedOnce(t *testing.T) {
+	emailSender := domain.NewEmailSender("sender@example.com", "Test Sender")
+	emailProvider := &domain.EmailProvider{
+		Kind:    domain.EmailProviderKindSMTP,
+		Senders: []domain.EmailSender{emailSender},
+	}
+
+	recipients := make([]*domain.ContactWithList, 0, 5)
+	for i := 0; i < 5; i++ {
+		recipients = append(recipients, &domain.ContactWithList{
+			Contact:  &domain.Contact{Email: fmt.Sprintf("user%d@example.com", i)},
+			ListID:   "list-1",
+			ListName: "Newsletter",
+		})
+	}
+
+	tests := []struct {
+		name          string
+		content       string
+		expectedCalls int
+	}{
+		{
+			name:          "static body renders once",
+			content:       `<a href="https://example.com/sale">Shop the {{ broadcast.name }}</a>`,
+			expectedCalls: 1,
+		},
+		{
+			name:          "personalized body renders per recipient",
+			content:       `Hello {{ contact.email }}, <a href="https://example.com/sale">shop now</a>`,
+			expectedCalls: len(recipients),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+			mockBroadcastRepo := mocks.NewMockBroadcastRepository(ctrl)
+			mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+			mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+			mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+			mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+			mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+			broadcast := &domain.Broadcast{
+				ID:          "broadcast-1",
+				WorkspaceID: "workspace-1",
+				Name:        "Summer Sale",
+				Audience:    domain.AudienceSettings{List: "list-1"},
+			}
+
+			template := &domain.Template{
+				ID: "template-1",
+				Email: &domain.EmailTemplate{
+					SenderID:         emailSender.ID,
+					Subject:          "Hi {{ contact.email }}",
+					VisualEditorTree: createQueueValidTestTree(createQueueTestTextBlock("txt1", tt.content)),
+				},
+			}
+
+			mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-1", "broadcast-1").
+				Return(broadcast, nil)
+
+			var enqueued []*domain.EmailQueueEntry
+			mockQueueRepo.EXPECT().Enqueue(gomock.Any(), "workspace-1", gomock.Any()).
+				DoAndReturn(func(ctx context.Context, workspaceID string, entries []*domain.EmailQueueEntry) error {
+					enqueued = entries
+					return nil
+				})
+
+			sender := NewQueueMessageSender(
+				mockQueueRepo,
+				mockBroadcastRepo,
+				mockMessageHistoryRepo,
+				mockTemplateRepo,
+				nil,
+				mockLogger,
+				nil,
+				"https://api.example.com",
+			)
+
+			qms := sender.(*queueMessageSender)
+			compileCalls := 0
+			qms.compileTemplate = func(req notifuse_mjml.CompileTemplateRequest) (*notifuse_mjml.CompileTemplateResponse, error) {
+				compileCalls++
+				return notifuse_mjml.CompileTemplate(req)
+			}
+
+			sent, failed, err := sender.SendBatch(
+				context.Background(),
+				"workspace-1",
+				"integration-1",
+				"secret-key",
+				"https://api.example.com",
+				"",
+				true,
+				true,
+				"broadcast-1",
+				recipients,
+				map[string]*domain.Template{"template-1": template},
+				emailProvider,
+				time.Now().Add(5*time.Minute),
+				"",
+			)
+
+			require.NoError(t, err)
+			assert.Equal(t, len(recipients), sent)
+			assert.Equal(t, 0, failed)
+			assert.Equal(t, tt.expectedCalls, compileCalls)
+
+			// Headers, unsubscribe links and tracked links stay personalized
+			require.Len(t, enqueued, len(recipients))
+			unsubscribeURLs := map[string]bool{}
+			for i, entry := range enqueued {
+				assert.Equal(t, "Hi "+recipients[i].Contact.Email, entry.Payload.Subject)
+				assert.NotContains(t, entry.Payload.HTMLContent, `href="https://example.com/sale"`)
+				assert.NotEmpty(t, entry.Payload.EmailOptions.ListUnsubscribeURL)
+				unsubscribeURLs[entry.Payload.EmailOptions.ListUnsubscribeURL] = true
+			}
+			assert.Len(t, unsubscribeURLs, len(recipients))
+		})
+	}
+}
+
 func TestQueueSendBatch_WithRecipientFeed_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -878,6 +1189,7 @@ func TestQueueSendBatch_WithRecipientFeed_Success(t *testing.T) {
 		"https://api.example.com",
 		"",
 		true,
+		true,
 		"broadcast-1",
 		recipients,
 		map[string]*domain.Template{"template-1": template},
@@ -891,6 +1203,115 @@ func TestQueueSendBatch_WithRecipientFeed_Success(t *testing.T) {
 	assert.Equal(t, 0, failed)
 }
 
+func TestQueueSendBatch_WithRecipientFeed_Batched(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+	mockBroadcastRepo := mocks.NewMockBroadcastRepository(ctrl)
+	mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+	mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+	mockDataFeedFetcher := bmocks.NewMockDataFeedFetcher(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	emailSender := domain.NewEmailSender("sender@example.com", "Test Sender")
+	emailProvider := &domain.EmailProvider{
+		Kind:    domain.EmailProviderKindSMTP,
+		Senders: []domain.EmailSender{emailSender},
+	}
+
+	broadcast := &domain.Broadcast{
+		ID:            "broadcast-1",
+		WorkspaceID:   "workspace-1",
+		Name:          "Feed Broadcast",
+		UTMParameters: &domain.UTMParameters{Source: "test", Medium: "email"},
+		DataFeed: &domain.DataFeedSettings{
+			RecipientFeed: &domain.RecipientFeedSettings{
+				Enabled:   true,
+				URL:       "https://feed.example.com/recipient",
+				BatchSize: 50,
+			},
+		},
+	}
+
+	template := &domain.Template{
+		ID: "template-1",
+		Email: &domain.EmailTemplate{
+			SenderID:         emailSender.ID,
+			Subject:          "Your product: {{ recipient_feed.product }}",
+			VisualEditorTree: createQueueValidTestTree(createQueueTestTextBlock("txt1", "Product: {{ recipient_feed.product }}")),
+		},
+	}
+
+	recipients := []*domain.ContactWithList{
+		{Contact: &domain.Contact{Email: "alice@example.com"}, ListID: "list-1", ListName: "Subscribers"},
+		{Contact: &domain.Contact{Email: "bob@example.com"}, ListID: "list-1", ListName: "Subscribers"},
+	}
+
+	mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-1", "broadcast-1").
+		Return(broadcast, nil)
+
+	// One batched request for both recipients, no per-recipient requests
+	mockDataFeedFetcher.EXPECT().FetchRecipientBatch(
+		gomock.Any(),
+		broadcast.DataFeed.RecipientFeed,
+		gomock.Any(),
+	).Times(1).DoAndReturn(func(ctx context.Context, settings *domain.RecipientFeedSettings,
+		payload *domain.RecipientFeedBatchRequestPayload) (map[string]map[string]interface{}, error) {
+		require.Len(t, payload.Contacts, 2)
+		assert.Equal(t, "list-1", payload.List.ID)
+		return map[string]map[string]interface{}{
+			"alice@example.com": {"product": "Widget", "_success": true},
+			"bob@example.com":   {"product": "Gadget", "_success": true},
+		}, nil
+	})
+	mockDataFeedFetcher.EXPECT().FetchRecipient(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	mockQueueRepo.EXPECT().Enqueue(gomock.Any(), "workspace-1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, workspaceID string, entries []*domain.EmailQueueEntry) error {
+			require.Len(t, entries, 2)
+			assert.Contains(t, entries[0].Payload.Subject, "Widget")
+			assert.Contains(t, entries[1].Payload.Subject, "Gadget")
+			return nil
+		})
+
+	sender := NewQueueMessageSender(
+		mockQueueRepo,
+		mockBroadcastRepo,
+		mockMessageHistoryRepo,
+		mockTemplateRepo,
+		mockDataFeedFetcher,
+		mockLogger,
+		nil,
+		"https://api.example.com",
+	)
+
+	sent, failed, err := sender.SendBatch(
+		context.Background(),
+		"workspace-1",
+		"integration-1",
+		"secret-key",
+		"https://api.example.com",
+		"",
+		true,
+		true,
+		"broadcast-1",
+		recipients,
+		map[string]*domain.Template{"template-1": template},
+		emailProvider,
+		time.Now().Add(5*time.Minute),
+		"",
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, 0, failed)
+}
+
 func TestQueueSendBatch_WithRecipientFeed_FetchError_PausesBroadcast(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -974,6 +1395,7 @@ func TestQueueSendBatch_WithRecipientFeed_FetchError_PausesBroadcast(t *testing.
 		"https://api.example.com",
 		"",
 		true,
+		true,
 		"broadcast-1",
 		recipients,
 		map[string]*domain.Template{"template-1": template},
@@ -1071,6 +1493,7 @@ func TestQueueSendBatch_WithRecipientFeed_Disabled(t *testing.T) {
 		"https://api.example.com",
 		"",
 		true,
+		true,
 		"broadcast-1",
 		recipients,
 		map[string]*domain.Template{"template-1": template},
@@ -1162,6 +1585,7 @@ func TestQueueSendBatch_WithRecipientFeed_NilFetcher(t *testing.T) {
 		"https://api.example.com",
 		"",
 		true,
+		true,
 		"broadcast-1",
 		recipients,
 		map[string]*domain.Template{"template-1": template},
@@ -1307,6 +1731,7 @@ func TestQueueMessageSender_BuildQueueEntry(t *testing.T) {
 			emailProvider,
 			"",
 			"",
+			nil,
 		)
 
 		require.NoError(t, err)
@@ -1369,6 +1794,7 @@ func TestQueueMessageSender_BuildQueueEntry(t *testing.T) {
 			emailProvider,
 			"",
 			"",
+			nil,
 		)
 
 		require.NoError(t, err)
@@ -1408,6 +1834,7 @@ func TestQueueMessageSender_BuildQueueEntry(t *testing.T) {
 			emailProvider,
 			"",
 			"",
+			nil,
 		)
 
 		assert.Error(t, err)
@@ -1451,6 +1878,7 @@ func TestQueueMessageSender_BuildQueueEntry(t *testing.T) {
 			emailProvider,
 			"",
 			"",
+			nil,
 		)
 
 		require.NoError(t, err)
@@ -94,6 +94,11 @@ func (s *BroadcastService) CreateBroadcast(ctx context.Context, request *domain.
 		)
 	}
 
+	secretKey, err := s.restoreFeedClientSecrets(ctx, request.WorkspaceID, request.DataFeed, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate the request
 	broadcast, err := request.Validate()
 	if err != nil {
@@ -101,6 +106,10 @@ func (s *BroadcastService) CreateBroadcast(ctx context.Context, request *domain.
 		return nil, err
 	}
 
+	if err := s.validateExcludeOpenersOf(ctx, request.WorkspaceID, broadcast.Audience.ExcludeOpenersOf); err != nil {
+		return nil, err
+	}
+
 	// Generate a unique ID for the broadcast if not provided
 	if broadcast.ID == "" {
 		// Create a random ID
@@ -124,6 +133,12 @@ func (s *BroadcastService) CreateBroadcast(ctx context.Context, request *domain.
 		broadcast.Status = domain.BroadcastStatusScheduled
 	}
 
+	if secretKey != "" {
+		if err := broadcast.DataFeed.EncryptClientSecrets(secretKey); err != nil {
+			return nil, err
+		}
+	}
+
 	// Persist the broadcast
 	err = s.repo.CreateBroadcast(ctx, broadcast)
 	if err != nil {
@@ -132,6 +147,7 @@ func (s *BroadcastService) CreateBroadcast(ctx context.Context, request *domain.
 	}
 
 	s.logger.Info("Broadcast created successfully")
+	maskFeedClientSecrets(broadcast)
 
 	return broadcast, nil
 }
@@ -156,7 +172,13 @@ func (s *BroadcastService) GetBroadcast(ctx context.Context, workspaceID, broadc
 	}
 
 	// Fetch the broadcast from the repository
-	return s.repo.GetBroadcast(ctx, workspaceID, broadcastID)
+	broadcast, err := s.repo.GetBroadcast(ctx, workspaceID, broadcastID)
+	if err != nil {
+		return nil, err
+	}
+
+	maskFeedClientSecrets(broadcast)
+	return broadcast, nil
 }
 
 // UpdateBroadcast updates an existing broadcast
@@ -176,6 +198,14 @@ func (s *BroadcastService) UpdateBroadcast(ctx context.Context, request *domain.
 		return nil, err
 	}
 
+	previousExcludeOpenersOf := existingBroadcast.Audience.ExcludeOpenersOf
+
+	// Validate merges the request into the existing broadcast, so masked secrets are restored first
+	secretKey, err := s.restoreFeedClientSecrets(ctx, request.WorkspaceID, request.DataFeed, existingBroadcast.DataFeed)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate and update broadcast fields
 	updatedBroadcast, err := request.Validate(existingBroadcast)
 	if err != nil {
@@ -183,6 +213,19 @@ func (s *BroadcastService) UpdateBroadcast(ctx context.Context, request *domain.
 		return nil, err
 	}
 
+	// An unchanged reference is kept even if that broadcast was deleted since
+	if updatedBroadcast.Audience.ExcludeOpenersOf != previousExcludeOpenersOf {
+		if err := s.validateExcludeOpenersOf(ctx, request.WorkspaceID, updatedBroadcast.Audience.ExcludeOpenersOf); err != nil {
+			return nil, err
+		}
+	}
+
+	if secretKey != "" {
+		if err := updatedBroadcast.DataFeed.EncryptClientSecrets(secretKey); err != nil {
+			return nil, err
+		}
+	}
+
 	// Set the updated time
 	updatedBroadcast.UpdatedAt = time.Now().UTC()
 
@@ -194,10 +237,54 @@ func (s *BroadcastService) UpdateBroadcast(ctx context.Context, request *domain.
 	}
 
 	s.logger.Info("Broadcast updated successfully")
+	maskFeedClientSecrets(updatedBroadcast)
 
 	return updatedBroadcast, nil
 }
 
+// restoreFeedClientSecrets replaces the masked OAuth2 client secrets of the data feed with the
+// stored ones and returns the workspace secret key the secrets are encrypted with before the
+// broadcast is saved, or "" when no feed uses OAuth2
+func (s *BroadcastService) restoreFeedClientSecrets(ctx context.Context, workspaceID string, dataFeed, stored *domain.DataFeedSettings) (string, error) {
+	if dataFeed == nil || !dataFeed.HasOAuth2() {
+		return "", nil
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		s.logger.WithField("workspace_id", workspaceID).Error("Failed to get workspace for data feed client secrets")
+		return "", fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	if err := dataFeed.RestoreClientSecrets(stored, workspace.Settings.SecretKey); err != nil {
+		return "", err
+	}
+	return workspace.Settings.SecretKey, nil
+}
+
+// maskFeedClientSecrets masks the data feed OAuth2 client secrets of a broadcast returned by the API
+func maskFeedClientSecrets(broadcast *domain.Broadcast) {
+	if broadcast.DataFeed != nil {
+		broadcast.DataFeed.MaskClientSecrets()
+	}
+}
+
+// validateExcludeOpenersOf checks that the broadcast whose openers are excluded exists in the workspace
+func (s *BroadcastService) validateExcludeOpenersOf(ctx context.Context, workspaceID, broadcastID string) error {
+	if broadcastID == "" {
+		return nil
+	}
+
+	if _, err := s.repo.GetBroadcast(ctx, workspaceID, broadcastID); err != nil {
+		if _, ok := err.(*domain.ErrBroadcastNotFound); ok {
+			return domain.NewValidationError(fmt.Sprintf("exclude_openers_of references an unknown broadcast: %s", broadcastID))
+		}
+		s.logger.WithField("broadcast_id", broadcastID).Error("Failed to get excluded broadcast")
+		return fmt.Errorf("failed to get excluded broadcast: %w", err)
+	}
+	return nil
+}
+
 // ListBroadcasts retrieves a list of broadcasts with pagination
 func (s *BroadcastService) ListBroadcasts(ctx context.Context, params domain.ListBroadcastsParams) (*domain.BroadcastListResponse, error) {
 	// Authenticate user for workspace
@@ -225,6 +312,10 @@ func (s *BroadcastService) ListBroadcasts(ctx context.Context, params domain.Lis
 		return nil, err
 	}
 
+	for _, broadcast := range response.Broadcasts {
+		maskFeedClientSecrets(broadcast)
+	}
+
 	// If WithTemplates is true, fetch template details for each variation
 	if params.WithTemplates {
 		for _, broadcast := range response.Broadcasts {
@@ -285,6 +376,14 @@ func (s *BroadcastService) ScheduleBroadcast(ctx context.Context, request *domai
 		return fmt.Errorf("no marketing email provider configured for this workspace")
 	}
 
+	// Optionally probe the integration so a misconfigured provider fails now, not mid-send
+	if request.CheckIntegration {
+		if err := s.emailSvc.CheckIntegration(ctx, request.WorkspaceID, ""); err != nil {
+			s.logger.WithField("broadcast_id", request.ID).WithField("error", err.Error()).Warn("Email integration check failed, broadcast not scheduled")
+			return domain.NewValidationError(fmt.Sprintf("email integration check failed: %s", err.Error()))
+		}
+	}
+
 	// Using a channel to wait for the event callback
 	done := make(chan error, 1)
 
@@ -308,10 +407,11 @@ func (s *BroadcastService) ScheduleBroadcast(ctx context.Context, request *domai
 		if bcast.DataFeed != nil && bcast.DataFeed.GlobalFeed != nil && bcast.DataFeed.GlobalFeed.Enabled {
 			// Get list information for the payload
 			var listName string
-			if bcast.Audience.List != "" {
-				list, listErr := s.listService.GetListByID(ctx, request.WorkspaceID, bcast.Audience.List)
+			listID := bcast.Audience.PrimaryList()
+			if listID != "" {
+				list, listErr := s.listService.GetListByID(ctx, request.WorkspaceID, listID)
 				if listErr != nil {
-					s.logger.WithField("list_id", bcast.Audience.List).Warn("Failed to get list for global feed payload")
+					s.logger.WithField("list_id", listID).Warn("Failed to get list for global feed payload")
 				} else if list != nil {
 					listName = list.Name
 				}
@@ -319,11 +419,12 @@ func (s *BroadcastService) ScheduleBroadcast(ctx context.Context, request *domai
 
 			payload := &domain.GlobalFeedRequestPayload{
 				Broadcast: domain.GlobalFeedBroadcast{
-					ID:   bcast.ID,
-					Name: bcast.Name,
+					ID:       bcast.ID,
+					Name:     bcast.Name,
+					Metadata: bcast.Metadata,
 				},
 				List: domain.GlobalFeedList{
-					ID:   bcast.Audience.List,
+					ID:   listID,
 					Name: listName,
 				},
 				Workspace: domain.GlobalFeedWorkspace{
@@ -385,6 +486,7 @@ func (s *BroadcastService) ScheduleBroadcast(ctx context.Context, request *domai
 			"broadcast_id": request.ID,
 			"send_now":     request.SendNow,
 			"status":       string(bcast.Status),
+			"list_join":    bcast.Schedule.IsListJoin(),
 		}
 
 		// Include actual scheduled time if broadcast is scheduled
@@ -813,6 +915,107 @@ func (s *BroadcastService) CancelBroadcast(ctx context.Context, request *domain.
 	return err
 }
 
+// CancelBroadcastSchedule reverts a scheduled broadcast to draft so it can be edited and
+// scheduled again. Nothing is sent: the pending send task is removed by the task service
+// when it handles the unscheduled event.
+func (s *BroadcastService) CancelBroadcastSchedule(ctx context.Context, request *domain.CancelBroadcastScheduleRequest) error {
+	// Authenticate user for workspace
+	var err error
+	ctx, _, _, err = s.authService.AuthenticateUserForWorkspace(ctx, request.WorkspaceID)
+	if err != nil {
+		s.logger.WithField("broadcast_id", request.ID).Error("Failed to authenticate user for workspace")
+		return fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Validate the request
+	if err := request.Validate(); err != nil {
+		s.logger.Error("Failed to validate cancel broadcast schedule request")
+		return err
+	}
+
+	// Using a channel to wait for the event callback
+	done := make(chan error, 1)
+
+	// Use transaction to retrieve, update the broadcast, and publish the event
+	err = s.repo.WithTransaction(ctx, request.WorkspaceID, func(tx *sql.Tx) error {
+		// Retrieve the broadcast
+		broadcast, err := s.repo.GetBroadcastTx(ctx, tx, request.WorkspaceID, request.ID)
+		if err != nil {
+			s.logger.Error("Failed to get broadcast for cancelling schedule")
+			return err
+		}
+
+		// Once processing has started the broadcast can only be paused or cancelled
+		if broadcast.Status != domain.BroadcastStatusScheduled {
+			err := fmt.Errorf("only broadcasts with scheduled status can be unscheduled, current status: %s", broadcast.Status)
+			s.logger.Error("Cannot cancel schedule of broadcast with non-scheduled status")
+			return err
+		}
+
+		// Revert to draft, keeping the timezone and trigger settings for the next schedule
+		broadcast.Status = domain.BroadcastStatusDraft
+		broadcast.Schedule.IsScheduled = false
+		broadcast.Schedule.ScheduledDate = ""
+		broadcast.Schedule.ScheduledTime = ""
+		broadcast.UpdatedAt = time.Now().UTC()
+
+		err = s.repo.UpdateBroadcastTx(ctx, tx, broadcast)
+		if err != nil {
+			s.logger.Error("Failed to update broadcast in repository")
+			return err
+		}
+
+		// A scheduled broadcast has not enqueued anything yet, but clear the queue in case
+		// the send task started just before the status changed
+		deletedCount, deleteErr := s.emailQueueRepo.DeleteBySourceTx(ctx, tx, domain.EmailQueueSourceBroadcast, broadcast.ID)
+		if deleteErr != nil {
+			s.logger.WithField("broadcast_id", broadcast.ID).Error("Failed to delete email queue entries")
+			return deleteErr
+		}
+		s.logger.WithFields(map[string]interface{}{
+			"broadcast_id":        broadcast.ID,
+			"deleted_queue_count": deletedCount,
+		}).Info("Broadcast schedule cancelled successfully")
+
+		// Create an event with acknowledgment callback
+		eventPayload := domain.EventPayload{
+			Type:        domain.EventBroadcastUnscheduled,
+			WorkspaceID: request.WorkspaceID,
+			EntityID:    request.ID,
+			Data: map[string]interface{}{
+				"broadcast_id": request.ID,
+			},
+		}
+
+		// Publish the event with callback within the transaction
+		s.eventBus.PublishWithAck(ctx, eventPayload, func(eventErr error) {
+			if eventErr != nil {
+				s.logger.WithFields(map[string]interface{}{
+					"broadcast_id": request.ID,
+					"workspace_id": request.WorkspaceID,
+					"error":        eventErr.Error(),
+				}).Error("Failed to process unschedule broadcast event")
+
+				done <- fmt.Errorf("failed to process unschedule event: %w", eventErr)
+			} else {
+				s.logger.WithField("broadcast_id", request.ID).Info("Unschedule broadcast event processed successfully")
+				done <- nil
+			}
+		})
+
+		// Wait for the event processing to complete
+		select {
+		case eventErr := <-done:
+			// Roll back the transaction if the event processing failed
+			return eventErr
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	return err
+}
+
 // DeleteBroadcast deletes a broadcast
 func (s *BroadcastService) DeleteBroadcast(ctx context.Context, request *domain.DeleteBroadcastRequest) error {
 	// Authenticate user for workspace
@@ -985,7 +1188,7 @@ func (s *BroadcastService) SendToIndividual(ctx context.Context, request *domain
 		WorkspaceWebsiteURL: workspace.Settings.WebsiteURL,
 		ContactWithList: domain.ContactWithList{
 			Contact:  contact,
-			ListID:   broadcast.Audience.List, // Use list from broadcast audience for unsubscribe URL
+			ListID:   broadcast.Audience.PrimaryList(), // Use list from broadcast audience for unsubscribe URL
 			ListName: "",
 		},
 		MessageID:        messageID,
@@ -1042,13 +1245,15 @@ func (s *BroadcastService) SendToIndividual(ctx context.Context, request *domain
 		Content:       *compiledTemplate.HTML,
 		Provider:      emailProvider,
 		EmailOptions: domain.EmailOptions{
-			ReplyTo: emailContent.ReplyTo,
+			ReplyTo:     emailContent.ReplyTo,
+			BulkHeaders: workspace.Settings.BulkHeadersEnabled(),
 		},
 	}
 
 	// Extract List-Unsubscribe URL from template data for RFC-8058 compliance
 	if unsubscribeURL, ok := templateData["oneclick_unsubscribe_url"].(string); ok && unsubscribeURL != "" {
 		emailRequest.EmailOptions.ListUnsubscribeURL = unsubscribeURL
+		emailRequest.EmailOptions.ListUnsubscribeMailto = domain.BuildListUnsubscribeMailto(workspace.Settings.ListUnsubscribeMailto, unsubscribeURL)
 	}
 
 	// Send the email
@@ -1059,7 +1264,7 @@ func (s *BroadcastService) SendToIndividual(ctx context.Context, request *domain
 	}
 
 	now := time.Now().UTC()
-	listID := broadcast.Audience.List
+	listID := broadcast.Audience.PrimaryList()
 	message := &domain.MessageHistory{
 		ID:              messageID,
 		ContactEmail:    request.RecipientEmail,
@@ -1278,9 +1483,11 @@ func (s *BroadcastService) RefreshGlobalFeed(ctx context.Context, request *domai
 
 	// Build feed settings from request
 	feedSettings := &domain.GlobalFeedSettings{
-		Enabled: true,
-		URL:     request.URL,
-		Headers: request.Headers,
+		Enabled:  true,
+		URL:      request.URL,
+		Headers:  request.Headers,
+		Auth:     request.Auth,
+		FieldMap: request.FieldMap,
 	}
 
 	// Get workspace and list information for the payload
@@ -1290,11 +1497,20 @@ func (s *BroadcastService) RefreshGlobalFeed(ctx context.Context, request *domai
 		return nil, fmt.Errorf("failed to get workspace: %w", err)
 	}
 
+	var storedAuth *domain.DataFeedAuth
+	if broadcast.DataFeed != nil && broadcast.DataFeed.GlobalFeed != nil {
+		storedAuth = broadcast.DataFeed.GlobalFeed.Auth
+	}
+	if err := request.Auth.RestoreClientSecret(storedAuth, workspace.Settings.SecretKey); err != nil {
+		return nil, err
+	}
+
 	var listName string
-	if broadcast.Audience.List != "" {
-		list, listErr := s.listService.GetListByID(ctx, request.WorkspaceID, broadcast.Audience.List)
+	listID := broadcast.Audience.PrimaryList()
+	if listID != "" {
+		list, listErr := s.listService.GetListByID(ctx, request.WorkspaceID, listID)
 		if listErr != nil {
-			s.logger.WithField("list_id", broadcast.Audience.List).Warn("Failed to get list for global feed payload")
+			s.logger.WithField("list_id", listID).Warn("Failed to get list for global feed payload")
 		} else if list != nil {
 			listName = list.Name
 		}
@@ -1303,11 +1519,12 @@ func (s *BroadcastService) RefreshGlobalFeed(ctx context.Context, request *domai
 	// Build the payload
 	payload := &domain.GlobalFeedRequestPayload{
 		Broadcast: domain.GlobalFeedBroadcast{
-			ID:   broadcast.ID,
-			Name: broadcast.Name,
+			ID:       broadcast.ID,
+			Name:     broadcast.Name,
+			Metadata: broadcast.Metadata,
 		},
 		List: domain.GlobalFeedList{
-			ID:   broadcast.Audience.List,
+			ID:   listID,
 			Name: listName,
 		},
 		Workspace: domain.GlobalFeedWorkspace{
@@ -1367,9 +1584,11 @@ func (s *BroadcastService) TestRecipientFeed(ctx context.Context, request *domai
 
 	// Build feed settings from request
 	feedSettings := &domain.RecipientFeedSettings{
-		Enabled: true,
-		URL:     request.URL,
-		Headers: request.Headers,
+		Enabled:  true,
+		URL:      request.URL,
+		Headers:  request.Headers,
+		Auth:     request.Auth,
+		FieldMap: request.FieldMap,
 	}
 
 	// Get or create a sample contact for testing
@@ -1410,11 +1629,20 @@ func (s *BroadcastService) TestRecipientFeed(ctx context.Context, request *domai
 		return nil, fmt.Errorf("failed to get workspace: %w", err)
 	}
 
+	var storedAuth *domain.DataFeedAuth
+	if broadcast.DataFeed != nil && broadcast.DataFeed.RecipientFeed != nil {
+		storedAuth = broadcast.DataFeed.RecipientFeed.Auth
+	}
+	if err := request.Auth.RestoreClientSecret(storedAuth, workspace.Settings.SecretKey); err != nil {
+		return nil, err
+	}
+
 	var listName string
-	if broadcast.Audience.List != "" {
-		list, listErr := s.listService.GetListByID(ctx, request.WorkspaceID, broadcast.Audience.List)
+	listID := broadcast.Audience.PrimaryList()
+	if listID != "" {
+		list, listErr := s.listService.GetListByID(ctx, request.WorkspaceID, listID)
 		if listErr != nil {
-			s.logger.WithField("list_id", broadcast.Audience.List).Warn("Failed to get list for recipient feed payload")
+			s.logger.WithField("list_id", listID).Warn("Failed to get list for recipient feed payload")
 		} else if list != nil {
 			listName = list.Name
 		}
@@ -1424,11 +1652,12 @@ func (s *BroadcastService) TestRecipientFeed(ctx context.Context, request *domai
 	payload := &domain.RecipientFeedRequestPayload{
 		Contact: domain.BuildRecipientFeedContact(contact),
 		Broadcast: domain.RecipientFeedBroadcast{
-			ID:   broadcast.ID,
-			Name: broadcast.Name,
+			ID:       broadcast.ID,
+			Name:     broadcast.Name,
+			Metadata: broadcast.Metadata,
 		},
 		List: domain.RecipientFeedList{
-			ID:   broadcast.Audience.List,
+			ID:   listID,
 			Name: listName,
 		},
 		Workspace: domain.RecipientFeedWorkspace{
@@ -1468,6 +1697,181 @@ func (s *BroadcastService) TestRecipientFeed(ctx context.Context, request *domai
 	}, nil
 }
 
+// PreviewRendered renders the email a few sample recipients of the broadcast audience would
+// receive, with the stored global feed data and a live recipient feed fetch when configured.
+// Nothing is sent and links are not tracked. A recipient that fails to render is reported
+// with an error instead of failing the whole preview.
+func (s *BroadcastService) PreviewRendered(ctx context.Context, request *domain.PreviewRenderedBroadcastRequest) (*domain.PreviewRenderedBroadcastResponse, error) {
+	// Validate the request
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Authenticate user for workspace
+	ctx, _, _, err := s.authService.AuthenticateUserForWorkspace(ctx, request.WorkspaceID)
+	if err != nil {
+		s.logger.WithField("broadcast_id", request.BroadcastID).Error("Failed to authenticate user for workspace")
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, request.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	broadcast, err := s.repo.GetBroadcast(ctx, request.WorkspaceID, request.BroadcastID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Render the requested variation, or the first one
+	templateID := request.TemplateID
+	if templateID == "" {
+		if len(broadcast.TestSettings.Variations) == 0 {
+			return nil, fmt.Errorf("broadcast has no variations")
+		}
+		templateID = broadcast.TestSettings.Variations[0].TemplateID
+	}
+	found := false
+	for _, v := range broadcast.TestSettings.Variations {
+		if v.TemplateID == templateID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("variation with ID %s not found in broadcast", templateID)
+	}
+
+	template, err := s.templateSvc.GetTemplateByID(ctx, request.WorkspaceID, templateID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = domain.DefaultBroadcastPreviewRecipients
+	}
+	recipients, err := s.contactRepo.GetContactsForBroadcast(ctx, request.WorkspaceID, broadcast.Audience, limit, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sample recipients: %w", err)
+	}
+
+	// Use workspace CustomEndpointURL if provided, otherwise use the default API endpoint
+	endpoint := s.apiEndpoint
+	if workspace.Settings.CustomEndpointURL != nil && *workspace.Settings.CustomEndpointURL != "" {
+		endpoint = *workspace.Settings.CustomEndpointURL
+	}
+
+	response := &domain.PreviewRenderedBroadcastResponse{
+		TemplateID: templateID,
+		Previews:   make([]domain.BroadcastRecipientPreview, 0, len(recipients)),
+	}
+	for _, recipient := range recipients {
+		preview := domain.BroadcastRecipientPreview{
+			Email:  recipient.Contact.Email,
+			ListID: recipient.ListID,
+		}
+		subject, html, err := s.renderBroadcastPreview(ctx, workspace, endpoint, broadcast, template, recipient)
+		if err != nil {
+			preview.Error = err.Error()
+		} else {
+			preview.Subject = subject
+			preview.HTML = html
+		}
+		response.Previews = append(response.Previews, preview)
+	}
+
+	return response, nil
+}
+
+// renderBroadcastPreview renders the subject and HTML body of the broadcast for one recipient
+func (s *BroadcastService) renderBroadcastPreview(
+	ctx context.Context,
+	workspace *domain.Workspace,
+	endpoint string,
+	broadcast *domain.Broadcast,
+	template *domain.Template,
+	recipient *domain.ContactWithList,
+) (string, string, error) {
+	messageID := uuid.New().String()
+	trackingSettings := notifuse_mjml.TrackingSettings{
+		Endpoint:    endpoint,
+		WorkspaceID: workspace.ID,
+		MessageID:   messageID,
+	}
+	if broadcast.UTMParameters != nil {
+		trackingSettings.UTMSource = broadcast.UTMParameters.Source
+		trackingSettings.UTMMedium = broadcast.UTMParameters.Medium
+		trackingSettings.UTMCampaign = broadcast.UTMParameters.Campaign
+		trackingSettings.UTMContent = broadcast.UTMParameters.Content
+		trackingSettings.UTMTerm = broadcast.UTMParameters.Term
+	}
+
+	templateData, err := domain.BuildTemplateData(domain.TemplateDataRequest{
+		WorkspaceID:         workspace.ID,
+		WorkspaceSecretKey:  workspace.Settings.SecretKey,
+		WorkspaceWebsiteURL: workspace.Settings.WebsiteURL,
+		ContactWithList:     *recipient,
+		MessageID:           messageID,
+		TrackingSettings:    trackingSettings,
+		Broadcast:           broadcast,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to build template data: %w", err)
+	}
+
+	if broadcast.DataFeed != nil && broadcast.DataFeed.RecipientFeed != nil &&
+		broadcast.DataFeed.RecipientFeed.Enabled && s.dataFeedFetcher != nil {
+		feedData, err := s.dataFeedFetcher.FetchRecipient(ctx, broadcast.DataFeed.RecipientFeed, &domain.RecipientFeedRequestPayload{
+			Contact:   domain.BuildRecipientFeedContact(recipient.Contact),
+			List:      domain.RecipientFeedList{ID: recipient.ListID, Name: recipient.ListName},
+			Broadcast: domain.RecipientFeedBroadcast{ID: broadcast.ID, Name: broadcast.Name, Metadata: broadcast.Metadata},
+			Workspace: domain.RecipientFeedWorkspace{ID: workspace.ID},
+		})
+		if err != nil {
+			return "", "", fmt.Errorf("failed to fetch recipient feed: %w", err)
+		}
+		templateData["recipient_feed"] = feedData
+	}
+
+	contactLanguage := ""
+	if recipient.Contact.Language != nil && !recipient.Contact.Language.IsNull {
+		contactLanguage = recipient.Contact.Language.String
+	}
+	emailContent := template.ResolveEmailContent(contactLanguage, workspace.Settings.DefaultLanguage)
+	if emailContent == nil {
+		return "", "", fmt.Errorf("email content not available after language resolution")
+	}
+
+	subject, err := notifuse_mjml.ProcessLiquidTemplate(emailContent.Subject, templateData, "email_subject")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to process subject: %w", err)
+	}
+
+	compileReq := domain.CompileTemplateRequest{
+		WorkspaceID:      workspace.ID,
+		MessageID:        messageID,
+		VisualEditorTree: emailContent.VisualEditorTree,
+		TemplateData:     notifuse_mjml.MapOfAny(templateData),
+		TrackingSettings: trackingSettings,
+	}
+	compileReq.MjmlSource = emailContent.GetCodeModeMjmlSource()
+	compiledTemplate, err := s.templateSvc.CompileTemplate(ctx, compileReq)
+	if err != nil {
+		return "", "", err
+	}
+	if !compiledTemplate.Success || compiledTemplate.HTML == nil {
+		errMsg := "template compilation failed"
+		if compiledTemplate.Error != nil {
+			errMsg = compiledTemplate.Error.Message
+		}
+		return "", "", fmt.Errorf("%s", errMsg)
+	}
+
+	return subject, *compiledTemplate.HTML, nil
+}
+
 // ValidateSlug checks if slug is valid format (no nanoid - clean slugs)
 func ValidateSlug(slug string) error {
 	if slug == "" {
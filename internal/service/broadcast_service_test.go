@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"strings"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/Notifuse/notifuse/internal/domain"
 	domainmocks "github.com/Notifuse/notifuse/internal/domain/mocks"
 	broadcastmocks "github.com/Notifuse/notifuse/internal/service/broadcast/mocks"
+	"github.com/Notifuse/notifuse/pkg/crypto"
 	"github.com/Notifuse/notifuse/pkg/logger"
 	notifusemjml "github.com/Notifuse/notifuse/pkg/notifuse_mjml"
 	"github.com/golang/mock/gomock"
@@ -446,6 +448,177 @@ func TestBroadcastService_UpdateBroadcast_Success(t *testing.T) {
 	assert.Equal(t, req.Name, updated.Name)
 }
 
+func TestBroadcastService_UpdateBroadcast_FeedClientSecret(t *testing.T) {
+	const secretKey = "workspace-secret-key"
+
+	feedWithSecret := func(clientSecret string) *domain.DataFeedSettings {
+		return &domain.DataFeedSettings{
+			GlobalFeed: &domain.GlobalFeedSettings{
+				Enabled: true,
+				URL:     "https://api.example.com/data",
+				Auth: &domain.DataFeedAuth{OAuth2: &domain.DataFeedOAuth2Config{
+					TokenURL:     "https://auth.example.com/token",
+					ClientID:     "feed-client",
+					ClientSecret: clientSecret,
+				}},
+			},
+		}
+	}
+
+	d := setupBroadcastSvc(t)
+	defer d.ctrl.Finish()
+
+	ctx := context.Background()
+	authOK(d.authService, ctx, "w1")
+
+	existing := testBroadcast("w1", "b1")
+	existing.DataFeed = feedWithSecret("feed-secret")
+	require.NoError(t, existing.DataFeed.EncryptClientSecrets(secretKey))
+
+	// The client sends back the masked secret it received
+	req := &domain.UpdateBroadcastRequest{
+		WorkspaceID:  "w1",
+		ID:           "b1",
+		Name:         "Updated Name",
+		Audience:     existing.Audience,
+		Schedule:     existing.Schedule,
+		TestSettings: existing.TestSettings,
+		DataFeed:     feedWithSecret(domain.DataFeedClientSecretMask),
+	}
+
+	d.repo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(existing, nil)
+	d.workspaceRepo.EXPECT().GetByID(ctx, "w1").Return(&domain.Workspace{
+		ID:       "w1",
+		Settings: domain.WorkspaceSettings{SecretKey: secretKey},
+	}, nil)
+	d.repo.EXPECT().UpdateBroadcast(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, b *domain.Broadcast) error {
+		oauth2 := b.DataFeed.GlobalFeed.Auth.OAuth2
+		assert.Empty(t, oauth2.ClientSecret, "the secret must not be stored in plain text")
+		secret, err := crypto.DecryptFromHexString(oauth2.EncryptedClientSecret, secretKey)
+		require.NoError(t, err)
+		assert.Equal(t, "feed-secret", secret)
+		return nil
+	})
+
+	updated, err := d.svc.UpdateBroadcast(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DataFeedClientSecretMask, updated.DataFeed.GlobalFeed.Auth.OAuth2.ClientSecret)
+	assert.Empty(t, updated.DataFeed.GlobalFeed.Auth.OAuth2.EncryptedClientSecret)
+}
+
+func TestBroadcastService_CreateBroadcast_ExcludeOpenersOf(t *testing.T) {
+	t.Run("existing broadcast is accepted", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+
+		ctx := context.Background()
+		req := &domain.CreateBroadcastRequest{
+			WorkspaceID: "w1",
+			Name:        "Resend",
+			Audience:    domain.AudienceSettings{List: "list1", ExcludeOpenersOf: "b0"},
+		}
+		authOK(d.authService, ctx, req.WorkspaceID)
+
+		d.repo.EXPECT().GetBroadcast(ctx, "w1", "b0").Return(testBroadcast("w1", "b0"), nil)
+		d.repo.EXPECT().CreateBroadcast(gomock.Any(), gomock.Any()).Return(nil)
+
+		b, err := d.svc.CreateBroadcast(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "b0", b.Audience.ExcludeOpenersOf)
+	})
+
+	t.Run("unknown broadcast is rejected", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+
+		ctx := context.Background()
+		req := &domain.CreateBroadcastRequest{
+			WorkspaceID: "w1",
+			Name:        "Resend",
+			Audience:    domain.AudienceSettings{List: "list1", ExcludeOpenersOf: "other-workspace-broadcast"},
+		}
+		authOK(d.authService, ctx, req.WorkspaceID)
+
+		d.repo.EXPECT().GetBroadcast(ctx, "w1", "other-workspace-broadcast").
+			Return(nil, &domain.ErrBroadcastNotFound{ID: "other-workspace-broadcast"})
+
+		b, err := d.svc.CreateBroadcast(ctx, req)
+		require.Error(t, err)
+		assert.Nil(t, b)
+		assert.IsType(t, domain.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "exclude_openers_of")
+	})
+
+	t.Run("lookup failure is returned", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+
+		ctx := context.Background()
+		req := &domain.CreateBroadcastRequest{
+			WorkspaceID: "w1",
+			Name:        "Resend",
+			Audience:    domain.AudienceSettings{List: "list1", ExcludeOpenersOf: "b0"},
+		}
+		authOK(d.authService, ctx, req.WorkspaceID)
+
+		d.repo.EXPECT().GetBroadcast(ctx, "w1", "b0").Return(nil, errors.New("db down"))
+
+		_, err := d.svc.CreateBroadcast(ctx, req)
+		require.Error(t, err)
+		_, isValidation := err.(domain.ValidationError)
+		assert.False(t, isValidation)
+		assert.Contains(t, err.Error(), "db down")
+	})
+}
+
+func TestBroadcastService_UpdateBroadcast_ExcludeOpenersOf(t *testing.T) {
+	newRequest := func(excludeOpenersOf string) *domain.UpdateBroadcastRequest {
+		return &domain.UpdateBroadcastRequest{
+			WorkspaceID: "w1",
+			ID:          "b1",
+			Name:        "Resend",
+			Audience:    domain.AudienceSettings{List: "list1", ExcludeOpenersOf: excludeOpenersOf},
+			TestSettings: domain.BroadcastTestSettings{
+				Variations: []domain.BroadcastVariation{{VariationName: "A", TemplateID: "tplA"}},
+			},
+		}
+	}
+
+	t.Run("unknown broadcast is rejected", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+
+		ctx := context.Background()
+		req := newRequest("missing")
+		authOK(d.authService, ctx, req.WorkspaceID)
+
+		d.repo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(testBroadcast("w1", "b1"), nil)
+		d.repo.EXPECT().GetBroadcast(ctx, "w1", "missing").Return(nil, &domain.ErrBroadcastNotFound{ID: "missing"})
+
+		_, err := d.svc.UpdateBroadcast(ctx, req)
+		require.Error(t, err)
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+
+	t.Run("unchanged reference is not looked up again", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+
+		ctx := context.Background()
+		req := newRequest("b0")
+		authOK(d.authService, ctx, req.WorkspaceID)
+
+		existing := testBroadcast("w1", "b1")
+		existing.Audience.ExcludeOpenersOf = "b0"
+		d.repo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(existing, nil)
+		d.repo.EXPECT().UpdateBroadcast(ctx, gomock.Any()).Return(nil)
+
+		updated, err := d.svc.UpdateBroadcast(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "b0", updated.Audience.ExcludeOpenersOf)
+	})
+}
+
 func TestBroadcastService_ListBroadcasts_WithTemplates(t *testing.T) {
 	d := setupBroadcastSvc(t)
 	defer d.ctrl.Finish()
@@ -503,6 +676,73 @@ func TestBroadcastService_CancelBroadcast_Success(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestBroadcastService_CancelBroadcastSchedule_Success(t *testing.T) {
+	d := setupBroadcastSvc(t)
+	defer d.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &domain.CancelBroadcastScheduleRequest{WorkspaceID: "w1", ID: "b1"}
+	authOK(d.authService, ctx, req.WorkspaceID)
+
+	d.repo.EXPECT().WithTransaction(ctx, req.WorkspaceID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
+	)
+
+	scheduled := testBroadcast(req.WorkspaceID, req.ID)
+	scheduled.Status = domain.BroadcastStatusScheduled
+	scheduled.Schedule = domain.ScheduleSettings{
+		IsScheduled:   true,
+		ScheduledDate: "2030-01-15",
+		ScheduledTime: "09:00",
+		Timezone:      "Europe/Paris",
+	}
+
+	d.repo.EXPECT().GetBroadcastTx(gomock.Any(), gomock.Any(), req.WorkspaceID, req.ID).Return(scheduled, nil)
+	d.repo.EXPECT().UpdateBroadcastTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *sql.Tx, b *domain.Broadcast) error {
+			assert.Equal(t, domain.BroadcastStatusDraft, b.Status)
+			assert.False(t, b.Schedule.IsScheduled)
+			assert.Empty(t, b.Schedule.ScheduledDate)
+			assert.Empty(t, b.Schedule.ScheduledTime)
+			assert.Equal(t, "Europe/Paris", b.Schedule.Timezone)
+			return nil
+		},
+	)
+	d.emailQueueRepo.EXPECT().DeleteBySourceTx(gomock.Any(), gomock.Any(), domain.EmailQueueSourceBroadcast, req.ID).Return(int64(0), nil)
+
+	d.eventBus.EXPECT().PublishWithAck(gomock.Any(), gomock.Any(), gomock.Any()).Do(
+		func(_ context.Context, payload domain.EventPayload, ack domain.EventAckCallback) {
+			assert.Equal(t, domain.EventBroadcastUnscheduled, payload.Type)
+			assert.Equal(t, req.ID, payload.Data["broadcast_id"])
+			ack(nil)
+		},
+	)
+
+	err := d.svc.CancelBroadcastSchedule(ctx, req)
+	require.NoError(t, err)
+}
+
+func TestBroadcastService_CancelBroadcastSchedule_InvalidStatus(t *testing.T) {
+	d := setupBroadcastSvc(t)
+	defer d.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &domain.CancelBroadcastScheduleRequest{WorkspaceID: "w1", ID: "b1"}
+	authOK(d.authService, ctx, req.WorkspaceID)
+
+	d.repo.EXPECT().WithTransaction(ctx, req.WorkspaceID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
+	)
+
+	processing := testBroadcast(req.WorkspaceID, req.ID)
+	processing.Status = domain.BroadcastStatusProcessing
+	d.repo.EXPECT().GetBroadcastTx(gomock.Any(), gomock.Any(), req.WorkspaceID, req.ID).Return(processing, nil)
+
+	err := d.svc.CancelBroadcastSchedule(ctx, req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only broadcasts with scheduled status can be unscheduled")
+}
+
 func TestBroadcastService_DeleteBroadcast_Success(t *testing.T) {
 	d := setupBroadcastSvc(t)
 	defer d.ctrl.Finish()
@@ -859,6 +1099,34 @@ func TestBroadcastService_ScheduleBroadcast_NoEmailProvider(t *testing.T) {
 	assert.Contains(t, err.Error(), "no marketing email provider configured")
 }
 
+func TestBroadcastService_ScheduleBroadcast_CheckIntegrationFailure(t *testing.T) {
+	d := setupBroadcastSvc(t)
+	defer d.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &domain.ScheduleBroadcastRequest{WorkspaceID: "w1", ID: "b1", SendNow: true, CheckIntegration: true}
+	authOK(d.authService, ctx, req.WorkspaceID)
+
+	workspace := &domain.Workspace{
+		ID:       "w1",
+		Settings: domain.WorkspaceSettings{MarketingEmailProviderID: "mkt"},
+		Integrations: domain.Integrations{
+			{ID: "mkt", Type: domain.IntegrationTypeEmail, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindSMTP, Senders: []domain.EmailSender{domain.NewEmailSender("from@example.com", "From")}}},
+		},
+	}
+	d.workspaceRepo.EXPECT().GetByID(ctx, req.WorkspaceID).Return(workspace, nil)
+	d.emailSvc.EXPECT().CheckIntegration(ctx, req.WorkspaceID, "").
+		Return(errors.New(`integration "mkt" (smtp) failed the connection check: authentication failed with code: 535`))
+
+	// The broadcast must not be touched when the check fails
+	err := d.svc.ScheduleBroadcast(ctx, req)
+	require.Error(t, err)
+	_, ok := err.(domain.ValidationError)
+	assert.True(t, ok)
+	assert.Contains(t, err.Error(), "email integration check failed")
+	assert.Contains(t, err.Error(), "authentication failed with code: 535")
+}
+
 func TestBroadcastService_ScheduleBroadcast_TransactionFailure(t *testing.T) {
 	d := setupBroadcastSvc(t)
 	defer d.ctrl.Finish()
@@ -2941,6 +3209,33 @@ func TestBroadcastService_RefreshGlobalFeed_Success(t *testing.T) {
 	assert.NotNil(t, resp.FetchedAt)
 }
 
+func TestBroadcastService_RefreshGlobalFeed_SendsMetadata(t *testing.T) {
+	d := setupBroadcastSvc(t)
+	defer d.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &domain.RefreshGlobalFeedRequest{WorkspaceID: "w1", BroadcastID: "b1", URL: "https://example.com/feed", Headers: []domain.DataFeedHeader{}}
+	authOK(d.authService, ctx, req.WorkspaceID)
+
+	b := testBroadcast(req.WorkspaceID, req.BroadcastID)
+	b.Metadata = domain.MapOfAny{"campaign_id": "crm-42"}
+	d.repo.EXPECT().GetBroadcast(ctx, req.WorkspaceID, req.BroadcastID).Return(b, nil)
+	d.workspaceRepo.EXPECT().GetByID(ctx, req.WorkspaceID).Return(&domain.Workspace{ID: "w1", Name: "Test Workspace"}, nil)
+	d.listService.EXPECT().GetListByID(ctx, req.WorkspaceID, b.Audience.List).Return(&domain.List{ID: "list1", Name: "Test List"}, nil)
+
+	d.dataFeedFetcher.EXPECT().FetchGlobal(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *domain.GlobalFeedSettings, payload *domain.GlobalFeedRequestPayload) (map[string]interface{}, error) {
+			body, err := json.Marshal(payload.Broadcast)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"id":"b1","name":"Test Broadcast","metadata":{"campaign_id":"crm-42"}}`, string(body))
+			return map[string]interface{}{"_success": true}, nil
+		})
+
+	resp, err := d.svc.RefreshGlobalFeed(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+}
+
 func TestBroadcastService_RefreshGlobalFeed_FetchError(t *testing.T) {
 	d := setupBroadcastSvc(t)
 	defer d.ctrl.Finish()
@@ -3230,3 +3525,141 @@ func TestBroadcastService_TestRecipientFeed_ValidationFailure(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "url is required")
 }
+
+func TestBroadcastService_PreviewRendered_GlobalFeed(t *testing.T) {
+	d := setupBroadcastSvc(t)
+	defer d.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &domain.PreviewRenderedBroadcastRequest{WorkspaceID: "w1", BroadcastID: "b1"}
+	authOK(d.authService, ctx, req.WorkspaceID)
+
+	workspace := &domain.Workspace{ID: "w1", Settings: domain.WorkspaceSettings{SecretKey: "secret", DefaultLanguage: "en"}}
+	d.workspaceRepo.EXPECT().GetByID(ctx, req.WorkspaceID).Return(workspace, nil)
+
+	b := testBroadcast(req.WorkspaceID, req.BroadcastID)
+	b.DataFeed = &domain.DataFeedSettings{
+		GlobalFeedData: domain.MapOfAny{"headline": "Spring Sale"},
+	}
+	d.repo.EXPECT().GetBroadcast(ctx, req.WorkspaceID, req.BroadcastID).Return(b, nil)
+
+	template := &domain.Template{
+		ID: "tplA",
+		Email: &domain.EmailTemplate{
+			Subject:          "{{ global_feed.headline }} for {{ contact.first_name }}",
+			VisualEditorTree: createMJMLRootBlock(),
+		},
+	}
+	d.templateSvc.EXPECT().GetTemplateByID(ctx, req.WorkspaceID, "tplA", int64(0)).Return(template, nil)
+
+	contact := &domain.Contact{
+		Email:     "jane@example.com",
+		FirstName: &domain.NullableString{String: "Jane", IsNull: false},
+	}
+	d.contactRepo.EXPECT().
+		GetContactsForBroadcast(ctx, req.WorkspaceID, b.Audience, domain.DefaultBroadcastPreviewRecipients, "").
+		Return([]*domain.ContactWithList{{Contact: contact, ListID: "list1", ListName: "Newsletter"}}, nil)
+
+	html := "<p>Spring Sale</p>"
+	d.templateSvc.EXPECT().CompileTemplate(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, compileReq domain.CompileTemplateRequest) (*domain.CompileTemplateResponse, error) {
+			globalFeed, ok := compileReq.TemplateData["global_feed"].(domain.MapOfAny)
+			require.True(t, ok, "global_feed must be passed to the template")
+			assert.Equal(t, "Spring Sale", globalFeed["headline"])
+			assert.False(t, compileReq.TrackingSettings.EnableTracking)
+			return &domain.CompileTemplateResponse{Success: true, HTML: &html}, nil
+		})
+
+	resp, err := d.svc.PreviewRendered(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "tplA", resp.TemplateID)
+	require.Len(t, resp.Previews, 1)
+	assert.Equal(t, "jane@example.com", resp.Previews[0].Email)
+	assert.Equal(t, "list1", resp.Previews[0].ListID)
+	assert.Equal(t, "Spring Sale for Jane", resp.Previews[0].Subject)
+	assert.Equal(t, html, resp.Previews[0].HTML)
+	assert.Empty(t, resp.Previews[0].Error)
+}
+
+func TestBroadcastService_PreviewRendered_RecipientFeedError(t *testing.T) {
+	d := setupBroadcastSvc(t)
+	defer d.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &domain.PreviewRenderedBroadcastRequest{WorkspaceID: "w1", BroadcastID: "b1", Limit: 1}
+	authOK(d.authService, ctx, req.WorkspaceID)
+
+	workspace := &domain.Workspace{ID: "w1", Settings: domain.WorkspaceSettings{SecretKey: "secret"}}
+	d.workspaceRepo.EXPECT().GetByID(ctx, req.WorkspaceID).Return(workspace, nil)
+
+	b := testBroadcast(req.WorkspaceID, req.BroadcastID)
+	b.DataFeed = &domain.DataFeedSettings{
+		RecipientFeed: &domain.RecipientFeedSettings{Enabled: true, URL: "https://example.com/feed"},
+	}
+	d.repo.EXPECT().GetBroadcast(ctx, req.WorkspaceID, req.BroadcastID).Return(b, nil)
+
+	template := &domain.Template{ID: "tplA", Email: &domain.EmailTemplate{Subject: "Hello"}}
+	d.templateSvc.EXPECT().GetTemplateByID(ctx, req.WorkspaceID, "tplA", int64(0)).Return(template, nil)
+
+	contact := &domain.Contact{Email: "jane@example.com"}
+	d.contactRepo.EXPECT().
+		GetContactsForBroadcast(ctx, req.WorkspaceID, b.Audience, 1, "").
+		Return([]*domain.ContactWithList{{Contact: contact, ListID: "list1"}}, nil)
+
+	d.dataFeedFetcher.EXPECT().FetchRecipient(ctx, gomock.Any(), gomock.Any()).Return(nil, errors.New("timeout"))
+
+	resp, err := d.svc.PreviewRendered(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, resp.Previews, 1)
+	assert.Equal(t, "jane@example.com", resp.Previews[0].Email)
+	assert.Empty(t, resp.Previews[0].HTML)
+	assert.Contains(t, resp.Previews[0].Error, "failed to fetch recipient feed")
+}
+
+func TestBroadcastService_PreviewRendered_Errors(t *testing.T) {
+	t.Run("validation error", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+
+		resp, err := d.svc.PreviewRendered(context.Background(), &domain.PreviewRenderedBroadcastRequest{
+			WorkspaceID: "w1",
+			BroadcastID: "b1",
+			Limit:       domain.MaxBroadcastPreviewRecipients + 1,
+		})
+		assert.Nil(t, resp)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "limit must be between")
+	})
+
+	t.Run("broadcast not found", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+
+		ctx := context.Background()
+		req := &domain.PreviewRenderedBroadcastRequest{WorkspaceID: "w1", BroadcastID: "missing"}
+		authOK(d.authService, ctx, req.WorkspaceID)
+		d.workspaceRepo.EXPECT().GetByID(ctx, req.WorkspaceID).Return(&domain.Workspace{ID: "w1"}, nil)
+		d.repo.EXPECT().GetBroadcast(ctx, req.WorkspaceID, req.BroadcastID).
+			Return(nil, &domain.ErrBroadcastNotFound{ID: req.BroadcastID})
+
+		resp, err := d.svc.PreviewRendered(ctx, req)
+		assert.Nil(t, resp)
+		var notFound *domain.ErrBroadcastNotFound
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("unknown variation", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+
+		ctx := context.Background()
+		req := &domain.PreviewRenderedBroadcastRequest{WorkspaceID: "w1", BroadcastID: "b1", TemplateID: "other"}
+		authOK(d.authService, ctx, req.WorkspaceID)
+		d.workspaceRepo.EXPECT().GetByID(ctx, req.WorkspaceID).Return(&domain.Workspace{ID: "w1"}, nil)
+		d.repo.EXPECT().GetBroadcast(ctx, req.WorkspaceID, req.BroadcastID).Return(testBroadcast("w1", "b1"), nil)
+
+		resp, err := d.svc.PreviewRendered(ctx, req)
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "variation with ID other not found in broadcast")
+	})
+}
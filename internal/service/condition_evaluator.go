@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// ConditionContext holds what is needed to evaluate a condition tree against a contact
+type ConditionContext struct {
+	// QueryBuilder translates the tree to SQL (defaults to NewQueryBuilder())
+	QueryBuilder *QueryBuilder
+	// DB is the workspace database the contact lives in
+	DB *sql.DB
+}
+
+// EvaluateConditions reports whether the contact matches the condition tree.
+// The tree is translated to SQL with the same query builder used by segments and
+// automation triggers, then checked against the contact's row in the workspace database,
+// so contact field, list membership, timeline event and goal leaves are all supported.
+func EvaluateConditions(ctx context.Context, conditions *domain.TreeNode, contact *domain.Contact, evalCtx ConditionContext) (bool, error) {
+	if contact == nil {
+		return false, fmt.Errorf("contact is required")
+	}
+	if evalCtx.DB == nil {
+		return false, fmt.Errorf("database connection is required")
+	}
+
+	queryBuilder := evalCtx.QueryBuilder
+	if queryBuilder == nil {
+		queryBuilder = NewQueryBuilder()
+	}
+
+	// The QueryBuilder returns a SELECT ... FROM contacts ... WHERE ... query,
+	// narrowed down to the contact with an email filter
+	sqlStr, args, err := queryBuilder.BuildSQL(conditions)
+	if err != nil {
+		return false, err
+	}
+
+	checkSQL := fmt.Sprintf("SELECT EXISTS (%s AND email = $%d)", sqlStr, len(args)+1)
+	args = append(args, contact.Email)
+
+	var exists bool
+	if err := evalCtx.DB.QueryRowContext(ctx, checkSQL, args...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("condition query failed: %w", err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func contactFilterLeaf(filter *domain.DimensionFilter) *domain.TreeNode {
+	return &domain.TreeNode{
+		Kind: "leaf",
+		Leaf: &domain.TreeNodeLeaf{
+			Source:  "contacts",
+			Contact: &domain.ContactCondition{Filters: []*domain.DimensionFilter{filter}},
+		},
+	}
+}
+
+func TestEvaluateConditions(t *testing.T) {
+	contact := &domain.Contact{Email: "test@example.com"}
+
+	tests := []struct {
+		name       string
+		conditions *domain.TreeNode
+		sqlPart    string
+		args       []interface{}
+		matches    bool
+	}{
+		{
+			name: "string leaf",
+			conditions: contactFilterLeaf(&domain.DimensionFilter{
+				FieldName:    "country",
+				FieldType:    "string",
+				Operator:     "equals",
+				StringValues: []string{"FR"},
+			}),
+			sqlPart: "country = $1",
+			args:    []interface{}{"FR", "test@example.com"},
+			matches: true,
+		},
+		{
+			name: "numeric leaf",
+			conditions: contactFilterLeaf(&domain.DimensionFilter{
+				FieldName:    "custom_number_1",
+				FieldType:    "number",
+				Operator:     "gt",
+				NumberValues: []float64{100},
+			}),
+			sqlPart: "custom_number_1 > $1",
+			args:    []interface{}{float64(100), "test@example.com"},
+			matches: false,
+		},
+		{
+			name: "date leaf",
+			conditions: contactFilterLeaf(&domain.DimensionFilter{
+				FieldName:    "created_at",
+				FieldType:    "time",
+				Operator:     "in_the_last_days",
+				StringValues: []string{"30"},
+			}),
+			sqlPart: "created_at > NOW() - INTERVAL '30 days'",
+			args:    []interface{}{"test@example.com"},
+			matches: true,
+		},
+		{
+			name: "event leaf",
+			conditions: &domain.TreeNode{
+				Kind: "leaf",
+				Leaf: &domain.TreeNodeLeaf{
+					Source: "contact_timeline",
+					ContactTimeline: &domain.ContactTimelineCondition{
+						Kind:          "open_email",
+						CountOperator: "at_least",
+						CountValue:    3,
+					},
+				},
+			},
+			sqlPart: "FROM contact_timeline ct WHERE ct.email = contacts.email AND ct.kind = $1) >= $2",
+			args:    []interface{}{"open_email", 3, "test@example.com"},
+			matches: true,
+		},
+		{
+			name: "compound branch",
+			conditions: &domain.TreeNode{
+				Kind: "branch",
+				Branch: &domain.TreeNodeBranch{
+					Operator: "or",
+					Leaves: []*domain.TreeNode{
+						contactFilterLeaf(&domain.DimensionFilter{
+							FieldName:    "country",
+							FieldType:    "string",
+							Operator:     "equals",
+							StringValues: []string{"FR"},
+						}),
+						contactFilterLeaf(&domain.DimensionFilter{
+							FieldName:    "custom_number_1",
+							FieldType:    "number",
+							Operator:     "gte",
+							NumberValues: []float64{10},
+						}),
+					},
+				},
+			},
+			sqlPart: " OR ",
+			args:    []interface{}{"FR", float64(10), "test@example.com"},
+			matches: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			args := make([]driver.Value, len(tt.args))
+			for i, arg := range tt.args {
+				args[i] = arg
+			}
+			mock.ExpectQuery(`SELECT EXISTS \(.*` + regexp.QuoteMeta(tt.sqlPart) + `.* AND email = \$\d+\)`).
+				WithArgs(args...).
+				WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(tt.matches))
+
+			matches, err := EvaluateConditions(context.Background(), tt.conditions, contact, ConditionContext{DB: db})
+			require.NoError(t, err)
+			assert.Equal(t, tt.matches, matches)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestEvaluateConditions_Errors(t *testing.T) {
+	contact := &domain.Contact{Email: "test@example.com"}
+	conditions := contactFilterLeaf(&domain.DimensionFilter{
+		FieldName:    "country",
+		FieldType:    "string",
+		Operator:     "equals",
+		StringValues: []string{"FR"},
+	})
+
+	t.Run("requires a contact", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		_, err = EvaluateConditions(context.Background(), conditions, nil, ConditionContext{DB: db})
+		assert.EqualError(t, err, "contact is required")
+	})
+
+	t.Run("requires a database", func(t *testing.T) {
+		_, err := EvaluateConditions(context.Background(), conditions, contact, ConditionContext{})
+		assert.EqualError(t, err, "database connection is required")
+	})
+
+	t.Run("invalid tree", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		_, err = EvaluateConditions(context.Background(), nil, contact, ConditionContext{DB: db})
+		assert.Error(t, err)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery("SELECT EXISTS").WillReturnError(errors.New("connection reset"))
+
+		_, err = EvaluateConditions(context.Background(), conditions, contact, ConditionContext{DB: db})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "condition query failed")
+	})
+}
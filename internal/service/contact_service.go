@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
+	"io"
 	"log"
 	"strings"
 
@@ -311,6 +313,7 @@ func (s *ContactService) BatchImportContacts(ctx context.Context, workspaceID st
 		}
 	}
 
+	response.Summarize()
 	return response
 }
 
@@ -393,3 +396,70 @@ func (s *ContactService) CountContacts(ctx context.Context, workspaceID string)
 
 	return count, nil
 }
+
+// ExportContacts streams every contact matching the request filters to w as CSV.
+// Contacts are read page by page with the contacts.list cursor so large audiences
+// never have to be held in memory; the header row is only written once the first
+// page has been fetched so that early failures can still be reported as errors.
+func (s *ContactService) ExportContacts(ctx context.Context, req *domain.ExportContactsRequest, w io.Writer) error {
+	var err error
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, req.WorkspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Check permission for reading contacts
+	if !userWorkspace.HasPermission(domain.PermissionResourceContacts, domain.PermissionTypeRead) {
+		return domain.NewPermissionError(
+			domain.PermissionResourceContacts,
+			domain.PermissionTypeRead,
+			"Insufficient permissions: read access to contacts required",
+		)
+	}
+
+	filter := req.GetContactsRequest
+	filter.Limit = domain.ContactExportBatchSize
+	filter.Cursor = ""
+	filter.WithContactLists = false
+
+	writer := csv.NewWriter(w)
+	headerWritten := false
+	exported := 0
+
+	for {
+		page, err := s.repo.GetContacts(ctx, &filter)
+		if err != nil {
+			s.logger.WithFields(map[string]interface{}{
+				"workspace_id": req.WorkspaceID,
+				"exported":     exported,
+				"error":        err.Error(),
+			}).Error("Failed to get contacts for export")
+			return fmt.Errorf("failed to get contacts: %w", err)
+		}
+
+		if !headerWritten {
+			if err := writer.Write(req.Columns); err != nil {
+				return fmt.Errorf("failed to write CSV header: %w", err)
+			}
+			headerWritten = true
+		}
+
+		for _, contact := range page.Contacts {
+			if err := writer.Write(domain.ContactExportRow(contact, req.Columns)); err != nil {
+				return fmt.Errorf("failed to write CSV row: %w", err)
+			}
+		}
+		exported += len(page.Contacts)
+
+		// Flush each page so the client receives rows as they are read
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+
+		if page.NextCursor == "" {
+			return nil
+		}
+		filter.Cursor = page.NextCursor
+	}
+}
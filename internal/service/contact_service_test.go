@@ -1,6 +1,7 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -675,6 +676,10 @@ func TestContactService_BatchImportContacts(t *testing.T) {
 		assert.Equal(t, domain.UpsertContactOperationCreate, newOp.Action)
 		assert.NotNil(t, existingOp)
 		assert.Equal(t, domain.UpsertContactOperationUpdate, existingOp.Action)
+
+		// Operations are also reported in the standard bulk operation shape
+		assert.Equal(t, domain.BulkOperationSummary{Created: 1, Updated: 1}, response.Summary)
+		assert.Len(t, response.Results, 2)
 	})
 }
 
@@ -1149,3 +1154,96 @@ func TestContactService_CountContacts(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to count contacts")
 	})
 }
+
+func TestContactService_ExportContacts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo, _, mockAuthService, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
+
+	ctx := context.Background()
+	workspaceID := "workspace123"
+
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user123",
+		WorkspaceID: workspaceID,
+		Role:        "member",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceContacts: {Read: true, Write: false},
+		},
+	}
+
+	newRequest := func() *domain.ExportContactsRequest {
+		return &domain.ExportContactsRequest{
+			GetContactsRequest: domain.GetContactsRequest{
+				WorkspaceID: workspaceID,
+				Segments:    []string{"vip"},
+			},
+			Columns: []string{"email", "first_name", "custom_number_1"},
+		}
+	}
+
+	t.Run("Success - Streams every page with the cursor", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+
+		gomock.InOrder(
+			mockRepo.EXPECT().GetContacts(ctx, gomock.Any()).DoAndReturn(
+				func(_ context.Context, req *domain.GetContactsRequest) (*domain.GetContactsResponse, error) {
+					assert.Equal(t, domain.ContactExportBatchSize, req.Limit)
+					assert.Equal(t, "", req.Cursor)
+					assert.Equal(t, []string{"vip"}, req.Segments)
+					return &domain.GetContactsResponse{
+						Contacts: []*domain.Contact{
+							{Email: "a@example.com", FirstName: &domain.NullableString{String: "Ann"}},
+						},
+						NextCursor: "cursor-1",
+					}, nil
+				}),
+			mockRepo.EXPECT().GetContacts(ctx, gomock.Any()).DoAndReturn(
+				func(_ context.Context, req *domain.GetContactsRequest) (*domain.GetContactsResponse, error) {
+					assert.Equal(t, "cursor-1", req.Cursor)
+					return &domain.GetContactsResponse{
+						Contacts: []*domain.Contact{
+							{Email: "b@example.com", CustomNumber1: &domain.NullableFloat64{Float64: 2.5}},
+						},
+					}, nil
+				}),
+		)
+
+		var buf bytes.Buffer
+		err := service.ExportContacts(ctx, newRequest(), &buf)
+		assert.NoError(t, err)
+		assert.Equal(t, "email,first_name,custom_number_1\na@example.com,Ann,\nb@example.com,,2.5\n", buf.String())
+	})
+
+	t.Run("Error - Insufficient permissions", func(t *testing.T) {
+		userWorkspaceNoPerms := &domain.UserWorkspace{
+			UserID:      "user123",
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceContacts: {Read: false, Write: false},
+			},
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspaceNoPerms, nil)
+
+		var buf bytes.Buffer
+		err := service.ExportContacts(ctx, newRequest(), &buf)
+		var permErr *domain.PermissionError
+		assert.True(t, errors.As(err, &permErr))
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("Error - Repository error before anything is written", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetContacts(ctx, gomock.Any()).Return(nil, errors.New("db error"))
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger)
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		var buf bytes.Buffer
+		err := service.ExportContacts(ctx, newRequest(), &buf)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get contacts")
+		assert.Empty(t, buf.String())
+	})
+}
@@ -22,3 +22,8 @@ func NewContactTimelineService(repo domain.ContactTimelineRepository) *ContactTi
 func (s *ContactTimelineService) List(ctx context.Context, workspaceID string, email string, limit int, cursor *string) ([]*domain.ContactTimelineEntry, *string, error) {
 	return s.repo.List(ctx, workspaceID, email, limit, cursor)
 }
+
+// ListAll retrieves the full timeline of a contact with pagination and kind filtering
+func (s *ContactTimelineService) ListAll(ctx context.Context, workspaceID string, email string, kinds []string, limit int, cursor *string) ([]*domain.ContactTimelineEntry, *string, error) {
+	return s.repo.ListAll(ctx, workspaceID, email, kinds, limit, cursor)
+}
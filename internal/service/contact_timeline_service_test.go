@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -188,6 +189,46 @@ func TestContactTimelineService_List(t *testing.T) {
 	})
 }
 
+func TestContactTimelineService_ListAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockContactTimelineRepository(ctrl)
+	service := NewContactTimelineService(mockRepo)
+
+	ctx := context.Background()
+	kinds := []string{"automation.start", domain.TimelineKindAutomationNode}
+
+	t.Run("Success - Passes kinds and pagination to the repository", func(t *testing.T) {
+		cursor := "cursor123"
+		expectedEntries := []*domain.ContactTimelineEntry{
+			{ID: "exec1", Email: "user@example.com", EntityType: domain.TimelineEntityTypeAutomationNode, Kind: domain.TimelineKindAutomationNode},
+		}
+
+		mockRepo.EXPECT().
+			ListAll(ctx, "ws123", "user@example.com", kinds, 20, &cursor).
+			Return(expectedEntries, nil, nil)
+
+		entries, nextCursor, err := service.ListAll(ctx, "ws123", "user@example.com", kinds, 20, &cursor)
+
+		require.NoError(t, err)
+		assert.Equal(t, expectedEntries, entries)
+		assert.Nil(t, nextCursor)
+	})
+
+	t.Run("Error - Repository error", func(t *testing.T) {
+		mockRepo.EXPECT().
+			ListAll(ctx, "ws123", "user@example.com", kinds, 50, (*string)(nil)).
+			Return(nil, nil, errors.New("database error"))
+
+		entries, nextCursor, err := service.ListAll(ctx, "ws123", "user@example.com", kinds, 50, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, entries)
+		assert.Nil(t, nextCursor)
+	})
+}
+
 func TestNewContactTimelineService(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
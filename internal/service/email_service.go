@@ -2,7 +2,11 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
@@ -36,6 +40,13 @@ type EmailService struct {
 	mailgunService   domain.EmailProviderService
 	mailjetService   domain.EmailProviderService
 	sendGridService  domain.EmailProviderService
+	attachmentLimits domain.AttachmentLimits
+	dnsResolver      txtResolver
+}
+
+// txtResolver looks up DNS TXT records (satisfied by *net.Resolver)
+type txtResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
 }
 
 // NewEmailService creates a new EmailService instance
@@ -83,9 +94,16 @@ func NewEmailService(
 		mailgunService:   mailgunService,
 		mailjetService:   mailjetService,
 		sendGridService:  sendGridService,
+		attachmentLimits: domain.DefaultAttachmentLimits,
+		dnsResolver:      net.DefaultResolver,
 	}
 }
 
+// SetAttachmentLimits sets the attachment count and total size limits enforced before sending
+func (s *EmailService) SetAttachmentLimits(limits domain.AttachmentLimits) {
+	s.attachmentLimits = limits
+}
+
 // CreateSESClient creates a new SES client with the provided credentials
 func CreateSESClient(region, accessKey, secretKey string) domain.SESClient {
 	sess, _ := session.NewSession(&aws.Config{
@@ -156,6 +174,286 @@ func (s *EmailService) TestEmailProvider(ctx context.Context, workspaceID string
 	return nil
 }
 
+// TestIntegration checks a saved email integration can connect and authenticate
+func (s *EmailService) TestIntegration(ctx context.Context, workspaceID, integrationID string) error {
+	ctx, span := tracing.StartServiceSpan(ctx, "EmailService", "TestIntegration")
+	defer tracing.EndSpan(span, nil)
+
+	// Authenticate user
+	ctx, _, _, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		tracing.MarkSpanError(ctx, err)
+		return err
+	}
+
+	if err := s.CheckIntegration(ctx, workspaceID, integrationID); err != nil {
+		tracing.MarkSpanError(ctx, err)
+		return err
+	}
+
+	return nil
+}
+
+// CheckIntegration probes an email integration without sending a message. An empty
+// integrationID checks the workspace marketing provider. Providers whose service has
+// no connection probe only get their configuration checked.
+func (s *EmailService) CheckIntegration(ctx context.Context, workspaceID, integrationID string) error {
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	if integrationID == "" {
+		integrationID = workspace.Settings.MarketingEmailProviderID
+		if integrationID == "" {
+			return fmt.Errorf("no marketing email provider configured for this workspace")
+		}
+	}
+
+	integration := workspace.GetIntegrationByID(integrationID)
+	if integration == nil {
+		return fmt.Errorf("integration %s not found in workspace", integrationID)
+	}
+	if integration.Type != domain.IntegrationTypeEmail {
+		return fmt.Errorf("integration %s is not an email provider", integrationID)
+	}
+
+	provider := &integration.EmailProvider
+	if len(provider.Senders) == 0 {
+		return fmt.Errorf("integration %q has no sender configured", integration.Name)
+	}
+
+	if s.isDemo {
+		return nil
+	}
+
+	providerService, err := s.getProviderService(provider.Kind)
+	if err != nil {
+		return err
+	}
+
+	checker, ok := providerService.(domain.EmailProviderConnectionChecker)
+	if !ok {
+		return nil
+	}
+
+	if err := checker.CheckConnection(ctx, provider); err != nil {
+		return fmt.Errorf("integration %q (%s) failed the connection check: %w", integration.Name, provider.Kind, err)
+	}
+
+	return nil
+}
+
+// providerSPFIncludes are the SPF includes expected for providers that send from their own servers
+var providerSPFIncludes = map[domain.EmailProviderKind]string{
+	domain.EmailProviderKindSES:       "amazonses.com",
+	domain.EmailProviderKindSparkPost: "sparkpostmail.com",
+	domain.EmailProviderKindPostmark:  "spf.mtasv.net",
+	domain.EmailProviderKindMailgun:   "mailgun.org",
+	domain.EmailProviderKindMailjet:   "spf.mailjet.com",
+	domain.EmailProviderKindSendGrid:  "sendgrid.net",
+}
+
+// CheckDeliverability authenticates the user and looks up the SPF, DKIM and DMARC records
+// of an email integration's sender domain
+func (s *EmailService) CheckDeliverability(ctx context.Context, req domain.CheckDeliverabilityRequest) (*domain.DeliverabilityReport, error) {
+	ctx, span := tracing.StartServiceSpan(ctx, "EmailService", "CheckDeliverability")
+	defer tracing.EndSpan(span, nil)
+
+	if err := req.Validate(); err != nil {
+		return nil, domain.ValidationError{Message: err.Error()}
+	}
+
+	ctx, _, _, err := s.authService.AuthenticateUserForWorkspace(ctx, req.WorkspaceID)
+	if err != nil {
+		tracing.MarkSpanError(ctx, err)
+		return nil, err
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, req.WorkspaceID)
+	if err != nil {
+		tracing.MarkSpanError(ctx, err)
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	integration := workspace.GetIntegrationByID(req.IntegrationID)
+	if integration == nil {
+		return nil, domain.ValidationError{Message: fmt.Sprintf("integration %s not found in workspace", req.IntegrationID)}
+	}
+	if integration.Type != domain.IntegrationTypeEmail {
+		return nil, domain.ValidationError{Message: fmt.Sprintf("integration %s is not an email provider", req.IntegrationID)}
+	}
+
+	sender := integration.EmailProvider.GetSender(req.SenderID)
+	if sender == nil {
+		return nil, domain.ValidationError{Message: fmt.Sprintf("integration %q has no sender configured", integration.Name)}
+	}
+	at := strings.LastIndex(sender.Email, "@")
+	if at < 0 || at == len(sender.Email)-1 {
+		return nil, domain.ValidationError{Message: fmt.Sprintf("invalid sender email: %s", sender.Email)}
+	}
+	senderDomain := strings.ToLower(sender.Email[at+1:])
+
+	spfInclude := req.SPFInclude
+	if spfInclude == "" {
+		spfInclude = providerSPFIncludes[integration.EmailProvider.Kind]
+	}
+
+	report := &domain.DeliverabilityReport{
+		Sender: sender.Email,
+		Domain: senderDomain,
+		SPF:    s.checkSPF(ctx, senderDomain, spfInclude),
+		DKIM:   s.checkDKIM(ctx, senderDomain, req.DKIMSelector),
+		DMARC:  s.checkDMARC(ctx, senderDomain),
+	}
+	report.Passed = report.SPF.Status != domain.DeliverabilityCheckFail &&
+		report.DKIM.Status != domain.DeliverabilityCheckFail &&
+		report.DMARC.Status != domain.DeliverabilityCheckFail
+
+	return report, nil
+}
+
+// lookupTXTWithPrefix returns the TXT records of host starting with prefix (case-insensitive).
+// A host without records is not an error.
+func (s *EmailService) lookupTXTWithPrefix(ctx context.Context, host, prefix string) ([]string, error) {
+	records, err := s.dnsResolver.LookupTXT(ctx, host)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var matching []string
+	for _, record := range records {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(record)), strings.ToLower(prefix)) {
+			matching = append(matching, strings.TrimSpace(record))
+		}
+	}
+	return matching, nil
+}
+
+// checkSPF checks the domain publishes a single SPF record including the provider's servers
+func (s *EmailService) checkSPF(ctx context.Context, senderDomain, include string) domain.DeliverabilityCheck {
+	check := domain.DeliverabilityCheck{Host: senderDomain}
+
+	records, err := s.lookupTXTWithPrefix(ctx, senderDomain, "v=spf1")
+	switch {
+	case err != nil:
+		check.Status = domain.DeliverabilityCheckFail
+		check.Message = fmt.Sprintf("DNS lookup failed: %v", err)
+		return check
+	case len(records) == 0:
+		check.Status = domain.DeliverabilityCheckFail
+		check.Message = "No SPF record found"
+		return check
+	case len(records) > 1:
+		check.Status = domain.DeliverabilityCheckFail
+		check.Message = "Multiple SPF records found, receivers treat this as a permanent error"
+		return check
+	}
+
+	check.Record = records[0]
+	mechanisms := strings.Fields(strings.ToLower(check.Record))
+
+	if include != "" && !slices.Contains(mechanisms, "include:"+strings.ToLower(include)) {
+		check.Status = domain.DeliverabilityCheckFail
+		check.Message = fmt.Sprintf("SPF record does not include %s", include)
+		return check
+	}
+	if slices.Contains(mechanisms, "+all") || slices.Contains(mechanisms, "all") {
+		check.Status = domain.DeliverabilityCheckWarning
+		check.Message = "SPF record allows any server to send (+all)"
+		return check
+	}
+
+	check.Status = domain.DeliverabilityCheckPass
+	if include != "" {
+		check.Message = fmt.Sprintf("SPF record includes %s", include)
+	} else {
+		check.Message = "SPF record found, make sure it authorizes your SMTP server"
+	}
+	return check
+}
+
+// checkDKIM checks the DKIM selector publishes a public key
+func (s *EmailService) checkDKIM(ctx context.Context, senderDomain, selector string) domain.DeliverabilityCheck {
+	check := domain.DeliverabilityCheck{Host: selector + "._domainkey." + senderDomain}
+
+	records, err := s.lookupTXTWithPrefix(ctx, check.Host, "")
+	if err != nil {
+		check.Status = domain.DeliverabilityCheckFail
+		check.Message = fmt.Sprintf("DNS lookup failed: %v", err)
+		return check
+	}
+
+	for _, record := range records {
+		tags := parseDNSTagList(record)
+		publicKey, ok := tags["p"]
+		if !ok {
+			continue
+		}
+		check.Record = record
+		if publicKey == "" {
+			check.Status = domain.DeliverabilityCheckFail
+			check.Message = fmt.Sprintf("DKIM key for selector %s is revoked", selector)
+			return check
+		}
+		check.Status = domain.DeliverabilityCheckPass
+		check.Message = fmt.Sprintf("DKIM key found for selector %s", selector)
+		return check
+	}
+
+	check.Status = domain.DeliverabilityCheckFail
+	check.Message = fmt.Sprintf("No DKIM record found for selector %s", selector)
+	return check
+}
+
+// checkDMARC checks the domain publishes a DMARC policy
+func (s *EmailService) checkDMARC(ctx context.Context, senderDomain string) domain.DeliverabilityCheck {
+	check := domain.DeliverabilityCheck{Host: "_dmarc." + senderDomain}
+
+	records, err := s.lookupTXTWithPrefix(ctx, check.Host, "v=DMARC1")
+	if err != nil {
+		check.Status = domain.DeliverabilityCheckFail
+		check.Message = fmt.Sprintf("DNS lookup failed: %v", err)
+		return check
+	}
+	if len(records) == 0 {
+		check.Status = domain.DeliverabilityCheckFail
+		check.Message = "No DMARC record found"
+		return check
+	}
+
+	check.Record = records[0]
+	switch policy := strings.ToLower(parseDNSTagList(check.Record)["p"]); policy {
+	case "quarantine", "reject":
+		check.Status = domain.DeliverabilityCheckPass
+		check.Message = fmt.Sprintf("DMARC policy is %s", policy)
+	case "none":
+		check.Status = domain.DeliverabilityCheckWarning
+		check.Message = "DMARC policy is none, failing messages are still delivered"
+	default:
+		check.Status = domain.DeliverabilityCheckFail
+		check.Message = "DMARC record has no valid policy (p=)"
+	}
+	return check
+}
+
+// parseDNSTagList parses a "k=v; k=v" tag list as used by DKIM and DMARC records
+func parseDNSTagList(record string) map[string]string {
+	tags := make(map[string]string)
+	for _, part := range strings.Split(record, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		tags[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+	return tags
+}
+
 // SendEmail sends an email using the specified provider
 func (s *EmailService) SendEmail(ctx context.Context, request domain.SendEmailProviderRequest, isMarketing bool) error {
 	if s.isDemo {
@@ -167,6 +465,11 @@ func (s *EmailService) SendEmail(ctx context.Context, request domain.SendEmailPr
 		return fmt.Errorf("invalid request: %w", err)
 	}
 
+	// Reject attachments the provider would refuse before anything is sent
+	if err := s.attachmentLimits.Check(request.EmailOptions.Attachments); err != nil {
+		return err
+	}
+
 	// If fromAddress is not provided, use the first sender's email from the provider
 	if request.FromAddress == "" && len(request.Provider.Senders) > 0 {
 		request.FromAddress = request.Provider.Senders[0].Email
@@ -229,6 +532,92 @@ func (s *EmailService) OpenEmail(ctx context.Context, messageID string, workspac
 	return nil
 }
 
+// renderedEmail is an email template rendered for one recipient
+type renderedEmail struct {
+	FromAddress string
+	FromName    string
+	Subject     string
+	HTML        string
+	ReplyTo     string // Reply-to address of the template, empty when it has none
+}
+
+// renderEmailTemplate renders the language variant of an email template matching the contact's
+// language: it resolves the sender, compiles the HTML and processes the subject through Liquid,
+// applying the from name, subject and subject preview overrides of the email options
+func renderEmailTemplate(
+	ctx context.Context,
+	templateService domain.TemplateService,
+	template *domain.Template,
+	workspace *domain.Workspace,
+	emailProvider *domain.EmailProvider,
+	contact *domain.Contact,
+	messageID string,
+	templateData map[string]interface{},
+	trackingSettings notifuse_mjml.TrackingSettings,
+	emailOptions domain.EmailOptions,
+) (*renderedEmail, error) {
+	contactLang := ""
+	if contact != nil && contact.Language != nil && !contact.Language.IsNull {
+		contactLang = contact.Language.String
+	}
+	emailContent := template.ResolveEmailContent(contactLang, workspace.Settings.DefaultLanguage)
+
+	emailSender := emailProvider.GetSender(emailContent.SenderID)
+	if emailSender == nil {
+		return nil, fmt.Errorf("sender not found: %s", emailContent.SenderID)
+	}
+
+	compileTemplateRequest := domain.CompileTemplateRequest{
+		WorkspaceID:            workspace.ID,
+		MessageID:              messageID,
+		VisualEditorTree:       emailContent.VisualEditorTree,
+		TemplateData:           templateData,
+		TrackingSettings:       trackingSettings,
+		SubjectPreviewOverride: emailOptions.SubjectPreview,
+	}
+	compileTemplateRequest.MjmlSource = emailContent.GetCodeModeMjmlSource()
+
+	compiledTemplate, err := templateService.CompileTemplate(ctx, compileTemplateRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile template: %w", err)
+	}
+	if !compiledTemplate.Success || compiledTemplate.HTML == nil {
+		errMsg := "Unknown error"
+		if compiledTemplate.Error != nil {
+			errMsg = compiledTemplate.Error.Message
+		}
+		return nil, fmt.Errorf("template compilation failed: %s", errMsg)
+	}
+
+	// Allow override of from name via email options
+	fromName := emailSender.Name
+	if emailOptions.FromName != nil && *emailOptions.FromName != "" {
+		fromName = *emailOptions.FromName
+	}
+
+	// Process subject line through Liquid templating if it contains Liquid tags
+	subject, err := notifuse_mjml.ProcessLiquidTemplate(emailContent.Subject, templateData, "email_subject")
+	if err != nil {
+		return nil, fmt.Errorf("failed to process subject with Liquid: %w", err)
+	}
+
+	// Allow override of subject via email options
+	if emailOptions.Subject != nil && *emailOptions.Subject != "" {
+		subject, err = notifuse_mjml.ProcessLiquidTemplate(*emailOptions.Subject, templateData, "email_subject_override")
+		if err != nil {
+			return nil, fmt.Errorf("failed to process subject override with Liquid: %w", err)
+		}
+	}
+
+	return &renderedEmail{
+		FromAddress: emailSender.Email,
+		FromName:    fromName,
+		Subject:     subject,
+		HTML:        *compiledTemplate.HTML,
+		ReplyTo:     emailContent.ReplyTo,
+	}, nil
+}
+
 // SendEmailForTemplate handles sending through the email channel
 func (s *EmailService) SendEmailForTemplate(ctx context.Context, request domain.SendEmailRequest) error {
 	ctx, span := tracing.StartServiceSpan(ctx, "EmailService", "SendEmailForTemplate")
@@ -266,32 +655,12 @@ func (s *EmailService) SendEmailForTemplate(ctx context.Context, request domain.
 		return fmt.Errorf("failed to get template: %w", err)
 	}
 
-	// Resolve language variant based on contact's language
-	contactLang := ""
-	if request.Contact != nil && request.Contact.Language != nil && !request.Contact.Language.IsNull {
-		contactLang = request.Contact.Language.String
-	}
-
 	// Get workspace to check for custom endpoint URL and default language
 	workspace, err := s.workspaceRepo.GetByID(ctx, request.WorkspaceID)
 	if err != nil {
 		return fmt.Errorf("failed to get workspace: %w", err)
 	}
 
-	emailContent := template.ResolveEmailContent(contactLang, workspace.Settings.DefaultLanguage)
-
-	// Find the emailSender
-	emailSender := request.EmailProvider.GetSender(emailContent.SenderID)
-
-	if emailSender == nil {
-		return fmt.Errorf("sender not found: %s", emailContent.SenderID)
-	}
-
-	span.AddAttributes(
-		trace.StringAttribute("template.subject", emailContent.Subject),
-		trace.StringAttribute("template.from_email", emailSender.Email),
-	)
-
 	// set utm_content to the template id if not set
 	if request.TrackingSettings.UTMContent == "" {
 		request.TrackingSettings.UTMContent = template.ID
@@ -315,99 +684,29 @@ func (s *EmailService) SendEmailForTemplate(ctx context.Context, request domain.
 		MessageID:      request.MessageID,
 	}
 
-	compileTemplateRequest := domain.CompileTemplateRequest{
-		WorkspaceID:            request.WorkspaceID,
-		MessageID:              request.MessageID,
-		VisualEditorTree:       emailContent.VisualEditorTree,
-		TemplateData:           request.MessageData.Data,
-		TrackingSettings:       trackingSettings,
-		SubjectPreviewOverride: request.EmailOptions.SubjectPreview,
-	}
-	compileTemplateRequest.MjmlSource = emailContent.GetCodeModeMjmlSource()
-
-	// Compile the template with the message data (use system context to bypass authentication)
-	compiledTemplate, err := s.templateService.CompileTemplate(systemCtx, compileTemplateRequest)
+	// Render the template (use system context to bypass authentication)
+	rendered, err := renderEmailTemplate(systemCtx, s.templateService, template, workspace, request.EmailProvider,
+		request.Contact, request.MessageID, request.MessageData.Data, trackingSettings, request.EmailOptions)
 	if err != nil {
 		s.logger.WithFields(map[string]interface{}{
 			"error":       err.Error(),
+			"message_id":  request.MessageID,
 			"template_id": request.TemplateConfig.TemplateID,
-		}).Error("Failed to compile template")
+		}).Error("Failed to render email template")
 
-		tracing.MarkSpanError(ctx, err)
-		return fmt.Errorf("failed to compile template: %w", err)
-	}
-
-	tracing.AddAttribute(ctx, "template.compilation_success", compiledTemplate.Success)
-
-	if !compiledTemplate.Success || compiledTemplate.HTML == nil {
-		errMsg := "Unknown error"
-		if compiledTemplate.Error != nil {
-			errMsg = compiledTemplate.Error.Message
-		}
-		s.logger.WithField("error", errMsg).Error("Template compilation failed")
-
-		err := fmt.Errorf("template compilation failed: %s", errMsg)
 		tracing.MarkSpanError(ctx, err)
 		return err
 	}
 
-	// Get necessary email information from the template
-	fromEmail := emailSender.Email
-	fromName := emailSender.Name
-
-	// Allow override of from name via email options
-	if request.EmailOptions.FromName != nil && *request.EmailOptions.FromName != "" {
-		s.logger.WithFields(map[string]interface{}{
-			"message_id":         request.MessageID,
-			"default_from_name":  emailSender.Name,
-			"override_from_name": *request.EmailOptions.FromName,
-		}).Debug("Using from_name override")
-		fromName = *request.EmailOptions.FromName
-	}
-
-	// Process subject line through Liquid templating if it contains Liquid tags
-	subject, err := notifuse_mjml.ProcessLiquidTemplate(
-		emailContent.Subject,
-		request.MessageData.Data,
-		"email_subject",
+	span.AddAttributes(
+		trace.StringAttribute("template.subject", rendered.Subject),
+		trace.StringAttribute("template.from_email", rendered.FromAddress),
 	)
-	if err != nil {
-		s.logger.WithFields(map[string]interface{}{
-			"error":       err.Error(),
-			"message_id":  request.MessageID,
-			"template_id": request.TemplateConfig.TemplateID,
-			"subject":     emailContent.Subject,
-		}).Error("Failed to process subject line with Liquid templating")
-		tracing.MarkSpanError(ctx, err)
-		return fmt.Errorf("failed to process subject with Liquid: %w", err)
-	}
-
-	// Allow override of subject via email options
-	if request.EmailOptions.Subject != nil && *request.EmailOptions.Subject != "" {
-		overrideSubject, err := notifuse_mjml.ProcessLiquidTemplate(
-			*request.EmailOptions.Subject,
-			request.MessageData.Data,
-			"email_subject_override",
-		)
-		if err != nil {
-			s.logger.WithFields(map[string]interface{}{
-				"error":       err.Error(),
-				"message_id":  request.MessageID,
-				"template_id": request.TemplateConfig.TemplateID,
-				"subject":     *request.EmailOptions.Subject,
-			}).Error("Failed to process subject override with Liquid templating")
-			tracing.MarkSpanError(ctx, err)
-			return fmt.Errorf("failed to process subject override with Liquid: %w", err)
-		}
-		s.logger.WithFields(map[string]interface{}{
-			"message_id":       request.MessageID,
-			"default_subject":  subject,
-			"override_subject": overrideSubject,
-		}).Debug("Using subject override")
-		subject = overrideSubject
-	}
 
-	htmlContent := *compiledTemplate.HTML
+	fromEmail := rendered.FromAddress
+	fromName := rendered.FromName
+	subject := rendered.Subject
+	htmlContent := rendered.HTML
 
 	now := time.Now().UTC()
 
@@ -454,8 +753,8 @@ func (s *EmailService) SendEmailForTemplate(ctx context.Context, request domain.
 	tracing.AddAttribute(ctx, "email.sending", true)
 
 	// optional override for reply to
-	if emailContent.ReplyTo != "" {
-		request.EmailOptions.ReplyTo = emailContent.ReplyTo
+	if rendered.ReplyTo != "" {
+		request.EmailOptions.ReplyTo = rendered.ReplyTo
 	}
 
 	// Create SendEmailProviderRequest
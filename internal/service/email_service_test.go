@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"net"
 	"testing"
 	"time"
 
@@ -439,6 +441,261 @@ func TestEmailService_TestEmailProvider(t *testing.T) {
 	})
 }
 
+func TestEmailService_CheckIntegration(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+
+	newWorkspace := func(smtpPort int) *domain.Workspace {
+		return &domain.Workspace{
+			ID: workspaceID,
+			Settings: domain.WorkspaceSettings{
+				MarketingEmailProviderID: "smtp-integration",
+			},
+			Integrations: []domain.Integration{
+				{
+					ID:   "smtp-integration",
+					Name: "Main SMTP",
+					Type: domain.IntegrationTypeEmail,
+					EmailProvider: domain.EmailProvider{
+						Kind:    domain.EmailProviderKindSMTP,
+						Senders: []domain.EmailSender{domain.NewEmailSender("sender@example.com", "Sender")},
+						SMTP: &domain.SMTPSettings{
+							Host:     "127.0.0.1",
+							Port:     smtpPort,
+							Username: "user",
+							Password: "pass",
+						},
+					},
+				},
+				{
+					ID:   "no-sender",
+					Name: "No Sender",
+					Type: domain.IntegrationTypeEmail,
+					EmailProvider: domain.EmailProvider{
+						Kind: domain.EmailProviderKindSES,
+						SES:  &domain.AmazonSESSettings{Region: "us-east-1"},
+					},
+				},
+			},
+		}
+	}
+
+	setup := func(t *testing.T) (*mocks.MockWorkspaceRepository, *EmailService) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		return mockWorkspaceRepo, &EmailService{
+			logger:        pkgmocks.NewMockLogger(ctrl),
+			workspaceRepo: mockWorkspaceRepo,
+			smtpService:   NewSMTPService(&noopLogger{}),
+			sesService:    mocks.NewMockEmailProviderService(ctrl),
+		}
+	}
+
+	t.Run("defaults to the marketing provider and probes SMTP", func(t *testing.T) {
+		server := newMockSMTPServer(t, true)
+		defer server.Close()
+
+		mockWorkspaceRepo, emailService := setup(t)
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(newWorkspace(server.Port()), nil)
+
+		require.NoError(t, emailService.CheckIntegration(ctx, workspaceID, ""))
+		assert.Empty(t, server.GetMessages())
+	})
+
+	t.Run("reports SMTP handshake failures", func(t *testing.T) {
+		server := newMockSMTPServer(t, false)
+		defer server.Close()
+
+		mockWorkspaceRepo, emailService := setup(t)
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(newWorkspace(server.Port()), nil)
+
+		err := emailService.CheckIntegration(ctx, workspaceID, "smtp-integration")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `integration "Main SMTP" (smtp) failed the connection check`)
+		assert.Contains(t, err.Error(), "authentication failed with code: 535")
+	})
+
+	t.Run("no marketing provider configured", func(t *testing.T) {
+		mockWorkspaceRepo, emailService := setup(t)
+		workspace := newWorkspace(0)
+		workspace.Settings.MarketingEmailProviderID = ""
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+
+		err := emailService.CheckIntegration(ctx, workspaceID, "")
+		assert.EqualError(t, err, "no marketing email provider configured for this workspace")
+	})
+
+	t.Run("unknown integration", func(t *testing.T) {
+		mockWorkspaceRepo, emailService := setup(t)
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(newWorkspace(0), nil)
+
+		err := emailService.CheckIntegration(ctx, workspaceID, "missing")
+		assert.EqualError(t, err, "integration missing not found in workspace")
+	})
+
+	t.Run("integration without sender", func(t *testing.T) {
+		mockWorkspaceRepo, emailService := setup(t)
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(newWorkspace(0), nil)
+
+		err := emailService.CheckIntegration(ctx, workspaceID, "no-sender")
+		assert.EqualError(t, err, `integration "No Sender" has no sender configured`)
+	})
+
+	t.Run("workspace lookup failure", func(t *testing.T) {
+		mockWorkspaceRepo, emailService := setup(t)
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(nil, assert.AnError)
+
+		err := emailService.CheckIntegration(ctx, workspaceID, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get workspace")
+	})
+}
+
+// stubTXTResolver serves TXT records from a map and reports other hosts as not found
+type stubTXTResolver map[string][]string
+
+func (r stubTXTResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	records, ok := r[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+func TestEmailService_CheckDeliverability(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+
+	workspace := &domain.Workspace{
+		ID: workspaceID,
+		Integrations: []domain.Integration{
+			{
+				ID:   "smtp-integration",
+				Name: "Main SMTP",
+				Type: domain.IntegrationTypeEmail,
+				EmailProvider: domain.EmailProvider{
+					Kind:    domain.EmailProviderKindSMTP,
+					Senders: []domain.EmailSender{domain.NewEmailSender("news@example.com", "News")},
+					SMTP:    &domain.SMTPSettings{Host: "smtp.example.com", Port: 587},
+				},
+			},
+			{
+				ID:   "ses-integration",
+				Name: "SES",
+				Type: domain.IntegrationTypeEmail,
+				EmailProvider: domain.EmailProvider{
+					Kind:    domain.EmailProviderKindSES,
+					Senders: []domain.EmailSender{domain.NewEmailSender("hello@example.com", "Hello")},
+					SES:     &domain.AmazonSESSettings{Region: "us-east-1"},
+				},
+			},
+		},
+	}
+
+	setup := func(t *testing.T, resolver stubTXTResolver) *EmailService {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockAuthService := mocks.NewMockAuthService(ctrl)
+		mockAuthService.EXPECT().
+			AuthenticateUserForWorkspace(gomock.Any(), workspaceID).
+			Return(ctx, &domain.User{ID: "user-123"}, nil, nil)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+
+		return &EmailService{
+			logger:        pkgmocks.NewMockLogger(ctrl),
+			authService:   mockAuthService,
+			workspaceRepo: mockWorkspaceRepo,
+			dnsResolver:   resolver,
+		}
+	}
+
+	t.Run("all records aligned", func(t *testing.T) {
+		emailService := setup(t, stubTXTResolver{
+			"example.com":                   {"google-site-verification=abc", "v=spf1 include:_spf.example.net include:amazonses.com -all"},
+			"s1._domainkey.example.com":     {"v=DKIM1; k=rsa; p=MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC"},
+			"_dmarc.example.com":            {"v=DMARC1; p=reject; rua=mailto:dmarc@example.com"},
+			"unused._domainkey.example.com": {"v=DKIM1; p="},
+		})
+
+		report, err := emailService.CheckDeliverability(ctx, domain.CheckDeliverabilityRequest{
+			WorkspaceID:   workspaceID,
+			IntegrationID: "ses-integration",
+			DKIMSelector:  "s1",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "hello@example.com", report.Sender)
+		assert.Equal(t, "example.com", report.Domain)
+		assert.Equal(t, domain.DeliverabilityCheckPass, report.SPF.Status)
+		assert.Equal(t, "SPF record includes amazonses.com", report.SPF.Message)
+		assert.Equal(t, domain.DeliverabilityCheckPass, report.DKIM.Status)
+		assert.Equal(t, "s1._domainkey.example.com", report.DKIM.Host)
+		assert.Equal(t, domain.DeliverabilityCheckPass, report.DMARC.Status)
+		assert.True(t, report.Passed)
+	})
+
+	t.Run("flags a missing DKIM selector", func(t *testing.T) {
+		emailService := setup(t, stubTXTResolver{
+			"example.com":        {"v=spf1 include:smtp.example.com ~all"},
+			"_dmarc.example.com": {"v=DMARC1; p=none"},
+		})
+
+		report, err := emailService.CheckDeliverability(ctx, domain.CheckDeliverabilityRequest{
+			WorkspaceID:   workspaceID,
+			IntegrationID: "smtp-integration",
+			DKIMSelector:  "mail",
+			SPFInclude:    "smtp.example.com",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.DeliverabilityCheckPass, report.SPF.Status)
+		assert.Equal(t, domain.DeliverabilityCheckFail, report.DKIM.Status)
+		assert.Equal(t, "mail._domainkey.example.com", report.DKIM.Host)
+		assert.Equal(t, "No DKIM record found for selector mail", report.DKIM.Message)
+		assert.Equal(t, domain.DeliverabilityCheckWarning, report.DMARC.Status)
+		assert.False(t, report.Passed)
+	})
+
+	t.Run("flags a missing provider include and DMARC record", func(t *testing.T) {
+		emailService := setup(t, stubTXTResolver{
+			"example.com":               {"v=spf1 include:_spf.google.com -all"},
+			"s1._domainkey.example.com": {"v=DKIM1; p="},
+		})
+
+		report, err := emailService.CheckDeliverability(ctx, domain.CheckDeliverabilityRequest{
+			WorkspaceID:   workspaceID,
+			IntegrationID: "ses-integration",
+			DKIMSelector:  "s1",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.DeliverabilityCheckFail, report.SPF.Status)
+		assert.Equal(t, "SPF record does not include amazonses.com", report.SPF.Message)
+		assert.Equal(t, domain.DeliverabilityCheckFail, report.DKIM.Status)
+		assert.Equal(t, "DKIM key for selector s1 is revoked", report.DKIM.Message)
+		assert.Equal(t, domain.DeliverabilityCheckFail, report.DMARC.Status)
+		assert.Equal(t, "No DMARC record found", report.DMARC.Message)
+		assert.False(t, report.Passed)
+	})
+
+	t.Run("unknown integration", func(t *testing.T) {
+		emailService := setup(t, stubTXTResolver{})
+
+		_, err := emailService.CheckDeliverability(ctx, domain.CheckDeliverabilityRequest{
+			WorkspaceID:   workspaceID,
+			IntegrationID: "missing",
+			DKIMSelector:  "s1",
+		})
+		require.Error(t, err)
+		assert.IsType(t, domain.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "integration missing not found in workspace")
+	})
+}
+
 func TestEmailService_SendEmail(t *testing.T) {
 	// Setup the controller
 	ctrl := gomock.NewController(t)
@@ -551,6 +808,81 @@ func TestEmailService_SendEmail(t *testing.T) {
 	})
 }
 
+func TestEmailService_SendEmail_AttachmentLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// No SendEmail expectation: the provider must never be called for rejected emails
+	mockSESService := mocks.NewMockEmailProviderService(ctrl)
+
+	emailService := EmailService{
+		sesService:       mockSESService,
+		attachmentLimits: domain.DefaultAttachmentLimits,
+	}
+	emailService.SetAttachmentLimits(domain.AttachmentLimits{MaxCount: 5, MaxTotalSize: 1024 * 1024})
+
+	provider := domain.EmailProvider{
+		Kind:    domain.EmailProviderKindSES,
+		Senders: []domain.EmailSender{{ID: "sender1", Email: "default@example.com", Name: "Default Sender"}},
+		SES:     &domain.AmazonSESSettings{Region: "us-east-1", AccessKey: "key", SecretKey: "secret"},
+	}
+	newRequest := func(attachments []domain.Attachment) domain.SendEmailProviderRequest {
+		return domain.SendEmailProviderRequest{
+			WorkspaceID:   "workspace-123",
+			IntegrationID: "integration-123",
+			MessageID:     uuid.New().String(),
+			FromAddress:   "sender@example.com",
+			FromName:      "Test Sender",
+			To:            "recipient@example.com",
+			Subject:       "Report",
+			Content:       "<html><body>See attached</body></html>",
+			Provider:      &provider,
+			EmailOptions:  domain.EmailOptions{Attachments: attachments},
+		}
+	}
+
+	t.Run("oversized combined attachments are rejected before sending", func(t *testing.T) {
+		// Each file is under the total limit, together they exceed it
+		content := base64.StdEncoding.EncodeToString(make([]byte, 600*1024))
+		request := newRequest([]domain.Attachment{
+			{Filename: "report-part1.pdf", Content: content},
+			{Filename: "report-part2.pdf", Content: content},
+		})
+
+		err := emailService.SendEmail(context.Background(), request, false)
+
+		require.Error(t, err)
+		var limitErr *domain.ErrAttachmentLimitExceeded
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, "total attachment size of 1228800 bytes (2 attachments) exceeds the maximum of 1048576 bytes per email", err.Error())
+	})
+
+	t.Run("too many attachments are rejected before sending", func(t *testing.T) {
+		content := base64.StdEncoding.EncodeToString([]byte("small"))
+		attachments := make([]domain.Attachment, 6)
+		for i := range attachments {
+			attachments[i] = domain.Attachment{Filename: "note.txt", Content: content}
+		}
+
+		err := emailService.SendEmail(context.Background(), newRequest(attachments), false)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "email has 6 attachments, exceeding the maximum of 5 per email")
+	})
+
+	t.Run("attachments within limits are sent", func(t *testing.T) {
+		content := base64.StdEncoding.EncodeToString(make([]byte, 300*1024))
+		request := newRequest([]domain.Attachment{
+			{Filename: "report-part1.pdf", Content: content},
+			{Filename: "report-part2.pdf", Content: content},
+		})
+
+		mockSESService.EXPECT().SendEmail(gomock.Any(), gomock.Any()).Return(nil)
+
+		require.NoError(t, emailService.SendEmail(context.Background(), request, false))
+	})
+}
+
 func TestEmailService_getProviderService(t *testing.T) {
 	// Setup the controller
 	ctrl := gomock.NewController(t)
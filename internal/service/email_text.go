@@ -0,0 +1,193 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// Elements whose content is not part of the readable text
+var htmlTextSkippedTags = map[string]bool{
+	"head":     true,
+	"script":   true,
+	"style":    true,
+	"title":    true,
+	"noscript": true,
+	"template": true,
+}
+
+// Elements rendered as their own paragraph, separated by a blank line
+var htmlTextParagraphTags = map[string]bool{
+	"p": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"table": true, "ul": true, "ol": true, "blockquote": true, "pre": true, "hr": true,
+}
+
+// Elements starting on a new line
+var htmlTextLineTags = map[string]bool{
+	"div": true, "tr": true, "li": true, "section": true, "article": true,
+	"header": true, "footer": true, "center": true, "address": true,
+}
+
+// htmlTextWriter accumulates the plain text rendering of an HTML document
+type htmlTextWriter struct {
+	b            strings.Builder
+	pendingSpace bool
+}
+
+func (w *htmlTextWriter) atLineStart() bool {
+	s := w.b.String()
+	return s == "" || strings.HasSuffix(s, "\n")
+}
+
+// writeText writes a text node with its whitespace collapsed, keeping a single space
+// at its edges so inline elements ("Hello <b>World</b>") don't run together
+func (w *htmlTextWriter) writeText(raw string) {
+	words := strings.Fields(raw)
+	if len(words) == 0 {
+		if raw != "" {
+			w.pendingSpace = true
+		}
+		return
+	}
+
+	first, _ := utf8.DecodeRuneInString(raw)
+	if (w.pendingSpace || unicode.IsSpace(first)) && !w.atLineStart() {
+		w.b.WriteByte(' ')
+	}
+	w.b.WriteString(strings.Join(words, " "))
+
+	last, _ := utf8.DecodeLastRuneInString(raw)
+	w.pendingSpace = unicode.IsSpace(last)
+}
+
+// breakLine ends the current line, followed by a blank line when paragraph is set
+func (w *htmlTextWriter) breakLine(paragraph bool) {
+	w.pendingSpace = false
+	if !w.atLineStart() {
+		w.b.WriteByte('\n')
+	}
+	if paragraph {
+		w.b.WriteByte('\n')
+	}
+}
+
+// String returns the text with trimmed lines and at most one blank line in a row
+func (w *htmlTextWriter) String() string {
+	lines := strings.Split(w.b.String(), "\n")
+	result := make([]string, 0, len(lines))
+	blank := false
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			blank = len(result) > 0
+			continue
+		}
+		if blank {
+			result = append(result, "")
+			blank = false
+		}
+		result = append(result, line)
+	}
+	return strings.Join(result, "\n")
+}
+
+// htmlToText renders the HTML body of an email as plain text for its text/plain alternative.
+// Head, script and style content is dropped, block elements and <br> start new lines, list
+// items are prefixed with "- " and links keep their URL next to the text ("Shop (https://...)").
+func htmlToText(htmlContent string) string {
+	w := &htmlTextWriter{}
+	tokenizer := html.NewTokenizer(strings.NewReader(htmlContent))
+
+	skipDepth := 0
+	href := ""
+	linkStart := 0
+
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			// io.EOF at the end of the document, the tokenizer doesn't fail on malformed HTML
+			return w.String()
+
+		case html.TextToken:
+			if skipDepth == 0 {
+				w.writeText(string(tokenizer.Text()))
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			tag := string(name)
+
+			if htmlTextSkippedTags[tag] {
+				if tokenType == html.StartTagToken {
+					skipDepth++
+				}
+				continue
+			}
+			if skipDepth > 0 {
+				continue
+			}
+
+			switch {
+			case tag == "br":
+				w.breakLine(false)
+			case tag == "li":
+				w.breakLine(false)
+				w.b.WriteString("- ")
+			case htmlTextParagraphTags[tag]:
+				w.breakLine(true)
+			case htmlTextLineTags[tag]:
+				w.breakLine(false)
+			case tag == "td" || tag == "th":
+				// Cells of a row are separated by a space
+				w.pendingSpace = true
+			case tag == "a":
+				href = ""
+				for hasAttr {
+					var key, val []byte
+					key, val, hasAttr = tokenizer.TagAttr()
+					if string(key) == "href" {
+						href = strings.TrimSpace(string(val))
+					}
+				}
+				linkStart = w.b.Len()
+			}
+
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+
+			if htmlTextSkippedTags[tag] {
+				if skipDepth > 0 {
+					skipDepth--
+				}
+				continue
+			}
+			if skipDepth > 0 {
+				continue
+			}
+
+			switch {
+			case htmlTextParagraphTags[tag]:
+				w.breakLine(true)
+			case htmlTextLineTags[tag]:
+				w.breakLine(false)
+			case tag == "a":
+				// Only web links are worth showing, anchors and mailto links are noise in text
+				if strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://") {
+					linkText := strings.TrimSpace(w.b.String()[linkStart:])
+					if linkText != href {
+						if !w.atLineStart() {
+							w.b.WriteByte(' ')
+						}
+						w.b.WriteString("(" + href + ")")
+						w.pendingSpace = false
+					}
+				}
+				href = ""
+			}
+		}
+	}
+}
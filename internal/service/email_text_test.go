@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{
+			name:     "empty",
+			html:     "",
+			expected: "",
+		},
+		{
+			name:     "plain text",
+			html:     "Hello",
+			expected: "Hello",
+		},
+		{
+			name:     "inline elements keep their spacing",
+			html:     "<p>Hello <b>dear</b> <i>friend</i>,\n   welcome</p>",
+			expected: "Hello dear friend, welcome",
+		},
+		{
+			name:     "paragraphs and headings are separated by a blank line",
+			html:     "<h1>Title</h1><p>First</p><p>Second</p>",
+			expected: "Title\n\nFirst\n\nSecond",
+		},
+		{
+			name:     "line breaks and divs start new lines",
+			html:     "<div>One</div><div>Two<br>Three</div>",
+			expected: "One\nTwo\nThree",
+		},
+		{
+			name:     "head, style and script are dropped",
+			html:     "<html><head><title>Subject</title><style>p { color: red; }</style></head><body><script>alert(1)</script><p>Body</p></body></html>",
+			expected: "Body",
+		},
+		{
+			name:     "list items",
+			html:     "<ul><li>First</li><li>Second</li></ul>",
+			expected: "- First\n- Second",
+		},
+		{
+			name:     "links keep their URL",
+			html:     `<p>Read <a href="https://example.com/post">the post</a> now</p>`,
+			expected: "Read the post (https://example.com/post) now",
+		},
+		{
+			name:     "links showing their URL are not repeated",
+			html:     `<a href="https://example.com">https://example.com</a>`,
+			expected: "https://example.com",
+		},
+		{
+			name:     "anchors and mailto links are not shown",
+			html:     `<a href="#top">Top</a> <a href="mailto:hi@example.com">Contact</a>`,
+			expected: "Top Contact",
+		},
+		{
+			name:     "entities are decoded",
+			html:     "<p>Caf&eacute; &amp; cr&egrave;me&nbsp;br&ucirc;l&eacute;e</p>",
+			expected: "Café & crème brûlée",
+		},
+		{
+			name:     "table cells of a layout",
+			html:     "<table><tr><td>Left</td><td>Right</td></tr><tr><td>Footer</td></tr></table>",
+			expected: "Left Right\nFooter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, htmlToText(tt.html))
+		})
+	}
+}
@@ -762,10 +762,15 @@ func (s *MailgunService) sendEmailSimple(ctx context.Context, apiURL string, req
 
 	// Add RFC-8058 List-Unsubscribe headers for one-click unsubscribe
 	if request.EmailOptions.ListUnsubscribeURL != "" {
-		form.Add("h:List-Unsubscribe", fmt.Sprintf("<%s>", request.EmailOptions.ListUnsubscribeURL))
+		form.Add("h:List-Unsubscribe", request.EmailOptions.ListUnsubscribeHeader())
 		form.Add("h:List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
 	}
 
+	// Mark marketing emails as bulk so auto-responders don't answer them
+	for _, header := range request.EmailOptions.BulkHeaderFields() {
+		form.Add("h:"+header.Name, header.Value)
+	}
+
 	// Add messageID as a custom variable for tracking
 	form.Add("v:notifuse_message_id", request.MessageID)
 
@@ -844,7 +849,7 @@ func (s *MailgunService) sendEmailWithAttachments(ctx context.Context, apiURL st
 
 	// Add RFC-8058 List-Unsubscribe headers for one-click unsubscribe
 	if request.EmailOptions.ListUnsubscribeURL != "" {
-		if err := writer.WriteField("h:List-Unsubscribe", fmt.Sprintf("<%s>", request.EmailOptions.ListUnsubscribeURL)); err != nil {
+		if err := writer.WriteField("h:List-Unsubscribe", request.EmailOptions.ListUnsubscribeHeader()); err != nil {
 			return fmt.Errorf("failed to write list-unsubscribe field: %w", err)
 		}
 		if err := writer.WriteField("h:List-Unsubscribe-Post", "List-Unsubscribe=One-Click"); err != nil {
@@ -852,6 +857,13 @@ func (s *MailgunService) sendEmailWithAttachments(ctx context.Context, apiURL st
 		}
 	}
 
+	// Mark marketing emails as bulk so auto-responders don't answer them
+	for _, header := range request.EmailOptions.BulkHeaderFields() {
+		if err := writer.WriteField("h:"+header.Name, header.Value); err != nil {
+			return fmt.Errorf("failed to write %s header field: %w", header.Name, err)
+		}
+	}
+
 	// Add messageID as a custom variable for tracking
 	if err := writer.WriteField("v:notifuse_message_id", request.MessageID); err != nil {
 		return fmt.Errorf("failed to write message id field: %w", err)
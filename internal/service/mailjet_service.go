@@ -567,10 +567,15 @@ func (s *MailjetService) SendEmail(ctx context.Context, request domain.SendEmail
 
 	// Add RFC-8058 List-Unsubscribe headers for one-click unsubscribe
 	if request.EmailOptions.ListUnsubscribeURL != "" {
-		message.Headers["List-Unsubscribe"] = fmt.Sprintf("<%s>", request.EmailOptions.ListUnsubscribeURL)
+		message.Headers["List-Unsubscribe"] = request.EmailOptions.ListUnsubscribeHeader()
 		message.Headers["List-Unsubscribe-Post"] = "List-Unsubscribe=One-Click"
 	}
 
+	// Mark marketing emails as bulk so auto-responders don't answer them
+	for _, header := range request.EmailOptions.BulkHeaderFields() {
+		message.Headers[header.Name] = header.Value
+	}
+
 	// Add attachments if specified
 	// Mailjet uses separate arrays for regular attachments and inline images
 	// https://dev.mailjet.com/email/guides/send-api-v31/#send-with-attached-files
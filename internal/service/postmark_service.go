@@ -588,11 +588,20 @@ func (s *PostmarkService) SendEmail(ctx context.Context, request domain.SendEmai
 	}
 
 	// Add RFC-8058 List-Unsubscribe headers for one-click unsubscribe
+	var headers []map[string]string
 	if request.EmailOptions.ListUnsubscribeURL != "" {
-		requestBody["Headers"] = []map[string]string{
-			{"Name": "List-Unsubscribe-Post", "Value": "List-Unsubscribe=One-Click"},
-			{"Name": "List-Unsubscribe", "Value": fmt.Sprintf("<%s>", request.EmailOptions.ListUnsubscribeURL)},
-		}
+		headers = append(headers,
+			map[string]string{"Name": "List-Unsubscribe-Post", "Value": "List-Unsubscribe=One-Click"},
+			map[string]string{"Name": "List-Unsubscribe", "Value": request.EmailOptions.ListUnsubscribeHeader()},
+		)
+	}
+
+	// Mark marketing emails as bulk so auto-responders don't answer them
+	for _, header := range request.EmailOptions.BulkHeaderFields() {
+		headers = append(headers, map[string]string{"Name": header.Name, "Value": header.Value})
+	}
+	if len(headers) > 0 {
+		requestBody["Headers"] = headers
 	}
 
 	// Add attachments if specified
@@ -2336,6 +2336,62 @@ func TestPostmarkService_SendEmail(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("with List-Unsubscribe URL and mailto", func(t *testing.T) {
+		// Setup
+		service, httpClient, _, _ := setupPostmarkTest(t)
+
+		providerConfig := &domain.EmailProvider{
+			Kind: domain.EmailProviderKindPostmark,
+			Postmark: &domain.PostmarkSettings{
+				ServerToken: "test-server-token",
+			},
+		}
+
+		httpClient.EXPECT().
+			Do(gomock.Any()).
+			DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				body, _ := io.ReadAll(req.Body)
+				var requestBody map[string]interface{}
+				err := json.Unmarshal(body, &requestBody)
+				require.NoError(t, err)
+
+				headers, ok := requestBody["Headers"].([]interface{})
+				require.True(t, ok, "Headers should be present")
+
+				var listUnsubscribe string
+				for _, h := range headers {
+					header := h.(map[string]interface{})
+					if header["Name"].(string) == "List-Unsubscribe" {
+						listUnsubscribe = header["Value"].(string)
+					}
+				}
+				assert.Contains(t, listUnsubscribe, "<https://example.com/unsubscribe/abc123>")
+				assert.Contains(t, listUnsubscribe, "<mailto:unsubscribe@example.com?subject=unsubscribe&body=")
+				assert.Equal(t, 2, len(strings.Split(listUnsubscribe, ", ")), "header should list both URIs")
+
+				return createMockResponse(http.StatusOK, `{"MessageID":"12345"}`), nil
+			})
+
+		request := domain.SendEmailProviderRequest{
+			WorkspaceID:   "workspace-123",
+			IntegrationID: "test-integration-id",
+			MessageID:     "test-message-id",
+			FromAddress:   "sender@example.com",
+			FromName:      "Sender",
+			To:            "recipient@example.com",
+			Subject:       "Subject",
+			Content:       "Content",
+			Provider:      providerConfig,
+			EmailOptions: domain.EmailOptions{
+				ListUnsubscribeURL:    "https://example.com/unsubscribe/abc123",
+				ListUnsubscribeMailto: domain.BuildListUnsubscribeMailto("unsubscribe@example.com", "https://example.com/unsubscribe/abc123"),
+			},
+		}
+		err := service.SendEmail(context.Background(), request)
+
+		assert.NoError(t, err)
+	})
+
 	t.Run("with RFC-8058 List-Unsubscribe headers and attachments", func(t *testing.T) {
 		// Setup
 		service, httpClient, _, mockLogger := setupPostmarkTest(t)
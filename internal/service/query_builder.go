@@ -14,6 +14,12 @@ type QueryBuilder struct {
 	allowedOperators map[string]sqlOperator
 }
 
+// engagementScoreExpr evaluates a contact's stored engagement score decayed to the current time
+var engagementScoreExpr = fmt.Sprintf(
+	"COALESCE(engagement_score * POWER(0.5::float8, (EXTRACT(EPOCH FROM (NOW() - engagement_score_at)) / 86400 / %d)::float8), 0)",
+	domain.EngagementScoreHalfLifeDays,
+)
+
 // fieldConfig defines metadata for a field
 type fieldConfig struct {
 	dbColumn  string
@@ -70,6 +76,12 @@ func (qb *QueryBuilder) initializeContactFields() {
 		}
 	}
 
+	// Engagement score, decayed from its last update to the current time
+	qb.allowedFields[domain.ContactFieldEngagementScore] = fieldConfig{
+		dbColumn:  engagementScoreExpr,
+		fieldType: "number",
+	}
+
 	// Time fields
 	timeFields := []string{
 		"created_at", "updated_at",
@@ -283,6 +295,11 @@ func (qb *QueryBuilder) parseFilter(filter *domain.DimensionFilter, argIndex int
 		return "", nil, argIndex, fmt.Errorf("filter cannot be nil")
 	}
 
+	// Route computed fields (e.g. days_since(created_at)) to specialized handler
+	if fn, arg, ok := domain.ParseComputedField(filter.FieldName); ok {
+		return qb.buildComputedCondition(fn, arg, filter, argIndex)
+	}
+
 	// Validate field exists in whitelist
 	fieldCfg, ok := qb.allowedFields[filter.FieldName]
 	if !ok {
@@ -342,6 +359,39 @@ func (qb *QueryBuilder) parseFilter(filter *domain.DimensionFilter, argIndex int
 	return qb.buildCondition(fieldCfg.dbColumn, filter.Operator, sqlOp, values, argIndex)
 }
 
+// buildComputedCondition builds SQL conditions for computed fields.
+// days_since(field) evaluates to the number of whole days elapsed since a time field
+// and is compared with the standard numeric operators.
+func (qb *QueryBuilder) buildComputedCondition(fn, arg string, filter *domain.DimensionFilter, argIndex int) (string, []interface{}, int, error) {
+	if fn != domain.ComputedFieldDaysSince {
+		return "", nil, argIndex, fmt.Errorf("unsupported computed field function: %s", fn)
+	}
+
+	// The referenced field must be a whitelisted time field
+	fieldCfg, ok := qb.allowedFields[arg]
+	if !ok {
+		return "", nil, argIndex, fmt.Errorf("invalid field name: %s", arg)
+	}
+	if fieldCfg.fieldType != "time" {
+		return "", nil, argIndex, fmt.Errorf("%s requires a time field, got %s (%s)", fn, arg, fieldCfg.fieldType)
+	}
+
+	expr := fmt.Sprintf("FLOOR(EXTRACT(EPOCH FROM (NOW() - %s)) / 86400)", fieldCfg.dbColumn)
+
+	switch filter.Operator {
+	case "equals", "not_equals", "gt", "gte", "lt", "lte":
+		values, err := qb.getNumberValues(filter)
+		if err != nil {
+			return "", nil, argIndex, err
+		}
+		return qb.buildCondition(expr, filter.Operator, qb.allowedOperators[filter.Operator], values, argIndex)
+	case "is_set", "is_not_set":
+		return fmt.Sprintf("%s %s", fieldCfg.dbColumn, qb.allowedOperators[filter.Operator].sql), nil, argIndex, nil
+	default:
+		return "", nil, argIndex, fmt.Errorf("operator %s is not supported for computed field %s", filter.Operator, filter.FieldName)
+	}
+}
+
 // getStringValues extracts string values from filter
 func (qb *QueryBuilder) getStringValues(filter *domain.DimensionFilter) ([]interface{}, error) {
 	if len(filter.StringValues) == 0 {
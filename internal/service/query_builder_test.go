@@ -1432,6 +1432,122 @@ func TestQueryBuilder_ContactTimeline(t *testing.T) {
 	})
 }
 
+func TestQueryBuilder_BuildSQL_ComputedFields(t *testing.T) {
+	qb := NewQueryBuilder()
+
+	computedLeaf := func(filter *domain.DimensionFilter) *domain.TreeNode {
+		return &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source:  "contacts",
+				Contact: &domain.ContactCondition{Filters: []*domain.DimensionFilter{filter}},
+			},
+		}
+	}
+
+	t.Run("days_since gt condition", func(t *testing.T) {
+		sql, args, err := qb.BuildSQL(computedLeaf(&domain.DimensionFilter{
+			FieldName:    "days_since(created_at)",
+			FieldType:    "number",
+			Operator:     "gt",
+			NumberValues: []float64{30},
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, sql, "FLOOR(EXTRACT(EPOCH FROM (NOW() - created_at)) / 86400) > $1")
+		assert.Equal(t, []interface{}{float64(30)}, args)
+	})
+
+	t.Run("engagement_score gte condition decays to now", func(t *testing.T) {
+		sql, args, err := qb.BuildSQL(computedLeaf(&domain.DimensionFilter{
+			FieldName:    domain.ContactFieldEngagementScore,
+			FieldType:    "number",
+			Operator:     "gte",
+			NumberValues: []float64{5},
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, sql, "COALESCE(engagement_score * POWER(0.5::float8, (EXTRACT(EPOCH FROM (NOW() - engagement_score_at)) / 86400 / 30)::float8), 0) >= $1")
+		assert.Equal(t, []interface{}{float64(5)}, args)
+	})
+
+	t.Run("days_since on custom datetime field", func(t *testing.T) {
+		sql, _, err := qb.BuildSQL(computedLeaf(&domain.DimensionFilter{
+			FieldName:    "days_since(custom_datetime_1)",
+			FieldType:    "number",
+			Operator:     "lte",
+			NumberValues: []float64{7},
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, sql, "FLOOR(EXTRACT(EPOCH FROM (NOW() - custom_datetime_1)) / 86400) <= $1")
+	})
+
+	t.Run("days_since is_set checks underlying field", func(t *testing.T) {
+		sql, args, err := qb.BuildSQL(computedLeaf(&domain.DimensionFilter{
+			FieldName: "days_since(custom_datetime_2)",
+			FieldType: "number",
+			Operator:  "is_set",
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, sql, "custom_datetime_2 IS NOT NULL")
+		assert.Empty(t, args)
+	})
+
+	t.Run("days_since in trigger condition", func(t *testing.T) {
+		sql, args, err := qb.BuildTriggerCondition(computedLeaf(&domain.DimensionFilter{
+			FieldName:    "days_since(created_at)",
+			FieldType:    "number",
+			Operator:     "gte",
+			NumberValues: []float64{30},
+		}), "NEW.email")
+		require.NoError(t, err)
+		assert.Contains(t, sql, "FLOOR(EXTRACT(EPOCH FROM (NOW() - created_at)) / 86400) >= $1")
+		assert.Len(t, args, 1)
+	})
+
+	t.Run("rejects non-time field", func(t *testing.T) {
+		_, _, err := qb.BuildSQL(computedLeaf(&domain.DimensionFilter{
+			FieldName:    "days_since(email)",
+			FieldType:    "number",
+			Operator:     "gt",
+			NumberValues: []float64{30},
+		}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requires a time field")
+	})
+
+	t.Run("rejects unknown field", func(t *testing.T) {
+		_, _, err := qb.BuildSQL(computedLeaf(&domain.DimensionFilter{
+			FieldName:    "days_since(created_at; DROP TABLE contacts)",
+			FieldType:    "number",
+			Operator:     "gt",
+			NumberValues: []float64{30},
+		}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid field name")
+	})
+
+	t.Run("rejects unknown function", func(t *testing.T) {
+		_, _, err := qb.BuildSQL(computedLeaf(&domain.DimensionFilter{
+			FieldName:    "age_years(created_at)",
+			FieldType:    "number",
+			Operator:     "gt",
+			NumberValues: []float64{1},
+		}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported computed field function")
+	})
+
+	t.Run("rejects string operators", func(t *testing.T) {
+		_, _, err := qb.BuildSQL(computedLeaf(&domain.DimensionFilter{
+			FieldName:    "days_since(created_at)",
+			FieldType:    "number",
+			Operator:     "contains",
+			NumberValues: []float64{3},
+		}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not supported for computed field")
+	})
+}
+
 func TestQueryBuilder_BuildSQL_JSONFiltering(t *testing.T) {
 	qb := NewQueryBuilder()
 
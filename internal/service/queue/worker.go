@@ -2,7 +2,9 @@ package queue
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -17,6 +19,12 @@ type EmailQueueWorkerConfig struct {
 	PollInterval time.Duration // How often to poll for new work (default: 1s)
 	BatchSize    int           // How many emails to fetch per poll (default: 50)
 	MaxRetries   int           // Max retry attempts before permanent failure (default: 3)
+	SendJitter   time.Duration // Max random delay added before each send, 0 disables (default: 0)
+
+	// CommitInterval is how many sent emails are removed from the queue together (default: 1).
+	// Larger values save round trips on high-volume deployments; sent emails not yet removed
+	// when the worker crashes are picked up again as stuck entries.
+	CommitInterval int
 
 	// Circuit breaker settings
 	CircuitBreakerThreshold int           // Provider errors before opening circuit (default: 5)
@@ -30,6 +38,7 @@ func DefaultWorkerConfig() *EmailQueueWorkerConfig {
 		PollInterval:            1 * time.Second,
 		BatchSize:               50,
 		MaxRetries:              3,
+		CommitInterval:          1,
 		CircuitBreakerThreshold: 5,
 		CircuitBreakerCooldown:  getCircuitBreakerCooldown(),
 	}
@@ -60,6 +69,10 @@ type EmailQueueWorker struct {
 	running bool
 	mu      sync.RWMutex
 
+	// Sent entries waiting to be removed from the queue, by workspace (see CommitInterval)
+	sentEntries map[string][]string
+	sentMu      sync.Mutex
+
 	// Callbacks for progress tracking
 	onEmailSent   EmailSentCallback
 	onEmailFailed EmailFailedCallback
@@ -100,6 +113,7 @@ func NewEmailQueueWorker(
 		errorClassifier:    emailerror.NewClassifier(),
 		config:             config,
 		logger:             log,
+		sentEntries:        make(map[string][]string),
 	}
 }
 
@@ -121,9 +135,10 @@ func (w *EmailQueueWorker) Start(ctx context.Context) error {
 	w.mu.Unlock()
 
 	w.logger.WithFields(map[string]interface{}{
-		"worker_count":  w.config.WorkerCount,
-		"poll_interval": w.config.PollInterval.String(),
-		"batch_size":    w.config.BatchSize,
+		"worker_count":    w.config.WorkerCount,
+		"poll_interval":   w.config.PollInterval.String(),
+		"batch_size":      w.config.BatchSize,
+		"commit_interval": w.config.CommitInterval,
 	}).Info("Starting email queue worker")
 
 	// Start the main processing loop
@@ -239,6 +254,9 @@ func (w *EmailQueueWorker) processWorkspace(workspace *domain.Workspace) {
 		"count":        len(entries),
 	}).Debug("Processing queued emails")
 
+	// Remove the sent entries of an incomplete group once the batch is done
+	defer w.commitSentEntries(workspace.ID)
+
 	// Process each entry
 	for _, entry := range entries {
 		select {
@@ -310,20 +328,23 @@ func (w *EmailQueueWorker) processEntry(workspace *domain.Workspace, entry *doma
 		return
 	}
 
-	// Build the send request
-	request := entry.Payload.ToSendEmailProviderRequest(
-		workspace.ID,
-		entry.IntegrationID,
-		entry.MessageID,
-		entry.ContactEmail,
-		&integration.EmailProvider,
-	)
+	// Random pause so sends don't go out in a perfectly uniform burst
+	if delay := w.sendJitterDelay(); delay > 0 {
+		select {
+		case <-w.ctx.Done():
+			w.logger.WithFields(map[string]interface{}{
+				"entry_id": entry.ID,
+			}).Debug("Send jitter wait cancelled")
+			return
+		case <-time.After(delay):
+		}
+	}
 
 	// Send the email
-	err := w.emailService.SendEmail(w.ctx, *request, true) // isMarketing = true
+	sentVia, err := w.sendEmail(w.ctx, workspace, integration, entry, buildSendRequest(workspace, integration, entry))
 	if err != nil {
 		// Classify the error
-		classifiedErr := w.errorClassifier.Classify(err, integration.EmailProvider.Kind)
+		classifiedErr := w.errorClassifier.Classify(err, sentVia.EmailProvider.Kind)
 
 		// Log the classification for debugging
 		w.logger.WithFields(map[string]interface{}{
@@ -336,17 +357,17 @@ func (w *EmailQueueWorker) processEntry(workspace *domain.Workspace, entry *doma
 		}).Debug("Classified send error")
 
 		// Record failure to circuit breaker (only counts provider errors)
-		w.circuitBreaker.RecordFailure(entry.IntegrationID, classifiedErr)
+		w.circuitBreaker.RecordFailure(sentVia.ID, classifiedErr)
 
 		w.handleError(workspace, entry, err, classifiedErr)
 		return
 	}
 
 	// Record success to reset circuit breaker
-	w.circuitBreaker.RecordSuccess(entry.IntegrationID)
+	w.circuitBreaker.RecordSuccess(sentVia.ID)
 
 	// Mark as sent
-	if err := w.queueRepo.MarkAsSent(w.ctx, workspace.ID, entry.ID); err != nil {
+	if err := w.markAsSent(workspace.ID, entry.ID); err != nil {
 		w.logger.WithFields(map[string]interface{}{
 			"entry_id": entry.ID,
 			"error":    err.Error(),
@@ -372,14 +393,146 @@ func (w *EmailQueueWorker) processEntry(workspace *domain.Workspace, entry *doma
 	}
 }
 
+// markAsSent removes a sent entry from the queue. With a CommitInterval above 1 the entry
+// is removed with the next group of sent entries instead, and errors are logged on commit.
+func (w *EmailQueueWorker) markAsSent(workspaceID string, entryID string) error {
+	if w.config.CommitInterval <= 1 {
+		return w.queueRepo.MarkAsSent(w.ctx, workspaceID, entryID)
+	}
+
+	w.sentMu.Lock()
+	w.sentEntries[workspaceID] = append(w.sentEntries[workspaceID], entryID)
+	full := len(w.sentEntries[workspaceID]) >= w.config.CommitInterval
+	w.sentMu.Unlock()
+
+	if full {
+		w.commitSentEntries(workspaceID)
+	}
+	return nil
+}
+
+// commitSentEntries removes the workspace's pending sent entries from the queue
+func (w *EmailQueueWorker) commitSentEntries(workspaceID string) {
+	w.sentMu.Lock()
+	ids := w.sentEntries[workspaceID]
+	delete(w.sentEntries, workspaceID)
+	w.sentMu.Unlock()
+
+	if len(ids) == 0 {
+		return
+	}
+
+	// Still commit the sends already made when the worker is stopping
+	if err := w.queueRepo.MarkAsSentBatch(context.WithoutCancel(w.ctx), workspaceID, ids); err != nil {
+		w.logger.WithFields(map[string]interface{}{
+			"workspace_id": workspaceID,
+			"count":        len(ids),
+			"error":        err.Error(),
+		}).Error("Failed to mark emails as sent")
+	}
+}
+
+// ErrNotSent marks a SendNow failure that happened before the email was handed to the provider
+var ErrNotSent = errors.New("email not sent")
+
+// SendNow sends an entry right away instead of enqueueing it, for emails that must not wait for
+// the next queue poll (automation nodes with immediate delivery). The send goes through the
+// integration's rate limiter, circuit breaker and failover like queued sends; the caller records
+// the message history. Errors wrapping ErrNotSent mean the provider was never called.
+func (w *EmailQueueWorker) SendNow(ctx context.Context, workspace *domain.Workspace, entry *domain.EmailQueueEntry) error {
+	integration := workspace.GetIntegrationByID(entry.IntegrationID)
+	if integration == nil {
+		return fmt.Errorf("%w: integration not found: %s", ErrNotSent, entry.IntegrationID)
+	}
+
+	if w.circuitBreaker.IsOpen(integration.ID) {
+		return fmt.Errorf("%w: circuit breaker open for integration %s", ErrNotSent, integration.ID)
+	}
+
+	ratePerMinute := integration.EmailProvider.RateLimitPerMinute
+	if ratePerMinute <= 0 {
+		ratePerMinute = 60 // Default to 1 per second if not configured
+	}
+	if err := w.rateLimiter.Wait(ctx, integration.ID, ratePerMinute); err != nil {
+		return fmt.Errorf("%w: rate limit wait: %w", ErrNotSent, err)
+	}
+
+	sentVia, err := w.sendEmail(ctx, workspace, integration, entry, buildSendRequest(workspace, integration, entry))
+	if err != nil {
+		w.circuitBreaker.RecordFailure(sentVia.ID, w.errorClassifier.Classify(err, sentVia.EmailProvider.Kind))
+		return err
+	}
+
+	w.circuitBreaker.RecordSuccess(sentVia.ID)
+	return nil
+}
+
+// buildSendRequest builds the provider request of an entry with the workspace's current settings
+func buildSendRequest(workspace *domain.Workspace, integration *domain.Integration, entry *domain.EmailQueueEntry) *domain.SendEmailProviderRequest {
+	request := entry.Payload.ToSendEmailProviderRequest(
+		workspace.ID,
+		entry.IntegrationID,
+		entry.MessageID,
+		entry.ContactEmail,
+		&integration.EmailProvider,
+	)
+
+	// Advertise the workspace unsubscribe inbox next to the one-click URL (broadcasts are enqueued without it)
+	if request.EmailOptions.ListUnsubscribeURL != "" && request.EmailOptions.ListUnsubscribeMailto == "" {
+		request.EmailOptions.ListUnsubscribeMailto = domain.BuildListUnsubscribeMailto(
+			workspace.Settings.ListUnsubscribeMailto,
+			request.EmailOptions.ListUnsubscribeURL,
+		)
+	}
+
+	// Mark marketing emails as bulk so auto-responders don't answer them
+	request.EmailOptions.BulkHeaders = entry.IsMarketing() && workspace.Settings.BulkHeadersEnabled()
+
+	return request
+}
+
+// sendEmail sends the request through the entry's integration. When the primary SMTP server
+// cannot be reached or rejects authentication, the send is retried once through the
+// integration's failover. It returns the integration the last attempt went through.
+func (w *EmailQueueWorker) sendEmail(ctx context.Context, workspace *domain.Workspace, integration *domain.Integration, entry *domain.EmailQueueEntry, request *domain.SendEmailProviderRequest) (*domain.Integration, error) {
+	isMarketing := entry.IsMarketing()
+
+	err := w.emailService.SendEmail(ctx, *request, isMarketing)
+	if err == nil || !domain.IsSMTPSessionError(err) {
+		return integration, err
+	}
+
+	failover := workspace.GetFailoverIntegration(integration)
+	if failover == nil || w.circuitBreaker.IsOpen(failover.ID) {
+		return integration, err
+	}
+
+	// The primary is down: count it against its circuit breaker, then use the failover for this send
+	w.circuitBreaker.RecordFailure(integration.ID, w.errorClassifier.Classify(err, integration.EmailProvider.Kind))
+
+	w.logger.WithFields(map[string]interface{}{
+		"entry_id":                entry.ID,
+		"integration_id":          integration.ID,
+		"failover_integration_id": failover.ID,
+		"error":                   err.Error(),
+	}).Warn("Primary SMTP integration unavailable, sending via failover integration")
+
+	failoverRequest := *request
+	failoverRequest.IntegrationID = failover.ID
+	failoverRequest.Provider = &failover.EmailProvider
+
+	return failover, w.emailService.SendEmail(ctx, failoverRequest, isMarketing)
+}
+
 // handleError handles a send error, scheduling retry or deleting permanently failed entries
 // classifiedErr may be nil for internal errors (e.g., integration not found)
 func (w *EmailQueueWorker) handleError(workspace *domain.Workspace, entry *domain.EmailQueueEntry, sendErr error, classifiedErr *emailerror.ClassifiedError) {
 	entry.Attempts++ // Increment since MarkAsProcessing already did this
 
-	// Determine if this is a permanent failure (non-retryable recipient error or max attempts)
+	// Determine if this is a permanent failure (non-retryable error, rejected message or max attempts)
+	// A message the provider rejected fails the same way on every attempt
 	isPermanent := entry.Attempts >= entry.MaxAttempts
-	if classifiedErr != nil && !classifiedErr.Retryable {
+	if classifiedErr != nil && (!classifiedErr.Retryable || classifiedErr.Category == emailerror.ErrorCategoryRejected) {
 		isPermanent = true
 	}
 
@@ -394,6 +547,7 @@ func (w *EmailQueueWorker) handleError(workspace *domain.Workspace, entry *domai
 	}
 	if classifiedErr != nil {
 		logFields["error_type"] = classifiedErr.Type
+		logFields["error_category"] = classifiedErr.Category
 	}
 	w.logger.WithFields(logFields).Warn("Failed to send email")
 
@@ -448,6 +602,11 @@ func (w *EmailQueueWorker) upsertMessageHistory(
 	entry *domain.EmailQueueEntry,
 	sendErr error,
 ) {
+	// Seed inbox copies are not recorded, keeping them out of the broadcast stats
+	if entry.Payload.Seed {
+		return
+	}
+
 	now := time.Now().UTC()
 
 	message := &domain.MessageHistory{
@@ -462,14 +621,18 @@ func (w *EmailQueueWorker) upsertMessageHistory(
 		UpdatedAt:       now,
 	}
 
-	// Set source (broadcast or automation)
-	if entry.SourceType == domain.EmailQueueSourceBroadcast {
+	// Set source (broadcast, automation or transactional)
+	switch entry.SourceType {
+	case domain.EmailQueueSourceBroadcast:
 		message.BroadcastID = &entry.SourceID
 		if entry.Payload.ListID != "" {
 			message.ListID = &entry.Payload.ListID
 		}
-	} else if entry.SourceType == domain.EmailQueueSourceAutomation {
+	case domain.EmailQueueSourceAutomation:
 		message.AutomationID = &entry.SourceID
+	case domain.EmailQueueSourceTransactional:
+		// One-off template sends have no transactional notification to link, the source is kept in the metadata
+		message.MessageData.Metadata = map[string]interface{}{"source": string(entry.SourceType)}
 	}
 
 	// Set failure info if send failed (will be cleared on retry success via UPSERT)
@@ -508,6 +671,14 @@ func (w *EmailQueueWorker) GetCircuitBreakerStats() map[string]CircuitBreakerSta
 	return w.circuitBreaker.GetStats()
 }
 
+// sendJitterDelay returns a random delay in [0, SendJitter), or 0 when jitter is disabled
+func (w *EmailQueueWorker) sendJitterDelay() time.Duration {
+	if w.config.SendJitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(w.config.SendJitter)))
+}
+
 // getMinEmailRateLimit returns the minimum rate limit across all email integrations
 // Returns default of 60 if no email integrations found
 func (w *EmailQueueWorker) getMinEmailRateLimit(workspace *domain.Workspace) int {
@@ -3,6 +3,9 @@ package queue
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
+	"strconv"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -23,6 +26,7 @@ func TestDefaultWorkerConfig(t *testing.T) {
 	assert.Equal(t, 1*time.Second, config.PollInterval)
 	assert.Equal(t, 50, config.BatchSize)
 	assert.Equal(t, 3, config.MaxRetries)
+	assert.Equal(t, 1, config.CommitInterval)
 }
 
 func TestNewEmailQueueWorker(t *testing.T) {
@@ -315,6 +319,249 @@ func TestEmailQueueWorker_ProcessEntry_Success(t *testing.T) {
 	worker.processEntry(workspace, entry)
 }
 
+func TestEmailQueueWorker_ProcessEntry_AddsListUnsubscribeMailto(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+	mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	integrationID := "integration-1"
+	workspaceID := "workspace-1"
+	unsubscribeURL := "https://api.example.com/unsubscribe-oneclick?lids=newsletter&wid=workspace-1"
+
+	workspace := &domain.Workspace{
+		ID: workspaceID,
+		Settings: domain.WorkspaceSettings{
+			ListUnsubscribeMailto: "unsubscribe@example.com",
+		},
+		Integrations: []domain.Integration{
+			{
+				ID: integrationID,
+				EmailProvider: domain.EmailProvider{
+					Kind:               domain.EmailProviderKindSMTP,
+					RateLimitPerMinute: 100,
+				},
+			},
+		},
+	}
+
+	entry := &domain.EmailQueueEntry{
+		ID:            "entry-1",
+		Status:        domain.EmailQueueStatusPending,
+		SourceType:    domain.EmailQueueSourceBroadcast,
+		SourceID:      "broadcast-1",
+		IntegrationID: integrationID,
+		ContactEmail:  "test@example.com",
+		MessageID:     "msg-1",
+		Payload: domain.EmailQueuePayload{
+			FromAddress:        "sender@example.com",
+			Subject:            "Test Subject",
+			HTMLContent:        "<p>Hello</p>",
+			RateLimitPerMinute: 100,
+			EmailOptions: domain.EmailOptions{
+				ListUnsubscribeURL: unsubscribeURL,
+			},
+		},
+		MaxAttempts: 3,
+	}
+
+	mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), workspaceID, entry.ID).Return(nil)
+	mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).
+		DoAndReturn(func(ctx context.Context, request domain.SendEmailProviderRequest, isMarketing bool) error {
+			assert.Equal(t, unsubscribeURL, request.EmailOptions.ListUnsubscribeURL)
+			assert.Equal(t,
+				domain.BuildListUnsubscribeMailto("unsubscribe@example.com", unsubscribeURL),
+				request.EmailOptions.ListUnsubscribeMailto)
+			return nil
+		})
+	mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).Return(nil)
+	mockQueueRepo.EXPECT().MarkAsSent(gomock.Any(), workspaceID, entry.ID).Return(nil)
+
+	worker := NewEmailQueueWorker(
+		mockQueueRepo,
+		mockWorkspaceRepo,
+		mockEmailService,
+		mockMessageHistoryRepo,
+		DefaultWorkerConfig(),
+		mockLogger,
+	)
+	worker.ctx = context.Background()
+
+	worker.processEntry(workspace, entry)
+}
+
+func TestEmailQueueWorker_ProcessEntry_BulkHeaders(t *testing.T) {
+	tests := []struct {
+		name               string
+		sourceType         domain.EmailQueueSourceType
+		transactional      bool
+		disableBulkHeaders bool
+		wantBulkHeaders    bool
+	}{
+		{name: "broadcast", sourceType: domain.EmailQueueSourceBroadcast, wantBulkHeaders: true},
+		{name: "automation", sourceType: domain.EmailQueueSourceAutomation, wantBulkHeaders: true},
+		{name: "transactional", sourceType: domain.EmailQueueSourceTransactional, wantBulkHeaders: false},
+		{name: "automation transactional_email node", sourceType: domain.EmailQueueSourceAutomation, transactional: true, wantBulkHeaders: false},
+		{name: "broadcast in a workspace without bulk headers", sourceType: domain.EmailQueueSourceBroadcast, disableBulkHeaders: true, wantBulkHeaders: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+			mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+			mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+			mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+			mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+			mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+			mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+			integrationID := "integration-1"
+			workspaceID := "workspace-1"
+
+			workspace := &domain.Workspace{
+				ID: workspaceID,
+				Settings: domain.WorkspaceSettings{
+					DisableBulkHeaders: tt.disableBulkHeaders,
+				},
+				Integrations: []domain.Integration{
+					{
+						ID: integrationID,
+						EmailProvider: domain.EmailProvider{
+							Kind:               domain.EmailProviderKindSMTP,
+							RateLimitPerMinute: 100,
+						},
+					},
+				},
+			}
+
+			entry := &domain.EmailQueueEntry{
+				ID:            "entry-1",
+				Status:        domain.EmailQueueStatusPending,
+				SourceType:    tt.sourceType,
+				SourceID:      "source-1",
+				IntegrationID: integrationID,
+				ContactEmail:  "test@example.com",
+				MessageID:     "msg-1",
+				Payload: domain.EmailQueuePayload{
+					FromAddress:        "sender@example.com",
+					Subject:            "Test Subject",
+					HTMLContent:        "<p>Hello</p>",
+					RateLimitPerMinute: 100,
+					Transactional:      tt.transactional,
+				},
+				MaxAttempts: 3,
+			}
+
+			mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), workspaceID, entry.ID).Return(nil)
+			mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), entry.IsMarketing()).
+				DoAndReturn(func(ctx context.Context, request domain.SendEmailProviderRequest, isMarketing bool) error {
+					assert.Equal(t, tt.wantBulkHeaders, request.EmailOptions.BulkHeaders)
+					return nil
+				})
+			mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).Return(nil)
+			mockQueueRepo.EXPECT().MarkAsSent(gomock.Any(), workspaceID, entry.ID).Return(nil)
+
+			worker := NewEmailQueueWorker(
+				mockQueueRepo,
+				mockWorkspaceRepo,
+				mockEmailService,
+				mockMessageHistoryRepo,
+				DefaultWorkerConfig(),
+				mockLogger,
+			)
+			worker.ctx = context.Background()
+
+			worker.processEntry(workspace, entry)
+		})
+	}
+}
+
+func TestEmailQueueWorker_ProcessEntry_SeedSkipsMessageHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+	mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	integrationID := "integration-1"
+	workspaceID := "workspace-1"
+
+	workspace := &domain.Workspace{
+		ID: workspaceID,
+		Integrations: []domain.Integration{
+			{
+				ID: integrationID,
+				EmailProvider: domain.EmailProvider{
+					Kind:               domain.EmailProviderKindSMTP,
+					RateLimitPerMinute: 100,
+				},
+			},
+		},
+	}
+
+	entry := &domain.EmailQueueEntry{
+		ID:            "entry-1",
+		Status:        domain.EmailQueueStatusPending,
+		SourceType:    domain.EmailQueueSourceBroadcast,
+		SourceID:      "broadcast-1",
+		IntegrationID: integrationID,
+		ContactEmail:  "seed@inbox.test",
+		MessageID:     "msg-1",
+		Payload: domain.EmailQueuePayload{
+			FromAddress:        "sender@example.com",
+			Subject:            "Test Subject",
+			HTMLContent:        "<p>Hello</p>",
+			RateLimitPerMinute: 100,
+			Seed:               true,
+		},
+		MaxAttempts: 3,
+	}
+
+	var sentTo string
+	mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), workspaceID, entry.ID).Return(nil)
+	mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).
+		DoAndReturn(func(ctx context.Context, request domain.SendEmailProviderRequest, isMarketing bool) error {
+			sentTo = request.To
+			return nil
+		})
+	mockQueueRepo.EXPECT().MarkAsSent(gomock.Any(), workspaceID, entry.ID).Return(nil)
+	// No message history Upsert: seed copies stay out of the broadcast stats
+
+	worker := NewEmailQueueWorker(
+		mockQueueRepo,
+		mockWorkspaceRepo,
+		mockEmailService,
+		mockMessageHistoryRepo,
+		DefaultWorkerConfig(),
+		mockLogger,
+	)
+	worker.ctx = context.Background()
+
+	worker.processEntry(workspace, entry)
+
+	assert.Equal(t, "seed@inbox.test", sentTo)
+}
+
 func TestEmailQueueWorker_ProcessEntry_SendFailure(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -390,6 +637,278 @@ func TestEmailQueueWorker_ProcessEntry_SendFailure(t *testing.T) {
 	worker.processEntry(workspace, entry)
 }
 
+func TestEmailQueueWorker_ProcessEntry_RejectedNotRetried(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+	mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	// Setup logger
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	integrationID := "integration-1"
+	entryID := "entry-1"
+	workspaceID := "workspace-1"
+
+	workspace := &domain.Workspace{
+		ID: workspaceID,
+		Integrations: []domain.Integration{
+			{
+				ID: integrationID,
+				EmailProvider: domain.EmailProvider{
+					Kind:               domain.EmailProviderKindSMTP,
+					RateLimitPerMinute: 100,
+				},
+			},
+		},
+	}
+
+	entry := &domain.EmailQueueEntry{
+		ID:            entryID,
+		Status:        domain.EmailQueueStatusPending,
+		SourceType:    domain.EmailQueueSourceBroadcast,
+		SourceID:      "broadcast-1",
+		IntegrationID: integrationID,
+		ContactEmail:  "test@example.com",
+		MessageID:     "msg-1",
+		Payload: domain.EmailQueuePayload{
+			FromAddress:        "sender@example.com",
+			FromName:           "Sender",
+			Subject:            "Test Subject",
+			HTMLContent:        "<p>Hello</p>",
+			RateLimitPerMinute: 100,
+		},
+		Attempts:    0,
+		MaxAttempts: 3,
+	}
+
+	// 554 is a permanent SMTP failure, retrying the same message fails again
+	sendErr := errors.New("DATA rejected with code: 554")
+
+	// Expect calls in order
+	mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), workspaceID, entryID).Return(nil)
+	mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).Return(sendErr)
+	mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).Return(nil)
+	// Deleted on the first attempt instead of being scheduled for a retry
+	mockQueueRepo.EXPECT().Delete(gomock.Any(), workspaceID, entryID).Return(nil)
+
+	worker := NewEmailQueueWorker(
+		mockQueueRepo,
+		mockWorkspaceRepo,
+		mockEmailService,
+		mockMessageHistoryRepo,
+		DefaultWorkerConfig(),
+		mockLogger,
+	)
+	worker.ctx = context.Background()
+
+	// Process the entry
+	worker.processEntry(workspace, entry)
+}
+
+func TestEmailQueueWorker_SendNow(t *testing.T) {
+	workspace := &domain.Workspace{
+		ID: "workspace-1",
+		Integrations: []domain.Integration{
+			{
+				ID: "integration-1",
+				EmailProvider: domain.EmailProvider{
+					Kind:               domain.EmailProviderKindSMTP,
+					RateLimitPerMinute: 6000,
+				},
+			},
+		},
+	}
+	newEntry := func() *domain.EmailQueueEntry {
+		return &domain.EmailQueueEntry{
+			SourceType:    domain.EmailQueueSourceAutomation,
+			SourceID:      "automation-1",
+			IntegrationID: "integration-1",
+			ContactEmail:  "test@example.com",
+			MessageID:     "msg-1",
+			Payload: domain.EmailQueuePayload{
+				FromAddress: "sender@example.com",
+				Subject:     "Test Subject",
+				HTMLContent: "<p>Hello</p>",
+			},
+		}
+	}
+
+	t.Run("sends with the workspace bulk headers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+		var sent domain.SendEmailProviderRequest
+		mockEmailService.EXPECT().
+			SendEmail(gomock.Any(), gomock.Any(), true).
+			DoAndReturn(func(_ context.Context, request domain.SendEmailProviderRequest, _ bool) error {
+				sent = request
+				return nil
+			})
+
+		worker := NewEmailQueueWorker(nil, nil, mockEmailService, nil, DefaultWorkerConfig(), mockLogger)
+
+		err := worker.SendNow(context.Background(), workspace, newEntry())
+		require.NoError(t, err)
+		assert.Equal(t, "test@example.com", sent.To)
+		assert.True(t, sent.EmailOptions.BulkHeaders)
+	})
+
+	t.Run("open circuit breaker does not send", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+		sendErr := errors.New("SMTP connection failed")
+		threshold := DefaultCircuitBreakerConfig().Threshold
+		mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).Return(sendErr).Times(threshold)
+
+		worker := NewEmailQueueWorker(nil, nil, mockEmailService, nil, DefaultWorkerConfig(), mockLogger)
+
+		for i := 0; i < threshold; i++ {
+			err := worker.SendNow(context.Background(), workspace, newEntry())
+			require.ErrorIs(t, err, sendErr)
+			assert.NotErrorIs(t, err, ErrNotSent)
+		}
+
+		err := worker.SendNow(context.Background(), workspace, newEntry())
+		assert.ErrorIs(t, err, ErrNotSent)
+	})
+
+	t.Run("unknown integration does not send", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		worker := NewEmailQueueWorker(nil, nil, mocks.NewMockEmailServiceInterface(ctrl), nil, DefaultWorkerConfig(), pkgmocks.NewMockLogger(ctrl))
+
+		entry := newEntry()
+		entry.IntegrationID = "missing"
+		err := worker.SendNow(context.Background(), workspace, entry)
+		assert.ErrorIs(t, err, ErrNotSent)
+	})
+}
+
+func TestEmailQueueWorker_ProcessEntry_SMTPFailover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+	mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).Times(1)
+
+	// The primary server is down: its port no longer accepts connections
+	primary, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	primaryAddr := primary.Addr().(*net.TCPAddr)
+	require.NoError(t, primary.Close())
+
+	// The failover server accepts the delivery
+	failover, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer failover.Close()
+	failoverAddr := failover.Addr().(*net.TCPAddr)
+	var delivered int32
+	go func() {
+		for {
+			conn, err := failover.Accept()
+			if err != nil {
+				return
+			}
+			atomic.AddInt32(&delivered, 1)
+			conn.Close()
+		}
+	}()
+
+	workspaceID := "workspace-1"
+	smtpIntegration := func(id string, addr *net.TCPAddr, failoverID string) domain.Integration {
+		return domain.Integration{
+			ID:   id,
+			Type: domain.IntegrationTypeEmail,
+			EmailProvider: domain.EmailProvider{
+				Kind:                  domain.EmailProviderKindSMTP,
+				SMTP:                  &domain.SMTPSettings{Host: "127.0.0.1", Port: addr.Port},
+				RateLimitPerMinute:    6000,
+				FailoverIntegrationID: failoverID,
+			},
+		}
+	}
+	workspace := &domain.Workspace{
+		ID: workspaceID,
+		Integrations: []domain.Integration{
+			smtpIntegration("primary", primaryAddr, "backup"),
+			smtpIntegration("backup", failoverAddr, ""),
+		},
+	}
+
+	entry := &domain.EmailQueueEntry{
+		ID:            "entry-1",
+		Status:        domain.EmailQueueStatusPending,
+		SourceType:    domain.EmailQueueSourceBroadcast,
+		SourceID:      "broadcast-1",
+		IntegrationID: "primary",
+		ContactEmail:  "test@example.com",
+		MessageID:     "msg-1",
+		Payload: domain.EmailQueuePayload{
+			FromAddress: "sender@example.com",
+			FromName:    "Sender",
+			Subject:     "Test Subject",
+			HTMLContent: "<p>Hello</p>",
+		},
+		MaxAttempts: 3,
+	}
+
+	// Deliver by connecting to the configured server, failing like the SMTP service does
+	var sentVia []string
+	mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).
+		DoAndReturn(func(_ context.Context, request domain.SendEmailProviderRequest, _ bool) error {
+			sentVia = append(sentVia, request.IntegrationID)
+			addr := net.JoinHostPort(request.Provider.SMTP.Host, strconv.Itoa(request.Provider.SMTP.Port))
+			conn, err := net.DialTimeout("tcp", addr, time.Second)
+			if err != nil {
+				return &domain.SMTPSessionError{Err: fmt.Errorf("failed to connect: %w", err)}
+			}
+			return conn.Close()
+		}).Times(2)
+
+	mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), workspaceID, "entry-1").Return(nil)
+	mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).Return(nil)
+	mockQueueRepo.EXPECT().MarkAsSent(gomock.Any(), workspaceID, "entry-1").Return(nil)
+
+	worker := NewEmailQueueWorker(
+		mockQueueRepo,
+		mockWorkspaceRepo,
+		mockEmailService,
+		mockMessageHistoryRepo,
+		DefaultWorkerConfig(),
+		mockLogger,
+	)
+	worker.ctx = context.Background()
+
+	worker.processEntry(workspace, entry)
+
+	assert.Equal(t, []string{"primary", "backup"}, sentVia)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&delivered) == 1 }, time.Second, 10*time.Millisecond)
+}
+
 func TestEmailQueueWorker_ProcessEntry_MaxAttemptsExceeded(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -703,6 +1222,85 @@ func TestEmailQueueWorker_ProcessWorkspace(t *testing.T) {
 		worker.processWorkspace(workspace)
 	})
 
+	t.Run("claims up to batch size and commits sent entries in groups", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+		mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+		integrationID := "integration-1"
+		workspaceID := "workspace-1"
+
+		workspace := &domain.Workspace{
+			ID: workspaceID,
+			Integrations: []domain.Integration{
+				{
+					ID: integrationID,
+					EmailProvider: domain.EmailProvider{
+						Kind:               domain.EmailProviderKindSMTP,
+						RateLimitPerMinute: 6000, // High rate for test
+					},
+				},
+			},
+		}
+
+		var entries []*domain.EmailQueueEntry
+		for i := 1; i <= 5; i++ {
+			entries = append(entries, &domain.EmailQueueEntry{
+				ID:            fmt.Sprintf("entry-%d", i),
+				Status:        domain.EmailQueueStatusPending,
+				SourceType:    domain.EmailQueueSourceBroadcast,
+				SourceID:      "broadcast-1",
+				IntegrationID: integrationID,
+				ContactEmail:  fmt.Sprintf("test%d@example.com", i),
+				MessageID:     fmt.Sprintf("msg-%d", i),
+				Payload: domain.EmailQueuePayload{
+					RateLimitPerMinute: 6000,
+				},
+				MaxAttempts: 3,
+			})
+		}
+
+		config := DefaultWorkerConfig()
+		config.BatchSize = 5
+		config.CommitInterval = 2
+
+		// Claims exactly the configured batch size
+		mockQueueRepo.EXPECT().FetchPending(gomock.Any(), workspaceID, 5).Return(entries, nil)
+		mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(5)
+		mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).Return(nil).Times(5)
+		mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).Return(nil).Times(5)
+
+		// Sent entries are removed two at a time, the remainder once the batch is done
+		gomock.InOrder(
+			mockQueueRepo.EXPECT().MarkAsSentBatch(gomock.Any(), workspaceID, []string{"entry-1", "entry-2"}).Return(nil),
+			mockQueueRepo.EXPECT().MarkAsSentBatch(gomock.Any(), workspaceID, []string{"entry-3", "entry-4"}).Return(nil),
+			mockQueueRepo.EXPECT().MarkAsSentBatch(gomock.Any(), workspaceID, []string{"entry-5"}).Return(nil),
+		)
+
+		worker := NewEmailQueueWorker(
+			mockQueueRepo,
+			mockWorkspaceRepo,
+			mockEmailService,
+			mockMessageHistoryRepo,
+			config,
+			mockLogger,
+		)
+
+		worker.ctx = context.Background()
+		worker.processWorkspace(workspace)
+
+		assert.Empty(t, worker.sentEntries)
+	})
+
 	t.Run("handles empty queue", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -989,6 +1587,111 @@ func TestEmailQueueWorker_RateLimiting(t *testing.T) {
 	assert.Equal(t, int32(3), processedCount)
 }
 
+func TestEmailQueueWorker_SendJitter(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		worker := &EmailQueueWorker{config: DefaultWorkerConfig()}
+		assert.Equal(t, time.Duration(0), worker.sendJitterDelay())
+	})
+
+	t.Run("delays stay within bounds and vary", func(t *testing.T) {
+		jitter := 50 * time.Millisecond
+		worker := &EmailQueueWorker{config: &EmailQueueWorkerConfig{SendJitter: jitter}}
+
+		seen := map[time.Duration]bool{}
+		for i := 0; i < 20; i++ {
+			delay := worker.sendJitterDelay()
+			assert.GreaterOrEqual(t, delay, time.Duration(0))
+			assert.Less(t, delay, jitter)
+			seen[delay] = true
+		}
+		assert.Greater(t, len(seen), 1, "jitter delays should vary")
+	})
+
+	t.Run("inter-send intervals stay within the jitter bounds", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+		mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+		integrationID := "integration-1"
+		workspaceID := "workspace-1"
+		workspace := &domain.Workspace{
+			ID: workspaceID,
+			Integrations: []domain.Integration{
+				{
+					ID: integrationID,
+					EmailProvider: domain.EmailProvider{
+						Kind:               domain.EmailProviderKindSMTP,
+						RateLimitPerMinute: 60000, // Rate limiter adds at most 1ms between sends
+					},
+				},
+			},
+		}
+
+		jitter := 30 * time.Millisecond
+		const batchSize = 6
+		var sentAt []time.Time
+
+		mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(batchSize)
+		mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).DoAndReturn(
+			func(ctx context.Context, req domain.SendEmailProviderRequest, isMarketing bool) error {
+				sentAt = append(sentAt, time.Now())
+				return nil
+			},
+		).Times(batchSize)
+		mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).Return(nil).Times(batchSize)
+		mockQueueRepo.EXPECT().MarkAsSent(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(batchSize)
+
+		config := DefaultWorkerConfig()
+		config.SendJitter = jitter
+		worker := NewEmailQueueWorker(
+			mockQueueRepo,
+			mockWorkspaceRepo,
+			mockEmailService,
+			mockMessageHistoryRepo,
+			config,
+			mockLogger,
+		)
+		worker.ctx = context.Background()
+
+		for i := 0; i < batchSize; i++ {
+			worker.processEntry(workspace, &domain.EmailQueueEntry{
+				ID:            "entry-" + string(rune('1'+i)),
+				Status:        domain.EmailQueueStatusPending,
+				SourceType:    domain.EmailQueueSourceBroadcast,
+				SourceID:      "broadcast-1",
+				IntegrationID: integrationID,
+				ContactEmail:  "test@example.com",
+				MessageID:     "msg-" + string(rune('1'+i)),
+				MaxAttempts:   3,
+			})
+		}
+
+		require.Len(t, sentAt, batchSize)
+		minInterval, maxInterval := time.Duration(1<<62), time.Duration(0)
+		for i := 1; i < len(sentAt); i++ {
+			interval := sentAt[i].Sub(sentAt[i-1])
+			// Upper bound leaves room for the rate limiter and scheduling overhead
+			assert.Less(t, interval, jitter+20*time.Millisecond)
+			if interval < minInterval {
+				minInterval = interval
+			}
+			if interval > maxInterval {
+				maxInterval = interval
+			}
+		}
+		assert.Greater(t, maxInterval-minInterval, time.Duration(0), "inter-send intervals should vary")
+	})
+}
+
 func TestEmailQueueWorker_DefaultRateLimit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1171,6 +1874,118 @@ func TestEmailQueueWorker_ProcessEntry_StoresTemplateDataInMessageHistory(t *tes
 	worker.processEntry(workspace, entry)
 }
 
+func TestEmailQueueWorker_ProcessEntry_MessageHistorySource(t *testing.T) {
+	tests := []struct {
+		name       string
+		sourceType domain.EmailQueueSourceType
+		check      func(t *testing.T, msg *domain.MessageHistory)
+	}{
+		{
+			name:       "broadcast",
+			sourceType: domain.EmailQueueSourceBroadcast,
+			check: func(t *testing.T, msg *domain.MessageHistory) {
+				require.NotNil(t, msg.BroadcastID)
+				assert.Equal(t, "source-1", *msg.BroadcastID)
+				assert.Nil(t, msg.AutomationID)
+				assert.Nil(t, msg.MessageData.Metadata)
+			},
+		},
+		{
+			name:       "automation",
+			sourceType: domain.EmailQueueSourceAutomation,
+			check: func(t *testing.T, msg *domain.MessageHistory) {
+				require.NotNil(t, msg.AutomationID)
+				assert.Equal(t, "source-1", *msg.AutomationID)
+				assert.Nil(t, msg.BroadcastID)
+				assert.Nil(t, msg.MessageData.Metadata)
+			},
+		},
+		{
+			name:       "transactional",
+			sourceType: domain.EmailQueueSourceTransactional,
+			check: func(t *testing.T, msg *domain.MessageHistory) {
+				assert.Nil(t, msg.BroadcastID)
+				assert.Nil(t, msg.AutomationID)
+				assert.Nil(t, msg.TransactionalNotificationID)
+				assert.Equal(t, map[string]interface{}{"source": "transactional"}, msg.MessageData.Metadata)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+			mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+			mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+			mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+			mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+			mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+			mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+			integrationID := "integration-1"
+			workspaceID := "workspace-1"
+
+			workspace := &domain.Workspace{
+				ID: workspaceID,
+				Integrations: []domain.Integration{
+					{
+						ID: integrationID,
+						EmailProvider: domain.EmailProvider{
+							Kind:               domain.EmailProviderKindSMTP,
+							RateLimitPerMinute: 100,
+						},
+					},
+				},
+			}
+
+			entry := &domain.EmailQueueEntry{
+				ID:            "entry-1",
+				Status:        domain.EmailQueueStatusPending,
+				SourceType:    tt.sourceType,
+				SourceID:      "source-1",
+				IntegrationID: integrationID,
+				ContactEmail:  "test@example.com",
+				MessageID:     "msg-1",
+				TemplateID:    "template-1",
+				Payload: domain.EmailQueuePayload{
+					FromAddress:        "sender@example.com",
+					Subject:            "Test Subject",
+					HTMLContent:        "<p>Hello</p>",
+					RateLimitPerMinute: 100,
+				},
+				MaxAttempts: 3,
+			}
+
+			mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), workspaceID, entry.ID).Return(nil)
+			mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), tt.sourceType.IsMarketing()).Return(nil)
+			mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, wid, secretKey string, msg *domain.MessageHistory) error {
+					assert.Equal(t, "template-1", msg.TemplateID)
+					tt.check(t, msg)
+					return nil
+				})
+			mockQueueRepo.EXPECT().MarkAsSent(gomock.Any(), workspaceID, entry.ID).Return(nil)
+
+			worker := NewEmailQueueWorker(
+				mockQueueRepo,
+				mockWorkspaceRepo,
+				mockEmailService,
+				mockMessageHistoryRepo,
+				DefaultWorkerConfig(),
+				mockLogger,
+			)
+			worker.ctx = context.Background()
+
+			worker.processEntry(workspace, entry)
+		})
+	}
+}
+
 func TestEmailQueueWorker_GetMinEmailRateLimit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
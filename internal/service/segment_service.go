@@ -405,19 +405,27 @@ func (s *SegmentService) RebuildSegment(ctx context.Context, workspaceID, segmen
 	return nil
 }
 
-// PreviewSegment executes the segment query and returns a preview of matching contacts
-// This does NOT save the results to the database
-func (s *SegmentService) PreviewSegment(ctx context.Context, workspaceID string, tree *domain.TreeNode, limit int) (*domain.PreviewSegmentResponse, error) {
-	if workspaceID == "" {
-		return nil, fmt.Errorf("workspace_id is required")
-	}
-	if tree == nil {
-		return nil, fmt.Errorf("tree is required")
+// PreviewSegment executes the segment query and returns the total count of matching contacts
+// along with a page of them. Membership is not persisted.
+func (s *SegmentService) PreviewSegment(ctx context.Context, req *domain.PreviewSegmentRequest) (*domain.PreviewSegmentResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
+
+	limit := req.Limit
 	if limit <= 0 || limit > 100 {
 		limit = 20 // Default preview limit
 	}
 
+	tree := req.Tree
+	if req.SegmentID != "" {
+		segment, err := s.segmentRepo.GetSegmentByID(ctx, req.WorkspaceID, req.SegmentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get segment: %w", err)
+		}
+		tree = segment.Tree
+	}
+
 	// Validate the tree
 	if err := tree.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid tree: %w", err)
@@ -430,21 +438,33 @@ func (s *SegmentService) PreviewSegment(ctx context.Context, workspaceID string,
 	}
 
 	s.logger.WithFields(map[string]interface{}{
-		"workspace_id": workspaceID,
+		"workspace_id": req.WorkspaceID,
 		"sql":          sqlQuery,
 		"args":         args,
 	}).Debug("Preview segment SQL generated")
 
 	// Get count using repository method
-	totalCount, err := s.segmentRepo.PreviewSegment(ctx, workspaceID, sqlQuery, args, limit)
+	totalCount, err := s.segmentRepo.PreviewSegment(ctx, req.WorkspaceID, sqlQuery, args, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to preview segment: %w", err)
 	}
 
+	contacts, err := s.segmentRepo.PreviewSegmentContacts(ctx, req.WorkspaceID, sqlQuery, args, limit, req.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview segment contacts: %w", err)
+	}
+
+	emails := make([]string, 0, len(contacts))
+	for _, contact := range contacts {
+		emails = append(emails, contact.Email)
+	}
+
 	return &domain.PreviewSegmentResponse{
-		Emails:       []string{}, // Don't return emails for privacy/performance
+		Emails:       emails,
+		Contacts:     contacts,
 		TotalCount:   totalCount,
 		Limit:        limit,
+		Offset:       req.Offset,
 		GeneratedSQL: sqlQuery,
 		SQLArgs:      args,
 	}, nil
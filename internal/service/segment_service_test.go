@@ -815,13 +815,17 @@ func TestSegmentService_PreviewSegment(t *testing.T) {
 		mockRepo.EXPECT().
 			PreviewSegment(ctx, "workspace123", gomock.Any(), gomock.Any(), 10).
 			Return(42, nil)
+		mockRepo.EXPECT().
+			PreviewSegmentContacts(ctx, "workspace123", gomock.Any(), gomock.Any(), 10, 0).
+			Return([]*domain.Contact{{Email: "a@example.com"}, {Email: "b@example.com"}}, nil)
 
-		response, err := service.PreviewSegment(ctx, "workspace123", tree, 10)
+		response, err := service.PreviewSegment(ctx, &domain.PreviewSegmentRequest{WorkspaceID: "workspace123", Tree: tree, Limit: 10})
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
 		assert.Equal(t, 42, response.TotalCount)
 		assert.Equal(t, 10, response.Limit)
-		assert.Empty(t, response.Emails) // Emails not returned for privacy/performance
+		assert.Equal(t, []string{"a@example.com", "b@example.com"}, response.Emails)
+		assert.Len(t, response.Contacts, 2)
 		assert.NotEmpty(t, response.GeneratedSQL)
 	})
 
@@ -831,8 +835,11 @@ func TestSegmentService_PreviewSegment(t *testing.T) {
 		mockRepo.EXPECT().
 			PreviewSegment(ctx, "workspace123", gomock.Any(), gomock.Any(), 10).
 			Return(0, nil)
+		mockRepo.EXPECT().
+			PreviewSegmentContacts(ctx, "workspace123", gomock.Any(), gomock.Any(), 10, 0).
+			Return([]*domain.Contact{}, nil)
 
-		response, err := service.PreviewSegment(ctx, "workspace123", tree, 10)
+		response, err := service.PreviewSegment(ctx, &domain.PreviewSegmentRequest{WorkspaceID: "workspace123", Tree: tree, Limit: 10})
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
 		assert.Equal(t, 0, response.TotalCount)
@@ -859,7 +866,7 @@ func TestSegmentService_PreviewSegment(t *testing.T) {
 				workspaceID: "workspace123",
 				tree:        nil,
 				limit:       10,
-				errContains: "tree is required",
+				errContains: "tree or segment_id is required",
 			},
 			{
 				name:        "invalid tree structure",
@@ -872,7 +879,7 @@ func TestSegmentService_PreviewSegment(t *testing.T) {
 
 		for _, tc := range testCases {
 			t.Run(tc.name, func(t *testing.T) {
-				response, err := service.PreviewSegment(ctx, tc.workspaceID, tc.tree, tc.limit)
+				response, err := service.PreviewSegment(ctx, &domain.PreviewSegmentRequest{WorkspaceID: tc.workspaceID, Tree: tc.tree, Limit: tc.limit})
 				assert.Error(t, err)
 				assert.Nil(t, response)
 				assert.Contains(t, err.Error(), tc.errContains)
@@ -887,7 +894,7 @@ func TestSegmentService_PreviewSegment(t *testing.T) {
 			PreviewSegment(ctx, "workspace123", gomock.Any(), gomock.Any(), 10).
 			Return(0, errors.New("preview failed"))
 
-		response, err := service.PreviewSegment(ctx, "workspace123", tree, 10)
+		response, err := service.PreviewSegment(ctx, &domain.PreviewSegmentRequest{WorkspaceID: "workspace123", Tree: tree, Limit: 10})
 		assert.Error(t, err)
 		assert.Nil(t, response)
 		assert.Contains(t, err.Error(), "failed to preview segment")
@@ -899,9 +906,12 @@ func TestSegmentService_PreviewSegment(t *testing.T) {
 		mockRepo.EXPECT().
 			PreviewSegment(ctx, "workspace123", gomock.Any(), gomock.Any(), 20).
 			Return(10, nil)
+		mockRepo.EXPECT().
+			PreviewSegmentContacts(ctx, "workspace123", gomock.Any(), gomock.Any(), 20, 0).
+			Return([]*domain.Contact{}, nil)
 
 		// Test with invalid limit (0) - should default to 20
-		response, err := service.PreviewSegment(ctx, "workspace123", tree, 0)
+		response, err := service.PreviewSegment(ctx, &domain.PreviewSegmentRequest{WorkspaceID: "workspace123", Tree: tree, Limit: 0})
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
 		assert.Equal(t, 20, response.Limit) // Default limit
@@ -913,13 +923,63 @@ func TestSegmentService_PreviewSegment(t *testing.T) {
 		mockRepo.EXPECT().
 			PreviewSegment(ctx, "workspace123", gomock.Any(), gomock.Any(), 20).
 			Return(10, nil)
+		mockRepo.EXPECT().
+			PreviewSegmentContacts(ctx, "workspace123", gomock.Any(), gomock.Any(), 20, 0).
+			Return([]*domain.Contact{}, nil)
 
 		// Test with limit > 100 - should default to 20
-		response, err := service.PreviewSegment(ctx, "workspace123", tree, 150)
+		response, err := service.PreviewSegment(ctx, &domain.PreviewSegmentRequest{WorkspaceID: "workspace123", Tree: tree, Limit: 150})
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
 		assert.Equal(t, 20, response.Limit) // Default limit
 	})
+
+	t.Run("preview by segment id with offset", func(t *testing.T) {
+		tree := createTestTree()
+
+		mockRepo.EXPECT().
+			GetSegmentByID(ctx, "workspace123", "seg123").
+			Return(&domain.Segment{ID: "seg123", Tree: tree}, nil)
+		mockRepo.EXPECT().
+			PreviewSegment(ctx, "workspace123", gomock.Any(), gomock.Any(), 5).
+			Return(12, nil)
+		mockRepo.EXPECT().
+			PreviewSegmentContacts(ctx, "workspace123", gomock.Any(), gomock.Any(), 5, 10).
+			Return([]*domain.Contact{{Email: "c@example.com"}}, nil)
+
+		response, err := service.PreviewSegment(ctx, &domain.PreviewSegmentRequest{WorkspaceID: "workspace123", SegmentID: "seg123", Limit: 5, Offset: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, 12, response.TotalCount)
+		assert.Equal(t, 10, response.Offset)
+		assert.Equal(t, []string{"c@example.com"}, response.Emails)
+	})
+
+	t.Run("segment not found", func(t *testing.T) {
+		mockRepo.EXPECT().
+			GetSegmentByID(ctx, "workspace123", "missing").
+			Return(nil, errors.New("segment not found"))
+
+		response, err := service.PreviewSegment(ctx, &domain.PreviewSegmentRequest{WorkspaceID: "workspace123", SegmentID: "missing"})
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "failed to get segment")
+	})
+
+	t.Run("repository contacts error", func(t *testing.T) {
+		tree := createTestTree()
+
+		mockRepo.EXPECT().
+			PreviewSegment(ctx, "workspace123", gomock.Any(), gomock.Any(), 10).
+			Return(3, nil)
+		mockRepo.EXPECT().
+			PreviewSegmentContacts(ctx, "workspace123", gomock.Any(), gomock.Any(), 10, 0).
+			Return(nil, errors.New("query failed"))
+
+		response, err := service.PreviewSegment(ctx, &domain.PreviewSegmentRequest{WorkspaceID: "workspace123", Tree: tree, Limit: 10})
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		assert.Contains(t, err.Error(), "failed to preview segment contacts")
+	})
 }
 
 func TestSegmentService_GetSegmentContacts(t *testing.T) {
@@ -347,11 +347,19 @@ func (s *SendGridService) SendEmail(ctx context.Context, request domain.SendEmai
 	// Add RFC-8058 List-Unsubscribe headers for one-click unsubscribe
 	if request.EmailOptions.ListUnsubscribeURL != "" {
 		mailReq.Headers = map[string]string{
-			"List-Unsubscribe":      fmt.Sprintf("<%s>", request.EmailOptions.ListUnsubscribeURL),
+			"List-Unsubscribe":      request.EmailOptions.ListUnsubscribeHeader(),
 			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
 		}
 	}
 
+	// Mark marketing emails as bulk so auto-responders don't answer them
+	for _, header := range request.EmailOptions.BulkHeaderFields() {
+		if mailReq.Headers == nil {
+			mailReq.Headers = make(map[string]string)
+		}
+		mailReq.Headers[header.Name] = header.Value
+	}
+
 	// Add attachments if specified
 	if len(request.EmailOptions.Attachments) > 0 {
 		for i, att := range request.EmailOptions.Attachments {
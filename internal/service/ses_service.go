@@ -884,9 +884,9 @@ func (s *SESService) SendEmail(ctx context.Context, request domain.SendEmailProv
 		}
 	}
 
-	// Use SendRawEmail when attachments or List-Unsubscribe headers are needed
+	// Use SendRawEmail when attachments, List-Unsubscribe or bulk headers are needed
 	// (AWS SES V1 SendEmail API doesn't support custom headers)
-	if len(request.EmailOptions.Attachments) > 0 || request.EmailOptions.ListUnsubscribeURL != "" {
+	if len(request.EmailOptions.Attachments) > 0 || request.EmailOptions.ListUnsubscribeURL != "" || request.EmailOptions.BulkHeaders {
 		// Only pass configSetName if it was verified to exist (graceful degradation)
 		configSetToUse := ""
 		if input.ConfigurationSetName != nil {
@@ -970,10 +970,15 @@ func (s *SESService) sendRawEmail(ctx context.Context, sesClient domain.SESClien
 
 	// Add RFC-8058 List-Unsubscribe headers for one-click unsubscribe
 	if request.EmailOptions.ListUnsubscribeURL != "" {
-		buf.WriteString(fmt.Sprintf("List-Unsubscribe: <%s>\r\n", request.EmailOptions.ListUnsubscribeURL))
+		buf.WriteString(fmt.Sprintf("List-Unsubscribe: %s\r\n", request.EmailOptions.ListUnsubscribeHeader()))
 		buf.WriteString("List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n")
 	}
 
+	// Mark marketing emails as bulk so auto-responders don't answer them
+	for _, header := range request.EmailOptions.BulkHeaderFields() {
+		buf.WriteString(fmt.Sprintf("%s: %s\r\n", header.Name, header.Value))
+	}
+
 	buf.WriteString("MIME-Version: 1.0\r\n")
 
 	// Create multipart writer
@@ -5,12 +5,14 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"fmt"
 	"net"
 	"net/textproto"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
@@ -38,6 +40,12 @@ func getSMTPDialTimeout() time.Duration {
 type smtpConnection struct {
 	conn   net.Conn
 	reader *bufio.Reader
+
+	// Sender the session was opened for, EHLO and XOAUTH2 authentication depend on it
+	from string
+
+	// Extensions advertised in the last EHLO response, keyed by upper-cased keyword
+	capabilities map[string]string
 }
 
 func newSMTPConnection(conn net.Conn) *smtpConnection {
@@ -89,6 +97,49 @@ func (c *smtpConnection) readMultilineResponse() (int, error) {
 	}
 }
 
+// readEHLOResponse reads a multi-line EHLO response and returns the advertised extensions.
+// The first line is the server greeting and is not an extension.
+func (c *smtpConnection) readEHLOResponse() (int, map[string]string, error) {
+	capabilities := make(map[string]string)
+	for first := true; ; first = false {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if len(line) < 4 {
+			return 0, nil, fmt.Errorf("short response: %s", line)
+		}
+
+		code := 0
+		if _, err := fmt.Sscanf(line[:3], "%d", &code); err != nil {
+			return 0, nil, fmt.Errorf("invalid response code: %s", line)
+		}
+
+		if !first {
+			keyword, params, _ := strings.Cut(strings.TrimSpace(line[4:]), " ")
+			capabilities[strings.ToUpper(keyword)] = params
+		}
+
+		if line[3] == ' ' {
+			return code, capabilities, nil
+		}
+	}
+}
+
+// ehlo greets the server and caches the extensions it advertises
+func (c *smtpConnection) ehlo(hostname string) (int, error) {
+	if _, err := fmt.Fprintf(c.conn, "EHLO %s\r\n", hostname); err != nil {
+		return 0, err
+	}
+	code, capabilities, err := c.readEHLOResponse()
+	if err != nil {
+		return 0, err
+	}
+	c.capabilities = capabilities
+	return code, nil
+}
+
 func (c *smtpConnection) sendCommand(cmd string) (int, string, error) {
 	if _, err := fmt.Fprintf(c.conn, "%s\r\n", cmd); err != nil {
 		return 0, "", err
@@ -96,17 +147,37 @@ func (c *smtpConnection) sendCommand(cmd string) (int, string, error) {
 	return c.readResponse()
 }
 
-func (c *smtpConnection) sendCommandMultiline(cmd string) (int, error) {
-	if _, err := fmt.Fprintf(c.conn, "%s\r\n", cmd); err != nil {
-		return 0, err
+// reset aborts any pending mail transaction so the session can carry the next message
+func (c *smtpConnection) reset() error {
+	code, _, err := c.sendCommand("RSET")
+	if err != nil {
+		return err
 	}
-	return c.readMultilineResponse()
+	if code != 250 {
+		return fmt.Errorf("RSET rejected with code: %d", code)
+	}
+	return nil
+}
+
+// quit ends the session politely and closes the connection
+func (c *smtpConnection) quit() {
+	_, _, _ = c.sendCommand("QUIT")
+	c.Close()
 }
 
 func (c *smtpConnection) Close() error {
 	return c.conn.Close()
 }
 
+// interruptOn aborts blocked reads and writes on the connection once ctx is done.
+// The returned stop function detaches ctx and reports false when it already fired,
+// in which case the connection is unusable and must be closed.
+func (c *smtpConnection) interruptOn(ctx context.Context) (stop func() bool) {
+	return context.AfterFunc(ctx, func() {
+		_ = c.conn.SetDeadline(time.Now())
+	})
+}
+
 // OAuth2TokenProvider is an interface for getting OAuth2 access tokens
 type OAuth2TokenProvider interface {
 	GetAccessToken(settings *domain.SMTPSettings) (string, error)
@@ -137,25 +208,101 @@ func sendRawEmail(host string, port int, username, password string, useTLS bool,
 // sendRawEmailWithSettings sends an email using raw SMTP commands with full settings support.
 // It supports both basic authentication and OAuth2 (XOAUTH2) authentication.
 func sendRawEmailWithSettings(settings *domain.SMTPSettings, from string, to []string, msg []byte, oauth2Provider OAuth2TokenProvider) error {
+	return sendRawEmailContext(context.Background(), settings, from, to, msg, oauth2Provider)
+}
+
+// sendRawEmailContext is sendRawEmailWithSettings aborting the session when ctx is done
+func sendRawEmailContext(ctx context.Context, settings *domain.SMTPSettings, from string, to []string, msg []byte, oauth2Provider OAuth2TokenProvider) error {
+	smtpConn, err := openSMTPSessionContext(ctx, settings, from, oauth2Provider)
+	if err != nil {
+		return err
+	}
+	defer smtpConn.Close()
+
+	stop := smtpConn.interruptOn(ctx)
+	err = smtpConn.sendMessage(from, to, msg)
+	if !stop() {
+		if err != nil {
+			return fmt.Errorf("SMTP send interrupted: %w", ctx.Err())
+		}
+		// The server accepted the message, only QUIT is skipped
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// QUIT
+	_, _, _ = smtpConn.sendCommand("QUIT")
+
+	return nil
+}
+
+// openSMTPSession connects to the server and runs the handshake (EHLO, STARTTLS, AUTH),
+// returning a connection ready to accept MAIL FROM.
+func openSMTPSession(settings *domain.SMTPSettings, from string, oauth2Provider OAuth2TokenProvider) (*smtpConnection, error) {
+	return openSMTPSessionContext(context.Background(), settings, from, oauth2Provider)
+}
+
+// openSMTPSessionContext is openSMTPSession aborting the handshake when ctx is done.
+// The returned connection is detached from ctx, so it can outlive it in a pool.
+func openSMTPSessionContext(ctx context.Context, settings *domain.SMTPSettings, from string, oauth2Provider OAuth2TokenProvider) (_ *smtpConnection, err error) {
+	// Any failure here means the server can't be used at all, which callers may fail over on
+	defer func() {
+		if err != nil {
+			err = &domain.SMTPSessionError{Err: err}
+		}
+	}()
+
 	addr := net.JoinHostPort(settings.Host, fmt.Sprintf("%d", settings.Port))
 
+	tlsMode := settings.EffectiveTLSMode()
+	tlsConfig := &tls.Config{
+		ServerName: settings.Host,
+		MinVersion: smtpMinTLSVersion(settings),
+		RootCAs:    smtpRootCAs,
+	}
+
 	// Connect to SMTP server with configurable timeout
 	dialer := &net.Dialer{Timeout: getSMTPDialTimeout()}
-	conn, err := dialer.Dial("tcp", addr)
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
 	if err != nil {
-		return fmt.Errorf("failed to connect: %w", err)
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+
+	// Implicit TLS negotiates before the server greeting
+	if tlsMode == domain.SMTPTLSModeImplicit {
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("TLS handshake failed: %w", err)
+		}
+		conn = tlsConn
 	}
 
 	smtpConn := newSMTPConnection(conn)
-	defer smtpConn.Close()
+	smtpConn.from = from
+	defer func() {
+		if err != nil {
+			smtpConn.Close()
+		}
+	}()
+
+	stopInterrupt := smtpConn.interruptOn(ctx)
+	defer func() {
+		if !stopInterrupt() {
+			// The deadline set on interruption leaves the connection unusable
+			err = fmt.Errorf("SMTP handshake interrupted: %w", ctx.Err())
+		}
+	}()
 
 	// Read greeting (use multiline to handle RFC 5321 multi-line banners - issue #183)
 	code, err := smtpConn.readMultilineResponse()
 	if err != nil {
-		return fmt.Errorf("failed to read greeting: %w", err)
+		return nil, fmt.Errorf("failed to read greeting: %w", err)
 	}
 	if code != 220 {
-		return fmt.Errorf("unexpected greeting code: %d", code)
+		return nil, fmt.Errorf("unexpected greeting code: %d", code)
 	}
 
 	// Send EHLO - use configured hostname, fall back to from-email domain, then SMTP host
@@ -168,45 +315,46 @@ func sendRawEmailWithSettings(settings *domain.SMTPSettings, from string, to []s
 	if hostname == "" {
 		hostname = settings.Host
 	}
-	code, err = smtpConn.sendCommandMultiline(fmt.Sprintf("EHLO %s", hostname))
+	code, err = smtpConn.ehlo(hostname)
 	if err != nil {
-		return fmt.Errorf("EHLO failed: %w", err)
+		return nil, fmt.Errorf("EHLO failed: %w", err)
 	}
 	if code != 250 {
-		return fmt.Errorf("EHLO rejected with code: %d", code)
+		return nil, fmt.Errorf("EHLO rejected with code: %d", code)
 	}
 
-	// STARTTLS if enabled
-	if settings.UseTLS {
+	// STARTTLS if enabled, before any credentials are sent
+	if tlsMode == domain.SMTPTLSModeStartTLS {
+		if _, ok := smtpConn.capabilities["STARTTLS"]; !ok {
+			return nil, fmt.Errorf("server does not advertise STARTTLS, refusing to continue without TLS")
+		}
+
 		code, _, err = smtpConn.sendCommand("STARTTLS")
 		if err != nil {
-			return fmt.Errorf("STARTTLS command failed: %w", err)
+			return nil, fmt.Errorf("STARTTLS command failed: %w", err)
 		}
 		if code != 220 {
-			return fmt.Errorf("STARTTLS rejected with code: %d", code)
+			return nil, fmt.Errorf("STARTTLS rejected with code: %d", code)
 		}
 
 		// Upgrade connection to TLS
-		tlsConfig := &tls.Config{
-			ServerName: settings.Host,
-			MinVersion: tls.VersionTLS12,
-		}
 		tlsConn := tls.Client(conn, tlsConfig)
 		if err := tlsConn.Handshake(); err != nil {
-			return fmt.Errorf("TLS handshake failed: %w", err)
+			return nil, fmt.Errorf("TLS handshake failed: %w", err)
 		}
 
-		// Replace connection with TLS connection
-		smtpConn = newSMTPConnection(tlsConn)
-		defer smtpConn.Close()
+		// Upgrade the session in place so it keeps its sender for pooled reuse;
+		// the capabilities advertised in cleartext are replaced by the next EHLO
+		smtpConn.conn = tlsConn
+		smtpConn.reader = bufio.NewReader(tlsConn)
 
 		// Send EHLO again after TLS
-		code, err = smtpConn.sendCommandMultiline(fmt.Sprintf("EHLO %s", hostname))
+		code, err = smtpConn.ehlo(hostname)
 		if err != nil {
-			return fmt.Errorf("EHLO after TLS failed: %w", err)
+			return nil, fmt.Errorf("EHLO after TLS failed: %w", err)
 		}
 		if code != 250 {
-			return fmt.Errorf("EHLO after TLS rejected with code: %d", code)
+			return nil, fmt.Errorf("EHLO after TLS rejected with code: %d", code)
 		}
 	}
 
@@ -214,12 +362,12 @@ func sendRawEmailWithSettings(settings *domain.SMTPSettings, from string, to []s
 	if settings.AuthType == "oauth2" {
 		// OAuth2 XOAUTH2 authentication
 		if oauth2Provider == nil {
-			return fmt.Errorf("OAuth2 authentication requires a token provider")
+			return nil, fmt.Errorf("OAuth2 authentication requires a token provider")
 		}
 
 		accessToken, err := oauth2Provider.GetAccessToken(settings)
 		if err != nil {
-			return fmt.Errorf("failed to get OAuth2 token: %w", err)
+			return nil, fmt.Errorf("failed to get OAuth2 token: %w", err)
 		}
 
 		// XOAUTH2 format: base64("user=" + email + "\x01auth=Bearer " + token + "\x01\x01")
@@ -230,7 +378,7 @@ func sendRawEmailWithSettings(settings *domain.SMTPSettings, from string, to []s
 
 		code, response, err := smtpConn.sendCommand(fmt.Sprintf("AUTH XOAUTH2 %s", encoded))
 		if err != nil {
-			return fmt.Errorf("XOAUTH2 AUTH failed: %w", err)
+			return nil, fmt.Errorf("XOAUTH2 AUTH failed: %w", err)
 		}
 		if code != 235 {
 			// Try refresh once if this looks like a token expiry (535)
@@ -251,13 +399,13 @@ func sendRawEmailWithSettings(settings *domain.SMTPSettings, from string, to []s
 				responseToTry = parts[1] // Try the part after the status code
 			}
 			if decoded, decodeErr := base64.StdEncoding.DecodeString(responseToTry); decodeErr == nil && len(decoded) > 0 {
-				return fmt.Errorf("XOAUTH2 authentication failed: %s", string(decoded))
+				return nil, fmt.Errorf("XOAUTH2 authentication failed: %s", string(decoded))
 			}
 			// Also try the full response in case it's just base64
 			if decoded, decodeErr := base64.StdEncoding.DecodeString(response); decodeErr == nil && len(decoded) > 0 {
-				return fmt.Errorf("XOAUTH2 authentication failed: %s", string(decoded))
+				return nil, fmt.Errorf("XOAUTH2 authentication failed: %s", string(decoded))
 			}
-			return fmt.Errorf("XOAUTH2 authentication failed with code: %d, response: %s", code, response)
+			return nil, fmt.Errorf("XOAUTH2 authentication failed with code: %d, response: %s", code, response)
 		}
 	authComplete:
 	} else {
@@ -268,16 +416,21 @@ func sendRawEmailWithSettings(settings *domain.SMTPSettings, from string, to []s
 			encoded := base64.StdEncoding.EncodeToString([]byte(authString))
 			code, _, err = smtpConn.sendCommand(fmt.Sprintf("AUTH PLAIN %s", encoded))
 			if err != nil {
-				return fmt.Errorf("AUTH failed: %w", err)
+				return nil, fmt.Errorf("AUTH failed: %w", err)
 			}
 			if code != 235 {
-				return fmt.Errorf("authentication failed with code: %d", code)
+				return nil, fmt.Errorf("authentication failed with code: %d", code)
 			}
 		}
 	}
 
+	return smtpConn, nil
+}
+
+// sendMessage runs one mail transaction (MAIL FROM, RCPT TO, DATA) on an open session
+func (c *smtpConnection) sendMessage(from string, to []string, msg []byte) error {
 	// MAIL FROM - without any extensions (this is the key fix for issue #172)
-	code, _, err = smtpConn.sendCommand(fmt.Sprintf("MAIL FROM:<%s>", from))
+	code, _, err := c.sendCommand(fmt.Sprintf("MAIL FROM:<%s>", from))
 	if err != nil {
 		return fmt.Errorf("MAIL FROM failed: %w", err)
 	}
@@ -290,7 +443,7 @@ func sendRawEmailWithSettings(settings *domain.SMTPSettings, from string, to []s
 		if recipient == "" {
 			continue
 		}
-		code, _, err = smtpConn.sendCommand(fmt.Sprintf("RCPT TO:<%s>", recipient))
+		code, _, err = c.sendCommand(fmt.Sprintf("RCPT TO:<%s>", recipient))
 		if err != nil {
 			return fmt.Errorf("RCPT TO failed for %s: %w", recipient, err)
 		}
@@ -300,7 +453,7 @@ func sendRawEmailWithSettings(settings *domain.SMTPSettings, from string, to []s
 	}
 
 	// DATA
-	code, _, err = smtpConn.sendCommand("DATA")
+	code, _, err = c.sendCommand("DATA")
 	if err != nil {
 		return fmt.Errorf("DATA command failed: %w", err)
 	}
@@ -315,7 +468,7 @@ func sendRawEmailWithSettings(settings *domain.SMTPSettings, from string, to []s
 	// The DotWriter handles this automatically and also sends the terminating
 	// CRLF.CRLF sequence when Close() is called.
 	// See: https://datatracker.ietf.org/doc/html/rfc5321#section-4.5.2
-	tw := textproto.NewWriter(bufio.NewWriter(smtpConn.conn))
+	tw := textproto.NewWriter(bufio.NewWriter(c.conn))
 	dw := tw.DotWriter()
 	if _, err := dw.Write(msg); err != nil {
 		dw.Close()
@@ -326,7 +479,7 @@ func sendRawEmailWithSettings(settings *domain.SMTPSettings, from string, to []s
 	}
 
 	// Read response after DATA
-	code, _, err = smtpConn.readResponse()
+	code, _, err = c.readResponse()
 	if err != nil {
 		return fmt.Errorf("failed to read DATA response: %w", err)
 	}
@@ -334,16 +487,214 @@ func sendRawEmailWithSettings(settings *domain.SMTPSettings, from string, to []s
 		return fmt.Errorf("message rejected with code: %d", code)
 	}
 
-	// QUIT
-	_, _, _ = smtpConn.sendCommand("QUIT")
-
 	return nil
 }
 
+// smtpConnectionLimiter caps the number of simultaneous connections opened to a relay
+type smtpConnectionLimiter struct {
+	limit int
+	slots chan struct{}
+}
+
+// acquire waits for a free connection slot, or returns when the context is done
+func (l *smtpConnectionLimiter) acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *smtpConnectionLimiter) release() {
+	<-l.slots
+}
+
+// held returns the number of slots taken by sends in flight
+func (l *smtpConnectionLimiter) held() int {
+	return len(l.slots)
+}
+
+// smtpPoolIdleTimeout is how long a pooled connection may sit idle before it is discarded
+// when SMTPSettings.IdleTimeoutSeconds is not set, well below the 5 minute server timeout
+// recommended by RFC 5321
+const smtpPoolIdleTimeout = 30 * time.Second
+
+// smtpPoolIdleTimeoutFor returns the configured idle timeout of pooled sessions
+func smtpPoolIdleTimeoutFor(settings *domain.SMTPSettings) time.Duration {
+	if settings.IdleTimeoutSeconds > 0 {
+		return time.Duration(settings.IdleTimeoutSeconds) * time.Second
+	}
+	return smtpPoolIdleTimeout
+}
+
+// smtpPoolMaxIdle returns how many idle sessions a pool keeps. Idle sessions count
+// against the relay's connection limit, so the pool never exceeds MaxConcurrentConnections;
+// sendPooled evicts idle sessions before dialling when sends in flight would exceed it.
+func smtpPoolMaxIdle(settings *domain.SMTPSettings) int {
+	if settings.MaxConcurrentConnections > 0 && settings.MaxIdleConnections > settings.MaxConcurrentConnections {
+		return settings.MaxConcurrentConnections
+	}
+	return settings.MaxIdleConnections
+}
+
+// pooledSMTPConnection is an authenticated session waiting in a pool
+type pooledSMTPConnection struct {
+	conn      *smtpConnection
+	idleSince time.Time
+}
+
+// smtpConnectionPool keeps authenticated sessions to a relay open between sends,
+// so consecutive messages skip the connect, EHLO, STARTTLS and AUTH round trips.
+// Sessions of all senders of an integration share the pool, so maxIdle bounds the
+// open idle sockets of the integration however many from addresses it sends with.
+type smtpConnectionPool struct {
+	mu          sync.Mutex
+	fingerprint string
+	maxIdle     int
+	idleTimeout time.Duration
+	idle        []pooledSMTPConnection
+
+	// Closes expired sessions while any are idle, so a pool that goes quiet releases its sockets
+	sweepTimer *time.Timer
+}
+
+// get returns an idle session of the sender reset with RSET, or nil when none is usable.
+// Sessions the server dropped while idle fail the RSET and are discarded.
+func (p *smtpConnectionPool) get(from string) *smtpConnection {
+	for {
+		p.mu.Lock()
+		// Most recently used first, it is the least likely to have been dropped by the server
+		i := len(p.idle) - 1
+		for i >= 0 && p.idle[i].conn.from != from {
+			i--
+		}
+		if i < 0 {
+			p.mu.Unlock()
+			return nil
+		}
+		pooled := p.idle[i]
+		p.idle = append(p.idle[:i], p.idle[i+1:]...)
+		p.mu.Unlock()
+
+		if time.Since(pooled.idleSince) > p.idleTimeout {
+			pooled.conn.quit()
+			continue
+		}
+		if err := pooled.conn.reset(); err != nil {
+			pooled.conn.Close()
+			continue
+		}
+		return pooled.conn
+	}
+}
+
+// put returns a session to the pool, closing it when the pool is full
+func (p *smtpConnectionPool) put(conn *smtpConnection) {
+	p.mu.Lock()
+	if len(p.idle) < p.maxIdle {
+		p.idle = append(p.idle, pooledSMTPConnection{conn: conn, idleSince: time.Now()})
+		conn = nil
+		if p.sweepTimer == nil {
+			p.sweepTimer = time.AfterFunc(p.idleTimeout, p.sweep)
+		}
+	}
+	p.mu.Unlock()
+
+	if conn != nil {
+		conn.quit()
+	}
+}
+
+// evict closes the oldest idle sessions until at most keep remain
+func (p *smtpConnectionPool) evict(keep int) {
+	if keep < 0 {
+		keep = 0
+	}
+
+	p.mu.Lock()
+	var evicted []pooledSMTPConnection
+	if len(p.idle) > keep {
+		evicted = append(evicted, p.idle[:len(p.idle)-keep]...)
+		p.idle = append([]pooledSMTPConnection(nil), p.idle[len(p.idle)-keep:]...)
+	}
+	p.mu.Unlock()
+
+	for _, pooled := range evicted {
+		pooled.conn.quit()
+	}
+}
+
+// sweep closes the sessions idle for longer than the timeout, and runs again when the
+// oldest remaining session expires
+func (p *smtpConnectionPool) sweep() {
+	p.mu.Lock()
+	p.sweepTimer = nil
+	var expired []pooledSMTPConnection
+	kept := make([]pooledSMTPConnection, 0, len(p.idle))
+	for _, pooled := range p.idle {
+		if time.Since(pooled.idleSince) >= p.idleTimeout {
+			expired = append(expired, pooled)
+		} else {
+			kept = append(kept, pooled)
+		}
+	}
+	p.idle = kept
+	// Sessions are returned in order, the first one is the oldest
+	if len(p.idle) > 0 {
+		p.sweepTimer = time.AfterFunc(p.idleTimeout-time.Since(p.idle[0].idleSince), p.sweep)
+	}
+	p.mu.Unlock()
+
+	for _, pooled := range expired {
+		pooled.conn.quit()
+	}
+}
+
+// drain closes all idle sessions
+func (p *smtpConnectionPool) drain() {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = nil
+	if p.sweepTimer != nil {
+		p.sweepTimer.Stop()
+		p.sweepTimer = nil
+	}
+	p.mu.Unlock()
+
+	for _, pooled := range idle {
+		pooled.conn.quit()
+	}
+}
+
+// smtpPoolFingerprint identifies the settings a pooled session was opened with,
+// so sessions are not reused after the integration is reconfigured
+func smtpPoolFingerprint(settings *domain.SMTPSettings) string {
+	return strings.Join([]string{
+		settings.Host,
+		fmt.Sprintf("%d", settings.Port),
+		settings.Username,
+		settings.Password,
+		settings.EffectiveTLSMode(),
+		settings.MinTLSVersion,
+		settings.EHLOHostname,
+		settings.AuthType,
+		settings.OAuth2ClientID,
+	}, "\x00")
+}
+
 // SMTPService implements the domain.EmailProviderService interface for SMTP
 type SMTPService struct {
 	logger         logger.Logger
 	oauth2Provider OAuth2TokenProvider
+
+	// Per-integration connection limiters, honoring SMTPSettings.MaxConcurrentConnections
+	limitersMu sync.Mutex
+	limiters   map[string]*smtpConnectionLimiter
+
+	// Per-integration pools of idle sessions, honoring SMTPSettings.MaxIdleConnections and IdleTimeoutSeconds
+	poolsMu sync.Mutex
+	pools   map[string]*smtpConnectionPool
 }
 
 // NewSMTPService creates a new instance of SMTPService
@@ -366,6 +717,123 @@ func (s *SMTPService) SetOAuth2Provider(provider OAuth2TokenProvider) {
 	s.oauth2Provider = provider
 }
 
+// connectionLimiter returns the limiter of an integration, or nil when its connections are unlimited.
+// A limiter is replaced when the configured limit changes; sends holding a slot of the old one finish normally.
+func (s *SMTPService) connectionLimiter(workspaceID, integrationID string, settings *domain.SMTPSettings) *smtpConnectionLimiter {
+	if settings.MaxConcurrentConnections <= 0 {
+		return nil
+	}
+
+	key := workspaceID + ":" + integrationID
+
+	s.limitersMu.Lock()
+	defer s.limitersMu.Unlock()
+
+	if s.limiters == nil {
+		s.limiters = make(map[string]*smtpConnectionLimiter)
+	}
+	limiter, ok := s.limiters[key]
+	if !ok || limiter.limit != settings.MaxConcurrentConnections {
+		limiter = &smtpConnectionLimiter{
+			limit: settings.MaxConcurrentConnections,
+			slots: make(chan struct{}, settings.MaxConcurrentConnections),
+		}
+		s.limiters[key] = limiter
+	}
+	return limiter
+}
+
+// connectionPool returns the pool of idle sessions for an integration, or nil when pooling is disabled.
+// The pool is shared by all senders so its idle sessions stay within the integration's connection limit.
+func (s *SMTPService) connectionPool(workspaceID, integrationID string, settings *domain.SMTPSettings) *smtpConnectionPool {
+	if settings.MaxIdleConnections <= 0 {
+		return nil
+	}
+
+	key := workspaceID + ":" + integrationID
+	fingerprint := smtpPoolFingerprint(settings)
+	maxIdle := smtpPoolMaxIdle(settings)
+	idleTimeout := smtpPoolIdleTimeoutFor(settings)
+
+	s.poolsMu.Lock()
+	defer s.poolsMu.Unlock()
+
+	if s.pools == nil {
+		s.pools = make(map[string]*smtpConnectionPool)
+	}
+	pool, ok := s.pools[key]
+	if !ok || pool.fingerprint != fingerprint || pool.maxIdle != maxIdle || pool.idleTimeout != idleTimeout {
+		if ok {
+			go pool.drain()
+		}
+		pool = &smtpConnectionPool{
+			fingerprint: fingerprint,
+			maxIdle:     maxIdle,
+			idleTimeout: idleTimeout,
+		}
+		s.pools[key] = pool
+	}
+	return pool
+}
+
+// sendPooled sends a message over an idle session of the pool when one is available,
+// opening a new session otherwise, and returns the session to the pool afterwards.
+// A session that fails mid-transaction is closed rather than pooled, as its state is unknown.
+// The limiter, when set, is held by the caller: idle sessions of other senders are closed
+// before dialling so idle and in-flight sessions together stay within the relay's limit.
+func (s *SMTPService) sendPooled(ctx context.Context, pool *smtpConnectionPool, limiter *smtpConnectionLimiter, settings *domain.SMTPSettings, from string, to []string, msg []byte) error {
+	conn := pool.get(from)
+	if conn == nil {
+		if limiter != nil {
+			pool.evict(limiter.limit - limiter.held())
+		}
+
+		var err error
+		conn, err = openSMTPSessionContext(ctx, settings, from, s.oauth2Provider)
+		if err != nil {
+			return err
+		}
+	}
+
+	stop := conn.interruptOn(ctx)
+	err := conn.sendMessage(from, to, msg)
+	if !stop() {
+		conn.Close()
+		if err != nil {
+			return fmt.Errorf("SMTP send interrupted: %w", ctx.Err())
+		}
+		// The server accepted the message before ctx ended
+		return nil
+	}
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	pool.put(conn)
+	return nil
+}
+
+// smtpRootCAs verifies SMTP server certificates, nil uses the system roots.
+// Can be overridden in tests to trust a self-signed mock server.
+var smtpRootCAs *x509.CertPool
+
+// smtpMinTLSVersion maps the configured minimum TLS version, keeping TLS 1.2 as the default
+func smtpMinTLSVersion(settings *domain.SMTPSettings) uint16 {
+	if settings.MinTLSVersion == domain.SMTPTLSVersion13 {
+		return tls.VersionTLS13
+	}
+	return tls.VersionTLS12
+}
+
+// smtpBodyEncoding maps the configured body encoding to go-mail, keeping quoted-printable as the default
+func smtpBodyEncoding(settings *domain.SMTPSettings) mail.Encoding {
+	if settings.BodyEncoding == domain.SMTPBodyEncodingBase64 {
+		return mail.EncodingB64
+	}
+	return mail.EncodingQP
+}
+
 // SendEmail sends an email using SMTP
 func (s *SMTPService) SendEmail(ctx context.Context, request domain.SendEmailProviderRequest) error {
 	// Validate the request
@@ -380,7 +848,7 @@ func (s *SMTPService) SendEmail(ctx context.Context, request domain.SendEmailPro
 	smtpSettings := request.Provider.SMTP
 
 	// Create and configure the message using go-mail for MIME composition
-	msg := mail.NewMsg(mail.WithNoDefaultUserAgent())
+	msg := mail.NewMsg(mail.WithNoDefaultUserAgent(), mail.WithEncoding(smtpBodyEncoding(smtpSettings)))
 
 	if err := msg.FromFormat(request.FromName, request.FromAddress); err != nil {
 		return fmt.Errorf("invalid sender: %w", err)
@@ -436,12 +904,25 @@ func (s *SMTPService) SendEmail(ctx context.Context, request domain.SendEmailPro
 
 	// Add RFC-8058 List-Unsubscribe headers for one-click unsubscribe
 	if request.EmailOptions.ListUnsubscribeURL != "" {
-		msg.SetGenHeader("List-Unsubscribe", fmt.Sprintf("<%s>", request.EmailOptions.ListUnsubscribeURL))
+		msg.SetGenHeader("List-Unsubscribe", request.EmailOptions.ListUnsubscribeHeader())
 		msg.SetGenHeader("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
 	}
 
+	// Mark marketing emails as bulk so auto-responders don't answer them
+	for _, header := range request.EmailOptions.BulkHeaderFields() {
+		msg.SetGenHeader(mail.Header(header.Name), header.Value)
+	}
+
 	msg.Subject(request.Subject)
-	msg.SetBodyString(mail.TypeTextHTML, request.Content)
+
+	// Send a text/plain alternative so clients that don't render HTML don't show a blank body.
+	// With inline images go-mail wraps the alternative part in multipart/related, next to the embeds.
+	textContent := request.TextContent
+	if textContent == "" {
+		textContent = htmlToText(request.Content)
+	}
+	msg.SetBodyString(mail.TypeTextPlain, textContent)
+	msg.AddAlternativeString(mail.TypeTextHTML, request.Content)
 
 	// Add attachments if specified
 	for i, att := range request.EmailOptions.Attachments {
@@ -480,9 +961,27 @@ func (s *SMTPService) SendEmail(ctx context.Context, request domain.SendEmailPro
 		return fmt.Errorf("failed to write message: %w", err)
 	}
 
+	// Wait for a connection slot when the relay caps simultaneous connections
+	limiter := s.connectionLimiter(request.WorkspaceID, request.IntegrationID, smtpSettings)
+	if limiter != nil {
+		if err := limiter.acquire(ctx); err != nil {
+			return fmt.Errorf("failed to acquire SMTP connection slot: %w", err)
+		}
+		defer limiter.release()
+	}
+
+	// Reuse an authenticated session when the integration keeps idle connections
+	if pool := s.connectionPool(request.WorkspaceID, request.IntegrationID, smtpSettings); pool != nil {
+		if err := s.sendPooled(ctx, pool, limiter, smtpSettings, request.FromAddress, recipients, buf.Bytes()); err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+		return nil
+	}
+
 	// Send using native net/smtp (avoids BODY=8BITMIME extension issues - fix for issue #172)
 	// Use sendRawEmailWithSettings for OAuth2 support
-	if err := sendRawEmailWithSettings(
+	if err := sendRawEmailContext(
+		ctx,
 		smtpSettings,
 		request.FromAddress,
 		recipients,
@@ -494,3 +993,25 @@ func (s *SMTPService) SendEmail(ctx context.Context, request domain.SendEmailPro
 
 	return nil
 }
+
+// CheckConnection runs the SMTP handshake (EHLO, STARTTLS, AUTH) against the relay and
+// quits without sending, so a misconfigured integration is reported before a send starts
+func (s *SMTPService) CheckConnection(ctx context.Context, provider *domain.EmailProvider) error {
+	if provider == nil || provider.SMTP == nil {
+		return fmt.Errorf("SMTP settings required")
+	}
+
+	// XOAUTH2 authenticates as the sender mailbox, and EHLO falls back to its domain
+	from := ""
+	if len(provider.Senders) > 0 {
+		from = provider.Senders[0].Email
+	}
+
+	smtpConn, err := openSMTPSession(provider.SMTP, from, s.oauth2Provider)
+	if err != nil {
+		return err
+	}
+	smtpConn.quit()
+
+	return nil
+}
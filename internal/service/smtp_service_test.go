@@ -3,9 +3,22 @@ package service
 import (
 	"bufio"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
 	"net"
+	netmail "net/mail"
+	"net/textproto"
 	"strings"
 	"sync"
 	"testing"
@@ -26,8 +39,15 @@ type mockSMTPServer struct {
 	authSuccess     bool
 	closed          bool
 	wg              sync.WaitGroup
-	mailFromCmd     string // captures the exact MAIL FROM command
-	multilineBanner bool   // send multi-line 220 banner (RFC 5321 compliant)
+	mailFromCmd     string      // captures the exact MAIL FROM command
+	multilineBanner bool        // send multi-line 220 banner (RFC 5321 compliant)
+	tlsConfig       *tls.Config // advertise STARTTLS and upgrade connections with this config
+	implicitTLS     bool        // negotiate TLS with tlsConfig before the greeting instead of advertising STARTTLS
+
+	// concurrency tracking: sessions are counted from accept until QUIT is answered
+	greetingDelay time.Duration
+	active        int
+	maxActive     int
 }
 
 type capturedMessage struct {
@@ -72,6 +92,62 @@ func newMockSMTPServerWithMultilineBanner(t *testing.T, authSuccess bool) *mockS
 	return server
 }
 
+// newSelfSignedCertificate creates a certificate for 127.0.0.1 and a pool trusting it
+func newSelfSignedCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(leaf)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, roots
+}
+
+// trustMockSMTPServer makes the SMTP client trust the mock server certificate for the test duration
+func trustMockSMTPServer(t *testing.T, roots *x509.CertPool) {
+	previous := smtpRootCAs
+	smtpRootCAs = roots
+	t.Cleanup(func() { smtpRootCAs = previous })
+}
+
+// newMockSMTPServerWithTLS creates a mock SMTP server advertising STARTTLS with a
+// self-signed certificate, negotiating TLS versions up to maxVersion only
+func newMockSMTPServerWithTLS(t *testing.T, maxVersion uint16) *mockSMTPServer {
+	server, _ := newMockSMTPServerWithCertificate(t, maxVersion, false)
+	return server
+}
+
+// newMockSMTPServerWithCertificate creates a mock SMTP server with a self-signed certificate,
+// either advertising STARTTLS or, when implicit is set, negotiating TLS before the greeting.
+// It returns the pool trusting the certificate.
+func newMockSMTPServerWithCertificate(t *testing.T, maxVersion uint16, implicit bool) (*mockSMTPServer, *x509.CertPool) {
+	cert, roots := newSelfSignedCertificate(t)
+
+	server := newMockSMTPServer(t, true)
+	server.mu.Lock()
+	server.tlsConfig = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MaxVersion:   maxVersion,
+	}
+	server.implicitTLS = implicit
+	server.mu.Unlock()
+	return server, roots
+}
+
 func (s *mockSMTPServer) serve() {
 	defer s.wg.Done()
 	for {
@@ -94,6 +170,40 @@ func (s *mockSMTPServer) handleConnection(conn net.Conn) {
 	defer s.wg.Done()
 	defer conn.Close()
 
+	s.mu.Lock()
+	s.active++
+	if s.active > s.maxActive {
+		s.maxActive = s.active
+	}
+	greetingDelay := s.greetingDelay
+	tlsConfig := s.tlsConfig
+	implicitTLS := s.implicitTLS
+	s.mu.Unlock()
+
+	ended := false
+	endSession := func() {
+		if ended {
+			return
+		}
+		ended = true
+		s.mu.Lock()
+		s.active--
+		s.mu.Unlock()
+	}
+	defer endSession()
+
+	// Hold the session open so concurrent clients overlap
+	time.Sleep(greetingDelay)
+
+	if implicitTLS {
+		tlsConn := tls.Server(conn, tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			return
+		}
+		conn = tlsConn
+		tlsConfig = nil
+	}
+
 	reader := bufio.NewReader(conn)
 
 	// Send greeting (multi-line or single-line based on configuration)
@@ -119,13 +229,15 @@ func (s *mockSMTPServer) handleConnection(conn net.Conn) {
 			return
 		}
 
+		// DATA lines are kept verbatim, folded headers start with whitespace
+		rawLine := strings.TrimRight(line, "\r\n")
 		line = strings.TrimSpace(line)
 		s.mu.Lock()
 		s.commands = append(s.commands, line)
 		s.mu.Unlock()
 
 		if inData {
-			if line == "." {
+			if rawLine == "." {
 				inData = false
 				s.mu.Lock()
 				s.messages = append(s.messages, capturedMessage{
@@ -137,7 +249,7 @@ func (s *mockSMTPServer) handleConnection(conn net.Conn) {
 				conn.Write([]byte("250 OK message queued\r\n"))
 				continue
 			}
-			dataBuffer.WriteString(line + "\r\n")
+			dataBuffer.WriteString(rawLine + "\r\n")
 			continue
 		}
 
@@ -149,8 +261,21 @@ func (s *mockSMTPServer) handleConnection(conn net.Conn) {
 			conn.Write([]byte("250-8BITMIME\r\n")) // Advertise 8BITMIME to test that we don't use it
 			conn.Write([]byte("250-SMTPUTF8\r\n")) // Advertise SMTPUTF8 to test that we don't use it
 			conn.Write([]byte("250-SIZE 10485760\r\n"))
+			if tlsConfig != nil {
+				conn.Write([]byte("250-STARTTLS\r\n"))
+			}
 			conn.Write([]byte("250 AUTH PLAIN LOGIN\r\n"))
 
+		case strings.HasPrefix(upperLine, "STARTTLS") && tlsConfig != nil:
+			conn.Write([]byte("220 Ready to start TLS\r\n"))
+			tlsConn := tls.Server(conn, tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			tlsConfig = nil
+			reader = bufio.NewReader(conn)
+
 		case strings.HasPrefix(upperLine, "AUTH"):
 			if s.authSuccess {
 				conn.Write([]byte("235 Authentication successful\r\n"))
@@ -184,6 +309,8 @@ func (s *mockSMTPServer) handleConnection(conn net.Conn) {
 			conn.Write([]byte("354 Start mail input\r\n"))
 
 		case strings.HasPrefix(upperLine, "QUIT"):
+			// End the session before answering, so the client can't start a new one first
+			endSession()
 			conn.Write([]byte("221 Bye\r\n"))
 			return
 
@@ -231,6 +358,20 @@ func (s *mockSMTPServer) GetMessages() []capturedMessage {
 	return result
 }
 
+// SetGreetingDelay delays the greeting of each new session
+func (s *mockSMTPServer) SetGreetingDelay(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.greetingDelay = d
+}
+
+// MaxConcurrentConnections returns the highest number of simultaneous sessions seen
+func (s *mockSMTPServer) MaxConcurrentConnections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.maxActive
+}
+
 func (s *mockSMTPServer) GetCommands() []string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -320,6 +461,7 @@ func TestSendRawEmail_AuthFailure(t *testing.T) {
 
 	err := sendRawEmail("127.0.0.1", port, "user", "pass", false, "sender@example.com", []string{"recipient@example.com"}, msg)
 	require.Error(t, err)
+	assert.True(t, domain.IsSMTPSessionError(err), "auth failures mark the session as unusable")
 }
 
 func TestSendRawEmail_ConnectionError(t *testing.T) {
@@ -328,6 +470,7 @@ func TestSendRawEmail_ConnectionError(t *testing.T) {
 
 	err := sendRawEmail("127.0.0.1", 59999, "", "", false, "sender@example.com", []string{"recipient@example.com"}, msg)
 	require.Error(t, err)
+	assert.True(t, domain.IsSMTPSessionError(err), "connection failures mark the session as unusable")
 }
 
 func TestSendRawEmail_MultipleRecipients(t *testing.T) {
@@ -432,6 +575,113 @@ func TestSMTPService_SendEmail_Integration(t *testing.T) {
 	assert.NotContains(t, mailFromCmd, "SMTPUTF8")
 }
 
+func TestSMTPService_SendEmail_MaxConcurrentConnections(t *testing.T) {
+	server := newMockSMTPServer(t, true)
+	server.SetGreetingDelay(50 * time.Millisecond)
+	defer server.Close()
+
+	service := NewSMTPService(&noopLogger{})
+	provider := &domain.EmailProvider{
+		Kind: domain.EmailProviderKindSMTP,
+		SMTP: &domain.SMTPSettings{
+			Host:                     "127.0.0.1",
+			Port:                     server.Port(),
+			MaxConcurrentConnections: 2,
+		},
+	}
+
+	const count = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- service.SendEmail(context.Background(), domain.SendEmailProviderRequest{
+				WorkspaceID:   "workspace-123",
+				IntegrationID: "integration-123",
+				MessageID:     fmt.Sprintf("message-%d", i),
+				FromAddress:   "sender@example.com",
+				FromName:      "Test Sender",
+				To:            fmt.Sprintf("recipient-%d@example.com", i),
+				Subject:       "Test Subject",
+				Content:       "<p>Hello</p>",
+				Provider:      provider,
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, server.GetMessages(), count)
+	assert.Equal(t, 2, server.MaxConcurrentConnections(), "should never exceed the configured limit")
+
+	t.Run("context cancelled while waiting for a slot", func(t *testing.T) {
+		blocked := &domain.EmailProvider{
+			Kind: domain.EmailProviderKindSMTP,
+			SMTP: &domain.SMTPSettings{Host: "127.0.0.1", Port: server.Port(), MaxConcurrentConnections: 1},
+		}
+		limiter := service.connectionLimiter("workspace-123", "integration-456", blocked.SMTP)
+		require.NoError(t, limiter.acquire(context.Background()))
+		defer limiter.release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := service.SendEmail(ctx, domain.SendEmailProviderRequest{
+			WorkspaceID:   "workspace-123",
+			IntegrationID: "integration-456",
+			MessageID:     "message-blocked",
+			FromAddress:   "sender@example.com",
+			FromName:      "Test Sender",
+			To:            "recipient@example.com",
+			Subject:       "Test Subject",
+			Content:       "<p>Hello</p>",
+			Provider:      blocked,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to acquire SMTP connection slot")
+	})
+}
+
+func TestSMTPService_SendEmail_UnlimitedConnections(t *testing.T) {
+	server := newMockSMTPServer(t, true)
+	server.SetGreetingDelay(50 * time.Millisecond)
+	defer server.Close()
+
+	service := NewSMTPService(&noopLogger{})
+	provider := &domain.EmailProvider{
+		Kind: domain.EmailProviderKindSMTP,
+		SMTP: &domain.SMTPSettings{Host: "127.0.0.1", Port: server.Port()},
+	}
+	assert.Nil(t, service.connectionLimiter("workspace-123", "integration-123", provider.SMTP))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := service.SendEmail(context.Background(), domain.SendEmailProviderRequest{
+				WorkspaceID:   "workspace-123",
+				IntegrationID: "integration-123",
+				MessageID:     fmt.Sprintf("message-%d", i),
+				FromAddress:   "sender@example.com",
+				FromName:      "Test Sender",
+				To:            fmt.Sprintf("recipient-%d@example.com", i),
+				Subject:       "Test Subject",
+				Content:       "<p>Hello</p>",
+				Provider:      provider,
+			})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Greater(t, server.MaxConcurrentConnections(), 1, "connections should not be serialized without a limit")
+}
+
 func TestSMTPService_SendEmail_DefaultEhloUsesFromDomain(t *testing.T) {
 	server := newMockSMTPServer(t, true)
 	defer server.Close()
@@ -528,6 +778,554 @@ func TestSMTPService_SendEmail_CustomEHLOHostname(t *testing.T) {
 	assert.True(t, foundEhlo, "Should have sent an EHLO command")
 }
 
+func TestSMTPService_SendEmail_MinTLSVersion(t *testing.T) {
+	// The server only speaks up to TLS 1.2
+	server := newMockSMTPServerWithTLS(t, tls.VersionTLS12)
+	defer server.Close()
+
+	service := NewSMTPService(&noopLogger{})
+
+	provider := &domain.EmailProvider{
+		Kind: domain.EmailProviderKindSMTP,
+		SMTP: &domain.SMTPSettings{
+			Host:          "127.0.0.1",
+			Port:          server.Port(),
+			UseTLS:        true,
+			MinTLSVersion: domain.SMTPTLSVersion13,
+		},
+	}
+
+	request := domain.SendEmailProviderRequest{
+		WorkspaceID:   "workspace-123",
+		IntegrationID: "integration-123",
+		MessageID:     "message-123",
+		FromAddress:   "sender@example.com",
+		FromName:      "Test Sender",
+		To:            "recipient@example.com",
+		Subject:       "Test Subject",
+		Content:       "<h1>Hello</h1>",
+		Provider:      provider,
+		EmailOptions:  domain.EmailOptions{},
+	}
+
+	err := service.SendEmail(context.Background(), request)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "TLS handshake failed")
+	assert.Contains(t, err.Error(), "protocol version")
+	assert.Empty(t, server.GetMessages())
+}
+
+func TestSMTPService_SendEmail_TLSModes(t *testing.T) {
+	newRequest := func(port int, tlsMode string) domain.SendEmailProviderRequest {
+		return domain.SendEmailProviderRequest{
+			WorkspaceID:   "workspace-123",
+			IntegrationID: "integration-123",
+			MessageID:     "message-123",
+			FromAddress:   "sender@example.com",
+			FromName:      "Test Sender",
+			To:            "recipient@example.com",
+			Subject:       "Test Subject",
+			Content:       "<h1>Hello</h1>",
+			Provider: &domain.EmailProvider{
+				Kind: domain.EmailProviderKindSMTP,
+				SMTP: &domain.SMTPSettings{
+					Host:     "127.0.0.1",
+					Port:     port,
+					Username: "user",
+					Password: "pass",
+					TLSMode:  tlsMode,
+				},
+			},
+		}
+	}
+
+	commandIndex := func(commands []string, prefix string) int {
+		for i, cmd := range commands {
+			if strings.HasPrefix(strings.ToUpper(cmd), prefix) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	t.Run("starttls upgrades before authenticating", func(t *testing.T) {
+		server, roots := newMockSMTPServerWithCertificate(t, tls.VersionTLS13, false)
+		defer server.Close()
+		trustMockSMTPServer(t, roots)
+
+		service := NewSMTPService(&noopLogger{})
+		require.NoError(t, service.SendEmail(context.Background(), newRequest(server.Port(), domain.SMTPTLSModeStartTLS)))
+		require.Len(t, server.GetMessages(), 1)
+
+		commands := server.GetCommands()
+		starttls := commandIndex(commands, "STARTTLS")
+		require.NotEqual(t, -1, starttls)
+		assert.Less(t, starttls, commandIndex(commands, "AUTH"), "credentials must only be sent after the TLS upgrade")
+		assert.True(t, strings.HasPrefix(strings.ToUpper(commands[starttls+1]), "EHLO"), "EHLO must be repeated after STARTTLS")
+	})
+
+	t.Run("starttls not advertised", func(t *testing.T) {
+		server := newMockSMTPServer(t, true)
+		defer server.Close()
+
+		service := NewSMTPService(&noopLogger{})
+		err := service.SendEmail(context.Background(), newRequest(server.Port(), domain.SMTPTLSModeStartTLS))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "server does not advertise STARTTLS")
+		assert.True(t, domain.IsSMTPSessionError(err))
+		assert.Equal(t, -1, commandIndex(server.GetCommands(), "AUTH"), "credentials must not be sent in cleartext")
+		assert.Empty(t, server.GetMessages())
+	})
+
+	t.Run("implicit TLS negotiates before the greeting", func(t *testing.T) {
+		server, roots := newMockSMTPServerWithCertificate(t, tls.VersionTLS13, true)
+		defer server.Close()
+		trustMockSMTPServer(t, roots)
+
+		service := NewSMTPService(&noopLogger{})
+		require.NoError(t, service.SendEmail(context.Background(), newRequest(server.Port(), domain.SMTPTLSModeImplicit)))
+		require.Len(t, server.GetMessages(), 1)
+		assert.Equal(t, -1, commandIndex(server.GetCommands(), "STARTTLS"))
+	})
+
+	t.Run("implicit TLS against a plaintext server", func(t *testing.T) {
+		server := newMockSMTPServer(t, true)
+		defer server.Close()
+
+		service := NewSMTPService(&noopLogger{})
+		err := service.SendEmail(context.Background(), newRequest(server.Port(), domain.SMTPTLSModeImplicit))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "TLS handshake failed")
+		assert.Empty(t, server.GetMessages())
+	})
+}
+
+func TestSMTPService_SendEmail_PooledConnectionReuse(t *testing.T) {
+	server := newMockSMTPServer(t, true)
+	defer server.Close()
+
+	service := NewSMTPService(&noopLogger{})
+	settings := &domain.SMTPSettings{
+		Host:               "127.0.0.1",
+		Port:               server.Port(),
+		Username:           "user",
+		Password:           "pass",
+		MaxIdleConnections: 1,
+	}
+	provider := &domain.EmailProvider{Kind: domain.EmailProviderKindSMTP, SMTP: settings}
+
+	for i := 0; i < 2; i++ {
+		err := service.SendEmail(context.Background(), domain.SendEmailProviderRequest{
+			WorkspaceID:   "workspace-123",
+			IntegrationID: "integration-123",
+			MessageID:     fmt.Sprintf("message-%d", i),
+			FromAddress:   "sender@example.com",
+			FromName:      "Test Sender",
+			To:            fmt.Sprintf("recipient-%d@example.com", i),
+			Subject:       "Test Subject",
+			Content:       "<p>Hello</p>",
+			Provider:      provider,
+		})
+		require.NoError(t, err)
+	}
+
+	messages := server.GetMessages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, []string{"recipient-1@example.com"}, messages[1].recipients)
+
+	countPrefix := func(prefix string) int {
+		count := 0
+		for _, cmd := range server.GetCommands() {
+			if strings.HasPrefix(strings.ToUpper(cmd), prefix) {
+				count++
+			}
+		}
+		return count
+	}
+	assert.Equal(t, 1, countPrefix("EHLO"), "second message should reuse the session without a new EHLO")
+	assert.Equal(t, 1, countPrefix("AUTH"), "second message should not authenticate again")
+	assert.Equal(t, 1, countPrefix("RSET"), "pooled session should be reset between messages")
+	assert.Equal(t, 0, countPrefix("QUIT"), "pooled session should stay open")
+
+	// Capabilities from the initial EHLO are cached on the pooled session
+	pool := service.connectionPool("workspace-123", "integration-123", settings)
+	require.Len(t, pool.idle, 1)
+	assert.Equal(t, "10485760", pool.idle[0].conn.capabilities["SIZE"])
+	assert.Equal(t, "PLAIN LOGIN", pool.idle[0].conn.capabilities["AUTH"])
+	assert.Contains(t, pool.idle[0].conn.capabilities, "8BITMIME")
+
+	pool.drain()
+	assert.Equal(t, 1, countPrefix("QUIT"))
+}
+
+func TestSMTPService_SendEmail_PooledConnectionReuseOverStartTLS(t *testing.T) {
+	server, roots := newMockSMTPServerWithCertificate(t, tls.VersionTLS13, false)
+	defer server.Close()
+	trustMockSMTPServer(t, roots)
+
+	service := NewSMTPService(&noopLogger{})
+	settings := &domain.SMTPSettings{
+		Host:               "127.0.0.1",
+		Port:               server.Port(),
+		Username:           "user",
+		Password:           "pass",
+		TLSMode:            domain.SMTPTLSModeStartTLS,
+		MaxIdleConnections: 1,
+	}
+	provider := &domain.EmailProvider{Kind: domain.EmailProviderKindSMTP, SMTP: settings}
+
+	for i := 0; i < 2; i++ {
+		err := service.SendEmail(context.Background(), domain.SendEmailProviderRequest{
+			WorkspaceID:   "workspace-123",
+			IntegrationID: "integration-123",
+			MessageID:     fmt.Sprintf("message-%d", i),
+			FromAddress:   "sender@example.com",
+			FromName:      "Test Sender",
+			To:            fmt.Sprintf("recipient-%d@example.com", i),
+			Subject:       "Test Subject",
+			Content:       "<p>Hello</p>",
+			Provider:      provider,
+		})
+		require.NoError(t, err)
+	}
+	require.Len(t, server.GetMessages(), 2)
+
+	countPrefix := func(prefix string) int {
+		count := 0
+		for _, cmd := range server.GetCommands() {
+			if strings.HasPrefix(strings.ToUpper(cmd), prefix) {
+				count++
+			}
+		}
+		return count
+	}
+	assert.Equal(t, 1, countPrefix("STARTTLS"), "second message should reuse the upgraded session")
+	assert.Equal(t, 2, countPrefix("EHLO"), "EHLO is only repeated once, after the upgrade")
+	assert.Equal(t, 1, countPrefix("AUTH"), "second message should not authenticate again")
+	assert.Equal(t, 1, countPrefix("RSET"), "pooled session should be reset between messages")
+
+	// The upgraded session keeps its sender and the capabilities advertised over TLS
+	pool := service.connectionPool("workspace-123", "integration-123", settings)
+	require.Len(t, pool.idle, 1)
+	assert.Equal(t, "sender@example.com", pool.idle[0].conn.from)
+	assert.NotContains(t, pool.idle[0].conn.capabilities, "STARTTLS")
+
+	pool.drain()
+}
+
+func TestSMTPService_SendEmail_PooledConnectionDropped(t *testing.T) {
+	server := newMockSMTPServer(t, true)
+	defer server.Close()
+
+	service := NewSMTPService(&noopLogger{})
+	settings := &domain.SMTPSettings{
+		Host:               "127.0.0.1",
+		Port:               server.Port(),
+		Username:           "user",
+		Password:           "pass",
+		MaxIdleConnections: 1,
+	}
+	provider := &domain.EmailProvider{Kind: domain.EmailProviderKindSMTP, SMTP: settings}
+
+	send := func(i int) error {
+		return service.SendEmail(context.Background(), domain.SendEmailProviderRequest{
+			WorkspaceID:   "workspace-123",
+			IntegrationID: "integration-123",
+			MessageID:     fmt.Sprintf("message-%d", i),
+			FromAddress:   "sender@example.com",
+			FromName:      "Test Sender",
+			To:            fmt.Sprintf("recipient-%d@example.com", i),
+			Subject:       "Test Subject",
+			Content:       "<p>Hello</p>",
+			Provider:      provider,
+		})
+	}
+
+	require.NoError(t, send(0))
+
+	// Simulate the server closing the idle session
+	pool := service.connectionPool("workspace-123", "integration-123", settings)
+	require.Len(t, pool.idle, 1)
+	pool.idle[0].conn.Close()
+
+	require.NoError(t, send(1), "a dropped session should be replaced transparently")
+	require.Len(t, server.GetMessages(), 2)
+
+	ehloCount := 0
+	for _, cmd := range server.GetCommands() {
+		if strings.HasPrefix(strings.ToUpper(cmd), "EHLO") {
+			ehloCount++
+		}
+	}
+	assert.Equal(t, 2, ehloCount, "a new session should be opened after the drop")
+
+	require.Len(t, pool.idle, 1)
+	pool.drain()
+}
+
+func TestSMTPService_SendEmail_PooledSendersShareIdleCap(t *testing.T) {
+	server := newMockSMTPServer(t, true)
+	defer server.Close()
+
+	service := NewSMTPService(&noopLogger{})
+	settings := &domain.SMTPSettings{
+		Host:                     "127.0.0.1",
+		Port:                     server.Port(),
+		MaxIdleConnections:       5,
+		MaxConcurrentConnections: 2,
+	}
+	provider := &domain.EmailProvider{Kind: domain.EmailProviderKindSMTP, SMTP: settings}
+
+	for i := 0; i < 4; i++ {
+		err := service.SendEmail(context.Background(), domain.SendEmailProviderRequest{
+			WorkspaceID:   "workspace-123",
+			IntegrationID: "integration-123",
+			MessageID:     fmt.Sprintf("message-%d", i),
+			FromAddress:   fmt.Sprintf("sender-%d@example.com", i),
+			FromName:      "Test Sender",
+			To:            "recipient@example.com",
+			Subject:       "Test Subject",
+			Content:       "<p>Hello</p>",
+			Provider:      provider,
+		})
+		require.NoError(t, err)
+	}
+	require.Len(t, server.GetMessages(), 4)
+
+	// Each sender opened its own session, but the integration keeps at most its connection limit open
+	pool := service.connectionPool("workspace-123", "integration-123", settings)
+	assert.Len(t, pool.idle, 2)
+	assert.Equal(t, 2, server.MaxConcurrentConnections(), "idle sessions should be evicted before dialling past the limit")
+	pool.drain()
+}
+
+func TestSMTPService_SendEmail_ContextDeadline(t *testing.T) {
+	for _, maxIdle := range []int{0, 1} {
+		t.Run(fmt.Sprintf("max idle %d", maxIdle), func(t *testing.T) {
+			server := newMockSMTPServer(t, true)
+			defer server.Close()
+			// The server stalls longer than the caller is willing to wait
+			server.SetGreetingDelay(time.Second)
+
+			service := NewSMTPService(&noopLogger{})
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			err := service.SendEmail(ctx, domain.SendEmailProviderRequest{
+				WorkspaceID:   "workspace-123",
+				IntegrationID: "integration-123",
+				MessageID:     "message-123",
+				FromAddress:   "sender@example.com",
+				FromName:      "Test Sender",
+				To:            "recipient@example.com",
+				Subject:       "Test Subject",
+				Content:       "<p>Hello</p>",
+				Provider: &domain.EmailProvider{
+					Kind: domain.EmailProviderKindSMTP,
+					SMTP: &domain.SMTPSettings{Host: "127.0.0.1", Port: server.Port(), MaxIdleConnections: maxIdle},
+				},
+			})
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+			assert.Less(t, time.Since(start), 500*time.Millisecond, "the send should stop when its context ends")
+			assert.Empty(t, server.GetMessages())
+		})
+	}
+}
+
+func TestSMTPService_CheckConnection(t *testing.T) {
+	newProvider := func(port int) *domain.EmailProvider {
+		return &domain.EmailProvider{
+			Kind:    domain.EmailProviderKindSMTP,
+			Senders: []domain.EmailSender{domain.NewEmailSender("sender@example.com", "Sender")},
+			SMTP: &domain.SMTPSettings{
+				Host:     "127.0.0.1",
+				Port:     port,
+				Username: "user",
+				Password: "pass",
+			},
+		}
+	}
+
+	t.Run("handshake succeeds without sending", func(t *testing.T) {
+		server := newMockSMTPServer(t, true)
+		defer server.Close()
+
+		service := NewSMTPService(&noopLogger{})
+		require.NoError(t, service.CheckConnection(context.Background(), newProvider(server.Port())))
+
+		assert.Empty(t, server.GetMessages())
+		// QUIT is read by the server goroutine after the client returns
+		assert.Eventually(t, func() bool {
+			commands := server.GetCommands()
+			return len(commands) > 0 && strings.EqualFold(commands[len(commands)-1], "QUIT")
+		}, time.Second, 10*time.Millisecond)
+		for _, cmd := range server.GetCommands() {
+			assert.False(t, strings.HasPrefix(strings.ToUpper(cmd), "MAIL FROM"), "probe must not start a mail transaction")
+		}
+	})
+
+	t.Run("authentication failure", func(t *testing.T) {
+		server := newMockSMTPServer(t, false)
+		defer server.Close()
+
+		service := NewSMTPService(&noopLogger{})
+		err := service.CheckConnection(context.Background(), newProvider(server.Port()))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "authentication failed with code: 535")
+	})
+
+	t.Run("connection refused", func(t *testing.T) {
+		service := NewSMTPService(&noopLogger{})
+		err := service.CheckConnection(context.Background(), newProvider(59999))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to connect")
+	})
+
+	t.Run("missing SMTP settings", func(t *testing.T) {
+		service := NewSMTPService(&noopLogger{})
+		err := service.CheckConnection(context.Background(), &domain.EmailProvider{Kind: domain.EmailProviderKindSMTP})
+		assert.EqualError(t, err, "SMTP settings required")
+	})
+}
+
+func TestSMTPConnectionPool(t *testing.T) {
+	server := newMockSMTPServer(t, true)
+	defer server.Close()
+
+	settings := &domain.SMTPSettings{Host: "127.0.0.1", Port: server.Port(), MaxIdleConnections: 1}
+
+	t.Run("disabled without max idle connections", func(t *testing.T) {
+		service := NewSMTPService(&noopLogger{})
+		assert.Nil(t, service.connectionPool("ws", "int", &domain.SMTPSettings{Host: "127.0.0.1", Port: 25}))
+	})
+
+	t.Run("replaced when settings change", func(t *testing.T) {
+		service := NewSMTPService(&noopLogger{})
+		pool := service.connectionPool("ws", "int", settings)
+		assert.Same(t, pool, service.connectionPool("ws", "int", settings))
+
+		changed := *settings
+		changed.EHLOHostname = "mail.example.com"
+		assert.NotSame(t, pool, service.connectionPool("ws", "int", &changed))
+	})
+
+	t.Run("expired sessions are not reused", func(t *testing.T) {
+		conn, err := openSMTPSession(settings, "sender@example.com", nil)
+		require.NoError(t, err)
+
+		pool := &smtpConnectionPool{maxIdle: 1, idleTimeout: smtpPoolIdleTimeout}
+		pool.idle = append(pool.idle, pooledSMTPConnection{conn: conn, idleSince: time.Now().Add(-2 * smtpPoolIdleTimeout)})
+		assert.Nil(t, pool.get("sender@example.com"))
+		assert.Empty(t, pool.idle)
+	})
+
+	t.Run("expired sessions are closed when the pool goes quiet", func(t *testing.T) {
+		quietServer := newMockSMTPServer(t, true)
+		defer quietServer.Close()
+
+		quietSettings := &domain.SMTPSettings{Host: "127.0.0.1", Port: quietServer.Port(), MaxIdleConnections: 2}
+		first, err := openSMTPSession(quietSettings, "sender@example.com", nil)
+		require.NoError(t, err)
+		second, err := openSMTPSession(quietSettings, "sender@example.com", nil)
+		require.NoError(t, err)
+
+		pool := &smtpConnectionPool{maxIdle: 2, idleTimeout: 100 * time.Millisecond}
+		pool.put(first)
+		time.Sleep(50 * time.Millisecond)
+		pool.put(second)
+
+		// No further sends: the sweep alone closes both sessions, each once it expires
+		idleCount := func() int {
+			pool.mu.Lock()
+			defer pool.mu.Unlock()
+			return len(pool.idle)
+		}
+		require.Eventually(t, func() bool { return idleCount() == 1 }, time.Second, 5*time.Millisecond)
+		require.Eventually(t, func() bool { return idleCount() == 0 }, time.Second, 5*time.Millisecond)
+
+		quitCount := func() int {
+			count := 0
+			for _, cmd := range quietServer.GetCommands() {
+				if strings.EqualFold(cmd, "QUIT") {
+					count++
+				}
+			}
+			return count
+		}
+		assert.Eventually(t, func() bool { return quitCount() == 2 }, time.Second, 5*time.Millisecond,
+			"expired sessions should be closed with QUIT")
+	})
+
+	t.Run("configured idle timeout", func(t *testing.T) {
+		service := NewSMTPService(&noopLogger{})
+		assert.Equal(t, smtpPoolIdleTimeout, service.connectionPool("ws", "int", settings).idleTimeout)
+
+		changed := *settings
+		changed.IdleTimeoutSeconds = 120
+		pool := service.connectionPool("ws", "int", &changed)
+		assert.Equal(t, 120*time.Second, pool.idleTimeout)
+	})
+
+	t.Run("idle sessions capped by the connection limit", func(t *testing.T) {
+		service := NewSMTPService(&noopLogger{})
+		limited := *settings
+		limited.MaxIdleConnections = 10
+		limited.MaxConcurrentConnections = 2
+		assert.Equal(t, 2, service.connectionPool("ws", "int", &limited).maxIdle)
+	})
+
+	t.Run("dropped sessions are discarded", func(t *testing.T) {
+		conn, err := openSMTPSession(settings, "sender@example.com", nil)
+		require.NoError(t, err)
+		conn.Close()
+
+		pool := &smtpConnectionPool{maxIdle: 1, idleTimeout: smtpPoolIdleTimeout}
+		pool.put(conn)
+		assert.Nil(t, pool.get("sender@example.com"))
+		assert.Empty(t, pool.idle)
+	})
+
+	t.Run("full pool closes returned sessions", func(t *testing.T) {
+		first, err := openSMTPSession(settings, "sender@example.com", nil)
+		require.NoError(t, err)
+		second, err := openSMTPSession(settings, "sender@example.com", nil)
+		require.NoError(t, err)
+
+		pool := &smtpConnectionPool{maxIdle: 1, idleTimeout: smtpPoolIdleTimeout}
+		pool.put(first)
+		pool.put(second)
+		assert.Len(t, pool.idle, 1)
+		assert.Same(t, first, pool.get("sender@example.com"))
+		first.quit()
+	})
+
+	t.Run("senders share the idle cap", func(t *testing.T) {
+		first, err := openSMTPSession(settings, "first@example.com", nil)
+		require.NoError(t, err)
+		second, err := openSMTPSession(settings, "second@example.com", nil)
+		require.NoError(t, err)
+
+		pool := &smtpConnectionPool{maxIdle: 1, idleTimeout: smtpPoolIdleTimeout}
+		pool.put(first)
+		pool.put(second)
+		assert.Len(t, pool.idle, 1)
+
+		// Sessions are only reused by the sender they were opened for
+		assert.Nil(t, pool.get("second@example.com"))
+		assert.Same(t, first, pool.get("first@example.com"))
+		first.quit()
+	})
+}
+
+func TestSMTPMinTLSVersion(t *testing.T) {
+	assert.Equal(t, uint16(tls.VersionTLS12), smtpMinTLSVersion(&domain.SMTPSettings{}))
+	assert.Equal(t, uint16(tls.VersionTLS12), smtpMinTLSVersion(&domain.SMTPSettings{MinTLSVersion: domain.SMTPTLSVersion12}))
+	assert.Equal(t, uint16(tls.VersionTLS13), smtpMinTLSVersion(&domain.SMTPSettings{MinTLSVersion: domain.SMTPTLSVersion13}))
+}
+
 func TestSMTPService_SendEmail_WithCCAndBCC(t *testing.T) {
 	server := newMockSMTPServer(t, true)
 	defer server.Close()
@@ -705,6 +1503,44 @@ func TestSMTPService_SendEmail_WithListUnsubscribe(t *testing.T) {
 	assert.Contains(t, string(messages[0].data), "List-Unsubscribe-Post:")
 }
 
+func TestSMTPService_SendEmail_BulkHeaders(t *testing.T) {
+	server := newMockSMTPServer(t, true)
+	defer server.Close()
+
+	service := NewSMTPService(&noopLogger{})
+	provider := &domain.EmailProvider{
+		Kind: domain.EmailProviderKindSMTP,
+		SMTP: &domain.SMTPSettings{Host: "127.0.0.1", Port: server.Port()},
+	}
+
+	send := func(messageID string, bulk bool) {
+		err := service.SendEmail(context.Background(), domain.SendEmailProviderRequest{
+			WorkspaceID:   "workspace-123",
+			IntegrationID: "integration-123",
+			MessageID:     messageID,
+			FromAddress:   "sender@example.com",
+			FromName:      "Test Sender",
+			To:            "recipient@example.com",
+			Subject:       "Test Subject",
+			Content:       "<h1>Hello</h1>",
+			Provider:      provider,
+			EmailOptions:  domain.EmailOptions{BulkHeaders: bulk},
+		})
+		require.NoError(t, err)
+	}
+
+	// Broadcast send, then transactional send
+	send("broadcast-message", true)
+	send("transactional-message", false)
+
+	messages := server.GetMessages()
+	require.Len(t, messages, 2)
+	assert.Contains(t, string(messages[0].data), "Precedence: bulk\r\n")
+	assert.Contains(t, string(messages[0].data), "Auto-Submitted: auto-generated\r\n")
+	assert.NotContains(t, string(messages[1].data), "Precedence:")
+	assert.NotContains(t, string(messages[1].data), "Auto-Submitted:")
+}
+
 func TestSMTPService_SendEmail_InlineAttachment(t *testing.T) {
 	server := newMockSMTPServer(t, true)
 	defer server.Close()
@@ -1086,13 +1922,15 @@ func (s *mockOAuth2SMTPServer) handleOAuth2Connection(conn net.Conn) {
 			return
 		}
 
+		// DATA lines are kept verbatim, folded headers start with whitespace
+		rawLine := strings.TrimRight(line, "\r\n")
 		line = strings.TrimSpace(line)
 		s.mu.Lock()
 		s.commands = append(s.commands, line)
 		s.mu.Unlock()
 
 		if inData {
-			if line == "." {
+			if rawLine == "." {
 				inData = false
 				s.mu.Lock()
 				s.messages = append(s.messages, capturedMessage{
@@ -1104,7 +1942,7 @@ func (s *mockOAuth2SMTPServer) handleOAuth2Connection(conn net.Conn) {
 				conn.Write([]byte("250 OK message queued\r\n"))
 				continue
 			}
-			dataBuffer.WriteString(line + "\r\n")
+			dataBuffer.WriteString(rawLine + "\r\n")
 			continue
 		}
 
@@ -1478,13 +2316,15 @@ func (s *mockOAuth2SMTPServerWithRetry) handleConnectionWithRetry(conn net.Conn)
 			return
 		}
 
+		// DATA lines are kept verbatim, folded headers start with whitespace
+		rawLine := strings.TrimRight(line, "\r\n")
 		line = strings.TrimSpace(line)
 		s.mu.Lock()
 		s.commands = append(s.commands, line)
 		s.mu.Unlock()
 
 		if inData {
-			if line == "." {
+			if rawLine == "." {
 				inData = false
 				s.mu.Lock()
 				s.messages = append(s.messages, capturedMessage{
@@ -1496,7 +2336,7 @@ func (s *mockOAuth2SMTPServerWithRetry) handleConnectionWithRetry(conn net.Conn)
 				conn.Write([]byte("250 OK message queued\r\n"))
 				continue
 			}
-			dataBuffer.WriteString(line + "\r\n")
+			dataBuffer.WriteString(rawLine + "\r\n")
 			continue
 		}
 
@@ -1805,3 +2645,203 @@ func TestDotStuffingIntegration(t *testing.T) {
 	assert.Contains(t, receivedData, "..com/path/to/image.png",
 		"Expected dot-stuffed content (double dot) but got: %s", receivedData)
 }
+
+// capturedPart is a decoded part of a captured message
+type capturedPart struct {
+	contentType string
+	encoding    string
+	body        string
+}
+
+// decodeCapturedParts undoes SMTP dot-stuffing on a captured message and returns its
+// parts in order, walking nested multipart bodies. Each multipart container is
+// also returned, with an empty body, so tests can check the MIME structure.
+func decodeCapturedParts(t *testing.T, data []byte) []capturedPart {
+	unstuffed := strings.ReplaceAll("\r\n"+string(data), "\r\n..", "\r\n.")[2:]
+
+	msg, err := netmail.ReadMessage(strings.NewReader(unstuffed))
+	require.NoError(t, err)
+
+	var parts []capturedPart
+	var walk func(header textproto.MIMEHeader, body io.Reader)
+	walk = func(header textproto.MIMEHeader, body io.Reader) {
+		mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+		require.NoError(t, err)
+
+		if strings.HasPrefix(mediaType, "multipart/") {
+			parts = append(parts, capturedPart{contentType: mediaType})
+			reader := multipart.NewReader(body, params["boundary"])
+			for {
+				part, err := reader.NextRawPart()
+				if err == io.EOF {
+					return
+				}
+				require.NoError(t, err)
+				walk(part.Header, part)
+			}
+		}
+
+		encoding := header.Get("Content-Transfer-Encoding")
+		switch encoding {
+		case "quoted-printable":
+			body = quotedprintable.NewReader(body)
+		case "base64":
+			body = base64.NewDecoder(base64.StdEncoding, body)
+		}
+		decoded, err := io.ReadAll(body)
+		require.NoError(t, err)
+		parts = append(parts, capturedPart{contentType: mediaType, encoding: encoding, body: string(decoded)})
+	}
+	walk(textproto.MIMEHeader(msg.Header), msg.Body)
+	return parts
+}
+
+// findCapturedPart returns the first part of the given media type
+func findCapturedPart(t *testing.T, parts []capturedPart, contentType string) capturedPart {
+	for _, part := range parts {
+		if part.contentType == contentType {
+			return part
+		}
+	}
+	require.Failf(t, "part not found", "no %s part in the captured message", contentType)
+	return capturedPart{}
+}
+
+func TestSMTPService_SendEmail_BodyEncoding(t *testing.T) {
+	// The second line starts with a period, which must survive SMTP dot-stuffing
+	// regardless of the Content-Transfer-Encoding
+	content := "<p>Visit https://www.example.com/a/very/long/path/that/forces/a/soft/line/break/in/quoted/printable</p>\r\n" +
+		".com/path/to/image.png\r\n<p>Caf\u00e9 &amp; cr\u00e8me</p>"
+
+	tests := []struct {
+		name             string
+		bodyEncoding     string
+		expectedEncoding string
+	}{
+		{"default is quoted-printable", "", "quoted-printable"},
+		{"explicit quoted-printable", domain.SMTPBodyEncodingQuotedPrintable, "quoted-printable"},
+		{"base64", domain.SMTPBodyEncodingBase64, "base64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockSMTPServer(t, true)
+			defer server.Close()
+
+			service := NewSMTPService(&noopLogger{})
+			request := domain.SendEmailProviderRequest{
+				WorkspaceID:   "workspace-123",
+				IntegrationID: "integration-123",
+				MessageID:     "message-123",
+				FromAddress:   "sender@example.com",
+				FromName:      "Test Sender",
+				To:            "recipient@example.com",
+				Subject:       "Encoding",
+				Content:       content,
+				Provider: &domain.EmailProvider{
+					Kind: domain.EmailProviderKindSMTP,
+					SMTP: &domain.SMTPSettings{
+						Host:         "127.0.0.1",
+						Port:         server.Port(),
+						BodyEncoding: tt.bodyEncoding,
+					},
+				},
+			}
+
+			err := service.SendEmail(context.Background(), request)
+			require.NoError(t, err)
+
+			messages := server.GetMessages()
+			require.Len(t, messages, 1)
+
+			htmlPart := findCapturedPart(t, decodeCapturedParts(t, messages[0].data), "text/html")
+			assert.Equal(t, tt.expectedEncoding, htmlPart.encoding)
+			assert.Equal(t, content, strings.TrimRight(htmlPart.body, "\r\n"))
+		})
+	}
+}
+
+func TestSMTPService_SendEmail_TextAlternative(t *testing.T) {
+	htmlContent := `<h1>Welcome</h1><p>Read <a href="https://example.com/post">the post</a></p><img src="cid:logo.png">`
+
+	tests := []struct {
+		name          string
+		textContent   string
+		attachments   []domain.Attachment
+		expectedText  string
+		expectedTypes []string
+	}{
+		{
+			name:          "explicit text content",
+			textContent:   "Welcome, read the post at https://example.com/post",
+			expectedText:  "Welcome, read the post at https://example.com/post",
+			expectedTypes: []string{"multipart/alternative", "text/plain", "text/html"},
+		},
+		{
+			name:          "text generated from the HTML",
+			expectedText:  "Welcome\n\nRead the post (https://example.com/post)",
+			expectedTypes: []string{"multipart/alternative", "text/plain", "text/html"},
+		},
+		{
+			name: "inline image and attachment",
+			attachments: []domain.Attachment{
+				{Filename: "logo.png", Content: "iVBORw0KGgo=", ContentType: "image/png", Disposition: "inline"},
+				{Filename: "terms.txt", Content: "VGVybXM=", ContentType: "text/plain", Disposition: "attachment"},
+			},
+			expectedText: "Welcome\n\nRead the post (https://example.com/post)",
+			expectedTypes: []string{
+				"multipart/mixed",
+				"multipart/related",
+				"multipart/alternative", "text/plain", "text/html",
+				"image/png",
+				"text/plain",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMockSMTPServer(t, true)
+			defer server.Close()
+
+			service := NewSMTPService(&noopLogger{})
+			request := domain.SendEmailProviderRequest{
+				WorkspaceID:   "workspace-123",
+				IntegrationID: "integration-123",
+				MessageID:     "message-123",
+				FromAddress:   "sender@example.com",
+				FromName:      "Test Sender",
+				To:            "recipient@example.com",
+				Subject:       "Alternative",
+				Content:       htmlContent,
+				TextContent:   tt.textContent,
+				Provider: &domain.EmailProvider{
+					Kind: domain.EmailProviderKindSMTP,
+					SMTP: &domain.SMTPSettings{Host: "127.0.0.1", Port: server.Port()},
+				},
+				EmailOptions: domain.EmailOptions{Attachments: tt.attachments},
+			}
+
+			err := service.SendEmail(context.Background(), request)
+			require.NoError(t, err)
+
+			messages := server.GetMessages()
+			require.Len(t, messages, 1)
+
+			parts := decodeCapturedParts(t, messages[0].data)
+			types := make([]string, 0, len(parts))
+			for _, part := range parts {
+				types = append(types, part.contentType)
+			}
+			// The text part comes first, clients display the last alternative they support
+			assert.Equal(t, tt.expectedTypes, types)
+
+			// go-mail writes the text part with CRLF line endings
+			textPart := findCapturedPart(t, parts, "text/plain")
+			assert.Equal(t, tt.expectedText, strings.TrimRight(strings.ReplaceAll(textPart.body, "\r\n", "\n"), "\n"))
+
+			htmlPart := findCapturedPart(t, parts, "text/html")
+			assert.Equal(t, htmlContent, strings.TrimRight(htmlPart.body, "\r\n"))
+		})
+	}
+}
@@ -860,11 +860,19 @@ func (s *SparkPostService) SendEmail(ctx context.Context, request domain.SendEma
 	// Add RFC-8058 List-Unsubscribe headers for one-click unsubscribe
 	if request.EmailOptions.ListUnsubscribeURL != "" {
 		emailReq.Content.Headers = map[string]string{
-			"List-Unsubscribe":      fmt.Sprintf("<%s>", request.EmailOptions.ListUnsubscribeURL),
+			"List-Unsubscribe":      request.EmailOptions.ListUnsubscribeHeader(),
 			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
 		}
 	}
 
+	// Mark marketing emails as bulk so auto-responders don't answer them
+	for _, header := range request.EmailOptions.BulkHeaderFields() {
+		if emailReq.Content.Headers == nil {
+			emailReq.Content.Headers = make(map[string]string)
+		}
+		emailReq.Content.Headers[header.Name] = header.Value
+	}
+
 	// Add CC recipients if specified
 	for _, ccAddress := range request.EmailOptions.CC {
 		if ccAddress != "" {
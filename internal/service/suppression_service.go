@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+type SuppressionService struct {
+	repo        domain.SuppressionRepository
+	authService domain.AuthService
+	logger      logger.Logger
+}
+
+func NewSuppressionService(
+	repo domain.SuppressionRepository,
+	authService domain.AuthService,
+	logger logger.Logger,
+) *SuppressionService {
+	return &SuppressionService{
+		repo:        repo,
+		authService: authService,
+		logger:      logger,
+	}
+}
+
+// ImportSuppressions upserts a list of addresses into the workspace suppression list.
+// Invalid entries are reported in the response without failing the import, and an
+// address listed several times is imported once with its last reason.
+func (s *SuppressionService) ImportSuppressions(ctx context.Context, req *domain.ImportSuppressionsRequest) (*domain.ImportSuppressionsResponse, error) {
+	var err error
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Check permission
+	if !userWorkspace.HasPermission(domain.PermissionResourceContacts, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceContacts,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to contacts required for suppressions",
+		)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, domain.NewValidationError(fmt.Sprintf("invalid request: %s", err.Error()))
+	}
+
+	now := time.Now().UTC()
+	response := &domain.ImportSuppressionsResponse{Failed: []domain.SuppressionImportError{}}
+	suppressions := make([]*domain.Suppression, 0, len(req.Suppressions))
+	indexByEmail := make(map[string]int, len(req.Suppressions))
+
+	for _, suppression := range req.Suppressions {
+		suppression.Normalize()
+		if err := suppression.Validate(); err != nil {
+			response.Failed = append(response.Failed, domain.SuppressionImportError{
+				Email: suppression.Email,
+				Error: err.Error(),
+			})
+			continue
+		}
+		suppression.CreatedAt = now
+		suppression.UpdatedAt = now
+
+		// ON CONFLICT cannot touch the same row twice in one statement
+		if i, ok := indexByEmail[suppression.Email]; ok {
+			suppressions[i] = suppression
+			continue
+		}
+		indexByEmail[suppression.Email] = len(suppressions)
+		suppressions = append(suppressions, suppression)
+	}
+
+	if err := s.repo.BulkUpsert(ctx, req.WorkspaceID, suppressions); err != nil {
+		s.logger.WithField("error", err.Error()).Error("Failed to import suppressions")
+		return nil, fmt.Errorf("failed to import suppressions: %w", err)
+	}
+	response.Imported = len(suppressions)
+
+	s.logger.WithFields(map[string]interface{}{
+		"workspace_id": req.WorkspaceID,
+		"imported":     response.Imported,
+		"failed":       len(response.Failed),
+	}).Info("Suppressions imported successfully")
+
+	return response, nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSuppressionServiceTest(t *testing.T) (
+	*mocks.MockSuppressionRepository,
+	*mocks.MockAuthService,
+	*SuppressionService,
+) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRepo := mocks.NewMockSuppressionRepository(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	return mockRepo, mockAuthService, NewSuppressionService(mockRepo, mockAuthService, mockLogger)
+}
+
+func TestSuppressionService_ImportSuppressions(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace123"
+
+	writer := &domain.UserWorkspace{
+		WorkspaceID: workspaceID,
+		UserID:      "user123",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceContacts: domain.ResourcePermissions{Read: true, Write: true},
+		},
+	}
+
+	t.Run("imports valid addresses and reports invalid ones", func(t *testing.T) {
+		mockRepo, mockAuthService, service := setupSuppressionServiceTest(t)
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).
+			Return(ctx, &domain.User{ID: "user123"}, writer, nil)
+
+		var upserted []*domain.Suppression
+		mockRepo.EXPECT().BulkUpsert(gomock.Any(), workspaceID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, suppressions []*domain.Suppression) error {
+				upserted = suppressions
+				return nil
+			})
+
+		resp, err := service.ImportSuppressions(ctx, &domain.ImportSuppressionsRequest{
+			WorkspaceID: workspaceID,
+			CSV:         "email,reason\nA@Example.com,bounce\nnot-an-email,bounce\nb@example.com\na@example.com,complaint\n",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, resp.Imported)
+		require.Len(t, resp.Failed, 1)
+		assert.Equal(t, "not-an-email", resp.Failed[0].Email)
+
+		// Duplicates are collapsed, keeping the last reason
+		require.Len(t, upserted, 2)
+		assert.Equal(t, "a@example.com", upserted[0].Email)
+		assert.Equal(t, domain.SuppressionReasonComplaint, upserted[0].Reason)
+		assert.Equal(t, "b@example.com", upserted[1].Email)
+		assert.Equal(t, domain.SuppressionReasonManual, upserted[1].Reason)
+		assert.False(t, upserted[0].CreatedAt.IsZero())
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		_, mockAuthService, service := setupSuppressionServiceTest(t)
+
+		reader := &domain.UserWorkspace{
+			WorkspaceID: workspaceID,
+			UserID:      "user123",
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceContacts: domain.ResourcePermissions{Read: true},
+			},
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).
+			Return(ctx, &domain.User{ID: "user123"}, reader, nil)
+
+		_, err := service.ImportSuppressions(ctx, &domain.ImportSuppressionsRequest{
+			WorkspaceID:  workspaceID,
+			Suppressions: []*domain.Suppression{{Email: "a@example.com"}},
+		})
+		require.Error(t, err)
+		var permErr *domain.PermissionError
+		assert.ErrorAs(t, err, &permErr)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		_, mockAuthService, service := setupSuppressionServiceTest(t)
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).
+			Return(ctx, &domain.User{ID: "user123"}, writer, nil)
+
+		_, err := service.ImportSuppressions(ctx, &domain.ImportSuppressionsRequest{WorkspaceID: workspaceID})
+		require.Error(t, err)
+		_, ok := err.(domain.ValidationError)
+		assert.True(t, ok)
+	})
+
+	t.Run("authentication error", func(t *testing.T) {
+		_, mockAuthService, service := setupSuppressionServiceTest(t)
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).
+			Return(ctx, nil, nil, errors.New("auth error"))
+
+		_, err := service.ImportSuppressions(ctx, &domain.ImportSuppressionsRequest{WorkspaceID: workspaceID})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to authenticate user")
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo, mockAuthService, service := setupSuppressionServiceTest(t)
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).
+			Return(ctx, &domain.User{ID: "user123"}, writer, nil)
+		mockRepo.EXPECT().BulkUpsert(gomock.Any(), workspaceID, gomock.Any()).Return(errors.New("db error"))
+
+		_, err := service.ImportSuppressions(ctx, &domain.ImportSuppressionsRequest{
+			WorkspaceID:  workspaceID,
+			Suppressions: []*domain.Suppression{{Email: "a@example.com"}},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to import suppressions")
+	})
+}
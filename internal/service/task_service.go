@@ -769,10 +769,29 @@ func (s *TaskService) SubscribeToBroadcastEvents(eventBus domain.EventBus) {
 	eventBus.Subscribe(domain.EventBroadcastSent, s.handleBroadcastSent)
 	eventBus.Subscribe(domain.EventBroadcastFailed, s.handleBroadcastFailed)
 	eventBus.Subscribe(domain.EventBroadcastCancelled, s.handleBroadcastCancelled)
+	eventBus.Subscribe(domain.EventBroadcastUnscheduled, s.handleBroadcastUnscheduled)
 
 	s.logger.Info("TaskService subscribed to broadcast events")
 }
 
+// listJoinBroadcastInterval is how often, in seconds, a list-join broadcast looks for
+// list members who have reached the join offset
+const listJoinBroadcastInterval int64 = 300
+
+// setListJoinBroadcastTask turns a send_broadcast task into the recurring task that
+// sends a list-join broadcast to members as they reach the join offset
+func setListJoinBroadcastTask(task *domain.Task) {
+	interval := listJoinBroadcastInterval
+	task.RecurringInterval = &interval
+	if task.State == nil {
+		task.State = &domain.TaskState{}
+	}
+	if task.State.SendBroadcast == nil {
+		task.State.SendBroadcast = &domain.SendBroadcastState{ChannelType: "email"}
+	}
+	task.State.SendBroadcast.ListJoin = true
+}
+
 // Event handlers for broadcast events
 func (s *TaskService) handleBroadcastScheduled(ctx context.Context, payload domain.EventPayload) {
 	ctx, span := tracing.StartServiceSpan(ctx, "TaskService", "handleBroadcastScheduled")
@@ -791,6 +810,7 @@ func (s *TaskService) handleBroadcastScheduled(ctx context.Context, payload doma
 	// Extract payload data before transaction (needed after commit for immediate execution)
 	sendNow, _ := payload.Data["send_now"].(bool)
 	status, _ := payload.Data["status"].(string)
+	listJoin, _ := payload.Data["list_join"].(bool)
 
 	// Track whether we should trigger immediate execution after commit
 	shouldExecuteImmediately := false
@@ -836,6 +856,10 @@ func (s *TaskService) handleBroadcastScheduled(ctx context.Context, payload doma
 					existingTask.BroadcastID = &broadcastIDCopy
 				}
 
+				if listJoin {
+					setListJoinBroadcastTask(existingTask)
+				}
+
 				if updateErr := s.repo.Update(txCtx, payload.WorkspaceID, existingTask); updateErr != nil {
 					tracing.MarkSpanError(txCtx, updateErr)
 					s.logger.WithFields(map[string]interface{}{
@@ -881,6 +905,9 @@ func (s *TaskService) handleBroadcastScheduled(ctx context.Context, payload doma
 			MaxRetries:    3,
 			RetryInterval: 300, // 5 minutes
 		}
+		if listJoin {
+			setListJoinBroadcastTask(task)
+		}
 
 		// If the broadcast is set to send immediately, we don't need to set NextRunAfter
 		// If it's scheduled for the future, we should set NextRunAfter based on the schedule
@@ -1263,6 +1290,55 @@ func (s *TaskService) handleBroadcastCancelled(ctx context.Context, payload doma
 	}
 }
 
+// handleBroadcastUnscheduled deletes the send task of a broadcast reverted to draft, so
+// scheduling it again creates a fresh task for the new time
+func (s *TaskService) handleBroadcastUnscheduled(ctx context.Context, payload domain.EventPayload) {
+	ctx, span := tracing.StartServiceSpan(ctx, "TaskService", "handleBroadcastUnscheduled")
+	defer tracing.EndSpan(span, nil)
+
+	tracing.AddAttribute(ctx, "workspace_id", payload.WorkspaceID)
+	tracing.AddAttribute(ctx, "event_type", payload.Type)
+
+	broadcastID, ok := payload.Data["broadcast_id"].(string)
+	if !ok || broadcastID == "" {
+		err := fmt.Errorf("missing or invalid broadcast_id")
+		tracing.MarkSpanError(ctx, err)
+		s.logger.Error("Failed to handle broadcast unscheduled event: missing or invalid broadcast_id")
+		return
+	}
+
+	tracing.AddAttribute(ctx, "broadcast_id", broadcastID)
+
+	s.logger.WithFields(map[string]interface{}{
+		"broadcast_id": broadcastID,
+		"workspace_id": payload.WorkspaceID,
+	}).Info("Handling broadcast unscheduled event")
+
+	// Find associated task by broadcast ID
+	task, err := s.repo.GetTaskByBroadcastID(ctx, payload.WorkspaceID, broadcastID)
+	if err != nil {
+		tracing.MarkSpanError(ctx, err)
+		s.logger.WithField("error", err.Error()).Debug("No task found for unscheduled broadcast")
+		return
+	}
+
+	tracing.AddAttribute(ctx, "task_id", task.ID)
+
+	if err := s.repo.Delete(ctx, payload.WorkspaceID, task.ID); err != nil {
+		tracing.MarkSpanError(ctx, err)
+		s.logger.WithFields(map[string]interface{}{
+			"broadcast_id": broadcastID,
+			"task_id":      task.ID,
+			"error":        err.Error(),
+		}).Error("Failed to delete task for unscheduled broadcast")
+	} else {
+		s.logger.WithFields(map[string]interface{}{
+			"broadcast_id": broadcastID,
+			"task_id":      task.ID,
+		}).Info("Successfully deleted task for unscheduled broadcast")
+	}
+}
+
 // ResetTask resets a failed recurring task, clearing error state and rescheduling for immediate execution
 func (s *TaskService) ResetTask(ctx context.Context, workspace, taskID string) error {
 	ctx, span := tracing.StartServiceSpan(ctx, "TaskService", "ResetTask")
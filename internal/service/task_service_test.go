@@ -866,6 +866,7 @@ func TestTaskService_BroadcastEventHandlers(t *testing.T) {
 	mockEventBus.EXPECT().Subscribe(domain.EventBroadcastSent, gomock.Any()).Times(1)
 	mockEventBus.EXPECT().Subscribe(domain.EventBroadcastFailed, gomock.Any()).Times(1)
 	mockEventBus.EXPECT().Subscribe(domain.EventBroadcastCancelled, gomock.Any()).Times(1)
+	mockEventBus.EXPECT().Subscribe(domain.EventBroadcastUnscheduled, gomock.Any()).Times(1)
 
 	// Disable auto-execution for testing to avoid goroutine issues
 	taskService.SetAutoExecuteImmediate(false)
@@ -1879,6 +1880,67 @@ func TestTaskService_HandleBroadcastCancelled(t *testing.T) {
 	})
 }
 
+func TestTaskService_HandleBroadcastUnscheduled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockSettingRepo := mocks.NewMockSettingRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	var mockAuthService *AuthService = nil
+
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	taskService := NewTaskService(mockRepo, mockSettingRepo, mockLogger, mockAuthService, "http://localhost:8080")
+	taskService.SetAutoExecuteImmediate(false)
+
+	workspaceID := "workspace1"
+	broadcastID := "broadcast123"
+	payload := domain.EventPayload{
+		Type:        domain.EventBroadcastUnscheduled,
+		WorkspaceID: workspaceID,
+		EntityID:    broadcastID,
+		Data: map[string]interface{}{
+			"broadcast_id": broadcastID,
+		},
+	}
+
+	t.Run("Deletes the pending task", func(t *testing.T) {
+		nextRun := time.Now().Add(24 * time.Hour)
+		task := &domain.Task{
+			ID:           "task456",
+			WorkspaceID:  workspaceID,
+			Type:         "send_broadcast",
+			Status:       domain.TaskStatusPending,
+			BroadcastID:  &broadcastID,
+			NextRunAfter: &nextRun,
+		}
+
+		mockRepo.EXPECT().GetTaskByBroadcastID(gomock.Any(), workspaceID, broadcastID).Return(task, nil)
+		mockRepo.EXPECT().Delete(gomock.Any(), workspaceID, task.ID).Return(nil)
+
+		taskService.handleBroadcastUnscheduled(context.Background(), payload)
+	})
+
+	t.Run("Handles task not found", func(t *testing.T) {
+		mockRepo.EXPECT().GetTaskByBroadcastID(gomock.Any(), workspaceID, broadcastID).Return(nil, errors.New("task not found"))
+
+		taskService.handleBroadcastUnscheduled(context.Background(), payload)
+	})
+
+	t.Run("Handles missing broadcast ID", func(t *testing.T) {
+		taskService.handleBroadcastUnscheduled(context.Background(), domain.EventPayload{
+			Type:        domain.EventBroadcastUnscheduled,
+			WorkspaceID: workspaceID,
+			Data:        map[string]interface{}{},
+		})
+	})
+}
+
 func TestTaskService_HandleBroadcastScheduledExtended(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -2009,6 +2071,46 @@ func TestTaskService_HandleBroadcastScheduledExtended(t *testing.T) {
 		taskService.handleBroadcastScheduled(ctx, payload)
 	})
 
+	t.Run("Creates a recurring task for list-join broadcast", func(t *testing.T) {
+		ctx := context.Background()
+		workspaceID := "workspace1"
+		broadcastID := "broadcastListJoin"
+
+		payload := domain.EventPayload{
+			Type:        domain.EventBroadcastScheduled,
+			WorkspaceID: workspaceID,
+			EntityID:    broadcastID,
+			Data: map[string]interface{}{
+				"send_now":  true,
+				"status":    string(domain.BroadcastStatusProcessing),
+				"list_join": true,
+			},
+		}
+
+		mockRepo.EXPECT().
+			WithTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, fn func(*sql.Tx) error) error {
+				return fn(nil)
+			})
+
+		mockRepo.EXPECT().
+			GetTaskByBroadcastID(gomock.Any(), workspaceID, broadcastID).
+			Return(nil, errors.New("not found"))
+
+		mockRepo.EXPECT().
+			Create(gomock.Any(), workspaceID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, task *domain.Task) error {
+				assert.True(t, task.IsRecurring())
+				assert.Equal(t, listJoinBroadcastInterval, *task.RecurringInterval)
+				assert.NotNil(t, task.State.SendBroadcast)
+				assert.True(t, task.State.SendBroadcast.ListJoin)
+				assert.Equal(t, broadcastID, task.State.SendBroadcast.BroadcastID)
+				return nil
+			})
+
+		taskService.handleBroadcastScheduled(ctx, payload)
+	})
+
 	t.Run("Handles task creation error", func(t *testing.T) {
 		// Setup
 		ctx := context.Background()
@@ -23,6 +23,7 @@ type TransactionalNotificationService struct {
 	templateService    domain.TemplateService
 	contactService     domain.ContactService
 	emailService       domain.EmailServiceInterface
+	emailQueueRepo     domain.EmailQueueRepository
 	authService        domain.AuthService
 	logger             logger.Logger
 	workspaceRepo      domain.WorkspaceRepository
@@ -36,6 +37,7 @@ func NewTransactionalNotificationService(
 	templateService domain.TemplateService,
 	contactService domain.ContactService,
 	emailService domain.EmailServiceInterface,
+	emailQueueRepo domain.EmailQueueRepository,
 	authService domain.AuthService,
 	logger logger.Logger,
 	workspaceRepo domain.WorkspaceRepository,
@@ -47,6 +49,7 @@ func NewTransactionalNotificationService(
 		templateService:    templateService,
 		contactService:     contactService,
 		emailService:       emailService,
+		emailQueueRepo:     emailQueueRepo,
 		authService:        authService,
 		logger:             logger,
 		workspaceRepo:      workspaceRepo,
@@ -445,12 +448,9 @@ func (s *TransactionalNotificationService) SendNotification(
 	)
 
 	// Authenticate user for workspace (skip for system calls)
-	var err error
-	if ctx.Value(domain.SystemCallKey) == nil {
-		ctx, _, _, err = s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
-		if err != nil {
-			return "", fmt.Errorf("failed to authenticate user for workspace: %w", err)
-		}
+	ctx, err := s.authenticateUnlessSystemCall(ctx, workspaceID)
+	if err != nil {
+		return "", err
 	}
 
 	// Add contact info to span if available
@@ -494,20 +494,9 @@ func (s *TransactionalNotificationService) SendNotification(
 		return "", err
 	}
 
-	contactOperation := s.contactService.UpsertContact(ctx, workspaceID, params.Contact)
-	if contactOperation.Action == domain.UpsertContactOperationError {
-		err := fmt.Errorf("failed to upsert contact: %s", contactOperation.Error)
-		tracing.MarkSpanError(ctx, err)
-		return "", err
-	}
-
-	tracing.AddAttribute(ctx, "contact.operation", string(contactOperation.Action))
-
-	// Get the contact with complete information
-	contact, err := s.contactService.GetContactByEmail(ctx, workspaceID, params.Contact.Email)
+	contact, err := s.upsertRecipient(ctx, workspaceID, params.Contact)
 	if err != nil {
-		tracing.MarkSpanError(ctx, err)
-		return "", fmt.Errorf("contact not found after upsert: %w", err)
+		return "", err
 	}
 
 	// Determine which channels to send through
@@ -578,33 +567,9 @@ func (s *TransactionalNotificationService) SendNotification(
 		)
 
 		// Prepare message data with contact and custom data
-		notification.TrackingSettings.EnableTracking = workspace.Settings.EmailTrackingEnabled
-
-		// Use workspace CustomEndpointURL if provided, otherwise use the default API endpoint
-		if workspace.Settings.CustomEndpointURL != nil && *workspace.Settings.CustomEndpointURL != "" {
-			notification.TrackingSettings.Endpoint = *workspace.Settings.CustomEndpointURL
-		} else {
-			notification.TrackingSettings.Endpoint = s.apiEndpoint
-		}
+		notification.TrackingSettings = s.trackingSettings(workspace, messageID, notification.TrackingSettings)
 
-		notification.TrackingSettings.WorkspaceID = workspaceID
-		notification.TrackingSettings.MessageID = messageID
-
-		contactWithList := domain.ContactWithList{
-			Contact: contact,
-		}
-
-		req := domain.TemplateDataRequest{
-			WorkspaceID:         workspace.ID,
-			WorkspaceSecretKey:  workspace.Settings.SecretKey,
-			WorkspaceWebsiteURL: workspace.Settings.WebsiteURL,
-			ContactWithList:     contactWithList,
-			MessageID:           messageID,
-			ProvidedData:        params.Data,
-			TrackingSettings:    notification.TrackingSettings,
-			Broadcast:           nil,
-		}
-		templateData, err := domain.BuildTemplateData(req)
+		templateData, err := buildTransactionalTemplateData(workspace, contact, messageID, params.Data, notification.TrackingSettings)
 		if err != nil {
 			tracing.MarkSpanError(childCtx, err)
 			childSpan.End()
@@ -623,29 +588,18 @@ func (s *TransactionalNotificationService) SendNotification(
 		// Send the message based on channel type
 		if channel == domain.TransactionalChannelEmail {
 
-			// Get the email provider and integration ID using the workspace's GetEmailProviderWithIntegrationID method
-			emailProvider, integrationID, err := workspace.GetEmailProviderWithIntegrationID(false)
+			emailProvider, integrationID, err := resolveTransactionalEmailProvider(workspace, "")
 			if err != nil {
 				tracing.MarkSpanError(childCtx, err)
 				childSpan.End()
 				return "", err
 			}
 
-			// Validate that the provider is configured
-			if emailProvider == nil || emailProvider.Kind == "" {
-				err := fmt.Errorf("no email provider configured for transactional notifications")
-				tracing.MarkSpanError(childCtx, err)
-				childSpan.End()
-				return "", err
-			}
-
 			childSpan.AddAttributes(
 				trace.StringAttribute("provider.kind", string(emailProvider.Kind)),
 				trace.StringAttribute("integration_id", integrationID),
 			)
 
-			notification.TrackingSettings.EnableTracking = workspace.Settings.EmailTrackingEnabled
-
 			notificationID := params.ID
 			request := domain.SendEmailRequest{
 				WorkspaceID:                 workspaceID,
@@ -694,6 +648,211 @@ func (s *TransactionalNotificationService) SendNotification(
 	return messageID, nil
 }
 
+// SendTemplate renders a template for a single recipient and enqueues it as a transactional email.
+// Unlike SendNotification it does not require a configured transactional notification, and the
+// queued email is classified as transactional so it bypasses marketing opt-outs and broadcast stats.
+func (s *TransactionalNotificationService) SendTemplate(
+	ctx context.Context,
+	workspaceID string,
+	params domain.TransactionalTemplateSendParams,
+) (string, error) {
+	ctx, span := tracing.StartServiceSpan(ctx, "TransactionalNotificationService", "SendTemplate")
+	defer span.End()
+
+	span.AddAttributes(
+		trace.StringAttribute("workspace", workspaceID),
+		trace.StringAttribute("template_id", params.TemplateID),
+		trace.StringAttribute("contact.email", params.To),
+	)
+
+	// Authenticate user for workspace (skip for system calls)
+	ctx, err := s.authenticateUnlessSystemCall(ctx, workspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		tracing.MarkSpanError(ctx, err)
+		return "", fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	emailProvider, integrationID, err := resolveTransactionalEmailProvider(workspace, params.IntegrationID)
+	if err != nil {
+		tracing.MarkSpanError(ctx, err)
+		return "", err
+	}
+
+	template, err := s.templateService.GetTemplateByID(ctx, workspaceID, params.TemplateID, int64(0))
+	if err != nil {
+		tracing.MarkSpanError(ctx, err)
+		return "", fmt.Errorf("template not found: %w", err)
+	}
+	if template.Email == nil {
+		return "", errors.New("template does not contain email content")
+	}
+
+	// Upsert the recipient so the message can be tracked in its timeline
+	contact, err := s.upsertRecipient(ctx, workspaceID, &domain.Contact{Email: params.To})
+	if err != nil {
+		return "", err
+	}
+
+	messageID := uuid.New().String()
+	trackingSettings := s.trackingSettings(workspace, messageID, notifuse_mjml.TrackingSettings{})
+
+	templateData, err := buildTransactionalTemplateData(workspace, contact, messageID, params.Data, trackingSettings)
+	if err != nil {
+		tracing.MarkSpanError(ctx, err)
+		return "", fmt.Errorf("failed to build template data: %w", err)
+	}
+
+	rendered, err := renderEmailTemplate(ctx, s.templateService, template, workspace, emailProvider,
+		contact, messageID, templateData, trackingSettings, params.EmailOptions)
+	if err != nil {
+		tracing.MarkSpanError(ctx, err)
+		return "", err
+	}
+
+	emailOptions := params.EmailOptions
+	if emailOptions.ReplyTo == "" {
+		emailOptions.ReplyTo = rendered.ReplyTo
+	}
+
+	now := time.Now().UTC()
+	entry := &domain.EmailQueueEntry{
+		ID:            uuid.New().String(),
+		Status:        domain.EmailQueueStatusPending,
+		Priority:      domain.EmailQueuePriorityTransactional,
+		SourceType:    domain.EmailQueueSourceTransactional,
+		SourceID:      params.TemplateID,
+		IntegrationID: integrationID,
+		ProviderKind:  emailProvider.Kind,
+		ContactEmail:  contact.Email,
+		MessageID:     messageID,
+		TemplateID:    params.TemplateID,
+		Payload: domain.EmailQueuePayload{
+			FromAddress:        rendered.FromAddress,
+			FromName:           rendered.FromName,
+			Subject:            rendered.Subject,
+			HTMLContent:        rendered.HTML,
+			EmailOptions:       emailOptions,
+			RateLimitPerMinute: emailProvider.RateLimitPerMinute,
+			TemplateVersion:    int(template.Version),
+			TemplateData:       templateData,
+		},
+		MaxAttempts: 3,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.emailQueueRepo.Enqueue(ctx, workspaceID, []*domain.EmailQueueEntry{entry}); err != nil {
+		tracing.MarkSpanError(ctx, err)
+		return "", fmt.Errorf("failed to enqueue email: %w", err)
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"workspace_id": workspaceID,
+		"template_id":  params.TemplateID,
+		"contact":      contact.Email,
+		"message_id":   messageID,
+	}).Info("Transactional template email enqueued")
+
+	span.AddAttributes(trace.StringAttribute("message_id", messageID))
+
+	return messageID, nil
+}
+
+// authenticateUnlessSystemCall authenticates the user for the workspace, system calls skip authentication
+func (s *TransactionalNotificationService) authenticateUnlessSystemCall(ctx context.Context, workspaceID string) (context.Context, error) {
+	if ctx.Value(domain.SystemCallKey) != nil {
+		return ctx, nil
+	}
+	ctx, _, _, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to authenticate user for workspace: %w", err)
+	}
+	return ctx, nil
+}
+
+// upsertRecipient upserts the contact a transactional email is sent to
+// and returns it with complete information
+func (s *TransactionalNotificationService) upsertRecipient(ctx context.Context, workspaceID string, contact *domain.Contact) (*domain.Contact, error) {
+	contactOperation := s.contactService.UpsertContact(ctx, workspaceID, contact)
+	if contactOperation.Action == domain.UpsertContactOperationError {
+		err := fmt.Errorf("failed to upsert contact: %s", contactOperation.Error)
+		tracing.MarkSpanError(ctx, err)
+		return nil, err
+	}
+
+	tracing.AddAttribute(ctx, "contact.operation", string(contactOperation.Action))
+
+	recipient, err := s.contactService.GetContactByEmail(ctx, workspaceID, contact.Email)
+	if err != nil {
+		tracing.MarkSpanError(ctx, err)
+		return nil, fmt.Errorf("contact not found after upsert: %w", err)
+	}
+	return recipient, nil
+}
+
+// trackingSettings completes the tracking settings of a transactional email with the workspace
+// tracking preference and endpoint, keeping the UTM parameters already set
+func (s *TransactionalNotificationService) trackingSettings(workspace *domain.Workspace, messageID string, settings notifuse_mjml.TrackingSettings) notifuse_mjml.TrackingSettings {
+	settings.EnableTracking = workspace.Settings.EmailTrackingEnabled
+
+	// Use workspace CustomEndpointURL if provided, otherwise use the default API endpoint
+	if workspace.Settings.CustomEndpointURL != nil && *workspace.Settings.CustomEndpointURL != "" {
+		settings.Endpoint = *workspace.Settings.CustomEndpointURL
+	} else {
+		settings.Endpoint = s.apiEndpoint
+	}
+
+	settings.WorkspaceID = workspace.ID
+	settings.MessageID = messageID
+	return settings
+}
+
+// buildTransactionalTemplateData builds the template data of a transactional email for the contact
+func buildTransactionalTemplateData(workspace *domain.Workspace, contact *domain.Contact, messageID string, data domain.MapOfAny, trackingSettings notifuse_mjml.TrackingSettings) (domain.MapOfAny, error) {
+	return domain.BuildTemplateData(domain.TemplateDataRequest{
+		WorkspaceID:         workspace.ID,
+		WorkspaceSecretKey:  workspace.Settings.SecretKey,
+		WorkspaceWebsiteURL: workspace.Settings.WebsiteURL,
+		ContactWithList:     domain.ContactWithList{Contact: contact},
+		MessageID:           messageID,
+		ProvidedData:        data,
+		TrackingSettings:    trackingSettings,
+	})
+}
+
+// resolveTransactionalEmailProvider returns the email provider of the given integration,
+// or the workspace transactional email provider when no integration is given
+func resolveTransactionalEmailProvider(workspace *domain.Workspace, integrationID string) (*domain.EmailProvider, string, error) {
+	var emailProvider *domain.EmailProvider
+	if integrationID != "" {
+		integration := workspace.GetIntegrationByID(integrationID)
+		if integration == nil {
+			return nil, "", fmt.Errorf("integration %s not found", integrationID)
+		}
+		if integration.Type != domain.IntegrationTypeEmail {
+			return nil, "", fmt.Errorf("integration %s is not an email provider", integrationID)
+		}
+		emailProvider = &integration.EmailProvider
+	} else {
+		var err error
+		emailProvider, integrationID, err = workspace.GetEmailProviderWithIntegrationID(false)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	// Validate that the provider is configured
+	if emailProvider == nil || emailProvider.Kind == "" {
+		return nil, "", fmt.Errorf("no email provider configured for transactional notifications")
+	}
+	return emailProvider, integrationID, nil
+}
+
 // TestTemplate sends a test email with a template to verify it works
 func (s *TransactionalNotificationService) TestTemplate(ctx context.Context, workspaceID string, templateID string, integrationID string, senderID string, recipientEmail string, language string, emailOptions domain.EmailOptions) error {
 	// Authenticate user
@@ -1043,6 +1043,7 @@ func TestNewTransactionalNotificationService(t *testing.T) {
 	mockTemplateService := mocks.NewMockTemplateService(ctrl)
 	mockContactService := mocks.NewMockContactService(ctrl)
 	mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+	mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
 	mockAuthService := mocks.NewMockAuthService(ctrl)
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
@@ -1054,6 +1055,7 @@ func TestNewTransactionalNotificationService(t *testing.T) {
 		mockTemplateService,
 		mockContactService,
 		mockEmailService,
+		mockEmailQueueRepo,
 		mockAuthService,
 		mockLogger,
 		mockWorkspaceRepo,
@@ -1066,6 +1068,7 @@ func TestNewTransactionalNotificationService(t *testing.T) {
 	assert.Equal(t, mockTemplateService, service.templateService)
 	assert.Equal(t, mockContactService, service.contactService)
 	assert.Equal(t, mockEmailService, service.emailService)
+	assert.Equal(t, mockEmailQueueRepo, service.emailQueueRepo)
 	assert.Equal(t, mockAuthService, service.authService)
 	assert.Equal(t, mockLogger, service.logger)
 	assert.Equal(t, mockWorkspaceRepo, service.workspaceRepo)
@@ -2682,3 +2685,217 @@ func TestTransactionalNotificationService_TestTemplate_WithLanguage(t *testing.T
 	err := service.TestTemplate(ctx, workspaceID, templateID, integrationID, senderID, recipientEmail, "fr", domain.EmailOptions{})
 	require.NoError(t, err)
 }
+
+func TestTransactionalNotificationService_SendTemplate(t *testing.T) {
+	workspaceID := "test-workspace"
+	templateID := "welcome-template"
+	integrationID := "transactional-integration"
+	recipientEmail := "recipient@example.com"
+
+	newWorkspace := func() *domain.Workspace {
+		return &domain.Workspace{
+			ID:   workspaceID,
+			Name: "Test Workspace",
+			Settings: domain.WorkspaceSettings{
+				SecretKey:                    "test-secret-key",
+				TransactionalEmailProviderID: integrationID,
+			},
+			Integrations: []domain.Integration{
+				{
+					ID:   integrationID,
+					Name: "Transactional Integration",
+					Type: domain.IntegrationTypeEmail,
+					EmailProvider: domain.EmailProvider{
+						Kind:               domain.EmailProviderKindSparkPost,
+						RateLimitPerMinute: 60,
+						Senders: []domain.EmailSender{
+							{ID: "sender-1", Email: "sender@example.com", Name: "Sender", IsDefault: true},
+						},
+					},
+				},
+				{
+					ID:   "sms-integration",
+					Name: "Not an email integration",
+					Type: "sms",
+				},
+			},
+		}
+	}
+
+	template := &domain.Template{
+		ID:      templateID,
+		Name:    "Welcome",
+		Version: 2,
+		Email: &domain.EmailTemplate{
+			Subject: "Welcome {{ name }}",
+			VisualEditorTree: &notifuse_mjml.MJMLBlock{
+				BaseBlock: notifuse_mjml.NewBaseBlock("root", notifuse_mjml.MJMLComponentMjml),
+			},
+		},
+	}
+
+	html := "<html><body>Welcome</body></html>"
+
+	setup := func(t *testing.T) (*TransactionalNotificationService, *mocks.MockWorkspaceRepository, *mocks.MockTemplateService, *mocks.MockContactService, *mocks.MockEmailQueueRepository) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockTemplateService := mocks.NewMockTemplateService(ctrl)
+		mockContactService := mocks.NewMockContactService(ctrl)
+		mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+
+		service := &TransactionalNotificationService{
+			templateService: mockTemplateService,
+			contactService:  mockContactService,
+			emailQueueRepo:  mockEmailQueueRepo,
+			authService:     mocks.NewMockAuthService(ctrl),
+			logger:          mockLogger,
+			workspaceRepo:   mockWorkspaceRepo,
+			apiEndpoint:     "https://api.example.com",
+		}
+
+		return service, mockWorkspaceRepo, mockTemplateService, mockContactService, mockEmailQueueRepo
+	}
+
+	// System calls skip authentication
+	ctx := context.WithValue(context.Background(), domain.SystemCallKey, true)
+
+	t.Run("enqueues transactional email with workspace provider", func(t *testing.T) {
+		service, mockWorkspaceRepo, mockTemplateService, mockContactService, mockEmailQueueRepo := setup(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(newWorkspace(), nil)
+		mockTemplateService.EXPECT().GetTemplateByID(gomock.Any(), workspaceID, templateID, int64(0)).Return(template, nil)
+		mockContactService.EXPECT().UpsertContact(gomock.Any(), workspaceID, gomock.Any()).
+			Return(domain.UpsertContactOperation{Email: recipientEmail, Action: domain.UpsertContactOperationCreate})
+		mockContactService.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, recipientEmail).
+			Return(&domain.Contact{Email: recipientEmail}, nil)
+		mockTemplateService.EXPECT().CompileTemplate(gomock.Any(), gomock.Any()).
+			Return(&domain.CompileTemplateResponse{Success: true, HTML: &html}, nil)
+
+		var captured *domain.EmailQueueEntry
+		mockEmailQueueRepo.EXPECT().Enqueue(gomock.Any(), workspaceID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, entries []*domain.EmailQueueEntry) error {
+				require.Len(t, entries, 1)
+				captured = entries[0]
+				return nil
+			})
+
+		messageID, err := service.SendTemplate(ctx, workspaceID, domain.TransactionalTemplateSendParams{
+			To:         recipientEmail,
+			TemplateID: templateID,
+			Data:       domain.MapOfAny{"name": "Jane"},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, captured)
+		assert.Equal(t, captured.MessageID, messageID)
+		assert.Equal(t, domain.EmailQueueSourceTransactional, captured.SourceType)
+		assert.Equal(t, domain.EmailQueuePriorityTransactional, captured.Priority)
+		assert.Equal(t, domain.EmailQueueStatusPending, captured.Status)
+		assert.Equal(t, integrationID, captured.IntegrationID)
+		assert.Equal(t, recipientEmail, captured.ContactEmail)
+		assert.Equal(t, templateID, captured.TemplateID)
+		assert.Equal(t, "Welcome Jane", captured.Payload.Subject)
+		assert.Equal(t, "sender@example.com", captured.Payload.FromAddress)
+		assert.Equal(t, html, captured.Payload.HTMLContent)
+		assert.Equal(t, 2, captured.Payload.TemplateVersion)
+		assert.Equal(t, 60, captured.Payload.RateLimitPerMinute)
+	})
+
+	t.Run("applies the email option overrides like notification sends", func(t *testing.T) {
+		service, mockWorkspaceRepo, mockTemplateService, mockContactService, mockEmailQueueRepo := setup(t)
+
+		withReplyTo := *template
+		emailContent := *template.Email
+		emailContent.ReplyTo = "support@example.com"
+		withReplyTo.Email = &emailContent
+
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(newWorkspace(), nil)
+		mockTemplateService.EXPECT().GetTemplateByID(gomock.Any(), workspaceID, templateID, int64(0)).Return(&withReplyTo, nil)
+		mockContactService.EXPECT().UpsertContact(gomock.Any(), workspaceID, gomock.Any()).
+			Return(domain.UpsertContactOperation{Email: recipientEmail, Action: domain.UpsertContactOperationUpdate})
+		mockContactService.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, recipientEmail).
+			Return(&domain.Contact{Email: recipientEmail}, nil)
+		mockTemplateService.EXPECT().CompileTemplate(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req domain.CompileTemplateRequest) (*domain.CompileTemplateResponse, error) {
+				assert.Equal(t, "https://api.example.com", req.TrackingSettings.Endpoint)
+				assert.Equal(t, workspaceID, req.TrackingSettings.WorkspaceID)
+				assert.Equal(t, "Jane", req.TemplateData["name"])
+				return &domain.CompileTemplateResponse{Success: true, HTML: &html}, nil
+			})
+
+		var captured *domain.EmailQueueEntry
+		mockEmailQueueRepo.EXPECT().Enqueue(gomock.Any(), workspaceID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, entries []*domain.EmailQueueEntry) error {
+				captured = entries[0]
+				return nil
+			})
+
+		subject := "Reset your password, {{ name }}"
+		fromName := "Security Team"
+		_, err := service.SendTemplate(ctx, workspaceID, domain.TransactionalTemplateSendParams{
+			To:           recipientEmail,
+			TemplateID:   templateID,
+			Data:         domain.MapOfAny{"name": "Jane"},
+			EmailOptions: domain.EmailOptions{Subject: &subject, FromName: &fromName},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, captured)
+		assert.Equal(t, "Reset your password, Jane", captured.Payload.Subject)
+		assert.Equal(t, "Security Team", captured.Payload.FromName)
+		assert.Equal(t, "support@example.com", captured.Payload.EmailOptions.ReplyTo)
+	})
+
+	t.Run("rejects integration that is not an email provider", func(t *testing.T) {
+		service, mockWorkspaceRepo, _, _, _ := setup(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(newWorkspace(), nil)
+
+		_, err := service.SendTemplate(ctx, workspaceID, domain.TransactionalTemplateSendParams{
+			To:            recipientEmail,
+			TemplateID:    templateID,
+			IntegrationID: "sms-integration",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not an email provider")
+	})
+
+	t.Run("fails without transactional provider", func(t *testing.T) {
+		service, mockWorkspaceRepo, _, _, _ := setup(t)
+
+		workspace := newWorkspace()
+		workspace.Settings.TransactionalEmailProviderID = ""
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+
+		_, err := service.SendTemplate(ctx, workspaceID, domain.TransactionalTemplateSendParams{
+			To:         recipientEmail,
+			TemplateID: templateID,
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no email provider configured")
+	})
+
+	t.Run("returns error when template is missing", func(t *testing.T) {
+		service, mockWorkspaceRepo, mockTemplateService, _, _ := setup(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(newWorkspace(), nil)
+		mockTemplateService.EXPECT().GetTemplateByID(gomock.Any(), workspaceID, "missing", int64(0)).
+			Return(nil, &domain.ErrTemplateNotFound{Message: "template not found"})
+
+		_, err := service.SendTemplate(ctx, workspaceID, domain.TransactionalTemplateSendParams{
+			To:         recipientEmail,
+			TemplateID: "missing",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
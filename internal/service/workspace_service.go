@@ -377,6 +377,9 @@ func (s *WorkspaceService) UpdateWorkspace(ctx context.Context, id string, name
 	existingWorkspace.Settings.BlogSettings = settings.BlogSettings
 	existingWorkspace.Settings.DefaultLanguage = settings.DefaultLanguage
 	existingWorkspace.Settings.Languages = settings.Languages
+	existingWorkspace.Settings.ListUnsubscribeMailto = settings.ListUnsubscribeMailto
+	existingWorkspace.Settings.BroadcastSeedList = settings.BroadcastSeedList
+	existingWorkspace.Settings.DisableBulkHeaders = settings.DisableBulkHeaders
 
 	// Handle template blocks - preserve existing blocks if not provided in update
 	// Note: Template blocks should be managed via dedicated /api/templateBlocks.* endpoints
@@ -1229,6 +1232,9 @@ func (s *WorkspaceService) CreateIntegration(ctx context.Context, req domain.Cre
 		s.logger.WithField("workspace_id", req.WorkspaceID).WithField("integration_id", integrationID).WithField("error", err.Error()).Error("Failed to validate integration")
 		return "", err
 	}
+	if err := workspace.ValidateFailoverIntegration(integrationID, &integration.EmailProvider); err != nil {
+		return "", err
+	}
 
 	// Add the integration to the workspace
 	workspace.AddIntegration(integration)
@@ -1413,6 +1419,9 @@ func (s *WorkspaceService) UpdateIntegration(ctx context.Context, req domain.Upd
 		s.logger.WithField("workspace_id", req.WorkspaceID).WithField("integration_id", req.IntegrationID).WithField("error", err.Error()).Error("Failed to validate updated integration")
 		return err
 	}
+	if err := workspace.ValidateFailoverIntegration(req.IntegrationID, &updatedIntegration.EmailProvider); err != nil {
+		return err
+	}
 
 	// Update the integration in the workspace
 	workspace.AddIntegration(updatedIntegration) // This will replace the existing one
@@ -0,0 +1,129 @@
+package emailerror
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/textproto"
+	"regexp"
+	"strconv"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// ErrorCategory groups send failures the same way across SMTP and HTTP providers,
+// so queue handling can tell why a send failed without knowing the provider
+type ErrorCategory string
+
+const (
+	// ErrorCategoryAuth indicates the provider refused the credentials (bad API key, SMTP login failed)
+	ErrorCategoryAuth ErrorCategory = "auth"
+
+	// ErrorCategoryConnection indicates the provider could not be reached or closed the connection
+	ErrorCategoryConnection ErrorCategory = "connection"
+
+	// ErrorCategoryRateLimited indicates the provider is throttling the sender
+	ErrorCategoryRateLimited ErrorCategory = "rate_limited"
+
+	// ErrorCategoryRejected indicates the provider permanently refused the message or recipient
+	ErrorCategoryRejected ErrorCategory = "rejected"
+
+	// ErrorCategoryTransient indicates a temporary failure that is expected to clear on retry
+	ErrorCategoryTransient ErrorCategory = "transient"
+)
+
+// Matches the reply code in SMTP errors like "RCPT TO rejected for bob@example.com with code: 550"
+var smtpCodeRegex = regexp.MustCompile(`(?i)with code:\s*(\d{3})`)
+
+// SMTP connection error patterns, for failures that happen before any reply is received
+var connectionPatterns = []string{
+	"connection refused",
+	"connection reset",
+	"no such host",
+	"broken pipe",
+	"tls handshake",
+	"i/o timeout",
+	"eof",
+}
+
+// categorize maps a classified error to its ErrorCategory, from the SMTP reply code or
+// HTTP status when one is known and from the error type otherwise
+func categorize(result *ClassifiedError, provider domain.EmailProviderKind) ErrorCategory {
+	if provider == domain.EmailProviderKindSMTP {
+		if code := extractSMTPCode(result.Original); code > 0 {
+			result.SMTPCode = code
+			return SMTPReplyCategory(code)
+		}
+	}
+
+	if result.HTTPStatus > 0 {
+		return HTTPStatusCategory(result.HTTPStatus)
+	}
+
+	var netErr net.Error
+	if errors.As(result.Original, &netErr) || containsAny(result.Original.Error(), connectionPatterns) {
+		return ErrorCategoryConnection
+	}
+	if result.Type == ErrorTypeRecipient {
+		return ErrorCategoryRejected
+	}
+	return ErrorCategoryTransient
+}
+
+// extractSMTPCode returns the SMTP reply code of an error, or 0 when there is none
+func extractSMTPCode(err error) int {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		return protoErr.Code
+	}
+	if matches := smtpCodeRegex.FindStringSubmatch(err.Error()); len(matches) >= 2 {
+		if code, convErr := strconv.Atoi(matches[1]); convErr == nil {
+			return code
+		}
+	}
+	return 0
+}
+
+// SMTPReplyCategory maps an SMTP reply code (RFC 5321) to an ErrorCategory.
+// A code of 0 means no reply was received.
+func SMTPReplyCategory(code int) ErrorCategory {
+	switch {
+	case code == 0:
+		return ErrorCategoryConnection
+	// 421: service not available, closing transmission channel
+	case code == 421:
+		return ErrorCategoryConnection
+	// 530: authentication required, 534: mechanism too weak, 535: credentials invalid, 538: encryption required
+	case code == 530, code == 534, code == 535, code == 538:
+		return ErrorCategoryAuth
+	// 5xx: permanent failures (unknown mailbox, policy rejection, syntax errors)
+	case code >= 500 && code < 600:
+		return ErrorCategoryRejected
+	// 4xx: temporary failures (mailbox busy, local error, insufficient storage)
+	case code >= 400 && code < 500:
+		return ErrorCategoryTransient
+	default:
+		return ErrorCategoryTransient
+	}
+}
+
+// HTTPStatusCategory maps the HTTP status returned by an API provider to an ErrorCategory.
+// A status of 0 means no response was received.
+func HTTPStatusCategory(status int) ErrorCategory {
+	switch {
+	case status == 0:
+		return ErrorCategoryConnection
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return ErrorCategoryAuth
+	case status == http.StatusTooManyRequests:
+		return ErrorCategoryRateLimited
+	case status == http.StatusRequestTimeout:
+		return ErrorCategoryTransient
+	case status >= 500:
+		return ErrorCategoryTransient
+	case status >= 400:
+		return ErrorCategoryRejected
+	default:
+		return ErrorCategoryTransient
+	}
+}
@@ -0,0 +1,141 @@
+package emailerror
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSMTPReplyCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     int
+		expected ErrorCategory
+	}{
+		{name: "no reply", code: 0, expected: ErrorCategoryConnection},
+		{name: "421 service not available", code: 421, expected: ErrorCategoryConnection},
+		{name: "450 mailbox busy", code: 450, expected: ErrorCategoryTransient},
+		{name: "451 local error", code: 451, expected: ErrorCategoryTransient},
+		{name: "452 insufficient storage", code: 452, expected: ErrorCategoryTransient},
+		{name: "530 authentication required", code: 530, expected: ErrorCategoryAuth},
+		{name: "534 mechanism too weak", code: 534, expected: ErrorCategoryAuth},
+		{name: "535 credentials invalid", code: 535, expected: ErrorCategoryAuth},
+		{name: "538 encryption required", code: 538, expected: ErrorCategoryAuth},
+		{name: "500 syntax error", code: 500, expected: ErrorCategoryRejected},
+		{name: "550 mailbox unavailable", code: 550, expected: ErrorCategoryRejected},
+		{name: "552 storage exceeded", code: 552, expected: ErrorCategoryRejected},
+		{name: "553 mailbox name not allowed", code: 553, expected: ErrorCategoryRejected},
+		{name: "554 transaction failed", code: 554, expected: ErrorCategoryRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SMTPReplyCategory(tt.code))
+		})
+	}
+}
+
+func TestHTTPStatusCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		expected ErrorCategory
+	}{
+		{name: "no response", status: 0, expected: ErrorCategoryConnection},
+		{name: "400 bad request", status: 400, expected: ErrorCategoryRejected},
+		{name: "401 unauthorized", status: 401, expected: ErrorCategoryAuth},
+		{name: "403 forbidden", status: 403, expected: ErrorCategoryAuth},
+		{name: "406 inactive recipient", status: 406, expected: ErrorCategoryRejected},
+		{name: "408 request timeout", status: 408, expected: ErrorCategoryTransient},
+		{name: "422 unprocessable entity", status: 422, expected: ErrorCategoryRejected},
+		{name: "429 too many requests", status: 429, expected: ErrorCategoryRateLimited},
+		{name: "500 internal server error", status: 500, expected: ErrorCategoryTransient},
+		{name: "502 bad gateway", status: 502, expected: ErrorCategoryTransient},
+		{name: "503 service unavailable", status: 503, expected: ErrorCategoryTransient},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, HTTPStatusCategory(tt.status))
+		})
+	}
+}
+
+func TestClassifier_Category(t *testing.T) {
+	classifier := NewClassifier()
+
+	tests := []struct {
+		name     string
+		err      error
+		provider domain.EmailProviderKind
+		expected ErrorCategory
+		smtpCode int
+	}{
+		{
+			name:     "smtp recipient rejected",
+			err:      errors.New("RCPT TO rejected for bob@example.com with code: 550"),
+			provider: domain.EmailProviderKindSMTP,
+			expected: ErrorCategoryRejected,
+			smtpCode: 550,
+		},
+		{
+			name:     "smtp login failed",
+			err:      fmt.Errorf("failed to send email: %w", errors.New("authentication failed with code: 535")),
+			provider: domain.EmailProviderKindSMTP,
+			expected: ErrorCategoryAuth,
+			smtpCode: 535,
+		},
+		{
+			name:     "smtp mailbox busy",
+			err:      &textproto.Error{Code: 450, Msg: "mailbox busy"},
+			provider: domain.EmailProviderKindSMTP,
+			expected: ErrorCategoryTransient,
+			smtpCode: 450,
+		},
+		{
+			name:     "smtp server unreachable",
+			err:      &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			provider: domain.EmailProviderKindSMTP,
+			expected: ErrorCategoryConnection,
+		},
+		{
+			name:     "http rate limited",
+			err:      errors.New("postmark API error: status code: 429"),
+			provider: domain.EmailProviderKindPostmark,
+			expected: ErrorCategoryRateLimited,
+		},
+		{
+			name:     "http bad api key",
+			err:      errors.New("sendgrid API error: status code: 401"),
+			provider: domain.EmailProviderKindSendGrid,
+			expected: ErrorCategoryAuth,
+		},
+		{
+			name:     "recipient error without status",
+			err:      errors.New("MessageRejected: Email address is not verified"),
+			provider: domain.EmailProviderKindSES,
+			expected: ErrorCategoryRejected,
+		},
+		{
+			name:     "unknown error without status",
+			err:      errors.New("something went wrong"),
+			provider: domain.EmailProviderKindMailgun,
+			expected: ErrorCategoryTransient,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := classifier.Classify(tt.err, tt.provider)
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.Category)
+			assert.Equal(t, tt.smtpCode, result.SMTPCode)
+		})
+	}
+}
@@ -25,24 +25,28 @@ func (c *Classifier) Classify(err error, provider domain.EmailProviderKind) *Cla
 	errStr := err.Error()
 	httpStatus := extractHTTPStatus(errStr)
 
+	var result *ClassifiedError
 	switch provider {
 	case domain.EmailProviderKindSES:
-		return c.classifySESError(err, errStr, httpStatus)
+		result = c.classifySESError(err, errStr, httpStatus)
 	case domain.EmailProviderKindPostmark:
-		return c.classifyPostmarkError(err, errStr, httpStatus)
+		result = c.classifyPostmarkError(err, errStr, httpStatus)
 	case domain.EmailProviderKindMailgun:
-		return c.classifyMailgunError(err, errStr, httpStatus)
+		result = c.classifyMailgunError(err, errStr, httpStatus)
 	case domain.EmailProviderKindMailjet:
-		return c.classifyMailjetError(err, errStr, httpStatus)
+		result = c.classifyMailjetError(err, errStr, httpStatus)
 	case domain.EmailProviderKindSparkPost:
-		return c.classifySparkPostError(err, errStr, httpStatus)
+		result = c.classifySparkPostError(err, errStr, httpStatus)
 	case domain.EmailProviderKindSMTP:
-		return c.classifySMTPError(err, errStr, httpStatus)
+		result = c.classifySMTPError(err, errStr, httpStatus)
 	case domain.EmailProviderKindSendGrid:
-		return c.classifySendGridError(err, errStr, httpStatus)
+		result = c.classifySendGridError(err, errStr, httpStatus)
 	default:
-		return c.classifyUnknownProvider(err, errStr, httpStatus)
+		result = c.classifyUnknownProvider(err, errStr, httpStatus)
 	}
+
+	result.Category = categorize(result, provider)
+	return result
 }
 
 // HTTP status extraction patterns
@@ -31,6 +31,12 @@ type ClassifiedError struct {
 	// HTTPStatus is the extracted HTTP status code (0 if not applicable)
 	HTTPStatus int
 
+	// SMTPCode is the extracted SMTP reply code (0 if not applicable)
+	SMTPCode int
+
+	// Category is the provider independent category of the failure
+	Category ErrorCategory
+
 	// Retryable indicates whether this error can be retried
 	Retryable bool
 }
@@ -258,6 +258,40 @@ func ProcessLiquidTemplate(content string, templateData map[string]interface{},
 	return processLiquidContent(content, templateData, context)
 }
 
+// liquidMarkupRegexp matches {{ ... }} output and {% ... %} tag markup
+var liquidMarkupRegexp = regexp.MustCompile(`(?s){{(.*?)}}|{%(.*?)%}`)
+
+// liquidStringLiteralRegexp matches quoted string literals inside Liquid markup
+var liquidStringLiteralRegexp = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+
+// liquidIdentifierRegexp matches identifiers that are not property lookups (not preceded by a dot)
+var liquidIdentifierRegexp = regexp.MustCompile(`(?:^|[^\w.])([A-Za-z_][\w-]*)`)
+
+// LiquidReferencesAny reports whether the Liquid markup in content references any of the
+// given top-level variable or tag names. It errs on the side of reporting a reference: a
+// loop variable or filter sharing one of the names counts as a match.
+func LiquidReferencesAny(content string, names []string) bool {
+	if !strings.Contains(content, "{{") && !strings.Contains(content, "{%") {
+		return false
+	}
+
+	lookup := make(map[string]bool, len(names))
+	for _, name := range names {
+		lookup[name] = true
+	}
+
+	for _, match := range liquidMarkupRegexp.FindAllStringSubmatch(cleanLiquidTemplate(content), -1) {
+		markup := liquidStringLiteralRegexp.ReplaceAllString(match[1]+match[2], "")
+		for _, ident := range liquidIdentifierRegexp.FindAllStringSubmatch(markup, -1) {
+			if lookup[ident[1]] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // parseTemplateDataString parses JSON string to map[string]interface{} for internal MJML functions
 func parseTemplateDataString(templateData string) (map[string]interface{}, error) {
 	if templateData == "" {
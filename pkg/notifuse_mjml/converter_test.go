@@ -208,6 +208,34 @@ func TestCleanLiquidTemplate(t *testing.T) {
 	}
 }
 
+func TestLiquidReferencesAny(t *testing.T) {
+	names := []string{"contact", "unsubscribe_url"}
+
+	tests := []struct {
+		name     string
+		content  string
+		expected bool
+	}{
+		{name: "no liquid", content: "<p>Hello world</p>", expected: false},
+		{name: "other variable", content: "<p>{{ broadcast.name }}</p>", expected: false},
+		{name: "property with matching name", content: "{{ broadcast.contact }}", expected: false},
+		{name: "string literal with matching name", content: `{{ "contact" | upcase }}`, expected: false},
+		{name: "output variable", content: "Hi {{ contact.first_name }}", expected: true},
+		{name: "filter argument", content: `{{ broadcast.name | default: contact.email }}`, expected: true},
+		{name: "tag markup", content: "{% if contact.language == 'fr' %}Bonjour{% endif %}", expected: true},
+		{name: "custom tag", content: "<a href=\"{% unsubscribe_url %}\">Unsubscribe</a>", expected: true},
+		{name: "nbsp inside markup", content: "{{&nbsp;contact.email }}", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LiquidReferencesAny(tt.content, names); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
 func TestProcessLiquidTemplateEmailSubjects(t *testing.T) {
 	// Specific tests for email subject line scenarios
 	tests := []struct {
@@ -0,0 +1,45 @@
+package notifuse_mjml
+
+import (
+	"math"
+	"time"
+)
+
+// nowFunc returns the current time; overridden in tests
+var nowFunc = time.Now
+
+// ComputedFilters provides Liquid filters for derived contact values.
+// Method names are exposed to templates in snake_case (DaysSince -> days_since).
+type ComputedFilters struct{}
+
+// DaysSince returns the number of whole days elapsed since the given date,
+// mirroring the days_since(field) computed field used in conditions.
+// Usage: {{ contact.created_at | days_since }}
+// Returns nil when the input cannot be parsed as a date.
+func (f *ComputedFilters) DaysSince(input interface{}) interface{} {
+	t, ok := parseFilterTime(input)
+	if !ok {
+		return nil
+	}
+	return int(math.Floor(nowFunc().Sub(t).Hours() / 24))
+}
+
+// parseFilterTime converts a Liquid value into a time.Time
+func parseFilterTime(input interface{}) (time.Time, bool) {
+	switch v := input.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, false
+		}
+		return *v, true
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,72 @@
+package notifuse_mjml
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputedFilters_DaysSince(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	originalNow := nowFunc
+	nowFunc = func() time.Time { return now }
+	defer func() { nowFunc = originalNow }()
+
+	filters := &ComputedFilters{}
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected interface{}
+	}{
+		{"RFC3339 string", now.AddDate(0, 0, -40).Format(time.RFC3339), 40},
+		{"RFC3339Nano string", now.AddDate(0, 0, -10).Format(time.RFC3339Nano), 10},
+		{"date only string", "2026-03-01", 14},
+		{"partial day rounds down", now.Add(-47 * time.Hour), 1},
+		{"time pointer", func() *time.Time { v := now.AddDate(0, 0, -3); return &v }(), 3},
+		{"invalid string", "not-a-date", nil},
+		{"nil input", nil, nil},
+		{"unsupported type", 42, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := filters.DaysSince(tt.input)
+			if result != tt.expected {
+				t.Errorf("DaysSince(%v) = %v, expected %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSecureLiquidEngine_DaysSinceFilter(t *testing.T) {
+	template := `{% assign age = contact.created_at | days_since %}{% if age > 30 %}veteran{% else %}newcomer{% endif %} {{ contact.first_name | upcase }}`
+
+	tests := []struct {
+		name     string
+		daysAgo  int
+		expected string
+	}{
+		{"contact created 40 days ago passes", 40, "veteran JOHN"},
+		{"contact created 10 days ago fails", 10, "newcomer JOHN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewSecureLiquidEngine()
+			data := map[string]interface{}{
+				"contact": map[string]interface{}{
+					"first_name": "John",
+					"created_at": time.Now().AddDate(0, 0, -tt.daysAgo).UTC().Format(time.RFC3339),
+				},
+			}
+
+			result, err := engine.Render(template, data)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -14,37 +14,47 @@ const (
 	DefaultRenderTimeout   = 5 * time.Second
 	DefaultMaxTemplateSize = 100 * 1024       // 100KB
 	DefaultMaxMemory       = 10 * 1024 * 1024 // 10MB (informational, not enforced by Go Liquid)
+
+	// DefaultMaxLoopIterations caps how many items a single {% for %} loop renders,
+	// so a template looping over a huge feed array cannot run away
+	DefaultMaxLoopIterations = 100
 )
 
 // SecureLiquidEngine wraps the liquidgo engine with security protections
 type SecureLiquidEngine struct {
-	timeout time.Duration
-	maxSize int
-	env     *liquid.Environment
+	timeout           time.Duration
+	maxSize           int
+	maxLoopIterations int
+	env               *liquid.Environment
 }
 
 // NewSecureLiquidEngine creates a new secure liquidgo engine with default settings
 func NewSecureLiquidEngine() *SecureLiquidEngine {
-	env := liquid.NewEnvironment()
-	tags.RegisterStandardTags(env)
-
-	return &SecureLiquidEngine{
-		timeout: DefaultRenderTimeout,
-		maxSize: DefaultMaxTemplateSize,
-		env:     env,
-	}
+	return NewSecureLiquidEngineWithOptions(DefaultRenderTimeout, DefaultMaxTemplateSize)
 }
 
 // NewSecureLiquidEngineWithOptions creates a new secure liquidgo engine with custom settings
 func NewSecureLiquidEngineWithOptions(timeout time.Duration, maxSize int) *SecureLiquidEngine {
+	engine := &SecureLiquidEngine{
+		timeout:           timeout,
+		maxSize:           maxSize,
+		maxLoopIterations: DefaultMaxLoopIterations,
+	}
+
 	env := liquid.NewEnvironment()
 	tags.RegisterStandardTags(env)
+	registerSystemURLTags(env)
+	registerBoundedForTag(env, func() int { return engine.maxLoopIterations })
+	_ = env.RegisterFilter(&ComputedFilters{})
+	engine.env = env
 
-	return &SecureLiquidEngine{
-		timeout: timeout,
-		maxSize: maxSize,
-		env:     env,
-	}
+	return engine
+}
+
+// SetMaxLoopIterations sets how many items a single {% for %} loop renders at most,
+// for templates parsed afterwards. Zero or less disables the cap.
+func (s *SecureLiquidEngine) SetMaxLoopIterations(max int) {
+	s.maxLoopIterations = max
 }
 
 // RenderWithTimeout renders a Liquid template with timeout and size protection
@@ -9,6 +9,7 @@ import (
 func TestSecureLiquidEngine_TimeoutEnforcement(t *testing.T) {
 	t.Run("infinite loop causes timeout", func(t *testing.T) {
 		engine := NewSecureLiquidEngine()
+		engine.SetMaxLoopIterations(0) // let the loops run until the timeout
 
 		// Template with massive nested loops that should timeout
 		template := `
@@ -282,6 +283,7 @@ func TestSecureLiquidEngine_ErrorMessages(t *testing.T) {
 	t.Run("clear error for timeout", func(t *testing.T) {
 		// Create engine with very short timeout
 		engine := NewSecureLiquidEngineWithOptions(10*time.Millisecond, 100*1024)
+		engine.SetMaxLoopIterations(0)
 
 		// Template with loop that will take longer than 10ms
 		template := `{% for i in (1..100000) %}<div>{{ i }}</div>{% endfor %}`
@@ -0,0 +1,103 @@
+package notifuse_mjml
+
+import (
+	"github.com/Notifuse/liquidgo/liquid"
+	"github.com/Notifuse/liquidgo/liquid/tags"
+)
+
+// systemURLTags maps each Notifuse link tag to the template variables it renders, in order
+// of preference. The URLs themselves are built and signed by BuildTemplateData for the
+// current send (contact, list, message), so the tags never handle tokens directly.
+//
+//	{% unsubscribe_url %}  - list-scoped unsubscribe link; falls back to the preference
+//	                         center when the message is not sent to a list
+//	{% preferences_url %}  - contact preference center (notification center) link
+var systemURLTags = map[string][]string{
+	"unsubscribe_url": {"unsubscribe_url", "notification_center_url"},
+	"preferences_url": {"notification_center_url"},
+}
+
+// SystemURLTag renders one of the signed system URLs from the render context
+type SystemURLTag struct {
+	*liquid.Tag
+	variables []string
+}
+
+// RenderToOutputBuffer writes the first available URL, or nothing when the
+// send context has none (e.g. previews without a contact)
+func (t *SystemURLTag) RenderToOutputBuffer(context liquid.TagContext, output *string) {
+	for _, variable := range t.variables {
+		if value, ok := context.FindVariable(variable, false).(string); ok && value != "" {
+			*output += value
+			return
+		}
+	}
+}
+
+// registerSystemURLTags registers the Notifuse link tags on a Liquid environment
+func registerSystemURLTags(env *liquid.Environment) {
+	for name, variables := range systemURLTags {
+		variables := variables
+		env.RegisterTag(name, tags.TagConstructor(func(tagName, markup string, parseContext liquid.ParseContextInterface) (interface{}, error) {
+			return &SystemURLTag{
+				Tag:       liquid.NewTag(tagName, markup, parseContext),
+				variables: variables,
+			}, nil
+		}))
+	}
+}
+
+// boundedForLimitVariable is the reserved variable through which a BoundedForTag
+// hands its effective limit to the standard for tag
+const boundedForLimitVariable = "__notifuse_for_limit"
+
+// BoundedForTag is the standard {% for %} tag with a cap on the number of iterations.
+// A limit set by the template still applies when it is lower than the cap.
+type BoundedForTag struct {
+	*tags.ForTag
+	limit         interface{} // limit expression from the template, nil when not set
+	maxIterations int
+}
+
+// RenderToOutputBuffer renders the loop over at most maxIterations items
+func (t *BoundedForTag) RenderToOutputBuffer(context liquid.TagContext, output *string) {
+	limit := t.maxIterations
+	if t.limit != nil {
+		if value := context.Evaluate(t.limit); value != nil {
+			if templateLimit, err := liquid.ToInteger(value); err == nil && templateLimit < limit {
+				limit = templateLimit
+			}
+		}
+	}
+
+	ctx, ok := context.Context().(*liquid.Context)
+	if !ok {
+		return
+	}
+	ctx.Stack(map[string]interface{}{boundedForLimitVariable: limit}, func() {
+		t.ForTag.RenderToOutputBuffer(context, output)
+	})
+}
+
+// registerBoundedForTag replaces the standard for tag with a BoundedForTag. maxIterations is
+// read when a template is parsed; a value of zero or less keeps the standard, unbounded tag.
+func registerBoundedForTag(env *liquid.Environment, maxIterations func() int) {
+	env.RegisterTag("for", tags.TagConstructor(func(tagName, markup string, parseContext liquid.ParseContextInterface) (interface{}, error) {
+		tag, err := tags.NewForTag(tagName, markup, parseContext)
+		if err != nil || maxIterations() <= 0 {
+			return tag, err
+		}
+
+		// The last limit attribute wins, so the appended one replaces the template's own,
+		// which is kept aside and applied at render time when lower than the cap
+		bounded, err := tags.NewForTag(tagName, markup+" limit:"+boundedForLimitVariable, parseContext)
+		if err != nil {
+			return nil, err
+		}
+		return &BoundedForTag{
+			ForTag:        bounded,
+			limit:         tag.Limit(),
+			maxIterations: maxIterations(),
+		}, nil
+	}))
+}
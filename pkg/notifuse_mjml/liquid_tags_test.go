@@ -0,0 +1,179 @@
+package notifuse_mjml
+
+import (
+	"testing"
+)
+
+func TestSecureLiquidEngine_SystemURLTags(t *testing.T) {
+	listData := map[string]interface{}{
+		"unsubscribe_url":         "https://track.example.com/notification-center?action=unsubscribe&lid=news",
+		"notification_center_url": "https://track.example.com/notification-center?email=a%40example.com",
+	}
+	transactionalData := map[string]interface{}{
+		"notification_center_url": "https://track.example.com/notification-center?email=a%40example.com",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		data     map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "unsubscribe_url renders the list-scoped link",
+			template: `<a href="{% unsubscribe_url %}">Unsubscribe</a>`,
+			data:     listData,
+			expected: `<a href="https://track.example.com/notification-center?action=unsubscribe&lid=news">Unsubscribe</a>`,
+		},
+		{
+			name:     "preferences_url renders the preference center link",
+			template: `<a href="{% preferences_url %}">Preferences</a>`,
+			data:     listData,
+			expected: `<a href="https://track.example.com/notification-center?email=a%40example.com">Preferences</a>`,
+		},
+		{
+			name:     "unsubscribe_url falls back to the preference center without a list",
+			template: `{% unsubscribe_url %}`,
+			data:     transactionalData,
+			expected: "https://track.example.com/notification-center?email=a%40example.com",
+		},
+		{
+			name:     "tags render nothing without a contact",
+			template: `[{% unsubscribe_url %}][{% preferences_url %}]`,
+			data:     map[string]interface{}{},
+			expected: "[][]",
+		},
+		{
+			name:     "tags work inside control flow",
+			template: `{% if contact.first_name %}{% preferences_url %}{% endif %}`,
+			data: map[string]interface{}{
+				"contact":                 map[string]interface{}{"first_name": "Ann"},
+				"notification_center_url": "https://track.example.com/nc",
+			},
+			expected: "https://track.example.com/nc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewSecureLiquidEngine()
+			result, err := engine.Render(tt.template, tt.data)
+			if err != nil {
+				t.Fatalf("Render returned error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Render() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSecureLiquidEngine_BoundedForTag(t *testing.T) {
+	feedItems := func(count int) map[string]interface{} {
+		items := make([]interface{}, count)
+		for i := range items {
+			items[i] = map[string]interface{}{"title": "x"}
+		}
+		return map[string]interface{}{
+			"recipient_feed": map[string]interface{}{"items": items},
+		}
+	}
+
+	t.Run("renders every item of a small feed array", func(t *testing.T) {
+		data := map[string]interface{}{
+			"recipient_feed": map[string]interface{}{
+				"items": []interface{}{
+					map[string]interface{}{"title": "First"},
+					map[string]interface{}{"title": "Second"},
+					map[string]interface{}{"title": "Third"},
+				},
+			},
+		}
+
+		engine := NewSecureLiquidEngine()
+		result, err := engine.Render(`{% for item in recipient_feed.items %}[{{ item.title }}]{% endfor %}`, data)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != "[First][Second][Third]" {
+			t.Errorf("expected all three items, got %q", result)
+		}
+	})
+
+	t.Run("caps a huge feed array at the default limit", func(t *testing.T) {
+		engine := NewSecureLiquidEngine()
+		result, err := engine.Render(`{% for item in recipient_feed.items %}{{ item.title }}{% endfor %}`, feedItems(100000))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result) != DefaultMaxLoopIterations {
+			t.Errorf("expected %d iterations, got %d", DefaultMaxLoopIterations, len(result))
+		}
+	})
+
+	t.Run("caps at a configured limit", func(t *testing.T) {
+		engine := NewSecureLiquidEngine()
+		engine.SetMaxLoopIterations(5)
+		result, err := engine.Render(`{% for item in recipient_feed.items %}{{ item.title }}{% endfor %}{{ recipient_feed.items.size }}`, feedItems(50))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != "xxxxx50" {
+			t.Errorf("expected 5 iterations, got %q", result)
+		}
+	})
+
+	t.Run("keeps a lower template limit", func(t *testing.T) {
+		engine := NewSecureLiquidEngine()
+		result, err := engine.Render(`{% for item in recipient_feed.items limit: 3 %}{{ item.title }}{% endfor %}`, feedItems(50))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != "xxx" {
+			t.Errorf("expected 3 iterations, got %q", result)
+		}
+	})
+
+	t.Run("ignores a template limit above the cap", func(t *testing.T) {
+		engine := NewSecureLiquidEngine()
+		engine.SetMaxLoopIterations(4)
+		result, err := engine.Render(`{% for item in recipient_feed.items limit: 40 %}{{ item.title }}{% endfor %}`, feedItems(50))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != "xxxx" {
+			t.Errorf("expected 4 iterations, got %q", result)
+		}
+	})
+
+	t.Run("forloop and else still work", func(t *testing.T) {
+		engine := NewSecureLiquidEngine()
+		result, err := engine.Render(`{% for item in recipient_feed.items %}{{ forloop.index }}/{{ forloop.length }} {% else %}empty{% endfor %}`, feedItems(2))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != "1/2 2/2 " {
+			t.Errorf("unexpected result %q", result)
+		}
+
+		result, err = engine.Render(`{% for item in recipient_feed.items %}x{% else %}empty{% endfor %}`, feedItems(0))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != "empty" {
+			t.Errorf("expected else branch, got %q", result)
+		}
+	})
+
+	t.Run("disabled cap keeps the standard tag", func(t *testing.T) {
+		engine := NewSecureLiquidEngine()
+		engine.SetMaxLoopIterations(0)
+		result, err := engine.Render(`{% for item in recipient_feed.items %}{{ item.title }}{% endfor %}`, feedItems(150))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result) != 150 {
+			t.Errorf("expected 150 iterations, got %d", len(result))
+		}
+	})
+}
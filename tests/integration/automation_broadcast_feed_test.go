@@ -0,0 +1,172 @@
+package integration
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/service"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	shortuuid "github.com/lithammer/shortuuid/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAutomationBroadcastGlobalFeed verifies that an automation triggered by a broadcast
+// email being opened keeps the broadcast context, so its email node renders the same
+// global_feed value as the broadcast
+func TestAutomationBroadcastGlobalFeed(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	factory := suite.DataFactory
+	client := suite.APIClient
+
+	user, err := factory.CreateUser()
+	require.NoError(t, err)
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+	err = factory.AddUserToWorkspace(user.ID, workspace.ID, "owner")
+	require.NoError(t, err)
+	_, err = factory.SetupWorkspaceWithSMTPProvider(workspace.ID)
+	require.NoError(t, err)
+
+	err = client.Login(user.Email, "password")
+	require.NoError(t, err)
+	client.SetWorkspaceID(workspace.ID)
+
+	list, err := factory.CreateList(workspace.ID)
+	require.NoError(t, err)
+	template, err := factory.CreateTemplate(workspace.ID,
+		testutil.WithTemplateSubject("Still thinking about {{ global_feed.headline }}?"))
+	require.NoError(t, err)
+
+	fetchedAt := time.Now().UTC()
+	broadcast, err := factory.CreateBroadcast(workspace.ID,
+		testutil.WithBroadcastGlobalFeedData(map[string]interface{}{"headline": "Spring Sale"}, &fetchedAt))
+	require.NoError(t, err)
+
+	// Automation enrolling contacts who open an email
+	automationID := shortuuid.New()
+	triggerNodeID := shortuuid.New()
+	emailNodeID := shortuuid.New()
+
+	resp, err := client.CreateAutomation(map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"automation": map[string]interface{}{
+			"id":           automationID,
+			"workspace_id": workspace.ID,
+			"name":         "Broadcast Follow-up",
+			"status":       "draft",
+			"list_id":      list.ID,
+			"trigger": map[string]interface{}{
+				"event_kind": "email.opened",
+				"frequency":  "every_time",
+			},
+			"root_node_id": triggerNodeID,
+			"nodes": []map[string]interface{}{
+				{
+					"id":            triggerNodeID,
+					"automation_id": automationID,
+					"type":          "trigger",
+					"next_node_id":  emailNodeID,
+					"config":        map[string]interface{}{},
+					"position":      map[string]interface{}{"x": 0, "y": 0},
+				},
+				{
+					"id":            emailNodeID,
+					"automation_id": automationID,
+					"type":          "email",
+					"config":        map[string]interface{}{"template_id": template.ID},
+					"position":      map[string]interface{}{"x": 0, "y": 100},
+				},
+			},
+			"stats": map[string]interface{}{"enrolled": 0, "completed": 0, "exited": 0, "failed": 0},
+		},
+	})
+	require.NoError(t, err)
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		t.Fatalf("CreateAutomation: expected 201, got %d: %s", resp.StatusCode, string(body))
+	}
+	resp.Body.Close()
+
+	activateResp, err := client.ActivateAutomation(map[string]interface{}{
+		"workspace_id":  workspace.ID,
+		"automation_id": automationID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, activateResp.StatusCode)
+	activateResp.Body.Close()
+
+	contact, err := factory.CreateContact(workspace.ID, testutil.WithContactEmail("feed-reader@example.com"))
+	require.NoError(t, err)
+	_, err = factory.CreateContactList(workspace.ID,
+		testutil.WithContactListEmail(contact.Email),
+		testutil.WithContactListListID(list.ID),
+		testutil.WithContactListStatus(domain.ContactListStatusActive),
+	)
+	require.NoError(t, err)
+
+	// The contact receives the broadcast, then opens it
+	message, err := factory.CreateMessageHistory(workspace.ID,
+		testutil.WithMessageContact(contact.Email),
+		testutil.WithMessageBroadcast(broadcast.ID))
+	require.NoError(t, err)
+
+	workspaceRepo := suite.ServerManager.GetApp().GetWorkspaceRepository()
+	workspaceDB, err := workspaceRepo.GetConnection(context.Background(), workspace.ID)
+	require.NoError(t, err)
+	_, err = workspaceDB.ExecContext(context.Background(),
+		`UPDATE message_history SET opened_at = NOW(), updated_at = NOW() WHERE id = $1`, message.ID)
+	require.NoError(t, err)
+
+	ca := waitForEnrollment(t, factory, workspace.ID, automationID, contact.Email, 2*time.Second)
+	require.NotNil(t, ca)
+	assert.Equal(t, broadcast.ID, ca.Context[domain.ContactAutomationContextBroadcastID])
+	assert.Equal(t, domain.MapOfAny{"headline": "Spring Sale"}, ca.GlobalFeed())
+
+	// Run the email node for the enrollment and check the queued subject
+	application := suite.ServerManager.GetApp()
+	executor := service.NewEmailNodeExecutor(
+		application.GetEmailQueueRepository(),
+		application.GetTemplateRepository(),
+		workspaceRepo,
+		application.GetListRepository(),
+		application.GetContactListRepository(),
+		"https://api.example.com",
+		application.GetLogger(),
+	)
+
+	result, err := executor.Execute(context.Background(), service.NodeExecutionParams{
+		WorkspaceID: workspace.ID,
+		Contact:     ca,
+		Node: &domain.AutomationNode{
+			ID:     emailNodeID,
+			Type:   domain.NodeTypeEmail,
+			Config: map[string]interface{}{"template_id": template.ID},
+		},
+		Automation:  &domain.Automation{ID: automationID, Name: "Broadcast Follow-up", ListID: list.ID},
+		ContactData: contact,
+	})
+	require.NoError(t, err)
+	require.Equal(t, true, result.Output["queued"])
+
+	var subject string
+	err = workspaceDB.QueryRowContext(context.Background(),
+		`SELECT payload->>'subject' FROM email_queue WHERE message_id = $1`, result.Output["message_id"]).Scan(&subject)
+	require.NoError(t, err)
+	assert.Equal(t, "Still thinking about Spring Sale?", subject)
+}
@@ -0,0 +1,107 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/service"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAutomationBranch_ComputedDaysSince verifies that branch nodes can route contacts
+// on computed fields like days_since(created_at), evaluated against the workspace database
+func TestAutomationBranch_ComputedDaysSince(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	factory := suite.DataFactory
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	veteran, err := factory.CreateContact(workspace.ID,
+		testutil.WithContactEmail("veteran@example.com"),
+		testutil.WithContactCreatedAt(now.AddDate(0, 0, -40)))
+	require.NoError(t, err)
+	newcomer, err := factory.CreateContact(workspace.ID,
+		testutil.WithContactEmail("newcomer@example.com"),
+		testutil.WithContactCreatedAt(now.AddDate(0, 0, -10)))
+	require.NoError(t, err)
+
+	executor := service.NewBranchNodeExecutor(
+		service.NewQueryBuilder(),
+		suite.ServerManager.GetApp().GetWorkspaceRepository(),
+	)
+
+	node := &domain.AutomationNode{
+		ID:   "branch1",
+		Type: domain.NodeTypeBranch,
+		Config: map[string]interface{}{
+			"paths": []interface{}{
+				map[string]interface{}{
+					"id":           "older",
+					"name":         "Signed up over 30 days ago",
+					"next_node_id": "older_node",
+					"conditions": map[string]interface{}{
+						"kind": "leaf",
+						"leaf": map[string]interface{}{
+							"source": "contacts",
+							"contact": map[string]interface{}{
+								"filters": []interface{}{
+									map[string]interface{}{
+										"field_name":    "days_since(created_at)",
+										"field_type":    "number",
+										"operator":      "gt",
+										"number_values": []interface{}{30},
+									},
+								},
+							},
+						},
+					},
+				},
+				map[string]interface{}{
+					"id":           "recent",
+					"name":         "Recent signups",
+					"next_node_id": "recent_node",
+				},
+			},
+			"default_path_id": "recent",
+		},
+	}
+
+	t.Run("contact created 40 days ago takes the condition path", func(t *testing.T) {
+		result, err := executor.Execute(context.Background(), service.NodeExecutionParams{
+			WorkspaceID: workspace.ID,
+			Node:        node,
+			ContactData: veteran,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "older_node", *result.NextNodeID)
+		assert.Equal(t, "older", result.Output["path_taken"])
+	})
+
+	t.Run("contact created 10 days ago takes the default path", func(t *testing.T) {
+		result, err := executor.Execute(context.Background(), service.NodeExecutionParams{
+			WorkspaceID: workspace.ID,
+			Node:        node,
+			ContactData: newcomer,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "recent_node", *result.NextNodeID)
+		assert.Equal(t, "default", result.Output["path_taken"])
+	})
+}
@@ -0,0 +1,86 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/repository"
+	"github.com/Notifuse/notifuse/internal/service"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAutomationContactDeleted_ExitsEnrollment enrolls a contact, deletes it before the
+// scheduler runs, and checks the scheduler exits its automation with reason contact_deleted
+// instead of retrying the missing contact lookup until the enrollment fails
+func TestAutomationContactDeleted_ExitsEnrollment(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	ctx := context.Background()
+	factory := suite.DataFactory
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+
+	automation, err := factory.CreateAutomation(workspace.ID,
+		testutil.WithAutomationName("Welcome"),
+		testutil.WithAutomationStatus(domain.AutomationStatusLive),
+		testutil.WithAutomationRootNodeID("wait"),
+		testutil.WithAutomationNodes([]*domain.AutomationNode{
+			{ID: "wait", Type: domain.NodeTypeDelay, Config: map[string]interface{}{"duration": 1, "unit": "days"}},
+		}),
+	)
+	require.NoError(t, err)
+
+	email := "leaving@example.com"
+	_, err = factory.CreateContact(workspace.ID, testutil.WithContactEmail(email))
+	require.NoError(t, err)
+
+	testApp := suite.ServerManager.GetApp()
+	workspaceRepo := testApp.GetWorkspaceRepository()
+	contactRepo := testApp.GetContactRepository()
+	automationRepo := repository.NewAutomationRepository(workspaceRepo, service.NewAutomationTriggerGenerator(service.NewQueryBuilder()))
+
+	enrolled, err := automationRepo.EnrollContact(ctx, workspace.ID, automation, email)
+	require.NoError(t, err)
+	require.True(t, enrolled)
+
+	require.NoError(t, contactRepo.DeleteContact(ctx, workspace.ID, email))
+
+	executor := service.NewAutomationExecutor(
+		automationRepo, contactRepo, workspaceRepo, nil, nil, nil, nil, nil,
+		repository.NewContactTimelineRepository(workspaceRepo), nil, nil,
+		testApp.GetLogger(),
+		"",
+	)
+
+	// The first pass exits the enrollment, later passes leave it alone
+	for i := 0; i < 2; i++ {
+		_, err = executor.ProcessBatch(ctx, 100)
+		require.NoError(t, err)
+	}
+
+	ca, err := automationRepo.GetContactAutomationByEmail(ctx, workspace.ID, automation.ID, email)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ContactAutomationStatusExited, ca.Status)
+	require.NotNil(t, ca.ExitReason)
+	assert.Equal(t, domain.ContactDeletedExitReason, *ca.ExitReason)
+	assert.Equal(t, 0, ca.RetryCount)
+	assert.Nil(t, ca.LastError)
+	assert.Nil(t, ca.ScheduledAt)
+
+	stats, err := factory.GetAutomationStats(workspace.ID, automation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Exited)
+	assert.Equal(t, int64(0), stats.Failed)
+}
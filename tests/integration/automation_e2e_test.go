@@ -195,6 +195,15 @@ func TestAutomation(t *testing.T) {
 	t.Run("MultipleEntries", func(t *testing.T) {
 		testAutomationMultipleEntries(t, factory, client, workspace.ID)
 	})
+	t.Run("MultipleTriggerEvents", func(t *testing.T) {
+		testAutomationMultipleTriggerEvents(t, factory, client, workspace.ID)
+	})
+	t.Run("EnrollmentConditions", func(t *testing.T) {
+		testAutomationEnrollmentConditions(t, factory, client, workspace.ID)
+	})
+	t.Run("Prerequisite", func(t *testing.T) {
+		testAutomationPrerequisite(t, factory, client, workspace.ID)
+	})
 	t.Run("DelayTiming", func(t *testing.T) {
 		testAutomationDelayTiming(t, factory, client, workspace.ID)
 	})
@@ -213,12 +222,30 @@ func TestAutomation(t *testing.T) {
 	t.Run("ListOperations", func(t *testing.T) {
 		testAutomationListOperations(t, factory, client, workspace.ID)
 	})
+	t.Run("UpdateContact", func(t *testing.T) {
+		testAutomationUpdateContact(t, factory, client, workspace.ID)
+	})
+	t.Run("UnsubscribeAll", func(t *testing.T) {
+		testAutomationUnsubscribeAll(t, factory, client, workspace.ID)
+	})
+	t.Run("EnrollInAutomation", func(t *testing.T) {
+		testAutomationEnrollInAutomation(t, factory, client, workspace.ID)
+	})
+	t.Run("RecordRevenue", func(t *testing.T) {
+		testAutomationRecordRevenue(t, factory, client, workspace.ID)
+	})
+	t.Run("WaitForListStatus", func(t *testing.T) {
+		testAutomationWaitForListStatus(t, factory, client, workspace.ID)
+	})
 	t.Run("ContextData", func(t *testing.T) {
 		testAutomationContextData(t, factory, client, workspace.ID)
 	})
 	t.Run("SegmentTrigger", func(t *testing.T) {
 		testAutomationSegmentTrigger(t, factory, client, workspace.ID)
 	})
+	t.Run("MissingTriggerSegment", func(t *testing.T) {
+		testAutomationMissingTriggerSegment(t, client, workspace.ID)
+	})
 	t.Run("DeletionCleanup", func(t *testing.T) {
 		testAutomationDeletionCleanup(t, factory, client, workspace.ID)
 	})
@@ -231,6 +258,9 @@ func TestAutomation(t *testing.T) {
 	t.Run("PauseResume", func(t *testing.T) {
 		testAutomationPauseResume(t, factory, client, workspace.ID)
 	})
+	t.Run("VersionedLiveEdit", func(t *testing.T) {
+		testAutomationVersionedLiveEdit(t, factory, client, workspace.ID)
+	})
 	t.Run("Permissions", func(t *testing.T) {
 		// Permissions test needs additional users with different permission levels
 		memberNoPerms, err := factory.CreateUser()
@@ -273,6 +303,9 @@ func TestAutomation(t *testing.T) {
 	t.Run("TimelineEndEvent_Completed", func(t *testing.T) {
 		testAutomationTimelineEndEvent(t, factory, client, workspace.ID)
 	})
+	t.Run("ContactTimeline", func(t *testing.T) {
+		testAutomationContactTimeline(t, factory, client, workspace.ID)
+	})
 	t.Run("ContactCreatedTrigger", func(t *testing.T) {
 		testAutomationContactCreatedTrigger(t, factory, client, workspace.ID)
 	})
@@ -526,6 +559,319 @@ func testAutomationDeduplication(t *testing.T, factory *testutil.TestDataFactory
 	t.Logf("Deduplication E2E test passed: frequency=once working correctly, automation completed")
 }
 
+// testAutomationMultipleTriggerEvents tests an automation enrolling on list.subscribed OR a custom event,
+// with frequency: once deduplicating enrollments across both events
+// Uses HTTP for automation CRUD, factory for lists, subscriptions and timeline events (intentional)
+func testAutomationMultipleTriggerEvents(t *testing.T, factory *testutil.TestDataFactory, client *testutil.APIClient, workspaceID string) {
+	// 1. Create list via factory
+	list, err := factory.CreateList(workspaceID)
+	require.NoError(t, err)
+
+	// 2. Create automation via HTTP with two trigger events
+	automationID := shortuuid.New()
+	triggerNodeID := shortuuid.New()
+	eventName := "multi_trigger_event_e2e"
+
+	createReq := map[string]interface{}{
+		"workspace_id": workspaceID,
+		"automation": map[string]interface{}{
+			"id":           automationID,
+			"workspace_id": workspaceID,
+			"name":         "Multiple Trigger Events E2E",
+			"status":       "draft",
+			"trigger": map[string]interface{}{
+				"event_kind": "list.subscribed",
+				"list_id":    list.ID,
+				"events": []map[string]interface{}{
+					{"event_kind": "custom_event", "custom_event_name": eventName},
+				},
+				"frequency": "once",
+			},
+			"root_node_id": triggerNodeID,
+			"nodes": []map[string]interface{}{
+				{
+					"id":            triggerNodeID,
+					"automation_id": automationID,
+					"type":          "trigger",
+					"config":        map[string]interface{}{},
+					"position":      map[string]interface{}{"x": 0, "y": 0},
+				},
+			},
+			"stats": map[string]interface{}{"enrolled": 0, "completed": 0, "exited": 0, "failed": 0},
+		},
+	}
+
+	resp, err := client.CreateAutomation(createReq)
+	require.NoError(t, err)
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		t.Fatalf("MultipleTriggerEvents CreateAutomation: Expected 201, got %d: %s", resp.StatusCode, string(body))
+	}
+	resp.Body.Close()
+
+	// 3. Activate automation via HTTP
+	activateResp, err := client.ActivateAutomation(map[string]interface{}{
+		"workspace_id":  workspaceID,
+		"automation_id": automationID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, activateResp.StatusCode)
+	activateResp.Body.Close()
+
+	// 4. First contact enrolls through the list subscription
+	listEmail := "multi-trigger-list-e2e@example.com"
+	_, err = factory.CreateContact(workspaceID, testutil.WithContactEmail(listEmail))
+	require.NoError(t, err)
+	_, err = factory.CreateContactList(workspaceID,
+		testutil.WithContactListEmail(listEmail),
+		testutil.WithContactListListID(list.ID),
+		testutil.WithContactListStatus(domain.ContactListStatusActive),
+	)
+	require.NoError(t, err)
+
+	ca := waitForEnrollment(t, factory, workspaceID, automationID, listEmail, 2*time.Second)
+	require.NotNil(t, ca, "Contact should be enrolled via list.subscribed")
+
+	// 5. Second contact enrolls through the custom event
+	eventEmail := "multi-trigger-event-e2e@example.com"
+	_, err = factory.CreateContact(workspaceID, testutil.WithContactEmail(eventEmail))
+	require.NoError(t, err)
+	err = factory.CreateCustomEvent(workspaceID, eventEmail, eventName, map[string]interface{}{})
+	require.NoError(t, err)
+
+	ca = waitForEnrollment(t, factory, workspaceID, automationID, eventEmail, 2*time.Second)
+	require.NotNil(t, ca, "Contact should be enrolled via custom event")
+
+	// 6. Dedup across events: the list subscriber fires the custom event,
+	// and the custom event contact fires it again - neither re-enrolls
+	err = factory.CreateCustomEvent(workspaceID, listEmail, eventName, map[string]interface{}{})
+	require.NoError(t, err)
+	err = factory.CreateCustomEvent(workspaceID, eventEmail, eventName, map[string]interface{}{})
+	require.NoError(t, err)
+
+	// Give triggers a chance to (incorrectly) enroll again
+	time.Sleep(500 * time.Millisecond)
+
+	count, err := factory.CountContactAutomations(workspaceID, automationID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "Each contact should be enrolled exactly once across both trigger events")
+
+	stats := waitForStatsCompleted(t, factory, workspaceID, automationID, 2, 5*time.Second)
+	require.NotNil(t, stats, "Stats should exist")
+	assert.Equal(t, int64(2), stats.Enrolled, "Enrolled should be 2 despite 4 matching events")
+
+	t.Logf("Multiple trigger events E2E test passed: enrollment from either event, dedup across both")
+}
+
+// testAutomationEnrollmentConditions tests a list.subscribed trigger with enrollment_conditions:
+// every contact subscribes to the list, but only those matching country=US are enrolled
+// Uses HTTP for automation CRUD, factory for lists and subscriptions (intentional)
+func testAutomationEnrollmentConditions(t *testing.T, factory *testutil.TestDataFactory, client *testutil.APIClient, workspaceID string) {
+	// 1. Create list via factory
+	list, err := factory.CreateList(workspaceID)
+	require.NoError(t, err)
+
+	// 2. Create automation via HTTP with enrollment conditions on the trigger
+	automationID := shortuuid.New()
+	triggerNodeID := shortuuid.New()
+
+	createReq := map[string]interface{}{
+		"workspace_id": workspaceID,
+		"automation": map[string]interface{}{
+			"id":           automationID,
+			"workspace_id": workspaceID,
+			"name":         "Enrollment Conditions E2E",
+			"status":       "draft",
+			"list_id":      list.ID,
+			"trigger": map[string]interface{}{
+				"event_kind": "list.subscribed",
+				"list_id":    list.ID,
+				"enrollment_conditions": map[string]interface{}{
+					"kind": "leaf",
+					"leaf": map[string]interface{}{
+						"source": "contacts",
+						"contact": map[string]interface{}{
+							"filters": []map[string]interface{}{
+								{
+									"field_name":    "country",
+									"field_type":    "string",
+									"operator":      "equals",
+									"string_values": []string{"US"},
+								},
+							},
+						},
+					},
+				},
+				"frequency": "once",
+			},
+			"root_node_id": triggerNodeID,
+			"nodes": []map[string]interface{}{
+				{
+					"id":            triggerNodeID,
+					"automation_id": automationID,
+					"type":          "trigger",
+					"config":        map[string]interface{}{},
+					"position":      map[string]interface{}{"x": 0, "y": 0},
+				},
+			},
+			"stats": map[string]interface{}{"enrolled": 0, "completed": 0, "exited": 0, "failed": 0},
+		},
+	}
+
+	resp, err := client.CreateAutomation(createReq)
+	require.NoError(t, err)
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		t.Fatalf("EnrollmentConditions CreateAutomation: Expected 201, got %d: %s", resp.StatusCode, string(body))
+	}
+	resp.Body.Close()
+
+	// 3. Activate automation via HTTP
+	activateResp, err := client.ActivateAutomation(map[string]interface{}{
+		"workspace_id":  workspaceID,
+		"automation_id": automationID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, activateResp.StatusCode)
+	activateResp.Body.Close()
+
+	// 4. Subscribe a US contact and a French contact to the list
+	usEmail := "enroll-cond-us-e2e@example.com"
+	frEmail := "enroll-cond-fr-e2e@example.com"
+	for email, country := range map[string]string{usEmail: "US", frEmail: "FR"} {
+		_, err = factory.CreateContact(workspaceID,
+			testutil.WithContactEmail(email),
+			testutil.WithContactCountry(country))
+		require.NoError(t, err)
+		_, err = factory.CreateContactList(workspaceID,
+			testutil.WithContactListEmail(email),
+			testutil.WithContactListListID(list.ID),
+			testutil.WithContactListStatus(domain.ContactListStatusActive),
+		)
+		require.NoError(t, err)
+	}
+
+	// 5. Only the US contact is enrolled
+	ca := waitForEnrollment(t, factory, workspaceID, automationID, usEmail, 2*time.Second)
+	require.NotNil(t, ca, "US contact should be enrolled")
+
+	// Give the trigger a chance to (incorrectly) enroll the French contact
+	time.Sleep(500 * time.Millisecond)
+
+	frCA, err := factory.GetContactAutomation(workspaceID, automationID, frEmail)
+	assert.True(t, err != nil || frCA == nil, "French contact should not be enrolled")
+
+	count, err := factory.CountContactAutomations(workspaceID, automationID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "Only contacts matching the enrollment conditions should be enrolled")
+
+	// 6. Both subscriptions are recorded on the timeline regardless of enrollment
+	waitForTimelineEvent(t, factory, workspaceID, frEmail, "list.subscribed", 2*time.Second)
+
+	t.Logf("Enrollment conditions E2E test passed: only country=US subscriber enrolled")
+}
+
+// testAutomationPrerequisite tests a custom_event trigger with a prerequisite: both contacts
+// fire the event, but only the one who completed the prerequisite automation is enrolled
+// Uses HTTP for automation CRUD, factory for the prerequisite history and events (intentional)
+func testAutomationPrerequisite(t *testing.T, factory *testutil.TestDataFactory, client *testutil.APIClient, workspaceID string) {
+	// 1. Prerequisite automation, completed by one contact only
+	onboarding, err := factory.CreateAutomation(workspaceID, testutil.WithAutomationName("Onboarding"))
+	require.NoError(t, err)
+
+	completedEmail := "prereq-completed-e2e@example.com"
+	skippedEmail := "prereq-skipped-e2e@example.com"
+	for _, email := range []string{completedEmail, skippedEmail} {
+		_, err = factory.CreateContact(workspaceID, testutil.WithContactEmail(email))
+		require.NoError(t, err)
+	}
+
+	workspaceDB, err := factory.GetWorkspaceDB(workspaceID)
+	require.NoError(t, err)
+	_, err = workspaceDB.Exec(
+		`INSERT INTO contact_automations (id, automation_id, contact_email, status, entered_at)
+		 VALUES ($1, $2, $3, 'completed', NOW() - INTERVAL '1 day'),
+		        ($4, $2, $5, 'exited', NOW() - INTERVAL '1 day')`,
+		shortuuid.New(), onboarding.ID, completedEmail, shortuuid.New(), skippedEmail)
+	require.NoError(t, err)
+
+	// 2. Create automation via HTTP with the prerequisite on the trigger
+	automationID := shortuuid.New()
+	triggerNodeID := shortuuid.New()
+
+	createReq := map[string]interface{}{
+		"workspace_id": workspaceID,
+		"automation": map[string]interface{}{
+			"id":           automationID,
+			"workspace_id": workspaceID,
+			"name":         "Prerequisite E2E",
+			"status":       "draft",
+			"trigger": map[string]interface{}{
+				"event_kind":        "custom_event",
+				"custom_event_name": "prereq_purchase",
+				"prerequisite": map[string]interface{}{
+					"automation_id": onboarding.ID,
+					"within":        30,
+					"within_unit":   "days",
+				},
+				"frequency": "once",
+			},
+			"root_node_id": triggerNodeID,
+			"nodes": []map[string]interface{}{
+				{
+					"id":            triggerNodeID,
+					"automation_id": automationID,
+					"type":          "trigger",
+					"config":        map[string]interface{}{},
+					"position":      map[string]interface{}{"x": 0, "y": 0},
+				},
+			},
+			"stats": map[string]interface{}{"enrolled": 0, "completed": 0, "exited": 0, "failed": 0},
+		},
+	}
+
+	resp, err := client.CreateAutomation(createReq)
+	require.NoError(t, err)
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		t.Fatalf("Prerequisite CreateAutomation: Expected 201, got %d: %s", resp.StatusCode, string(body))
+	}
+	resp.Body.Close()
+
+	// 3. Activate automation via HTTP
+	activateResp, err := client.ActivateAutomation(map[string]interface{}{
+		"workspace_id":  workspaceID,
+		"automation_id": automationID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, activateResp.StatusCode)
+	activateResp.Body.Close()
+
+	// 4. Both contacts fire the trigger event
+	for _, email := range []string{completedEmail, skippedEmail} {
+		require.NoError(t, factory.CreateCustomEvent(workspaceID, email, "prereq_purchase", nil))
+	}
+
+	// 5. Only the contact who completed the prerequisite is enrolled
+	ca := waitForEnrollment(t, factory, workspaceID, automationID, completedEmail, 2*time.Second)
+	require.NotNil(t, ca, "Contact who completed the prerequisite should be enrolled")
+
+	// Give the trigger a chance to (incorrectly) enroll the other contact
+	time.Sleep(500 * time.Millisecond)
+
+	skippedCA, err := factory.GetContactAutomation(workspaceID, automationID, skippedEmail)
+	assert.True(t, err != nil || skippedCA == nil, "Contact who did not complete the prerequisite should not be enrolled")
+
+	count, err := factory.CountContactAutomations(workspaceID, automationID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "Only contacts who completed the prerequisite should be enrolled")
+
+	t.Logf("Prerequisite E2E test passed: only the contact who completed onboarding enrolled")
+}
+
 // testAutomationMultipleEntries tests frequency: every_time allows multiple enrollments
 // Uses HTTP for automation CRUD, factory for timeline events (intentional)
 func testAutomationMultipleEntries(t *testing.T, factory *testutil.TestDataFactory, client *testutil.APIClient, workspaceID string) {
@@ -754,6 +1100,25 @@ func testAutomationDelayTiming(t *testing.T, factory *testutil.TestDataFactory,
 	}
 	t.Logf("Delay scheduled at: %v (%.1f minutes from trigger)", ca.ScheduledAt, ca.ScheduledAt.Sub(beforeTrigger).Minutes())
 
+	// 9. Verify the next-tick preview reports the delay's scheduled_at and the add_to_list node
+	nextResp, err := client.GetContactNextTick(automationID, email)
+	require.NoError(t, err)
+	defer nextResp.Body.Close()
+	require.Equal(t, http.StatusOK, nextResp.StatusCode)
+
+	var tick domain.ContactNextTick
+	require.NoError(t, json.NewDecoder(nextResp.Body).Decode(&tick))
+	require.NotNil(t, tick.CurrentNodeID)
+	assert.Equal(t, addToListNodeID, *tick.CurrentNodeID)
+	require.NotNil(t, tick.ScheduledAt)
+	assert.True(t, tick.ScheduledAt.After(time.Now()), "Next tick should be in the future while the delay is pending")
+	assert.WithinDuration(t, *ca.ScheduledAt, *tick.ScheduledAt, time.Second)
+	assert.Equal(t, domain.NextActionExecuteNode, tick.NextAction.Type)
+	require.NotNil(t, tick.NextAction.NodeID)
+	assert.Equal(t, addToListNodeID, *tick.NextAction.NodeID)
+	require.NotNil(t, tick.NextAction.NodeType)
+	assert.Equal(t, domain.NodeTypeAddToList, *tick.NextAction.NodeType)
+
 	t.Logf("Delay timing E2E test passed: scheduler advanced to delay node with correct timing")
 }
 
@@ -948,6 +1313,7 @@ func testAutomationBranchRouting(t *testing.T, factory *testutil.TestDataFactory
 							},
 						},
 						"default_path_id": addToDefaultListNodeID,
+						"verbose":         true,
 					},
 					"position": map[string]interface{}{"x": 0, "y": 100},
 				},
@@ -1016,6 +1382,26 @@ func testAutomationBranchRouting(t *testing.T, factory *testutil.TestDataFactory
 	assert.Equal(t, int64(1), stats.Completed, "Completed count should be 1")
 	t.Logf("Automation stats: enrolled=%d, completed=%d", stats.Enrolled, stats.Completed)
 
+	// 8. Verify the verbose branch execution recorded the evaluated country and the chosen path
+	executions, err := factory.GetNodeExecutions(workspaceID, completedCA.ID)
+	require.NoError(t, err)
+
+	var branchExecution *domain.NodeExecution
+	for _, exec := range executions {
+		if exec.NodeID == branchNodeID && exec.Action == domain.NodeActionCompleted {
+			branchExecution = exec
+		}
+	}
+	require.NotNil(t, branchExecution, "Branch node execution should be recorded")
+	assert.Equal(t, "vip_path", branchExecution.Output["path_taken"])
+	evaluated, ok := branchExecution.Output["evaluated_fields"].(map[string]interface{})
+	require.True(t, ok, "Verbose branch output should contain evaluated_fields, got: %v", branchExecution.Output)
+	assert.Equal(t, "US", evaluated["country"])
+	pathResults, ok := branchExecution.Output["path_results"].([]interface{})
+	require.True(t, ok, "Verbose branch output should contain path_results, got: %v", branchExecution.Output)
+	require.Len(t, pathResults, 1)
+	assert.Equal(t, map[string]interface{}{"path_id": "vip_path", "matched": true}, pathResults[0])
+
 	t.Logf("Branch routing E2E test passed: automation completed successfully")
 }
 
@@ -1506,23 +1892,24 @@ func testAutomationListOperations(t *testing.T, factory *testutil.TestDataFactor
 	t.Logf("List operations E2E test passed: both add_to_list and remove_from_list nodes executed")
 }
 
-// testAutomationContextData tests that timeline event data is passed to automation context
-// Uses HTTP for automation CRUD, factory for timeline events (intentional)
-func testAutomationContextData(t *testing.T, factory *testutil.TestDataFactory, client *testutil.APIClient, workspaceID string) {
-	// 1. Create automation via HTTP
+// testAutomationUpdateContact tests that an update_contact node renders Liquid values,
+// clears fields set to null and persists the contact before advancing
+func testAutomationUpdateContact(t *testing.T, factory *testutil.TestDataFactory, client *testutil.APIClient, workspaceID string) {
+	// 1. Create automation via HTTP with an update_contact node
 	automationID := shortuuid.New()
 	triggerNodeID := shortuuid.New()
+	updateNodeID := shortuuid.New()
 
 	createReq := map[string]interface{}{
 		"workspace_id": workspaceID,
 		"automation": map[string]interface{}{
 			"id":           automationID,
 			"workspace_id": workspaceID,
-			"name":         "Context Data Automation E2E",
+			"name":         "Update Contact Automation E2E",
 			"status":       "draft",
 			"trigger": map[string]interface{}{
-				"event_kind": "custom_event", "custom_event_name": "purchase_e2e",
-				"frequency": "every_time",
+				"event_kind": "custom_event", "custom_event_name": "update_contact_event_e2e",
+				"frequency": "once",
 			},
 			"root_node_id": triggerNodeID,
 			"nodes": []map[string]interface{}{
@@ -1531,8 +1918,22 @@ func testAutomationContextData(t *testing.T, factory *testutil.TestDataFactory,
 					"automation_id": automationID,
 					"type":          "trigger",
 					"config":        map[string]interface{}{},
+					"next_node_id":  updateNodeID,
 					"position":      map[string]interface{}{"x": 0, "y": 0},
 				},
+				{
+					"id":            updateNodeID,
+					"automation_id": automationID,
+					"type":          "update_contact",
+					"config": map[string]interface{}{
+						"fields": map[string]interface{}{
+							"custom_string_1": "{{ contact.first_name | upcase }}",
+							"custom_number_1": 42,
+							"job_title":       nil,
+						},
+					},
+					"position": map[string]interface{}{"x": 0, "y": 100},
+				},
 			},
 			"stats": map[string]interface{}{"enrolled": 0, "completed": 0, "exited": 0, "failed": 0},
 		},
@@ -1552,32 +1953,598 @@ func testAutomationContextData(t *testing.T, factory *testutil.TestDataFactory,
 	require.Equal(t, http.StatusOK, activateResp.StatusCode)
 	activateResp.Body.Close()
 
-	// 3. Create contact via HTTP
-	email := "purchase-test-e2e@example.com"
+	// 3. Create contact with the fields the node reads and clears
+	email := "update-contact-e2e@example.com"
 	contactResp, err := client.CreateContact(map[string]interface{}{
 		"workspace_id": workspaceID,
-		"contact":      map[string]interface{}{"email": email},
+		"contact": map[string]interface{}{
+			"email":      email,
+			"first_name": "Ada",
+			"job_title":  "Engineer",
+		},
 	})
 	require.NoError(t, err)
 	require.Equal(t, http.StatusOK, contactResp.StatusCode, "Contact creation should succeed")
 	contactResp.Body.Close()
 
-	// 4. Trigger with purchase data via factory (timeline events - no HTTP API)
-	err = factory.CreateCustomEvent(workspaceID, email, "purchase_e2e", map[string]interface{}{
-		"order_id": "ORD-123",
-		"amount":   99.99,
-		"items": []interface{}{
-			map[string]interface{}{"sku": "SKU-001", "qty": 2},
-		},
-	})
+	// 4. Trigger automation via factory (timeline events - no HTTP API)
+	err = factory.CreateCustomEvent(workspaceID, email, "update_contact_event_e2e", nil)
 	require.NoError(t, err)
 
-	// 5. Wait for enrollment via HTTP
+	// 5. Wait for enrollment and completion
 	caMap := waitForEnrollmentViaAPI(t, client, automationID, email, 2*time.Second)
 	require.NotNil(t, caMap)
-	t.Logf("Contact enrolled with status: %s", caMap["status"])
 
-	// 6. Wait for automation to complete (trigger-only automation should complete)
+	completedCA := waitForAutomationComplete(t, factory, workspaceID, automationID, email, 10*time.Second)
+	require.NotNil(t, completedCA, "Automation should complete")
+	assert.Equal(t, domain.ContactAutomationStatusCompleted, completedCA.Status, "Status should be completed")
+
+	// 6. Verify the contact attributes via HTTP
+	getResp, err := client.GetContactByEmail(email)
+	require.NoError(t, err)
+	defer getResp.Body.Close()
+	require.Equal(t, http.StatusOK, getResp.StatusCode)
+
+	var result map[string]interface{}
+	require.NoError(t, json.NewDecoder(getResp.Body).Decode(&result))
+	contact, ok := result["contact"].(map[string]interface{})
+	require.True(t, ok, "Response should contain the contact")
+
+	assert.Equal(t, "ADA", contact["custom_string_1"], "custom_string_1 should be rendered from Liquid")
+	assert.Equal(t, float64(42), contact["custom_number_1"], "custom_number_1 should be set")
+	assert.Nil(t, contact["job_title"], "job_title should be cleared by the explicit null")
+	assert.Equal(t, "Ada", contact["first_name"], "Fields not in the node config should be unchanged")
+
+	t.Logf("Update contact E2E test passed: contact fields updated by update_contact node")
+}
+
+// testAutomationEnrollInAutomation tests automation composition: automation A enrolls the
+// contact into automation B through an enroll_in_automation node, and B runs to completion
+// Uses HTTP for automation CRUD and list checks, factory for contacts and timeline events (intentional)
+func testAutomationEnrollInAutomation(t *testing.T, factory *testutil.TestDataFactory, client *testutil.APIClient, workspaceID string) {
+	list, err := factory.CreateList(workspaceID)
+	require.NoError(t, err)
+
+	createAndActivate := func(automationID, name, eventName string, nodes []map[string]interface{}) {
+		resp, err := client.CreateAutomation(map[string]interface{}{
+			"workspace_id": workspaceID,
+			"automation": map[string]interface{}{
+				"id":           automationID,
+				"workspace_id": workspaceID,
+				"name":         name,
+				"status":       "draft",
+				"trigger": map[string]interface{}{
+					"event_kind": "custom_event", "custom_event_name": eventName,
+					"frequency": "once",
+				},
+				"root_node_id": nodes[0]["id"],
+				"nodes":        nodes,
+				"stats":        map[string]interface{}{"enrolled": 0, "completed": 0, "exited": 0, "failed": 0},
+			},
+		})
+		require.NoError(t, err)
+		if resp.StatusCode != http.StatusCreated {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			t.Fatalf("EnrollInAutomation CreateAutomation %s: Expected 201, got %d: %s", name, resp.StatusCode, string(body))
+		}
+		resp.Body.Close()
+
+		activateResp, err := client.ActivateAutomation(map[string]interface{}{
+			"workspace_id":  workspaceID,
+			"automation_id": automationID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, activateResp.StatusCode)
+		activateResp.Body.Close()
+	}
+
+	// 1. Automation B: trigger -> add_to_list. Its own event is never fired in this test.
+	automationBID := shortuuid.New()
+	triggerBID := shortuuid.New()
+	addToListID := shortuuid.New()
+	createAndActivate(automationBID, "Enroll Target E2E", "enroll_target_never_fired", []map[string]interface{}{
+		{
+			"id":            triggerBID,
+			"automation_id": automationBID,
+			"type":          "trigger",
+			"config":        map[string]interface{}{},
+			"next_node_id":  addToListID,
+			"position":      map[string]interface{}{"x": 0, "y": 0},
+		},
+		{
+			"id":            addToListID,
+			"automation_id": automationBID,
+			"type":          "add_to_list",
+			"config":        map[string]interface{}{"list_id": list.ID, "status": "active"},
+			"position":      map[string]interface{}{"x": 0, "y": 100},
+		},
+	})
+
+	// 2. Automation A: trigger -> enroll_in_automation(B)
+	automationAID := shortuuid.New()
+	triggerAID := shortuuid.New()
+	enrollNodeID := shortuuid.New()
+	createAndActivate(automationAID, "Enroll Source E2E", "enroll_source_e2e", []map[string]interface{}{
+		{
+			"id":            triggerAID,
+			"automation_id": automationAID,
+			"type":          "trigger",
+			"config":        map[string]interface{}{},
+			"next_node_id":  enrollNodeID,
+			"position":      map[string]interface{}{"x": 0, "y": 0},
+		},
+		{
+			"id":            enrollNodeID,
+			"automation_id": automationAID,
+			"type":          "enroll_in_automation",
+			"config":        map[string]interface{}{"automation_id": automationBID},
+			"position":      map[string]interface{}{"x": 0, "y": 100},
+		},
+	})
+
+	// 3. Trigger A for a contact
+	email := "enroll-in-automation-e2e@example.com"
+	_, err = factory.CreateContact(workspaceID, testutil.WithContactEmail(email))
+	require.NoError(t, err)
+	err = factory.CreateCustomEvent(workspaceID, email, "enroll_source_e2e", nil)
+	require.NoError(t, err)
+
+	// 4. A completes after enrolling the contact into B, then B runs to completion
+	completedA := waitForAutomationComplete(t, factory, workspaceID, automationAID, email, 10*time.Second)
+	require.NotNil(t, completedA, "Automation A should complete")
+
+	completedB := waitForAutomationComplete(t, factory, workspaceID, automationBID, email, 10*time.Second)
+	require.NotNil(t, completedB, "Automation B should run and complete")
+
+	// 5. B's add_to_list node ran for the contact
+	listResp, err := client.GetContactListByIDs(workspaceID, email, list.ID)
+	require.NoError(t, err)
+	defer listResp.Body.Close()
+	require.Equal(t, http.StatusOK, listResp.StatusCode, "Automation B should have added the contact to the list")
+
+	stats, err := factory.GetAutomationStats(workspaceID, automationBID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Enrolled)
+}
+
+// testAutomationRecordRevenue tests the record_revenue node: each contact's custom_number_1
+// is recorded as revenue and the automation stats hold the per-currency total
+func testAutomationRecordRevenue(t *testing.T, factory *testutil.TestDataFactory, client *testutil.APIClient, workspaceID string) {
+	// 1. Automation: trigger -> record_revenue(custom_number_1, USD)
+	automationID := shortuuid.New()
+	triggerNodeID := shortuuid.New()
+	revenueNodeID := shortuuid.New()
+
+	resp, err := client.CreateAutomation(map[string]interface{}{
+		"workspace_id": workspaceID,
+		"automation": map[string]interface{}{
+			"id":           automationID,
+			"workspace_id": workspaceID,
+			"name":         "Record Revenue E2E",
+			"status":       "draft",
+			"trigger": map[string]interface{}{
+				"event_kind": "custom_event", "custom_event_name": "record_revenue_e2e",
+				"frequency": "once",
+			},
+			"root_node_id": triggerNodeID,
+			"nodes": []map[string]interface{}{
+				{
+					"id":            triggerNodeID,
+					"automation_id": automationID,
+					"type":          "trigger",
+					"config":        map[string]interface{}{},
+					"next_node_id":  revenueNodeID,
+					"position":      map[string]interface{}{"x": 0, "y": 0},
+				},
+				{
+					"id":            revenueNodeID,
+					"automation_id": automationID,
+					"type":          "record_revenue",
+					"config":        map[string]interface{}{"amount_field": "custom_number_1", "currency": "USD"},
+					"position":      map[string]interface{}{"x": 0, "y": 100},
+				},
+			},
+			"stats": map[string]interface{}{"enrolled": 0, "completed": 0, "exited": 0, "failed": 0},
+		},
+	})
+	require.NoError(t, err)
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		t.Fatalf("RecordRevenue CreateAutomation: Expected 201, got %d: %s", resp.StatusCode, string(body))
+	}
+	resp.Body.Close()
+
+	activateResp, err := client.ActivateAutomation(map[string]interface{}{
+		"workspace_id":  workspaceID,
+		"automation_id": automationID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, activateResp.StatusCode)
+	activateResp.Body.Close()
+
+	// 2. Trigger the automation for several contacts with different order values
+	amounts := map[string]float64{
+		"record-revenue-e2e-1@example.com": 10.5,
+		"record-revenue-e2e-2@example.com": 20,
+		"record-revenue-e2e-3@example.com": 30.25,
+	}
+	for email, amount := range amounts {
+		_, err := factory.CreateContact(workspaceID, testutil.WithContactEmail(email), testutil.WithContactCustomNumber1(amount))
+		require.NoError(t, err)
+		err = factory.CreateCustomEvent(workspaceID, email, "record_revenue_e2e", nil)
+		require.NoError(t, err)
+	}
+
+	for email := range amounts {
+		completed := waitForAutomationComplete(t, factory, workspaceID, automationID, email, 10*time.Second)
+		require.NotNil(t, completed, "Automation should complete for %s", email)
+	}
+
+	// 3. The automation's revenue aggregates every recorded amount
+	stats, err := factory.GetAutomationStats(workspaceID, automationID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), stats.Completed)
+	assert.InDelta(t, 60.75, stats.Revenue["USD"], 0.0001)
+	assert.Len(t, stats.Revenue, 1)
+}
+
+// testAutomationWaitForListStatus tests the wait_for_list_status node: a contact joining the list
+// while waiting takes the matched path, a contact that never joins takes the timeout path
+// Uses HTTP for automation CRUD and list subscription, factory to fast-forward the scheduler (intentional)
+func testAutomationWaitForListStatus(t *testing.T, factory *testutil.TestDataFactory, client *testutil.APIClient, workspaceID string) {
+	// 1. Lists: the watched list, and one per path to record which path was taken
+	watchedList, err := factory.CreateList(workspaceID)
+	require.NoError(t, err)
+	matchedList, err := factory.CreateList(workspaceID)
+	require.NoError(t, err)
+	timeoutList, err := factory.CreateList(workspaceID)
+	require.NoError(t, err)
+
+	// 2. Automation: trigger -> wait_for_list_status(active, 1 day) -> add_to_list(matched | timeout)
+	automationID := shortuuid.New()
+	triggerNodeID := shortuuid.New()
+	waitNodeID := shortuuid.New()
+	matchedNodeID := shortuuid.New()
+	timeoutNodeID := shortuuid.New()
+
+	resp, err := client.CreateAutomation(map[string]interface{}{
+		"workspace_id": workspaceID,
+		"automation": map[string]interface{}{
+			"id":           automationID,
+			"workspace_id": workspaceID,
+			"name":         "Wait For List Status E2E",
+			"status":       "draft",
+			"trigger": map[string]interface{}{
+				"event_kind": "custom_event", "custom_event_name": "wait_for_list_status_e2e",
+				"frequency": "once",
+			},
+			"root_node_id": triggerNodeID,
+			"nodes": []map[string]interface{}{
+				{
+					"id":            triggerNodeID,
+					"automation_id": automationID,
+					"type":          "trigger",
+					"config":        map[string]interface{}{},
+					"next_node_id":  waitNodeID,
+					"position":      map[string]interface{}{"x": 0, "y": 0},
+				},
+				{
+					"id":            waitNodeID,
+					"automation_id": automationID,
+					"type":          "wait_for_list_status",
+					"config": map[string]interface{}{
+						"list_id":         watchedList.ID,
+						"target_status":   "active",
+						"timeout":         1,
+						"timeout_unit":    "days",
+						"matched_node_id": matchedNodeID,
+						"timeout_node_id": timeoutNodeID,
+					},
+					"position": map[string]interface{}{"x": 0, "y": 100},
+				},
+				{
+					"id":            matchedNodeID,
+					"automation_id": automationID,
+					"type":          "add_to_list",
+					"config":        map[string]interface{}{"list_id": matchedList.ID, "status": "active"},
+					"position":      map[string]interface{}{"x": -150, "y": 200},
+				},
+				{
+					"id":            timeoutNodeID,
+					"automation_id": automationID,
+					"type":          "add_to_list",
+					"config":        map[string]interface{}{"list_id": timeoutList.ID, "status": "active"},
+					"position":      map[string]interface{}{"x": 150, "y": 200},
+				},
+			},
+			"stats": map[string]interface{}{"enrolled": 0, "completed": 0, "exited": 0, "failed": 0},
+		},
+	})
+	require.NoError(t, err)
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		t.Fatalf("WaitForListStatus CreateAutomation: Expected 201, got %d: %s", resp.StatusCode, string(body))
+	}
+	resp.Body.Close()
+
+	activateResp, err := client.ActivateAutomation(map[string]interface{}{
+		"workspace_id":  workspaceID,
+		"automation_id": automationID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, activateResp.StatusCode)
+	activateResp.Body.Close()
+
+	// 3. Enroll two contacts; neither is in the watched list, so both park on the wait node
+	joinsEmail := "wait-list-joins-e2e@example.com"
+	neverEmail := "wait-list-never-e2e@example.com"
+	parked := map[string]*domain.ContactAutomation{}
+	for _, email := range []string{joinsEmail, neverEmail} {
+		_, err := factory.CreateContact(workspaceID, testutil.WithContactEmail(email))
+		require.NoError(t, err)
+		err = factory.CreateCustomEvent(workspaceID, email, "wait_for_list_status_e2e", nil)
+		require.NoError(t, err)
+
+		var ca *domain.ContactAutomation
+		testutil.WaitForCondition(t, func() bool {
+			ca, err = factory.GetContactAutomation(workspaceID, automationID, email)
+			if err != nil || ca == nil {
+				return false
+			}
+			return ca.CurrentNodeID != nil && *ca.CurrentNodeID == waitNodeID && ca.ScheduledAt != nil
+		}, 10*time.Second, "waiting for contact to park on the wait node")
+		assert.Equal(t, domain.ContactAutomationStatusActive, ca.Status)
+		assert.True(t, ca.ScheduledAt.After(time.Now()), "Re-check should be scheduled in the future")
+		parked[email] = ca
+	}
+
+	// 4. The first contact joins the watched list; the next check resolves the wait
+	subscribeResp, err := client.UpdateContactListStatus(workspaceID, joinsEmail, watchedList.ID, "active")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, subscribeResp.StatusCode)
+	subscribeResp.Body.Close()
+
+	err = factory.UpdateContactAutomationScheduledAt(workspaceID, parked[joinsEmail].ID, time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	joined := waitForAutomationComplete(t, factory, workspaceID, automationID, joinsEmail, 15*time.Second)
+	require.NotNil(t, joined, "Contact joining the list should complete")
+
+	// 5. The second contact never joins; once the deadline has passed the timeout path is taken
+	err = factory.ExpireNodeWaitDeadline(workspaceID, parked[neverEmail].ID, waitNodeID)
+	require.NoError(t, err)
+	err = factory.UpdateContactAutomationScheduledAt(workspaceID, parked[neverEmail].ID, time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	timedOut := waitForAutomationComplete(t, factory, workspaceID, automationID, neverEmail, 15*time.Second)
+	require.NotNil(t, timedOut, "Contact that never joins should complete through the timeout path")
+
+	// 6. Each contact took its path: check the wait outcome and the path lists
+	lastWaitOutcome := func(contactAutomationID string) interface{} {
+		executions, err := factory.GetNodeExecutions(workspaceID, contactAutomationID)
+		require.NoError(t, err)
+		var outcome interface{}
+		for _, exec := range executions {
+			if exec.NodeID == waitNodeID && exec.Action == domain.NodeActionCompleted {
+				outcome = exec.Output["outcome"]
+			}
+		}
+		return outcome
+	}
+	assert.Equal(t, "matched", lastWaitOutcome(parked[joinsEmail].ID))
+	assert.Equal(t, "timed_out", lastWaitOutcome(parked[neverEmail].ID))
+
+	for _, check := range []struct {
+		email    string
+		listID   string
+		expected bool
+	}{
+		{joinsEmail, matchedList.ID, true},
+		{joinsEmail, timeoutList.ID, false},
+		{neverEmail, timeoutList.ID, true},
+		{neverEmail, matchedList.ID, false},
+	} {
+		listResp, err := client.GetContactListByIDs(workspaceID, check.email, check.listID)
+		require.NoError(t, err)
+		if check.expected {
+			assert.Equal(t, http.StatusOK, listResp.StatusCode, "%s should be in list %s", check.email, check.listID)
+		} else {
+			assert.NotEqual(t, http.StatusOK, listResp.StatusCode, "%s should not be in list %s", check.email, check.listID)
+		}
+		listResp.Body.Close()
+	}
+}
+
+// testAutomationUnsubscribeAll tests the unsubscribe_all node in both modes:
+// "unsubscribed" marks every active membership as unsubscribed, "removed" removes them
+// Uses HTTP for automation CRUD and list checks, factory for lists and timeline events (intentional)
+func testAutomationUnsubscribeAll(t *testing.T, factory *testutil.TestDataFactory, client *testutil.APIClient, workspaceID string) {
+	// 1. Create three lists via factory
+	listIDs := make([]string, 0, 3)
+	for i := 0; i < 3; i++ {
+		list, err := factory.CreateList(workspaceID)
+		require.NoError(t, err)
+		listIDs = append(listIDs, list.ID)
+	}
+
+	for _, mode := range []string{"unsubscribed", "removed"} {
+		t.Run(mode, func(t *testing.T) {
+			// 2. Create automation via HTTP: trigger -> unsubscribe_all
+			automationID := shortuuid.New()
+			triggerNodeID := shortuuid.New()
+			unsubscribeNodeID := shortuuid.New()
+			eventName := fmt.Sprintf("unsubscribe_all_%s_e2e", mode)
+
+			resp, err := client.CreateAutomation(map[string]interface{}{
+				"workspace_id": workspaceID,
+				"automation": map[string]interface{}{
+					"id":           automationID,
+					"workspace_id": workspaceID,
+					"name":         fmt.Sprintf("Unsubscribe All (%s) E2E", mode),
+					"status":       "draft",
+					"trigger": map[string]interface{}{
+						"event_kind": "custom_event", "custom_event_name": eventName,
+						"frequency": "once",
+					},
+					"root_node_id": triggerNodeID,
+					"nodes": []map[string]interface{}{
+						{
+							"id":            triggerNodeID,
+							"automation_id": automationID,
+							"type":          "trigger",
+							"config":        map[string]interface{}{},
+							"next_node_id":  unsubscribeNodeID,
+							"position":      map[string]interface{}{"x": 0, "y": 0},
+						},
+						{
+							"id":            unsubscribeNodeID,
+							"automation_id": automationID,
+							"type":          "unsubscribe_all",
+							"config":        map[string]interface{}{"status": mode},
+							"position":      map[string]interface{}{"x": 0, "y": 100},
+						},
+					},
+					"stats": map[string]interface{}{"enrolled": 0, "completed": 0, "exited": 0, "failed": 0},
+				},
+			})
+			require.NoError(t, err)
+			if resp.StatusCode != http.StatusCreated {
+				body, _ := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				t.Fatalf("UnsubscribeAll CreateAutomation: Expected 201, got %d: %s", resp.StatusCode, string(body))
+			}
+			resp.Body.Close()
+
+			activateResp, err := client.ActivateAutomation(map[string]interface{}{
+				"workspace_id":  workspaceID,
+				"automation_id": automationID,
+			})
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, activateResp.StatusCode)
+			activateResp.Body.Close()
+
+			// 3. Create contact subscribed to every list
+			email := fmt.Sprintf("unsubscribe-all-%s-e2e@example.com", mode)
+			_, err = factory.CreateContact(workspaceID, testutil.WithContactEmail(email))
+			require.NoError(t, err)
+			for _, listID := range listIDs {
+				_, err = factory.CreateContactList(workspaceID,
+					testutil.WithContactListEmail(email),
+					testutil.WithContactListListID(listID),
+					testutil.WithContactListStatus(domain.ContactListStatusActive),
+				)
+				require.NoError(t, err)
+			}
+
+			// 4. Trigger automation and wait for completion
+			err = factory.CreateCustomEvent(workspaceID, email, eventName, nil)
+			require.NoError(t, err)
+
+			completedCA := waitForAutomationComplete(t, factory, workspaceID, automationID, email, 10*time.Second)
+			require.NotNil(t, completedCA, "Automation should complete")
+
+			// 5. Every membership reflects the chosen status
+			for _, listID := range listIDs {
+				listResp, err := client.GetContactListByIDs(workspaceID, email, listID)
+				require.NoError(t, err)
+
+				if mode == "removed" {
+					assert.Equal(t, http.StatusNotFound, listResp.StatusCode, "Contact should be removed from list %s", listID)
+				} else {
+					require.Equal(t, http.StatusOK, listResp.StatusCode)
+					var result map[string]interface{}
+					require.NoError(t, json.NewDecoder(listResp.Body).Decode(&result))
+					cl, ok := result["contact_list"].(map[string]interface{})
+					require.True(t, ok, "contact_list should be present")
+					assert.Equal(t, "unsubscribed", cl["status"], "Contact should be unsubscribed from list %s", listID)
+				}
+				listResp.Body.Close()
+			}
+
+			// 6. The list changes are recorded on the timeline
+			expectedKind := "list.unsubscribed"
+			if mode == "removed" {
+				expectedKind = "list.removed"
+			}
+			events := waitForTimelineEvent(t, factory, workspaceID, email, expectedKind, 2*time.Second)
+			assert.Len(t, events, len(listIDs), "One %s event per list", expectedKind)
+		})
+	}
+}
+
+// testAutomationContextData tests that timeline event data is passed to automation context
+// Uses HTTP for automation CRUD, factory for timeline events (intentional)
+func testAutomationContextData(t *testing.T, factory *testutil.TestDataFactory, client *testutil.APIClient, workspaceID string) {
+	// 1. Create automation via HTTP
+	automationID := shortuuid.New()
+	triggerNodeID := shortuuid.New()
+
+	createReq := map[string]interface{}{
+		"workspace_id": workspaceID,
+		"automation": map[string]interface{}{
+			"id":           automationID,
+			"workspace_id": workspaceID,
+			"name":         "Context Data Automation E2E",
+			"status":       "draft",
+			"trigger": map[string]interface{}{
+				"event_kind": "custom_event", "custom_event_name": "purchase_e2e",
+				"frequency": "every_time",
+			},
+			"root_node_id": triggerNodeID,
+			"nodes": []map[string]interface{}{
+				{
+					"id":            triggerNodeID,
+					"automation_id": automationID,
+					"type":          "trigger",
+					"config":        map[string]interface{}{},
+					"position":      map[string]interface{}{"x": 0, "y": 0},
+				},
+			},
+			"stats": map[string]interface{}{"enrolled": 0, "completed": 0, "exited": 0, "failed": 0},
+		},
+	}
+
+	resp, err := client.CreateAutomation(createReq)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	// 2. Activate automation via HTTP
+	activateResp, err := client.ActivateAutomation(map[string]interface{}{
+		"workspace_id":  workspaceID,
+		"automation_id": automationID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, activateResp.StatusCode)
+	activateResp.Body.Close()
+
+	// 3. Create contact via HTTP
+	email := "purchase-test-e2e@example.com"
+	contactResp, err := client.CreateContact(map[string]interface{}{
+		"workspace_id": workspaceID,
+		"contact":      map[string]interface{}{"email": email},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, contactResp.StatusCode, "Contact creation should succeed")
+	contactResp.Body.Close()
+
+	// 4. Trigger with purchase data via factory (timeline events - no HTTP API)
+	err = factory.CreateCustomEvent(workspaceID, email, "purchase_e2e", map[string]interface{}{
+		"order_id": "ORD-123",
+		"amount":   99.99,
+		"items": []interface{}{
+			map[string]interface{}{"sku": "SKU-001", "qty": 2},
+		},
+	})
+	require.NoError(t, err)
+
+	// 5. Wait for enrollment via HTTP
+	caMap := waitForEnrollmentViaAPI(t, client, automationID, email, 2*time.Second)
+	require.NotNil(t, caMap)
+	t.Logf("Contact enrolled with status: %s", caMap["status"])
+
+	// 6. Wait for automation to complete (trigger-only automation should complete)
 	completedCA := waitForAutomationComplete(t, factory, workspaceID, automationID, email, 10*time.Second)
 	require.NotNil(t, completedCA, "Automation should complete")
 	assert.Equal(t, domain.ContactAutomationStatusCompleted, completedCA.Status, "Status should be completed")
@@ -1593,6 +2560,62 @@ func testAutomationContextData(t *testing.T, factory *testutil.TestDataFactory,
 	t.Logf("Context data E2E test passed: automation completed with purchase event")
 }
 
+// testAutomationMissingTriggerSegment verifies that an automation whose trigger references a
+// segment that does not exist is refused at activation with a descriptive error
+func testAutomationMissingTriggerSegment(t *testing.T, client *testutil.APIClient, workspaceID string) {
+	automationID := shortuuid.New()
+	triggerNodeID := shortuuid.New()
+	missingSegmentID := "seg-" + shortuuid.New()[:8]
+
+	resp, err := client.CreateAutomation(map[string]interface{}{
+		"workspace_id": workspaceID,
+		"automation": map[string]interface{}{
+			"id":           automationID,
+			"workspace_id": workspaceID,
+			"name":         "Missing Segment Trigger E2E",
+			"status":       "draft",
+			"trigger": map[string]interface{}{
+				"event_kind": "segment.joined",
+				"segment_id": missingSegmentID,
+				"frequency":  "once",
+			},
+			"root_node_id": triggerNodeID,
+			"nodes": []map[string]interface{}{
+				{
+					"id":            triggerNodeID,
+					"automation_id": automationID,
+					"type":          "trigger",
+					"config":        map[string]interface{}{},
+					"position":      map[string]interface{}{"x": 0, "y": 0},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	activateResp, err := client.ActivateAutomation(map[string]interface{}{
+		"workspace_id":  workspaceID,
+		"automation_id": automationID,
+	})
+	require.NoError(t, err)
+	defer activateResp.Body.Close()
+	body, _ := io.ReadAll(activateResp.Body)
+
+	assert.Equal(t, http.StatusBadRequest, activateResp.StatusCode)
+	assert.Contains(t, string(body), "references segment "+missingSegmentID+", which does not exist")
+
+	getResp, err := client.GetAutomation(automationID)
+	require.NoError(t, err)
+	defer getResp.Body.Close()
+	var result struct {
+		Automation domain.Automation `json:"automation"`
+	}
+	require.NoError(t, json.NewDecoder(getResp.Body).Decode(&result))
+	assert.Equal(t, domain.AutomationStatusDraft, result.Automation.Status)
+}
+
 // testAutomationSegmentTrigger tests triggering automation on segment.joined event
 // Uses HTTP for automation CRUD, factory for timeline events (intentional)
 func testAutomationSegmentTrigger(t *testing.T, factory *testutil.TestDataFactory, client *testutil.APIClient, workspaceID string) {
@@ -2160,6 +3183,202 @@ func testAutomationPauseResume(t *testing.T, factory *testutil.TestDataFactory,
 	t.Log("Pause/Resume test passed: contacts freeze when paused and resume when automation is live again")
 }
 
+// testAutomationVersionedLiveEdit tests that editing a live automation creates a new version:
+// contacts parked mid-workflow finish on the version they enrolled on, new enrollments use the new one
+func testAutomationVersionedLiveEdit(t *testing.T, factory *testutil.TestDataFactory, client *testutil.APIClient, workspaceID string) {
+	// 1. Create one list per version
+	v1List, err := factory.CreateList(workspaceID)
+	require.NoError(t, err)
+	v2List, err := factory.CreateList(workspaceID)
+	require.NoError(t, err)
+
+	// 2. Create and activate v1: trigger → delay → add_to_list(v1List)
+	automationID := shortuuid.New()
+	triggerNodeID := shortuuid.New()
+	delayNodeID := shortuuid.New()
+	v1AddNodeID := shortuuid.New()
+	v2AddNodeID := shortuuid.New()
+
+	trigger := map[string]interface{}{
+		"event_kind":        "custom_event",
+		"custom_event_name": "versioned_live_edit_event",
+		"frequency":         "once",
+	}
+
+	createReq := map[string]interface{}{
+		"workspace_id": workspaceID,
+		"automation": map[string]interface{}{
+			"id":           automationID,
+			"workspace_id": workspaceID,
+			"name":         "Versioned Live Edit E2E",
+			"status":       "draft",
+			"trigger":      trigger,
+			"root_node_id": triggerNodeID,
+			"nodes": []map[string]interface{}{
+				{
+					"id":            triggerNodeID,
+					"automation_id": automationID,
+					"type":          "trigger",
+					"config":        map[string]interface{}{},
+					"next_node_id":  delayNodeID,
+					"position":      map[string]interface{}{"x": 0, "y": 0},
+				},
+				{
+					"id":            delayNodeID,
+					"automation_id": automationID,
+					"type":          "delay",
+					"config":        map[string]interface{}{"duration": 5, "unit": "minutes"},
+					"next_node_id":  v1AddNodeID,
+					"position":      map[string]interface{}{"x": 0, "y": 100},
+				},
+				{
+					"id":            v1AddNodeID,
+					"automation_id": automationID,
+					"type":          "add_to_list",
+					"config":        map[string]interface{}{"list_id": v1List.ID, "status": "active"},
+					"position":      map[string]interface{}{"x": 0, "y": 200},
+				},
+			},
+			"stats": map[string]interface{}{"enrolled": 0, "completed": 0, "exited": 0, "failed": 0},
+		},
+	}
+
+	resp, err := client.CreateAutomation(createReq)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	activateResp, err := client.ActivateAutomation(map[string]interface{}{
+		"workspace_id":  workspaceID,
+		"automation_id": automationID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, activateResp.StatusCode)
+	activateResp.Body.Close()
+
+	// 3. Enroll a first contact and wait until it is parked after the delay
+	parkedEmail := "versioned-parked@example.com"
+	_, err = factory.CreateContact(workspaceID, testutil.WithContactEmail(parkedEmail))
+	require.NoError(t, err)
+	err = factory.CreateCustomEvent(workspaceID, parkedEmail, "versioned_live_edit_event", nil)
+	require.NoError(t, err)
+
+	var parked *domain.ContactAutomation
+	testutil.WaitForCondition(t, func() bool {
+		parked, err = factory.GetContactAutomation(workspaceID, automationID, parkedEmail)
+		if err != nil || parked == nil {
+			return false
+		}
+		return parked.CurrentNodeID != nil && *parked.CurrentNodeID == v1AddNodeID && parked.ScheduledAt != nil
+	}, 10*time.Second, "waiting for contact to be parked after the delay node")
+	require.NotNil(t, parked.AutomationVersion)
+	assert.Equal(t, 1, *parked.AutomationVersion, "Contact should be pinned to version 1")
+
+	// 4. Edit the live automation: the delay and v1 add_to_list are replaced by add_to_list(v2List)
+	updateResp, err := client.UpdateAutomation(map[string]interface{}{
+		"workspace_id": workspaceID,
+		"automation": map[string]interface{}{
+			"id":           automationID,
+			"workspace_id": workspaceID,
+			"name":         "Versioned Live Edit E2E",
+			"status":       "live",
+			"trigger":      trigger,
+			"root_node_id": triggerNodeID,
+			"nodes": []map[string]interface{}{
+				{
+					"id":            triggerNodeID,
+					"automation_id": automationID,
+					"type":          "trigger",
+					"config":        map[string]interface{}{},
+					"next_node_id":  v2AddNodeID,
+					"position":      map[string]interface{}{"x": 0, "y": 0},
+				},
+				{
+					"id":            v2AddNodeID,
+					"automation_id": automationID,
+					"type":          "add_to_list",
+					"config":        map[string]interface{}{"list_id": v2List.ID, "status": "active"},
+					"position":      map[string]interface{}{"x": 0, "y": 100},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, updateResp.StatusCode)
+	updateResp.Body.Close()
+
+	getResp, err := client.GetAutomation(automationID)
+	require.NoError(t, err)
+	defer getResp.Body.Close()
+	require.Equal(t, http.StatusOK, getResp.StatusCode)
+	var getResult struct {
+		Automation domain.Automation `json:"automation"`
+	}
+	require.NoError(t, json.NewDecoder(getResp.Body).Decode(&getResult))
+	assert.Equal(t, 2, getResult.Automation.Version, "Editing the workflow should create version 2")
+
+	// 5. Release the parked contact: it must complete on version 1
+	err = factory.UpdateContactAutomationScheduledAt(workspaceID, parked.ID, time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	waitForAutomationComplete(t, factory, workspaceID, automationID, parkedEmail, 15*time.Second)
+
+	parkedExecutions, err := factory.GetNodeExecutions(workspaceID, parked.ID)
+	require.NoError(t, err)
+	parkedNodes := map[string]bool{}
+	for _, exec := range parkedExecutions {
+		parkedNodes[exec.NodeID] = true
+	}
+	assert.True(t, parkedNodes[v1AddNodeID], "Parked contact should run the version 1 add_to_list node")
+	assert.False(t, parkedNodes[v2AddNodeID], "Parked contact should not run version 2 nodes")
+
+	// 6. Enroll a new contact: it must run version 2
+	newEmail := "versioned-new@example.com"
+	_, err = factory.CreateContact(workspaceID, testutil.WithContactEmail(newEmail))
+	require.NoError(t, err)
+	err = factory.CreateCustomEvent(workspaceID, newEmail, "versioned_live_edit_event", nil)
+	require.NoError(t, err)
+
+	enrolled := waitForAutomationComplete(t, factory, workspaceID, automationID, newEmail, 15*time.Second)
+	require.NotNil(t, enrolled)
+	require.NotNil(t, enrolled.AutomationVersion)
+	assert.Equal(t, 2, *enrolled.AutomationVersion, "New enrollment should be pinned to version 2")
+
+	newExecutions, err := factory.GetNodeExecutions(workspaceID, enrolled.ID)
+	require.NoError(t, err)
+	newNodes := map[string]bool{}
+	for _, exec := range newExecutions {
+		newNodes[exec.NodeID] = true
+	}
+	assert.True(t, newNodes[v2AddNodeID], "New enrollment should run the version 2 add_to_list node")
+	assert.False(t, newNodes[delayNodeID], "New enrollment should not run the removed delay node")
+
+	// 7. Each contact landed in its version's list
+	for _, check := range []struct {
+		email  string
+		listID string
+	}{
+		{parkedEmail, v1List.ID},
+		{newEmail, v2List.ID},
+	} {
+		listResp, err := client.GetContactListByIDs(workspaceID, check.email, check.listID)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, listResp.StatusCode, "%s should be in list %s", check.email, check.listID)
+		listResp.Body.Close()
+	}
+	for _, check := range []struct {
+		email  string
+		listID string
+	}{
+		{parkedEmail, v2List.ID},
+		{newEmail, v1List.ID},
+	} {
+		listResp, err := client.GetContactListByIDs(workspaceID, check.email, check.listID)
+		require.NoError(t, err)
+		assert.NotEqual(t, http.StatusOK, listResp.StatusCode, "%s should not be in list %s", check.email, check.listID)
+		listResp.Body.Close()
+	}
+}
+
 // testAutomationPermissions tests that automation API respects user permissions
 func testAutomationPermissions(t *testing.T, factory *testutil.TestDataFactory, client *testutil.APIClient, workspaceID string, owner *domain.User, memberNoPerms *domain.User, memberReadOnly *domain.User) {
 	// Owner creates an automation via HTTP
@@ -2401,6 +3620,139 @@ func testAutomationTimelineStartEvent(t *testing.T, factory *testutil.TestDataFa
 }
 
 // testAutomationTimelineEndEvent tests that automation.end timeline event is created when contact completes
+// testAutomationContactTimeline tests that contacts.timeline returns the enrollment, node
+// and send events of a contact that ran an automation
+func testAutomationContactTimeline(t *testing.T, factory *testutil.TestDataFactory, client *testutil.APIClient, workspaceID string) {
+	list, err := factory.CreateList(workspaceID)
+	require.NoError(t, err)
+	template, err := factory.CreateTemplate(workspaceID)
+	require.NoError(t, err)
+
+	automationID := shortuuid.New()
+	triggerNodeID := shortuuid.New()
+	emailNodeID := shortuuid.New()
+
+	createReq := map[string]interface{}{
+		"workspace_id": workspaceID,
+		"automation": map[string]interface{}{
+			"id":           automationID,
+			"workspace_id": workspaceID,
+			"name":         "Contact Timeline Test",
+			"status":       "draft",
+			"list_id":      list.ID,
+			"trigger": map[string]interface{}{
+				"event_kind": "list.subscribed",
+				"list_id":    list.ID,
+				"frequency":  "once",
+			},
+			"root_node_id": triggerNodeID,
+			"nodes": []map[string]interface{}{
+				{
+					"id":            triggerNodeID,
+					"automation_id": automationID,
+					"type":          "trigger",
+					"config":        map[string]interface{}{},
+					"next_node_id":  emailNodeID,
+					"position":      map[string]interface{}{"x": 0, "y": 0},
+				},
+				{
+					"id":            emailNodeID,
+					"automation_id": automationID,
+					"type":          "email",
+					"config":        map[string]interface{}{"template_id": template.ID},
+					"position":      map[string]interface{}{"x": 0, "y": 100},
+				},
+			},
+			"stats": map[string]interface{}{"enrolled": 0, "completed": 0, "exited": 0, "failed": 0},
+		},
+	}
+
+	resp, err := client.CreateAutomation(createReq)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp.Body.Close()
+
+	activateResp, err := client.ActivateAutomation(map[string]interface{}{
+		"workspace_id":  workspaceID,
+		"automation_id": automationID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, activateResp.StatusCode)
+	activateResp.Body.Close()
+
+	email := "contact-timeline@example.com"
+	_, err = factory.CreateContact(workspaceID, testutil.WithContactEmail(email))
+	require.NoError(t, err)
+	_, err = factory.CreateContactList(workspaceID,
+		testutil.WithContactListEmail(email),
+		testutil.WithContactListListID(list.ID),
+		testutil.WithContactListStatus(domain.ContactListStatusActive),
+	)
+	require.NoError(t, err)
+
+	completedCA := waitForAutomationComplete(t, factory, workspaceID, automationID, email, 10*time.Second)
+	require.NotNil(t, completedCA, "Automation should complete")
+
+	// The send is recorded once the email queue has delivered the message
+	var kinds map[string]int
+	var nodeTypes map[string]bool
+	deadline := time.Now().Add(15 * time.Second)
+	for {
+		timelineResp, err := client.Get("/api/contacts.timeline", map[string]string{
+			"workspace_id": workspaceID,
+			"email":        email,
+			"limit":        "100",
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, timelineResp.StatusCode)
+
+		var result domain.TimelineListResponse
+		err = json.NewDecoder(timelineResp.Body).Decode(&result)
+		timelineResp.Body.Close()
+		require.NoError(t, err)
+
+		kinds = map[string]int{}
+		nodeTypes = map[string]bool{}
+		for i, entry := range result.Timeline {
+			kinds[entry.Kind]++
+			if entry.Kind == domain.TimelineKindAutomationNode {
+				assert.Equal(t, automationID, *entry.EntityID)
+				nodeTypes[entry.EntityData["node_type"].(string)] = true
+			}
+			if i > 0 {
+				assert.False(t, entry.CreatedAt.After(result.Timeline[i-1].CreatedAt), "timeline should be ordered newest first")
+			}
+		}
+
+		if kinds["insert_message_history"] > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	assert.Equal(t, 1, kinds["automation.start"], "Timeline should contain the enrollment")
+	assert.True(t, nodeTypes["trigger"], "Timeline should contain the trigger node execution")
+	assert.True(t, nodeTypes["email"], "Timeline should contain the email node execution")
+	assert.GreaterOrEqual(t, kinds["insert_message_history"], 1, "Timeline should contain the send")
+
+	// Kind filtering only returns the requested kinds
+	filteredResp, err := client.Get("/api/contacts.timeline", map[string]string{
+		"workspace_id": workspaceID,
+		"email":        email,
+		"kinds":        "automation.start,automation.node",
+	})
+	require.NoError(t, err)
+	defer filteredResp.Body.Close()
+	require.Equal(t, http.StatusOK, filteredResp.StatusCode)
+
+	var filtered domain.TimelineListResponse
+	require.NoError(t, json.NewDecoder(filteredResp.Body).Decode(&filtered))
+	require.NotEmpty(t, filtered.Timeline)
+	for _, entry := range filtered.Timeline {
+		assert.Contains(t, []string{"automation.start", domain.TimelineKindAutomationNode}, entry.Kind)
+	}
+}
+
 func testAutomationTimelineEndEvent(t *testing.T, factory *testutil.TestDataFactory, client *testutil.APIClient, workspaceID string) {
 	// 1. Build and create automation via HTTP with trigger → delay (terminal)
 	automationID := shortuuid.New()
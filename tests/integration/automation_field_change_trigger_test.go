@@ -0,0 +1,88 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/repository"
+	"github.com/Notifuse/notifuse/internal/service"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAutomationTrigger_ContactFieldChange enrolls contacts only when a contact.updated
+// event changes the configured field to the configured value, and checks that the
+// "once" frequency does not re-enroll a contact whose field changes back to that value
+func TestAutomationTrigger_ContactFieldChange(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	ctx := context.Background()
+	factory := suite.DataFactory
+
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+
+	// The lifecycle stage is stored in custom_string_1
+	automation, err := factory.CreateAutomation(workspace.ID,
+		testutil.WithAutomationName("Churned winback"),
+		testutil.WithAutomationStatus(domain.AutomationStatusLive),
+		testutil.WithAutomationRootNodeID("wait"),
+		testutil.WithAutomationNodes([]*domain.AutomationNode{
+			{ID: "wait", Type: domain.NodeTypeDelay, Config: map[string]interface{}{"duration": 1, "unit": "days"}},
+		}),
+		testutil.WithAutomationTrigger(&domain.TimelineTriggerConfig{
+			EventKind:   "contact.updated",
+			FieldChange: &domain.TriggerFieldChange{Field: "custom_string_1", To: "churned"},
+			Frequency:   domain.TriggerFrequencyOnce,
+		}),
+	)
+	require.NoError(t, err)
+
+	testApp := suite.ServerManager.GetApp()
+	automationRepo := repository.NewAutomationRepository(testApp.GetWorkspaceRepository(), service.NewAutomationTriggerGenerator(service.NewQueryBuilder()))
+	require.NoError(t, automationRepo.CreateAutomationTrigger(ctx, workspace.ID, automation))
+
+	workspaceDB, err := factory.GetWorkspaceDB(workspace.ID)
+	require.NoError(t, err)
+
+	contact, err := factory.CreateContact(workspace.ID, testutil.WithContactEmail("stage@example.com"))
+	require.NoError(t, err)
+
+	setStage := func(stage string) {
+		_, err := workspaceDB.ExecContext(ctx,
+			`UPDATE contacts SET custom_string_1 = $1, updated_at = NOW() WHERE email = $2`,
+			stage, contact.Email)
+		require.NoError(t, err)
+	}
+	enrollments := func() int {
+		count, err := factory.CountContactAutomations(workspace.ID, automation.ID)
+		require.NoError(t, err)
+		return count
+	}
+
+	// Updates of other fields or to other values do not enroll
+	_, err = workspaceDB.ExecContext(ctx, `UPDATE contacts SET first_name = 'Jane' WHERE email = $1`, contact.Email)
+	require.NoError(t, err)
+	setStage("active")
+	assert.Equal(t, 0, enrollments())
+
+	// Changing the stage to churned enrolls the contact
+	setStage("churned")
+	assert.Equal(t, 1, enrollments())
+
+	// Leaving and re-entering the churned stage does not re-enroll under "once"
+	setStage("active")
+	setStage("churned")
+	assert.Equal(t, 1, enrollments())
+}
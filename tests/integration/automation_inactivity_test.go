@@ -0,0 +1,93 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/repository"
+	"github.com/Notifuse/notifuse/internal/service"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAutomationInactivity_EnrollsOnlyInactiveContacts verifies that the inactivity evaluator
+// enrolls contacts without activity during the trigger window and leaves active ones alone
+func TestAutomationInactivity_EnrollsOnlyInactiveContacts(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	ctx := context.Background()
+	factory := suite.DataFactory
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+
+	automation, err := factory.CreateAutomation(workspace.ID,
+		testutil.WithAutomationName("Win-back"),
+		testutil.WithAutomationStatus(domain.AutomationStatusLive),
+		testutil.WithAutomationRootNodeID("trigger"),
+		testutil.WithAutomationTrigger(&domain.TimelineTriggerConfig{
+			EventKind:      "inactivity",
+			Frequency:      domain.TriggerFrequencyEveryTime,
+			InactivityDays: 30,
+		}),
+	)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	_, err = factory.CreateContact(workspace.ID, testutil.WithContactEmail("inactive@example.com"))
+	require.NoError(t, err)
+	_, err = factory.CreateContact(workspace.ID, testutil.WithContactEmail("active@example.com"))
+	require.NoError(t, err)
+
+	workspaceRepo := suite.ServerManager.GetApp().GetWorkspaceRepository()
+	workspaceDB, err := workspaceRepo.GetConnection(ctx, workspace.ID)
+	require.NoError(t, err)
+
+	// Both contacts signed up long ago; only the active one opened an email recently
+	_, err = workspaceDB.ExecContext(ctx,
+		`UPDATE contacts SET created_at = $1 WHERE email IN ('inactive@example.com', 'active@example.com')`,
+		now.AddDate(0, 0, -90))
+	require.NoError(t, err)
+	require.NoError(t, factory.CreateContactTimelineEventAt(workspace.ID, "inactive@example.com", "open_email", nil, now.AddDate(0, 0, -45)))
+	require.NoError(t, factory.CreateContactTimelineEventAt(workspace.ID, "active@example.com", "open_email", nil, now.AddDate(0, 0, -2)))
+
+	executor := service.NewAutomationExecutor(
+		repository.NewAutomationRepository(workspaceRepo, service.NewAutomationTriggerGenerator(service.NewQueryBuilder())),
+		nil, workspaceRepo, nil, nil, nil, nil, nil, nil, nil, nil,
+		suite.ServerManager.GetApp().GetLogger(),
+		"",
+	)
+
+	enrolled, err := executor.EnrollInactiveContacts(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, enrolled)
+
+	rows, err := workspaceDB.QueryContext(ctx,
+		`SELECT contact_email FROM contact_automations WHERE automation_id = $1 ORDER BY contact_email`, automation.ID)
+	require.NoError(t, err)
+	defer rows.Close()
+	var emails []string
+	for rows.Next() {
+		var email string
+		require.NoError(t, rows.Scan(&email))
+		emails = append(emails, email)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"inactive@example.com"}, emails)
+
+	// The enrolled contact is not enrolled again on the next run
+	enrolled, err = executor.EnrollInactiveContacts(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, enrolled)
+}
@@ -0,0 +1,105 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/repository"
+	"github.com/Notifuse/notifuse/internal/service"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAutomationNodeStats runs contacts through an automation, checks the per-node execution
+// stats returned by automations.nodeStats, then deletes the node and checks its stats are dropped
+func TestAutomationNodeStats(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	ctx := context.Background()
+	factory := suite.DataFactory
+	client := suite.APIClient
+
+	user, err := factory.CreateUser()
+	require.NoError(t, err)
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+	require.NoError(t, factory.AddUserToWorkspace(user.ID, workspace.ID, "owner"))
+	require.NoError(t, client.Login(user.Email, "password"))
+	client.SetWorkspaceID(workspace.ID)
+
+	automation, err := factory.CreateAutomation(workspace.ID,
+		testutil.WithAutomationName("Onboarding"),
+		testutil.WithAutomationStatus(domain.AutomationStatusLive),
+		testutil.WithAutomationRootNodeID("wait"),
+		testutil.WithAutomationNodes([]*domain.AutomationNode{
+			{ID: "wait", Type: domain.NodeTypeDelay, Config: map[string]interface{}{"duration": 1, "unit": "days"}},
+		}),
+	)
+	require.NoError(t, err)
+
+	testApp := suite.ServerManager.GetApp()
+	workspaceRepo := testApp.GetWorkspaceRepository()
+	contactRepo := testApp.GetContactRepository()
+	automationRepo := repository.NewAutomationRepository(workspaceRepo, service.NewAutomationTriggerGenerator(service.NewQueryBuilder()))
+
+	for _, email := range []string{"first@example.com", "second@example.com"} {
+		_, err = factory.CreateContact(workspace.ID, testutil.WithContactEmail(email))
+		require.NoError(t, err)
+		enrolled, err := automationRepo.EnrollContact(ctx, workspace.ID, automation, email)
+		require.NoError(t, err)
+		require.True(t, enrolled)
+	}
+
+	executor := service.NewAutomationExecutor(
+		automationRepo, contactRepo, workspaceRepo, nil, nil, nil, nil, nil,
+		repository.NewContactTimelineRepository(workspaceRepo), nil, nil,
+		testApp.GetLogger(),
+		"",
+	)
+	_, err = executor.ProcessBatch(ctx, 100)
+	require.NoError(t, err)
+
+	resp, err := client.GetAutomationNodeStats(automation.ID)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result struct {
+		NodeStats map[string]*domain.AutomationNodeExecutionStats `json:"node_stats"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.Contains(t, result.NodeStats, "wait")
+	assert.Equal(t, int64(2), result.NodeStats["wait"].Executions)
+	assert.Equal(t, int64(0), result.NodeStats["wait"].Failures)
+	assert.GreaterOrEqual(t, result.NodeStats["wait"].AvgDurationMs, float64(0))
+
+	before, err := factory.GetAutomationStats(workspace.ID, automation.ID)
+	require.NoError(t, err)
+
+	// Replacing the wait node with a new one drops the deleted node's stats
+	current, err := automationRepo.GetByID(ctx, workspace.ID, automation.ID)
+	require.NoError(t, err)
+	current.RootNodeID = "hold"
+	current.Nodes = []*domain.AutomationNode{
+		{ID: "hold", AutomationID: automation.ID, Type: domain.NodeTypeDelay, Config: map[string]interface{}{"duration": 2, "unit": "days"}},
+	}
+	require.NoError(t, automationRepo.Update(ctx, workspace.ID, current))
+
+	after, err := factory.GetAutomationStats(workspace.ID, automation.ID)
+	require.NoError(t, err)
+	assert.NotContains(t, after.Nodes, "wait")
+	assert.Equal(t, before.Enrolled, after.Enrolled, "pruning node stats keeps the automation counters")
+}
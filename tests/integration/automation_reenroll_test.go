@@ -0,0 +1,99 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/repository"
+	"github.com/Notifuse/notifuse/internal/service"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAutomationReenrollNode runs a contact through a reenroll node allowing one re-enrollment:
+// the first run exits and restarts the automation, the second run continues past the node
+func TestAutomationReenrollNode(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	ctx := context.Background()
+	factory := suite.DataFactory
+
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+
+	wait := "wait"
+	automation, err := factory.CreateAutomation(workspace.ID,
+		testutil.WithAutomationStatus(domain.AutomationStatusLive),
+		testutil.WithAutomationTrigger(&domain.TimelineTriggerConfig{
+			EventKind: "contact.created",
+			Frequency: domain.TriggerFrequencyEveryTime,
+		}),
+		testutil.WithAutomationRootNodeID("reenroll"),
+		testutil.WithAutomationNodes([]*domain.AutomationNode{
+			{ID: "reenroll", Type: domain.NodeTypeReenroll, NextNodeID: &wait, Config: map[string]interface{}{"max_reenrollments": 1}},
+			{ID: "wait", Type: domain.NodeTypeDelay, Config: map[string]interface{}{"duration": 1, "unit": "days"}},
+		}),
+	)
+	require.NoError(t, err)
+
+	testApp := suite.ServerManager.GetApp()
+	workspaceRepo := testApp.GetWorkspaceRepository()
+	automationRepo := repository.NewAutomationRepository(workspaceRepo, service.NewAutomationTriggerGenerator(service.NewQueryBuilder()))
+
+	email := "reenroll@example.com"
+	_, err = factory.CreateContact(workspace.ID, testutil.WithContactEmail(email))
+	require.NoError(t, err)
+	enrolled, err := automationRepo.EnrollContact(ctx, workspace.ID, automation, email)
+	require.NoError(t, err)
+	require.True(t, enrolled)
+
+	first, err := automationRepo.GetContactAutomationByEmail(ctx, workspace.ID, automation.ID, email)
+	require.NoError(t, err)
+
+	executor := service.NewAutomationExecutor(
+		automationRepo, testApp.GetContactRepository(), workspaceRepo, nil, nil, nil, nil, nil,
+		repository.NewContactTimelineRepository(workspaceRepo), nil, nil,
+		testApp.GetLogger(),
+		"",
+	)
+
+	// The first run re-enrolls the contact and exits
+	_, err = executor.ProcessBatch(ctx, 100)
+	require.NoError(t, err)
+
+	exited, err := automationRepo.GetContactAutomation(ctx, workspace.ID, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ContactAutomationStatusExited, exited.Status)
+	require.NotNil(t, exited.ExitReason)
+	assert.Equal(t, domain.ReenrolledExitReason, *exited.ExitReason)
+
+	second, err := automationRepo.GetContactAutomationByEmail(ctx, workspace.ID, automation.ID, email)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, second.ID)
+	assert.Equal(t, domain.ContactAutomationStatusActive, second.Status)
+
+	// The second run is over the limit and continues to the wait node
+	_, err = executor.ProcessBatch(ctx, 100)
+	require.NoError(t, err)
+
+	second, err = automationRepo.GetContactAutomation(ctx, workspace.ID, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ContactAutomationStatusActive, second.Status)
+	require.NotNil(t, second.CurrentNodeID)
+	assert.Equal(t, "wait", *second.CurrentNodeID)
+
+	count, err := factory.CountContactAutomations(workspace.ID, automation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "the contact is re-enrolled once")
+}
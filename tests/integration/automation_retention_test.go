@@ -0,0 +1,117 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/repository"
+	"github.com/Notifuse/notifuse/internal/service"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAutomationRetention_PurgesFinishedContactAutomations verifies that the retention job
+// deletes old completed/exited contact automations while keeping active, recent and recently
+// touched ones, and that the aggregate automation stats are left intact
+func TestAutomationRetention_PurgesFinishedContactAutomations(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	ctx := context.Background()
+	factory := suite.DataFactory
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+
+	automation, err := factory.CreateAutomation(workspace.ID, testutil.WithAutomationName("Retention Test"))
+	require.NoError(t, err)
+
+	workspaceRepo := suite.ServerManager.GetApp().GetWorkspaceRepository()
+	workspaceDB, err := workspaceRepo.GetConnection(ctx, workspace.ID)
+	require.NoError(t, err)
+
+	_, err = workspaceDB.ExecContext(ctx,
+		`UPDATE automations SET stats = '{"enrolled": 5, "completed": 3, "exited": 1, "failed": 0}'::jsonb WHERE id = $1`,
+		automation.ID)
+	require.NoError(t, err)
+	statsBefore, err := factory.GetAutomationStats(workspace.ID, automation.ID)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	old := now.AddDate(0, 0, -200)
+
+	seed := func(id, email string, status domain.ContactAutomationStatus, enteredAt time.Time) {
+		_, err := workspaceDB.ExecContext(ctx,
+			`INSERT INTO contact_automations (id, automation_id, contact_email, status, entered_at)
+			 VALUES ($1, $2, $3, $4, $5)`,
+			id, automation.ID, email, string(status), enteredAt)
+		require.NoError(t, err)
+	}
+	seedExecution := func(id, contactAutomationID string, completedAt time.Time) {
+		_, err := workspaceDB.ExecContext(ctx,
+			`INSERT INTO automation_node_executions
+				(id, contact_automation_id, automation_id, node_id, node_type, action, entered_at, completed_at)
+			 VALUES ($1, $2, $3, 'node1', 'email', 'completed', $4, $4)`,
+			id, contactAutomationID, automation.ID, completedAt)
+		require.NoError(t, err)
+	}
+
+	seed("ca-old-completed", "old-completed@example.com", domain.ContactAutomationStatusCompleted, old)
+	seedExecution("ne-old-completed", "ca-old-completed", old.Add(time.Hour))
+	seed("ca-old-exited", "old-exited@example.com", domain.ContactAutomationStatusExited, old)
+	seed("ca-old-active", "old-active@example.com", domain.ContactAutomationStatusActive, old)
+	seed("ca-recent-completed", "recent-completed@example.com", domain.ContactAutomationStatusCompleted, now.AddDate(0, 0, -10))
+	// Enrolled long ago but only finished recently
+	seed("ca-recently-finished", "recently-finished@example.com", domain.ContactAutomationStatusCompleted, old)
+	seedExecution("ne-recently-finished", "ca-recently-finished", now.AddDate(0, 0, -5))
+
+	executor := service.NewAutomationExecutor(
+		repository.NewAutomationRepository(workspaceRepo, service.NewAutomationTriggerGenerator(service.NewQueryBuilder())),
+		nil, workspaceRepo, nil, nil, nil, nil, nil, nil, nil, nil,
+		suite.ServerManager.GetApp().GetLogger(),
+		"",
+	)
+
+	deleted, err := executor.PurgeFinishedContactAutomations(ctx, 90)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	rows, err := workspaceDB.QueryContext(ctx,
+		`SELECT id FROM contact_automations WHERE automation_id = $1 ORDER BY id`, automation.ID)
+	require.NoError(t, err)
+	defer rows.Close()
+	var remaining []string
+	for rows.Next() {
+		var id string
+		require.NoError(t, rows.Scan(&id))
+		remaining = append(remaining, id)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"ca-old-active", "ca-recent-completed", "ca-recently-finished"}, remaining)
+
+	// Node executions of purged contact automations are removed by cascade
+	var executions int
+	err = workspaceDB.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM automation_node_executions WHERE contact_automation_id = 'ca-old-completed'`).Scan(&executions)
+	require.NoError(t, err)
+	assert.Equal(t, 0, executions)
+
+	statsAfter, err := factory.GetAutomationStats(workspace.ID, automation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, statsBefore, statsAfter)
+
+	// Running again is a no-op
+	deleted, err = executor.PurgeFinishedContactAutomations(ctx, 90)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+}
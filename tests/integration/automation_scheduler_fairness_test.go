@@ -0,0 +1,101 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/repository"
+	"github.com/Notifuse/notifuse/internal/service"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAutomationSchedulerFairness enrolls many contacts in automation A, then a few in
+// automation B, and checks a single scheduler batch smaller than A's backlog still
+// processes all of B's contacts instead of only A's earliest ones
+func TestAutomationSchedulerFairness(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	ctx := context.Background()
+	factory := suite.DataFactory
+
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+
+	newAutomation := func(name string) *domain.Automation {
+		automation, err := factory.CreateAutomation(workspace.ID,
+			testutil.WithAutomationName(name),
+			testutil.WithAutomationStatus(domain.AutomationStatusLive),
+			testutil.WithAutomationRootNodeID("wait"),
+			testutil.WithAutomationNodes([]*domain.AutomationNode{
+				{ID: "wait", Type: domain.NodeTypeDelay, Config: map[string]interface{}{"duration": 1, "unit": "days"}},
+			}),
+		)
+		require.NoError(t, err)
+		return automation
+	}
+	large := newAutomation("Large")
+	small := newAutomation("Small")
+
+	testApp := suite.ServerManager.GetApp()
+	workspaceRepo := testApp.GetWorkspaceRepository()
+	automationRepo := repository.NewAutomationRepository(workspaceRepo, service.NewAutomationTriggerGenerator(service.NewQueryBuilder()))
+
+	enroll := func(automation *domain.Automation, prefix string, count int) []string {
+		emails := make([]string, 0, count)
+		for i := 0; i < count; i++ {
+			email := fmt.Sprintf("%s-%d@example.com", prefix, i)
+			_, err := factory.CreateContact(workspace.ID, testutil.WithContactEmail(email))
+			require.NoError(t, err)
+			enrolled, err := automationRepo.EnrollContact(ctx, workspace.ID, automation, email)
+			require.NoError(t, err)
+			require.True(t, enrolled)
+			emails = append(emails, email)
+		}
+		return emails
+	}
+
+	// The large automation's contacts are all scheduled before the small one's
+	enroll(large, "large", 40)
+	smallEmails := enroll(small, "small", 3)
+
+	executor := service.NewAutomationExecutor(
+		automationRepo, testApp.GetContactRepository(), workspaceRepo, nil, nil, nil, nil, nil,
+		repository.NewContactTimelineRepository(workspaceRepo), nil, nil,
+		testApp.GetLogger(),
+		"",
+	)
+	processed, err := executor.ProcessBatch(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 10, processed)
+
+	// Processed contacts wait on the delay node until tomorrow
+	now := time.Now().UTC()
+	for _, email := range smallEmails {
+		ca, err := automationRepo.GetContactAutomationByEmail(ctx, workspace.ID, small.ID, email)
+		require.NoError(t, err)
+		require.NotNil(t, ca.ScheduledAt)
+		assert.True(t, ca.ScheduledAt.After(now), "%s should be processed in the first batch", email)
+	}
+
+	// The rest of the batch went to the large automation, which keeps most of its backlog
+	_, pending, err := automationRepo.ListContactAutomations(ctx, workspace.ID, domain.ContactAutomationFilter{
+		AutomationID: large.ID,
+		ScheduledBy:  &now,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 33, pending)
+}
@@ -0,0 +1,148 @@
+package integration
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	shortuuid "github.com/lithammer/shortuuid/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAutomationTags verifies that automations can be tagged and that
+// automations.list only returns automations carrying the requested tag
+func TestAutomationTags(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	factory := suite.DataFactory
+	client := suite.APIClient
+
+	user, err := factory.CreateUser()
+	require.NoError(t, err)
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+	err = factory.AddUserToWorkspace(user.ID, workspace.ID, "owner")
+	require.NoError(t, err)
+
+	err = client.Login(user.Email, "password")
+	require.NoError(t, err)
+	client.SetWorkspaceID(workspace.ID)
+
+	list, err := factory.CreateList(workspace.ID)
+	require.NoError(t, err)
+
+	createAutomation := func(name string, tags []string) string {
+		automationID := shortuuid.New()
+		triggerNodeID := shortuuid.New()
+		resp, err := client.CreateAutomation(map[string]interface{}{
+			"workspace_id": workspace.ID,
+			"automation": map[string]interface{}{
+				"id":           automationID,
+				"workspace_id": workspace.ID,
+				"name":         name,
+				"status":       "draft",
+				"list_id":      list.ID,
+				"tags":         tags,
+				"trigger": map[string]interface{}{
+					"event_kind": "list.subscribed",
+					"list_id":    list.ID,
+					"frequency":  "once",
+				},
+				"root_node_id": triggerNodeID,
+				"nodes": []map[string]interface{}{
+					{
+						"id":            triggerNodeID,
+						"automation_id": automationID,
+						"type":          "trigger",
+						"config":        map[string]interface{}{},
+						"position":      map[string]interface{}{"x": 0, "y": 0},
+					},
+				},
+				"stats": map[string]interface{}{"enrolled": 0, "completed": 0, "exited": 0, "failed": 0},
+			},
+		})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusCreated {
+			body, _ := io.ReadAll(resp.Body)
+			t.Fatalf("CreateAutomation: expected 201, got %d: %s", resp.StatusCode, string(body))
+		}
+		return automationID
+	}
+
+	listByTag := func(tag string) []*domain.Automation {
+		resp, err := client.ListAutomations(map[string]string{"tag": tag})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result struct {
+			Automations []*domain.Automation `json:"automations"`
+			Total       int                  `json:"total"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.Equal(t, len(result.Automations), result.Total)
+		return result.Automations
+	}
+
+	onboardingID := createAutomation("Onboarding", []string{"onboarding", " lifecycle "})
+	winBackID := createAutomation("Win-back", []string{"lifecycle"})
+	untaggedID := createAutomation("Untagged", nil)
+
+	t.Run("list filters by tag", func(t *testing.T) {
+		automations := listByTag("onboarding")
+		require.Len(t, automations, 1)
+		assert.Equal(t, onboardingID, automations[0].ID)
+		assert.Equal(t, []string{"onboarding", "lifecycle"}, automations[0].Tags)
+
+		ids := []string{}
+		for _, a := range listByTag("lifecycle") {
+			ids = append(ids, a.ID)
+		}
+		assert.ElementsMatch(t, []string{onboardingID, winBackID}, ids)
+		assert.NotContains(t, ids, untaggedID)
+	})
+
+	t.Run("setTags replaces tags", func(t *testing.T) {
+		resp, err := client.SetAutomationTags(map[string]interface{}{
+			"workspace_id":  workspace.ID,
+			"automation_id": untaggedID,
+			"tags":          []string{"onboarding"},
+		})
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		ids := []string{}
+		for _, a := range listByTag("onboarding") {
+			ids = append(ids, a.ID)
+		}
+		assert.ElementsMatch(t, []string{onboardingID, untaggedID}, ids)
+	})
+
+	t.Run("tags lists distinct tags", func(t *testing.T) {
+		resp, err := client.ListAutomationTags()
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result struct {
+			Tags []string `json:"tags"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.Equal(t, []string{"lifecycle", "onboarding"}, result.Tags)
+	})
+}
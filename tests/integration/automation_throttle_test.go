@@ -0,0 +1,78 @@
+package integration
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/repository"
+	"github.com/Notifuse/notifuse/internal/service"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAutomationThrottle_ConcurrentReservations reserves throttle slots from concurrent
+// workers and checks that no minute is handed out more often than the node allows
+func TestAutomationThrottle_ConcurrentReservations(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	ctx := context.Background()
+	factory := suite.DataFactory
+
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+
+	automation, err := factory.CreateAutomation(workspace.ID,
+		testutil.WithAutomationStatus(domain.AutomationStatusLive),
+		testutil.WithAutomationRootNodeID("throttle"),
+		testutil.WithAutomationNodes([]*domain.AutomationNode{
+			{ID: "throttle", Type: domain.NodeTypeThrottle, Config: map[string]interface{}{"max_per_minute": 5}},
+		}),
+	)
+	require.NoError(t, err)
+
+	testApp := suite.ServerManager.GetApp()
+	automationRepo := repository.NewAutomationRepository(testApp.GetWorkspaceRepository(), service.NewAutomationTriggerGenerator(service.NewQueryBuilder()))
+
+	now := time.Now().UTC()
+	const workers = 20
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		slots = make(map[int64]int)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reservedAt, err := automationRepo.ReserveThrottleSlot(ctx, workspace.ID, automation.ID, "throttle", 5, now)
+			if !assert.NoError(t, err) {
+				return
+			}
+			mu.Lock()
+			slots[reservedAt.Unix()]++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	// The 20 contacts are spread over four consecutive minutes, five per minute
+	require.Len(t, slots, 4)
+	for i := 0; i < 4; i++ {
+		minute := now.Truncate(time.Minute).Add(time.Duration(i) * time.Minute)
+		assert.Equal(t, 5, slots[minute.Unix()], "minute %d", i)
+	}
+}
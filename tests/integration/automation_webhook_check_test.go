@@ -0,0 +1,108 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	shortuuid "github.com/lithammer/shortuuid/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAutomationWebhookURLCheck verifies that saving an automation with
+// validate_webhooks surfaces a warning for an unreachable webhook URL while
+// the automation itself is still saved
+func TestAutomationWebhookURLCheck(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	factory := suite.DataFactory
+	client := suite.APIClient
+
+	user, err := factory.CreateUser()
+	require.NoError(t, err)
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+	err = factory.AddUserToWorkspace(user.ID, workspace.ID, "owner")
+	require.NoError(t, err)
+
+	err = client.Login(user.Email, "password")
+	require.NoError(t, err)
+	client.SetWorkspaceID(workspace.ID)
+
+	list, err := factory.CreateList(workspace.ID)
+	require.NoError(t, err)
+
+	automationID := shortuuid.New()
+	triggerNodeID := shortuuid.New()
+	webhookNodeID := shortuuid.New()
+
+	resp, err := client.CreateAutomation(map[string]interface{}{
+		"workspace_id":      workspace.ID,
+		"validate_webhooks": true,
+		"automation": map[string]interface{}{
+			"id":           automationID,
+			"workspace_id": workspace.ID,
+			"name":         "Webhook Check",
+			"status":       "draft",
+			"list_id":      list.ID,
+			"trigger": map[string]interface{}{
+				"event_kind": "list.subscribed",
+				"list_id":    list.ID,
+				"frequency":  "once",
+			},
+			"root_node_id": triggerNodeID,
+			"nodes": []map[string]interface{}{
+				{
+					"id":            triggerNodeID,
+					"automation_id": automationID,
+					"type":          "trigger",
+					"next_node_id":  webhookNodeID,
+					"config":        map[string]interface{}{},
+					"position":      map[string]interface{}{"x": 0, "y": 0},
+				},
+				{
+					"id":            webhookNodeID,
+					"automation_id": automationID,
+					"type":          "webhook",
+					"config": map[string]interface{}{
+						// .invalid never resolves (RFC 2606)
+						"url": "https://hooks.notifuse.invalid/automation",
+					},
+					"position": map[string]interface{}{"x": 0, "y": 100},
+				},
+			},
+			"stats": map[string]interface{}{"enrolled": 0, "completed": 0, "exited": 0, "failed": 0},
+		},
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var result struct {
+		Automation *domain.Automation `json:"automation"`
+		Warnings   []string           `json:"warnings"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	require.NotNil(t, result.Automation)
+	assert.Equal(t, automationID, result.Automation.ID)
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], webhookNodeID)
+	assert.Contains(t, result.Warnings[0], "not reachable")
+
+	getResp, err := client.GetAutomation(automationID)
+	require.NoError(t, err)
+	defer getResp.Body.Close()
+	assert.Equal(t, http.StatusOK, getResp.StatusCode)
+}
@@ -0,0 +1,104 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBroadcastCancelSchedule_RevertsToDraftWithoutSending schedules a broadcast for
+// tomorrow, cancels the schedule and verifies the broadcast is back to draft, its send
+// task is gone and nothing was enqueued or delivered.
+func TestBroadcastCancelSchedule_RevertsToDraftWithoutSending(t *testing.T) {
+	h := setupPhase2(t, 5, 600)
+	defer h.Cleanup()
+
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+	scheduleResp, err := h.client.ScheduleBroadcast(map[string]interface{}{
+		"workspace_id":           h.workspaceID,
+		"id":                     h.broadcastID,
+		"send_now":               false,
+		"scheduled_date":         tomorrow.Format("2006-01-02"),
+		"scheduled_time":         "10:00",
+		"timezone":               "UTC",
+		"use_recipient_timezone": false,
+	})
+	require.NoError(t, err)
+	scheduleResp.Body.Close()
+	require.Equal(t, http.StatusOK, scheduleResp.StatusCode)
+
+	h.taskID = h.waitForTaskID(t, 5*time.Second)
+	require.Equal(t, "scheduled", h.getBroadcast(t)["status"])
+
+	cancelResp, err := h.client.CancelBroadcastSchedule(map[string]interface{}{
+		"workspace_id": h.workspaceID,
+		"id":           h.broadcastID,
+	})
+	require.NoError(t, err)
+	cancelResp.Body.Close()
+	require.Equal(t, http.StatusOK, cancelResp.StatusCode)
+
+	// Broadcast is a draft again with the scheduled date cleared
+	bd := h.getBroadcast(t)
+	assert.Equal(t, "draft", bd["status"])
+	schedule, ok := bd["schedule"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, false, schedule["is_scheduled"])
+	assert.Empty(t, schedule["scheduled_date"])
+
+	// The send task is deleted, so running pending tasks has nothing to send
+	waitForCondition(t, func() bool {
+		return len(h.listBroadcastTasks(t)) == 0
+	}, 5*time.Second, "send task deleted")
+
+	execResp, err := h.client.ExecutePendingTasks(10)
+	require.NoError(t, err)
+	execResp.Body.Close()
+
+	time.Sleep(2 * time.Second)
+
+	for status, count := range h.countQueue(t) {
+		assert.Zero(t, count, "no %s queue entries expected for an unscheduled broadcast", status)
+	}
+	sent, err := testutil.GetMailpitMessageCount(t, h.subject)
+	require.NoError(t, err)
+	assert.Zero(t, sent, "no email should be sent for an unscheduled broadcast")
+	assert.Equal(t, "draft", h.getBroadcast(t)["status"])
+
+	// A draft has no schedule to cancel
+	againResp, err := h.client.CancelBroadcastSchedule(map[string]interface{}{
+		"workspace_id": h.workspaceID,
+		"id":           h.broadcastID,
+	})
+	require.NoError(t, err)
+	againResp.Body.Close()
+	assert.NotEqual(t, http.StatusOK, againResp.StatusCode)
+}
+
+// listBroadcastTasks returns the tasks attached to the harness broadcast
+func (h *phase2Harness) listBroadcastTasks(t *testing.T) []interface{} {
+	t.Helper()
+	resp, err := h.client.ListTasks(map[string]string{"broadcast_id": h.broadcastID})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &result))
+	tasks, _ := result["tasks"].([]interface{})
+
+	var broadcastTasks []interface{}
+	for _, ti := range tasks {
+		if task, ok := ti.(map[string]interface{}); ok && task["broadcast_id"] == h.broadcastID {
+			broadcastTasks = append(broadcastTasks, task)
+		}
+	}
+	return broadcastTasks
+}
@@ -175,6 +175,73 @@ func TestBroadcastDataFeedEmailRendering(t *testing.T) {
 		assert.Contains(t, requests[0].Body, "workspace", "Request should contain workspace info")
 	})
 
+	t.Run("field_map renames camelCase feed fields for templates", func(t *testing.T) {
+		err := testutil.ClearMailpitMessages(t)
+		require.NoError(t, err)
+
+		// Feed returns camelCase keys, the template uses the mapped snake_case name
+		mockServer := NewMockFeedServer()
+		defer mockServer.Close()
+		mockServer.SetResponse(map[string]interface{}{
+			"promoCode": "SPRING2026",
+		})
+
+		list, err := factory.CreateList(workspace.ID,
+			testutil.WithListName("Data Feed Field Map List"))
+		require.NoError(t, err)
+
+		contactEmail := fmt.Sprintf("datafeed-fieldmap-%s@example.com", uuid.New().String()[:8])
+		_, err = factory.CreateContact(workspace.ID, testutil.WithContactEmail(contactEmail))
+		require.NoError(t, err)
+
+		_, err = factory.CreateContactList(workspace.ID,
+			testutil.WithContactListEmail(contactEmail),
+			testutil.WithContactListListID(list.ID),
+			testutil.WithContactListStatus(domain.ContactListStatusActive))
+		require.NoError(t, err)
+
+		uniqueID := uuid.New().String()[:8]
+		template, err := factory.CreateTemplate(workspace.ID,
+			testutil.WithTemplateName("Data Feed Field Map Template"),
+			testutil.WithTemplateSubject(fmt.Sprintf("Field map %s", uniqueID)),
+			testutil.WithTemplateEmailContent(
+				"Your code is [{{ global_feed.promo_code }}] not [{{ global_feed.promoCode }}]."))
+		require.NoError(t, err)
+
+		broadcast, err := factory.CreateBroadcast(workspace.ID,
+			testutil.WithBroadcastName("Data Feed Field Map Test"),
+			testutil.WithBroadcastTemplateID(template.ID),
+			testutil.WithBroadcastGlobalFeed(&domain.GlobalFeedSettings{
+				Enabled:  true,
+				URL:      mockServer.URL(),
+				Headers:  []domain.DataFeedHeader{},
+				FieldMap: map[string]string{"promoCode": "promo_code"},
+			}),
+			testutil.WithBroadcastAudience(domain.AudienceSettings{
+				List:                list.ID,
+				ExcludeUnsubscribed: true,
+			}))
+		require.NoError(t, err)
+
+		scheduleResp, err := client.ScheduleBroadcast(map[string]interface{}{
+			"workspace_id": workspace.ID,
+			"id":           broadcast.ID,
+			"send_now":     true,
+		})
+		require.NoError(t, err)
+		scheduleResp.Body.Close()
+
+		_, err = testutil.WaitForBroadcastStatusWithExecution(t, client, broadcast.ID,
+			[]string{"processed", "completed"}, 60*time.Second)
+		require.NoError(t, err)
+
+		msg, err := waitForEmailByRecipientAddr(t, contactEmail, 15*time.Second)
+		require.NoError(t, err, "Should receive email in Mailpit")
+
+		assert.Contains(t, msg.HTML, "Your code is [SPRING2026]", "Mapped variable should render")
+		assert.Contains(t, msg.HTML, "not []", "Original camelCase field should no longer be available")
+	})
+
 	t.Run("same global feed data rendered for multiple recipients", func(t *testing.T) {
 		err := testutil.ClearMailpitMessages(t)
 		require.NoError(t, err)
@@ -365,6 +432,154 @@ func TestBroadcastDataFeedEmailRendering(t *testing.T) {
 		assert.Contains(t, msg.HTML, "Hello Eve", "Body should contain rendered contact name")
 		assert.NotContains(t, msg.HTML, "{{ global_feed", "Body should NOT contain raw Liquid syntax")
 	})
+
+	t.Run("global feed snapshot is pinned once the broadcast completes", func(t *testing.T) {
+		served := map[string]interface{}{
+			"promo_code":   "AUDIT2026",
+			"product_name": "Starter Plan",
+		}
+		mockServer := NewMockFeedServer()
+		defer mockServer.Close()
+		mockServer.SetResponse(served)
+
+		list, err := factory.CreateList(workspace.ID,
+			testutil.WithListName("Data Feed Snapshot List"))
+		require.NoError(t, err)
+
+		contactEmail := fmt.Sprintf("datafeed-snapshot-%s@example.com", uuid.New().String()[:8])
+		_, err = factory.CreateContact(workspace.ID, testutil.WithContactEmail(contactEmail))
+		require.NoError(t, err)
+
+		_, err = factory.CreateContactList(workspace.ID,
+			testutil.WithContactListEmail(contactEmail),
+			testutil.WithContactListListID(list.ID),
+			testutil.WithContactListStatus(domain.ContactListStatusActive))
+		require.NoError(t, err)
+
+		template, err := factory.CreateTemplate(workspace.ID,
+			testutil.WithTemplateName("Data Feed Snapshot Template"),
+			testutil.WithTemplateSubject("Code {{ global_feed.promo_code }}"),
+			testutil.WithTemplateEmailContent("Try {{ global_feed.product_name }}"))
+		require.NoError(t, err)
+
+		broadcast, err := factory.CreateBroadcast(workspace.ID,
+			testutil.WithBroadcastName("Data Feed Snapshot Test"),
+			testutil.WithBroadcastTemplateID(template.ID),
+			testutil.WithBroadcastGlobalFeed(&domain.GlobalFeedSettings{
+				Enabled: true,
+				URL:     mockServer.URL(),
+				Headers: []domain.DataFeedHeader{},
+			}),
+			testutil.WithBroadcastAudience(domain.AudienceSettings{
+				List:                list.ID,
+				ExcludeUnsubscribed: true,
+			}))
+		require.NoError(t, err)
+
+		scheduleResp, err := client.ScheduleBroadcast(map[string]interface{}{
+			"workspace_id": workspace.ID,
+			"id":           broadcast.ID,
+			"send_now":     true,
+		})
+		require.NoError(t, err)
+		scheduleResp.Body.Close()
+
+		_, err = testutil.WaitForBroadcastStatusWithExecution(t, client, broadcast.ID,
+			[]string{"processed", "completed"}, 60*time.Second)
+		require.NoError(t, err)
+
+		getDataFeed := func() map[string]interface{} {
+			resp, err := client.GetBroadcast(broadcast.ID)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var result map[string]interface{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			dataFeed, ok := result["broadcast"].(map[string]interface{})["data_feed"].(map[string]interface{})
+			require.True(t, ok, "data_feed should be returned by broadcasts.get")
+			return dataFeed
+		}
+
+		// The snapshot returned by the API is what the feed served at send time
+		snapshot := getDataFeed()
+		feedData, ok := snapshot["global_feed_data"].(map[string]interface{})
+		require.True(t, ok)
+		for key, value := range served {
+			assert.Equal(t, value, feedData[key])
+		}
+		fetchedAt, ok := snapshot["global_feed_fetched_at"].(string)
+		require.True(t, ok)
+		require.NotEmpty(t, fetchedAt)
+
+		// The feed changes after the send
+		mockServer.SetResponse(map[string]interface{}{
+			"promo_code":   "CHANGED",
+			"product_name": "Changed Plan",
+		})
+
+		refreshResp, err := client.RefreshGlobalFeed(map[string]interface{}{
+			"workspace_id": workspace.ID,
+			"broadcast_id": broadcast.ID,
+			"url":          mockServer.URL(),
+			"headers":      []interface{}{},
+		})
+		require.NoError(t, err)
+		var refreshResult map[string]interface{}
+		require.NoError(t, json.NewDecoder(refreshResp.Body).Decode(&refreshResult))
+		refreshResp.Body.Close()
+		require.Equal(t, true, refreshResult["success"])
+		assert.Equal(t, "CHANGED", refreshResult["data"].(map[string]interface{})["promo_code"])
+
+		updateResp, err := client.UpdateBroadcast(map[string]interface{}{
+			"workspace_id": workspace.ID,
+			"id":           broadcast.ID,
+			"name":         "Data Feed Snapshot Test",
+			"audience":     map[string]interface{}{"list": list.ID, "exclude_unsubscribed": true},
+			"schedule":     map[string]interface{}{"is_scheduled": false},
+			"test_settings": map[string]interface{}{
+				"enabled":    false,
+				"variations": []interface{}{map[string]interface{}{"template_id": template.ID}},
+			},
+			"data_feed": map[string]interface{}{
+				"global_feed": map[string]interface{}{"enabled": true, "url": "https://example.com/feed", "headers": []interface{}{}},
+			},
+		})
+		require.NoError(t, err)
+		updateResp.Body.Close()
+		assert.NotEqual(t, http.StatusOK, updateResp.StatusCode, "completed broadcasts cannot be updated")
+
+		// The stored snapshot is unchanged
+		pinned := getDataFeed()
+		assert.Equal(t, feedData, pinned["global_feed_data"])
+		assert.Equal(t, fetchedAt, pinned["global_feed_fetched_at"])
+		assert.Equal(t, mockServer.URL(), pinned["global_feed"].(map[string]interface{})["url"])
+
+		// Pausing a processed broadcast leaves it completed but no longer processed,
+		// a full update must still keep the snapshot it was sent with
+		pauseResp, err := client.PauseBroadcast(map[string]interface{}{
+			"workspace_id": workspace.ID,
+			"id":           broadcast.ID,
+		})
+		require.NoError(t, err)
+		pauseResp.Body.Close()
+		require.Equal(t, http.StatusOK, pauseResp.StatusCode)
+
+		broadcastRepo := suite.ServerManager.GetApp().GetBroadcastRepository()
+		paused, err := broadcastRepo.GetBroadcast(context.Background(), workspace.ID, broadcast.ID)
+		require.NoError(t, err)
+		require.Equal(t, domain.BroadcastStatusPaused, paused.Status)
+		require.NotNil(t, paused.CompletedAt)
+
+		paused.DataFeed.GlobalFeedData = domain.MapOfAny{"promo_code": "CHANGED"}
+		paused.DataFeed.GlobalFeed.URL = "https://example.com/feed"
+		require.NoError(t, broadcastRepo.UpdateBroadcast(context.Background(), paused))
+
+		pinned = getDataFeed()
+		assert.Equal(t, feedData, pinned["global_feed_data"])
+		assert.Equal(t, fetchedAt, pinned["global_feed_fetched_at"])
+		assert.Equal(t, mockServer.URL(), pinned["global_feed"].(map[string]interface{})["url"])
+	})
 }
 
 // TestRecipientFeedEmailRendering tests that recipient_feed Liquid variables
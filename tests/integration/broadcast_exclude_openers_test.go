@@ -0,0 +1,94 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBroadcastAudience_ExcludeOpenersOf sends broadcast A to a list, records opens for some
+// recipients, and checks that a follow-up broadcast B with exclude_openers_of A only targets
+// the recipients who did not open A
+func TestBroadcastAudience_ExcludeOpenersOf(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	factory := suite.DataFactory
+
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+	list, err := factory.CreateList(workspace.ID)
+	require.NoError(t, err)
+
+	broadcastA, err := factory.CreateBroadcast(workspace.ID,
+		testutil.WithBroadcastName("Launch"),
+		testutil.WithBroadcastAudience(domain.AudienceSettings{List: list.ID, ExcludeUnsubscribed: true}))
+	require.NoError(t, err)
+
+	recipients := map[string][]testutil.MessageHistoryOption{
+		"opener@example.com":     {testutil.WithMessageOpened(true)},
+		"clicker@example.com":    {testutil.WithMessageClicked(true)},
+		"non-opener@example.com": {testutil.WithMessageDelivered(true)},
+	}
+	for email, opts := range recipients {
+		_, err := factory.CreateContact(workspace.ID, testutil.WithContactEmail(email))
+		require.NoError(t, err)
+		_, err = factory.CreateContactList(workspace.ID,
+			testutil.WithContactListEmail(email),
+			testutil.WithContactListListID(list.ID),
+			testutil.WithContactListStatus(domain.ContactListStatusActive),
+		)
+		require.NoError(t, err)
+		opts = append(opts, testutil.WithMessageContact(email), testutil.WithMessageBroadcast(broadcastA.ID))
+		_, err = factory.CreateMessageHistory(workspace.ID, opts...)
+		require.NoError(t, err)
+	}
+
+	// A contact who joined the list after broadcast A went out also receives the follow-up
+	_, err = factory.CreateContact(workspace.ID, testutil.WithContactEmail("newcomer@example.com"))
+	require.NoError(t, err)
+	_, err = factory.CreateContactList(workspace.ID,
+		testutil.WithContactListEmail("newcomer@example.com"),
+		testutil.WithContactListListID(list.ID),
+		testutil.WithContactListStatus(domain.ContactListStatusActive),
+	)
+	require.NoError(t, err)
+
+	audienceB := domain.AudienceSettings{
+		List:                list.ID,
+		ExcludeUnsubscribed: true,
+		ExcludeOpenersOf:    broadcastA.ID,
+	}
+
+	contactRepo := suite.ServerManager.GetApp().GetContactRepository()
+
+	contacts, err := contactRepo.GetContactsForBroadcast(context.Background(), workspace.ID, audienceB, 100, "")
+	require.NoError(t, err)
+	emails := make([]string, 0, len(contacts))
+	for _, c := range contacts {
+		emails = append(emails, c.Contact.Email)
+	}
+	assert.ElementsMatch(t, []string{"newcomer@example.com", "non-opener@example.com"}, emails)
+
+	count, err := contactRepo.CountContactsForBroadcast(context.Background(), workspace.ID, audienceB)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// Without the option broadcast B reaches the whole list
+	audienceB.ExcludeOpenersOf = ""
+	count, err = contactRepo.CountContactsForBroadcast(context.Background(), workspace.ID, audienceB)
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+}
@@ -0,0 +1,152 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBroadcastListJoinTrigger tests that a list-join broadcast is sent to members who
+// joined the list longer ago than the offset, and not yet to recent joiners
+func TestBroadcastListJoinTrigger(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	// Start background workers (needed for email queue worker to deliver to Mailpit)
+	ctx := context.Background()
+	err := suite.ServerManager.StartBackgroundWorkers(ctx)
+	require.NoError(t, err)
+
+	client := suite.APIClient
+	factory := suite.DataFactory
+
+	user, err := factory.CreateUser()
+	require.NoError(t, err)
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+	err = factory.AddUserToWorkspace(user.ID, workspace.ID, "owner")
+	require.NoError(t, err)
+
+	_, err = factory.SetupWorkspaceWithSMTPProvider(workspace.ID,
+		testutil.WithIntegrationEmailProvider(domain.EmailProvider{
+			Kind: domain.EmailProviderKindSMTP,
+			Senders: []domain.EmailSender{
+				domain.NewEmailSender("noreply@notifuse.test", "Notifuse List Join Test"),
+			},
+			SMTP: &domain.SMTPSettings{
+				Host:   "localhost",
+				Port:   1025,
+				UseTLS: false,
+			},
+			RateLimitPerMinute: 2000,
+		}))
+	require.NoError(t, err)
+
+	err = client.Login(user.Email, "password")
+	require.NoError(t, err)
+	client.SetWorkspaceID(workspace.ID)
+
+	err = testutil.ClearMailpitMessages(t)
+	require.NoError(t, err)
+
+	suffix := uuid.New().String()[:8]
+	list, err := factory.CreateList(workspace.ID, testutil.WithListName("List Join Audience"))
+	require.NoError(t, err)
+
+	oldJoiner := fmt.Sprintf("list-join-old-%s@example.com", suffix)
+	recentJoiner := fmt.Sprintf("list-join-recent-%s@example.com", suffix)
+	for _, email := range []string{oldJoiner, recentJoiner} {
+		_, err = factory.CreateContact(workspace.ID, testutil.WithContactEmail(email))
+		require.NoError(t, err)
+		_, err = factory.CreateContactList(workspace.ID,
+			testutil.WithContactListEmail(email),
+			testutil.WithContactListListID(list.ID),
+			testutil.WithContactListStatus(domain.ContactListStatusActive))
+		require.NoError(t, err)
+	}
+
+	// The old joiner has been on the list for a little over 3 days
+	err = factory.SetContactListJoinedAt(workspace.ID, oldJoiner, list.ID, time.Now().UTC().Add(-72*time.Hour-time.Minute))
+	require.NoError(t, err)
+
+	template, err := factory.CreateTemplate(workspace.ID,
+		testutil.WithTemplateName("List Join Template"),
+		testutil.WithTemplateSubject(fmt.Sprintf("Three days in %s", suffix)))
+	require.NoError(t, err)
+
+	broadcast, err := factory.CreateBroadcast(workspace.ID,
+		testutil.WithBroadcastName("List Join Broadcast"),
+		testutil.WithBroadcastAudience(domain.AudienceSettings{
+			List:                list.ID,
+			ExcludeUnsubscribed: true,
+		}))
+	require.NoError(t, err)
+
+	broadcast.TestSettings.Variations[0].TemplateID = template.ID
+	broadcast.Schedule.TriggerMode = domain.BroadcastTriggerModeListJoin
+	broadcast.Schedule.JoinOffset = 3
+	broadcast.Schedule.JoinOffsetUnit = "days"
+	updateResp, err := client.UpdateBroadcast(map[string]interface{}{
+		"workspace_id":  workspace.ID,
+		"id":            broadcast.ID,
+		"name":          broadcast.Name,
+		"audience":      broadcast.Audience,
+		"schedule":      broadcast.Schedule,
+		"test_settings": broadcast.TestSettings,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 200, updateResp.StatusCode)
+	updateResp.Body.Close()
+
+	scheduleResp, err := client.ScheduleBroadcast(map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"id":           broadcast.ID,
+		"send_now":     true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 200, scheduleResp.StatusCode)
+	scheduleResp.Body.Close()
+
+	execResp, err := client.ExecutePendingTasks(10)
+	require.NoError(t, err)
+	execResp.Body.Close()
+
+	// The contact who joined 3 days ago receives the broadcast
+	msg, err := waitForEmailByRecipient(t, oldJoiner, 30*time.Second)
+	require.NoError(t, err, "Contact who joined 3 days ago should receive the broadcast")
+	assert.Contains(t, msg.Subject, suffix)
+
+	// The recent joiner has not reached the offset yet
+	_, err = waitForEmailByRecipient(t, recentJoiner, 5*time.Second)
+	assert.Error(t, err, "Recent joiner should not receive the broadcast yet")
+
+	// The broadcast keeps running for members who reach the offset later
+	getResp, err := client.GetBroadcast(broadcast.ID)
+	require.NoError(t, err)
+	defer getResp.Body.Close()
+
+	var getResult map[string]interface{}
+	err = json.NewDecoder(getResp.Body).Decode(&getResult)
+	require.NoError(t, err)
+	broadcastData := getResult["broadcast"].(map[string]interface{})
+	assert.Equal(t, string(domain.BroadcastStatusProcessing), broadcastData["status"])
+	assert.Equal(t, float64(1), broadcastData["enqueued_count"])
+}
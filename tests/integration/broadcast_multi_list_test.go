@@ -0,0 +1,172 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBroadcastMultipleLists verifies that a broadcast targeting several lists sends
+// exactly one email per contact, even when the contact is subscribed to more than one
+// of the targeted lists, and that exclude_unsubscribed is honored per list
+func TestBroadcastMultipleLists(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	client := suite.APIClient
+	factory := suite.DataFactory
+
+	user, err := factory.CreateUser()
+	require.NoError(t, err)
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+	err = factory.AddUserToWorkspace(user.ID, workspace.ID, "owner")
+	require.NoError(t, err)
+
+	_, err = factory.SetupWorkspaceWithSMTPProvider(workspace.ID,
+		testutil.WithIntegrationEmailProvider(domain.EmailProvider{
+			Kind: domain.EmailProviderKindSMTP,
+			Senders: []domain.EmailSender{
+				domain.NewEmailSender("noreply@notifuse.test", "Notifuse Multi List Test"),
+			},
+			SMTP: &domain.SMTPSettings{
+				Host:   "localhost",
+				Port:   1025,
+				UseTLS: false,
+			},
+			RateLimitPerMinute: 2000,
+		}))
+	require.NoError(t, err)
+
+	err = client.Login(user.Email, "password")
+	require.NoError(t, err)
+	client.SetWorkspaceID(workspace.ID)
+
+	err = testutil.ClearMailpitMessages(t)
+	require.NoError(t, err)
+
+	newsletter, err := factory.CreateList(workspace.ID, testutil.WithListName("Newsletter"))
+	require.NoError(t, err)
+	productUpdates, err := factory.CreateList(workspace.ID, testutil.WithListName("Product Updates"))
+	require.NoError(t, err)
+
+	subscribe := func(email, listID string, status domain.ContactListStatus) {
+		_, err := factory.CreateContactList(workspace.ID,
+			testutil.WithContactListEmail(email),
+			testutil.WithContactListListID(listID),
+			testutil.WithContactListStatus(status),
+		)
+		require.NoError(t, err)
+	}
+
+	// both@ is active on both targeted lists and must receive a single email
+	// mixed@ unsubscribed from one list but is still active on the other
+	// unsubscribed@ is only on one list, as unsubscribed, and must be excluded
+	bothEmail := "multi-list-both@example.com"
+	newsletterEmail := "multi-list-newsletter@example.com"
+	mixedEmail := "multi-list-mixed@example.com"
+	unsubscribedEmail := "multi-list-unsubscribed@example.com"
+	for _, email := range []string{bothEmail, newsletterEmail, mixedEmail, unsubscribedEmail} {
+		_, err := factory.CreateContact(workspace.ID, testutil.WithContactEmail(email))
+		require.NoError(t, err)
+	}
+	subscribe(bothEmail, newsletter.ID, domain.ContactListStatusActive)
+	subscribe(bothEmail, productUpdates.ID, domain.ContactListStatusActive)
+	subscribe(newsletterEmail, newsletter.ID, domain.ContactListStatusActive)
+	subscribe(mixedEmail, newsletter.ID, domain.ContactListStatusUnsubscribed)
+	subscribe(mixedEmail, productUpdates.ID, domain.ContactListStatusActive)
+	subscribe(unsubscribedEmail, productUpdates.ID, domain.ContactListStatusUnsubscribed)
+
+	expectedRecipients := []string{bothEmail, newsletterEmail, mixedEmail}
+
+	uniqueSubject := fmt.Sprintf("Multi List Test %s", uuid.New().String()[:8])
+	template, err := factory.CreateTemplate(workspace.ID,
+		testutil.WithTemplateName("Multi List Template"),
+		testutil.WithTemplateSubject(uniqueSubject))
+	require.NoError(t, err)
+
+	broadcast, err := factory.CreateBroadcast(workspace.ID,
+		testutil.WithBroadcastName("Multi List Broadcast"),
+		testutil.WithBroadcastAudience(domain.AudienceSettings{
+			List:                newsletter.ID,
+			Lists:               []string{productUpdates.ID},
+			ExcludeUnsubscribed: true,
+		}))
+	require.NoError(t, err)
+
+	broadcast.TestSettings.Variations[0].TemplateID = template.ID
+	updateResp, err := client.UpdateBroadcast(map[string]interface{}{
+		"workspace_id":  workspace.ID,
+		"id":            broadcast.ID,
+		"name":          broadcast.Name,
+		"audience":      broadcast.Audience,
+		"schedule":      broadcast.Schedule,
+		"test_settings": broadcast.TestSettings,
+	})
+	require.NoError(t, err)
+	defer updateResp.Body.Close()
+	require.Equal(t, http.StatusOK, updateResp.StatusCode, "Broadcast update should succeed")
+
+	err = suite.ServerManager.StartBackgroundWorkers(context.Background())
+	require.NoError(t, err)
+
+	scheduleResp, err := client.ScheduleBroadcast(map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"id":           broadcast.ID,
+		"send_now":     true,
+	})
+	require.NoError(t, err)
+	defer scheduleResp.Body.Close()
+	if scheduleResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(scheduleResp.Body)
+		t.Fatalf("Failed to schedule broadcast: %d - %s", scheduleResp.StatusCode, string(body))
+	}
+
+	_, err = testutil.WaitForBroadcastStatusWithExecution(t, client, broadcast.ID,
+		[]string{"processed", "completed"}, 2*time.Minute)
+	require.NoError(t, err, "Broadcast should complete successfully")
+
+	err = testutil.WaitForMailpitMessages(t, uniqueSubject, len(expectedRecipients), time.Minute)
+	require.NoError(t, err)
+
+	t.Run("each contact receives exactly one email", func(t *testing.T) {
+		messageHistoryRepo := suite.ServerManager.GetApp().GetMessageHistoryRepository()
+
+		var messages []*domain.MessageHistory
+		testutil.WaitForCondition(t, func() bool {
+			messages, _, err = messageHistoryRepo.ListMessages(context.Background(), workspace.ID,
+				workspace.Settings.SecretKey, domain.MessageListParams{
+					BroadcastID: broadcast.ID,
+					Limit:       100,
+				})
+			return err == nil && len(messages) >= len(expectedRecipients)
+		}, 30*time.Second, "waiting for broadcast message history")
+
+		sends := map[string]int{}
+		for _, msg := range messages {
+			sends[msg.ContactEmail]++
+		}
+		assert.Equal(t, map[string]int{bothEmail: 1, newsletterEmail: 1, mixedEmail: 1}, sends)
+
+		count, err := testutil.GetMailpitMessageCount(t, uniqueSubject)
+		require.NoError(t, err)
+		assert.Equal(t, len(expectedRecipients), count, "Mailpit should receive one email per contact")
+	})
+}
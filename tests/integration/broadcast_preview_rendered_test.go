@@ -0,0 +1,87 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBroadcastPreviewRendered renders a broadcast with stored global feed data for a
+// sample contact of its list and checks the preview contains the feed and contact values
+func TestBroadcastPreviewRendered(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	factory := suite.DataFactory
+	client := suite.APIClient
+
+	user, err := factory.CreateUser()
+	require.NoError(t, err)
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+	err = factory.AddUserToWorkspace(user.ID, workspace.ID, "owner")
+	require.NoError(t, err)
+
+	err = client.Login(user.Email, "password")
+	require.NoError(t, err)
+	client.SetWorkspaceID(workspace.ID)
+
+	list, err := factory.CreateList(workspace.ID)
+	require.NoError(t, err)
+	template, err := factory.CreateTemplate(workspace.ID,
+		testutil.WithTemplateSubject("{{ global_feed.headline }} for {{ contact.first_name }}"))
+	require.NoError(t, err)
+
+	fetchedAt := time.Now().UTC()
+	broadcast, err := factory.CreateBroadcast(workspace.ID,
+		testutil.WithBroadcastAudience(domain.AudienceSettings{List: list.ID, ExcludeUnsubscribed: true}),
+		testutil.WithBroadcastTemplateID(template.ID),
+		testutil.WithBroadcastGlobalFeedData(map[string]interface{}{"headline": "Spring Sale"}, &fetchedAt))
+	require.NoError(t, err)
+
+	_, err = factory.CreateContact(workspace.ID,
+		testutil.WithContactEmail("jane@example.com"),
+		testutil.WithContactName("Jane", "Doe"))
+	require.NoError(t, err)
+	_, err = factory.CreateContactList(workspace.ID,
+		testutil.WithContactListEmail("jane@example.com"),
+		testutil.WithContactListListID(list.ID),
+		testutil.WithContactListStatus(domain.ContactListStatusActive),
+	)
+	require.NoError(t, err)
+
+	resp, err := client.PreviewRenderedBroadcast(map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"broadcast_id": broadcast.ID,
+		"limit":        1,
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result domain.PreviewRenderedBroadcastResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+
+	assert.Equal(t, template.ID, result.TemplateID)
+	require.Len(t, result.Previews, 1)
+	preview := result.Previews[0]
+	assert.Empty(t, preview.Error)
+	assert.Equal(t, "jane@example.com", preview.Email)
+	assert.Equal(t, list.ID, preview.ListID)
+	assert.Equal(t, "Spring Sale for Jane", preview.Subject)
+	assert.NotEmpty(t, preview.HTML)
+}
@@ -0,0 +1,163 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBroadcastSeedList tests that the workspace seed inboxes receive every broadcast
+// while the broadcast recipient counts and message history only cover the audience
+func TestBroadcastSeedList(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	// Start background workers (needed for email queue worker to deliver to Mailpit)
+	ctx := context.Background()
+	err := suite.ServerManager.StartBackgroundWorkers(ctx)
+	require.NoError(t, err)
+
+	client := suite.APIClient
+	factory := suite.DataFactory
+
+	user, err := factory.CreateUser()
+	require.NoError(t, err)
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+	err = factory.AddUserToWorkspace(user.ID, workspace.ID, "owner")
+	require.NoError(t, err)
+
+	_, err = factory.SetupWorkspaceWithSMTPProvider(workspace.ID,
+		testutil.WithIntegrationEmailProvider(domain.EmailProvider{
+			Kind: domain.EmailProviderKindSMTP,
+			Senders: []domain.EmailSender{
+				domain.NewEmailSender("noreply@notifuse.test", "Notifuse Seed Test"),
+			},
+			SMTP: &domain.SMTPSettings{
+				Host:   "localhost",
+				Port:   1025,
+				UseTLS: false,
+			},
+			RateLimitPerMinute: 2000,
+		}))
+	require.NoError(t, err)
+
+	suffix := uuid.New().String()[:8]
+	seeds := []string{
+		fmt.Sprintf("seed-gmail-%s@seeds.test", suffix),
+		fmt.Sprintf("seed-outlook-%s@seeds.test", suffix),
+	}
+	err = factory.SetWorkspaceBroadcastSeedList(workspace.ID, seeds)
+	require.NoError(t, err)
+
+	err = client.Login(user.Email, "password")
+	require.NoError(t, err)
+	client.SetWorkspaceID(workspace.ID)
+
+	err = testutil.ClearMailpitMessages(t)
+	require.NoError(t, err)
+
+	// One real recipient in the audience
+	list, err := factory.CreateList(workspace.ID, testutil.WithListName("Seed List Audience"))
+	require.NoError(t, err)
+	contactEmail := fmt.Sprintf("seed-audience-%s@example.com", suffix)
+	_, err = factory.CreateContact(workspace.ID, testutil.WithContactEmail(contactEmail))
+	require.NoError(t, err)
+	_, err = factory.CreateContactList(workspace.ID,
+		testutil.WithContactListEmail(contactEmail),
+		testutil.WithContactListListID(list.ID),
+		testutil.WithContactListStatus(domain.ContactListStatusActive))
+	require.NoError(t, err)
+
+	template, err := factory.CreateTemplate(workspace.ID,
+		testutil.WithTemplateName("Seed List Template"),
+		testutil.WithTemplateSubject(fmt.Sprintf("Seed list check %s", suffix)))
+	require.NoError(t, err)
+
+	broadcast, err := factory.CreateBroadcast(workspace.ID,
+		testutil.WithBroadcastName("Seed List Broadcast"),
+		testutil.WithBroadcastAudience(domain.AudienceSettings{
+			List:                list.ID,
+			ExcludeUnsubscribed: true,
+		}))
+	require.NoError(t, err)
+
+	broadcast.TestSettings.Variations[0].TemplateID = template.ID
+	updateResp, err := client.UpdateBroadcast(map[string]interface{}{
+		"workspace_id":  workspace.ID,
+		"id":            broadcast.ID,
+		"name":          broadcast.Name,
+		"audience":      broadcast.Audience,
+		"schedule":      broadcast.Schedule,
+		"test_settings": broadcast.TestSettings,
+	})
+	require.NoError(t, err)
+	updateResp.Body.Close()
+
+	scheduleResp, err := client.ScheduleBroadcast(map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"id":           broadcast.ID,
+		"send_now":     true,
+	})
+	require.NoError(t, err)
+	scheduleResp.Body.Close()
+
+	_, err = testutil.WaitForBroadcastStatusWithExecution(t, client, broadcast.ID,
+		[]string{"processed", "completed"}, 60*time.Second)
+	require.NoError(t, err)
+
+	// The audience and every seed inbox receive the broadcast
+	msg, err := waitForEmailByRecipient(t, contactEmail, 15*time.Second)
+	require.NoError(t, err, "Audience contact should receive the broadcast")
+	assert.Contains(t, msg.Subject, suffix)
+
+	for _, seed := range seeds {
+		seedMsg, err := waitForEmailByRecipient(t, seed, 15*time.Second)
+		require.NoError(t, err, "Seed inbox %s should receive the broadcast", seed)
+		assert.Contains(t, seedMsg.Subject, suffix)
+	}
+
+	// The broadcast recipient counts exclude the seeds
+	getResp, err := client.GetBroadcast(broadcast.ID)
+	require.NoError(t, err)
+	defer getResp.Body.Close()
+
+	var getResult map[string]interface{}
+	err = json.NewDecoder(getResp.Body).Decode(&getResult)
+	require.NoError(t, err)
+	broadcastData := getResult["broadcast"].(map[string]interface{})
+	assert.Equal(t, float64(1), broadcastData["enqueued_count"], "Enqueued count should only include the audience")
+
+	// Seed copies leave no message history, so the broadcast stats only cover the audience
+	messageHistoryRepo := suite.ServerManager.GetApp().GetMessageHistoryRepository()
+	messages, _, err := messageHistoryRepo.ListMessages(
+		context.Background(),
+		workspace.ID,
+		workspace.Settings.SecretKey,
+		domain.MessageListParams{
+			BroadcastID: broadcast.ID,
+			Limit:       100,
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, messages, 1, "Only the audience contact should have a message history entry")
+	assert.Equal(t, contactEmail, messages[0].ContactEmail)
+}
@@ -0,0 +1,129 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/service"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestContactEngagementScore_BranchThreshold verifies that opens, clicks and custom events
+// accumulate into the contact engagement score and that branch nodes can route on it
+func TestContactEngagementScore_BranchThreshold(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	factory := suite.DataFactory
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+
+	engaged, err := factory.CreateContact(workspace.ID, testutil.WithContactEmail("engaged@example.com"))
+	require.NoError(t, err)
+	quiet, err := factory.CreateContact(workspace.ID, testutil.WithContactEmail("quiet@example.com"))
+	require.NoError(t, err)
+
+	// Clicked message: open (+1) and click (+3)
+	_, err = factory.CreateMessageHistory(workspace.ID,
+		testutil.WithMessageContact(engaged.Email),
+		testutil.WithMessageClicked(true))
+	require.NoError(t, err)
+	// Two custom events (+2 each)
+	require.NoError(t, factory.CreateCustomEvent(workspace.ID, engaged.Email, "product_viewed", nil))
+	require.NoError(t, factory.CreateCustomEvent(workspace.ID, engaged.Email, "cart_updated", nil))
+
+	// Opened message only (+1)
+	_, err = factory.CreateMessageHistory(workspace.ID,
+		testutil.WithMessageContact(quiet.Email),
+		testutil.WithMessageOpened(true))
+	require.NoError(t, err)
+
+	workspaceDB, err := suite.ServerManager.GetApp().GetWorkspaceRepository().GetConnection(context.Background(), workspace.ID)
+	require.NoError(t, err)
+
+	scoreOf := func(email string) float64 {
+		var score float64
+		err := workspaceDB.QueryRowContext(context.Background(),
+			`SELECT engagement_score FROM contacts WHERE email = $1`, email).Scan(&score)
+		require.NoError(t, err)
+		return score
+	}
+	assert.InDelta(t, 8, scoreOf(engaged.Email), 0.01)
+	assert.InDelta(t, 1, scoreOf(quiet.Email), 0.01)
+
+	executor := service.NewBranchNodeExecutor(
+		service.NewQueryBuilder(),
+		suite.ServerManager.GetApp().GetWorkspaceRepository(),
+	)
+
+	node := &domain.AutomationNode{
+		ID:   "branch1",
+		Type: domain.NodeTypeBranch,
+		Config: map[string]interface{}{
+			"paths": []interface{}{
+				map[string]interface{}{
+					"id":           "engaged",
+					"name":         "Highly engaged",
+					"next_node_id": "engaged_node",
+					"conditions": map[string]interface{}{
+						"kind": "leaf",
+						"leaf": map[string]interface{}{
+							"source": "contacts",
+							"contact": map[string]interface{}{
+								"filters": []interface{}{
+									map[string]interface{}{
+										"field_name":    domain.ContactFieldEngagementScore,
+										"field_type":    "number",
+										"operator":      "gte",
+										"number_values": []interface{}{5},
+									},
+								},
+							},
+						},
+					},
+				},
+				map[string]interface{}{
+					"id":           "other",
+					"name":         "Everyone else",
+					"next_node_id": "other_node",
+				},
+			},
+			"default_path_id": "other",
+		},
+	}
+
+	t.Run("contact above the threshold takes the condition path", func(t *testing.T) {
+		result, err := executor.Execute(context.Background(), service.NodeExecutionParams{
+			WorkspaceID: workspace.ID,
+			Node:        node,
+			ContactData: engaged,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "engaged_node", *result.NextNodeID)
+		assert.Equal(t, "engaged", result.Output["path_taken"])
+	})
+
+	t.Run("contact below the threshold takes the default path", func(t *testing.T) {
+		result, err := executor.Execute(context.Background(), service.NodeExecutionParams{
+			WorkspaceID: workspace.ID,
+			Node:        node,
+			ContactData: quiet,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, result.NextNodeID)
+		assert.Equal(t, "other_node", *result.NextNodeID)
+		assert.Equal(t, "default", result.Output["path_taken"])
+	})
+}
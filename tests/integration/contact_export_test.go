@@ -0,0 +1,111 @@
+package integration
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestContactExport verifies that contacts.export streams the contacts of a segment
+// as CSV with the selected columns, and leaves out contacts outside the segment
+func TestContactExport(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	client := suite.APIClient
+	factory := suite.DataFactory
+
+	user, err := factory.CreateUser()
+	require.NoError(t, err)
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+	err = factory.AddUserToWorkspace(user.ID, workspace.ID, "owner")
+	require.NoError(t, err)
+
+	err = client.Login(user.Email, "password")
+	require.NoError(t, err)
+	client.SetWorkspaceID(workspace.ID)
+
+	segment, err := factory.CreateSegment(workspace.ID)
+	require.NoError(t, err)
+
+	// ann@ and bob@ are in the segment, carol@ is not
+	_, err = factory.CreateContact(workspace.ID,
+		testutil.WithContactEmail("export-ann@example.com"),
+		testutil.WithContactName("Ann", "Lee"),
+		testutil.WithContactCountry("FR"))
+	require.NoError(t, err)
+	_, err = factory.CreateContact(workspace.ID,
+		testutil.WithContactEmail("export-bob@example.com"),
+		testutil.WithContactName("Bob", "Smith"),
+		testutil.WithContactCountry("US"))
+	require.NoError(t, err)
+	_, err = factory.CreateContact(workspace.ID,
+		testutil.WithContactEmail("export-carol@example.com"),
+		testutil.WithContactName("Carol", "Jones"),
+		testutil.WithContactCountry("DE"))
+	require.NoError(t, err)
+
+	workspaceDB, err := factory.GetWorkspaceDB(workspace.ID)
+	require.NoError(t, err)
+	now := time.Now().UTC()
+	for _, email := range []string{"export-ann@example.com", "export-bob@example.com"} {
+		_, err = workspaceDB.ExecContext(context.Background(),
+			`INSERT INTO contact_segments (email, segment_id, version, matched_at, computed_at) VALUES ($1, $2, $3, $4, $4)`,
+			email, segment.ID, segment.Version, now)
+		require.NoError(t, err)
+	}
+
+	t.Run("exports segment members with selected columns", func(t *testing.T) {
+		resp, err := client.Get("/api/contacts.export", map[string]string{
+			"workspace_id": workspace.ID,
+			"segments[]":   segment.ID,
+			"columns":      "email,first_name,country",
+		})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+		assert.Contains(t, resp.Header.Get("Content-Disposition"), "attachment")
+
+		records, err := csv.NewReader(resp.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3, "header plus one row per segment member")
+
+		assert.Equal(t, []string{"email", "first_name", "country"}, records[0])
+
+		rows := records[1:]
+		sort.Slice(rows, func(i, j int) bool { return rows[i][0] < rows[j][0] })
+		assert.Equal(t, [][]string{
+			{"export-ann@example.com", "Ann", "FR"},
+			{"export-bob@example.com", "Bob", "US"},
+		}, rows)
+	})
+
+	t.Run("rejects unknown columns", func(t *testing.T) {
+		resp, err := client.Get("/api/contacts.export", map[string]string{
+			"workspace_id": workspace.ID,
+			"columns":      "email,password",
+		})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,57 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestContactMessagingHold_DefersBroadcastUntilHoldPasses sends a broadcast to a list
+// where one contact has an active messaging hold. The other recipients are delivered
+// right away, the held contact's email stays queued and is delivered once the hold passes.
+func TestContactMessagingHold_DefersBroadcastUntilHoldPasses(t *testing.T) {
+	h := setupPhase2(t, 2, 600)
+	defer h.Cleanup()
+
+	holdUntil := time.Now().UTC().Add(15 * time.Second)
+	heldEmail := fmt.Sprintf("held-%s@example.com", uuid.New().String()[:6])
+	resp, err := h.client.BatchImportContacts([]map[string]interface{}{{
+		"email":                heldEmail,
+		"first_name":           "Held",
+		"messaging_hold_until": holdUntil.Format(time.RFC3339),
+	}}, []string{h.listID})
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	h.scheduleAndExecute(t)
+	h.waitForBroadcastStatus(t, []string{"processed"}, 30*time.Second)
+
+	require.NoError(t, h.suite.ServerManager.StartBackgroundWorkers(context.Background()))
+
+	// The two contacts without a hold are delivered, the held one stays pending
+	h.waitForMailpitCount(t, 2, 10*time.Second)
+	time.Sleep(2 * time.Second)
+	require.True(t, time.Now().Before(holdUntil), "hold expired before assertions; test host too slow")
+
+	sent, err := testutil.GetMailpitMessageCount(t, h.subject)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent, "held contact must not be sent to while the hold is active")
+	assert.Equal(t, int64(1), h.countQueue(t)[domain.EmailQueueStatusPending])
+
+	// Once the hold passes the queued email is delivered
+	h.waitForMailpitCount(t, 3, time.Until(holdUntil)+20*time.Second)
+	waitForCondition(t, func() bool {
+		return h.countQueue(t)[domain.EmailQueueStatusPending] == 0
+	}, 5*time.Second, "held entry drained")
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/repository"
 	"github.com/Notifuse/notifuse/tests/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -680,3 +681,58 @@ func TestEmailQueueConcurrency(t *testing.T) {
 	})
 
 }
+
+// TestEmailQueueDedup verifies that the same logical message enqueued twice within the
+// dedup window is only sent once
+func TestEmailQueueDedup(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, appFactory)
+	defer suite.Cleanup()
+
+	factory := suite.DataFactory
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+	integration, err := factory.SetupWorkspaceWithSMTPProvider(workspace.ID)
+	require.NoError(t, err)
+
+	app := suite.ServerManager.GetApp()
+	queueRepo := repository.NewEmailQueueRepository(app.GetWorkspaceRepository(), time.Minute)
+	ctx := context.Background()
+
+	workerCtx, cancelWorker := context.WithCancel(context.Background())
+	defer cancelWorker()
+	require.NoError(t, suite.ServerManager.StartBackgroundWorkers(workerCtx))
+
+	require.NoError(t, testutil.ClearMailpitMessages(t))
+
+	uniqueSubject := "Dedup Test " + testutil.GenerateRandomString(8)
+	newEntry := func() *domain.EmailQueueEntry {
+		entry := testutil.CreateTestEmailQueueEntry(integration.ID, "dedup-test@example.com", "broadcast-dedup", domain.EmailQueueSourceBroadcast)
+		entry.TemplateID = "tpl-dedup"
+		entry.DedupeKey = string(domain.EmailQueueSourceBroadcast)
+		entry.Payload.Subject = uniqueSubject
+		return entry
+	}
+
+	// Same contact, template and dedupe key, as a double-scheduled broadcast would enqueue
+	require.NoError(t, queueRepo.Enqueue(ctx, workspace.ID, []*domain.EmailQueueEntry{newEntry()}))
+	require.NoError(t, queueRepo.Enqueue(ctx, workspace.ID, []*domain.EmailQueueEntry{newEntry()}))
+
+	require.NoError(t, testutil.WaitForQueueEmpty(t, queueRepo, workspace.ID, 30*time.Second))
+	require.NoError(t, testutil.WaitForMailpitMessages(t, uniqueSubject, 1, 10*time.Second))
+
+	// Leave time for a duplicate to show up if one had been enqueued
+	time.Sleep(2 * time.Second)
+	count, err := testutil.GetMailpitMessageCount(t, uniqueSubject)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "duplicate send should have been dropped")
+
+	// A different dedupe key is a different logical message
+	other := newEntry()
+	other.DedupeKey = "broadcast:other"
+	require.NoError(t, queueRepo.Enqueue(ctx, workspace.ID, []*domain.EmailQueueEntry{other}))
+	require.NoError(t, testutil.WaitForMailpitMessages(t, uniqueSubject, 2, 30*time.Second))
+}
@@ -0,0 +1,162 @@
+package integration
+
+import (
+	"encoding/json"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLiquidSystemURLTags verifies that {% unsubscribe_url %} and {% preferences_url %}
+// render signed links in a sent email, and that the public endpoints used by the
+// notification center accept them
+func TestLiquidSystemURLTags(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	client := suite.APIClient
+	factory := suite.DataFactory
+
+	user, err := factory.CreateUser()
+	require.NoError(t, err)
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+	err = factory.AddUserToWorkspace(user.ID, workspace.ID, "owner")
+	require.NoError(t, err)
+
+	_, err = factory.SetupWorkspaceWithSMTPProvider(workspace.ID)
+	require.NoError(t, err)
+
+	err = client.Login(user.Email, "password")
+	require.NoError(t, err)
+	client.SetWorkspaceID(workspace.ID)
+
+	err = testutil.ClearMailpitMessages(t)
+	require.NoError(t, err)
+
+	list, err := factory.CreateList(workspace.ID)
+	require.NoError(t, err)
+	contact, err := factory.CreateContact(workspace.ID)
+	require.NoError(t, err)
+	_, err = factory.CreateContactList(workspace.ID,
+		testutil.WithContactListEmail(contact.Email),
+		testutil.WithContactListListID(list.ID),
+		testutil.WithContactListStatus(domain.ContactListStatusActive))
+	require.NoError(t, err)
+
+	// Links are rendered as text so link tracking does not rewrite them
+	template, err := factory.CreateTemplate(workspace.ID,
+		testutil.WithTemplateEmailContent("UNSUB[{% unsubscribe_url %}] PREFS[{% preferences_url %}]"))
+	require.NoError(t, err)
+
+	broadcast, err := factory.CreateBroadcast(workspace.ID,
+		testutil.WithBroadcastTemplateID(template.ID),
+		testutil.WithBroadcastAudience(domain.AudienceSettings{
+			List:                list.ID,
+			ExcludeUnsubscribed: true,
+		}))
+	require.NoError(t, err)
+
+	resp, err := client.SendBroadcastToIndividual(map[string]interface{}{
+		"workspace_id":    workspace.ID,
+		"broadcast_id":    broadcast.ID,
+		"recipient_email": contact.Email,
+		"template_id":     template.ID,
+	})
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	msg, err := waitForEmailAndGetMessage(t, contact.Email, 10*time.Second)
+	require.NoError(t, err, "Should receive email in Mailpit")
+
+	body := html.UnescapeString(msg.HTML)
+	extract := func(label string) *url.URL {
+		match := regexp.MustCompile(label + `\[([^\]]*)\]`).FindStringSubmatch(body)
+		require.Len(t, match, 2, "%s link should be rendered", label)
+		require.NotEmpty(t, match[1], "%s link should not be empty", label)
+		parsed, err := url.Parse(match[1])
+		require.NoError(t, err)
+		return parsed
+	}
+
+	unsubscribeURL := extract("UNSUB")
+	preferencesURL := extract("PREFS")
+
+	t.Run("unsubscribe_url is list-scoped and signed", func(t *testing.T) {
+		query := unsubscribeURL.Query()
+		assert.Equal(t, "/notification-center", unsubscribeURL.Path)
+		assert.Equal(t, "unsubscribe", query.Get("action"))
+		assert.Equal(t, list.ID, query.Get("lid"))
+		assert.Equal(t, workspace.ID, query.Get("wid"))
+		assert.Equal(t, contact.Email, query.Get("email"))
+		assert.NotEmpty(t, query.Get("email_hmac"))
+		assert.NotEmpty(t, query.Get("mid"))
+	})
+
+	t.Run("preferences_url is accepted by the preferences endpoint", func(t *testing.T) {
+		query := preferencesURL.Query()
+		prefsResp, err := client.Get("/preferences", map[string]string{
+			"workspace_id": query.Get("wid"),
+			"email":        query.Get("email"),
+			"email_hmac":   query.Get("email_hmac"),
+		})
+		require.NoError(t, err)
+		defer prefsResp.Body.Close()
+		assert.Equal(t, http.StatusOK, prefsResp.StatusCode)
+	})
+
+	t.Run("unsubscribe_url is accepted by the public unsubscribe endpoint", func(t *testing.T) {
+		// The notification center page posts the link parameters to the public endpoint
+		query := unsubscribeURL.Query()
+		unsubResp, err := client.Post("/unsubscribe-oneclick", map[string]interface{}{
+			"wid":        query.Get("wid"),
+			"email":      query.Get("email"),
+			"email_hmac": query.Get("email_hmac"),
+			"lids":       []string{query.Get("lid")},
+			"mid":        query.Get("mid"),
+		})
+		require.NoError(t, err)
+		defer unsubResp.Body.Close()
+		require.Equal(t, http.StatusOK, unsubResp.StatusCode)
+
+		listResp, err := client.GetContactListByIDs(workspace.ID, contact.Email, list.ID)
+		require.NoError(t, err)
+		defer listResp.Body.Close()
+		require.Equal(t, http.StatusOK, listResp.StatusCode)
+
+		var result map[string]interface{}
+		require.NoError(t, json.NewDecoder(listResp.Body).Decode(&result))
+		contactList, ok := result["contact_list"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, "unsubscribed", contactList["status"])
+	})
+
+	t.Run("tampered signature is rejected", func(t *testing.T) {
+		query := preferencesURL.Query()
+		prefsResp, err := client.Get("/preferences", map[string]string{
+			"workspace_id": query.Get("wid"),
+			"email":        query.Get("email"),
+			"email_hmac":   "invalid",
+		})
+		require.NoError(t, err)
+		defer prefsResp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, prefsResp.StatusCode)
+	})
+}
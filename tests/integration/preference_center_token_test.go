@@ -0,0 +1,135 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPreferenceCenterToken verifies that a signed preference center token can be used
+// to read a contact's subscriptions and toggle them, recording the list events
+func TestPreferenceCenterToken(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, appFactory)
+	defer func() { suite.Cleanup() }()
+
+	factory := suite.DataFactory
+	baseURL := suite.ServerManager.GetURL()
+	appInstance := suite.ServerManager.GetApp()
+
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+
+	newsletter, err := factory.CreateList(workspace.ID,
+		testutil.WithListName("Newsletter"),
+		testutil.WithListPublic(true),
+		testutil.WithListDoubleOptin(false))
+	require.NoError(t, err)
+	product, err := factory.CreateList(workspace.ID,
+		testutil.WithListName("Product updates"),
+		testutil.WithListPublic(true),
+		testutil.WithListDoubleOptin(false))
+	require.NoError(t, err)
+
+	email := fmt.Sprintf("pref-token-%d@example.com", time.Now().UnixNano())
+	_, err = factory.CreateContact(workspace.ID, testutil.WithContactEmail(email))
+	require.NoError(t, err)
+	_, err = factory.CreateContactList(workspace.ID,
+		testutil.WithContactListEmail(email),
+		testutil.WithContactListListID(newsletter.ID),
+		testutil.WithContactListStatus(domain.ContactListStatusActive))
+	require.NoError(t, err)
+
+	token := domain.GeneratePreferencesToken(workspace.ID, email, workspace.Settings.SecretKey)
+
+	getPreferences := func(t *testing.T, token string) (*http.Response, *domain.ContactPreferencesResponse) {
+		resp, err := http.Get(baseURL + "/preferences?token=" + token)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode != http.StatusOK {
+			return resp, nil
+		}
+		var result domain.ContactPreferencesResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return resp, &result
+	}
+
+	statusOf := func(t *testing.T, listID string) domain.ContactListStatus {
+		contactList, err := appInstance.GetContactListRepository().GetContactListByIDs(context.Background(), workspace.ID, email, listID)
+		require.NoError(t, err)
+		return contactList.Status
+	}
+
+	t.Run("fetches current subscriptions", func(t *testing.T) {
+		resp, prefs := getPreferences(t, token)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.Equal(t, email, prefs.Contact.Email)
+		assert.Len(t, prefs.PublicLists, 2)
+		require.Len(t, prefs.ContactLists, 1)
+		assert.Equal(t, newsletter.ID, prefs.ContactLists[0].ListID)
+		assert.Equal(t, domain.ContactListStatusActive, prefs.ContactLists[0].Status)
+	})
+
+	t.Run("toggles lists", func(t *testing.T) {
+		resp, err := postPreferences(baseURL, domain.UpdateContactPreferencesRequest{
+			Token: token,
+			Lists: []domain.ListPreference{
+				{ListID: newsletter.ID, Subscribed: false},
+				{ListID: product.ID, Subscribed: true},
+			},
+		})
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.Equal(t, domain.ContactListStatusUnsubscribed, statusOf(t, newsletter.ID))
+		assert.Equal(t, domain.ContactListStatusActive, statusOf(t, product.ID))
+
+		_, prefs := getPreferences(t, token)
+		require.NotNil(t, prefs)
+		statuses := map[string]domain.ContactListStatus{}
+		for _, contactList := range prefs.ContactLists {
+			statuses[contactList.ListID] = contactList.Status
+		}
+		assert.Equal(t, domain.ContactListStatusUnsubscribed, statuses[newsletter.ID])
+		assert.Equal(t, domain.ContactListStatusActive, statuses[product.ID])
+
+		unsubscribed, err := factory.GetContactTimelineEvents(workspace.ID, email, "list.unsubscribed")
+		require.NoError(t, err)
+		assert.NotEmpty(t, unsubscribed, "unsubscribe should be recorded on the timeline")
+
+		subscribed, err := factory.GetContactTimelineEvents(workspace.ID, email, "list.subscribed")
+		require.NoError(t, err)
+		assert.NotEmpty(t, subscribed, "subscribe should be recorded on the timeline")
+	})
+
+	t.Run("rejects a token signed with another key", func(t *testing.T) {
+		forged := domain.GeneratePreferencesToken(workspace.ID, email, "not-the-workspace-secret")
+
+		resp, _ := getPreferences(t, forged)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		postResp, err := postPreferences(baseURL, domain.UpdateContactPreferencesRequest{
+			Token: forged,
+			Lists: []domain.ListPreference{{ListID: product.ID, Subscribed: false}},
+		})
+		require.NoError(t, err)
+		defer func() { _ = postResp.Body.Close() }()
+		assert.Equal(t, http.StatusUnauthorized, postResp.StatusCode)
+
+		assert.Equal(t, domain.ContactListStatusActive, statusOf(t, product.ID))
+	})
+}
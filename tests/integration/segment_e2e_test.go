@@ -67,6 +67,11 @@ func TestSegmentE2E(t *testing.T) {
 		testSegmentPreview(t, client, factory, workspace.ID)
 	})
 
+	t.Run("Segment Preview Contacts", func(t *testing.T) {
+		t.Cleanup(func() { testutil.CleanupAllTasks(t, client, workspace.ID) })
+		testSegmentPreviewContacts(t, client, factory, workspace.ID)
+	})
+
 	t.Run("Complex Segment with AND/OR Logic", func(t *testing.T) {
 		t.Cleanup(func() { testutil.CleanupAllTasks(t, client, workspace.ID) })
 		testComplexSegmentTree(t, client, factory, workspace.ID)
@@ -279,13 +284,128 @@ func testSegmentPreview(t *testing.T, client *testutil.APIClient, factory *testu
 		emails := result["emails"].([]interface{})
 		totalCount := int(result["total_count"].(float64))
 
-		// Emails should not be returned for privacy/performance reasons
-		assert.Empty(t, emails, "Emails should not be returned in preview")
+		// A page of matching contacts is returned alongside the count
+		assert.Len(t, emails, min(totalCount, 10), "Preview should return a page of matching emails")
 		// Should find at least 5 premium contacts in the count
 		assert.True(t, totalCount >= 5, "Expected total count of at least 5")
 	})
 }
 
+// testSegmentPreviewContacts tests that the preview returns a page of matching contacts
+// for both an inline tree and an existing segment, without building the segment
+func testSegmentPreviewContacts(t *testing.T, client *testutil.APIClient, factory *testutil.TestDataFactory, workspaceID string) {
+	germanEmails := []string{}
+	for i := 0; i < 3; i++ {
+		email := fmt.Sprintf("preview-de-%d@example.com", i)
+		_, err := factory.CreateContact(workspaceID,
+			testutil.WithContactEmail(email),
+			testutil.WithContactCountry("DE"))
+		require.NoError(t, err)
+		germanEmails = append(germanEmails, email)
+	}
+	for i := 0; i < 4; i++ {
+		_, err := factory.CreateContact(workspaceID,
+			testutil.WithContactEmail(fmt.Sprintf("preview-it-%d@example.com", i)),
+			testutil.WithContactCountry("IT"))
+		require.NoError(t, err)
+	}
+
+	tree := map[string]interface{}{
+		"kind": "leaf",
+		"leaf": map[string]interface{}{
+			"source": "contacts",
+			"contact": map[string]interface{}{
+				"filters": []map[string]interface{}{
+					{
+						"field_name":    "country",
+						"field_type":    "string",
+						"operator":      "equals",
+						"string_values": []string{"DE"},
+					},
+				},
+			},
+		},
+	}
+
+	preview := func(t *testing.T, req map[string]interface{}) domain.PreviewSegmentResponse {
+		resp, err := client.Post("/api/segments.preview", req)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result domain.PreviewSegmentResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return result
+	}
+
+	contactEmails := func(contacts []*domain.Contact) []string {
+		emails := make([]string, 0, len(contacts))
+		for _, contact := range contacts {
+			emails = append(emails, contact.Email)
+			require.NotNil(t, contact.Country)
+			assert.Equal(t, "DE", contact.Country.String)
+		}
+		return emails
+	}
+
+	t.Run("should return matching contacts for a country tree", func(t *testing.T) {
+		result := preview(t, map[string]interface{}{
+			"workspace_id": workspaceID,
+			"tree":         tree,
+			"limit":        10,
+		})
+
+		assert.Equal(t, 3, result.TotalCount)
+		assert.ElementsMatch(t, germanEmails, contactEmails(result.Contacts))
+		assert.ElementsMatch(t, germanEmails, result.Emails)
+	})
+
+	t.Run("should paginate matching contacts", func(t *testing.T) {
+		firstPage := preview(t, map[string]interface{}{
+			"workspace_id": workspaceID,
+			"tree":         tree,
+			"limit":        2,
+		})
+		secondPage := preview(t, map[string]interface{}{
+			"workspace_id": workspaceID,
+			"tree":         tree,
+			"limit":        2,
+			"offset":       2,
+		})
+
+		assert.Equal(t, 3, firstPage.TotalCount)
+		assert.Equal(t, 3, secondPage.TotalCount)
+		assert.Len(t, firstPage.Contacts, 2)
+		assert.Len(t, secondPage.Contacts, 1)
+		assert.Equal(t, 2, secondPage.Offset)
+		assert.ElementsMatch(t, germanEmails, append(contactEmails(firstPage.Contacts), contactEmails(secondPage.Contacts)...))
+	})
+
+	t.Run("should preview an existing segment by id", func(t *testing.T) {
+		segmentID := fmt.Sprintf("decontacts%d", time.Now().Unix())
+		createResp, err := client.Post("/api/segments.create", map[string]interface{}{
+			"workspace_id": workspaceID,
+			"id":           segmentID,
+			"name":         "German Contacts",
+			"color":        "#1890FF",
+			"timezone":     "UTC",
+			"tree":         tree,
+		})
+		require.NoError(t, err)
+		_ = createResp.Body.Close()
+		require.Equal(t, http.StatusCreated, createResp.StatusCode)
+
+		result := preview(t, map[string]interface{}{
+			"workspace_id": workspaceID,
+			"segment_id":   segmentID,
+			"limit":        10,
+		})
+
+		assert.Equal(t, 3, result.TotalCount)
+		assert.ElementsMatch(t, germanEmails, contactEmails(result.Contacts))
+	})
+}
+
 // testComplexSegmentTree tests segments with complex AND/OR logic
 func testComplexSegmentTree(t *testing.T, client *testutil.APIClient, factory *testutil.TestDataFactory, workspaceID string) {
 	t.Run("should handle complex segment trees with AND/OR logic", func(t *testing.T) {
@@ -412,8 +532,8 @@ func testComplexSegmentTree(t *testing.T, client *testutil.APIClient, factory *t
 
 		// Should match 5 contacts (3 US VIP + 2 CA VIP)
 		assert.Equal(t, 5, totalCount, "Expected exactly 5 matching contacts")
-		// Emails should not be returned for privacy/performance reasons
-		assert.Empty(t, emails, "Emails should not be returned in preview")
+		// All matching contacts fit in the preview page
+		assert.Len(t, emails, totalCount, "Preview should return the matching emails")
 	})
 }
 
@@ -494,8 +614,8 @@ func testSegmentWithContactLists(t *testing.T, client *testutil.APIClient, facto
 
 		// Should find 5 newsletter subscribers
 		assert.Equal(t, 5, totalCount, "Expected 5 newsletter subscribers")
-		// Emails should not be returned for privacy/performance reasons
-		assert.Empty(t, emails, "Emails should not be returned in preview")
+		// All matching contacts fit in the preview page
+		assert.Len(t, emails, totalCount, "Preview should return the matching emails")
 	})
 }
 
@@ -575,8 +695,8 @@ func testSegmentWithContactTimeline(t *testing.T, client *testutil.APIClient, fa
 
 		// Should find 2 active users
 		assert.Equal(t, 2, totalCount, "Expected 2 active users")
-		// Emails should not be returned for privacy/performance reasons
-		assert.Empty(t, emails, "Emails should not be returned in preview")
+		// All matching contacts fit in the preview page
+		assert.Len(t, emails, totalCount, "Preview should return the matching emails")
 	})
 }
 
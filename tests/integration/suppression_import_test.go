@@ -0,0 +1,181 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSuppressionImport verifies that addresses imported through suppressions.import
+// are skipped by subsequent broadcasts even though they are active list subscribers
+func TestSuppressionImport(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer suite.Cleanup()
+
+	client := suite.APIClient
+	factory := suite.DataFactory
+
+	user, err := factory.CreateUser()
+	require.NoError(t, err)
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+	err = factory.AddUserToWorkspace(user.ID, workspace.ID, "owner")
+	require.NoError(t, err)
+
+	_, err = factory.SetupWorkspaceWithSMTPProvider(workspace.ID,
+		testutil.WithIntegrationEmailProvider(domain.EmailProvider{
+			Kind: domain.EmailProviderKindSMTP,
+			Senders: []domain.EmailSender{
+				domain.NewEmailSender("noreply@notifuse.test", "Notifuse Suppression Test"),
+			},
+			SMTP: &domain.SMTPSettings{
+				Host:   "localhost",
+				Port:   1025,
+				UseTLS: false,
+			},
+			RateLimitPerMinute: 2000,
+		}))
+	require.NoError(t, err)
+
+	err = client.Login(user.Email, "password")
+	require.NoError(t, err)
+	client.SetWorkspaceID(workspace.ID)
+
+	err = testutil.ClearMailpitMessages(t)
+	require.NoError(t, err)
+
+	list, err := factory.CreateList(workspace.ID, testutil.WithListName("Newsletter"))
+	require.NoError(t, err)
+
+	keptEmail := "suppression-kept@example.com"
+	bouncedEmail := "suppression-bounced@example.com"
+	complainedEmail := "suppression-complained@example.com"
+	for _, email := range []string{keptEmail, bouncedEmail, complainedEmail} {
+		_, err := factory.CreateContact(workspace.ID, testutil.WithContactEmail(email))
+		require.NoError(t, err)
+		_, err = factory.CreateContactList(workspace.ID,
+			testutil.WithContactListEmail(email),
+			testutil.WithContactListListID(list.ID),
+			testutil.WithContactListStatus(domain.ContactListStatusActive),
+		)
+		require.NoError(t, err)
+	}
+
+	t.Run("import reports imported and failed rows", func(t *testing.T) {
+		// Addresses are matched case-insensitively, and an address that is not a
+		// contact yet can still be suppressed
+		resp, err := client.ImportSuppressions(
+			"email,reason\n" +
+				"Suppression-Bounced@Example.com,bounce\n" +
+				complainedEmail + ",complaint\n" +
+				"not-a-contact@example.com,unsubscribe\n" +
+				"not-an-email,bounce\n")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result domain.ImportSuppressionsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.Equal(t, 3, result.Imported)
+		require.Len(t, result.Failed, 1)
+		assert.Equal(t, "not-an-email", result.Failed[0].Email)
+	})
+
+	t.Run("re-importing an address updates it", func(t *testing.T) {
+		resp, err := client.ImportSuppressions(bouncedEmail + ",bounce\n")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	uniqueSubject := fmt.Sprintf("Suppression Test %s", uuid.New().String()[:8])
+	template, err := factory.CreateTemplate(workspace.ID,
+		testutil.WithTemplateName("Suppression Template"),
+		testutil.WithTemplateSubject(uniqueSubject))
+	require.NoError(t, err)
+
+	broadcast, err := factory.CreateBroadcast(workspace.ID,
+		testutil.WithBroadcastName("Suppression Broadcast"),
+		testutil.WithBroadcastAudience(domain.AudienceSettings{
+			List:                list.ID,
+			ExcludeUnsubscribed: true,
+		}))
+	require.NoError(t, err)
+
+	broadcast.TestSettings.Variations[0].TemplateID = template.ID
+	updateResp, err := client.UpdateBroadcast(map[string]interface{}{
+		"workspace_id":  workspace.ID,
+		"id":            broadcast.ID,
+		"name":          broadcast.Name,
+		"audience":      broadcast.Audience,
+		"schedule":      broadcast.Schedule,
+		"test_settings": broadcast.TestSettings,
+	})
+	require.NoError(t, err)
+	defer updateResp.Body.Close()
+	require.Equal(t, http.StatusOK, updateResp.StatusCode, "Broadcast update should succeed")
+
+	err = suite.ServerManager.StartBackgroundWorkers(context.Background())
+	require.NoError(t, err)
+
+	scheduleResp, err := client.ScheduleBroadcast(map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"id":           broadcast.ID,
+		"send_now":     true,
+	})
+	require.NoError(t, err)
+	defer scheduleResp.Body.Close()
+	if scheduleResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(scheduleResp.Body)
+		t.Fatalf("Failed to schedule broadcast: %d - %s", scheduleResp.StatusCode, string(body))
+	}
+
+	_, err = testutil.WaitForBroadcastStatusWithExecution(t, client, broadcast.ID,
+		[]string{"processed", "completed"}, 2*time.Minute)
+	require.NoError(t, err, "Broadcast should complete successfully")
+
+	err = testutil.WaitForMailpitMessages(t, uniqueSubject, 1, time.Minute)
+	require.NoError(t, err)
+
+	t.Run("broadcast skips suppressed addresses", func(t *testing.T) {
+		messageHistoryRepo := suite.ServerManager.GetApp().GetMessageHistoryRepository()
+
+		var messages []*domain.MessageHistory
+		testutil.WaitForCondition(t, func() bool {
+			messages, _, err = messageHistoryRepo.ListMessages(context.Background(), workspace.ID,
+				workspace.Settings.SecretKey, domain.MessageListParams{
+					BroadcastID: broadcast.ID,
+					Limit:       100,
+				})
+			return err == nil && len(messages) >= 1
+		}, 30*time.Second, "waiting for broadcast message history")
+
+		sends := map[string]int{}
+		for _, msg := range messages {
+			sends[msg.ContactEmail]++
+		}
+		assert.Equal(t, map[string]int{keptEmail: 1}, sends)
+
+		count, err := testutil.GetMailpitMessageCount(t, uniqueSubject)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count, "Only the non-suppressed contact should receive the broadcast")
+	})
+}
@@ -0,0 +1,125 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTransactionalTemplateSend verifies that /api/transactional.sendTemplate renders a template,
+// delivers it through the email queue and records it as a transactional (non-broadcast) message.
+func TestTransactionalTemplateSend(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, appFactory)
+	defer suite.Cleanup()
+
+	client := suite.APIClient
+	factory := suite.DataFactory
+
+	user, err := factory.CreateUser()
+	require.NoError(t, err)
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+
+	err = factory.AddUserToWorkspace(user.ID, workspace.ID, "owner")
+	require.NoError(t, err)
+
+	// Set up SMTP email provider (Mailpit) as the transactional provider
+	_, err = factory.SetupWorkspaceWithSMTPProvider(workspace.ID)
+	require.NoError(t, err)
+
+	err = client.Login(user.Email, "password")
+	require.NoError(t, err)
+	client.SetWorkspaceID(workspace.ID)
+
+	app := suite.ServerManager.GetApp()
+
+	workerCtx, cancelWorker := context.WithCancel(context.Background())
+	defer cancelWorker()
+	err = suite.ServerManager.StartBackgroundWorkers(workerCtx)
+	require.NoError(t, err)
+
+	err = testutil.ClearMailpitMessages(t)
+	if err != nil {
+		t.Logf("Warning: Could not clear Mailpit messages: %v", err)
+	}
+
+	t.Run("should render and deliver transactional email", func(t *testing.T) {
+		template, err := factory.CreateTemplate(workspace.ID,
+			testutil.WithTemplateSubject("Reset your password, {{ first_name }}"),
+			testutil.WithTemplateEmailContent("Click {{ reset_url }} to reset your password"))
+		require.NoError(t, err)
+
+		recipient := "password-reset-" + time.Now().Format("150405.000") + "@example.com"
+
+		resp, err := client.SendTransactionalTemplate(map[string]interface{}{
+			"to":          recipient,
+			"template_id": template.ID,
+			"data": map[string]interface{}{
+				"first_name": "Ada",
+				"reset_url":  "https://example.com/reset/abc123",
+			},
+		})
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var result map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		messageID, ok := result["message_id"].(string)
+		require.True(t, ok, "response should include message_id")
+		require.NotEmpty(t, messageID)
+
+		// Rendering: subject and body are processed with the provided data
+		message, err := testutil.WaitForMailpitMessageByRecipient(t, recipient, 30*time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, "Reset your password, Ada", message.Subject)
+		assert.Contains(t, message.HTML, "https://example.com/reset/abc123")
+
+		// Classification: the message is not attributed to any broadcast, so it never
+		// contributes to broadcast statistics
+		ws, err := app.GetWorkspaceRepository().GetByID(context.Background(), workspace.ID)
+		require.NoError(t, err)
+
+		var history *domain.MessageHistory
+		require.Eventually(t, func() bool {
+			history, err = app.GetMessageHistoryRepository().Get(context.Background(), workspace.ID, ws.Settings.SecretKey, messageID)
+			return err == nil && history != nil
+		}, 10*time.Second, 200*time.Millisecond, "message history should be recorded")
+
+		assert.Nil(t, history.BroadcastID, "transactional email must not be linked to a broadcast")
+		assert.Nil(t, history.AutomationID, "transactional email must not be linked to an automation")
+		assert.Equal(t, recipient, history.ContactEmail)
+		assert.Equal(t, template.ID, history.TemplateID)
+		assert.Equal(t, "transactional", history.MessageData.Metadata["source"], "message history should record the transactional source")
+	})
+
+	t.Run("should reject unknown template", func(t *testing.T) {
+		resp, err := client.SendTransactionalTemplate(map[string]interface{}{
+			"to":          "unknown-template@example.com",
+			"template_id": "does-not-exist",
+		})
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should validate required fields", func(t *testing.T) {
+		resp, err := client.SendTransactionalTemplate(map[string]interface{}{
+			"template_id": "any",
+		})
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
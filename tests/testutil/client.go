@@ -372,6 +372,11 @@ func (c *APIClient) CancelBroadcast(request map[string]interface{}) (*http.Respo
 	return c.Post("/api/broadcasts.cancel", request)
 }
 
+// CancelBroadcastSchedule reverts a scheduled broadcast to draft
+func (c *APIClient) CancelBroadcastSchedule(request map[string]interface{}) (*http.Response, error) {
+	return c.Post("/api/broadcasts.cancelSchedule", request)
+}
+
 // DeleteBroadcast deletes a broadcast
 func (c *APIClient) DeleteBroadcast(request map[string]interface{}) (*http.Response, error) {
 	return c.Post("/api/broadcasts.delete", request)
@@ -401,6 +406,11 @@ func (c *APIClient) RefreshGlobalFeed(request map[string]interface{}) (*http.Res
 	return c.Post("/api/broadcasts.refreshGlobalFeed", request)
 }
 
+// PreviewRenderedBroadcast renders a broadcast for a few recipients of its audience
+func (c *APIClient) PreviewRenderedBroadcast(request map[string]interface{}) (*http.Response, error) {
+	return c.Post("/api/broadcasts.previewRendered", request)
+}
+
 // TestRecipientFeed tests the recipient feed with a specific contact
 func (c *APIClient) TestRecipientFeed(request map[string]interface{}) (*http.Response, error) {
 	return c.Post("/api/broadcasts.testRecipientFeed", request)
@@ -433,6 +443,14 @@ func (c *APIClient) ListContacts(params map[string]string) (*http.Response, erro
 	return c.Get("/api/contacts.list", params)
 }
 
+// ImportSuppressions upserts addresses into the workspace suppression list from CSV content
+func (c *APIClient) ImportSuppressions(csv string) (*http.Response, error) {
+	return c.Post("/api/suppressions.import", map[string]interface{}{
+		"workspace_id": c.workspaceID,
+		"csv":          csv,
+	})
+}
+
 // Template API helpers
 func (c *APIClient) CreateTemplate(template map[string]interface{}) (*http.Response, error) {
 	return c.Post("/api/templates.create", template)
@@ -676,6 +694,15 @@ func (c *APIClient) SendTransactionalNotification(notification map[string]interf
 	})
 }
 
+// SendTransactionalTemplate sends a transactional email directly from a template
+func (c *APIClient) SendTransactionalTemplate(request map[string]interface{}) (*http.Response, error) {
+	// Add workspace_id if not already present
+	if request["workspace_id"] == nil {
+		request["workspace_id"] = c.workspaceID
+	}
+	return c.Post("/api/transactional.sendTemplate", request)
+}
+
 // TestTransactionalTemplate tests a transactional template
 func (c *APIClient) TestTransactionalTemplate(request map[string]interface{}) (*http.Response, error) {
 	// Add workspace_id if not already present
@@ -811,11 +838,31 @@ func (c *APIClient) DeleteAutomation(request map[string]interface{}) (*http.Resp
 	return c.Post("/api/automations.delete", request)
 }
 
+// ListAutomationTags lists the distinct tags used by the workspace's automations
+func (c *APIClient) ListAutomationTags() (*http.Response, error) {
+	return c.Get("/api/automations.tags")
+}
+
+// SetAutomationTags replaces the tags of an automation
+func (c *APIClient) SetAutomationTags(request map[string]interface{}) (*http.Response, error) {
+	return c.Post("/api/automations.setTags", request)
+}
+
 // ActivateAutomation activates an automation (creates DB trigger)
 func (c *APIClient) ActivateAutomation(request map[string]interface{}) (*http.Response, error) {
 	return c.Post("/api/automations.activate", request)
 }
 
+// EnrollContacts enrolls contacts in a live automation
+func (c *APIClient) EnrollContacts(request map[string]interface{}) (*http.Response, error) {
+	return c.Post("/api/automations.enroll", request)
+}
+
+// ReenrollContacts enrolls emails or segment members again at the root node of a live automation
+func (c *APIClient) ReenrollContacts(request map[string]interface{}) (*http.Response, error) {
+	return c.Post("/api/automations.reenroll", request)
+}
+
 // PauseAutomation pauses an automation
 func (c *APIClient) PauseAutomation(request map[string]interface{}) (*http.Response, error) {
 	return c.Post("/api/automations.pause", request)
@@ -829,3 +876,20 @@ func (c *APIClient) GetContactNodeExecutions(automationID, email string) (*http.
 	}
 	return c.Get("/api/automations.nodeExecutions", params)
 }
+
+// GetAutomationNodeStats gets the per-node execution stats of an automation
+func (c *APIClient) GetAutomationNodeStats(automationID string) (*http.Response, error) {
+	params := map[string]string{
+		"automation_id": automationID,
+	}
+	return c.Get("/api/automations.nodeStats", params)
+}
+
+// GetContactNextTick previews the scheduler's next tick for a contact in an automation
+func (c *APIClient) GetContactNextTick(automationID, email string) (*http.Response, error) {
+	params := map[string]string{
+		"automation_id": automationID,
+		"email":         email,
+	}
+	return c.Get("/api/automations.contacts.next", params)
+}
@@ -354,6 +354,23 @@ func (tdf *TestDataFactory) UpdateContactListStatus(workspaceID, email, listID s
 	return tdf.contactListRepo.UpdateContactListStatus(context.Background(), workspaceID, email, listID, status)
 }
 
+// SetContactListJoinedAt backdates when a contact joined a list
+func (tdf *TestDataFactory) SetContactListJoinedAt(workspaceID, email, listID string, joinedAt time.Time) error {
+	workspaceDB, err := tdf.workspaceRepo.GetConnection(context.Background(), workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace database: %w", err)
+	}
+
+	_, err = workspaceDB.ExecContext(context.Background(),
+		`UPDATE contact_lists SET created_at = $1 WHERE email = $2 AND list_id = $3`,
+		joinedAt, email, listID)
+	if err != nil {
+		return fmt.Errorf("failed to update contact list join time: %w", err)
+	}
+
+	return nil
+}
+
 // CreateContactTimelineEvent creates a timeline event for a contact
 func (tdf *TestDataFactory) CreateContactTimelineEvent(workspaceID, email, kind string, metadata map[string]interface{}) error {
 	// Get workspace database connection
@@ -771,6 +788,16 @@ func (tdf *TestDataFactory) SetWorkspaceCustomEndpointURL(workspaceID, endpointU
 	return tdf.workspaceRepo.Update(context.Background(), workspace)
 }
 
+// SetWorkspaceBroadcastSeedList sets the seed inboxes that receive a copy of every broadcast
+func (tdf *TestDataFactory) SetWorkspaceBroadcastSeedList(workspaceID string, seeds []string) error {
+	workspace, err := tdf.workspaceRepo.GetByID(context.Background(), workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+	workspace.Settings.BroadcastSeedList = seeds
+	return tdf.workspaceRepo.Update(context.Background(), workspace)
+}
+
 // Option types for customizing test data
 type UserOption func(*domain.User)
 type WorkspaceOption func(*domain.Workspace)
@@ -866,6 +893,12 @@ func WithContactCustomNumber1(value float64) ContactOption {
 	}
 }
 
+func WithContactCreatedAt(createdAt time.Time) ContactOption {
+	return func(c *domain.Contact) {
+		c.CreatedAt = createdAt
+	}
+}
+
 func WithContactPhone(phone string) ContactOption {
 	return func(c *domain.Contact) {
 		c.Phone = &domain.NullableString{String: phone, IsNull: false}
@@ -2438,7 +2471,8 @@ func (tdf *TestDataFactory) GetContactAutomation(workspaceID, automationID, emai
 
 	err = workspaceDB.QueryRowContext(context.Background(), `
 		SELECT id, automation_id, contact_email, current_node_id, status,
-		       entered_at, scheduled_at, context, retry_count, last_error, last_retry_at, max_retries, exit_reason
+		       entered_at, scheduled_at, context, retry_count, last_error, last_retry_at, max_retries, exit_reason,
+		       automation_version
 		FROM contact_automations
 		WHERE automation_id = $1 AND contact_email = $2
 		ORDER BY entered_at DESC
@@ -2446,6 +2480,7 @@ func (tdf *TestDataFactory) GetContactAutomation(workspaceID, automationID, emai
 	`, automationID, email).Scan(
 		&ca.ID, &ca.AutomationID, &ca.ContactEmail, &ca.CurrentNodeID, &ca.Status,
 		&ca.EnteredAt, &scheduledAt, &contextJSON, &ca.RetryCount, &lastError, &lastRetryAt, &ca.MaxRetries, &exitReason,
+		&ca.AutomationVersion,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get contact automation: %w", err)
@@ -2618,6 +2653,25 @@ func (tdf *TestDataFactory) UpdateContactAutomationScheduledAt(workspaceID, cont
 	return nil
 }
 
+// ExpireNodeWaitDeadline moves the wait_until recorded by a waiting node into the past (for testing wait timeouts)
+func (tdf *TestDataFactory) ExpireNodeWaitDeadline(workspaceID, contactAutomationID, nodeID string) error {
+	workspaceDB, err := tdf.workspaceRepo.GetConnection(context.Background(), workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace DB: %w", err)
+	}
+
+	_, err = workspaceDB.ExecContext(context.Background(), `
+		UPDATE automation_node_executions
+		SET output = jsonb_set(output, '{wait_until}', to_jsonb($1::text))
+		WHERE contact_automation_id = $2 AND node_id = $3 AND output->>'wait_until' IS NOT NULL
+	`, time.Now().UTC().Add(-time.Minute).Format(time.RFC3339Nano), contactAutomationID, nodeID)
+	if err != nil {
+		return fmt.Errorf("failed to expire wait deadline: %w", err)
+	}
+
+	return nil
+}
+
 // GetTriggerLogEntry checks if a trigger log entry exists for deduplication
 func (tdf *TestDataFactory) GetTriggerLogEntry(workspaceID, automationID, email string) (bool, error) {
 	workspaceDB, err := tdf.workspaceRepo.GetConnection(context.Background(), workspaceID)